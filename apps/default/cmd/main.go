@@ -4,32 +4,46 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"time"
 
 	_ "embed"
 
-	"buf.build/gen/go/antinvestor/files/connectrpc/go/files/v1/filesv1connect"
-	filespb "buf.build/gen/go/antinvestor/files/protocolbuffers/go/files/v1"
 	"connectrpc.com/connect"
 	"github.com/antinvestor/common/v2"
 	"github.com/antinvestor/common/v2/permissions"
 	aconfig "github.com/antinvestor/service-files/apps/default/config"
+	filespb "github.com/antinvestor/service-files/apps/default/gen/files/v1"
+	"github.com/antinvestor/service-files/apps/default/gen/files/v1/filesv1connect"
 	"github.com/antinvestor/service-files/apps/default/service/authz"
 	"github.com/antinvestor/service-files/apps/default/service/business"
+	"github.com/antinvestor/service-files/apps/default/service/cache"
 	"github.com/antinvestor/service-files/apps/default/service/events"
 	"github.com/antinvestor/service-files/apps/default/service/handler"
 	"github.com/antinvestor/service-files/apps/default/service/handler/routing"
+	"github.com/antinvestor/service-files/apps/default/service/metrics"
+	"github.com/antinvestor/service-files/apps/default/service/middleware"
 	"github.com/antinvestor/service-files/apps/default/service/queue"
+	"github.com/antinvestor/service-files/apps/default/service/scanner"
+	"github.com/antinvestor/service-files/apps/default/service/storage"
 	"github.com/antinvestor/service-files/apps/default/service/storage/connection"
 	"github.com/antinvestor/service-files/apps/default/service/storage/provider"
 	"github.com/antinvestor/service-files/apps/default/service/storage/repository"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/antinvestor/service-files/apps/default/service/webhooks"
 	"github.com/pitabwire/frame/v2"
+	framecache "github.com/pitabwire/frame/v2/cache"
+	"github.com/pitabwire/frame/v2/cache/redis"
 	"github.com/pitabwire/frame/v2/config"
+	"github.com/pitabwire/frame/v2/data"
 	"github.com/pitabwire/frame/v2/datastore"
 	"github.com/pitabwire/frame/v2/security/authorizer"
 	connectInterceptors "github.com/pitabwire/frame/v2/security/interceptors/connect"
 	framehttp "github.com/pitabwire/frame/v2/security/interceptors/httptor"
 	"github.com/pitabwire/frame/v2/setup"
+	"github.com/pitabwire/frame/v2/telemetry"
 	"github.com/pitabwire/util"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 )
 
 //go:embed files.openapi.yaml
@@ -53,7 +67,22 @@ func main() {
 		util.Log(ctx).WithError(err).Fatal("invalid files configuration")
 	}
 
-	ctx, svc := frame.NewServiceWithContext(ctx, frame.WithConfig(&cfg), frame.WithDatastore())
+	serviceOptions := []frame.Option{frame.WithConfig(&cfg), frame.WithDatastore()}
+
+	// Service metrics are scraped from /metrics on the internal metrics
+	// address unless disabled, in which case OTEL_METRICS_EXPORTER selects how
+	// they are exported.
+	var prometheusHandler http.Handler
+	if cfg.MetricsPrometheusEnabled {
+		var metricsReader sdkmetric.Reader
+		metricsReader, prometheusHandler, err = metrics.NewPrometheusExporter()
+		if err != nil {
+			util.Log(ctx).WithError(err).Fatal("could not setup prometheus exporter")
+		}
+		serviceOptions = append(serviceOptions, frame.WithTelemetry(telemetry.WithMetricsReader(metricsReader)))
+	}
+
+	ctx, svc := frame.NewServiceWithContext(ctx, serviceOptions...)
 
 	svc.Setup().RegisterFunc(setup.NameMigrate, func(ctx context.Context) error {
 		return repository.Migrate(ctx, svc.DatastoreManager(), cfg.GetDatabaseMigrationPath())
@@ -81,10 +110,24 @@ func main() {
 	dbPool := dbManager.GetPool(ctx, datastore.DefaultPoolName)
 	workManager := svc.WorkManager()
 
+	serviceMetrics := metrics.NewMetrics()
+
 	storageProvider, err := provider.GetStorageProvider(ctx, &cfg)
 	if err != nil {
 		log.WithError(err).Fatal("could not setup storage provider")
 	}
+	storageProvider = storage.NewInstrumentedProvider(storageProvider, serviceMetrics)
+
+	svc.AddHealthCheck(metrics.NewDependencyCheck("database", 0, func(ctx context.Context) error {
+		sqlDB, dbErr := dbPool.DB(ctx, true).DB()
+		if dbErr != nil {
+			return dbErr
+		}
+		return sqlDB.PingContext(ctx)
+	}))
+	svc.AddHealthCheck(metrics.NewDependencyCheck("storage_provider", 0, func(ctx context.Context) error {
+		return storage.CheckReachable(ctx, storageProvider)
+	}))
 
 	mediaRepo := repository.NewMediaRepository(ctx, dbPool, workManager)
 	auditRepo := repository.NewMediaAuditRepository(ctx, dbPool, workManager)
@@ -98,6 +141,15 @@ func main() {
 		repository.NewRetentionPolicyRepository(ctx, dbPool, workManager),
 		repository.NewFileRetentionRepository(ctx, dbPool, workManager),
 		repository.NewStorageStatsRepository(ctx, dbPool, workManager),
+		repository.NewWebhookSubscriptionRepository(ctx, dbPool, workManager),
+		repository.NewWebhookDeliveryRepository(ctx, dbPool, workManager),
+		repository.NewThumbnailJobRepository(ctx, dbPool, workManager),
+		repository.NewThumbnailRegenerationRepository(ctx, dbPool, workManager),
+		repository.NewArchiveExtractionRepository(ctx, dbPool, workManager),
+		repository.NewShareLinkRepository(ctx, dbPool, workManager),
+		repository.NewAccessGrantRepository(ctx, dbPool, workManager),
+		repository.NewUserDataExportRepository(ctx, dbPool, workManager),
+		repository.NewUserDataErasureRepository(ctx, dbPool, workManager),
 	)
 	if err != nil {
 		log.WithError(err).Fatal("failed to setup media database")
@@ -108,7 +160,12 @@ func main() {
 	sm := svc.SecurityManager()
 	authzMiddleware := authz.NewMiddleware(sm.GetAuthorizer(ctx), metadataStore)
 
-	fileServer := handler.NewFileServer(svc, mediaService, authzMiddleware, metadataStore, storageProvider)
+	readCache, cacheOptions, err := setupReadCache(&cfg, serviceMetrics)
+	if err != nil {
+		log.WithError(err).Fatal("could not setup read cache")
+	}
+
+	fileServer := handler.NewFileServer(svc, mediaService, authzMiddleware, metadataStore, storageProvider, readCache)
 
 	auth := sm.GetAuthorizer(ctx)
 
@@ -131,33 +188,157 @@ func main() {
 		log.WithError(err).Fatal("could not create default interceptors")
 	}
 
+	rateLimiter, rateLimitOptions, err := setupRateLimiter(&cfg)
+	if err != nil {
+		log.WithError(err).Fatal("could not setup rate limiter")
+	}
+
+	// Metrics run outermost so RPCs rejected by authentication are counted too.
+	interceptorList := append([]connect.Interceptor{serviceMetrics.Interceptor()}, defaultInterceptorList...)
+	if rateLimiter != nil {
+		// Limits are keyed by the caller, so they apply after authentication.
+		interceptorList = append(interceptorList, rateLimiter.Interceptor())
+	}
+
 	connectPath, connectHandler := filesv1connect.NewFilesServiceHandler(
-		fileServer, connect.WithInterceptors(defaultInterceptorList...))
+		fileServer, connect.WithInterceptors(interceptorList...))
+
+	mediaRouter := routing.SetupMediaRoutes(svc, metadataStore, storageProvider, mediaService, authzMiddleware,
+		middleware.DefaultSecurityHeadersConfig())
 
-	mediaRouter := routing.SetupMediaRoutes(svc, metadataStore, storageProvider, mediaService, authzMiddleware)
+	var mediaHandler http.Handler = mediaRouter
+	if rateLimiter != nil {
+		mediaHandler = rateLimiter.Middleware()(mediaRouter)
+	}
 
 	mux := http.NewServeMux()
 	mux.Handle(connectPath, connectHandler)
 	mux.Handle("/openapi.yaml", common.NewOpenAPIHandler(apiSpecFile, nil))
 	mux.Handle("/v1/media/", framehttp.AuthenticationMiddleware(
-		framehttp.TenancyAccessMiddleware(mediaRouter, tenancyAccessChecker),
+		framehttp.TenancyAccessMiddleware(mediaHandler, tenancyAccessChecker),
 		sm.GetAuthenticator(ctx)))
+	// Share links carry their own credential, the token, so they skip
+	// authentication; clients are limited by address instead.
+	shareLinkHandler := routing.NewShareLinkHandler(svc, metadataStore, mediaService,
+		middleware.DefaultSecurityHeadersConfig())
+	mux.Handle(routing.ShareLinkPathPrefix, middleware.RateLimitMiddleware(
+		middleware.NewIPRateLimiter(middleware.DefaultRateLimiterConfig()))(shareLinkHandler))
+	// Unrouted requests get a pattern too, so request metrics never label
+	// them by their raw path.
+	mux.Handle("/", http.NotFoundHandler())
 
 	defaultServer := frame.WithHTTPHandler(mux)
 	// Permission registration stays setup-only; runtime does not re-POST manifests.
-	serviceOptions := []frame.Option{defaultServer, frame.WithRegisterEvents(
+	serviceOptions = []frame.Option{defaultServer, frame.WithHTTPMiddleware(serviceMetrics.Middleware()), frame.WithRegisterEvents(
 		events.NewAuditSaveHandler(auditRepo),
 		events.NewMetadataSaveHandler(mediaRepo),
 	)}
+	serviceOptions = append(serviceOptions, cacheOptions...)
+	serviceOptions = append(serviceOptions, rateLimitOptions...)
 
 	thumbnailQueueHandler := queue.NewThumbnailQueueHandler(svc, metadataStore, storageProvider)
 	thumbnailGenerateQueue := frame.WithRegisterSubscriber(cfg.QueueThumbnailsGenerateName, cfg.QueueThumbnailsGenerateURL, &thumbnailQueueHandler)
 	thumbnailGeneratePublish := frame.WithRegisterPublisher(cfg.QueueThumbnailsGenerateName, cfg.QueueThumbnailsGenerateURL)
 	serviceOptions = append(serviceOptions, thumbnailGenerateQueue, thumbnailGeneratePublish)
+	thumbnailRetrier := queue.NewThumbnailRetrier(&thumbnailQueueHandler)
+
+	if len(os.Args) > 1 && os.Args[1] == regenerateThumbnailsCommand {
+		if err = runRegenerateThumbnails(ctx, svc, metadataStore, &thumbnailQueueHandler, os.Args[2:]); err != nil {
+			log.WithError(err).Fatal("thumbnail regeneration failed")
+		}
+		return
+	}
+
+	thumbnailRegenerator := queue.NewThumbnailRegenerator(svc, metadataStore, queue.PublishThumbnailGeneration(svc))
+	thumbnailRegenerateQueue := frame.WithRegisterSubscriber(cfg.QueueThumbnailsRegenerateName, cfg.QueueThumbnailsRegenerateURL, thumbnailRegenerator)
+	thumbnailRegeneratePublish := frame.WithRegisterPublisher(cfg.QueueThumbnailsRegenerateName, cfg.QueueThumbnailsRegenerateURL)
+	serviceOptions = append(serviceOptions, thumbnailRegenerateQueue, thumbnailRegeneratePublish)
+
+	archiveExtractor := queue.NewArchiveExtractor(svc, metadataStore, mediaService, queue.PublishThumbnailGeneration(svc), authzMiddleware)
+	archiveExtractQueue := frame.WithRegisterSubscriber(cfg.QueueArchiveExtractName, cfg.QueueArchiveExtractURL, archiveExtractor)
+	archiveExtractPublish := frame.WithRegisterPublisher(cfg.QueueArchiveExtractName, cfg.QueueArchiveExtractURL)
+	serviceOptions = append(serviceOptions, archiveExtractQueue, archiveExtractPublish)
+
+	userDataExporter := queue.NewUserDataExporter(svc, metadataStore, mediaService, auditRepo, authzMiddleware)
+	userExportQueue := frame.WithRegisterSubscriber(cfg.QueueUserExportName, cfg.QueueUserExportURL, userDataExporter)
+	userExportPublish := frame.WithRegisterPublisher(cfg.QueueUserExportName, cfg.QueueUserExportURL)
+	serviceOptions = append(serviceOptions, userExportQueue, userExportPublish)
+
+	profileEraser, ok := authzMiddleware.(authz.ProfileEraser)
+	if !ok {
+		log.Fatal("authorization middleware cannot erase profiles")
+	}
+	userDataEraser := queue.NewUserDataEraser(svc, metadataStore, storageProvider, profileEraser, auditRepo, readCache)
+	userEraseQueue := frame.WithRegisterSubscriber(cfg.QueueUserEraseName, cfg.QueueUserEraseURL, userDataEraser)
+	userErasePublish := frame.WithRegisterPublisher(cfg.QueueUserEraseName, cfg.QueueUserEraseURL)
+	serviceOptions = append(serviceOptions, userEraseQueue, userErasePublish)
+
+	if cfg.ScanEnabled() {
+		mediaScanner, scannerErr := scanner.New(&cfg)
+		if scannerErr != nil {
+			log.WithError(scannerErr).Fatal("could not setup malware scanner")
+		}
+		scanQueueHandler := queue.NewScanQueueHandler(svc, metadataStore, storageProvider, mediaScanner)
+		mediaScanQueue := frame.WithRegisterSubscriber(cfg.QueueMediaScanName, cfg.QueueMediaScanURL, &scanQueueHandler)
+		mediaScanPublish := frame.WithRegisterPublisher(cfg.QueueMediaScanName, cfg.QueueMediaScanURL)
+		serviceOptions = append(serviceOptions, mediaScanQueue, mediaScanPublish)
+	}
+
+	// File lifecycle events: published for other services and fanned out to
+	// tenant webhook subscriptions. Retries run outside of tenant claims, so
+	// the service may elevate to a global system principal.
+	webhookDispatcher := webhooks.NewDispatcher(svc, metadataStore)
+	fileEventsQueue := frame.WithRegisterSubscriber(cfg.QueueFileEventsName, cfg.QueueFileEventsURL, webhookDispatcher)
+	fileEventsPublish := frame.WithRegisterPublisher(cfg.QueueFileEventsName, cfg.QueueFileEventsURL)
+	serviceOptions = append(serviceOptions, fileEventsQueue, fileEventsPublish,
+		frame.WithSystemPrincipalAllowGlobal(utils.SystemServiceName))
 
 	svc.Init(ctx, serviceOptions...)
 
+	queueDepthSampler := queue.NewQueueDepthSampler(metadataStore, serviceMetrics,
+		time.Duration(cfg.QueueDepthPollSeconds)*time.Second)
+	accessGrantSweeper := queue.NewAccessGrantSweeper(authzMiddleware,
+		time.Duration(cfg.AccessGrantSweepSeconds)*time.Second)
+	var namespaceLister authz.NamespaceLister
+	if cfg.AuthorizationServiceCanRead() {
+		namespaceLister, err = authz.NewKetoNamespaceLister(cfg.GetAuthorizationServiceReadURI())
+		if err != nil {
+			log.WithError(err).Fatal("could not connect to the keto read api")
+		}
+	}
+	if profileReconciler, ok := authzMiddleware.(authz.ProfileReconciler); ok && cfg.ProfileServiceURI != "" {
+		profileReconciler.SetProfileDirectory(authz.NewProfileServiceDirectory(cfg.ProfileServiceURI, svc.HTTPClientManager().Client(ctx)))
+	}
+	ownershipReconciler := queue.NewOwnershipReconciler(authzMiddleware, namespaceLister, metadataStore,
+		time.Duration(cfg.OwnershipReconcileSeconds)*time.Second, cfg.OwnershipReconcileDryRun)
+
+	var prometheusServer *metrics.PrometheusServer
+	if prometheusHandler != nil {
+		prometheusServer = metrics.NewPrometheusServer(cfg.MetricsPrometheusAddress, prometheusHandler)
+		prometheusServer.Start(ctx)
+	}
+	webhookDispatcher.Start(ctx)
+	thumbnailRetrier.Start(ctx)
+	thumbnailRegenerator.Start(ctx)
+	queueDepthSampler.Start(ctx)
+	accessGrantSweeper.Start(ctx)
+	ownershipReconciler.Start(ctx)
+
 	err = svc.Run(ctx, "")
+
+	webhookDispatcher.Stop()
+	thumbnailRetrier.Stop()
+	thumbnailRegenerator.Stop()
+	queueDepthSampler.Stop()
+	accessGrantSweeper.Stop()
+	ownershipReconciler.Stop()
+	if namespaceLister != nil {
+		_ = namespaceLister.Close()
+	}
+	if prometheusServer != nil {
+		prometheusServer.Stop()
+	}
+
 	if err != nil {
 		log.WithError(err).Fatal("could not run server")
 	}
@@ -190,3 +371,58 @@ func validateEncryptionConfig(cfg *aconfig.FilesConfig) error {
 	}
 	return nil
 }
+
+// readCacheName registers the shared read cache with the service so it is
+// closed on shutdown.
+const readCacheName = "files_read_cache"
+
+// setupReadCache creates the cache of metadata, thumbnail and URL preview
+// reads, shared through Redis when a cache URI is configured.
+func setupReadCache(cfg *aconfig.FilesConfig, recorder cache.Recorder) (*cache.Manager, []frame.Option, error) {
+	if !cfg.CacheEnabled {
+		return nil, nil, nil
+	}
+	if cfg.CacheURI == "" {
+		return cache.NewManager(nil, recorder), nil, nil
+	}
+
+	rawCache, err := redis.New(framecache.WithDSN(data.DSN(cfg.CacheURI)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("connect read cache: %w", err)
+	}
+	return cache.NewSharedManager(rawCache, nil, recorder), []frame.Option{frame.WithCache(readCacheName, rawCache)}, nil
+}
+
+// rateLimitCacheName registers the rate limit counters with the service so
+// they are closed on shutdown.
+const rateLimitCacheName = "files_rate_limits"
+
+// rateLimitedUploads are the RPCs and media routes holding an upload slot
+// while they run.
+var rateLimitedUploads = []string{"UploadContent", "UploadMultipartPart", "PatchContent", "http:upload"}
+
+// setupRateLimiter creates the per-tenant rate limiter. Counters are shared
+// between replicas through Redis when a rate limit or cache URI is
+// configured, and kept per replica otherwise.
+func setupRateLimiter(cfg *aconfig.FilesConfig) (*middleware.TenantRateLimiter, []frame.Option, error) {
+	if !cfg.RateLimitEnabled {
+		return nil, nil, nil
+	}
+
+	storeURI := cfg.RateLimitStoreURI
+	if storeURI == "" {
+		storeURI = cfg.CacheURI
+	}
+	if storeURI == "" {
+		rawCache := framecache.NewInMemoryCache()
+		return middleware.NewTenantRateLimiter(cfg, rawCache, rateLimitedUploads...),
+			[]frame.Option{frame.WithCache(rateLimitCacheName, rawCache)}, nil
+	}
+
+	rawCache, err := redis.New(framecache.WithDSN(data.DSN(storeURI)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("connect rate limit store: %w", err)
+	}
+	return middleware.NewTenantRateLimiter(cfg, rawCache, rateLimitedUploads...),
+		[]frame.Option{frame.WithCache(rateLimitCacheName, rawCache)}, nil
+}
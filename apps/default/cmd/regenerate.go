@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	aconfig "github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/queue"
+	"github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/pitabwire/frame/v2"
+	"github.com/pitabwire/util"
+)
+
+// regenerateThumbnailsCommand runs a bulk thumbnail regeneration in the
+// foreground instead of serving, generating thumbnails inline rather than
+// through the queue.
+const regenerateThumbnailsCommand = "regenerate-thumbnails"
+
+type regenerateOptions struct {
+	tenantID    string
+	partitionID string
+	resumeRunID string
+	run         types.ThumbnailRegeneration
+}
+
+func parseRegenerateFlags(args []string, output io.Writer) (*regenerateOptions, error) {
+	opts := &regenerateOptions{}
+	var ownerID, createdAfter, createdBefore string
+
+	fs := flag.NewFlagSet(regenerateThumbnailsCommand, flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&opts.tenantID, "tenant", "", "tenant whose images are regenerated (required)")
+	fs.StringVar(&opts.partitionID, "partition", "", "partition whose images are regenerated (required)")
+	fs.StringVar(&ownerID, "owner", "", "only images of this owner")
+	fs.StringVar(&opts.run.Filter.ContentType, "content-type", "", "only images of this type; a value ending in / matches as a prefix")
+	fs.StringVar(&createdAfter, "created-after", "", "only images created at or after this RFC3339 time")
+	fs.StringVar(&createdBefore, "created-before", "", "only images created before this RFC3339 time")
+	fs.BoolVar(&opts.run.RemoveStale, "remove-stale", false, "remove thumbnails of sizes no longer configured")
+	fs.IntVar(&opts.run.RatePerSecond, "rate", 0, "images regenerated per second; 0 uses the configured default")
+	fs.StringVar(&opts.resumeRunID, "resume", "", "resume this run from its checkpoint")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if opts.tenantID == "" || opts.partitionID == "" {
+		return nil, errors.New("-tenant and -partition are required")
+	}
+	if opts.run.Filter.ContentType != "" && !strings.HasPrefix(opts.run.Filter.ContentType, "image/") {
+		return nil, errors.New("-content-type must be an image type")
+	}
+	if opts.run.RatePerSecond < 0 {
+		return nil, errors.New("-rate must not be negative")
+	}
+	opts.run.Filter.OwnerID = types.OwnerID(ownerID)
+
+	for _, bound := range []struct {
+		name  string
+		value string
+		dest  **time.Time
+	}{
+		{"-created-after", createdAfter, &opts.run.Filter.CreatedAfter},
+		{"-created-before", createdBefore, &opts.run.Filter.CreatedBefore},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", bound.name, err)
+		}
+		*bound.dest = &t
+	}
+	return opts, nil
+}
+
+// runRegenerateThumbnails starts or resumes a regeneration run for one
+// tenant and blocks until it finishes. An interrupt stops the run with its
+// checkpoint saved, ready for -resume.
+func runRegenerateThumbnails(
+	ctx context.Context,
+	svc *frame.Service,
+	db storage.Database,
+	thumbnailHandler *queue.ThumbnailQueueHandler,
+	args []string,
+) error {
+	opts, err := parseRegenerateFlags(args, os.Stderr)
+	if err != nil {
+		return err
+	}
+	cfg := svc.Config().(*aconfig.FilesConfig)
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx = utils.TenantContext(ctx, opts.tenantID, opts.partitionID)
+
+	run := &opts.run
+	if opts.resumeRunID != "" {
+		run, err = db.GetThumbnailRegeneration(ctx, opts.resumeRunID)
+		if err != nil {
+			return err
+		}
+		if run == nil {
+			return fmt.Errorf("regeneration run %s not found", opts.resumeRunID)
+		}
+		if run.State == types.ThumbnailRegenerationCompleted {
+			return fmt.Errorf("regeneration run %s already completed", run.ID)
+		}
+		if run.State == types.ThumbnailRegenerationRunning && run.LeaseUntil != nil && run.LeaseUntil.After(time.Now()) {
+			return fmt.Errorf("regeneration run %s: %w", run.ID, queue.ErrRegenerationInProgress)
+		}
+		run.State = types.ThumbnailRegenerationRunning
+		run.LastError = ""
+		if err = db.UpdateThumbnailRegeneration(ctx, run); err != nil {
+			return err
+		}
+	} else {
+		run.State = types.ThumbnailRegenerationRunning
+		if run.RatePerSecond == 0 {
+			run.RatePerSecond = cfg.ThumbnailRegenerationRatePerSecond
+		}
+		if err = db.StoreThumbnailRegeneration(ctx, run); err != nil {
+			return err
+		}
+	}
+	util.Log(ctx).With("run_id", run.ID).Info("thumbnail regeneration started")
+
+	inline := func(ctx context.Context, mediaID types.MediaID) error {
+		payload, marshalErr := json.Marshal(map[string]string{"media_id": string(mediaID)})
+		if marshalErr != nil {
+			return marshalErr
+		}
+		return thumbnailHandler.Handle(ctx, nil, payload)
+	}
+	return queue.NewThumbnailRegenerator(svc, db, inline).Run(ctx, run)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRegenerateFlags(t *testing.T) {
+	opts, err := parseRegenerateFlags([]string{
+		"-tenant", "t1", "-partition", "p1", "-owner", "user-1", "-content-type", "image/",
+		"-created-after", "2026-01-01T00:00:00Z", "-remove-stale", "-rate", "5",
+	}, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, "t1", opts.tenantID)
+	assert.Equal(t, "p1", opts.partitionID)
+	assert.Equal(t, "user-1", string(opts.run.Filter.OwnerID))
+	assert.Equal(t, "image/", opts.run.Filter.ContentType)
+	require.NotNil(t, opts.run.Filter.CreatedAfter)
+	assert.Equal(t, 2026, opts.run.Filter.CreatedAfter.Year())
+	assert.Nil(t, opts.run.Filter.CreatedBefore)
+	assert.True(t, opts.run.RemoveStale)
+	assert.Equal(t, 5, opts.run.RatePerSecond)
+
+	testCases := []struct {
+		name string
+		args []string
+	}{
+		{name: "missing_tenant", args: []string{"-partition", "p1"}},
+		{name: "non_image_type", args: []string{"-tenant", "t1", "-partition", "p1", "-content-type", "video/mp4"}},
+		{name: "bad_time", args: []string{"-tenant", "t1", "-partition", "p1", "-created-before", "yesterday"}},
+		{name: "negative_rate", args: []string{"-tenant", "t1", "-partition", "p1", "-rate", "-1"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, parseErr := parseRegenerateFlags(tc.args, io.Discard)
+			require.Error(t, parseErr)
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/pitabwire/frame/v2/config"
 )
@@ -24,6 +27,89 @@ type ThumbnailSize struct {
 	ResizeMethod string `yaml:"method,omitempty"`
 }
 
+// Image sanitization modes applied to uploaded images.
+const (
+	// ImageSanitizeStrip applies the EXIF orientation and removes location,
+	// device and other descriptive metadata.
+	ImageSanitizeStrip = "strip"
+	// ImageSanitizeKeep strips like ImageSanitizeStrip and keeps a copy of the
+	// extracted fields in the media metadata.
+	ImageSanitizeKeep = "keep"
+	// ImageSanitizeOff stores images as uploaded.
+	ImageSanitizeOff = "off"
+)
+
+// Policies for uploads whose content contradicts their declared type.
+const (
+	// ContentTypeMismatchReject refuses the upload.
+	ContentTypeMismatchReject = "reject"
+	// ContentTypeMismatchCorrect stores the detected type and records the
+	// declared one on the media.
+	ContentTypeMismatchCorrect = "correct"
+)
+
+// Malware scanners uploads can be checked with.
+const (
+	// ScannerNone stores uploads without scanning them.
+	ScannerNone = "none"
+	// ScannerClamAV streams uploads to a clamd daemon.
+	ScannerClamAV = "clamav"
+)
+
+// ContentTypeRule restricts uploads by content type and file extension.
+// Entries are MIME types such as image/png, wildcards such as image/* or
+// extensions such as .exe.
+type ContentTypeRule struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// ContentTypeRules are content type rules keyed by tenant_id,
+// tenant_id/purpose or */purpose. They are read from a JSON object.
+type ContentTypeRules map[string]ContentTypeRule
+
+// UnmarshalText decodes the rules from JSON.
+func (r *ContentTypeRules) UnmarshalText(text []byte) error {
+	rules := map[string]ContentTypeRule{}
+	if err := json.Unmarshal(text, &rules); err != nil {
+		return fmt.Errorf("invalid content type rules: %w", err)
+	}
+	*r = rules
+	return nil
+}
+
+// RateLimit caps requests per second, concurrent uploads and download bytes
+// per second. In rules a zero field inherits the broader limit and a
+// negative one lifts it; in the resolved limits zero means unlimited.
+type RateLimit struct {
+	RequestsPerSecond      int64 `json:"requests_per_second,omitempty"`
+	ConcurrentUploads      int64 `json:"concurrent_uploads,omitempty"`
+	DownloadBytesPerSecond int64 `json:"download_bytes_per_second,omitempty"`
+}
+
+// RateLimitRule holds the limits of a tenant as a whole and of each of its
+// users.
+type RateLimitRule struct {
+	Tenant RateLimit `json:"tenant"`
+	User   RateLimit `json:"user"`
+}
+
+// RateLimitRules are rate limit rules keyed by tenant_id,
+// tenant_id/operation or */operation, where an operation is an RPC method
+// name such as UploadContent or an HTTP route such as http:download. They
+// are read from a JSON object.
+type RateLimitRules map[string]RateLimitRule
+
+// UnmarshalText decodes the rules from JSON.
+func (r *RateLimitRules) UnmarshalText(text []byte) error {
+	rules := map[string]RateLimitRule{}
+	if err := json.Unmarshal(text, &rules); err != nil {
+		return fmt.Errorf("invalid rate limit rules: %w", err)
+	}
+	*r = rules
+	return nil
+}
+
 // DefaultMaxFileSizeBytes defines the default file size allowed in transfers
 var DefaultMaxFileSizeBytes = FileSizeBytes(10485760)
 
@@ -64,8 +150,141 @@ type FilesConfig struct {
 	QueueThumbnailsGenerateURL  string `envDefault:"mem://thumbnails_generate" env:"QUEUE_THUMBNAILS_GENERATE_URL"`
 	QueueThumbnailsGenerateName string `envDefault:"thumbnails_generate" env:"QUEUE_THUMBNAILS_GENERATE_NAME"`
 
+	// Thumbnail jobs: attempts before a job is dead-lettered, the backoff
+	// base and cap between attempts and how often due retries are polled.
+	ThumbnailMaxAttempts      int `envDefault:"5" env:"THUMBNAIL_MAX_ATTEMPTS"`
+	ThumbnailRetryBaseSeconds int `envDefault:"30" env:"THUMBNAIL_RETRY_BASE_SECONDS"`
+	ThumbnailRetryMaxSeconds  int `envDefault:"1800" env:"THUMBNAIL_RETRY_MAX_SECONDS"`
+	ThumbnailRetryPollSeconds int `envDefault:"15" env:"THUMBNAIL_RETRY_POLL_SECONDS"`
+
+	// Bulk thumbnail regeneration runs walk existing images on their own
+	// queue; the rate caps the images queued for generation per second. A
+	// worker holds a run for the lease and renews it while it makes
+	// progress; runs whose lease lapses are picked up again.
+	QueueThumbnailsRegenerateURL       string `envDefault:"mem://thumbnails_regenerate" env:"QUEUE_THUMBNAILS_REGENERATE_URL"`
+	QueueThumbnailsRegenerateName      string `envDefault:"thumbnails_regenerate" env:"QUEUE_THUMBNAILS_REGENERATE_NAME"`
+	ThumbnailRegenerationRatePerSecond int    `envDefault:"10" env:"THUMBNAIL_REGENERATION_RATE_PER_SECOND"`
+	ThumbnailRegenerationLeaseSeconds  int    `envDefault:"120" env:"THUMBNAIL_REGENERATION_LEASE_SECONDS"`
+
+	// Archive extraction jobs expand uploaded zip and tar.gz archives on
+	// their own queue. An archive may hold at most ArchiveExtractMaxEntries
+	// entries and expand to ArchiveExtractMaxBytes, or ArchiveExtractMaxRatio
+	// times its own size if that is less; larger archives stop extracting.
+	QueueArchiveExtractURL   string `envDefault:"mem://archive_extract" env:"QUEUE_ARCHIVE_EXTRACT_URL"`
+	QueueArchiveExtractName  string `envDefault:"archive_extract" env:"QUEUE_ARCHIVE_EXTRACT_NAME"`
+	ArchiveExtractMaxEntries int    `envDefault:"1000" env:"ARCHIVE_EXTRACT_MAX_ENTRIES"`
+	ArchiveExtractMaxBytes   int64  `envDefault:"1073741824" env:"ARCHIVE_EXTRACT_MAX_BYTES"`
+	ArchiveExtractMaxRatio   int64  `envDefault:"100" env:"ARCHIVE_EXTRACT_MAX_RATIO"`
+
+	// User data exports package everything a profile owns into one zip
+	// archive on their own queue. The archive is streamed into storage and
+	// an export whose archive outgrows UserExportMaxBytes fails.
+	QueueUserExportURL  string `envDefault:"mem://user_export" env:"QUEUE_USER_EXPORT_URL"`
+	QueueUserExportName string `envDefault:"user_export" env:"QUEUE_USER_EXPORT_NAME"`
+	UserExportMaxBytes  int64  `envDefault:"5368709120" env:"USER_EXPORT_MAX_BYTES"`
+
+	// User data erasures remove or anonymize everything a profile owns on
+	// their own queue. The report of each erasure is signed with the signing
+	// key; erasures are refused while it is unset.
+	QueueUserEraseURL       string `envDefault:"mem://user_erase" env:"QUEUE_USER_ERASE_URL"`
+	QueueUserEraseName      string `envDefault:"user_erase" env:"QUEUE_USER_ERASE_NAME"`
+	ErasureReportSigningKey string `envDefault:"" env:"ERASURE_REPORT_SIGNING_KEY"`
+
+	// Password protected share links are locked for the lockout once the
+	// given number of wrong passwords are sent in a row.
+	ShareLinkMaxPasswordAttempts int `envDefault:"5" env:"SHARE_LINK_MAX_PASSWORD_ATTEMPTS"`
+	ShareLinkLockoutSeconds      int `envDefault:"900" env:"SHARE_LINK_LOCKOUT_SECONDS"`
+
+	// An ownership transfer updates Keto file by file while the caller waits,
+	// so it may cover at most this many files; larger trees are handed over
+	// a folder at a time.
+	OwnershipTransferMaxFiles int `envDefault:"1000" env:"OWNERSHIP_TRANSFER_MAX_FILES"`
+
+	// Access grants made with an expiry are revoked from Keto by a sweep run
+	// at this interval; checks deny them as soon as they expire regardless.
+	AccessGrantSweepSeconds int `envDefault:"60" env:"ACCESS_GRANT_SWEEP_SECONDS"`
+
+	// Media OwnerIDs, folders and grants are compared with Keto at this
+	// interval by one replica at a time, and Keto's file and file_folder
+	// tuples are checked for media and folders that no longer exist. In
+	// dry-run mode the differences found are only logged, not repaired.
+	OwnershipReconcileSeconds int  `envDefault:"86400" env:"OWNERSHIP_RECONCILE_SECONDS"`
+	OwnershipReconcileDryRun  bool `envDefault:"true" env:"OWNERSHIP_RECONCILE_DRY_RUN"`
+	// Profiles granted access are checked against the profile service at
+	// this URI during reconciliation; without it only grants to groups and
+	// tenant partitions are checked.
+	ProfileServiceURI string `envDefault:"" env:"PROFILE_SERVICE_URI"`
+
+	// Read cache for metadata, thumbnails and URL previews. Without a URI
+	// each replica keeps an in-process LRU cache; a redis:// URI shares one
+	// cache between replicas.
+	CacheEnabled bool   `envDefault:"true" env:"CACHE_ENABLED"`
+	CacheURI     string `envDefault:"" env:"CACHE_URI"`
+
+	// Metrics: the Prometheus scrape endpoint replaces the OTel metric
+	// exporter selected by OTEL_METRICS_EXPORTER while enabled. It is served
+	// on its own address, kept off the public listener, and queue depths are
+	// sampled at the given interval.
+	MetricsPrometheusEnabled bool   `envDefault:"true" env:"METRICS_PROMETHEUS_ENABLED"`
+	MetricsPrometheusAddress string `envDefault:":9464" env:"METRICS_PROMETHEUS_ADDRESS"`
+	QueueDepthPollSeconds    int    `envDefault:"30" env:"QUEUE_DEPTH_POLL_SECONDS"`
+
+	// Rate limits applied per tenant and per user to RPCs and media routes,
+	// with rules overriding them for tenants and operations. Zero limits are
+	// unlimited. Counters are shared between replicas through the store URI,
+	// or the cache URI when unset, and kept per replica without either.
+	RateLimitEnabled                      bool           `envDefault:"true" env:"RATE_LIMIT_ENABLED"`
+	RateLimitTenantRequestsPerSecond      int64          `envDefault:"1000" env:"RATE_LIMIT_TENANT_REQUESTS_PER_SECOND"`
+	RateLimitUserRequestsPerSecond        int64          `envDefault:"100" env:"RATE_LIMIT_USER_REQUESTS_PER_SECOND"`
+	RateLimitTenantConcurrentUploads      int64          `envDefault:"200" env:"RATE_LIMIT_TENANT_CONCURRENT_UPLOADS"`
+	RateLimitUserConcurrentUploads        int64          `envDefault:"10" env:"RATE_LIMIT_USER_CONCURRENT_UPLOADS"`
+	RateLimitTenantDownloadBytesPerSecond int64          `envDefault:"0" env:"RATE_LIMIT_TENANT_DOWNLOAD_BYTES_PER_SECOND"`
+	RateLimitUserDownloadBytesPerSecond   int64          `envDefault:"0" env:"RATE_LIMIT_USER_DOWNLOAD_BYTES_PER_SECOND"`
+	RateLimitRules                        RateLimitRules `env:"RATE_LIMIT_RULES"`
+	RateLimitStoreURI                     string         `envDefault:"" env:"RATE_LIMIT_STORE_URI"`
+
+	QueueFileEventsURL  string `envDefault:"mem://file_lifecycle_events" env:"QUEUE_FILE_EVENTS_URL"`
+	QueueFileEventsName string `envDefault:"file_lifecycle_events" env:"QUEUE_FILE_EVENTS_NAME"`
+
+	// Webhook delivery: attempts before a delivery is dead-lettered, the
+	// backoff base and cap between attempts, the HTTP timeout per attempt and
+	// how often pending retries are polled.
+	WebhookMaxAttempts           int  `envDefault:"8" env:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookRetryBaseSeconds      int  `envDefault:"30" env:"WEBHOOK_RETRY_BASE_SECONDS"`
+	WebhookRetryMaxSeconds       int  `envDefault:"3600" env:"WEBHOOK_RETRY_MAX_SECONDS"`
+	WebhookTimeoutSeconds        int  `envDefault:"10" env:"WEBHOOK_TIMEOUT_SECONDS"`
+	WebhookRetryPollSeconds      int  `envDefault:"15" env:"WEBHOOK_RETRY_POLL_SECONDS"`
+	WebhookAllowPrivateEndpoints bool `envDefault:"false" env:"WEBHOOK_ALLOW_PRIVATE_ENDPOINTS"`
+
 	CsrfSecret string `envDefault:"" env:"CSRF_SECRET"`
 
+	// Upload image sanitization mode, one of strip, keep or off. Tenants can
+	// be given their own mode as comma separated tenant_id:mode pairs.
+	ImageSanitizeMode        string            `envDefault:"strip" env:"IMAGE_SANITIZE_MODE"`
+	ImageSanitizeTenantModes map[string]string `env:"IMAGE_SANITIZE_TENANT_MODES" envSeparator:"," envKeyValSeparator:":"`
+
+	// Uploads are sniffed for their real content type. A declared type the
+	// content contradicts is rejected or corrected per the mismatch policy.
+	// Allow and deny lists apply to every upload; rules add lists for
+	// tenants and upload purposes. Deny entries accumulate across matching
+	// rules, while the most specific rule with an allow list sets it.
+	ContentTypeMismatchPolicy string           `envDefault:"correct" env:"CONTENT_TYPE_MISMATCH_POLICY"`
+	ContentTypeAllow          []string         `env:"CONTENT_TYPE_ALLOW" envSeparator:","`
+	ContentTypeDeny           []string         `env:"CONTENT_TYPE_DENY" envSeparator:","`
+	ContentTypeRules          ContentTypeRules `env:"CONTENT_TYPE_RULES"`
+
+	// Uploads are scanned for malware after they are stored when a scanner
+	// is configured, and stay pending until the scan completes. Downloads,
+	// signed URLs and sharing are refused while the scan status is one of the
+	// blocked statuses; infected media is always blocked and made private.
+	ScannerProvider     string   `envDefault:"none" env:"SCANNER_PROVIDER"`
+	ClamAVAddress       string   `envDefault:"tcp://127.0.0.1:3310" env:"CLAMAV_ADDRESS"`
+	ScanTimeoutSeconds  int      `envDefault:"120" env:"SCAN_TIMEOUT_SECONDS"`
+	ScanBlockedStatuses []string `envDefault:"pending,infected,failed" env:"SCAN_BLOCKED_STATUSES" envSeparator:","`
+
+	QueueMediaScanURL  string `envDefault:"mem://media_scan" env:"QUEUE_MEDIA_SCAN_URL"`
+	QueueMediaScanName string `envDefault:"media_scan" env:"QUEUE_MEDIA_SCAN_NAME"`
+
 	ProviderGcsPrivateBucket  string `envDefault:"" env:"GCS_PRIVATE_BUCKET"`
 	ProviderGcsPublicBucket   string `envDefault:"" env:"GCS_PUBLIC_BUCKET"`
 	ProviderS3PrivateBucket   string `envDefault:"" env:"S3_PRIVATE_BUCKET"`
@@ -98,6 +317,10 @@ type FilesConfig struct {
 	// Maximum allowed thumbnail width/height in pixels. Default: 2048
 	MaxThumbnailDimension int `envDefault:"2048" env:"MAX_THUMBNAIL_DIMENSION"`
 
+	// Maximum number of transform derivatives stored per media. Requests for
+	// further transforms are refused once the limit is reached. Default: 32
+	MaxDerivativesPerMedia int `envDefault:"32" env:"MAX_DERIVATIVES_PER_MEDIA"`
+
 	// A list of thumbnail sizes to be pre-generated for downloaded remote / uploaded content
 	ThumbnailSizes []ThumbnailSize `yaml:"thumbnail_sizes"`
 }
@@ -116,6 +339,88 @@ func (c *FilesConfig) Normalise() error {
 		c.MaxThumbnailDimension = 2048
 	}
 
+	if c.ImageSanitizeMode == "" {
+		c.ImageSanitizeMode = ImageSanitizeStrip
+	}
+	if !validImageSanitizeMode(c.ImageSanitizeMode) {
+		return fmt.Errorf("invalid image sanitize mode %q", c.ImageSanitizeMode)
+	}
+	for tenantID, mode := range c.ImageSanitizeTenantModes {
+		if !validImageSanitizeMode(mode) {
+			return fmt.Errorf("invalid image sanitize mode %q for tenant %s", mode, tenantID)
+		}
+	}
+
+	if c.ContentTypeMismatchPolicy == "" {
+		c.ContentTypeMismatchPolicy = ContentTypeMismatchCorrect
+	}
+	if c.ContentTypeMismatchPolicy != ContentTypeMismatchReject && c.ContentTypeMismatchPolicy != ContentTypeMismatchCorrect {
+		return fmt.Errorf("invalid content type mismatch policy %q", c.ContentTypeMismatchPolicy)
+	}
+
+	if c.ScannerProvider == "" {
+		c.ScannerProvider = ScannerNone
+	}
+	if c.ScannerProvider != ScannerNone && c.ScannerProvider != ScannerClamAV {
+		return fmt.Errorf("invalid scanner provider %q", c.ScannerProvider)
+	}
+	if c.ScanTimeoutSeconds <= 0 {
+		c.ScanTimeoutSeconds = 120
+	}
+	for _, status := range c.ScanBlockedStatuses {
+		if status != "pending" && status != "infected" && status != "failed" {
+			return fmt.Errorf("invalid blocked scan status %q", status)
+		}
+	}
+
+	if c.ThumbnailMaxAttempts <= 0 {
+		c.ThumbnailMaxAttempts = 5
+	}
+
+	if c.ThumbnailRegenerationRatePerSecond <= 0 {
+		c.ThumbnailRegenerationRatePerSecond = 10
+	}
+	if c.ThumbnailRegenerationLeaseSeconds <= 0 {
+		c.ThumbnailRegenerationLeaseSeconds = 120
+	}
+
+	if c.ArchiveExtractMaxEntries <= 0 {
+		c.ArchiveExtractMaxEntries = 1000
+	}
+	if c.ArchiveExtractMaxBytes <= 0 {
+		c.ArchiveExtractMaxBytes = 1 << 30
+	}
+	if c.ArchiveExtractMaxRatio <= 0 {
+		c.ArchiveExtractMaxRatio = 100
+	}
+
+	if c.UserExportMaxBytes <= 0 {
+		c.UserExportMaxBytes = 5 << 30
+	}
+
+	if c.QueueDepthPollSeconds <= 0 {
+		c.QueueDepthPollSeconds = 30
+	}
+
+	if c.ShareLinkMaxPasswordAttempts <= 0 {
+		c.ShareLinkMaxPasswordAttempts = 5
+	}
+	if c.ShareLinkLockoutSeconds <= 0 {
+		c.ShareLinkLockoutSeconds = 900
+	}
+
+	if c.OwnershipTransferMaxFiles <= 0 {
+		c.OwnershipTransferMaxFiles = 1000
+	}
+
+	if c.AccessGrantSweepSeconds <= 0 {
+		c.AccessGrantSweepSeconds = 60
+	}
+
+	if c.OwnershipReconcileSeconds <= 0 {
+		c.OwnershipReconcileSeconds = 86400
+	}
+
 	if len(c.ThumbnailSizes) == 0 {
 		c.ThumbnailSizes = []ThumbnailSize{
 			{Width: 32, Height: 32, ResizeMethod: "crop"},
@@ -136,3 +441,175 @@ func (c *FilesConfig) Normalise() error {
 
 	return nil
 }
+
+// ImageSanitizeModeFor returns the image sanitization mode of a tenant.
+func (c *FilesConfig) ImageSanitizeModeFor(tenantID string) string {
+	if mode, ok := c.ImageSanitizeTenantModes[tenantID]; ok && tenantID != "" {
+		return mode
+	}
+	if c.ImageSanitizeMode == "" {
+		return ImageSanitizeStrip
+	}
+	return c.ImageSanitizeMode
+}
+
+func validImageSanitizeMode(mode string) bool {
+	return mode == ImageSanitizeStrip || mode == ImageSanitizeKeep || mode == ImageSanitizeOff
+}
+
+// ScanEnabled reports whether uploads are scanned for malware.
+func (c *FilesConfig) ScanEnabled() bool {
+	return c.ScannerProvider != "" && c.ScannerProvider != ScannerNone
+}
+
+// ScanBlocks reports whether media with the scan status may not be
+// downloaded or shared. Infected media is always blocked.
+func (c *FilesConfig) ScanBlocks(status string) bool {
+	if status == "infected" {
+		return true
+	}
+	for _, blocked := range c.ScanBlockedStatuses {
+		if blocked == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ContentTypeRuleFor returns the content type rule applying to uploads of a
+// tenant for a purpose. Either may be empty.
+func (c *FilesConfig) ContentTypeRuleFor(tenantID, purpose string) ContentTypeRule {
+	rule := ContentTypeRule{
+		Allow: c.ContentTypeAllow,
+		Deny:  append([]string{}, c.ContentTypeDeny...),
+	}
+
+	// From least to most specific.
+	var keys []string
+	if purpose != "" {
+		keys = append(keys, "*/"+purpose)
+	}
+	if tenantID != "" {
+		keys = append(keys, tenantID)
+		if purpose != "" {
+			keys = append(keys, tenantID+"/"+purpose)
+		}
+	}
+	for _, key := range keys {
+		matched, ok := c.ContentTypeRules[key]
+		if !ok {
+			continue
+		}
+		if len(matched.Allow) > 0 {
+			rule.Allow = matched.Allow
+		}
+		rule.Deny = append(rule.Deny, matched.Deny...)
+	}
+	return rule
+}
+
+// RateLimitRuleFor returns the limits applying to an operation of a tenant
+// and whether an operation specific rule matched, in which case the
+// operation is counted apart from the tenant's other traffic.
+func (c *FilesConfig) RateLimitRuleFor(tenantID, operation string) (RateLimitRule, bool) {
+	rule := RateLimitRule{
+		Tenant: RateLimit{
+			RequestsPerSecond:      c.RateLimitTenantRequestsPerSecond,
+			ConcurrentUploads:      c.RateLimitTenantConcurrentUploads,
+			DownloadBytesPerSecond: c.RateLimitTenantDownloadBytesPerSecond,
+		},
+		User: RateLimit{
+			RequestsPerSecond:      c.RateLimitUserRequestsPerSecond,
+			ConcurrentUploads:      c.RateLimitUserConcurrentUploads,
+			DownloadBytesPerSecond: c.RateLimitUserDownloadBytesPerSecond,
+		},
+	}
+
+	// From least to most specific.
+	keys := []string{"*/" + operation}
+	if tenantID != "" {
+		keys = append(keys, tenantID, tenantID+"/"+operation)
+	}
+	operationScoped := false
+	for _, key := range keys {
+		matched, ok := c.RateLimitRules[key]
+		if !ok {
+			continue
+		}
+		if key != tenantID {
+			operationScoped = true
+		}
+		rule.Tenant = rule.Tenant.override(matched.Tenant)
+		rule.User = rule.User.override(matched.User)
+	}
+
+	rule.Tenant = rule.Tenant.resolved()
+	rule.User = rule.User.resolved()
+	return rule, operationScoped
+}
+
+func (l RateLimit) override(o RateLimit) RateLimit {
+	if o.RequestsPerSecond != 0 {
+		l.RequestsPerSecond = o.RequestsPerSecond
+	}
+	if o.ConcurrentUploads != 0 {
+		l.ConcurrentUploads = o.ConcurrentUploads
+	}
+	if o.DownloadBytesPerSecond != 0 {
+		l.DownloadBytesPerSecond = o.DownloadBytesPerSecond
+	}
+	return l
+}
+
+func (l RateLimit) resolved() RateLimit {
+	l.RequestsPerSecond = max(l.RequestsPerSecond, 0)
+	l.ConcurrentUploads = max(l.ConcurrentUploads, 0)
+	l.DownloadBytesPerSecond = max(l.DownloadBytesPerSecond, 0)
+	return l
+}
+
+// Permits reports whether a rule accepts content of the given type and
+// filename. Any deny entry matching the type or extension refuses it. When
+// the allow list has type entries the type must match one of them, and
+// likewise for extension entries.
+func (r ContentTypeRule) Permits(contentType, filename string) bool {
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = strings.TrimSpace(contentType[:i])
+	}
+	ext := strings.ToLower(filepath.Ext(filename))
+
+	for _, entry := range r.Deny {
+		if matchContentTypeEntry(entry, contentType, ext) {
+			return false
+		}
+	}
+
+	var typeAllowed, extAllowed, hasTypes, hasExts bool
+	for _, entry := range r.Allow {
+		if strings.HasPrefix(entry, ".") {
+			hasExts = true
+			extAllowed = extAllowed || matchContentTypeEntry(entry, contentType, ext)
+		} else {
+			hasTypes = true
+			typeAllowed = typeAllowed || matchContentTypeEntry(entry, contentType, ext)
+		}
+	}
+	return (!hasTypes || typeAllowed) && (!hasExts || extAllowed)
+}
+
+func matchContentTypeEntry(entry, contentType, ext string) bool {
+	entry = strings.ToLower(strings.TrimSpace(entry))
+	switch {
+	case entry == "":
+		return false
+	case strings.HasPrefix(entry, "."):
+		return entry == ext
+	case entry == "*" || entry == "*/*":
+		return true
+	case strings.HasSuffix(entry, "/*"):
+		return strings.HasPrefix(contentType, strings.TrimSuffix(entry, "*"))
+	default:
+		return entry == contentType
+	}
+}
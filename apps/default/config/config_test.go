@@ -40,3 +40,121 @@ func (s *ConfigTestSuite) TestFilesConfig_Normalise() {
 		})
 	}
 }
+
+func (s *ConfigTestSuite) TestFilesConfig_ImageSanitizeMode() {
+	t := s.T()
+
+	cfg := config.FilesConfig{
+		ImageSanitizeTenantModes: map[string]string{"tenant-a": config.ImageSanitizeOff},
+	}
+	require.NoError(t, cfg.Normalise())
+	require.Equal(t, config.ImageSanitizeStrip, cfg.ImageSanitizeModeFor(""))
+	require.Equal(t, config.ImageSanitizeStrip, cfg.ImageSanitizeModeFor("tenant-b"))
+	require.Equal(t, config.ImageSanitizeOff, cfg.ImageSanitizeModeFor("tenant-a"))
+
+	invalid := config.FilesConfig{ImageSanitizeMode: "blur"}
+	require.Error(t, invalid.Normalise())
+
+	invalidTenant := config.FilesConfig{ImageSanitizeTenantModes: map[string]string{"tenant-a": "blur"}}
+	require.Error(t, invalidTenant.Normalise())
+}
+
+func (s *ConfigTestSuite) TestFilesConfig_ContentTypeRules() {
+	t := s.T()
+
+	var rules config.ContentTypeRules
+	require.NoError(t, rules.UnmarshalText([]byte(`{
+		"tenant-a": {"deny": ["video/*"]},
+		"*/avatar": {"allow": ["image/png", "image/jpeg"]},
+		"tenant-a/document": {"allow": ["application/pdf", ".pdf"]}
+	}`)))
+	require.Error(t, rules.UnmarshalText([]byte(`["image/png"]`)))
+
+	cfg := config.FilesConfig{ContentTypeDeny: []string{"text/html", ".exe"}, ContentTypeRules: rules}
+	require.NoError(t, cfg.Normalise())
+	require.Equal(t, config.ContentTypeMismatchCorrect, cfg.ContentTypeMismatchPolicy)
+
+	general := cfg.ContentTypeRuleFor("tenant-b", "")
+	require.True(t, general.Permits("video/mp4", "clip.mp4"))
+	require.False(t, general.Permits("text/html; charset=utf-8", "page.html"))
+	require.False(t, general.Permits("application/octet-stream", "setup.EXE"))
+
+	tenant := cfg.ContentTypeRuleFor("tenant-a", "")
+	require.False(t, tenant.Permits("video/mp4", "clip.mp4"))
+	require.False(t, tenant.Permits("text/html", "page.html"), "deny lists accumulate")
+
+	avatar := cfg.ContentTypeRuleFor("tenant-a", "avatar")
+	require.True(t, avatar.Permits("image/png", "me.png"))
+	require.False(t, avatar.Permits("image/gif", "me.gif"))
+
+	document := cfg.ContentTypeRuleFor("tenant-a", "document")
+	require.True(t, document.Permits("application/pdf", "report.pdf"))
+	require.False(t, document.Permits("application/pdf", "report.docx"), "type and extension must both be allowed")
+
+	invalid := config.FilesConfig{ContentTypeMismatchPolicy: "ignore"}
+	require.Error(t, invalid.Normalise())
+}
+
+func (s *ConfigTestSuite) TestFilesConfig_RateLimitRules() {
+	t := s.T()
+
+	var rules config.RateLimitRules
+	require.NoError(t, rules.UnmarshalText([]byte(`{
+		"*/UploadContent": {"user": {"concurrent_uploads": 2}},
+		"tenant-a": {"tenant": {"requests_per_second": 50, "download_bytes_per_second": 1048576}},
+		"tenant-a/DownloadContent": {"user": {"download_bytes_per_second": -1}}
+	}`)))
+	require.Error(t, rules.UnmarshalText([]byte(`[]`)))
+
+	cfg := config.FilesConfig{
+		RateLimitTenantRequestsPerSecond:    1000,
+		RateLimitUserRequestsPerSecond:      100,
+		RateLimitUserConcurrentUploads:      10,
+		RateLimitUserDownloadBytesPerSecond: 4096,
+		RateLimitRules:                      rules,
+	}
+
+	general, scoped := cfg.RateLimitRuleFor("tenant-b", "GetContent")
+	require.False(t, scoped)
+	require.Equal(t, int64(1000), general.Tenant.RequestsPerSecond)
+	require.Equal(t, int64(100), general.User.RequestsPerSecond)
+
+	upload, scoped := cfg.RateLimitRuleFor("tenant-b", "UploadContent")
+	require.True(t, scoped)
+	require.Equal(t, int64(2), upload.User.ConcurrentUploads)
+	require.Equal(t, int64(100), upload.User.RequestsPerSecond, "unset fields inherit")
+
+	tenant, scoped := cfg.RateLimitRuleFor("tenant-a", "GetContent")
+	require.False(t, scoped)
+	require.Equal(t, int64(50), tenant.Tenant.RequestsPerSecond)
+	require.Equal(t, int64(1048576), tenant.Tenant.DownloadBytesPerSecond)
+
+	download, scoped := cfg.RateLimitRuleFor("tenant-a", "DownloadContent")
+	require.True(t, scoped)
+	require.Zero(t, download.User.DownloadBytesPerSecond, "negative limits lift the default")
+	require.Equal(t, int64(1048576), download.Tenant.DownloadBytesPerSecond)
+}
+
+func (s *ConfigTestSuite) TestFilesConfig_ScanPolicy() {
+	t := s.T()
+
+	cfg := config.FilesConfig{ScanBlockedStatuses: []string{"pending"}}
+	require.NoError(t, cfg.Normalise())
+	require.False(t, cfg.ScanEnabled())
+	require.Equal(t, 120, cfg.ScanTimeoutSeconds)
+	require.True(t, cfg.ScanBlocks("pending"))
+	require.True(t, cfg.ScanBlocks("infected"), "infected media is always blocked")
+	require.False(t, cfg.ScanBlocks("failed"))
+	require.False(t, cfg.ScanBlocks("clean"))
+	require.False(t, cfg.ScanBlocks(""))
+
+	enabled := config.FilesConfig{ScannerProvider: config.ScannerClamAV}
+	require.NoError(t, enabled.Normalise())
+	require.True(t, enabled.ScanEnabled())
+
+	invalid := config.FilesConfig{ScannerProvider: "sophos"}
+	require.Error(t, invalid.Normalise())
+
+	invalidStatus := config.FilesConfig{ScanBlockedStatuses: []string{"clean"}}
+	require.Error(t, invalidStatus.Normalise())
+}
@@ -0,0 +1,18992 @@
+// Copyright 2023-2026 Ant Investor Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// =============================================================================
+// Files Management API
+// =============================================================================
+//
+// Overview:
+//   The Files API provides comprehensive file and media management capabilities
+//   including upload, download, thumbnail generation, versioning, retention,
+//   access control, and search. All content is identified by content URIs
+//   via HTTPS API paths (e.g. https://<server>/v1/media/download/<server_name>/<media_id>).
+//
+// Quick Start (common workflows):
+//
+//   Simple Upload:
+//     1. Call UploadContent (stream metadata + chunks)
+//     2. Receive content_uri, media_id, server_name in response
+//     3. Use media_id for all subsequent operations (download, thumbnail, etc.)
+//
+//   Pre-allocated Upload (get URI before content is ready):
+//     1. Call CreateContent -> receive content_uri, media_id, server_name
+//     2. Later, call UploadContent with server_name + media_id in metadata
+//
+//   Download:
+//     - Small files: GetContent(media_id) -> bytes + metadata
+//     - Large files: DownloadContent(media_id) -> stream of chunks
+//     - Partial: DownloadContentRange(media_id, start, end) -> stream
+//     - Thumbnail: GetContentThumbnail(media_id, width, height)
+//
+//   Share with others:
+//     1. Upload content
+//     2. Call GrantAccess(media_id, principal_id, role)
+//
+//   Large file upload (multipart):
+//     1. CreateMultipartUpload -> upload_id
+//     2. UploadMultipartPart (repeat, can be parallel)
+//     3. CompleteMultipartUpload(upload_id, checksum, parts)
+//
+// API Paths (HTTPS):
+//   POST   /v1/media/upload                                        - Upload content
+//   GET    /v1/media/config                                        - Get server config
+//   GET    /v1/media/download/{serverName}/{mediaId}               - Download content
+//   GET    /v1/media/download/{serverName}/{mediaId}/{downloadName} - Download with filename
+//   GET    /v1/media/thumbnail/{serverName}/{mediaId}              - Get thumbnail
+//   GET    /v1/media/search                                        - Search media
+//
+// Architecture Principles:
+//   1. Labels for Organization - User-defined labels (map<string,string>) are for
+//      organization and search only, NOT access control.
+//   2. Explicit Access Control - All access is managed through AccessGrant.
+//      Use GrantAccess/RevokeAccess to control who can access content.
+//   3. Idempotency - All mutating operations support idempotency_key to safely
+//      handle network retries.
+//   4. State Machine - Media objects follow a defined lifecycle: CREATING ->
+//      AVAILABLE -> ARCHIVED/DELETED
+//   5. Pagination - All list operations use PageCursor from common package for
+//      consistent cursor-based pagination across services.
+//
+// Access Control Model:
+//
+//   All access control is handled through explicit AccessGrant entries.
+//   The owner automatically receives OWNER role and cannot be revoked.
+//
+//   Principal Types:
+//     - USER: Direct user access (principal_id = "user:<id>")
+//     - SERVICE: Service account access (principal_id = "service:<id>")
+//     - ORGANIZATION: All members of a tenant partition get access
+//       (principal_id = "tenancy:<tenant_id>/<partition_id>")
+//     - GROUP: All members of a group get access
+//       (principal_id = "group:<group_id>")
+//     - CHAT_GROUP: All members of a chat group get access
+//       (principal_id = "room:<room_id>" or "chat:<chat_id>")
+//
+//   This flexible model supports:
+//     - Organization-wide sharing (grant to org:acme-corp)
+//     - Chat room attachments (grant to room:!abc123:matrix.org)
+//     - Team folders (grant to org:acme-corp/engineering)
+//     - Admin-only access (grant to specific users with WRITER/OWNER role)
+//     - Public content (grant to "user:public" or use convenience field)
+//
+// Use Cases:
+//
+//   1. Chat Room Files:
+//      Upload, then GrantAccess with principal_id="room:!abc123:matrix.org"
+//      -> All room members can access
+//
+//   2. Organization Documents:
+//      Upload, then GrantAccess with principal_id="tenancy:<tenant_id>/<partition_id>"
+//      -> All partition members can access
+//
+//   3. Admin-Only Files:
+//      Upload, then GrantAccess to specific users with OWNER/WRITER role
+//      -> Only admins can modify
+//
+//   4. Public Assets:
+//      Set visibility=PUBLIC, no grants needed
+//
+// Dependencies:
+//   - google/protobuf/timestamp.proto
+//   - google/protobuf/struct.proto
+//   - common/v1/common.proto (PageCursor)
+// =============================================================================
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: files/v1/files.proto
+
+//go:build protoopaque
+
+package filesv1
+
+import (
+	v1 "buf.build/gen/go/antinvestor/common/protocolbuffers/go/common/v1"
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "buf.build/gen/go/gnostic/gnostic/protocolbuffers/go/gnostic/openapi/v3"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ThumbnailMethod defines how thumbnails should be generated from source media.
+//
+//   - SCALE: Scales the image to fit within the target dimensions while
+//     preserving the original aspect ratio. The result may have dimensions
+//     smaller than or equal to the requested size.
+//   - CROP: Crops the image to exactly match the target dimensions. This
+//     may result in losing some of the original image content.
+//
+// Choosing between methods:
+//   - Use SCALE for: photographs, images where all content matters
+//   - Use CROP: avatars, preview tiles where exact dimensions are critical
+//
+// buf:lint:ignore ENUM_VALUE_PREFIX
+type ThumbnailMethod int32
+
+const (
+	// buf:lint:ignore ENUM_ZERO_VALUE_SUFFIX
+	// Scale the image to fit within dimensions, preserving aspect ratio.
+	// The output dimensions will be <= the requested width/height.
+	ThumbnailMethod_SCALE ThumbnailMethod = 0
+	// Crop the image to exactly match the requested dimensions.
+	// May lose edge content; useful for avatars and uniform tiles.
+	ThumbnailMethod_CROP ThumbnailMethod = 1
+)
+
+// Enum value maps for ThumbnailMethod.
+var (
+	ThumbnailMethod_name = map[int32]string{
+		0: "SCALE",
+		1: "CROP",
+	}
+	ThumbnailMethod_value = map[string]int32{
+		"SCALE": 0,
+		"CROP":  1,
+	}
+)
+
+func (x ThumbnailMethod) Enum() *ThumbnailMethod {
+	p := new(ThumbnailMethod)
+	*p = x
+	return p
+}
+
+func (x ThumbnailMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ThumbnailMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[0].Descriptor()
+}
+
+func (ThumbnailMethod) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[0]
+}
+
+func (x ThumbnailMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// ImageFormat selects the encoding of a transformed thumbnail.
+type ImageFormat int32
+
+const (
+	// Keep the default thumbnail encoding (JPEG).
+	ImageFormat_IMAGE_FORMAT_UNSPECIFIED ImageFormat = 0
+	// Lossy JPEG; honours the requested quality.
+	ImageFormat_IMAGE_FORMAT_JPEG ImageFormat = 1
+	// Lossless PNG; preserves transparency.
+	ImageFormat_IMAGE_FORMAT_PNG ImageFormat = 2
+	// Lossless WebP; preserves transparency.
+	ImageFormat_IMAGE_FORMAT_WEBP ImageFormat = 3
+)
+
+// Enum value maps for ImageFormat.
+var (
+	ImageFormat_name = map[int32]string{
+		0: "IMAGE_FORMAT_UNSPECIFIED",
+		1: "IMAGE_FORMAT_JPEG",
+		2: "IMAGE_FORMAT_PNG",
+		3: "IMAGE_FORMAT_WEBP",
+	}
+	ImageFormat_value = map[string]int32{
+		"IMAGE_FORMAT_UNSPECIFIED": 0,
+		"IMAGE_FORMAT_JPEG":        1,
+		"IMAGE_FORMAT_PNG":         2,
+		"IMAGE_FORMAT_WEBP":        3,
+	}
+)
+
+func (x ImageFormat) Enum() *ImageFormat {
+	p := new(ImageFormat)
+	*p = x
+	return p
+}
+
+func (x ImageFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImageFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[1].Descriptor()
+}
+
+func (ImageFormat) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[1]
+}
+
+func (x ImageFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// MediaState represents the lifecycle state of a media object.
+//
+// State Transitions:
+//
+//	CREATING -> AVAILABLE (normal upload complete or finalize signed upload)
+//	CREATING -> FAILED (upload failed or was aborted)
+//	AVAILABLE -> ARCHIVED (retention policy or manual archive)
+//	AVAILABLE -> DELETED (soft delete)
+//	AVAILABLE -> CREATING (re-upload to same media_id)
+//	ARCHIVED -> AVAILABLE (restore from archive)
+//	DELETED -> AVAILABLE (restore from soft delete)
+//	DELETED -> (hard delete after retention period expires)
+//
+// IMPORTANT: Content in CREATING state cannot be downloaded. Clients must
+// poll or wait for AVAILABLE state before serving content.
+type MediaState int32
+
+const (
+	// Default state - should not be used explicitly.
+	MediaState_MEDIA_STATE_UNSPECIFIED MediaState = 0
+	// Pre-created URI allocated, or multipart upload in progress.
+	// Content is not yet available for download. For signed uploads,
+	// FinalizeSignedUpload must be called to transition to AVAILABLE.
+	MediaState_MEDIA_STATE_CREATING MediaState = 1
+	// Upload complete and content is available for download.
+	// This is the only state from which content can be retrieved.
+	MediaState_MEDIA_STATE_AVAILABLE MediaState = 2
+	// Content has been archived per retention policy or manual action.
+	// Archived content may require restoration before download.
+	MediaState_MEDIA_STATE_ARCHIVED MediaState = 3
+	// Content has been soft-deleted. The data still exists but is hidden
+	// from normal queries. Can be restored via restore operation.
+	// Permanently removed after configured retention period if not restored.
+	MediaState_MEDIA_STATE_DELETED MediaState = 4
+	// Upload or processing failed. Content is not available.
+	// Check extra.error_message for failure details.
+	MediaState_MEDIA_STATE_FAILED MediaState = 5
+)
+
+// Enum value maps for MediaState.
+var (
+	MediaState_name = map[int32]string{
+		0: "MEDIA_STATE_UNSPECIFIED",
+		1: "MEDIA_STATE_CREATING",
+		2: "MEDIA_STATE_AVAILABLE",
+		3: "MEDIA_STATE_ARCHIVED",
+		4: "MEDIA_STATE_DELETED",
+		5: "MEDIA_STATE_FAILED",
+	}
+	MediaState_value = map[string]int32{
+		"MEDIA_STATE_UNSPECIFIED": 0,
+		"MEDIA_STATE_CREATING":    1,
+		"MEDIA_STATE_AVAILABLE":   2,
+		"MEDIA_STATE_ARCHIVED":    3,
+		"MEDIA_STATE_DELETED":     4,
+		"MEDIA_STATE_FAILED":      5,
+	}
+)
+
+func (x MediaState) Enum() *MediaState {
+	p := new(MediaState)
+	*p = x
+	return p
+}
+
+func (x MediaState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MediaState) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[2].Descriptor()
+}
+
+func (MediaState) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[2]
+}
+
+func (x MediaState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// ScanStatus represents the antivirus/antimalware scan status of media.
+//
+// Security Note: Download should be blocked for INFECTED content unless
+// the organization policy explicitly allows it. PENDING content should
+// also be blocked until scan completes with CLEAN status.
+type ScanStatus int32
+
+const (
+	// Scan status not determined or scan not requested.
+	ScanStatus_SCAN_STATUS_UNSPECIFIED ScanStatus = 0
+	// Scan queued but not yet completed. Content should NOT be served
+	// to end users until scan completes with CLEAN status.
+	ScanStatus_SCAN_STATUS_PENDING ScanStatus = 1
+	// Antivirus scan completed and content verified safe.
+	// Content can be served to users.
+	ScanStatus_SCAN_STATUS_CLEAN ScanStatus = 2
+	// Antivirus scan detected malware or potentially unwanted content.
+	// Content MUST NOT be served to users. Quarantine recommended.
+	ScanStatus_SCAN_STATUS_INFECTED ScanStatus = 3
+	// Antivirus scan failed to complete (engine error, timeout, etc.).
+	// Content should be held in quarantine until manual review.
+	ScanStatus_SCAN_STATUS_FAILED ScanStatus = 4
+)
+
+// Enum value maps for ScanStatus.
+var (
+	ScanStatus_name = map[int32]string{
+		0: "SCAN_STATUS_UNSPECIFIED",
+		1: "SCAN_STATUS_PENDING",
+		2: "SCAN_STATUS_CLEAN",
+		3: "SCAN_STATUS_INFECTED",
+		4: "SCAN_STATUS_FAILED",
+	}
+	ScanStatus_value = map[string]int32{
+		"SCAN_STATUS_UNSPECIFIED": 0,
+		"SCAN_STATUS_PENDING":     1,
+		"SCAN_STATUS_CLEAN":       2,
+		"SCAN_STATUS_INFECTED":    3,
+		"SCAN_STATUS_FAILED":      4,
+	}
+)
+
+func (x ScanStatus) Enum() *ScanStatus {
+	p := new(ScanStatus)
+	*p = x
+	return p
+}
+
+func (x ScanStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScanStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[3].Descriptor()
+}
+
+func (ScanStatus) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[3]
+}
+
+func (x ScanStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// AccessRole defines the role granted to a principal for accessing media.
+//
+// Permission Matrix:
+//
+//	| Operation          | READER | WRITER | OWNER |
+//	|-------------------|--------|--------|-------|
+//	| GetContent        |   Y    |   Y    |   Y   |
+//	| GetThumbnail      |   Y    |   Y    |   Y   |
+//	| PatchContent      |   N    |   Y    |   Y   |
+//	| DeleteContent     |   N    |   N    |   Y   |
+//	| GrantAccess       |   N    |   N    |   Y   |
+//	| RevokeAccess      |   N    |   N    |   Y   |
+//	| SetRetention      |   N    |   N    |   Y   |
+//	| RestoreVersion    |   N    |   Y    |   Y   |
+//
+// OWNER role also includes ability to transfer ownership to another
+// principal and permanently delete (hard delete) content.
+type AccessRole int32
+
+const (
+	// Default role - no permissions.
+	AccessRole_ACCESS_ROLE_UNSPECIFIED AccessRole = 0
+	// Reader role: can read content, view metadata, list versions.
+	// Cannot modify, delete, or manage access.
+	AccessRole_ACCESS_ROLE_READER AccessRole = 1
+	// Writer role: all reader permissions plus can upload new versions,
+	// patch metadata, and restore previous versions.
+	// Cannot delete, manage access, or change retention.
+	AccessRole_ACCESS_ROLE_WRITER AccessRole = 2
+	// Owner role: full control including delete, access management,
+	// retention policy, and ownership transfer.
+	AccessRole_ACCESS_ROLE_OWNER AccessRole = 3
+)
+
+// Enum value maps for AccessRole.
+var (
+	AccessRole_name = map[int32]string{
+		0: "ACCESS_ROLE_UNSPECIFIED",
+		1: "ACCESS_ROLE_READER",
+		2: "ACCESS_ROLE_WRITER",
+		3: "ACCESS_ROLE_OWNER",
+	}
+	AccessRole_value = map[string]int32{
+		"ACCESS_ROLE_UNSPECIFIED": 0,
+		"ACCESS_ROLE_READER":      1,
+		"ACCESS_ROLE_WRITER":      2,
+		"ACCESS_ROLE_OWNER":       3,
+	}
+)
+
+func (x AccessRole) Enum() *AccessRole {
+	p := new(AccessRole)
+	*p = x
+	return p
+}
+
+func (x AccessRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccessRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[4].Descriptor()
+}
+
+func (AccessRole) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[4]
+}
+
+func (x AccessRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// DeleteOutcome represents the outcome of a delete operation.
+//
+// This field clarifies what happened during deletion, which is important
+// for client logic and audit trails.
+type DeleteOutcome int32
+
+const (
+	// Outcome not specified - should not occur.
+	DeleteOutcome_DELETE_OUTCOME_UNSPECIFIED DeleteOutcome = 0
+	// Content was soft-deleted. Data still exists but marked as DELETED.
+	// Can be restored within the retention period.
+	DeleteOutcome_DELETE_OUTCOME_SOFT DeleteOutcome = 1
+	// Content was permanently deleted. Data is irretrievably lost.
+	DeleteOutcome_DELETE_OUTCOME_HARD DeleteOutcome = 2
+	// Delete was denied by retention policy. The content is under
+	// retention protection and cannot be deleted until the retention
+	// period expires.
+	DeleteOutcome_DELETE_OUTCOME_DENIED_BY_RETENTION DeleteOutcome = 3
+)
+
+// Enum value maps for DeleteOutcome.
+var (
+	DeleteOutcome_name = map[int32]string{
+		0: "DELETE_OUTCOME_UNSPECIFIED",
+		1: "DELETE_OUTCOME_SOFT",
+		2: "DELETE_OUTCOME_HARD",
+		3: "DELETE_OUTCOME_DENIED_BY_RETENTION",
+	}
+	DeleteOutcome_value = map[string]int32{
+		"DELETE_OUTCOME_UNSPECIFIED":         0,
+		"DELETE_OUTCOME_SOFT":                1,
+		"DELETE_OUTCOME_HARD":                2,
+		"DELETE_OUTCOME_DENIED_BY_RETENTION": 3,
+	}
+)
+
+func (x DeleteOutcome) Enum() *DeleteOutcome {
+	p := new(DeleteOutcome)
+	*p = x
+	return p
+}
+
+func (x DeleteOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeleteOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[5].Descriptor()
+}
+
+func (DeleteOutcome) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[5]
+}
+
+func (x DeleteOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// MultipartUploadState represents the state of a multipart upload session.
+type MultipartUploadState int32
+
+const (
+	MultipartUploadState_MULTIPART_UPLOAD_STATE_UNSPECIFIED MultipartUploadState = 0
+	MultipartUploadState_MULTIPART_UPLOAD_STATE_UPLOADING   MultipartUploadState = 1 // Parts are being uploaded
+	MultipartUploadState_MULTIPART_UPLOAD_STATE_COMPLETING  MultipartUploadState = 2 // Finalization in progress
+	MultipartUploadState_MULTIPART_UPLOAD_STATE_COMPLETED   MultipartUploadState = 3 // Successfully completed
+	MultipartUploadState_MULTIPART_UPLOAD_STATE_ABORTED     MultipartUploadState = 4 // Aborted by client
+	MultipartUploadState_MULTIPART_UPLOAD_STATE_EXPIRED     MultipartUploadState = 5 // Expired due to timeout
+)
+
+// Enum value maps for MultipartUploadState.
+var (
+	MultipartUploadState_name = map[int32]string{
+		0: "MULTIPART_UPLOAD_STATE_UNSPECIFIED",
+		1: "MULTIPART_UPLOAD_STATE_UPLOADING",
+		2: "MULTIPART_UPLOAD_STATE_COMPLETING",
+		3: "MULTIPART_UPLOAD_STATE_COMPLETED",
+		4: "MULTIPART_UPLOAD_STATE_ABORTED",
+		5: "MULTIPART_UPLOAD_STATE_EXPIRED",
+	}
+	MultipartUploadState_value = map[string]int32{
+		"MULTIPART_UPLOAD_STATE_UNSPECIFIED": 0,
+		"MULTIPART_UPLOAD_STATE_UPLOADING":   1,
+		"MULTIPART_UPLOAD_STATE_COMPLETING":  2,
+		"MULTIPART_UPLOAD_STATE_COMPLETED":   3,
+		"MULTIPART_UPLOAD_STATE_ABORTED":     4,
+		"MULTIPART_UPLOAD_STATE_EXPIRED":     5,
+	}
+)
+
+func (x MultipartUploadState) Enum() *MultipartUploadState {
+	p := new(MultipartUploadState)
+	*p = x
+	return p
+}
+
+func (x MultipartUploadState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MultipartUploadState) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[6].Descriptor()
+}
+
+func (MultipartUploadState) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[6]
+}
+
+func (x MultipartUploadState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// PrincipalType defines the type of principal in an access grant.
+//
+// This helps the system understand how to resolve group membership
+// when checking access permissions.
+type PrincipalType int32
+
+const (
+	// Principal type not specified.
+	PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED PrincipalType = 0
+	// Individual user account.
+	// ID format: "user:<user_id>" or just "<user_id>"
+	PrincipalType_PRINCIPAL_TYPE_USER PrincipalType = 1
+	// Service account or application.
+	// ID format: "service:<service_name>"
+	PrincipalType_PRINCIPAL_TYPE_SERVICE PrincipalType = 2
+	// Tenant partition.
+	// ID format: "<tenant_id>/<partition_id>" or "tenancy:<tenant_id>/<partition_id>"
+	// All members of the partition get access, following membership changes.
+	// Only partitions of the caller's own tenant can be granted access.
+	PrincipalType_PRINCIPAL_TYPE_ORGANIZATION PrincipalType = 3
+	// Chat group (e.g., Matrix room, Slack channel).
+	// ID format: "room:<room_id>" or "chat:<chat_id>"
+	// All members of the chat group get access.
+	PrincipalType_PRINCIPAL_TYPE_CHAT_GROUP PrincipalType = 4
+	// Group of profiles.
+	// ID format: "<group_id>" or "group:<group_id>"
+	// All members of the group get access, following membership changes.
+	PrincipalType_PRINCIPAL_TYPE_GROUP PrincipalType = 5
+)
+
+// Enum value maps for PrincipalType.
+var (
+	PrincipalType_name = map[int32]string{
+		0: "PRINCIPAL_TYPE_UNSPECIFIED",
+		1: "PRINCIPAL_TYPE_USER",
+		2: "PRINCIPAL_TYPE_SERVICE",
+		3: "PRINCIPAL_TYPE_ORGANIZATION",
+		4: "PRINCIPAL_TYPE_CHAT_GROUP",
+		5: "PRINCIPAL_TYPE_GROUP",
+	}
+	PrincipalType_value = map[string]int32{
+		"PRINCIPAL_TYPE_UNSPECIFIED":  0,
+		"PRINCIPAL_TYPE_USER":         1,
+		"PRINCIPAL_TYPE_SERVICE":      2,
+		"PRINCIPAL_TYPE_ORGANIZATION": 3,
+		"PRINCIPAL_TYPE_CHAT_GROUP":   4,
+		"PRINCIPAL_TYPE_GROUP":        5,
+	}
+)
+
+func (x PrincipalType) Enum() *PrincipalType {
+	p := new(PrincipalType)
+	*p = x
+	return p
+}
+
+func (x PrincipalType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PrincipalType) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[7].Descriptor()
+}
+
+func (PrincipalType) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[7]
+}
+
+func (x PrincipalType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// LifecycleEventType identifies a file lifecycle event.
+//
+// Events are published to the file events queue and delivered to every
+// webhook subscription of the tenant that subscribes to the type.
+type LifecycleEventType int32
+
+const (
+	LifecycleEventType_LIFECYCLE_EVENT_TYPE_UNSPECIFIED LifecycleEventType = 0
+	// Content finished uploading and is available.
+	LifecycleEventType_LIFECYCLE_EVENT_TYPE_UPLOADED LifecycleEventType = 1
+	// Thumbnails were generated for the content.
+	LifecycleEventType_LIFECYCLE_EVENT_TYPE_THUMBNAILED LifecycleEventType = 2
+	// Access was granted to another principal.
+	LifecycleEventType_LIFECYCLE_EVENT_TYPE_SHARED LifecycleEventType = 3
+	// Visibility was changed to public.
+	LifecycleEventType_LIFECYCLE_EVENT_TYPE_MADE_PUBLIC LifecycleEventType = 4
+	// Content was deleted.
+	LifecycleEventType_LIFECYCLE_EVENT_TYPE_DELETED LifecycleEventType = 5
+	// Malware was found in the content, which is no longer served.
+	LifecycleEventType_LIFECYCLE_EVENT_TYPE_QUARANTINED LifecycleEventType = 6
+	// An export of a profile's data finished; the metadata is the archive.
+	LifecycleEventType_LIFECYCLE_EVENT_TYPE_USER_DATA_EXPORTED LifecycleEventType = 7
+)
+
+// Enum value maps for LifecycleEventType.
+var (
+	LifecycleEventType_name = map[int32]string{
+		0: "LIFECYCLE_EVENT_TYPE_UNSPECIFIED",
+		1: "LIFECYCLE_EVENT_TYPE_UPLOADED",
+		2: "LIFECYCLE_EVENT_TYPE_THUMBNAILED",
+		3: "LIFECYCLE_EVENT_TYPE_SHARED",
+		4: "LIFECYCLE_EVENT_TYPE_MADE_PUBLIC",
+		5: "LIFECYCLE_EVENT_TYPE_DELETED",
+		6: "LIFECYCLE_EVENT_TYPE_QUARANTINED",
+		7: "LIFECYCLE_EVENT_TYPE_USER_DATA_EXPORTED",
+	}
+	LifecycleEventType_value = map[string]int32{
+		"LIFECYCLE_EVENT_TYPE_UNSPECIFIED":        0,
+		"LIFECYCLE_EVENT_TYPE_UPLOADED":           1,
+		"LIFECYCLE_EVENT_TYPE_THUMBNAILED":        2,
+		"LIFECYCLE_EVENT_TYPE_SHARED":             3,
+		"LIFECYCLE_EVENT_TYPE_MADE_PUBLIC":        4,
+		"LIFECYCLE_EVENT_TYPE_DELETED":            5,
+		"LIFECYCLE_EVENT_TYPE_QUARANTINED":        6,
+		"LIFECYCLE_EVENT_TYPE_USER_DATA_EXPORTED": 7,
+	}
+)
+
+func (x LifecycleEventType) Enum() *LifecycleEventType {
+	p := new(LifecycleEventType)
+	*p = x
+	return p
+}
+
+func (x LifecycleEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LifecycleEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[8].Descriptor()
+}
+
+func (LifecycleEventType) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[8]
+}
+
+func (x LifecycleEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// WebhookDeliveryState represents the state of a single webhook delivery.
+type WebhookDeliveryState int32
+
+const (
+	WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_UNSPECIFIED WebhookDeliveryState = 0
+	WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_PENDING     WebhookDeliveryState = 1 // Waiting for the first or next attempt
+	WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_DELIVERED   WebhookDeliveryState = 2 // Endpoint answered with a 2xx status
+	WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_DEAD_LETTER WebhookDeliveryState = 3 // Attempts exhausted, no more retries
+)
+
+// Enum value maps for WebhookDeliveryState.
+var (
+	WebhookDeliveryState_name = map[int32]string{
+		0: "WEBHOOK_DELIVERY_STATE_UNSPECIFIED",
+		1: "WEBHOOK_DELIVERY_STATE_PENDING",
+		2: "WEBHOOK_DELIVERY_STATE_DELIVERED",
+		3: "WEBHOOK_DELIVERY_STATE_DEAD_LETTER",
+	}
+	WebhookDeliveryState_value = map[string]int32{
+		"WEBHOOK_DELIVERY_STATE_UNSPECIFIED": 0,
+		"WEBHOOK_DELIVERY_STATE_PENDING":     1,
+		"WEBHOOK_DELIVERY_STATE_DELIVERED":   2,
+		"WEBHOOK_DELIVERY_STATE_DEAD_LETTER": 3,
+	}
+)
+
+func (x WebhookDeliveryState) Enum() *WebhookDeliveryState {
+	p := new(WebhookDeliveryState)
+	*p = x
+	return p
+}
+
+func (x WebhookDeliveryState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookDeliveryState) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[9].Descriptor()
+}
+
+func (WebhookDeliveryState) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[9]
+}
+
+func (x WebhookDeliveryState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// ThumbnailRegenerationState is the state of a bulk thumbnail regeneration run.
+type ThumbnailRegenerationState int32
+
+const (
+	ThumbnailRegenerationState_THUMBNAIL_REGENERATION_STATE_UNSPECIFIED ThumbnailRegenerationState = 0
+	ThumbnailRegenerationState_THUMBNAIL_REGENERATION_STATE_RUNNING     ThumbnailRegenerationState = 1 // Walking media; also left set by an interrupted run
+	ThumbnailRegenerationState_THUMBNAIL_REGENERATION_STATE_COMPLETED   ThumbnailRegenerationState = 2 // All matching media were processed
+	ThumbnailRegenerationState_THUMBNAIL_REGENERATION_STATE_FAILED      ThumbnailRegenerationState = 3 // Stopped on an error; can be resumed
+)
+
+// Enum value maps for ThumbnailRegenerationState.
+var (
+	ThumbnailRegenerationState_name = map[int32]string{
+		0: "THUMBNAIL_REGENERATION_STATE_UNSPECIFIED",
+		1: "THUMBNAIL_REGENERATION_STATE_RUNNING",
+		2: "THUMBNAIL_REGENERATION_STATE_COMPLETED",
+		3: "THUMBNAIL_REGENERATION_STATE_FAILED",
+	}
+	ThumbnailRegenerationState_value = map[string]int32{
+		"THUMBNAIL_REGENERATION_STATE_UNSPECIFIED": 0,
+		"THUMBNAIL_REGENERATION_STATE_RUNNING":     1,
+		"THUMBNAIL_REGENERATION_STATE_COMPLETED":   2,
+		"THUMBNAIL_REGENERATION_STATE_FAILED":      3,
+	}
+)
+
+func (x ThumbnailRegenerationState) Enum() *ThumbnailRegenerationState {
+	p := new(ThumbnailRegenerationState)
+	*p = x
+	return p
+}
+
+func (x ThumbnailRegenerationState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ThumbnailRegenerationState) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[10].Descriptor()
+}
+
+func (ThumbnailRegenerationState) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[10]
+}
+
+func (x ThumbnailRegenerationState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// OwnershipIssueKind is a difference between media records and Keto tuples.
+type OwnershipIssueKind int32
+
+const (
+	OwnershipIssueKind_OWNERSHIP_ISSUE_KIND_UNSPECIFIED          OwnershipIssueKind = 0
+	OwnershipIssueKind_OWNERSHIP_ISSUE_KIND_MISSING_OWNER_TUPLE  OwnershipIssueKind = 1 // The owner of a media has no owner tuple
+	OwnershipIssueKind_OWNERSHIP_ISSUE_KIND_STALE_OWNER_TUPLE    OwnershipIssueKind = 2 // An owner tuple names a profile that neither owns the media nor was granted ownership
+	OwnershipIssueKind_OWNERSHIP_ISSUE_KIND_DELETED_MEDIA_TUPLE  OwnershipIssueKind = 3 // A tuple is left on a deleted media
+	OwnershipIssueKind_OWNERSHIP_ISSUE_KIND_UNKNOWN_SUBJECT      OwnershipIssueKind = 4 // A grant names a group or tenant partition Keto holds no tuples for, or a profile the profile service no longer knows
+	OwnershipIssueKind_OWNERSHIP_ISSUE_KIND_MISSING_PARENT_TUPLE OwnershipIssueKind = 5 // A media filed in a folder is not linked to the owner's folder
+	OwnershipIssueKind_OWNERSHIP_ISSUE_KIND_STALE_PARENT_TUPLE   OwnershipIssueKind = 6 // A media is linked to a folder it is not filed in
+)
+
+// Enum value maps for OwnershipIssueKind.
+var (
+	OwnershipIssueKind_name = map[int32]string{
+		0: "OWNERSHIP_ISSUE_KIND_UNSPECIFIED",
+		1: "OWNERSHIP_ISSUE_KIND_MISSING_OWNER_TUPLE",
+		2: "OWNERSHIP_ISSUE_KIND_STALE_OWNER_TUPLE",
+		3: "OWNERSHIP_ISSUE_KIND_DELETED_MEDIA_TUPLE",
+		4: "OWNERSHIP_ISSUE_KIND_UNKNOWN_SUBJECT",
+		5: "OWNERSHIP_ISSUE_KIND_MISSING_PARENT_TUPLE",
+		6: "OWNERSHIP_ISSUE_KIND_STALE_PARENT_TUPLE",
+	}
+	OwnershipIssueKind_value = map[string]int32{
+		"OWNERSHIP_ISSUE_KIND_UNSPECIFIED":          0,
+		"OWNERSHIP_ISSUE_KIND_MISSING_OWNER_TUPLE":  1,
+		"OWNERSHIP_ISSUE_KIND_STALE_OWNER_TUPLE":    2,
+		"OWNERSHIP_ISSUE_KIND_DELETED_MEDIA_TUPLE":  3,
+		"OWNERSHIP_ISSUE_KIND_UNKNOWN_SUBJECT":      4,
+		"OWNERSHIP_ISSUE_KIND_MISSING_PARENT_TUPLE": 5,
+		"OWNERSHIP_ISSUE_KIND_STALE_PARENT_TUPLE":   6,
+	}
+)
+
+func (x OwnershipIssueKind) Enum() *OwnershipIssueKind {
+	p := new(OwnershipIssueKind)
+	*p = x
+	return p
+}
+
+func (x OwnershipIssueKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OwnershipIssueKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[11].Descriptor()
+}
+
+func (OwnershipIssueKind) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[11]
+}
+
+func (x OwnershipIssueKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// ShareLinkPermission is what the holder of a share link may do.
+type ShareLinkPermission int32
+
+const (
+	ShareLinkPermission_SHARE_LINK_PERMISSION_UNSPECIFIED ShareLinkPermission = 0
+	ShareLinkPermission_SHARE_LINK_PERMISSION_VIEW        ShareLinkPermission = 1 // Preview in the browser; served inline only
+	ShareLinkPermission_SHARE_LINK_PERMISSION_DOWNLOAD    ShareLinkPermission = 2 // Preview and download as an attachment
+)
+
+// Enum value maps for ShareLinkPermission.
+var (
+	ShareLinkPermission_name = map[int32]string{
+		0: "SHARE_LINK_PERMISSION_UNSPECIFIED",
+		1: "SHARE_LINK_PERMISSION_VIEW",
+		2: "SHARE_LINK_PERMISSION_DOWNLOAD",
+	}
+	ShareLinkPermission_value = map[string]int32{
+		"SHARE_LINK_PERMISSION_UNSPECIFIED": 0,
+		"SHARE_LINK_PERMISSION_VIEW":        1,
+		"SHARE_LINK_PERMISSION_DOWNLOAD":    2,
+	}
+)
+
+func (x ShareLinkPermission) Enum() *ShareLinkPermission {
+	p := new(ShareLinkPermission)
+	*p = x
+	return p
+}
+
+func (x ShareLinkPermission) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ShareLinkPermission) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[12].Descriptor()
+}
+
+func (ShareLinkPermission) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[12]
+}
+
+func (x ShareLinkPermission) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// ShareLinkState is whether a share link can still be used.
+type ShareLinkState int32
+
+const (
+	ShareLinkState_SHARE_LINK_STATE_UNSPECIFIED ShareLinkState = 0
+	ShareLinkState_SHARE_LINK_STATE_ACTIVE      ShareLinkState = 1 // Usable
+	ShareLinkState_SHARE_LINK_STATE_EXPIRED     ShareLinkState = 2 // Past its expiry time
+	ShareLinkState_SHARE_LINK_STATE_REVOKED     ShareLinkState = 3 // Revoked by its creator
+	ShareLinkState_SHARE_LINK_STATE_EXHAUSTED   ShareLinkState = 4 // Maximum downloads reached
+)
+
+// Enum value maps for ShareLinkState.
+var (
+	ShareLinkState_name = map[int32]string{
+		0: "SHARE_LINK_STATE_UNSPECIFIED",
+		1: "SHARE_LINK_STATE_ACTIVE",
+		2: "SHARE_LINK_STATE_EXPIRED",
+		3: "SHARE_LINK_STATE_REVOKED",
+		4: "SHARE_LINK_STATE_EXHAUSTED",
+	}
+	ShareLinkState_value = map[string]int32{
+		"SHARE_LINK_STATE_UNSPECIFIED": 0,
+		"SHARE_LINK_STATE_ACTIVE":      1,
+		"SHARE_LINK_STATE_EXPIRED":     2,
+		"SHARE_LINK_STATE_REVOKED":     3,
+		"SHARE_LINK_STATE_EXHAUSTED":   4,
+	}
+)
+
+func (x ShareLinkState) Enum() *ShareLinkState {
+	p := new(ShareLinkState)
+	*p = x
+	return p
+}
+
+func (x ShareLinkState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ShareLinkState) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[13].Descriptor()
+}
+
+func (ShareLinkState) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[13]
+}
+
+func (x ShareLinkState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// ArchiveExtractionState is the state of an archive extraction job.
+type ArchiveExtractionState int32
+
+const (
+	ArchiveExtractionState_ARCHIVE_EXTRACTION_STATE_UNSPECIFIED ArchiveExtractionState = 0
+	ArchiveExtractionState_ARCHIVE_EXTRACTION_STATE_RUNNING     ArchiveExtractionState = 1 // Entries are being extracted
+	ArchiveExtractionState_ARCHIVE_EXTRACTION_STATE_COMPLETED   ArchiveExtractionState = 2 // Every entry was extracted, skipped or failed
+	ArchiveExtractionState_ARCHIVE_EXTRACTION_STATE_FAILED      ArchiveExtractionState = 3 // Stopped early; entries extracted so far are kept
+)
+
+// Enum value maps for ArchiveExtractionState.
+var (
+	ArchiveExtractionState_name = map[int32]string{
+		0: "ARCHIVE_EXTRACTION_STATE_UNSPECIFIED",
+		1: "ARCHIVE_EXTRACTION_STATE_RUNNING",
+		2: "ARCHIVE_EXTRACTION_STATE_COMPLETED",
+		3: "ARCHIVE_EXTRACTION_STATE_FAILED",
+	}
+	ArchiveExtractionState_value = map[string]int32{
+		"ARCHIVE_EXTRACTION_STATE_UNSPECIFIED": 0,
+		"ARCHIVE_EXTRACTION_STATE_RUNNING":     1,
+		"ARCHIVE_EXTRACTION_STATE_COMPLETED":   2,
+		"ARCHIVE_EXTRACTION_STATE_FAILED":      3,
+	}
+)
+
+func (x ArchiveExtractionState) Enum() *ArchiveExtractionState {
+	p := new(ArchiveExtractionState)
+	*p = x
+	return p
+}
+
+func (x ArchiveExtractionState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ArchiveExtractionState) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[14].Descriptor()
+}
+
+func (ArchiveExtractionState) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[14]
+}
+
+func (x ArchiveExtractionState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// UserDataExportState is the state of a user data export job.
+type UserDataExportState int32
+
+const (
+	UserDataExportState_USER_DATA_EXPORT_STATE_UNSPECIFIED UserDataExportState = 0
+	UserDataExportState_USER_DATA_EXPORT_STATE_RUNNING     UserDataExportState = 1 // Files are being packaged
+	UserDataExportState_USER_DATA_EXPORT_STATE_COMPLETED   UserDataExportState = 2 // The archive is stored as result_media_id
+	UserDataExportState_USER_DATA_EXPORT_STATE_FAILED      UserDataExportState = 3 // Stopped early; no archive is kept
+)
+
+// Enum value maps for UserDataExportState.
+var (
+	UserDataExportState_name = map[int32]string{
+		0: "USER_DATA_EXPORT_STATE_UNSPECIFIED",
+		1: "USER_DATA_EXPORT_STATE_RUNNING",
+		2: "USER_DATA_EXPORT_STATE_COMPLETED",
+		3: "USER_DATA_EXPORT_STATE_FAILED",
+	}
+	UserDataExportState_value = map[string]int32{
+		"USER_DATA_EXPORT_STATE_UNSPECIFIED": 0,
+		"USER_DATA_EXPORT_STATE_RUNNING":     1,
+		"USER_DATA_EXPORT_STATE_COMPLETED":   2,
+		"USER_DATA_EXPORT_STATE_FAILED":      3,
+	}
+)
+
+func (x UserDataExportState) Enum() *UserDataExportState {
+	p := new(UserDataExportState)
+	*p = x
+	return p
+}
+
+func (x UserDataExportState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserDataExportState) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[15].Descriptor()
+}
+
+func (UserDataExportState) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[15]
+}
+
+func (x UserDataExportState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// UserDataErasureState is the state of a user data erasure job.
+type UserDataErasureState int32
+
+const (
+	UserDataErasureState_USER_DATA_ERASURE_STATE_UNSPECIFIED UserDataErasureState = 0
+	UserDataErasureState_USER_DATA_ERASURE_STATE_RUNNING     UserDataErasureState = 1 // Data is being erased
+	UserDataErasureState_USER_DATA_ERASURE_STATE_COMPLETED   UserDataErasureState = 2 // Everything not held was erased; the report is signed
+	UserDataErasureState_USER_DATA_ERASURE_STATE_FAILED      UserDataErasureState = 3 // Stopped early; what was erased so far stays erased
+)
+
+// Enum value maps for UserDataErasureState.
+var (
+	UserDataErasureState_name = map[int32]string{
+		0: "USER_DATA_ERASURE_STATE_UNSPECIFIED",
+		1: "USER_DATA_ERASURE_STATE_RUNNING",
+		2: "USER_DATA_ERASURE_STATE_COMPLETED",
+		3: "USER_DATA_ERASURE_STATE_FAILED",
+	}
+	UserDataErasureState_value = map[string]int32{
+		"USER_DATA_ERASURE_STATE_UNSPECIFIED": 0,
+		"USER_DATA_ERASURE_STATE_RUNNING":     1,
+		"USER_DATA_ERASURE_STATE_COMPLETED":   2,
+		"USER_DATA_ERASURE_STATE_FAILED":      3,
+	}
+)
+
+func (x UserDataErasureState) Enum() *UserDataErasureState {
+	p := new(UserDataErasureState)
+	*p = x
+	return p
+}
+
+func (x UserDataErasureState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserDataErasureState) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[16].Descriptor()
+}
+
+func (UserDataErasureState) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[16]
+}
+
+func (x UserDataErasureState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// ThumbnailJobState is the state of the thumbnail generation job of a media item.
+type ThumbnailJobState int32
+
+const (
+	ThumbnailJobState_THUMBNAIL_JOB_STATE_UNSPECIFIED ThumbnailJobState = 0
+	ThumbnailJobState_THUMBNAIL_JOB_STATE_PENDING     ThumbnailJobState = 1 // Waiting for the first or next attempt
+	ThumbnailJobState_THUMBNAIL_JOB_STATE_COMPLETED   ThumbnailJobState = 2 // All configured thumbnails were generated
+	ThumbnailJobState_THUMBNAIL_JOB_STATE_DEAD_LETTER ThumbnailJobState = 3 // Attempts exhausted, no more retries
+)
+
+// Enum value maps for ThumbnailJobState.
+var (
+	ThumbnailJobState_name = map[int32]string{
+		0: "THUMBNAIL_JOB_STATE_UNSPECIFIED",
+		1: "THUMBNAIL_JOB_STATE_PENDING",
+		2: "THUMBNAIL_JOB_STATE_COMPLETED",
+		3: "THUMBNAIL_JOB_STATE_DEAD_LETTER",
+	}
+	ThumbnailJobState_value = map[string]int32{
+		"THUMBNAIL_JOB_STATE_UNSPECIFIED": 0,
+		"THUMBNAIL_JOB_STATE_PENDING":     1,
+		"THUMBNAIL_JOB_STATE_COMPLETED":   2,
+		"THUMBNAIL_JOB_STATE_DEAD_LETTER": 3,
+	}
+)
+
+func (x ThumbnailJobState) Enum() *ThumbnailJobState {
+	p := new(ThumbnailJobState)
+	*p = x
+	return p
+}
+
+func (x ThumbnailJobState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ThumbnailJobState) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[17].Descriptor()
+}
+
+func (ThumbnailJobState) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[17]
+}
+
+func (x ThumbnailJobState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+type MediaMetadata_Visibility int32
+
+const (
+	// Visibility not specified - defaults to PRIVATE.
+	MediaMetadata_VISIBILITY_UNSPECIFIED MediaMetadata_Visibility = 0
+	// Public content: accessible to any authenticated user.
+	// No grants required for read access.
+	// Use for: public assets, marketing materials, shared resources.
+	MediaMetadata_VISIBILITY_PUBLIC MediaMetadata_Visibility = 1
+	// Private content: only accessible to owner and principals with explicit grants.
+	// Default for all uploads. Use for sensitive content.
+	MediaMetadata_VISIBILITY_PRIVATE MediaMetadata_Visibility = 2
+)
+
+// Enum value maps for MediaMetadata_Visibility.
+var (
+	MediaMetadata_Visibility_name = map[int32]string{
+		0: "VISIBILITY_UNSPECIFIED",
+		1: "VISIBILITY_PUBLIC",
+		2: "VISIBILITY_PRIVATE",
+	}
+	MediaMetadata_Visibility_value = map[string]int32{
+		"VISIBILITY_UNSPECIFIED": 0,
+		"VISIBILITY_PUBLIC":      1,
+		"VISIBILITY_PRIVATE":     2,
+	}
+)
+
+func (x MediaMetadata_Visibility) Enum() *MediaMetadata_Visibility {
+	p := new(MediaMetadata_Visibility)
+	*p = x
+	return p
+}
+
+func (x MediaMetadata_Visibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MediaMetadata_Visibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[18].Descriptor()
+}
+
+func (MediaMetadata_Visibility) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[18]
+}
+
+func (x MediaMetadata_Visibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+type SearchMediaRequest_SortBy int32
+
+const (
+	SearchMediaRequest_SORT_BY_UNSPECIFIED SearchMediaRequest_SortBy = 0
+	SearchMediaRequest_SORT_BY_CREATED_AT  SearchMediaRequest_SortBy = 1
+	SearchMediaRequest_SORT_BY_UPDATED_AT  SearchMediaRequest_SortBy = 2
+	SearchMediaRequest_SORT_BY_FILENAME    SearchMediaRequest_SortBy = 3
+	SearchMediaRequest_SORT_BY_FILE_SIZE   SearchMediaRequest_SortBy = 4
+)
+
+// Enum value maps for SearchMediaRequest_SortBy.
+var (
+	SearchMediaRequest_SortBy_name = map[int32]string{
+		0: "SORT_BY_UNSPECIFIED",
+		1: "SORT_BY_CREATED_AT",
+		2: "SORT_BY_UPDATED_AT",
+		3: "SORT_BY_FILENAME",
+		4: "SORT_BY_FILE_SIZE",
+	}
+	SearchMediaRequest_SortBy_value = map[string]int32{
+		"SORT_BY_UNSPECIFIED": 0,
+		"SORT_BY_CREATED_AT":  1,
+		"SORT_BY_UPDATED_AT":  2,
+		"SORT_BY_FILENAME":    3,
+		"SORT_BY_FILE_SIZE":   4,
+	}
+)
+
+func (x SearchMediaRequest_SortBy) Enum() *SearchMediaRequest_SortBy {
+	p := new(SearchMediaRequest_SortBy)
+	*p = x
+	return p
+}
+
+func (x SearchMediaRequest_SortBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchMediaRequest_SortBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[19].Descriptor()
+}
+
+func (SearchMediaRequest_SortBy) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[19]
+}
+
+func (x SearchMediaRequest_SortBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Retention mode.
+// DELETE: remove after retention period
+// ARCHIVE: move to cold storage after period
+type RetentionPolicy_Mode int32
+
+const (
+	RetentionPolicy_MODE_UNSPECIFIED RetentionPolicy_Mode = 0
+	RetentionPolicy_MODE_DELETE      RetentionPolicy_Mode = 1
+	RetentionPolicy_MODE_ARCHIVE     RetentionPolicy_Mode = 2
+)
+
+// Enum value maps for RetentionPolicy_Mode.
+var (
+	RetentionPolicy_Mode_name = map[int32]string{
+		0: "MODE_UNSPECIFIED",
+		1: "MODE_DELETE",
+		2: "MODE_ARCHIVE",
+	}
+	RetentionPolicy_Mode_value = map[string]int32{
+		"MODE_UNSPECIFIED": 0,
+		"MODE_DELETE":      1,
+		"MODE_ARCHIVE":     2,
+	}
+)
+
+func (x RetentionPolicy_Mode) Enum() *RetentionPolicy_Mode {
+	p := new(RetentionPolicy_Mode)
+	*p = x
+	return p
+}
+
+func (x RetentionPolicy_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RetentionPolicy_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_files_v1_files_proto_enumTypes[20].Descriptor()
+}
+
+func (RetentionPolicy_Mode) Type() protoreflect.EnumType {
+	return &file_files_v1_files_proto_enumTypes[20]
+}
+
+func (x RetentionPolicy_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// MediaMetadata represents the complete metadata for an uploaded file.
+//
+// This is the authoritative source of truth for file attributes. Clients
+// should always fetch fresh metadata before performing operations that
+// depend on current state (e.g., checking visibility before download).
+//
+// Thread Safety: Metadata may be updated concurrently. Use etag for
+// optimistic locking when patching.
+type MediaMetadata struct {
+	state                          protoimpl.MessageState   `protogen:"opaque.v1"`
+	xxx_hidden_MediaId             string                   `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_ContentType         string                   `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3"`
+	xxx_hidden_FileSizeBytes       int64                    `protobuf:"varint,3,opt,name=file_size_bytes,json=fileSizeBytes,proto3"`
+	xxx_hidden_CreatedAt           *timestamppb.Timestamp   `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3"`
+	xxx_hidden_UpdatedAt           *timestamppb.Timestamp   `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3"`
+	xxx_hidden_Filename            string                   `protobuf:"bytes,6,opt,name=filename,proto3"`
+	xxx_hidden_ChecksumSha256      string                   `protobuf:"bytes,7,opt,name=checksum_sha256,json=checksumSha256,proto3"`
+	xxx_hidden_Visibility          MediaMetadata_Visibility `protobuf:"varint,9,opt,name=visibility,proto3,enum=files.v1.MediaMetadata_Visibility"`
+	xxx_hidden_Extra               *structpb.Struct         `protobuf:"bytes,10,opt,name=extra,proto3"`
+	xxx_hidden_ExpiresAt           *timestamppb.Timestamp   `protobuf:"bytes,11,opt,name=expires_at,json=expiresAt,proto3"`
+	xxx_hidden_Version             int64                    `protobuf:"varint,12,opt,name=version,proto3"`
+	xxx_hidden_IsLatest            bool                     `protobuf:"varint,13,opt,name=is_latest,json=isLatest,proto3"`
+	xxx_hidden_State               MediaState               `protobuf:"varint,14,opt,name=state,proto3,enum=files.v1.MediaState"`
+	xxx_hidden_Etag                string                   `protobuf:"bytes,15,opt,name=etag,proto3"`
+	xxx_hidden_ScanStatus          ScanStatus               `protobuf:"varint,30,opt,name=scan_status,json=scanStatus,proto3,enum=files.v1.ScanStatus"`
+	xxx_hidden_ArchivedAt          *timestamppb.Timestamp   `protobuf:"bytes,31,opt,name=archived_at,json=archivedAt,proto3"`
+	xxx_hidden_DeletedAt           *timestamppb.Timestamp   `protobuf:"bytes,32,opt,name=deleted_at,json=deletedAt,proto3"`
+	xxx_hidden_Labels              map[string]string        `protobuf:"bytes,20,rep,name=labels,proto3" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_ContentUri          string                   `protobuf:"bytes,21,opt,name=content_uri,json=contentUri,proto3"`
+	xxx_hidden_OrganizationId      string                   `protobuf:"bytes,22,opt,name=organization_id,json=organizationId,proto3"`
+	xxx_hidden_Properties          *MediaProperties         `protobuf:"bytes,23,opt,name=properties,proto3"`
+	xxx_hidden_Blurhash            string                   `protobuf:"bytes,24,opt,name=blurhash,proto3"`
+	xxx_hidden_DominantColor       string                   `protobuf:"bytes,25,opt,name=dominant_color,json=dominantColor,proto3"`
+	xxx_hidden_DeclaredContentType string                   `protobuf:"bytes,26,opt,name=declared_content_type,json=declaredContentType,proto3"`
+	xxx_hidden_Folder              string                   `protobuf:"bytes,33,opt,name=folder,proto3"`
+	unknownFields                  protoimpl.UnknownFields
+	sizeCache                      protoimpl.SizeCache
+}
+
+func (x *MediaMetadata) Reset() {
+	*x = MediaMetadata{}
+	mi := &file_files_v1_files_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaMetadata) ProtoMessage() {}
+
+func (x *MediaMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *MediaMetadata) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *MediaMetadata) GetContentType() string {
+	if x != nil {
+		return x.xxx_hidden_ContentType
+	}
+	return ""
+}
+
+func (x *MediaMetadata) GetFileSizeBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_FileSizeBytes
+	}
+	return 0
+}
+
+func (x *MediaMetadata) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *MediaMetadata) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_UpdatedAt
+	}
+	return nil
+}
+
+func (x *MediaMetadata) GetFilename() string {
+	if x != nil {
+		return x.xxx_hidden_Filename
+	}
+	return ""
+}
+
+func (x *MediaMetadata) GetChecksumSha256() string {
+	if x != nil {
+		return x.xxx_hidden_ChecksumSha256
+	}
+	return ""
+}
+
+func (x *MediaMetadata) GetVisibility() MediaMetadata_Visibility {
+	if x != nil {
+		return x.xxx_hidden_Visibility
+	}
+	return MediaMetadata_VISIBILITY_UNSPECIFIED
+}
+
+func (x *MediaMetadata) GetExtra() *structpb.Struct {
+	if x != nil {
+		return x.xxx_hidden_Extra
+	}
+	return nil
+}
+
+func (x *MediaMetadata) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *MediaMetadata) GetVersion() int64 {
+	if x != nil {
+		return x.xxx_hidden_Version
+	}
+	return 0
+}
+
+func (x *MediaMetadata) GetIsLatest() bool {
+	if x != nil {
+		return x.xxx_hidden_IsLatest
+	}
+	return false
+}
+
+func (x *MediaMetadata) GetState() MediaState {
+	if x != nil {
+		return x.xxx_hidden_State
+	}
+	return MediaState_MEDIA_STATE_UNSPECIFIED
+}
+
+func (x *MediaMetadata) GetEtag() string {
+	if x != nil {
+		return x.xxx_hidden_Etag
+	}
+	return ""
+}
+
+func (x *MediaMetadata) GetScanStatus() ScanStatus {
+	if x != nil {
+		return x.xxx_hidden_ScanStatus
+	}
+	return ScanStatus_SCAN_STATUS_UNSPECIFIED
+}
+
+func (x *MediaMetadata) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ArchivedAt
+	}
+	return nil
+}
+
+func (x *MediaMetadata) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_DeletedAt
+	}
+	return nil
+}
+
+func (x *MediaMetadata) GetLabels() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Labels
+	}
+	return nil
+}
+
+func (x *MediaMetadata) GetContentUri() string {
+	if x != nil {
+		return x.xxx_hidden_ContentUri
+	}
+	return ""
+}
+
+func (x *MediaMetadata) GetOrganizationId() string {
+	if x != nil {
+		return x.xxx_hidden_OrganizationId
+	}
+	return ""
+}
+
+func (x *MediaMetadata) GetProperties() *MediaProperties {
+	if x != nil {
+		return x.xxx_hidden_Properties
+	}
+	return nil
+}
+
+func (x *MediaMetadata) GetBlurhash() string {
+	if x != nil {
+		return x.xxx_hidden_Blurhash
+	}
+	return ""
+}
+
+func (x *MediaMetadata) GetDominantColor() string {
+	if x != nil {
+		return x.xxx_hidden_DominantColor
+	}
+	return ""
+}
+
+func (x *MediaMetadata) GetDeclaredContentType() string {
+	if x != nil {
+		return x.xxx_hidden_DeclaredContentType
+	}
+	return ""
+}
+
+func (x *MediaMetadata) GetFolder() string {
+	if x != nil {
+		return x.xxx_hidden_Folder
+	}
+	return ""
+}
+
+func (x *MediaMetadata) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *MediaMetadata) SetContentType(v string) {
+	x.xxx_hidden_ContentType = v
+}
+
+func (x *MediaMetadata) SetFileSizeBytes(v int64) {
+	x.xxx_hidden_FileSizeBytes = v
+}
+
+func (x *MediaMetadata) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *MediaMetadata) SetUpdatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_UpdatedAt = v
+}
+
+func (x *MediaMetadata) SetFilename(v string) {
+	x.xxx_hidden_Filename = v
+}
+
+func (x *MediaMetadata) SetChecksumSha256(v string) {
+	x.xxx_hidden_ChecksumSha256 = v
+}
+
+func (x *MediaMetadata) SetVisibility(v MediaMetadata_Visibility) {
+	x.xxx_hidden_Visibility = v
+}
+
+func (x *MediaMetadata) SetExtra(v *structpb.Struct) {
+	x.xxx_hidden_Extra = v
+}
+
+func (x *MediaMetadata) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *MediaMetadata) SetVersion(v int64) {
+	x.xxx_hidden_Version = v
+}
+
+func (x *MediaMetadata) SetIsLatest(v bool) {
+	x.xxx_hidden_IsLatest = v
+}
+
+func (x *MediaMetadata) SetState(v MediaState) {
+	x.xxx_hidden_State = v
+}
+
+func (x *MediaMetadata) SetEtag(v string) {
+	x.xxx_hidden_Etag = v
+}
+
+func (x *MediaMetadata) SetScanStatus(v ScanStatus) {
+	x.xxx_hidden_ScanStatus = v
+}
+
+func (x *MediaMetadata) SetArchivedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ArchivedAt = v
+}
+
+func (x *MediaMetadata) SetDeletedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_DeletedAt = v
+}
+
+func (x *MediaMetadata) SetLabels(v map[string]string) {
+	x.xxx_hidden_Labels = v
+}
+
+func (x *MediaMetadata) SetContentUri(v string) {
+	x.xxx_hidden_ContentUri = v
+}
+
+func (x *MediaMetadata) SetOrganizationId(v string) {
+	x.xxx_hidden_OrganizationId = v
+}
+
+func (x *MediaMetadata) SetProperties(v *MediaProperties) {
+	x.xxx_hidden_Properties = v
+}
+
+func (x *MediaMetadata) SetBlurhash(v string) {
+	x.xxx_hidden_Blurhash = v
+}
+
+func (x *MediaMetadata) SetDominantColor(v string) {
+	x.xxx_hidden_DominantColor = v
+}
+
+func (x *MediaMetadata) SetDeclaredContentType(v string) {
+	x.xxx_hidden_DeclaredContentType = v
+}
+
+func (x *MediaMetadata) SetFolder(v string) {
+	x.xxx_hidden_Folder = v
+}
+
+func (x *MediaMetadata) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *MediaMetadata) HasUpdatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_UpdatedAt != nil
+}
+
+func (x *MediaMetadata) HasExtra() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Extra != nil
+}
+
+func (x *MediaMetadata) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *MediaMetadata) HasArchivedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ArchivedAt != nil
+}
+
+func (x *MediaMetadata) HasDeletedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_DeletedAt != nil
+}
+
+func (x *MediaMetadata) HasProperties() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Properties != nil
+}
+
+func (x *MediaMetadata) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+func (x *MediaMetadata) ClearUpdatedAt() {
+	x.xxx_hidden_UpdatedAt = nil
+}
+
+func (x *MediaMetadata) ClearExtra() {
+	x.xxx_hidden_Extra = nil
+}
+
+func (x *MediaMetadata) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+func (x *MediaMetadata) ClearArchivedAt() {
+	x.xxx_hidden_ArchivedAt = nil
+}
+
+func (x *MediaMetadata) ClearDeletedAt() {
+	x.xxx_hidden_DeletedAt = nil
+}
+
+func (x *MediaMetadata) ClearProperties() {
+	x.xxx_hidden_Properties = nil
+}
+
+type MediaMetadata_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Unique identifier for this media.
+	// Format: alphanumeric with hyphens/underscores, 3-40 characters.
+	// Example: "abc123xyz"
+	MediaId string
+	// MIME type of the content.
+	// Examples: "image/png", "application/pdf", "video/mp4"
+	// Detected from file content during upload; may be overridden.
+	ContentType string
+	// Size of the file in bytes.
+	// For available content, this is the actual stored size.
+	// For creating state, this may be the expected size from upload metadata.
+	FileSizeBytes int64
+	// Timestamp when the media was first created (upload initiated).
+	// Set by server; cannot be modified by clients.
+	CreatedAt *timestamppb.Timestamp
+	// Timestamp when metadata was last modified.
+	// Updated on any metadata change (rename, visibility change, etc.)
+	UpdatedAt *timestamppb.Timestamp
+	// Original filename as uploaded by the client.
+	// May contain Unicode characters. Used for Content-Disposition headers.
+	// Maximum recommended length: 255 bytes (filesystem compatibility).
+	Filename string
+	// SHA-256 checksum of the file content.
+	// Format: 64 lowercase hexadecimal characters.
+	// Computed server-side from stored content; clients can verify
+	// during upload using UploadMetadata.checksum_sha256.
+	ChecksumSha256 string
+	// Visibility determines who can access this content without explicit grant.
+	//
+	// - PUBLIC: Accessible to any authenticated user within the namespace.
+	//           No access grant required.
+	// - PRIVATE: Only accessible to owner and principals with explicit grants.
+	//            Default for all uploads.
+	Visibility MediaMetadata_Visibility
+	// Additional metadata as key-value pairs.
+	// This is for system-level metadata (e.g., image dimensions, video duration).
+	// For user-defined metadata, use labels instead.
+	// Maximum size: 64KB when serialized as JSON.
+	Extra *structpb.Struct
+	// Expiration timestamp after which content may be automatically deleted.
+	// Null/empty means no expiration. Set by owner or by retention policy.
+	// After expiration, content enters DELETED state.
+	ExpiresAt *timestamppb.Timestamp
+	// Current version number for versioned content.
+	// Starts at 1 and increments on each new version upload.
+	// Use GetVersions to retrieve version history.
+	Version int64
+	// Whether this metadata entry represents the latest version.
+	// True for the current version; false for historical versions.
+	// When listing versions, only the latest entry has is_latest=true.
+	IsLatest bool
+	// Current lifecycle state of the media.
+	// Controls whether content is available for download.
+	// See MediaState enum for valid transitions.
+	State MediaState
+	// ETag for optimistic concurrency control.
+	// Send this value in If-Match header for conditional operations.
+	// Changes on every metadata modification.
+	Etag string
+	// Antivirus/antimalware scan status.
+	// Content may be blocked from download if scan is not CLEAN.
+	// See ScanStatus for details.
+	ScanStatus ScanStatus
+	// Timestamp when content was archived (null if not archived).
+	ArchivedAt *timestamppb.Timestamp
+	// Timestamp when content was soft-deleted (null if not deleted).
+	DeletedAt *timestamppb.Timestamp
+	// User-defined labels for organization and search.
+	// These are MUTABLE and user-controlled.
+	//
+	// IMPORTANT: Labels are NOT security boundaries. They are for:
+	//   - Project organization (e.g., project_id, department)
+	//   - Categorization (e.g., document_type, tags)
+	//   - Search/filtering (e.g., status, year)
+	//
+	// Example usage:
+	//   labels["project"] = "billing-service"
+	//   labels["room_id"] = "matrix:!abc123"
+	//   labels["folder"] = "invoices/2026"
+	//
+	// Constraints:
+	//   - Maximum 50 labels per media
+	//   - Key length: 1-128 characters
+	//   - Value length: 0-1024 characters
+	//   - Keys must be valid UTF-8 strings
+	Labels map[string]string
+	// Full content URI for this content.
+	// Format: https://<server_name>/v1/media/download/<server_name>/<media_id>
+	// Example: https://cdn.example.com/v1/media/download/cdn.example.com/abc123xyz
+	ContentUri string
+	// Organization or group ID this media is associated with.
+	//
+	// This is metadata for organization/filtering purposes only.
+	// It does NOT control access - use AccessGrant for access control.
+	//
+	// Format:
+	//   - Organization: "org:<org_id>" (e.g., "org:acme-corp")
+	//   - Chat Group: "room:<room_id>" (e.g., "room:!abc123:matrix.org")
+	//                  "chat:<chat_id>" (e.g., "chat:C1234567890")
+	//
+	// Use this for:
+	//   - Search/filtering by organization or chat group
+	//   - UI organization display
+	//   - Audit trails
+	//
+	// For access control, use GrantAccess with principal_type ORGANIZATION or CHAT_GROUP.
+	OrganizationId string
+	// Intrinsic properties read from the content at upload, such as image
+	// dimensions, media duration and codecs, or a document's page count.
+	// Unset when nothing could be extracted.
+	Properties *MediaProperties
+	// BlurHash of the image, for rendering a placeholder while thumbnails
+	// load. Computed with the thumbnails; empty for other media and until
+	// thumbnailing completes. See https://blurha.sh.
+	Blurhash string
+	// Most common color of the image as #rrggbb, for a solid placeholder.
+	// Set alongside blurhash.
+	DominantColor string
+	// Content type the client declared when the server detected a different
+	// one from the content and stored that instead. Empty when the declared
+	// type was kept.
+	DeclaredContentType string
+	// Slash separated folder path the content is filed under, such as
+	// "invoices/2026". Empty for content at the root.
+	Folder string
+}
+
+func (b0 MediaMetadata_builder) Build() *MediaMetadata {
+	m0 := &MediaMetadata{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_ContentType = b.ContentType
+	x.xxx_hidden_FileSizeBytes = b.FileSizeBytes
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	x.xxx_hidden_UpdatedAt = b.UpdatedAt
+	x.xxx_hidden_Filename = b.Filename
+	x.xxx_hidden_ChecksumSha256 = b.ChecksumSha256
+	x.xxx_hidden_Visibility = b.Visibility
+	x.xxx_hidden_Extra = b.Extra
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	x.xxx_hidden_Version = b.Version
+	x.xxx_hidden_IsLatest = b.IsLatest
+	x.xxx_hidden_State = b.State
+	x.xxx_hidden_Etag = b.Etag
+	x.xxx_hidden_ScanStatus = b.ScanStatus
+	x.xxx_hidden_ArchivedAt = b.ArchivedAt
+	x.xxx_hidden_DeletedAt = b.DeletedAt
+	x.xxx_hidden_Labels = b.Labels
+	x.xxx_hidden_ContentUri = b.ContentUri
+	x.xxx_hidden_OrganizationId = b.OrganizationId
+	x.xxx_hidden_Properties = b.Properties
+	x.xxx_hidden_Blurhash = b.Blurhash
+	x.xxx_hidden_DominantColor = b.DominantColor
+	x.xxx_hidden_DeclaredContentType = b.DeclaredContentType
+	x.xxx_hidden_Folder = b.Folder
+	return m0
+}
+
+// MediaProperties describes the content itself, as read from its headers at
+// upload. Fields that do not apply to the kind of media are left unset.
+type MediaProperties struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Format     string                 `protobuf:"bytes,1,opt,name=format,proto3"`
+	xxx_hidden_Width      int32                  `protobuf:"varint,2,opt,name=width,proto3"`
+	xxx_hidden_Height     int32                  `protobuf:"varint,3,opt,name=height,proto3"`
+	xxx_hidden_ColorSpace string                 `protobuf:"bytes,4,opt,name=color_space,json=colorSpace,proto3"`
+	xxx_hidden_DurationMs int64                  `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3"`
+	xxx_hidden_Codecs     []string               `protobuf:"bytes,6,rep,name=codecs,proto3"`
+	xxx_hidden_PageCount  int32                  `protobuf:"varint,7,opt,name=page_count,json=pageCount,proto3"`
+	xxx_hidden_Title      string                 `protobuf:"bytes,8,opt,name=title,proto3"`
+	xxx_hidden_Exif       map[string]string      `protobuf:"bytes,9,rep,name=exif,proto3" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *MediaProperties) Reset() {
+	*x = MediaProperties{}
+	mi := &file_files_v1_files_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaProperties) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaProperties) ProtoMessage() {}
+
+func (x *MediaProperties) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *MediaProperties) GetFormat() string {
+	if x != nil {
+		return x.xxx_hidden_Format
+	}
+	return ""
+}
+
+func (x *MediaProperties) GetWidth() int32 {
+	if x != nil {
+		return x.xxx_hidden_Width
+	}
+	return 0
+}
+
+func (x *MediaProperties) GetHeight() int32 {
+	if x != nil {
+		return x.xxx_hidden_Height
+	}
+	return 0
+}
+
+func (x *MediaProperties) GetColorSpace() string {
+	if x != nil {
+		return x.xxx_hidden_ColorSpace
+	}
+	return ""
+}
+
+func (x *MediaProperties) GetDurationMs() int64 {
+	if x != nil {
+		return x.xxx_hidden_DurationMs
+	}
+	return 0
+}
+
+func (x *MediaProperties) GetCodecs() []string {
+	if x != nil {
+		return x.xxx_hidden_Codecs
+	}
+	return nil
+}
+
+func (x *MediaProperties) GetPageCount() int32 {
+	if x != nil {
+		return x.xxx_hidden_PageCount
+	}
+	return 0
+}
+
+func (x *MediaProperties) GetTitle() string {
+	if x != nil {
+		return x.xxx_hidden_Title
+	}
+	return ""
+}
+
+func (x *MediaProperties) GetExif() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Exif
+	}
+	return nil
+}
+
+func (x *MediaProperties) SetFormat(v string) {
+	x.xxx_hidden_Format = v
+}
+
+func (x *MediaProperties) SetWidth(v int32) {
+	x.xxx_hidden_Width = v
+}
+
+func (x *MediaProperties) SetHeight(v int32) {
+	x.xxx_hidden_Height = v
+}
+
+func (x *MediaProperties) SetColorSpace(v string) {
+	x.xxx_hidden_ColorSpace = v
+}
+
+func (x *MediaProperties) SetDurationMs(v int64) {
+	x.xxx_hidden_DurationMs = v
+}
+
+func (x *MediaProperties) SetCodecs(v []string) {
+	x.xxx_hidden_Codecs = v
+}
+
+func (x *MediaProperties) SetPageCount(v int32) {
+	x.xxx_hidden_PageCount = v
+}
+
+func (x *MediaProperties) SetTitle(v string) {
+	x.xxx_hidden_Title = v
+}
+
+func (x *MediaProperties) SetExif(v map[string]string) {
+	x.xxx_hidden_Exif = v
+}
+
+type MediaProperties_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Detected container format.
+	// Examples: "jpeg", "png", "gif", "webp", "mp4", "webm", "mp3", "pdf"
+	Format string
+	// Width and height in pixels of an image or of the first video track.
+	Width  int32
+	Height int32
+	// Colour model of an image.
+	// Examples: "rgb", "rgba", "gray", "cmyk", "indexed"
+	ColorSpace string
+	// Playback duration of audio or video content in milliseconds.
+	DurationMs int64
+	// Codecs of the audio and video tracks, in track order.
+	// Examples: "avc1", "mp4a", "vp9", "opus", "mp3"
+	Codecs []string
+	// Number of pages of a document.
+	PageCount int32
+	// Document title from its metadata.
+	Title string
+	// EXIF fields of an image, present when the tenant keeps a copy of the
+	// metadata removed during upload sanitization.
+	// Keys: make, model, software, date_time, date_time_original, lens_make,
+	// lens_model, serial_number, orientation, gps_latitude, gps_longitude,
+	// gps_altitude. The gps_* keys are only returned to the media owner.
+	Exif map[string]string
+}
+
+func (b0 MediaProperties_builder) Build() *MediaProperties {
+	m0 := &MediaProperties{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Format = b.Format
+	x.xxx_hidden_Width = b.Width
+	x.xxx_hidden_Height = b.Height
+	x.xxx_hidden_ColorSpace = b.ColorSpace
+	x.xxx_hidden_DurationMs = b.DurationMs
+	x.xxx_hidden_Codecs = b.Codecs
+	x.xxx_hidden_PageCount = b.PageCount
+	x.xxx_hidden_Title = b.Title
+	x.xxx_hidden_Exif = b.Exif
+	return m0
+}
+
+// AccessGrant represents a grant of access to a principal for media.
+//
+// Access grants are media-scoped. Each grant defines
+// WHO (principal_id) gets WHAT role (role) for specific content.
+//
+// The principal type determines how membership is resolved:
+//   - USER: Direct user access
+//   - ORGANIZATION: All members of the tenant partition get access
+//   - GROUP: All members of the group get access
+//
+// SERVICE and CHAT_GROUP principals are not yet accepted.
+//
+// Ownership: The original uploader automatically receives OWNER role
+// and cannot be revoked. This ensures content always has an owner.
+//
+// Best Practices:
+//   - Use service accounts for application access (WRITER role)
+//   - Use organizations/chat groups for team access management
+//   - Audit grants periodically for stale access
+type AccessGrant struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_PrincipalId   string                 `protobuf:"bytes,1,opt,name=principal_id,json=principalId,proto3"`
+	xxx_hidden_PrincipalType PrincipalType          `protobuf:"varint,6,opt,name=principal_type,json=principalType,proto3,enum=files.v1.PrincipalType"`
+	xxx_hidden_Role          AccessRole             `protobuf:"varint,2,opt,name=role,proto3,enum=files.v1.AccessRole"`
+	xxx_hidden_GrantedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=granted_at,json=grantedAt,proto3"`
+	xxx_hidden_GrantedBy     string                 `protobuf:"bytes,4,opt,name=granted_by,json=grantedBy,proto3"`
+	xxx_hidden_ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3"`
+	xxx_hidden_InheritedFrom string                 `protobuf:"bytes,7,opt,name=inherited_from,json=inheritedFrom,proto3"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *AccessGrant) Reset() {
+	*x = AccessGrant{}
+	mi := &file_files_v1_files_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessGrant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessGrant) ProtoMessage() {}
+
+func (x *AccessGrant) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AccessGrant) GetPrincipalId() string {
+	if x != nil {
+		return x.xxx_hidden_PrincipalId
+	}
+	return ""
+}
+
+func (x *AccessGrant) GetPrincipalType() PrincipalType {
+	if x != nil {
+		return x.xxx_hidden_PrincipalType
+	}
+	return PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED
+}
+
+func (x *AccessGrant) GetRole() AccessRole {
+	if x != nil {
+		return x.xxx_hidden_Role
+	}
+	return AccessRole_ACCESS_ROLE_UNSPECIFIED
+}
+
+func (x *AccessGrant) GetGrantedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_GrantedAt
+	}
+	return nil
+}
+
+func (x *AccessGrant) GetGrantedBy() string {
+	if x != nil {
+		return x.xxx_hidden_GrantedBy
+	}
+	return ""
+}
+
+func (x *AccessGrant) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *AccessGrant) GetInheritedFrom() string {
+	if x != nil {
+		return x.xxx_hidden_InheritedFrom
+	}
+	return ""
+}
+
+func (x *AccessGrant) SetPrincipalId(v string) {
+	x.xxx_hidden_PrincipalId = v
+}
+
+func (x *AccessGrant) SetPrincipalType(v PrincipalType) {
+	x.xxx_hidden_PrincipalType = v
+}
+
+func (x *AccessGrant) SetRole(v AccessRole) {
+	x.xxx_hidden_Role = v
+}
+
+func (x *AccessGrant) SetGrantedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_GrantedAt = v
+}
+
+func (x *AccessGrant) SetGrantedBy(v string) {
+	x.xxx_hidden_GrantedBy = v
+}
+
+func (x *AccessGrant) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *AccessGrant) SetInheritedFrom(v string) {
+	x.xxx_hidden_InheritedFrom = v
+}
+
+func (x *AccessGrant) HasGrantedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_GrantedAt != nil
+}
+
+func (x *AccessGrant) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *AccessGrant) ClearGrantedAt() {
+	x.xxx_hidden_GrantedAt = nil
+}
+
+func (x *AccessGrant) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+type AccessGrant_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Principal ID receiving access.
+	// Format depends on principal_type:
+	//   - User: "user:<user_id>" or just "<user_id>"
+	//   - Organization: "tenancy:<tenant_id>/<partition_id>" or just "<tenant_id>/<partition_id>"
+	//   - Group: "group:<group_id>" or just "<group_id>"
+	PrincipalId string
+	// Type of principal. Helps the system resolve membership correctly.
+	// If not specified, the server infers from principal_id format.
+	PrincipalType PrincipalType
+	// Role to assign to the principal.
+	// See AccessRole for permission matrix.
+	Role AccessRole
+	// Timestamp when this grant was created.
+	GrantedAt *timestamppb.Timestamp
+	// ID of the principal who created this grant.
+	// Null if created by system (e.g., owner upload).
+	GrantedBy string
+	// Optional expiry for time-limited access.
+	// Null means permanent grant until manually revoked.
+	// Useful for temporary access sharing. An expired grant is denied at once
+	// and revoked automatically shortly after; it must be in the future when
+	// granted.
+	ExpiresAt *timestamppb.Timestamp
+	// Folder the grant is inherited from, when it was made on a folder the
+	// file or folder is filed under rather than on the object itself.
+	// Empty for direct grants.
+	InheritedFrom string
+}
+
+func (b0 AccessGrant_builder) Build() *AccessGrant {
+	m0 := &AccessGrant{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_PrincipalId = b.PrincipalId
+	x.xxx_hidden_PrincipalType = b.PrincipalType
+	x.xxx_hidden_Role = b.Role
+	x.xxx_hidden_GrantedAt = b.GrantedAt
+	x.xxx_hidden_GrantedBy = b.GrantedBy
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	x.xxx_hidden_InheritedFrom = b.InheritedFrom
+	return m0
+}
+
+// UploadMetadata contains metadata for file upload.
+// This must be sent as the first message in the upload stream.
+//
+// Usage Patterns:
+//
+// Pattern 1: New Upload
+//
+//	Send metadata (without server_name/media_id), then chunks.
+//	Server generates new media_id and returns complete content URI.
+//
+// Pattern 2: Upload to Pre-created URI
+//
+//	First call CreateContent to reserve a URI, then:
+//	- Set server_name and media_id from the created URI
+//	- Send chunks to that specific URI
+//	This is useful for:
+//	- Resumable uploads (persist URI across sessions)
+//	- Deferred uploads (get URI early, upload later)
+//	- Client-side parallel uploads
+//
+// Pattern 3: Version Creation
+//
+//	Set base_version to the current version number.
+//	Server creates new version if latest_version == base_version.
+//	Returns conflict error if versions don't match.
+type UploadMetadata struct {
+	state                     protoimpl.MessageState   `protogen:"opaque.v1"`
+	xxx_hidden_ContentType    string                   `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3"`
+	xxx_hidden_Filename       string                   `protobuf:"bytes,2,opt,name=filename,proto3"`
+	xxx_hidden_TotalSize      int64                    `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3"`
+	xxx_hidden_Properties     *structpb.Struct         `protobuf:"bytes,4,opt,name=properties,proto3"`
+	xxx_hidden_Visibility     MediaMetadata_Visibility `protobuf:"varint,6,opt,name=visibility,proto3,enum=files.v1.MediaMetadata_Visibility"`
+	xxx_hidden_OrganizationId string                   `protobuf:"bytes,23,opt,name=organization_id,json=organizationId,proto3"`
+	xxx_hidden_ExpiresAt      *timestamppb.Timestamp   `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3"`
+	xxx_hidden_ServerName     string                   `protobuf:"bytes,8,opt,name=server_name,json=serverName,proto3"`
+	xxx_hidden_Purpose        string                   `protobuf:"bytes,10,opt,name=purpose,proto3"`
+	xxx_hidden_Folder         string                   `protobuf:"bytes,11,opt,name=folder,proto3"`
+	xxx_hidden_MediaId        string                   `protobuf:"bytes,9,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_ChecksumSha256 string                   `protobuf:"bytes,16,opt,name=checksum_sha256,json=checksumSha256,proto3"`
+	xxx_hidden_BaseVersion    int64                    `protobuf:"varint,17,opt,name=base_version,json=baseVersion,proto3"`
+	xxx_hidden_Labels         map[string]string        `protobuf:"bytes,20,rep,name=labels,proto3" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_AccessorId     []string                 `protobuf:"bytes,15,rep,name=accessor_id,json=accessorId,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *UploadMetadata) Reset() {
+	*x = UploadMetadata{}
+	mi := &file_files_v1_files_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadMetadata) ProtoMessage() {}
+
+func (x *UploadMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UploadMetadata) GetContentType() string {
+	if x != nil {
+		return x.xxx_hidden_ContentType
+	}
+	return ""
+}
+
+func (x *UploadMetadata) GetFilename() string {
+	if x != nil {
+		return x.xxx_hidden_Filename
+	}
+	return ""
+}
+
+func (x *UploadMetadata) GetTotalSize() int64 {
+	if x != nil {
+		return x.xxx_hidden_TotalSize
+	}
+	return 0
+}
+
+func (x *UploadMetadata) GetProperties() *structpb.Struct {
+	if x != nil {
+		return x.xxx_hidden_Properties
+	}
+	return nil
+}
+
+func (x *UploadMetadata) GetVisibility() MediaMetadata_Visibility {
+	if x != nil {
+		return x.xxx_hidden_Visibility
+	}
+	return MediaMetadata_VISIBILITY_UNSPECIFIED
+}
+
+func (x *UploadMetadata) GetOrganizationId() string {
+	if x != nil {
+		return x.xxx_hidden_OrganizationId
+	}
+	return ""
+}
+
+func (x *UploadMetadata) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *UploadMetadata) GetServerName() string {
+	if x != nil {
+		return x.xxx_hidden_ServerName
+	}
+	return ""
+}
+
+func (x *UploadMetadata) GetPurpose() string {
+	if x != nil {
+		return x.xxx_hidden_Purpose
+	}
+	return ""
+}
+
+func (x *UploadMetadata) GetFolder() string {
+	if x != nil {
+		return x.xxx_hidden_Folder
+	}
+	return ""
+}
+
+func (x *UploadMetadata) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *UploadMetadata) GetChecksumSha256() string {
+	if x != nil {
+		return x.xxx_hidden_ChecksumSha256
+	}
+	return ""
+}
+
+func (x *UploadMetadata) GetBaseVersion() int64 {
+	if x != nil {
+		return x.xxx_hidden_BaseVersion
+	}
+	return 0
+}
+
+func (x *UploadMetadata) GetLabels() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Labels
+	}
+	return nil
+}
+
+func (x *UploadMetadata) GetAccessorId() []string {
+	if x != nil {
+		return x.xxx_hidden_AccessorId
+	}
+	return nil
+}
+
+func (x *UploadMetadata) SetContentType(v string) {
+	x.xxx_hidden_ContentType = v
+}
+
+func (x *UploadMetadata) SetFilename(v string) {
+	x.xxx_hidden_Filename = v
+}
+
+func (x *UploadMetadata) SetTotalSize(v int64) {
+	x.xxx_hidden_TotalSize = v
+}
+
+func (x *UploadMetadata) SetProperties(v *structpb.Struct) {
+	x.xxx_hidden_Properties = v
+}
+
+func (x *UploadMetadata) SetVisibility(v MediaMetadata_Visibility) {
+	x.xxx_hidden_Visibility = v
+}
+
+func (x *UploadMetadata) SetOrganizationId(v string) {
+	x.xxx_hidden_OrganizationId = v
+}
+
+func (x *UploadMetadata) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *UploadMetadata) SetServerName(v string) {
+	x.xxx_hidden_ServerName = v
+}
+
+func (x *UploadMetadata) SetPurpose(v string) {
+	x.xxx_hidden_Purpose = v
+}
+
+func (x *UploadMetadata) SetFolder(v string) {
+	x.xxx_hidden_Folder = v
+}
+
+func (x *UploadMetadata) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *UploadMetadata) SetChecksumSha256(v string) {
+	x.xxx_hidden_ChecksumSha256 = v
+}
+
+func (x *UploadMetadata) SetBaseVersion(v int64) {
+	x.xxx_hidden_BaseVersion = v
+}
+
+func (x *UploadMetadata) SetLabels(v map[string]string) {
+	x.xxx_hidden_Labels = v
+}
+
+func (x *UploadMetadata) SetAccessorId(v []string) {
+	x.xxx_hidden_AccessorId = v
+}
+
+func (x *UploadMetadata) HasProperties() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Properties != nil
+}
+
+func (x *UploadMetadata) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *UploadMetadata) ClearProperties() {
+	x.xxx_hidden_Properties = nil
+}
+
+func (x *UploadMetadata) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+type UploadMetadata_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// MIME type of the content.
+	// Detected from the content if not specified.
+	// Server may override based on content detection.
+	ContentType string
+	// Original filename.
+	// Used for Content-Disposition header on download.
+	// Maximum recommended: 255 bytes.
+	Filename string
+	// Total size of the file in bytes.
+	// Optional but recommended for:
+	//   - Progress tracking on client
+	//   - Storage quota validation
+	//   - Multipart upload chunking
+	TotalSize int64
+	// Additional properties for the upload.
+	// Use for file-specific metadata (e.g., image dimensions).
+	// System fields like "width", "height" are recognized.
+	Properties *structpb.Struct
+	// Initial visibility setting for the content.
+	// Defaults to PRIVATE if not specified.
+	// See MediaMetadata.Visibility for details.
+	Visibility MediaMetadata_Visibility
+	// Organization or group ID for metadata purposes.
+	//
+	// This is optional metadata for organization/filtering.
+	// For access control, use GrantAccess after upload.
+	//
+	// Format:
+	//   - Organization: "org:<org_id>" (e.g., "org:acme-corp")
+	//   - Chat Group: "room:<room_id>" or "chat:<chat_id>"
+	OrganizationId string
+	// Expiration timestamp for the content.
+	// After expiration, content may be automatically deleted.
+	// Null/empty means no expiration.
+	ExpiresAt *timestamppb.Timestamp
+	// Server name from pre-created content URI.
+	// Format: "cdn.example.com"
+	// Required for Pattern 2 (upload to pre-created URI).
+	ServerName string
+	// What the upload is for, e.g. "avatar" or "attachment".
+	// Selects the tenant's content type rules for the purpose; uploads without
+	// a purpose follow the tenant's general rules.
+	Purpose string
+	// Folder to file the upload under, as a slash separated path such as
+	// "invoices/2026". Empty files the upload at the root.
+	Folder string
+	// Media ID from pre-created content URI.
+	// Format: "abc123"
+	// Must match pattern [0-9a-z_-]{3,40}
+	// Required for Pattern 2 (upload to pre-created URI).
+	MediaId string
+	// SHA-256 checksum for integrity verification.
+	// Format: 64 lowercase hexadecimal characters.
+	//
+	// If provided, server verifies uploaded content matches this checksum.
+	// Mismatch causes upload failure.
+	//
+	// Recommended for:
+	//   - Large files where re-upload is expensive
+	//   - Integrity-critical content
+	//   - Multipart uploads (required at completion)
+	ChecksumSha256 string
+	// Base version for optimistic concurrency.
+	//
+	// If provided and media_id exists:
+	//   - Creates new version if latest_version == base_version
+	//   - Returns ALREADY_EXISTS error if versions differ
+	//
+	// This prevents lost updates when multiple clients upload
+	// to the same media_id concurrently.
+	//
+	// Example:
+	//   Current version: 3
+	//   base_version: 3 -> Creates version 4
+	//   base_version: 2 -> Returns error (conflict)
+	//   base_version: (empty) -> Creates version 4 (no check)
+	BaseVersion int64
+	// User-defined labels for organization.
+	// See MediaMetadata.labels for constraints.
+	//
+	// Can be updated later via PatchContent.
+	Labels map[string]string
+	// Convenience field: principals to grant initial access.
+	//
+	// These are converted to AccessGrant entries during upload.
+	// Each accessor_id is granted READER role by default.
+	//
+	// This is a convenience for common use cases. For fine-grained
+	// access control, use GrantAccess after upload.
+	//
+	// Format: same as AccessGrant.principal_id
+	AccessorId []string
+}
+
+func (b0 UploadMetadata_builder) Build() *UploadMetadata {
+	m0 := &UploadMetadata{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_ContentType = b.ContentType
+	x.xxx_hidden_Filename = b.Filename
+	x.xxx_hidden_TotalSize = b.TotalSize
+	x.xxx_hidden_Properties = b.Properties
+	x.xxx_hidden_Visibility = b.Visibility
+	x.xxx_hidden_OrganizationId = b.OrganizationId
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	x.xxx_hidden_ServerName = b.ServerName
+	x.xxx_hidden_Purpose = b.Purpose
+	x.xxx_hidden_Folder = b.Folder
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_ChecksumSha256 = b.ChecksumSha256
+	x.xxx_hidden_BaseVersion = b.BaseVersion
+	x.xxx_hidden_Labels = b.Labels
+	x.xxx_hidden_AccessorId = b.AccessorId
+	return m0
+}
+
+// UploadContentRequest uploads content via streaming.
+//
+// Stream Format:
+//  1. First message: data with metadata field populated
+//  2. Subsequent messages: data with chunk field populated
+//  3. Client sends end-of-stream (closes stream)
+//
+// Chunk Size:
+//
+//	Recommended: 64KB - 1MB per chunk
+//	Maximum: 4MB per chunk
+//	Server buffers chunks in memory; very large chunks may cause OOM.
+//
+// Timeouts:
+//   - Overall upload: configurable via metadata.timeout_ms
+//   - Per-chunk: TCP keepalive handles this
+//
+// Idempotency:
+//
+//	If network fails mid-upload, client can retry with same idempotency_key.
+//	Server stores upload state and allows resume.
+type UploadContentRequest struct {
+	state                     protoimpl.MessageState      `protogen:"opaque.v1"`
+	xxx_hidden_Data           isUploadContentRequest_Data `protobuf_oneof:"data"`
+	xxx_hidden_IdempotencyKey string                      `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *UploadContentRequest) Reset() {
+	*x = UploadContentRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadContentRequest) ProtoMessage() {}
+
+func (x *UploadContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UploadContentRequest) GetMetadata() *UploadMetadata {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Data.(*uploadContentRequest_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *UploadContentRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Data.(*uploadContentRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+func (x *UploadContentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *UploadContentRequest) SetMetadata(v *UploadMetadata) {
+	if v == nil {
+		x.xxx_hidden_Data = nil
+		return
+	}
+	x.xxx_hidden_Data = &uploadContentRequest_Metadata{v}
+}
+
+func (x *UploadContentRequest) SetChunk(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Data = &uploadContentRequest_Chunk{v}
+}
+
+func (x *UploadContentRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+func (x *UploadContentRequest) HasData() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Data != nil
+}
+
+func (x *UploadContentRequest) HasMetadata() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Data.(*uploadContentRequest_Metadata)
+	return ok
+}
+
+func (x *UploadContentRequest) HasChunk() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Data.(*uploadContentRequest_Chunk)
+	return ok
+}
+
+func (x *UploadContentRequest) ClearData() {
+	x.xxx_hidden_Data = nil
+}
+
+func (x *UploadContentRequest) ClearMetadata() {
+	if _, ok := x.xxx_hidden_Data.(*uploadContentRequest_Metadata); ok {
+		x.xxx_hidden_Data = nil
+	}
+}
+
+func (x *UploadContentRequest) ClearChunk() {
+	if _, ok := x.xxx_hidden_Data.(*uploadContentRequest_Chunk); ok {
+		x.xxx_hidden_Data = nil
+	}
+}
+
+const UploadContentRequest_Data_not_set_case case_UploadContentRequest_Data = 0
+const UploadContentRequest_Metadata_case case_UploadContentRequest_Data = 1
+const UploadContentRequest_Chunk_case case_UploadContentRequest_Data = 2
+
+func (x *UploadContentRequest) WhichData() case_UploadContentRequest_Data {
+	if x == nil {
+		return UploadContentRequest_Data_not_set_case
+	}
+	switch x.xxx_hidden_Data.(type) {
+	case *uploadContentRequest_Metadata:
+		return UploadContentRequest_Metadata_case
+	case *uploadContentRequest_Chunk:
+		return UploadContentRequest_Chunk_case
+	default:
+		return UploadContentRequest_Data_not_set_case
+	}
+}
+
+type UploadContentRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Fields of oneof xxx_hidden_Data:
+	// Metadata: MUST be the first message in the stream.
+	// Contains file properties, labels, access settings.
+	Metadata *UploadMetadata
+	// Chunk: binary content data.
+	// Can be repeated multiple times.
+	// Server concatenates chunks in order received.
+	Chunk []byte
+	// -- end of xxx_hidden_Data
+	// Idempotency key for this upload operation.
+	//
+	// If provided, allows safe retry on network failure.
+	// Same key with same metadata creates same media_id.
+	//
+	// Recommended: Use UUID or similar high-entropy key.
+	// Key is scoped to the owner.
+	//
+	// TTL: 24 hours from first request.
+	IdempotencyKey string
+}
+
+func (b0 UploadContentRequest_builder) Build() *UploadContentRequest {
+	m0 := &UploadContentRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Metadata != nil {
+		x.xxx_hidden_Data = &uploadContentRequest_Metadata{b.Metadata}
+	}
+	if b.Chunk != nil {
+		x.xxx_hidden_Data = &uploadContentRequest_Chunk{b.Chunk}
+	}
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type case_UploadContentRequest_Data protoreflect.FieldNumber
+
+func (x case_UploadContentRequest_Data) String() string {
+	md := file_files_v1_files_proto_msgTypes[4].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isUploadContentRequest_Data interface {
+	isUploadContentRequest_Data()
+}
+
+type uploadContentRequest_Metadata struct {
+	// Metadata: MUST be the first message in the stream.
+	// Contains file properties, labels, access settings.
+	Metadata *UploadMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type uploadContentRequest_Chunk struct {
+	// Chunk: binary content data.
+	// Can be repeated multiple times.
+	// Server concatenates chunks in order received.
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*uploadContentRequest_Metadata) isUploadContentRequest_Data() {}
+
+func (*uploadContentRequest_Chunk) isUploadContentRequest_Data() {}
+
+// UploadContentResponse contains the result of a successful upload.
+type UploadContentResponse struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ContentUri string                 `protobuf:"bytes,1,opt,name=content_uri,json=contentUri,proto3"`
+	xxx_hidden_MediaId    string                 `protobuf:"bytes,2,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_ServerName string                 `protobuf:"bytes,3,opt,name=server_name,json=serverName,proto3"`
+	xxx_hidden_Metadata   *MediaMetadata         `protobuf:"bytes,4,opt,name=metadata,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *UploadContentResponse) Reset() {
+	*x = UploadContentResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadContentResponse) ProtoMessage() {}
+
+func (x *UploadContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UploadContentResponse) GetContentUri() string {
+	if x != nil {
+		return x.xxx_hidden_ContentUri
+	}
+	return ""
+}
+
+func (x *UploadContentResponse) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *UploadContentResponse) GetServerName() string {
+	if x != nil {
+		return x.xxx_hidden_ServerName
+	}
+	return ""
+}
+
+func (x *UploadContentResponse) GetMetadata() *MediaMetadata {
+	if x != nil {
+		return x.xxx_hidden_Metadata
+	}
+	return nil
+}
+
+func (x *UploadContentResponse) SetContentUri(v string) {
+	x.xxx_hidden_ContentUri = v
+}
+
+func (x *UploadContentResponse) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *UploadContentResponse) SetServerName(v string) {
+	x.xxx_hidden_ServerName = v
+}
+
+func (x *UploadContentResponse) SetMetadata(v *MediaMetadata) {
+	x.xxx_hidden_Metadata = v
+}
+
+func (x *UploadContentResponse) HasMetadata() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Metadata != nil
+}
+
+func (x *UploadContentResponse) ClearMetadata() {
+	x.xxx_hidden_Metadata = nil
+}
+
+type UploadContentResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Full content URI for the uploaded content.
+	// Format: https://<server>/v1/media/download/<server_name>/<media_id>
+	// Example: https://cdn.example.com/v1/media/download/cdn.example.com/abc123xyz
+	ContentUri string
+	// Extracted media ID from the URI.
+	// Shortcut for parsing content_uri.
+	MediaId string
+	// Extracted server name from the URI.
+	// Shortcut for parsing content_uri.
+	ServerName string
+	// Complete metadata for the uploaded content.
+	// Includes all server-generated fields (created_at, etag, etc.)
+	Metadata *MediaMetadata
+}
+
+func (b0 UploadContentResponse_builder) Build() *UploadContentResponse {
+	m0 := &UploadContentResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_ContentUri = b.ContentUri
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_ServerName = b.ServerName
+	x.xxx_hidden_Metadata = b.Metadata
+	return m0
+}
+
+// CreateContentRequest creates a new content URI without uploading content.
+//
+// This reserves a URI that can be used later for actual upload.
+// The URI remains reserved until content is uploaded or expires.
+//
+// Use Cases:
+//  1. Pre-allocation: Get URI before upload (e.g., for database records)
+//  2. Deferred upload: Get URI now, upload later
+//  3. Resumable: Store URI, allow client to resume interrupted upload
+//
+// Expiration:
+//
+//	Unused URIs expire after configurable period (default: 24 hours).
+//	After expiration, the media_id is no longer valid for upload.
+type CreateContentRequest struct {
+	state                     protoimpl.MessageState   `protogen:"opaque.v1"`
+	xxx_hidden_ContentType    string                   `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3"`
+	xxx_hidden_Filename       string                   `protobuf:"bytes,2,opt,name=filename,proto3"`
+	xxx_hidden_Visibility     MediaMetadata_Visibility `protobuf:"varint,3,opt,name=visibility,proto3,enum=files.v1.MediaMetadata_Visibility"`
+	xxx_hidden_ExpiresAt      *timestamppb.Timestamp   `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3"`
+	xxx_hidden_Labels         map[string]string        `protobuf:"bytes,5,rep,name=labels,proto3" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_AccessorId     []string                 `protobuf:"bytes,6,rep,name=accessor_id,json=accessorId,proto3"`
+	xxx_hidden_OrganizationId string                   `protobuf:"bytes,7,opt,name=organization_id,json=organizationId,proto3"`
+	xxx_hidden_IdempotencyKey string                   `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *CreateContentRequest) Reset() {
+	*x = CreateContentRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateContentRequest) ProtoMessage() {}
+
+func (x *CreateContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateContentRequest) GetContentType() string {
+	if x != nil {
+		return x.xxx_hidden_ContentType
+	}
+	return ""
+}
+
+func (x *CreateContentRequest) GetFilename() string {
+	if x != nil {
+		return x.xxx_hidden_Filename
+	}
+	return ""
+}
+
+func (x *CreateContentRequest) GetVisibility() MediaMetadata_Visibility {
+	if x != nil {
+		return x.xxx_hidden_Visibility
+	}
+	return MediaMetadata_VISIBILITY_UNSPECIFIED
+}
+
+func (x *CreateContentRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateContentRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Labels
+	}
+	return nil
+}
+
+func (x *CreateContentRequest) GetAccessorId() []string {
+	if x != nil {
+		return x.xxx_hidden_AccessorId
+	}
+	return nil
+}
+
+func (x *CreateContentRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.xxx_hidden_OrganizationId
+	}
+	return ""
+}
+
+func (x *CreateContentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateContentRequest) SetContentType(v string) {
+	x.xxx_hidden_ContentType = v
+}
+
+func (x *CreateContentRequest) SetFilename(v string) {
+	x.xxx_hidden_Filename = v
+}
+
+func (x *CreateContentRequest) SetVisibility(v MediaMetadata_Visibility) {
+	x.xxx_hidden_Visibility = v
+}
+
+func (x *CreateContentRequest) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *CreateContentRequest) SetLabels(v map[string]string) {
+	x.xxx_hidden_Labels = v
+}
+
+func (x *CreateContentRequest) SetAccessorId(v []string) {
+	x.xxx_hidden_AccessorId = v
+}
+
+func (x *CreateContentRequest) SetOrganizationId(v string) {
+	x.xxx_hidden_OrganizationId = v
+}
+
+func (x *CreateContentRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+func (x *CreateContentRequest) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *CreateContentRequest) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+type CreateContentRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Expected MIME type of content to be uploaded.
+	// Helps with content-type detection validation.
+	ContentType string
+	// Expected filename for the content.
+	// Used for Content-Disposition header.
+	Filename string
+	// Initial visibility setting.
+	// Defaults to PRIVATE.
+	Visibility MediaMetadata_Visibility
+	// Optional expiration for the pre-created URI.
+	// If unused until this time, URI becomes invalid.
+	// Server enforces max expiration (e.g., 7 days).
+	ExpiresAt *timestamppb.Timestamp
+	// User-defined labels for organization.
+	Labels map[string]string
+	// Principals to grant initial READER access.
+	AccessorId []string
+	// Organization or group ID for metadata purposes.
+	// Optional - for organization/filtering, not access control.
+	OrganizationId string
+	// Idempotency key for URI creation.
+	// Same key always returns same URI within TTL.
+	IdempotencyKey string
+}
+
+func (b0 CreateContentRequest_builder) Build() *CreateContentRequest {
+	m0 := &CreateContentRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_ContentType = b.ContentType
+	x.xxx_hidden_Filename = b.Filename
+	x.xxx_hidden_Visibility = b.Visibility
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	x.xxx_hidden_Labels = b.Labels
+	x.xxx_hidden_AccessorId = b.AccessorId
+	x.xxx_hidden_OrganizationId = b.OrganizationId
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+// CreateContentResponse contains the pre-created content URI.
+type CreateContentResponse struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ContentUri string                 `protobuf:"bytes,1,opt,name=content_uri,json=contentUri,proto3"`
+	xxx_hidden_ExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3"`
+	xxx_hidden_MediaId    string                 `protobuf:"bytes,3,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_ServerName string                 `protobuf:"bytes,4,opt,name=server_name,json=serverName,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CreateContentResponse) Reset() {
+	*x = CreateContentResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateContentResponse) ProtoMessage() {}
+
+func (x *CreateContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateContentResponse) GetContentUri() string {
+	if x != nil {
+		return x.xxx_hidden_ContentUri
+	}
+	return ""
+}
+
+func (x *CreateContentResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateContentResponse) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *CreateContentResponse) GetServerName() string {
+	if x != nil {
+		return x.xxx_hidden_ServerName
+	}
+	return ""
+}
+
+func (x *CreateContentResponse) SetContentUri(v string) {
+	x.xxx_hidden_ContentUri = v
+}
+
+func (x *CreateContentResponse) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *CreateContentResponse) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *CreateContentResponse) SetServerName(v string) {
+	x.xxx_hidden_ServerName = v
+}
+
+func (x *CreateContentResponse) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *CreateContentResponse) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+type CreateContentResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Full content URI for future upload.
+	// Use server_name + media_id in UploadMetadata to upload.
+	ContentUri string
+	// Timestamp when this URI expires if unused.
+	ExpiresAt *timestamppb.Timestamp
+	// Media ID component of the URI.
+	MediaId string
+	// Server name component of the URI.
+	ServerName string
+}
+
+func (b0 CreateContentResponse_builder) Build() *CreateContentResponse {
+	m0 := &CreateContentResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_ContentUri = b.ContentUri
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_ServerName = b.ServerName
+	return m0
+}
+
+type CreateMultipartUploadRequest struct {
+	state                     protoimpl.MessageState   `protogen:"opaque.v1"`
+	xxx_hidden_Filename       string                   `protobuf:"bytes,1,opt,name=filename,proto3"`
+	xxx_hidden_ContentType    string                   `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3"`
+	xxx_hidden_TotalSize      int64                    `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3"`
+	xxx_hidden_Visibility     MediaMetadata_Visibility `protobuf:"varint,4,opt,name=visibility,proto3,enum=files.v1.MediaMetadata_Visibility"`
+	xxx_hidden_ExpiresAt      *timestamppb.Timestamp   `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3"`
+	xxx_hidden_Labels         map[string]string        `protobuf:"bytes,6,rep,name=labels,proto3" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_AccessorId     []string                 `protobuf:"bytes,7,rep,name=accessor_id,json=accessorId,proto3"`
+	xxx_hidden_OrganizationId string                   `protobuf:"bytes,8,opt,name=organization_id,json=organizationId,proto3"`
+	xxx_hidden_IdempotencyKey string                   `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *CreateMultipartUploadRequest) Reset() {
+	*x = CreateMultipartUploadRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMultipartUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMultipartUploadRequest) ProtoMessage() {}
+
+func (x *CreateMultipartUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateMultipartUploadRequest) GetFilename() string {
+	if x != nil {
+		return x.xxx_hidden_Filename
+	}
+	return ""
+}
+
+func (x *CreateMultipartUploadRequest) GetContentType() string {
+	if x != nil {
+		return x.xxx_hidden_ContentType
+	}
+	return ""
+}
+
+func (x *CreateMultipartUploadRequest) GetTotalSize() int64 {
+	if x != nil {
+		return x.xxx_hidden_TotalSize
+	}
+	return 0
+}
+
+func (x *CreateMultipartUploadRequest) GetVisibility() MediaMetadata_Visibility {
+	if x != nil {
+		return x.xxx_hidden_Visibility
+	}
+	return MediaMetadata_VISIBILITY_UNSPECIFIED
+}
+
+func (x *CreateMultipartUploadRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateMultipartUploadRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Labels
+	}
+	return nil
+}
+
+func (x *CreateMultipartUploadRequest) GetAccessorId() []string {
+	if x != nil {
+		return x.xxx_hidden_AccessorId
+	}
+	return nil
+}
+
+func (x *CreateMultipartUploadRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.xxx_hidden_OrganizationId
+	}
+	return ""
+}
+
+func (x *CreateMultipartUploadRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateMultipartUploadRequest) SetFilename(v string) {
+	x.xxx_hidden_Filename = v
+}
+
+func (x *CreateMultipartUploadRequest) SetContentType(v string) {
+	x.xxx_hidden_ContentType = v
+}
+
+func (x *CreateMultipartUploadRequest) SetTotalSize(v int64) {
+	x.xxx_hidden_TotalSize = v
+}
+
+func (x *CreateMultipartUploadRequest) SetVisibility(v MediaMetadata_Visibility) {
+	x.xxx_hidden_Visibility = v
+}
+
+func (x *CreateMultipartUploadRequest) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *CreateMultipartUploadRequest) SetLabels(v map[string]string) {
+	x.xxx_hidden_Labels = v
+}
+
+func (x *CreateMultipartUploadRequest) SetAccessorId(v []string) {
+	x.xxx_hidden_AccessorId = v
+}
+
+func (x *CreateMultipartUploadRequest) SetOrganizationId(v string) {
+	x.xxx_hidden_OrganizationId = v
+}
+
+func (x *CreateMultipartUploadRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+func (x *CreateMultipartUploadRequest) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *CreateMultipartUploadRequest) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+type CreateMultipartUploadRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Original filename for the final file.
+	Filename string
+	// Expected MIME type.
+	ContentType string
+	// Total size of the complete file in bytes.
+	// Used for progress tracking and validation.
+	TotalSize int64
+	// Initial visibility.
+	Visibility MediaMetadata_Visibility
+	// Expiration timestamp for the media after upload completes.
+	ExpiresAt *timestamppb.Timestamp
+	// User-defined labels for organization.
+	Labels map[string]string
+	// Principals to grant initial READER access.
+	AccessorId []string
+	// Organization or group ID for metadata purposes.
+	// Optional - for organization/filtering, not access control.
+	OrganizationId string
+	// Idempotency key for the entire multipart operation.
+	IdempotencyKey string
+}
+
+func (b0 CreateMultipartUploadRequest_builder) Build() *CreateMultipartUploadRequest {
+	m0 := &CreateMultipartUploadRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Filename = b.Filename
+	x.xxx_hidden_ContentType = b.ContentType
+	x.xxx_hidden_TotalSize = b.TotalSize
+	x.xxx_hidden_Visibility = b.Visibility
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	x.xxx_hidden_Labels = b.Labels
+	x.xxx_hidden_AccessorId = b.AccessorId
+	x.xxx_hidden_OrganizationId = b.OrganizationId
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type CreateMultipartUploadResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_UploadId string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CreateMultipartUploadResponse) Reset() {
+	*x = CreateMultipartUploadResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMultipartUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMultipartUploadResponse) ProtoMessage() {}
+
+func (x *CreateMultipartUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateMultipartUploadResponse) GetUploadId() string {
+	if x != nil {
+		return x.xxx_hidden_UploadId
+	}
+	return ""
+}
+
+func (x *CreateMultipartUploadResponse) SetUploadId(v string) {
+	x.xxx_hidden_UploadId = v
+}
+
+type CreateMultipartUploadResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Opaque upload ID for this multipart session.
+	// Required for all subsequent operations on this upload.
+	UploadId string
+}
+
+func (b0 CreateMultipartUploadResponse_builder) Build() *CreateMultipartUploadResponse {
+	m0 := &CreateMultipartUploadResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_UploadId = b.UploadId
+	return m0
+}
+
+type UploadMultipartPartRequest struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_UploadId   string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3"`
+	xxx_hidden_PartNumber int32                  `protobuf:"varint,2,opt,name=part_number,json=partNumber,proto3"`
+	xxx_hidden_Content    []byte                 `protobuf:"bytes,3,opt,name=content,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *UploadMultipartPartRequest) Reset() {
+	*x = UploadMultipartPartRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadMultipartPartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadMultipartPartRequest) ProtoMessage() {}
+
+func (x *UploadMultipartPartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UploadMultipartPartRequest) GetUploadId() string {
+	if x != nil {
+		return x.xxx_hidden_UploadId
+	}
+	return ""
+}
+
+func (x *UploadMultipartPartRequest) GetPartNumber() int32 {
+	if x != nil {
+		return x.xxx_hidden_PartNumber
+	}
+	return 0
+}
+
+func (x *UploadMultipartPartRequest) GetContent() []byte {
+	if x != nil {
+		return x.xxx_hidden_Content
+	}
+	return nil
+}
+
+func (x *UploadMultipartPartRequest) SetUploadId(v string) {
+	x.xxx_hidden_UploadId = v
+}
+
+func (x *UploadMultipartPartRequest) SetPartNumber(v int32) {
+	x.xxx_hidden_PartNumber = v
+}
+
+func (x *UploadMultipartPartRequest) SetContent(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Content = v
+}
+
+type UploadMultipartPartRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Upload ID from CreateMultipartUploadResponse.
+	UploadId string
+	// Part number (1-based).
+	// Must be unique within the upload.
+	// Can be uploaded in any order (server assembles).
+	PartNumber int32
+	// Content of this part.
+	// Recommended size: 5MB - 100MB for efficiency.
+	Content []byte
+}
+
+func (b0 UploadMultipartPartRequest_builder) Build() *UploadMultipartPartRequest {
+	m0 := &UploadMultipartPartRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_UploadId = b.UploadId
+	x.xxx_hidden_PartNumber = b.PartNumber
+	x.xxx_hidden_Content = b.Content
+	return m0
+}
+
+type UploadMultipartPartResponse struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Etag       string                 `protobuf:"bytes,1,opt,name=etag,proto3"`
+	xxx_hidden_PartNumber int32                  `protobuf:"varint,2,opt,name=part_number,json=partNumber,proto3"`
+	xxx_hidden_Size       int64                  `protobuf:"varint,3,opt,name=size,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *UploadMultipartPartResponse) Reset() {
+	*x = UploadMultipartPartResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadMultipartPartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadMultipartPartResponse) ProtoMessage() {}
+
+func (x *UploadMultipartPartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UploadMultipartPartResponse) GetEtag() string {
+	if x != nil {
+		return x.xxx_hidden_Etag
+	}
+	return ""
+}
+
+func (x *UploadMultipartPartResponse) GetPartNumber() int32 {
+	if x != nil {
+		return x.xxx_hidden_PartNumber
+	}
+	return 0
+}
+
+func (x *UploadMultipartPartResponse) GetSize() int64 {
+	if x != nil {
+		return x.xxx_hidden_Size
+	}
+	return 0
+}
+
+func (x *UploadMultipartPartResponse) SetEtag(v string) {
+	x.xxx_hidden_Etag = v
+}
+
+func (x *UploadMultipartPartResponse) SetPartNumber(v int32) {
+	x.xxx_hidden_PartNumber = v
+}
+
+func (x *UploadMultipartPartResponse) SetSize(v int64) {
+	x.xxx_hidden_Size = v
+}
+
+type UploadMultipartPartResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// ETag for this uploaded part.
+	// Required when completing the upload.
+	// Format: "<md5>:<part_number>" or opaque string.
+	Etag string
+	// Part number (echoed from request).
+	PartNumber int32
+	// Size of the uploaded part in bytes.
+	Size int64
+}
+
+func (b0 UploadMultipartPartResponse_builder) Build() *UploadMultipartPartResponse {
+	m0 := &UploadMultipartPartResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Etag = b.Etag
+	x.xxx_hidden_PartNumber = b.PartNumber
+	x.xxx_hidden_Size = b.Size
+	return m0
+}
+
+type CompleteMultipartUploadRequest struct {
+	state                     protoimpl.MessageState                  `protogen:"opaque.v1"`
+	xxx_hidden_UploadId       string                                  `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3"`
+	xxx_hidden_ChecksumSha256 string                                  `protobuf:"bytes,2,opt,name=checksum_sha256,json=checksumSha256,proto3"`
+	xxx_hidden_Parts          *[]*CompleteMultipartUploadRequest_Part `protobuf:"bytes,3,rep,name=parts,proto3"`
+	xxx_hidden_IdempotencyKey string                                  `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *CompleteMultipartUploadRequest) Reset() {
+	*x = CompleteMultipartUploadRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteMultipartUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteMultipartUploadRequest) ProtoMessage() {}
+
+func (x *CompleteMultipartUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CompleteMultipartUploadRequest) GetUploadId() string {
+	if x != nil {
+		return x.xxx_hidden_UploadId
+	}
+	return ""
+}
+
+func (x *CompleteMultipartUploadRequest) GetChecksumSha256() string {
+	if x != nil {
+		return x.xxx_hidden_ChecksumSha256
+	}
+	return ""
+}
+
+func (x *CompleteMultipartUploadRequest) GetParts() []*CompleteMultipartUploadRequest_Part {
+	if x != nil {
+		if x.xxx_hidden_Parts != nil {
+			return *x.xxx_hidden_Parts
+		}
+	}
+	return nil
+}
+
+func (x *CompleteMultipartUploadRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CompleteMultipartUploadRequest) SetUploadId(v string) {
+	x.xxx_hidden_UploadId = v
+}
+
+func (x *CompleteMultipartUploadRequest) SetChecksumSha256(v string) {
+	x.xxx_hidden_ChecksumSha256 = v
+}
+
+func (x *CompleteMultipartUploadRequest) SetParts(v []*CompleteMultipartUploadRequest_Part) {
+	x.xxx_hidden_Parts = &v
+}
+
+func (x *CompleteMultipartUploadRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+type CompleteMultipartUploadRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Upload ID from CreateMultipartUploadResponse.
+	UploadId string
+	// SHA-256 checksum of the COMPLETE file (all parts combined).
+	// Required for integrity verification.
+	ChecksumSha256 string
+	Parts          []*CompleteMultipartUploadRequest_Part
+	// Idempotency key for completion.
+	IdempotencyKey string
+}
+
+func (b0 CompleteMultipartUploadRequest_builder) Build() *CompleteMultipartUploadRequest {
+	m0 := &CompleteMultipartUploadRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_UploadId = b.UploadId
+	x.xxx_hidden_ChecksumSha256 = b.ChecksumSha256
+	x.xxx_hidden_Parts = &b.Parts
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type CompleteMultipartUploadResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Metadata *MediaMetadata         `protobuf:"bytes,1,opt,name=metadata,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CompleteMultipartUploadResponse) Reset() {
+	*x = CompleteMultipartUploadResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteMultipartUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteMultipartUploadResponse) ProtoMessage() {}
+
+func (x *CompleteMultipartUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CompleteMultipartUploadResponse) GetMetadata() *MediaMetadata {
+	if x != nil {
+		return x.xxx_hidden_Metadata
+	}
+	return nil
+}
+
+func (x *CompleteMultipartUploadResponse) SetMetadata(v *MediaMetadata) {
+	x.xxx_hidden_Metadata = v
+}
+
+func (x *CompleteMultipartUploadResponse) HasMetadata() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Metadata != nil
+}
+
+func (x *CompleteMultipartUploadResponse) ClearMetadata() {
+	x.xxx_hidden_Metadata = nil
+}
+
+type CompleteMultipartUploadResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Final metadata for the uploaded media.
+	Metadata *MediaMetadata
+}
+
+func (b0 CompleteMultipartUploadResponse_builder) Build() *CompleteMultipartUploadResponse {
+	m0 := &CompleteMultipartUploadResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Metadata = b.Metadata
+	return m0
+}
+
+type AbortMultipartUploadRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_UploadId       string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3"`
+	xxx_hidden_IdempotencyKey string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *AbortMultipartUploadRequest) Reset() {
+	*x = AbortMultipartUploadRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortMultipartUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortMultipartUploadRequest) ProtoMessage() {}
+
+func (x *AbortMultipartUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AbortMultipartUploadRequest) GetUploadId() string {
+	if x != nil {
+		return x.xxx_hidden_UploadId
+	}
+	return ""
+}
+
+func (x *AbortMultipartUploadRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *AbortMultipartUploadRequest) SetUploadId(v string) {
+	x.xxx_hidden_UploadId = v
+}
+
+func (x *AbortMultipartUploadRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+type AbortMultipartUploadRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Upload ID to abort.
+	UploadId string
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 AbortMultipartUploadRequest_builder) Build() *AbortMultipartUploadRequest {
+	m0 := &AbortMultipartUploadRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_UploadId = b.UploadId
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type AbortMultipartUploadResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Aborted bool                   `protobuf:"varint,1,opt,name=aborted,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AbortMultipartUploadResponse) Reset() {
+	*x = AbortMultipartUploadResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortMultipartUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortMultipartUploadResponse) ProtoMessage() {}
+
+func (x *AbortMultipartUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AbortMultipartUploadResponse) GetAborted() bool {
+	if x != nil {
+		return x.xxx_hidden_Aborted
+	}
+	return false
+}
+
+func (x *AbortMultipartUploadResponse) SetAborted(v bool) {
+	x.xxx_hidden_Aborted = v
+}
+
+type AbortMultipartUploadResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Whether the abort was successful.
+	// False if upload already completed or not found.
+	Aborted bool
+}
+
+func (b0 AbortMultipartUploadResponse_builder) Build() *AbortMultipartUploadResponse {
+	m0 := &AbortMultipartUploadResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Aborted = b.Aborted
+	return m0
+}
+
+type ListMultipartPartsRequest struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_UploadId string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3"`
+	xxx_hidden_Cursor   *v1.PageCursor         `protobuf:"bytes,2,opt,name=cursor,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListMultipartPartsRequest) Reset() {
+	*x = ListMultipartPartsRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMultipartPartsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMultipartPartsRequest) ProtoMessage() {}
+
+func (x *ListMultipartPartsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListMultipartPartsRequest) GetUploadId() string {
+	if x != nil {
+		return x.xxx_hidden_UploadId
+	}
+	return ""
+}
+
+func (x *ListMultipartPartsRequest) GetCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_Cursor
+	}
+	return nil
+}
+
+func (x *ListMultipartPartsRequest) SetUploadId(v string) {
+	x.xxx_hidden_UploadId = v
+}
+
+func (x *ListMultipartPartsRequest) SetCursor(v *v1.PageCursor) {
+	x.xxx_hidden_Cursor = v
+}
+
+func (x *ListMultipartPartsRequest) HasCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Cursor != nil
+}
+
+func (x *ListMultipartPartsRequest) ClearCursor() {
+	x.xxx_hidden_Cursor = nil
+}
+
+type ListMultipartPartsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Upload ID to list parts for.
+	UploadId string
+	// Pagination using common PageCursor.
+	Cursor *v1.PageCursor
+}
+
+func (b0 ListMultipartPartsRequest_builder) Build() *ListMultipartPartsRequest {
+	m0 := &ListMultipartPartsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_UploadId = b.UploadId
+	x.xxx_hidden_Cursor = b.Cursor
+	return m0
+}
+
+type ListMultipartPartsResponse struct {
+	state                 protoimpl.MessageState              `protogen:"opaque.v1"`
+	xxx_hidden_Parts      *[]*ListMultipartPartsResponse_Part `protobuf:"bytes,1,rep,name=parts,proto3"`
+	xxx_hidden_NextCursor *v1.PageCursor                      `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ListMultipartPartsResponse) Reset() {
+	*x = ListMultipartPartsResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMultipartPartsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMultipartPartsResponse) ProtoMessage() {}
+
+func (x *ListMultipartPartsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListMultipartPartsResponse) GetParts() []*ListMultipartPartsResponse_Part {
+	if x != nil {
+		if x.xxx_hidden_Parts != nil {
+			return *x.xxx_hidden_Parts
+		}
+	}
+	return nil
+}
+
+func (x *ListMultipartPartsResponse) GetNextCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_NextCursor
+	}
+	return nil
+}
+
+func (x *ListMultipartPartsResponse) SetParts(v []*ListMultipartPartsResponse_Part) {
+	x.xxx_hidden_Parts = &v
+}
+
+func (x *ListMultipartPartsResponse) SetNextCursor(v *v1.PageCursor) {
+	x.xxx_hidden_NextCursor = v
+}
+
+func (x *ListMultipartPartsResponse) HasNextCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_NextCursor != nil
+}
+
+func (x *ListMultipartPartsResponse) ClearNextCursor() {
+	x.xxx_hidden_NextCursor = nil
+}
+
+type ListMultipartPartsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Parts []*ListMultipartPartsResponse_Part
+	// Pagination cursor for next page.
+	// Use in next request's PageCursor.page.
+	NextCursor *v1.PageCursor
+}
+
+func (b0 ListMultipartPartsResponse_builder) Build() *ListMultipartPartsResponse {
+	m0 := &ListMultipartPartsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Parts = &b.Parts
+	x.xxx_hidden_NextCursor = b.NextCursor
+	return m0
+}
+
+type GetMultipartUploadRequest struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_UploadId string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetMultipartUploadRequest) Reset() {
+	*x = GetMultipartUploadRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMultipartUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMultipartUploadRequest) ProtoMessage() {}
+
+func (x *GetMultipartUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetMultipartUploadRequest) GetUploadId() string {
+	if x != nil {
+		return x.xxx_hidden_UploadId
+	}
+	return ""
+}
+
+func (x *GetMultipartUploadRequest) SetUploadId(v string) {
+	x.xxx_hidden_UploadId = v
+}
+
+type GetMultipartUploadRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Upload ID to inspect.
+	UploadId string
+}
+
+func (b0 GetMultipartUploadRequest_builder) Build() *GetMultipartUploadRequest {
+	m0 := &GetMultipartUploadRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_UploadId = b.UploadId
+	return m0
+}
+
+type GetMultipartUploadResponse struct {
+	state                    protoimpl.MessageState   `protogen:"opaque.v1"`
+	xxx_hidden_MediaId       string                   `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_Filename      string                   `protobuf:"bytes,2,opt,name=filename,proto3"`
+	xxx_hidden_TotalSize     int64                    `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3"`
+	xxx_hidden_UploadedSize  int64                    `protobuf:"varint,4,opt,name=uploaded_size,json=uploadedSize,proto3"`
+	xxx_hidden_Visibility    MediaMetadata_Visibility `protobuf:"varint,5,opt,name=visibility,proto3,enum=files.v1.MediaMetadata_Visibility"`
+	xxx_hidden_CreatedAt     *timestamppb.Timestamp   `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3"`
+	xxx_hidden_Labels        map[string]string        `protobuf:"bytes,7,rep,name=labels,proto3" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_PartsUploaded int32                    `protobuf:"varint,8,opt,name=parts_uploaded,json=partsUploaded,proto3"`
+	xxx_hidden_UploadState   MultipartUploadState     `protobuf:"varint,9,opt,name=upload_state,json=uploadState,proto3,enum=files.v1.MultipartUploadState"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *GetMultipartUploadResponse) Reset() {
+	*x = GetMultipartUploadResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMultipartUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMultipartUploadResponse) ProtoMessage() {}
+
+func (x *GetMultipartUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetMultipartUploadResponse) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *GetMultipartUploadResponse) GetFilename() string {
+	if x != nil {
+		return x.xxx_hidden_Filename
+	}
+	return ""
+}
+
+func (x *GetMultipartUploadResponse) GetTotalSize() int64 {
+	if x != nil {
+		return x.xxx_hidden_TotalSize
+	}
+	return 0
+}
+
+func (x *GetMultipartUploadResponse) GetUploadedSize() int64 {
+	if x != nil {
+		return x.xxx_hidden_UploadedSize
+	}
+	return 0
+}
+
+func (x *GetMultipartUploadResponse) GetVisibility() MediaMetadata_Visibility {
+	if x != nil {
+		return x.xxx_hidden_Visibility
+	}
+	return MediaMetadata_VISIBILITY_UNSPECIFIED
+}
+
+func (x *GetMultipartUploadResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *GetMultipartUploadResponse) GetLabels() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Labels
+	}
+	return nil
+}
+
+func (x *GetMultipartUploadResponse) GetPartsUploaded() int32 {
+	if x != nil {
+		return x.xxx_hidden_PartsUploaded
+	}
+	return 0
+}
+
+func (x *GetMultipartUploadResponse) GetUploadState() MultipartUploadState {
+	if x != nil {
+		return x.xxx_hidden_UploadState
+	}
+	return MultipartUploadState_MULTIPART_UPLOAD_STATE_UNSPECIFIED
+}
+
+func (x *GetMultipartUploadResponse) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *GetMultipartUploadResponse) SetFilename(v string) {
+	x.xxx_hidden_Filename = v
+}
+
+func (x *GetMultipartUploadResponse) SetTotalSize(v int64) {
+	x.xxx_hidden_TotalSize = v
+}
+
+func (x *GetMultipartUploadResponse) SetUploadedSize(v int64) {
+	x.xxx_hidden_UploadedSize = v
+}
+
+func (x *GetMultipartUploadResponse) SetVisibility(v MediaMetadata_Visibility) {
+	x.xxx_hidden_Visibility = v
+}
+
+func (x *GetMultipartUploadResponse) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *GetMultipartUploadResponse) SetLabels(v map[string]string) {
+	x.xxx_hidden_Labels = v
+}
+
+func (x *GetMultipartUploadResponse) SetPartsUploaded(v int32) {
+	x.xxx_hidden_PartsUploaded = v
+}
+
+func (x *GetMultipartUploadResponse) SetUploadState(v MultipartUploadState) {
+	x.xxx_hidden_UploadState = v
+}
+
+func (x *GetMultipartUploadResponse) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *GetMultipartUploadResponse) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+type GetMultipartUploadResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID being uploaded to (once completed).
+	// Empty if upload not yet associated with specific media_id.
+	MediaId string
+	// Original filename.
+	Filename string
+	// Total expected size (from CreateMultipartUpload).
+	TotalSize int64
+	// Total bytes uploaded so far.
+	UploadedSize int64
+	// Visibility setting.
+	Visibility MediaMetadata_Visibility
+	// When the multipart upload was created.
+	CreatedAt *timestamppb.Timestamp
+	// Labels for the eventual media.
+	Labels map[string]string
+	// Number of parts uploaded so far.
+	PartsUploaded int32
+	// Current state of the upload.
+	UploadState MultipartUploadState
+}
+
+func (b0 GetMultipartUploadResponse_builder) Build() *GetMultipartUploadResponse {
+	m0 := &GetMultipartUploadResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_Filename = b.Filename
+	x.xxx_hidden_TotalSize = b.TotalSize
+	x.xxx_hidden_UploadedSize = b.UploadedSize
+	x.xxx_hidden_Visibility = b.Visibility
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	x.xxx_hidden_Labels = b.Labels
+	x.xxx_hidden_PartsUploaded = b.PartsUploaded
+	x.xxx_hidden_UploadState = b.UploadState
+	return m0
+}
+
+// ArchiveExtractionFailure records an archive entry that was not extracted.
+type ArchiveExtractionFailure struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Path  string                 `protobuf:"bytes,1,opt,name=path,proto3"`
+	xxx_hidden_Error string                 `protobuf:"bytes,2,opt,name=error,proto3"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ArchiveExtractionFailure) Reset() {
+	*x = ArchiveExtractionFailure{}
+	mi := &file_files_v1_files_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveExtractionFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveExtractionFailure) ProtoMessage() {}
+
+func (x *ArchiveExtractionFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ArchiveExtractionFailure) GetPath() string {
+	if x != nil {
+		return x.xxx_hidden_Path
+	}
+	return ""
+}
+
+func (x *ArchiveExtractionFailure) GetError() string {
+	if x != nil {
+		return x.xxx_hidden_Error
+	}
+	return ""
+}
+
+func (x *ArchiveExtractionFailure) SetPath(v string) {
+	x.xxx_hidden_Path = v
+}
+
+func (x *ArchiveExtractionFailure) SetError(v string) {
+	x.xxx_hidden_Error = v
+}
+
+type ArchiveExtractionFailure_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Path of the entry within the archive.
+	Path string
+	// Why the entry was not extracted.
+	Error string
+}
+
+func (b0 ArchiveExtractionFailure_builder) Build() *ArchiveExtractionFailure {
+	m0 := &ArchiveExtractionFailure{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Path = b.Path
+	x.xxx_hidden_Error = b.Error
+	return m0
+}
+
+// ArchiveExtraction reports an archive extraction job.
+type ArchiveExtraction struct {
+	state                     protoimpl.MessageState       `protogen:"opaque.v1"`
+	xxx_hidden_JobId          string                       `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3"`
+	xxx_hidden_State          ArchiveExtractionState       `protobuf:"varint,2,opt,name=state,proto3,enum=files.v1.ArchiveExtractionState"`
+	xxx_hidden_SourceMediaId  string                       `protobuf:"bytes,3,opt,name=source_media_id,json=sourceMediaId,proto3"`
+	xxx_hidden_Folder         string                       `protobuf:"bytes,4,opt,name=folder,proto3"`
+	xxx_hidden_Entries        int64                        `protobuf:"varint,5,opt,name=entries,proto3"`
+	xxx_hidden_Extracted      int64                        `protobuf:"varint,6,opt,name=extracted,proto3"`
+	xxx_hidden_Skipped        int64                        `protobuf:"varint,7,opt,name=skipped,proto3"`
+	xxx_hidden_Failed         int64                        `protobuf:"varint,8,opt,name=failed,proto3"`
+	xxx_hidden_ExtractedBytes int64                        `protobuf:"varint,9,opt,name=extracted_bytes,json=extractedBytes,proto3"`
+	xxx_hidden_Failures       *[]*ArchiveExtractionFailure `protobuf:"bytes,10,rep,name=failures,proto3"`
+	xxx_hidden_LastError      string                       `protobuf:"bytes,11,opt,name=last_error,json=lastError,proto3"`
+	xxx_hidden_CreatedAt      *timestamppb.Timestamp       `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3"`
+	xxx_hidden_UpdatedAt      *timestamppb.Timestamp       `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3"`
+	xxx_hidden_CompletedAt    *timestamppb.Timestamp       `protobuf:"bytes,14,opt,name=completed_at,json=completedAt,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *ArchiveExtraction) Reset() {
+	*x = ArchiveExtraction{}
+	mi := &file_files_v1_files_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveExtraction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveExtraction) ProtoMessage() {}
+
+func (x *ArchiveExtraction) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ArchiveExtraction) GetJobId() string {
+	if x != nil {
+		return x.xxx_hidden_JobId
+	}
+	return ""
+}
+
+func (x *ArchiveExtraction) GetState() ArchiveExtractionState {
+	if x != nil {
+		return x.xxx_hidden_State
+	}
+	return ArchiveExtractionState_ARCHIVE_EXTRACTION_STATE_UNSPECIFIED
+}
+
+func (x *ArchiveExtraction) GetSourceMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_SourceMediaId
+	}
+	return ""
+}
+
+func (x *ArchiveExtraction) GetFolder() string {
+	if x != nil {
+		return x.xxx_hidden_Folder
+	}
+	return ""
+}
+
+func (x *ArchiveExtraction) GetEntries() int64 {
+	if x != nil {
+		return x.xxx_hidden_Entries
+	}
+	return 0
+}
+
+func (x *ArchiveExtraction) GetExtracted() int64 {
+	if x != nil {
+		return x.xxx_hidden_Extracted
+	}
+	return 0
+}
+
+func (x *ArchiveExtraction) GetSkipped() int64 {
+	if x != nil {
+		return x.xxx_hidden_Skipped
+	}
+	return 0
+}
+
+func (x *ArchiveExtraction) GetFailed() int64 {
+	if x != nil {
+		return x.xxx_hidden_Failed
+	}
+	return 0
+}
+
+func (x *ArchiveExtraction) GetExtractedBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_ExtractedBytes
+	}
+	return 0
+}
+
+func (x *ArchiveExtraction) GetFailures() []*ArchiveExtractionFailure {
+	if x != nil {
+		if x.xxx_hidden_Failures != nil {
+			return *x.xxx_hidden_Failures
+		}
+	}
+	return nil
+}
+
+func (x *ArchiveExtraction) GetLastError() string {
+	if x != nil {
+		return x.xxx_hidden_LastError
+	}
+	return ""
+}
+
+func (x *ArchiveExtraction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *ArchiveExtraction) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_UpdatedAt
+	}
+	return nil
+}
+
+func (x *ArchiveExtraction) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CompletedAt
+	}
+	return nil
+}
+
+func (x *ArchiveExtraction) SetJobId(v string) {
+	x.xxx_hidden_JobId = v
+}
+
+func (x *ArchiveExtraction) SetState(v ArchiveExtractionState) {
+	x.xxx_hidden_State = v
+}
+
+func (x *ArchiveExtraction) SetSourceMediaId(v string) {
+	x.xxx_hidden_SourceMediaId = v
+}
+
+func (x *ArchiveExtraction) SetFolder(v string) {
+	x.xxx_hidden_Folder = v
+}
+
+func (x *ArchiveExtraction) SetEntries(v int64) {
+	x.xxx_hidden_Entries = v
+}
+
+func (x *ArchiveExtraction) SetExtracted(v int64) {
+	x.xxx_hidden_Extracted = v
+}
+
+func (x *ArchiveExtraction) SetSkipped(v int64) {
+	x.xxx_hidden_Skipped = v
+}
+
+func (x *ArchiveExtraction) SetFailed(v int64) {
+	x.xxx_hidden_Failed = v
+}
+
+func (x *ArchiveExtraction) SetExtractedBytes(v int64) {
+	x.xxx_hidden_ExtractedBytes = v
+}
+
+func (x *ArchiveExtraction) SetFailures(v []*ArchiveExtractionFailure) {
+	x.xxx_hidden_Failures = &v
+}
+
+func (x *ArchiveExtraction) SetLastError(v string) {
+	x.xxx_hidden_LastError = v
+}
+
+func (x *ArchiveExtraction) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *ArchiveExtraction) SetUpdatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_UpdatedAt = v
+}
+
+func (x *ArchiveExtraction) SetCompletedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CompletedAt = v
+}
+
+func (x *ArchiveExtraction) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *ArchiveExtraction) HasUpdatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_UpdatedAt != nil
+}
+
+func (x *ArchiveExtraction) HasCompletedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CompletedAt != nil
+}
+
+func (x *ArchiveExtraction) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+func (x *ArchiveExtraction) ClearUpdatedAt() {
+	x.xxx_hidden_UpdatedAt = nil
+}
+
+func (x *ArchiveExtraction) ClearCompletedAt() {
+	x.xxx_hidden_CompletedAt = nil
+}
+
+type ArchiveExtraction_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Unique job ID.
+	JobId string
+	// Current job state.
+	State ArchiveExtractionState
+	// Media ID of the zip or tar.gz archive.
+	SourceMediaId string
+	// Folder the entries are extracted under.
+	Folder string
+	// Archive entries examined so far.
+	Entries int64
+	// Entries stored as media.
+	Extracted int64
+	// Directories, links and other entries that are not files.
+	Skipped int64
+	// Entries that could not be extracted; see failures.
+	Failed int64
+	// Uncompressed bytes extracted so far.
+	ExtractedBytes int64
+	// Failed entries in path order, up to the first 100.
+	Failures []*ArchiveExtractionFailure
+	// Error that stopped the job, if any.
+	LastError string
+	// Time the job was created.
+	CreatedAt *timestamppb.Timestamp
+	// Time progress was last recorded.
+	UpdatedAt *timestamppb.Timestamp
+	// Time the job completed or failed.
+	CompletedAt *timestamppb.Timestamp
+}
+
+func (b0 ArchiveExtraction_builder) Build() *ArchiveExtraction {
+	m0 := &ArchiveExtraction{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_JobId = b.JobId
+	x.xxx_hidden_State = b.State
+	x.xxx_hidden_SourceMediaId = b.SourceMediaId
+	x.xxx_hidden_Folder = b.Folder
+	x.xxx_hidden_Entries = b.Entries
+	x.xxx_hidden_Extracted = b.Extracted
+	x.xxx_hidden_Skipped = b.Skipped
+	x.xxx_hidden_Failed = b.Failed
+	x.xxx_hidden_ExtractedBytes = b.ExtractedBytes
+	x.xxx_hidden_Failures = &b.Failures
+	x.xxx_hidden_LastError = b.LastError
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	x.xxx_hidden_UpdatedAt = b.UpdatedAt
+	x.xxx_hidden_CompletedAt = b.CompletedAt
+	return m0
+}
+
+// ExtractArchiveRequest expands an uploaded archive into individual media.
+type ExtractArchiveRequest struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_Folder  string                 `protobuf:"bytes,2,opt,name=folder,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ExtractArchiveRequest) Reset() {
+	*x = ExtractArchiveRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtractArchiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtractArchiveRequest) ProtoMessage() {}
+
+func (x *ExtractArchiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ExtractArchiveRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *ExtractArchiveRequest) GetFolder() string {
+	if x != nil {
+		return x.xxx_hidden_Folder
+	}
+	return ""
+}
+
+func (x *ExtractArchiveRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *ExtractArchiveRequest) SetFolder(v string) {
+	x.xxx_hidden_Folder = v
+}
+
+type ExtractArchiveRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID of an uploaded zip or tar.gz archive.
+	MediaId string
+	// Folder to extract into; entries keep their path below it. Empty
+	// extracts into a folder named after the archive, next to it.
+	Folder string
+}
+
+func (b0 ExtractArchiveRequest_builder) Build() *ExtractArchiveRequest {
+	m0 := &ExtractArchiveRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_Folder = b.Folder
+	return m0
+}
+
+type ExtractArchiveResponse struct {
+	state          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Job *ArchiveExtraction     `protobuf:"bytes,1,opt,name=job,proto3"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExtractArchiveResponse) Reset() {
+	*x = ExtractArchiveResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtractArchiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtractArchiveResponse) ProtoMessage() {}
+
+func (x *ExtractArchiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ExtractArchiveResponse) GetJob() *ArchiveExtraction {
+	if x != nil {
+		return x.xxx_hidden_Job
+	}
+	return nil
+}
+
+func (x *ExtractArchiveResponse) SetJob(v *ArchiveExtraction) {
+	x.xxx_hidden_Job = v
+}
+
+func (x *ExtractArchiveResponse) HasJob() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Job != nil
+}
+
+func (x *ExtractArchiveResponse) ClearJob() {
+	x.xxx_hidden_Job = nil
+}
+
+type ExtractArchiveResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The started job.
+	Job *ArchiveExtraction
+}
+
+func (b0 ExtractArchiveResponse_builder) Build() *ExtractArchiveResponse {
+	m0 := &ExtractArchiveResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Job = b.Job
+	return m0
+}
+
+type GetArchiveExtractionRequest struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_JobId string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetArchiveExtractionRequest) Reset() {
+	*x = GetArchiveExtractionRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArchiveExtractionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArchiveExtractionRequest) ProtoMessage() {}
+
+func (x *GetArchiveExtractionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetArchiveExtractionRequest) GetJobId() string {
+	if x != nil {
+		return x.xxx_hidden_JobId
+	}
+	return ""
+}
+
+func (x *GetArchiveExtractionRequest) SetJobId(v string) {
+	x.xxx_hidden_JobId = v
+}
+
+type GetArchiveExtractionRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Job to report on.
+	JobId string
+}
+
+func (b0 GetArchiveExtractionRequest_builder) Build() *GetArchiveExtractionRequest {
+	m0 := &GetArchiveExtractionRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_JobId = b.JobId
+	return m0
+}
+
+type GetArchiveExtractionResponse struct {
+	state          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Job *ArchiveExtraction     `protobuf:"bytes,1,opt,name=job,proto3"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetArchiveExtractionResponse) Reset() {
+	*x = GetArchiveExtractionResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetArchiveExtractionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetArchiveExtractionResponse) ProtoMessage() {}
+
+func (x *GetArchiveExtractionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetArchiveExtractionResponse) GetJob() *ArchiveExtraction {
+	if x != nil {
+		return x.xxx_hidden_Job
+	}
+	return nil
+}
+
+func (x *GetArchiveExtractionResponse) SetJob(v *ArchiveExtraction) {
+	x.xxx_hidden_Job = v
+}
+
+func (x *GetArchiveExtractionResponse) HasJob() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Job != nil
+}
+
+func (x *GetArchiveExtractionResponse) ClearJob() {
+	x.xxx_hidden_Job = nil
+}
+
+type GetArchiveExtractionResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Job *ArchiveExtraction
+}
+
+func (b0 GetArchiveExtractionResponse_builder) Build() *GetArchiveExtractionResponse {
+	m0 := &GetArchiveExtractionResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Job = b.Job
+	return m0
+}
+
+type GetSignedUploadUrlRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId        string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_ExpiresSeconds int64                  `protobuf:"varint,2,opt,name=expires_seconds,json=expiresSeconds,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *GetSignedUploadUrlRequest) Reset() {
+	*x = GetSignedUploadUrlRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSignedUploadUrlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSignedUploadUrlRequest) ProtoMessage() {}
+
+func (x *GetSignedUploadUrlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetSignedUploadUrlRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *GetSignedUploadUrlRequest) GetExpiresSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_ExpiresSeconds
+	}
+	return 0
+}
+
+func (x *GetSignedUploadUrlRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *GetSignedUploadUrlRequest) SetExpiresSeconds(v int64) {
+	x.xxx_hidden_ExpiresSeconds = v
+}
+
+type GetSignedUploadUrlRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to get signed upload URL for.
+	// Media must be in CREATING state (from CreateContent).
+	MediaId string
+	// URL expiration in seconds.
+	// Minimum: 60 (1 minute)
+	// Maximum: 3600 (1 hour) - configurable by admin
+	// Shorter = more secure
+	ExpiresSeconds int64
+}
+
+func (b0 GetSignedUploadUrlRequest_builder) Build() *GetSignedUploadUrlRequest {
+	m0 := &GetSignedUploadUrlRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_ExpiresSeconds = b.ExpiresSeconds
+	return m0
+}
+
+type GetSignedUploadUrlResponse struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_UploadUrl string                 `protobuf:"bytes,1,opt,name=upload_url,json=uploadUrl,proto3"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetSignedUploadUrlResponse) Reset() {
+	*x = GetSignedUploadUrlResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSignedUploadUrlResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSignedUploadUrlResponse) ProtoMessage() {}
+
+func (x *GetSignedUploadUrlResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetSignedUploadUrlResponse) GetUploadUrl() string {
+	if x != nil {
+		return x.xxx_hidden_UploadUrl
+	}
+	return ""
+}
+
+func (x *GetSignedUploadUrlResponse) SetUploadUrl(v string) {
+	x.xxx_hidden_UploadUrl = v
+}
+
+type GetSignedUploadUrlResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Signed URL for direct upload to storage.
+	// HTTP PUT with binary content.
+	UploadUrl string
+}
+
+func (b0 GetSignedUploadUrlResponse_builder) Build() *GetSignedUploadUrlResponse {
+	m0 := &GetSignedUploadUrlResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_UploadUrl = b.UploadUrl
+	return m0
+}
+
+type FinalizeSignedUploadRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId        string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_ChecksumSha256 string                 `protobuf:"bytes,2,opt,name=checksum_sha256,json=checksumSha256,proto3"`
+	xxx_hidden_SizeBytes      int64                  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3"`
+	xxx_hidden_IdempotencyKey string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *FinalizeSignedUploadRequest) Reset() {
+	*x = FinalizeSignedUploadRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinalizeSignedUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalizeSignedUploadRequest) ProtoMessage() {}
+
+func (x *FinalizeSignedUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *FinalizeSignedUploadRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *FinalizeSignedUploadRequest) GetChecksumSha256() string {
+	if x != nil {
+		return x.xxx_hidden_ChecksumSha256
+	}
+	return ""
+}
+
+func (x *FinalizeSignedUploadRequest) GetSizeBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_SizeBytes
+	}
+	return 0
+}
+
+func (x *FinalizeSignedUploadRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *FinalizeSignedUploadRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *FinalizeSignedUploadRequest) SetChecksumSha256(v string) {
+	x.xxx_hidden_ChecksumSha256 = v
+}
+
+func (x *FinalizeSignedUploadRequest) SetSizeBytes(v int64) {
+	x.xxx_hidden_SizeBytes = v
+}
+
+func (x *FinalizeSignedUploadRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+type FinalizeSignedUploadRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to finalize.
+	MediaId string
+	// SHA-256 checksum of the uploaded content.
+	// Must match what was actually uploaded.
+	ChecksumSha256 string
+	// Size of the uploaded content in bytes.
+	// Must match actual uploaded size.
+	SizeBytes int64
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 FinalizeSignedUploadRequest_builder) Build() *FinalizeSignedUploadRequest {
+	m0 := &FinalizeSignedUploadRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_ChecksumSha256 = b.ChecksumSha256
+	x.xxx_hidden_SizeBytes = b.SizeBytes
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type FinalizeSignedUploadResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Metadata *MediaMetadata         `protobuf:"bytes,1,opt,name=metadata,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *FinalizeSignedUploadResponse) Reset() {
+	*x = FinalizeSignedUploadResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinalizeSignedUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalizeSignedUploadResponse) ProtoMessage() {}
+
+func (x *FinalizeSignedUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *FinalizeSignedUploadResponse) GetMetadata() *MediaMetadata {
+	if x != nil {
+		return x.xxx_hidden_Metadata
+	}
+	return nil
+}
+
+func (x *FinalizeSignedUploadResponse) SetMetadata(v *MediaMetadata) {
+	x.xxx_hidden_Metadata = v
+}
+
+func (x *FinalizeSignedUploadResponse) HasMetadata() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Metadata != nil
+}
+
+func (x *FinalizeSignedUploadResponse) ClearMetadata() {
+	x.xxx_hidden_Metadata = nil
+}
+
+type FinalizeSignedUploadResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Final media metadata.
+	// State should now be AVAILABLE.
+	Metadata *MediaMetadata
+}
+
+func (b0 FinalizeSignedUploadResponse_builder) Build() *FinalizeSignedUploadResponse {
+	m0 := &FinalizeSignedUploadResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Metadata = b.Metadata
+	return m0
+}
+
+type GetSignedDownloadUrlRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId        string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_ExpiresSeconds int64                  `protobuf:"varint,2,opt,name=expires_seconds,json=expiresSeconds,proto3"`
+	xxx_hidden_Download       bool                   `protobuf:"varint,3,opt,name=download,proto3"`
+	xxx_hidden_Filename       string                 `protobuf:"bytes,4,opt,name=filename,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *GetSignedDownloadUrlRequest) Reset() {
+	*x = GetSignedDownloadUrlRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSignedDownloadUrlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSignedDownloadUrlRequest) ProtoMessage() {}
+
+func (x *GetSignedDownloadUrlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetSignedDownloadUrlRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *GetSignedDownloadUrlRequest) GetExpiresSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_ExpiresSeconds
+	}
+	return 0
+}
+
+func (x *GetSignedDownloadUrlRequest) GetDownload() bool {
+	if x != nil {
+		return x.xxx_hidden_Download
+	}
+	return false
+}
+
+func (x *GetSignedDownloadUrlRequest) GetFilename() string {
+	if x != nil {
+		return x.xxx_hidden_Filename
+	}
+	return ""
+}
+
+func (x *GetSignedDownloadUrlRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *GetSignedDownloadUrlRequest) SetExpiresSeconds(v int64) {
+	x.xxx_hidden_ExpiresSeconds = v
+}
+
+func (x *GetSignedDownloadUrlRequest) SetDownload(v bool) {
+	x.xxx_hidden_Download = v
+}
+
+func (x *GetSignedDownloadUrlRequest) SetFilename(v string) {
+	x.xxx_hidden_Filename = v
+}
+
+type GetSignedDownloadUrlRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to get download URL for.
+	MediaId string
+	// URL expiration in seconds.
+	ExpiresSeconds int64
+	// Optional: Force download (Content-Disposition: attachment).
+	// Default: false (inline for images, etc.)
+	Download bool
+	// Optional: Override filename in Content-Disposition.
+	Filename string
+}
+
+func (b0 GetSignedDownloadUrlRequest_builder) Build() *GetSignedDownloadUrlRequest {
+	m0 := &GetSignedDownloadUrlRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_ExpiresSeconds = b.ExpiresSeconds
+	x.xxx_hidden_Download = b.Download
+	x.xxx_hidden_Filename = b.Filename
+	return m0
+}
+
+type GetSignedDownloadUrlResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_DownloadUrl string                 `protobuf:"bytes,1,opt,name=download_url,json=downloadUrl,proto3"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetSignedDownloadUrlResponse) Reset() {
+	*x = GetSignedDownloadUrlResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSignedDownloadUrlResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSignedDownloadUrlResponse) ProtoMessage() {}
+
+func (x *GetSignedDownloadUrlResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetSignedDownloadUrlResponse) GetDownloadUrl() string {
+	if x != nil {
+		return x.xxx_hidden_DownloadUrl
+	}
+	return ""
+}
+
+func (x *GetSignedDownloadUrlResponse) SetDownloadUrl(v string) {
+	x.xxx_hidden_DownloadUrl = v
+}
+
+type GetSignedDownloadUrlResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Signed URL for direct download from storage.
+	DownloadUrl string
+}
+
+func (b0 GetSignedDownloadUrlResponse_builder) Build() *GetSignedDownloadUrlResponse {
+	m0 := &GetSignedDownloadUrlResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_DownloadUrl = b.DownloadUrl
+	return m0
+}
+
+// GetContentRequest downloads complete content.
+//
+// This is the standard download method for most use cases.
+// For large files or streaming needs, use DownloadContent.
+//
+// Cache Headers:
+//
+//	Server respects If-None-Match and If-Modified-Since.
+//	Include etag from metadata for efficient caching.
+//
+// Timeouts:
+//
+//	Default: 20 seconds
+//	For large files, use DownloadContent (streaming).
+type GetContentRequest struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId   string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_TimeoutMs int64                  `protobuf:"varint,2,opt,name=timeout_ms,json=timeoutMs,proto3"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetContentRequest) Reset() {
+	*x = GetContentRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContentRequest) ProtoMessage() {}
+
+func (x *GetContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetContentRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *GetContentRequest) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.xxx_hidden_TimeoutMs
+	}
+	return 0
+}
+
+func (x *GetContentRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *GetContentRequest) SetTimeoutMs(v int64) {
+	x.xxx_hidden_TimeoutMs = v
+}
+
+type GetContentRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to download.
+	MediaId string
+	// Timeout in milliseconds.
+	// Default: 20000 (20 seconds)
+	// For very large files, use streaming API.
+	TimeoutMs int64
+}
+
+func (b0 GetContentRequest_builder) Build() *GetContentRequest {
+	m0 := &GetContentRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_TimeoutMs = b.TimeoutMs
+	return m0
+}
+
+type GetContentResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Content  []byte                 `protobuf:"bytes,1,opt,name=content,proto3"`
+	xxx_hidden_Metadata *MediaMetadata         `protobuf:"bytes,2,opt,name=metadata,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetContentResponse) Reset() {
+	*x = GetContentResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContentResponse) ProtoMessage() {}
+
+func (x *GetContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetContentResponse) GetContent() []byte {
+	if x != nil {
+		return x.xxx_hidden_Content
+	}
+	return nil
+}
+
+func (x *GetContentResponse) GetMetadata() *MediaMetadata {
+	if x != nil {
+		return x.xxx_hidden_Metadata
+	}
+	return nil
+}
+
+func (x *GetContentResponse) SetContent(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Content = v
+}
+
+func (x *GetContentResponse) SetMetadata(v *MediaMetadata) {
+	x.xxx_hidden_Metadata = v
+}
+
+func (x *GetContentResponse) HasMetadata() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Metadata != nil
+}
+
+func (x *GetContentResponse) ClearMetadata() {
+	x.xxx_hidden_Metadata = nil
+}
+
+type GetContentResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Binary content of the file.
+	Content []byte
+	// Current metadata at time of download.
+	// Always fetch fresh before using for decisions.
+	Metadata *MediaMetadata
+}
+
+func (b0 GetContentResponse_builder) Build() *GetContentResponse {
+	m0 := &GetContentResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Content = b.Content
+	x.xxx_hidden_Metadata = b.Metadata
+	return m0
+}
+
+type GetContentOverrideNameRequest struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId   string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_FileName  string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3"`
+	xxx_hidden_TimeoutMs int64                  `protobuf:"varint,3,opt,name=timeout_ms,json=timeoutMs,proto3"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetContentOverrideNameRequest) Reset() {
+	*x = GetContentOverrideNameRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContentOverrideNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContentOverrideNameRequest) ProtoMessage() {}
+
+func (x *GetContentOverrideNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetContentOverrideNameRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *GetContentOverrideNameRequest) GetFileName() string {
+	if x != nil {
+		return x.xxx_hidden_FileName
+	}
+	return ""
+}
+
+func (x *GetContentOverrideNameRequest) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.xxx_hidden_TimeoutMs
+	}
+	return 0
+}
+
+func (x *GetContentOverrideNameRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *GetContentOverrideNameRequest) SetFileName(v string) {
+	x.xxx_hidden_FileName = v
+}
+
+func (x *GetContentOverrideNameRequest) SetTimeoutMs(v int64) {
+	x.xxx_hidden_TimeoutMs = v
+}
+
+type GetContentOverrideNameRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to download.
+	MediaId string
+	// Filename to override in Content-Disposition header.
+	// Useful for API downloads where original name may be unsafe.
+	FileName string
+	// Timeout in milliseconds.
+	TimeoutMs int64
+}
+
+func (b0 GetContentOverrideNameRequest_builder) Build() *GetContentOverrideNameRequest {
+	m0 := &GetContentOverrideNameRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_FileName = b.FileName
+	x.xxx_hidden_TimeoutMs = b.TimeoutMs
+	return m0
+}
+
+type GetContentOverrideNameResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Content  []byte                 `protobuf:"bytes,1,opt,name=content,proto3"`
+	xxx_hidden_Metadata *MediaMetadata         `protobuf:"bytes,2,opt,name=metadata,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetContentOverrideNameResponse) Reset() {
+	*x = GetContentOverrideNameResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContentOverrideNameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContentOverrideNameResponse) ProtoMessage() {}
+
+func (x *GetContentOverrideNameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetContentOverrideNameResponse) GetContent() []byte {
+	if x != nil {
+		return x.xxx_hidden_Content
+	}
+	return nil
+}
+
+func (x *GetContentOverrideNameResponse) GetMetadata() *MediaMetadata {
+	if x != nil {
+		return x.xxx_hidden_Metadata
+	}
+	return nil
+}
+
+func (x *GetContentOverrideNameResponse) SetContent(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Content = v
+}
+
+func (x *GetContentOverrideNameResponse) SetMetadata(v *MediaMetadata) {
+	x.xxx_hidden_Metadata = v
+}
+
+func (x *GetContentOverrideNameResponse) HasMetadata() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Metadata != nil
+}
+
+func (x *GetContentOverrideNameResponse) ClearMetadata() {
+	x.xxx_hidden_Metadata = nil
+}
+
+type GetContentOverrideNameResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Content  []byte
+	Metadata *MediaMetadata
+}
+
+func (b0 GetContentOverrideNameResponse_builder) Build() *GetContentOverrideNameResponse {
+	m0 := &GetContentOverrideNameResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Content = b.Content
+	x.xxx_hidden_Metadata = b.Metadata
+	return m0
+}
+
+// DownloadContentResponse carries a portion of streamed content.
+//
+// Server streams chunks as they're read from storage.
+// Client assembles chunks in order received.
+type DownloadContentResponse struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Data []byte                 `protobuf:"bytes,1,opt,name=data,proto3"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DownloadContentResponse) Reset() {
+	*x = DownloadContentResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadContentResponse) ProtoMessage() {}
+
+func (x *DownloadContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DownloadContentResponse) GetData() []byte {
+	if x != nil {
+		return x.xxx_hidden_Data
+	}
+	return nil
+}
+
+func (x *DownloadContentResponse) SetData(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Data = v
+}
+
+type DownloadContentResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Chunk data.
+	// Chunk size varies based on server configuration.
+	Data []byte
+}
+
+func (b0 DownloadContentResponse_builder) Build() *DownloadContentResponse {
+	m0 := &DownloadContentResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Data = b.Data
+	return m0
+}
+
+type DownloadContentRequest struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DownloadContentRequest) Reset() {
+	*x = DownloadContentRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadContentRequest) ProtoMessage() {}
+
+func (x *DownloadContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DownloadContentRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *DownloadContentRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+type DownloadContentRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to download.
+	MediaId string
+}
+
+func (b0 DownloadContentRequest_builder) Build() *DownloadContentRequest {
+	m0 := &DownloadContentRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	return m0
+}
+
+// DownloadArchiveRequest selects the files of an archive, either by media
+// ID or by folder.
+type DownloadArchiveRequest struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaIds []string               `protobuf:"bytes,1,rep,name=media_ids,json=mediaIds,proto3"`
+	xxx_hidden_Folder   string                 `protobuf:"bytes,2,opt,name=folder,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *DownloadArchiveRequest) Reset() {
+	*x = DownloadArchiveRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadArchiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadArchiveRequest) ProtoMessage() {}
+
+func (x *DownloadArchiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DownloadArchiveRequest) GetMediaIds() []string {
+	if x != nil {
+		return x.xxx_hidden_MediaIds
+	}
+	return nil
+}
+
+func (x *DownloadArchiveRequest) GetFolder() string {
+	if x != nil {
+		return x.xxx_hidden_Folder
+	}
+	return ""
+}
+
+func (x *DownloadArchiveRequest) SetMediaIds(v []string) {
+	x.xxx_hidden_MediaIds = v
+}
+
+func (x *DownloadArchiveRequest) SetFolder(v string) {
+	x.xxx_hidden_Folder = v
+}
+
+type DownloadArchiveRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media IDs to archive, in archive order.
+	MediaIds []string
+	// Folder to archive with its subfolders when no media IDs are given.
+	// Entries keep their path below the folder.
+	Folder string
+}
+
+func (b0 DownloadArchiveRequest_builder) Build() *DownloadArchiveRequest {
+	m0 := &DownloadArchiveRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaIds = b.MediaIds
+	x.xxx_hidden_Folder = b.Folder
+	return m0
+}
+
+// DownloadArchiveResponse carries a portion of the streamed zip archive.
+//
+// The archive ends with a manifest.json entry listing every requested file
+// and, for those that could not be archived, the reason.
+type DownloadArchiveResponse struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Data []byte                 `protobuf:"bytes,1,opt,name=data,proto3"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DownloadArchiveResponse) Reset() {
+	*x = DownloadArchiveResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadArchiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadArchiveResponse) ProtoMessage() {}
+
+func (x *DownloadArchiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DownloadArchiveResponse) GetData() []byte {
+	if x != nil {
+		return x.xxx_hidden_Data
+	}
+	return nil
+}
+
+func (x *DownloadArchiveResponse) SetData(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Data = v
+}
+
+type DownloadArchiveResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Chunk data.
+	Data []byte
+}
+
+func (b0 DownloadArchiveResponse_builder) Build() *DownloadArchiveResponse {
+	m0 := &DownloadArchiveResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Data = b.Data
+	return m0
+}
+
+// DownloadContentRangeResponse carries a portion of streamed range content.
+//
+// Server streams chunks as they're read from storage.
+// Client assembles chunks in order received.
+type DownloadContentRangeResponse struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Data []byte                 `protobuf:"bytes,1,opt,name=data,proto3"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DownloadContentRangeResponse) Reset() {
+	*x = DownloadContentRangeResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadContentRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadContentRangeResponse) ProtoMessage() {}
+
+func (x *DownloadContentRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DownloadContentRangeResponse) GetData() []byte {
+	if x != nil {
+		return x.xxx_hidden_Data
+	}
+	return nil
+}
+
+func (x *DownloadContentRangeResponse) SetData(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Data = v
+}
+
+type DownloadContentRangeResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Chunk data.
+	// Chunk size varies based on server configuration.
+	Data []byte
+}
+
+func (b0 DownloadContentRangeResponse_builder) Build() *DownloadContentRangeResponse {
+	m0 := &DownloadContentRangeResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Data = b.Data
+	return m0
+}
+
+type DownloadContentRangeRequest struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_Start   int64                  `protobuf:"varint,2,opt,name=start,proto3"`
+	xxx_hidden_End     int64                  `protobuf:"varint,3,opt,name=end,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DownloadContentRangeRequest) Reset() {
+	*x = DownloadContentRangeRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadContentRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadContentRangeRequest) ProtoMessage() {}
+
+func (x *DownloadContentRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DownloadContentRangeRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *DownloadContentRangeRequest) GetStart() int64 {
+	if x != nil {
+		return x.xxx_hidden_Start
+	}
+	return 0
+}
+
+func (x *DownloadContentRangeRequest) GetEnd() int64 {
+	if x != nil {
+		return x.xxx_hidden_End
+	}
+	return 0
+}
+
+func (x *DownloadContentRangeRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *DownloadContentRangeRequest) SetStart(v int64) {
+	x.xxx_hidden_Start = v
+}
+
+func (x *DownloadContentRangeRequest) SetEnd(v int64) {
+	x.xxx_hidden_End = v
+}
+
+type DownloadContentRangeRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to download.
+	MediaId string
+	// Start byte offset (inclusive).
+	// Must be >= 0 and < file size.
+	Start int64
+	// End byte offset (exclusive).
+	// Must be > start and <= file size.
+	// Omit or set to -1 for remainder of file.
+	End int64
+}
+
+func (b0 DownloadContentRangeRequest_builder) Build() *DownloadContentRangeRequest {
+	m0 := &DownloadContentRangeRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_Start = b.Start
+	x.xxx_hidden_End = b.End
+	return m0
+}
+
+// HeadContentRequest retrieves metadata without downloading content.
+//
+// Use for:
+//   - Checking if content exists
+//   - Getting metadata before download
+//   - Checking content length for range requests
+//   - Verifying etag for cache validation
+type HeadContentRequest struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *HeadContentRequest) Reset() {
+	*x = HeadContentRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeadContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeadContentRequest) ProtoMessage() {}
+
+func (x *HeadContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *HeadContentRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *HeadContentRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+type HeadContentRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to get metadata for.
+	MediaId string
+}
+
+func (b0 HeadContentRequest_builder) Build() *HeadContentRequest {
+	m0 := &HeadContentRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	return m0
+}
+
+type HeadContentResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Metadata *MediaMetadata         `protobuf:"bytes,1,opt,name=metadata,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *HeadContentResponse) Reset() {
+	*x = HeadContentResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeadContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeadContentResponse) ProtoMessage() {}
+
+func (x *HeadContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *HeadContentResponse) GetMetadata() *MediaMetadata {
+	if x != nil {
+		return x.xxx_hidden_Metadata
+	}
+	return nil
+}
+
+func (x *HeadContentResponse) SetMetadata(v *MediaMetadata) {
+	x.xxx_hidden_Metadata = v
+}
+
+func (x *HeadContentResponse) HasMetadata() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Metadata != nil
+}
+
+func (x *HeadContentResponse) ClearMetadata() {
+	x.xxx_hidden_Metadata = nil
+}
+
+type HeadContentResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Current metadata.
+	Metadata *MediaMetadata
+}
+
+func (b0 HeadContentResponse_builder) Build() *HeadContentResponse {
+	m0 := &HeadContentResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Metadata = b.Metadata
+	return m0
+}
+
+// DeleteContentRequest deletes content from the repository.
+//
+// Soft Delete (default):
+//   - Content marked as DELETED
+//   - Data retained for retention period
+//   - Can be restored via RestoreVersion
+//   - Storage not reclaimed until hard delete or expiration
+//
+// Hard Delete:
+//   - Content permanently removed
+//   - Cannot be restored
+//   - Storage reclaimed immediately
+//   - Requires OWNER role
+//
+// Retention Policy:
+//
+//	If content is under retention policy, hard delete may be denied.
+//	Use DELETE_OUTCOME_DENIED_BY_RETENTION in response.
+type DeleteContentRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId        string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_HardDelete     bool                   `protobuf:"varint,2,opt,name=hard_delete,json=hardDelete,proto3"`
+	xxx_hidden_IdempotencyKey string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *DeleteContentRequest) Reset() {
+	*x = DeleteContentRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteContentRequest) ProtoMessage() {}
+
+func (x *DeleteContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteContentRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *DeleteContentRequest) GetHardDelete() bool {
+	if x != nil {
+		return x.xxx_hidden_HardDelete
+	}
+	return false
+}
+
+func (x *DeleteContentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *DeleteContentRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *DeleteContentRequest) SetHardDelete(v bool) {
+	x.xxx_hidden_HardDelete = v
+}
+
+func (x *DeleteContentRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+type DeleteContentRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to delete.
+	MediaId string
+	// True for permanent deletion, false for soft delete.
+	// Default: false (soft delete)
+	HardDelete bool
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 DeleteContentRequest_builder) Build() *DeleteContentRequest {
+	m0 := &DeleteContentRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_HardDelete = b.HardDelete
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type DeleteContentResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Success bool                   `protobuf:"varint,1,opt,name=success,proto3"`
+	xxx_hidden_Outcome DeleteOutcome          `protobuf:"varint,2,opt,name=outcome,proto3,enum=files.v1.DeleteOutcome"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DeleteContentResponse) Reset() {
+	*x = DeleteContentResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteContentResponse) ProtoMessage() {}
+
+func (x *DeleteContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteContentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.xxx_hidden_Success
+	}
+	return false
+}
+
+func (x *DeleteContentResponse) GetOutcome() DeleteOutcome {
+	if x != nil {
+		return x.xxx_hidden_Outcome
+	}
+	return DeleteOutcome_DELETE_OUTCOME_UNSPECIFIED
+}
+
+func (x *DeleteContentResponse) SetSuccess(v bool) {
+	x.xxx_hidden_Success = v
+}
+
+func (x *DeleteContentResponse) SetOutcome(v DeleteOutcome) {
+	x.xxx_hidden_Outcome = v
+}
+
+type DeleteContentResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Whether the delete was successful.
+	// False if media not found or permission denied.
+	Success bool
+	// Detailed outcome of the delete operation.
+	Outcome DeleteOutcome
+}
+
+func (b0 DeleteContentResponse_builder) Build() *DeleteContentResponse {
+	m0 := &DeleteContentResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Success = b.Success
+	x.xxx_hidden_Outcome = b.Outcome
+	return m0
+}
+
+// PatchContentRequest updates metadata for existing content.
+//
+// This is a PATCH (partial update) operation.
+// Fields not specified are left unchanged.
+//
+// Optimistic Concurrency:
+//
+//	Include etag from current metadata in If-Match header.
+//	Server returns PRECONDITION_FAILED if etag doesn't match.
+//
+// What can be patched:
+//   - filename: Rename the file
+//   - visibility: Change access level
+//   - expires_at: Update expiration
+//   - set_labels: Add/update labels
+//   - remove_labels: Remove specific labels
+//   - set_extra: Add/update extra metadata
+//
+// What cannot be patched (requires new version):
+//   - content_type
+//   - file_size_bytes
+//   - checksum_sha256
+type PatchContentRequest struct {
+	state                     protoimpl.MessageState   `protogen:"opaque.v1"`
+	xxx_hidden_MediaId        string                   `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_SetExtra       *structpb.Struct         `protobuf:"bytes,2,opt,name=set_extra,json=setExtra,proto3"`
+	xxx_hidden_SetLabels      map[string]string        `protobuf:"bytes,3,rep,name=set_labels,json=setLabels,proto3" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_RemoveLabels   []string                 `protobuf:"bytes,4,rep,name=remove_labels,json=removeLabels,proto3"`
+	xxx_hidden_Filename       string                   `protobuf:"bytes,5,opt,name=filename,proto3"`
+	xxx_hidden_Visibility     MediaMetadata_Visibility `protobuf:"varint,6,opt,name=visibility,proto3,enum=files.v1.MediaMetadata_Visibility"`
+	xxx_hidden_ExpiresAt      *timestamppb.Timestamp   `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3"`
+	xxx_hidden_Folder         *string                  `protobuf:"bytes,8,opt,name=folder,proto3,oneof"`
+	xxx_hidden_IdempotencyKey string                   `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	XXX_raceDetectHookData    protoimpl.RaceDetectHookData
+	XXX_presence              [1]uint32
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *PatchContentRequest) Reset() {
+	*x = PatchContentRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchContentRequest) ProtoMessage() {}
+
+func (x *PatchContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *PatchContentRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *PatchContentRequest) GetSetExtra() *structpb.Struct {
+	if x != nil {
+		return x.xxx_hidden_SetExtra
+	}
+	return nil
+}
+
+func (x *PatchContentRequest) GetSetLabels() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_SetLabels
+	}
+	return nil
+}
+
+func (x *PatchContentRequest) GetRemoveLabels() []string {
+	if x != nil {
+		return x.xxx_hidden_RemoveLabels
+	}
+	return nil
+}
+
+func (x *PatchContentRequest) GetFilename() string {
+	if x != nil {
+		return x.xxx_hidden_Filename
+	}
+	return ""
+}
+
+func (x *PatchContentRequest) GetVisibility() MediaMetadata_Visibility {
+	if x != nil {
+		return x.xxx_hidden_Visibility
+	}
+	return MediaMetadata_VISIBILITY_UNSPECIFIED
+}
+
+func (x *PatchContentRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *PatchContentRequest) GetFolder() string {
+	if x != nil {
+		if x.xxx_hidden_Folder != nil {
+			return *x.xxx_hidden_Folder
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *PatchContentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PatchContentRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *PatchContentRequest) SetSetExtra(v *structpb.Struct) {
+	x.xxx_hidden_SetExtra = v
+}
+
+func (x *PatchContentRequest) SetSetLabels(v map[string]string) {
+	x.xxx_hidden_SetLabels = v
+}
+
+func (x *PatchContentRequest) SetRemoveLabels(v []string) {
+	x.xxx_hidden_RemoveLabels = v
+}
+
+func (x *PatchContentRequest) SetFilename(v string) {
+	x.xxx_hidden_Filename = v
+}
+
+func (x *PatchContentRequest) SetVisibility(v MediaMetadata_Visibility) {
+	x.xxx_hidden_Visibility = v
+}
+
+func (x *PatchContentRequest) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *PatchContentRequest) SetFolder(v string) {
+	x.xxx_hidden_Folder = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 9)
+}
+
+func (x *PatchContentRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+func (x *PatchContentRequest) HasSetExtra() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_SetExtra != nil
+}
+
+func (x *PatchContentRequest) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *PatchContentRequest) HasFolder() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *PatchContentRequest) ClearSetExtra() {
+	x.xxx_hidden_SetExtra = nil
+}
+
+func (x *PatchContentRequest) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+func (x *PatchContentRequest) ClearFolder() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_Folder = nil
+}
+
+type PatchContentRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to patch.
+	MediaId string
+	// Set/update extra metadata.
+	// Merge with existing extra (not replace).
+	// To remove a key, set value to null in the struct.
+	SetExtra *structpb.Struct
+	// Set/update labels.
+	// Merge with existing labels.
+	SetLabels map[string]string
+	// Labels to remove entirely.
+	RemoveLabels []string
+	// New filename.
+	// If empty, filename is unchanged.
+	Filename string
+	// New visibility.
+	// If VISIBILITY_UNSPECIFIED, visibility is unchanged.
+	Visibility MediaMetadata_Visibility
+	// New expiration timestamp.
+	// If empty, expires_at is unchanged.
+	ExpiresAt *timestamppb.Timestamp
+	// Folder to move the content to.
+	// If unset, the folder is unchanged; empty moves the content to the root.
+	Folder *string
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 PatchContentRequest_builder) Build() *PatchContentRequest {
+	m0 := &PatchContentRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_SetExtra = b.SetExtra
+	x.xxx_hidden_SetLabels = b.SetLabels
+	x.xxx_hidden_RemoveLabels = b.RemoveLabels
+	x.xxx_hidden_Filename = b.Filename
+	x.xxx_hidden_Visibility = b.Visibility
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	if b.Folder != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 9)
+		x.xxx_hidden_Folder = b.Folder
+	}
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type PatchContentResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Metadata *MediaMetadata         `protobuf:"bytes,1,opt,name=metadata,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *PatchContentResponse) Reset() {
+	*x = PatchContentResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchContentResponse) ProtoMessage() {}
+
+func (x *PatchContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *PatchContentResponse) GetMetadata() *MediaMetadata {
+	if x != nil {
+		return x.xxx_hidden_Metadata
+	}
+	return nil
+}
+
+func (x *PatchContentResponse) SetMetadata(v *MediaMetadata) {
+	x.xxx_hidden_Metadata = v
+}
+
+func (x *PatchContentResponse) HasMetadata() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Metadata != nil
+}
+
+func (x *PatchContentResponse) ClearMetadata() {
+	x.xxx_hidden_Metadata = nil
+}
+
+type PatchContentResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Updated metadata reflecting changes.
+	Metadata *MediaMetadata
+}
+
+func (b0 PatchContentResponse_builder) Build() *PatchContentResponse {
+	m0 := &PatchContentResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Metadata = b.Metadata
+	return m0
+}
+
+// GrantAccessRequest grants access to a principal for media.
+//
+// Who can grant:
+//   - OWNER: can grant any role
+//   - WRITER: can grant READER only
+//
+// Duplicate grants:
+//
+//	Granting to principal with existing role updates their role.
+//	No error returned; grant is simply updated.
+type GrantAccessRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId        string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_Grant          *AccessGrant           `protobuf:"bytes,2,opt,name=grant,proto3"`
+	xxx_hidden_Folder         string                 `protobuf:"bytes,3,opt,name=folder,proto3"`
+	xxx_hidden_IdempotencyKey string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *GrantAccessRequest) Reset() {
+	*x = GrantAccessRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantAccessRequest) ProtoMessage() {}
+
+func (x *GrantAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GrantAccessRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *GrantAccessRequest) GetGrant() *AccessGrant {
+	if x != nil {
+		return x.xxx_hidden_Grant
+	}
+	return nil
+}
+
+func (x *GrantAccessRequest) GetFolder() string {
+	if x != nil {
+		return x.xxx_hidden_Folder
+	}
+	return ""
+}
+
+func (x *GrantAccessRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *GrantAccessRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *GrantAccessRequest) SetGrant(v *AccessGrant) {
+	x.xxx_hidden_Grant = v
+}
+
+func (x *GrantAccessRequest) SetFolder(v string) {
+	x.xxx_hidden_Folder = v
+}
+
+func (x *GrantAccessRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+func (x *GrantAccessRequest) HasGrant() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Grant != nil
+}
+
+func (x *GrantAccessRequest) ClearGrant() {
+	x.xxx_hidden_Grant = nil
+}
+
+type GrantAccessRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to grant access to.
+	MediaId string
+	// The access grant to apply.
+	Grant *AccessGrant
+	// Folder of the caller's files to grant access to, instead of media_id.
+	// The grant applies to every file of the caller's filed in the folder or
+	// in the folders below it, including files moved there later.
+	// Only the viewer, editor and owner roles can be granted on a folder.
+	Folder string
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 GrantAccessRequest_builder) Build() *GrantAccessRequest {
+	m0 := &GrantAccessRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_Grant = b.Grant
+	x.xxx_hidden_Folder = b.Folder
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type GrantAccessResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Success bool                   `protobuf:"varint,1,opt,name=success,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GrantAccessResponse) Reset() {
+	*x = GrantAccessResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantAccessResponse) ProtoMessage() {}
+
+func (x *GrantAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GrantAccessResponse) GetSuccess() bool {
+	if x != nil {
+		return x.xxx_hidden_Success
+	}
+	return false
+}
+
+func (x *GrantAccessResponse) SetSuccess(v bool) {
+	x.xxx_hidden_Success = v
+}
+
+type GrantAccessResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Whether the grant was applied.
+	Success bool
+}
+
+func (b0 GrantAccessResponse_builder) Build() *GrantAccessResponse {
+	m0 := &GrantAccessResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Success = b.Success
+	return m0
+}
+
+type RevokeAccessRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId        string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_PrincipalId    string                 `protobuf:"bytes,2,opt,name=principal_id,json=principalId,proto3"`
+	xxx_hidden_PrincipalType  PrincipalType          `protobuf:"varint,3,opt,name=principal_type,json=principalType,proto3,enum=files.v1.PrincipalType"`
+	xxx_hidden_Folder         string                 `protobuf:"bytes,4,opt,name=folder,proto3"`
+	xxx_hidden_IdempotencyKey string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *RevokeAccessRequest) Reset() {
+	*x = RevokeAccessRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAccessRequest) ProtoMessage() {}
+
+func (x *RevokeAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RevokeAccessRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *RevokeAccessRequest) GetPrincipalId() string {
+	if x != nil {
+		return x.xxx_hidden_PrincipalId
+	}
+	return ""
+}
+
+func (x *RevokeAccessRequest) GetPrincipalType() PrincipalType {
+	if x != nil {
+		return x.xxx_hidden_PrincipalType
+	}
+	return PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED
+}
+
+func (x *RevokeAccessRequest) GetFolder() string {
+	if x != nil {
+		return x.xxx_hidden_Folder
+	}
+	return ""
+}
+
+func (x *RevokeAccessRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *RevokeAccessRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *RevokeAccessRequest) SetPrincipalId(v string) {
+	x.xxx_hidden_PrincipalId = v
+}
+
+func (x *RevokeAccessRequest) SetPrincipalType(v PrincipalType) {
+	x.xxx_hidden_PrincipalType = v
+}
+
+func (x *RevokeAccessRequest) SetFolder(v string) {
+	x.xxx_hidden_Folder = v
+}
+
+func (x *RevokeAccessRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+type RevokeAccessRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to revoke access from.
+	MediaId string
+	// Principal ID to revoke.
+	// Cannot revoke owner (returns error).
+	PrincipalId string
+	// Type of the principal. If not specified, the server infers it from
+	// the principal_id prefix, defaulting to a user.
+	PrincipalType PrincipalType
+	// Folder of the caller's files to revoke access from, instead of media_id.
+	Folder string
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 RevokeAccessRequest_builder) Build() *RevokeAccessRequest {
+	m0 := &RevokeAccessRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_PrincipalId = b.PrincipalId
+	x.xxx_hidden_PrincipalType = b.PrincipalType
+	x.xxx_hidden_Folder = b.Folder
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type RevokeAccessResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Success bool                   `protobuf:"varint,1,opt,name=success,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RevokeAccessResponse) Reset() {
+	*x = RevokeAccessResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAccessResponse) ProtoMessage() {}
+
+func (x *RevokeAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RevokeAccessResponse) GetSuccess() bool {
+	if x != nil {
+		return x.xxx_hidden_Success
+	}
+	return false
+}
+
+func (x *RevokeAccessResponse) SetSuccess(v bool) {
+	x.xxx_hidden_Success = v
+}
+
+type RevokeAccessResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Whether the revocation was applied.
+	// False if grant not found or cannot revoke owner.
+	Success bool
+}
+
+func (b0 RevokeAccessResponse_builder) Build() *RevokeAccessResponse {
+	m0 := &RevokeAccessResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Success = b.Success
+	return m0
+}
+
+type ListAccessRequest struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId    string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_FilterRole AccessRole             `protobuf:"varint,2,opt,name=filter_role,json=filterRole,proto3,enum=files.v1.AccessRole"`
+	xxx_hidden_Cursor     *v1.PageCursor         `protobuf:"bytes,3,opt,name=cursor,proto3"`
+	xxx_hidden_Folder     string                 `protobuf:"bytes,4,opt,name=folder,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ListAccessRequest) Reset() {
+	*x = ListAccessRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessRequest) ProtoMessage() {}
+
+func (x *ListAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListAccessRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *ListAccessRequest) GetFilterRole() AccessRole {
+	if x != nil {
+		return x.xxx_hidden_FilterRole
+	}
+	return AccessRole_ACCESS_ROLE_UNSPECIFIED
+}
+
+func (x *ListAccessRequest) GetCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_Cursor
+	}
+	return nil
+}
+
+func (x *ListAccessRequest) GetFolder() string {
+	if x != nil {
+		return x.xxx_hidden_Folder
+	}
+	return ""
+}
+
+func (x *ListAccessRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *ListAccessRequest) SetFilterRole(v AccessRole) {
+	x.xxx_hidden_FilterRole = v
+}
+
+func (x *ListAccessRequest) SetCursor(v *v1.PageCursor) {
+	x.xxx_hidden_Cursor = v
+}
+
+func (x *ListAccessRequest) SetFolder(v string) {
+	x.xxx_hidden_Folder = v
+}
+
+func (x *ListAccessRequest) HasCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Cursor != nil
+}
+
+func (x *ListAccessRequest) ClearCursor() {
+	x.xxx_hidden_Cursor = nil
+}
+
+type ListAccessRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to list grants for.
+	MediaId string
+	// Filter by role (optional).
+	FilterRole AccessRole
+	// Pagination using common PageCursor.
+	Cursor *v1.PageCursor
+	// Folder of the caller's files to list grants for, instead of media_id.
+	Folder string
+}
+
+func (b0 ListAccessRequest_builder) Build() *ListAccessRequest {
+	m0 := &ListAccessRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_FilterRole = b.FilterRole
+	x.xxx_hidden_Cursor = b.Cursor
+	x.xxx_hidden_Folder = b.Folder
+	return m0
+}
+
+type ListAccessResponse struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Grants     *[]*AccessGrant        `protobuf:"bytes,1,rep,name=grants,proto3"`
+	xxx_hidden_NextCursor *v1.PageCursor         `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ListAccessResponse) Reset() {
+	*x = ListAccessResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccessResponse) ProtoMessage() {}
+
+func (x *ListAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListAccessResponse) GetGrants() []*AccessGrant {
+	if x != nil {
+		if x.xxx_hidden_Grants != nil {
+			return *x.xxx_hidden_Grants
+		}
+	}
+	return nil
+}
+
+func (x *ListAccessResponse) GetNextCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_NextCursor
+	}
+	return nil
+}
+
+func (x *ListAccessResponse) SetGrants(v []*AccessGrant) {
+	x.xxx_hidden_Grants = &v
+}
+
+func (x *ListAccessResponse) SetNextCursor(v *v1.PageCursor) {
+	x.xxx_hidden_NextCursor = v
+}
+
+func (x *ListAccessResponse) HasNextCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_NextCursor != nil
+}
+
+func (x *ListAccessResponse) ClearNextCursor() {
+	x.xxx_hidden_NextCursor = nil
+}
+
+type ListAccessResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Access grants for this media or folder: direct grants first, then those
+	// inherited from enclosing folders, nearest first.
+	Grants []*AccessGrant
+	// Pagination cursor for next page.
+	NextCursor *v1.PageCursor
+}
+
+func (b0 ListAccessResponse_builder) Build() *ListAccessResponse {
+	m0 := &ListAccessResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Grants = &b.Grants
+	x.xxx_hidden_NextCursor = b.NextCursor
+	return m0
+}
+
+type ListSharedWithMeRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cursor      *v1.PageCursor         `protobuf:"bytes,1,opt,name=cursor,proto3"`
+	xxx_hidden_SharedBy    string                 `protobuf:"bytes,2,opt,name=shared_by,json=sharedBy,proto3"`
+	xxx_hidden_ContentType string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ListSharedWithMeRequest) Reset() {
+	*x = ListSharedWithMeRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedWithMeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedWithMeRequest) ProtoMessage() {}
+
+func (x *ListSharedWithMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListSharedWithMeRequest) GetCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_Cursor
+	}
+	return nil
+}
+
+func (x *ListSharedWithMeRequest) GetSharedBy() string {
+	if x != nil {
+		return x.xxx_hidden_SharedBy
+	}
+	return ""
+}
+
+func (x *ListSharedWithMeRequest) GetContentType() string {
+	if x != nil {
+		return x.xxx_hidden_ContentType
+	}
+	return ""
+}
+
+func (x *ListSharedWithMeRequest) SetCursor(v *v1.PageCursor) {
+	x.xxx_hidden_Cursor = v
+}
+
+func (x *ListSharedWithMeRequest) SetSharedBy(v string) {
+	x.xxx_hidden_SharedBy = v
+}
+
+func (x *ListSharedWithMeRequest) SetContentType(v string) {
+	x.xxx_hidden_ContentType = v
+}
+
+func (x *ListSharedWithMeRequest) HasCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Cursor != nil
+}
+
+func (x *ListSharedWithMeRequest) ClearCursor() {
+	x.xxx_hidden_Cursor = nil
+}
+
+type ListSharedWithMeRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Pagination using common PageCursor.
+	Cursor *v1.PageCursor
+	// Filter by the profile that shared the file (optional).
+	SharedBy string
+	// Filter by content type prefix (optional).
+	// Example: "image/" matches all image types.
+	ContentType string
+}
+
+func (b0 ListSharedWithMeRequest_builder) Build() *ListSharedWithMeRequest {
+	m0 := &ListSharedWithMeRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Cursor = b.Cursor
+	x.xxx_hidden_SharedBy = b.SharedBy
+	x.xxx_hidden_ContentType = b.ContentType
+	return m0
+}
+
+// SharedItem is a file shared with the caller by someone else.
+type SharedItem struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Media *MediaMetadata         `protobuf:"bytes,1,opt,name=media,proto3"`
+	xxx_hidden_Grant *AccessGrant           `protobuf:"bytes,2,opt,name=grant,proto3"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SharedItem) Reset() {
+	*x = SharedItem{}
+	mi := &file_files_v1_files_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedItem) ProtoMessage() {}
+
+func (x *SharedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SharedItem) GetMedia() *MediaMetadata {
+	if x != nil {
+		return x.xxx_hidden_Media
+	}
+	return nil
+}
+
+func (x *SharedItem) GetGrant() *AccessGrant {
+	if x != nil {
+		return x.xxx_hidden_Grant
+	}
+	return nil
+}
+
+func (x *SharedItem) SetMedia(v *MediaMetadata) {
+	x.xxx_hidden_Media = v
+}
+
+func (x *SharedItem) SetGrant(v *AccessGrant) {
+	x.xxx_hidden_Grant = v
+}
+
+func (x *SharedItem) HasMedia() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Media != nil
+}
+
+func (x *SharedItem) HasGrant() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Grant != nil
+}
+
+func (x *SharedItem) ClearMedia() {
+	x.xxx_hidden_Media = nil
+}
+
+func (x *SharedItem) ClearGrant() {
+	x.xxx_hidden_Grant = nil
+}
+
+type SharedItem_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Metadata of the shared file.
+	Media *MediaMetadata
+	// The grant through which the caller holds its strongest role on the
+	// file: the caller itself, or a group or tenant partition it belongs to,
+	// with who shared the file and when.
+	Grant *AccessGrant
+}
+
+func (b0 SharedItem_builder) Build() *SharedItem {
+	m0 := &SharedItem{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Media = b.Media
+	x.xxx_hidden_Grant = b.Grant
+	return m0
+}
+
+type ListSharedWithMeResponse struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Items      *[]*SharedItem         `protobuf:"bytes,1,rep,name=items,proto3"`
+	xxx_hidden_NextCursor *v1.PageCursor         `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ListSharedWithMeResponse) Reset() {
+	*x = ListSharedWithMeResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedWithMeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedWithMeResponse) ProtoMessage() {}
+
+func (x *ListSharedWithMeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListSharedWithMeResponse) GetItems() []*SharedItem {
+	if x != nil {
+		if x.xxx_hidden_Items != nil {
+			return *x.xxx_hidden_Items
+		}
+	}
+	return nil
+}
+
+func (x *ListSharedWithMeResponse) GetNextCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_NextCursor
+	}
+	return nil
+}
+
+func (x *ListSharedWithMeResponse) SetItems(v []*SharedItem) {
+	x.xxx_hidden_Items = &v
+}
+
+func (x *ListSharedWithMeResponse) SetNextCursor(v *v1.PageCursor) {
+	x.xxx_hidden_NextCursor = v
+}
+
+func (x *ListSharedWithMeResponse) HasNextCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_NextCursor != nil
+}
+
+func (x *ListSharedWithMeResponse) ClearNextCursor() {
+	x.xxx_hidden_NextCursor = nil
+}
+
+type ListSharedWithMeResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Files shared with the caller, most recently shared first.
+	Items []*SharedItem
+	// Pagination cursor for next page.
+	NextCursor *v1.PageCursor
+}
+
+func (b0 ListSharedWithMeResponse_builder) Build() *ListSharedWithMeResponse {
+	m0 := &ListSharedWithMeResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Items = &b.Items
+	x.xxx_hidden_NextCursor = b.NextCursor
+	return m0
+}
+
+type TransferOwnershipRequest struct {
+	state                        protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId           string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_Folder            string                 `protobuf:"bytes,2,opt,name=folder,proto3"`
+	xxx_hidden_AllFiles          bool                   `protobuf:"varint,3,opt,name=all_files,json=allFiles,proto3"`
+	xxx_hidden_NewOwnerId        string                 `protobuf:"bytes,4,opt,name=new_owner_id,json=newOwnerId,proto3"`
+	xxx_hidden_PreviousOwnerRole AccessRole             `protobuf:"varint,5,opt,name=previous_owner_role,json=previousOwnerRole,proto3,enum=files.v1.AccessRole"`
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *TransferOwnershipRequest) Reset() {
+	*x = TransferOwnershipRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferOwnershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferOwnershipRequest) ProtoMessage() {}
+
+func (x *TransferOwnershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TransferOwnershipRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *TransferOwnershipRequest) GetFolder() string {
+	if x != nil {
+		return x.xxx_hidden_Folder
+	}
+	return ""
+}
+
+func (x *TransferOwnershipRequest) GetAllFiles() bool {
+	if x != nil {
+		return x.xxx_hidden_AllFiles
+	}
+	return false
+}
+
+func (x *TransferOwnershipRequest) GetNewOwnerId() string {
+	if x != nil {
+		return x.xxx_hidden_NewOwnerId
+	}
+	return ""
+}
+
+func (x *TransferOwnershipRequest) GetPreviousOwnerRole() AccessRole {
+	if x != nil {
+		return x.xxx_hidden_PreviousOwnerRole
+	}
+	return AccessRole_ACCESS_ROLE_UNSPECIFIED
+}
+
+func (x *TransferOwnershipRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *TransferOwnershipRequest) SetFolder(v string) {
+	x.xxx_hidden_Folder = v
+}
+
+func (x *TransferOwnershipRequest) SetAllFiles(v bool) {
+	x.xxx_hidden_AllFiles = v
+}
+
+func (x *TransferOwnershipRequest) SetNewOwnerId(v string) {
+	x.xxx_hidden_NewOwnerId = v
+}
+
+func (x *TransferOwnershipRequest) SetPreviousOwnerRole(v AccessRole) {
+	x.xxx_hidden_PreviousOwnerRole = v
+}
+
+type TransferOwnershipRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Single file of the caller's to transfer.
+	MediaId string
+	// Folder of the caller's to transfer, with every file filed in it or
+	// below it.
+	Folder string
+	// Transfer every file the caller owns.
+	// Exactly one of media_id, folder and all_files must be set.
+	AllFiles bool
+	// Profile receiving ownership.
+	NewOwnerId string
+	// Role left to the previous owner on each transferred file (optional).
+	// Unspecified leaves the previous owner no access.
+	PreviousOwnerRole AccessRole
+}
+
+func (b0 TransferOwnershipRequest_builder) Build() *TransferOwnershipRequest {
+	m0 := &TransferOwnershipRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_Folder = b.Folder
+	x.xxx_hidden_AllFiles = b.AllFiles
+	x.xxx_hidden_NewOwnerId = b.NewOwnerId
+	x.xxx_hidden_PreviousOwnerRole = b.PreviousOwnerRole
+	return m0
+}
+
+type TransferOwnershipResponse struct {
+	state                         protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_TransferredFiles   int64                  `protobuf:"varint,1,opt,name=transferred_files,json=transferredFiles,proto3"`
+	xxx_hidden_PreviousOwnerUsage *UsageStats            `protobuf:"bytes,2,opt,name=previous_owner_usage,json=previousOwnerUsage,proto3"`
+	xxx_hidden_NewOwnerUsage      *UsageStats            `protobuf:"bytes,3,opt,name=new_owner_usage,json=newOwnerUsage,proto3"`
+	unknownFields                 protoimpl.UnknownFields
+	sizeCache                     protoimpl.SizeCache
+}
+
+func (x *TransferOwnershipResponse) Reset() {
+	*x = TransferOwnershipResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferOwnershipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferOwnershipResponse) ProtoMessage() {}
+
+func (x *TransferOwnershipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TransferOwnershipResponse) GetTransferredFiles() int64 {
+	if x != nil {
+		return x.xxx_hidden_TransferredFiles
+	}
+	return 0
+}
+
+func (x *TransferOwnershipResponse) GetPreviousOwnerUsage() *UsageStats {
+	if x != nil {
+		return x.xxx_hidden_PreviousOwnerUsage
+	}
+	return nil
+}
+
+func (x *TransferOwnershipResponse) GetNewOwnerUsage() *UsageStats {
+	if x != nil {
+		return x.xxx_hidden_NewOwnerUsage
+	}
+	return nil
+}
+
+func (x *TransferOwnershipResponse) SetTransferredFiles(v int64) {
+	x.xxx_hidden_TransferredFiles = v
+}
+
+func (x *TransferOwnershipResponse) SetPreviousOwnerUsage(v *UsageStats) {
+	x.xxx_hidden_PreviousOwnerUsage = v
+}
+
+func (x *TransferOwnershipResponse) SetNewOwnerUsage(v *UsageStats) {
+	x.xxx_hidden_NewOwnerUsage = v
+}
+
+func (x *TransferOwnershipResponse) HasPreviousOwnerUsage() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_PreviousOwnerUsage != nil
+}
+
+func (x *TransferOwnershipResponse) HasNewOwnerUsage() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_NewOwnerUsage != nil
+}
+
+func (x *TransferOwnershipResponse) ClearPreviousOwnerUsage() {
+	x.xxx_hidden_PreviousOwnerUsage = nil
+}
+
+func (x *TransferOwnershipResponse) ClearNewOwnerUsage() {
+	x.xxx_hidden_NewOwnerUsage = nil
+}
+
+type TransferOwnershipResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Number of files transferred, not counting their thumbnails.
+	TransferredFiles int64
+	// Usage of the previous owner after the transfer.
+	PreviousOwnerUsage *UsageStats
+	// Usage of the new owner after the transfer.
+	NewOwnerUsage *UsageStats
+}
+
+func (b0 TransferOwnershipResponse_builder) Build() *TransferOwnershipResponse {
+	m0 := &TransferOwnershipResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_TransferredFiles = b.TransferredFiles
+	x.xxx_hidden_PreviousOwnerUsage = b.PreviousOwnerUsage
+	x.xxx_hidden_NewOwnerUsage = b.NewOwnerUsage
+	return m0
+}
+
+// ShareLink is a link giving anyone holding its token access to one file
+// without an account.
+type ShareLink struct {
+	state                        protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_LinkId            string                 `protobuf:"bytes,1,opt,name=link_id,json=linkId,proto3"`
+	xxx_hidden_MediaId           string                 `protobuf:"bytes,2,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_Permission        ShareLinkPermission    `protobuf:"varint,3,opt,name=permission,proto3,enum=files.v1.ShareLinkPermission"`
+	xxx_hidden_State             ShareLinkState         `protobuf:"varint,4,opt,name=state,proto3,enum=files.v1.ShareLinkState"`
+	xxx_hidden_ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3"`
+	xxx_hidden_PasswordProtected bool                   `protobuf:"varint,6,opt,name=password_protected,json=passwordProtected,proto3"`
+	xxx_hidden_MaxDownloads      int32                  `protobuf:"varint,7,opt,name=max_downloads,json=maxDownloads,proto3"`
+	xxx_hidden_DownloadCount     int32                  `protobuf:"varint,8,opt,name=download_count,json=downloadCount,proto3"`
+	xxx_hidden_CreatedBy         string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3"`
+	xxx_hidden_CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3"`
+	xxx_hidden_RevokedAt         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=revoked_at,json=revokedAt,proto3"`
+	xxx_hidden_LastAccessedAt    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=last_accessed_at,json=lastAccessedAt,proto3"`
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *ShareLink) Reset() {
+	*x = ShareLink{}
+	mi := &file_files_v1_files_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareLink) ProtoMessage() {}
+
+func (x *ShareLink) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ShareLink) GetLinkId() string {
+	if x != nil {
+		return x.xxx_hidden_LinkId
+	}
+	return ""
+}
+
+func (x *ShareLink) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *ShareLink) GetPermission() ShareLinkPermission {
+	if x != nil {
+		return x.xxx_hidden_Permission
+	}
+	return ShareLinkPermission_SHARE_LINK_PERMISSION_UNSPECIFIED
+}
+
+func (x *ShareLink) GetState() ShareLinkState {
+	if x != nil {
+		return x.xxx_hidden_State
+	}
+	return ShareLinkState_SHARE_LINK_STATE_UNSPECIFIED
+}
+
+func (x *ShareLink) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *ShareLink) GetPasswordProtected() bool {
+	if x != nil {
+		return x.xxx_hidden_PasswordProtected
+	}
+	return false
+}
+
+func (x *ShareLink) GetMaxDownloads() int32 {
+	if x != nil {
+		return x.xxx_hidden_MaxDownloads
+	}
+	return 0
+}
+
+func (x *ShareLink) GetDownloadCount() int32 {
+	if x != nil {
+		return x.xxx_hidden_DownloadCount
+	}
+	return 0
+}
+
+func (x *ShareLink) GetCreatedBy() string {
+	if x != nil {
+		return x.xxx_hidden_CreatedBy
+	}
+	return ""
+}
+
+func (x *ShareLink) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *ShareLink) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_RevokedAt
+	}
+	return nil
+}
+
+func (x *ShareLink) GetLastAccessedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_LastAccessedAt
+	}
+	return nil
+}
+
+func (x *ShareLink) SetLinkId(v string) {
+	x.xxx_hidden_LinkId = v
+}
+
+func (x *ShareLink) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *ShareLink) SetPermission(v ShareLinkPermission) {
+	x.xxx_hidden_Permission = v
+}
+
+func (x *ShareLink) SetState(v ShareLinkState) {
+	x.xxx_hidden_State = v
+}
+
+func (x *ShareLink) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *ShareLink) SetPasswordProtected(v bool) {
+	x.xxx_hidden_PasswordProtected = v
+}
+
+func (x *ShareLink) SetMaxDownloads(v int32) {
+	x.xxx_hidden_MaxDownloads = v
+}
+
+func (x *ShareLink) SetDownloadCount(v int32) {
+	x.xxx_hidden_DownloadCount = v
+}
+
+func (x *ShareLink) SetCreatedBy(v string) {
+	x.xxx_hidden_CreatedBy = v
+}
+
+func (x *ShareLink) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *ShareLink) SetRevokedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_RevokedAt = v
+}
+
+func (x *ShareLink) SetLastAccessedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_LastAccessedAt = v
+}
+
+func (x *ShareLink) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *ShareLink) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *ShareLink) HasRevokedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_RevokedAt != nil
+}
+
+func (x *ShareLink) HasLastAccessedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_LastAccessedAt != nil
+}
+
+func (x *ShareLink) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+func (x *ShareLink) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+func (x *ShareLink) ClearRevokedAt() {
+	x.xxx_hidden_RevokedAt = nil
+}
+
+func (x *ShareLink) ClearLastAccessedAt() {
+	x.xxx_hidden_LastAccessedAt = nil
+}
+
+type ShareLink_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Unique link ID.
+	LinkId string
+	// Shared media.
+	MediaId string
+	// What the link allows.
+	Permission ShareLinkPermission
+	// Whether the link can still be used.
+	State ShareLinkState
+	// Time the link stops working; unset links do not expire.
+	ExpiresAt *timestamppb.Timestamp
+	// Whether a password is required.
+	PasswordProtected bool
+	// Downloads allowed; 0 allows any number.
+	MaxDownloads int32
+	// Times the content was served through the link.
+	DownloadCount int32
+	// Profile that created the link.
+	CreatedBy string
+	// Time the link was created.
+	CreatedAt *timestamppb.Timestamp
+	// Time the link was revoked.
+	RevokedAt *timestamppb.Timestamp
+	// Time the link was last used.
+	LastAccessedAt *timestamppb.Timestamp
+}
+
+func (b0 ShareLink_builder) Build() *ShareLink {
+	m0 := &ShareLink{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_LinkId = b.LinkId
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_Permission = b.Permission
+	x.xxx_hidden_State = b.State
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	x.xxx_hidden_PasswordProtected = b.PasswordProtected
+	x.xxx_hidden_MaxDownloads = b.MaxDownloads
+	x.xxx_hidden_DownloadCount = b.DownloadCount
+	x.xxx_hidden_CreatedBy = b.CreatedBy
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	x.xxx_hidden_RevokedAt = b.RevokedAt
+	x.xxx_hidden_LastAccessedAt = b.LastAccessedAt
+	return m0
+}
+
+// CreateShareLinkRequest creates a share link for a file the caller owns.
+//
+// The link's token is only returned in the response; the server keeps a
+// hash of it.
+type CreateShareLinkRequest struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId      string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_Permission   ShareLinkPermission    `protobuf:"varint,2,opt,name=permission,proto3,enum=files.v1.ShareLinkPermission"`
+	xxx_hidden_ExpiresAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3"`
+	xxx_hidden_Password     string                 `protobuf:"bytes,4,opt,name=password,proto3"`
+	xxx_hidden_MaxDownloads int32                  `protobuf:"varint,5,opt,name=max_downloads,json=maxDownloads,proto3"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *CreateShareLinkRequest) Reset() {
+	*x = CreateShareLinkRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareLinkRequest) ProtoMessage() {}
+
+func (x *CreateShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateShareLinkRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *CreateShareLinkRequest) GetPermission() ShareLinkPermission {
+	if x != nil {
+		return x.xxx_hidden_Permission
+	}
+	return ShareLinkPermission_SHARE_LINK_PERMISSION_UNSPECIFIED
+}
+
+func (x *CreateShareLinkRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateShareLinkRequest) GetPassword() string {
+	if x != nil {
+		return x.xxx_hidden_Password
+	}
+	return ""
+}
+
+func (x *CreateShareLinkRequest) GetMaxDownloads() int32 {
+	if x != nil {
+		return x.xxx_hidden_MaxDownloads
+	}
+	return 0
+}
+
+func (x *CreateShareLinkRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *CreateShareLinkRequest) SetPermission(v ShareLinkPermission) {
+	x.xxx_hidden_Permission = v
+}
+
+func (x *CreateShareLinkRequest) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *CreateShareLinkRequest) SetPassword(v string) {
+	x.xxx_hidden_Password = v
+}
+
+func (x *CreateShareLinkRequest) SetMaxDownloads(v int32) {
+	x.xxx_hidden_MaxDownloads = v
+}
+
+func (x *CreateShareLinkRequest) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *CreateShareLinkRequest) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+type CreateShareLinkRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media to share.
+	MediaId string
+	// What the link allows; unspecified allows viewing only.
+	Permission ShareLinkPermission
+	// Time the link stops working (optional).
+	ExpiresAt *timestamppb.Timestamp
+	// Password required to use the link (optional).
+	Password string
+	// Downloads allowed; 0 allows any number.
+	MaxDownloads int32
+}
+
+func (b0 CreateShareLinkRequest_builder) Build() *CreateShareLinkRequest {
+	m0 := &CreateShareLinkRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_Permission = b.Permission
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	x.xxx_hidden_Password = b.Password
+	x.xxx_hidden_MaxDownloads = b.MaxDownloads
+	return m0
+}
+
+type CreateShareLinkResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Link  *ShareLink             `protobuf:"bytes,1,opt,name=link,proto3"`
+	xxx_hidden_Token string                 `protobuf:"bytes,2,opt,name=token,proto3"`
+	xxx_hidden_Url   string                 `protobuf:"bytes,3,opt,name=url,proto3"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateShareLinkResponse) Reset() {
+	*x = CreateShareLinkResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareLinkResponse) ProtoMessage() {}
+
+func (x *CreateShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateShareLinkResponse) GetLink() *ShareLink {
+	if x != nil {
+		return x.xxx_hidden_Link
+	}
+	return nil
+}
+
+func (x *CreateShareLinkResponse) GetToken() string {
+	if x != nil {
+		return x.xxx_hidden_Token
+	}
+	return ""
+}
+
+func (x *CreateShareLinkResponse) GetUrl() string {
+	if x != nil {
+		return x.xxx_hidden_Url
+	}
+	return ""
+}
+
+func (x *CreateShareLinkResponse) SetLink(v *ShareLink) {
+	x.xxx_hidden_Link = v
+}
+
+func (x *CreateShareLinkResponse) SetToken(v string) {
+	x.xxx_hidden_Token = v
+}
+
+func (x *CreateShareLinkResponse) SetUrl(v string) {
+	x.xxx_hidden_Url = v
+}
+
+func (x *CreateShareLinkResponse) HasLink() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Link != nil
+}
+
+func (x *CreateShareLinkResponse) ClearLink() {
+	x.xxx_hidden_Link = nil
+}
+
+type CreateShareLinkResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The created link.
+	Link *ShareLink
+	// Opaque token identifying the link. Shown only once.
+	Token string
+	// URL serving the shared file.
+	Url string
+}
+
+func (b0 CreateShareLinkResponse_builder) Build() *CreateShareLinkResponse {
+	m0 := &CreateShareLinkResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Link = b.Link
+	x.xxx_hidden_Token = b.Token
+	x.xxx_hidden_Url = b.Url
+	return m0
+}
+
+type ListShareLinksRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId        string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_IncludeRevoked bool                   `protobuf:"varint,2,opt,name=include_revoked,json=includeRevoked,proto3"`
+	xxx_hidden_Cursor         *v1.PageCursor         `protobuf:"bytes,3,opt,name=cursor,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *ListShareLinksRequest) Reset() {
+	*x = ListShareLinksRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareLinksRequest) ProtoMessage() {}
+
+func (x *ListShareLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListShareLinksRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *ListShareLinksRequest) GetIncludeRevoked() bool {
+	if x != nil {
+		return x.xxx_hidden_IncludeRevoked
+	}
+	return false
+}
+
+func (x *ListShareLinksRequest) GetCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_Cursor
+	}
+	return nil
+}
+
+func (x *ListShareLinksRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *ListShareLinksRequest) SetIncludeRevoked(v bool) {
+	x.xxx_hidden_IncludeRevoked = v
+}
+
+func (x *ListShareLinksRequest) SetCursor(v *v1.PageCursor) {
+	x.xxx_hidden_Cursor = v
+}
+
+func (x *ListShareLinksRequest) HasCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Cursor != nil
+}
+
+func (x *ListShareLinksRequest) ClearCursor() {
+	x.xxx_hidden_Cursor = nil
+}
+
+type ListShareLinksRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media whose links to list.
+	MediaId string
+	// Include revoked links.
+	IncludeRevoked bool
+	// Pagination cursor.
+	Cursor *v1.PageCursor
+}
+
+func (b0 ListShareLinksRequest_builder) Build() *ListShareLinksRequest {
+	m0 := &ListShareLinksRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_IncludeRevoked = b.IncludeRevoked
+	x.xxx_hidden_Cursor = b.Cursor
+	return m0
+}
+
+type ListShareLinksResponse struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Links      *[]*ShareLink          `protobuf:"bytes,1,rep,name=links,proto3"`
+	xxx_hidden_NextCursor *v1.PageCursor         `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ListShareLinksResponse) Reset() {
+	*x = ListShareLinksResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareLinksResponse) ProtoMessage() {}
+
+func (x *ListShareLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListShareLinksResponse) GetLinks() []*ShareLink {
+	if x != nil {
+		if x.xxx_hidden_Links != nil {
+			return *x.xxx_hidden_Links
+		}
+	}
+	return nil
+}
+
+func (x *ListShareLinksResponse) GetNextCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_NextCursor
+	}
+	return nil
+}
+
+func (x *ListShareLinksResponse) SetLinks(v []*ShareLink) {
+	x.xxx_hidden_Links = &v
+}
+
+func (x *ListShareLinksResponse) SetNextCursor(v *v1.PageCursor) {
+	x.xxx_hidden_NextCursor = v
+}
+
+func (x *ListShareLinksResponse) HasNextCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_NextCursor != nil
+}
+
+func (x *ListShareLinksResponse) ClearNextCursor() {
+	x.xxx_hidden_NextCursor = nil
+}
+
+type ListShareLinksResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Links of the media, newest first.
+	Links []*ShareLink
+	// Pagination cursor for next page.
+	NextCursor *v1.PageCursor
+}
+
+func (b0 ListShareLinksResponse_builder) Build() *ListShareLinksResponse {
+	m0 := &ListShareLinksResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Links = &b.Links
+	x.xxx_hidden_NextCursor = b.NextCursor
+	return m0
+}
+
+type RevokeShareLinkRequest struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_LinkId string                 `protobuf:"bytes,1,opt,name=link_id,json=linkId,proto3"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RevokeShareLinkRequest) Reset() {
+	*x = RevokeShareLinkRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareLinkRequest) ProtoMessage() {}
+
+func (x *RevokeShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RevokeShareLinkRequest) GetLinkId() string {
+	if x != nil {
+		return x.xxx_hidden_LinkId
+	}
+	return ""
+}
+
+func (x *RevokeShareLinkRequest) SetLinkId(v string) {
+	x.xxx_hidden_LinkId = v
+}
+
+type RevokeShareLinkRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Link to revoke.
+	LinkId string
+}
+
+func (b0 RevokeShareLinkRequest_builder) Build() *RevokeShareLinkRequest {
+	m0 := &RevokeShareLinkRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_LinkId = b.LinkId
+	return m0
+}
+
+type RevokeShareLinkResponse struct {
+	state           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Link *ShareLink             `protobuf:"bytes,1,opt,name=link,proto3"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RevokeShareLinkResponse) Reset() {
+	*x = RevokeShareLinkResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareLinkResponse) ProtoMessage() {}
+
+func (x *RevokeShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RevokeShareLinkResponse) GetLink() *ShareLink {
+	if x != nil {
+		return x.xxx_hidden_Link
+	}
+	return nil
+}
+
+func (x *RevokeShareLinkResponse) SetLink(v *ShareLink) {
+	x.xxx_hidden_Link = v
+}
+
+func (x *RevokeShareLinkResponse) HasLink() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Link != nil
+}
+
+func (x *RevokeShareLinkResponse) ClearLink() {
+	x.xxx_hidden_Link = nil
+}
+
+type RevokeShareLinkResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The revoked link.
+	Link *ShareLink
+}
+
+func (b0 RevokeShareLinkResponse_builder) Build() *RevokeShareLinkResponse {
+	m0 := &RevokeShareLinkResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Link = b.Link
+	return m0
+}
+
+// GetContentThumbnailRequest generates a thumbnail from media.
+//
+// Requirements:
+//   - Media must be an image or video
+//   - For videos, keyframe extraction is used
+//
+// Animated Thumbnails:
+//
+//	Set animated=true to prefer animated versions (GIF, WebP).
+//	Falls back to static if unavailable.
+//
+// Caching:
+//
+//	Thumbnails are cached server-side.
+//	Same parameters return cached result.
+type GetContentThumbnailRequest struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId     string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_Width       int32                  `protobuf:"varint,2,opt,name=width,proto3"`
+	xxx_hidden_Height      int32                  `protobuf:"varint,3,opt,name=height,proto3"`
+	xxx_hidden_Method      ThumbnailMethod        `protobuf:"varint,4,opt,name=method,proto3,enum=files.v1.ThumbnailMethod"`
+	xxx_hidden_TimeoutMs   int64                  `protobuf:"varint,5,opt,name=timeout_ms,json=timeoutMs,proto3"`
+	xxx_hidden_Animated    bool                   `protobuf:"varint,6,opt,name=animated,proto3"`
+	xxx_hidden_Rotate      int32                  `protobuf:"varint,7,opt,name=rotate,proto3"`
+	xxx_hidden_Format      ImageFormat            `protobuf:"varint,8,opt,name=format,proto3,enum=files.v1.ImageFormat"`
+	xxx_hidden_Quality     int32                  `protobuf:"varint,9,opt,name=quality,proto3"`
+	xxx_hidden_FocalX      float32                `protobuf:"fixed32,10,opt,name=focal_x,json=focalX,proto3,oneof"`
+	xxx_hidden_FocalY      float32                `protobuf:"fixed32,11,opt,name=focal_y,json=focalY,proto3,oneof"`
+	xxx_hidden_Dpr         float32                `protobuf:"fixed32,12,opt,name=dpr,proto3"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetContentThumbnailRequest) Reset() {
+	*x = GetContentThumbnailRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContentThumbnailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContentThumbnailRequest) ProtoMessage() {}
+
+func (x *GetContentThumbnailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetContentThumbnailRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *GetContentThumbnailRequest) GetWidth() int32 {
+	if x != nil {
+		return x.xxx_hidden_Width
+	}
+	return 0
+}
+
+func (x *GetContentThumbnailRequest) GetHeight() int32 {
+	if x != nil {
+		return x.xxx_hidden_Height
+	}
+	return 0
+}
+
+func (x *GetContentThumbnailRequest) GetMethod() ThumbnailMethod {
+	if x != nil {
+		return x.xxx_hidden_Method
+	}
+	return ThumbnailMethod_SCALE
+}
+
+func (x *GetContentThumbnailRequest) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.xxx_hidden_TimeoutMs
+	}
+	return 0
+}
+
+func (x *GetContentThumbnailRequest) GetAnimated() bool {
+	if x != nil {
+		return x.xxx_hidden_Animated
+	}
+	return false
+}
+
+func (x *GetContentThumbnailRequest) GetRotate() int32 {
+	if x != nil {
+		return x.xxx_hidden_Rotate
+	}
+	return 0
+}
+
+func (x *GetContentThumbnailRequest) GetFormat() ImageFormat {
+	if x != nil {
+		return x.xxx_hidden_Format
+	}
+	return ImageFormat_IMAGE_FORMAT_UNSPECIFIED
+}
+
+func (x *GetContentThumbnailRequest) GetQuality() int32 {
+	if x != nil {
+		return x.xxx_hidden_Quality
+	}
+	return 0
+}
+
+func (x *GetContentThumbnailRequest) GetFocalX() float32 {
+	if x != nil {
+		return x.xxx_hidden_FocalX
+	}
+	return 0
+}
+
+func (x *GetContentThumbnailRequest) GetFocalY() float32 {
+	if x != nil {
+		return x.xxx_hidden_FocalY
+	}
+	return 0
+}
+
+func (x *GetContentThumbnailRequest) GetDpr() float32 {
+	if x != nil {
+		return x.xxx_hidden_Dpr
+	}
+	return 0
+}
+
+func (x *GetContentThumbnailRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *GetContentThumbnailRequest) SetWidth(v int32) {
+	x.xxx_hidden_Width = v
+}
+
+func (x *GetContentThumbnailRequest) SetHeight(v int32) {
+	x.xxx_hidden_Height = v
+}
+
+func (x *GetContentThumbnailRequest) SetMethod(v ThumbnailMethod) {
+	x.xxx_hidden_Method = v
+}
+
+func (x *GetContentThumbnailRequest) SetTimeoutMs(v int64) {
+	x.xxx_hidden_TimeoutMs = v
+}
+
+func (x *GetContentThumbnailRequest) SetAnimated(v bool) {
+	x.xxx_hidden_Animated = v
+}
+
+func (x *GetContentThumbnailRequest) SetRotate(v int32) {
+	x.xxx_hidden_Rotate = v
+}
+
+func (x *GetContentThumbnailRequest) SetFormat(v ImageFormat) {
+	x.xxx_hidden_Format = v
+}
+
+func (x *GetContentThumbnailRequest) SetQuality(v int32) {
+	x.xxx_hidden_Quality = v
+}
+
+func (x *GetContentThumbnailRequest) SetFocalX(v float32) {
+	x.xxx_hidden_FocalX = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 12)
+}
+
+func (x *GetContentThumbnailRequest) SetFocalY(v float32) {
+	x.xxx_hidden_FocalY = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 10, 12)
+}
+
+func (x *GetContentThumbnailRequest) SetDpr(v float32) {
+	x.xxx_hidden_Dpr = v
+}
+
+func (x *GetContentThumbnailRequest) HasFocalX() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *GetContentThumbnailRequest) HasFocalY() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 10)
+}
+
+func (x *GetContentThumbnailRequest) ClearFocalX() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_FocalX = 0
+}
+
+func (x *GetContentThumbnailRequest) ClearFocalY() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 10)
+	x.xxx_hidden_FocalY = 0
+}
+
+type GetContentThumbnailRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to generate thumbnail from.
+	MediaId string
+	// Desired width in pixels.
+	// Actual size may differ based on aspect ratio and method.
+	Width int32
+	// Desired height in pixels.
+	Height int32
+	// Resizing method.
+	// SCALE: fit within dimensions, preserve ratio
+	// CROP: exact dimensions, may lose edge content
+	Method ThumbnailMethod
+	// Timeout in milliseconds.
+	TimeoutMs int64
+	// Prefer animated thumbnail if available.
+	// Applies to GIF, WebP, video keyframes.
+	Animated bool
+	// Clockwise rotation in degrees, applied after resizing.
+	Rotate int32
+	// Output encoding. Defaults to JPEG.
+	Format ImageFormat
+	// JPEG quality from 1 to 100. 0 uses the server default (85). Other
+	// formats are lossless and reject a quality.
+	Quality int32
+	// Horizontal focal point for CROP as a fraction of the source width.
+	// Defaults to 0.5 (centre).
+	FocalX *float32
+	// Vertical focal point for CROP as a fraction of the source height.
+	// Defaults to 0.5 (centre).
+	FocalY *float32
+	// Device pixel ratio; the rendered size is width and height multiplied
+	// by this value. 0 means 1.
+	Dpr float32
+}
+
+func (b0 GetContentThumbnailRequest_builder) Build() *GetContentThumbnailRequest {
+	m0 := &GetContentThumbnailRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_Width = b.Width
+	x.xxx_hidden_Height = b.Height
+	x.xxx_hidden_Method = b.Method
+	x.xxx_hidden_TimeoutMs = b.TimeoutMs
+	x.xxx_hidden_Animated = b.Animated
+	x.xxx_hidden_Rotate = b.Rotate
+	x.xxx_hidden_Format = b.Format
+	x.xxx_hidden_Quality = b.Quality
+	if b.FocalX != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 12)
+		x.xxx_hidden_FocalX = *b.FocalX
+	}
+	if b.FocalY != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 10, 12)
+		x.xxx_hidden_FocalY = *b.FocalY
+	}
+	x.xxx_hidden_Dpr = b.Dpr
+	return m0
+}
+
+type GetContentThumbnailResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Content     []byte                 `protobuf:"bytes,1,opt,name=content,proto3"`
+	xxx_hidden_Metadata    *MediaMetadata         `protobuf:"bytes,2,opt,name=metadata,proto3"`
+	xxx_hidden_ContentType string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetContentThumbnailResponse) Reset() {
+	*x = GetContentThumbnailResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContentThumbnailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContentThumbnailResponse) ProtoMessage() {}
+
+func (x *GetContentThumbnailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetContentThumbnailResponse) GetContent() []byte {
+	if x != nil {
+		return x.xxx_hidden_Content
+	}
+	return nil
+}
+
+func (x *GetContentThumbnailResponse) GetMetadata() *MediaMetadata {
+	if x != nil {
+		return x.xxx_hidden_Metadata
+	}
+	return nil
+}
+
+func (x *GetContentThumbnailResponse) GetContentType() string {
+	if x != nil {
+		return x.xxx_hidden_ContentType
+	}
+	return ""
+}
+
+func (x *GetContentThumbnailResponse) SetContent(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Content = v
+}
+
+func (x *GetContentThumbnailResponse) SetMetadata(v *MediaMetadata) {
+	x.xxx_hidden_Metadata = v
+}
+
+func (x *GetContentThumbnailResponse) SetContentType(v string) {
+	x.xxx_hidden_ContentType = v
+}
+
+func (x *GetContentThumbnailResponse) HasMetadata() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Metadata != nil
+}
+
+func (x *GetContentThumbnailResponse) ClearMetadata() {
+	x.xxx_hidden_Metadata = nil
+}
+
+type GetContentThumbnailResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Thumbnail image data.
+	Content []byte
+	// Metadata of the source media.
+	Metadata *MediaMetadata
+	// MIME type of content, which depends on the requested format.
+	ContentType string
+}
+
+func (b0 GetContentThumbnailResponse_builder) Build() *GetContentThumbnailResponse {
+	m0 := &GetContentThumbnailResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Content = b.Content
+	x.xxx_hidden_Metadata = b.Metadata
+	x.xxx_hidden_ContentType = b.ContentType
+	return m0
+}
+
+// ThumbnailStatus reports the thumbnail generation job of a media item.
+type ThumbnailStatus struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId        string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_State          ThumbnailJobState      `protobuf:"varint,2,opt,name=state,proto3,enum=files.v1.ThumbnailJobState"`
+	xxx_hidden_Attempts       int32                  `protobuf:"varint,3,opt,name=attempts,proto3"`
+	xxx_hidden_LastError      string                 `protobuf:"bytes,4,opt,name=last_error,json=lastError,proto3"`
+	xxx_hidden_NextAttemptAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=next_attempt_at,json=nextAttemptAt,proto3"`
+	xxx_hidden_CompletedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3"`
+	xxx_hidden_CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3"`
+	xxx_hidden_UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3"`
+	xxx_hidden_ThumbnailCount int32                  `protobuf:"varint,9,opt,name=thumbnail_count,json=thumbnailCount,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *ThumbnailStatus) Reset() {
+	*x = ThumbnailStatus{}
+	mi := &file_files_v1_files_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThumbnailStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThumbnailStatus) ProtoMessage() {}
+
+func (x *ThumbnailStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ThumbnailStatus) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *ThumbnailStatus) GetState() ThumbnailJobState {
+	if x != nil {
+		return x.xxx_hidden_State
+	}
+	return ThumbnailJobState_THUMBNAIL_JOB_STATE_UNSPECIFIED
+}
+
+func (x *ThumbnailStatus) GetAttempts() int32 {
+	if x != nil {
+		return x.xxx_hidden_Attempts
+	}
+	return 0
+}
+
+func (x *ThumbnailStatus) GetLastError() string {
+	if x != nil {
+		return x.xxx_hidden_LastError
+	}
+	return ""
+}
+
+func (x *ThumbnailStatus) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_NextAttemptAt
+	}
+	return nil
+}
+
+func (x *ThumbnailStatus) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CompletedAt
+	}
+	return nil
+}
+
+func (x *ThumbnailStatus) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *ThumbnailStatus) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_UpdatedAt
+	}
+	return nil
+}
+
+func (x *ThumbnailStatus) GetThumbnailCount() int32 {
+	if x != nil {
+		return x.xxx_hidden_ThumbnailCount
+	}
+	return 0
+}
+
+func (x *ThumbnailStatus) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *ThumbnailStatus) SetState(v ThumbnailJobState) {
+	x.xxx_hidden_State = v
+}
+
+func (x *ThumbnailStatus) SetAttempts(v int32) {
+	x.xxx_hidden_Attempts = v
+}
+
+func (x *ThumbnailStatus) SetLastError(v string) {
+	x.xxx_hidden_LastError = v
+}
+
+func (x *ThumbnailStatus) SetNextAttemptAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_NextAttemptAt = v
+}
+
+func (x *ThumbnailStatus) SetCompletedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CompletedAt = v
+}
+
+func (x *ThumbnailStatus) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *ThumbnailStatus) SetUpdatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_UpdatedAt = v
+}
+
+func (x *ThumbnailStatus) SetThumbnailCount(v int32) {
+	x.xxx_hidden_ThumbnailCount = v
+}
+
+func (x *ThumbnailStatus) HasNextAttemptAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_NextAttemptAt != nil
+}
+
+func (x *ThumbnailStatus) HasCompletedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CompletedAt != nil
+}
+
+func (x *ThumbnailStatus) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *ThumbnailStatus) HasUpdatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_UpdatedAt != nil
+}
+
+func (x *ThumbnailStatus) ClearNextAttemptAt() {
+	x.xxx_hidden_NextAttemptAt = nil
+}
+
+func (x *ThumbnailStatus) ClearCompletedAt() {
+	x.xxx_hidden_CompletedAt = nil
+}
+
+func (x *ThumbnailStatus) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+func (x *ThumbnailStatus) ClearUpdatedAt() {
+	x.xxx_hidden_UpdatedAt = nil
+}
+
+type ThumbnailStatus_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media the thumbnails are generated for.
+	MediaId string
+	// Current job state.
+	State ThumbnailJobState
+	// Number of attempts made so far.
+	Attempts int32
+	// Error of the last failed attempt.
+	LastError string
+	// Time of the next attempt while the job is PENDING.
+	NextAttemptAt *timestamppb.Timestamp
+	// Time the job completed.
+	CompletedAt *timestamppb.Timestamp
+	// Time the job was created.
+	CreatedAt *timestamppb.Timestamp
+	// Time the job was last updated.
+	UpdatedAt *timestamppb.Timestamp
+	// Number of thumbnails currently stored for the media.
+	ThumbnailCount int32
+}
+
+func (b0 ThumbnailStatus_builder) Build() *ThumbnailStatus {
+	m0 := &ThumbnailStatus{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_State = b.State
+	x.xxx_hidden_Attempts = b.Attempts
+	x.xxx_hidden_LastError = b.LastError
+	x.xxx_hidden_NextAttemptAt = b.NextAttemptAt
+	x.xxx_hidden_CompletedAt = b.CompletedAt
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	x.xxx_hidden_UpdatedAt = b.UpdatedAt
+	x.xxx_hidden_ThumbnailCount = b.ThumbnailCount
+	return m0
+}
+
+type GetThumbnailStatusRequest struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetThumbnailStatusRequest) Reset() {
+	*x = GetThumbnailStatusRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThumbnailStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThumbnailStatusRequest) ProtoMessage() {}
+
+func (x *GetThumbnailStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetThumbnailStatusRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *GetThumbnailStatusRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+type GetThumbnailStatusRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media to report on.
+	MediaId string
+}
+
+func (b0 GetThumbnailStatusRequest_builder) Build() *GetThumbnailStatusRequest {
+	m0 := &GetThumbnailStatusRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	return m0
+}
+
+type GetThumbnailStatusResponse struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Status *ThumbnailStatus       `protobuf:"bytes,1,opt,name=status,proto3"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetThumbnailStatusResponse) Reset() {
+	*x = GetThumbnailStatusResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThumbnailStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThumbnailStatusResponse) ProtoMessage() {}
+
+func (x *GetThumbnailStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetThumbnailStatusResponse) GetStatus() *ThumbnailStatus {
+	if x != nil {
+		return x.xxx_hidden_Status
+	}
+	return nil
+}
+
+func (x *GetThumbnailStatusResponse) SetStatus(v *ThumbnailStatus) {
+	x.xxx_hidden_Status = v
+}
+
+func (x *GetThumbnailStatusResponse) HasStatus() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Status != nil
+}
+
+func (x *GetThumbnailStatusResponse) ClearStatus() {
+	x.xxx_hidden_Status = nil
+}
+
+type GetThumbnailStatusResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Status *ThumbnailStatus
+}
+
+func (b0 GetThumbnailStatusResponse_builder) Build() *GetThumbnailStatusResponse {
+	m0 := &GetThumbnailStatusResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Status = b.Status
+	return m0
+}
+
+type RequeueThumbnailRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId        string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_IdempotencyKey string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *RequeueThumbnailRequest) Reset() {
+	*x = RequeueThumbnailRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueThumbnailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueThumbnailRequest) ProtoMessage() {}
+
+func (x *RequeueThumbnailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RequeueThumbnailRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *RequeueThumbnailRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *RequeueThumbnailRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *RequeueThumbnailRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+type RequeueThumbnailRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media whose thumbnails are generated again.
+	MediaId string
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 RequeueThumbnailRequest_builder) Build() *RequeueThumbnailRequest {
+	m0 := &RequeueThumbnailRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type RequeueThumbnailResponse struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Status *ThumbnailStatus       `protobuf:"bytes,1,opt,name=status,proto3"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RequeueThumbnailResponse) Reset() {
+	*x = RequeueThumbnailResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueThumbnailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueThumbnailResponse) ProtoMessage() {}
+
+func (x *RequeueThumbnailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RequeueThumbnailResponse) GetStatus() *ThumbnailStatus {
+	if x != nil {
+		return x.xxx_hidden_Status
+	}
+	return nil
+}
+
+func (x *RequeueThumbnailResponse) SetStatus(v *ThumbnailStatus) {
+	x.xxx_hidden_Status = v
+}
+
+func (x *RequeueThumbnailResponse) HasStatus() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Status != nil
+}
+
+func (x *RequeueThumbnailResponse) ClearStatus() {
+	x.xxx_hidden_Status = nil
+}
+
+type RequeueThumbnailResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Status of the requeued job.
+	Status *ThumbnailStatus
+}
+
+func (b0 RequeueThumbnailResponse_builder) Build() *RequeueThumbnailResponse {
+	m0 := &RequeueThumbnailResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Status = b.Status
+	return m0
+}
+
+// ThumbnailRegeneration reports a bulk thumbnail regeneration run.
+type ThumbnailRegeneration struct {
+	state                       protoimpl.MessageState     `protogen:"opaque.v1"`
+	xxx_hidden_RunId            string                     `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3"`
+	xxx_hidden_State            ThumbnailRegenerationState `protobuf:"varint,2,opt,name=state,proto3,enum=files.v1.ThumbnailRegenerationState"`
+	xxx_hidden_OwnerId          string                     `protobuf:"bytes,3,opt,name=owner_id,json=ownerId,proto3"`
+	xxx_hidden_ContentType      string                     `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3"`
+	xxx_hidden_CreatedAfter     *timestamppb.Timestamp     `protobuf:"bytes,5,opt,name=created_after,json=createdAfter,proto3"`
+	xxx_hidden_CreatedBefore    *timestamppb.Timestamp     `protobuf:"bytes,6,opt,name=created_before,json=createdBefore,proto3"`
+	xxx_hidden_RemoveStaleSizes bool                       `protobuf:"varint,7,opt,name=remove_stale_sizes,json=removeStaleSizes,proto3"`
+	xxx_hidden_RatePerSecond    int32                      `protobuf:"varint,8,opt,name=rate_per_second,json=ratePerSecond,proto3"`
+	xxx_hidden_Scanned          int64                      `protobuf:"varint,9,opt,name=scanned,proto3"`
+	xxx_hidden_Enqueued         int64                      `protobuf:"varint,10,opt,name=enqueued,proto3"`
+	xxx_hidden_Removed          int64                      `protobuf:"varint,11,opt,name=removed,proto3"`
+	xxx_hidden_Checkpoint       string                     `protobuf:"bytes,12,opt,name=checkpoint,proto3"`
+	xxx_hidden_LastError        string                     `protobuf:"bytes,13,opt,name=last_error,json=lastError,proto3"`
+	xxx_hidden_CreatedAt        *timestamppb.Timestamp     `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3"`
+	xxx_hidden_UpdatedAt        *timestamppb.Timestamp     `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3"`
+	xxx_hidden_CompletedAt      *timestamppb.Timestamp     `protobuf:"bytes,16,opt,name=completed_at,json=completedAt,proto3"`
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *ThumbnailRegeneration) Reset() {
+	*x = ThumbnailRegeneration{}
+	mi := &file_files_v1_files_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ThumbnailRegeneration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ThumbnailRegeneration) ProtoMessage() {}
+
+func (x *ThumbnailRegeneration) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ThumbnailRegeneration) GetRunId() string {
+	if x != nil {
+		return x.xxx_hidden_RunId
+	}
+	return ""
+}
+
+func (x *ThumbnailRegeneration) GetState() ThumbnailRegenerationState {
+	if x != nil {
+		return x.xxx_hidden_State
+	}
+	return ThumbnailRegenerationState_THUMBNAIL_REGENERATION_STATE_UNSPECIFIED
+}
+
+func (x *ThumbnailRegeneration) GetOwnerId() string {
+	if x != nil {
+		return x.xxx_hidden_OwnerId
+	}
+	return ""
+}
+
+func (x *ThumbnailRegeneration) GetContentType() string {
+	if x != nil {
+		return x.xxx_hidden_ContentType
+	}
+	return ""
+}
+
+func (x *ThumbnailRegeneration) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAfter
+	}
+	return nil
+}
+
+func (x *ThumbnailRegeneration) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedBefore
+	}
+	return nil
+}
+
+func (x *ThumbnailRegeneration) GetRemoveStaleSizes() bool {
+	if x != nil {
+		return x.xxx_hidden_RemoveStaleSizes
+	}
+	return false
+}
+
+func (x *ThumbnailRegeneration) GetRatePerSecond() int32 {
+	if x != nil {
+		return x.xxx_hidden_RatePerSecond
+	}
+	return 0
+}
+
+func (x *ThumbnailRegeneration) GetScanned() int64 {
+	if x != nil {
+		return x.xxx_hidden_Scanned
+	}
+	return 0
+}
+
+func (x *ThumbnailRegeneration) GetEnqueued() int64 {
+	if x != nil {
+		return x.xxx_hidden_Enqueued
+	}
+	return 0
+}
+
+func (x *ThumbnailRegeneration) GetRemoved() int64 {
+	if x != nil {
+		return x.xxx_hidden_Removed
+	}
+	return 0
+}
+
+func (x *ThumbnailRegeneration) GetCheckpoint() string {
+	if x != nil {
+		return x.xxx_hidden_Checkpoint
+	}
+	return ""
+}
+
+func (x *ThumbnailRegeneration) GetLastError() string {
+	if x != nil {
+		return x.xxx_hidden_LastError
+	}
+	return ""
+}
+
+func (x *ThumbnailRegeneration) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *ThumbnailRegeneration) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_UpdatedAt
+	}
+	return nil
+}
+
+func (x *ThumbnailRegeneration) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CompletedAt
+	}
+	return nil
+}
+
+func (x *ThumbnailRegeneration) SetRunId(v string) {
+	x.xxx_hidden_RunId = v
+}
+
+func (x *ThumbnailRegeneration) SetState(v ThumbnailRegenerationState) {
+	x.xxx_hidden_State = v
+}
+
+func (x *ThumbnailRegeneration) SetOwnerId(v string) {
+	x.xxx_hidden_OwnerId = v
+}
+
+func (x *ThumbnailRegeneration) SetContentType(v string) {
+	x.xxx_hidden_ContentType = v
+}
+
+func (x *ThumbnailRegeneration) SetCreatedAfter(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAfter = v
+}
+
+func (x *ThumbnailRegeneration) SetCreatedBefore(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedBefore = v
+}
+
+func (x *ThumbnailRegeneration) SetRemoveStaleSizes(v bool) {
+	x.xxx_hidden_RemoveStaleSizes = v
+}
+
+func (x *ThumbnailRegeneration) SetRatePerSecond(v int32) {
+	x.xxx_hidden_RatePerSecond = v
+}
+
+func (x *ThumbnailRegeneration) SetScanned(v int64) {
+	x.xxx_hidden_Scanned = v
+}
+
+func (x *ThumbnailRegeneration) SetEnqueued(v int64) {
+	x.xxx_hidden_Enqueued = v
+}
+
+func (x *ThumbnailRegeneration) SetRemoved(v int64) {
+	x.xxx_hidden_Removed = v
+}
+
+func (x *ThumbnailRegeneration) SetCheckpoint(v string) {
+	x.xxx_hidden_Checkpoint = v
+}
+
+func (x *ThumbnailRegeneration) SetLastError(v string) {
+	x.xxx_hidden_LastError = v
+}
+
+func (x *ThumbnailRegeneration) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *ThumbnailRegeneration) SetUpdatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_UpdatedAt = v
+}
+
+func (x *ThumbnailRegeneration) SetCompletedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CompletedAt = v
+}
+
+func (x *ThumbnailRegeneration) HasCreatedAfter() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAfter != nil
+}
+
+func (x *ThumbnailRegeneration) HasCreatedBefore() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedBefore != nil
+}
+
+func (x *ThumbnailRegeneration) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *ThumbnailRegeneration) HasUpdatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_UpdatedAt != nil
+}
+
+func (x *ThumbnailRegeneration) HasCompletedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CompletedAt != nil
+}
+
+func (x *ThumbnailRegeneration) ClearCreatedAfter() {
+	x.xxx_hidden_CreatedAfter = nil
+}
+
+func (x *ThumbnailRegeneration) ClearCreatedBefore() {
+	x.xxx_hidden_CreatedBefore = nil
+}
+
+func (x *ThumbnailRegeneration) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+func (x *ThumbnailRegeneration) ClearUpdatedAt() {
+	x.xxx_hidden_UpdatedAt = nil
+}
+
+func (x *ThumbnailRegeneration) ClearCompletedAt() {
+	x.xxx_hidden_CompletedAt = nil
+}
+
+type ThumbnailRegeneration_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Unique run ID.
+	RunId string
+	// Current run state.
+	State ThumbnailRegenerationState
+	// Owner filter; empty covers all owners of the tenant.
+	OwnerId string
+	// Content type filter; empty covers all images.
+	ContentType string
+	// Only media created at or after this time.
+	CreatedAfter *timestamppb.Timestamp
+	// Only media created before this time.
+	CreatedBefore *timestamppb.Timestamp
+	// Whether thumbnail sizes that are no longer configured are removed.
+	RemoveStaleSizes bool
+	// Media processed per second.
+	RatePerSecond int32
+	// Media examined so far.
+	Scanned int64
+	// Media whose thumbnail job was queued because sizes were missing.
+	Enqueued int64
+	// Stale thumbnails removed.
+	Removed int64
+	// ID of the last media processed; a resumed run continues after it.
+	Checkpoint string
+	// Last error encountered, if any.
+	LastError string
+	// Time the run was created.
+	CreatedAt *timestamppb.Timestamp
+	// Time progress was last recorded.
+	UpdatedAt *timestamppb.Timestamp
+	// Time the run completed.
+	CompletedAt *timestamppb.Timestamp
+}
+
+func (b0 ThumbnailRegeneration_builder) Build() *ThumbnailRegeneration {
+	m0 := &ThumbnailRegeneration{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_RunId = b.RunId
+	x.xxx_hidden_State = b.State
+	x.xxx_hidden_OwnerId = b.OwnerId
+	x.xxx_hidden_ContentType = b.ContentType
+	x.xxx_hidden_CreatedAfter = b.CreatedAfter
+	x.xxx_hidden_CreatedBefore = b.CreatedBefore
+	x.xxx_hidden_RemoveStaleSizes = b.RemoveStaleSizes
+	x.xxx_hidden_RatePerSecond = b.RatePerSecond
+	x.xxx_hidden_Scanned = b.Scanned
+	x.xxx_hidden_Enqueued = b.Enqueued
+	x.xxx_hidden_Removed = b.Removed
+	x.xxx_hidden_Checkpoint = b.Checkpoint
+	x.xxx_hidden_LastError = b.LastError
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	x.xxx_hidden_UpdatedAt = b.UpdatedAt
+	x.xxx_hidden_CompletedAt = b.CompletedAt
+	return m0
+}
+
+// RegenerateThumbnailsRequest starts a bulk regeneration run, or resumes one
+// from its checkpoint.
+type RegenerateThumbnailsRequest struct {
+	state                       protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_OwnerId          string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3"`
+	xxx_hidden_ContentType      string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3"`
+	xxx_hidden_CreatedAfter     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_after,json=createdAfter,proto3"`
+	xxx_hidden_CreatedBefore    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_before,json=createdBefore,proto3"`
+	xxx_hidden_RemoveStaleSizes bool                   `protobuf:"varint,5,opt,name=remove_stale_sizes,json=removeStaleSizes,proto3"`
+	xxx_hidden_RatePerSecond    int32                  `protobuf:"varint,6,opt,name=rate_per_second,json=ratePerSecond,proto3"`
+	xxx_hidden_ResumeRunId      string                 `protobuf:"bytes,7,opt,name=resume_run_id,json=resumeRunId,proto3"`
+	xxx_hidden_IdempotencyKey   string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields               protoimpl.UnknownFields
+	sizeCache                   protoimpl.SizeCache
+}
+
+func (x *RegenerateThumbnailsRequest) Reset() {
+	*x = RegenerateThumbnailsRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateThumbnailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateThumbnailsRequest) ProtoMessage() {}
+
+func (x *RegenerateThumbnailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RegenerateThumbnailsRequest) GetOwnerId() string {
+	if x != nil {
+		return x.xxx_hidden_OwnerId
+	}
+	return ""
+}
+
+func (x *RegenerateThumbnailsRequest) GetContentType() string {
+	if x != nil {
+		return x.xxx_hidden_ContentType
+	}
+	return ""
+}
+
+func (x *RegenerateThumbnailsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAfter
+	}
+	return nil
+}
+
+func (x *RegenerateThumbnailsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedBefore
+	}
+	return nil
+}
+
+func (x *RegenerateThumbnailsRequest) GetRemoveStaleSizes() bool {
+	if x != nil {
+		return x.xxx_hidden_RemoveStaleSizes
+	}
+	return false
+}
+
+func (x *RegenerateThumbnailsRequest) GetRatePerSecond() int32 {
+	if x != nil {
+		return x.xxx_hidden_RatePerSecond
+	}
+	return 0
+}
+
+func (x *RegenerateThumbnailsRequest) GetResumeRunId() string {
+	if x != nil {
+		return x.xxx_hidden_ResumeRunId
+	}
+	return ""
+}
+
+func (x *RegenerateThumbnailsRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *RegenerateThumbnailsRequest) SetOwnerId(v string) {
+	x.xxx_hidden_OwnerId = v
+}
+
+func (x *RegenerateThumbnailsRequest) SetContentType(v string) {
+	x.xxx_hidden_ContentType = v
+}
+
+func (x *RegenerateThumbnailsRequest) SetCreatedAfter(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAfter = v
+}
+
+func (x *RegenerateThumbnailsRequest) SetCreatedBefore(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedBefore = v
+}
+
+func (x *RegenerateThumbnailsRequest) SetRemoveStaleSizes(v bool) {
+	x.xxx_hidden_RemoveStaleSizes = v
+}
+
+func (x *RegenerateThumbnailsRequest) SetRatePerSecond(v int32) {
+	x.xxx_hidden_RatePerSecond = v
+}
+
+func (x *RegenerateThumbnailsRequest) SetResumeRunId(v string) {
+	x.xxx_hidden_ResumeRunId = v
+}
+
+func (x *RegenerateThumbnailsRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+func (x *RegenerateThumbnailsRequest) HasCreatedAfter() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAfter != nil
+}
+
+func (x *RegenerateThumbnailsRequest) HasCreatedBefore() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedBefore != nil
+}
+
+func (x *RegenerateThumbnailsRequest) ClearCreatedAfter() {
+	x.xxx_hidden_CreatedAfter = nil
+}
+
+func (x *RegenerateThumbnailsRequest) ClearCreatedBefore() {
+	x.xxx_hidden_CreatedBefore = nil
+}
+
+type RegenerateThumbnailsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Only media of this owner (optional).
+	OwnerId string
+	// Only media of this content type (optional). A value ending in "/"
+	// matches as a prefix. Must be an image type.
+	ContentType string
+	// Only media created at or after this time (optional).
+	CreatedAfter *timestamppb.Timestamp
+	// Only media created before this time (optional).
+	CreatedBefore *timestamppb.Timestamp
+	// Remove thumbnails of sizes that are no longer configured.
+	RemoveStaleSizes bool
+	// Images queued for generation, or stale thumbnails removed, per second.
+	// 0 uses the server default.
+	RatePerSecond int32
+	// Resume this run from its checkpoint instead of starting a new one.
+	// The filters of the original run apply.
+	ResumeRunId string
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 RegenerateThumbnailsRequest_builder) Build() *RegenerateThumbnailsRequest {
+	m0 := &RegenerateThumbnailsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_OwnerId = b.OwnerId
+	x.xxx_hidden_ContentType = b.ContentType
+	x.xxx_hidden_CreatedAfter = b.CreatedAfter
+	x.xxx_hidden_CreatedBefore = b.CreatedBefore
+	x.xxx_hidden_RemoveStaleSizes = b.RemoveStaleSizes
+	x.xxx_hidden_RatePerSecond = b.RatePerSecond
+	x.xxx_hidden_ResumeRunId = b.ResumeRunId
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type RegenerateThumbnailsResponse struct {
+	state          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Run *ThumbnailRegeneration `protobuf:"bytes,1,opt,name=run,proto3"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RegenerateThumbnailsResponse) Reset() {
+	*x = RegenerateThumbnailsResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateThumbnailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateThumbnailsResponse) ProtoMessage() {}
+
+func (x *RegenerateThumbnailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RegenerateThumbnailsResponse) GetRun() *ThumbnailRegeneration {
+	if x != nil {
+		return x.xxx_hidden_Run
+	}
+	return nil
+}
+
+func (x *RegenerateThumbnailsResponse) SetRun(v *ThumbnailRegeneration) {
+	x.xxx_hidden_Run = v
+}
+
+func (x *RegenerateThumbnailsResponse) HasRun() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Run != nil
+}
+
+func (x *RegenerateThumbnailsResponse) ClearRun() {
+	x.xxx_hidden_Run = nil
+}
+
+type RegenerateThumbnailsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The started or resumed run.
+	Run *ThumbnailRegeneration
+}
+
+func (b0 RegenerateThumbnailsResponse_builder) Build() *RegenerateThumbnailsResponse {
+	m0 := &RegenerateThumbnailsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Run = b.Run
+	return m0
+}
+
+// ReconcileOwnershipRequest compares one batch of media records with their
+// Keto tuples.
+type ReconcileOwnershipRequest struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cursor *v1.PageCursor         `protobuf:"bytes,1,opt,name=cursor,proto3"`
+	xxx_hidden_DryRun bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ReconcileOwnershipRequest) Reset() {
+	*x = ReconcileOwnershipRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileOwnershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileOwnershipRequest) ProtoMessage() {}
+
+func (x *ReconcileOwnershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ReconcileOwnershipRequest) GetCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_Cursor
+	}
+	return nil
+}
+
+func (x *ReconcileOwnershipRequest) GetDryRun() bool {
+	if x != nil {
+		return x.xxx_hidden_DryRun
+	}
+	return false
+}
+
+func (x *ReconcileOwnershipRequest) SetCursor(v *v1.PageCursor) {
+	x.xxx_hidden_Cursor = v
+}
+
+func (x *ReconcileOwnershipRequest) SetDryRun(v bool) {
+	x.xxx_hidden_DryRun = v
+}
+
+func (x *ReconcileOwnershipRequest) HasCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Cursor != nil
+}
+
+func (x *ReconcileOwnershipRequest) ClearCursor() {
+	x.xxx_hidden_Cursor = nil
+}
+
+type ReconcileOwnershipRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Batch size in limit; page is the next_cursor page of the previous
+	// batch, empty to start from the first media.
+	Cursor *v1.PageCursor
+	// Only report the differences found, without repairing them.
+	DryRun bool
+}
+
+func (b0 ReconcileOwnershipRequest_builder) Build() *ReconcileOwnershipRequest {
+	m0 := &ReconcileOwnershipRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Cursor = b.Cursor
+	x.xxx_hidden_DryRun = b.DryRun
+	return m0
+}
+
+// OwnershipIssue is one difference between a media and its Keto tuples.
+type OwnershipIssue struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Kind     OwnershipIssueKind     `protobuf:"varint,1,opt,name=kind,proto3,enum=files.v1.OwnershipIssueKind"`
+	xxx_hidden_MediaId  string                 `protobuf:"bytes,2,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_Relation string                 `protobuf:"bytes,3,opt,name=relation,proto3"`
+	xxx_hidden_Subject  string                 `protobuf:"bytes,4,opt,name=subject,proto3"`
+	xxx_hidden_Repaired bool                   `protobuf:"varint,5,opt,name=repaired,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *OwnershipIssue) Reset() {
+	*x = OwnershipIssue{}
+	mi := &file_files_v1_files_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OwnershipIssue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OwnershipIssue) ProtoMessage() {}
+
+func (x *OwnershipIssue) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *OwnershipIssue) GetKind() OwnershipIssueKind {
+	if x != nil {
+		return x.xxx_hidden_Kind
+	}
+	return OwnershipIssueKind_OWNERSHIP_ISSUE_KIND_UNSPECIFIED
+}
+
+func (x *OwnershipIssue) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *OwnershipIssue) GetRelation() string {
+	if x != nil {
+		return x.xxx_hidden_Relation
+	}
+	return ""
+}
+
+func (x *OwnershipIssue) GetSubject() string {
+	if x != nil {
+		return x.xxx_hidden_Subject
+	}
+	return ""
+}
+
+func (x *OwnershipIssue) GetRepaired() bool {
+	if x != nil {
+		return x.xxx_hidden_Repaired
+	}
+	return false
+}
+
+func (x *OwnershipIssue) SetKind(v OwnershipIssueKind) {
+	x.xxx_hidden_Kind = v
+}
+
+func (x *OwnershipIssue) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *OwnershipIssue) SetRelation(v string) {
+	x.xxx_hidden_Relation = v
+}
+
+func (x *OwnershipIssue) SetSubject(v string) {
+	x.xxx_hidden_Subject = v
+}
+
+func (x *OwnershipIssue) SetRepaired(v bool) {
+	x.xxx_hidden_Repaired = v
+}
+
+type OwnershipIssue_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// What is wrong.
+	Kind OwnershipIssueKind
+	// Media the tuple is on.
+	MediaId string
+	// Keto relation of the tuple that is missing or should not be there.
+	Relation string
+	// Subject of the tuple, as "<namespace>:<id>" or "<namespace>:<id>#<relation>".
+	Subject string
+	// Whether the difference was repaired.
+	Repaired bool
+}
+
+func (b0 OwnershipIssue_builder) Build() *OwnershipIssue {
+	m0 := &OwnershipIssue{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Kind = b.Kind
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_Relation = b.Relation
+	x.xxx_hidden_Subject = b.Subject
+	x.xxx_hidden_Repaired = b.Repaired
+	return m0
+}
+
+type ReconcileOwnershipResponse struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Scanned    int64                  `protobuf:"varint,1,opt,name=scanned,proto3"`
+	xxx_hidden_Issues     *[]*OwnershipIssue     `protobuf:"bytes,2,rep,name=issues,proto3"`
+	xxx_hidden_NextCursor *v1.PageCursor         `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ReconcileOwnershipResponse) Reset() {
+	*x = ReconcileOwnershipResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileOwnershipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileOwnershipResponse) ProtoMessage() {}
+
+func (x *ReconcileOwnershipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ReconcileOwnershipResponse) GetScanned() int64 {
+	if x != nil {
+		return x.xxx_hidden_Scanned
+	}
+	return 0
+}
+
+func (x *ReconcileOwnershipResponse) GetIssues() []*OwnershipIssue {
+	if x != nil {
+		if x.xxx_hidden_Issues != nil {
+			return *x.xxx_hidden_Issues
+		}
+	}
+	return nil
+}
+
+func (x *ReconcileOwnershipResponse) GetNextCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_NextCursor
+	}
+	return nil
+}
+
+func (x *ReconcileOwnershipResponse) SetScanned(v int64) {
+	x.xxx_hidden_Scanned = v
+}
+
+func (x *ReconcileOwnershipResponse) SetIssues(v []*OwnershipIssue) {
+	x.xxx_hidden_Issues = &v
+}
+
+func (x *ReconcileOwnershipResponse) SetNextCursor(v *v1.PageCursor) {
+	x.xxx_hidden_NextCursor = v
+}
+
+func (x *ReconcileOwnershipResponse) HasNextCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_NextCursor != nil
+}
+
+func (x *ReconcileOwnershipResponse) ClearNextCursor() {
+	x.xxx_hidden_NextCursor = nil
+}
+
+type ReconcileOwnershipResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media compared in this batch, including deleted media.
+	Scanned int64
+	// Differences found.
+	Issues []*OwnershipIssue
+	// Cursor for the next batch; unset once every media has been compared.
+	NextCursor *v1.PageCursor
+}
+
+func (b0 ReconcileOwnershipResponse_builder) Build() *ReconcileOwnershipResponse {
+	m0 := &ReconcileOwnershipResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Scanned = b.Scanned
+	x.xxx_hidden_Issues = &b.Issues
+	x.xxx_hidden_NextCursor = b.NextCursor
+	return m0
+}
+
+type GetThumbnailRegenerationRequest struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_RunId string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetThumbnailRegenerationRequest) Reset() {
+	*x = GetThumbnailRegenerationRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThumbnailRegenerationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThumbnailRegenerationRequest) ProtoMessage() {}
+
+func (x *GetThumbnailRegenerationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetThumbnailRegenerationRequest) GetRunId() string {
+	if x != nil {
+		return x.xxx_hidden_RunId
+	}
+	return ""
+}
+
+func (x *GetThumbnailRegenerationRequest) SetRunId(v string) {
+	x.xxx_hidden_RunId = v
+}
+
+type GetThumbnailRegenerationRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Run to report on.
+	RunId string
+}
+
+func (b0 GetThumbnailRegenerationRequest_builder) Build() *GetThumbnailRegenerationRequest {
+	m0 := &GetThumbnailRegenerationRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_RunId = b.RunId
+	return m0
+}
+
+type GetThumbnailRegenerationResponse struct {
+	state          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Run *ThumbnailRegeneration `protobuf:"bytes,1,opt,name=run,proto3"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetThumbnailRegenerationResponse) Reset() {
+	*x = GetThumbnailRegenerationResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThumbnailRegenerationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThumbnailRegenerationResponse) ProtoMessage() {}
+
+func (x *GetThumbnailRegenerationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetThumbnailRegenerationResponse) GetRun() *ThumbnailRegeneration {
+	if x != nil {
+		return x.xxx_hidden_Run
+	}
+	return nil
+}
+
+func (x *GetThumbnailRegenerationResponse) SetRun(v *ThumbnailRegeneration) {
+	x.xxx_hidden_Run = v
+}
+
+func (x *GetThumbnailRegenerationResponse) HasRun() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Run != nil
+}
+
+func (x *GetThumbnailRegenerationResponse) ClearRun() {
+	x.xxx_hidden_Run = nil
+}
+
+type GetThumbnailRegenerationResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Run *ThumbnailRegeneration
+}
+
+func (b0 GetThumbnailRegenerationResponse_builder) Build() *GetThumbnailRegenerationResponse {
+	m0 := &GetThumbnailRegenerationResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Run = b.Run
+	return m0
+}
+
+// GetUrlPreviewRequest fetches OpenGraph metadata for a URL.
+//
+// This enables link previews in chat/messaging applications.
+// Server fetches the URL, extracts og: meta tags, and returns them.
+//
+// Rate Limiting:
+//
+//	Preview requests may be rate limited per domain.
+//
+// Caching:
+//
+//	Previews are cached server-side (configurable TTL).
+//	Subsequent requests for same URL return cached result.
+type GetUrlPreviewRequest struct {
+	state          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Url string                 `protobuf:"bytes,1,opt,name=url,proto3"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetUrlPreviewRequest) Reset() {
+	*x = GetUrlPreviewRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUrlPreviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUrlPreviewRequest) ProtoMessage() {}
+
+func (x *GetUrlPreviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetUrlPreviewRequest) GetUrl() string {
+	if x != nil {
+		return x.xxx_hidden_Url
+	}
+	return ""
+}
+
+func (x *GetUrlPreviewRequest) SetUrl(v string) {
+	x.xxx_hidden_Url = v
+}
+
+type GetUrlPreviewRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// URL to fetch preview for.
+	// Must be a valid, publicly accessible URL.
+	Url string
+}
+
+func (b0 GetUrlPreviewRequest_builder) Build() *GetUrlPreviewRequest {
+	m0 := &GetUrlPreviewRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Url = b.Url
+	return m0
+}
+
+type GetUrlPreviewResponse struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_OgData         *structpb.Struct       `protobuf:"bytes,1,opt,name=og_data,json=ogData,proto3"`
+	xxx_hidden_OgImageMediaId string                 `protobuf:"bytes,2,opt,name=og_image_media_id,json=ogImageMediaId,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *GetUrlPreviewResponse) Reset() {
+	*x = GetUrlPreviewResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUrlPreviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUrlPreviewResponse) ProtoMessage() {}
+
+func (x *GetUrlPreviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetUrlPreviewResponse) GetOgData() *structpb.Struct {
+	if x != nil {
+		return x.xxx_hidden_OgData
+	}
+	return nil
+}
+
+func (x *GetUrlPreviewResponse) GetOgImageMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_OgImageMediaId
+	}
+	return ""
+}
+
+func (x *GetUrlPreviewResponse) SetOgData(v *structpb.Struct) {
+	x.xxx_hidden_OgData = v
+}
+
+func (x *GetUrlPreviewResponse) SetOgImageMediaId(v string) {
+	x.xxx_hidden_OgImageMediaId = v
+}
+
+func (x *GetUrlPreviewResponse) HasOgData() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_OgData != nil
+}
+
+func (x *GetUrlPreviewResponse) ClearOgData() {
+	x.xxx_hidden_OgData = nil
+}
+
+type GetUrlPreviewResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// OpenGraph metadata as key-value pairs.
+	// Includes: og:title, og:description, og:image, og:type, etc.
+	// Keys may vary based on page metadata.
+	OgData *structpb.Struct
+	// Media ID of the preview image, if any.
+	// Can be used to fetch the image via GetContent.
+	OgImageMediaId string
+}
+
+func (b0 GetUrlPreviewResponse_builder) Build() *GetUrlPreviewResponse {
+	m0 := &GetUrlPreviewResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_OgData = b.OgData
+	x.xxx_hidden_OgImageMediaId = b.OgImageMediaId
+	return m0
+}
+
+// GetConfigRequest retrieves server configuration.
+//
+// This allows clients to discover server capabilities and limits
+// without hardcoding values.
+type GetConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigRequest) Reset() {
+	*x = GetConfigRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRequest) ProtoMessage() {}
+
+func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type GetConfigRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 GetConfigRequest_builder) Build() *GetConfigRequest {
+	m0 := &GetConfigRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type GetConfigResponse struct {
+	state                                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MaxUploadBytes            int64                  `protobuf:"varint,1,opt,name=max_upload_bytes,json=maxUploadBytes,proto3"`
+	xxx_hidden_DirectClientUploadEnabled bool                   `protobuf:"varint,2,opt,name=direct_client_upload_enabled,json=directClientUploadEnabled,proto3"`
+	xxx_hidden_MaxSignedUrlExpireSeconds int64                  `protobuf:"varint,3,opt,name=max_signed_url_expire_seconds,json=maxSignedUrlExpireSeconds,proto3"`
+	xxx_hidden_MinSignedUrlExpireSeconds int64                  `protobuf:"varint,4,opt,name=min_signed_url_expire_seconds,json=minSignedUrlExpireSeconds,proto3"`
+	xxx_hidden_SupportedThumbnailMethods []ThumbnailMethod      `protobuf:"varint,5,rep,packed,name=supported_thumbnail_methods,json=supportedThumbnailMethods,proto3,enum=files.v1.ThumbnailMethod"`
+	xxx_hidden_MaxThumbnailWidth         int32                  `protobuf:"varint,6,opt,name=max_thumbnail_width,json=maxThumbnailWidth,proto3"`
+	xxx_hidden_MaxThumbnailHeight        int32                  `protobuf:"varint,7,opt,name=max_thumbnail_height,json=maxThumbnailHeight,proto3"`
+	xxx_hidden_MaxLabelsPerMedia         int32                  `protobuf:"varint,8,opt,name=max_labels_per_media,json=maxLabelsPerMedia,proto3"`
+	xxx_hidden_MaxLabelKeyLength         int32                  `protobuf:"varint,9,opt,name=max_label_key_length,json=maxLabelKeyLength,proto3"`
+	xxx_hidden_MaxLabelValueLength       int32                  `protobuf:"varint,10,opt,name=max_label_value_length,json=maxLabelValueLength,proto3"`
+	xxx_hidden_Extra                     *structpb.Struct       `protobuf:"bytes,11,opt,name=extra,proto3"`
+	unknownFields                        protoimpl.UnknownFields
+	sizeCache                            protoimpl.SizeCache
+}
+
+func (x *GetConfigResponse) Reset() {
+	*x = GetConfigResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigResponse) ProtoMessage() {}
+
+func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetConfigResponse) GetMaxUploadBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxUploadBytes
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetDirectClientUploadEnabled() bool {
+	if x != nil {
+		return x.xxx_hidden_DirectClientUploadEnabled
+	}
+	return false
+}
+
+func (x *GetConfigResponse) GetMaxSignedUrlExpireSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_MaxSignedUrlExpireSeconds
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetMinSignedUrlExpireSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_MinSignedUrlExpireSeconds
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetSupportedThumbnailMethods() []ThumbnailMethod {
+	if x != nil {
+		return x.xxx_hidden_SupportedThumbnailMethods
+	}
+	return nil
+}
+
+func (x *GetConfigResponse) GetMaxThumbnailWidth() int32 {
+	if x != nil {
+		return x.xxx_hidden_MaxThumbnailWidth
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetMaxThumbnailHeight() int32 {
+	if x != nil {
+		return x.xxx_hidden_MaxThumbnailHeight
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetMaxLabelsPerMedia() int32 {
+	if x != nil {
+		return x.xxx_hidden_MaxLabelsPerMedia
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetMaxLabelKeyLength() int32 {
+	if x != nil {
+		return x.xxx_hidden_MaxLabelKeyLength
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetMaxLabelValueLength() int32 {
+	if x != nil {
+		return x.xxx_hidden_MaxLabelValueLength
+	}
+	return 0
+}
+
+func (x *GetConfigResponse) GetExtra() *structpb.Struct {
+	if x != nil {
+		return x.xxx_hidden_Extra
+	}
+	return nil
+}
+
+func (x *GetConfigResponse) SetMaxUploadBytes(v int64) {
+	x.xxx_hidden_MaxUploadBytes = v
+}
+
+func (x *GetConfigResponse) SetDirectClientUploadEnabled(v bool) {
+	x.xxx_hidden_DirectClientUploadEnabled = v
+}
+
+func (x *GetConfigResponse) SetMaxSignedUrlExpireSeconds(v int64) {
+	x.xxx_hidden_MaxSignedUrlExpireSeconds = v
+}
+
+func (x *GetConfigResponse) SetMinSignedUrlExpireSeconds(v int64) {
+	x.xxx_hidden_MinSignedUrlExpireSeconds = v
+}
+
+func (x *GetConfigResponse) SetSupportedThumbnailMethods(v []ThumbnailMethod) {
+	x.xxx_hidden_SupportedThumbnailMethods = v
+}
+
+func (x *GetConfigResponse) SetMaxThumbnailWidth(v int32) {
+	x.xxx_hidden_MaxThumbnailWidth = v
+}
+
+func (x *GetConfigResponse) SetMaxThumbnailHeight(v int32) {
+	x.xxx_hidden_MaxThumbnailHeight = v
+}
+
+func (x *GetConfigResponse) SetMaxLabelsPerMedia(v int32) {
+	x.xxx_hidden_MaxLabelsPerMedia = v
+}
+
+func (x *GetConfigResponse) SetMaxLabelKeyLength(v int32) {
+	x.xxx_hidden_MaxLabelKeyLength = v
+}
+
+func (x *GetConfigResponse) SetMaxLabelValueLength(v int32) {
+	x.xxx_hidden_MaxLabelValueLength = v
+}
+
+func (x *GetConfigResponse) SetExtra(v *structpb.Struct) {
+	x.xxx_hidden_Extra = v
+}
+
+func (x *GetConfigResponse) HasExtra() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Extra != nil
+}
+
+func (x *GetConfigResponse) ClearExtra() {
+	x.xxx_hidden_Extra = nil
+}
+
+type GetConfigResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Maximum upload size in bytes.
+	// Clients should enforce this before upload.
+	MaxUploadBytes int64
+	// Whether direct client upload is enabled.
+	// If false, clients must use signed URLs.
+	DirectClientUploadEnabled bool
+	// Maximum expiration time for signed URLs (seconds).
+	MaxSignedUrlExpireSeconds int64
+	// Minimum expiration time for signed URLs (seconds).
+	MinSignedUrlExpireSeconds int64
+	// Supported thumbnail methods.
+	SupportedThumbnailMethods []ThumbnailMethod
+	// Maximum thumbnail dimensions.
+	MaxThumbnailWidth  int32
+	MaxThumbnailHeight int32
+	// Maximum number of labels per media.
+	MaxLabelsPerMedia int32
+	// Maximum label key length.
+	MaxLabelKeyLength int32
+	// Maximum label value length.
+	MaxLabelValueLength int32
+	// Additional server configuration.
+	Extra *structpb.Struct
+}
+
+func (b0 GetConfigResponse_builder) Build() *GetConfigResponse {
+	m0 := &GetConfigResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MaxUploadBytes = b.MaxUploadBytes
+	x.xxx_hidden_DirectClientUploadEnabled = b.DirectClientUploadEnabled
+	x.xxx_hidden_MaxSignedUrlExpireSeconds = b.MaxSignedUrlExpireSeconds
+	x.xxx_hidden_MinSignedUrlExpireSeconds = b.MinSignedUrlExpireSeconds
+	x.xxx_hidden_SupportedThumbnailMethods = b.SupportedThumbnailMethods
+	x.xxx_hidden_MaxThumbnailWidth = b.MaxThumbnailWidth
+	x.xxx_hidden_MaxThumbnailHeight = b.MaxThumbnailHeight
+	x.xxx_hidden_MaxLabelsPerMedia = b.MaxLabelsPerMedia
+	x.xxx_hidden_MaxLabelKeyLength = b.MaxLabelKeyLength
+	x.xxx_hidden_MaxLabelValueLength = b.MaxLabelValueLength
+	x.xxx_hidden_Extra = b.Extra
+	return m0
+}
+
+// SearchMediaRequest searches for media matching criteria.
+//
+// Uses common.SearchRequest from common package for pagination and
+// standard search parameters.
+//
+// Search Scope:
+//   - Includes all states (AVAILABLE, ARCHIVED, DELETED) by default
+//   - Use state filter to limit
+//
+// Full-Text Search:
+//
+//	The query string is matched against:
+//	- filename
+//	- labels (keys and values)
+//	- extra metadata (if indexed)
+//
+// Fuzzy Matching:
+//
+//	Search is prefix-based for performance.
+//	"doc" matches "document.pdf" but not "udocument.pdf".
+//
+// Performance:
+//
+//	Use specific filters instead of broad queries.
+//	Limit results when possible.
+type SearchMediaRequest struct {
+	state                        protoimpl.MessageState     `protogen:"opaque.v1"`
+	xxx_hidden_Cursor            *v1.PageCursor             `protobuf:"bytes,1,opt,name=cursor,proto3"`
+	xxx_hidden_Query             string                     `protobuf:"bytes,2,opt,name=query,proto3"`
+	xxx_hidden_IdQuery           string                     `protobuf:"bytes,3,opt,name=id_query,json=idQuery,proto3"`
+	xxx_hidden_OwnerId           string                     `protobuf:"bytes,4,opt,name=owner_id,json=ownerId,proto3"`
+	xxx_hidden_CreatedAfter      *timestamppb.Timestamp     `protobuf:"bytes,5,opt,name=created_after,json=createdAfter,proto3"`
+	xxx_hidden_CreatedBefore     *timestamppb.Timestamp     `protobuf:"bytes,6,opt,name=created_before,json=createdBefore,proto3"`
+	xxx_hidden_Visibility        MediaMetadata_Visibility   `protobuf:"varint,7,opt,name=visibility,proto3,enum=files.v1.MediaMetadata_Visibility"`
+	xxx_hidden_ContentType       string                     `protobuf:"bytes,8,opt,name=content_type,json=contentType,proto3"`
+	xxx_hidden_Labels            map[string]string          `protobuf:"bytes,9,rep,name=labels,proto3" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_SizeGte           int64                      `protobuf:"varint,10,opt,name=size_gte,json=sizeGte,proto3"`
+	xxx_hidden_SizeLte           int64                      `protobuf:"varint,11,opt,name=size_lte,json=sizeLte,proto3"`
+	xxx_hidden_State             MediaState                 `protobuf:"varint,12,opt,name=state,proto3,enum=files.v1.MediaState"`
+	xxx_hidden_ScanStatus        ScanStatus                 `protobuf:"varint,13,opt,name=scan_status,json=scanStatus,proto3,enum=files.v1.ScanStatus"`
+	xxx_hidden_Visibilities      []MediaMetadata_Visibility `protobuf:"varint,14,rep,packed,name=visibilities,proto3,enum=files.v1.MediaMetadata_Visibility"`
+	xxx_hidden_AccessibleViaRole AccessRole                 `protobuf:"varint,15,opt,name=accessible_via_role,json=accessibleViaRole,proto3,enum=files.v1.AccessRole"`
+	xxx_hidden_TimeoutMs         int64                      `protobuf:"varint,16,opt,name=timeout_ms,json=timeoutMs,proto3"`
+	xxx_hidden_OrganizationId    string                     `protobuf:"bytes,17,opt,name=organization_id,json=organizationId,proto3"`
+	xxx_hidden_SortBy            SearchMediaRequest_SortBy  `protobuf:"varint,20,opt,name=sort_by,json=sortBy,proto3,enum=files.v1.SearchMediaRequest_SortBy"`
+	xxx_hidden_SortDesc          bool                       `protobuf:"varint,21,opt,name=sort_desc,json=sortDesc,proto3"`
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *SearchMediaRequest) Reset() {
+	*x = SearchMediaRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMediaRequest) ProtoMessage() {}
+
+func (x *SearchMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SearchMediaRequest) GetCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_Cursor
+	}
+	return nil
+}
+
+func (x *SearchMediaRequest) GetQuery() string {
+	if x != nil {
+		return x.xxx_hidden_Query
+	}
+	return ""
+}
+
+func (x *SearchMediaRequest) GetIdQuery() string {
+	if x != nil {
+		return x.xxx_hidden_IdQuery
+	}
+	return ""
+}
+
+func (x *SearchMediaRequest) GetOwnerId() string {
+	if x != nil {
+		return x.xxx_hidden_OwnerId
+	}
+	return ""
+}
+
+func (x *SearchMediaRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAfter
+	}
+	return nil
+}
+
+func (x *SearchMediaRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedBefore
+	}
+	return nil
+}
+
+func (x *SearchMediaRequest) GetVisibility() MediaMetadata_Visibility {
+	if x != nil {
+		return x.xxx_hidden_Visibility
+	}
+	return MediaMetadata_VISIBILITY_UNSPECIFIED
+}
+
+func (x *SearchMediaRequest) GetContentType() string {
+	if x != nil {
+		return x.xxx_hidden_ContentType
+	}
+	return ""
+}
+
+func (x *SearchMediaRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Labels
+	}
+	return nil
+}
+
+func (x *SearchMediaRequest) GetSizeGte() int64 {
+	if x != nil {
+		return x.xxx_hidden_SizeGte
+	}
+	return 0
+}
+
+func (x *SearchMediaRequest) GetSizeLte() int64 {
+	if x != nil {
+		return x.xxx_hidden_SizeLte
+	}
+	return 0
+}
+
+func (x *SearchMediaRequest) GetState() MediaState {
+	if x != nil {
+		return x.xxx_hidden_State
+	}
+	return MediaState_MEDIA_STATE_UNSPECIFIED
+}
+
+func (x *SearchMediaRequest) GetScanStatus() ScanStatus {
+	if x != nil {
+		return x.xxx_hidden_ScanStatus
+	}
+	return ScanStatus_SCAN_STATUS_UNSPECIFIED
+}
+
+func (x *SearchMediaRequest) GetVisibilities() []MediaMetadata_Visibility {
+	if x != nil {
+		return x.xxx_hidden_Visibilities
+	}
+	return nil
+}
+
+func (x *SearchMediaRequest) GetAccessibleViaRole() AccessRole {
+	if x != nil {
+		return x.xxx_hidden_AccessibleViaRole
+	}
+	return AccessRole_ACCESS_ROLE_UNSPECIFIED
+}
+
+func (x *SearchMediaRequest) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.xxx_hidden_TimeoutMs
+	}
+	return 0
+}
+
+func (x *SearchMediaRequest) GetOrganizationId() string {
+	if x != nil {
+		return x.xxx_hidden_OrganizationId
+	}
+	return ""
+}
+
+func (x *SearchMediaRequest) GetSortBy() SearchMediaRequest_SortBy {
+	if x != nil {
+		return x.xxx_hidden_SortBy
+	}
+	return SearchMediaRequest_SORT_BY_UNSPECIFIED
+}
+
+func (x *SearchMediaRequest) GetSortDesc() bool {
+	if x != nil {
+		return x.xxx_hidden_SortDesc
+	}
+	return false
+}
+
+func (x *SearchMediaRequest) SetCursor(v *v1.PageCursor) {
+	x.xxx_hidden_Cursor = v
+}
+
+func (x *SearchMediaRequest) SetQuery(v string) {
+	x.xxx_hidden_Query = v
+}
+
+func (x *SearchMediaRequest) SetIdQuery(v string) {
+	x.xxx_hidden_IdQuery = v
+}
+
+func (x *SearchMediaRequest) SetOwnerId(v string) {
+	x.xxx_hidden_OwnerId = v
+}
+
+func (x *SearchMediaRequest) SetCreatedAfter(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAfter = v
+}
+
+func (x *SearchMediaRequest) SetCreatedBefore(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedBefore = v
+}
+
+func (x *SearchMediaRequest) SetVisibility(v MediaMetadata_Visibility) {
+	x.xxx_hidden_Visibility = v
+}
+
+func (x *SearchMediaRequest) SetContentType(v string) {
+	x.xxx_hidden_ContentType = v
+}
+
+func (x *SearchMediaRequest) SetLabels(v map[string]string) {
+	x.xxx_hidden_Labels = v
+}
+
+func (x *SearchMediaRequest) SetSizeGte(v int64) {
+	x.xxx_hidden_SizeGte = v
+}
+
+func (x *SearchMediaRequest) SetSizeLte(v int64) {
+	x.xxx_hidden_SizeLte = v
+}
+
+func (x *SearchMediaRequest) SetState(v MediaState) {
+	x.xxx_hidden_State = v
+}
+
+func (x *SearchMediaRequest) SetScanStatus(v ScanStatus) {
+	x.xxx_hidden_ScanStatus = v
+}
+
+func (x *SearchMediaRequest) SetVisibilities(v []MediaMetadata_Visibility) {
+	x.xxx_hidden_Visibilities = v
+}
+
+func (x *SearchMediaRequest) SetAccessibleViaRole(v AccessRole) {
+	x.xxx_hidden_AccessibleViaRole = v
+}
+
+func (x *SearchMediaRequest) SetTimeoutMs(v int64) {
+	x.xxx_hidden_TimeoutMs = v
+}
+
+func (x *SearchMediaRequest) SetOrganizationId(v string) {
+	x.xxx_hidden_OrganizationId = v
+}
+
+func (x *SearchMediaRequest) SetSortBy(v SearchMediaRequest_SortBy) {
+	x.xxx_hidden_SortBy = v
+}
+
+func (x *SearchMediaRequest) SetSortDesc(v bool) {
+	x.xxx_hidden_SortDesc = v
+}
+
+func (x *SearchMediaRequest) HasCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Cursor != nil
+}
+
+func (x *SearchMediaRequest) HasCreatedAfter() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAfter != nil
+}
+
+func (x *SearchMediaRequest) HasCreatedBefore() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedBefore != nil
+}
+
+func (x *SearchMediaRequest) ClearCursor() {
+	x.xxx_hidden_Cursor = nil
+}
+
+func (x *SearchMediaRequest) ClearCreatedAfter() {
+	x.xxx_hidden_CreatedAfter = nil
+}
+
+func (x *SearchMediaRequest) ClearCreatedBefore() {
+	x.xxx_hidden_CreatedBefore = nil
+}
+
+type SearchMediaRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Pagination using common PageCursor.
+	Cursor *v1.PageCursor
+	// Full-text search query string.
+	Query string
+	// Specific ID or ID pattern to search for.
+	IdQuery string
+	// Filter by owner ID.
+	OwnerId string
+	// Filter: created after this time.
+	CreatedAfter *timestamppb.Timestamp
+	// Filter: created before this time.
+	CreatedBefore *timestamppb.Timestamp
+	// Filter by visibility.
+	Visibility MediaMetadata_Visibility
+	// Filter by content type prefix.
+	// Example: "image/" matches all image types.
+	ContentType string
+	// Filter by labels (AND match).
+	// All specified labels must be present.
+	Labels map[string]string
+	// Filter: size >= this value (bytes).
+	SizeGte int64
+	// Filter: size <= this value (bytes).
+	SizeLte int64
+	// Filter by media state.
+	// If unspecified, returns all states.
+	State MediaState
+	// Filter by scan status.
+	ScanStatus ScanStatus
+	// Filter by visibility (multiple values).
+	Visibilities []MediaMetadata_Visibility
+	// Filter by access role (media user has access to).
+	AccessibleViaRole AccessRole
+	// Timeout for search operation in milliseconds.
+	// Default: server-determined timeout.
+	TimeoutMs int64
+	// Filter by organization ID.
+	// Returns media where organization_id matches.
+	OrganizationId string
+	// Sort field.
+	SortBy SearchMediaRequest_SortBy
+	// Sort in descending order.
+	// Default: false (ascending).
+	SortDesc bool
+}
+
+func (b0 SearchMediaRequest_builder) Build() *SearchMediaRequest {
+	m0 := &SearchMediaRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Cursor = b.Cursor
+	x.xxx_hidden_Query = b.Query
+	x.xxx_hidden_IdQuery = b.IdQuery
+	x.xxx_hidden_OwnerId = b.OwnerId
+	x.xxx_hidden_CreatedAfter = b.CreatedAfter
+	x.xxx_hidden_CreatedBefore = b.CreatedBefore
+	x.xxx_hidden_Visibility = b.Visibility
+	x.xxx_hidden_ContentType = b.ContentType
+	x.xxx_hidden_Labels = b.Labels
+	x.xxx_hidden_SizeGte = b.SizeGte
+	x.xxx_hidden_SizeLte = b.SizeLte
+	x.xxx_hidden_State = b.State
+	x.xxx_hidden_ScanStatus = b.ScanStatus
+	x.xxx_hidden_Visibilities = b.Visibilities
+	x.xxx_hidden_AccessibleViaRole = b.AccessibleViaRole
+	x.xxx_hidden_TimeoutMs = b.TimeoutMs
+	x.xxx_hidden_OrganizationId = b.OrganizationId
+	x.xxx_hidden_SortBy = b.SortBy
+	x.xxx_hidden_SortDesc = b.SortDesc
+	return m0
+}
+
+type SearchMediaResponse struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Results    *[]*MediaMetadata      `protobuf:"bytes,1,rep,name=results,proto3"`
+	xxx_hidden_NextCursor *v1.PageCursor         `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *SearchMediaResponse) Reset() {
+	*x = SearchMediaResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMediaResponse) ProtoMessage() {}
+
+func (x *SearchMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SearchMediaResponse) GetResults() []*MediaMetadata {
+	if x != nil {
+		if x.xxx_hidden_Results != nil {
+			return *x.xxx_hidden_Results
+		}
+	}
+	return nil
+}
+
+func (x *SearchMediaResponse) GetNextCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_NextCursor
+	}
+	return nil
+}
+
+func (x *SearchMediaResponse) SetResults(v []*MediaMetadata) {
+	x.xxx_hidden_Results = &v
+}
+
+func (x *SearchMediaResponse) SetNextCursor(v *v1.PageCursor) {
+	x.xxx_hidden_NextCursor = v
+}
+
+func (x *SearchMediaResponse) HasNextCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_NextCursor != nil
+}
+
+func (x *SearchMediaResponse) ClearNextCursor() {
+	x.xxx_hidden_NextCursor = nil
+}
+
+type SearchMediaResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Matching media metadata.
+	Results []*MediaMetadata
+	// Pagination cursor for next page.
+	NextCursor *v1.PageCursor
+}
+
+func (b0 SearchMediaResponse_builder) Build() *SearchMediaResponse {
+	m0 := &SearchMediaResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Results = &b.Results
+	x.xxx_hidden_NextCursor = b.NextCursor
+	return m0
+}
+
+// BatchGetContentRequest retrieves multiple files.
+//
+// Limits:
+//   - Maximum items per request determined by server config
+//   - Partial success supported (some items may fail)
+//
+// Performance:
+//
+//	For large batches, use pagination or multiple requests.
+type BatchGetContentRequest struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaIds []string               `protobuf:"bytes,1,rep,name=media_ids,json=mediaIds,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *BatchGetContentRequest) Reset() {
+	*x = BatchGetContentRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetContentRequest) ProtoMessage() {}
+
+func (x *BatchGetContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BatchGetContentRequest) GetMediaIds() []string {
+	if x != nil {
+		return x.xxx_hidden_MediaIds
+	}
+	return nil
+}
+
+func (x *BatchGetContentRequest) SetMediaIds(v []string) {
+	x.xxx_hidden_MediaIds = v
+}
+
+type BatchGetContentRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media IDs to retrieve.
+	MediaIds []string
+}
+
+func (b0 BatchGetContentRequest_builder) Build() *BatchGetContentRequest {
+	m0 := &BatchGetContentRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaIds = b.MediaIds
+	return m0
+}
+
+type BatchGetContentResponse struct {
+	state              protoimpl.MessageState                    `protogen:"opaque.v1"`
+	xxx_hidden_Results *[]*BatchGetContentResponse_ContentResult `protobuf:"bytes,1,rep,name=results,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BatchGetContentResponse) Reset() {
+	*x = BatchGetContentResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetContentResponse) ProtoMessage() {}
+
+func (x *BatchGetContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BatchGetContentResponse) GetResults() []*BatchGetContentResponse_ContentResult {
+	if x != nil {
+		if x.xxx_hidden_Results != nil {
+			return *x.xxx_hidden_Results
+		}
+	}
+	return nil
+}
+
+func (x *BatchGetContentResponse) SetResults(v []*BatchGetContentResponse_ContentResult) {
+	x.xxx_hidden_Results = &v
+}
+
+type BatchGetContentResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Results []*BatchGetContentResponse_ContentResult
+}
+
+func (b0 BatchGetContentResponse_builder) Build() *BatchGetContentResponse {
+	m0 := &BatchGetContentResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Results = &b.Results
+	return m0
+}
+
+type BatchDeleteContentRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaIds       []string               `protobuf:"bytes,1,rep,name=media_ids,json=mediaIds,proto3"`
+	xxx_hidden_HardDelete     bool                   `protobuf:"varint,2,opt,name=hard_delete,json=hardDelete,proto3"`
+	xxx_hidden_IdempotencyKey string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *BatchDeleteContentRequest) Reset() {
+	*x = BatchDeleteContentRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteContentRequest) ProtoMessage() {}
+
+func (x *BatchDeleteContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BatchDeleteContentRequest) GetMediaIds() []string {
+	if x != nil {
+		return x.xxx_hidden_MediaIds
+	}
+	return nil
+}
+
+func (x *BatchDeleteContentRequest) GetHardDelete() bool {
+	if x != nil {
+		return x.xxx_hidden_HardDelete
+	}
+	return false
+}
+
+func (x *BatchDeleteContentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *BatchDeleteContentRequest) SetMediaIds(v []string) {
+	x.xxx_hidden_MediaIds = v
+}
+
+func (x *BatchDeleteContentRequest) SetHardDelete(v bool) {
+	x.xxx_hidden_HardDelete = v
+}
+
+func (x *BatchDeleteContentRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+type BatchDeleteContentRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media IDs to delete.
+	MediaIds []string
+	// True for hard delete, false for soft delete.
+	HardDelete bool
+	// Idempotency key (applies to entire batch).
+	IdempotencyKey string
+}
+
+func (b0 BatchDeleteContentRequest_builder) Build() *BatchDeleteContentRequest {
+	m0 := &BatchDeleteContentRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaIds = b.MediaIds
+	x.xxx_hidden_HardDelete = b.HardDelete
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type BatchDeleteContentResponse struct {
+	state              protoimpl.MessageState                      `protogen:"opaque.v1"`
+	xxx_hidden_Results *[]*BatchDeleteContentResponse_DeleteResult `protobuf:"bytes,1,rep,name=results,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BatchDeleteContentResponse) Reset() {
+	*x = BatchDeleteContentResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteContentResponse) ProtoMessage() {}
+
+func (x *BatchDeleteContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BatchDeleteContentResponse) GetResults() []*BatchDeleteContentResponse_DeleteResult {
+	if x != nil {
+		if x.xxx_hidden_Results != nil {
+			return *x.xxx_hidden_Results
+		}
+	}
+	return nil
+}
+
+func (x *BatchDeleteContentResponse) SetResults(v []*BatchDeleteContentResponse_DeleteResult) {
+	x.xxx_hidden_Results = &v
+}
+
+type BatchDeleteContentResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Results []*BatchDeleteContentResponse_DeleteResult
+}
+
+func (b0 BatchDeleteContentResponse_builder) Build() *BatchDeleteContentResponse {
+	m0 := &BatchDeleteContentResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Results = &b.Results
+	return m0
+}
+
+// FileVersion represents a historical version of media.
+type FileVersion struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Version        int64                  `protobuf:"varint,1,opt,name=version,proto3"`
+	xxx_hidden_MediaId        string                 `protobuf:"bytes,2,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3"`
+	xxx_hidden_CreatedBy      string                 `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3"`
+	xxx_hidden_SizeBytes      int64                  `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3"`
+	xxx_hidden_ChecksumSha256 string                 `protobuf:"bytes,6,opt,name=checksum_sha256,json=checksumSha256,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *FileVersion) Reset() {
+	*x = FileVersion{}
+	mi := &file_files_v1_files_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileVersion) ProtoMessage() {}
+
+func (x *FileVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *FileVersion) GetVersion() int64 {
+	if x != nil {
+		return x.xxx_hidden_Version
+	}
+	return 0
+}
+
+func (x *FileVersion) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *FileVersion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *FileVersion) GetCreatedBy() string {
+	if x != nil {
+		return x.xxx_hidden_CreatedBy
+	}
+	return ""
+}
+
+func (x *FileVersion) GetSizeBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_SizeBytes
+	}
+	return 0
+}
+
+func (x *FileVersion) GetChecksumSha256() string {
+	if x != nil {
+		return x.xxx_hidden_ChecksumSha256
+	}
+	return ""
+}
+
+func (x *FileVersion) SetVersion(v int64) {
+	x.xxx_hidden_Version = v
+}
+
+func (x *FileVersion) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *FileVersion) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *FileVersion) SetCreatedBy(v string) {
+	x.xxx_hidden_CreatedBy = v
+}
+
+func (x *FileVersion) SetSizeBytes(v int64) {
+	x.xxx_hidden_SizeBytes = v
+}
+
+func (x *FileVersion) SetChecksumSha256(v string) {
+	x.xxx_hidden_ChecksumSha256 = v
+}
+
+func (x *FileVersion) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *FileVersion) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+type FileVersion_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Version number (1-based, ascending).
+	Version int64
+	// Media ID for this version (same across versions).
+	MediaId string
+	// When this version was created.
+	CreatedAt *timestamppb.Timestamp
+	// ID of principal who created this version.
+	CreatedBy string
+	// Size of this version's content in bytes.
+	SizeBytes int64
+	// SHA-256 checksum of this version.
+	ChecksumSha256 string
+}
+
+func (b0 FileVersion_builder) Build() *FileVersion {
+	m0 := &FileVersion{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Version = b.Version
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	x.xxx_hidden_CreatedBy = b.CreatedBy
+	x.xxx_hidden_SizeBytes = b.SizeBytes
+	x.xxx_hidden_ChecksumSha256 = b.ChecksumSha256
+	return m0
+}
+
+type GetVersionsRequest struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId   string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_Cursor    *v1.PageCursor         `protobuf:"bytes,2,opt,name=cursor,proto3"`
+	xxx_hidden_TimeoutMs int64                  `protobuf:"varint,3,opt,name=timeout_ms,json=timeoutMs,proto3"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetVersionsRequest) Reset() {
+	*x = GetVersionsRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionsRequest) ProtoMessage() {}
+
+func (x *GetVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetVersionsRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *GetVersionsRequest) GetCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_Cursor
+	}
+	return nil
+}
+
+func (x *GetVersionsRequest) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.xxx_hidden_TimeoutMs
+	}
+	return 0
+}
+
+func (x *GetVersionsRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *GetVersionsRequest) SetCursor(v *v1.PageCursor) {
+	x.xxx_hidden_Cursor = v
+}
+
+func (x *GetVersionsRequest) SetTimeoutMs(v int64) {
+	x.xxx_hidden_TimeoutMs = v
+}
+
+func (x *GetVersionsRequest) HasCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Cursor != nil
+}
+
+func (x *GetVersionsRequest) ClearCursor() {
+	x.xxx_hidden_Cursor = nil
+}
+
+type GetVersionsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to get versions for.
+	MediaId string
+	// Pagination using common PageCursor.
+	Cursor *v1.PageCursor
+	// Timeout in milliseconds.
+	TimeoutMs int64
+}
+
+func (b0 GetVersionsRequest_builder) Build() *GetVersionsRequest {
+	m0 := &GetVersionsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_Cursor = b.Cursor
+	x.xxx_hidden_TimeoutMs = b.TimeoutMs
+	return m0
+}
+
+type GetVersionsResponse struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Versions      *[]*FileVersion        `protobuf:"bytes,1,rep,name=versions,proto3"`
+	xxx_hidden_LatestVersion int64                  `protobuf:"varint,2,opt,name=latest_version,json=latestVersion,proto3"`
+	xxx_hidden_NextCursor    *v1.PageCursor         `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *GetVersionsResponse) Reset() {
+	*x = GetVersionsResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionsResponse) ProtoMessage() {}
+
+func (x *GetVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetVersionsResponse) GetVersions() []*FileVersion {
+	if x != nil {
+		if x.xxx_hidden_Versions != nil {
+			return *x.xxx_hidden_Versions
+		}
+	}
+	return nil
+}
+
+func (x *GetVersionsResponse) GetLatestVersion() int64 {
+	if x != nil {
+		return x.xxx_hidden_LatestVersion
+	}
+	return 0
+}
+
+func (x *GetVersionsResponse) GetNextCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_NextCursor
+	}
+	return nil
+}
+
+func (x *GetVersionsResponse) SetVersions(v []*FileVersion) {
+	x.xxx_hidden_Versions = &v
+}
+
+func (x *GetVersionsResponse) SetLatestVersion(v int64) {
+	x.xxx_hidden_LatestVersion = v
+}
+
+func (x *GetVersionsResponse) SetNextCursor(v *v1.PageCursor) {
+	x.xxx_hidden_NextCursor = v
+}
+
+func (x *GetVersionsResponse) HasNextCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_NextCursor != nil
+}
+
+func (x *GetVersionsResponse) ClearNextCursor() {
+	x.xxx_hidden_NextCursor = nil
+}
+
+type GetVersionsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Versions in descending order (newest first).
+	Versions []*FileVersion
+	// Latest version number.
+	LatestVersion int64
+	// Pagination cursor for next page.
+	NextCursor *v1.PageCursor
+}
+
+func (b0 GetVersionsResponse_builder) Build() *GetVersionsResponse {
+	m0 := &GetVersionsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Versions = &b.Versions
+	x.xxx_hidden_LatestVersion = b.LatestVersion
+	x.xxx_hidden_NextCursor = b.NextCursor
+	return m0
+}
+
+type RestoreVersionRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId        string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_Version        int64                  `protobuf:"varint,2,opt,name=version,proto3"`
+	xxx_hidden_IdempotencyKey string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *RestoreVersionRequest) Reset() {
+	*x = RestoreVersionRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreVersionRequest) ProtoMessage() {}
+
+func (x *RestoreVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RestoreVersionRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *RestoreVersionRequest) GetVersion() int64 {
+	if x != nil {
+		return x.xxx_hidden_Version
+	}
+	return 0
+}
+
+func (x *RestoreVersionRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *RestoreVersionRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *RestoreVersionRequest) SetVersion(v int64) {
+	x.xxx_hidden_Version = v
+}
+
+func (x *RestoreVersionRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+type RestoreVersionRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to restore version for.
+	MediaId string
+	// Version number to restore.
+	// Must be an existing version.
+	Version int64
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 RestoreVersionRequest_builder) Build() *RestoreVersionRequest {
+	m0 := &RestoreVersionRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_Version = b.Version
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type RestoreVersionResponse struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Metadata *MediaMetadata         `protobuf:"bytes,1,opt,name=metadata,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *RestoreVersionResponse) Reset() {
+	*x = RestoreVersionResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreVersionResponse) ProtoMessage() {}
+
+func (x *RestoreVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RestoreVersionResponse) GetMetadata() *MediaMetadata {
+	if x != nil {
+		return x.xxx_hidden_Metadata
+	}
+	return nil
+}
+
+func (x *RestoreVersionResponse) SetMetadata(v *MediaMetadata) {
+	x.xxx_hidden_Metadata = v
+}
+
+func (x *RestoreVersionResponse) HasMetadata() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Metadata != nil
+}
+
+func (x *RestoreVersionResponse) ClearMetadata() {
+	x.xxx_hidden_Metadata = nil
+}
+
+type RestoreVersionResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Metadata of the restored file.
+	// Version is now the latest.
+	Metadata *MediaMetadata
+}
+
+func (b0 RestoreVersionResponse_builder) Build() *RestoreVersionResponse {
+	m0 := &RestoreVersionResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Metadata = b.Metadata
+	return m0
+}
+
+// RetentionPolicy defines how long content is retained.
+//
+// Policies can be applied to individual media or configured as default.
+type RetentionPolicy struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_PolicyId      string                 `protobuf:"bytes,1,opt,name=policy_id,json=policyId,proto3"`
+	xxx_hidden_Name          string                 `protobuf:"bytes,2,opt,name=name,proto3"`
+	xxx_hidden_Description   string                 `protobuf:"bytes,3,opt,name=description,proto3"`
+	xxx_hidden_RetentionDays int64                  `protobuf:"varint,4,opt,name=retention_days,json=retentionDays,proto3"`
+	xxx_hidden_Mode          RetentionPolicy_Mode   `protobuf:"varint,5,opt,name=mode,proto3,enum=files.v1.RetentionPolicy_Mode"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *RetentionPolicy) Reset() {
+	*x = RetentionPolicy{}
+	mi := &file_files_v1_files_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetentionPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetentionPolicy) ProtoMessage() {}
+
+func (x *RetentionPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RetentionPolicy) GetPolicyId() string {
+	if x != nil {
+		return x.xxx_hidden_PolicyId
+	}
+	return ""
+}
+
+func (x *RetentionPolicy) GetName() string {
+	if x != nil {
+		return x.xxx_hidden_Name
+	}
+	return ""
+}
+
+func (x *RetentionPolicy) GetDescription() string {
+	if x != nil {
+		return x.xxx_hidden_Description
+	}
+	return ""
+}
+
+func (x *RetentionPolicy) GetRetentionDays() int64 {
+	if x != nil {
+		return x.xxx_hidden_RetentionDays
+	}
+	return 0
+}
+
+func (x *RetentionPolicy) GetMode() RetentionPolicy_Mode {
+	if x != nil {
+		return x.xxx_hidden_Mode
+	}
+	return RetentionPolicy_MODE_UNSPECIFIED
+}
+
+func (x *RetentionPolicy) SetPolicyId(v string) {
+	x.xxx_hidden_PolicyId = v
+}
+
+func (x *RetentionPolicy) SetName(v string) {
+	x.xxx_hidden_Name = v
+}
+
+func (x *RetentionPolicy) SetDescription(v string) {
+	x.xxx_hidden_Description = v
+}
+
+func (x *RetentionPolicy) SetRetentionDays(v int64) {
+	x.xxx_hidden_RetentionDays = v
+}
+
+func (x *RetentionPolicy) SetMode(v RetentionPolicy_Mode) {
+	x.xxx_hidden_Mode = v
+}
+
+type RetentionPolicy_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Unique policy ID within the system.
+	PolicyId string
+	// Human-readable policy name.
+	Name string
+	// Description of this policy.
+	Description string
+	// Retention period in days.
+	// -1 means permanent retention (never auto-delete).
+	// 0 means delete immediately after some event.
+	RetentionDays int64
+	Mode          RetentionPolicy_Mode
+}
+
+func (b0 RetentionPolicy_builder) Build() *RetentionPolicy {
+	m0 := &RetentionPolicy{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_PolicyId = b.PolicyId
+	x.xxx_hidden_Name = b.Name
+	x.xxx_hidden_Description = b.Description
+	x.xxx_hidden_RetentionDays = b.RetentionDays
+	x.xxx_hidden_Mode = b.Mode
+	return m0
+}
+
+type SetRetentionPolicyRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId        string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_PolicyId       string                 `protobuf:"bytes,2,opt,name=policy_id,json=policyId,proto3"`
+	xxx_hidden_IdempotencyKey string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *SetRetentionPolicyRequest) Reset() {
+	*x = SetRetentionPolicyRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRetentionPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRetentionPolicyRequest) ProtoMessage() {}
+
+func (x *SetRetentionPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SetRetentionPolicyRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *SetRetentionPolicyRequest) GetPolicyId() string {
+	if x != nil {
+		return x.xxx_hidden_PolicyId
+	}
+	return ""
+}
+
+func (x *SetRetentionPolicyRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *SetRetentionPolicyRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *SetRetentionPolicyRequest) SetPolicyId(v string) {
+	x.xxx_hidden_PolicyId = v
+}
+
+func (x *SetRetentionPolicyRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+type SetRetentionPolicyRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to apply policy to.
+	MediaId string
+	// Policy ID to apply.
+	// Empty string removes policy.
+	PolicyId string
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 SetRetentionPolicyRequest_builder) Build() *SetRetentionPolicyRequest {
+	m0 := &SetRetentionPolicyRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_PolicyId = b.PolicyId
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type SetRetentionPolicyResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Success bool                   `protobuf:"varint,1,opt,name=success,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SetRetentionPolicyResponse) Reset() {
+	*x = SetRetentionPolicyResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRetentionPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRetentionPolicyResponse) ProtoMessage() {}
+
+func (x *SetRetentionPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *SetRetentionPolicyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.xxx_hidden_Success
+	}
+	return false
+}
+
+func (x *SetRetentionPolicyResponse) SetSuccess(v bool) {
+	x.xxx_hidden_Success = v
+}
+
+type SetRetentionPolicyResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Whether the policy was set.
+	Success bool
+}
+
+func (b0 SetRetentionPolicyResponse_builder) Build() *SetRetentionPolicyResponse {
+	m0 := &SetRetentionPolicyResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Success = b.Success
+	return m0
+}
+
+type GetRetentionPolicyRequest struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetRetentionPolicyRequest) Reset() {
+	*x = GetRetentionPolicyRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRetentionPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRetentionPolicyRequest) ProtoMessage() {}
+
+func (x *GetRetentionPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetRetentionPolicyRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *GetRetentionPolicyRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+type GetRetentionPolicyRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID to get policy for.
+	MediaId string
+}
+
+func (b0 GetRetentionPolicyRequest_builder) Build() *GetRetentionPolicyRequest {
+	m0 := &GetRetentionPolicyRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	return m0
+}
+
+type GetRetentionPolicyResponse struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Policy    *RetentionPolicy       `protobuf:"bytes,1,opt,name=policy,proto3"`
+	xxx_hidden_ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetRetentionPolicyResponse) Reset() {
+	*x = GetRetentionPolicyResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRetentionPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRetentionPolicyResponse) ProtoMessage() {}
+
+func (x *GetRetentionPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetRetentionPolicyResponse) GetPolicy() *RetentionPolicy {
+	if x != nil {
+		return x.xxx_hidden_Policy
+	}
+	return nil
+}
+
+func (x *GetRetentionPolicyResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_ExpiresAt
+	}
+	return nil
+}
+
+func (x *GetRetentionPolicyResponse) SetPolicy(v *RetentionPolicy) {
+	x.xxx_hidden_Policy = v
+}
+
+func (x *GetRetentionPolicyResponse) SetExpiresAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_ExpiresAt = v
+}
+
+func (x *GetRetentionPolicyResponse) HasPolicy() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Policy != nil
+}
+
+func (x *GetRetentionPolicyResponse) HasExpiresAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_ExpiresAt != nil
+}
+
+func (x *GetRetentionPolicyResponse) ClearPolicy() {
+	x.xxx_hidden_Policy = nil
+}
+
+func (x *GetRetentionPolicyResponse) ClearExpiresAt() {
+	x.xxx_hidden_ExpiresAt = nil
+}
+
+type GetRetentionPolicyResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Applied retention policy.
+	// Null if no policy assigned.
+	Policy *RetentionPolicy
+	// Calculated expiration time based on policy.
+	ExpiresAt *timestamppb.Timestamp
+}
+
+func (b0 GetRetentionPolicyResponse_builder) Build() *GetRetentionPolicyResponse {
+	m0 := &GetRetentionPolicyResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Policy = b.Policy
+	x.xxx_hidden_ExpiresAt = b.ExpiresAt
+	return m0
+}
+
+type ListRetentionPoliciesRequest struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cursor *v1.PageCursor         `protobuf:"bytes,1,opt,name=cursor,proto3"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListRetentionPoliciesRequest) Reset() {
+	*x = ListRetentionPoliciesRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRetentionPoliciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRetentionPoliciesRequest) ProtoMessage() {}
+
+func (x *ListRetentionPoliciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListRetentionPoliciesRequest) GetCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_Cursor
+	}
+	return nil
+}
+
+func (x *ListRetentionPoliciesRequest) SetCursor(v *v1.PageCursor) {
+	x.xxx_hidden_Cursor = v
+}
+
+func (x *ListRetentionPoliciesRequest) HasCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Cursor != nil
+}
+
+func (x *ListRetentionPoliciesRequest) ClearCursor() {
+	x.xxx_hidden_Cursor = nil
+}
+
+type ListRetentionPoliciesRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Pagination using common PageCursor.
+	Cursor *v1.PageCursor
+}
+
+func (b0 ListRetentionPoliciesRequest_builder) Build() *ListRetentionPoliciesRequest {
+	m0 := &ListRetentionPoliciesRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Cursor = b.Cursor
+	return m0
+}
+
+type ListRetentionPoliciesResponse struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Policies   *[]*RetentionPolicy    `protobuf:"bytes,1,rep,name=policies,proto3"`
+	xxx_hidden_NextCursor *v1.PageCursor         `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ListRetentionPoliciesResponse) Reset() {
+	*x = ListRetentionPoliciesResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRetentionPoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRetentionPoliciesResponse) ProtoMessage() {}
+
+func (x *ListRetentionPoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListRetentionPoliciesResponse) GetPolicies() []*RetentionPolicy {
+	if x != nil {
+		if x.xxx_hidden_Policies != nil {
+			return *x.xxx_hidden_Policies
+		}
+	}
+	return nil
+}
+
+func (x *ListRetentionPoliciesResponse) GetNextCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_NextCursor
+	}
+	return nil
+}
+
+func (x *ListRetentionPoliciesResponse) SetPolicies(v []*RetentionPolicy) {
+	x.xxx_hidden_Policies = &v
+}
+
+func (x *ListRetentionPoliciesResponse) SetNextCursor(v *v1.PageCursor) {
+	x.xxx_hidden_NextCursor = v
+}
+
+func (x *ListRetentionPoliciesResponse) HasNextCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_NextCursor != nil
+}
+
+func (x *ListRetentionPoliciesResponse) ClearNextCursor() {
+	x.xxx_hidden_NextCursor = nil
+}
+
+type ListRetentionPoliciesResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Policies []*RetentionPolicy
+	// Pagination cursor for next page.
+	NextCursor *v1.PageCursor
+}
+
+func (b0 ListRetentionPoliciesResponse_builder) Build() *ListRetentionPoliciesResponse {
+	m0 := &ListRetentionPoliciesResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Policies = &b.Policies
+	x.xxx_hidden_NextCursor = b.NextCursor
+	return m0
+}
+
+type UsageStats struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_TotalFiles   int64                  `protobuf:"varint,1,opt,name=total_files,json=totalFiles,proto3"`
+	xxx_hidden_TotalBytes   int64                  `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3"`
+	xxx_hidden_PublicFiles  int64                  `protobuf:"varint,3,opt,name=public_files,json=publicFiles,proto3"`
+	xxx_hidden_PrivateFiles int64                  `protobuf:"varint,4,opt,name=private_files,json=privateFiles,proto3"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *UsageStats) Reset() {
+	*x = UsageStats{}
+	mi := &file_files_v1_files_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageStats) ProtoMessage() {}
+
+func (x *UsageStats) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UsageStats) GetTotalFiles() int64 {
+	if x != nil {
+		return x.xxx_hidden_TotalFiles
+	}
+	return 0
+}
+
+func (x *UsageStats) GetTotalBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_TotalBytes
+	}
+	return 0
+}
+
+func (x *UsageStats) GetPublicFiles() int64 {
+	if x != nil {
+		return x.xxx_hidden_PublicFiles
+	}
+	return 0
+}
+
+func (x *UsageStats) GetPrivateFiles() int64 {
+	if x != nil {
+		return x.xxx_hidden_PrivateFiles
+	}
+	return 0
+}
+
+func (x *UsageStats) SetTotalFiles(v int64) {
+	x.xxx_hidden_TotalFiles = v
+}
+
+func (x *UsageStats) SetTotalBytes(v int64) {
+	x.xxx_hidden_TotalBytes = v
+}
+
+func (x *UsageStats) SetPublicFiles(v int64) {
+	x.xxx_hidden_PublicFiles = v
+}
+
+func (x *UsageStats) SetPrivateFiles(v int64) {
+	x.xxx_hidden_PrivateFiles = v
+}
+
+type UsageStats_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Total files accessible to this user.
+	TotalFiles int64
+	// Total bytes used.
+	TotalBytes int64
+	// Number of public files.
+	PublicFiles int64
+	// Number of private files.
+	PrivateFiles int64
+}
+
+func (b0 UsageStats_builder) Build() *UsageStats {
+	m0 := &UsageStats{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_TotalFiles = b.TotalFiles
+	x.xxx_hidden_TotalBytes = b.TotalBytes
+	x.xxx_hidden_PublicFiles = b.PublicFiles
+	x.xxx_hidden_PrivateFiles = b.PrivateFiles
+	return m0
+}
+
+type GetUserUsageRequest struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetUserUsageRequest) Reset() {
+	*x = GetUserUsageRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserUsageRequest) ProtoMessage() {}
+
+func (x *GetUserUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetUserUsageRequest) GetUserId() string {
+	if x != nil {
+		return x.xxx_hidden_UserId
+	}
+	return ""
+}
+
+func (x *GetUserUsageRequest) SetUserId(v string) {
+	x.xxx_hidden_UserId = v
+}
+
+type GetUserUsageRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// User ID to get usage for.
+	// If empty, returns usage for authenticated user.
+	UserId string
+}
+
+func (b0 GetUserUsageRequest_builder) Build() *GetUserUsageRequest {
+	m0 := &GetUserUsageRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_UserId = b.UserId
+	return m0
+}
+
+type GetUserUsageResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Usage       *UsageStats            `protobuf:"bytes,1,opt,name=usage,proto3"`
+	xxx_hidden_PeriodStart *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=period_start,json=periodStart,proto3"`
+	xxx_hidden_PeriodEnd   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=period_end,json=periodEnd,proto3"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *GetUserUsageResponse) Reset() {
+	*x = GetUserUsageResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserUsageResponse) ProtoMessage() {}
+
+func (x *GetUserUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetUserUsageResponse) GetUsage() *UsageStats {
+	if x != nil {
+		return x.xxx_hidden_Usage
+	}
+	return nil
+}
+
+func (x *GetUserUsageResponse) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_PeriodStart
+	}
+	return nil
+}
+
+func (x *GetUserUsageResponse) GetPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_PeriodEnd
+	}
+	return nil
+}
+
+func (x *GetUserUsageResponse) SetUsage(v *UsageStats) {
+	x.xxx_hidden_Usage = v
+}
+
+func (x *GetUserUsageResponse) SetPeriodStart(v *timestamppb.Timestamp) {
+	x.xxx_hidden_PeriodStart = v
+}
+
+func (x *GetUserUsageResponse) SetPeriodEnd(v *timestamppb.Timestamp) {
+	x.xxx_hidden_PeriodEnd = v
+}
+
+func (x *GetUserUsageResponse) HasUsage() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Usage != nil
+}
+
+func (x *GetUserUsageResponse) HasPeriodStart() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_PeriodStart != nil
+}
+
+func (x *GetUserUsageResponse) HasPeriodEnd() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_PeriodEnd != nil
+}
+
+func (x *GetUserUsageResponse) ClearUsage() {
+	x.xxx_hidden_Usage = nil
+}
+
+func (x *GetUserUsageResponse) ClearPeriodStart() {
+	x.xxx_hidden_PeriodStart = nil
+}
+
+func (x *GetUserUsageResponse) ClearPeriodEnd() {
+	x.xxx_hidden_PeriodEnd = nil
+}
+
+type GetUserUsageResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Usage statistics.
+	Usage *UsageStats
+	// Start of the billing/usage period.
+	PeriodStart *timestamppb.Timestamp
+	// End of the billing/usage period.
+	PeriodEnd *timestamppb.Timestamp
+}
+
+func (b0 GetUserUsageResponse_builder) Build() *GetUserUsageResponse {
+	m0 := &GetUserUsageResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Usage = b.Usage
+	x.xxx_hidden_PeriodStart = b.PeriodStart
+	x.xxx_hidden_PeriodEnd = b.PeriodEnd
+	return m0
+}
+
+type GetStorageStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStorageStatsRequest) Reset() {
+	*x = GetStorageStatsRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStorageStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStorageStatsRequest) ProtoMessage() {}
+
+func (x *GetStorageStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type GetStorageStatsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 GetStorageStatsRequest_builder) Build() *GetStorageStatsRequest {
+	m0 := &GetStorageStatsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type GetStorageStatsResponse struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_TotalBytes int64                  `protobuf:"varint,1,opt,name=total_bytes,json=totalBytes,proto3"`
+	xxx_hidden_TotalFiles int64                  `protobuf:"varint,2,opt,name=total_files,json=totalFiles,proto3"`
+	xxx_hidden_TotalUsers int64                  `protobuf:"varint,3,opt,name=total_users,json=totalUsers,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *GetStorageStatsResponse) Reset() {
+	*x = GetStorageStatsResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStorageStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStorageStatsResponse) ProtoMessage() {}
+
+func (x *GetStorageStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetStorageStatsResponse) GetTotalBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_TotalBytes
+	}
+	return 0
+}
+
+func (x *GetStorageStatsResponse) GetTotalFiles() int64 {
+	if x != nil {
+		return x.xxx_hidden_TotalFiles
+	}
+	return 0
+}
+
+func (x *GetStorageStatsResponse) GetTotalUsers() int64 {
+	if x != nil {
+		return x.xxx_hidden_TotalUsers
+	}
+	return 0
+}
+
+func (x *GetStorageStatsResponse) SetTotalBytes(v int64) {
+	x.xxx_hidden_TotalBytes = v
+}
+
+func (x *GetStorageStatsResponse) SetTotalFiles(v int64) {
+	x.xxx_hidden_TotalFiles = v
+}
+
+func (x *GetStorageStatsResponse) SetTotalUsers(v int64) {
+	x.xxx_hidden_TotalUsers = v
+}
+
+type GetStorageStatsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Total bytes stored.
+	TotalBytes int64
+	// Total file count.
+	TotalFiles int64
+	// Total users with files.
+	TotalUsers int64
+}
+
+func (b0 GetStorageStatsResponse_builder) Build() *GetStorageStatsResponse {
+	m0 := &GetStorageStatsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_TotalBytes = b.TotalBytes
+	x.xxx_hidden_TotalFiles = b.TotalFiles
+	x.xxx_hidden_TotalUsers = b.TotalUsers
+	return m0
+}
+
+// LifecycleEvent is the payload delivered to webhook endpoints.
+type LifecycleEvent struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_EventId    string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3"`
+	xxx_hidden_EventType  LifecycleEventType     `protobuf:"varint,2,opt,name=event_type,json=eventType,proto3,enum=files.v1.LifecycleEventType"`
+	xxx_hidden_MediaId    string                 `protobuf:"bytes,3,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_OwnerId    string                 `protobuf:"bytes,4,opt,name=owner_id,json=ownerId,proto3"`
+	xxx_hidden_ActorId    string                 `protobuf:"bytes,5,opt,name=actor_id,json=actorId,proto3"`
+	xxx_hidden_OccurredAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3"`
+	xxx_hidden_Attributes map[string]string      `protobuf:"bytes,7,rep,name=attributes,proto3" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *LifecycleEvent) Reset() {
+	*x = LifecycleEvent{}
+	mi := &file_files_v1_files_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LifecycleEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LifecycleEvent) ProtoMessage() {}
+
+func (x *LifecycleEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *LifecycleEvent) GetEventId() string {
+	if x != nil {
+		return x.xxx_hidden_EventId
+	}
+	return ""
+}
+
+func (x *LifecycleEvent) GetEventType() LifecycleEventType {
+	if x != nil {
+		return x.xxx_hidden_EventType
+	}
+	return LifecycleEventType_LIFECYCLE_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *LifecycleEvent) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *LifecycleEvent) GetOwnerId() string {
+	if x != nil {
+		return x.xxx_hidden_OwnerId
+	}
+	return ""
+}
+
+func (x *LifecycleEvent) GetActorId() string {
+	if x != nil {
+		return x.xxx_hidden_ActorId
+	}
+	return ""
+}
+
+func (x *LifecycleEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_OccurredAt
+	}
+	return nil
+}
+
+func (x *LifecycleEvent) GetAttributes() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Attributes
+	}
+	return nil
+}
+
+func (x *LifecycleEvent) SetEventId(v string) {
+	x.xxx_hidden_EventId = v
+}
+
+func (x *LifecycleEvent) SetEventType(v LifecycleEventType) {
+	x.xxx_hidden_EventType = v
+}
+
+func (x *LifecycleEvent) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *LifecycleEvent) SetOwnerId(v string) {
+	x.xxx_hidden_OwnerId = v
+}
+
+func (x *LifecycleEvent) SetActorId(v string) {
+	x.xxx_hidden_ActorId = v
+}
+
+func (x *LifecycleEvent) SetOccurredAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_OccurredAt = v
+}
+
+func (x *LifecycleEvent) SetAttributes(v map[string]string) {
+	x.xxx_hidden_Attributes = v
+}
+
+func (x *LifecycleEvent) HasOccurredAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_OccurredAt != nil
+}
+
+func (x *LifecycleEvent) ClearOccurredAt() {
+	x.xxx_hidden_OccurredAt = nil
+}
+
+type LifecycleEvent_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Unique event ID.
+	EventId string
+	// Event type.
+	EventType LifecycleEventType
+	// Media the event refers to.
+	MediaId string
+	// Owner of the media.
+	OwnerId string
+	// Principal that caused the event, if any.
+	ActorId string
+	// Time the event occurred.
+	OccurredAt *timestamppb.Timestamp
+	// Event specific attributes, e.g. "principal_id" and "role" for SHARED.
+	Attributes map[string]string
+}
+
+func (b0 LifecycleEvent_builder) Build() *LifecycleEvent {
+	m0 := &LifecycleEvent{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_EventId = b.EventId
+	x.xxx_hidden_EventType = b.EventType
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_OwnerId = b.OwnerId
+	x.xxx_hidden_ActorId = b.ActorId
+	x.xxx_hidden_OccurredAt = b.OccurredAt
+	x.xxx_hidden_Attributes = b.Attributes
+	return m0
+}
+
+// WebhookSubscription registers an endpoint for lifecycle events.
+type WebhookSubscription struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3"`
+	xxx_hidden_Url            string                 `protobuf:"bytes,2,opt,name=url,proto3"`
+	xxx_hidden_EventTypes     []LifecycleEventType   `protobuf:"varint,3,rep,packed,name=event_types,json=eventTypes,proto3,enum=files.v1.LifecycleEventType"`
+	xxx_hidden_Description    string                 `protobuf:"bytes,4,opt,name=description,proto3"`
+	xxx_hidden_Active         bool                   `protobuf:"varint,5,opt,name=active,proto3"`
+	xxx_hidden_CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3"`
+	xxx_hidden_CreatedBy      string                 `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *WebhookSubscription) Reset() {
+	*x = WebhookSubscription{}
+	mi := &file_files_v1_files_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookSubscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookSubscription) ProtoMessage() {}
+
+func (x *WebhookSubscription) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WebhookSubscription) GetSubscriptionId() string {
+	if x != nil {
+		return x.xxx_hidden_SubscriptionId
+	}
+	return ""
+}
+
+func (x *WebhookSubscription) GetUrl() string {
+	if x != nil {
+		return x.xxx_hidden_Url
+	}
+	return ""
+}
+
+func (x *WebhookSubscription) GetEventTypes() []LifecycleEventType {
+	if x != nil {
+		return x.xxx_hidden_EventTypes
+	}
+	return nil
+}
+
+func (x *WebhookSubscription) GetDescription() string {
+	if x != nil {
+		return x.xxx_hidden_Description
+	}
+	return ""
+}
+
+func (x *WebhookSubscription) GetActive() bool {
+	if x != nil {
+		return x.xxx_hidden_Active
+	}
+	return false
+}
+
+func (x *WebhookSubscription) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *WebhookSubscription) GetCreatedBy() string {
+	if x != nil {
+		return x.xxx_hidden_CreatedBy
+	}
+	return ""
+}
+
+func (x *WebhookSubscription) SetSubscriptionId(v string) {
+	x.xxx_hidden_SubscriptionId = v
+}
+
+func (x *WebhookSubscription) SetUrl(v string) {
+	x.xxx_hidden_Url = v
+}
+
+func (x *WebhookSubscription) SetEventTypes(v []LifecycleEventType) {
+	x.xxx_hidden_EventTypes = v
+}
+
+func (x *WebhookSubscription) SetDescription(v string) {
+	x.xxx_hidden_Description = v
+}
+
+func (x *WebhookSubscription) SetActive(v bool) {
+	x.xxx_hidden_Active = v
+}
+
+func (x *WebhookSubscription) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *WebhookSubscription) SetCreatedBy(v string) {
+	x.xxx_hidden_CreatedBy = v
+}
+
+func (x *WebhookSubscription) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *WebhookSubscription) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+type WebhookSubscription_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Unique subscription ID.
+	SubscriptionId string
+	// Endpoint receiving the events.
+	Url string
+	// Event types delivered to the endpoint.
+	// Empty means all event types.
+	EventTypes []LifecycleEventType
+	// Human-readable description.
+	Description string
+	// Whether events are currently delivered.
+	Active bool
+	// Timestamp when the subscription was created.
+	CreatedAt *timestamppb.Timestamp
+	// ID of the principal who created the subscription.
+	CreatedBy string
+}
+
+func (b0 WebhookSubscription_builder) Build() *WebhookSubscription {
+	m0 := &WebhookSubscription{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_SubscriptionId = b.SubscriptionId
+	x.xxx_hidden_Url = b.Url
+	x.xxx_hidden_EventTypes = b.EventTypes
+	x.xxx_hidden_Description = b.Description
+	x.xxx_hidden_Active = b.Active
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	x.xxx_hidden_CreatedBy = b.CreatedBy
+	return m0
+}
+
+// WebhookDelivery records the delivery of one event to one subscription.
+type WebhookDelivery struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_DeliveryId     string                 `protobuf:"bytes,1,opt,name=delivery_id,json=deliveryId,proto3"`
+	xxx_hidden_SubscriptionId string                 `protobuf:"bytes,2,opt,name=subscription_id,json=subscriptionId,proto3"`
+	xxx_hidden_EventId        string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3"`
+	xxx_hidden_EventType      LifecycleEventType     `protobuf:"varint,4,opt,name=event_type,json=eventType,proto3,enum=files.v1.LifecycleEventType"`
+	xxx_hidden_State          WebhookDeliveryState   `protobuf:"varint,5,opt,name=state,proto3,enum=files.v1.WebhookDeliveryState"`
+	xxx_hidden_Attempts       int32                  `protobuf:"varint,6,opt,name=attempts,proto3"`
+	xxx_hidden_LastStatusCode int32                  `protobuf:"varint,7,opt,name=last_status_code,json=lastStatusCode,proto3"`
+	xxx_hidden_LastError      string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3"`
+	xxx_hidden_NextAttemptAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=next_attempt_at,json=nextAttemptAt,proto3"`
+	xxx_hidden_DeliveredAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=delivered_at,json=deliveredAt,proto3"`
+	xxx_hidden_CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_files_v1_files_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *WebhookDelivery) GetDeliveryId() string {
+	if x != nil {
+		return x.xxx_hidden_DeliveryId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetSubscriptionId() string {
+	if x != nil {
+		return x.xxx_hidden_SubscriptionId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventId() string {
+	if x != nil {
+		return x.xxx_hidden_EventId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventType() LifecycleEventType {
+	if x != nil {
+		return x.xxx_hidden_EventType
+	}
+	return LifecycleEventType_LIFECYCLE_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *WebhookDelivery) GetState() WebhookDeliveryState {
+	if x != nil {
+		return x.xxx_hidden_State
+	}
+	return WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_UNSPECIFIED
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.xxx_hidden_Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastStatusCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_LastStatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.xxx_hidden_LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_NextAttemptAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_DeliveredAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) SetDeliveryId(v string) {
+	x.xxx_hidden_DeliveryId = v
+}
+
+func (x *WebhookDelivery) SetSubscriptionId(v string) {
+	x.xxx_hidden_SubscriptionId = v
+}
+
+func (x *WebhookDelivery) SetEventId(v string) {
+	x.xxx_hidden_EventId = v
+}
+
+func (x *WebhookDelivery) SetEventType(v LifecycleEventType) {
+	x.xxx_hidden_EventType = v
+}
+
+func (x *WebhookDelivery) SetState(v WebhookDeliveryState) {
+	x.xxx_hidden_State = v
+}
+
+func (x *WebhookDelivery) SetAttempts(v int32) {
+	x.xxx_hidden_Attempts = v
+}
+
+func (x *WebhookDelivery) SetLastStatusCode(v int32) {
+	x.xxx_hidden_LastStatusCode = v
+}
+
+func (x *WebhookDelivery) SetLastError(v string) {
+	x.xxx_hidden_LastError = v
+}
+
+func (x *WebhookDelivery) SetNextAttemptAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_NextAttemptAt = v
+}
+
+func (x *WebhookDelivery) SetDeliveredAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_DeliveredAt = v
+}
+
+func (x *WebhookDelivery) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *WebhookDelivery) HasNextAttemptAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_NextAttemptAt != nil
+}
+
+func (x *WebhookDelivery) HasDeliveredAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_DeliveredAt != nil
+}
+
+func (x *WebhookDelivery) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *WebhookDelivery) ClearNextAttemptAt() {
+	x.xxx_hidden_NextAttemptAt = nil
+}
+
+func (x *WebhookDelivery) ClearDeliveredAt() {
+	x.xxx_hidden_DeliveredAt = nil
+}
+
+func (x *WebhookDelivery) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+type WebhookDelivery_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Unique delivery ID.
+	DeliveryId string
+	// Subscription the event was delivered to.
+	SubscriptionId string
+	// Event being delivered.
+	EventId string
+	// Type of the event being delivered.
+	EventType LifecycleEventType
+	// Current delivery state.
+	State WebhookDeliveryState
+	// Number of attempts made so far.
+	Attempts int32
+	// HTTP status code of the last attempt, 0 if no response was received.
+	LastStatusCode int32
+	// Error of the last failed attempt.
+	LastError string
+	// Time of the next attempt while the delivery is PENDING.
+	NextAttemptAt *timestamppb.Timestamp
+	// Time the endpoint acknowledged the delivery.
+	DeliveredAt *timestamppb.Timestamp
+	// Time the delivery was created.
+	CreatedAt *timestamppb.Timestamp
+}
+
+func (b0 WebhookDelivery_builder) Build() *WebhookDelivery {
+	m0 := &WebhookDelivery{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_DeliveryId = b.DeliveryId
+	x.xxx_hidden_SubscriptionId = b.SubscriptionId
+	x.xxx_hidden_EventId = b.EventId
+	x.xxx_hidden_EventType = b.EventType
+	x.xxx_hidden_State = b.State
+	x.xxx_hidden_Attempts = b.Attempts
+	x.xxx_hidden_LastStatusCode = b.LastStatusCode
+	x.xxx_hidden_LastError = b.LastError
+	x.xxx_hidden_NextAttemptAt = b.NextAttemptAt
+	x.xxx_hidden_DeliveredAt = b.DeliveredAt
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	return m0
+}
+
+type CreateWebhookSubscriptionRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Url            string                 `protobuf:"bytes,1,opt,name=url,proto3"`
+	xxx_hidden_EventTypes     []LifecycleEventType   `protobuf:"varint,2,rep,packed,name=event_types,json=eventTypes,proto3,enum=files.v1.LifecycleEventType"`
+	xxx_hidden_Description    string                 `protobuf:"bytes,3,opt,name=description,proto3"`
+	xxx_hidden_IdempotencyKey string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *CreateWebhookSubscriptionRequest) Reset() {
+	*x = CreateWebhookSubscriptionRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookSubscriptionRequest) ProtoMessage() {}
+
+func (x *CreateWebhookSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetUrl() string {
+	if x != nil {
+		return x.xxx_hidden_Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetEventTypes() []LifecycleEventType {
+	if x != nil {
+		return x.xxx_hidden_EventTypes
+	}
+	return nil
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetDescription() string {
+	if x != nil {
+		return x.xxx_hidden_Description
+	}
+	return ""
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateWebhookSubscriptionRequest) SetUrl(v string) {
+	x.xxx_hidden_Url = v
+}
+
+func (x *CreateWebhookSubscriptionRequest) SetEventTypes(v []LifecycleEventType) {
+	x.xxx_hidden_EventTypes = v
+}
+
+func (x *CreateWebhookSubscriptionRequest) SetDescription(v string) {
+	x.xxx_hidden_Description = v
+}
+
+func (x *CreateWebhookSubscriptionRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+type CreateWebhookSubscriptionRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Endpoint receiving the events. Must be an http or https URL.
+	Url string
+	// Event types to deliver. Empty means all event types.
+	EventTypes []LifecycleEventType
+	// Human-readable description.
+	Description string
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 CreateWebhookSubscriptionRequest_builder) Build() *CreateWebhookSubscriptionRequest {
+	m0 := &CreateWebhookSubscriptionRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Url = b.Url
+	x.xxx_hidden_EventTypes = b.EventTypes
+	x.xxx_hidden_Description = b.Description
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type CreateWebhookSubscriptionResponse struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Subscription  *WebhookSubscription   `protobuf:"bytes,1,opt,name=subscription,proto3"`
+	xxx_hidden_SigningSecret string                 `protobuf:"bytes,2,opt,name=signing_secret,json=signingSecret,proto3"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *CreateWebhookSubscriptionResponse) Reset() {
+	*x = CreateWebhookSubscriptionResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookSubscriptionResponse) ProtoMessage() {}
+
+func (x *CreateWebhookSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CreateWebhookSubscriptionResponse) GetSubscription() *WebhookSubscription {
+	if x != nil {
+		return x.xxx_hidden_Subscription
+	}
+	return nil
+}
+
+func (x *CreateWebhookSubscriptionResponse) GetSigningSecret() string {
+	if x != nil {
+		return x.xxx_hidden_SigningSecret
+	}
+	return ""
+}
+
+func (x *CreateWebhookSubscriptionResponse) SetSubscription(v *WebhookSubscription) {
+	x.xxx_hidden_Subscription = v
+}
+
+func (x *CreateWebhookSubscriptionResponse) SetSigningSecret(v string) {
+	x.xxx_hidden_SigningSecret = v
+}
+
+func (x *CreateWebhookSubscriptionResponse) HasSubscription() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Subscription != nil
+}
+
+func (x *CreateWebhookSubscriptionResponse) ClearSubscription() {
+	x.xxx_hidden_Subscription = nil
+}
+
+type CreateWebhookSubscriptionResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The created subscription.
+	Subscription *WebhookSubscription
+	// Secret used to sign deliveries.
+	// Only returned once; store it securely.
+	SigningSecret string
+}
+
+func (b0 CreateWebhookSubscriptionResponse_builder) Build() *CreateWebhookSubscriptionResponse {
+	m0 := &CreateWebhookSubscriptionResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Subscription = b.Subscription
+	x.xxx_hidden_SigningSecret = b.SigningSecret
+	return m0
+}
+
+type ListWebhookSubscriptionsRequest struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Cursor *v1.PageCursor         `protobuf:"bytes,1,opt,name=cursor,proto3"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListWebhookSubscriptionsRequest) Reset() {
+	*x = ListWebhookSubscriptionsRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookSubscriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookSubscriptionsRequest) ProtoMessage() {}
+
+func (x *ListWebhookSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListWebhookSubscriptionsRequest) GetCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_Cursor
+	}
+	return nil
+}
+
+func (x *ListWebhookSubscriptionsRequest) SetCursor(v *v1.PageCursor) {
+	x.xxx_hidden_Cursor = v
+}
+
+func (x *ListWebhookSubscriptionsRequest) HasCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Cursor != nil
+}
+
+func (x *ListWebhookSubscriptionsRequest) ClearCursor() {
+	x.xxx_hidden_Cursor = nil
+}
+
+type ListWebhookSubscriptionsRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Pagination using common PageCursor.
+	Cursor *v1.PageCursor
+}
+
+func (b0 ListWebhookSubscriptionsRequest_builder) Build() *ListWebhookSubscriptionsRequest {
+	m0 := &ListWebhookSubscriptionsRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Cursor = b.Cursor
+	return m0
+}
+
+type ListWebhookSubscriptionsResponse struct {
+	state                    protoimpl.MessageState  `protogen:"opaque.v1"`
+	xxx_hidden_Subscriptions *[]*WebhookSubscription `protobuf:"bytes,1,rep,name=subscriptions,proto3"`
+	xxx_hidden_NextCursor    *v1.PageCursor          `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *ListWebhookSubscriptionsResponse) Reset() {
+	*x = ListWebhookSubscriptionsResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookSubscriptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookSubscriptionsResponse) ProtoMessage() {}
+
+func (x *ListWebhookSubscriptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListWebhookSubscriptionsResponse) GetSubscriptions() []*WebhookSubscription {
+	if x != nil {
+		if x.xxx_hidden_Subscriptions != nil {
+			return *x.xxx_hidden_Subscriptions
+		}
+	}
+	return nil
+}
+
+func (x *ListWebhookSubscriptionsResponse) GetNextCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_NextCursor
+	}
+	return nil
+}
+
+func (x *ListWebhookSubscriptionsResponse) SetSubscriptions(v []*WebhookSubscription) {
+	x.xxx_hidden_Subscriptions = &v
+}
+
+func (x *ListWebhookSubscriptionsResponse) SetNextCursor(v *v1.PageCursor) {
+	x.xxx_hidden_NextCursor = v
+}
+
+func (x *ListWebhookSubscriptionsResponse) HasNextCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_NextCursor != nil
+}
+
+func (x *ListWebhookSubscriptionsResponse) ClearNextCursor() {
+	x.xxx_hidden_NextCursor = nil
+}
+
+type ListWebhookSubscriptionsResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Subscriptions []*WebhookSubscription
+	// Pagination cursor for next page.
+	NextCursor *v1.PageCursor
+}
+
+func (b0 ListWebhookSubscriptionsResponse_builder) Build() *ListWebhookSubscriptionsResponse {
+	m0 := &ListWebhookSubscriptionsResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Subscriptions = &b.Subscriptions
+	x.xxx_hidden_NextCursor = b.NextCursor
+	return m0
+}
+
+type DeleteWebhookSubscriptionRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3"`
+	xxx_hidden_IdempotencyKey string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookSubscriptionRequest) Reset() {
+	*x = DeleteWebhookSubscriptionRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookSubscriptionRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteWebhookSubscriptionRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.xxx_hidden_SubscriptionId
+	}
+	return ""
+}
+
+func (x *DeleteWebhookSubscriptionRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *DeleteWebhookSubscriptionRequest) SetSubscriptionId(v string) {
+	x.xxx_hidden_SubscriptionId = v
+}
+
+func (x *DeleteWebhookSubscriptionRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+type DeleteWebhookSubscriptionRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Subscription to delete.
+	SubscriptionId string
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 DeleteWebhookSubscriptionRequest_builder) Build() *DeleteWebhookSubscriptionRequest {
+	m0 := &DeleteWebhookSubscriptionRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_SubscriptionId = b.SubscriptionId
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type DeleteWebhookSubscriptionResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Success bool                   `protobuf:"varint,1,opt,name=success,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookSubscriptionResponse) Reset() {
+	*x = DeleteWebhookSubscriptionResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookSubscriptionResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *DeleteWebhookSubscriptionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.xxx_hidden_Success
+	}
+	return false
+}
+
+func (x *DeleteWebhookSubscriptionResponse) SetSuccess(v bool) {
+	x.xxx_hidden_Success = v
+}
+
+type DeleteWebhookSubscriptionResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Whether the subscription was deleted.
+	Success bool
+}
+
+func (b0 DeleteWebhookSubscriptionResponse_builder) Build() *DeleteWebhookSubscriptionResponse {
+	m0 := &DeleteWebhookSubscriptionResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Success = b.Success
+	return m0
+}
+
+type ListWebhookDeliveriesRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3"`
+	xxx_hidden_FilterState    WebhookDeliveryState   `protobuf:"varint,2,opt,name=filter_state,json=filterState,proto3,enum=files.v1.WebhookDeliveryState"`
+	xxx_hidden_Cursor         *v1.PageCursor         `protobuf:"bytes,3,opt,name=cursor,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListWebhookDeliveriesRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.xxx_hidden_SubscriptionId
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetFilterState() WebhookDeliveryState {
+	if x != nil {
+		return x.xxx_hidden_FilterState
+	}
+	return WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_UNSPECIFIED
+}
+
+func (x *ListWebhookDeliveriesRequest) GetCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_Cursor
+	}
+	return nil
+}
+
+func (x *ListWebhookDeliveriesRequest) SetSubscriptionId(v string) {
+	x.xxx_hidden_SubscriptionId = v
+}
+
+func (x *ListWebhookDeliveriesRequest) SetFilterState(v WebhookDeliveryState) {
+	x.xxx_hidden_FilterState = v
+}
+
+func (x *ListWebhookDeliveriesRequest) SetCursor(v *v1.PageCursor) {
+	x.xxx_hidden_Cursor = v
+}
+
+func (x *ListWebhookDeliveriesRequest) HasCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Cursor != nil
+}
+
+func (x *ListWebhookDeliveriesRequest) ClearCursor() {
+	x.xxx_hidden_Cursor = nil
+}
+
+type ListWebhookDeliveriesRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Subscription to list deliveries for.
+	SubscriptionId string
+	// Filter by state (optional).
+	FilterState WebhookDeliveryState
+	// Pagination using common PageCursor.
+	Cursor *v1.PageCursor
+}
+
+func (b0 ListWebhookDeliveriesRequest_builder) Build() *ListWebhookDeliveriesRequest {
+	m0 := &ListWebhookDeliveriesRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_SubscriptionId = b.SubscriptionId
+	x.xxx_hidden_FilterState = b.FilterState
+	x.xxx_hidden_Cursor = b.Cursor
+	return m0
+}
+
+type ListWebhookDeliveriesResponse struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Deliveries *[]*WebhookDelivery    `protobuf:"bytes,1,rep,name=deliveries,proto3"`
+	xxx_hidden_NextCursor *v1.PageCursor         `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		if x.xxx_hidden_Deliveries != nil {
+			return *x.xxx_hidden_Deliveries
+		}
+	}
+	return nil
+}
+
+func (x *ListWebhookDeliveriesResponse) GetNextCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_NextCursor
+	}
+	return nil
+}
+
+func (x *ListWebhookDeliveriesResponse) SetDeliveries(v []*WebhookDelivery) {
+	x.xxx_hidden_Deliveries = &v
+}
+
+func (x *ListWebhookDeliveriesResponse) SetNextCursor(v *v1.PageCursor) {
+	x.xxx_hidden_NextCursor = v
+}
+
+func (x *ListWebhookDeliveriesResponse) HasNextCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_NextCursor != nil
+}
+
+func (x *ListWebhookDeliveriesResponse) ClearNextCursor() {
+	x.xxx_hidden_NextCursor = nil
+}
+
+type ListWebhookDeliveriesResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Deliveries, most recent first.
+	Deliveries []*WebhookDelivery
+	// Pagination cursor for next page.
+	NextCursor *v1.PageCursor
+}
+
+func (b0 ListWebhookDeliveriesResponse_builder) Build() *ListWebhookDeliveriesResponse {
+	m0 := &ListWebhookDeliveriesResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Deliveries = &b.Deliveries
+	x.xxx_hidden_NextCursor = b.NextCursor
+	return m0
+}
+
+// AdminListMediaRequest lists the media of the caller's tenant partition.
+type AdminListMediaRequest struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_OwnerId string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3"`
+	xxx_hidden_Folder  string                 `protobuf:"bytes,2,opt,name=folder,proto3"`
+	xxx_hidden_Cursor  *v1.PageCursor         `protobuf:"bytes,3,opt,name=cursor,proto3"`
+	xxx_hidden_Reason  string                 `protobuf:"bytes,4,opt,name=reason,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AdminListMediaRequest) Reset() {
+	*x = AdminListMediaRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminListMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminListMediaRequest) ProtoMessage() {}
+
+func (x *AdminListMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AdminListMediaRequest) GetOwnerId() string {
+	if x != nil {
+		return x.xxx_hidden_OwnerId
+	}
+	return ""
+}
+
+func (x *AdminListMediaRequest) GetFolder() string {
+	if x != nil {
+		return x.xxx_hidden_Folder
+	}
+	return ""
+}
+
+func (x *AdminListMediaRequest) GetCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_Cursor
+	}
+	return nil
+}
+
+func (x *AdminListMediaRequest) GetReason() string {
+	if x != nil {
+		return x.xxx_hidden_Reason
+	}
+	return ""
+}
+
+func (x *AdminListMediaRequest) SetOwnerId(v string) {
+	x.xxx_hidden_OwnerId = v
+}
+
+func (x *AdminListMediaRequest) SetFolder(v string) {
+	x.xxx_hidden_Folder = v
+}
+
+func (x *AdminListMediaRequest) SetCursor(v *v1.PageCursor) {
+	x.xxx_hidden_Cursor = v
+}
+
+func (x *AdminListMediaRequest) SetReason(v string) {
+	x.xxx_hidden_Reason = v
+}
+
+func (x *AdminListMediaRequest) HasCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Cursor != nil
+}
+
+func (x *AdminListMediaRequest) ClearCursor() {
+	x.xxx_hidden_Cursor = nil
+}
+
+type AdminListMediaRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Only list media of this owner (optional).
+	OwnerId string
+	// Only list media filed under this folder or below it; needs owner_id.
+	Folder string
+	// Page size in limit; page is the next_cursor page of the previous page.
+	Cursor *v1.PageCursor
+	// Why the media are being listed, for the audit log.
+	Reason string
+}
+
+func (b0 AdminListMediaRequest_builder) Build() *AdminListMediaRequest {
+	m0 := &AdminListMediaRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_OwnerId = b.OwnerId
+	x.xxx_hidden_Folder = b.Folder
+	x.xxx_hidden_Cursor = b.Cursor
+	x.xxx_hidden_Reason = b.Reason
+	return m0
+}
+
+type AdminListMediaResponse struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Media      *[]*MediaMetadata      `protobuf:"bytes,1,rep,name=media,proto3"`
+	xxx_hidden_NextCursor *v1.PageCursor         `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *AdminListMediaResponse) Reset() {
+	*x = AdminListMediaResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminListMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminListMediaResponse) ProtoMessage() {}
+
+func (x *AdminListMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AdminListMediaResponse) GetMedia() []*MediaMetadata {
+	if x != nil {
+		if x.xxx_hidden_Media != nil {
+			return *x.xxx_hidden_Media
+		}
+	}
+	return nil
+}
+
+func (x *AdminListMediaResponse) GetNextCursor() *v1.PageCursor {
+	if x != nil {
+		return x.xxx_hidden_NextCursor
+	}
+	return nil
+}
+
+func (x *AdminListMediaResponse) SetMedia(v []*MediaMetadata) {
+	x.xxx_hidden_Media = &v
+}
+
+func (x *AdminListMediaResponse) SetNextCursor(v *v1.PageCursor) {
+	x.xxx_hidden_NextCursor = v
+}
+
+func (x *AdminListMediaResponse) HasNextCursor() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_NextCursor != nil
+}
+
+func (x *AdminListMediaResponse) ClearNextCursor() {
+	x.xxx_hidden_NextCursor = nil
+}
+
+type AdminListMediaResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Original media in ID order; thumbnails are not listed.
+	Media []*MediaMetadata
+	// Pagination cursor for the next page.
+	NextCursor *v1.PageCursor
+}
+
+func (b0 AdminListMediaResponse_builder) Build() *AdminListMediaResponse {
+	m0 := &AdminListMediaResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Media = &b.Media
+	x.xxx_hidden_NextCursor = b.NextCursor
+	return m0
+}
+
+// AdminGetMediaRequest gets any media of the caller's tenant partition.
+type AdminGetMediaRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId        string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_ExpiresSeconds int64                  `protobuf:"varint,2,opt,name=expires_seconds,json=expiresSeconds,proto3"`
+	xxx_hidden_Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *AdminGetMediaRequest) Reset() {
+	*x = AdminGetMediaRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminGetMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminGetMediaRequest) ProtoMessage() {}
+
+func (x *AdminGetMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AdminGetMediaRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *AdminGetMediaRequest) GetExpiresSeconds() int64 {
+	if x != nil {
+		return x.xxx_hidden_ExpiresSeconds
+	}
+	return 0
+}
+
+func (x *AdminGetMediaRequest) GetReason() string {
+	if x != nil {
+		return x.xxx_hidden_Reason
+	}
+	return ""
+}
+
+func (x *AdminGetMediaRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *AdminGetMediaRequest) SetExpiresSeconds(v int64) {
+	x.xxx_hidden_ExpiresSeconds = v
+}
+
+func (x *AdminGetMediaRequest) SetReason(v string) {
+	x.xxx_hidden_Reason = v
+}
+
+type AdminGetMediaRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media to get.
+	MediaId string
+	// Expiry of the download URL in seconds; defaults to 300.
+	ExpiresSeconds int64
+	// Why the media is being viewed, for the audit log.
+	Reason string
+}
+
+func (b0 AdminGetMediaRequest_builder) Build() *AdminGetMediaRequest {
+	m0 := &AdminGetMediaRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_ExpiresSeconds = b.ExpiresSeconds
+	x.xxx_hidden_Reason = b.Reason
+	return m0
+}
+
+type AdminGetMediaResponse struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Metadata    *MediaMetadata         `protobuf:"bytes,1,opt,name=metadata,proto3"`
+	xxx_hidden_Grants      *[]*AccessGrant        `protobuf:"bytes,2,rep,name=grants,proto3"`
+	xxx_hidden_DownloadUrl string                 `protobuf:"bytes,3,opt,name=download_url,json=downloadUrl,proto3"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *AdminGetMediaResponse) Reset() {
+	*x = AdminGetMediaResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminGetMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminGetMediaResponse) ProtoMessage() {}
+
+func (x *AdminGetMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AdminGetMediaResponse) GetMetadata() *MediaMetadata {
+	if x != nil {
+		return x.xxx_hidden_Metadata
+	}
+	return nil
+}
+
+func (x *AdminGetMediaResponse) GetGrants() []*AccessGrant {
+	if x != nil {
+		if x.xxx_hidden_Grants != nil {
+			return *x.xxx_hidden_Grants
+		}
+	}
+	return nil
+}
+
+func (x *AdminGetMediaResponse) GetDownloadUrl() string {
+	if x != nil {
+		return x.xxx_hidden_DownloadUrl
+	}
+	return ""
+}
+
+func (x *AdminGetMediaResponse) SetMetadata(v *MediaMetadata) {
+	x.xxx_hidden_Metadata = v
+}
+
+func (x *AdminGetMediaResponse) SetGrants(v []*AccessGrant) {
+	x.xxx_hidden_Grants = &v
+}
+
+func (x *AdminGetMediaResponse) SetDownloadUrl(v string) {
+	x.xxx_hidden_DownloadUrl = v
+}
+
+func (x *AdminGetMediaResponse) HasMetadata() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Metadata != nil
+}
+
+func (x *AdminGetMediaResponse) ClearMetadata() {
+	x.xxx_hidden_Metadata = nil
+}
+
+type AdminGetMediaResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Metadata of the media.
+	Metadata *MediaMetadata
+	// Every grant on the media, including those inherited from its folder.
+	Grants []*AccessGrant
+	// Signed URL to download the content.
+	DownloadUrl string
+}
+
+func (b0 AdminGetMediaResponse_builder) Build() *AdminGetMediaResponse {
+	m0 := &AdminGetMediaResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Metadata = b.Metadata
+	x.xxx_hidden_Grants = &b.Grants
+	x.xxx_hidden_DownloadUrl = b.DownloadUrl
+	return m0
+}
+
+// AdminGrantAccessRequest shares any file of the caller's tenant partition.
+type AdminGrantAccessRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId        string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_Grant          *AccessGrant           `protobuf:"bytes,2,opt,name=grant,proto3"`
+	xxx_hidden_Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3"`
+	xxx_hidden_IdempotencyKey string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *AdminGrantAccessRequest) Reset() {
+	*x = AdminGrantAccessRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminGrantAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminGrantAccessRequest) ProtoMessage() {}
+
+func (x *AdminGrantAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AdminGrantAccessRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *AdminGrantAccessRequest) GetGrant() *AccessGrant {
+	if x != nil {
+		return x.xxx_hidden_Grant
+	}
+	return nil
+}
+
+func (x *AdminGrantAccessRequest) GetReason() string {
+	if x != nil {
+		return x.xxx_hidden_Reason
+	}
+	return ""
+}
+
+func (x *AdminGrantAccessRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *AdminGrantAccessRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *AdminGrantAccessRequest) SetGrant(v *AccessGrant) {
+	x.xxx_hidden_Grant = v
+}
+
+func (x *AdminGrantAccessRequest) SetReason(v string) {
+	x.xxx_hidden_Reason = v
+}
+
+func (x *AdminGrantAccessRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+func (x *AdminGrantAccessRequest) HasGrant() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Grant != nil
+}
+
+func (x *AdminGrantAccessRequest) ClearGrant() {
+	x.xxx_hidden_Grant = nil
+}
+
+type AdminGrantAccessRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media to grant access to.
+	MediaId string
+	// The access grant to apply. The owner role cannot be granted; use
+	// AdminTransferOwnership instead.
+	Grant *AccessGrant
+	// Why access is being granted, for the audit log.
+	Reason string
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 AdminGrantAccessRequest_builder) Build() *AdminGrantAccessRequest {
+	m0 := &AdminGrantAccessRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_Grant = b.Grant
+	x.xxx_hidden_Reason = b.Reason
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type AdminGrantAccessResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Success bool                   `protobuf:"varint,1,opt,name=success,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AdminGrantAccessResponse) Reset() {
+	*x = AdminGrantAccessResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminGrantAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminGrantAccessResponse) ProtoMessage() {}
+
+func (x *AdminGrantAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AdminGrantAccessResponse) GetSuccess() bool {
+	if x != nil {
+		return x.xxx_hidden_Success
+	}
+	return false
+}
+
+func (x *AdminGrantAccessResponse) SetSuccess(v bool) {
+	x.xxx_hidden_Success = v
+}
+
+type AdminGrantAccessResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Whether the grant was applied.
+	Success bool
+}
+
+func (b0 AdminGrantAccessResponse_builder) Build() *AdminGrantAccessResponse {
+	m0 := &AdminGrantAccessResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Success = b.Success
+	return m0
+}
+
+// AdminTransferOwnershipRequest hands files of any owner in the caller's
+// tenant partition to another profile.
+type AdminTransferOwnershipRequest struct {
+	state                        protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId           string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_OwnerId           string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3"`
+	xxx_hidden_Folder            string                 `protobuf:"bytes,3,opt,name=folder,proto3"`
+	xxx_hidden_AllFiles          bool                   `protobuf:"varint,4,opt,name=all_files,json=allFiles,proto3"`
+	xxx_hidden_NewOwnerId        string                 `protobuf:"bytes,5,opt,name=new_owner_id,json=newOwnerId,proto3"`
+	xxx_hidden_PreviousOwnerRole AccessRole             `protobuf:"varint,6,opt,name=previous_owner_role,json=previousOwnerRole,proto3,enum=files.v1.AccessRole"`
+	xxx_hidden_Reason            string                 `protobuf:"bytes,7,opt,name=reason,proto3"`
+	xxx_hidden_IdempotencyKey    string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *AdminTransferOwnershipRequest) Reset() {
+	*x = AdminTransferOwnershipRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminTransferOwnershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminTransferOwnershipRequest) ProtoMessage() {}
+
+func (x *AdminTransferOwnershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AdminTransferOwnershipRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *AdminTransferOwnershipRequest) GetOwnerId() string {
+	if x != nil {
+		return x.xxx_hidden_OwnerId
+	}
+	return ""
+}
+
+func (x *AdminTransferOwnershipRequest) GetFolder() string {
+	if x != nil {
+		return x.xxx_hidden_Folder
+	}
+	return ""
+}
+
+func (x *AdminTransferOwnershipRequest) GetAllFiles() bool {
+	if x != nil {
+		return x.xxx_hidden_AllFiles
+	}
+	return false
+}
+
+func (x *AdminTransferOwnershipRequest) GetNewOwnerId() string {
+	if x != nil {
+		return x.xxx_hidden_NewOwnerId
+	}
+	return ""
+}
+
+func (x *AdminTransferOwnershipRequest) GetPreviousOwnerRole() AccessRole {
+	if x != nil {
+		return x.xxx_hidden_PreviousOwnerRole
+	}
+	return AccessRole_ACCESS_ROLE_UNSPECIFIED
+}
+
+func (x *AdminTransferOwnershipRequest) GetReason() string {
+	if x != nil {
+		return x.xxx_hidden_Reason
+	}
+	return ""
+}
+
+func (x *AdminTransferOwnershipRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *AdminTransferOwnershipRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *AdminTransferOwnershipRequest) SetOwnerId(v string) {
+	x.xxx_hidden_OwnerId = v
+}
+
+func (x *AdminTransferOwnershipRequest) SetFolder(v string) {
+	x.xxx_hidden_Folder = v
+}
+
+func (x *AdminTransferOwnershipRequest) SetAllFiles(v bool) {
+	x.xxx_hidden_AllFiles = v
+}
+
+func (x *AdminTransferOwnershipRequest) SetNewOwnerId(v string) {
+	x.xxx_hidden_NewOwnerId = v
+}
+
+func (x *AdminTransferOwnershipRequest) SetPreviousOwnerRole(v AccessRole) {
+	x.xxx_hidden_PreviousOwnerRole = v
+}
+
+func (x *AdminTransferOwnershipRequest) SetReason(v string) {
+	x.xxx_hidden_Reason = v
+}
+
+func (x *AdminTransferOwnershipRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+type AdminTransferOwnershipRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Single file to transfer, from whoever owns it.
+	MediaId string
+	// Current owner of the files to transfer; required with folder or
+	// all_files.
+	OwnerId string
+	// Folder of the owner's to transfer, with every file filed in it or below
+	// it.
+	Folder string
+	// Transfer every file the owner has.
+	// Exactly one of media_id, folder and all_files must be set.
+	AllFiles bool
+	// Profile receiving ownership.
+	NewOwnerId string
+	// Role left to the previous owner on each transferred file (optional).
+	PreviousOwnerRole AccessRole
+	// Why ownership is being transferred, for the audit log.
+	Reason string
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 AdminTransferOwnershipRequest_builder) Build() *AdminTransferOwnershipRequest {
+	m0 := &AdminTransferOwnershipRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_OwnerId = b.OwnerId
+	x.xxx_hidden_Folder = b.Folder
+	x.xxx_hidden_AllFiles = b.AllFiles
+	x.xxx_hidden_NewOwnerId = b.NewOwnerId
+	x.xxx_hidden_PreviousOwnerRole = b.PreviousOwnerRole
+	x.xxx_hidden_Reason = b.Reason
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type AdminTransferOwnershipResponse struct {
+	state                         protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_TransferredFiles   int64                  `protobuf:"varint,1,opt,name=transferred_files,json=transferredFiles,proto3"`
+	xxx_hidden_PreviousOwnerUsage *UsageStats            `protobuf:"bytes,2,opt,name=previous_owner_usage,json=previousOwnerUsage,proto3"`
+	xxx_hidden_NewOwnerUsage      *UsageStats            `protobuf:"bytes,3,opt,name=new_owner_usage,json=newOwnerUsage,proto3"`
+	unknownFields                 protoimpl.UnknownFields
+	sizeCache                     protoimpl.SizeCache
+}
+
+func (x *AdminTransferOwnershipResponse) Reset() {
+	*x = AdminTransferOwnershipResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminTransferOwnershipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminTransferOwnershipResponse) ProtoMessage() {}
+
+func (x *AdminTransferOwnershipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AdminTransferOwnershipResponse) GetTransferredFiles() int64 {
+	if x != nil {
+		return x.xxx_hidden_TransferredFiles
+	}
+	return 0
+}
+
+func (x *AdminTransferOwnershipResponse) GetPreviousOwnerUsage() *UsageStats {
+	if x != nil {
+		return x.xxx_hidden_PreviousOwnerUsage
+	}
+	return nil
+}
+
+func (x *AdminTransferOwnershipResponse) GetNewOwnerUsage() *UsageStats {
+	if x != nil {
+		return x.xxx_hidden_NewOwnerUsage
+	}
+	return nil
+}
+
+func (x *AdminTransferOwnershipResponse) SetTransferredFiles(v int64) {
+	x.xxx_hidden_TransferredFiles = v
+}
+
+func (x *AdminTransferOwnershipResponse) SetPreviousOwnerUsage(v *UsageStats) {
+	x.xxx_hidden_PreviousOwnerUsage = v
+}
+
+func (x *AdminTransferOwnershipResponse) SetNewOwnerUsage(v *UsageStats) {
+	x.xxx_hidden_NewOwnerUsage = v
+}
+
+func (x *AdminTransferOwnershipResponse) HasPreviousOwnerUsage() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_PreviousOwnerUsage != nil
+}
+
+func (x *AdminTransferOwnershipResponse) HasNewOwnerUsage() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_NewOwnerUsage != nil
+}
+
+func (x *AdminTransferOwnershipResponse) ClearPreviousOwnerUsage() {
+	x.xxx_hidden_PreviousOwnerUsage = nil
+}
+
+func (x *AdminTransferOwnershipResponse) ClearNewOwnerUsage() {
+	x.xxx_hidden_NewOwnerUsage = nil
+}
+
+type AdminTransferOwnershipResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Number of files transferred, not counting their thumbnails.
+	TransferredFiles int64
+	// Usage of the previous owner after the transfer.
+	PreviousOwnerUsage *UsageStats
+	// Usage of the new owner after the transfer.
+	NewOwnerUsage *UsageStats
+}
+
+func (b0 AdminTransferOwnershipResponse_builder) Build() *AdminTransferOwnershipResponse {
+	m0 := &AdminTransferOwnershipResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_TransferredFiles = b.TransferredFiles
+	x.xxx_hidden_PreviousOwnerUsage = b.PreviousOwnerUsage
+	x.xxx_hidden_NewOwnerUsage = b.NewOwnerUsage
+	return m0
+}
+
+// AdminDeleteContentRequest deletes any media of the caller's tenant
+// partition.
+type AdminDeleteContentRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId        string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_Reason         string                 `protobuf:"bytes,2,opt,name=reason,proto3"`
+	xxx_hidden_IdempotencyKey string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *AdminDeleteContentRequest) Reset() {
+	*x = AdminDeleteContentRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminDeleteContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminDeleteContentRequest) ProtoMessage() {}
+
+func (x *AdminDeleteContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AdminDeleteContentRequest) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *AdminDeleteContentRequest) GetReason() string {
+	if x != nil {
+		return x.xxx_hidden_Reason
+	}
+	return ""
+}
+
+func (x *AdminDeleteContentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *AdminDeleteContentRequest) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *AdminDeleteContentRequest) SetReason(v string) {
+	x.xxx_hidden_Reason = v
+}
+
+func (x *AdminDeleteContentRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+type AdminDeleteContentRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media to delete.
+	MediaId string
+	// Why the media is being deleted, for the audit log.
+	Reason string
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 AdminDeleteContentRequest_builder) Build() *AdminDeleteContentRequest {
+	m0 := &AdminDeleteContentRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_Reason = b.Reason
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type AdminDeleteContentResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Success bool                   `protobuf:"varint,1,opt,name=success,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AdminDeleteContentResponse) Reset() {
+	*x = AdminDeleteContentResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminDeleteContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminDeleteContentResponse) ProtoMessage() {}
+
+func (x *AdminDeleteContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *AdminDeleteContentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.xxx_hidden_Success
+	}
+	return false
+}
+
+func (x *AdminDeleteContentResponse) SetSuccess(v bool) {
+	x.xxx_hidden_Success = v
+}
+
+type AdminDeleteContentResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Whether the media was deleted.
+	Success bool
+}
+
+func (b0 AdminDeleteContentResponse_builder) Build() *AdminDeleteContentResponse {
+	m0 := &AdminDeleteContentResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Success = b.Success
+	return m0
+}
+
+// UserDataExport reports a user data export job.
+type UserDataExport struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ExportId      string                 `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3"`
+	xxx_hidden_State         UserDataExportState    `protobuf:"varint,2,opt,name=state,proto3,enum=files.v1.UserDataExportState"`
+	xxx_hidden_ProfileId     string                 `protobuf:"bytes,3,opt,name=profile_id,json=profileId,proto3"`
+	xxx_hidden_RequestedBy   string                 `protobuf:"bytes,4,opt,name=requested_by,json=requestedBy,proto3"`
+	xxx_hidden_TotalFiles    int64                  `protobuf:"varint,5,opt,name=total_files,json=totalFiles,proto3"`
+	xxx_hidden_ExportedFiles int64                  `protobuf:"varint,6,opt,name=exported_files,json=exportedFiles,proto3"`
+	xxx_hidden_FailedFiles   int64                  `protobuf:"varint,7,opt,name=failed_files,json=failedFiles,proto3"`
+	xxx_hidden_ExportedBytes int64                  `protobuf:"varint,8,opt,name=exported_bytes,json=exportedBytes,proto3"`
+	xxx_hidden_ResultMediaId string                 `protobuf:"bytes,9,opt,name=result_media_id,json=resultMediaId,proto3"`
+	xxx_hidden_LastError     string                 `protobuf:"bytes,10,opt,name=last_error,json=lastError,proto3"`
+	xxx_hidden_CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3"`
+	xxx_hidden_UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3"`
+	xxx_hidden_CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=completed_at,json=completedAt,proto3"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *UserDataExport) Reset() {
+	*x = UserDataExport{}
+	mi := &file_files_v1_files_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDataExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDataExport) ProtoMessage() {}
+
+func (x *UserDataExport) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UserDataExport) GetExportId() string {
+	if x != nil {
+		return x.xxx_hidden_ExportId
+	}
+	return ""
+}
+
+func (x *UserDataExport) GetState() UserDataExportState {
+	if x != nil {
+		return x.xxx_hidden_State
+	}
+	return UserDataExportState_USER_DATA_EXPORT_STATE_UNSPECIFIED
+}
+
+func (x *UserDataExport) GetProfileId() string {
+	if x != nil {
+		return x.xxx_hidden_ProfileId
+	}
+	return ""
+}
+
+func (x *UserDataExport) GetRequestedBy() string {
+	if x != nil {
+		return x.xxx_hidden_RequestedBy
+	}
+	return ""
+}
+
+func (x *UserDataExport) GetTotalFiles() int64 {
+	if x != nil {
+		return x.xxx_hidden_TotalFiles
+	}
+	return 0
+}
+
+func (x *UserDataExport) GetExportedFiles() int64 {
+	if x != nil {
+		return x.xxx_hidden_ExportedFiles
+	}
+	return 0
+}
+
+func (x *UserDataExport) GetFailedFiles() int64 {
+	if x != nil {
+		return x.xxx_hidden_FailedFiles
+	}
+	return 0
+}
+
+func (x *UserDataExport) GetExportedBytes() int64 {
+	if x != nil {
+		return x.xxx_hidden_ExportedBytes
+	}
+	return 0
+}
+
+func (x *UserDataExport) GetResultMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_ResultMediaId
+	}
+	return ""
+}
+
+func (x *UserDataExport) GetLastError() string {
+	if x != nil {
+		return x.xxx_hidden_LastError
+	}
+	return ""
+}
+
+func (x *UserDataExport) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *UserDataExport) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_UpdatedAt
+	}
+	return nil
+}
+
+func (x *UserDataExport) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CompletedAt
+	}
+	return nil
+}
+
+func (x *UserDataExport) SetExportId(v string) {
+	x.xxx_hidden_ExportId = v
+}
+
+func (x *UserDataExport) SetState(v UserDataExportState) {
+	x.xxx_hidden_State = v
+}
+
+func (x *UserDataExport) SetProfileId(v string) {
+	x.xxx_hidden_ProfileId = v
+}
+
+func (x *UserDataExport) SetRequestedBy(v string) {
+	x.xxx_hidden_RequestedBy = v
+}
+
+func (x *UserDataExport) SetTotalFiles(v int64) {
+	x.xxx_hidden_TotalFiles = v
+}
+
+func (x *UserDataExport) SetExportedFiles(v int64) {
+	x.xxx_hidden_ExportedFiles = v
+}
+
+func (x *UserDataExport) SetFailedFiles(v int64) {
+	x.xxx_hidden_FailedFiles = v
+}
+
+func (x *UserDataExport) SetExportedBytes(v int64) {
+	x.xxx_hidden_ExportedBytes = v
+}
+
+func (x *UserDataExport) SetResultMediaId(v string) {
+	x.xxx_hidden_ResultMediaId = v
+}
+
+func (x *UserDataExport) SetLastError(v string) {
+	x.xxx_hidden_LastError = v
+}
+
+func (x *UserDataExport) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *UserDataExport) SetUpdatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_UpdatedAt = v
+}
+
+func (x *UserDataExport) SetCompletedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CompletedAt = v
+}
+
+func (x *UserDataExport) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *UserDataExport) HasUpdatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_UpdatedAt != nil
+}
+
+func (x *UserDataExport) HasCompletedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CompletedAt != nil
+}
+
+func (x *UserDataExport) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+func (x *UserDataExport) ClearUpdatedAt() {
+	x.xxx_hidden_UpdatedAt = nil
+}
+
+func (x *UserDataExport) ClearCompletedAt() {
+	x.xxx_hidden_CompletedAt = nil
+}
+
+type UserDataExport_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Unique export ID.
+	ExportId string
+	// Current export state.
+	State UserDataExportState
+	// Profile whose data is exported.
+	ProfileId string
+	// Profile that requested the export; a tenant admin or the profile itself.
+	RequestedBy string
+	// Files and earlier versions to export, known once packaging starts.
+	TotalFiles int64
+	// Files and versions packaged so far.
+	ExportedFiles int64
+	// Files and versions that could not be read; listed in the manifest.
+	FailedFiles int64
+	// Decrypted bytes packaged so far.
+	ExportedBytes int64
+	// Media ID of the zip archive once the export completed.
+	ResultMediaId string
+	// Error that stopped the export, if any.
+	LastError string
+	// Time the export was requested.
+	CreatedAt *timestamppb.Timestamp
+	// Time progress was last recorded.
+	UpdatedAt *timestamppb.Timestamp
+	// Time the export completed or failed.
+	CompletedAt *timestamppb.Timestamp
+}
+
+func (b0 UserDataExport_builder) Build() *UserDataExport {
+	m0 := &UserDataExport{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_ExportId = b.ExportId
+	x.xxx_hidden_State = b.State
+	x.xxx_hidden_ProfileId = b.ProfileId
+	x.xxx_hidden_RequestedBy = b.RequestedBy
+	x.xxx_hidden_TotalFiles = b.TotalFiles
+	x.xxx_hidden_ExportedFiles = b.ExportedFiles
+	x.xxx_hidden_FailedFiles = b.FailedFiles
+	x.xxx_hidden_ExportedBytes = b.ExportedBytes
+	x.xxx_hidden_ResultMediaId = b.ResultMediaId
+	x.xxx_hidden_LastError = b.LastError
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	x.xxx_hidden_UpdatedAt = b.UpdatedAt
+	x.xxx_hidden_CompletedAt = b.CompletedAt
+	return m0
+}
+
+// ExportUserDataRequest starts an export of a profile's data.
+type ExportUserDataRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ProfileId      string                 `protobuf:"bytes,1,opt,name=profile_id,json=profileId,proto3"`
+	xxx_hidden_Reason         string                 `protobuf:"bytes,2,opt,name=reason,proto3"`
+	xxx_hidden_IdempotencyKey string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[130]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[130]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ExportUserDataRequest) GetProfileId() string {
+	if x != nil {
+		return x.xxx_hidden_ProfileId
+	}
+	return ""
+}
+
+func (x *ExportUserDataRequest) GetReason() string {
+	if x != nil {
+		return x.xxx_hidden_Reason
+	}
+	return ""
+}
+
+func (x *ExportUserDataRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *ExportUserDataRequest) SetProfileId(v string) {
+	x.xxx_hidden_ProfileId = v
+}
+
+func (x *ExportUserDataRequest) SetReason(v string) {
+	x.xxx_hidden_Reason = v
+}
+
+func (x *ExportUserDataRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+type ExportUserDataRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Profile to export; empty exports the caller. Exporting another profile
+	// needs the admin relation on the caller's tenant partition.
+	ProfileId string
+	// Why a tenant admin exports another profile; required when profile_id is
+	// not the caller and recorded in the audit log.
+	Reason string
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 ExportUserDataRequest_builder) Build() *ExportUserDataRequest {
+	m0 := &ExportUserDataRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_ProfileId = b.ProfileId
+	x.xxx_hidden_Reason = b.Reason
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type ExportUserDataResponse struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Export *UserDataExport        `protobuf:"bytes,1,opt,name=export,proto3"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[131]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[131]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ExportUserDataResponse) GetExport() *UserDataExport {
+	if x != nil {
+		return x.xxx_hidden_Export
+	}
+	return nil
+}
+
+func (x *ExportUserDataResponse) SetExport(v *UserDataExport) {
+	x.xxx_hidden_Export = v
+}
+
+func (x *ExportUserDataResponse) HasExport() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Export != nil
+}
+
+func (x *ExportUserDataResponse) ClearExport() {
+	x.xxx_hidden_Export = nil
+}
+
+type ExportUserDataResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The started export.
+	Export *UserDataExport
+}
+
+func (b0 ExportUserDataResponse_builder) Build() *ExportUserDataResponse {
+	m0 := &ExportUserDataResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Export = b.Export
+	return m0
+}
+
+type GetUserDataExportRequest struct {
+	state               protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ExportId string                 `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetUserDataExportRequest) Reset() {
+	*x = GetUserDataExportRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserDataExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserDataExportRequest) ProtoMessage() {}
+
+func (x *GetUserDataExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[132]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetUserDataExportRequest) GetExportId() string {
+	if x != nil {
+		return x.xxx_hidden_ExportId
+	}
+	return ""
+}
+
+func (x *GetUserDataExportRequest) SetExportId(v string) {
+	x.xxx_hidden_ExportId = v
+}
+
+type GetUserDataExportRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Export to report on.
+	ExportId string
+}
+
+func (b0 GetUserDataExportRequest_builder) Build() *GetUserDataExportRequest {
+	m0 := &GetUserDataExportRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_ExportId = b.ExportId
+	return m0
+}
+
+type GetUserDataExportResponse struct {
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Export *UserDataExport        `protobuf:"bytes,1,opt,name=export,proto3"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetUserDataExportResponse) Reset() {
+	*x = GetUserDataExportResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[133]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserDataExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserDataExportResponse) ProtoMessage() {}
+
+func (x *GetUserDataExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[133]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetUserDataExportResponse) GetExport() *UserDataExport {
+	if x != nil {
+		return x.xxx_hidden_Export
+	}
+	return nil
+}
+
+func (x *GetUserDataExportResponse) SetExport(v *UserDataExport) {
+	x.xxx_hidden_Export = v
+}
+
+func (x *GetUserDataExportResponse) HasExport() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Export != nil
+}
+
+func (x *GetUserDataExportResponse) ClearExport() {
+	x.xxx_hidden_Export = nil
+}
+
+type GetUserDataExportResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Export *UserDataExport
+}
+
+func (b0 GetUserDataExportResponse_builder) Build() *GetUserDataExportResponse {
+	m0 := &GetUserDataExportResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Export = b.Export
+	return m0
+}
+
+// UserDataErasureRetainedFile is a file an erasure kept.
+type UserDataErasureRetainedFile struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId       string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3"`
+	xxx_hidden_RetainedUntil *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=retained_until,json=retainedUntil,proto3"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *UserDataErasureRetainedFile) Reset() {
+	*x = UserDataErasureRetainedFile{}
+	mi := &file_files_v1_files_proto_msgTypes[134]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDataErasureRetainedFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDataErasureRetainedFile) ProtoMessage() {}
+
+func (x *UserDataErasureRetainedFile) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[134]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UserDataErasureRetainedFile) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *UserDataErasureRetainedFile) GetReason() string {
+	if x != nil {
+		return x.xxx_hidden_Reason
+	}
+	return ""
+}
+
+func (x *UserDataErasureRetainedFile) GetRetainedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_RetainedUntil
+	}
+	return nil
+}
+
+func (x *UserDataErasureRetainedFile) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *UserDataErasureRetainedFile) SetReason(v string) {
+	x.xxx_hidden_Reason = v
+}
+
+func (x *UserDataErasureRetainedFile) SetRetainedUntil(v *timestamppb.Timestamp) {
+	x.xxx_hidden_RetainedUntil = v
+}
+
+func (x *UserDataErasureRetainedFile) HasRetainedUntil() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_RetainedUntil != nil
+}
+
+func (x *UserDataErasureRetainedFile) ClearRetainedUntil() {
+	x.xxx_hidden_RetainedUntil = nil
+}
+
+type UserDataErasureRetainedFile_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Media ID of the kept file.
+	MediaId string
+	// Why it was kept: "legal_hold" or "retention".
+	Reason string
+	// Time its retention expires; unset for legal holds and permanent
+	// retention.
+	RetainedUntil *timestamppb.Timestamp
+}
+
+func (b0 UserDataErasureRetainedFile_builder) Build() *UserDataErasureRetainedFile {
+	m0 := &UserDataErasureRetainedFile{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_Reason = b.Reason
+	x.xxx_hidden_RetainedUntil = b.RetainedUntil
+	return m0
+}
+
+// UserDataErasure reports a user data erasure job.
+type UserDataErasure struct {
+	state                    protoimpl.MessageState          `protogen:"opaque.v1"`
+	xxx_hidden_ErasureId     string                          `protobuf:"bytes,1,opt,name=erasure_id,json=erasureId,proto3"`
+	xxx_hidden_State         UserDataErasureState            `protobuf:"varint,2,opt,name=state,proto3,enum=files.v1.UserDataErasureState"`
+	xxx_hidden_ProfileId     string                          `protobuf:"bytes,3,opt,name=profile_id,json=profileId,proto3"`
+	xxx_hidden_RequestedBy   string                          `protobuf:"bytes,4,opt,name=requested_by,json=requestedBy,proto3"`
+	xxx_hidden_ErasedFiles   int64                           `protobuf:"varint,5,opt,name=erased_files,json=erasedFiles,proto3"`
+	xxx_hidden_RetainedFiles *[]*UserDataErasureRetainedFile `protobuf:"bytes,6,rep,name=retained_files,json=retainedFiles,proto3"`
+	xxx_hidden_Report        string                          `protobuf:"bytes,7,opt,name=report,proto3"`
+	xxx_hidden_Signature     string                          `protobuf:"bytes,8,opt,name=signature,proto3"`
+	xxx_hidden_LastError     string                          `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3"`
+	xxx_hidden_CreatedAt     *timestamppb.Timestamp          `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3"`
+	xxx_hidden_CompletedAt   *timestamppb.Timestamp          `protobuf:"bytes,11,opt,name=completed_at,json=completedAt,proto3"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *UserDataErasure) Reset() {
+	*x = UserDataErasure{}
+	mi := &file_files_v1_files_proto_msgTypes[135]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDataErasure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDataErasure) ProtoMessage() {}
+
+func (x *UserDataErasure) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[135]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *UserDataErasure) GetErasureId() string {
+	if x != nil {
+		return x.xxx_hidden_ErasureId
+	}
+	return ""
+}
+
+func (x *UserDataErasure) GetState() UserDataErasureState {
+	if x != nil {
+		return x.xxx_hidden_State
+	}
+	return UserDataErasureState_USER_DATA_ERASURE_STATE_UNSPECIFIED
+}
+
+func (x *UserDataErasure) GetProfileId() string {
+	if x != nil {
+		return x.xxx_hidden_ProfileId
+	}
+	return ""
+}
+
+func (x *UserDataErasure) GetRequestedBy() string {
+	if x != nil {
+		return x.xxx_hidden_RequestedBy
+	}
+	return ""
+}
+
+func (x *UserDataErasure) GetErasedFiles() int64 {
+	if x != nil {
+		return x.xxx_hidden_ErasedFiles
+	}
+	return 0
+}
+
+func (x *UserDataErasure) GetRetainedFiles() []*UserDataErasureRetainedFile {
+	if x != nil {
+		if x.xxx_hidden_RetainedFiles != nil {
+			return *x.xxx_hidden_RetainedFiles
+		}
+	}
+	return nil
+}
+
+func (x *UserDataErasure) GetReport() string {
+	if x != nil {
+		return x.xxx_hidden_Report
+	}
+	return ""
+}
+
+func (x *UserDataErasure) GetSignature() string {
+	if x != nil {
+		return x.xxx_hidden_Signature
+	}
+	return ""
+}
+
+func (x *UserDataErasure) GetLastError() string {
+	if x != nil {
+		return x.xxx_hidden_LastError
+	}
+	return ""
+}
+
+func (x *UserDataErasure) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CreatedAt
+	}
+	return nil
+}
+
+func (x *UserDataErasure) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_CompletedAt
+	}
+	return nil
+}
+
+func (x *UserDataErasure) SetErasureId(v string) {
+	x.xxx_hidden_ErasureId = v
+}
+
+func (x *UserDataErasure) SetState(v UserDataErasureState) {
+	x.xxx_hidden_State = v
+}
+
+func (x *UserDataErasure) SetProfileId(v string) {
+	x.xxx_hidden_ProfileId = v
+}
+
+func (x *UserDataErasure) SetRequestedBy(v string) {
+	x.xxx_hidden_RequestedBy = v
+}
+
+func (x *UserDataErasure) SetErasedFiles(v int64) {
+	x.xxx_hidden_ErasedFiles = v
+}
+
+func (x *UserDataErasure) SetRetainedFiles(v []*UserDataErasureRetainedFile) {
+	x.xxx_hidden_RetainedFiles = &v
+}
+
+func (x *UserDataErasure) SetReport(v string) {
+	x.xxx_hidden_Report = v
+}
+
+func (x *UserDataErasure) SetSignature(v string) {
+	x.xxx_hidden_Signature = v
+}
+
+func (x *UserDataErasure) SetLastError(v string) {
+	x.xxx_hidden_LastError = v
+}
+
+func (x *UserDataErasure) SetCreatedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CreatedAt = v
+}
+
+func (x *UserDataErasure) SetCompletedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_CompletedAt = v
+}
+
+func (x *UserDataErasure) HasCreatedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CreatedAt != nil
+}
+
+func (x *UserDataErasure) HasCompletedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_CompletedAt != nil
+}
+
+func (x *UserDataErasure) ClearCreatedAt() {
+	x.xxx_hidden_CreatedAt = nil
+}
+
+func (x *UserDataErasure) ClearCompletedAt() {
+	x.xxx_hidden_CompletedAt = nil
+}
+
+type UserDataErasure_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Unique erasure ID.
+	ErasureId string
+	// Current erasure state.
+	State UserDataErasureState
+	// Profile whose data is erased.
+	ProfileId string
+	// Profile that requested the erasure; a tenant admin or the profile itself.
+	RequestedBy string
+	// Files erased, once the erasure completed.
+	ErasedFiles int64
+	// Files kept by a legal hold or retention, once the erasure completed.
+	RetainedFiles []*UserDataErasureRetainedFile
+	// JSON report of everything erased, revoked, anonymized and kept, once
+	// the erasure completed.
+	Report string
+	// Signature of the report: "sha256=" followed by the hex HMAC-SHA256 of
+	// the report bytes under the service's erasure report key.
+	Signature string
+	// Error that stopped the erasure, if any.
+	LastError string
+	// Time the erasure was requested.
+	CreatedAt *timestamppb.Timestamp
+	// Time the erasure completed or failed.
+	CompletedAt *timestamppb.Timestamp
+}
+
+func (b0 UserDataErasure_builder) Build() *UserDataErasure {
+	m0 := &UserDataErasure{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_ErasureId = b.ErasureId
+	x.xxx_hidden_State = b.State
+	x.xxx_hidden_ProfileId = b.ProfileId
+	x.xxx_hidden_RequestedBy = b.RequestedBy
+	x.xxx_hidden_ErasedFiles = b.ErasedFiles
+	x.xxx_hidden_RetainedFiles = &b.RetainedFiles
+	x.xxx_hidden_Report = b.Report
+	x.xxx_hidden_Signature = b.Signature
+	x.xxx_hidden_LastError = b.LastError
+	x.xxx_hidden_CreatedAt = b.CreatedAt
+	x.xxx_hidden_CompletedAt = b.CompletedAt
+	return m0
+}
+
+// EraseUserDataRequest starts an erasure of a profile's data.
+type EraseUserDataRequest struct {
+	state                     protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ProfileId      string                 `protobuf:"bytes,1,opt,name=profile_id,json=profileId,proto3"`
+	xxx_hidden_Reason         string                 `protobuf:"bytes,2,opt,name=reason,proto3"`
+	xxx_hidden_IdempotencyKey string                 `protobuf:"bytes,100,opt,name=idempotency_key,json=idempotencyKey,proto3"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *EraseUserDataRequest) Reset() {
+	*x = EraseUserDataRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[136]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataRequest) ProtoMessage() {}
+
+func (x *EraseUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[136]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *EraseUserDataRequest) GetProfileId() string {
+	if x != nil {
+		return x.xxx_hidden_ProfileId
+	}
+	return ""
+}
+
+func (x *EraseUserDataRequest) GetReason() string {
+	if x != nil {
+		return x.xxx_hidden_Reason
+	}
+	return ""
+}
+
+func (x *EraseUserDataRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.xxx_hidden_IdempotencyKey
+	}
+	return ""
+}
+
+func (x *EraseUserDataRequest) SetProfileId(v string) {
+	x.xxx_hidden_ProfileId = v
+}
+
+func (x *EraseUserDataRequest) SetReason(v string) {
+	x.xxx_hidden_Reason = v
+}
+
+func (x *EraseUserDataRequest) SetIdempotencyKey(v string) {
+	x.xxx_hidden_IdempotencyKey = v
+}
+
+type EraseUserDataRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Profile to erase; empty erases the caller. Erasing another profile
+	// needs the admin relation on the caller's tenant partition.
+	ProfileId string
+	// Why a tenant admin erases another profile; required when profile_id is
+	// not the caller and recorded in the audit log.
+	Reason string
+	// Idempotency key.
+	IdempotencyKey string
+}
+
+func (b0 EraseUserDataRequest_builder) Build() *EraseUserDataRequest {
+	m0 := &EraseUserDataRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_ProfileId = b.ProfileId
+	x.xxx_hidden_Reason = b.Reason
+	x.xxx_hidden_IdempotencyKey = b.IdempotencyKey
+	return m0
+}
+
+type EraseUserDataResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Erasure *UserDataErasure       `protobuf:"bytes,1,opt,name=erasure,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *EraseUserDataResponse) Reset() {
+	*x = EraseUserDataResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[137]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataResponse) ProtoMessage() {}
+
+func (x *EraseUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[137]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *EraseUserDataResponse) GetErasure() *UserDataErasure {
+	if x != nil {
+		return x.xxx_hidden_Erasure
+	}
+	return nil
+}
+
+func (x *EraseUserDataResponse) SetErasure(v *UserDataErasure) {
+	x.xxx_hidden_Erasure = v
+}
+
+func (x *EraseUserDataResponse) HasErasure() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Erasure != nil
+}
+
+func (x *EraseUserDataResponse) ClearErasure() {
+	x.xxx_hidden_Erasure = nil
+}
+
+type EraseUserDataResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The started erasure.
+	Erasure *UserDataErasure
+}
+
+func (b0 EraseUserDataResponse_builder) Build() *EraseUserDataResponse {
+	m0 := &EraseUserDataResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Erasure = b.Erasure
+	return m0
+}
+
+type GetUserDataErasureRequest struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ErasureId string                 `protobuf:"bytes,1,opt,name=erasure_id,json=erasureId,proto3"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetUserDataErasureRequest) Reset() {
+	*x = GetUserDataErasureRequest{}
+	mi := &file_files_v1_files_proto_msgTypes[138]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserDataErasureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserDataErasureRequest) ProtoMessage() {}
+
+func (x *GetUserDataErasureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[138]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetUserDataErasureRequest) GetErasureId() string {
+	if x != nil {
+		return x.xxx_hidden_ErasureId
+	}
+	return ""
+}
+
+func (x *GetUserDataErasureRequest) SetErasureId(v string) {
+	x.xxx_hidden_ErasureId = v
+}
+
+type GetUserDataErasureRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Erasure to report on.
+	ErasureId string
+}
+
+func (b0 GetUserDataErasureRequest_builder) Build() *GetUserDataErasureRequest {
+	m0 := &GetUserDataErasureRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_ErasureId = b.ErasureId
+	return m0
+}
+
+type GetUserDataErasureResponse struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Erasure *UserDataErasure       `protobuf:"bytes,1,opt,name=erasure,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetUserDataErasureResponse) Reset() {
+	*x = GetUserDataErasureResponse{}
+	mi := &file_files_v1_files_proto_msgTypes[139]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserDataErasureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserDataErasureResponse) ProtoMessage() {}
+
+func (x *GetUserDataErasureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[139]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GetUserDataErasureResponse) GetErasure() *UserDataErasure {
+	if x != nil {
+		return x.xxx_hidden_Erasure
+	}
+	return nil
+}
+
+func (x *GetUserDataErasureResponse) SetErasure(v *UserDataErasure) {
+	x.xxx_hidden_Erasure = v
+}
+
+func (x *GetUserDataErasureResponse) HasErasure() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Erasure != nil
+}
+
+func (x *GetUserDataErasureResponse) ClearErasure() {
+	x.xxx_hidden_Erasure = nil
+}
+
+type GetUserDataErasureResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Erasure *UserDataErasure
+}
+
+func (b0 GetUserDataErasureResponse_builder) Build() *GetUserDataErasureResponse {
+	m0 := &GetUserDataErasureResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Erasure = b.Erasure
+	return m0
+}
+
+// Parts that make up the complete file.
+// Must include all parts in order.
+// Server verifies each part's etag matches.
+type CompleteMultipartUploadRequest_Part struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_PartNumber int32                  `protobuf:"varint,1,opt,name=part_number,json=partNumber,proto3"`
+	xxx_hidden_Etag       string                 `protobuf:"bytes,2,opt,name=etag,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CompleteMultipartUploadRequest_Part) Reset() {
+	*x = CompleteMultipartUploadRequest_Part{}
+	mi := &file_files_v1_files_proto_msgTypes[145]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteMultipartUploadRequest_Part) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteMultipartUploadRequest_Part) ProtoMessage() {}
+
+func (x *CompleteMultipartUploadRequest_Part) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[145]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *CompleteMultipartUploadRequest_Part) GetPartNumber() int32 {
+	if x != nil {
+		return x.xxx_hidden_PartNumber
+	}
+	return 0
+}
+
+func (x *CompleteMultipartUploadRequest_Part) GetEtag() string {
+	if x != nil {
+		return x.xxx_hidden_Etag
+	}
+	return ""
+}
+
+func (x *CompleteMultipartUploadRequest_Part) SetPartNumber(v int32) {
+	x.xxx_hidden_PartNumber = v
+}
+
+func (x *CompleteMultipartUploadRequest_Part) SetEtag(v string) {
+	x.xxx_hidden_Etag = v
+}
+
+type CompleteMultipartUploadRequest_Part_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	PartNumber int32
+	Etag       string
+}
+
+func (b0 CompleteMultipartUploadRequest_Part_builder) Build() *CompleteMultipartUploadRequest_Part {
+	m0 := &CompleteMultipartUploadRequest_Part{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_PartNumber = b.PartNumber
+	x.xxx_hidden_Etag = b.Etag
+	return m0
+}
+
+// Uploaded parts in ascending part_number order.
+type ListMultipartPartsResponse_Part struct {
+	state                 protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_PartNumber int32                  `protobuf:"varint,1,opt,name=part_number,json=partNumber,proto3"`
+	xxx_hidden_Etag       string                 `protobuf:"bytes,2,opt,name=etag,proto3"`
+	xxx_hidden_Size       int64                  `protobuf:"varint,3,opt,name=size,proto3"`
+	xxx_hidden_UploadedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=uploaded_at,json=uploadedAt,proto3"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ListMultipartPartsResponse_Part) Reset() {
+	*x = ListMultipartPartsResponse_Part{}
+	mi := &file_files_v1_files_proto_msgTypes[146]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMultipartPartsResponse_Part) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMultipartPartsResponse_Part) ProtoMessage() {}
+
+func (x *ListMultipartPartsResponse_Part) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[146]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ListMultipartPartsResponse_Part) GetPartNumber() int32 {
+	if x != nil {
+		return x.xxx_hidden_PartNumber
+	}
+	return 0
+}
+
+func (x *ListMultipartPartsResponse_Part) GetEtag() string {
+	if x != nil {
+		return x.xxx_hidden_Etag
+	}
+	return ""
+}
+
+func (x *ListMultipartPartsResponse_Part) GetSize() int64 {
+	if x != nil {
+		return x.xxx_hidden_Size
+	}
+	return 0
+}
+
+func (x *ListMultipartPartsResponse_Part) GetUploadedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.xxx_hidden_UploadedAt
+	}
+	return nil
+}
+
+func (x *ListMultipartPartsResponse_Part) SetPartNumber(v int32) {
+	x.xxx_hidden_PartNumber = v
+}
+
+func (x *ListMultipartPartsResponse_Part) SetEtag(v string) {
+	x.xxx_hidden_Etag = v
+}
+
+func (x *ListMultipartPartsResponse_Part) SetSize(v int64) {
+	x.xxx_hidden_Size = v
+}
+
+func (x *ListMultipartPartsResponse_Part) SetUploadedAt(v *timestamppb.Timestamp) {
+	x.xxx_hidden_UploadedAt = v
+}
+
+func (x *ListMultipartPartsResponse_Part) HasUploadedAt() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_UploadedAt != nil
+}
+
+func (x *ListMultipartPartsResponse_Part) ClearUploadedAt() {
+	x.xxx_hidden_UploadedAt = nil
+}
+
+type ListMultipartPartsResponse_Part_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	PartNumber int32
+	Etag       string
+	Size       int64
+	UploadedAt *timestamppb.Timestamp
+}
+
+func (b0 ListMultipartPartsResponse_Part_builder) Build() *ListMultipartPartsResponse_Part {
+	m0 := &ListMultipartPartsResponse_Part{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_PartNumber = b.PartNumber
+	x.xxx_hidden_Etag = b.Etag
+	x.xxx_hidden_Size = b.Size
+	x.xxx_hidden_UploadedAt = b.UploadedAt
+	return m0
+}
+
+type BatchGetContentResponse_ContentResult struct {
+	state              protoimpl.MessageState                         `protogen:"opaque.v1"`
+	xxx_hidden_MediaId string                                         `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_Result  isBatchGetContentResponse_ContentResult_Result `protobuf_oneof:"result"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BatchGetContentResponse_ContentResult) Reset() {
+	*x = BatchGetContentResponse_ContentResult{}
+	mi := &file_files_v1_files_proto_msgTypes[150]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetContentResponse_ContentResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetContentResponse_ContentResult) ProtoMessage() {}
+
+func (x *BatchGetContentResponse_ContentResult) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[150]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BatchGetContentResponse_ContentResult) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *BatchGetContentResponse_ContentResult) GetContent() *GetContentResponse {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Result.(*batchGetContentResponse_ContentResult_Content); ok {
+			return x.Content
+		}
+	}
+	return nil
+}
+
+func (x *BatchGetContentResponse_ContentResult) GetError() string {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Result.(*batchGetContentResponse_ContentResult_Error); ok {
+			return x.Error
+		}
+	}
+	return ""
+}
+
+func (x *BatchGetContentResponse_ContentResult) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *BatchGetContentResponse_ContentResult) SetContent(v *GetContentResponse) {
+	if v == nil {
+		x.xxx_hidden_Result = nil
+		return
+	}
+	x.xxx_hidden_Result = &batchGetContentResponse_ContentResult_Content{v}
+}
+
+func (x *BatchGetContentResponse_ContentResult) SetError(v string) {
+	x.xxx_hidden_Result = &batchGetContentResponse_ContentResult_Error{v}
+}
+
+func (x *BatchGetContentResponse_ContentResult) HasResult() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Result != nil
+}
+
+func (x *BatchGetContentResponse_ContentResult) HasContent() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Result.(*batchGetContentResponse_ContentResult_Content)
+	return ok
+}
+
+func (x *BatchGetContentResponse_ContentResult) HasError() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Result.(*batchGetContentResponse_ContentResult_Error)
+	return ok
+}
+
+func (x *BatchGetContentResponse_ContentResult) ClearResult() {
+	x.xxx_hidden_Result = nil
+}
+
+func (x *BatchGetContentResponse_ContentResult) ClearContent() {
+	if _, ok := x.xxx_hidden_Result.(*batchGetContentResponse_ContentResult_Content); ok {
+		x.xxx_hidden_Result = nil
+	}
+}
+
+func (x *BatchGetContentResponse_ContentResult) ClearError() {
+	if _, ok := x.xxx_hidden_Result.(*batchGetContentResponse_ContentResult_Error); ok {
+		x.xxx_hidden_Result = nil
+	}
+}
+
+const BatchGetContentResponse_ContentResult_Result_not_set_case case_BatchGetContentResponse_ContentResult_Result = 0
+const BatchGetContentResponse_ContentResult_Content_case case_BatchGetContentResponse_ContentResult_Result = 2
+const BatchGetContentResponse_ContentResult_Error_case case_BatchGetContentResponse_ContentResult_Result = 3
+
+func (x *BatchGetContentResponse_ContentResult) WhichResult() case_BatchGetContentResponse_ContentResult_Result {
+	if x == nil {
+		return BatchGetContentResponse_ContentResult_Result_not_set_case
+	}
+	switch x.xxx_hidden_Result.(type) {
+	case *batchGetContentResponse_ContentResult_Content:
+		return BatchGetContentResponse_ContentResult_Content_case
+	case *batchGetContentResponse_ContentResult_Error:
+		return BatchGetContentResponse_ContentResult_Error_case
+	default:
+		return BatchGetContentResponse_ContentResult_Result_not_set_case
+	}
+}
+
+type BatchGetContentResponse_ContentResult_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	MediaId string
+	// Fields of oneof xxx_hidden_Result:
+	Content *GetContentResponse
+	Error   *string
+	// -- end of xxx_hidden_Result
+}
+
+func (b0 BatchGetContentResponse_ContentResult_builder) Build() *BatchGetContentResponse_ContentResult {
+	m0 := &BatchGetContentResponse_ContentResult{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	if b.Content != nil {
+		x.xxx_hidden_Result = &batchGetContentResponse_ContentResult_Content{b.Content}
+	}
+	if b.Error != nil {
+		x.xxx_hidden_Result = &batchGetContentResponse_ContentResult_Error{*b.Error}
+	}
+	return m0
+}
+
+type case_BatchGetContentResponse_ContentResult_Result protoreflect.FieldNumber
+
+func (x case_BatchGetContentResponse_ContentResult_Result) String() string {
+	md := file_files_v1_files_proto_msgTypes[150].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isBatchGetContentResponse_ContentResult_Result interface {
+	isBatchGetContentResponse_ContentResult_Result()
+}
+
+type batchGetContentResponse_ContentResult_Content struct {
+	Content *GetContentResponse `protobuf:"bytes,2,opt,name=content,proto3,oneof"`
+}
+
+type batchGetContentResponse_ContentResult_Error struct {
+	Error string `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+func (*batchGetContentResponse_ContentResult_Content) isBatchGetContentResponse_ContentResult_Result() {
+}
+
+func (*batchGetContentResponse_ContentResult_Error) isBatchGetContentResponse_ContentResult_Result() {
+}
+
+type BatchDeleteContentResponse_DeleteResult struct {
+	state              protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_MediaId string                 `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3"`
+	xxx_hidden_Success bool                   `protobuf:"varint,2,opt,name=success,proto3"`
+	xxx_hidden_Error   string                 `protobuf:"bytes,3,opt,name=error,proto3"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BatchDeleteContentResponse_DeleteResult) Reset() {
+	*x = BatchDeleteContentResponse_DeleteResult{}
+	mi := &file_files_v1_files_proto_msgTypes[151]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteContentResponse_DeleteResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteContentResponse_DeleteResult) ProtoMessage() {}
+
+func (x *BatchDeleteContentResponse_DeleteResult) ProtoReflect() protoreflect.Message {
+	mi := &file_files_v1_files_proto_msgTypes[151]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BatchDeleteContentResponse_DeleteResult) GetMediaId() string {
+	if x != nil {
+		return x.xxx_hidden_MediaId
+	}
+	return ""
+}
+
+func (x *BatchDeleteContentResponse_DeleteResult) GetSuccess() bool {
+	if x != nil {
+		return x.xxx_hidden_Success
+	}
+	return false
+}
+
+func (x *BatchDeleteContentResponse_DeleteResult) GetError() string {
+	if x != nil {
+		return x.xxx_hidden_Error
+	}
+	return ""
+}
+
+func (x *BatchDeleteContentResponse_DeleteResult) SetMediaId(v string) {
+	x.xxx_hidden_MediaId = v
+}
+
+func (x *BatchDeleteContentResponse_DeleteResult) SetSuccess(v bool) {
+	x.xxx_hidden_Success = v
+}
+
+func (x *BatchDeleteContentResponse_DeleteResult) SetError(v string) {
+	x.xxx_hidden_Error = v
+}
+
+type BatchDeleteContentResponse_DeleteResult_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	MediaId string
+	Success bool
+	Error   string
+}
+
+func (b0 BatchDeleteContentResponse_DeleteResult_builder) Build() *BatchDeleteContentResponse_DeleteResult {
+	m0 := &BatchDeleteContentResponse_DeleteResult{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_MediaId = b.MediaId
+	x.xxx_hidden_Success = b.Success
+	x.xxx_hidden_Error = b.Error
+	return m0
+}
+
+var File_files_v1_files_proto protoreflect.FileDescriptor
+
+const file_files_v1_files_proto_rawDesc = "" +
+	"\n" +
+	"\x14files/v1/files.proto\x12\bfiles.v1\x1a\x1bbuf/validate/validate.proto\x1a\x16common/v1/common.proto\x1a\x1bcommon/v1/permissions.proto\x1a$gnostic/openapi/v3/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x86\n" +
+	"\n" +
+	"\rMediaMetadata\x126\n" +
+	"\bmedia_id\x18\x01 \x01(\tB\x1b\xbaH\x18r\x16\x10\x03\x18(2\x10[0-9a-z_-]{3,40}R\amediaId\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12&\n" +
+	"\x0ffile_size_bytes\x18\x03 \x01(\x03R\rfileSizeBytes\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1a\n" +
+	"\bfilename\x18\x06 \x01(\tR\bfilename\x12'\n" +
+	"\x0fchecksum_sha256\x18\a \x01(\tR\x0echecksumSha256\x12B\n" +
+	"\n" +
+	"visibility\x18\t \x01(\x0e2\".files.v1.MediaMetadata.VisibilityR\n" +
+	"visibility\x12-\n" +
+	"\x05extra\x18\n" +
+	" \x01(\v2\x17.google.protobuf.StructR\x05extra\x129\n" +
+	"\n" +
+	"expires_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\aversion\x18\f \x01(\x03R\aversion\x12\x1b\n" +
+	"\tis_latest\x18\r \x01(\bR\bisLatest\x12*\n" +
+	"\x05state\x18\x0e \x01(\x0e2\x14.files.v1.MediaStateR\x05state\x12\x12\n" +
+	"\x04etag\x18\x0f \x01(\tR\x04etag\x125\n" +
+	"\vscan_status\x18\x1e \x01(\x0e2\x14.files.v1.ScanStatusR\n" +
+	"scanStatus\x12;\n" +
+	"\varchived_at\x18\x1f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAt\x129\n" +
+	"\n" +
+	"deleted_at\x18  \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12;\n" +
+	"\x06labels\x18\x14 \x03(\v2#.files.v1.MediaMetadata.LabelsEntryR\x06labels\x12\x1f\n" +
+	"\vcontent_uri\x18\x15 \x01(\tR\n" +
+	"contentUri\x12'\n" +
+	"\x0forganization_id\x18\x16 \x01(\tR\x0eorganizationId\x129\n" +
+	"\n" +
+	"properties\x18\x17 \x01(\v2\x19.files.v1.MediaPropertiesR\n" +
+	"properties\x12\x1a\n" +
+	"\bblurhash\x18\x18 \x01(\tR\bblurhash\x12%\n" +
+	"\x0edominant_color\x18\x19 \x01(\tR\rdominantColor\x122\n" +
+	"\x15declared_content_type\x18\x1a \x01(\tR\x13declaredContentType\x12\x16\n" +
+	"\x06folder\x18! \x01(\tR\x06folder\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"W\n" +
+	"\n" +
+	"Visibility\x12\x1a\n" +
+	"\x16VISIBILITY_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11VISIBILITY_PUBLIC\x10\x01\x12\x16\n" +
+	"\x12VISIBILITY_PRIVATE\x10\x02\"\xd8\x02\n" +
+	"\x0fMediaProperties\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\x12\x1f\n" +
+	"\vcolor_space\x18\x04 \x01(\tR\n" +
+	"colorSpace\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\x12\x16\n" +
+	"\x06codecs\x18\x06 \x03(\tR\x06codecs\x12\x1d\n" +
+	"\n" +
+	"page_count\x18\a \x01(\x05R\tpageCount\x12\x14\n" +
+	"\x05title\x18\b \x01(\tR\x05title\x127\n" +
+	"\x04exif\x18\t \x03(\v2#.files.v1.MediaProperties.ExifEntryR\x04exif\x1a7\n" +
+	"\tExifEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd6\x02\n" +
+	"\vAccessGrant\x12!\n" +
+	"\fprincipal_id\x18\x01 \x01(\tR\vprincipalId\x12>\n" +
+	"\x0eprincipal_type\x18\x06 \x01(\x0e2\x17.files.v1.PrincipalTypeR\rprincipalType\x12(\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x14.files.v1.AccessRoleR\x04role\x129\n" +
+	"\n" +
+	"granted_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tgrantedAt\x12\x1d\n" +
+	"\n" +
+	"granted_by\x18\x04 \x01(\tR\tgrantedBy\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12%\n" +
+	"\x0einherited_from\x18\a \x01(\tR\rinheritedFrom\"\xfa\x05\n" +
+	"\x0eUploadMetadata\x12!\n" +
+	"\fcontent_type\x18\x01 \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x03R\ttotalSize\x127\n" +
+	"\n" +
+	"properties\x18\x04 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"properties\x12B\n" +
+	"\n" +
+	"visibility\x18\x06 \x01(\x0e2\".files.v1.MediaMetadata.VisibilityR\n" +
+	"visibility\x12'\n" +
+	"\x0forganization_id\x18\x17 \x01(\tR\x0eorganizationId\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1f\n" +
+	"\vserver_name\x18\b \x01(\tR\n" +
+	"serverName\x12!\n" +
+	"\apurpose\x18\n" +
+	" \x01(\tB\a\xbaH\x04r\x02\x18@R\apurpose\x12 \n" +
+	"\x06folder\x18\v \x01(\tB\b\xbaH\x05r\x03\x18\x80\bR\x06folder\x129\n" +
+	"\bmedia_id\x18\t \x01(\tB\x1e\xbaH\x1b\xd8\x01\x01r\x16\x10\x03\x18(2\x10[0-9a-z_-]{3,40}R\amediaId\x12'\n" +
+	"\x0fchecksum_sha256\x18\x10 \x01(\tR\x0echecksumSha256\x12!\n" +
+	"\fbase_version\x18\x11 \x01(\x03R\vbaseVersion\x12<\n" +
+	"\x06labels\x18\x14 \x03(\v2$.files.v1.UploadMetadata.LabelsEntryR\x06labels\x12C\n" +
+	"\vaccessor_id\x18\x0f \x03(\tB\"\xbaH\x1f\x92\x01\x1c\b\x01\"\x18r\x16\x10\x03\x18(2\x10[0-9a-z_-]{3,40}R\n" +
+	"accessorId\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x97\x01\n" +
+	"\x14UploadContentRequest\x126\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x18.files.v1.UploadMetadataH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunk\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKeyB\x06\n" +
+	"\x04data\"\xa9\x01\n" +
+	"\x15UploadContentResponse\x12\x1f\n" +
+	"\vcontent_uri\x18\x01 \x01(\tR\n" +
+	"contentUri\x12\x19\n" +
+	"\bmedia_id\x18\x02 \x01(\tR\amediaId\x12\x1f\n" +
+	"\vserver_name\x18\x03 \x01(\tR\n" +
+	"serverName\x123\n" +
+	"\bmetadata\x18\x04 \x01(\v2\x17.files.v1.MediaMetadataR\bmetadata\"\xc6\x03\n" +
+	"\x14CreateContentRequest\x12!\n" +
+	"\fcontent_type\x18\x01 \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12B\n" +
+	"\n" +
+	"visibility\x18\x03 \x01(\x0e2\".files.v1.MediaMetadata.VisibilityR\n" +
+	"visibility\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12B\n" +
+	"\x06labels\x18\x05 \x03(\v2*.files.v1.CreateContentRequest.LabelsEntryR\x06labels\x12\x1f\n" +
+	"\vaccessor_id\x18\x06 \x03(\tR\n" +
+	"accessorId\x12'\n" +
+	"\x0forganization_id\x18\a \x01(\tR\x0eorganizationId\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xaf\x01\n" +
+	"\x15CreateContentResponse\x12\x1f\n" +
+	"\vcontent_uri\x18\x01 \x01(\tR\n" +
+	"contentUri\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x19\n" +
+	"\bmedia_id\x18\x03 \x01(\tR\amediaId\x12\x1f\n" +
+	"\vserver_name\x18\x04 \x01(\tR\n" +
+	"serverName\"\xf5\x03\n" +
+	"\x1cCreateMultipartUploadRequest\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x03R\ttotalSize\x12B\n" +
+	"\n" +
+	"visibility\x18\x04 \x01(\x0e2\".files.v1.MediaMetadata.VisibilityR\n" +
+	"visibility\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12J\n" +
+	"\x06labels\x18\x06 \x03(\v22.files.v1.CreateMultipartUploadRequest.LabelsEntryR\x06labels\x12\x1f\n" +
+	"\vaccessor_id\x18\a \x03(\tR\n" +
+	"accessorId\x12'\n" +
+	"\x0forganization_id\x18\b \x01(\tR\x0eorganizationId\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"<\n" +
+	"\x1dCreateMultipartUploadResponse\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\"t\n" +
+	"\x1aUploadMultipartPartRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x1f\n" +
+	"\vpart_number\x18\x02 \x01(\x05R\n" +
+	"partNumber\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\"f\n" +
+	"\x1bUploadMultipartPartResponse\x12\x12\n" +
+	"\x04etag\x18\x01 \x01(\tR\x04etag\x12\x1f\n" +
+	"\vpart_number\x18\x02 \x01(\x05R\n" +
+	"partNumber\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\"\x91\x02\n" +
+	"\x1eCompleteMultipartUploadRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12'\n" +
+	"\x0fchecksum_sha256\x18\x02 \x01(\tR\x0echecksumSha256\x12C\n" +
+	"\x05parts\x18\x03 \x03(\v2-.files.v1.CompleteMultipartUploadRequest.PartR\x05parts\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\x1a;\n" +
+	"\x04Part\x12\x1f\n" +
+	"\vpart_number\x18\x01 \x01(\x05R\n" +
+	"partNumber\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\"V\n" +
+	"\x1fCompleteMultipartUploadResponse\x123\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x17.files.v1.MediaMetadataR\bmetadata\"c\n" +
+	"\x1bAbortMultipartUploadRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"8\n" +
+	"\x1cAbortMultipartUploadResponse\x12\x18\n" +
+	"\aaborted\x18\x01 \x01(\bR\aaborted\"g\n" +
+	"\x19ListMultipartPartsRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12-\n" +
+	"\x06cursor\x18\x02 \x01(\v2\x15.common.v1.PageCursorR\x06cursor\"\xa4\x02\n" +
+	"\x1aListMultipartPartsResponse\x12?\n" +
+	"\x05parts\x18\x01 \x03(\v2).files.v1.ListMultipartPartsResponse.PartR\x05parts\x126\n" +
+	"\vnext_cursor\x18\x02 \x01(\v2\x15.common.v1.PageCursorR\n" +
+	"nextCursor\x1a\x8c\x01\n" +
+	"\x04Part\x12\x1f\n" +
+	"\vpart_number\x18\x01 \x01(\x05R\n" +
+	"partNumber\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12;\n" +
+	"\vuploaded_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"uploadedAt\"8\n" +
+	"\x19GetMultipartUploadRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\"\x85\x04\n" +
+	"\x1aGetMultipartUploadResponse\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x03R\ttotalSize\x12#\n" +
+	"\ruploaded_size\x18\x04 \x01(\x03R\fuploadedSize\x12B\n" +
+	"\n" +
+	"visibility\x18\x05 \x01(\x0e2\".files.v1.MediaMetadata.VisibilityR\n" +
+	"visibility\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12H\n" +
+	"\x06labels\x18\a \x03(\v20.files.v1.GetMultipartUploadResponse.LabelsEntryR\x06labels\x12%\n" +
+	"\x0eparts_uploaded\x18\b \x01(\x05R\rpartsUploaded\x12A\n" +
+	"\fupload_state\x18\t \x01(\x0e2\x1e.files.v1.MultipartUploadStateR\vuploadState\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"D\n" +
+	"\x18ArchiveExtractionFailure\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xc9\x04\n" +
+	"\x11ArchiveExtraction\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x126\n" +
+	"\x05state\x18\x02 \x01(\x0e2 .files.v1.ArchiveExtractionStateR\x05state\x12&\n" +
+	"\x0fsource_media_id\x18\x03 \x01(\tR\rsourceMediaId\x12\x16\n" +
+	"\x06folder\x18\x04 \x01(\tR\x06folder\x12\x18\n" +
+	"\aentries\x18\x05 \x01(\x03R\aentries\x12\x1c\n" +
+	"\textracted\x18\x06 \x01(\x03R\textracted\x12\x18\n" +
+	"\askipped\x18\a \x01(\x03R\askipped\x12\x16\n" +
+	"\x06failed\x18\b \x01(\x03R\x06failed\x12'\n" +
+	"\x0fextracted_bytes\x18\t \x01(\x03R\x0eextractedBytes\x12>\n" +
+	"\bfailures\x18\n" +
+	" \x03(\v2\".files.v1.ArchiveExtractionFailureR\bfailures\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\v \x01(\tR\tlastError\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12=\n" +
+	"\fcompleted_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"]\n" +
+	"\x15ExtractArchiveRequest\x12\"\n" +
+	"\bmedia_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\amediaId\x12 \n" +
+	"\x06folder\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\bR\x06folder\"G\n" +
+	"\x16ExtractArchiveResponse\x12-\n" +
+	"\x03job\x18\x01 \x01(\v2\x1b.files.v1.ArchiveExtractionR\x03job\"=\n" +
+	"\x1bGetArchiveExtractionRequest\x12\x1e\n" +
+	"\x06job_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x05jobId\"M\n" +
+	"\x1cGetArchiveExtractionResponse\x12-\n" +
+	"\x03job\x18\x01 \x01(\v2\x1b.files.v1.ArchiveExtractionR\x03job\"_\n" +
+	"\x19GetSignedUploadUrlRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12'\n" +
+	"\x0fexpires_seconds\x18\x02 \x01(\x03R\x0eexpiresSeconds\";\n" +
+	"\x1aGetSignedUploadUrlResponse\x12\x1d\n" +
+	"\n" +
+	"upload_url\x18\x01 \x01(\tR\tuploadUrl\"\xa9\x01\n" +
+	"\x1bFinalizeSignedUploadRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12'\n" +
+	"\x0fchecksum_sha256\x18\x02 \x01(\tR\x0echecksumSha256\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"S\n" +
+	"\x1cFinalizeSignedUploadResponse\x123\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x17.files.v1.MediaMetadataR\bmetadata\"\x99\x01\n" +
+	"\x1bGetSignedDownloadUrlRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12'\n" +
+	"\x0fexpires_seconds\x18\x02 \x01(\x03R\x0eexpiresSeconds\x12\x1a\n" +
+	"\bdownload\x18\x03 \x01(\bR\bdownload\x12\x1a\n" +
+	"\bfilename\x18\x04 \x01(\tR\bfilename\"A\n" +
+	"\x1cGetSignedDownloadUrlResponse\x12!\n" +
+	"\fdownload_url\x18\x01 \x01(\tR\vdownloadUrl\"j\n" +
+	"\x11GetContentRequest\x126\n" +
+	"\bmedia_id\x18\x01 \x01(\tB\x1b\xbaH\x18r\x16\x10\x01\x18(2\x10[0-9a-z_-]{3,40}R\amediaId\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x02 \x01(\x03R\ttimeoutMs\"c\n" +
+	"\x12GetContentResponse\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\fR\acontent\x123\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x17.files.v1.MediaMetadataR\bmetadata\"\x9c\x01\n" +
+	"\x1dGetContentOverrideNameRequest\x126\n" +
+	"\bmedia_id\x18\x01 \x01(\tB\x1b\xbaH\x18r\x16\x10\x01\x18(2\x10[0-9a-z_-]{3,40}R\amediaId\x12$\n" +
+	"\tfile_name\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\bfileName\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x03 \x01(\x03R\ttimeoutMs\"o\n" +
+	"\x1eGetContentOverrideNameResponse\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\fR\acontent\x123\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x17.files.v1.MediaMetadataR\bmetadata\"-\n" +
+	"\x17DownloadContentResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"P\n" +
+	"\x16DownloadContentRequest\x126\n" +
+	"\bmedia_id\x18\x01 \x01(\tB\x1b\xbaH\x18r\x16\x10\x01\x18(2\x10[0-9a-z_-]{3,40}R\amediaId\"b\n" +
+	"\x16DownloadArchiveRequest\x12&\n" +
+	"\tmedia_ids\x18\x01 \x03(\tB\t\xbaH\x06\x92\x01\x03\x10\xe8\aR\bmediaIds\x12 \n" +
+	"\x06folder\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\bR\x06folder\"-\n" +
+	"\x17DownloadArchiveResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"2\n" +
+	"\x1cDownloadContentRangeResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"}\n" +
+	"\x1bDownloadContentRangeRequest\x126\n" +
+	"\bmedia_id\x18\x01 \x01(\tB\x1b\xbaH\x18r\x16\x10\x01\x18(2\x10[0-9a-z_-]{3,40}R\amediaId\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\x03R\x05start\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\x03R\x03end\"L\n" +
+	"\x12HeadContentRequest\x126\n" +
+	"\bmedia_id\x18\x01 \x01(\tB\x1b\xbaH\x18r\x16\x10\x01\x18(2\x10[0-9a-z_-]{3,40}R\amediaId\"J\n" +
+	"\x13HeadContentResponse\x123\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x17.files.v1.MediaMetadataR\bmetadata\"{\n" +
+	"\x14DeleteContentRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12\x1f\n" +
+	"\vhard_delete\x18\x02 \x01(\bR\n" +
+	"hardDelete\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"d\n" +
+	"\x15DeleteContentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x121\n" +
+	"\aoutcome\x18\x02 \x01(\x0e2\x17.files.v1.DeleteOutcomeR\aoutcome\"\x8c\x04\n" +
+	"\x13PatchContentRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x124\n" +
+	"\tset_extra\x18\x02 \x01(\v2\x17.google.protobuf.StructR\bsetExtra\x12K\n" +
+	"\n" +
+	"set_labels\x18\x03 \x03(\v2,.files.v1.PatchContentRequest.SetLabelsEntryR\tsetLabels\x12#\n" +
+	"\rremove_labels\x18\x04 \x03(\tR\fremoveLabels\x12\x1a\n" +
+	"\bfilename\x18\x05 \x01(\tR\bfilename\x12B\n" +
+	"\n" +
+	"visibility\x18\x06 \x01(\x0e2\".files.v1.MediaMetadata.VisibilityR\n" +
+	"visibility\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12%\n" +
+	"\x06folder\x18\b \x01(\tB\b\xbaH\x05r\x03\x18\x80\bH\x00R\x06folder\x88\x01\x01\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\x1a<\n" +
+	"\x0eSetLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\t\n" +
+	"\a_folder\"K\n" +
+	"\x14PatchContentResponse\x123\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x17.files.v1.MediaMetadataR\bmetadata\"\x9d\x01\n" +
+	"\x12GrantAccessRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12+\n" +
+	"\x05grant\x18\x02 \x01(\v2\x15.files.v1.AccessGrantR\x05grant\x12\x16\n" +
+	"\x06folder\x18\x03 \x01(\tR\x06folder\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"/\n" +
+	"\x13GrantAccessResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xd4\x01\n" +
+	"\x13RevokeAccessRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12!\n" +
+	"\fprincipal_id\x18\x02 \x01(\tR\vprincipalId\x12>\n" +
+	"\x0eprincipal_type\x18\x03 \x01(\x0e2\x17.files.v1.PrincipalTypeR\rprincipalType\x12\x16\n" +
+	"\x06folder\x18\x04 \x01(\tR\x06folder\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"0\n" +
+	"\x14RevokeAccessResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xac\x01\n" +
+	"\x11ListAccessRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x125\n" +
+	"\vfilter_role\x18\x02 \x01(\x0e2\x14.files.v1.AccessRoleR\n" +
+	"filterRole\x12-\n" +
+	"\x06cursor\x18\x03 \x01(\v2\x15.common.v1.PageCursorR\x06cursor\x12\x16\n" +
+	"\x06folder\x18\x04 \x01(\tR\x06folder\"{\n" +
+	"\x12ListAccessResponse\x12-\n" +
+	"\x06grants\x18\x01 \x03(\v2\x15.files.v1.AccessGrantR\x06grants\x126\n" +
+	"\vnext_cursor\x18\x02 \x01(\v2\x15.common.v1.PageCursorR\n" +
+	"nextCursor\"\x88\x01\n" +
+	"\x17ListSharedWithMeRequest\x12-\n" +
+	"\x06cursor\x18\x01 \x01(\v2\x15.common.v1.PageCursorR\x06cursor\x12\x1b\n" +
+	"\tshared_by\x18\x02 \x01(\tR\bsharedBy\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\"h\n" +
+	"\n" +
+	"SharedItem\x12-\n" +
+	"\x05media\x18\x01 \x01(\v2\x17.files.v1.MediaMetadataR\x05media\x12+\n" +
+	"\x05grant\x18\x02 \x01(\v2\x15.files.v1.AccessGrantR\x05grant\"~\n" +
+	"\x18ListSharedWithMeResponse\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.files.v1.SharedItemR\x05items\x126\n" +
+	"\vnext_cursor\x18\x02 \x01(\v2\x15.common.v1.PageCursorR\n" +
+	"nextCursor\"\xd2\x01\n" +
+	"\x18TransferOwnershipRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12\x16\n" +
+	"\x06folder\x18\x02 \x01(\tR\x06folder\x12\x1b\n" +
+	"\tall_files\x18\x03 \x01(\bR\ballFiles\x12 \n" +
+	"\fnew_owner_id\x18\x04 \x01(\tR\n" +
+	"newOwnerId\x12D\n" +
+	"\x13previous_owner_role\x18\x05 \x01(\x0e2\x14.files.v1.AccessRoleR\x11previousOwnerRole\"\xce\x01\n" +
+	"\x19TransferOwnershipResponse\x12+\n" +
+	"\x11transferred_files\x18\x01 \x01(\x03R\x10transferredFiles\x12F\n" +
+	"\x14previous_owner_usage\x18\x02 \x01(\v2\x14.files.v1.UsageStatsR\x12previousOwnerUsage\x12<\n" +
+	"\x0fnew_owner_usage\x18\x03 \x01(\v2\x14.files.v1.UsageStatsR\rnewOwnerUsage\"\xbf\x04\n" +
+	"\tShareLink\x12\x17\n" +
+	"\alink_id\x18\x01 \x01(\tR\x06linkId\x12\x19\n" +
+	"\bmedia_id\x18\x02 \x01(\tR\amediaId\x12=\n" +
+	"\n" +
+	"permission\x18\x03 \x01(\x0e2\x1d.files.v1.ShareLinkPermissionR\n" +
+	"permission\x12.\n" +
+	"\x05state\x18\x04 \x01(\x0e2\x18.files.v1.ShareLinkStateR\x05state\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12-\n" +
+	"\x12password_protected\x18\x06 \x01(\bR\x11passwordProtected\x12#\n" +
+	"\rmax_downloads\x18\a \x01(\x05R\fmaxDownloads\x12%\n" +
+	"\x0edownload_count\x18\b \x01(\x05R\rdownloadCount\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\t \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"revoked_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\x12D\n" +
+	"\x10last_accessed_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x0elastAccessedAt\"\x89\x02\n" +
+	"\x16CreateShareLinkRequest\x12\"\n" +
+	"\bmedia_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\amediaId\x12=\n" +
+	"\n" +
+	"permission\x18\x02 \x01(\x0e2\x1d.files.v1.ShareLinkPermissionR\n" +
+	"permission\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12#\n" +
+	"\bpassword\x18\x04 \x01(\tB\a\xbaH\x04r\x02\x18HR\bpassword\x12,\n" +
+	"\rmax_downloads\x18\x05 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\fmaxDownloads\"j\n" +
+	"\x17CreateShareLinkResponse\x12'\n" +
+	"\x04link\x18\x01 \x01(\v2\x13.files.v1.ShareLinkR\x04link\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\"\x93\x01\n" +
+	"\x15ListShareLinksRequest\x12\"\n" +
+	"\bmedia_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\amediaId\x12'\n" +
+	"\x0finclude_revoked\x18\x02 \x01(\bR\x0eincludeRevoked\x12-\n" +
+	"\x06cursor\x18\x03 \x01(\v2\x15.common.v1.PageCursorR\x06cursor\"{\n" +
+	"\x16ListShareLinksResponse\x12)\n" +
+	"\x05links\x18\x01 \x03(\v2\x13.files.v1.ShareLinkR\x05links\x126\n" +
+	"\vnext_cursor\x18\x02 \x01(\v2\x15.common.v1.PageCursorR\n" +
+	"nextCursor\":\n" +
+	"\x16RevokeShareLinkRequest\x12 \n" +
+	"\alink_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06linkId\"B\n" +
+	"\x17RevokeShareLinkResponse\x12'\n" +
+	"\x04link\x18\x01 \x01(\v2\x13.files.v1.ShareLinkR\x04link\"\xa7\x04\n" +
+	"\x1aGetContentThumbnailRequest\x126\n" +
+	"\bmedia_id\x18\x01 \x01(\tB\x1b\xbaH\x18r\x16\x10\x01\x18(2\x10[0-9a-z_-]{3,40}R\amediaId\x12\x1d\n" +
+	"\x05width\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02 \x00R\x05width\x12\x1f\n" +
+	"\x06height\x18\x03 \x01(\x05B\a\xbaH\x04\x1a\x02 \x00R\x06height\x121\n" +
+	"\x06method\x18\x04 \x01(\x0e2\x19.files.v1.ThumbnailMethodR\x06method\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x05 \x01(\x03R\ttimeoutMs\x12\x1a\n" +
+	"\banimated\x18\x06 \x01(\bR\banimated\x12'\n" +
+	"\x06rotate\x18\a \x01(\x05B\x0f\xbaH\f\x1a\n" +
+	"0\x000Z0\xb4\x010\x8e\x02R\x06rotate\x12-\n" +
+	"\x06format\x18\b \x01(\x0e2\x15.files.v1.ImageFormatR\x06format\x12#\n" +
+	"\aquality\x18\t \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x00R\aquality\x12-\n" +
+	"\afocal_x\x18\n" +
+	" \x01(\x02B\x0f\xbaH\f\n" +
+	"\n" +
+	"\x1d\x00\x00\x80?-\x00\x00\x00\x00H\x00R\x06focalX\x88\x01\x01\x12-\n" +
+	"\afocal_y\x18\v \x01(\x02B\x0f\xbaH\f\n" +
+	"\n" +
+	"\x1d\x00\x00\x80?-\x00\x00\x00\x00H\x01R\x06focalY\x88\x01\x01\x120\n" +
+	"\x03dpr\x18\f \x01(\x02B\x1e\xbaH\x1b\n" +
+	"\x195\x00\x00\x00\x005\x00\x00\x80?5\x00\x00\xc0?5\x00\x00\x00@5\x00\x00@@R\x03dprB\n" +
+	"\n" +
+	"\b_focal_xB\n" +
+	"\n" +
+	"\b_focal_y\"\x8f\x01\n" +
+	"\x1bGetContentThumbnailResponse\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\fR\acontent\x123\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x17.files.v1.MediaMetadataR\bmetadata\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\"\xbc\x03\n" +
+	"\x0fThumbnailStatus\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x121\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x1b.files.v1.ThumbnailJobStateR\x05state\x12\x1a\n" +
+	"\battempts\x18\x03 \x01(\x05R\battempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x04 \x01(\tR\tlastError\x12B\n" +
+	"\x0fnext_attempt_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x12=\n" +
+	"\fcompleted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12'\n" +
+	"\x0fthumbnail_count\x18\t \x01(\x05R\x0ethumbnailCount\"S\n" +
+	"\x19GetThumbnailStatusRequest\x126\n" +
+	"\bmedia_id\x18\x01 \x01(\tB\x1b\xbaH\x18r\x16\x10\x01\x18(2\x10[0-9a-z_-]{3,40}R\amediaId\"O\n" +
+	"\x1aGetThumbnailStatusResponse\x121\n" +
+	"\x06status\x18\x01 \x01(\v2\x19.files.v1.ThumbnailStatusR\x06status\"z\n" +
+	"\x17RequeueThumbnailRequest\x126\n" +
+	"\bmedia_id\x18\x01 \x01(\tB\x1b\xbaH\x18r\x16\x10\x01\x18(2\x10[0-9a-z_-]{3,40}R\amediaId\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"M\n" +
+	"\x18RequeueThumbnailResponse\x121\n" +
+	"\x06status\x18\x01 \x01(\v2\x19.files.v1.ThumbnailStatusR\x06status\"\xc6\x05\n" +
+	"\x15ThumbnailRegeneration\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\x12:\n" +
+	"\x05state\x18\x02 \x01(\x0e2$.files.v1.ThumbnailRegenerationStateR\x05state\x12\x19\n" +
+	"\bowner_id\x18\x03 \x01(\tR\aownerId\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12?\n" +
+	"\rcreated_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12,\n" +
+	"\x12remove_stale_sizes\x18\a \x01(\bR\x10removeStaleSizes\x12&\n" +
+	"\x0frate_per_second\x18\b \x01(\x05R\rratePerSecond\x12\x18\n" +
+	"\ascanned\x18\t \x01(\x03R\ascanned\x12\x1a\n" +
+	"\benqueued\x18\n" +
+	" \x01(\x03R\benqueued\x12\x18\n" +
+	"\aremoved\x18\v \x01(\x03R\aremoved\x12\x1e\n" +
+	"\n" +
+	"checkpoint\x18\f \x01(\tR\n" +
+	"checkpoint\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\r \x01(\tR\tlastError\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12=\n" +
+	"\fcompleted_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"\xa2\x03\n" +
+	"\x1bRegenerateThumbnailsRequest\x12#\n" +
+	"\bowner_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\aownerId\x12+\n" +
+	"\fcontent_type\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\vcontentType\x12?\n" +
+	"\rcreated_after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12,\n" +
+	"\x12remove_stale_sizes\x18\x05 \x01(\bR\x10removeStaleSizes\x122\n" +
+	"\x0frate_per_second\x18\x06 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x00R\rratePerSecond\x12\"\n" +
+	"\rresume_run_id\x18\a \x01(\tR\vresumeRunId\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"Q\n" +
+	"\x1cRegenerateThumbnailsResponse\x121\n" +
+	"\x03run\x18\x01 \x01(\v2\x1f.files.v1.ThumbnailRegenerationR\x03run\"c\n" +
+	"\x19ReconcileOwnershipRequest\x12-\n" +
+	"\x06cursor\x18\x01 \x01(\v2\x15.common.v1.PageCursorR\x06cursor\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\"\xaf\x01\n" +
+	"\x0eOwnershipIssue\x120\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x1c.files.v1.OwnershipIssueKindR\x04kind\x12\x19\n" +
+	"\bmedia_id\x18\x02 \x01(\tR\amediaId\x12\x1a\n" +
+	"\brelation\x18\x03 \x01(\tR\brelation\x12\x18\n" +
+	"\asubject\x18\x04 \x01(\tR\asubject\x12\x1a\n" +
+	"\brepaired\x18\x05 \x01(\bR\brepaired\"\xa0\x01\n" +
+	"\x1aReconcileOwnershipResponse\x12\x18\n" +
+	"\ascanned\x18\x01 \x01(\x03R\ascanned\x120\n" +
+	"\x06issues\x18\x02 \x03(\v2\x18.files.v1.OwnershipIssueR\x06issues\x126\n" +
+	"\vnext_cursor\x18\x03 \x01(\v2\x15.common.v1.PageCursorR\n" +
+	"nextCursor\"A\n" +
+	"\x1fGetThumbnailRegenerationRequest\x12\x1e\n" +
+	"\x06run_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x05runId\"U\n" +
+	" GetThumbnailRegenerationResponse\x121\n" +
+	"\x03run\x18\x01 \x01(\v2\x1f.files.v1.ThumbnailRegenerationR\x03run\"2\n" +
+	"\x14GetUrlPreviewRequest\x12\x1a\n" +
+	"\x03url\x18\x01 \x01(\tB\b\xbaH\x05r\x03\x88\x01\x01R\x03url\"t\n" +
+	"\x15GetUrlPreviewResponse\x120\n" +
+	"\aog_data\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06ogData\x12)\n" +
+	"\x11og_image_media_id\x18\x02 \x01(\tR\x0eogImageMediaId\"\x12\n" +
+	"\x10GetConfigRequest\"\x85\x05\n" +
+	"\x11GetConfigResponse\x12(\n" +
+	"\x10max_upload_bytes\x18\x01 \x01(\x03R\x0emaxUploadBytes\x12?\n" +
+	"\x1cdirect_client_upload_enabled\x18\x02 \x01(\bR\x19directClientUploadEnabled\x12@\n" +
+	"\x1dmax_signed_url_expire_seconds\x18\x03 \x01(\x03R\x19maxSignedUrlExpireSeconds\x12@\n" +
+	"\x1dmin_signed_url_expire_seconds\x18\x04 \x01(\x03R\x19minSignedUrlExpireSeconds\x12Y\n" +
+	"\x1bsupported_thumbnail_methods\x18\x05 \x03(\x0e2\x19.files.v1.ThumbnailMethodR\x19supportedThumbnailMethods\x12.\n" +
+	"\x13max_thumbnail_width\x18\x06 \x01(\x05R\x11maxThumbnailWidth\x120\n" +
+	"\x14max_thumbnail_height\x18\a \x01(\x05R\x12maxThumbnailHeight\x12/\n" +
+	"\x14max_labels_per_media\x18\b \x01(\x05R\x11maxLabelsPerMedia\x12/\n" +
+	"\x14max_label_key_length\x18\t \x01(\x05R\x11maxLabelKeyLength\x123\n" +
+	"\x16max_label_value_length\x18\n" +
+	" \x01(\x05R\x13maxLabelValueLength\x12-\n" +
+	"\x05extra\x18\v \x01(\v2\x17.google.protobuf.StructR\x05extra\"\xc1\b\n" +
+	"\x12SearchMediaRequest\x12-\n" +
+	"\x06cursor\x18\x01 \x01(\v2\x15.common.v1.PageCursorR\x06cursor\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x19\n" +
+	"\bid_query\x18\x03 \x01(\tR\aidQuery\x12\x19\n" +
+	"\bowner_id\x18\x04 \x01(\tR\aownerId\x12?\n" +
+	"\rcreated_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12B\n" +
+	"\n" +
+	"visibility\x18\a \x01(\x0e2\".files.v1.MediaMetadata.VisibilityR\n" +
+	"visibility\x12!\n" +
+	"\fcontent_type\x18\b \x01(\tR\vcontentType\x12@\n" +
+	"\x06labels\x18\t \x03(\v2(.files.v1.SearchMediaRequest.LabelsEntryR\x06labels\x12\x19\n" +
+	"\bsize_gte\x18\n" +
+	" \x01(\x03R\asizeGte\x12\x19\n" +
+	"\bsize_lte\x18\v \x01(\x03R\asizeLte\x12*\n" +
+	"\x05state\x18\f \x01(\x0e2\x14.files.v1.MediaStateR\x05state\x125\n" +
+	"\vscan_status\x18\r \x01(\x0e2\x14.files.v1.ScanStatusR\n" +
+	"scanStatus\x12F\n" +
+	"\fvisibilities\x18\x0e \x03(\x0e2\".files.v1.MediaMetadata.VisibilityR\fvisibilities\x12D\n" +
+	"\x13accessible_via_role\x18\x0f \x01(\x0e2\x14.files.v1.AccessRoleR\x11accessibleViaRole\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x10 \x01(\x03R\ttimeoutMs\x12'\n" +
+	"\x0forganization_id\x18\x11 \x01(\tR\x0eorganizationId\x12<\n" +
+	"\asort_by\x18\x14 \x01(\x0e2#.files.v1.SearchMediaRequest.SortByR\x06sortBy\x12\x1b\n" +
+	"\tsort_desc\x18\x15 \x01(\bR\bsortDesc\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"~\n" +
+	"\x06SortBy\x12\x17\n" +
+	"\x13SORT_BY_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12SORT_BY_CREATED_AT\x10\x01\x12\x16\n" +
+	"\x12SORT_BY_UPDATED_AT\x10\x02\x12\x14\n" +
+	"\x10SORT_BY_FILENAME\x10\x03\x12\x15\n" +
+	"\x11SORT_BY_FILE_SIZE\x10\x04\"\x80\x01\n" +
+	"\x13SearchMediaResponse\x121\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.files.v1.MediaMetadataR\aresults\x126\n" +
+	"\vnext_cursor\x18\x02 \x01(\v2\x15.common.v1.PageCursorR\n" +
+	"nextCursor\"A\n" +
+	"\x16BatchGetContentRequest\x12'\n" +
+	"\tmedia_ids\x18\x01 \x03(\tB\n" +
+	"\xbaH\a\x92\x01\x04\b\x01\x10dR\bmediaIds\"\xed\x01\n" +
+	"\x17BatchGetContentResponse\x12I\n" +
+	"\aresults\x18\x01 \x03(\v2/.files.v1.BatchGetContentResponse.ContentResultR\aresults\x1a\x86\x01\n" +
+	"\rContentResult\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x128\n" +
+	"\acontent\x18\x02 \x01(\v2\x1c.files.v1.GetContentResponseH\x00R\acontent\x12\x16\n" +
+	"\x05error\x18\x03 \x01(\tH\x00R\x05errorB\b\n" +
+	"\x06result\"\x8e\x01\n" +
+	"\x19BatchDeleteContentRequest\x12'\n" +
+	"\tmedia_ids\x18\x01 \x03(\tB\n" +
+	"\xbaH\a\x92\x01\x04\b\x01\x10dR\bmediaIds\x12\x1f\n" +
+	"\vhard_delete\x18\x02 \x01(\bR\n" +
+	"hardDelete\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"\xc4\x01\n" +
+	"\x1aBatchDeleteContentResponse\x12K\n" +
+	"\aresults\x18\x01 \x03(\v21.files.v1.BatchDeleteContentResponse.DeleteResultR\aresults\x1aY\n" +
+	"\fDeleteResult\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xe4\x01\n" +
+	"\vFileVersion\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\x12\x19\n" +
+	"\bmedia_id\x18\x02 \x01(\tR\amediaId\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x05 \x01(\x03R\tsizeBytes\x12'\n" +
+	"\x0fchecksum_sha256\x18\x06 \x01(\tR\x0echecksumSha256\"}\n" +
+	"\x12GetVersionsRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12-\n" +
+	"\x06cursor\x18\x02 \x01(\v2\x15.common.v1.PageCursorR\x06cursor\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x03 \x01(\x03R\ttimeoutMs\"\xa7\x01\n" +
+	"\x13GetVersionsResponse\x121\n" +
+	"\bversions\x18\x01 \x03(\v2\x15.files.v1.FileVersionR\bversions\x12%\n" +
+	"\x0elatest_version\x18\x02 \x01(\x03R\rlatestVersion\x126\n" +
+	"\vnext_cursor\x18\x03 \x01(\v2\x15.common.v1.PageCursorR\n" +
+	"nextCursor\"u\n" +
+	"\x15RestoreVersionRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"M\n" +
+	"\x16RestoreVersionResponse\x123\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x17.files.v1.MediaMetadataR\bmetadata\"\x80\x02\n" +
+	"\x0fRetentionPolicy\x12\x1b\n" +
+	"\tpolicy_id\x18\x01 \x01(\tR\bpolicyId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12%\n" +
+	"\x0eretention_days\x18\x04 \x01(\x03R\rretentionDays\x122\n" +
+	"\x04mode\x18\x05 \x01(\x0e2\x1e.files.v1.RetentionPolicy.ModeR\x04mode\"?\n" +
+	"\x04Mode\x12\x14\n" +
+	"\x10MODE_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vMODE_DELETE\x10\x01\x12\x10\n" +
+	"\fMODE_ARCHIVE\x10\x02\"|\n" +
+	"\x19SetRetentionPolicyRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12\x1b\n" +
+	"\tpolicy_id\x18\x02 \x01(\tR\bpolicyId\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"6\n" +
+	"\x1aSetRetentionPolicyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"6\n" +
+	"\x19GetRetentionPolicyRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\"\x8a\x01\n" +
+	"\x1aGetRetentionPolicyResponse\x121\n" +
+	"\x06policy\x18\x01 \x01(\v2\x19.files.v1.RetentionPolicyR\x06policy\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"M\n" +
+	"\x1cListRetentionPoliciesRequest\x12-\n" +
+	"\x06cursor\x18\x01 \x01(\v2\x15.common.v1.PageCursorR\x06cursor\"\x8e\x01\n" +
+	"\x1dListRetentionPoliciesResponse\x125\n" +
+	"\bpolicies\x18\x01 \x03(\v2\x19.files.v1.RetentionPolicyR\bpolicies\x126\n" +
+	"\vnext_cursor\x18\x02 \x01(\v2\x15.common.v1.PageCursorR\n" +
+	"nextCursor\"\x96\x01\n" +
+	"\n" +
+	"UsageStats\x12\x1f\n" +
+	"\vtotal_files\x18\x01 \x01(\x03R\n" +
+	"totalFiles\x12\x1f\n" +
+	"\vtotal_bytes\x18\x02 \x01(\x03R\n" +
+	"totalBytes\x12!\n" +
+	"\fpublic_files\x18\x03 \x01(\x03R\vpublicFiles\x12#\n" +
+	"\rprivate_files\x18\x04 \x01(\x03R\fprivateFiles\".\n" +
+	"\x13GetUserUsageRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xbc\x01\n" +
+	"\x14GetUserUsageResponse\x12*\n" +
+	"\x05usage\x18\x01 \x01(\v2\x14.files.v1.UsageStatsR\x05usage\x12=\n" +
+	"\fperiod_start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x129\n" +
+	"\n" +
+	"period_end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tperiodEnd\"\x18\n" +
+	"\x16GetStorageStatsRequest\"|\n" +
+	"\x17GetStorageStatsResponse\x12\x1f\n" +
+	"\vtotal_bytes\x18\x01 \x01(\x03R\n" +
+	"totalBytes\x12\x1f\n" +
+	"\vtotal_files\x18\x02 \x01(\x03R\n" +
+	"totalFiles\x12\x1f\n" +
+	"\vtotal_users\x18\x03 \x01(\x03R\n" +
+	"totalUsers\"\xff\x02\n" +
+	"\x0eLifecycleEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12;\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\x0e2\x1c.files.v1.LifecycleEventTypeR\teventType\x12\x19\n" +
+	"\bmedia_id\x18\x03 \x01(\tR\amediaId\x12\x19\n" +
+	"\bowner_id\x18\x04 \x01(\tR\aownerId\x12\x19\n" +
+	"\bactor_id\x18\x05 \x01(\tR\aactorId\x12;\n" +
+	"\voccurred_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12H\n" +
+	"\n" +
+	"attributes\x18\a \x03(\v2(.files.v1.LifecycleEvent.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa3\x02\n" +
+	"\x13WebhookSubscription\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tR\x0esubscriptionId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12=\n" +
+	"\vevent_types\x18\x03 \x03(\x0e2\x1c.files.v1.LifecycleEventTypeR\n" +
+	"eventTypes\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06active\x18\x05 \x01(\bR\x06active\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\a \x01(\tR\tcreatedBy\"\x8c\x04\n" +
+	"\x0fWebhookDelivery\x12\x1f\n" +
+	"\vdelivery_id\x18\x01 \x01(\tR\n" +
+	"deliveryId\x12'\n" +
+	"\x0fsubscription_id\x18\x02 \x01(\tR\x0esubscriptionId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12;\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\x0e2\x1c.files.v1.LifecycleEventTypeR\teventType\x124\n" +
+	"\x05state\x18\x05 \x01(\x0e2\x1e.files.v1.WebhookDeliveryStateR\x05state\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\x12(\n" +
+	"\x10last_status_code\x18\a \x01(\x05R\x0elastStatusCode\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x12B\n" +
+	"\x0fnext_attempt_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x12=\n" +
+	"\fdelivered_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd7\x01\n" +
+	" CreateWebhookSubscriptionRequest\x12\x1f\n" +
+	"\x03url\x18\x01 \x01(\tB\r\xbaH\n" +
+	"r\b\x10\x01\x18\x80\x10\x88\x01\x01R\x03url\x12=\n" +
+	"\vevent_types\x18\x02 \x03(\x0e2\x1c.files.v1.LifecycleEventTypeR\n" +
+	"eventTypes\x12*\n" +
+	"\vdescription\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xf4\x03R\vdescription\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"\x8d\x01\n" +
+	"!CreateWebhookSubscriptionResponse\x12A\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x1d.files.v1.WebhookSubscriptionR\fsubscription\x12%\n" +
+	"\x0esigning_secret\x18\x02 \x01(\tR\rsigningSecret\"P\n" +
+	"\x1fListWebhookSubscriptionsRequest\x12-\n" +
+	"\x06cursor\x18\x01 \x01(\v2\x15.common.v1.PageCursorR\x06cursor\"\x9f\x01\n" +
+	" ListWebhookSubscriptionsResponse\x12C\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2\x1d.files.v1.WebhookSubscriptionR\rsubscriptions\x126\n" +
+	"\vnext_cursor\x18\x02 \x01(\v2\x15.common.v1.PageCursorR\n" +
+	"nextCursor\"}\n" +
+	" DeleteWebhookSubscriptionRequest\x120\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x0esubscriptionId\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"=\n" +
+	"!DeleteWebhookSubscriptionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xc2\x01\n" +
+	"\x1cListWebhookDeliveriesRequest\x120\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x0esubscriptionId\x12A\n" +
+	"\ffilter_state\x18\x02 \x01(\x0e2\x1e.files.v1.WebhookDeliveryStateR\vfilterState\x12-\n" +
+	"\x06cursor\x18\x03 \x01(\v2\x15.common.v1.PageCursorR\x06cursor\"\x92\x01\n" +
+	"\x1dListWebhookDeliveriesResponse\x129\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x19.files.v1.WebhookDeliveryR\n" +
+	"deliveries\x126\n" +
+	"\vnext_cursor\x18\x02 \x01(\v2\x15.common.v1.PageCursorR\n" +
+	"nextCursor\"\x9a\x01\n" +
+	"\x15AdminListMediaRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x16\n" +
+	"\x06folder\x18\x02 \x01(\tR\x06folder\x12-\n" +
+	"\x06cursor\x18\x03 \x01(\v2\x15.common.v1.PageCursorR\x06cursor\x12\x1f\n" +
+	"\x06reason\x18\x04 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06reason\"\x7f\n" +
+	"\x16AdminListMediaResponse\x12-\n" +
+	"\x05media\x18\x01 \x03(\v2\x17.files.v1.MediaMetadataR\x05media\x126\n" +
+	"\vnext_cursor\x18\x02 \x01(\v2\x15.common.v1.PageCursorR\n" +
+	"nextCursor\"{\n" +
+	"\x14AdminGetMediaRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12'\n" +
+	"\x0fexpires_seconds\x18\x02 \x01(\x03R\x0eexpiresSeconds\x12\x1f\n" +
+	"\x06reason\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06reason\"\x9e\x01\n" +
+	"\x15AdminGetMediaResponse\x123\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x17.files.v1.MediaMetadataR\bmetadata\x12-\n" +
+	"\x06grants\x18\x02 \x03(\v2\x15.files.v1.AccessGrantR\x06grants\x12!\n" +
+	"\fdownload_url\x18\x03 \x01(\tR\vdownloadUrl\"\xab\x01\n" +
+	"\x17AdminGrantAccessRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12+\n" +
+	"\x05grant\x18\x02 \x01(\v2\x15.files.v1.AccessGrantR\x05grant\x12\x1f\n" +
+	"\x06reason\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"4\n" +
+	"\x18AdminGrantAccessResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xbc\x02\n" +
+	"\x1dAdminTransferOwnershipRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12\x16\n" +
+	"\x06folder\x18\x03 \x01(\tR\x06folder\x12\x1b\n" +
+	"\tall_files\x18\x04 \x01(\bR\ballFiles\x12 \n" +
+	"\fnew_owner_id\x18\x05 \x01(\tR\n" +
+	"newOwnerId\x12D\n" +
+	"\x13previous_owner_role\x18\x06 \x01(\x0e2\x14.files.v1.AccessRoleR\x11previousOwnerRole\x12\x1f\n" +
+	"\x06reason\x18\a \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"\xd3\x01\n" +
+	"\x1eAdminTransferOwnershipResponse\x12+\n" +
+	"\x11transferred_files\x18\x01 \x01(\x03R\x10transferredFiles\x12F\n" +
+	"\x14previous_owner_usage\x18\x02 \x01(\v2\x14.files.v1.UsageStatsR\x12previousOwnerUsage\x12<\n" +
+	"\x0fnew_owner_usage\x18\x03 \x01(\v2\x14.files.v1.UsageStatsR\rnewOwnerUsage\"\x80\x01\n" +
+	"\x19AdminDeleteContentRequest\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12\x1f\n" +
+	"\x06reason\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"6\n" +
+	"\x1aAdminDeleteContentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xb2\x04\n" +
+	"\x0eUserDataExport\x12\x1b\n" +
+	"\texport_id\x18\x01 \x01(\tR\bexportId\x123\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x1d.files.v1.UserDataExportStateR\x05state\x12\x1d\n" +
+	"\n" +
+	"profile_id\x18\x03 \x01(\tR\tprofileId\x12!\n" +
+	"\frequested_by\x18\x04 \x01(\tR\vrequestedBy\x12\x1f\n" +
+	"\vtotal_files\x18\x05 \x01(\x03R\n" +
+	"totalFiles\x12%\n" +
+	"\x0eexported_files\x18\x06 \x01(\x03R\rexportedFiles\x12!\n" +
+	"\ffailed_files\x18\a \x01(\x03R\vfailedFiles\x12%\n" +
+	"\x0eexported_bytes\x18\b \x01(\x03R\rexportedBytes\x12&\n" +
+	"\x0fresult_media_id\x18\t \x01(\tR\rresultMediaId\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\n" +
+	" \x01(\tR\tlastError\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12=\n" +
+	"\fcompleted_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"\x81\x01\n" +
+	"\x15ExportUserDataRequest\x12\x1d\n" +
+	"\n" +
+	"profile_id\x18\x01 \x01(\tR\tprofileId\x12 \n" +
+	"\x06reason\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\bR\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"J\n" +
+	"\x16ExportUserDataResponse\x120\n" +
+	"\x06export\x18\x01 \x01(\v2\x18.files.v1.UserDataExportR\x06export\"@\n" +
+	"\x18GetUserDataExportRequest\x12$\n" +
+	"\texport_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\bexportId\"M\n" +
+	"\x19GetUserDataExportResponse\x120\n" +
+	"\x06export\x18\x01 \x01(\v2\x18.files.v1.UserDataExportR\x06export\"\x93\x01\n" +
+	"\x1bUserDataErasureRetainedFile\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12A\n" +
+	"\x0eretained_until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rretainedUntil\"\xe8\x03\n" +
+	"\x0fUserDataErasure\x12\x1d\n" +
+	"\n" +
+	"erasure_id\x18\x01 \x01(\tR\terasureId\x124\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x1e.files.v1.UserDataErasureStateR\x05state\x12\x1d\n" +
+	"\n" +
+	"profile_id\x18\x03 \x01(\tR\tprofileId\x12!\n" +
+	"\frequested_by\x18\x04 \x01(\tR\vrequestedBy\x12!\n" +
+	"\ferased_files\x18\x05 \x01(\x03R\verasedFiles\x12L\n" +
+	"\x0eretained_files\x18\x06 \x03(\v2%.files.v1.UserDataErasureRetainedFileR\rretainedFiles\x12\x16\n" +
+	"\x06report\x18\a \x01(\tR\x06report\x12\x1c\n" +
+	"\tsignature\x18\b \x01(\tR\tsignature\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\t \x01(\tR\tlastError\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fcompleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"\x80\x01\n" +
+	"\x14EraseUserDataRequest\x12\x1d\n" +
+	"\n" +
+	"profile_id\x18\x01 \x01(\tR\tprofileId\x12 \n" +
+	"\x06reason\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\bR\x06reason\x12'\n" +
+	"\x0fidempotency_key\x18d \x01(\tR\x0eidempotencyKey\"L\n" +
+	"\x15EraseUserDataResponse\x123\n" +
+	"\aerasure\x18\x01 \x01(\v2\x19.files.v1.UserDataErasureR\aerasure\"C\n" +
+	"\x19GetUserDataErasureRequest\x12&\n" +
+	"\n" +
+	"erasure_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\terasureId\"Q\n" +
+	"\x1aGetUserDataErasureResponse\x123\n" +
+	"\aerasure\x18\x01 \x01(\v2\x19.files.v1.UserDataErasureR\aerasure*&\n" +
+	"\x0fThumbnailMethod\x12\t\n" +
+	"\x05SCALE\x10\x00\x12\b\n" +
+	"\x04CROP\x10\x01*o\n" +
+	"\vImageFormat\x12\x1c\n" +
+	"\x18IMAGE_FORMAT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11IMAGE_FORMAT_JPEG\x10\x01\x12\x14\n" +
+	"\x10IMAGE_FORMAT_PNG\x10\x02\x12\x15\n" +
+	"\x11IMAGE_FORMAT_WEBP\x10\x03*\xa9\x01\n" +
+	"\n" +
+	"MediaState\x12\x1b\n" +
+	"\x17MEDIA_STATE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14MEDIA_STATE_CREATING\x10\x01\x12\x19\n" +
+	"\x15MEDIA_STATE_AVAILABLE\x10\x02\x12\x18\n" +
+	"\x14MEDIA_STATE_ARCHIVED\x10\x03\x12\x17\n" +
+	"\x13MEDIA_STATE_DELETED\x10\x04\x12\x16\n" +
+	"\x12MEDIA_STATE_FAILED\x10\x05*\x8b\x01\n" +
+	"\n" +
+	"ScanStatus\x12\x1b\n" +
+	"\x17SCAN_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13SCAN_STATUS_PENDING\x10\x01\x12\x15\n" +
+	"\x11SCAN_STATUS_CLEAN\x10\x02\x12\x18\n" +
+	"\x14SCAN_STATUS_INFECTED\x10\x03\x12\x16\n" +
+	"\x12SCAN_STATUS_FAILED\x10\x04*p\n" +
+	"\n" +
+	"AccessRole\x12\x1b\n" +
+	"\x17ACCESS_ROLE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12ACCESS_ROLE_READER\x10\x01\x12\x16\n" +
+	"\x12ACCESS_ROLE_WRITER\x10\x02\x12\x15\n" +
+	"\x11ACCESS_ROLE_OWNER\x10\x03*\x89\x01\n" +
+	"\rDeleteOutcome\x12\x1e\n" +
+	"\x1aDELETE_OUTCOME_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13DELETE_OUTCOME_SOFT\x10\x01\x12\x17\n" +
+	"\x13DELETE_OUTCOME_HARD\x10\x02\x12&\n" +
+	"\"DELETE_OUTCOME_DENIED_BY_RETENTION\x10\x03*\xf9\x01\n" +
+	"\x14MultipartUploadState\x12&\n" +
+	"\"MULTIPART_UPLOAD_STATE_UNSPECIFIED\x10\x00\x12$\n" +
+	" MULTIPART_UPLOAD_STATE_UPLOADING\x10\x01\x12%\n" +
+	"!MULTIPART_UPLOAD_STATE_COMPLETING\x10\x02\x12$\n" +
+	" MULTIPART_UPLOAD_STATE_COMPLETED\x10\x03\x12\"\n" +
+	"\x1eMULTIPART_UPLOAD_STATE_ABORTED\x10\x04\x12\"\n" +
+	"\x1eMULTIPART_UPLOAD_STATE_EXPIRED\x10\x05*\xbe\x01\n" +
+	"\rPrincipalType\x12\x1e\n" +
+	"\x1aPRINCIPAL_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13PRINCIPAL_TYPE_USER\x10\x01\x12\x1a\n" +
+	"\x16PRINCIPAL_TYPE_SERVICE\x10\x02\x12\x1f\n" +
+	"\x1bPRINCIPAL_TYPE_ORGANIZATION\x10\x03\x12\x1d\n" +
+	"\x19PRINCIPAL_TYPE_CHAT_GROUP\x10\x04\x12\x18\n" +
+	"\x14PRINCIPAL_TYPE_GROUP\x10\x05*\xbf\x02\n" +
+	"\x12LifecycleEventType\x12$\n" +
+	" LIFECYCLE_EVENT_TYPE_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dLIFECYCLE_EVENT_TYPE_UPLOADED\x10\x01\x12$\n" +
+	" LIFECYCLE_EVENT_TYPE_THUMBNAILED\x10\x02\x12\x1f\n" +
+	"\x1bLIFECYCLE_EVENT_TYPE_SHARED\x10\x03\x12$\n" +
+	" LIFECYCLE_EVENT_TYPE_MADE_PUBLIC\x10\x04\x12 \n" +
+	"\x1cLIFECYCLE_EVENT_TYPE_DELETED\x10\x05\x12$\n" +
+	" LIFECYCLE_EVENT_TYPE_QUARANTINED\x10\x06\x12+\n" +
+	"'LIFECYCLE_EVENT_TYPE_USER_DATA_EXPORTED\x10\a*\xb0\x01\n" +
+	"\x14WebhookDeliveryState\x12&\n" +
+	"\"WEBHOOK_DELIVERY_STATE_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eWEBHOOK_DELIVERY_STATE_PENDING\x10\x01\x12$\n" +
+	" WEBHOOK_DELIVERY_STATE_DELIVERED\x10\x02\x12&\n" +
+	"\"WEBHOOK_DELIVERY_STATE_DEAD_LETTER\x10\x03*\xc9\x01\n" +
+	"\x1aThumbnailRegenerationState\x12,\n" +
+	"(THUMBNAIL_REGENERATION_STATE_UNSPECIFIED\x10\x00\x12(\n" +
+	"$THUMBNAIL_REGENERATION_STATE_RUNNING\x10\x01\x12*\n" +
+	"&THUMBNAIL_REGENERATION_STATE_COMPLETED\x10\x02\x12'\n" +
+	"#THUMBNAIL_REGENERATION_STATE_FAILED\x10\x03*\xc8\x02\n" +
+	"\x12OwnershipIssueKind\x12$\n" +
+	" OWNERSHIP_ISSUE_KIND_UNSPECIFIED\x10\x00\x12,\n" +
+	"(OWNERSHIP_ISSUE_KIND_MISSING_OWNER_TUPLE\x10\x01\x12*\n" +
+	"&OWNERSHIP_ISSUE_KIND_STALE_OWNER_TUPLE\x10\x02\x12,\n" +
+	"(OWNERSHIP_ISSUE_KIND_DELETED_MEDIA_TUPLE\x10\x03\x12(\n" +
+	"$OWNERSHIP_ISSUE_KIND_UNKNOWN_SUBJECT\x10\x04\x12-\n" +
+	")OWNERSHIP_ISSUE_KIND_MISSING_PARENT_TUPLE\x10\x05\x12+\n" +
+	"'OWNERSHIP_ISSUE_KIND_STALE_PARENT_TUPLE\x10\x06*\x80\x01\n" +
+	"\x13ShareLinkPermission\x12%\n" +
+	"!SHARE_LINK_PERMISSION_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aSHARE_LINK_PERMISSION_VIEW\x10\x01\x12\"\n" +
+	"\x1eSHARE_LINK_PERMISSION_DOWNLOAD\x10\x02*\xab\x01\n" +
+	"\x0eShareLinkState\x12 \n" +
+	"\x1cSHARE_LINK_STATE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17SHARE_LINK_STATE_ACTIVE\x10\x01\x12\x1c\n" +
+	"\x18SHARE_LINK_STATE_EXPIRED\x10\x02\x12\x1c\n" +
+	"\x18SHARE_LINK_STATE_REVOKED\x10\x03\x12\x1e\n" +
+	"\x1aSHARE_LINK_STATE_EXHAUSTED\x10\x04*\xb5\x01\n" +
+	"\x16ArchiveExtractionState\x12(\n" +
+	"$ARCHIVE_EXTRACTION_STATE_UNSPECIFIED\x10\x00\x12$\n" +
+	" ARCHIVE_EXTRACTION_STATE_RUNNING\x10\x01\x12&\n" +
+	"\"ARCHIVE_EXTRACTION_STATE_COMPLETED\x10\x02\x12#\n" +
+	"\x1fARCHIVE_EXTRACTION_STATE_FAILED\x10\x03*\xaa\x01\n" +
+	"\x13UserDataExportState\x12&\n" +
+	"\"USER_DATA_EXPORT_STATE_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eUSER_DATA_EXPORT_STATE_RUNNING\x10\x01\x12$\n" +
+	" USER_DATA_EXPORT_STATE_COMPLETED\x10\x02\x12!\n" +
+	"\x1dUSER_DATA_EXPORT_STATE_FAILED\x10\x03*\xaf\x01\n" +
+	"\x14UserDataErasureState\x12'\n" +
+	"#USER_DATA_ERASURE_STATE_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fUSER_DATA_ERASURE_STATE_RUNNING\x10\x01\x12%\n" +
+	"!USER_DATA_ERASURE_STATE_COMPLETED\x10\x02\x12\"\n" +
+	"\x1eUSER_DATA_ERASURE_STATE_FAILED\x10\x03*\xa1\x01\n" +
+	"\x11ThumbnailJobState\x12#\n" +
+	"\x1fTHUMBNAIL_JOB_STATE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bTHUMBNAIL_JOB_STATE_PENDING\x10\x01\x12!\n" +
+	"\x1dTHUMBNAIL_JOB_STATE_COMPLETED\x10\x02\x12#\n" +
+	"\x1fTHUMBNAIL_JOB_STATE_DEAD_LETTER\x10\x032É\x01\n" +
+	"\fFilesService\x12\xb2\x02\n" +
+	"\rUploadContent\x12\x1e.files.v1.UploadContentRequest\x1a\x1f.files.v1.UploadContentResponse\"\xdd\x01\xbaG\xc5\x01\n" +
+	"\x05Media\x12\x1aUpload content (streaming)\x1a\x90\x01Uploads content via streaming. Supports new uploads and uploads to pre-created URIs. Send metadata as first message, followed by content chunks.*\ruploadContent\x82\xb5\x18\x10\n" +
+	"\x0econtent_upload(\x01\x12\xad\x02\n" +
+	"\rCreateContent\x12\x1e.files.v1.CreateContentRequest\x1a\x1f.files.v1.CreateContentResponse\"\xda\x01\xbaG\xc2\x01\n" +
+	"\x05Media\x12 Create pre-allocated content URI\x1a\x87\x01Creates a content URI without uploading content. Use returned server_name and media_id with UploadContent to complete the upload later.*\rcreateContent\x82\xb5\x18\x10\n" +
+	"\x0econtent_upload\x12\x9c\x02\n" +
+	"\x15CreateMultipartUpload\x12&.files.v1.CreateMultipartUploadRequest\x1a'.files.v1.CreateMultipartUploadResponse\"\xb1\x01\xbaG\x99\x01\n" +
+	"\x05Media\x12\x1fCreate multipart upload session\x1aXCreates a new multipart upload session and returns an upload_id for managing the upload.*\x15createMultipartUpload\x82\xb5\x18\x10\n" +
+	"\x0econtent_upload\x12\xfd\x01\n" +
+	"\x12GetMultipartUpload\x12#.files.v1.GetMultipartUploadRequest\x1a$.files.v1.GetMultipartUploadResponse\"\x9b\x01\xbaG\x82\x01\n" +
+	"\x05Media\x12\x1bGet multipart upload status\x1aHRetrieves the current status and progress of a multipart upload session.*\x12getMultipartUpload\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\x81\x02\n" +
+	"\x13UploadMultipartPart\x12$.files.v1.UploadMultipartPartRequest\x1a%.files.v1.UploadMultipartPartResponse\"\x9c\x01\xbaG\x84\x01\n" +
+	"\x05Media\x12\x15Upload multipart part\x1aOUploads a single part of a multipart upload. Parts can be uploaded in parallel.*\x13uploadMultipartPart\x82\xb5\x18\x10\n" +
+	"\x0econtent_upload\x12\xbb\x02\n" +
+	"\x17CompleteMultipartUpload\x12(.files.v1.CompleteMultipartUploadRequest\x1a).files.v1.CompleteMultipartUploadResponse\"\xca\x01\xbaG\xb2\x01\n" +
+	"\x05Media\x12\x19Complete multipart upload\x1auCompletes a multipart upload by verifying all parts and creating the final media. Requires checksum of complete file.*\x17completeMultipartUpload\x82\xb5\x18\x10\n" +
+	"\x0econtent_upload\x12\xef\x01\n" +
+	"\x14AbortMultipartUpload\x12%.files.v1.AbortMultipartUploadRequest\x1a&.files.v1.AbortMultipartUploadResponse\"\x87\x01\xbaGp\n" +
+	"\x05Media\x12\x16Abort multipart upload\x1a9Aborts a multipart upload, discarding all uploaded parts.*\x14abortMultipartUpload\x82\xb5\x18\x10\n" +
+	"\x0econtent_delete\x12\xf4\x01\n" +
+	"\x12ListMultipartParts\x12#.files.v1.ListMultipartPartsRequest\x1a$.files.v1.ListMultipartPartsResponse\"\x92\x01\xbaGz\n" +
+	"\x05Media\x12\x14List multipart parts\x1aGLists all uploaded parts of a multipart upload session with pagination.*\x12listMultipartParts\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\xd1\x02\n" +
+	"\x0eExtractArchive\x12\x1f.files.v1.ExtractArchiveRequest\x1a .files.v1.ExtractArchiveResponse\"\xfb\x01\xbaG\xe3\x01\n" +
+	"\x05Media\x12\x0fExtract archive\x1a\xb8\x01Starts a job expanding an uploaded zip or tar.gz archive into individual media under a folder. Each entry is processed like a regular upload; entries that fail are reported on the job.*\x0eextractArchive\x82\xb5\x18\x10\n" +
+	"\x0econtent_upload\x12\xb8\x02\n" +
+	"\x14GetArchiveExtraction\x12%.files.v1.GetArchiveExtractionRequest\x1a&.files.v1.GetArchiveExtractionResponse\"\xd0\x01\xbaG\xb5\x01\n" +
+	"\x05Media\x12\x16Get archive extraction\x1a~Returns the progress of an archive extraction job: entries extracted, skipped and failed, and why failed entries were refused.*\x14getArchiveExtraction\x82\xb5\x18\x10\n" +
+	"\x0econtent_upload\x90\x02\x01\x12\xd7\x01\n" +
+	"\vHeadContent\x12\x1c.files.v1.HeadContentRequest\x1a\x1d.files.v1.HeadContentResponse\"\x8a\x01\xbaGr\n" +
+	"\x05Media\x12\x14Get content metadata\x1aFRetrieves metadata for content without downloading the content itself.*\vheadContent\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\x80\x02\n" +
+	"\fPatchContent\x12\x1d.files.v1.PatchContentRequest\x1a\x1e.files.v1.PatchContentResponse\"\xb0\x01\xbaG\x98\x01\n" +
+	"\x05Media\x12\x16Patch content metadata\x1aiUpdates metadata for existing content. Supports filename, visibility, labels, and extra metadata updates.*\fpatchContent\x82\xb5\x18\x10\n" +
+	"\x0econtent_manage\x12\xfc\x01\n" +
+	"\x12GetSignedUploadUrl\x12#.files.v1.GetSignedUploadUrlRequest\x1a$.files.v1.GetSignedUploadUrlResponse\"\x9a\x01\xbaG\x84\x01\n" +
+	"\x05Media\x12\x15Get signed upload URL\x1aPGets a signed URL for direct upload to storage. Media must be in CREATING state.*\x12getSignedUploadUrl\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x12\x9e\x02\n" +
+	"\x14FinalizeSignedUpload\x12%.files.v1.FinalizeSignedUploadRequest\x1a&.files.v1.FinalizeSignedUploadResponse\"\xb6\x01\xbaG\x9e\x01\n" +
+	"\x05Media\x12\x16Finalize signed upload\x1agFinalizes a signed upload, verifying checksum and size, transitioning state from CREATING to AVAILABLE.*\x14finalizeSignedUpload\x82\xb5\x18\x10\n" +
+	"\x0econtent_upload\x12\xeb\x01\n" +
+	"\x14GetSignedDownloadUrl\x12%.files.v1.GetSignedDownloadUrlRequest\x1a&.files.v1.GetSignedDownloadUrlResponse\"\x83\x01\xbaGk\n" +
+	"\x05Media\x12\x17Get signed download URL\x1a3Gets a signed URL for direct download from storage.*\x14getSignedDownloadUrl\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\xe2\x01\n" +
+	"\rDeleteContent\x12\x1e.files.v1.DeleteContentRequest\x1a\x1f.files.v1.DeleteContentResponse\"\x8f\x01\xbaGx\n" +
+	"\x05Media\x12\x0eDelete content\x1aPDeletes content. Supports soft delete (recoverable) and hard delete (permanent).*\rdeleteContent\x82\xb5\x18\x10\n" +
+	"\x0econtent_delete\x12\xb7\x01\n" +
+	"\n" +
+	"GetContent\x12\x1b.files.v1.GetContentRequest\x1a\x1c.files.v1.GetContentResponse\"n\xbaGV\n" +
+	"\x05Media\x12\x10Download content\x1a/Downloads complete content from the repository.*\n" +
+	"getContent\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\x9c\x02\n" +
+	"\x16GetContentOverrideName\x12'.files.v1.GetContentOverrideNameRequest\x1a(.files.v1.GetContentOverrideNameResponse\"\xae\x01\xbaG\x95\x01\n" +
+	"\x05Media\x12'Download content with filename override\x1aKDownloads content and overrides the filename in Content-Disposition header.*\x16getContentOverrideName\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\xf5\x01\n" +
+	"\x0fDownloadContent\x12 .files.v1.DownloadContentRequest\x1a!.files.v1.DownloadContentResponse\"\x9a\x01\xbaG\x81\x01\n" +
+	"\x05Media\x12\x1cDownload content (streaming)\x1aIStreams content from the repository for large files or memory efficiency.*\x0fdownloadContent\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x010\x01\x12\x8a\x02\n" +
+	"\x14DownloadContentRange\x12%.files.v1.DownloadContentRangeRequest\x1a&.files.v1.DownloadContentRangeResponse\"\xa0\x01\xbaG\x87\x01\n" +
+	"\x05Media\x12\x16Download content range\x1aPStreams a specific byte range of content, useful for resume or partial download.*\x14downloadContentRange\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x010\x01\x12\xe9\x02\n" +
+	"\x0fDownloadArchive\x12 .files.v1.DownloadArchiveRequest\x1a!.files.v1.DownloadArchiveResponse\"\x8e\x02\xbaG\xf5\x01\n" +
+	"\x05Media\x12\x1cDownload archive (streaming)\x1a\xbc\x01Streams a zip archive of the listed files or of a folder, built as the files are read. Files that cannot be read are reported in the archive's manifest.json instead of failing the archive.*\x0fdownloadArchive\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x010\x01\x12\x97\x02\n" +
+	"\x13GetContentThumbnail\x12$.files.v1.GetContentThumbnailRequest\x1a%.files.v1.GetContentThumbnailResponse\"\xb2\x01\xbaG\x99\x01\n" +
+	"\x05Media\x12\x15Get content thumbnail\x1adGenerates a thumbnail with specified dimensions and method. Supports static and animated thumbnails.*\x13getContentThumbnail\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\x85\x02\n" +
+	"\x12GetThumbnailStatus\x12#.files.v1.GetThumbnailStatusRequest\x1a$.files.v1.GetThumbnailStatusResponse\"\xa3\x01\xbaG\x8a\x01\n" +
+	"\x05Media\x12\x14Get thumbnail status\x1aWReports the state, attempts and last error of the thumbnail generation job of an image.*\x12getThumbnailStatus\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\xff\x01\n" +
+	"\x10RequeueThumbnail\x12!.files.v1.RequeueThumbnailRequest\x1a\".files.v1.RequeueThumbnailResponse\"\xa3\x01\xbaG\x8b\x01\n" +
+	"\x05Media\x12\x1cRequeue thumbnail generation\x1aRResets a failed or dead-lettered thumbnail job and queues it for generation again.*\x10requeueThumbnail\x82\xb5\x18\x10\n" +
+	"\x0econtent_manage\x12\xd4\x02\n" +
+	"\x14RegenerateThumbnails\x12%.files.v1.RegenerateThumbnailsRequest\x1a&.files.v1.RegenerateThumbnailsResponse\"\xec\x01\xbaG\xd2\x01\n" +
+	"\vMaintenance\x12\x15Regenerate thumbnails\x1a\x95\x01Starts or resumes a throttled run that queues missing thumbnail sizes for existing images and optionally removes sizes that are no longer configured.*\x14regenerateThumbnails\x82\xb5\x18\x12\n" +
+	"\x10thumbnail_manage\x12\xa1\x02\n" +
+	"\x18GetThumbnailRegeneration\x12).files.v1.GetThumbnailRegenerationRequest\x1a*.files.v1.GetThumbnailRegenerationResponse\"\xad\x01\xbaG\x90\x01\n" +
+	"\vMaintenance\x12\x1aGet thumbnail regeneration\x1aKReports the state, checkpoint and counters of a thumbnail regeneration run.*\x18getThumbnailRegeneration\x82\xb5\x18\x12\n" +
+	"\x10thumbnail_manage\x90\x02\x01\x12\xfe\x02\n" +
+	"\x12ReconcileOwnership\x12#.files.v1.ReconcileOwnershipRequest\x1a$.files.v1.ReconcileOwnershipResponse\"\x9c\x02\xbaG\x82\x02\n" +
+	"\vMaintenance\x12\x13Reconcile ownership\x1a\xc9\x01Compares one batch of media records with their Keto tuples and reports missing owner tuples, tuples left on deleted media and grants to unknown groups or partitions. Repairs them unless dry_run is set.*\x12reconcileOwnership\x82\xb5\x18\x12\n" +
+	"\x10access_reconcile\x12\xd5\x01\n" +
+	"\rGetUrlPreview\x12\x1e.files.v1.GetUrlPreviewRequest\x1a\x1f.files.v1.GetUrlPreviewResponse\"\x82\x01\xbaGj\n" +
+	"\x05Media\x12\x0fGet URL preview\x1aARetrieves OpenGraph metadata for a URL to generate link previews.*\rgetUrlPreview\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\xce\x01\n" +
+	"\tGetConfig\x12\x1a.files.v1.GetConfigRequest\x1a\x1b.files.v1.GetConfigResponse\"\x87\x01\xbaGo\n" +
+	"\x05Media\x12\x18Get server configuration\x1aARetrieves server configuration including limits and capabilities.*\tgetConfig\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\x8a\x02\n" +
+	"\vSearchMedia\x12\x1c.files.v1.SearchMediaRequest\x1a\x1d.files.v1.SearchMediaResponse\"\xbd\x01\xbaG\xa4\x01\n" +
+	"\x05Media\x12\x12Search media files\x1azSearches for media matching specified criteria with pagination support. Uses common.SearchRequest for standard pagination.*\vsearchMedia\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\xe5\x01\n" +
+	"\x0fBatchGetContent\x12 .files.v1.BatchGetContentRequest\x1a!.files.v1.BatchGetContentResponse\"\x8c\x01\xbaGt\n" +
+	"\x05Media\x12\x11Batch get content\x1aGRetrieves multiple files in a single request. Supports partial failure.*\x0fbatchGetContent\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\xd6\x01\n" +
+	"\x12BatchDeleteContent\x12#.files.v1.BatchDeleteContentRequest\x1a$.files.v1.BatchDeleteContentResponse\"u\xbaG^\n" +
+	"\x05Media\x12\x14Batch delete content\x1a+Deletes multiple files in a single request.*\x12batchDeleteContent\x82\xb5\x18\x10\n" +
+	"\x0econtent_delete\x12\x80\x02\n" +
+	"\vGrantAccess\x12\x1c.files.v1.GrantAccessRequest\x1a\x1d.files.v1.GrantAccessResponse\"\xb3\x01\xbaG\x97\x01\n" +
+	"\x06Access\x12\fGrant access\x1arGrants a principal access to a media object, or to every file in one of the caller's folders, with specified role.*\vgrantAccess\x82\xb5\x18\x14\n" +
+	"\x12file_access_manage\x12\xca\x01\n" +
+	"\fRevokeAccess\x12\x1d.files.v1.RevokeAccessRequest\x1a\x1e.files.v1.RevokeAccessResponse\"{\xbaG`\n" +
+	"\x06Access\x12\rRevoke access\x1a9Revokes a principal's access to a media object or folder.*\frevokeAccess\x82\xb5\x18\x14\n" +
+	"\x12file_access_manage\x12\x86\x02\n" +
+	"\n" +
+	"ListAccess\x12\x1b.files.v1.ListAccessRequest\x1a\x1c.files.v1.ListAccessResponse\"\xbc\x01\xbaG\x9f\x01\n" +
+	"\x06Access\x12\x12List access grants\x1auLists all access grants for a media object or folder with pagination, marking those inherited from enclosing folders.*\n" +
+	"listAccess\x82\xb5\x18\x12\n" +
+	"\x10file_access_view\x90\x02\x01\x12\x89\x03\n" +
+	"\x10ListSharedWithMe\x12!.files.v1.ListSharedWithMeRequest\x1a\".files.v1.ListSharedWithMeResponse\"\xad\x02\xbaG\x94\x02\n" +
+	"\x06Access\x12\x19List files shared with me\x1a\xdc\x01Lists the files shared with the caller, directly or through its groups and tenant partitions, with their metadata, the role granted, who shared them and when. Supports pagination and filtering by sharer and content type.*\x10listSharedWithMe\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\xed\x02\n" +
+	"\x11TransferOwnership\x12\".files.v1.TransferOwnershipRequest\x1a#.files.v1.TransferOwnershipResponse\"\x8e\x02\xbaG\xf2\x01\n" +
+	"\x06Access\x12\x12Transfer ownership\x1a\xc0\x01Transfers ownership of a file, a folder tree or all of the caller's files to another profile, optionally leaving the caller a role on them. Returns the usage of both owners after the transfer.*\x11transferOwnership\x82\xb5\x18\x14\n" +
+	"\x12file_access_manage\x12\xd9\x02\n" +
+	"\x0fCreateShareLink\x12 .files.v1.CreateShareLinkRequest\x1a!.files.v1.CreateShareLinkResponse\"\x80\x02\xbaG\xe4\x01\n" +
+	"\x06Access\x12\x11Create share link\x1a\xb5\x01Creates a share link to a file the caller owns. The returned token and URL give access without authentication, subject to the link's expiry, password, download limit and permission.*\x0fcreateShareLink\x82\xb5\x18\x14\n" +
+	"\x12file_access_manage\x12\x8d\x02\n" +
+	"\x0eListShareLinks\x12\x1f.files.v1.ListShareLinksRequest\x1a .files.v1.ListShareLinksResponse\"\xb7\x01\xbaG\x9a\x01\n" +
+	"\x06Access\x12\x10List share links\x1anLists the share links of a file the caller owns with their state and download counts. Tokens are not returned.*\x0elistShareLinks\x82\xb5\x18\x12\n" +
+	"\x10file_access_view\x90\x02\x01\x12\xee\x01\n" +
+	"\x0fRevokeShareLink\x12 .files.v1.RevokeShareLinkRequest\x1a!.files.v1.RevokeShareLinkResponse\"\x95\x01\xbaGz\n" +
+	"\x06Access\x12\x11Revoke share link\x1aLRevokes a share link so its token no longer resolves. Revoking is permanent.*\x0frevokeShareLink\x82\xb5\x18\x14\n" +
+	"\x12file_access_manage\x12\xbe\x01\n" +
+	"\vGetVersions\x12\x1c.files.v1.GetVersionsRequest\x1a\x1d.files.v1.GetVersionsResponse\"r\xbaGZ\n" +
+	"\x05Media\x12\x11Get file versions\x1a1Retrieves all versions of a file with pagination.*\vgetVersions\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\xc9\x01\n" +
+	"\x0eRestoreVersion\x12\x1f.files.v1.RestoreVersionRequest\x1a .files.v1.RestoreVersionResponse\"t\xbaG]\n" +
+	"\x05Media\x12\x0fRestore version\x1a3Restores a specific version as the current version.*\x0erestoreVersion\x82\xb5\x18\x10\n" +
+	"\x0econtent_manage\x12\xdc\x01\n" +
+	"\x12SetRetentionPolicy\x12#.files.v1.SetRetentionPolicyRequest\x1a$.files.v1.SetRetentionPolicyResponse\"{\xbaGd\n" +
+	"\tRetention\x12\x14Set retention policy\x1a-Applies a retention policy to a media object.*\x12setRetentionPolicy\x82\xb5\x18\x10\n" +
+	"\x0econtent_manage\x12\xe5\x01\n" +
+	"\x12GetRetentionPolicy\x12#.files.v1.GetRetentionPolicyRequest\x1a$.files.v1.GetRetentionPolicyResponse\"\x83\x01\xbaGk\n" +
+	"\tRetention\x12\x14Get retention policy\x1a4Gets the retention policy applied to a media object.*\x12getRetentionPolicy\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\xf7\x01\n" +
+	"\x15ListRetentionPolicies\x12&.files.v1.ListRetentionPoliciesRequest\x1a'.files.v1.ListRetentionPoliciesResponse\"\x8c\x01\xbaGt\n" +
+	"\tRetention\x12\x17List retention policies\x1a7Lists all available retention policies with pagination.*\x15listRetentionPolicies\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\xc0\x01\n" +
+	"\fGetUserUsage\x12\x1d.files.v1.GetUserUsageRequest\x1a\x1e.files.v1.GetUserUsageResponse\"q\xbaGY\n" +
+	"\tAnalytics\x12\x0eGet user usage\x1a.Retrieves storage usage statistics for a user.*\fgetUserUsage\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\xca\x01\n" +
+	"\x0fGetStorageStats\x12 .files.v1.GetStorageStatsRequest\x1a!.files.v1.GetStorageStatsResponse\"r\xbaGZ\n" +
+	"\tAnalytics\x12\x16Get storage statistics\x1a$Retrieves global storage statistics.*\x0fgetStorageStats\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\xa3\x02\n" +
+	"\x19CreateWebhookSubscription\x12*.files.v1.CreateWebhookSubscriptionRequest\x1a+.files.v1.CreateWebhookSubscriptionResponse\"\xac\x01\xbaG\x94\x01\n" +
+	"\bWebhooks\x12\x1bCreate webhook subscription\x1aPRegisters an endpoint that receives signed file lifecycle events for the tenant.*\x19createWebhookSubscription\x82\xb5\x18\x10\n" +
+	"\x0ewebhook_manage\x12\x8f\x02\n" +
+	"\x18ListWebhookSubscriptions\x12).files.v1.ListWebhookSubscriptionsRequest\x1a*.files.v1.ListWebhookSubscriptionsResponse\"\x9b\x01\xbaG\x80\x01\n" +
+	"\bWebhooks\x12\x1aList webhook subscriptions\x1a>Lists the webhook subscriptions of the tenant with pagination.*\x18listWebhookSubscriptions\x82\xb5\x18\x10\n" +
+	"\x0ewebhook_manage\x90\x02\x01\x12\x94\x02\n" +
+	"\x19DeleteWebhookSubscription\x12*.files.v1.DeleteWebhookSubscriptionRequest\x1a+.files.v1.DeleteWebhookSubscriptionResponse\"\x9d\x01\xbaG\x85\x01\n" +
+	"\bWebhooks\x12\x1bDelete webhook subscription\x1aARemoves a webhook subscription. Pending deliveries are abandoned.*\x19deleteWebhookSubscription\x82\xb5\x18\x10\n" +
+	"\x0ewebhook_manage\x12\x88\x02\n" +
+	"\x15ListWebhookDeliveries\x12&.files.v1.ListWebhookDeliveriesRequest\x1a'.files.v1.ListWebhookDeliveriesResponse\"\x9d\x01\xbaG\x82\x01\n" +
+	"\bWebhooks\x12\x17List webhook deliveries\x1aFLists delivery attempts for a webhook subscription, most recent first.*\x15listWebhookDeliveries\x82\xb5\x18\x10\n" +
+	"\x0ewebhook_manage\x90\x02\x01\x12\xa8\x02\n" +
+	"\x0eAdminListMedia\x12\x1f.files.v1.AdminListMediaRequest\x1a .files.v1.AdminListMediaResponse\"\xd2\x01\xbaG\xb9\x01\n" +
+	"\x0eAdministration\x12\x11List tenant media\x1a\x83\x01Lists the media of every owner in the caller's tenant partition. Requires the admin relation on the partition; each use is audited.*\x0eadminListMedia\x82\xb5\x18\x0e\n" +
+	"\ftenant_admin\x90\x02\x01\x12\xcf\x02\n" +
+	"\rAdminGetMedia\x12\x1e.files.v1.AdminGetMediaRequest\x1a\x1f.files.v1.AdminGetMediaResponse\"\xfc\x01\xbaG\xe3\x01\n" +
+	"\x0eAdministration\x12\x10Get tenant media\x1a\xaf\x01Returns the metadata, access grants and a signed download URL of any media in the caller's tenant partition. Requires the admin relation on the partition; each use is audited.*\radminGetMedia\x82\xb5\x18\x0e\n" +
+	"\ftenant_admin\x90\x02\x01\x12\xc7\x02\n" +
+	"\x10AdminGrantAccess\x12!.files.v1.AdminGrantAccessRequest\x1a\".files.v1.AdminGrantAccessResponse\"\xeb\x01\xbaG\xd5\x01\n" +
+	"\x0eAdministration\x12\x12Share tenant media\x1a\x9c\x01Grants a profile, group or partition a role on any file in the caller's tenant partition. Requires the admin relation on the partition; each use is audited.*\x10adminGrantAccess\x82\xb5\x18\x0e\n" +
+	"\ftenant_admin\x12\xf7\x02\n" +
+	"\x16AdminTransferOwnership\x12'.files.v1.AdminTransferOwnershipRequest\x1a(.files.v1.AdminTransferOwnershipResponse\"\x89\x02\xbaG\xf3\x01\n" +
+	"\x0eAdministration\x12\x15Transfer tenant media\x1a\xb1\x01Transfers a file, a folder tree or all files of any owner in the caller's tenant partition to another profile. Requires the admin relation on the partition; each use is audited.*\x16adminTransferOwnership\x82\xb5\x18\x0e\n" +
+	"\ftenant_admin\x12\xa9\x02\n" +
+	"\x12AdminDeleteContent\x12#.files.v1.AdminDeleteContentRequest\x1a$.files.v1.AdminDeleteContentResponse\"\xc7\x01\xbaG\xb1\x01\n" +
+	"\x0eAdministration\x12\x13Delete tenant media\x1avDeletes any media in the caller's tenant partition. Requires the admin relation on the partition; each use is audited.*\x12adminDeleteContent\x82\xb5\x18\x0e\n" +
+	"\ftenant_admin\x12\xf1\x02\n" +
+	"\x0eExportUserData\x12\x1f.files.v1.ExportUserDataRequest\x1a .files.v1.ExportUserDataResponse\"\x9b\x02\xbaG\x85\x02\n" +
+	"\aPrivacy\x12\x10Export user data\x1a\xd7\x01Starts a job packaging every file a profile owns, decrypted with its versions and metadata, the access granted on or to them and the profile's audit trail into a zip archive stored as a private media of the profile.*\x0eexportUserData\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x12\xad\x02\n" +
+	"\x11GetUserDataExport\x12\".files.v1.GetUserDataExportRequest\x1a#.files.v1.GetUserDataExportResponse\"\xce\x01\xbaG\xb5\x01\n" +
+	"\aPrivacy\x12\x14Get user data export\x1a\x80\x01Returns the progress of a user data export: files packaged and failed so far, and the media ID of the archive once it completed.*\x11getUserDataExport\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x12\xb0\x03\n" +
+	"\rEraseUserData\x12\x1e.files.v1.EraseUserDataRequest\x1a\x1f.files.v1.EraseUserDataResponse\"\xdd\x02\xbaG\xc5\x02\n" +
+	"\aPrivacy\x12\x0fErase user data\x1a\x99\x02Starts a job permanently erasing every file a profile owns that no legal hold or retention keeps, with its versions, derivatives and stored content, revoking the access granted on them and to the profile and anonymizing its other records. The job ends with a signed erasure report.*\reraseUserData\x82\xb5\x18\x10\n" +
+	"\x0econtent_delete\x12\xbc\x02\n" +
+	"\x12GetUserDataErasure\x12#.files.v1.GetUserDataErasureRequest\x1a$.files.v1.GetUserDataErasureResponse\"\xda\x01\xbaG\xc1\x01\n" +
+	"\aPrivacy\x12\x15Get user data erasure\x1a\x8a\x01Returns the state of a user data erasure and, once it completed, the files kept by legal holds or retention and the signed erasure report.*\x12getUserDataErasure\x82\xb5\x18\x0e\n" +
+	"\fcontent_view\x90\x02\x01\x1a\xd6\x06\x82\xb5\x18\xd1\x06\n" +
+	"\fservice_file\x12\fcontent_view\x12\x0econtent_upload\x12\x0econtent_manage\x12\x0econtent_delete\x12\x10file_access_view\x12\x12file_access_manage\x12\x0ewebhook_manage\x12\x10thumbnail_manage\x12\x10access_reconcile\x12\ftenant_admin\x1a\xa8\x01\b\x01\x12\fcontent_view\x12\x0econtent_upload\x12\x0econtent_manage\x12\x0econtent_delete\x12\x10file_access_view\x12\x12file_access_manage\x12\x0ewebhook_manage\x12\x10thumbnail_manage\x12\x10access_reconcile\x12\ftenant_admin\x1a\xa8\x01\b\x02\x12\fcontent_view\x12\x0econtent_upload\x12\x0econtent_manage\x12\x0econtent_delete\x12\x10file_access_view\x12\x12file_access_manage\x12\x0ewebhook_manage\x12\x10thumbnail_manage\x12\x10access_reconcile\x12\ftenant_admin\x1aB\b\x03\x12\fcontent_view\x12\x0econtent_upload\x12\x0econtent_manage\x12\x10file_access_view\x1a\"\b\x04\x12\fcontent_view\x12\x10file_access_view\x1a2\b\x05\x12\fcontent_view\x12\x0econtent_upload\x12\x10file_access_view\x1a\xa8\x01\b\x06\x12\fcontent_view\x12\x0econtent_upload\x12\x0econtent_manage\x12\x0econtent_delete\x12\x10file_access_view\x12\x12file_access_manage\x12\x0ewebhook_manage\x12\x10thumbnail_manage\x12\x10access_reconcile\x12\ftenant_adminB\xa6\x04\xbaG\xcc\x03\x12\xa0\x03\n" +
+	"\x14Files Management API\x12\xe5\x01Comprehensive file and media management API supporting upload, download, thumbnails, versioning, retention, and search. Files are identified via content URIs using HTTPS API paths (e.g. /v1/media/download/{serverName}/{mediaId}).\"M\n" +
+	"\x10Ant Investor Ltd\x12#https://github.com/antinvestor/apis\x1a\x14info@antinvestor.com*I\n" +
+	"\x0eApache License\x127https://github.com/antinvestor/apis/blob/master/LICENSE2\x06v1.0.0*':%\n" +
+	"#\n" +
+	"\n" +
+	"BearerAuth\x12\x15\n" +
+	"\x13\n" +
+	"\x04http*\x06bearer2\x03JWT\n" +
+	"\afilesv1P\x01ZFgithub.com/antinvestor/service-files/apps/default/gen/files/v1;filesv1\xf8\x01\x01b\x06proto3"
+
+var file_files_v1_files_proto_enumTypes = make([]protoimpl.EnumInfo, 21)
+var file_files_v1_files_proto_msgTypes = make([]protoimpl.MessageInfo, 153)
+var file_files_v1_files_proto_goTypes = []any{
+	(ThumbnailMethod)(0),                            // 0: files.v1.ThumbnailMethod
+	(ImageFormat)(0),                                // 1: files.v1.ImageFormat
+	(MediaState)(0),                                 // 2: files.v1.MediaState
+	(ScanStatus)(0),                                 // 3: files.v1.ScanStatus
+	(AccessRole)(0),                                 // 4: files.v1.AccessRole
+	(DeleteOutcome)(0),                              // 5: files.v1.DeleteOutcome
+	(MultipartUploadState)(0),                       // 6: files.v1.MultipartUploadState
+	(PrincipalType)(0),                              // 7: files.v1.PrincipalType
+	(LifecycleEventType)(0),                         // 8: files.v1.LifecycleEventType
+	(WebhookDeliveryState)(0),                       // 9: files.v1.WebhookDeliveryState
+	(ThumbnailRegenerationState)(0),                 // 10: files.v1.ThumbnailRegenerationState
+	(OwnershipIssueKind)(0),                         // 11: files.v1.OwnershipIssueKind
+	(ShareLinkPermission)(0),                        // 12: files.v1.ShareLinkPermission
+	(ShareLinkState)(0),                             // 13: files.v1.ShareLinkState
+	(ArchiveExtractionState)(0),                     // 14: files.v1.ArchiveExtractionState
+	(UserDataExportState)(0),                        // 15: files.v1.UserDataExportState
+	(UserDataErasureState)(0),                       // 16: files.v1.UserDataErasureState
+	(ThumbnailJobState)(0),                          // 17: files.v1.ThumbnailJobState
+	(MediaMetadata_Visibility)(0),                   // 18: files.v1.MediaMetadata.Visibility
+	(SearchMediaRequest_SortBy)(0),                  // 19: files.v1.SearchMediaRequest.SortBy
+	(RetentionPolicy_Mode)(0),                       // 20: files.v1.RetentionPolicy.Mode
+	(*MediaMetadata)(nil),                           // 21: files.v1.MediaMetadata
+	(*MediaProperties)(nil),                         // 22: files.v1.MediaProperties
+	(*AccessGrant)(nil),                             // 23: files.v1.AccessGrant
+	(*UploadMetadata)(nil),                          // 24: files.v1.UploadMetadata
+	(*UploadContentRequest)(nil),                    // 25: files.v1.UploadContentRequest
+	(*UploadContentResponse)(nil),                   // 26: files.v1.UploadContentResponse
+	(*CreateContentRequest)(nil),                    // 27: files.v1.CreateContentRequest
+	(*CreateContentResponse)(nil),                   // 28: files.v1.CreateContentResponse
+	(*CreateMultipartUploadRequest)(nil),            // 29: files.v1.CreateMultipartUploadRequest
+	(*CreateMultipartUploadResponse)(nil),           // 30: files.v1.CreateMultipartUploadResponse
+	(*UploadMultipartPartRequest)(nil),              // 31: files.v1.UploadMultipartPartRequest
+	(*UploadMultipartPartResponse)(nil),             // 32: files.v1.UploadMultipartPartResponse
+	(*CompleteMultipartUploadRequest)(nil),          // 33: files.v1.CompleteMultipartUploadRequest
+	(*CompleteMultipartUploadResponse)(nil),         // 34: files.v1.CompleteMultipartUploadResponse
+	(*AbortMultipartUploadRequest)(nil),             // 35: files.v1.AbortMultipartUploadRequest
+	(*AbortMultipartUploadResponse)(nil),            // 36: files.v1.AbortMultipartUploadResponse
+	(*ListMultipartPartsRequest)(nil),               // 37: files.v1.ListMultipartPartsRequest
+	(*ListMultipartPartsResponse)(nil),              // 38: files.v1.ListMultipartPartsResponse
+	(*GetMultipartUploadRequest)(nil),               // 39: files.v1.GetMultipartUploadRequest
+	(*GetMultipartUploadResponse)(nil),              // 40: files.v1.GetMultipartUploadResponse
+	(*ArchiveExtractionFailure)(nil),                // 41: files.v1.ArchiveExtractionFailure
+	(*ArchiveExtraction)(nil),                       // 42: files.v1.ArchiveExtraction
+	(*ExtractArchiveRequest)(nil),                   // 43: files.v1.ExtractArchiveRequest
+	(*ExtractArchiveResponse)(nil),                  // 44: files.v1.ExtractArchiveResponse
+	(*GetArchiveExtractionRequest)(nil),             // 45: files.v1.GetArchiveExtractionRequest
+	(*GetArchiveExtractionResponse)(nil),            // 46: files.v1.GetArchiveExtractionResponse
+	(*GetSignedUploadUrlRequest)(nil),               // 47: files.v1.GetSignedUploadUrlRequest
+	(*GetSignedUploadUrlResponse)(nil),              // 48: files.v1.GetSignedUploadUrlResponse
+	(*FinalizeSignedUploadRequest)(nil),             // 49: files.v1.FinalizeSignedUploadRequest
+	(*FinalizeSignedUploadResponse)(nil),            // 50: files.v1.FinalizeSignedUploadResponse
+	(*GetSignedDownloadUrlRequest)(nil),             // 51: files.v1.GetSignedDownloadUrlRequest
+	(*GetSignedDownloadUrlResponse)(nil),            // 52: files.v1.GetSignedDownloadUrlResponse
+	(*GetContentRequest)(nil),                       // 53: files.v1.GetContentRequest
+	(*GetContentResponse)(nil),                      // 54: files.v1.GetContentResponse
+	(*GetContentOverrideNameRequest)(nil),           // 55: files.v1.GetContentOverrideNameRequest
+	(*GetContentOverrideNameResponse)(nil),          // 56: files.v1.GetContentOverrideNameResponse
+	(*DownloadContentResponse)(nil),                 // 57: files.v1.DownloadContentResponse
+	(*DownloadContentRequest)(nil),                  // 58: files.v1.DownloadContentRequest
+	(*DownloadArchiveRequest)(nil),                  // 59: files.v1.DownloadArchiveRequest
+	(*DownloadArchiveResponse)(nil),                 // 60: files.v1.DownloadArchiveResponse
+	(*DownloadContentRangeResponse)(nil),            // 61: files.v1.DownloadContentRangeResponse
+	(*DownloadContentRangeRequest)(nil),             // 62: files.v1.DownloadContentRangeRequest
+	(*HeadContentRequest)(nil),                      // 63: files.v1.HeadContentRequest
+	(*HeadContentResponse)(nil),                     // 64: files.v1.HeadContentResponse
+	(*DeleteContentRequest)(nil),                    // 65: files.v1.DeleteContentRequest
+	(*DeleteContentResponse)(nil),                   // 66: files.v1.DeleteContentResponse
+	(*PatchContentRequest)(nil),                     // 67: files.v1.PatchContentRequest
+	(*PatchContentResponse)(nil),                    // 68: files.v1.PatchContentResponse
+	(*GrantAccessRequest)(nil),                      // 69: files.v1.GrantAccessRequest
+	(*GrantAccessResponse)(nil),                     // 70: files.v1.GrantAccessResponse
+	(*RevokeAccessRequest)(nil),                     // 71: files.v1.RevokeAccessRequest
+	(*RevokeAccessResponse)(nil),                    // 72: files.v1.RevokeAccessResponse
+	(*ListAccessRequest)(nil),                       // 73: files.v1.ListAccessRequest
+	(*ListAccessResponse)(nil),                      // 74: files.v1.ListAccessResponse
+	(*ListSharedWithMeRequest)(nil),                 // 75: files.v1.ListSharedWithMeRequest
+	(*SharedItem)(nil),                              // 76: files.v1.SharedItem
+	(*ListSharedWithMeResponse)(nil),                // 77: files.v1.ListSharedWithMeResponse
+	(*TransferOwnershipRequest)(nil),                // 78: files.v1.TransferOwnershipRequest
+	(*TransferOwnershipResponse)(nil),               // 79: files.v1.TransferOwnershipResponse
+	(*ShareLink)(nil),                               // 80: files.v1.ShareLink
+	(*CreateShareLinkRequest)(nil),                  // 81: files.v1.CreateShareLinkRequest
+	(*CreateShareLinkResponse)(nil),                 // 82: files.v1.CreateShareLinkResponse
+	(*ListShareLinksRequest)(nil),                   // 83: files.v1.ListShareLinksRequest
+	(*ListShareLinksResponse)(nil),                  // 84: files.v1.ListShareLinksResponse
+	(*RevokeShareLinkRequest)(nil),                  // 85: files.v1.RevokeShareLinkRequest
+	(*RevokeShareLinkResponse)(nil),                 // 86: files.v1.RevokeShareLinkResponse
+	(*GetContentThumbnailRequest)(nil),              // 87: files.v1.GetContentThumbnailRequest
+	(*GetContentThumbnailResponse)(nil),             // 88: files.v1.GetContentThumbnailResponse
+	(*ThumbnailStatus)(nil),                         // 89: files.v1.ThumbnailStatus
+	(*GetThumbnailStatusRequest)(nil),               // 90: files.v1.GetThumbnailStatusRequest
+	(*GetThumbnailStatusResponse)(nil),              // 91: files.v1.GetThumbnailStatusResponse
+	(*RequeueThumbnailRequest)(nil),                 // 92: files.v1.RequeueThumbnailRequest
+	(*RequeueThumbnailResponse)(nil),                // 93: files.v1.RequeueThumbnailResponse
+	(*ThumbnailRegeneration)(nil),                   // 94: files.v1.ThumbnailRegeneration
+	(*RegenerateThumbnailsRequest)(nil),             // 95: files.v1.RegenerateThumbnailsRequest
+	(*RegenerateThumbnailsResponse)(nil),            // 96: files.v1.RegenerateThumbnailsResponse
+	(*ReconcileOwnershipRequest)(nil),               // 97: files.v1.ReconcileOwnershipRequest
+	(*OwnershipIssue)(nil),                          // 98: files.v1.OwnershipIssue
+	(*ReconcileOwnershipResponse)(nil),              // 99: files.v1.ReconcileOwnershipResponse
+	(*GetThumbnailRegenerationRequest)(nil),         // 100: files.v1.GetThumbnailRegenerationRequest
+	(*GetThumbnailRegenerationResponse)(nil),        // 101: files.v1.GetThumbnailRegenerationResponse
+	(*GetUrlPreviewRequest)(nil),                    // 102: files.v1.GetUrlPreviewRequest
+	(*GetUrlPreviewResponse)(nil),                   // 103: files.v1.GetUrlPreviewResponse
+	(*GetConfigRequest)(nil),                        // 104: files.v1.GetConfigRequest
+	(*GetConfigResponse)(nil),                       // 105: files.v1.GetConfigResponse
+	(*SearchMediaRequest)(nil),                      // 106: files.v1.SearchMediaRequest
+	(*SearchMediaResponse)(nil),                     // 107: files.v1.SearchMediaResponse
+	(*BatchGetContentRequest)(nil),                  // 108: files.v1.BatchGetContentRequest
+	(*BatchGetContentResponse)(nil),                 // 109: files.v1.BatchGetContentResponse
+	(*BatchDeleteContentRequest)(nil),               // 110: files.v1.BatchDeleteContentRequest
+	(*BatchDeleteContentResponse)(nil),              // 111: files.v1.BatchDeleteContentResponse
+	(*FileVersion)(nil),                             // 112: files.v1.FileVersion
+	(*GetVersionsRequest)(nil),                      // 113: files.v1.GetVersionsRequest
+	(*GetVersionsResponse)(nil),                     // 114: files.v1.GetVersionsResponse
+	(*RestoreVersionRequest)(nil),                   // 115: files.v1.RestoreVersionRequest
+	(*RestoreVersionResponse)(nil),                  // 116: files.v1.RestoreVersionResponse
+	(*RetentionPolicy)(nil),                         // 117: files.v1.RetentionPolicy
+	(*SetRetentionPolicyRequest)(nil),               // 118: files.v1.SetRetentionPolicyRequest
+	(*SetRetentionPolicyResponse)(nil),              // 119: files.v1.SetRetentionPolicyResponse
+	(*GetRetentionPolicyRequest)(nil),               // 120: files.v1.GetRetentionPolicyRequest
+	(*GetRetentionPolicyResponse)(nil),              // 121: files.v1.GetRetentionPolicyResponse
+	(*ListRetentionPoliciesRequest)(nil),            // 122: files.v1.ListRetentionPoliciesRequest
+	(*ListRetentionPoliciesResponse)(nil),           // 123: files.v1.ListRetentionPoliciesResponse
+	(*UsageStats)(nil),                              // 124: files.v1.UsageStats
+	(*GetUserUsageRequest)(nil),                     // 125: files.v1.GetUserUsageRequest
+	(*GetUserUsageResponse)(nil),                    // 126: files.v1.GetUserUsageResponse
+	(*GetStorageStatsRequest)(nil),                  // 127: files.v1.GetStorageStatsRequest
+	(*GetStorageStatsResponse)(nil),                 // 128: files.v1.GetStorageStatsResponse
+	(*LifecycleEvent)(nil),                          // 129: files.v1.LifecycleEvent
+	(*WebhookSubscription)(nil),                     // 130: files.v1.WebhookSubscription
+	(*WebhookDelivery)(nil),                         // 131: files.v1.WebhookDelivery
+	(*CreateWebhookSubscriptionRequest)(nil),        // 132: files.v1.CreateWebhookSubscriptionRequest
+	(*CreateWebhookSubscriptionResponse)(nil),       // 133: files.v1.CreateWebhookSubscriptionResponse
+	(*ListWebhookSubscriptionsRequest)(nil),         // 134: files.v1.ListWebhookSubscriptionsRequest
+	(*ListWebhookSubscriptionsResponse)(nil),        // 135: files.v1.ListWebhookSubscriptionsResponse
+	(*DeleteWebhookSubscriptionRequest)(nil),        // 136: files.v1.DeleteWebhookSubscriptionRequest
+	(*DeleteWebhookSubscriptionResponse)(nil),       // 137: files.v1.DeleteWebhookSubscriptionResponse
+	(*ListWebhookDeliveriesRequest)(nil),            // 138: files.v1.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil),           // 139: files.v1.ListWebhookDeliveriesResponse
+	(*AdminListMediaRequest)(nil),                   // 140: files.v1.AdminListMediaRequest
+	(*AdminListMediaResponse)(nil),                  // 141: files.v1.AdminListMediaResponse
+	(*AdminGetMediaRequest)(nil),                    // 142: files.v1.AdminGetMediaRequest
+	(*AdminGetMediaResponse)(nil),                   // 143: files.v1.AdminGetMediaResponse
+	(*AdminGrantAccessRequest)(nil),                 // 144: files.v1.AdminGrantAccessRequest
+	(*AdminGrantAccessResponse)(nil),                // 145: files.v1.AdminGrantAccessResponse
+	(*AdminTransferOwnershipRequest)(nil),           // 146: files.v1.AdminTransferOwnershipRequest
+	(*AdminTransferOwnershipResponse)(nil),          // 147: files.v1.AdminTransferOwnershipResponse
+	(*AdminDeleteContentRequest)(nil),               // 148: files.v1.AdminDeleteContentRequest
+	(*AdminDeleteContentResponse)(nil),              // 149: files.v1.AdminDeleteContentResponse
+	(*UserDataExport)(nil),                          // 150: files.v1.UserDataExport
+	(*ExportUserDataRequest)(nil),                   // 151: files.v1.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil),                  // 152: files.v1.ExportUserDataResponse
+	(*GetUserDataExportRequest)(nil),                // 153: files.v1.GetUserDataExportRequest
+	(*GetUserDataExportResponse)(nil),               // 154: files.v1.GetUserDataExportResponse
+	(*UserDataErasureRetainedFile)(nil),             // 155: files.v1.UserDataErasureRetainedFile
+	(*UserDataErasure)(nil),                         // 156: files.v1.UserDataErasure
+	(*EraseUserDataRequest)(nil),                    // 157: files.v1.EraseUserDataRequest
+	(*EraseUserDataResponse)(nil),                   // 158: files.v1.EraseUserDataResponse
+	(*GetUserDataErasureRequest)(nil),               // 159: files.v1.GetUserDataErasureRequest
+	(*GetUserDataErasureResponse)(nil),              // 160: files.v1.GetUserDataErasureResponse
+	nil,                                             // 161: files.v1.MediaMetadata.LabelsEntry
+	nil,                                             // 162: files.v1.MediaProperties.ExifEntry
+	nil,                                             // 163: files.v1.UploadMetadata.LabelsEntry
+	nil,                                             // 164: files.v1.CreateContentRequest.LabelsEntry
+	nil,                                             // 165: files.v1.CreateMultipartUploadRequest.LabelsEntry
+	(*CompleteMultipartUploadRequest_Part)(nil),     // 166: files.v1.CompleteMultipartUploadRequest.Part
+	(*ListMultipartPartsResponse_Part)(nil),         // 167: files.v1.ListMultipartPartsResponse.Part
+	nil,                                             // 168: files.v1.GetMultipartUploadResponse.LabelsEntry
+	nil,                                             // 169: files.v1.PatchContentRequest.SetLabelsEntry
+	nil,                                             // 170: files.v1.SearchMediaRequest.LabelsEntry
+	(*BatchGetContentResponse_ContentResult)(nil),   // 171: files.v1.BatchGetContentResponse.ContentResult
+	(*BatchDeleteContentResponse_DeleteResult)(nil), // 172: files.v1.BatchDeleteContentResponse.DeleteResult
+	nil,                           // 173: files.v1.LifecycleEvent.AttributesEntry
+	(*timestamppb.Timestamp)(nil), // 174: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 175: google.protobuf.Struct
+	(*v1.PageCursor)(nil),         // 176: common.v1.PageCursor
+}
+var file_files_v1_files_proto_depIdxs = []int32{
+	174, // 0: files.v1.MediaMetadata.created_at:type_name -> google.protobuf.Timestamp
+	174, // 1: files.v1.MediaMetadata.updated_at:type_name -> google.protobuf.Timestamp
+	18,  // 2: files.v1.MediaMetadata.visibility:type_name -> files.v1.MediaMetadata.Visibility
+	175, // 3: files.v1.MediaMetadata.extra:type_name -> google.protobuf.Struct
+	174, // 4: files.v1.MediaMetadata.expires_at:type_name -> google.protobuf.Timestamp
+	2,   // 5: files.v1.MediaMetadata.state:type_name -> files.v1.MediaState
+	3,   // 6: files.v1.MediaMetadata.scan_status:type_name -> files.v1.ScanStatus
+	174, // 7: files.v1.MediaMetadata.archived_at:type_name -> google.protobuf.Timestamp
+	174, // 8: files.v1.MediaMetadata.deleted_at:type_name -> google.protobuf.Timestamp
+	161, // 9: files.v1.MediaMetadata.labels:type_name -> files.v1.MediaMetadata.LabelsEntry
+	22,  // 10: files.v1.MediaMetadata.properties:type_name -> files.v1.MediaProperties
+	162, // 11: files.v1.MediaProperties.exif:type_name -> files.v1.MediaProperties.ExifEntry
+	7,   // 12: files.v1.AccessGrant.principal_type:type_name -> files.v1.PrincipalType
+	4,   // 13: files.v1.AccessGrant.role:type_name -> files.v1.AccessRole
+	174, // 14: files.v1.AccessGrant.granted_at:type_name -> google.protobuf.Timestamp
+	174, // 15: files.v1.AccessGrant.expires_at:type_name -> google.protobuf.Timestamp
+	175, // 16: files.v1.UploadMetadata.properties:type_name -> google.protobuf.Struct
+	18,  // 17: files.v1.UploadMetadata.visibility:type_name -> files.v1.MediaMetadata.Visibility
+	174, // 18: files.v1.UploadMetadata.expires_at:type_name -> google.protobuf.Timestamp
+	163, // 19: files.v1.UploadMetadata.labels:type_name -> files.v1.UploadMetadata.LabelsEntry
+	24,  // 20: files.v1.UploadContentRequest.metadata:type_name -> files.v1.UploadMetadata
+	21,  // 21: files.v1.UploadContentResponse.metadata:type_name -> files.v1.MediaMetadata
+	18,  // 22: files.v1.CreateContentRequest.visibility:type_name -> files.v1.MediaMetadata.Visibility
+	174, // 23: files.v1.CreateContentRequest.expires_at:type_name -> google.protobuf.Timestamp
+	164, // 24: files.v1.CreateContentRequest.labels:type_name -> files.v1.CreateContentRequest.LabelsEntry
+	174, // 25: files.v1.CreateContentResponse.expires_at:type_name -> google.protobuf.Timestamp
+	18,  // 26: files.v1.CreateMultipartUploadRequest.visibility:type_name -> files.v1.MediaMetadata.Visibility
+	174, // 27: files.v1.CreateMultipartUploadRequest.expires_at:type_name -> google.protobuf.Timestamp
+	165, // 28: files.v1.CreateMultipartUploadRequest.labels:type_name -> files.v1.CreateMultipartUploadRequest.LabelsEntry
+	166, // 29: files.v1.CompleteMultipartUploadRequest.parts:type_name -> files.v1.CompleteMultipartUploadRequest.Part
+	21,  // 30: files.v1.CompleteMultipartUploadResponse.metadata:type_name -> files.v1.MediaMetadata
+	176, // 31: files.v1.ListMultipartPartsRequest.cursor:type_name -> common.v1.PageCursor
+	167, // 32: files.v1.ListMultipartPartsResponse.parts:type_name -> files.v1.ListMultipartPartsResponse.Part
+	176, // 33: files.v1.ListMultipartPartsResponse.next_cursor:type_name -> common.v1.PageCursor
+	18,  // 34: files.v1.GetMultipartUploadResponse.visibility:type_name -> files.v1.MediaMetadata.Visibility
+	174, // 35: files.v1.GetMultipartUploadResponse.created_at:type_name -> google.protobuf.Timestamp
+	168, // 36: files.v1.GetMultipartUploadResponse.labels:type_name -> files.v1.GetMultipartUploadResponse.LabelsEntry
+	6,   // 37: files.v1.GetMultipartUploadResponse.upload_state:type_name -> files.v1.MultipartUploadState
+	14,  // 38: files.v1.ArchiveExtraction.state:type_name -> files.v1.ArchiveExtractionState
+	41,  // 39: files.v1.ArchiveExtraction.failures:type_name -> files.v1.ArchiveExtractionFailure
+	174, // 40: files.v1.ArchiveExtraction.created_at:type_name -> google.protobuf.Timestamp
+	174, // 41: files.v1.ArchiveExtraction.updated_at:type_name -> google.protobuf.Timestamp
+	174, // 42: files.v1.ArchiveExtraction.completed_at:type_name -> google.protobuf.Timestamp
+	42,  // 43: files.v1.ExtractArchiveResponse.job:type_name -> files.v1.ArchiveExtraction
+	42,  // 44: files.v1.GetArchiveExtractionResponse.job:type_name -> files.v1.ArchiveExtraction
+	21,  // 45: files.v1.FinalizeSignedUploadResponse.metadata:type_name -> files.v1.MediaMetadata
+	21,  // 46: files.v1.GetContentResponse.metadata:type_name -> files.v1.MediaMetadata
+	21,  // 47: files.v1.GetContentOverrideNameResponse.metadata:type_name -> files.v1.MediaMetadata
+	21,  // 48: files.v1.HeadContentResponse.metadata:type_name -> files.v1.MediaMetadata
+	5,   // 49: files.v1.DeleteContentResponse.outcome:type_name -> files.v1.DeleteOutcome
+	175, // 50: files.v1.PatchContentRequest.set_extra:type_name -> google.protobuf.Struct
+	169, // 51: files.v1.PatchContentRequest.set_labels:type_name -> files.v1.PatchContentRequest.SetLabelsEntry
+	18,  // 52: files.v1.PatchContentRequest.visibility:type_name -> files.v1.MediaMetadata.Visibility
+	174, // 53: files.v1.PatchContentRequest.expires_at:type_name -> google.protobuf.Timestamp
+	21,  // 54: files.v1.PatchContentResponse.metadata:type_name -> files.v1.MediaMetadata
+	23,  // 55: files.v1.GrantAccessRequest.grant:type_name -> files.v1.AccessGrant
+	7,   // 56: files.v1.RevokeAccessRequest.principal_type:type_name -> files.v1.PrincipalType
+	4,   // 57: files.v1.ListAccessRequest.filter_role:type_name -> files.v1.AccessRole
+	176, // 58: files.v1.ListAccessRequest.cursor:type_name -> common.v1.PageCursor
+	23,  // 59: files.v1.ListAccessResponse.grants:type_name -> files.v1.AccessGrant
+	176, // 60: files.v1.ListAccessResponse.next_cursor:type_name -> common.v1.PageCursor
+	176, // 61: files.v1.ListSharedWithMeRequest.cursor:type_name -> common.v1.PageCursor
+	21,  // 62: files.v1.SharedItem.media:type_name -> files.v1.MediaMetadata
+	23,  // 63: files.v1.SharedItem.grant:type_name -> files.v1.AccessGrant
+	76,  // 64: files.v1.ListSharedWithMeResponse.items:type_name -> files.v1.SharedItem
+	176, // 65: files.v1.ListSharedWithMeResponse.next_cursor:type_name -> common.v1.PageCursor
+	4,   // 66: files.v1.TransferOwnershipRequest.previous_owner_role:type_name -> files.v1.AccessRole
+	124, // 67: files.v1.TransferOwnershipResponse.previous_owner_usage:type_name -> files.v1.UsageStats
+	124, // 68: files.v1.TransferOwnershipResponse.new_owner_usage:type_name -> files.v1.UsageStats
+	12,  // 69: files.v1.ShareLink.permission:type_name -> files.v1.ShareLinkPermission
+	13,  // 70: files.v1.ShareLink.state:type_name -> files.v1.ShareLinkState
+	174, // 71: files.v1.ShareLink.expires_at:type_name -> google.protobuf.Timestamp
+	174, // 72: files.v1.ShareLink.created_at:type_name -> google.protobuf.Timestamp
+	174, // 73: files.v1.ShareLink.revoked_at:type_name -> google.protobuf.Timestamp
+	174, // 74: files.v1.ShareLink.last_accessed_at:type_name -> google.protobuf.Timestamp
+	12,  // 75: files.v1.CreateShareLinkRequest.permission:type_name -> files.v1.ShareLinkPermission
+	174, // 76: files.v1.CreateShareLinkRequest.expires_at:type_name -> google.protobuf.Timestamp
+	80,  // 77: files.v1.CreateShareLinkResponse.link:type_name -> files.v1.ShareLink
+	176, // 78: files.v1.ListShareLinksRequest.cursor:type_name -> common.v1.PageCursor
+	80,  // 79: files.v1.ListShareLinksResponse.links:type_name -> files.v1.ShareLink
+	176, // 80: files.v1.ListShareLinksResponse.next_cursor:type_name -> common.v1.PageCursor
+	80,  // 81: files.v1.RevokeShareLinkResponse.link:type_name -> files.v1.ShareLink
+	0,   // 82: files.v1.GetContentThumbnailRequest.method:type_name -> files.v1.ThumbnailMethod
+	1,   // 83: files.v1.GetContentThumbnailRequest.format:type_name -> files.v1.ImageFormat
+	21,  // 84: files.v1.GetContentThumbnailResponse.metadata:type_name -> files.v1.MediaMetadata
+	17,  // 85: files.v1.ThumbnailStatus.state:type_name -> files.v1.ThumbnailJobState
+	174, // 86: files.v1.ThumbnailStatus.next_attempt_at:type_name -> google.protobuf.Timestamp
+	174, // 87: files.v1.ThumbnailStatus.completed_at:type_name -> google.protobuf.Timestamp
+	174, // 88: files.v1.ThumbnailStatus.created_at:type_name -> google.protobuf.Timestamp
+	174, // 89: files.v1.ThumbnailStatus.updated_at:type_name -> google.protobuf.Timestamp
+	89,  // 90: files.v1.GetThumbnailStatusResponse.status:type_name -> files.v1.ThumbnailStatus
+	89,  // 91: files.v1.RequeueThumbnailResponse.status:type_name -> files.v1.ThumbnailStatus
+	10,  // 92: files.v1.ThumbnailRegeneration.state:type_name -> files.v1.ThumbnailRegenerationState
+	174, // 93: files.v1.ThumbnailRegeneration.created_after:type_name -> google.protobuf.Timestamp
+	174, // 94: files.v1.ThumbnailRegeneration.created_before:type_name -> google.protobuf.Timestamp
+	174, // 95: files.v1.ThumbnailRegeneration.created_at:type_name -> google.protobuf.Timestamp
+	174, // 96: files.v1.ThumbnailRegeneration.updated_at:type_name -> google.protobuf.Timestamp
+	174, // 97: files.v1.ThumbnailRegeneration.completed_at:type_name -> google.protobuf.Timestamp
+	174, // 98: files.v1.RegenerateThumbnailsRequest.created_after:type_name -> google.protobuf.Timestamp
+	174, // 99: files.v1.RegenerateThumbnailsRequest.created_before:type_name -> google.protobuf.Timestamp
+	94,  // 100: files.v1.RegenerateThumbnailsResponse.run:type_name -> files.v1.ThumbnailRegeneration
+	176, // 101: files.v1.ReconcileOwnershipRequest.cursor:type_name -> common.v1.PageCursor
+	11,  // 102: files.v1.OwnershipIssue.kind:type_name -> files.v1.OwnershipIssueKind
+	98,  // 103: files.v1.ReconcileOwnershipResponse.issues:type_name -> files.v1.OwnershipIssue
+	176, // 104: files.v1.ReconcileOwnershipResponse.next_cursor:type_name -> common.v1.PageCursor
+	94,  // 105: files.v1.GetThumbnailRegenerationResponse.run:type_name -> files.v1.ThumbnailRegeneration
+	175, // 106: files.v1.GetUrlPreviewResponse.og_data:type_name -> google.protobuf.Struct
+	0,   // 107: files.v1.GetConfigResponse.supported_thumbnail_methods:type_name -> files.v1.ThumbnailMethod
+	175, // 108: files.v1.GetConfigResponse.extra:type_name -> google.protobuf.Struct
+	176, // 109: files.v1.SearchMediaRequest.cursor:type_name -> common.v1.PageCursor
+	174, // 110: files.v1.SearchMediaRequest.created_after:type_name -> google.protobuf.Timestamp
+	174, // 111: files.v1.SearchMediaRequest.created_before:type_name -> google.protobuf.Timestamp
+	18,  // 112: files.v1.SearchMediaRequest.visibility:type_name -> files.v1.MediaMetadata.Visibility
+	170, // 113: files.v1.SearchMediaRequest.labels:type_name -> files.v1.SearchMediaRequest.LabelsEntry
+	2,   // 114: files.v1.SearchMediaRequest.state:type_name -> files.v1.MediaState
+	3,   // 115: files.v1.SearchMediaRequest.scan_status:type_name -> files.v1.ScanStatus
+	18,  // 116: files.v1.SearchMediaRequest.visibilities:type_name -> files.v1.MediaMetadata.Visibility
+	4,   // 117: files.v1.SearchMediaRequest.accessible_via_role:type_name -> files.v1.AccessRole
+	19,  // 118: files.v1.SearchMediaRequest.sort_by:type_name -> files.v1.SearchMediaRequest.SortBy
+	21,  // 119: files.v1.SearchMediaResponse.results:type_name -> files.v1.MediaMetadata
+	176, // 120: files.v1.SearchMediaResponse.next_cursor:type_name -> common.v1.PageCursor
+	171, // 121: files.v1.BatchGetContentResponse.results:type_name -> files.v1.BatchGetContentResponse.ContentResult
+	172, // 122: files.v1.BatchDeleteContentResponse.results:type_name -> files.v1.BatchDeleteContentResponse.DeleteResult
+	174, // 123: files.v1.FileVersion.created_at:type_name -> google.protobuf.Timestamp
+	176, // 124: files.v1.GetVersionsRequest.cursor:type_name -> common.v1.PageCursor
+	112, // 125: files.v1.GetVersionsResponse.versions:type_name -> files.v1.FileVersion
+	176, // 126: files.v1.GetVersionsResponse.next_cursor:type_name -> common.v1.PageCursor
+	21,  // 127: files.v1.RestoreVersionResponse.metadata:type_name -> files.v1.MediaMetadata
+	20,  // 128: files.v1.RetentionPolicy.mode:type_name -> files.v1.RetentionPolicy.Mode
+	117, // 129: files.v1.GetRetentionPolicyResponse.policy:type_name -> files.v1.RetentionPolicy
+	174, // 130: files.v1.GetRetentionPolicyResponse.expires_at:type_name -> google.protobuf.Timestamp
+	176, // 131: files.v1.ListRetentionPoliciesRequest.cursor:type_name -> common.v1.PageCursor
+	117, // 132: files.v1.ListRetentionPoliciesResponse.policies:type_name -> files.v1.RetentionPolicy
+	176, // 133: files.v1.ListRetentionPoliciesResponse.next_cursor:type_name -> common.v1.PageCursor
+	124, // 134: files.v1.GetUserUsageResponse.usage:type_name -> files.v1.UsageStats
+	174, // 135: files.v1.GetUserUsageResponse.period_start:type_name -> google.protobuf.Timestamp
+	174, // 136: files.v1.GetUserUsageResponse.period_end:type_name -> google.protobuf.Timestamp
+	8,   // 137: files.v1.LifecycleEvent.event_type:type_name -> files.v1.LifecycleEventType
+	174, // 138: files.v1.LifecycleEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	173, // 139: files.v1.LifecycleEvent.attributes:type_name -> files.v1.LifecycleEvent.AttributesEntry
+	8,   // 140: files.v1.WebhookSubscription.event_types:type_name -> files.v1.LifecycleEventType
+	174, // 141: files.v1.WebhookSubscription.created_at:type_name -> google.protobuf.Timestamp
+	8,   // 142: files.v1.WebhookDelivery.event_type:type_name -> files.v1.LifecycleEventType
+	9,   // 143: files.v1.WebhookDelivery.state:type_name -> files.v1.WebhookDeliveryState
+	174, // 144: files.v1.WebhookDelivery.next_attempt_at:type_name -> google.protobuf.Timestamp
+	174, // 145: files.v1.WebhookDelivery.delivered_at:type_name -> google.protobuf.Timestamp
+	174, // 146: files.v1.WebhookDelivery.created_at:type_name -> google.protobuf.Timestamp
+	8,   // 147: files.v1.CreateWebhookSubscriptionRequest.event_types:type_name -> files.v1.LifecycleEventType
+	130, // 148: files.v1.CreateWebhookSubscriptionResponse.subscription:type_name -> files.v1.WebhookSubscription
+	176, // 149: files.v1.ListWebhookSubscriptionsRequest.cursor:type_name -> common.v1.PageCursor
+	130, // 150: files.v1.ListWebhookSubscriptionsResponse.subscriptions:type_name -> files.v1.WebhookSubscription
+	176, // 151: files.v1.ListWebhookSubscriptionsResponse.next_cursor:type_name -> common.v1.PageCursor
+	9,   // 152: files.v1.ListWebhookDeliveriesRequest.filter_state:type_name -> files.v1.WebhookDeliveryState
+	176, // 153: files.v1.ListWebhookDeliveriesRequest.cursor:type_name -> common.v1.PageCursor
+	131, // 154: files.v1.ListWebhookDeliveriesResponse.deliveries:type_name -> files.v1.WebhookDelivery
+	176, // 155: files.v1.ListWebhookDeliveriesResponse.next_cursor:type_name -> common.v1.PageCursor
+	176, // 156: files.v1.AdminListMediaRequest.cursor:type_name -> common.v1.PageCursor
+	21,  // 157: files.v1.AdminListMediaResponse.media:type_name -> files.v1.MediaMetadata
+	176, // 158: files.v1.AdminListMediaResponse.next_cursor:type_name -> common.v1.PageCursor
+	21,  // 159: files.v1.AdminGetMediaResponse.metadata:type_name -> files.v1.MediaMetadata
+	23,  // 160: files.v1.AdminGetMediaResponse.grants:type_name -> files.v1.AccessGrant
+	23,  // 161: files.v1.AdminGrantAccessRequest.grant:type_name -> files.v1.AccessGrant
+	4,   // 162: files.v1.AdminTransferOwnershipRequest.previous_owner_role:type_name -> files.v1.AccessRole
+	124, // 163: files.v1.AdminTransferOwnershipResponse.previous_owner_usage:type_name -> files.v1.UsageStats
+	124, // 164: files.v1.AdminTransferOwnershipResponse.new_owner_usage:type_name -> files.v1.UsageStats
+	15,  // 165: files.v1.UserDataExport.state:type_name -> files.v1.UserDataExportState
+	174, // 166: files.v1.UserDataExport.created_at:type_name -> google.protobuf.Timestamp
+	174, // 167: files.v1.UserDataExport.updated_at:type_name -> google.protobuf.Timestamp
+	174, // 168: files.v1.UserDataExport.completed_at:type_name -> google.protobuf.Timestamp
+	150, // 169: files.v1.ExportUserDataResponse.export:type_name -> files.v1.UserDataExport
+	150, // 170: files.v1.GetUserDataExportResponse.export:type_name -> files.v1.UserDataExport
+	174, // 171: files.v1.UserDataErasureRetainedFile.retained_until:type_name -> google.protobuf.Timestamp
+	16,  // 172: files.v1.UserDataErasure.state:type_name -> files.v1.UserDataErasureState
+	155, // 173: files.v1.UserDataErasure.retained_files:type_name -> files.v1.UserDataErasureRetainedFile
+	174, // 174: files.v1.UserDataErasure.created_at:type_name -> google.protobuf.Timestamp
+	174, // 175: files.v1.UserDataErasure.completed_at:type_name -> google.protobuf.Timestamp
+	156, // 176: files.v1.EraseUserDataResponse.erasure:type_name -> files.v1.UserDataErasure
+	156, // 177: files.v1.GetUserDataErasureResponse.erasure:type_name -> files.v1.UserDataErasure
+	174, // 178: files.v1.ListMultipartPartsResponse.Part.uploaded_at:type_name -> google.protobuf.Timestamp
+	54,  // 179: files.v1.BatchGetContentResponse.ContentResult.content:type_name -> files.v1.GetContentResponse
+	25,  // 180: files.v1.FilesService.UploadContent:input_type -> files.v1.UploadContentRequest
+	27,  // 181: files.v1.FilesService.CreateContent:input_type -> files.v1.CreateContentRequest
+	29,  // 182: files.v1.FilesService.CreateMultipartUpload:input_type -> files.v1.CreateMultipartUploadRequest
+	39,  // 183: files.v1.FilesService.GetMultipartUpload:input_type -> files.v1.GetMultipartUploadRequest
+	31,  // 184: files.v1.FilesService.UploadMultipartPart:input_type -> files.v1.UploadMultipartPartRequest
+	33,  // 185: files.v1.FilesService.CompleteMultipartUpload:input_type -> files.v1.CompleteMultipartUploadRequest
+	35,  // 186: files.v1.FilesService.AbortMultipartUpload:input_type -> files.v1.AbortMultipartUploadRequest
+	37,  // 187: files.v1.FilesService.ListMultipartParts:input_type -> files.v1.ListMultipartPartsRequest
+	43,  // 188: files.v1.FilesService.ExtractArchive:input_type -> files.v1.ExtractArchiveRequest
+	45,  // 189: files.v1.FilesService.GetArchiveExtraction:input_type -> files.v1.GetArchiveExtractionRequest
+	63,  // 190: files.v1.FilesService.HeadContent:input_type -> files.v1.HeadContentRequest
+	67,  // 191: files.v1.FilesService.PatchContent:input_type -> files.v1.PatchContentRequest
+	47,  // 192: files.v1.FilesService.GetSignedUploadUrl:input_type -> files.v1.GetSignedUploadUrlRequest
+	49,  // 193: files.v1.FilesService.FinalizeSignedUpload:input_type -> files.v1.FinalizeSignedUploadRequest
+	51,  // 194: files.v1.FilesService.GetSignedDownloadUrl:input_type -> files.v1.GetSignedDownloadUrlRequest
+	65,  // 195: files.v1.FilesService.DeleteContent:input_type -> files.v1.DeleteContentRequest
+	53,  // 196: files.v1.FilesService.GetContent:input_type -> files.v1.GetContentRequest
+	55,  // 197: files.v1.FilesService.GetContentOverrideName:input_type -> files.v1.GetContentOverrideNameRequest
+	58,  // 198: files.v1.FilesService.DownloadContent:input_type -> files.v1.DownloadContentRequest
+	62,  // 199: files.v1.FilesService.DownloadContentRange:input_type -> files.v1.DownloadContentRangeRequest
+	59,  // 200: files.v1.FilesService.DownloadArchive:input_type -> files.v1.DownloadArchiveRequest
+	87,  // 201: files.v1.FilesService.GetContentThumbnail:input_type -> files.v1.GetContentThumbnailRequest
+	90,  // 202: files.v1.FilesService.GetThumbnailStatus:input_type -> files.v1.GetThumbnailStatusRequest
+	92,  // 203: files.v1.FilesService.RequeueThumbnail:input_type -> files.v1.RequeueThumbnailRequest
+	95,  // 204: files.v1.FilesService.RegenerateThumbnails:input_type -> files.v1.RegenerateThumbnailsRequest
+	100, // 205: files.v1.FilesService.GetThumbnailRegeneration:input_type -> files.v1.GetThumbnailRegenerationRequest
+	97,  // 206: files.v1.FilesService.ReconcileOwnership:input_type -> files.v1.ReconcileOwnershipRequest
+	102, // 207: files.v1.FilesService.GetUrlPreview:input_type -> files.v1.GetUrlPreviewRequest
+	104, // 208: files.v1.FilesService.GetConfig:input_type -> files.v1.GetConfigRequest
+	106, // 209: files.v1.FilesService.SearchMedia:input_type -> files.v1.SearchMediaRequest
+	108, // 210: files.v1.FilesService.BatchGetContent:input_type -> files.v1.BatchGetContentRequest
+	110, // 211: files.v1.FilesService.BatchDeleteContent:input_type -> files.v1.BatchDeleteContentRequest
+	69,  // 212: files.v1.FilesService.GrantAccess:input_type -> files.v1.GrantAccessRequest
+	71,  // 213: files.v1.FilesService.RevokeAccess:input_type -> files.v1.RevokeAccessRequest
+	73,  // 214: files.v1.FilesService.ListAccess:input_type -> files.v1.ListAccessRequest
+	75,  // 215: files.v1.FilesService.ListSharedWithMe:input_type -> files.v1.ListSharedWithMeRequest
+	78,  // 216: files.v1.FilesService.TransferOwnership:input_type -> files.v1.TransferOwnershipRequest
+	81,  // 217: files.v1.FilesService.CreateShareLink:input_type -> files.v1.CreateShareLinkRequest
+	83,  // 218: files.v1.FilesService.ListShareLinks:input_type -> files.v1.ListShareLinksRequest
+	85,  // 219: files.v1.FilesService.RevokeShareLink:input_type -> files.v1.RevokeShareLinkRequest
+	113, // 220: files.v1.FilesService.GetVersions:input_type -> files.v1.GetVersionsRequest
+	115, // 221: files.v1.FilesService.RestoreVersion:input_type -> files.v1.RestoreVersionRequest
+	118, // 222: files.v1.FilesService.SetRetentionPolicy:input_type -> files.v1.SetRetentionPolicyRequest
+	120, // 223: files.v1.FilesService.GetRetentionPolicy:input_type -> files.v1.GetRetentionPolicyRequest
+	122, // 224: files.v1.FilesService.ListRetentionPolicies:input_type -> files.v1.ListRetentionPoliciesRequest
+	125, // 225: files.v1.FilesService.GetUserUsage:input_type -> files.v1.GetUserUsageRequest
+	127, // 226: files.v1.FilesService.GetStorageStats:input_type -> files.v1.GetStorageStatsRequest
+	132, // 227: files.v1.FilesService.CreateWebhookSubscription:input_type -> files.v1.CreateWebhookSubscriptionRequest
+	134, // 228: files.v1.FilesService.ListWebhookSubscriptions:input_type -> files.v1.ListWebhookSubscriptionsRequest
+	136, // 229: files.v1.FilesService.DeleteWebhookSubscription:input_type -> files.v1.DeleteWebhookSubscriptionRequest
+	138, // 230: files.v1.FilesService.ListWebhookDeliveries:input_type -> files.v1.ListWebhookDeliveriesRequest
+	140, // 231: files.v1.FilesService.AdminListMedia:input_type -> files.v1.AdminListMediaRequest
+	142, // 232: files.v1.FilesService.AdminGetMedia:input_type -> files.v1.AdminGetMediaRequest
+	144, // 233: files.v1.FilesService.AdminGrantAccess:input_type -> files.v1.AdminGrantAccessRequest
+	146, // 234: files.v1.FilesService.AdminTransferOwnership:input_type -> files.v1.AdminTransferOwnershipRequest
+	148, // 235: files.v1.FilesService.AdminDeleteContent:input_type -> files.v1.AdminDeleteContentRequest
+	151, // 236: files.v1.FilesService.ExportUserData:input_type -> files.v1.ExportUserDataRequest
+	153, // 237: files.v1.FilesService.GetUserDataExport:input_type -> files.v1.GetUserDataExportRequest
+	157, // 238: files.v1.FilesService.EraseUserData:input_type -> files.v1.EraseUserDataRequest
+	159, // 239: files.v1.FilesService.GetUserDataErasure:input_type -> files.v1.GetUserDataErasureRequest
+	26,  // 240: files.v1.FilesService.UploadContent:output_type -> files.v1.UploadContentResponse
+	28,  // 241: files.v1.FilesService.CreateContent:output_type -> files.v1.CreateContentResponse
+	30,  // 242: files.v1.FilesService.CreateMultipartUpload:output_type -> files.v1.CreateMultipartUploadResponse
+	40,  // 243: files.v1.FilesService.GetMultipartUpload:output_type -> files.v1.GetMultipartUploadResponse
+	32,  // 244: files.v1.FilesService.UploadMultipartPart:output_type -> files.v1.UploadMultipartPartResponse
+	34,  // 245: files.v1.FilesService.CompleteMultipartUpload:output_type -> files.v1.CompleteMultipartUploadResponse
+	36,  // 246: files.v1.FilesService.AbortMultipartUpload:output_type -> files.v1.AbortMultipartUploadResponse
+	38,  // 247: files.v1.FilesService.ListMultipartParts:output_type -> files.v1.ListMultipartPartsResponse
+	44,  // 248: files.v1.FilesService.ExtractArchive:output_type -> files.v1.ExtractArchiveResponse
+	46,  // 249: files.v1.FilesService.GetArchiveExtraction:output_type -> files.v1.GetArchiveExtractionResponse
+	64,  // 250: files.v1.FilesService.HeadContent:output_type -> files.v1.HeadContentResponse
+	68,  // 251: files.v1.FilesService.PatchContent:output_type -> files.v1.PatchContentResponse
+	48,  // 252: files.v1.FilesService.GetSignedUploadUrl:output_type -> files.v1.GetSignedUploadUrlResponse
+	50,  // 253: files.v1.FilesService.FinalizeSignedUpload:output_type -> files.v1.FinalizeSignedUploadResponse
+	52,  // 254: files.v1.FilesService.GetSignedDownloadUrl:output_type -> files.v1.GetSignedDownloadUrlResponse
+	66,  // 255: files.v1.FilesService.DeleteContent:output_type -> files.v1.DeleteContentResponse
+	54,  // 256: files.v1.FilesService.GetContent:output_type -> files.v1.GetContentResponse
+	56,  // 257: files.v1.FilesService.GetContentOverrideName:output_type -> files.v1.GetContentOverrideNameResponse
+	57,  // 258: files.v1.FilesService.DownloadContent:output_type -> files.v1.DownloadContentResponse
+	61,  // 259: files.v1.FilesService.DownloadContentRange:output_type -> files.v1.DownloadContentRangeResponse
+	60,  // 260: files.v1.FilesService.DownloadArchive:output_type -> files.v1.DownloadArchiveResponse
+	88,  // 261: files.v1.FilesService.GetContentThumbnail:output_type -> files.v1.GetContentThumbnailResponse
+	91,  // 262: files.v1.FilesService.GetThumbnailStatus:output_type -> files.v1.GetThumbnailStatusResponse
+	93,  // 263: files.v1.FilesService.RequeueThumbnail:output_type -> files.v1.RequeueThumbnailResponse
+	96,  // 264: files.v1.FilesService.RegenerateThumbnails:output_type -> files.v1.RegenerateThumbnailsResponse
+	101, // 265: files.v1.FilesService.GetThumbnailRegeneration:output_type -> files.v1.GetThumbnailRegenerationResponse
+	99,  // 266: files.v1.FilesService.ReconcileOwnership:output_type -> files.v1.ReconcileOwnershipResponse
+	103, // 267: files.v1.FilesService.GetUrlPreview:output_type -> files.v1.GetUrlPreviewResponse
+	105, // 268: files.v1.FilesService.GetConfig:output_type -> files.v1.GetConfigResponse
+	107, // 269: files.v1.FilesService.SearchMedia:output_type -> files.v1.SearchMediaResponse
+	109, // 270: files.v1.FilesService.BatchGetContent:output_type -> files.v1.BatchGetContentResponse
+	111, // 271: files.v1.FilesService.BatchDeleteContent:output_type -> files.v1.BatchDeleteContentResponse
+	70,  // 272: files.v1.FilesService.GrantAccess:output_type -> files.v1.GrantAccessResponse
+	72,  // 273: files.v1.FilesService.RevokeAccess:output_type -> files.v1.RevokeAccessResponse
+	74,  // 274: files.v1.FilesService.ListAccess:output_type -> files.v1.ListAccessResponse
+	77,  // 275: files.v1.FilesService.ListSharedWithMe:output_type -> files.v1.ListSharedWithMeResponse
+	79,  // 276: files.v1.FilesService.TransferOwnership:output_type -> files.v1.TransferOwnershipResponse
+	82,  // 277: files.v1.FilesService.CreateShareLink:output_type -> files.v1.CreateShareLinkResponse
+	84,  // 278: files.v1.FilesService.ListShareLinks:output_type -> files.v1.ListShareLinksResponse
+	86,  // 279: files.v1.FilesService.RevokeShareLink:output_type -> files.v1.RevokeShareLinkResponse
+	114, // 280: files.v1.FilesService.GetVersions:output_type -> files.v1.GetVersionsResponse
+	116, // 281: files.v1.FilesService.RestoreVersion:output_type -> files.v1.RestoreVersionResponse
+	119, // 282: files.v1.FilesService.SetRetentionPolicy:output_type -> files.v1.SetRetentionPolicyResponse
+	121, // 283: files.v1.FilesService.GetRetentionPolicy:output_type -> files.v1.GetRetentionPolicyResponse
+	123, // 284: files.v1.FilesService.ListRetentionPolicies:output_type -> files.v1.ListRetentionPoliciesResponse
+	126, // 285: files.v1.FilesService.GetUserUsage:output_type -> files.v1.GetUserUsageResponse
+	128, // 286: files.v1.FilesService.GetStorageStats:output_type -> files.v1.GetStorageStatsResponse
+	133, // 287: files.v1.FilesService.CreateWebhookSubscription:output_type -> files.v1.CreateWebhookSubscriptionResponse
+	135, // 288: files.v1.FilesService.ListWebhookSubscriptions:output_type -> files.v1.ListWebhookSubscriptionsResponse
+	137, // 289: files.v1.FilesService.DeleteWebhookSubscription:output_type -> files.v1.DeleteWebhookSubscriptionResponse
+	139, // 290: files.v1.FilesService.ListWebhookDeliveries:output_type -> files.v1.ListWebhookDeliveriesResponse
+	141, // 291: files.v1.FilesService.AdminListMedia:output_type -> files.v1.AdminListMediaResponse
+	143, // 292: files.v1.FilesService.AdminGetMedia:output_type -> files.v1.AdminGetMediaResponse
+	145, // 293: files.v1.FilesService.AdminGrantAccess:output_type -> files.v1.AdminGrantAccessResponse
+	147, // 294: files.v1.FilesService.AdminTransferOwnership:output_type -> files.v1.AdminTransferOwnershipResponse
+	149, // 295: files.v1.FilesService.AdminDeleteContent:output_type -> files.v1.AdminDeleteContentResponse
+	152, // 296: files.v1.FilesService.ExportUserData:output_type -> files.v1.ExportUserDataResponse
+	154, // 297: files.v1.FilesService.GetUserDataExport:output_type -> files.v1.GetUserDataExportResponse
+	158, // 298: files.v1.FilesService.EraseUserData:output_type -> files.v1.EraseUserDataResponse
+	160, // 299: files.v1.FilesService.GetUserDataErasure:output_type -> files.v1.GetUserDataErasureResponse
+	240, // [240:300] is the sub-list for method output_type
+	180, // [180:240] is the sub-list for method input_type
+	180, // [180:180] is the sub-list for extension type_name
+	180, // [180:180] is the sub-list for extension extendee
+	0,   // [0:180] is the sub-list for field type_name
+}
+
+func init() { file_files_v1_files_proto_init() }
+func file_files_v1_files_proto_init() {
+	if File_files_v1_files_proto != nil {
+		return
+	}
+	file_files_v1_files_proto_msgTypes[4].OneofWrappers = []any{
+		(*uploadContentRequest_Metadata)(nil),
+		(*uploadContentRequest_Chunk)(nil),
+	}
+	file_files_v1_files_proto_msgTypes[46].OneofWrappers = []any{}
+	file_files_v1_files_proto_msgTypes[66].OneofWrappers = []any{}
+	file_files_v1_files_proto_msgTypes[150].OneofWrappers = []any{
+		(*batchGetContentResponse_ContentResult_Content)(nil),
+		(*batchGetContentResponse_ContentResult_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_files_v1_files_proto_rawDesc), len(file_files_v1_files_proto_rawDesc)),
+			NumEnums:      21,
+			NumMessages:   153,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_files_v1_files_proto_goTypes,
+		DependencyIndexes: file_files_v1_files_proto_depIdxs,
+		EnumInfos:         file_files_v1_files_proto_enumTypes,
+		MessageInfos:      file_files_v1_files_proto_msgTypes,
+	}.Build()
+	File_files_v1_files_proto = out.File
+	file_files_v1_files_proto_goTypes = nil
+	file_files_v1_files_proto_depIdxs = nil
+}
@@ -0,0 +1,2287 @@
+// Copyright 2023-2026 Ant Investor Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// =============================================================================
+// Files Management API
+// =============================================================================
+//
+// Overview:
+//   The Files API provides comprehensive file and media management capabilities
+//   including upload, download, thumbnail generation, versioning, retention,
+//   access control, and search. All content is identified by content URIs
+//   via HTTPS API paths (e.g. https://<server>/v1/media/download/<server_name>/<media_id>).
+//
+// Quick Start (common workflows):
+//
+//   Simple Upload:
+//     1. Call UploadContent (stream metadata + chunks)
+//     2. Receive content_uri, media_id, server_name in response
+//     3. Use media_id for all subsequent operations (download, thumbnail, etc.)
+//
+//   Pre-allocated Upload (get URI before content is ready):
+//     1. Call CreateContent -> receive content_uri, media_id, server_name
+//     2. Later, call UploadContent with server_name + media_id in metadata
+//
+//   Download:
+//     - Small files: GetContent(media_id) -> bytes + metadata
+//     - Large files: DownloadContent(media_id) -> stream of chunks
+//     - Partial: DownloadContentRange(media_id, start, end) -> stream
+//     - Thumbnail: GetContentThumbnail(media_id, width, height)
+//
+//   Share with others:
+//     1. Upload content
+//     2. Call GrantAccess(media_id, principal_id, role)
+//
+//   Large file upload (multipart):
+//     1. CreateMultipartUpload -> upload_id
+//     2. UploadMultipartPart (repeat, can be parallel)
+//     3. CompleteMultipartUpload(upload_id, checksum, parts)
+//
+// API Paths (HTTPS):
+//   POST   /v1/media/upload                                        - Upload content
+//   GET    /v1/media/config                                        - Get server config
+//   GET    /v1/media/download/{serverName}/{mediaId}               - Download content
+//   GET    /v1/media/download/{serverName}/{mediaId}/{downloadName} - Download with filename
+//   GET    /v1/media/thumbnail/{serverName}/{mediaId}              - Get thumbnail
+//   GET    /v1/media/search                                        - Search media
+//
+// Architecture Principles:
+//   1. Labels for Organization - User-defined labels (map<string,string>) are for
+//      organization and search only, NOT access control.
+//   2. Explicit Access Control - All access is managed through AccessGrant.
+//      Use GrantAccess/RevokeAccess to control who can access content.
+//   3. Idempotency - All mutating operations support idempotency_key to safely
+//      handle network retries.
+//   4. State Machine - Media objects follow a defined lifecycle: CREATING ->
+//      AVAILABLE -> ARCHIVED/DELETED
+//   5. Pagination - All list operations use PageCursor from common package for
+//      consistent cursor-based pagination across services.
+//
+// Access Control Model:
+//
+//   All access control is handled through explicit AccessGrant entries.
+//   The owner automatically receives OWNER role and cannot be revoked.
+//
+//   Principal Types:
+//     - USER: Direct user access (principal_id = "user:<id>")
+//     - SERVICE: Service account access (principal_id = "service:<id>")
+//     - ORGANIZATION: All members of a tenant partition get access
+//       (principal_id = "tenancy:<tenant_id>/<partition_id>")
+//     - GROUP: All members of a group get access
+//       (principal_id = "group:<group_id>")
+//     - CHAT_GROUP: All members of a chat group get access
+//       (principal_id = "room:<room_id>" or "chat:<chat_id>")
+//
+//   This flexible model supports:
+//     - Organization-wide sharing (grant to org:acme-corp)
+//     - Chat room attachments (grant to room:!abc123:matrix.org)
+//     - Team folders (grant to org:acme-corp/engineering)
+//     - Admin-only access (grant to specific users with WRITER/OWNER role)
+//     - Public content (grant to "user:public" or use convenience field)
+//
+// Use Cases:
+//
+//   1. Chat Room Files:
+//      Upload, then GrantAccess with principal_id="room:!abc123:matrix.org"
+//      -> All room members can access
+//
+//   2. Organization Documents:
+//      Upload, then GrantAccess with principal_id="tenancy:<tenant_id>/<partition_id>"
+//      -> All partition members can access
+//
+//   3. Admin-Only Files:
+//      Upload, then GrantAccess to specific users with OWNER/WRITER role
+//      -> Only admins can modify
+//
+//   4. Public Assets:
+//      Set visibility=PUBLIC, no grants needed
+//
+// Dependencies:
+//   - google/protobuf/timestamp.proto
+//   - google/protobuf/struct.proto
+//   - common/v1/common.proto (PageCursor)
+// =============================================================================
+
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: files/v1/files.proto
+
+package filesv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/antinvestor/service-files/apps/default/gen/files/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// FilesServiceName is the fully-qualified name of the FilesService service.
+	FilesServiceName = "files.v1.FilesService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// FilesServiceUploadContentProcedure is the fully-qualified name of the FilesService's
+	// UploadContent RPC.
+	FilesServiceUploadContentProcedure = "/files.v1.FilesService/UploadContent"
+	// FilesServiceCreateContentProcedure is the fully-qualified name of the FilesService's
+	// CreateContent RPC.
+	FilesServiceCreateContentProcedure = "/files.v1.FilesService/CreateContent"
+	// FilesServiceCreateMultipartUploadProcedure is the fully-qualified name of the FilesService's
+	// CreateMultipartUpload RPC.
+	FilesServiceCreateMultipartUploadProcedure = "/files.v1.FilesService/CreateMultipartUpload"
+	// FilesServiceGetMultipartUploadProcedure is the fully-qualified name of the FilesService's
+	// GetMultipartUpload RPC.
+	FilesServiceGetMultipartUploadProcedure = "/files.v1.FilesService/GetMultipartUpload"
+	// FilesServiceUploadMultipartPartProcedure is the fully-qualified name of the FilesService's
+	// UploadMultipartPart RPC.
+	FilesServiceUploadMultipartPartProcedure = "/files.v1.FilesService/UploadMultipartPart"
+	// FilesServiceCompleteMultipartUploadProcedure is the fully-qualified name of the FilesService's
+	// CompleteMultipartUpload RPC.
+	FilesServiceCompleteMultipartUploadProcedure = "/files.v1.FilesService/CompleteMultipartUpload"
+	// FilesServiceAbortMultipartUploadProcedure is the fully-qualified name of the FilesService's
+	// AbortMultipartUpload RPC.
+	FilesServiceAbortMultipartUploadProcedure = "/files.v1.FilesService/AbortMultipartUpload"
+	// FilesServiceListMultipartPartsProcedure is the fully-qualified name of the FilesService's
+	// ListMultipartParts RPC.
+	FilesServiceListMultipartPartsProcedure = "/files.v1.FilesService/ListMultipartParts"
+	// FilesServiceExtractArchiveProcedure is the fully-qualified name of the FilesService's
+	// ExtractArchive RPC.
+	FilesServiceExtractArchiveProcedure = "/files.v1.FilesService/ExtractArchive"
+	// FilesServiceGetArchiveExtractionProcedure is the fully-qualified name of the FilesService's
+	// GetArchiveExtraction RPC.
+	FilesServiceGetArchiveExtractionProcedure = "/files.v1.FilesService/GetArchiveExtraction"
+	// FilesServiceHeadContentProcedure is the fully-qualified name of the FilesService's HeadContent
+	// RPC.
+	FilesServiceHeadContentProcedure = "/files.v1.FilesService/HeadContent"
+	// FilesServicePatchContentProcedure is the fully-qualified name of the FilesService's PatchContent
+	// RPC.
+	FilesServicePatchContentProcedure = "/files.v1.FilesService/PatchContent"
+	// FilesServiceGetSignedUploadUrlProcedure is the fully-qualified name of the FilesService's
+	// GetSignedUploadUrl RPC.
+	FilesServiceGetSignedUploadUrlProcedure = "/files.v1.FilesService/GetSignedUploadUrl"
+	// FilesServiceFinalizeSignedUploadProcedure is the fully-qualified name of the FilesService's
+	// FinalizeSignedUpload RPC.
+	FilesServiceFinalizeSignedUploadProcedure = "/files.v1.FilesService/FinalizeSignedUpload"
+	// FilesServiceGetSignedDownloadUrlProcedure is the fully-qualified name of the FilesService's
+	// GetSignedDownloadUrl RPC.
+	FilesServiceGetSignedDownloadUrlProcedure = "/files.v1.FilesService/GetSignedDownloadUrl"
+	// FilesServiceDeleteContentProcedure is the fully-qualified name of the FilesService's
+	// DeleteContent RPC.
+	FilesServiceDeleteContentProcedure = "/files.v1.FilesService/DeleteContent"
+	// FilesServiceGetContentProcedure is the fully-qualified name of the FilesService's GetContent RPC.
+	FilesServiceGetContentProcedure = "/files.v1.FilesService/GetContent"
+	// FilesServiceGetContentOverrideNameProcedure is the fully-qualified name of the FilesService's
+	// GetContentOverrideName RPC.
+	FilesServiceGetContentOverrideNameProcedure = "/files.v1.FilesService/GetContentOverrideName"
+	// FilesServiceDownloadContentProcedure is the fully-qualified name of the FilesService's
+	// DownloadContent RPC.
+	FilesServiceDownloadContentProcedure = "/files.v1.FilesService/DownloadContent"
+	// FilesServiceDownloadContentRangeProcedure is the fully-qualified name of the FilesService's
+	// DownloadContentRange RPC.
+	FilesServiceDownloadContentRangeProcedure = "/files.v1.FilesService/DownloadContentRange"
+	// FilesServiceDownloadArchiveProcedure is the fully-qualified name of the FilesService's
+	// DownloadArchive RPC.
+	FilesServiceDownloadArchiveProcedure = "/files.v1.FilesService/DownloadArchive"
+	// FilesServiceGetContentThumbnailProcedure is the fully-qualified name of the FilesService's
+	// GetContentThumbnail RPC.
+	FilesServiceGetContentThumbnailProcedure = "/files.v1.FilesService/GetContentThumbnail"
+	// FilesServiceGetThumbnailStatusProcedure is the fully-qualified name of the FilesService's
+	// GetThumbnailStatus RPC.
+	FilesServiceGetThumbnailStatusProcedure = "/files.v1.FilesService/GetThumbnailStatus"
+	// FilesServiceRequeueThumbnailProcedure is the fully-qualified name of the FilesService's
+	// RequeueThumbnail RPC.
+	FilesServiceRequeueThumbnailProcedure = "/files.v1.FilesService/RequeueThumbnail"
+	// FilesServiceRegenerateThumbnailsProcedure is the fully-qualified name of the FilesService's
+	// RegenerateThumbnails RPC.
+	FilesServiceRegenerateThumbnailsProcedure = "/files.v1.FilesService/RegenerateThumbnails"
+	// FilesServiceGetThumbnailRegenerationProcedure is the fully-qualified name of the FilesService's
+	// GetThumbnailRegeneration RPC.
+	FilesServiceGetThumbnailRegenerationProcedure = "/files.v1.FilesService/GetThumbnailRegeneration"
+	// FilesServiceReconcileOwnershipProcedure is the fully-qualified name of the FilesService's
+	// ReconcileOwnership RPC.
+	FilesServiceReconcileOwnershipProcedure = "/files.v1.FilesService/ReconcileOwnership"
+	// FilesServiceGetUrlPreviewProcedure is the fully-qualified name of the FilesService's
+	// GetUrlPreview RPC.
+	FilesServiceGetUrlPreviewProcedure = "/files.v1.FilesService/GetUrlPreview"
+	// FilesServiceGetConfigProcedure is the fully-qualified name of the FilesService's GetConfig RPC.
+	FilesServiceGetConfigProcedure = "/files.v1.FilesService/GetConfig"
+	// FilesServiceSearchMediaProcedure is the fully-qualified name of the FilesService's SearchMedia
+	// RPC.
+	FilesServiceSearchMediaProcedure = "/files.v1.FilesService/SearchMedia"
+	// FilesServiceBatchGetContentProcedure is the fully-qualified name of the FilesService's
+	// BatchGetContent RPC.
+	FilesServiceBatchGetContentProcedure = "/files.v1.FilesService/BatchGetContent"
+	// FilesServiceBatchDeleteContentProcedure is the fully-qualified name of the FilesService's
+	// BatchDeleteContent RPC.
+	FilesServiceBatchDeleteContentProcedure = "/files.v1.FilesService/BatchDeleteContent"
+	// FilesServiceGrantAccessProcedure is the fully-qualified name of the FilesService's GrantAccess
+	// RPC.
+	FilesServiceGrantAccessProcedure = "/files.v1.FilesService/GrantAccess"
+	// FilesServiceRevokeAccessProcedure is the fully-qualified name of the FilesService's RevokeAccess
+	// RPC.
+	FilesServiceRevokeAccessProcedure = "/files.v1.FilesService/RevokeAccess"
+	// FilesServiceListAccessProcedure is the fully-qualified name of the FilesService's ListAccess RPC.
+	FilesServiceListAccessProcedure = "/files.v1.FilesService/ListAccess"
+	// FilesServiceListSharedWithMeProcedure is the fully-qualified name of the FilesService's
+	// ListSharedWithMe RPC.
+	FilesServiceListSharedWithMeProcedure = "/files.v1.FilesService/ListSharedWithMe"
+	// FilesServiceTransferOwnershipProcedure is the fully-qualified name of the FilesService's
+	// TransferOwnership RPC.
+	FilesServiceTransferOwnershipProcedure = "/files.v1.FilesService/TransferOwnership"
+	// FilesServiceCreateShareLinkProcedure is the fully-qualified name of the FilesService's
+	// CreateShareLink RPC.
+	FilesServiceCreateShareLinkProcedure = "/files.v1.FilesService/CreateShareLink"
+	// FilesServiceListShareLinksProcedure is the fully-qualified name of the FilesService's
+	// ListShareLinks RPC.
+	FilesServiceListShareLinksProcedure = "/files.v1.FilesService/ListShareLinks"
+	// FilesServiceRevokeShareLinkProcedure is the fully-qualified name of the FilesService's
+	// RevokeShareLink RPC.
+	FilesServiceRevokeShareLinkProcedure = "/files.v1.FilesService/RevokeShareLink"
+	// FilesServiceGetVersionsProcedure is the fully-qualified name of the FilesService's GetVersions
+	// RPC.
+	FilesServiceGetVersionsProcedure = "/files.v1.FilesService/GetVersions"
+	// FilesServiceRestoreVersionProcedure is the fully-qualified name of the FilesService's
+	// RestoreVersion RPC.
+	FilesServiceRestoreVersionProcedure = "/files.v1.FilesService/RestoreVersion"
+	// FilesServiceSetRetentionPolicyProcedure is the fully-qualified name of the FilesService's
+	// SetRetentionPolicy RPC.
+	FilesServiceSetRetentionPolicyProcedure = "/files.v1.FilesService/SetRetentionPolicy"
+	// FilesServiceGetRetentionPolicyProcedure is the fully-qualified name of the FilesService's
+	// GetRetentionPolicy RPC.
+	FilesServiceGetRetentionPolicyProcedure = "/files.v1.FilesService/GetRetentionPolicy"
+	// FilesServiceListRetentionPoliciesProcedure is the fully-qualified name of the FilesService's
+	// ListRetentionPolicies RPC.
+	FilesServiceListRetentionPoliciesProcedure = "/files.v1.FilesService/ListRetentionPolicies"
+	// FilesServiceGetUserUsageProcedure is the fully-qualified name of the FilesService's GetUserUsage
+	// RPC.
+	FilesServiceGetUserUsageProcedure = "/files.v1.FilesService/GetUserUsage"
+	// FilesServiceGetStorageStatsProcedure is the fully-qualified name of the FilesService's
+	// GetStorageStats RPC.
+	FilesServiceGetStorageStatsProcedure = "/files.v1.FilesService/GetStorageStats"
+	// FilesServiceCreateWebhookSubscriptionProcedure is the fully-qualified name of the FilesService's
+	// CreateWebhookSubscription RPC.
+	FilesServiceCreateWebhookSubscriptionProcedure = "/files.v1.FilesService/CreateWebhookSubscription"
+	// FilesServiceListWebhookSubscriptionsProcedure is the fully-qualified name of the FilesService's
+	// ListWebhookSubscriptions RPC.
+	FilesServiceListWebhookSubscriptionsProcedure = "/files.v1.FilesService/ListWebhookSubscriptions"
+	// FilesServiceDeleteWebhookSubscriptionProcedure is the fully-qualified name of the FilesService's
+	// DeleteWebhookSubscription RPC.
+	FilesServiceDeleteWebhookSubscriptionProcedure = "/files.v1.FilesService/DeleteWebhookSubscription"
+	// FilesServiceListWebhookDeliveriesProcedure is the fully-qualified name of the FilesService's
+	// ListWebhookDeliveries RPC.
+	FilesServiceListWebhookDeliveriesProcedure = "/files.v1.FilesService/ListWebhookDeliveries"
+	// FilesServiceAdminListMediaProcedure is the fully-qualified name of the FilesService's
+	// AdminListMedia RPC.
+	FilesServiceAdminListMediaProcedure = "/files.v1.FilesService/AdminListMedia"
+	// FilesServiceAdminGetMediaProcedure is the fully-qualified name of the FilesService's
+	// AdminGetMedia RPC.
+	FilesServiceAdminGetMediaProcedure = "/files.v1.FilesService/AdminGetMedia"
+	// FilesServiceAdminGrantAccessProcedure is the fully-qualified name of the FilesService's
+	// AdminGrantAccess RPC.
+	FilesServiceAdminGrantAccessProcedure = "/files.v1.FilesService/AdminGrantAccess"
+	// FilesServiceAdminTransferOwnershipProcedure is the fully-qualified name of the FilesService's
+	// AdminTransferOwnership RPC.
+	FilesServiceAdminTransferOwnershipProcedure = "/files.v1.FilesService/AdminTransferOwnership"
+	// FilesServiceAdminDeleteContentProcedure is the fully-qualified name of the FilesService's
+	// AdminDeleteContent RPC.
+	FilesServiceAdminDeleteContentProcedure = "/files.v1.FilesService/AdminDeleteContent"
+	// FilesServiceExportUserDataProcedure is the fully-qualified name of the FilesService's
+	// ExportUserData RPC.
+	FilesServiceExportUserDataProcedure = "/files.v1.FilesService/ExportUserData"
+	// FilesServiceGetUserDataExportProcedure is the fully-qualified name of the FilesService's
+	// GetUserDataExport RPC.
+	FilesServiceGetUserDataExportProcedure = "/files.v1.FilesService/GetUserDataExport"
+	// FilesServiceEraseUserDataProcedure is the fully-qualified name of the FilesService's
+	// EraseUserData RPC.
+	FilesServiceEraseUserDataProcedure = "/files.v1.FilesService/EraseUserData"
+	// FilesServiceGetUserDataErasureProcedure is the fully-qualified name of the FilesService's
+	// GetUserDataErasure RPC.
+	FilesServiceGetUserDataErasureProcedure = "/files.v1.FilesService/GetUserDataErasure"
+)
+
+// FilesServiceClient is a client for the files.v1.FilesService service.
+type FilesServiceClient interface {
+	// UploadContent uploads content via streaming.
+	//
+	// Usage Patterns:
+	//   1. New upload: metadata (no server_name/media_id) -> chunks
+	//   2. Pre-created URI: CreateContent -> metadata + server_name/media_id -> chunks
+	//
+	// Streaming:
+	//   Send metadata first, then one or more chunk messages.
+	//   Server returns response when upload complete.
+	//
+	// Errors:
+	//   - INVALID_ARGUMENT: metadata missing or chunk after close
+	//   - NOT_FOUND: pre-created media_id not found
+	//   - ALREADY_EXISTS: media_id conflict (with idempotency)
+	//   - FAILED_PRECONDITION: quota exceeded
+	UploadContent(context.Context) *connect.ClientStreamForClient[v1.UploadContentRequest, v1.UploadContentResponse]
+	// CreateContent pre-allocates a content URI for future upload.
+	//
+	// Use when you need the URI before content is ready,
+	// or for implementing resumable uploads.
+	CreateContent(context.Context, *connect.Request[v1.CreateContentRequest]) (*connect.Response[v1.CreateContentResponse], error)
+	// CreateMultipartUpload initiates a multipart upload session.
+	CreateMultipartUpload(context.Context, *connect.Request[v1.CreateMultipartUploadRequest]) (*connect.Response[v1.CreateMultipartUploadResponse], error)
+	// GetMultipartUpload gets status of a multipart upload.
+	GetMultipartUpload(context.Context, *connect.Request[v1.GetMultipartUploadRequest]) (*connect.Response[v1.GetMultipartUploadResponse], error)
+	// UploadMultipartPart uploads a single part.
+	UploadMultipartPart(context.Context, *connect.Request[v1.UploadMultipartPartRequest]) (*connect.Response[v1.UploadMultipartPartResponse], error)
+	// CompleteMultipartUpload completes the upload.
+	CompleteMultipartUpload(context.Context, *connect.Request[v1.CompleteMultipartUploadRequest]) (*connect.Response[v1.CompleteMultipartUploadResponse], error)
+	// AbortMultipartUpload cancels the upload.
+	AbortMultipartUpload(context.Context, *connect.Request[v1.AbortMultipartUploadRequest]) (*connect.Response[v1.AbortMultipartUploadResponse], error)
+	// ListMultipartParts lists uploaded parts.
+	ListMultipartParts(context.Context, *connect.Request[v1.ListMultipartPartsRequest]) (*connect.Response[v1.ListMultipartPartsResponse], error)
+	// ExtractArchive expands an uploaded zip or tar.gz archive into individual
+	// media under a folder.
+	//
+	// Extraction runs in the background; every entry is uploaded as its own
+	// media with the usual deduplication, encryption, type validation and
+	// thumbnails. Entry counts, sizes and compression ratios are bounded and
+	// entries escaping the folder are refused.
+	ExtractArchive(context.Context, *connect.Request[v1.ExtractArchiveRequest]) (*connect.Response[v1.ExtractArchiveResponse], error)
+	// GetArchiveExtraction reports the progress of an extraction job.
+	GetArchiveExtraction(context.Context, *connect.Request[v1.GetArchiveExtractionRequest]) (*connect.Response[v1.GetArchiveExtractionResponse], error)
+	// HeadContent gets metadata without content.
+	HeadContent(context.Context, *connect.Request[v1.HeadContentRequest]) (*connect.Response[v1.HeadContentResponse], error)
+	// PatchContent updates metadata.
+	PatchContent(context.Context, *connect.Request[v1.PatchContentRequest]) (*connect.Response[v1.PatchContentResponse], error)
+	// GetSignedUploadUrl gets URL for direct storage upload.
+	GetSignedUploadUrl(context.Context, *connect.Request[v1.GetSignedUploadUrlRequest]) (*connect.Response[v1.GetSignedUploadUrlResponse], error)
+	// FinalizeSignedUpload completes a signed upload.
+	FinalizeSignedUpload(context.Context, *connect.Request[v1.FinalizeSignedUploadRequest]) (*connect.Response[v1.FinalizeSignedUploadResponse], error)
+	// GetSignedDownloadUrl gets URL for direct download.
+	GetSignedDownloadUrl(context.Context, *connect.Request[v1.GetSignedDownloadUrlRequest]) (*connect.Response[v1.GetSignedDownloadUrlResponse], error)
+	// DeleteContent deletes content.
+	DeleteContent(context.Context, *connect.Request[v1.DeleteContentRequest]) (*connect.Response[v1.DeleteContentResponse], error)
+	// GetContent downloads complete content.
+	GetContent(context.Context, *connect.Request[v1.GetContentRequest]) (*connect.Response[v1.GetContentResponse], error)
+	// GetContentOverrideName downloads with filename override.
+	GetContentOverrideName(context.Context, *connect.Request[v1.GetContentOverrideNameRequest]) (*connect.Response[v1.GetContentOverrideNameResponse], error)
+	// DownloadContent streams content.
+	DownloadContent(context.Context, *connect.Request[v1.DownloadContentRequest]) (*connect.ServerStreamForClient[v1.DownloadContentResponse], error)
+	// DownloadContentRange streams a byte range.
+	DownloadContentRange(context.Context, *connect.Request[v1.DownloadContentRangeRequest]) (*connect.ServerStreamForClient[v1.DownloadContentRangeResponse], error)
+	// DownloadArchive streams a zip archive of several files or a folder.
+	DownloadArchive(context.Context, *connect.Request[v1.DownloadArchiveRequest]) (*connect.ServerStreamForClient[v1.DownloadArchiveResponse], error)
+	// GetContentThumbnail generates a thumbnail.
+	GetContentThumbnail(context.Context, *connect.Request[v1.GetContentThumbnailRequest]) (*connect.Response[v1.GetContentThumbnailResponse], error)
+	// GetThumbnailStatus reports the thumbnail generation job of an image,
+	// including failures and dead-lettered jobs.
+	GetThumbnailStatus(context.Context, *connect.Request[v1.GetThumbnailStatusRequest]) (*connect.Response[v1.GetThumbnailStatusResponse], error)
+	// RequeueThumbnail resets the thumbnail generation job of an image and
+	// queues it again. Thumbnails that already exist are kept.
+	RequeueThumbnail(context.Context, *connect.Request[v1.RequeueThumbnailRequest]) (*connect.Response[v1.RequeueThumbnailResponse], error)
+	// RegenerateThumbnails walks existing images and queues generation of
+	// configured sizes they are missing, e.g. after the sizes changed.
+	//
+	// The run is throttled, records its progress and can be resumed from its
+	// checkpoint.
+	RegenerateThumbnails(context.Context, *connect.Request[v1.RegenerateThumbnailsRequest]) (*connect.Response[v1.RegenerateThumbnailsResponse], error)
+	// GetThumbnailRegeneration reports the progress of a regeneration run.
+	GetThumbnailRegeneration(context.Context, *connect.Request[v1.GetThumbnailRegenerationRequest]) (*connect.Response[v1.GetThumbnailRegenerationResponse], error)
+	// ReconcileOwnership compares one batch of media records with their Keto
+	// tuples and reports, or repairs, the differences.
+	ReconcileOwnership(context.Context, *connect.Request[v1.ReconcileOwnershipRequest]) (*connect.Response[v1.ReconcileOwnershipResponse], error)
+	// GetUrlPreview gets OpenGraph preview data.
+	GetUrlPreview(context.Context, *connect.Request[v1.GetUrlPreviewRequest]) (*connect.Response[v1.GetUrlPreviewResponse], error)
+	// GetConfig returns server configuration.
+	GetConfig(context.Context, *connect.Request[v1.GetConfigRequest]) (*connect.Response[v1.GetConfigResponse], error)
+	// SearchMedia searches for media.
+	SearchMedia(context.Context, *connect.Request[v1.SearchMediaRequest]) (*connect.Response[v1.SearchMediaResponse], error)
+	// BatchGetContent retrieves multiple files.
+	BatchGetContent(context.Context, *connect.Request[v1.BatchGetContentRequest]) (*connect.Response[v1.BatchGetContentResponse], error)
+	// BatchDeleteContent deletes multiple files.
+	BatchDeleteContent(context.Context, *connect.Request[v1.BatchDeleteContentRequest]) (*connect.Response[v1.BatchDeleteContentResponse], error)
+	// GrantAccess grants access to media.
+	GrantAccess(context.Context, *connect.Request[v1.GrantAccessRequest]) (*connect.Response[v1.GrantAccessResponse], error)
+	// RevokeAccess revokes access from media.
+	RevokeAccess(context.Context, *connect.Request[v1.RevokeAccessRequest]) (*connect.Response[v1.RevokeAccessResponse], error)
+	// ListAccess lists all grants for media.
+	ListAccess(context.Context, *connect.Request[v1.ListAccessRequest]) (*connect.Response[v1.ListAccessResponse], error)
+	// ListSharedWithMe lists the files others have shared with the caller,
+	// directly or through its groups and tenant partitions.
+	ListSharedWithMe(context.Context, *connect.Request[v1.ListSharedWithMeRequest]) (*connect.Response[v1.ListSharedWithMeResponse], error)
+	// TransferOwnership hands a file, a folder tree or all of the caller's
+	// files to another profile.
+	//
+	// Grants made on the files carry over; grants on the caller's folders stay
+	// with those folders. Either every file moves or none does.
+	TransferOwnership(context.Context, *connect.Request[v1.TransferOwnershipRequest]) (*connect.Response[v1.TransferOwnershipResponse], error)
+	// CreateShareLink creates a link to a file for people without an account.
+	//
+	// Links can expire, require a password, allow a limited number of
+	// downloads and be limited to viewing. Every use is audited.
+	CreateShareLink(context.Context, *connect.Request[v1.CreateShareLinkRequest]) (*connect.Response[v1.CreateShareLinkResponse], error)
+	// ListShareLinks lists the share links of a file.
+	ListShareLinks(context.Context, *connect.Request[v1.ListShareLinksRequest]) (*connect.Response[v1.ListShareLinksResponse], error)
+	// RevokeShareLink stops a share link from working.
+	RevokeShareLink(context.Context, *connect.Request[v1.RevokeShareLinkRequest]) (*connect.Response[v1.RevokeShareLinkResponse], error)
+	// GetVersions lists all versions.
+	GetVersions(context.Context, *connect.Request[v1.GetVersionsRequest]) (*connect.Response[v1.GetVersionsResponse], error)
+	// RestoreVersion restores a previous version.
+	RestoreVersion(context.Context, *connect.Request[v1.RestoreVersionRequest]) (*connect.Response[v1.RestoreVersionResponse], error)
+	// SetRetentionPolicy applies retention to media.
+	SetRetentionPolicy(context.Context, *connect.Request[v1.SetRetentionPolicyRequest]) (*connect.Response[v1.SetRetentionPolicyResponse], error)
+	// GetRetentionPolicy gets retention for media.
+	GetRetentionPolicy(context.Context, *connect.Request[v1.GetRetentionPolicyRequest]) (*connect.Response[v1.GetRetentionPolicyResponse], error)
+	// ListRetentionPolicies lists available policies.
+	ListRetentionPolicies(context.Context, *connect.Request[v1.ListRetentionPoliciesRequest]) (*connect.Response[v1.ListRetentionPoliciesResponse], error)
+	// GetUserUsage gets usage for a user.
+	GetUserUsage(context.Context, *connect.Request[v1.GetUserUsageRequest]) (*connect.Response[v1.GetUserUsageResponse], error)
+	// GetStorageStats gets global storage stats.
+	GetStorageStats(context.Context, *connect.Request[v1.GetStorageStatsRequest]) (*connect.Response[v1.GetStorageStatsResponse], error)
+	// CreateWebhookSubscription registers an endpoint for lifecycle events.
+	//
+	// The response carries the signing secret; it is not returned again.
+	CreateWebhookSubscription(context.Context, *connect.Request[v1.CreateWebhookSubscriptionRequest]) (*connect.Response[v1.CreateWebhookSubscriptionResponse], error)
+	// ListWebhookSubscriptions lists the tenant's webhook subscriptions.
+	ListWebhookSubscriptions(context.Context, *connect.Request[v1.ListWebhookSubscriptionsRequest]) (*connect.Response[v1.ListWebhookSubscriptionsResponse], error)
+	// DeleteWebhookSubscription removes a webhook subscription.
+	DeleteWebhookSubscription(context.Context, *connect.Request[v1.DeleteWebhookSubscriptionRequest]) (*connect.Response[v1.DeleteWebhookSubscriptionResponse], error)
+	// ListWebhookDeliveries lists the delivery history of a subscription,
+	// including dead-lettered deliveries.
+	ListWebhookDeliveries(context.Context, *connect.Request[v1.ListWebhookDeliveriesRequest]) (*connect.Response[v1.ListWebhookDeliveriesResponse], error)
+	// AdminListMedia lists the media of the caller's tenant partition.
+	//
+	// Errors:
+	//   - PERMISSION_DENIED: caller is not an admin of their partition
+	AdminListMedia(context.Context, *connect.Request[v1.AdminListMediaRequest]) (*connect.Response[v1.AdminListMediaResponse], error)
+	// AdminGetMedia gets the metadata, grants and a download URL of any media
+	// of the caller's tenant partition.
+	//
+	// Errors:
+	//   - PERMISSION_DENIED: caller is not an admin of their partition
+	//   - NOT_FOUND: media not found
+	AdminGetMedia(context.Context, *connect.Request[v1.AdminGetMediaRequest]) (*connect.Response[v1.AdminGetMediaResponse], error)
+	// AdminGrantAccess shares any file of the caller's tenant partition.
+	//
+	// Errors:
+	//   - PERMISSION_DENIED: caller is not an admin of their partition
+	//   - NOT_FOUND: media not found
+	AdminGrantAccess(context.Context, *connect.Request[v1.AdminGrantAccessRequest]) (*connect.Response[v1.AdminGrantAccessResponse], error)
+	// AdminTransferOwnership hands a file, a folder tree or all files of any
+	// owner in the caller's tenant partition to another profile.
+	//
+	// Errors:
+	//   - PERMISSION_DENIED: caller is not an admin of their partition
+	//   - NOT_FOUND: media not found
+	AdminTransferOwnership(context.Context, *connect.Request[v1.AdminTransferOwnershipRequest]) (*connect.Response[v1.AdminTransferOwnershipResponse], error)
+	// AdminDeleteContent deletes any media of the caller's tenant partition.
+	//
+	// Errors:
+	//   - PERMISSION_DENIED: caller is not an admin of their partition
+	//   - NOT_FOUND: media not found
+	AdminDeleteContent(context.Context, *connect.Request[v1.AdminDeleteContentRequest]) (*connect.Response[v1.AdminDeleteContentResponse], error)
+	// ExportUserData packages everything a profile owns into a zip archive.
+	//
+	// The export runs in the background. The archive holds the profile's
+	// files decrypted with their earlier versions under media/ and versions/,
+	// and metadata.json, grants.json and audit.json; it is stored as a new
+	// private media of the profile and a USER_DATA_EXPORTED lifecycle event
+	// announces it. Exports larger than the configured cap fail.
+	//
+	// Errors:
+	//   - INVALID_ARGUMENT: another profile was named without a reason
+	//   - PERMISSION_DENIED: another profile was named by a non-admin
+	ExportUserData(context.Context, *connect.Request[v1.ExportUserDataRequest]) (*connect.Response[v1.ExportUserDataResponse], error)
+	// GetUserDataExport reports the progress of an export requested by or for
+	// the caller.
+	GetUserDataExport(context.Context, *connect.Request[v1.GetUserDataExportRequest]) (*connect.Response[v1.GetUserDataExportResponse], error)
+	// EraseUserData permanently removes everything a profile owns.
+	//
+	// The erasure runs in the background. Files under a legal hold or an
+	// unexpired retention are kept and only lose the access granted on them;
+	// every other file is deleted with its thumbnails, derivatives, versions,
+	// retention, share links and stored content not shared with other media.
+	// Grants the profile received are revoked, its multipart uploads and jobs
+	// deleted, and its records on others' files anonymized. A DELETED
+	// lifecycle event is published for each erased file and the erasure ends
+	// with a signed report.
+	//
+	// Errors:
+	//   - INVALID_ARGUMENT: another profile was named without a reason
+	//   - PERMISSION_DENIED: another profile was named by a non-admin
+	EraseUserData(context.Context, *connect.Request[v1.EraseUserDataRequest]) (*connect.Response[v1.EraseUserDataResponse], error)
+	// GetUserDataErasure reports an erasure requested by or for the caller,
+	// with its signed report once it completed.
+	GetUserDataErasure(context.Context, *connect.Request[v1.GetUserDataErasureRequest]) (*connect.Response[v1.GetUserDataErasureResponse], error)
+}
+
+// NewFilesServiceClient constructs a client for the files.v1.FilesService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewFilesServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) FilesServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	filesServiceMethods := v1.File_files_v1_files_proto.Services().ByName("FilesService").Methods()
+	return &filesServiceClient{
+		uploadContent: connect.NewClient[v1.UploadContentRequest, v1.UploadContentResponse](
+			httpClient,
+			baseURL+FilesServiceUploadContentProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("UploadContent")),
+			connect.WithClientOptions(opts...),
+		),
+		createContent: connect.NewClient[v1.CreateContentRequest, v1.CreateContentResponse](
+			httpClient,
+			baseURL+FilesServiceCreateContentProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("CreateContent")),
+			connect.WithClientOptions(opts...),
+		),
+		createMultipartUpload: connect.NewClient[v1.CreateMultipartUploadRequest, v1.CreateMultipartUploadResponse](
+			httpClient,
+			baseURL+FilesServiceCreateMultipartUploadProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("CreateMultipartUpload")),
+			connect.WithClientOptions(opts...),
+		),
+		getMultipartUpload: connect.NewClient[v1.GetMultipartUploadRequest, v1.GetMultipartUploadResponse](
+			httpClient,
+			baseURL+FilesServiceGetMultipartUploadProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetMultipartUpload")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		uploadMultipartPart: connect.NewClient[v1.UploadMultipartPartRequest, v1.UploadMultipartPartResponse](
+			httpClient,
+			baseURL+FilesServiceUploadMultipartPartProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("UploadMultipartPart")),
+			connect.WithClientOptions(opts...),
+		),
+		completeMultipartUpload: connect.NewClient[v1.CompleteMultipartUploadRequest, v1.CompleteMultipartUploadResponse](
+			httpClient,
+			baseURL+FilesServiceCompleteMultipartUploadProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("CompleteMultipartUpload")),
+			connect.WithClientOptions(opts...),
+		),
+		abortMultipartUpload: connect.NewClient[v1.AbortMultipartUploadRequest, v1.AbortMultipartUploadResponse](
+			httpClient,
+			baseURL+FilesServiceAbortMultipartUploadProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("AbortMultipartUpload")),
+			connect.WithClientOptions(opts...),
+		),
+		listMultipartParts: connect.NewClient[v1.ListMultipartPartsRequest, v1.ListMultipartPartsResponse](
+			httpClient,
+			baseURL+FilesServiceListMultipartPartsProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("ListMultipartParts")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		extractArchive: connect.NewClient[v1.ExtractArchiveRequest, v1.ExtractArchiveResponse](
+			httpClient,
+			baseURL+FilesServiceExtractArchiveProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("ExtractArchive")),
+			connect.WithClientOptions(opts...),
+		),
+		getArchiveExtraction: connect.NewClient[v1.GetArchiveExtractionRequest, v1.GetArchiveExtractionResponse](
+			httpClient,
+			baseURL+FilesServiceGetArchiveExtractionProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetArchiveExtraction")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		headContent: connect.NewClient[v1.HeadContentRequest, v1.HeadContentResponse](
+			httpClient,
+			baseURL+FilesServiceHeadContentProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("HeadContent")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		patchContent: connect.NewClient[v1.PatchContentRequest, v1.PatchContentResponse](
+			httpClient,
+			baseURL+FilesServicePatchContentProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("PatchContent")),
+			connect.WithClientOptions(opts...),
+		),
+		getSignedUploadUrl: connect.NewClient[v1.GetSignedUploadUrlRequest, v1.GetSignedUploadUrlResponse](
+			httpClient,
+			baseURL+FilesServiceGetSignedUploadUrlProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetSignedUploadUrl")),
+			connect.WithClientOptions(opts...),
+		),
+		finalizeSignedUpload: connect.NewClient[v1.FinalizeSignedUploadRequest, v1.FinalizeSignedUploadResponse](
+			httpClient,
+			baseURL+FilesServiceFinalizeSignedUploadProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("FinalizeSignedUpload")),
+			connect.WithClientOptions(opts...),
+		),
+		getSignedDownloadUrl: connect.NewClient[v1.GetSignedDownloadUrlRequest, v1.GetSignedDownloadUrlResponse](
+			httpClient,
+			baseURL+FilesServiceGetSignedDownloadUrlProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetSignedDownloadUrl")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		deleteContent: connect.NewClient[v1.DeleteContentRequest, v1.DeleteContentResponse](
+			httpClient,
+			baseURL+FilesServiceDeleteContentProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("DeleteContent")),
+			connect.WithClientOptions(opts...),
+		),
+		getContent: connect.NewClient[v1.GetContentRequest, v1.GetContentResponse](
+			httpClient,
+			baseURL+FilesServiceGetContentProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetContent")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getContentOverrideName: connect.NewClient[v1.GetContentOverrideNameRequest, v1.GetContentOverrideNameResponse](
+			httpClient,
+			baseURL+FilesServiceGetContentOverrideNameProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetContentOverrideName")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		downloadContent: connect.NewClient[v1.DownloadContentRequest, v1.DownloadContentResponse](
+			httpClient,
+			baseURL+FilesServiceDownloadContentProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("DownloadContent")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		downloadContentRange: connect.NewClient[v1.DownloadContentRangeRequest, v1.DownloadContentRangeResponse](
+			httpClient,
+			baseURL+FilesServiceDownloadContentRangeProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("DownloadContentRange")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		downloadArchive: connect.NewClient[v1.DownloadArchiveRequest, v1.DownloadArchiveResponse](
+			httpClient,
+			baseURL+FilesServiceDownloadArchiveProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("DownloadArchive")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getContentThumbnail: connect.NewClient[v1.GetContentThumbnailRequest, v1.GetContentThumbnailResponse](
+			httpClient,
+			baseURL+FilesServiceGetContentThumbnailProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetContentThumbnail")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getThumbnailStatus: connect.NewClient[v1.GetThumbnailStatusRequest, v1.GetThumbnailStatusResponse](
+			httpClient,
+			baseURL+FilesServiceGetThumbnailStatusProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetThumbnailStatus")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		requeueThumbnail: connect.NewClient[v1.RequeueThumbnailRequest, v1.RequeueThumbnailResponse](
+			httpClient,
+			baseURL+FilesServiceRequeueThumbnailProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("RequeueThumbnail")),
+			connect.WithClientOptions(opts...),
+		),
+		regenerateThumbnails: connect.NewClient[v1.RegenerateThumbnailsRequest, v1.RegenerateThumbnailsResponse](
+			httpClient,
+			baseURL+FilesServiceRegenerateThumbnailsProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("RegenerateThumbnails")),
+			connect.WithClientOptions(opts...),
+		),
+		getThumbnailRegeneration: connect.NewClient[v1.GetThumbnailRegenerationRequest, v1.GetThumbnailRegenerationResponse](
+			httpClient,
+			baseURL+FilesServiceGetThumbnailRegenerationProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetThumbnailRegeneration")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		reconcileOwnership: connect.NewClient[v1.ReconcileOwnershipRequest, v1.ReconcileOwnershipResponse](
+			httpClient,
+			baseURL+FilesServiceReconcileOwnershipProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("ReconcileOwnership")),
+			connect.WithClientOptions(opts...),
+		),
+		getUrlPreview: connect.NewClient[v1.GetUrlPreviewRequest, v1.GetUrlPreviewResponse](
+			httpClient,
+			baseURL+FilesServiceGetUrlPreviewProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetUrlPreview")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getConfig: connect.NewClient[v1.GetConfigRequest, v1.GetConfigResponse](
+			httpClient,
+			baseURL+FilesServiceGetConfigProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetConfig")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		searchMedia: connect.NewClient[v1.SearchMediaRequest, v1.SearchMediaResponse](
+			httpClient,
+			baseURL+FilesServiceSearchMediaProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("SearchMedia")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		batchGetContent: connect.NewClient[v1.BatchGetContentRequest, v1.BatchGetContentResponse](
+			httpClient,
+			baseURL+FilesServiceBatchGetContentProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("BatchGetContent")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		batchDeleteContent: connect.NewClient[v1.BatchDeleteContentRequest, v1.BatchDeleteContentResponse](
+			httpClient,
+			baseURL+FilesServiceBatchDeleteContentProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("BatchDeleteContent")),
+			connect.WithClientOptions(opts...),
+		),
+		grantAccess: connect.NewClient[v1.GrantAccessRequest, v1.GrantAccessResponse](
+			httpClient,
+			baseURL+FilesServiceGrantAccessProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GrantAccess")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeAccess: connect.NewClient[v1.RevokeAccessRequest, v1.RevokeAccessResponse](
+			httpClient,
+			baseURL+FilesServiceRevokeAccessProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("RevokeAccess")),
+			connect.WithClientOptions(opts...),
+		),
+		listAccess: connect.NewClient[v1.ListAccessRequest, v1.ListAccessResponse](
+			httpClient,
+			baseURL+FilesServiceListAccessProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("ListAccess")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		listSharedWithMe: connect.NewClient[v1.ListSharedWithMeRequest, v1.ListSharedWithMeResponse](
+			httpClient,
+			baseURL+FilesServiceListSharedWithMeProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("ListSharedWithMe")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		transferOwnership: connect.NewClient[v1.TransferOwnershipRequest, v1.TransferOwnershipResponse](
+			httpClient,
+			baseURL+FilesServiceTransferOwnershipProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("TransferOwnership")),
+			connect.WithClientOptions(opts...),
+		),
+		createShareLink: connect.NewClient[v1.CreateShareLinkRequest, v1.CreateShareLinkResponse](
+			httpClient,
+			baseURL+FilesServiceCreateShareLinkProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("CreateShareLink")),
+			connect.WithClientOptions(opts...),
+		),
+		listShareLinks: connect.NewClient[v1.ListShareLinksRequest, v1.ListShareLinksResponse](
+			httpClient,
+			baseURL+FilesServiceListShareLinksProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("ListShareLinks")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		revokeShareLink: connect.NewClient[v1.RevokeShareLinkRequest, v1.RevokeShareLinkResponse](
+			httpClient,
+			baseURL+FilesServiceRevokeShareLinkProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("RevokeShareLink")),
+			connect.WithClientOptions(opts...),
+		),
+		getVersions: connect.NewClient[v1.GetVersionsRequest, v1.GetVersionsResponse](
+			httpClient,
+			baseURL+FilesServiceGetVersionsProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetVersions")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		restoreVersion: connect.NewClient[v1.RestoreVersionRequest, v1.RestoreVersionResponse](
+			httpClient,
+			baseURL+FilesServiceRestoreVersionProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("RestoreVersion")),
+			connect.WithClientOptions(opts...),
+		),
+		setRetentionPolicy: connect.NewClient[v1.SetRetentionPolicyRequest, v1.SetRetentionPolicyResponse](
+			httpClient,
+			baseURL+FilesServiceSetRetentionPolicyProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("SetRetentionPolicy")),
+			connect.WithClientOptions(opts...),
+		),
+		getRetentionPolicy: connect.NewClient[v1.GetRetentionPolicyRequest, v1.GetRetentionPolicyResponse](
+			httpClient,
+			baseURL+FilesServiceGetRetentionPolicyProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetRetentionPolicy")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		listRetentionPolicies: connect.NewClient[v1.ListRetentionPoliciesRequest, v1.ListRetentionPoliciesResponse](
+			httpClient,
+			baseURL+FilesServiceListRetentionPoliciesProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("ListRetentionPolicies")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getUserUsage: connect.NewClient[v1.GetUserUsageRequest, v1.GetUserUsageResponse](
+			httpClient,
+			baseURL+FilesServiceGetUserUsageProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetUserUsage")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getStorageStats: connect.NewClient[v1.GetStorageStatsRequest, v1.GetStorageStatsResponse](
+			httpClient,
+			baseURL+FilesServiceGetStorageStatsProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetStorageStats")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		createWebhookSubscription: connect.NewClient[v1.CreateWebhookSubscriptionRequest, v1.CreateWebhookSubscriptionResponse](
+			httpClient,
+			baseURL+FilesServiceCreateWebhookSubscriptionProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("CreateWebhookSubscription")),
+			connect.WithClientOptions(opts...),
+		),
+		listWebhookSubscriptions: connect.NewClient[v1.ListWebhookSubscriptionsRequest, v1.ListWebhookSubscriptionsResponse](
+			httpClient,
+			baseURL+FilesServiceListWebhookSubscriptionsProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("ListWebhookSubscriptions")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		deleteWebhookSubscription: connect.NewClient[v1.DeleteWebhookSubscriptionRequest, v1.DeleteWebhookSubscriptionResponse](
+			httpClient,
+			baseURL+FilesServiceDeleteWebhookSubscriptionProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("DeleteWebhookSubscription")),
+			connect.WithClientOptions(opts...),
+		),
+		listWebhookDeliveries: connect.NewClient[v1.ListWebhookDeliveriesRequest, v1.ListWebhookDeliveriesResponse](
+			httpClient,
+			baseURL+FilesServiceListWebhookDeliveriesProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("ListWebhookDeliveries")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		adminListMedia: connect.NewClient[v1.AdminListMediaRequest, v1.AdminListMediaResponse](
+			httpClient,
+			baseURL+FilesServiceAdminListMediaProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("AdminListMedia")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		adminGetMedia: connect.NewClient[v1.AdminGetMediaRequest, v1.AdminGetMediaResponse](
+			httpClient,
+			baseURL+FilesServiceAdminGetMediaProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("AdminGetMedia")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		adminGrantAccess: connect.NewClient[v1.AdminGrantAccessRequest, v1.AdminGrantAccessResponse](
+			httpClient,
+			baseURL+FilesServiceAdminGrantAccessProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("AdminGrantAccess")),
+			connect.WithClientOptions(opts...),
+		),
+		adminTransferOwnership: connect.NewClient[v1.AdminTransferOwnershipRequest, v1.AdminTransferOwnershipResponse](
+			httpClient,
+			baseURL+FilesServiceAdminTransferOwnershipProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("AdminTransferOwnership")),
+			connect.WithClientOptions(opts...),
+		),
+		adminDeleteContent: connect.NewClient[v1.AdminDeleteContentRequest, v1.AdminDeleteContentResponse](
+			httpClient,
+			baseURL+FilesServiceAdminDeleteContentProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("AdminDeleteContent")),
+			connect.WithClientOptions(opts...),
+		),
+		exportUserData: connect.NewClient[v1.ExportUserDataRequest, v1.ExportUserDataResponse](
+			httpClient,
+			baseURL+FilesServiceExportUserDataProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("ExportUserData")),
+			connect.WithClientOptions(opts...),
+		),
+		getUserDataExport: connect.NewClient[v1.GetUserDataExportRequest, v1.GetUserDataExportResponse](
+			httpClient,
+			baseURL+FilesServiceGetUserDataExportProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetUserDataExport")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		eraseUserData: connect.NewClient[v1.EraseUserDataRequest, v1.EraseUserDataResponse](
+			httpClient,
+			baseURL+FilesServiceEraseUserDataProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("EraseUserData")),
+			connect.WithClientOptions(opts...),
+		),
+		getUserDataErasure: connect.NewClient[v1.GetUserDataErasureRequest, v1.GetUserDataErasureResponse](
+			httpClient,
+			baseURL+FilesServiceGetUserDataErasureProcedure,
+			connect.WithSchema(filesServiceMethods.ByName("GetUserDataErasure")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// filesServiceClient implements FilesServiceClient.
+type filesServiceClient struct {
+	uploadContent             *connect.Client[v1.UploadContentRequest, v1.UploadContentResponse]
+	createContent             *connect.Client[v1.CreateContentRequest, v1.CreateContentResponse]
+	createMultipartUpload     *connect.Client[v1.CreateMultipartUploadRequest, v1.CreateMultipartUploadResponse]
+	getMultipartUpload        *connect.Client[v1.GetMultipartUploadRequest, v1.GetMultipartUploadResponse]
+	uploadMultipartPart       *connect.Client[v1.UploadMultipartPartRequest, v1.UploadMultipartPartResponse]
+	completeMultipartUpload   *connect.Client[v1.CompleteMultipartUploadRequest, v1.CompleteMultipartUploadResponse]
+	abortMultipartUpload      *connect.Client[v1.AbortMultipartUploadRequest, v1.AbortMultipartUploadResponse]
+	listMultipartParts        *connect.Client[v1.ListMultipartPartsRequest, v1.ListMultipartPartsResponse]
+	extractArchive            *connect.Client[v1.ExtractArchiveRequest, v1.ExtractArchiveResponse]
+	getArchiveExtraction      *connect.Client[v1.GetArchiveExtractionRequest, v1.GetArchiveExtractionResponse]
+	headContent               *connect.Client[v1.HeadContentRequest, v1.HeadContentResponse]
+	patchContent              *connect.Client[v1.PatchContentRequest, v1.PatchContentResponse]
+	getSignedUploadUrl        *connect.Client[v1.GetSignedUploadUrlRequest, v1.GetSignedUploadUrlResponse]
+	finalizeSignedUpload      *connect.Client[v1.FinalizeSignedUploadRequest, v1.FinalizeSignedUploadResponse]
+	getSignedDownloadUrl      *connect.Client[v1.GetSignedDownloadUrlRequest, v1.GetSignedDownloadUrlResponse]
+	deleteContent             *connect.Client[v1.DeleteContentRequest, v1.DeleteContentResponse]
+	getContent                *connect.Client[v1.GetContentRequest, v1.GetContentResponse]
+	getContentOverrideName    *connect.Client[v1.GetContentOverrideNameRequest, v1.GetContentOverrideNameResponse]
+	downloadContent           *connect.Client[v1.DownloadContentRequest, v1.DownloadContentResponse]
+	downloadContentRange      *connect.Client[v1.DownloadContentRangeRequest, v1.DownloadContentRangeResponse]
+	downloadArchive           *connect.Client[v1.DownloadArchiveRequest, v1.DownloadArchiveResponse]
+	getContentThumbnail       *connect.Client[v1.GetContentThumbnailRequest, v1.GetContentThumbnailResponse]
+	getThumbnailStatus        *connect.Client[v1.GetThumbnailStatusRequest, v1.GetThumbnailStatusResponse]
+	requeueThumbnail          *connect.Client[v1.RequeueThumbnailRequest, v1.RequeueThumbnailResponse]
+	regenerateThumbnails      *connect.Client[v1.RegenerateThumbnailsRequest, v1.RegenerateThumbnailsResponse]
+	getThumbnailRegeneration  *connect.Client[v1.GetThumbnailRegenerationRequest, v1.GetThumbnailRegenerationResponse]
+	reconcileOwnership        *connect.Client[v1.ReconcileOwnershipRequest, v1.ReconcileOwnershipResponse]
+	getUrlPreview             *connect.Client[v1.GetUrlPreviewRequest, v1.GetUrlPreviewResponse]
+	getConfig                 *connect.Client[v1.GetConfigRequest, v1.GetConfigResponse]
+	searchMedia               *connect.Client[v1.SearchMediaRequest, v1.SearchMediaResponse]
+	batchGetContent           *connect.Client[v1.BatchGetContentRequest, v1.BatchGetContentResponse]
+	batchDeleteContent        *connect.Client[v1.BatchDeleteContentRequest, v1.BatchDeleteContentResponse]
+	grantAccess               *connect.Client[v1.GrantAccessRequest, v1.GrantAccessResponse]
+	revokeAccess              *connect.Client[v1.RevokeAccessRequest, v1.RevokeAccessResponse]
+	listAccess                *connect.Client[v1.ListAccessRequest, v1.ListAccessResponse]
+	listSharedWithMe          *connect.Client[v1.ListSharedWithMeRequest, v1.ListSharedWithMeResponse]
+	transferOwnership         *connect.Client[v1.TransferOwnershipRequest, v1.TransferOwnershipResponse]
+	createShareLink           *connect.Client[v1.CreateShareLinkRequest, v1.CreateShareLinkResponse]
+	listShareLinks            *connect.Client[v1.ListShareLinksRequest, v1.ListShareLinksResponse]
+	revokeShareLink           *connect.Client[v1.RevokeShareLinkRequest, v1.RevokeShareLinkResponse]
+	getVersions               *connect.Client[v1.GetVersionsRequest, v1.GetVersionsResponse]
+	restoreVersion            *connect.Client[v1.RestoreVersionRequest, v1.RestoreVersionResponse]
+	setRetentionPolicy        *connect.Client[v1.SetRetentionPolicyRequest, v1.SetRetentionPolicyResponse]
+	getRetentionPolicy        *connect.Client[v1.GetRetentionPolicyRequest, v1.GetRetentionPolicyResponse]
+	listRetentionPolicies     *connect.Client[v1.ListRetentionPoliciesRequest, v1.ListRetentionPoliciesResponse]
+	getUserUsage              *connect.Client[v1.GetUserUsageRequest, v1.GetUserUsageResponse]
+	getStorageStats           *connect.Client[v1.GetStorageStatsRequest, v1.GetStorageStatsResponse]
+	createWebhookSubscription *connect.Client[v1.CreateWebhookSubscriptionRequest, v1.CreateWebhookSubscriptionResponse]
+	listWebhookSubscriptions  *connect.Client[v1.ListWebhookSubscriptionsRequest, v1.ListWebhookSubscriptionsResponse]
+	deleteWebhookSubscription *connect.Client[v1.DeleteWebhookSubscriptionRequest, v1.DeleteWebhookSubscriptionResponse]
+	listWebhookDeliveries     *connect.Client[v1.ListWebhookDeliveriesRequest, v1.ListWebhookDeliveriesResponse]
+	adminListMedia            *connect.Client[v1.AdminListMediaRequest, v1.AdminListMediaResponse]
+	adminGetMedia             *connect.Client[v1.AdminGetMediaRequest, v1.AdminGetMediaResponse]
+	adminGrantAccess          *connect.Client[v1.AdminGrantAccessRequest, v1.AdminGrantAccessResponse]
+	adminTransferOwnership    *connect.Client[v1.AdminTransferOwnershipRequest, v1.AdminTransferOwnershipResponse]
+	adminDeleteContent        *connect.Client[v1.AdminDeleteContentRequest, v1.AdminDeleteContentResponse]
+	exportUserData            *connect.Client[v1.ExportUserDataRequest, v1.ExportUserDataResponse]
+	getUserDataExport         *connect.Client[v1.GetUserDataExportRequest, v1.GetUserDataExportResponse]
+	eraseUserData             *connect.Client[v1.EraseUserDataRequest, v1.EraseUserDataResponse]
+	getUserDataErasure        *connect.Client[v1.GetUserDataErasureRequest, v1.GetUserDataErasureResponse]
+}
+
+// UploadContent calls files.v1.FilesService.UploadContent.
+func (c *filesServiceClient) UploadContent(ctx context.Context) *connect.ClientStreamForClient[v1.UploadContentRequest, v1.UploadContentResponse] {
+	return c.uploadContent.CallClientStream(ctx)
+}
+
+// CreateContent calls files.v1.FilesService.CreateContent.
+func (c *filesServiceClient) CreateContent(ctx context.Context, req *connect.Request[v1.CreateContentRequest]) (*connect.Response[v1.CreateContentResponse], error) {
+	return c.createContent.CallUnary(ctx, req)
+}
+
+// CreateMultipartUpload calls files.v1.FilesService.CreateMultipartUpload.
+func (c *filesServiceClient) CreateMultipartUpload(ctx context.Context, req *connect.Request[v1.CreateMultipartUploadRequest]) (*connect.Response[v1.CreateMultipartUploadResponse], error) {
+	return c.createMultipartUpload.CallUnary(ctx, req)
+}
+
+// GetMultipartUpload calls files.v1.FilesService.GetMultipartUpload.
+func (c *filesServiceClient) GetMultipartUpload(ctx context.Context, req *connect.Request[v1.GetMultipartUploadRequest]) (*connect.Response[v1.GetMultipartUploadResponse], error) {
+	return c.getMultipartUpload.CallUnary(ctx, req)
+}
+
+// UploadMultipartPart calls files.v1.FilesService.UploadMultipartPart.
+func (c *filesServiceClient) UploadMultipartPart(ctx context.Context, req *connect.Request[v1.UploadMultipartPartRequest]) (*connect.Response[v1.UploadMultipartPartResponse], error) {
+	return c.uploadMultipartPart.CallUnary(ctx, req)
+}
+
+// CompleteMultipartUpload calls files.v1.FilesService.CompleteMultipartUpload.
+func (c *filesServiceClient) CompleteMultipartUpload(ctx context.Context, req *connect.Request[v1.CompleteMultipartUploadRequest]) (*connect.Response[v1.CompleteMultipartUploadResponse], error) {
+	return c.completeMultipartUpload.CallUnary(ctx, req)
+}
+
+// AbortMultipartUpload calls files.v1.FilesService.AbortMultipartUpload.
+func (c *filesServiceClient) AbortMultipartUpload(ctx context.Context, req *connect.Request[v1.AbortMultipartUploadRequest]) (*connect.Response[v1.AbortMultipartUploadResponse], error) {
+	return c.abortMultipartUpload.CallUnary(ctx, req)
+}
+
+// ListMultipartParts calls files.v1.FilesService.ListMultipartParts.
+func (c *filesServiceClient) ListMultipartParts(ctx context.Context, req *connect.Request[v1.ListMultipartPartsRequest]) (*connect.Response[v1.ListMultipartPartsResponse], error) {
+	return c.listMultipartParts.CallUnary(ctx, req)
+}
+
+// ExtractArchive calls files.v1.FilesService.ExtractArchive.
+func (c *filesServiceClient) ExtractArchive(ctx context.Context, req *connect.Request[v1.ExtractArchiveRequest]) (*connect.Response[v1.ExtractArchiveResponse], error) {
+	return c.extractArchive.CallUnary(ctx, req)
+}
+
+// GetArchiveExtraction calls files.v1.FilesService.GetArchiveExtraction.
+func (c *filesServiceClient) GetArchiveExtraction(ctx context.Context, req *connect.Request[v1.GetArchiveExtractionRequest]) (*connect.Response[v1.GetArchiveExtractionResponse], error) {
+	return c.getArchiveExtraction.CallUnary(ctx, req)
+}
+
+// HeadContent calls files.v1.FilesService.HeadContent.
+func (c *filesServiceClient) HeadContent(ctx context.Context, req *connect.Request[v1.HeadContentRequest]) (*connect.Response[v1.HeadContentResponse], error) {
+	return c.headContent.CallUnary(ctx, req)
+}
+
+// PatchContent calls files.v1.FilesService.PatchContent.
+func (c *filesServiceClient) PatchContent(ctx context.Context, req *connect.Request[v1.PatchContentRequest]) (*connect.Response[v1.PatchContentResponse], error) {
+	return c.patchContent.CallUnary(ctx, req)
+}
+
+// GetSignedUploadUrl calls files.v1.FilesService.GetSignedUploadUrl.
+func (c *filesServiceClient) GetSignedUploadUrl(ctx context.Context, req *connect.Request[v1.GetSignedUploadUrlRequest]) (*connect.Response[v1.GetSignedUploadUrlResponse], error) {
+	return c.getSignedUploadUrl.CallUnary(ctx, req)
+}
+
+// FinalizeSignedUpload calls files.v1.FilesService.FinalizeSignedUpload.
+func (c *filesServiceClient) FinalizeSignedUpload(ctx context.Context, req *connect.Request[v1.FinalizeSignedUploadRequest]) (*connect.Response[v1.FinalizeSignedUploadResponse], error) {
+	return c.finalizeSignedUpload.CallUnary(ctx, req)
+}
+
+// GetSignedDownloadUrl calls files.v1.FilesService.GetSignedDownloadUrl.
+func (c *filesServiceClient) GetSignedDownloadUrl(ctx context.Context, req *connect.Request[v1.GetSignedDownloadUrlRequest]) (*connect.Response[v1.GetSignedDownloadUrlResponse], error) {
+	return c.getSignedDownloadUrl.CallUnary(ctx, req)
+}
+
+// DeleteContent calls files.v1.FilesService.DeleteContent.
+func (c *filesServiceClient) DeleteContent(ctx context.Context, req *connect.Request[v1.DeleteContentRequest]) (*connect.Response[v1.DeleteContentResponse], error) {
+	return c.deleteContent.CallUnary(ctx, req)
+}
+
+// GetContent calls files.v1.FilesService.GetContent.
+func (c *filesServiceClient) GetContent(ctx context.Context, req *connect.Request[v1.GetContentRequest]) (*connect.Response[v1.GetContentResponse], error) {
+	return c.getContent.CallUnary(ctx, req)
+}
+
+// GetContentOverrideName calls files.v1.FilesService.GetContentOverrideName.
+func (c *filesServiceClient) GetContentOverrideName(ctx context.Context, req *connect.Request[v1.GetContentOverrideNameRequest]) (*connect.Response[v1.GetContentOverrideNameResponse], error) {
+	return c.getContentOverrideName.CallUnary(ctx, req)
+}
+
+// DownloadContent calls files.v1.FilesService.DownloadContent.
+func (c *filesServiceClient) DownloadContent(ctx context.Context, req *connect.Request[v1.DownloadContentRequest]) (*connect.ServerStreamForClient[v1.DownloadContentResponse], error) {
+	return c.downloadContent.CallServerStream(ctx, req)
+}
+
+// DownloadContentRange calls files.v1.FilesService.DownloadContentRange.
+func (c *filesServiceClient) DownloadContentRange(ctx context.Context, req *connect.Request[v1.DownloadContentRangeRequest]) (*connect.ServerStreamForClient[v1.DownloadContentRangeResponse], error) {
+	return c.downloadContentRange.CallServerStream(ctx, req)
+}
+
+// DownloadArchive calls files.v1.FilesService.DownloadArchive.
+func (c *filesServiceClient) DownloadArchive(ctx context.Context, req *connect.Request[v1.DownloadArchiveRequest]) (*connect.ServerStreamForClient[v1.DownloadArchiveResponse], error) {
+	return c.downloadArchive.CallServerStream(ctx, req)
+}
+
+// GetContentThumbnail calls files.v1.FilesService.GetContentThumbnail.
+func (c *filesServiceClient) GetContentThumbnail(ctx context.Context, req *connect.Request[v1.GetContentThumbnailRequest]) (*connect.Response[v1.GetContentThumbnailResponse], error) {
+	return c.getContentThumbnail.CallUnary(ctx, req)
+}
+
+// GetThumbnailStatus calls files.v1.FilesService.GetThumbnailStatus.
+func (c *filesServiceClient) GetThumbnailStatus(ctx context.Context, req *connect.Request[v1.GetThumbnailStatusRequest]) (*connect.Response[v1.GetThumbnailStatusResponse], error) {
+	return c.getThumbnailStatus.CallUnary(ctx, req)
+}
+
+// RequeueThumbnail calls files.v1.FilesService.RequeueThumbnail.
+func (c *filesServiceClient) RequeueThumbnail(ctx context.Context, req *connect.Request[v1.RequeueThumbnailRequest]) (*connect.Response[v1.RequeueThumbnailResponse], error) {
+	return c.requeueThumbnail.CallUnary(ctx, req)
+}
+
+// RegenerateThumbnails calls files.v1.FilesService.RegenerateThumbnails.
+func (c *filesServiceClient) RegenerateThumbnails(ctx context.Context, req *connect.Request[v1.RegenerateThumbnailsRequest]) (*connect.Response[v1.RegenerateThumbnailsResponse], error) {
+	return c.regenerateThumbnails.CallUnary(ctx, req)
+}
+
+// GetThumbnailRegeneration calls files.v1.FilesService.GetThumbnailRegeneration.
+func (c *filesServiceClient) GetThumbnailRegeneration(ctx context.Context, req *connect.Request[v1.GetThumbnailRegenerationRequest]) (*connect.Response[v1.GetThumbnailRegenerationResponse], error) {
+	return c.getThumbnailRegeneration.CallUnary(ctx, req)
+}
+
+// ReconcileOwnership calls files.v1.FilesService.ReconcileOwnership.
+func (c *filesServiceClient) ReconcileOwnership(ctx context.Context, req *connect.Request[v1.ReconcileOwnershipRequest]) (*connect.Response[v1.ReconcileOwnershipResponse], error) {
+	return c.reconcileOwnership.CallUnary(ctx, req)
+}
+
+// GetUrlPreview calls files.v1.FilesService.GetUrlPreview.
+func (c *filesServiceClient) GetUrlPreview(ctx context.Context, req *connect.Request[v1.GetUrlPreviewRequest]) (*connect.Response[v1.GetUrlPreviewResponse], error) {
+	return c.getUrlPreview.CallUnary(ctx, req)
+}
+
+// GetConfig calls files.v1.FilesService.GetConfig.
+func (c *filesServiceClient) GetConfig(ctx context.Context, req *connect.Request[v1.GetConfigRequest]) (*connect.Response[v1.GetConfigResponse], error) {
+	return c.getConfig.CallUnary(ctx, req)
+}
+
+// SearchMedia calls files.v1.FilesService.SearchMedia.
+func (c *filesServiceClient) SearchMedia(ctx context.Context, req *connect.Request[v1.SearchMediaRequest]) (*connect.Response[v1.SearchMediaResponse], error) {
+	return c.searchMedia.CallUnary(ctx, req)
+}
+
+// BatchGetContent calls files.v1.FilesService.BatchGetContent.
+func (c *filesServiceClient) BatchGetContent(ctx context.Context, req *connect.Request[v1.BatchGetContentRequest]) (*connect.Response[v1.BatchGetContentResponse], error) {
+	return c.batchGetContent.CallUnary(ctx, req)
+}
+
+// BatchDeleteContent calls files.v1.FilesService.BatchDeleteContent.
+func (c *filesServiceClient) BatchDeleteContent(ctx context.Context, req *connect.Request[v1.BatchDeleteContentRequest]) (*connect.Response[v1.BatchDeleteContentResponse], error) {
+	return c.batchDeleteContent.CallUnary(ctx, req)
+}
+
+// GrantAccess calls files.v1.FilesService.GrantAccess.
+func (c *filesServiceClient) GrantAccess(ctx context.Context, req *connect.Request[v1.GrantAccessRequest]) (*connect.Response[v1.GrantAccessResponse], error) {
+	return c.grantAccess.CallUnary(ctx, req)
+}
+
+// RevokeAccess calls files.v1.FilesService.RevokeAccess.
+func (c *filesServiceClient) RevokeAccess(ctx context.Context, req *connect.Request[v1.RevokeAccessRequest]) (*connect.Response[v1.RevokeAccessResponse], error) {
+	return c.revokeAccess.CallUnary(ctx, req)
+}
+
+// ListAccess calls files.v1.FilesService.ListAccess.
+func (c *filesServiceClient) ListAccess(ctx context.Context, req *connect.Request[v1.ListAccessRequest]) (*connect.Response[v1.ListAccessResponse], error) {
+	return c.listAccess.CallUnary(ctx, req)
+}
+
+// ListSharedWithMe calls files.v1.FilesService.ListSharedWithMe.
+func (c *filesServiceClient) ListSharedWithMe(ctx context.Context, req *connect.Request[v1.ListSharedWithMeRequest]) (*connect.Response[v1.ListSharedWithMeResponse], error) {
+	return c.listSharedWithMe.CallUnary(ctx, req)
+}
+
+// TransferOwnership calls files.v1.FilesService.TransferOwnership.
+func (c *filesServiceClient) TransferOwnership(ctx context.Context, req *connect.Request[v1.TransferOwnershipRequest]) (*connect.Response[v1.TransferOwnershipResponse], error) {
+	return c.transferOwnership.CallUnary(ctx, req)
+}
+
+// CreateShareLink calls files.v1.FilesService.CreateShareLink.
+func (c *filesServiceClient) CreateShareLink(ctx context.Context, req *connect.Request[v1.CreateShareLinkRequest]) (*connect.Response[v1.CreateShareLinkResponse], error) {
+	return c.createShareLink.CallUnary(ctx, req)
+}
+
+// ListShareLinks calls files.v1.FilesService.ListShareLinks.
+func (c *filesServiceClient) ListShareLinks(ctx context.Context, req *connect.Request[v1.ListShareLinksRequest]) (*connect.Response[v1.ListShareLinksResponse], error) {
+	return c.listShareLinks.CallUnary(ctx, req)
+}
+
+// RevokeShareLink calls files.v1.FilesService.RevokeShareLink.
+func (c *filesServiceClient) RevokeShareLink(ctx context.Context, req *connect.Request[v1.RevokeShareLinkRequest]) (*connect.Response[v1.RevokeShareLinkResponse], error) {
+	return c.revokeShareLink.CallUnary(ctx, req)
+}
+
+// GetVersions calls files.v1.FilesService.GetVersions.
+func (c *filesServiceClient) GetVersions(ctx context.Context, req *connect.Request[v1.GetVersionsRequest]) (*connect.Response[v1.GetVersionsResponse], error) {
+	return c.getVersions.CallUnary(ctx, req)
+}
+
+// RestoreVersion calls files.v1.FilesService.RestoreVersion.
+func (c *filesServiceClient) RestoreVersion(ctx context.Context, req *connect.Request[v1.RestoreVersionRequest]) (*connect.Response[v1.RestoreVersionResponse], error) {
+	return c.restoreVersion.CallUnary(ctx, req)
+}
+
+// SetRetentionPolicy calls files.v1.FilesService.SetRetentionPolicy.
+func (c *filesServiceClient) SetRetentionPolicy(ctx context.Context, req *connect.Request[v1.SetRetentionPolicyRequest]) (*connect.Response[v1.SetRetentionPolicyResponse], error) {
+	return c.setRetentionPolicy.CallUnary(ctx, req)
+}
+
+// GetRetentionPolicy calls files.v1.FilesService.GetRetentionPolicy.
+func (c *filesServiceClient) GetRetentionPolicy(ctx context.Context, req *connect.Request[v1.GetRetentionPolicyRequest]) (*connect.Response[v1.GetRetentionPolicyResponse], error) {
+	return c.getRetentionPolicy.CallUnary(ctx, req)
+}
+
+// ListRetentionPolicies calls files.v1.FilesService.ListRetentionPolicies.
+func (c *filesServiceClient) ListRetentionPolicies(ctx context.Context, req *connect.Request[v1.ListRetentionPoliciesRequest]) (*connect.Response[v1.ListRetentionPoliciesResponse], error) {
+	return c.listRetentionPolicies.CallUnary(ctx, req)
+}
+
+// GetUserUsage calls files.v1.FilesService.GetUserUsage.
+func (c *filesServiceClient) GetUserUsage(ctx context.Context, req *connect.Request[v1.GetUserUsageRequest]) (*connect.Response[v1.GetUserUsageResponse], error) {
+	return c.getUserUsage.CallUnary(ctx, req)
+}
+
+// GetStorageStats calls files.v1.FilesService.GetStorageStats.
+func (c *filesServiceClient) GetStorageStats(ctx context.Context, req *connect.Request[v1.GetStorageStatsRequest]) (*connect.Response[v1.GetStorageStatsResponse], error) {
+	return c.getStorageStats.CallUnary(ctx, req)
+}
+
+// CreateWebhookSubscription calls files.v1.FilesService.CreateWebhookSubscription.
+func (c *filesServiceClient) CreateWebhookSubscription(ctx context.Context, req *connect.Request[v1.CreateWebhookSubscriptionRequest]) (*connect.Response[v1.CreateWebhookSubscriptionResponse], error) {
+	return c.createWebhookSubscription.CallUnary(ctx, req)
+}
+
+// ListWebhookSubscriptions calls files.v1.FilesService.ListWebhookSubscriptions.
+func (c *filesServiceClient) ListWebhookSubscriptions(ctx context.Context, req *connect.Request[v1.ListWebhookSubscriptionsRequest]) (*connect.Response[v1.ListWebhookSubscriptionsResponse], error) {
+	return c.listWebhookSubscriptions.CallUnary(ctx, req)
+}
+
+// DeleteWebhookSubscription calls files.v1.FilesService.DeleteWebhookSubscription.
+func (c *filesServiceClient) DeleteWebhookSubscription(ctx context.Context, req *connect.Request[v1.DeleteWebhookSubscriptionRequest]) (*connect.Response[v1.DeleteWebhookSubscriptionResponse], error) {
+	return c.deleteWebhookSubscription.CallUnary(ctx, req)
+}
+
+// ListWebhookDeliveries calls files.v1.FilesService.ListWebhookDeliveries.
+func (c *filesServiceClient) ListWebhookDeliveries(ctx context.Context, req *connect.Request[v1.ListWebhookDeliveriesRequest]) (*connect.Response[v1.ListWebhookDeliveriesResponse], error) {
+	return c.listWebhookDeliveries.CallUnary(ctx, req)
+}
+
+// AdminListMedia calls files.v1.FilesService.AdminListMedia.
+func (c *filesServiceClient) AdminListMedia(ctx context.Context, req *connect.Request[v1.AdminListMediaRequest]) (*connect.Response[v1.AdminListMediaResponse], error) {
+	return c.adminListMedia.CallUnary(ctx, req)
+}
+
+// AdminGetMedia calls files.v1.FilesService.AdminGetMedia.
+func (c *filesServiceClient) AdminGetMedia(ctx context.Context, req *connect.Request[v1.AdminGetMediaRequest]) (*connect.Response[v1.AdminGetMediaResponse], error) {
+	return c.adminGetMedia.CallUnary(ctx, req)
+}
+
+// AdminGrantAccess calls files.v1.FilesService.AdminGrantAccess.
+func (c *filesServiceClient) AdminGrantAccess(ctx context.Context, req *connect.Request[v1.AdminGrantAccessRequest]) (*connect.Response[v1.AdminGrantAccessResponse], error) {
+	return c.adminGrantAccess.CallUnary(ctx, req)
+}
+
+// AdminTransferOwnership calls files.v1.FilesService.AdminTransferOwnership.
+func (c *filesServiceClient) AdminTransferOwnership(ctx context.Context, req *connect.Request[v1.AdminTransferOwnershipRequest]) (*connect.Response[v1.AdminTransferOwnershipResponse], error) {
+	return c.adminTransferOwnership.CallUnary(ctx, req)
+}
+
+// AdminDeleteContent calls files.v1.FilesService.AdminDeleteContent.
+func (c *filesServiceClient) AdminDeleteContent(ctx context.Context, req *connect.Request[v1.AdminDeleteContentRequest]) (*connect.Response[v1.AdminDeleteContentResponse], error) {
+	return c.adminDeleteContent.CallUnary(ctx, req)
+}
+
+// ExportUserData calls files.v1.FilesService.ExportUserData.
+func (c *filesServiceClient) ExportUserData(ctx context.Context, req *connect.Request[v1.ExportUserDataRequest]) (*connect.Response[v1.ExportUserDataResponse], error) {
+	return c.exportUserData.CallUnary(ctx, req)
+}
+
+// GetUserDataExport calls files.v1.FilesService.GetUserDataExport.
+func (c *filesServiceClient) GetUserDataExport(ctx context.Context, req *connect.Request[v1.GetUserDataExportRequest]) (*connect.Response[v1.GetUserDataExportResponse], error) {
+	return c.getUserDataExport.CallUnary(ctx, req)
+}
+
+// EraseUserData calls files.v1.FilesService.EraseUserData.
+func (c *filesServiceClient) EraseUserData(ctx context.Context, req *connect.Request[v1.EraseUserDataRequest]) (*connect.Response[v1.EraseUserDataResponse], error) {
+	return c.eraseUserData.CallUnary(ctx, req)
+}
+
+// GetUserDataErasure calls files.v1.FilesService.GetUserDataErasure.
+func (c *filesServiceClient) GetUserDataErasure(ctx context.Context, req *connect.Request[v1.GetUserDataErasureRequest]) (*connect.Response[v1.GetUserDataErasureResponse], error) {
+	return c.getUserDataErasure.CallUnary(ctx, req)
+}
+
+// FilesServiceHandler is an implementation of the files.v1.FilesService service.
+type FilesServiceHandler interface {
+	// UploadContent uploads content via streaming.
+	//
+	// Usage Patterns:
+	//   1. New upload: metadata (no server_name/media_id) -> chunks
+	//   2. Pre-created URI: CreateContent -> metadata + server_name/media_id -> chunks
+	//
+	// Streaming:
+	//   Send metadata first, then one or more chunk messages.
+	//   Server returns response when upload complete.
+	//
+	// Errors:
+	//   - INVALID_ARGUMENT: metadata missing or chunk after close
+	//   - NOT_FOUND: pre-created media_id not found
+	//   - ALREADY_EXISTS: media_id conflict (with idempotency)
+	//   - FAILED_PRECONDITION: quota exceeded
+	UploadContent(context.Context, *connect.ClientStream[v1.UploadContentRequest]) (*connect.Response[v1.UploadContentResponse], error)
+	// CreateContent pre-allocates a content URI for future upload.
+	//
+	// Use when you need the URI before content is ready,
+	// or for implementing resumable uploads.
+	CreateContent(context.Context, *connect.Request[v1.CreateContentRequest]) (*connect.Response[v1.CreateContentResponse], error)
+	// CreateMultipartUpload initiates a multipart upload session.
+	CreateMultipartUpload(context.Context, *connect.Request[v1.CreateMultipartUploadRequest]) (*connect.Response[v1.CreateMultipartUploadResponse], error)
+	// GetMultipartUpload gets status of a multipart upload.
+	GetMultipartUpload(context.Context, *connect.Request[v1.GetMultipartUploadRequest]) (*connect.Response[v1.GetMultipartUploadResponse], error)
+	// UploadMultipartPart uploads a single part.
+	UploadMultipartPart(context.Context, *connect.Request[v1.UploadMultipartPartRequest]) (*connect.Response[v1.UploadMultipartPartResponse], error)
+	// CompleteMultipartUpload completes the upload.
+	CompleteMultipartUpload(context.Context, *connect.Request[v1.CompleteMultipartUploadRequest]) (*connect.Response[v1.CompleteMultipartUploadResponse], error)
+	// AbortMultipartUpload cancels the upload.
+	AbortMultipartUpload(context.Context, *connect.Request[v1.AbortMultipartUploadRequest]) (*connect.Response[v1.AbortMultipartUploadResponse], error)
+	// ListMultipartParts lists uploaded parts.
+	ListMultipartParts(context.Context, *connect.Request[v1.ListMultipartPartsRequest]) (*connect.Response[v1.ListMultipartPartsResponse], error)
+	// ExtractArchive expands an uploaded zip or tar.gz archive into individual
+	// media under a folder.
+	//
+	// Extraction runs in the background; every entry is uploaded as its own
+	// media with the usual deduplication, encryption, type validation and
+	// thumbnails. Entry counts, sizes and compression ratios are bounded and
+	// entries escaping the folder are refused.
+	ExtractArchive(context.Context, *connect.Request[v1.ExtractArchiveRequest]) (*connect.Response[v1.ExtractArchiveResponse], error)
+	// GetArchiveExtraction reports the progress of an extraction job.
+	GetArchiveExtraction(context.Context, *connect.Request[v1.GetArchiveExtractionRequest]) (*connect.Response[v1.GetArchiveExtractionResponse], error)
+	// HeadContent gets metadata without content.
+	HeadContent(context.Context, *connect.Request[v1.HeadContentRequest]) (*connect.Response[v1.HeadContentResponse], error)
+	// PatchContent updates metadata.
+	PatchContent(context.Context, *connect.Request[v1.PatchContentRequest]) (*connect.Response[v1.PatchContentResponse], error)
+	// GetSignedUploadUrl gets URL for direct storage upload.
+	GetSignedUploadUrl(context.Context, *connect.Request[v1.GetSignedUploadUrlRequest]) (*connect.Response[v1.GetSignedUploadUrlResponse], error)
+	// FinalizeSignedUpload completes a signed upload.
+	FinalizeSignedUpload(context.Context, *connect.Request[v1.FinalizeSignedUploadRequest]) (*connect.Response[v1.FinalizeSignedUploadResponse], error)
+	// GetSignedDownloadUrl gets URL for direct download.
+	GetSignedDownloadUrl(context.Context, *connect.Request[v1.GetSignedDownloadUrlRequest]) (*connect.Response[v1.GetSignedDownloadUrlResponse], error)
+	// DeleteContent deletes content.
+	DeleteContent(context.Context, *connect.Request[v1.DeleteContentRequest]) (*connect.Response[v1.DeleteContentResponse], error)
+	// GetContent downloads complete content.
+	GetContent(context.Context, *connect.Request[v1.GetContentRequest]) (*connect.Response[v1.GetContentResponse], error)
+	// GetContentOverrideName downloads with filename override.
+	GetContentOverrideName(context.Context, *connect.Request[v1.GetContentOverrideNameRequest]) (*connect.Response[v1.GetContentOverrideNameResponse], error)
+	// DownloadContent streams content.
+	DownloadContent(context.Context, *connect.Request[v1.DownloadContentRequest], *connect.ServerStream[v1.DownloadContentResponse]) error
+	// DownloadContentRange streams a byte range.
+	DownloadContentRange(context.Context, *connect.Request[v1.DownloadContentRangeRequest], *connect.ServerStream[v1.DownloadContentRangeResponse]) error
+	// DownloadArchive streams a zip archive of several files or a folder.
+	DownloadArchive(context.Context, *connect.Request[v1.DownloadArchiveRequest], *connect.ServerStream[v1.DownloadArchiveResponse]) error
+	// GetContentThumbnail generates a thumbnail.
+	GetContentThumbnail(context.Context, *connect.Request[v1.GetContentThumbnailRequest]) (*connect.Response[v1.GetContentThumbnailResponse], error)
+	// GetThumbnailStatus reports the thumbnail generation job of an image,
+	// including failures and dead-lettered jobs.
+	GetThumbnailStatus(context.Context, *connect.Request[v1.GetThumbnailStatusRequest]) (*connect.Response[v1.GetThumbnailStatusResponse], error)
+	// RequeueThumbnail resets the thumbnail generation job of an image and
+	// queues it again. Thumbnails that already exist are kept.
+	RequeueThumbnail(context.Context, *connect.Request[v1.RequeueThumbnailRequest]) (*connect.Response[v1.RequeueThumbnailResponse], error)
+	// RegenerateThumbnails walks existing images and queues generation of
+	// configured sizes they are missing, e.g. after the sizes changed.
+	//
+	// The run is throttled, records its progress and can be resumed from its
+	// checkpoint.
+	RegenerateThumbnails(context.Context, *connect.Request[v1.RegenerateThumbnailsRequest]) (*connect.Response[v1.RegenerateThumbnailsResponse], error)
+	// GetThumbnailRegeneration reports the progress of a regeneration run.
+	GetThumbnailRegeneration(context.Context, *connect.Request[v1.GetThumbnailRegenerationRequest]) (*connect.Response[v1.GetThumbnailRegenerationResponse], error)
+	// ReconcileOwnership compares one batch of media records with their Keto
+	// tuples and reports, or repairs, the differences.
+	ReconcileOwnership(context.Context, *connect.Request[v1.ReconcileOwnershipRequest]) (*connect.Response[v1.ReconcileOwnershipResponse], error)
+	// GetUrlPreview gets OpenGraph preview data.
+	GetUrlPreview(context.Context, *connect.Request[v1.GetUrlPreviewRequest]) (*connect.Response[v1.GetUrlPreviewResponse], error)
+	// GetConfig returns server configuration.
+	GetConfig(context.Context, *connect.Request[v1.GetConfigRequest]) (*connect.Response[v1.GetConfigResponse], error)
+	// SearchMedia searches for media.
+	SearchMedia(context.Context, *connect.Request[v1.SearchMediaRequest]) (*connect.Response[v1.SearchMediaResponse], error)
+	// BatchGetContent retrieves multiple files.
+	BatchGetContent(context.Context, *connect.Request[v1.BatchGetContentRequest]) (*connect.Response[v1.BatchGetContentResponse], error)
+	// BatchDeleteContent deletes multiple files.
+	BatchDeleteContent(context.Context, *connect.Request[v1.BatchDeleteContentRequest]) (*connect.Response[v1.BatchDeleteContentResponse], error)
+	// GrantAccess grants access to media.
+	GrantAccess(context.Context, *connect.Request[v1.GrantAccessRequest]) (*connect.Response[v1.GrantAccessResponse], error)
+	// RevokeAccess revokes access from media.
+	RevokeAccess(context.Context, *connect.Request[v1.RevokeAccessRequest]) (*connect.Response[v1.RevokeAccessResponse], error)
+	// ListAccess lists all grants for media.
+	ListAccess(context.Context, *connect.Request[v1.ListAccessRequest]) (*connect.Response[v1.ListAccessResponse], error)
+	// ListSharedWithMe lists the files others have shared with the caller,
+	// directly or through its groups and tenant partitions.
+	ListSharedWithMe(context.Context, *connect.Request[v1.ListSharedWithMeRequest]) (*connect.Response[v1.ListSharedWithMeResponse], error)
+	// TransferOwnership hands a file, a folder tree or all of the caller's
+	// files to another profile.
+	//
+	// Grants made on the files carry over; grants on the caller's folders stay
+	// with those folders. Either every file moves or none does.
+	TransferOwnership(context.Context, *connect.Request[v1.TransferOwnershipRequest]) (*connect.Response[v1.TransferOwnershipResponse], error)
+	// CreateShareLink creates a link to a file for people without an account.
+	//
+	// Links can expire, require a password, allow a limited number of
+	// downloads and be limited to viewing. Every use is audited.
+	CreateShareLink(context.Context, *connect.Request[v1.CreateShareLinkRequest]) (*connect.Response[v1.CreateShareLinkResponse], error)
+	// ListShareLinks lists the share links of a file.
+	ListShareLinks(context.Context, *connect.Request[v1.ListShareLinksRequest]) (*connect.Response[v1.ListShareLinksResponse], error)
+	// RevokeShareLink stops a share link from working.
+	RevokeShareLink(context.Context, *connect.Request[v1.RevokeShareLinkRequest]) (*connect.Response[v1.RevokeShareLinkResponse], error)
+	// GetVersions lists all versions.
+	GetVersions(context.Context, *connect.Request[v1.GetVersionsRequest]) (*connect.Response[v1.GetVersionsResponse], error)
+	// RestoreVersion restores a previous version.
+	RestoreVersion(context.Context, *connect.Request[v1.RestoreVersionRequest]) (*connect.Response[v1.RestoreVersionResponse], error)
+	// SetRetentionPolicy applies retention to media.
+	SetRetentionPolicy(context.Context, *connect.Request[v1.SetRetentionPolicyRequest]) (*connect.Response[v1.SetRetentionPolicyResponse], error)
+	// GetRetentionPolicy gets retention for media.
+	GetRetentionPolicy(context.Context, *connect.Request[v1.GetRetentionPolicyRequest]) (*connect.Response[v1.GetRetentionPolicyResponse], error)
+	// ListRetentionPolicies lists available policies.
+	ListRetentionPolicies(context.Context, *connect.Request[v1.ListRetentionPoliciesRequest]) (*connect.Response[v1.ListRetentionPoliciesResponse], error)
+	// GetUserUsage gets usage for a user.
+	GetUserUsage(context.Context, *connect.Request[v1.GetUserUsageRequest]) (*connect.Response[v1.GetUserUsageResponse], error)
+	// GetStorageStats gets global storage stats.
+	GetStorageStats(context.Context, *connect.Request[v1.GetStorageStatsRequest]) (*connect.Response[v1.GetStorageStatsResponse], error)
+	// CreateWebhookSubscription registers an endpoint for lifecycle events.
+	//
+	// The response carries the signing secret; it is not returned again.
+	CreateWebhookSubscription(context.Context, *connect.Request[v1.CreateWebhookSubscriptionRequest]) (*connect.Response[v1.CreateWebhookSubscriptionResponse], error)
+	// ListWebhookSubscriptions lists the tenant's webhook subscriptions.
+	ListWebhookSubscriptions(context.Context, *connect.Request[v1.ListWebhookSubscriptionsRequest]) (*connect.Response[v1.ListWebhookSubscriptionsResponse], error)
+	// DeleteWebhookSubscription removes a webhook subscription.
+	DeleteWebhookSubscription(context.Context, *connect.Request[v1.DeleteWebhookSubscriptionRequest]) (*connect.Response[v1.DeleteWebhookSubscriptionResponse], error)
+	// ListWebhookDeliveries lists the delivery history of a subscription,
+	// including dead-lettered deliveries.
+	ListWebhookDeliveries(context.Context, *connect.Request[v1.ListWebhookDeliveriesRequest]) (*connect.Response[v1.ListWebhookDeliveriesResponse], error)
+	// AdminListMedia lists the media of the caller's tenant partition.
+	//
+	// Errors:
+	//   - PERMISSION_DENIED: caller is not an admin of their partition
+	AdminListMedia(context.Context, *connect.Request[v1.AdminListMediaRequest]) (*connect.Response[v1.AdminListMediaResponse], error)
+	// AdminGetMedia gets the metadata, grants and a download URL of any media
+	// of the caller's tenant partition.
+	//
+	// Errors:
+	//   - PERMISSION_DENIED: caller is not an admin of their partition
+	//   - NOT_FOUND: media not found
+	AdminGetMedia(context.Context, *connect.Request[v1.AdminGetMediaRequest]) (*connect.Response[v1.AdminGetMediaResponse], error)
+	// AdminGrantAccess shares any file of the caller's tenant partition.
+	//
+	// Errors:
+	//   - PERMISSION_DENIED: caller is not an admin of their partition
+	//   - NOT_FOUND: media not found
+	AdminGrantAccess(context.Context, *connect.Request[v1.AdminGrantAccessRequest]) (*connect.Response[v1.AdminGrantAccessResponse], error)
+	// AdminTransferOwnership hands a file, a folder tree or all files of any
+	// owner in the caller's tenant partition to another profile.
+	//
+	// Errors:
+	//   - PERMISSION_DENIED: caller is not an admin of their partition
+	//   - NOT_FOUND: media not found
+	AdminTransferOwnership(context.Context, *connect.Request[v1.AdminTransferOwnershipRequest]) (*connect.Response[v1.AdminTransferOwnershipResponse], error)
+	// AdminDeleteContent deletes any media of the caller's tenant partition.
+	//
+	// Errors:
+	//   - PERMISSION_DENIED: caller is not an admin of their partition
+	//   - NOT_FOUND: media not found
+	AdminDeleteContent(context.Context, *connect.Request[v1.AdminDeleteContentRequest]) (*connect.Response[v1.AdminDeleteContentResponse], error)
+	// ExportUserData packages everything a profile owns into a zip archive.
+	//
+	// The export runs in the background. The archive holds the profile's
+	// files decrypted with their earlier versions under media/ and versions/,
+	// and metadata.json, grants.json and audit.json; it is stored as a new
+	// private media of the profile and a USER_DATA_EXPORTED lifecycle event
+	// announces it. Exports larger than the configured cap fail.
+	//
+	// Errors:
+	//   - INVALID_ARGUMENT: another profile was named without a reason
+	//   - PERMISSION_DENIED: another profile was named by a non-admin
+	ExportUserData(context.Context, *connect.Request[v1.ExportUserDataRequest]) (*connect.Response[v1.ExportUserDataResponse], error)
+	// GetUserDataExport reports the progress of an export requested by or for
+	// the caller.
+	GetUserDataExport(context.Context, *connect.Request[v1.GetUserDataExportRequest]) (*connect.Response[v1.GetUserDataExportResponse], error)
+	// EraseUserData permanently removes everything a profile owns.
+	//
+	// The erasure runs in the background. Files under a legal hold or an
+	// unexpired retention are kept and only lose the access granted on them;
+	// every other file is deleted with its thumbnails, derivatives, versions,
+	// retention, share links and stored content not shared with other media.
+	// Grants the profile received are revoked, its multipart uploads and jobs
+	// deleted, and its records on others' files anonymized. A DELETED
+	// lifecycle event is published for each erased file and the erasure ends
+	// with a signed report.
+	//
+	// Errors:
+	//   - INVALID_ARGUMENT: another profile was named without a reason
+	//   - PERMISSION_DENIED: another profile was named by a non-admin
+	EraseUserData(context.Context, *connect.Request[v1.EraseUserDataRequest]) (*connect.Response[v1.EraseUserDataResponse], error)
+	// GetUserDataErasure reports an erasure requested by or for the caller,
+	// with its signed report once it completed.
+	GetUserDataErasure(context.Context, *connect.Request[v1.GetUserDataErasureRequest]) (*connect.Response[v1.GetUserDataErasureResponse], error)
+}
+
+// NewFilesServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewFilesServiceHandler(svc FilesServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	filesServiceMethods := v1.File_files_v1_files_proto.Services().ByName("FilesService").Methods()
+	filesServiceUploadContentHandler := connect.NewClientStreamHandler(
+		FilesServiceUploadContentProcedure,
+		svc.UploadContent,
+		connect.WithSchema(filesServiceMethods.ByName("UploadContent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceCreateContentHandler := connect.NewUnaryHandler(
+		FilesServiceCreateContentProcedure,
+		svc.CreateContent,
+		connect.WithSchema(filesServiceMethods.ByName("CreateContent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceCreateMultipartUploadHandler := connect.NewUnaryHandler(
+		FilesServiceCreateMultipartUploadProcedure,
+		svc.CreateMultipartUpload,
+		connect.WithSchema(filesServiceMethods.ByName("CreateMultipartUpload")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetMultipartUploadHandler := connect.NewUnaryHandler(
+		FilesServiceGetMultipartUploadProcedure,
+		svc.GetMultipartUpload,
+		connect.WithSchema(filesServiceMethods.ByName("GetMultipartUpload")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceUploadMultipartPartHandler := connect.NewUnaryHandler(
+		FilesServiceUploadMultipartPartProcedure,
+		svc.UploadMultipartPart,
+		connect.WithSchema(filesServiceMethods.ByName("UploadMultipartPart")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceCompleteMultipartUploadHandler := connect.NewUnaryHandler(
+		FilesServiceCompleteMultipartUploadProcedure,
+		svc.CompleteMultipartUpload,
+		connect.WithSchema(filesServiceMethods.ByName("CompleteMultipartUpload")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceAbortMultipartUploadHandler := connect.NewUnaryHandler(
+		FilesServiceAbortMultipartUploadProcedure,
+		svc.AbortMultipartUpload,
+		connect.WithSchema(filesServiceMethods.ByName("AbortMultipartUpload")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceListMultipartPartsHandler := connect.NewUnaryHandler(
+		FilesServiceListMultipartPartsProcedure,
+		svc.ListMultipartParts,
+		connect.WithSchema(filesServiceMethods.ByName("ListMultipartParts")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceExtractArchiveHandler := connect.NewUnaryHandler(
+		FilesServiceExtractArchiveProcedure,
+		svc.ExtractArchive,
+		connect.WithSchema(filesServiceMethods.ByName("ExtractArchive")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetArchiveExtractionHandler := connect.NewUnaryHandler(
+		FilesServiceGetArchiveExtractionProcedure,
+		svc.GetArchiveExtraction,
+		connect.WithSchema(filesServiceMethods.ByName("GetArchiveExtraction")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceHeadContentHandler := connect.NewUnaryHandler(
+		FilesServiceHeadContentProcedure,
+		svc.HeadContent,
+		connect.WithSchema(filesServiceMethods.ByName("HeadContent")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServicePatchContentHandler := connect.NewUnaryHandler(
+		FilesServicePatchContentProcedure,
+		svc.PatchContent,
+		connect.WithSchema(filesServiceMethods.ByName("PatchContent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetSignedUploadUrlHandler := connect.NewUnaryHandler(
+		FilesServiceGetSignedUploadUrlProcedure,
+		svc.GetSignedUploadUrl,
+		connect.WithSchema(filesServiceMethods.ByName("GetSignedUploadUrl")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceFinalizeSignedUploadHandler := connect.NewUnaryHandler(
+		FilesServiceFinalizeSignedUploadProcedure,
+		svc.FinalizeSignedUpload,
+		connect.WithSchema(filesServiceMethods.ByName("FinalizeSignedUpload")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetSignedDownloadUrlHandler := connect.NewUnaryHandler(
+		FilesServiceGetSignedDownloadUrlProcedure,
+		svc.GetSignedDownloadUrl,
+		connect.WithSchema(filesServiceMethods.ByName("GetSignedDownloadUrl")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceDeleteContentHandler := connect.NewUnaryHandler(
+		FilesServiceDeleteContentProcedure,
+		svc.DeleteContent,
+		connect.WithSchema(filesServiceMethods.ByName("DeleteContent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetContentHandler := connect.NewUnaryHandler(
+		FilesServiceGetContentProcedure,
+		svc.GetContent,
+		connect.WithSchema(filesServiceMethods.ByName("GetContent")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetContentOverrideNameHandler := connect.NewUnaryHandler(
+		FilesServiceGetContentOverrideNameProcedure,
+		svc.GetContentOverrideName,
+		connect.WithSchema(filesServiceMethods.ByName("GetContentOverrideName")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceDownloadContentHandler := connect.NewServerStreamHandler(
+		FilesServiceDownloadContentProcedure,
+		svc.DownloadContent,
+		connect.WithSchema(filesServiceMethods.ByName("DownloadContent")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceDownloadContentRangeHandler := connect.NewServerStreamHandler(
+		FilesServiceDownloadContentRangeProcedure,
+		svc.DownloadContentRange,
+		connect.WithSchema(filesServiceMethods.ByName("DownloadContentRange")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceDownloadArchiveHandler := connect.NewServerStreamHandler(
+		FilesServiceDownloadArchiveProcedure,
+		svc.DownloadArchive,
+		connect.WithSchema(filesServiceMethods.ByName("DownloadArchive")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetContentThumbnailHandler := connect.NewUnaryHandler(
+		FilesServiceGetContentThumbnailProcedure,
+		svc.GetContentThumbnail,
+		connect.WithSchema(filesServiceMethods.ByName("GetContentThumbnail")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetThumbnailStatusHandler := connect.NewUnaryHandler(
+		FilesServiceGetThumbnailStatusProcedure,
+		svc.GetThumbnailStatus,
+		connect.WithSchema(filesServiceMethods.ByName("GetThumbnailStatus")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceRequeueThumbnailHandler := connect.NewUnaryHandler(
+		FilesServiceRequeueThumbnailProcedure,
+		svc.RequeueThumbnail,
+		connect.WithSchema(filesServiceMethods.ByName("RequeueThumbnail")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceRegenerateThumbnailsHandler := connect.NewUnaryHandler(
+		FilesServiceRegenerateThumbnailsProcedure,
+		svc.RegenerateThumbnails,
+		connect.WithSchema(filesServiceMethods.ByName("RegenerateThumbnails")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetThumbnailRegenerationHandler := connect.NewUnaryHandler(
+		FilesServiceGetThumbnailRegenerationProcedure,
+		svc.GetThumbnailRegeneration,
+		connect.WithSchema(filesServiceMethods.ByName("GetThumbnailRegeneration")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceReconcileOwnershipHandler := connect.NewUnaryHandler(
+		FilesServiceReconcileOwnershipProcedure,
+		svc.ReconcileOwnership,
+		connect.WithSchema(filesServiceMethods.ByName("ReconcileOwnership")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetUrlPreviewHandler := connect.NewUnaryHandler(
+		FilesServiceGetUrlPreviewProcedure,
+		svc.GetUrlPreview,
+		connect.WithSchema(filesServiceMethods.ByName("GetUrlPreview")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetConfigHandler := connect.NewUnaryHandler(
+		FilesServiceGetConfigProcedure,
+		svc.GetConfig,
+		connect.WithSchema(filesServiceMethods.ByName("GetConfig")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceSearchMediaHandler := connect.NewUnaryHandler(
+		FilesServiceSearchMediaProcedure,
+		svc.SearchMedia,
+		connect.WithSchema(filesServiceMethods.ByName("SearchMedia")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceBatchGetContentHandler := connect.NewUnaryHandler(
+		FilesServiceBatchGetContentProcedure,
+		svc.BatchGetContent,
+		connect.WithSchema(filesServiceMethods.ByName("BatchGetContent")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceBatchDeleteContentHandler := connect.NewUnaryHandler(
+		FilesServiceBatchDeleteContentProcedure,
+		svc.BatchDeleteContent,
+		connect.WithSchema(filesServiceMethods.ByName("BatchDeleteContent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGrantAccessHandler := connect.NewUnaryHandler(
+		FilesServiceGrantAccessProcedure,
+		svc.GrantAccess,
+		connect.WithSchema(filesServiceMethods.ByName("GrantAccess")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceRevokeAccessHandler := connect.NewUnaryHandler(
+		FilesServiceRevokeAccessProcedure,
+		svc.RevokeAccess,
+		connect.WithSchema(filesServiceMethods.ByName("RevokeAccess")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceListAccessHandler := connect.NewUnaryHandler(
+		FilesServiceListAccessProcedure,
+		svc.ListAccess,
+		connect.WithSchema(filesServiceMethods.ByName("ListAccess")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceListSharedWithMeHandler := connect.NewUnaryHandler(
+		FilesServiceListSharedWithMeProcedure,
+		svc.ListSharedWithMe,
+		connect.WithSchema(filesServiceMethods.ByName("ListSharedWithMe")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceTransferOwnershipHandler := connect.NewUnaryHandler(
+		FilesServiceTransferOwnershipProcedure,
+		svc.TransferOwnership,
+		connect.WithSchema(filesServiceMethods.ByName("TransferOwnership")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceCreateShareLinkHandler := connect.NewUnaryHandler(
+		FilesServiceCreateShareLinkProcedure,
+		svc.CreateShareLink,
+		connect.WithSchema(filesServiceMethods.ByName("CreateShareLink")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceListShareLinksHandler := connect.NewUnaryHandler(
+		FilesServiceListShareLinksProcedure,
+		svc.ListShareLinks,
+		connect.WithSchema(filesServiceMethods.ByName("ListShareLinks")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceRevokeShareLinkHandler := connect.NewUnaryHandler(
+		FilesServiceRevokeShareLinkProcedure,
+		svc.RevokeShareLink,
+		connect.WithSchema(filesServiceMethods.ByName("RevokeShareLink")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetVersionsHandler := connect.NewUnaryHandler(
+		FilesServiceGetVersionsProcedure,
+		svc.GetVersions,
+		connect.WithSchema(filesServiceMethods.ByName("GetVersions")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceRestoreVersionHandler := connect.NewUnaryHandler(
+		FilesServiceRestoreVersionProcedure,
+		svc.RestoreVersion,
+		connect.WithSchema(filesServiceMethods.ByName("RestoreVersion")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceSetRetentionPolicyHandler := connect.NewUnaryHandler(
+		FilesServiceSetRetentionPolicyProcedure,
+		svc.SetRetentionPolicy,
+		connect.WithSchema(filesServiceMethods.ByName("SetRetentionPolicy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetRetentionPolicyHandler := connect.NewUnaryHandler(
+		FilesServiceGetRetentionPolicyProcedure,
+		svc.GetRetentionPolicy,
+		connect.WithSchema(filesServiceMethods.ByName("GetRetentionPolicy")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceListRetentionPoliciesHandler := connect.NewUnaryHandler(
+		FilesServiceListRetentionPoliciesProcedure,
+		svc.ListRetentionPolicies,
+		connect.WithSchema(filesServiceMethods.ByName("ListRetentionPolicies")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetUserUsageHandler := connect.NewUnaryHandler(
+		FilesServiceGetUserUsageProcedure,
+		svc.GetUserUsage,
+		connect.WithSchema(filesServiceMethods.ByName("GetUserUsage")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetStorageStatsHandler := connect.NewUnaryHandler(
+		FilesServiceGetStorageStatsProcedure,
+		svc.GetStorageStats,
+		connect.WithSchema(filesServiceMethods.ByName("GetStorageStats")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceCreateWebhookSubscriptionHandler := connect.NewUnaryHandler(
+		FilesServiceCreateWebhookSubscriptionProcedure,
+		svc.CreateWebhookSubscription,
+		connect.WithSchema(filesServiceMethods.ByName("CreateWebhookSubscription")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceListWebhookSubscriptionsHandler := connect.NewUnaryHandler(
+		FilesServiceListWebhookSubscriptionsProcedure,
+		svc.ListWebhookSubscriptions,
+		connect.WithSchema(filesServiceMethods.ByName("ListWebhookSubscriptions")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceDeleteWebhookSubscriptionHandler := connect.NewUnaryHandler(
+		FilesServiceDeleteWebhookSubscriptionProcedure,
+		svc.DeleteWebhookSubscription,
+		connect.WithSchema(filesServiceMethods.ByName("DeleteWebhookSubscription")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceListWebhookDeliveriesHandler := connect.NewUnaryHandler(
+		FilesServiceListWebhookDeliveriesProcedure,
+		svc.ListWebhookDeliveries,
+		connect.WithSchema(filesServiceMethods.ByName("ListWebhookDeliveries")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceAdminListMediaHandler := connect.NewUnaryHandler(
+		FilesServiceAdminListMediaProcedure,
+		svc.AdminListMedia,
+		connect.WithSchema(filesServiceMethods.ByName("AdminListMedia")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceAdminGetMediaHandler := connect.NewUnaryHandler(
+		FilesServiceAdminGetMediaProcedure,
+		svc.AdminGetMedia,
+		connect.WithSchema(filesServiceMethods.ByName("AdminGetMedia")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceAdminGrantAccessHandler := connect.NewUnaryHandler(
+		FilesServiceAdminGrantAccessProcedure,
+		svc.AdminGrantAccess,
+		connect.WithSchema(filesServiceMethods.ByName("AdminGrantAccess")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceAdminTransferOwnershipHandler := connect.NewUnaryHandler(
+		FilesServiceAdminTransferOwnershipProcedure,
+		svc.AdminTransferOwnership,
+		connect.WithSchema(filesServiceMethods.ByName("AdminTransferOwnership")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceAdminDeleteContentHandler := connect.NewUnaryHandler(
+		FilesServiceAdminDeleteContentProcedure,
+		svc.AdminDeleteContent,
+		connect.WithSchema(filesServiceMethods.ByName("AdminDeleteContent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceExportUserDataHandler := connect.NewUnaryHandler(
+		FilesServiceExportUserDataProcedure,
+		svc.ExportUserData,
+		connect.WithSchema(filesServiceMethods.ByName("ExportUserData")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetUserDataExportHandler := connect.NewUnaryHandler(
+		FilesServiceGetUserDataExportProcedure,
+		svc.GetUserDataExport,
+		connect.WithSchema(filesServiceMethods.ByName("GetUserDataExport")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceEraseUserDataHandler := connect.NewUnaryHandler(
+		FilesServiceEraseUserDataProcedure,
+		svc.EraseUserData,
+		connect.WithSchema(filesServiceMethods.ByName("EraseUserData")),
+		connect.WithHandlerOptions(opts...),
+	)
+	filesServiceGetUserDataErasureHandler := connect.NewUnaryHandler(
+		FilesServiceGetUserDataErasureProcedure,
+		svc.GetUserDataErasure,
+		connect.WithSchema(filesServiceMethods.ByName("GetUserDataErasure")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/files.v1.FilesService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case FilesServiceUploadContentProcedure:
+			filesServiceUploadContentHandler.ServeHTTP(w, r)
+		case FilesServiceCreateContentProcedure:
+			filesServiceCreateContentHandler.ServeHTTP(w, r)
+		case FilesServiceCreateMultipartUploadProcedure:
+			filesServiceCreateMultipartUploadHandler.ServeHTTP(w, r)
+		case FilesServiceGetMultipartUploadProcedure:
+			filesServiceGetMultipartUploadHandler.ServeHTTP(w, r)
+		case FilesServiceUploadMultipartPartProcedure:
+			filesServiceUploadMultipartPartHandler.ServeHTTP(w, r)
+		case FilesServiceCompleteMultipartUploadProcedure:
+			filesServiceCompleteMultipartUploadHandler.ServeHTTP(w, r)
+		case FilesServiceAbortMultipartUploadProcedure:
+			filesServiceAbortMultipartUploadHandler.ServeHTTP(w, r)
+		case FilesServiceListMultipartPartsProcedure:
+			filesServiceListMultipartPartsHandler.ServeHTTP(w, r)
+		case FilesServiceExtractArchiveProcedure:
+			filesServiceExtractArchiveHandler.ServeHTTP(w, r)
+		case FilesServiceGetArchiveExtractionProcedure:
+			filesServiceGetArchiveExtractionHandler.ServeHTTP(w, r)
+		case FilesServiceHeadContentProcedure:
+			filesServiceHeadContentHandler.ServeHTTP(w, r)
+		case FilesServicePatchContentProcedure:
+			filesServicePatchContentHandler.ServeHTTP(w, r)
+		case FilesServiceGetSignedUploadUrlProcedure:
+			filesServiceGetSignedUploadUrlHandler.ServeHTTP(w, r)
+		case FilesServiceFinalizeSignedUploadProcedure:
+			filesServiceFinalizeSignedUploadHandler.ServeHTTP(w, r)
+		case FilesServiceGetSignedDownloadUrlProcedure:
+			filesServiceGetSignedDownloadUrlHandler.ServeHTTP(w, r)
+		case FilesServiceDeleteContentProcedure:
+			filesServiceDeleteContentHandler.ServeHTTP(w, r)
+		case FilesServiceGetContentProcedure:
+			filesServiceGetContentHandler.ServeHTTP(w, r)
+		case FilesServiceGetContentOverrideNameProcedure:
+			filesServiceGetContentOverrideNameHandler.ServeHTTP(w, r)
+		case FilesServiceDownloadContentProcedure:
+			filesServiceDownloadContentHandler.ServeHTTP(w, r)
+		case FilesServiceDownloadContentRangeProcedure:
+			filesServiceDownloadContentRangeHandler.ServeHTTP(w, r)
+		case FilesServiceDownloadArchiveProcedure:
+			filesServiceDownloadArchiveHandler.ServeHTTP(w, r)
+		case FilesServiceGetContentThumbnailProcedure:
+			filesServiceGetContentThumbnailHandler.ServeHTTP(w, r)
+		case FilesServiceGetThumbnailStatusProcedure:
+			filesServiceGetThumbnailStatusHandler.ServeHTTP(w, r)
+		case FilesServiceRequeueThumbnailProcedure:
+			filesServiceRequeueThumbnailHandler.ServeHTTP(w, r)
+		case FilesServiceRegenerateThumbnailsProcedure:
+			filesServiceRegenerateThumbnailsHandler.ServeHTTP(w, r)
+		case FilesServiceGetThumbnailRegenerationProcedure:
+			filesServiceGetThumbnailRegenerationHandler.ServeHTTP(w, r)
+		case FilesServiceReconcileOwnershipProcedure:
+			filesServiceReconcileOwnershipHandler.ServeHTTP(w, r)
+		case FilesServiceGetUrlPreviewProcedure:
+			filesServiceGetUrlPreviewHandler.ServeHTTP(w, r)
+		case FilesServiceGetConfigProcedure:
+			filesServiceGetConfigHandler.ServeHTTP(w, r)
+		case FilesServiceSearchMediaProcedure:
+			filesServiceSearchMediaHandler.ServeHTTP(w, r)
+		case FilesServiceBatchGetContentProcedure:
+			filesServiceBatchGetContentHandler.ServeHTTP(w, r)
+		case FilesServiceBatchDeleteContentProcedure:
+			filesServiceBatchDeleteContentHandler.ServeHTTP(w, r)
+		case FilesServiceGrantAccessProcedure:
+			filesServiceGrantAccessHandler.ServeHTTP(w, r)
+		case FilesServiceRevokeAccessProcedure:
+			filesServiceRevokeAccessHandler.ServeHTTP(w, r)
+		case FilesServiceListAccessProcedure:
+			filesServiceListAccessHandler.ServeHTTP(w, r)
+		case FilesServiceListSharedWithMeProcedure:
+			filesServiceListSharedWithMeHandler.ServeHTTP(w, r)
+		case FilesServiceTransferOwnershipProcedure:
+			filesServiceTransferOwnershipHandler.ServeHTTP(w, r)
+		case FilesServiceCreateShareLinkProcedure:
+			filesServiceCreateShareLinkHandler.ServeHTTP(w, r)
+		case FilesServiceListShareLinksProcedure:
+			filesServiceListShareLinksHandler.ServeHTTP(w, r)
+		case FilesServiceRevokeShareLinkProcedure:
+			filesServiceRevokeShareLinkHandler.ServeHTTP(w, r)
+		case FilesServiceGetVersionsProcedure:
+			filesServiceGetVersionsHandler.ServeHTTP(w, r)
+		case FilesServiceRestoreVersionProcedure:
+			filesServiceRestoreVersionHandler.ServeHTTP(w, r)
+		case FilesServiceSetRetentionPolicyProcedure:
+			filesServiceSetRetentionPolicyHandler.ServeHTTP(w, r)
+		case FilesServiceGetRetentionPolicyProcedure:
+			filesServiceGetRetentionPolicyHandler.ServeHTTP(w, r)
+		case FilesServiceListRetentionPoliciesProcedure:
+			filesServiceListRetentionPoliciesHandler.ServeHTTP(w, r)
+		case FilesServiceGetUserUsageProcedure:
+			filesServiceGetUserUsageHandler.ServeHTTP(w, r)
+		case FilesServiceGetStorageStatsProcedure:
+			filesServiceGetStorageStatsHandler.ServeHTTP(w, r)
+		case FilesServiceCreateWebhookSubscriptionProcedure:
+			filesServiceCreateWebhookSubscriptionHandler.ServeHTTP(w, r)
+		case FilesServiceListWebhookSubscriptionsProcedure:
+			filesServiceListWebhookSubscriptionsHandler.ServeHTTP(w, r)
+		case FilesServiceDeleteWebhookSubscriptionProcedure:
+			filesServiceDeleteWebhookSubscriptionHandler.ServeHTTP(w, r)
+		case FilesServiceListWebhookDeliveriesProcedure:
+			filesServiceListWebhookDeliveriesHandler.ServeHTTP(w, r)
+		case FilesServiceAdminListMediaProcedure:
+			filesServiceAdminListMediaHandler.ServeHTTP(w, r)
+		case FilesServiceAdminGetMediaProcedure:
+			filesServiceAdminGetMediaHandler.ServeHTTP(w, r)
+		case FilesServiceAdminGrantAccessProcedure:
+			filesServiceAdminGrantAccessHandler.ServeHTTP(w, r)
+		case FilesServiceAdminTransferOwnershipProcedure:
+			filesServiceAdminTransferOwnershipHandler.ServeHTTP(w, r)
+		case FilesServiceAdminDeleteContentProcedure:
+			filesServiceAdminDeleteContentHandler.ServeHTTP(w, r)
+		case FilesServiceExportUserDataProcedure:
+			filesServiceExportUserDataHandler.ServeHTTP(w, r)
+		case FilesServiceGetUserDataExportProcedure:
+			filesServiceGetUserDataExportHandler.ServeHTTP(w, r)
+		case FilesServiceEraseUserDataProcedure:
+			filesServiceEraseUserDataHandler.ServeHTTP(w, r)
+		case FilesServiceGetUserDataErasureProcedure:
+			filesServiceGetUserDataErasureHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedFilesServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedFilesServiceHandler struct{}
+
+func (UnimplementedFilesServiceHandler) UploadContent(context.Context, *connect.ClientStream[v1.UploadContentRequest]) (*connect.Response[v1.UploadContentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.UploadContent is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) CreateContent(context.Context, *connect.Request[v1.CreateContentRequest]) (*connect.Response[v1.CreateContentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.CreateContent is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) CreateMultipartUpload(context.Context, *connect.Request[v1.CreateMultipartUploadRequest]) (*connect.Response[v1.CreateMultipartUploadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.CreateMultipartUpload is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetMultipartUpload(context.Context, *connect.Request[v1.GetMultipartUploadRequest]) (*connect.Response[v1.GetMultipartUploadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetMultipartUpload is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) UploadMultipartPart(context.Context, *connect.Request[v1.UploadMultipartPartRequest]) (*connect.Response[v1.UploadMultipartPartResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.UploadMultipartPart is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) CompleteMultipartUpload(context.Context, *connect.Request[v1.CompleteMultipartUploadRequest]) (*connect.Response[v1.CompleteMultipartUploadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.CompleteMultipartUpload is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) AbortMultipartUpload(context.Context, *connect.Request[v1.AbortMultipartUploadRequest]) (*connect.Response[v1.AbortMultipartUploadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.AbortMultipartUpload is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) ListMultipartParts(context.Context, *connect.Request[v1.ListMultipartPartsRequest]) (*connect.Response[v1.ListMultipartPartsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.ListMultipartParts is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) ExtractArchive(context.Context, *connect.Request[v1.ExtractArchiveRequest]) (*connect.Response[v1.ExtractArchiveResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.ExtractArchive is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetArchiveExtraction(context.Context, *connect.Request[v1.GetArchiveExtractionRequest]) (*connect.Response[v1.GetArchiveExtractionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetArchiveExtraction is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) HeadContent(context.Context, *connect.Request[v1.HeadContentRequest]) (*connect.Response[v1.HeadContentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.HeadContent is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) PatchContent(context.Context, *connect.Request[v1.PatchContentRequest]) (*connect.Response[v1.PatchContentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.PatchContent is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetSignedUploadUrl(context.Context, *connect.Request[v1.GetSignedUploadUrlRequest]) (*connect.Response[v1.GetSignedUploadUrlResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetSignedUploadUrl is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) FinalizeSignedUpload(context.Context, *connect.Request[v1.FinalizeSignedUploadRequest]) (*connect.Response[v1.FinalizeSignedUploadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.FinalizeSignedUpload is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetSignedDownloadUrl(context.Context, *connect.Request[v1.GetSignedDownloadUrlRequest]) (*connect.Response[v1.GetSignedDownloadUrlResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetSignedDownloadUrl is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) DeleteContent(context.Context, *connect.Request[v1.DeleteContentRequest]) (*connect.Response[v1.DeleteContentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.DeleteContent is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetContent(context.Context, *connect.Request[v1.GetContentRequest]) (*connect.Response[v1.GetContentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetContent is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetContentOverrideName(context.Context, *connect.Request[v1.GetContentOverrideNameRequest]) (*connect.Response[v1.GetContentOverrideNameResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetContentOverrideName is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) DownloadContent(context.Context, *connect.Request[v1.DownloadContentRequest], *connect.ServerStream[v1.DownloadContentResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.DownloadContent is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) DownloadContentRange(context.Context, *connect.Request[v1.DownloadContentRangeRequest], *connect.ServerStream[v1.DownloadContentRangeResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.DownloadContentRange is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) DownloadArchive(context.Context, *connect.Request[v1.DownloadArchiveRequest], *connect.ServerStream[v1.DownloadArchiveResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.DownloadArchive is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetContentThumbnail(context.Context, *connect.Request[v1.GetContentThumbnailRequest]) (*connect.Response[v1.GetContentThumbnailResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetContentThumbnail is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetThumbnailStatus(context.Context, *connect.Request[v1.GetThumbnailStatusRequest]) (*connect.Response[v1.GetThumbnailStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetThumbnailStatus is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) RequeueThumbnail(context.Context, *connect.Request[v1.RequeueThumbnailRequest]) (*connect.Response[v1.RequeueThumbnailResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.RequeueThumbnail is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) RegenerateThumbnails(context.Context, *connect.Request[v1.RegenerateThumbnailsRequest]) (*connect.Response[v1.RegenerateThumbnailsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.RegenerateThumbnails is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetThumbnailRegeneration(context.Context, *connect.Request[v1.GetThumbnailRegenerationRequest]) (*connect.Response[v1.GetThumbnailRegenerationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetThumbnailRegeneration is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) ReconcileOwnership(context.Context, *connect.Request[v1.ReconcileOwnershipRequest]) (*connect.Response[v1.ReconcileOwnershipResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.ReconcileOwnership is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetUrlPreview(context.Context, *connect.Request[v1.GetUrlPreviewRequest]) (*connect.Response[v1.GetUrlPreviewResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetUrlPreview is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetConfig(context.Context, *connect.Request[v1.GetConfigRequest]) (*connect.Response[v1.GetConfigResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetConfig is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) SearchMedia(context.Context, *connect.Request[v1.SearchMediaRequest]) (*connect.Response[v1.SearchMediaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.SearchMedia is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) BatchGetContent(context.Context, *connect.Request[v1.BatchGetContentRequest]) (*connect.Response[v1.BatchGetContentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.BatchGetContent is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) BatchDeleteContent(context.Context, *connect.Request[v1.BatchDeleteContentRequest]) (*connect.Response[v1.BatchDeleteContentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.BatchDeleteContent is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GrantAccess(context.Context, *connect.Request[v1.GrantAccessRequest]) (*connect.Response[v1.GrantAccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GrantAccess is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) RevokeAccess(context.Context, *connect.Request[v1.RevokeAccessRequest]) (*connect.Response[v1.RevokeAccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.RevokeAccess is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) ListAccess(context.Context, *connect.Request[v1.ListAccessRequest]) (*connect.Response[v1.ListAccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.ListAccess is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) ListSharedWithMe(context.Context, *connect.Request[v1.ListSharedWithMeRequest]) (*connect.Response[v1.ListSharedWithMeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.ListSharedWithMe is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) TransferOwnership(context.Context, *connect.Request[v1.TransferOwnershipRequest]) (*connect.Response[v1.TransferOwnershipResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.TransferOwnership is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) CreateShareLink(context.Context, *connect.Request[v1.CreateShareLinkRequest]) (*connect.Response[v1.CreateShareLinkResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.CreateShareLink is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) ListShareLinks(context.Context, *connect.Request[v1.ListShareLinksRequest]) (*connect.Response[v1.ListShareLinksResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.ListShareLinks is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) RevokeShareLink(context.Context, *connect.Request[v1.RevokeShareLinkRequest]) (*connect.Response[v1.RevokeShareLinkResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.RevokeShareLink is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetVersions(context.Context, *connect.Request[v1.GetVersionsRequest]) (*connect.Response[v1.GetVersionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetVersions is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) RestoreVersion(context.Context, *connect.Request[v1.RestoreVersionRequest]) (*connect.Response[v1.RestoreVersionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.RestoreVersion is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) SetRetentionPolicy(context.Context, *connect.Request[v1.SetRetentionPolicyRequest]) (*connect.Response[v1.SetRetentionPolicyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.SetRetentionPolicy is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetRetentionPolicy(context.Context, *connect.Request[v1.GetRetentionPolicyRequest]) (*connect.Response[v1.GetRetentionPolicyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetRetentionPolicy is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) ListRetentionPolicies(context.Context, *connect.Request[v1.ListRetentionPoliciesRequest]) (*connect.Response[v1.ListRetentionPoliciesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.ListRetentionPolicies is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetUserUsage(context.Context, *connect.Request[v1.GetUserUsageRequest]) (*connect.Response[v1.GetUserUsageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetUserUsage is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetStorageStats(context.Context, *connect.Request[v1.GetStorageStatsRequest]) (*connect.Response[v1.GetStorageStatsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetStorageStats is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) CreateWebhookSubscription(context.Context, *connect.Request[v1.CreateWebhookSubscriptionRequest]) (*connect.Response[v1.CreateWebhookSubscriptionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.CreateWebhookSubscription is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) ListWebhookSubscriptions(context.Context, *connect.Request[v1.ListWebhookSubscriptionsRequest]) (*connect.Response[v1.ListWebhookSubscriptionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.ListWebhookSubscriptions is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) DeleteWebhookSubscription(context.Context, *connect.Request[v1.DeleteWebhookSubscriptionRequest]) (*connect.Response[v1.DeleteWebhookSubscriptionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.DeleteWebhookSubscription is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) ListWebhookDeliveries(context.Context, *connect.Request[v1.ListWebhookDeliveriesRequest]) (*connect.Response[v1.ListWebhookDeliveriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.ListWebhookDeliveries is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) AdminListMedia(context.Context, *connect.Request[v1.AdminListMediaRequest]) (*connect.Response[v1.AdminListMediaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.AdminListMedia is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) AdminGetMedia(context.Context, *connect.Request[v1.AdminGetMediaRequest]) (*connect.Response[v1.AdminGetMediaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.AdminGetMedia is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) AdminGrantAccess(context.Context, *connect.Request[v1.AdminGrantAccessRequest]) (*connect.Response[v1.AdminGrantAccessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.AdminGrantAccess is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) AdminTransferOwnership(context.Context, *connect.Request[v1.AdminTransferOwnershipRequest]) (*connect.Response[v1.AdminTransferOwnershipResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.AdminTransferOwnership is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) AdminDeleteContent(context.Context, *connect.Request[v1.AdminDeleteContentRequest]) (*connect.Response[v1.AdminDeleteContentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.AdminDeleteContent is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) ExportUserData(context.Context, *connect.Request[v1.ExportUserDataRequest]) (*connect.Response[v1.ExportUserDataResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.ExportUserData is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetUserDataExport(context.Context, *connect.Request[v1.GetUserDataExportRequest]) (*connect.Response[v1.GetUserDataExportResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetUserDataExport is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) EraseUserData(context.Context, *connect.Request[v1.EraseUserDataRequest]) (*connect.Response[v1.EraseUserDataResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.EraseUserData is not implemented"))
+}
+
+func (UnimplementedFilesServiceHandler) GetUserDataErasure(context.Context, *connect.Request[v1.GetUserDataErasureRequest]) (*connect.Response[v1.GetUserDataErasureResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("files.v1.FilesService.GetUserDataErasure is not implemented"))
+}
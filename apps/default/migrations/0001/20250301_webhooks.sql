@@ -0,0 +1,47 @@
+-- Webhook subscriptions for file lifecycle events
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id VARCHAR(50) PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    modified_at TIMESTAMPTZ,
+    version INTEGER DEFAULT 0,
+    tenant_id VARCHAR(50),
+    partition_id VARCHAR(50),
+    access_id VARCHAR(50),
+    deleted_at TIMESTAMPTZ,
+
+    url TEXT NOT NULL,
+    description TEXT,
+    event_types TEXT,
+    sealed_secret TEXT NOT NULL,
+    active BOOLEAN DEFAULT TRUE,
+    created_by TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_active ON webhook_subscriptions (active);
+
+-- Delivery history, including dead-lettered deliveries
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id VARCHAR(50) PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    modified_at TIMESTAMPTZ,
+    version INTEGER DEFAULT 0,
+    tenant_id VARCHAR(50),
+    partition_id VARCHAR(50),
+    access_id VARCHAR(50),
+    deleted_at TIMESTAMPTZ,
+
+    subscription_id VARCHAR(50) NOT NULL,
+    event_id VARCHAR(50) NOT NULL,
+    event_type TEXT,
+    payload TEXT,
+    state VARCHAR(20) DEFAULT 'pending',
+    attempts INTEGER DEFAULT 0,
+    last_status_code INTEGER,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ,
+    delivered_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id ON webhook_deliveries (subscription_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (state, next_attempt_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries (subscription_id, event_id);
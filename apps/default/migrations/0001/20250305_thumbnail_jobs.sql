@@ -0,0 +1,21 @@
+-- Thumbnail generation jobs, retried with backoff until dead-lettered
+CREATE TABLE IF NOT EXISTS thumbnail_jobs (
+    id VARCHAR(50) PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    modified_at TIMESTAMPTZ,
+    version INTEGER DEFAULT 0,
+    tenant_id VARCHAR(50),
+    partition_id VARCHAR(50),
+    access_id VARCHAR(50),
+    deleted_at TIMESTAMPTZ,
+
+    media_id VARCHAR(50) NOT NULL,
+    state VARCHAR(20) DEFAULT 'pending',
+    attempts INTEGER DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_thumbnail_jobs_media_id ON thumbnail_jobs (media_id);
+CREATE INDEX IF NOT EXISTS idx_thumbnail_jobs_due ON thumbnail_jobs (state, next_attempt_at);
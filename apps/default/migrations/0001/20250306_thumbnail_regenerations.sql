@@ -0,0 +1,28 @@
+-- Bulk thumbnail regeneration runs, leased by the worker walking them
+CREATE TABLE IF NOT EXISTS thumbnail_regenerations (
+    id VARCHAR(50) PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    modified_at TIMESTAMPTZ,
+    version INTEGER DEFAULT 0,
+    tenant_id VARCHAR(50),
+    partition_id VARCHAR(50),
+    access_id VARCHAR(50),
+    deleted_at TIMESTAMPTZ,
+
+    owner_id TEXT,
+    content_type TEXT,
+    created_after TIMESTAMPTZ,
+    created_before TIMESTAMPTZ,
+    remove_stale BOOLEAN DEFAULT false,
+    rate_per_second INTEGER DEFAULT 0,
+    state VARCHAR(20) DEFAULT 'running',
+    checkpoint VARCHAR(50),
+    scanned BIGINT DEFAULT 0,
+    enqueued BIGINT DEFAULT 0,
+    removed BIGINT DEFAULT 0,
+    last_error TEXT,
+    completed_at TIMESTAMPTZ,
+    lease_until TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_thumbnail_regenerations_lease ON thumbnail_regenerations (state, lease_until);
@@ -0,0 +1,5 @@
+-- Folders media is filed under, listed per owner
+ALTER TABLE media_metadata ADD COLUMN IF NOT EXISTS folder TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_media_metadata_folder ON media_metadata (folder);
+CREATE INDEX IF NOT EXISTS idx_media_metadata_owner_folder ON media_metadata (owner_id, folder);
@@ -0,0 +1,26 @@
+-- Archive extractions unpacking an uploaded zip into a folder
+CREATE TABLE IF NOT EXISTS archive_extractions (
+    id VARCHAR(50) PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    modified_at TIMESTAMPTZ,
+    version INTEGER DEFAULT 0,
+    tenant_id VARCHAR(50),
+    partition_id VARCHAR(50),
+    access_id VARCHAR(50),
+    deleted_at TIMESTAMPTZ,
+
+    owner_id TEXT,
+    source_media_id VARCHAR(50),
+    folder TEXT,
+    state VARCHAR(20) DEFAULT 'running',
+    entries BIGINT DEFAULT 0,
+    extracted BIGINT DEFAULT 0,
+    skipped BIGINT DEFAULT 0,
+    failed BIGINT DEFAULT 0,
+    extracted_bytes BIGINT DEFAULT 0,
+    failures JSONB,
+    last_error TEXT,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_archive_extractions_owner_id ON archive_extractions (owner_id);
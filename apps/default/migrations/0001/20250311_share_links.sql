@@ -0,0 +1,28 @@
+-- Public share links, resolved by the hash of their token
+CREATE TABLE IF NOT EXISTS share_links (
+    id VARCHAR(50) PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    modified_at TIMESTAMPTZ,
+    version INTEGER DEFAULT 0,
+    tenant_id VARCHAR(50),
+    partition_id VARCHAR(50),
+    access_id VARCHAR(50),
+    deleted_at TIMESTAMPTZ,
+
+    media_id VARCHAR(50),
+    owner_id TEXT,
+    token_hash VARCHAR(64),
+    password_hash TEXT,
+    permission VARCHAR(20) DEFAULT 'view',
+    expires_at TIMESTAMPTZ,
+    max_downloads INTEGER DEFAULT 0,
+    download_count INTEGER DEFAULT 0,
+    revoked_at TIMESTAMPTZ,
+    last_accessed_at TIMESTAMPTZ,
+    failed_attempts INTEGER DEFAULT 0,
+    locked_until TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_share_links_token_hash ON share_links (token_hash);
+CREATE INDEX IF NOT EXISTS idx_share_links_media_id ON share_links (media_id);
+CREATE INDEX IF NOT EXISTS idx_share_links_owner_id ON share_links (owner_id);
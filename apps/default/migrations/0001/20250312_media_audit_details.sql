@@ -0,0 +1,2 @@
+-- Details recorded with audited actions, such as share link access
+ALTER TABLE media_audit ADD COLUMN IF NOT EXISTS details JSONB;
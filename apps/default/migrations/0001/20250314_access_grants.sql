@@ -0,0 +1,22 @@
+-- Records of access granted in Keto, keeping what Keto cannot such as expiry
+CREATE TABLE IF NOT EXISTS access_grants (
+    id VARCHAR(50) PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    modified_at TIMESTAMPTZ,
+    version INTEGER DEFAULT 0,
+    tenant_id VARCHAR(50),
+    partition_id VARCHAR(50),
+    access_id VARCHAR(50),
+    deleted_at TIMESTAMPTZ,
+
+    object_namespace VARCHAR(50),
+    object_id TEXT,
+    relation VARCHAR(50),
+    principal_kind VARCHAR(20),
+    principal_id TEXT,
+    granted_by TEXT,
+    expires_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_grant_object ON access_grants (object_namespace, object_id);
+CREATE INDEX IF NOT EXISTS idx_access_grants_expires_at ON access_grants (expires_at);
@@ -0,0 +1,24 @@
+-- User data exports packaging everything a profile owns into one archive
+CREATE TABLE IF NOT EXISTS user_data_exports (
+    id VARCHAR(50) PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    modified_at TIMESTAMPTZ,
+    version INTEGER DEFAULT 0,
+    tenant_id VARCHAR(50),
+    partition_id VARCHAR(50),
+    access_id VARCHAR(50),
+    deleted_at TIMESTAMPTZ,
+
+    profile_id TEXT,
+    requested_by TEXT,
+    state VARCHAR(20) DEFAULT 'running',
+    total_files BIGINT DEFAULT 0,
+    exported_files BIGINT DEFAULT 0,
+    failed_files BIGINT DEFAULT 0,
+    exported_bytes BIGINT DEFAULT 0,
+    result_media_id VARCHAR(50),
+    last_error TEXT,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_data_exports_profile_id ON user_data_exports (profile_id);
@@ -0,0 +1,22 @@
+-- User data erasures removing or anonymizing everything a profile owns
+CREATE TABLE IF NOT EXISTS user_data_erasures (
+    id VARCHAR(50) PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    modified_at TIMESTAMPTZ,
+    version INTEGER DEFAULT 0,
+    tenant_id VARCHAR(50),
+    partition_id VARCHAR(50),
+    access_id VARCHAR(50),
+    deleted_at TIMESTAMPTZ,
+
+    profile_id TEXT,
+    requested_by TEXT,
+    state VARCHAR(20) DEFAULT 'running',
+    report TEXT,
+    signature TEXT,
+    progress TEXT,
+    last_error TEXT,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_data_erasures_profile_id ON user_data_erasures (profile_id);
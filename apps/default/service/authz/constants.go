@@ -4,8 +4,14 @@ const (
 	NamespaceFile          = "file"
 	NamespaceProfile       = "profile_user"
 	NamespaceTenancyAccess = "tenancy_access"
+	NamespaceGroup         = "group"
+	NamespaceFolder        = "file_folder"
 )
 
+// maxMembershipDepth bounds how far nested group and tenancy memberships
+// are followed when listing a profile's shares.
+const maxMembershipDepth = 3
+
 const (
 	PermissionView   = "view"
 	PermissionUpload = "upload"
@@ -25,6 +31,9 @@ const (
 	RelationViewer   = "granted_viewer"
 	RelationEditor   = "granted_editor"
 	RelationUploader = "granted_uploader"
+
+	// RelationParent links a file or folder to the folder it is filed in.
+	RelationParent = "parent"
 )
 
 func RoleToRelation(role string) string {
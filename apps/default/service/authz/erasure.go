@@ -0,0 +1,109 @@
+package authz
+
+import (
+	"context"
+
+	"github.com/pitabwire/frame/v2/security"
+)
+
+// ProfileEraser removes the relations of a profile whose data is erased. It
+// is kept apart from Middleware like TenantAdmin; only the erasure job uses
+// it.
+type ProfileEraser interface {
+	// RemoveFileRelations deletes the relations on a file and their grant
+	// records, returning how many grants were revoked. With keepOwnership
+	// the owner and parent relations stay, for files the erasure keeps.
+	RemoveFileRelations(ctx context.Context, fileID string, keepOwnership bool) (int, error)
+	// RemoveFolderRelations deletes the relations on a folder of an owner
+	// and their grant records, returning how many grants were revoked. With
+	// keepParent the folder stays linked to its parent.
+	RemoveFolderRelations(ctx context.Context, ownerProfileID, folder string, keepParent bool) (int, error)
+	// RevokeReceivedGrants deletes the roles a profile was granted on files
+	// and folders and their grant records, returning how many were revoked.
+	// Ownership of its own files is left to RemoveFileRelations.
+	RevokeReceivedGrants(ctx context.Context, profileID string) (int, error)
+}
+
+func (m *middleware) RemoveFileRelations(ctx context.Context, fileID string, keepOwnership bool) (int, error) {
+	return m.removeRelations(ctx, security.ObjectRef{Namespace: NamespaceFile, ID: fileID}, func(relation string) bool {
+		return keepOwnership && (relation == RelationOwner || relation == RelationParent)
+	})
+}
+
+func (m *middleware) RemoveFolderRelations(ctx context.Context, ownerProfileID, folder string, keepParent bool) (int, error) {
+	object := security.ObjectRef{Namespace: NamespaceFolder, ID: FolderObjectID(ownerProfileID, folder)}
+	return m.removeRelations(ctx, object, func(relation string) bool {
+		return keepParent && relation == RelationParent
+	})
+}
+
+// removeRelations deletes the tuples on object whose relation keep does not
+// hold and then every grant record on it, returning how many of the deleted
+// tuples were grants.
+func (m *middleware) removeRelations(ctx context.Context, object security.ObjectRef, keep func(relation string) bool) (int, error) {
+	tuples, err := m.authorizer.ListRelations(ctx, object)
+	if err != nil {
+		return 0, err
+	}
+	var removed []security.RelationTuple
+	revoked := 0
+	for _, t := range tuples {
+		if keep(t.Relation) {
+			continue
+		}
+		removed = append(removed, t)
+		if t.Relation != RelationOwner && t.Relation != RelationParent {
+			revoked++
+		}
+	}
+	if len(removed) > 0 {
+		if err = m.authorizer.DeleteTuples(ctx, removed); err != nil {
+			return 0, err
+		}
+	}
+
+	recordsCtx := grantRecordsContext(ctx)
+	records, err := m.mediaDB.ListAccessGrants(recordsCtx, object.Namespace, []string{object.ID})
+	if err != nil {
+		return revoked, err
+	}
+	for _, record := range records {
+		if err = m.mediaDB.DeleteAccessGrant(recordsCtx, record.ID); err != nil {
+			return revoked, err
+		}
+	}
+	return revoked, nil
+}
+
+func (m *middleware) RevokeReceivedGrants(ctx context.Context, profileID string) (int, error) {
+	principal := UserPrincipal(profileID)
+	recordsCtx := grantRecordsContext(ctx)
+
+	revoked := 0
+	for _, namespace := range []string{NamespaceFile, NamespaceFolder} {
+		tuples, err := m.authorizer.ListSubjectRelations(ctx, principal.Subject(), namespace)
+		if err != nil {
+			return revoked, err
+		}
+		var granted []security.RelationTuple
+		for _, t := range tuples {
+			if t.Relation != RelationOwner {
+				granted = append(granted, t)
+			}
+		}
+		if len(granted) == 0 {
+			continue
+		}
+		if err = m.authorizer.DeleteTuples(ctx, granted); err != nil {
+			return revoked, err
+		}
+		for _, t := range granted {
+			err = m.mediaDB.DeleteAccessGrants(recordsCtx, namespace, t.Object.ID, principal.Kind, principal.ID, []string{t.Relation})
+			if err != nil {
+				return revoked, err
+			}
+			revoked++
+		}
+	}
+	return revoked, nil
+}
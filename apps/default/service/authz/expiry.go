@@ -0,0 +1,184 @@
+package authz
+
+import (
+	"context"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/pitabwire/util"
+)
+
+// grantRecordsContext reads grant records whoever asks: a file shared across
+// tenants is checked under the claims of the grantee, not of the granter.
+func grantRecordsContext(ctx context.Context) context.Context {
+	return utils.SystemContext(ctx, "access grant records")
+}
+
+// grantTuple is the Keto tuple a grant record stands for.
+func grantTuple(grant *types.AccessGrant) security.RelationTuple {
+	return security.RelationTuple{
+		Object:   security.ObjectRef{Namespace: grant.ObjectNamespace, ID: grant.ObjectID},
+		Relation: grant.Relation,
+		Subject:  Principal{Kind: grant.PrincipalKind, ID: grant.PrincipalID}.Subject(),
+	}
+}
+
+type grantKey struct {
+	objectID  string
+	relation  string
+	principal Principal
+}
+
+// writeGrant records a grant and then writes its tuple. The record goes
+// first so that a grant meant to expire is never left in Keto without one.
+func (m *middleware) writeGrant(ctx context.Context, ownerProfileID string, tuple security.RelationTuple, principal Principal, expiresAt *time.Time) error {
+	record := &types.AccessGrant{
+		ObjectNamespace: tuple.Object.Namespace,
+		ObjectID:        tuple.Object.ID,
+		Relation:        tuple.Relation,
+		PrincipalKind:   principal.Kind,
+		PrincipalID:     principal.ID,
+		GrantedBy:       types.OwnerID(ownerProfileID),
+		ExpiresAt:       expiresAt,
+	}
+	if err := m.mediaDB.StoreAccessGrant(ctx, record); err != nil {
+		return err
+	}
+	if expiresAt != nil {
+		// The expiry may land before the next one remembered for the files
+		// it covers; a folder grant covers too many to find them.
+		m.grantExpiries.Clear()
+	}
+	if err := m.authorizer.WriteTuple(ctx, tuple); err != nil {
+		if delErr := m.mediaDB.DeleteAccessGrant(ctx, record.ID); delErr != nil {
+			util.Log(ctx).WithError(delErr).With("grant_id", record.ID).Warn("failed to remove record of unwritten grant")
+		}
+		return err
+	}
+	return nil
+}
+
+// revokeGrants deletes the tuples of the given grants and then their records.
+func (m *middleware) revokeGrants(ctx context.Context, grants []*types.AccessGrant) (int, error) {
+	revoked := 0
+	for _, grant := range grants {
+		if err := m.authorizer.DeleteTuple(ctx, grantTuple(grant)); err != nil {
+			return revoked, err
+		}
+		if err := m.mediaDB.DeleteAccessGrant(grantRecordsContext(ctx), grant.ID); err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+	return revoked, nil
+}
+
+// RevokeExpiredGrants revokes up to limit grants that expired before the
+// given time, returning how many were revoked.
+func (m *middleware) RevokeExpiredGrants(ctx context.Context, before time.Time, limit int) (int, error) {
+	expired, err := m.mediaDB.ListExpiredAccessGrants(grantRecordsContext(ctx), before, limit)
+	if err != nil {
+		return 0, err
+	}
+	return m.revokeGrants(ctx, expired)
+}
+
+const (
+	// grantExpiryCacheTTL bounds how long a grant made with an expiry on
+	// another replica can be missed by the checks of this one, and so how
+	// long past its expiry it may still be honoured there.
+	grantExpiryCacheTTL  = 10 * time.Second
+	grantExpiryCacheSize = 10000
+)
+
+func grantExpiryKey(metadata *types.MediaMetadata) string {
+	return string(metadata.MediaID) + "\x00" + string(metadata.OwnerID) + "\x00" + metadata.Folder
+}
+
+// mayHoldExpiredGrant reports whether a grant on the file of metadata or its
+// folders may have expired, going by when the next one was known to expire.
+func (m *middleware) mayHoldExpiredGrant(metadata *types.MediaMetadata) bool {
+	cached, ok := m.grantExpiries.Get(grantExpiryKey(metadata))
+	if !ok {
+		return true
+	}
+	next := cached.(time.Time)
+	return !next.IsZero() && !time.Now().Before(next)
+}
+
+// dropExpiredGrants revokes the expired grants on a file and the folders it
+// is filed under, so that they are denied before the sweeper gets to them,
+// and remembers when the next of the remaining grants expires. It reports
+// whether any were revoked.
+func (m *middleware) dropExpiredGrants(ctx context.Context, metadata *types.MediaMetadata) (bool, error) {
+	now := time.Now().UTC()
+	recordsCtx := grantRecordsContext(ctx)
+
+	records, err := m.mediaDB.ListAccessGrants(recordsCtx, NamespaceFile, []string{string(metadata.MediaID)})
+	if err != nil {
+		return false, err
+	}
+	var folderIDs []string
+	for folder := metadata.Folder; folder != ""; folder = ParentFolder(folder) {
+		folderIDs = append(folderIDs, FolderObjectID(string(metadata.OwnerID), folder))
+	}
+	folderRecords, err := m.mediaDB.ListAccessGrants(recordsCtx, NamespaceFolder, folderIDs)
+	if err != nil {
+		return false, err
+	}
+
+	var expired []*types.AccessGrant
+	var next time.Time
+	for _, record := range append(records, folderRecords...) {
+		switch {
+		case record.Expired(now):
+			expired = append(expired, record)
+		case record.ExpiresAt != nil && (next.IsZero() || record.ExpiresAt.Before(next)):
+			next = *record.ExpiresAt
+		}
+	}
+	if len(expired) == 0 {
+		m.grantExpiries.Set(grantExpiryKey(metadata), next)
+		return false, nil
+	}
+	revoked, err := m.revokeGrants(ctx, expired)
+	if err == nil {
+		m.grantExpiries.Set(grantExpiryKey(metadata), next)
+	}
+	return revoked > 0, err
+}
+
+// grantsOn lists the grants on an object, with when they were made, by whom
+// and when they expire where a record was kept. Expired grants are left out.
+func (m *middleware) grantsOn(ctx context.Context, object security.ObjectRef, inheritedFrom string) ([]AccessGrantInfo, error) {
+	tuples, err := m.authorizer.ListRelations(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	records, err := m.mediaDB.ListAccessGrants(grantRecordsContext(ctx), object.Namespace, []string{object.ID})
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[grantKey]*types.AccessGrant, len(records))
+	for _, record := range records {
+		byKey[grantKey{record.ObjectID, record.Relation, Principal{Kind: record.PrincipalKind, ID: record.PrincipalID}}] = record
+	}
+
+	now := time.Now().UTC()
+	var grants []AccessGrantInfo
+	for _, grant := range grantsFromTuples(tuples, inheritedFrom) {
+		record, ok := byKey[grantKey{object.ID, RoleToRelation(grant.Role), grant.Principal}]
+		if ok {
+			if record.Expired(now) {
+				continue
+			}
+			grant.ExpiresAt = record.ExpiresAt
+			grant.GrantedBy = string(record.GrantedBy)
+			grant.GrantedAt = record.CreatedAt
+		}
+		grants = append(grants, grant)
+	}
+	return grants, nil
+}
@@ -0,0 +1,196 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/pitabwire/util"
+)
+
+// ErrInvalidFolder is returned for folder grants on the root folder.
+var ErrInvalidFolder = errors.New("a folder is required")
+
+// traversedRelation stands for a whole object in a subject set that is only
+// ever traversed. Keto ignores the relation when following a parent, but the
+// authorizer only sends a subject set when one is named.
+const traversedRelation = "..."
+
+// FolderObjectID identifies a folder in the file_folder namespace. Folders
+// belong to the owner of the files filed in them, so the same path of two
+// owners is two folders and a folder grant only reaches its owner's files.
+func FolderObjectID(ownerProfileID, folder string) string {
+	return url.PathEscape(ownerProfileID) + "/" + folder
+}
+
+// parseFolderObjectID splits a file_folder object ID into the folder's
+// owner and path.
+func parseFolderObjectID(objectID string) (string, string, bool) {
+	escaped, folder, found := strings.Cut(objectID, "/")
+	if !found || folder == "" {
+		return "", "", false
+	}
+	owner, err := url.PathUnescape(escaped)
+	if err != nil || owner == "" {
+		return "", "", false
+	}
+	return owner, folder, true
+}
+
+// ParentFolder returns the folder containing folder, or "" at the root.
+func ParentFolder(folder string) string {
+	parent := path.Dir(folder)
+	if parent == "." || parent == "/" {
+		return ""
+	}
+	return parent
+}
+
+// folderParentTuple links child, a file or folder, to the folder it is filed in.
+func folderParentTuple(child security.ObjectRef, ownerProfileID, folder string) security.RelationTuple {
+	return security.RelationTuple{
+		Object:   child,
+		Relation: RelationParent,
+		Subject: security.SubjectRef{
+			Namespace: NamespaceFolder,
+			ID:        FolderObjectID(ownerProfileID, folder),
+			Relation:  traversedRelation,
+		},
+	}
+}
+
+// folderChainTuples links folder and each of its ancestors to their parent.
+func folderChainTuples(ownerProfileID, folder string) []security.RelationTuple {
+	var tuples []security.RelationTuple
+	for child := folder; ParentFolder(child) != ""; child = ParentFolder(child) {
+		tuples = append(tuples, folderParentTuple(
+			security.ObjectRef{Namespace: NamespaceFolder, ID: FolderObjectID(ownerProfileID, child)},
+			ownerProfileID, ParentFolder(child)))
+	}
+	return tuples
+}
+
+// SetFileFolder moves a file's parent relation from oldFolder to newFolder
+// so that it inherits the grants of its new folder and its ancestors.
+// Files in the root folder have no parent.
+func (m *middleware) SetFileFolder(ctx context.Context, ownerProfileID, fileID, oldFolder, newFolder string) error {
+	file := security.ObjectRef{Namespace: NamespaceFile, ID: fileID}
+	if oldFolder != "" && oldFolder != newFolder {
+		if err := m.authorizer.DeleteTuple(ctx, folderParentTuple(file, ownerProfileID, oldFolder)); err != nil {
+			return err
+		}
+	}
+	if newFolder == "" {
+		return nil
+	}
+
+	tuples := append([]security.RelationTuple{folderParentTuple(file, ownerProfileID, newFolder)},
+		folderChainTuples(ownerProfileID, newFolder)...)
+	return m.authorizer.WriteTuples(ctx, tuples)
+}
+
+// GrantFolderAccess grants a role on a folder of the owner's. The role
+// applies to every file of the owner's filed in the folder or below it.
+func (m *middleware) GrantFolderAccess(ctx context.Context, ownerProfileID, folder string, principal Principal, role string, expiresAt *time.Time) error {
+	if folder == "" {
+		return ErrInvalidFolder
+	}
+	relation := RoleToRelation(role)
+	if relation == "" || relation == RelationUploader {
+		return ErrInvalidRelation
+	}
+	if relation == RelationOwner && principal.Kind != PrincipalUser {
+		return ErrInvalidRelation
+	}
+
+	util.Log(ctx).WithFields(map[string]any{
+		"owner_profile_id": ownerProfileID,
+		"folder":           folder,
+		"principal_type":   principal.Kind,
+		"principal_id":     principal.ID,
+		"role":             role,
+	}).Debug("granting folder access")
+
+	// The chain lets grants on this folder's ancestors reach it as well.
+	if chain := folderChainTuples(ownerProfileID, folder); len(chain) > 0 {
+		if err := m.authorizer.WriteTuples(ctx, chain); err != nil {
+			return err
+		}
+	}
+	return m.writeGrant(ctx, ownerProfileID, security.RelationTuple{
+		Object:   security.ObjectRef{Namespace: NamespaceFolder, ID: FolderObjectID(ownerProfileID, folder)},
+		Relation: relation,
+		Subject:  principal.Subject(),
+	}, principal, expiresAt)
+}
+
+// RevokeFolderAccess removes every role a principal holds on a folder.
+func (m *middleware) RevokeFolderAccess(ctx context.Context, ownerProfileID, folder string, principal Principal) error {
+	if folder == "" {
+		return ErrInvalidFolder
+	}
+	object := security.ObjectRef{Namespace: NamespaceFolder, ID: FolderObjectID(ownerProfileID, folder)}
+	relations := []string{RelationOwner, RelationViewer, RelationEditor}
+	tuples := make([]security.RelationTuple, len(relations))
+	for i, rel := range relations {
+		tuples[i] = security.RelationTuple{Object: object, Relation: rel, Subject: principal.Subject()}
+	}
+	if err := m.authorizer.DeleteTuples(ctx, tuples); err != nil {
+		return err
+	}
+	return m.mediaDB.DeleteAccessGrants(grantRecordsContext(ctx), NamespaceFolder, object.ID, principal.Kind, principal.ID, relations)
+}
+
+// ListFolderAccessGrants lists the roles granted on a folder of the owner's,
+// including those inherited from the folders above it.
+func (m *middleware) ListFolderAccessGrants(ctx context.Context, ownerProfileID, folder string) ([]AccessGrantInfo, error) {
+	if folder == "" {
+		return nil, ErrInvalidFolder
+	}
+	return m.folderGrants(ctx, ownerProfileID, folder, folder)
+}
+
+// folderGrants lists the grants on folder and its ancestors. Grants on any
+// folder other than direct are reported as inherited from it.
+func (m *middleware) folderGrants(ctx context.Context, ownerProfileID, folder, direct string) ([]AccessGrantInfo, error) {
+	var grants []AccessGrantInfo
+	for current := folder; current != ""; current = ParentFolder(current) {
+		inheritedFrom := ""
+		if current != direct {
+			inheritedFrom = current
+		}
+		folderGrants, err := m.grantsOn(ctx,
+			security.ObjectRef{Namespace: NamespaceFolder, ID: FolderObjectID(ownerProfileID, current)}, inheritedFrom)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, folderGrants...)
+	}
+	return grants, nil
+}
+
+// grantsFromTuples maps the role tuples of an object to grants. Ownership
+// of a file lies with its owner and is not listed; a folder owner role is
+// a grant like any other.
+func grantsFromTuples(tuples []security.RelationTuple, inheritedFrom string) []AccessGrantInfo {
+	var grants []AccessGrantInfo
+	for _, t := range tuples {
+		if t.Relation == RelationOwner && t.Object.Namespace == NamespaceFile {
+			continue
+		}
+		role := RelationToRole(t.Relation)
+		if role == "" {
+			continue
+		}
+		principal, ok := PrincipalFromSubject(t.Subject)
+		if !ok {
+			continue
+		}
+		grants = append(grants, AccessGrantInfo{Principal: principal, Role: role, InheritedFrom: inheritedFrom})
+	}
+	return grants
+}
@@ -0,0 +1,32 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFolderObjectIDIsOwnerScoped(t *testing.T) {
+	assert.Equal(t, "owner-1/docs/2026", FolderObjectID("owner-1", "docs/2026"))
+	// A slash in an owner ID cannot make one owner's folder another's.
+	assert.NotEqual(t, FolderObjectID("a/b", "c"), FolderObjectID("a", "b/c"))
+}
+
+func TestFolderChainTuples(t *testing.T) {
+	assert.Empty(t, folderChainTuples("owner-1", "docs"))
+
+	tuples := folderChainTuples("owner-1", "docs/2026/q1")
+	assert.Equal(t, []security.RelationTuple{
+		{
+			Object:   security.ObjectRef{Namespace: NamespaceFolder, ID: "owner-1/docs/2026/q1"},
+			Relation: RelationParent,
+			Subject:  security.SubjectRef{Namespace: NamespaceFolder, ID: "owner-1/docs/2026", Relation: traversedRelation},
+		},
+		{
+			Object:   security.ObjectRef{Namespace: NamespaceFolder, ID: "owner-1/docs/2026"},
+			Relation: RelationParent,
+			Subject:  security.SubjectRef{Namespace: NamespaceFolder, ID: "owner-1/docs", Relation: traversedRelation},
+		},
+	}, tuples)
+}
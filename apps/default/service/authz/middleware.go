@@ -3,7 +3,9 @@ package authz
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/antinvestor/service-files/apps/default/service/cache"
 	"github.com/antinvestor/service-files/apps/default/service/storage"
 	"github.com/antinvestor/service-files/apps/default/service/types"
 	"github.com/pitabwire/frame/v2/security"
@@ -18,8 +20,16 @@ var (
 )
 
 type AccessGrantInfo struct {
-	PrincipalID string
-	Role        string
+	Principal Principal
+	Role      string
+	// InheritedFrom is the folder a grant is inherited from; empty for
+	// grants made on the object itself.
+	InheritedFrom string
+	// ExpiresAt, GrantedBy and GrantedAt are known for grants recorded
+	// when they were made; a nil ExpiresAt never expires.
+	ExpiresAt *time.Time
+	GrantedBy string
+	GrantedAt time.Time
 }
 
 type Middleware interface {
@@ -28,32 +38,57 @@ type Middleware interface {
 	CanDeleteFile(ctx context.Context, profileID, fileID string) error
 	CanUploadFile(ctx context.Context, profileID string) error
 
-	GrantFileAccess(ctx context.Context, ownerProfileID, fileID, targetProfileID, role string) error
-	RevokeFileAccess(ctx context.Context, ownerProfileID, fileID, targetProfileID string) error
+	GrantFileAccess(ctx context.Context, ownerProfileID, fileID string, principal Principal, role string, expiresAt *time.Time) error
+	RevokeFileAccess(ctx context.Context, ownerProfileID, fileID string, principal Principal) error
 
 	GetFileOwner(ctx context.Context, fileID string) (string, error)
 	ListSharedWith(ctx context.Context, ownerProfileID, fileID string) ([]string, error)
 	ListUserShares(ctx context.Context, profileID string) ([]string, error)
+	ListSharedFiles(ctx context.Context, profileID string) ([]SharedFile, error)
 	ListFileAccessGrants(ctx context.Context, ownerProfileID, fileID string) ([]AccessGrantInfo, error)
+
+	SetFileFolder(ctx context.Context, ownerProfileID, fileID, oldFolder, newFolder string) error
+	GrantFolderAccess(ctx context.Context, ownerProfileID, folder string, principal Principal, role string, expiresAt *time.Time) error
+	RevokeFolderAccess(ctx context.Context, ownerProfileID, folder string, principal Principal) error
+	ListFolderAccessGrants(ctx context.Context, ownerProfileID, folder string) ([]AccessGrantInfo, error)
+
+	CheckPartitionMember(ctx context.Context, profileID string) error
+	TransferFileOwnership(ctx context.Context, fromOwner, toOwner, fileID, folder, previousOwnerRole string) error
+	RestoreFileOwnership(ctx context.Context, fromOwner, toOwner, fileID, folder, previousOwnerRole string) error
+	ReconcileOwnership(ctx context.Context, afterID string, limit int, repair bool) (*ReconcileReport, error)
+	ReconcileTuples(ctx context.Context, tuples []security.RelationTuple, repair bool) ([]ReconcileIssue, error)
+
+	RevokeExpiredGrants(ctx context.Context, before time.Time, limit int) (int, error)
 }
 
 type middleware struct {
 	authorizer    security.Authorizer
 	mediaDB       storage.Database
 	servicePolicy ServicePolicy
+	profiles      ProfileDirectory
+
+	// grantExpiries holds, per file, when the next grant on it or its
+	// folders expires, so allowed checks look the records up only once one
+	// may have.
+	grantExpiries *cache.Cache
 }
 
 func NewMiddleware(authorizer security.Authorizer, mediaDB storage.Database) Middleware {
-	return &middleware{
-		authorizer:    authorizer,
-		mediaDB:       mediaDB,
-		servicePolicy: DefaultServicePolicy(),
-	}
+	return NewMiddlewareWithPolicy(authorizer, mediaDB, DefaultServicePolicy())
 }
 
 // NewMiddlewareWithPolicy allows injecting a custom service policy (for tests).
 func NewMiddlewareWithPolicy(authorizer security.Authorizer, mediaDB storage.Database, policy ServicePolicy) Middleware {
-	return &middleware{authorizer: authorizer, mediaDB: mediaDB, servicePolicy: policy}
+	return &middleware{
+		authorizer:    authorizer,
+		mediaDB:       mediaDB,
+		servicePolicy: policy,
+		grantExpiries: cache.NewCache(&cache.CacheConfig{
+			DefaultTTL:      grantExpiryCacheTTL,
+			CleanupInterval: time.Minute,
+			MaxSize:         grantExpiryCacheSize,
+		}),
+	}
 }
 
 func (m *middleware) CanViewFile(ctx context.Context, profileID, fileID string) error {
@@ -108,13 +143,16 @@ func (m *middleware) checkFilePermission(ctx context.Context, profileID, fileID,
 		// Unknown service or insufficient scope — fall through to normal auth
 	}
 
-	ownerID, err := m.GetFileOwner(ctx, fileID)
+	metadata, err := m.mediaDB.GetMediaMetadata(ctx, types.MediaID(fileID))
 	if err != nil {
 		log.WithError(err).Error("failed to get file owner")
 		return err
 	}
+	if metadata == nil {
+		return ErrNotFound
+	}
 
-	if profileID == ownerID {
+	if profileID == string(metadata.OwnerID) {
 		log.Debug("user is owner, granting access")
 		return nil
 	}
@@ -131,6 +169,22 @@ func (m *middleware) checkFilePermission(ctx context.Context, profileID, fileID,
 		return err
 	}
 
+	// Keto knows nothing of expiry: an allowed check may rest on an expired
+	// grant the sweeper has not revoked yet, so revoke it and check again.
+	if result.Allowed && m.mayHoldExpiredGrant(metadata) {
+		dropped, dropErr := m.dropExpiredGrants(ctx, metadata)
+		if dropErr != nil {
+			log.WithError(dropErr).Error("failed to revoke expired grants")
+			return dropErr
+		}
+		if dropped {
+			if result, err = m.authorizer.Check(ctx, req); err != nil {
+				log.WithError(err).Error("authorization check failed")
+				return err
+			}
+		}
+	}
+
 	if !result.Allowed {
 		return authorizer.NewPermissionDeniedError(
 			req.Object,
@@ -143,12 +197,13 @@ func (m *middleware) checkFilePermission(ctx context.Context, profileID, fileID,
 	return nil
 }
 
-func (m *middleware) GrantFileAccess(ctx context.Context, ownerProfileID, fileID, targetProfileID, role string) error {
+func (m *middleware) GrantFileAccess(ctx context.Context, ownerProfileID, fileID string, principal Principal, role string, expiresAt *time.Time) error {
 	log := util.Log(ctx).WithFields(map[string]any{
-		"owner_profile_id":  ownerProfileID,
-		"file_id":           fileID,
-		"target_profile_id": targetProfileID,
-		"role":              role,
+		"owner_profile_id": ownerProfileID,
+		"file_id":          fileID,
+		"principal_type":   principal.Kind,
+		"principal_id":     principal.ID,
+		"role":             role,
 	})
 
 	isOwner, err := m.isOwner(ctx, ownerProfileID, fileID)
@@ -165,19 +220,24 @@ func (m *middleware) GrantFileAccess(ctx context.Context, ownerProfileID, fileID
 	if relation == "" {
 		return ErrInvalidRelation
 	}
+	// Ownership stays with a single profile; sets only receive shared roles.
+	if relation == RelationOwner && principal.Kind != PrincipalUser {
+		return ErrInvalidRelation
+	}
 
-	return m.authorizer.WriteTuple(ctx, security.RelationTuple{
+	return m.writeGrant(ctx, ownerProfileID, security.RelationTuple{
 		Object:   security.ObjectRef{Namespace: NamespaceFile, ID: fileID},
 		Relation: relation,
-		Subject:  security.SubjectRef{Namespace: NamespaceProfile, ID: targetProfileID},
-	})
+		Subject:  principal.Subject(),
+	}, principal, expiresAt)
 }
 
-func (m *middleware) RevokeFileAccess(ctx context.Context, ownerProfileID, fileID, targetProfileID string) error {
+func (m *middleware) RevokeFileAccess(ctx context.Context, ownerProfileID, fileID string, principal Principal) error {
 	log := util.Log(ctx).WithFields(map[string]any{
-		"owner_profile_id":  ownerProfileID,
-		"file_id":           fileID,
-		"target_profile_id": targetProfileID,
+		"owner_profile_id": ownerProfileID,
+		"file_id":          fileID,
+		"principal_type":   principal.Kind,
+		"principal_id":     principal.ID,
 	})
 
 	isOwner, err := m.isOwner(ctx, ownerProfileID, fileID)
@@ -196,11 +256,14 @@ func (m *middleware) RevokeFileAccess(ctx context.Context, ownerProfileID, fileI
 		tuples[i] = security.RelationTuple{
 			Object:   security.ObjectRef{Namespace: NamespaceFile, ID: fileID},
 			Relation: rel,
-			Subject:  security.SubjectRef{Namespace: NamespaceProfile, ID: targetProfileID},
+			Subject:  principal.Subject(),
 		}
 	}
 
-	return m.authorizer.DeleteTuples(ctx, tuples)
+	if err = m.authorizer.DeleteTuples(ctx, tuples); err != nil {
+		return err
+	}
+	return m.mediaDB.DeleteAccessGrants(grantRecordsContext(ctx), NamespaceFile, fileID, principal.Kind, principal.ID, relations)
 }
 
 func (m *middleware) GetFileOwner(ctx context.Context, fileID string) (string, error) {
@@ -224,83 +287,249 @@ func (m *middleware) isOwner(ctx context.Context, profileID, fileID string) (boo
 	return profileID == ownerID, nil
 }
 
+// ListSharedWith lists the profiles a file is shared with directly.
 func (m *middleware) ListSharedWith(ctx context.Context, ownerProfileID, fileID string) ([]string, error) {
-	isOwner, err := m.isOwner(ctx, ownerProfileID, fileID)
+	grants, err := m.ListFileAccessGrants(ctx, ownerProfileID, fileID)
 	if err != nil {
 		return nil, err
 	}
 
-	if !isOwner {
-		return nil, ErrNotOwner
+	var subjects []string
+	for _, g := range grants {
+		if g.Principal.Kind == PrincipalUser && g.InheritedFrom == "" {
+			subjects = append(subjects, g.Principal.ID)
+		}
 	}
+	return subjects, nil
+}
 
-	relations := []string{RelationViewer, RelationEditor, RelationUploader}
-	var allSubjects []string
+// SharedFile is a file shared with a profile, with the grant through which
+// the profile holds its strongest role on it.
+type SharedFile struct {
+	FileID string
+	Grant  AccessGrantInfo
+}
 
-	for _, rel := range relations {
-		tuples, err := m.authorizer.ListSubjectRelations(ctx,
-			security.SubjectRef{Namespace: NamespaceProfile},
-			NamespaceFile+":"+fileID+":"+rel,
-		)
-		if err != nil {
-			continue
-		}
+// roleRank orders roles by what they allow, so that a file shared with a
+// profile more than once is listed with its strongest role.
+func roleRank(role string) int {
+	switch role {
+	case "owner":
+		return 3
+	case "editor":
+		return 2
+	case "viewer":
+		return 1
+	default:
+		return 0
+	}
+}
 
-		for _, t := range tuples {
-			allSubjects = append(allSubjects, t.Subject.ID)
+// ListUserShares lists the IDs of the files others share with a profile, as
+// ListSharedFiles does.
+func (m *middleware) ListUserShares(ctx context.Context, profileID string) ([]string, error) {
+	shared, err := m.ListSharedFiles(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(shared))
+	for _, file := range shared {
+		files = append(files, file.FileID)
+	}
+	return files, nil
+}
+
+// sharedFolderPageSize is how many files of a shared folder ListSharedFiles
+// reads at a time.
+const sharedFolderPageSize = 200
+
+// ListSharedFiles lists the files others share with a profile, directly or
+// through the groups and tenant partitions it belongs to, once each with the
+// strongest role it holds and who granted it when. Folder grants reach every
+// file of the folder's owner filed in the folder or below it. The profile's
+// own files and expired grants are left out.
+func (m *middleware) ListSharedFiles(ctx context.Context, profileID string) ([]SharedFile, error) {
+	subjects, err := m.memberSubjects(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	tuples := map[string][]security.RelationTuple{}
+	for _, namespace := range []string{NamespaceFile, NamespaceFolder} {
+		for _, subject := range subjects {
+			subjectTuples, listErr := m.authorizer.ListSubjectRelations(ctx, subject, namespace)
+			if listErr != nil {
+				return nil, listErr
+			}
+			for _, t := range subjectTuples {
+				// Owning a file is not having it shared; owning a folder is.
+				if namespace == NamespaceFile && t.Relation == RelationOwner {
+					continue
+				}
+				tuples[namespace] = append(tuples[namespace], t)
+			}
 		}
 	}
 
-	return allSubjects, nil
-}
+	now := time.Now().UTC()
+	index := map[string]int{}
+	var files []SharedFile
+	add := func(fileID string, grant AccessGrantInfo) {
+		if i, seen := index[fileID]; seen {
+			if roleRank(grant.Role) > roleRank(files[i].Grant.Role) {
+				files[i].Grant = grant
+			}
+			return
+		}
+		index[fileID] = len(files)
+		files = append(files, SharedFile{FileID: fileID, Grant: grant})
+	}
 
-func (m *middleware) ListUserShares(ctx context.Context, profileID string) ([]string, error) {
-	tuples, err := m.authorizer.ListSubjectRelations(ctx,
-		security.SubjectRef{Namespace: NamespaceProfile, ID: profileID},
-		NamespaceFile,
-	)
+	fileGrants, err := m.sharedGrants(ctx, NamespaceFile, tuples[NamespaceFile], now)
 	if err != nil {
 		return nil, err
 	}
+	for i, t := range tuples[NamespaceFile] {
+		if fileGrants[i] != nil {
+			add(t.Object.ID, *fileGrants[i])
+		}
+	}
 
-	files := make([]string, len(tuples))
-	for i, t := range tuples {
-		files[i] = t.Object.ID
+	folderGrants, err := m.sharedGrants(ctx, NamespaceFolder, tuples[NamespaceFolder], now)
+	if err != nil {
+		return nil, err
+	}
+	for i, t := range tuples[NamespaceFolder] {
+		grant := folderGrants[i]
+		if grant == nil {
+			continue
+		}
+		owner, folder, ok := parseFolderObjectID(t.Object.ID)
+		if !ok || owner == profileID {
+			continue
+		}
+		grant.InheritedFrom = folder
+		afterID := ""
+		for {
+			page, listErr := m.mediaDB.ListOwnerMedia(ctx, types.OwnerID(owner), folder, afterID, sharedFolderPageSize)
+			if listErr != nil {
+				return nil, listErr
+			}
+			for _, metadata := range page {
+				add(string(metadata.MediaID), *grant)
+			}
+			if len(page) < sharedFolderPageSize {
+				break
+			}
+			afterID = string(page[len(page)-1].MediaID)
+		}
 	}
 
 	return files, nil
 }
 
-func (m *middleware) ListFileAccessGrants(ctx context.Context, ownerProfileID, fileID string) ([]AccessGrantInfo, error) {
-	isOwner, err := m.isOwner(ctx, ownerProfileID, fileID)
+// sharedGrants maps role tuples on objects of namespace to the grants they
+// stand for, filled in from the grant records. Tuples that are not grants
+// to a principal, or whose grant has expired, map to nil.
+func (m *middleware) sharedGrants(ctx context.Context, namespace string, tuples []security.RelationTuple, now time.Time) ([]*AccessGrantInfo, error) {
+	if len(tuples) == 0 {
+		return nil, nil
+	}
+	objectIDs := make([]string, 0, len(tuples))
+	for _, t := range tuples {
+		objectIDs = append(objectIDs, t.Object.ID)
+	}
+	records, err := m.mediaDB.ListAccessGrants(grantRecordsContext(ctx), namespace, objectIDs)
 	if err != nil {
 		return nil, err
 	}
-
-	if !isOwner {
-		return nil, ErrNotOwner
+	byKey := make(map[grantKey]*types.AccessGrant, len(records))
+	for _, record := range records {
+		byKey[grantKey{record.ObjectID, record.Relation, Principal{Kind: record.PrincipalKind, ID: record.PrincipalID}}] = record
 	}
 
-	relations := []string{RelationViewer, RelationEditor, RelationUploader}
-	var grants []AccessGrantInfo
-
-	for _, rel := range relations {
-		tuples, err := m.authorizer.ListSubjectRelations(ctx,
-			security.SubjectRef{Namespace: NamespaceProfile},
-			NamespaceFile+":"+fileID+":"+rel,
-		)
-		if err != nil {
+	grants := make([]*AccessGrantInfo, len(tuples))
+	for i, t := range tuples {
+		role := RelationToRole(t.Relation)
+		principal, ok := PrincipalFromSubject(t.Subject)
+		if role == "" || !ok {
 			continue
 		}
+		grant := &AccessGrantInfo{Principal: principal, Role: role}
+		if record, found := byKey[grantKey{t.Object.ID, t.Relation, principal}]; found {
+			if record.Expired(now) {
+				continue
+			}
+			grant.ExpiresAt = record.ExpiresAt
+			grant.GrantedBy = string(record.GrantedBy)
+			grant.GrantedAt = record.CreatedAt
+		}
+		grants[i] = grant
+	}
+	return grants, nil
+}
 
-		role := RelationToRole(rel)
-		for _, t := range tuples {
-			grants = append(grants, AccessGrantInfo{
-				PrincipalID: t.Subject.ID,
-				Role:        role,
-			})
+// memberSubjects returns the profile's own subject followed by every
+// group#member and tenancy_access#member set it belongs to, following
+// nested sets up to maxMembershipDepth levels.
+func (m *middleware) memberSubjects(ctx context.Context, profileID string) ([]security.SubjectRef, error) {
+	subjects := []security.SubjectRef{{Namespace: NamespaceProfile, ID: profileID}}
+	seen := map[security.SubjectRef]struct{}{subjects[0]: {}}
+
+	frontier := subjects
+	for depth := 0; depth < maxMembershipDepth && len(frontier) > 0; depth++ {
+		var next []security.SubjectRef
+		for _, subject := range frontier {
+			for _, namespace := range []string{NamespaceGroup, NamespaceTenancyAccess} {
+				tuples, err := m.authorizer.ListSubjectRelations(ctx, subject, namespace)
+				if err != nil {
+					return nil, err
+				}
+				for _, t := range tuples {
+					if t.Relation != RoleMember {
+						continue
+					}
+					set := security.SubjectRef{Namespace: namespace, ID: t.Object.ID, Relation: RoleMember}
+					if _, ok := seen[set]; ok {
+						continue
+					}
+					seen[set] = struct{}{}
+					next = append(next, set)
+				}
+			}
 		}
+		subjects = append(subjects, next...)
+		frontier = next
 	}
 
-	return grants, nil
+	return subjects, nil
+}
+
+// ListFileAccessGrants lists the shared roles granted on a file, one entry
+// per principal and role, followed by those inherited from its folders.
+func (m *middleware) ListFileAccessGrants(ctx context.Context, ownerProfileID, fileID string) ([]AccessGrantInfo, error) {
+	metadata, err := m.mediaDB.GetMediaMetadata(ctx, types.MediaID(fileID))
+	if err != nil {
+		return nil, err
+	}
+	if metadata == nil {
+		return nil, ErrNotFound
+	}
+	if string(metadata.OwnerID) != ownerProfileID {
+		return nil, ErrNotOwner
+	}
+
+	grants, err := m.grantsOn(ctx, security.ObjectRef{Namespace: NamespaceFile, ID: fileID}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata.Folder == "" {
+		return grants, nil
+	}
+	inherited, err := m.folderGrants(ctx, ownerProfileID, metadata.Folder, "")
+	if err != nil {
+		return nil, err
+	}
+	return append(grants, inherited...), nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/url"
 	"testing"
+	"time"
 
 	aconfig "github.com/antinvestor/service-files/apps/default/config"
 	"github.com/antinvestor/service-files/apps/default/service/storage"
@@ -19,6 +20,7 @@ import (
 	"github.com/pitabwire/frame/v2/frametests/definition"
 	"github.com/pitabwire/frame/v2/frametests/deps/testpostgres"
 	"github.com/pitabwire/frame/v2/security"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -107,6 +109,15 @@ func (s *AuthzMiddlewareTestSuite) SetupSuite() {
 		repository.NewRetentionPolicyRepository(ctx, dbPool, svc.WorkManager()),
 		repository.NewFileRetentionRepository(ctx, dbPool, svc.WorkManager()),
 		repository.NewStorageStatsRepository(ctx, dbPool, svc.WorkManager()),
+		repository.NewWebhookSubscriptionRepository(ctx, dbPool, svc.WorkManager()),
+		repository.NewWebhookDeliveryRepository(ctx, dbPool, svc.WorkManager()),
+		repository.NewThumbnailJobRepository(ctx, dbPool, svc.WorkManager()),
+		repository.NewThumbnailRegenerationRepository(ctx, dbPool, svc.WorkManager()),
+		repository.NewArchiveExtractionRepository(ctx, dbPool, svc.WorkManager()),
+		repository.NewShareLinkRepository(ctx, dbPool, svc.WorkManager()),
+		repository.NewAccessGrantRepository(ctx, dbPool, svc.WorkManager()),
+		repository.NewUserDataExportRepository(ctx, dbPool, svc.WorkManager()),
+		repository.NewUserDataErasureRepository(ctx, dbPool, svc.WorkManager()),
 	)
 	require.NoError(s.T(), err)
 
@@ -214,13 +225,13 @@ func (s *AuthzMiddlewareTestSuite) TestGrantAndRevokeAccess() {
 
 	for _, tc := range cases {
 		s.T().Run(tc.name, func(t *testing.T) {
-			err := s.middleware.GrantFileAccess(ctx, "owner-2", string(media.MediaID), tc.targetID, tc.role)
+			err := s.middleware.GrantFileAccess(ctx, "owner-2", string(media.MediaID), UserPrincipal(tc.targetID), tc.role, nil)
 			require.NoError(t, err)
 
 			err = s.middleware.CanViewFile(ctx, tc.targetID, string(media.MediaID))
 			require.NoError(t, err)
 
-			err = s.middleware.RevokeFileAccess(ctx, "owner-2", string(media.MediaID), tc.targetID)
+			err = s.middleware.RevokeFileAccess(ctx, "owner-2", string(media.MediaID), UserPrincipal(tc.targetID))
 			require.NoError(t, err)
 
 			err = s.middleware.CanViewFile(ctx, tc.targetID, string(media.MediaID))
@@ -246,11 +257,18 @@ func (s *AuthzMiddlewareTestSuite) TestListUserShares() {
 		name     string
 		userID   string
 		relation string
+		listed   bool
 	}{
 		{
 			name:     "shares_listed",
 			userID:   "user-shared",
 			relation: RelationViewer,
+			listed:   true,
+		},
+		{
+			name:     "ownership_not_listed",
+			userID:   "owner-3",
+			relation: RelationOwner,
 		},
 	}
 
@@ -265,11 +283,487 @@ func (s *AuthzMiddlewareTestSuite) TestListUserShares() {
 
 			files, err := s.middleware.ListUserShares(ctx, tc.userID)
 			require.NoError(t, err)
-			require.Contains(t, files, string(media.MediaID))
+			if tc.listed {
+				require.Contains(t, files, string(media.MediaID))
+			} else {
+				require.NotContains(t, files, string(media.MediaID))
+			}
 		})
 	}
 }
 
+func (s *AuthzMiddlewareTestSuite) TestFolderInheritance() {
+	ctx := s.T().Context()
+
+	media := &types.MediaMetadata{
+		MediaID:       "media-folder",
+		OwnerID:       "owner-folder",
+		UploadName:    "file.txt",
+		Base64Hash:    "hash-folder",
+		FileSizeBytes: 10,
+		ServerName:    "server",
+		Folder:        "projects/alpha/docs",
+	}
+	require.NoError(s.T(), s.mediaDB.StoreMediaMetadata(ctx, media))
+	fileID := string(media.MediaID)
+	require.NoError(s.T(), s.middleware.SetFileFolder(ctx, "owner-folder", fileID, "", media.Folder))
+
+	require.NoError(s.T(), s.middleware.GrantFolderAccess(ctx, "owner-folder", "projects", UserPrincipal("folder-viewer"), "viewer", nil))
+	require.NoError(s.T(), s.middleware.GrantFolderAccess(ctx, "owner-folder", "projects/alpha", UserPrincipal("folder-editor"), "editor", nil))
+	// The same path of another owner is another folder.
+	require.NoError(s.T(), s.middleware.GrantFolderAccess(ctx, "someone-else", "projects", UserPrincipal("outsider"), "owner", nil))
+
+	s.T().Run("grants_reach_files_below", func(t *testing.T) {
+		require.NoError(t, s.middleware.CanViewFile(ctx, "folder-viewer", fileID))
+		require.Error(t, s.middleware.CanEditFile(ctx, "folder-viewer", fileID))
+		require.NoError(t, s.middleware.CanEditFile(ctx, "folder-editor", fileID))
+		require.Error(t, s.middleware.CanDeleteFile(ctx, "folder-editor", fileID))
+		require.Error(t, s.middleware.CanViewFile(ctx, "outsider", fileID))
+	})
+
+	s.T().Run("listing_marks_inherited", func(t *testing.T) {
+		grants, err := s.middleware.ListFileAccessGrants(ctx, "owner-folder", fileID)
+		require.NoError(t, err)
+		assert.Equal(t, "projects/alpha", findGrant(t, grants, "folder-editor").InheritedFrom)
+		assert.Equal(t, "projects", findGrant(t, grants, "folder-viewer").InheritedFrom)
+
+		grants, err = s.middleware.ListFolderAccessGrants(ctx, "owner-folder", "projects/alpha")
+		require.NoError(t, err)
+		assert.Empty(t, findGrant(t, grants, "folder-editor").InheritedFrom)
+		assert.Equal(t, "projects", findGrant(t, grants, "folder-viewer").InheritedFrom)
+	})
+
+	s.T().Run("listed_as_shared", func(t *testing.T) {
+		shared, err := s.middleware.ListSharedFiles(ctx, "folder-editor")
+		require.NoError(t, err)
+		require.Len(t, shared, 1)
+		assert.Equal(t, fileID, shared[0].FileID)
+		assert.Equal(t, "editor", shared[0].Grant.Role)
+		assert.Equal(t, "projects/alpha", shared[0].Grant.InheritedFrom)
+
+		files, err := s.middleware.ListUserShares(ctx, "outsider")
+		require.NoError(t, err)
+		assert.NotContains(t, files, fileID)
+	})
+
+	s.T().Run("uploader_cannot_be_granted", func(t *testing.T) {
+		err := s.middleware.GrantFolderAccess(ctx, "owner-folder", "projects", UserPrincipal("up"), "uploader", nil)
+		require.ErrorIs(t, err, ErrInvalidRelation)
+	})
+
+	s.T().Run("moving_drops_inherited_access", func(t *testing.T) {
+		require.NoError(t, s.middleware.SetFileFolder(ctx, "owner-folder", fileID, media.Folder, "archive"))
+		require.Error(t, s.middleware.CanViewFile(ctx, "folder-viewer", fileID))
+		require.Error(t, s.middleware.CanEditFile(ctx, "folder-editor", fileID))
+	})
+
+	s.T().Run("revoke", func(t *testing.T) {
+		require.NoError(t, s.middleware.SetFileFolder(ctx, "owner-folder", fileID, "archive", media.Folder))
+		require.NoError(t, s.middleware.RevokeFolderAccess(ctx, "owner-folder", "projects", UserPrincipal("folder-viewer")))
+		require.Error(t, s.middleware.CanViewFile(ctx, "folder-viewer", fileID))
+	})
+}
+
+func (s *AuthzMiddlewareTestSuite) TestGrantExpiry() {
+	ctx := s.T().Context()
+
+	media := &types.MediaMetadata{
+		MediaID:       "media-expiry",
+		OwnerID:       "owner-expiry",
+		UploadName:    "file.txt",
+		Base64Hash:    "hash-expiry",
+		FileSizeBytes: 10,
+		ServerName:    "server",
+	}
+	require.NoError(s.T(), s.mediaDB.StoreMediaMetadata(ctx, media))
+	fileID := string(media.MediaID)
+	later := time.Now().UTC().Add(time.Hour)
+	earlier := time.Now().UTC().Add(-time.Minute)
+
+	s.T().Run("unexpired_grant_is_listed_with_expiry", func(t *testing.T) {
+		require.NoError(t, s.middleware.GrantFileAccess(ctx, "owner-expiry", fileID, UserPrincipal("auditor"), "viewer", &later))
+		require.NoError(t, s.middleware.CanViewFile(ctx, "auditor", fileID))
+		// The next expiry is remembered, so checks before it skip the records.
+		assert.False(t, s.middleware.(*middleware).mayHoldExpiredGrant(media))
+
+		grants, err := s.middleware.ListFileAccessGrants(ctx, "owner-expiry", fileID)
+		require.NoError(t, err)
+		grant := findGrant(t, grants, "auditor")
+		require.NotNil(t, grant.ExpiresAt)
+		assert.WithinDuration(t, later, *grant.ExpiresAt, time.Second)
+		assert.Equal(t, "owner-expiry", grant.GrantedBy)
+	})
+
+	s.T().Run("expired_grant_denied_before_sweep", func(t *testing.T) {
+		require.NoError(t, s.middleware.GrantFileAccess(ctx, "owner-expiry", fileID, UserPrincipal("contractor"), "editor", &earlier))
+		assert.True(t, s.middleware.(*middleware).mayHoldExpiredGrant(media), "a grant with an expiry forgets the remembered ones")
+		require.Error(t, s.middleware.CanEditFile(ctx, "contractor", fileID))
+
+		shares, err := s.middleware.ListUserShares(ctx, "contractor")
+		require.NoError(t, err)
+		assert.NotContains(t, shares, fileID)
+	})
+
+	s.T().Run("regrant_without_expiry_is_permanent", func(t *testing.T) {
+		require.NoError(t, s.middleware.GrantFileAccess(ctx, "owner-expiry", fileID, UserPrincipal("auditor"), "viewer", nil))
+		grants, err := s.middleware.ListFileAccessGrants(ctx, "owner-expiry", fileID)
+		require.NoError(t, err)
+		assert.Nil(t, findGrant(t, grants, "auditor").ExpiresAt)
+	})
+
+	s.T().Run("sweep_revokes_expired_tuples", func(t *testing.T) {
+		require.NoError(t, s.middleware.GrantFileAccess(ctx, "owner-expiry", fileID, UserPrincipal("temp"), "viewer", &earlier))
+
+		revoked, err := s.middleware.RevokeExpiredGrants(ctx, time.Now().UTC(), 100)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, revoked, 1)
+
+		tuples, err := s.authorizer.ListRelations(ctx, security.ObjectRef{Namespace: NamespaceFile, ID: fileID})
+		require.NoError(t, err)
+		for _, tuple := range tuples {
+			assert.NotEqual(t, "temp", tuple.Subject.ID)
+		}
+	})
+}
+
+// findGrant returns the grant listed for a profile.
+func findGrant(t *testing.T, grants []AccessGrantInfo, profileID string) AccessGrantInfo {
+	t.Helper()
+	for _, g := range grants {
+		if g.Principal == UserPrincipal(profileID) {
+			return g
+		}
+	}
+	require.Failf(t, "grant not listed", "no grant for %s", profileID)
+	return AccessGrantInfo{}
+}
+
+func (s *AuthzMiddlewareTestSuite) TestReconcileOwnership() {
+	ctx := s.T().Context()
+
+	kept := &types.MediaMetadata{
+		MediaID:       "media-reconcile-kept",
+		OwnerID:       "owner-reconcile",
+		UploadName:    "file.txt",
+		Base64Hash:    "hash-reconcile-kept",
+		FileSizeBytes: 10,
+		ServerName:    "server",
+		Folder:        "reports/2025",
+	}
+	deleted := &types.MediaMetadata{
+		MediaID:       "media-reconcile-deleted",
+		OwnerID:       "owner-reconcile",
+		UploadName:    "file.txt",
+		Base64Hash:    "hash-reconcile-deleted",
+		FileSizeBytes: 10,
+		ServerName:    "server",
+	}
+	require.NoError(s.T(), s.mediaDB.StoreMediaMetadata(ctx, kept))
+	require.NoError(s.T(), s.mediaDB.StoreMediaMetadata(ctx, deleted))
+	keptID, deletedID := string(kept.MediaID), string(deleted.MediaID)
+
+	// A grant to a group that still has members, which is not a difference,
+	// grants to a group since deleted and to a profile the profile service
+	// no longer knows, an owner tuple left behind by a previous owner, a link
+	// to a folder the media is not filed in, and a grant outliving its media.
+	memberTuple := func(group string) security.RelationTuple {
+		return security.RelationTuple{
+			Object:   security.ObjectRef{Namespace: NamespaceGroup, ID: group},
+			Relation: RoleMember,
+			Subject:  security.SubjectRef{Namespace: NamespaceProfile, ID: "member-reconcile"},
+		}
+	}
+	require.NoError(s.T(), s.authorizer.WriteTuple(ctx, memberTuple("group-reconcile-live")))
+	require.NoError(s.T(), s.authorizer.WriteTuple(ctx, memberTuple("group-reconcile-deleted")))
+	require.NoError(s.T(), s.middleware.GrantFileAccess(ctx, "owner-reconcile", keptID,
+		Principal{Kind: PrincipalGroup, ID: "group-reconcile-live"}, "viewer", nil))
+	require.NoError(s.T(), s.middleware.GrantFileAccess(ctx, "owner-reconcile", keptID,
+		Principal{Kind: PrincipalGroup, ID: "group-reconcile-deleted"}, "viewer", nil))
+	require.NoError(s.T(), s.authorizer.DeleteTuple(ctx, memberTuple("group-reconcile-deleted")))
+	require.NoError(s.T(), s.middleware.GrantFileAccess(ctx, "owner-reconcile", keptID,
+		UserPrincipal("reader-reconcile-gone"), "viewer", nil))
+	profiles, ok := s.middleware.(ProfileReconciler)
+	require.True(s.T(), ok)
+	profiles.SetProfileDirectory(goneProfiles{"reader-reconcile-gone": true})
+	defer profiles.SetProfileDirectory(nil)
+	require.NoError(s.T(), s.authorizer.WriteTuple(ctx, fileOwnerTuple(keptID, "owner-reconcile-previous")))
+	require.NoError(s.T(), s.authorizer.WriteTuple(ctx,
+		folderParentTuple(security.ObjectRef{Namespace: NamespaceFile, ID: keptID}, "owner-reconcile", "drafts")))
+	require.NoError(s.T(), s.middleware.GrantFileAccess(ctx, "owner-reconcile", deletedID, UserPrincipal("reader-reconcile"), "viewer", nil))
+	require.NoError(s.T(), s.mediaDB.DeleteMedia(ctx, deleted.MediaID))
+
+	reconcileAll := func(t *testing.T, repair bool) map[string][]ReconcileIssue {
+		found := map[string][]ReconcileIssue{}
+		afterID := ""
+		for {
+			report, err := s.middleware.ReconcileOwnership(ctx, afterID, 2, repair)
+			require.NoError(t, err)
+			for _, issue := range report.Issues {
+				found[issue.FileID] = append(found[issue.FileID], issue)
+			}
+			if report.Scanned < 2 {
+				return found
+			}
+			afterID = report.LastID
+		}
+	}
+	kinds := func(issues []ReconcileIssue) []string {
+		var result []string
+		for _, issue := range issues {
+			result = append(result, issue.Kind)
+		}
+		return result
+	}
+
+	s.T().Run("dry_run_reports", func(t *testing.T) {
+		found := reconcileAll(t, false)
+		assert.ElementsMatch(t, []string{IssueStaleOwnerTuple, IssueMissingOwnerTuple,
+			IssueStaleParentTuple, IssueMissingParentTuple, IssueUnknownSubject, IssueUnknownSubject}, kinds(found[keptID]))
+		assert.Equal(t, []string{IssueDeletedMediaTuple}, kinds(found[deletedID]))
+		for _, issue := range append(found[keptID], found[deletedID]...) {
+			assert.False(t, issue.Repaired)
+		}
+
+		tuples, err := s.authorizer.ListRelations(ctx, security.ObjectRef{Namespace: NamespaceFile, ID: deletedID})
+		require.NoError(t, err)
+		assert.NotEmpty(t, tuples)
+	})
+
+	s.T().Run("repair", func(t *testing.T) {
+		found := reconcileAll(t, true)
+		require.NotEmpty(t, found[keptID])
+		for _, issue := range append(found[keptID], found[deletedID]...) {
+			assert.True(t, issue.Repaired, issue.Kind)
+		}
+
+		found = reconcileAll(t, false)
+		assert.Empty(t, found[keptID])
+		assert.Empty(t, found[deletedID])
+
+		tuples, err := s.authorizer.ListRelations(ctx, security.ObjectRef{Namespace: NamespaceFile, ID: deletedID})
+		require.NoError(t, err)
+		assert.Empty(t, tuples)
+		grants, err := s.mediaDB.ListAccessGrants(ctx, NamespaceFile, []string{deletedID})
+		require.NoError(t, err)
+		assert.Empty(t, grants)
+
+		// Only the grant to the group that still has members is kept.
+		grants, err = s.mediaDB.ListAccessGrants(ctx, NamespaceFile, []string{keptID})
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		assert.Equal(t, "group-reconcile-live", grants[0].PrincipalID)
+		require.NoError(t, s.middleware.CanViewFile(ctx, "member-reconcile", keptID))
+
+		// The repaired link inherits the grants of the folders above.
+		require.NoError(t, s.middleware.GrantFolderAccess(ctx, "owner-reconcile", "reports", UserPrincipal("reader-reconcile"), "viewer", nil))
+		require.NoError(t, s.middleware.CanViewFile(ctx, "reader-reconcile", keptID))
+	})
+}
+
+// goneProfiles is a ProfileDirectory that knows every profile but those
+// set to true.
+type goneProfiles map[string]bool
+
+func (g goneProfiles) ProfileExists(_ context.Context, profileID string) (bool, error) {
+	return !g[profileID], nil
+}
+
+func (s *AuthzMiddlewareTestSuite) TestReconcileTuples() {
+	ctx := s.T().Context()
+
+	kept := &types.MediaMetadata{
+		MediaID:       "media-tuples-kept",
+		OwnerID:       "owner-tuples",
+		UploadName:    "file.txt",
+		Base64Hash:    "hash-tuples-kept",
+		FileSizeBytes: 10,
+		ServerName:    "server",
+	}
+	require.NoError(s.T(), s.mediaDB.StoreMediaMetadata(ctx, kept))
+	keptID := string(kept.MediaID)
+
+	// Tuples on a media whose record is gone and on a folder of a profile
+	// without media, next to tuples on a media and a folder that exist.
+	goneID := "media-tuples-gone"
+	require.NoError(s.T(), s.middleware.GrantFileAccess(ctx, "owner-tuples", goneID, UserPrincipal("reader-tuples"), "viewer", nil))
+	require.NoError(s.T(), s.middleware.GrantFolderAccess(ctx, "owner-tuples-gone", "shared", UserPrincipal("reader-tuples"), "viewer", nil))
+	require.NoError(s.T(), s.middleware.GrantFileAccess(ctx, "owner-tuples", keptID, UserPrincipal("reader-tuples"), "viewer", nil))
+	require.NoError(s.T(), s.middleware.GrantFolderAccess(ctx, "owner-tuples", "shared", UserPrincipal("reader-tuples"), "viewer", nil))
+
+	var tuples []security.RelationTuple
+	for _, object := range []security.ObjectRef{
+		{Namespace: NamespaceFile, ID: goneID},
+		{Namespace: NamespaceFile, ID: keptID},
+		{Namespace: NamespaceFolder, ID: FolderObjectID("owner-tuples-gone", "shared")},
+		{Namespace: NamespaceFolder, ID: FolderObjectID("owner-tuples", "shared")},
+	} {
+		objectTuples, err := s.authorizer.ListRelations(ctx, object)
+		require.NoError(s.T(), err)
+		require.NotEmpty(s.T(), objectTuples)
+		tuples = append(tuples, objectTuples...)
+	}
+
+	s.T().Run("dry_run_reports", func(t *testing.T) {
+		issues, err := s.middleware.ReconcileTuples(ctx, tuples, false)
+		require.NoError(t, err)
+		require.Len(t, issues, 2)
+		for _, issue := range issues {
+			assert.Equal(t, IssueOrphanedTuple, issue.Kind)
+			assert.False(t, issue.Repaired)
+			assert.NotEqual(t, keptID, issue.Tuple.Object.ID)
+			assert.NotEqual(t, FolderObjectID("owner-tuples", "shared"), issue.Tuple.Object.ID)
+		}
+	})
+
+	s.T().Run("repair", func(t *testing.T) {
+		issues, err := s.middleware.ReconcileTuples(ctx, tuples, true)
+		require.NoError(t, err)
+		for _, issue := range issues {
+			assert.True(t, issue.Repaired)
+		}
+
+		gone, err := s.authorizer.ListRelations(ctx, security.ObjectRef{Namespace: NamespaceFile, ID: goneID})
+		require.NoError(t, err)
+		assert.Empty(t, gone)
+		grants, err := s.mediaDB.ListAccessGrants(ctx, NamespaceFolder, []string{FolderObjectID("owner-tuples-gone", "shared")})
+		require.NoError(t, err)
+		assert.Empty(t, grants)
+		require.NoError(t, s.middleware.CanViewFile(ctx, "reader-tuples", keptID))
+	})
+}
+
+func (s *AuthzMiddlewareTestSuite) TestTenantAdmin() {
+	ctx := s.T().Context()
+	admin, ok := s.middleware.(TenantAdmin)
+	require.True(s.T(), ok)
+
+	tenantCtx := func(profileID string) context.Context {
+		claims := &security.AuthenticationClaims{
+			TenantID:         "tenant-admin",
+			PartitionID:      "partition-admin",
+			RegisteredClaims: jwt.RegisteredClaims{Subject: profileID},
+		}
+		return claims.ClaimsToContext(ctx)
+	}
+	require.NoError(s.T(), s.authorizer.WriteTuple(ctx, BuildTenantAdminTuple("tenant-admin/partition-admin", "admin-user")))
+	require.NoError(s.T(), s.authorizer.WriteTuple(ctx, BuildAccessTuple("tenant-admin/partition-admin", "member-user")))
+
+	media := &types.MediaMetadata{
+		MediaID:       "media-tenant-admin",
+		OwnerID:       "owner-tenant-admin",
+		UploadName:    "file.txt",
+		Base64Hash:    "hash-tenant-admin",
+		FileSizeBytes: 10,
+		ServerName:    "server",
+	}
+	require.NoError(s.T(), s.mediaDB.StoreMediaMetadata(ctx, media))
+	mediaID := string(media.MediaID)
+
+	s.T().Run("admin_of_partition", func(t *testing.T) {
+		tenancy, err := admin.CheckTenantAdmin(tenantCtx("admin-user"), "admin-user")
+		require.NoError(t, err)
+		assert.Equal(t, "tenant-admin/partition-admin", tenancy)
+	})
+
+	s.T().Run("members_are_not_admins", func(t *testing.T) {
+		_, err := admin.CheckTenantAdmin(tenantCtx("member-user"), "member-user")
+		require.ErrorIs(t, err, ErrNotTenantAdmin)
+		_, err = admin.CheckTenantAdmin(ctx, "admin-user")
+		require.ErrorIs(t, err, ErrNotTenantAdmin)
+	})
+
+	s.T().Run("partition_members", func(t *testing.T) {
+		require.NoError(t, s.middleware.CheckPartitionMember(tenantCtx("admin-user"), "member-user"))
+		require.NoError(t, s.middleware.CheckPartitionMember(tenantCtx("member-user"), "admin-user"))
+		require.NoError(t, s.middleware.CheckPartitionMember(ctx, "member-user"))
+		require.ErrorIs(t, s.middleware.CheckPartitionMember(tenantCtx("admin-user"), "stranger-user"), ErrNotPartitionMember)
+		require.ErrorIs(t, s.middleware.CheckPartitionMember(ctx, "stranger-user"), ErrNotPartitionMember)
+	})
+
+	s.T().Run("file_checks_ignore_admins", func(t *testing.T) {
+		require.Error(t, s.middleware.CanViewFile(ctx, "admin-user", mediaID))
+	})
+
+	s.T().Run("grant_as_admin", func(t *testing.T) {
+		require.ErrorIs(t, admin.GrantFileAccessAsAdmin(ctx, "admin-user", mediaID, UserPrincipal("reader-user"), "owner", nil), ErrInvalidRelation)
+
+		require.NoError(t, admin.GrantFileAccessAsAdmin(ctx, "admin-user", mediaID, UserPrincipal("reader-user"), "viewer", nil))
+		require.NoError(t, s.middleware.CanViewFile(ctx, "reader-user", mediaID))
+
+		grants, err := s.middleware.ListFileAccessGrants(ctx, string(media.OwnerID), mediaID)
+		require.NoError(t, err)
+		assert.Equal(t, "admin-user", findGrant(t, grants, "reader-user").GrantedBy)
+	})
+}
+
+func (s *AuthzMiddlewareTestSuite) TestProfileEraser() {
+	ctx := s.T().Context()
+	eraser, ok := s.middleware.(ProfileEraser)
+	require.True(s.T(), ok)
+
+	store := func(mediaID, ownerID, folder string) string {
+		media := &types.MediaMetadata{
+			MediaID:       types.MediaID(mediaID),
+			OwnerID:       types.OwnerID(ownerID),
+			UploadName:    "file.txt",
+			Base64Hash:    types.Base64Hash("hash-" + mediaID),
+			FileSizeBytes: 10,
+			ServerName:    "server",
+			Folder:        folder,
+		}
+		require.NoError(s.T(), s.mediaDB.StoreMediaMetadata(ctx, media))
+		require.NoError(s.T(), s.authorizer.WriteTuple(ctx, fileOwnerTuple(mediaID, ownerID)))
+		require.NoError(s.T(), s.middleware.SetFileFolder(ctx, ownerID, mediaID, "", folder))
+		return mediaID
+	}
+	erased := store("media-erased", "owner-erased", "docs")
+	held := store("media-held", "owner-erased", "docs")
+	theirs := store("media-theirs", "owner-other", "")
+
+	require.NoError(s.T(), s.middleware.GrantFileAccess(ctx, "owner-erased", erased, UserPrincipal("erased-reader"), "viewer", nil))
+	require.NoError(s.T(), s.middleware.GrantFileAccess(ctx, "owner-erased", held, UserPrincipal("erased-reader"), "viewer", nil))
+	require.NoError(s.T(), s.middleware.GrantFolderAccess(ctx, "owner-erased", "docs", UserPrincipal("folder-reader"), "viewer", nil))
+	require.NoError(s.T(), s.middleware.GrantFileAccess(ctx, "owner-other", theirs, UserPrincipal("owner-erased"), "editor", nil))
+
+	s.T().Run("erased_file", func(t *testing.T) {
+		revoked, err := eraser.RemoveFileRelations(ctx, erased, false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, revoked)
+		tuples, err := s.authorizer.ListRelations(ctx, security.ObjectRef{Namespace: NamespaceFile, ID: erased})
+		require.NoError(t, err)
+		assert.Empty(t, tuples)
+	})
+
+	s.T().Run("held_file_keeps_ownership", func(t *testing.T) {
+		revoked, err := eraser.RemoveFileRelations(ctx, held, true)
+		require.NoError(t, err)
+		assert.Equal(t, 1, revoked)
+		require.Error(t, s.middleware.CanViewFile(ctx, "erased-reader", held))
+		owner, err := s.middleware.GetFileOwner(ctx, held)
+		require.NoError(t, err)
+		assert.Equal(t, "owner-erased", owner)
+	})
+
+	s.T().Run("folder", func(t *testing.T) {
+		revoked, err := eraser.RemoveFolderRelations(ctx, "owner-erased", "docs", true)
+		require.NoError(t, err)
+		assert.Equal(t, 1, revoked)
+		require.Error(t, s.middleware.CanViewFile(ctx, "folder-reader", held))
+	})
+
+	s.T().Run("received_grants", func(t *testing.T) {
+		require.NoError(t, s.middleware.CanEditFile(ctx, "owner-erased", theirs))
+		revoked, err := eraser.RevokeReceivedGrants(ctx, "owner-erased")
+		require.NoError(t, err)
+		assert.Equal(t, 1, revoked)
+		require.Error(t, s.middleware.CanEditFile(ctx, "owner-erased", theirs))
+
+		records, err := s.mediaDB.ListAccessGrants(grantRecordsContext(ctx), NamespaceFile, []string{theirs})
+		require.NoError(t, err)
+		assert.Empty(t, records)
+	})
+}
+
 func (s *AuthzMiddlewareTestSuite) TestPermissionHelpersAndListings() {
 	ctx := s.T().Context()
 
@@ -359,21 +853,21 @@ func (s *AuthzMiddlewareTestSuite) TestOwnershipAndRoleValidationErrors() {
 		{
 			name: "grant_denied_for_non_owner",
 			run: func(_ *testing.T) error {
-				return s.middleware.GrantFileAccess(ctx, "not-owner", "media-5", "viewer-5", "viewer")
+				return s.middleware.GrantFileAccess(ctx, "not-owner", "media-5", UserPrincipal("viewer-5"), "viewer", nil)
 			},
 			expectErr: ErrNotOwner,
 		},
 		{
 			name: "grant_rejects_invalid_role",
 			run: func(_ *testing.T) error {
-				return s.middleware.GrantFileAccess(ctx, "owner-5", "media-5", "viewer-5", "bad-role")
+				return s.middleware.GrantFileAccess(ctx, "owner-5", "media-5", UserPrincipal("viewer-5"), "bad-role", nil)
 			},
 			expectErr: ErrInvalidRelation,
 		},
 		{
 			name: "revoke_denied_for_non_owner",
 			run: func(_ *testing.T) error {
-				return s.middleware.RevokeFileAccess(ctx, "not-owner", "media-5", "viewer-5")
+				return s.middleware.RevokeFileAccess(ctx, "not-owner", "media-5", UserPrincipal("viewer-5"))
 			},
 			expectErr: ErrNotOwner,
 		},
@@ -0,0 +1,133 @@
+package authz
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/pitabwire/frame/v2/security/authorizer"
+	"github.com/pitabwire/util"
+)
+
+// ErrNotPartitionMember is returned when files are transferred to a profile
+// that does not belong to the tenant partition.
+var ErrNotPartitionMember = errors.New("the new owner is not a member of the tenant partition")
+
+// fileOwnerTuple names the owner of a file in Keto. Checks made here go by
+// the OwnerID of the media; the tuple is for services checking Keto directly.
+func fileOwnerTuple(fileID, ownerProfileID string) security.RelationTuple {
+	return security.RelationTuple{
+		Object:   security.ObjectRef{Namespace: NamespaceFile, ID: fileID},
+		Relation: RelationOwner,
+		Subject:  UserPrincipal(ownerProfileID).Subject(),
+	}
+}
+
+// relinkFileOwner moves a file's owner tuple from one owner to the other and
+// its parent relation from one owner's folder to the other owner's folder of
+// the same path. Files in the root folder have no parent.
+func (m *middleware) relinkFileOwner(ctx context.Context, fromOwner, toOwner, fileID, folder string) error {
+	file := security.ObjectRef{Namespace: NamespaceFile, ID: fileID}
+	removed := []security.RelationTuple{fileOwnerTuple(fileID, fromOwner)}
+	added := []security.RelationTuple{fileOwnerTuple(fileID, toOwner)}
+	if folder != "" {
+		removed = append(removed, folderParentTuple(file, fromOwner, folder))
+		added = append(added, folderParentTuple(file, toOwner, folder))
+		added = append(added, folderChainTuples(toOwner, folder)...)
+	}
+	if err := m.authorizer.DeleteTuples(ctx, removed); err != nil {
+		return err
+	}
+	return m.authorizer.WriteTuples(ctx, added)
+}
+
+// CheckPartitionMember verifies that a profile belongs, as a member or an
+// administrator, to the tenant partition of the caller's claims. Callers
+// without a partition in their claims may name a profile of any partition.
+func (m *middleware) CheckPartitionMember(ctx context.Context, profileID string) error {
+	if profileID == "" {
+		return authorizer.ErrInvalidSubject
+	}
+	tenancyPath := ""
+	if claims := security.ClaimsFromContext(ctx); claims != nil && claims.GetTenantID() != "" && claims.GetPartitionID() != "" {
+		tenancyPath = claims.GetTenantID() + "/" + claims.GetPartitionID()
+	}
+
+	tuples, err := m.authorizer.ListSubjectRelations(ctx, UserPrincipal(profileID).Subject(), NamespaceTenancyAccess)
+	if err != nil {
+		return err
+	}
+	for _, t := range tuples {
+		if t.Relation != RoleMember && t.Relation != RoleTenantAdmin {
+			continue
+		}
+		if tenancyPath == "" || t.Object.ID == tenancyPath {
+			return nil
+		}
+	}
+	return ErrNotPartitionMember
+}
+
+// TransferFileOwnership moves a file's relations to a new owner ahead of its
+// OwnerID changing. The owner tuple moves, the file is relinked under the
+// new owner's folder of the same path and, when previousOwnerRole is set, the
+// previous owner is granted that role on it. Grants made on the file itself
+// carry over; grants on the previous owner's folders stay with those folders.
+func (m *middleware) TransferFileOwnership(ctx context.Context, fromOwner, toOwner, fileID, folder, previousOwnerRole string) error {
+	relation := ""
+	if previousOwnerRole != "" {
+		relation = RoleToRelation(previousOwnerRole)
+		if relation == "" {
+			return ErrInvalidRelation
+		}
+	}
+
+	util.Log(ctx).WithFields(map[string]any{
+		"from_owner_id": fromOwner,
+		"to_owner_id":   toOwner,
+		"file_id":       fileID,
+		"folder":        folder,
+	}).Debug("transferring file ownership")
+
+	if err := m.relinkFileOwner(ctx, fromOwner, toOwner, fileID, folder); err != nil {
+		return err
+	}
+	if relation == "" {
+		return nil
+	}
+
+	previous := UserPrincipal(fromOwner)
+	err := m.writeGrant(ctx, toOwner, security.RelationTuple{
+		Object:   security.ObjectRef{Namespace: NamespaceFile, ID: fileID},
+		Relation: relation,
+		Subject:  previous.Subject(),
+	}, previous, nil)
+	if err != nil {
+		if undoErr := m.relinkFileOwner(ctx, toOwner, fromOwner, fileID, folder); undoErr != nil {
+			util.Log(ctx).WithError(undoErr).With("file_id", fileID).Warn("failed to relink file to its owner")
+		}
+		return err
+	}
+	return nil
+}
+
+// RestoreFileOwnership undoes TransferFileOwnership for a file whose OwnerID
+// could not be changed, dropping the role given to the previous owner.
+func (m *middleware) RestoreFileOwnership(ctx context.Context, fromOwner, toOwner, fileID, folder, previousOwnerRole string) error {
+	if relation := RoleToRelation(previousOwnerRole); relation != "" {
+		previous := UserPrincipal(fromOwner)
+		err := m.authorizer.DeleteTuple(ctx, security.RelationTuple{
+			Object:   security.ObjectRef{Namespace: NamespaceFile, ID: fileID},
+			Relation: relation,
+			Subject:  previous.Subject(),
+		})
+		if err != nil {
+			return err
+		}
+		err = m.mediaDB.DeleteAccessGrants(grantRecordsContext(ctx), NamespaceFile, fileID, previous.Kind, previous.ID, []string{relation})
+		if err != nil {
+			return err
+		}
+	}
+	return m.relinkFileOwner(ctx, toOwner, fromOwner, fileID, folder)
+}
@@ -0,0 +1,103 @@
+package authz
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/pitabwire/frame/v2/security"
+)
+
+// Principal kinds that can be granted access to a file.
+const (
+	// PrincipalUser is a single profile.
+	PrincipalUser = "user"
+	// PrincipalGroup is every member of a group, following its membership.
+	PrincipalGroup = "group"
+	// PrincipalTenancy is every member of a tenant partition, identified by
+	// its tenancy path "<tenant_id>/<partition_id>".
+	PrincipalTenancy = "tenancy"
+)
+
+// ErrInvalidPrincipal is returned for principals that cannot be granted access.
+var ErrInvalidPrincipal = errors.New("invalid principal")
+
+// Principal is a grantee of file access: a profile, or a set of profiles
+// whose membership Keto resolves at check time.
+type Principal struct {
+	Kind string
+	ID   string
+}
+
+// UserPrincipal returns the principal of a single profile.
+func UserPrincipal(profileID string) Principal {
+	return Principal{Kind: PrincipalUser, ID: profileID}
+}
+
+// ParsePrincipal builds a principal from an ID and kind. Without a kind it
+// is inferred from a "user:", "group:" or "tenancy:" prefix on the ID,
+// defaulting to a user.
+func ParsePrincipal(kind, id string) (Principal, error) {
+	if kind == "" {
+		kind = PrincipalUser
+		if prefix, rest, ok := strings.Cut(id, ":"); ok {
+			switch prefix {
+			case PrincipalUser, PrincipalGroup, PrincipalTenancy:
+				kind, id = prefix, rest
+			}
+		}
+	} else {
+		id = strings.TrimPrefix(id, kind+":")
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return Principal{}, ErrInvalidPrincipal
+	}
+	switch kind {
+	case PrincipalUser, PrincipalGroup:
+	case PrincipalTenancy:
+		tenantID, partitionID, ok := strings.Cut(id, "/")
+		if !ok || tenantID == "" || partitionID == "" || strings.Contains(partitionID, "/") {
+			return Principal{}, ErrInvalidPrincipal
+		}
+	default:
+		return Principal{}, ErrInvalidPrincipal
+	}
+	return Principal{Kind: kind, ID: id}, nil
+}
+
+// TenantID returns the tenant of a tenancy principal.
+func (p Principal) TenantID() string {
+	if p.Kind != PrincipalTenancy {
+		return ""
+	}
+	tenantID, _, _ := strings.Cut(p.ID, "/")
+	return tenantID
+}
+
+// Subject returns the Keto subject the principal is granted as.
+func (p Principal) Subject() security.SubjectRef {
+	switch p.Kind {
+	case PrincipalGroup:
+		return security.SubjectRef{Namespace: NamespaceGroup, ID: p.ID, Relation: RoleMember}
+	case PrincipalTenancy:
+		return security.SubjectRef{Namespace: NamespaceTenancyAccess, ID: p.ID, Relation: RoleMember}
+	default:
+		return security.SubjectRef{Namespace: NamespaceProfile, ID: p.ID}
+	}
+}
+
+// PrincipalFromSubject maps a Keto subject back to a principal. Subjects
+// that no grant produces are reported as not ok.
+func PrincipalFromSubject(subject security.SubjectRef) (Principal, bool) {
+	switch {
+	case subject.Relation == "" && (subject.Namespace == "" || subject.Namespace == NamespaceProfile):
+		return UserPrincipal(subject.ID), true
+	case subject.Namespace == NamespaceGroup && subject.Relation == RoleMember:
+		return Principal{Kind: PrincipalGroup, ID: subject.ID}, true
+	case subject.Namespace == NamespaceTenancyAccess && subject.Relation == RoleMember:
+		return Principal{Kind: PrincipalTenancy, ID: subject.ID}, true
+	default:
+		return Principal{}, false
+	}
+}
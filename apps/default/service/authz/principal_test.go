@@ -0,0 +1,65 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePrincipal(t *testing.T) {
+	tests := []struct {
+		name string
+		kind string
+		id   string
+		want Principal
+	}{
+		{"bare_id_is_user", "", "profile-1", Principal{Kind: PrincipalUser, ID: "profile-1"}},
+		{"user_prefix", "", "user:profile-1", Principal{Kind: PrincipalUser, ID: "profile-1"}},
+		{"group_prefix", "", "group:team-a", Principal{Kind: PrincipalGroup, ID: "team-a"}},
+		{"tenancy_prefix", "", "tenancy:t1/p1", Principal{Kind: PrincipalTenancy, ID: "t1/p1"}},
+		{"explicit_group", PrincipalGroup, "team-a", Principal{Kind: PrincipalGroup, ID: "team-a"}},
+		{"explicit_tenancy_with_prefix", PrincipalTenancy, "tenancy:t1/p1", Principal{Kind: PrincipalTenancy, ID: "t1/p1"}},
+		{"unknown_prefix_stays_in_id", "", "@alice:example.com", Principal{Kind: PrincipalUser, ID: "@alice:example.com"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParsePrincipal(tc.kind, tc.id)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	for _, bad := range [][2]string{
+		{"", ""},
+		{"", "group:"},
+		{PrincipalTenancy, "t1"},
+		{PrincipalTenancy, "t1/p1/x"},
+		{PrincipalTenancy, "/p1"},
+		{"service", "svc"},
+	} {
+		_, err := ParsePrincipal(bad[0], bad[1])
+		assert.ErrorIs(t, err, ErrInvalidPrincipal, bad[1])
+	}
+}
+
+func TestPrincipalSubject(t *testing.T) {
+	for _, p := range []Principal{
+		UserPrincipal("profile-1"),
+		{Kind: PrincipalGroup, ID: "team-a"},
+		{Kind: PrincipalTenancy, ID: "t1/p1"},
+	} {
+		got, ok := PrincipalFromSubject(p.Subject())
+		require.True(t, ok, p.Kind)
+		assert.Equal(t, p, got)
+	}
+
+	assert.Equal(t, security.SubjectRef{Namespace: NamespaceTenancyAccess, ID: "t1/p1", Relation: RoleMember},
+		Principal{Kind: PrincipalTenancy, ID: "t1/p1"}.Subject())
+	assert.Equal(t, "t1", Principal{Kind: PrincipalTenancy, ID: "t1/p1"}.TenantID())
+
+	_, ok := PrincipalFromSubject(security.SubjectRef{Namespace: NamespaceTenancyAccess, ID: "t1/p1", Relation: RoleService})
+	assert.False(t, ok, "service memberships are not file grants")
+}
@@ -0,0 +1,77 @@
+package authz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ProfileDirectory tells whether the profile service still knows a profile.
+// Grants to profiles it no longer knows are reported by ReconcileOwnership.
+type ProfileDirectory interface {
+	ProfileExists(ctx context.Context, profileID string) (bool, error)
+}
+
+// ProfileReconciler is implemented by a Middleware that checks the profiles
+// named by grants against a ProfileDirectory during reconciliation. Without
+// a directory profiles are taken to exist.
+type ProfileReconciler interface {
+	SetProfileDirectory(directory ProfileDirectory)
+}
+
+func (m *middleware) SetProfileDirectory(directory ProfileDirectory) {
+	m.profiles = directory
+}
+
+// profileGetByIDProcedure is the profile service call looking a profile up.
+const profileGetByIDProcedure = "/profile.v1.ProfileService/GetById"
+
+type profileServiceDirectory struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProfileServiceDirectory looks profiles up through the profile service
+// at serviceURI, calling GetById with the Connect protocol's JSON encoding.
+func NewProfileServiceDirectory(serviceURI string, client *http.Client) ProfileDirectory {
+	return &profileServiceDirectory{
+		endpoint: strings.TrimRight(serviceURI, "/") + profileGetByIDProcedure,
+		client:   client,
+	}
+}
+
+// ProfileExists reports a profile as gone only when the profile service
+// answers not_found; any other failure is returned so that grants are never
+// removed because the service could not be reached.
+func (d *profileServiceDirectory) ProfileExists(ctx context.Context, profileID string) (bool, error) {
+	body, err := json.Marshal(map[string]string{"id": profileID})
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusOK {
+		return true, nil
+	}
+	var connectErr struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	if decodeErr := json.NewDecoder(resp.Body).Decode(&connectErr); decodeErr == nil && connectErr.Code == "not_found" {
+		return false, nil
+	}
+	return false, fmt.Errorf("profile lookup failed: %s %s", resp.Status, connectErr.Message)
+}
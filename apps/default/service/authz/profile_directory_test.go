@@ -0,0 +1,52 @@
+package authz
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileServiceDirectory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != profileGetByIDProcedure {
+			http.NotFound(w, r)
+			return
+		}
+		var req struct {
+			ID string `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		switch req.ID {
+		case "known":
+			_, _ = w.Write([]byte(`{"data":{"id":"known"}}`))
+		case "gone":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"not_found","message":"profile not found"}`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"code":"unavailable","message":"try again"}`))
+		}
+	}))
+	defer server.Close()
+
+	directory := NewProfileServiceDirectory(server.URL+"/", server.Client())
+
+	exists, err := directory.ProfileExists(t.Context(), "known")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = directory.ProfileExists(t.Context(), "gone")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// Failures other than not_found never report a profile as gone.
+	_, err = directory.ProfileExists(t.Context(), "unreachable")
+	require.Error(t, err)
+	_, err = NewProfileServiceDirectory(server.URL+"/elsewhere", server.Client()).ProfileExists(t.Context(), "gone")
+	require.Error(t, err)
+}
@@ -0,0 +1,282 @@
+package authz
+
+import (
+	"context"
+
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/pitabwire/util"
+)
+
+// Kinds of difference ReconcileOwnership finds between media and Keto.
+const (
+	// IssueMissingOwnerTuple is a media whose owner has no owner tuple.
+	IssueMissingOwnerTuple = "missing_owner_tuple"
+	// IssueStaleOwnerTuple is an owner tuple for a profile that neither owns
+	// the media nor was granted ownership of it.
+	IssueStaleOwnerTuple = "stale_owner_tuple"
+	// IssueDeletedMediaTuple is a tuple left on a deleted media.
+	IssueDeletedMediaTuple = "deleted_media_tuple"
+	// IssueMissingParentTuple is a media filed in a folder that is not
+	// linked to its owner's folder of that path.
+	IssueMissingParentTuple = "missing_parent_tuple"
+	// IssueStaleParentTuple is a link from a media to a folder it is not
+	// filed in.
+	IssueStaleParentTuple = "stale_parent_tuple"
+	// IssueUnknownSubject is a grant to a group or tenant partition Keto
+	// holds no tuples for any more, or to a profile the profile service no
+	// longer knows.
+	IssueUnknownSubject = "unknown_subject"
+	// IssueOrphanedTuple is a tuple on a media whose record is gone, or on
+	// a folder of a profile that no longer has any media.
+	IssueOrphanedTuple = "orphaned_tuple"
+)
+
+// ReconcileIssue is one difference between a media and its Keto tuples.
+// Tuple is the tuple that is missing or should not be there. FileID is
+// empty for tuples on folders.
+type ReconcileIssue struct {
+	Kind     string
+	FileID   string
+	Tuple    security.RelationTuple
+	Repaired bool
+}
+
+// ReconcileReport is the outcome of reconciling one batch of media. LastID
+// resumes the walk; a batch shorter than asked for ends it.
+type ReconcileReport struct {
+	Scanned int
+	LastID  string
+	Issues  []ReconcileIssue
+}
+
+// ReconcileOwnership compares the media with IDs after afterID, deleted or
+// not, with their tuples in Keto. With repair set each difference is fixed;
+// a difference that cannot be fixed is reported as not repaired.
+func (m *middleware) ReconcileOwnership(ctx context.Context, afterID string, limit int, repair bool) (*ReconcileReport, error) {
+	media, err := m.mediaDB.ListMediaOwnership(ctx, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	report := &ReconcileReport{Scanned: len(media)}
+	if len(media) == 0 {
+		return report, nil
+	}
+	report.LastID = string(media[len(media)-1].MediaID)
+
+	fileIDs := make([]string, len(media))
+	for i, item := range media {
+		fileIDs[i] = string(item.MediaID)
+	}
+	records, err := m.mediaDB.ListAccessGrants(grantRecordsContext(ctx), NamespaceFile, fileIDs)
+	if err != nil {
+		return nil, err
+	}
+	recorded := make(map[grantKey]bool, len(records))
+	for _, record := range records {
+		recorded[grantKey{record.ObjectID, record.Relation, Principal{Kind: record.PrincipalKind, ID: record.PrincipalID}}] = true
+	}
+
+	subjects := map[security.SubjectRef]bool{}
+	for _, item := range media {
+		tuples, listErr := m.authorizer.ListRelations(ctx, security.ObjectRef{Namespace: NamespaceFile, ID: string(item.MediaID)})
+		if listErr != nil {
+			return nil, listErr
+		}
+
+		var issues []ReconcileIssue
+		if item.Deleted {
+			for _, t := range tuples {
+				issues = append(issues, ReconcileIssue{Kind: IssueDeletedMediaTuple, FileID: string(item.MediaID), Tuple: t})
+			}
+		} else {
+			issues, err = m.fileIssues(ctx, item, tuples, recorded, subjects)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if repair {
+			m.repairIssues(ctx, issues)
+		}
+		report.Issues = append(report.Issues, issues...)
+	}
+	return report, nil
+}
+
+// ReconcileTuples checks tuples read from a walk of the file or file_folder
+// namespace for objects that no longer exist: media whose record is gone,
+// deleted or not, and folders of profiles without any media. Tuples on
+// media that exist are left to ReconcileOwnership.
+func (m *middleware) ReconcileTuples(ctx context.Context, tuples []security.RelationTuple, repair bool) ([]ReconcileIssue, error) {
+	var issues []ReconcileIssue
+	var fileTuples []security.RelationTuple
+	for _, t := range tuples {
+		switch t.Object.Namespace {
+		case NamespaceFile:
+			fileTuples = append(fileTuples, t)
+		case NamespaceFolder:
+			owner, _, ok := parseFolderObjectID(t.Object.ID)
+			orphaned := !ok
+			if ok {
+				media, err := m.mediaDB.ListOwnerMediaWithDeleted(ctx, types.OwnerID(owner), "", 1)
+				if err != nil {
+					return nil, err
+				}
+				orphaned = len(media) == 0
+			}
+			if orphaned {
+				issues = append(issues, ReconcileIssue{Kind: IssueOrphanedTuple, Tuple: t})
+			}
+		}
+	}
+
+	if len(fileTuples) > 0 {
+		fileIDs := make([]types.MediaID, 0, len(fileTuples))
+		for _, t := range fileTuples {
+			fileIDs = append(fileIDs, types.MediaID(t.Object.ID))
+		}
+		existing, err := m.mediaDB.ListMediaOwnershipByID(ctx, fileIDs)
+		if err != nil {
+			return nil, err
+		}
+		found := make(map[string]bool, len(existing))
+		for _, item := range existing {
+			found[string(item.MediaID)] = true
+		}
+		for _, t := range fileTuples {
+			if !found[t.Object.ID] {
+				issues = append(issues, ReconcileIssue{Kind: IssueOrphanedTuple, FileID: t.Object.ID, Tuple: t})
+			}
+		}
+	}
+
+	if repair {
+		m.repairIssues(ctx, issues)
+	}
+	return issues, nil
+}
+
+// repairIssues repairs each issue, marking those repaired. Issues that cannot
+// be repaired are logged and left for the next reconciliation.
+func (m *middleware) repairIssues(ctx context.Context, issues []ReconcileIssue) {
+	for i := range issues {
+		if err := m.repairIssue(ctx, issues[i]); err != nil {
+			util.Log(ctx).WithError(err).With("file_id", issues[i].FileID, "kind", issues[i].Kind).
+				Warn("failed to repair ownership difference")
+			continue
+		}
+		issues[i].Repaired = true
+	}
+}
+
+// fileIssues compares the tuples of a media that exists with its owner, the
+// folder it is filed in and the grants recorded on it, and checks that the
+// subjects granted access still exist.
+func (m *middleware) fileIssues(ctx context.Context, item *types.MediaOwnership, tuples []security.RelationTuple,
+	recorded map[grantKey]bool, subjects map[security.SubjectRef]bool) ([]ReconcileIssue, error) {
+	fileID := string(item.MediaID)
+	owner := UserPrincipal(string(item.OwnerID))
+	var parent *security.RelationTuple
+	if item.Folder != "" && item.OwnerID != "" {
+		expected := folderParentTuple(security.ObjectRef{Namespace: NamespaceFile, ID: fileID}, owner.ID, item.Folder)
+		parent = &expected
+	}
+
+	var issues []ReconcileIssue
+	hasOwner, hasParent := false, false
+	for _, t := range tuples {
+		if t.Relation == RelationParent {
+			if parent != nil && t.Subject.Namespace == parent.Subject.Namespace && t.Subject.ID == parent.Subject.ID {
+				hasParent = true
+				continue
+			}
+			issues = append(issues, ReconcileIssue{Kind: IssueStaleParentTuple, FileID: fileID, Tuple: t})
+			continue
+		}
+		principal, ok := PrincipalFromSubject(t.Subject)
+		if !ok {
+			continue
+		}
+		if principal == owner && t.Relation == RelationOwner {
+			hasOwner = true
+			continue
+		}
+		if t.Relation == RelationOwner && !recorded[grantKey{fileID, t.Relation, principal}] {
+			issues = append(issues, ReconcileIssue{Kind: IssueStaleOwnerTuple, FileID: fileID, Tuple: t})
+			continue
+		}
+		if principal == owner {
+			continue
+		}
+		exists, err := m.subjectExists(ctx, principal, subjects)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			issues = append(issues, ReconcileIssue{Kind: IssueUnknownSubject, FileID: fileID, Tuple: t})
+		}
+	}
+
+	if !hasOwner && item.OwnerID != "" {
+		issues = append(issues, ReconcileIssue{Kind: IssueMissingOwnerTuple, FileID: fileID, Tuple: fileOwnerTuple(fileID, owner.ID)})
+	}
+	if parent != nil && !hasParent {
+		issues = append(issues, ReconcileIssue{Kind: IssueMissingParentTuple, FileID: fileID, Tuple: *parent})
+	}
+	return issues, nil
+}
+
+// subjectExists tells whether a principal granted access still exists: a
+// group or tenant partition Keto holds tuples for, or a profile the profile
+// directory knows. Answers are kept in subjects for the rest of the batch.
+func (m *middleware) subjectExists(ctx context.Context, principal Principal, subjects map[security.SubjectRef]bool) (bool, error) {
+	subject := principal.Subject()
+	if exists, ok := subjects[subject]; ok {
+		return exists, nil
+	}
+
+	exists := true
+	switch principal.Kind {
+	case PrincipalUser:
+		if m.profiles != nil {
+			found, err := m.profiles.ProfileExists(ctx, principal.ID)
+			if err != nil {
+				return false, err
+			}
+			exists = found
+		}
+	case PrincipalGroup, PrincipalTenancy:
+		tuples, err := m.authorizer.ListRelations(ctx, security.ObjectRef{Namespace: subject.Namespace, ID: subject.ID})
+		if err != nil {
+			return false, err
+		}
+		exists = len(tuples) > 0
+	}
+	subjects[subject] = exists
+	return exists, nil
+}
+
+// repairIssue writes a missing tuple, or deletes a tuple that should not be
+// there together with the record of the grant it stands for. A missing
+// parent is written with the chain linking its folder to the folders above.
+func (m *middleware) repairIssue(ctx context.Context, issue ReconcileIssue) error {
+	switch issue.Kind {
+	case IssueMissingOwnerTuple:
+		return m.authorizer.WriteTuple(ctx, issue.Tuple)
+	case IssueMissingParentTuple:
+		owner, folder, ok := parseFolderObjectID(issue.Tuple.Subject.ID)
+		if !ok {
+			return ErrInvalidFolder
+		}
+		return m.authorizer.WriteTuples(ctx, append([]security.RelationTuple{issue.Tuple}, folderChainTuples(owner, folder)...))
+	}
+	if err := m.authorizer.DeleteTuple(ctx, issue.Tuple); err != nil {
+		return err
+	}
+	principal, ok := PrincipalFromSubject(issue.Tuple.Subject)
+	if !ok {
+		return nil
+	}
+	return m.mediaDB.DeleteAccessGrants(grantRecordsContext(ctx), issue.Tuple.Object.Namespace, issue.Tuple.Object.ID,
+		principal.Kind, principal.ID, []string{issue.Tuple.Relation})
+}
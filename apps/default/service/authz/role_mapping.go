@@ -14,6 +14,9 @@ const (
 	RoleMember = "member"
 	// RoleService is the Plane-1 relation for internal service bots.
 	RoleService = "service"
+	// RoleTenantAdmin is the Plane-1 relation for administrators of a tenant
+	// partition, who may manage every file in it.
+	RoleTenantAdmin = "admin"
 )
 
 // BuildAccessTuple creates a tenancy_access#member tuple for a user.
@@ -34,6 +37,15 @@ func BuildServiceAccessTuple(tenancyPath, profileID string) security.RelationTup
 	}
 }
 
+// BuildTenantAdminTuple creates a tenancy_access#admin tuple for a user.
+func BuildTenantAdminTuple(tenancyPath, profileID string) security.RelationTuple {
+	return security.RelationTuple{
+		Object:   security.ObjectRef{Namespace: NamespaceTenancyAccess, ID: tenancyPath},
+		Relation: RoleTenantAdmin,
+		Subject:  security.SubjectRef{Namespace: NamespaceProfile, ID: profileID},
+	}
+}
+
 // HealServiceTenancyAccess provisions a missing Plane-1 #service tuple when an
 // internal system caller is denied tenancy access. Wire with
 // authorizer.WithOnTenancyAccessDenied(authz.HealServiceTenancyAccess).
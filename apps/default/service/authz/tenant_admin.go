@@ -0,0 +1,78 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/pitabwire/frame/v2/security/authorizer"
+	"github.com/pitabwire/util"
+)
+
+// ErrNotTenantAdmin is returned when the caller does not administer the
+// tenant partition of their claims.
+var ErrNotTenantAdmin = errors.New("only a tenant administrator can perform this action")
+
+// TenantAdmin lets administrators of a tenant partition manage any file in
+// it. It is kept apart from Middleware so the ordinary file checks never
+// consider the admin relation; only the admin RPCs use it.
+type TenantAdmin interface {
+	// CheckTenantAdmin verifies the caller administers the tenant partition
+	// of their claims and returns its tenancy path.
+	CheckTenantAdmin(ctx context.Context, profileID string) (string, error)
+	// GrantFileAccessAsAdmin grants a role on a file on behalf of its owner,
+	// recording the administrator as the granter.
+	GrantFileAccessAsAdmin(ctx context.Context, adminProfileID, fileID string, principal Principal, role string, expiresAt *time.Time) error
+}
+
+func (m *middleware) CheckTenantAdmin(ctx context.Context, profileID string) (string, error) {
+	if profileID == "" {
+		return "", authorizer.ErrInvalidSubject
+	}
+	claims := security.ClaimsFromContext(ctx)
+	if claims == nil || claims.GetTenantID() == "" || claims.GetPartitionID() == "" {
+		return "", ErrNotTenantAdmin
+	}
+	tenancyPath := claims.GetTenantID() + "/" + claims.GetPartitionID()
+
+	result, err := m.authorizer.Check(ctx, security.CheckRequest{
+		Object:     security.ObjectRef{Namespace: NamespaceTenancyAccess, ID: tenancyPath},
+		Permission: RoleTenantAdmin,
+		Subject:    security.SubjectRef{Namespace: NamespaceProfile, ID: profileID},
+	})
+	if err != nil {
+		util.Log(ctx).WithError(err).With("profile_id", profileID, "tenancy", tenancyPath).
+			Error("tenant admin check failed")
+		return "", err
+	}
+	if !result.Allowed {
+		return "", ErrNotTenantAdmin
+	}
+	return tenancyPath, nil
+}
+
+func (m *middleware) GrantFileAccessAsAdmin(ctx context.Context, adminProfileID, fileID string, principal Principal, role string, expiresAt *time.Time) error {
+	relation := RoleToRelation(role)
+	if relation == "" {
+		return ErrInvalidRelation
+	}
+	// Ownership changes go through a transfer, never a grant.
+	if relation == RelationOwner {
+		return ErrInvalidRelation
+	}
+
+	util.Log(ctx).WithFields(map[string]any{
+		"admin_profile_id": adminProfileID,
+		"file_id":          fileID,
+		"principal_type":   principal.Kind,
+		"principal_id":     principal.ID,
+		"role":             role,
+	}).Info("tenant admin granting file access")
+
+	return m.writeGrant(ctx, adminProfileID, security.RelationTuple{
+		Object:   security.ObjectRef{Namespace: NamespaceFile, ID: fileID},
+		Relation: relation,
+		Subject:  principal.Subject(),
+	}, principal, expiresAt)
+}
@@ -0,0 +1,380 @@
+package business
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/util"
+)
+
+const (
+	// ArchiveManifestName is the last entry of every archive, recording the
+	// outcome for each requested file.
+	ArchiveManifestName = "manifest.json"
+
+	// MaxArchiveEntries bounds the files a single archive may hold.
+	MaxArchiveEntries = 10000
+
+	archiveFolderPageSize = 500
+)
+
+var (
+	errArchiveEmpty   = errors.New("invalid parameter: media ids or a folder are required")
+	errArchiveTooBig  = fmt.Errorf("invalid parameter: archives are limited to %d files", MaxArchiveEntries)
+	errArchiveDenied  = errors.New("permission denied")
+	errArchiveMissing = errors.New("media not found")
+	errArchiveBadID   = errors.New("invalid media id")
+)
+
+// FileViewer decides whether a profile may read a file.
+type FileViewer interface {
+	CanViewFile(ctx context.Context, profileID, fileID string) error
+}
+
+// ArchiveEntry is a file requested for an archive. Entries that could not
+// be resolved carry the reason in Err and are only listed in the manifest.
+type ArchiveEntry struct {
+	MediaID  types.MediaID
+	Metadata *types.MediaMetadata
+	// Dir is the folder of the entry within the archive.
+	Dir string
+	Err error
+}
+
+// ArchiveManifestEntry records the outcome of archiving one file.
+type ArchiveManifestEntry struct {
+	MediaID string `json:"media_id"`
+	Path    string `json:"path,omitempty"`
+	Size    int64  `json:"size,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ArchiveManifest lists every requested file in archive order.
+type ArchiveManifest struct {
+	Entries []ArchiveManifestEntry `json:"entries"`
+}
+
+// ArchiveDocument is a generated file, such as an index of the archived
+// files, written into an archive after them.
+type ArchiveDocument struct {
+	Name  string
+	Write func(w io.Writer) error
+}
+
+// ArchiveOptions extends an archive beyond the requested files.
+type ArchiveOptions struct {
+	Documents []ArchiveDocument
+	// Progress, when set, is called with the outcome of each entry.
+	Progress func(record ArchiveManifestEntry)
+}
+
+// Archiver builds zip archives of several files while streaming them. Each
+// file is decrypted as it is written, so memory use does not grow with the
+// archive; zip64 records are used once sizes or the file count outgrow the
+// classic format.
+type Archiver struct {
+	db     storage.MediaRepository
+	media  MediaService
+	viewer FileViewer
+}
+
+// NewArchiver creates an archiver reading media through media.
+func NewArchiver(db storage.MediaRepository, media MediaService, viewer FileViewer) *Archiver {
+	return &Archiver{db: db, media: media, viewer: viewer}
+}
+
+// Resolve lists the files of an archive for subject: the given media IDs in
+// order, or when there are none every file of the subject's own folder.
+// Listed media the subject may not read are kept as failed entries; files of
+// the folder the subject may not read are left out.
+func (a *Archiver) Resolve(ctx context.Context, subject string, mediaIDs []string, folder string) ([]ArchiveEntry, error) {
+	if len(mediaIDs) > 0 {
+		return a.resolveMedia(ctx, subject, mediaIDs)
+	}
+
+	folder, err := CleanFolder(folder)
+	if err != nil {
+		return nil, err
+	}
+	if folder == "" {
+		return nil, errArchiveEmpty
+	}
+	if subject == "" {
+		return nil, errArchiveDenied
+	}
+	return a.resolveFolder(ctx, subject, folder)
+}
+
+func (a *Archiver) resolveMedia(ctx context.Context, subject string, mediaIDs []string) ([]ArchiveEntry, error) {
+	if len(mediaIDs) > MaxArchiveEntries {
+		return nil, errArchiveTooBig
+	}
+
+	entries := make([]ArchiveEntry, 0, len(mediaIDs))
+	seen := make(map[string]bool, len(mediaIDs))
+	for _, mediaID := range mediaIDs {
+		if seen[mediaID] {
+			continue
+		}
+		seen[mediaID] = true
+
+		entry := ArchiveEntry{MediaID: types.MediaID(mediaID)}
+		switch {
+		case !isValidMediaID(mediaID):
+			entry.Err = errArchiveBadID
+		case a.viewer.CanViewFile(ctx, subject, mediaID) != nil:
+			entry.Err = errArchiveDenied
+		default:
+			metadata, err := a.db.GetMediaMetadata(ctx, entry.MediaID)
+			if err != nil {
+				return nil, err
+			}
+			if metadata == nil {
+				entry.Err = errArchiveMissing
+			}
+			entry.Metadata = metadata
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (a *Archiver) resolveFolder(ctx context.Context, subject string, folder string) ([]ArchiveEntry, error) {
+	var entries []ArchiveEntry
+	scanned := 0
+	afterID := ""
+	for {
+		// Folders belong to their owner, so only the subject's are listed.
+		page, err := a.db.ListOwnerMedia(ctx, types.OwnerID(subject), folder, afterID, archiveFolderPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, metadata := range page {
+			scanned++
+			if scanned > MaxArchiveEntries {
+				return nil, errArchiveTooBig
+			}
+			if a.viewer.CanViewFile(ctx, subject, string(metadata.MediaID)) != nil {
+				continue
+			}
+			entries = append(entries, ArchiveEntry{
+				MediaID:  metadata.MediaID,
+				Metadata: metadata,
+				Dir:      strings.TrimPrefix(strings.TrimPrefix(metadata.Folder, folder), "/"),
+			})
+		}
+		if len(page) < archiveFolderPageSize {
+			return entries, nil
+		}
+		afterID = string(page[len(page)-1].MediaID)
+	}
+}
+
+// Write streams a zip archive of entries to w, closing it with the
+// manifest. A file that fails to open or read is recorded in the manifest
+// and the archive carries on; an error is returned only when w or ctx
+// fails.
+func (a *Archiver) Write(ctx context.Context, w io.Writer, cfg *config.FilesConfig, entries []ArchiveEntry) error {
+	return a.WriteWithOptions(ctx, w, cfg, entries, ArchiveOptions{})
+}
+
+// WriteWithOptions streams an archive like Write, adding the documents of
+// opts before the manifest. A document that fails ends the archive.
+func (a *Archiver) WriteWithOptions(ctx context.Context, w io.Writer, cfg *config.FilesConfig, entries []ArchiveEntry, opts ArchiveOptions) error {
+	zw := zip.NewWriter(w)
+	names := archiveNames{ArchiveManifestName: true}
+	for _, document := range opts.Documents {
+		names[strings.ToLower(document.Name)] = true
+	}
+	manifest := ArchiveManifest{Entries: make([]ArchiveManifestEntry, 0, len(entries))}
+
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		record := ArchiveManifestEntry{MediaID: string(entry.MediaID)}
+		if entry.Err != nil {
+			record.Error = entry.Err.Error()
+			manifest.Entries = append(manifest.Entries, record)
+			if opts.Progress != nil {
+				opts.Progress(record)
+			}
+			continue
+		}
+
+		record.Path = names.claim(path.Join(entry.Dir, archiveFileName(entry.Metadata)))
+		size, err := a.writeEntry(ctx, zw, cfg, entry.Metadata, record.Path)
+		if err != nil {
+			var writeErr *archiveWriteError
+			if errors.As(err, &writeErr) {
+				return writeErr.err
+			}
+			util.Log(ctx).WithError(err).With("media_id", entry.MediaID).Warn("failed to archive file")
+			record.Error = err.Error()
+		}
+		record.Size = size
+		manifest.Entries = append(manifest.Entries, record)
+		if opts.Progress != nil {
+			opts.Progress(record)
+		}
+	}
+
+	for _, document := range opts.Documents {
+		documentWriter, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     document.Name,
+			Method:   zip.Deflate,
+			Modified: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		if err = document.Write(documentWriter); err != nil {
+			return err
+		}
+	}
+
+	manifestWriter, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     ArchiveManifestName,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(manifestWriter)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(manifest); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// archiveWriteError marks failures of the archive's own writer, which end
+// the archive, apart from failures reading a file, which do not.
+type archiveWriteError struct {
+	err error
+}
+
+func (e *archiveWriteError) Error() string { return e.err.Error() }
+
+func (a *Archiver) writeEntry(ctx context.Context, zw *zip.Writer, cfg *config.FilesConfig, metadata *types.MediaMetadata, name string) (int64, error) {
+	result, err := a.media.DownloadFile(ctx, &DownloadRequest{
+		MediaID:  metadata.MediaID,
+		Config:   cfg,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer util.CloseAndLogOnError(ctx, result.FileData)
+
+	method := zip.Deflate
+	if isCompressedContent(string(metadata.ContentType)) {
+		method = zip.Store
+	}
+	header := &zip.FileHeader{Name: name, Method: method}
+	if metadata.CreationTimestamp > 0 {
+		header.Modified = time.UnixMilli(int64(metadata.CreationTimestamp))
+	}
+	fw, err := zw.CreateHeader(header)
+	if err != nil {
+		return 0, &archiveWriteError{err: err}
+	}
+
+	// A file failing part way leaves a truncated entry behind; the
+	// manifest says so.
+	size, err := io.Copy(fw, &archiveSource{reader: result.FileData})
+	var sourceErr *archiveSourceError
+	if errors.As(err, &sourceErr) {
+		return size, sourceErr.err
+	}
+	if err != nil {
+		return size, &archiveWriteError{err: err}
+	}
+	return size, nil
+}
+
+// archiveSource tells read failures of a file apart from write failures of
+// the archive within io.Copy.
+type archiveSource struct {
+	reader io.Reader
+}
+
+type archiveSourceError struct {
+	err error
+}
+
+func (e *archiveSourceError) Error() string { return e.err.Error() }
+
+func (s *archiveSource) Read(p []byte) (int, error) {
+	n, err := s.reader.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return n, &archiveSourceError{err: err}
+	}
+	return n, err
+}
+
+// archiveFileName names a file within its archive folder after its upload
+// name, falling back to its media ID.
+func archiveFileName(metadata *types.MediaMetadata) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r == '/' || r == '\\':
+			return '_'
+		case unicode.IsControl(r):
+			return -1
+		default:
+			return r
+		}
+	}, strings.TrimSpace(string(metadata.UploadName)))
+	if name == "" || name == "." || name == ".." {
+		return string(metadata.MediaID)
+	}
+	return name
+}
+
+// archiveNames hands out unique archive paths, numbering repeats as
+// "name (1).ext". Paths are compared case-insensitively so archives extract
+// cleanly on case-insensitive file systems.
+type archiveNames map[string]bool
+
+func (n archiveNames) claim(name string) string {
+	candidate := name
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for i := 1; n[strings.ToLower(candidate)]; i++ {
+		candidate = stem + " (" + strconv.Itoa(i) + ")" + ext
+	}
+	n[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// isCompressedContent reports content that deflate would not shrink.
+func isCompressedContent(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	if strings.HasPrefix(contentType, "image/") && contentType != "image/svg+xml" && contentType != "image/bmp" {
+		return true
+	}
+	if strings.HasPrefix(contentType, "video/") || strings.HasPrefix(contentType, "audio/") {
+		return true
+	}
+	switch contentType {
+	case "application/zip", "application/gzip", "application/x-gzip", "application/x-7z-compressed",
+		"application/x-rar-compressed", "application/x-bzip2", "application/x-xz", "application/zstd":
+		return true
+	}
+	return false
+}
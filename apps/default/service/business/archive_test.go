@@ -0,0 +1,236 @@
+package business
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type archiveMediaStore struct {
+	storage.MediaRepository
+	media []*types.MediaMetadata
+}
+
+func (s *archiveMediaStore) GetMediaMetadata(_ context.Context, mediaID types.MediaID) (*types.MediaMetadata, error) {
+	for _, metadata := range s.media {
+		if metadata.MediaID == mediaID {
+			return metadata, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *archiveMediaStore) ListOwnerMedia(_ context.Context, ownerID types.OwnerID, folder string, afterID string, limit int) ([]*types.MediaMetadata, error) {
+	var page []*types.MediaMetadata
+	for _, metadata := range s.media {
+		if metadata.OwnerID == ownerID && string(metadata.MediaID) > afterID && InFolder(metadata.Folder, folder) && len(page) < limit {
+			page = append(page, metadata)
+		}
+	}
+	return page, nil
+}
+
+type archiveContent struct {
+	MediaService
+	content map[types.MediaID]string
+	broken  map[types.MediaID]bool
+}
+
+func (c *archiveContent) DownloadFile(_ context.Context, req *DownloadRequest) (*DownloadResult, error) {
+	content, ok := c.content[req.MediaID]
+	if !ok {
+		return nil, errors.New("file not found in storage")
+	}
+	var reader io.Reader = strings.NewReader(content)
+	if c.broken[req.MediaID] {
+		reader = io.MultiReader(reader, iotest.ErrReader(errors.New("decryption failed")))
+	}
+	return &DownloadResult{MediaMetadata: req.Metadata, FileData: io.NopCloser(reader)}, nil
+}
+
+type archiveViewer struct {
+	denied map[string]bool
+}
+
+func (v archiveViewer) CanViewFile(_ context.Context, _, fileID string) error {
+	if v.denied[fileID] {
+		return errors.New("denied")
+	}
+	return nil
+}
+
+func readArchive(t *testing.T, data []byte) (map[string]string, ArchiveManifest) {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	var manifest ArchiveManifest
+	for _, file := range zr.File {
+		rc, openErr := file.Open()
+		require.NoError(t, openErr)
+		content, readErr := io.ReadAll(rc)
+		require.NoError(t, readErr, file.Name)
+		require.NoError(t, rc.Close())
+		if file.Name == ArchiveManifestName {
+			require.NoError(t, json.Unmarshal(content, &manifest))
+			continue
+		}
+		files[file.Name] = string(content)
+	}
+	return files, manifest
+}
+
+func TestArchiverMediaIDs(t *testing.T) {
+	ctx := t.Context()
+	store := &archiveMediaStore{media: []*types.MediaMetadata{
+		{MediaID: "m1", UploadName: "report.pdf", ContentType: "application/pdf"},
+		{MediaID: "m2", UploadName: "Report.pdf", ContentType: "application/pdf"},
+		{MediaID: "m3", UploadName: "secret.txt", ContentType: "text/plain"},
+		{MediaID: "m5", UploadName: "../../etc/passwd", ContentType: "text/plain"},
+		{MediaID: "m6", UploadName: "photo.jpg", ContentType: "image/jpeg"},
+		{MediaID: "m7", UploadName: "lost.txt", ContentType: "text/plain"},
+	}}
+	content := &archiveContent{
+		content: map[types.MediaID]string{"m1": "first", "m2": "second", "m5": "root", "m6": "partial"},
+		broken:  map[types.MediaID]bool{"m6": true},
+	}
+	archiver := NewArchiver(store, content, archiveViewer{denied: map[string]bool{"m3": true}})
+
+	entries, err := archiver.Resolve(ctx, "alice", []string{"m1", "m2", "m1", "m3", "m4", "bad id", "m5", "m6", "m7"}, "")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, archiver.Write(ctx, &out, &config.FilesConfig{}, entries))
+	files, manifest := readArchive(t, out.Bytes())
+
+	assert.Equal(t, "first", files["report.pdf"])
+	assert.Equal(t, "second", files["Report (1).pdf"], "names differing only in case are numbered")
+	assert.Equal(t, "root", files[".._.._etc_passwd"], "names cannot leave the archive root")
+	assert.Equal(t, "partial", files["photo.jpg"], "a file failing mid-stream keeps what was read")
+	assert.Len(t, files, 4)
+
+	require.Len(t, manifest.Entries, 8, "repeated media IDs are archived once")
+	byID := map[string]ArchiveManifestEntry{}
+	for _, entry := range manifest.Entries {
+		byID[entry.MediaID] = entry
+	}
+	assert.Equal(t, ArchiveManifestEntry{MediaID: "m1", Path: "report.pdf", Size: 5}, byID["m1"])
+	assert.Equal(t, "permission denied", byID["m3"].Error)
+	assert.Empty(t, byID["m3"].Path)
+	assert.Equal(t, "media not found", byID["m4"].Error)
+	assert.Equal(t, "invalid media id", byID["bad id"].Error)
+	assert.Equal(t, "decryption failed", byID["m6"].Error)
+	assert.Equal(t, "file not found in storage", byID["m7"].Error)
+}
+
+func TestArchiverFolder(t *testing.T) {
+	ctx := t.Context()
+	store := &archiveMediaStore{media: []*types.MediaMetadata{
+		{MediaID: "a1", OwnerID: "alice", UploadName: "jan.pdf", Folder: "invoices"},
+		{MediaID: "a2", OwnerID: "alice", UploadName: "feb.pdf", Folder: "invoices/2026"},
+		{MediaID: "a3", OwnerID: "alice", UploadName: "mar.pdf", Folder: "invoices/2026"},
+		{MediaID: "a4", OwnerID: "alice", UploadName: "other.pdf", Folder: "invoices-old"},
+		{MediaID: "a5", OwnerID: "alice", UploadName: "hidden.pdf", Folder: "invoices"},
+		{MediaID: "a6", OwnerID: "bob", UploadName: "bob.pdf", Folder: "invoices"},
+	}}
+	content := &archiveContent{content: map[types.MediaID]string{"a1": "1", "a2": "2", "a3": "3", "a4": "4", "a5": "5", "a6": "6"}}
+	archiver := NewArchiver(store, content, archiveViewer{denied: map[string]bool{"a5": true}})
+
+	entries, err := archiver.Resolve(ctx, "alice", nil, "/invoices/")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, archiver.Write(ctx, &out, &config.FilesConfig{}, entries))
+	files, manifest := readArchive(t, out.Bytes())
+
+	assert.Equal(t, map[string]string{"jan.pdf": "1", "2026/feb.pdf": "2", "2026/mar.pdf": "3"}, files)
+	assert.Len(t, manifest.Entries, 3, "files the caller cannot view and other owners' folders are left out")
+
+	_, err = archiver.Resolve(ctx, "alice", nil, "")
+	require.Error(t, err)
+	_, err = archiver.Resolve(ctx, "alice", nil, "invoices/../..")
+	require.Error(t, err)
+}
+
+func TestArchiverStopsWhenWriterFails(t *testing.T) {
+	ctx := t.Context()
+	store := &archiveMediaStore{media: []*types.MediaMetadata{{MediaID: "m1", UploadName: "a.txt"}}}
+	content := &archiveContent{content: map[types.MediaID]string{"m1": strings.Repeat("x", 1<<20)}}
+	archiver := NewArchiver(store, content, archiveViewer{})
+
+	entries, err := archiver.Resolve(ctx, "alice", []string{"m1"}, "")
+	require.NoError(t, err)
+	require.Error(t, archiver.Write(ctx, failingWriter{}, &config.FilesConfig{}, entries))
+}
+
+func TestArchiverDocuments(t *testing.T) {
+	ctx := t.Context()
+	store := &archiveMediaStore{media: []*types.MediaMetadata{
+		{MediaID: "m1", UploadName: "index.json"},
+		{MediaID: "m2", UploadName: "gone.txt"},
+	}}
+	content := &archiveContent{content: map[types.MediaID]string{"m1": "file"}}
+	archiver := NewArchiver(store, content, archiveViewer{})
+
+	entries, err := archiver.Resolve(ctx, "alice", []string{"m1", "m2"}, "")
+	require.NoError(t, err)
+
+	var progress []ArchiveManifestEntry
+	var out bytes.Buffer
+	require.NoError(t, archiver.WriteWithOptions(ctx, &out, &config.FilesConfig{}, entries, ArchiveOptions{
+		Documents: []ArchiveDocument{{Name: "index.json", Write: func(w io.Writer) error {
+			_, writeErr := io.WriteString(w, `{"files":1}`)
+			return writeErr
+		}}},
+		Progress: func(record ArchiveManifestEntry) { progress = append(progress, record) },
+	}))
+	files, manifest := readArchive(t, out.Bytes())
+
+	assert.Equal(t, `{"files":1}`, files["index.json"])
+	assert.Equal(t, "file", files["index (1).json"], "documents keep their names ahead of files")
+	assert.Equal(t, manifest.Entries, progress)
+
+	err = archiver.WriteWithOptions(ctx, io.Discard, &config.FilesConfig{}, entries, ArchiveOptions{
+		Documents: []ArchiveDocument{{Name: "index.json", Write: func(io.Writer) error { return errors.New("listing failed") }}},
+	})
+	require.EqualError(t, err, "listing failed")
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("client went away") }
+
+func TestCleanFolder(t *testing.T) {
+	for input, want := range map[string]string{
+		"":                "",
+		"/":               "",
+		" invoices/2026/": "invoices/2026",
+		"a/b/c":           "a/b/c",
+	} {
+		got, err := CleanFolder(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+	for _, input := range []string{"a//b", "../a", "a/./b", "a\\b", strings.Repeat("a", maxFolderLength+1)} {
+		_, err := CleanFolder(input)
+		assert.Error(t, err, input)
+	}
+
+	assert.True(t, InFolder("invoices/2026", "invoices"))
+	assert.True(t, InFolder("invoices", "invoices"))
+	assert.False(t, InFolder("invoices-old", "invoices"))
+	assert.True(t, InFolder("anything", ""))
+}
@@ -0,0 +1,252 @@
+package business
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/pitabwire/util"
+)
+
+const (
+	// sniffLength is the number of leading bytes content types are
+	// detected from.
+	sniffLength = 512
+
+	octetStream = "application/octet-stream"
+	svgType     = "image/svg+xml"
+)
+
+var (
+	errContentTypeMismatch   = errors.New("invalid parameter: content does not match the declared content type")
+	errContentTypeNotAllowed = errors.New("invalid parameter: content type is not allowed")
+)
+
+// contentTypeFamilies lists declared types that the detected type on the
+// left legitimately covers, e.g. office documents are zip archives. Entries
+// ending in * match by prefix and entries starting with * by suffix.
+var contentTypeFamilies = map[string][]string{
+	"application/zip": {
+		"application/x-zip-compressed", "application/epub+zip", "application/java-archive",
+		"application/vnd.android.package-archive", "application/vnd.openxmlformats-officedocument.*",
+		"application/vnd.oasis.opendocument.*",
+	},
+	"application/x-gzip": {"application/gzip", "application/x-gtar", "application/x-compressed-tar"},
+	"application/ogg":    {"audio/ogg", "video/ogg", "audio/opus", "audio/vorbis"},
+	"audio/mpeg":         {"audio/mp3", "audio/mpeg3"},
+	"audio/wave":         {"audio/wav", "audio/x-wav", "audio/vnd.wave"},
+	"image/bmp":          {"image/x-ms-bmp"},
+	"image/jpeg":         {"image/jpg", "image/pjpeg"},
+	"image/x-icon":       {"image/vnd.microsoft.icon"},
+	"text/html":          {"application/xhtml+xml"},
+	"text/xml":           {"application/xml", "*+xml"},
+	"video/mp4":          {"audio/mp4", "audio/x-m4a", "audio/m4a", "video/quicktime", "video/3gpp", "video/x-m4v"},
+	"video/webm":         {"audio/webm", "video/x-matroska", "audio/x-matroska"},
+}
+
+// textualTypes are non text/* types whose content is plain text.
+var textualTypes = []string{
+	"application/json", "application/xml", "application/javascript", "application/ecmascript",
+	"application/x-javascript", "application/yaml", "application/x-yaml", "application/toml",
+	"application/x-sh", "application/sql", "application/graphql", "application/x-ndjson",
+	"application/csv", "application/rtf", "application/x-www-form-urlencoded", "*+json", "*+xml",
+}
+
+// CheckDeclaredContentType applies the upload policy to the type and
+// filename a client declared, so uploads that can never be accepted are
+// refused before their content is received.
+func CheckDeclaredContentType(ctx context.Context, cfg *config.FilesConfig, purpose, contentType, filename string) error {
+	rule := cfg.ContentTypeRuleFor(tenantFromContext(ctx), purpose)
+	if !rule.Permits(contentType, filename) {
+		return errContentTypeNotAllowed
+	}
+	return nil
+}
+
+// resolveContentType detects the type of the content written to tmpDir and
+// reconciles it with the declared type. It returns the type to store and,
+// when the mismatch policy corrected the declared type, the declared one.
+func resolveContentType(ctx context.Context, req *UploadRequest, tmpDir types.Path) (types.ContentType, types.ContentType, error) {
+	header, err := readHeader(filepath.Join(string(tmpDir), "content"))
+	if err != nil {
+		return "", "", fmt.Errorf("internal server error")
+	}
+	detected := detectContentType(header)
+	declared := strings.TrimSpace(string(req.ContentType))
+
+	contentType, corrected := declared, ""
+	switch {
+	case declared == "":
+		contentType = detected
+	case !contentTypeCompatible(declared, detected):
+		if req.Config.ContentTypeMismatchPolicy == config.ContentTypeMismatchReject {
+			util.Log(ctx).With("declared", declared, "detected", detected).Info("rejected upload with mismatched content type")
+			return "", "", errContentTypeMismatch
+		}
+		contentType, corrected = detected, declared
+	}
+
+	rule := req.Config.ContentTypeRuleFor(tenantFromContext(ctx), req.Purpose)
+	filename := string(req.UploadName)
+	for _, candidate := range []string{declared, detected, contentType} {
+		if candidate == "" || (candidate == octetStream && contentType != octetStream) {
+			continue
+		}
+		if !rule.Permits(candidate, filename) {
+			return "", "", errContentTypeNotAllowed
+		}
+	}
+	return types.ContentType(contentType), types.ContentType(corrected), nil
+}
+
+func readHeader(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	header := make([]byte, sniffLength)
+	n, err := io.ReadFull(f, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return header[:n], nil
+}
+
+// detectContentType sniffs the content type from the leading bytes using the
+// WHATWG algorithm, which reports SVG documents as plain text or XML, so
+// those are recognised first.
+func detectContentType(header []byte) string {
+	if isSVG(header) {
+		return svgType
+	}
+	return http.DetectContentType(header)
+}
+
+// isSVG reports whether the root element after any XML declaration,
+// comments and doctype is an svg element. A doctype whose internal subset
+// runs past the header is treated as SVG, since the root element it hides
+// cannot be seen.
+func isSVG(header []byte) bool {
+	b := bytes.TrimPrefix(header, []byte("\xEF\xBB\xBF"))
+	for {
+		b = bytes.TrimLeft(b, " \t\r\n")
+		var end []byte
+		switch {
+		case bytes.HasPrefix(b, []byte("<?")):
+			end = []byte("?>")
+		case bytes.HasPrefix(b, []byte("<!--")):
+			end = []byte("-->")
+		case len(b) >= 9 && strings.EqualFold(string(b[:9]), "<!doctype"):
+			i, subset := doctypeEnd(b)
+			if i < 0 {
+				return subset
+			}
+			b = b[i:]
+			continue
+		default:
+			if len(b) < 5 || !strings.EqualFold(string(b[:4]), "<svg") {
+				return false
+			}
+			return strings.ContainsRune(" \t\r\n>/", rune(b[4]))
+		}
+		i := bytes.Index(b, end)
+		if i < 0 {
+			return false
+		}
+		b = b[i+len(end):]
+	}
+}
+
+// doctypeEnd returns the offset just past the doctype declaration at the
+// start of b, skipping quoted literals and the bracketed internal subset,
+// whose declarations and comments may themselves contain '>'. It returns -1 when
+// the declaration does not end within b, and reports whether an internal
+// subset was opened.
+func doctypeEnd(b []byte) (int, bool) {
+	var quote byte
+	subset := false
+	depth := 0
+	for i := len("<!doctype"); i < len(b); i++ {
+		c := b[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case depth > 0 && bytes.HasPrefix(b[i:], []byte("<!--")):
+			j := bytes.Index(b[i+4:], []byte("-->"))
+			if j < 0 {
+				return -1, subset
+			}
+			i += 4 + j + 2
+		case c == '[':
+			subset = true
+			depth++
+		case c == ']' && depth > 0:
+			depth--
+		case c == '>' && depth == 0:
+			return i + 1, subset
+		}
+	}
+	return -1, subset
+}
+
+// contentTypeCompatible reports whether the detected type is consistent with
+// the declared one. Unrecognised binary content is consistent with any
+// declaration; plain text with any textual one.
+func contentTypeCompatible(declared, detected string) bool {
+	declared, detected = baseMediaType(declared), baseMediaType(detected)
+	if declared == detected || detected == octetStream || declared == octetStream {
+		return true
+	}
+	if detected == "text/plain" && (strings.HasPrefix(declared, "text/") || matchesAny(declared, textualTypes)) {
+		return true
+	}
+	return matchesAny(declared, contentTypeFamilies[detected])
+}
+
+func baseMediaType(contentType string) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
+
+func matchesAny(contentType string, patterns []string) bool {
+	for _, p := range patterns {
+		switch {
+		case strings.HasSuffix(p, "*"):
+			if strings.HasPrefix(contentType, strings.TrimSuffix(p, "*")) {
+				return true
+			}
+		case strings.HasPrefix(p, "*"):
+			if strings.HasSuffix(contentType, strings.TrimPrefix(p, "*")) {
+				return true
+			}
+		case p == contentType:
+			return true
+		}
+	}
+	return false
+}
+
+func tenantFromContext(ctx context.Context) string {
+	if claims := security.ClaimsFromContext(ctx); claims != nil {
+		return claims.GetTenantID()
+	}
+	return ""
+}
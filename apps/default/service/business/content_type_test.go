@@ -0,0 +1,143 @@
+package business
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pngBytes(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))))
+	return buf.Bytes()
+}
+
+func TestDetectContentType(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content []byte
+		want    string
+	}{
+		{name: "png", content: pngBytes(t), want: "image/png"},
+		{name: "html", content: []byte("<!DOCTYPE html><html><script>alert(1)</script></html>"), want: "text/html; charset=utf-8"},
+		{name: "bare_svg", content: []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`), want: svgType},
+		{
+			name:    "svg_with_prolog",
+			content: []byte("\xEF\xBB\xBF<?xml version=\"1.0\"?>\n<!-- drawn -->\n<!DOCTYPE svg PUBLIC \"-//W3C//DTD SVG 1.1//EN\" \"x\">\n<SVG>"),
+			want:    svgType,
+		},
+		{
+			name:    "svg_with_internal_subset",
+			content: []byte("<?xml version=\"1.0\"?>\n<!DOCTYPE svg [\n  <!ENTITY greeting \"a > b\">\n  <!-- ] > -->\n]>\n<svg xmlns=\"http://www.w3.org/2000/svg\">"),
+			want:    svgType,
+		},
+		{
+			name:    "internal_subset_past_header",
+			content: []byte("<!DOCTYPE feed [\n  <!ENTITY pad \"" + strings.Repeat("x", 600) + "\">"),
+			want:    svgType,
+		},
+		{name: "xml_with_internal_subset", content: []byte(`<?xml version="1.0"?><!DOCTYPE feed [<!ENTITY a "b">]><feed></feed>`), want: "text/xml; charset=utf-8"},
+		{name: "other_xml", content: []byte(`<?xml version="1.0"?><feed></feed>`), want: "text/xml; charset=utf-8"},
+		{name: "svg_prefix_only", content: []byte("<svgfoo>"), want: "text/plain; charset=utf-8"},
+		{name: "binary", content: []byte{0x00, 0x01, 0x02, 0xFE}, want: octetStream},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, detectContentType(tc.content))
+		})
+	}
+}
+
+func TestContentTypeCompatible(t *testing.T) {
+	testCases := []struct {
+		declared, detected string
+		want               bool
+	}{
+		{"image/png", "image/png", true},
+		{"IMAGE/PNG", "image/png", true},
+		{"image/png", octetStream, true},
+		{"application/json", "text/plain; charset=utf-8", true},
+		{"text/csv; charset=utf-8", "text/plain; charset=utf-8", true},
+		{"application/vnd.api+json", "text/plain; charset=utf-8", true},
+		{"application/vnd.openxmlformats-officedocument.wordprocessingml.document", "application/zip", true},
+		{"audio/x-m4a", "video/mp4", true},
+		{"application/atom+xml", "text/xml; charset=utf-8", true},
+		{"image/png", "text/html; charset=utf-8", false},
+		{"image/png", svgType, false},
+		{"image/png", "image/jpeg", false},
+		{"application/pdf", "text/plain; charset=utf-8", false},
+		{"text/plain", "text/html; charset=utf-8", false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, contentTypeCompatible(tc.declared, tc.detected), "%s declared, %s detected", tc.declared, tc.detected)
+	}
+}
+
+func TestResolveContentType(t *testing.T) {
+	html := []byte("<html><body>hi</body></html>")
+
+	testCases := []struct {
+		name         string
+		content      []byte
+		declared     types.ContentType
+		filename     types.Filename
+		purpose      string
+		policy       string
+		deny         []string
+		wantType     types.ContentType
+		wantDeclared types.ContentType
+		wantErr      error
+	}{
+		{name: "matching", content: pngBytes(t), declared: "image/png", filename: "a.png", wantType: "image/png"},
+		{name: "undeclared_is_detected", content: pngBytes(t), filename: "a.png", wantType: "image/png"},
+		{
+			name: "mismatch_corrected", content: html, declared: "image/png", filename: "a.png",
+			wantType: "text/html; charset=utf-8", wantDeclared: "image/png",
+		},
+		{name: "mismatch_rejected", content: html, declared: "image/png", filename: "a.png", policy: config.ContentTypeMismatchReject, wantErr: errContentTypeMismatch},
+		{name: "denied_detected_type", content: html, declared: "text/plain", filename: "a.txt", deny: []string{"text/html"}, wantErr: errContentTypeNotAllowed},
+		{name: "denied_extension", content: []byte{0x4D, 0x5A, 0x00, 0x01}, declared: octetStream, filename: "setup.exe", deny: []string{".exe"}, wantErr: errContentTypeNotAllowed},
+		{name: "purpose_allow_list", content: pngBytes(t), declared: "image/png", filename: "me.png", purpose: "avatar", wantType: "image/png"},
+		{name: "purpose_refuses_unknown_binary", content: []byte{0x00, 0x01, 0x02}, filename: "me.png", purpose: "avatar", wantErr: errContentTypeNotAllowed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "content"), tc.content, 0600))
+
+			cfg := &config.FilesConfig{
+				ContentTypeMismatchPolicy: tc.policy,
+				ContentTypeDeny:           tc.deny,
+				ContentTypeRules: config.ContentTypeRules{
+					"*/avatar": {Allow: []string{"image/png", "image/jpeg"}},
+				},
+			}
+			require.NoError(t, cfg.Normalise())
+
+			contentType, declared, err := resolveContentType(context.Background(), &UploadRequest{
+				UploadName:  tc.filename,
+				ContentType: tc.declared,
+				Config:      cfg,
+				Purpose:     tc.purpose,
+			}, types.Path(tmpDir))
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantType, contentType)
+			assert.Equal(t, tc.wantDeclared, declared)
+		})
+	}
+}
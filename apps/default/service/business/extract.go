@@ -0,0 +1,396 @@
+package business
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/util"
+)
+
+const (
+	// MaxExtractionFailures bounds the failed entries an extraction job
+	// reports by path; later failures are only counted.
+	MaxExtractionFailures = 100
+
+	extractionProgressInterval = 25
+)
+
+var (
+	errExtractUnsupported = errors.New("invalid parameter: media is not a zip or tar.gz archive")
+	errExtractTooLarge    = errors.New("archive expands beyond the allowed size")
+	errEntryUnsafePath    = errors.New("entry path leaves the target folder")
+	errEntryNoName        = errors.New("entry has no file name")
+	errEntryTooLarge      = errors.New("entry is larger than the maximum file size")
+
+	zipMagic      = []byte("PK\x03\x04")
+	emptyZipMagic = []byte("PK\x05\x06")
+	gzipMagic     = []byte{0x1f, 0x8b}
+)
+
+// IsArchive reports whether media looks like a zip or tar.gz archive from
+// its content type or name.
+func IsArchive(metadata *types.MediaMetadata) bool {
+	switch strings.ToLower(string(metadata.ContentType)) {
+	case "application/zip", "application/x-zip-compressed", "application/gzip", "application/x-gzip",
+		"application/x-tar+gzip", "application/x-compressed-tar":
+		return true
+	}
+	name := strings.ToLower(string(metadata.UploadName))
+	return strings.HasSuffix(name, ".zip") || strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz")
+}
+
+// ExtractionFolder is the folder an archive is extracted into when none is
+// requested: a folder named after the archive, next to it.
+func ExtractionFolder(metadata *types.MediaMetadata) string {
+	name := archiveFileName(metadata)
+	for _, ext := range []string{".tar.gz", ".tgz", ".zip"} {
+		if len(name) > len(ext) && strings.HasSuffix(strings.ToLower(name), ext) {
+			name = name[:len(name)-len(ext)]
+			break
+		}
+	}
+	folder, err := CleanFolder(path.Join(metadata.Folder, name))
+	if err != nil {
+		return metadata.Folder
+	}
+	return folder
+}
+
+// ExtractedFileHandler runs the processing that follows an upload, such as
+// queueing thumbnails, for a file stored from an archive.
+type ExtractedFileHandler func(ctx context.Context, mediaID types.MediaID) error
+
+// ExtractionProgress is told about a job's counters as extraction goes on.
+type ExtractionProgress func(ctx context.Context, job *types.ArchiveExtraction)
+
+// Extractor expands zip and tar.gz archives into individual media. Every
+// file entry is uploaded for the job's owner as a regular upload would be,
+// so it is deduplicated, encrypted and type checked, and handed to the
+// file handler afterwards.
+//
+// Archives are bounded by entry count and by the bytes they expand to, the
+// lesser of a fixed size and a multiple of the archive's own size, so
+// archives built to expand enormously stop early. Entries with absolute or
+// parent relative paths are refused rather than placed elsewhere.
+type Extractor struct {
+	media    MediaService
+	onFile   ExtractedFileHandler
+	progress ExtractionProgress
+}
+
+// NewExtractor creates an extractor storing entries through media.
+func NewExtractor(media MediaService, onFile ExtractedFileHandler, progress ExtractionProgress) *Extractor {
+	return &Extractor{media: media, onFile: onFile, progress: progress}
+}
+
+// Extract expands source under job.Folder, updating the counters of job
+// as it goes. Entries that cannot be extracted are recorded on job; an
+// error is returned when the archive itself cannot be read, outgrows its
+// limits or ctx ends. Files extracted before an error are kept.
+func (e *Extractor) Extract(ctx context.Context, cfg *config.FilesConfig, job *types.ArchiveExtraction, source *types.MediaMetadata) error {
+	result, err := e.media.DownloadFile(ctx, &DownloadRequest{
+		MediaID:  source.MediaID,
+		Config:   cfg,
+		Metadata: source,
+	})
+	if err != nil {
+		return err
+	}
+	defer util.CloseAndLogOnError(ctx, result.FileData)
+
+	budget := &extractBudget{limit: extractLimit(cfg, source)}
+	run := &extraction{Extractor: e, cfg: cfg, job: job, budget: budget}
+
+	reader := bufio.NewReader(result.FileData)
+	magic, _ := reader.Peek(len(zipMagic))
+	switch {
+	case bytes.HasPrefix(magic, zipMagic), bytes.HasPrefix(magic, emptyZipMagic):
+		err = run.zip(ctx, reader)
+	case bytes.HasPrefix(magic, gzipMagic):
+		err = run.tarGz(ctx, reader)
+	default:
+		return errExtractUnsupported
+	}
+	e.report(ctx, job)
+	return err
+}
+
+func (e *Extractor) report(ctx context.Context, job *types.ArchiveExtraction) {
+	if e.progress != nil {
+		e.progress(ctx, job)
+	}
+}
+
+// extractLimit is the number of bytes source may expand to.
+func extractLimit(cfg *config.FilesConfig, source *types.MediaMetadata) int64 {
+	limit := cfg.ArchiveExtractMaxBytes
+	if cfg.ArchiveExtractMaxRatio > 0 && source.FileSizeBytes > 0 {
+		byRatio := int64(source.FileSizeBytes) * cfg.ArchiveExtractMaxRatio
+		if byRatio > 0 && (limit <= 0 || byRatio < limit) {
+			limit = byRatio
+		}
+	}
+	return limit
+}
+
+// extraction is a single run of an Extractor over one archive.
+type extraction struct {
+	*Extractor
+	cfg    *config.FilesConfig
+	job    *types.ArchiveExtraction
+	budget *extractBudget
+}
+
+func (x *extraction) zip(ctx context.Context, reader io.Reader) error {
+	// Zip archives are read from their central directory at the end, so
+	// the decrypted archive is spooled to disk first.
+	spool, err := os.CreateTemp("", "files-extract-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = spool.Close()
+		_ = os.Remove(spool.Name())
+	}()
+	size, err := io.Copy(spool, reader)
+	if err != nil {
+		return err
+	}
+
+	// Entries with unsafe paths are refused one by one below.
+	zr, err := zip.NewReader(spool, size)
+	if err != nil && !errors.Is(err, zip.ErrInsecurePath) {
+		return fmt.Errorf("reading archive: %w", err)
+	}
+
+	// The central directory gives away oversized archives before anything
+	// is extracted; the sizes it declares are enforced while reading.
+	if err = x.checkEntryCount(len(zr.File)); err != nil {
+		return err
+	}
+	var declared uint64
+	for _, file := range zr.File {
+		declared += file.UncompressedSize64
+		if x.budget.limit > 0 && declared > uint64(x.budget.limit) {
+			return errExtractTooLarge
+		}
+	}
+
+	for _, file := range zr.File {
+		open := func() (io.ReadCloser, error) {
+			content, openErr := file.Open()
+			if openErr != nil {
+				return nil, openErr
+			}
+			return budgetReadCloser{Reader: &budgetReader{reader: content, budget: x.budget}, Closer: content}, nil
+		}
+		if err = x.entry(ctx, file.Name, file.Mode(), int64(file.UncompressedSize64), open); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *extraction) tarGz(ctx context.Context, reader io.Reader) error {
+	gz, err := gzip.NewReader(reader)
+	if err != nil {
+		return fmt.Errorf("reading archive: %w", err)
+	}
+	defer util.CloseAndLogOnError(ctx, gz)
+
+	// Everything decompressed counts against the budget, including entries
+	// that are skipped over, so the size of a tar stream is bounded too.
+	tr := tar.NewReader(&budgetReader{reader: gz, budget: x.budget})
+	for {
+		header, nextErr := tr.Next()
+		if errors.Is(nextErr, io.EOF) {
+			return nil
+		}
+		if nextErr != nil && !errors.Is(nextErr, tar.ErrInsecurePath) {
+			if x.budget.exceeded {
+				return errExtractTooLarge
+			}
+			return fmt.Errorf("reading archive: %w", nextErr)
+		}
+		if err = x.checkEntryCount(int(x.job.Entries) + 1); err != nil {
+			return err
+		}
+
+		mode := header.FileInfo().Mode()
+		if header.Typeflag != tar.TypeReg {
+			// Links, devices and extended headers carry no file content.
+			mode |= fs.ModeIrregular
+		}
+		open := func() (io.ReadCloser, error) { return io.NopCloser(tr), nil }
+		if err = x.entry(ctx, header.Name, mode, header.Size, open); err != nil {
+			return err
+		}
+	}
+}
+
+func (x *extraction) checkEntryCount(count int) error {
+	if x.cfg.ArchiveExtractMaxEntries > 0 && count > x.cfg.ArchiveExtractMaxEntries {
+		return fmt.Errorf("archive holds more than %d entries", x.cfg.ArchiveExtractMaxEntries)
+	}
+	return nil
+}
+
+// entry extracts one archive entry. Only errors that end the whole
+// extraction are returned.
+func (x *extraction) entry(ctx context.Context, name string, mode fs.FileMode, size int64, open func() (io.ReadCloser, error)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	job := x.job
+	job.Entries++
+	defer func() {
+		job.ExtractedBytes = x.budget.used
+		if job.Entries%extractionProgressInterval == 0 {
+			x.report(ctx, job)
+		}
+	}()
+
+	if !mode.IsRegular() || isArchiveMetadata(name) {
+		job.Skipped++
+		return nil
+	}
+
+	folder, filename, err := extractEntryPath(job.Folder, name)
+	if err != nil {
+		x.fail(name, err)
+		return nil
+	}
+	if x.cfg.MaxFileSizeBytes > 0 && size > int64(x.cfg.MaxFileSizeBytes) {
+		x.fail(name, errEntryTooLarge)
+		return nil
+	}
+
+	content, err := open()
+	if err != nil {
+		x.fail(name, err)
+		return nil
+	}
+	defer util.CloseAndLogOnError(ctx, content)
+
+	result, err := x.media.UploadFile(ctx, &UploadRequest{
+		OwnerID:       job.OwnerID,
+		UploadName:    types.Filename(filename),
+		FileSizeBytes: types.FileSizeBytes(size),
+		FileData:      content,
+		Config:        x.cfg,
+		Folder:        folder,
+	})
+	if x.budget.exceeded {
+		return errExtractTooLarge
+	}
+	if err != nil {
+		x.fail(name, err)
+		return nil
+	}
+
+	job.Extracted++
+	if x.onFile != nil {
+		return x.onFile(ctx, result.MediaID)
+	}
+	return nil
+}
+
+func (x *extraction) fail(name string, err error) {
+	x.job.Failed++
+	if x.job.Failures == nil {
+		x.job.Failures = map[string]string{}
+	}
+	if len(x.job.Failures) < MaxExtractionFailures {
+		x.job.Failures[name] = err.Error()
+	}
+}
+
+// extractEntryPath places an archive entry below folder, returning the
+// entry's folder and file name. Backslashes are read as separators, as
+// archives made on Windows use them; absolute paths and ".." segments are
+// refused.
+func extractEntryPath(folder, name string) (string, string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if strings.HasPrefix(name, "/") || (len(name) >= 2 && name[1] == ':') {
+		return "", "", errEntryUnsafePath
+	}
+
+	segments := []string{folder}
+	for segment := range strings.SplitSeq(name, "/") {
+		switch segment {
+		case "", ".":
+			continue
+		case "..":
+			return "", "", errEntryUnsafePath
+		}
+		segments = append(segments, segment)
+	}
+	if len(segments) == 1 {
+		return "", "", errEntryNoName
+	}
+
+	entryFolder, err := CleanFolder(path.Join(segments[:len(segments)-1]...))
+	if err != nil {
+		return "", "", err
+	}
+	return entryFolder, segments[len(segments)-1], nil
+}
+
+// isArchiveMetadata reports entries archivers add for their own use.
+func isArchiveMetadata(name string) bool {
+	name = strings.ReplaceAll(name, "\\", "/")
+	return strings.HasPrefix(name, "__MACOSX/") || path.Base(name) == ".DS_Store"
+}
+
+// extractBudget counts the bytes an archive expanded to against its limit;
+// a limit of zero or less does not limit.
+type extractBudget struct {
+	limit    int64
+	used     int64
+	exceeded bool
+}
+
+// budgetReader reads until its budget is spent and fails if more remains.
+type budgetReader struct {
+	reader io.Reader
+	budget *extractBudget
+}
+
+func (r *budgetReader) Read(p []byte) (int, error) {
+	if r.budget.limit > 0 {
+		remaining := r.budget.limit - r.budget.used
+		if remaining <= 0 {
+			var probe [1]byte
+			n, err := r.reader.Read(probe[:])
+			if n > 0 {
+				r.budget.exceeded = true
+				return 0, errExtractTooLarge
+			}
+			return 0, err
+		}
+		if int64(len(p)) > remaining {
+			p = p[:remaining]
+		}
+	}
+	n, err := r.reader.Read(p)
+	r.budget.used += int64(n)
+	return n, err
+}
+
+type budgetReadCloser struct {
+	io.Reader
+	io.Closer
+}
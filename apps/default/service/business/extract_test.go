@@ -0,0 +1,208 @@
+package business
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// extractMedia serves one archive and records the files stored from it.
+type extractMedia struct {
+	MediaService
+	archive []byte
+	stored  map[string]string
+}
+
+func (m *extractMedia) DownloadFile(_ context.Context, req *DownloadRequest) (*DownloadResult, error) {
+	return &DownloadResult{MediaMetadata: req.Metadata, FileData: io.NopCloser(bytes.NewReader(m.archive))}, nil
+}
+
+func (m *extractMedia) UploadFile(_ context.Context, req *UploadRequest) (*UploadResult, error) {
+	content, err := io.ReadAll(req.FileData)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(string(req.UploadName), ".exe") {
+		return nil, errors.New("invalid parameter: content type is not allowed")
+	}
+	if m.stored == nil {
+		m.stored = map[string]string{}
+	}
+	m.stored[path.Join(req.Folder, string(req.UploadName))] = string(content)
+	return &UploadResult{MediaID: types.MediaID("m" + string(req.UploadName))}, nil
+}
+
+func extractConfig() *config.FilesConfig {
+	return &config.FilesConfig{
+		ArchiveExtractMaxEntries: 100,
+		ArchiveExtractMaxBytes:   1 << 20,
+		ArchiveExtractMaxRatio:   100,
+	}
+}
+
+func runExtraction(t *testing.T, cfg *config.FilesConfig, archive []byte) (*extractMedia, *types.ArchiveExtraction, []types.MediaID, error) {
+	t.Helper()
+	media := &extractMedia{archive: archive}
+	var processed []types.MediaID
+	extractor := NewExtractor(media, func(_ context.Context, mediaID types.MediaID) error {
+		processed = append(processed, mediaID)
+		return nil
+	}, nil)
+
+	job := &types.ArchiveExtraction{OwnerID: "alice", Folder: "scans"}
+	source := &types.MediaMetadata{MediaID: "archive", FileSizeBytes: types.FileSizeBytes(len(archive))}
+	err := extractor.Extract(t.Context(), cfg, job, source)
+	return media, job, processed, err
+}
+
+func buildZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	zw := zip.NewWriter(&out)
+	for name, content := range files {
+		fw, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return out.Bytes()
+}
+
+func TestExtractorZip(t *testing.T) {
+	archive := buildZip(t, map[string]string{
+		"docs/a.txt":       "a",
+		"docs/sub/b.txt":   "b",
+		"docs/empty/":      "",
+		"win\\c.txt":       "c",
+		"../evil.txt":      "evil",
+		"/etc/passwd":      "root",
+		"bad.exe":          "MZ",
+		"__MACOSX/._a.txt": "resource fork",
+	})
+
+	media, job, processed, err := runExtraction(t, extractConfig(), archive)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"scans/docs/a.txt":     "a",
+		"scans/docs/sub/b.txt": "b",
+		"scans/win/c.txt":      "c",
+	}, media.stored)
+	assert.Len(t, processed, 3, "every stored file is processed")
+
+	assert.Equal(t, int64(8), job.Entries)
+	assert.Equal(t, int64(3), job.Extracted)
+	assert.Equal(t, int64(2), job.Skipped)
+	assert.Equal(t, int64(3), job.Failed)
+	assert.Equal(t, errEntryUnsafePath.Error(), job.Failures["../evil.txt"])
+	assert.Equal(t, errEntryUnsafePath.Error(), job.Failures["/etc/passwd"])
+	assert.Contains(t, job.Failures["bad.exe"], "not allowed")
+}
+
+func TestExtractorTarGz(t *testing.T) {
+	var out bytes.Buffer
+	gz := gzip.NewWriter(&out)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "pages/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "pages/1.txt", Typeflag: tar.TypeReg, Mode: 0o644, Size: 3}))
+	_, err := tw.Write([]byte("one"))
+	require.NoError(t, err)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "pages/link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}))
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "pages/hard", Typeflag: tar.TypeLink, Linkname: "pages/1.txt"}))
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	media, job, _, err := runExtraction(t, extractConfig(), out.Bytes())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"scans/pages/1.txt": "one"}, media.stored)
+	assert.Equal(t, int64(4), job.Entries)
+	assert.Equal(t, int64(3), job.Skipped, "directories and links carry no content")
+	assert.Positive(t, job.ExtractedBytes)
+}
+
+func TestExtractorLimits(t *testing.T) {
+	t.Run("zip expanding too far", func(t *testing.T) {
+		archive := buildZip(t, map[string]string{"zeros.bin": strings.Repeat("\x00", 4<<20)})
+		media, job, _, err := runExtraction(t, extractConfig(), archive)
+		require.ErrorIs(t, err, errExtractTooLarge)
+		assert.Empty(t, media.stored, "declared sizes are checked before extracting")
+		assert.Zero(t, job.Entries)
+	})
+
+	t.Run("tar.gz expanding too far", func(t *testing.T) {
+		var out bytes.Buffer
+		gz := gzip.NewWriter(&out)
+		tw := tar.NewWriter(gz)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "small.txt", Typeflag: tar.TypeReg, Size: 2}))
+		_, err := tw.Write([]byte("ok"))
+		require.NoError(t, err)
+		zeros := strings.Repeat("\x00", 4<<20)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "zeros.bin", Typeflag: tar.TypeReg, Size: int64(len(zeros))}))
+		_, err = tw.Write([]byte(zeros))
+		require.NoError(t, err)
+		require.NoError(t, tw.Close())
+		require.NoError(t, gz.Close())
+
+		media, job, _, err := runExtraction(t, extractConfig(), out.Bytes())
+		require.ErrorIs(t, err, errExtractTooLarge)
+		assert.Equal(t, map[string]string{"scans/small.txt": "ok"}, media.stored, "files before the limit are kept")
+		assert.LessOrEqual(t, job.ExtractedBytes, int64(len(out.Bytes()))*100)
+	})
+
+	t.Run("too many entries", func(t *testing.T) {
+		cfg := extractConfig()
+		cfg.ArchiveExtractMaxEntries = 2
+		archive := buildZip(t, map[string]string{"a.txt": "a", "b.txt": "b", "c.txt": "c"})
+		media, _, _, err := runExtraction(t, cfg, archive)
+		require.Error(t, err)
+		assert.Empty(t, media.stored)
+	})
+
+	t.Run("entry over the file size limit", func(t *testing.T) {
+		cfg := extractConfig()
+		cfg.MaxFileSizeBytes = 4
+		archive := buildZip(t, map[string]string{"big.txt": "too big", "ok.txt": "ok"})
+		media, job, _, err := runExtraction(t, cfg, archive)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"scans/ok.txt": "ok"}, media.stored)
+		assert.Equal(t, errEntryTooLarge.Error(), job.Failures["big.txt"])
+	})
+
+	t.Run("not an archive", func(t *testing.T) {
+		_, _, _, err := runExtraction(t, extractConfig(), []byte("plain text"))
+		require.ErrorIs(t, err, errExtractUnsupported)
+	})
+}
+
+func TestExtractEntryPath(t *testing.T) {
+	for name, want := range map[string][2]string{
+		"a.txt":             {"root", "a.txt"},
+		"./docs//a.txt":     {"root/docs", "a.txt"},
+		"docs\\2026\\a.pdf": {"root/docs/2026", "a.pdf"},
+	} {
+		folder, filename, err := extractEntryPath("root", name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, [2]string{folder, filename}, name)
+	}
+	for _, name := range []string{"../a.txt", "docs/../../a.txt", "/a.txt", "C:/a.txt", "\\\\server\\a.txt", "./"} {
+		_, _, err := extractEntryPath("root", name)
+		assert.Error(t, err, name)
+	}
+
+	assert.Equal(t, "inbox/scans", ExtractionFolder(&types.MediaMetadata{UploadName: "scans.tar.gz", Folder: "inbox"}))
+	assert.Equal(t, "Scans", ExtractionFolder(&types.MediaMetadata{UploadName: "Scans.ZIP"}))
+}
@@ -0,0 +1,38 @@
+package business
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxFolderLength bounds the folder path stored with media.
+const maxFolderLength = 1024
+
+var errInvalidFolder = errors.New("invalid parameter: folder must be a relative path without . or .. segments")
+
+// CleanFolder normalises a folder path to slash separated segments without
+// leading or trailing slashes; the root folder is empty. Paths escaping the
+// root or holding empty segments are rejected.
+func CleanFolder(folder string) (string, error) {
+	folder = strings.Trim(strings.TrimSpace(folder), "/")
+	if folder == "" {
+		return "", nil
+	}
+	if len(folder) > maxFolderLength || !utf8.ValidString(folder) || strings.ContainsAny(folder, "\\\x00") {
+		return "", errInvalidFolder
+	}
+	for segment := range strings.SplitSeq(folder, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", errInvalidFolder
+		}
+	}
+	return path.Clean(folder), nil
+}
+
+// InFolder reports whether media filed under folder lies within parent,
+// directly or in one of its subfolders.
+func InFolder(folder, parent string) bool {
+	return parent == "" || folder == parent || strings.HasPrefix(folder, parent+"/")
+}
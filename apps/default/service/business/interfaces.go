@@ -31,6 +31,10 @@ type UploadRequest struct {
 	FileData      io.Reader
 	Config        *config.FilesConfig
 	IsPublic      bool
+	// Purpose selects the tenant's content type rules for the upload.
+	Purpose string
+	// Folder files the upload under a folder, as cleaned by CleanFolder.
+	Folder string
 }
 
 // UploadResult contains the result of an upload operation
@@ -47,6 +51,12 @@ type DownloadRequest struct {
 	ThumbnailSize      *types.ThumbnailSize
 	DownloadFilename   string
 	Config             *config.FilesConfig
+	// Transform, when set on a thumbnail request with non-default options,
+	// serves a stored or newly generated derivative instead of a thumbnail
+	Transform *types.ImageTransform
+	// Metadata of the requested media when the caller already holds it,
+	// for instance from a cache; it is looked up otherwise
+	Metadata *types.MediaMetadata
 }
 
 // DownloadResult contains the result of a download operation
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/mediainfo"
 	"github.com/antinvestor/service-files/apps/default/service/queue/thumbnailer"
 	"github.com/antinvestor/service-files/apps/default/service/storage"
 	"github.com/antinvestor/service-files/apps/default/service/types"
@@ -64,16 +65,29 @@ func (s *mediaService) DownloadFile(ctx context.Context, req *DownloadRequest) (
 		return nil, err
 	}
 
-	mediaMetadata, err := s.db.GetMediaMetadata(ctx, req.MediaID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get media metadata: %w", err)
+	var err error
+	mediaMetadata := req.Metadata
+	if mediaMetadata == nil || mediaMetadata.MediaID != req.MediaID {
+		mediaMetadata, err = s.db.GetMediaMetadata(ctx, req.MediaID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get media metadata: %w", err)
+		}
+		if mediaMetadata == nil {
+			return nil, fmt.Errorf("media not found")
+		}
 	}
-	if mediaMetadata == nil {
-		return nil, fmt.Errorf("media not found")
+
+	// Thumbnails and derivatives are served under the original's policy
+	if err = CheckScanPolicy(req.Config, mediaMetadata); err != nil {
+		return nil, err
 	}
 
 	if req.IsThumbnailRequest {
-		thumbnailMetadata, resolveErr := s.resolveThumbnail(ctx, req, mediaMetadata)
+		resolve := s.resolveThumbnail
+		if req.Transform != nil && !req.Transform.IsPlainThumbnail() {
+			resolve = s.resolveDerivative
+		}
+		thumbnailMetadata, resolveErr := resolve(ctx, req, mediaMetadata)
 		if resolveErr != nil {
 			return nil, resolveErr
 		}
@@ -234,6 +248,24 @@ func (s *mediaService) processUpload(ctx context.Context, req *UploadRequest) (*
 		return nil, fmt.Errorf("invalid parameter: HTTP Content-Length is greater than the maximum allowed upload size (%v)", req.Config.MaxFileSizeBytes)
 	}
 
+	// Check the content against its declared type and the upload policy
+	contentType, declaredType, err := resolveContentType(ctx, req, tmpDir)
+	if err != nil {
+		utils.RemoveDir(tmpDir, logger)
+		return nil, err
+	}
+
+	// Sanitize images before hashing so deduplication and integrity checks
+	// apply to the stored content
+	hash, bytesWritten, exif, err := sanitizeUpload(ctx, req.Config, tmpDir, hash, bytesWritten)
+	if err != nil {
+		utils.RemoveDir(tmpDir, logger)
+		return nil, err
+	}
+
+	// Read intrinsic properties while the plaintext content is at hand
+	properties := extractProperties(ctx, tmpDir)
+
 	// Check if file already exists by hash
 	existingMetadata, err := s.db.GetMediaMetadataByHash(ctx, req.OwnerID, hash)
 	if err != nil {
@@ -269,13 +301,19 @@ func (s *mediaService) processUpload(ctx context.Context, req *UploadRequest) (*
 		mediaMetadata = &types.MediaMetadata{
 			MediaID:           mediaID,
 			UploadName:        req.UploadName,
-			ContentType:       req.ContentType,
+			ContentType:       contentType,
 			FileSizeBytes:     bytesWritten,
 			Base64Hash:        hash,
 			OwnerID:           req.OwnerID,
 			ServerName:        req.Config.ServerName,
 			IsPublic:          req.IsPublic,
+			Folder:            req.Folder,
 			CreationTimestamp: uint64(time.Now().UnixMilli()),
+			Exif:              exif,
+			Properties:        properties,
+
+			DeclaredContentType: declaredType,
+			ScanStatus:          initialScanStatus(req.Config),
 		}
 	}
 
@@ -333,6 +371,11 @@ func (s *mediaService) validateDownloadRequest(req *DownloadRequest) error {
 		if req.ThumbnailSize.ResizeMethod != types.Crop && req.ThumbnailSize.ResizeMethod != types.Scale {
 			return fmt.Errorf("invalid parameter: unsupported resize method")
 		}
+		if req.Transform != nil {
+			if err := validateImageTransform(req.Transform, maxDim); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -373,7 +416,8 @@ func (s *mediaService) getFileData(ctx context.Context, mediaMetadata *types.Med
 	}
 
 	if mediaMetadata.Encryption != nil {
-		decryptingReader, err := storage.NewDecryptingReader(reader, []byte(cfg.EnvStorageEncryptionPhrase), mediaMetadata.Encryption)
+		decryptingReader, err := storage.NewDecryptingReader(reader, []byte(cfg.EnvStorageEncryptionPhrase),
+			mediaMetadata.Encryption, storage.EncryptionRecorderOf(s.provider))
 		if err != nil {
 			cleanup()
 			return nil, 0, "", fmt.Errorf("failed to initialise decrypting reader: %w", err)
@@ -441,7 +485,8 @@ func (s *mediaService) encryptToPath(
 	}
 	defer util.CloseAndLogOnError(ctx, dstFile)
 
-	info, err := storage.EncryptStream(ctx, srcFile, dstFile, []byte(cfg.EnvStorageEncryptionPhrase))
+	info, err := storage.EncryptStream(ctx, srcFile, dstFile, []byte(cfg.EnvStorageEncryptionPhrase),
+		storage.EncryptionRecorderOf(s.provider))
 	if err != nil {
 		return err
 	}
@@ -493,6 +538,44 @@ func (s *mediaService) resolveThumbnail(ctx context.Context, req *DownloadReques
 	return thumbnailMetadata, nil
 }
 
+// resolveDerivative returns the stored derivative for the request transform,
+// generating and storing it on first use.
+func (s *mediaService) resolveDerivative(ctx context.Context, req *DownloadRequest, mediaMetadata *types.MediaMetadata) (*types.ThumbnailMetadata, error) {
+	cfg := req.Config
+	transform := req.Transform.Normalised()
+	key := transform.Key()
+
+	derivative, err := s.db.GetDerivative(ctx, req.MediaID, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get derivative: %w", err)
+	}
+	if derivative != nil {
+		return derivative, nil
+	}
+
+	if !strings.HasPrefix(string(mediaMetadata.ContentType), "image/") {
+		return nil, fmt.Errorf("invalid parameter: media is not an image")
+	}
+
+	if cfg.MaxDerivativesPerMedia > 0 {
+		count, countErr := s.db.CountDerivatives(ctx, req.MediaID)
+		if countErr != nil {
+			return nil, fmt.Errorf("failed to count derivatives: %w", countErr)
+		}
+		if count >= int64(cfg.MaxDerivativesPerMedia) {
+			return nil, fmt.Errorf("failed precondition: media already has %d stored derivatives", count)
+		}
+	}
+
+	result, err, _ := s.thumbnailGroup.Do(string(req.MediaID)+":"+key, func() (any, error) {
+		return thumbnailer.GenerateDerivative(ctx, transform, mediaMetadata, cfg.AbsBasePath, s.db, s.provider, util.Log(ctx), cfg.EnvStorageEncryptionPhrase)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*types.ThumbnailMetadata), nil
+}
+
 func thumbnailKey(mediaID types.MediaID, size types.ThumbnailSize) string {
 	return fmt.Sprintf("%s:%dx%d:%s", mediaID, size.Width, size.Height, size.ResizeMethod)
 }
@@ -536,6 +619,18 @@ func (s *mediaService) storeFileAndMetadata(ctx context.Context, tmpDir types.Pa
 	return nil
 }
 
+// extractProperties reads the intrinsic properties of the uploaded content.
+// Extraction is best effort: content that cannot be parsed is stored without
+// properties.
+func extractProperties(ctx context.Context, tmpDir types.Path) *types.MediaProperties {
+	properties, err := mediainfo.Extract(filepath.Join(string(tmpDir), "content"))
+	if err != nil {
+		util.Log(ctx).WithError(err).Debug("failed to extract media properties")
+		return nil
+	}
+	return properties
+}
+
 // isValidMediaID checks if the media ID is valid
 func isValidMediaID(mediaID string) bool {
 	if mediaID == "" {
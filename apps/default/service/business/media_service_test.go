@@ -621,6 +621,90 @@ func (suite *MediaServiceTestSuite) Test_MediaService_UploadDuplicateAndConflict
 	})
 }
 
+func (suite *MediaServiceTestSuite) Test_MediaService_UploadSanitizesImages() {
+	testCases := []struct {
+		name       string
+		mode       string
+		expectExif map[string]string
+		unchanged  bool
+	}{
+		{name: "strip_removes_metadata", mode: config.ImageSanitizeStrip},
+		{name: "keep_records_metadata", mode: config.ImageSanitizeKeep, expectExif: map[string]string{"make": "Acme"}},
+		{name: "off_stores_original", mode: config.ImageSanitizeOff, unchanged: true},
+	}
+
+	suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				ctx, svc, res := suite.CreateService(t, dep)
+				db := &connection.Database{
+					WorkManager:     svc.WorkManager(),
+					MediaRepository: res.MediaRepository,
+				}
+				cfg := svc.Config().(*config.FilesConfig)
+				cfg.ImageSanitizeMode = tc.mode
+				storageProvider, err := provider.GetStorageProvider(ctx, cfg)
+				require.NoError(t, err)
+				service := NewMediaService(db, storageProvider)
+
+				payload := jpegWithExif(t)
+				mediaID := types.MediaID("sanitize" + util.RandomAlphaNumericString(12))
+				_, err = service.UploadFile(ctx, &UploadRequest{
+					OwnerID:       "@owner:example.com",
+					MediaID:       mediaID,
+					UploadName:    "photo.jpg",
+					ContentType:   "image/jpeg",
+					FileSizeBytes: types.FileSizeBytes(len(payload)),
+					FileData:      bytes.NewReader(payload),
+					Config:        cfg,
+					IsPublic:      true,
+				})
+				require.NoError(t, err)
+
+				download, err := service.DownloadFile(ctx, &DownloadRequest{MediaID: mediaID, Config: cfg})
+				require.NoError(t, err)
+				defer download.FileData.Close()
+				stored, err := io.ReadAll(download.FileData)
+				require.NoError(t, err)
+
+				metadata, err := db.GetMediaMetadata(ctx, mediaID)
+				require.NoError(t, err)
+				assert.Equal(t, types.FileSizeBytes(len(stored)), metadata.FileSizeBytes)
+				assert.Equal(t, tc.expectExif, metadata.Exif)
+				require.NotNil(t, metadata.Properties)
+				assert.Equal(t, "jpeg", metadata.Properties.Format)
+				assert.Equal(t, 16, metadata.Properties.Width)
+				assert.Equal(t, 16, metadata.Properties.Height)
+				if tc.unchanged {
+					assert.Equal(t, payload, stored)
+					return
+				}
+				assert.NotContains(t, string(stored), "Acme")
+				_, err = jpeg.Decode(bytes.NewReader(stored))
+				require.NoError(t, err)
+			})
+		}
+	})
+}
+
+// jpegWithExif returns a JPEG carrying an EXIF block with a camera make.
+func jpegWithExif(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	encoded := new(bytes.Buffer)
+	require.NoError(t, jpeg.Encode(encoded, img, nil))
+
+	tiff := []byte("II*\x00\x08\x00\x00\x00" +
+		"\x01\x00" + "\x0f\x01\x02\x00\x05\x00\x00\x00\x1a\x00\x00\x00" + "\x00\x00\x00\x00" +
+		"Acme\x00")
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}
+
+	out := append([]byte{}, encoded.Bytes()[:2]...)
+	out = append(out, app1...)
+	out = append(out, payload...)
+	return append(out, encoded.Bytes()[2:]...)
+}
+
 type testContext struct {
 	ctx context.Context
 }
@@ -632,3 +716,49 @@ func timePtr(v time.Time) *time.Time {
 func contextBackground() context.Context {
 	return context.Background()
 }
+
+func (suite *MediaServiceTestSuite) Test_MediaService_ScanPolicyBlocksDownloads() {
+	suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+		ctx, svc, res := suite.CreateService(t, dep)
+		db := &connection.Database{
+			WorkManager:     svc.WorkManager(),
+			MediaRepository: res.MediaRepository,
+		}
+		cfg := svc.Config().(*config.FilesConfig)
+		cfg.ScannerProvider = config.ScannerClamAV
+		cfg.ScanBlockedStatuses = []string{"pending", "failed"}
+		storageProvider, err := provider.GetStorageProvider(ctx, cfg)
+		require.NoError(t, err)
+		service := NewMediaService(db, storageProvider)
+
+		payload := []byte("scan me")
+		mediaID := types.MediaID("scanned" + util.RandomAlphaNumericString(12))
+		_, err = service.UploadFile(ctx, &UploadRequest{
+			OwnerID:       "@owner:example.com",
+			MediaID:       mediaID,
+			UploadName:    "notes.txt",
+			ContentType:   "text/plain",
+			FileSizeBytes: types.FileSizeBytes(len(payload)),
+			FileData:      bytes.NewReader(payload),
+			Config:        cfg,
+		})
+		require.NoError(t, err)
+
+		metadata, err := db.GetMediaMetadata(ctx, mediaID)
+		require.NoError(t, err)
+		assert.Equal(t, types.ScanStatusPending, metadata.ScanStatus)
+
+		_, err = service.DownloadFile(ctx, &DownloadRequest{MediaID: mediaID, Config: cfg})
+		require.ErrorIs(t, err, ErrScanBlocked)
+
+		require.NoError(t, db.StoreScanResult(ctx, mediaID, types.ScanStatusClean, ""))
+		download, err := service.DownloadFile(ctx, &DownloadRequest{MediaID: mediaID, Config: cfg})
+		require.NoError(t, err)
+		require.NoError(t, download.FileData.Close())
+
+		cfg.ScanBlockedStatuses = nil
+		require.NoError(t, db.StoreScanResult(ctx, mediaID, types.ScanStatusInfected, "Eicar-Test-Signature"))
+		_, err = service.DownloadFile(ctx, &DownloadRequest{MediaID: mediaID, Config: cfg})
+		require.ErrorIs(t, err, ErrScanBlocked, "infected media is always blocked")
+	})
+}
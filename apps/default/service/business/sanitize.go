@@ -0,0 +1,96 @@
+package business
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/imagemeta"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/pitabwire/util"
+)
+
+// sanitizeUpload applies the tenant's image sanitization to the content
+// written to tmpDir. SVG documents have their active content removed
+// whatever the tenant's mode. It returns the hash and size of the content,
+// recomputed when sanitization changed it, and the extracted metadata the
+// tenant keeps.
+func sanitizeUpload(
+	ctx context.Context,
+	cfg *config.FilesConfig,
+	tmpDir types.Path,
+	hash types.Base64Hash,
+	size types.FileSizeBytes,
+) (types.Base64Hash, types.FileSizeBytes, map[string]string, error) {
+	contentPath := filepath.Join(string(tmpDir), "content")
+	header, err := readHeader(contentPath)
+	if err != nil {
+		return "", 0, nil, fmt.Errorf("internal server error")
+	}
+	if isSVG(header) {
+		return sanitizeSVGUpload(ctx, contentPath, hash, size)
+	}
+
+	mode := cfg.ImageSanitizeModeFor(tenantFromContext(ctx))
+	if mode == config.ImageSanitizeOff {
+		return hash, size, nil, nil
+	}
+
+	result, err := imagemeta.SanitizeFile(contentPath)
+	if err != nil {
+		if errors.Is(err, imagemeta.ErrTooLarge) {
+			return "", 0, nil, fmt.Errorf("invalid parameter: image dimensions are too large")
+		}
+		util.Log(ctx).WithError(err).Warn("failed to sanitize uploaded image")
+		return "", 0, nil, fmt.Errorf("invalid parameter: image could not be processed")
+	}
+	if result == nil {
+		return hash, size, nil, nil
+	}
+
+	util.Log(ctx).With(
+		"image_format", result.Format,
+		"modified", result.Modified,
+		"oriented", result.Oriented,
+		"had_location", result.Exif != nil && result.Exif.HasLocation(),
+	).Debug("sanitized uploaded image")
+
+	if result.Modified {
+		hash, size, err = utils.ComputeHashAndSize(types.Path(contentPath))
+		if err != nil {
+			return "", 0, nil, fmt.Errorf("internal server error")
+		}
+	}
+
+	var kept map[string]string
+	if mode == config.ImageSanitizeKeep && result.Exif != nil && len(result.Exif.Fields) > 0 {
+		kept = result.Exif.Fields
+	}
+	return hash, size, kept, nil
+}
+
+func sanitizeSVGUpload(
+	ctx context.Context,
+	contentPath string,
+	hash types.Base64Hash,
+	size types.FileSizeBytes,
+) (types.Base64Hash, types.FileSizeBytes, map[string]string, error) {
+	modified, err := imagemeta.SanitizeSVGFile(contentPath)
+	if err != nil {
+		util.Log(ctx).WithError(err).Info("rejected malformed svg upload")
+		return "", 0, nil, fmt.Errorf("invalid parameter: svg document could not be processed")
+	}
+	if !modified {
+		return hash, size, nil, nil
+	}
+
+	util.Log(ctx).Debug("removed active content from uploaded svg")
+	hash, size, err = utils.ComputeHashAndSize(types.Path(contentPath))
+	if err != nil {
+		return "", 0, nil, fmt.Errorf("internal server error")
+	}
+	return hash, size, nil, nil
+}
@@ -0,0 +1,77 @@
+package business
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeUploadSVG(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mode      string
+		content   string
+		want      string
+		wantError bool
+	}{
+		{
+			name:    "active_content_removed",
+			mode:    config.ImageSanitizeStrip,
+			content: `<svg xmlns="http://www.w3.org/2000/svg" onload="alert(1)"><script>alert(2)</script><rect width="1"/></svg>`,
+			want:    `<svg xmlns="http://www.w3.org/2000/svg"><rect width="1"></rect></svg>`,
+		},
+		{
+			name:    "clean_document_untouched",
+			mode:    config.ImageSanitizeKeep,
+			content: `<svg xmlns="http://www.w3.org/2000/svg"><rect width="1"/></svg>`,
+			want:    `<svg xmlns="http://www.w3.org/2000/svg"><rect width="1"/></svg>`,
+		},
+		{
+			name:    "active_content_removed_when_off",
+			mode:    config.ImageSanitizeOff,
+			content: `<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script><rect width="1"/></svg>`,
+			want:    `<svg xmlns="http://www.w3.org/2000/svg"><rect width="1"></rect></svg>`,
+		},
+		{
+			name:      "malformed_document_rejected",
+			mode:      config.ImageSanitizeStrip,
+			content:   `<svg><script></svg>`,
+			wantError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			contentPath := filepath.Join(tmpDir, "content")
+			require.NoError(t, os.WriteFile(contentPath, []byte(tc.content), 0600))
+			hash, size, err := utils.ComputeHashAndSize(types.Path(contentPath))
+			require.NoError(t, err)
+
+			cfg := &config.FilesConfig{ImageSanitizeMode: tc.mode}
+			gotHash, gotSize, _, err := sanitizeUpload(context.Background(), cfg, types.Path(tmpDir), hash, size)
+			if tc.wantError {
+				assert.ErrorContains(t, err, "invalid parameter")
+				return
+			}
+			require.NoError(t, err)
+
+			stored, err := os.ReadFile(contentPath)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(stored))
+			assert.Equal(t, types.FileSizeBytes(len(stored)), gotSize)
+			if tc.want == tc.content {
+				assert.Equal(t, hash, gotHash)
+			} else {
+				assert.NotEqual(t, hash, gotHash)
+			}
+		})
+	}
+}
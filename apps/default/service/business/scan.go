@@ -0,0 +1,29 @@
+package business
+
+import (
+	"errors"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+)
+
+// ErrScanBlocked is returned for media that may not be served or shared
+// because of its malware scan status.
+var ErrScanBlocked = errors.New("failed precondition: media is blocked by the malware scan policy")
+
+// CheckScanPolicy returns ErrScanBlocked when the scan status of the media
+// is one the policy blocks.
+func CheckScanPolicy(cfg *config.FilesConfig, mediaMetadata *types.MediaMetadata) error {
+	if mediaMetadata != nil && cfg.ScanBlocks(string(mediaMetadata.ScanStatus)) {
+		return ErrScanBlocked
+	}
+	return nil
+}
+
+// initialScanStatus is the status new media is stored with.
+func initialScanStatus(cfg *config.FilesConfig) types.ScanStatus {
+	if cfg.ScanEnabled() {
+		return types.ScanStatusPending
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+package business
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Share link states, derived from the stored link at the time it is used.
+const (
+	ShareLinkActive    = "active"
+	ShareLinkExpired   = "expired"
+	ShareLinkRevoked   = "revoked"
+	ShareLinkExhausted = "exhausted"
+)
+
+// shareLinkTokenBytes is the entropy of a share link token.
+const shareLinkTokenBytes = 32
+
+var (
+	// ErrShareLinkUnavailable is returned for links that are expired,
+	// revoked or out of downloads.
+	ErrShareLinkUnavailable = errors.New("share link is no longer available")
+	// ErrShareLinkPasswordRequired is returned when a protected link is
+	// used without a password.
+	ErrShareLinkPasswordRequired = errors.New("share link requires a password")
+	// ErrShareLinkPasswordIncorrect is returned for a wrong password.
+	ErrShareLinkPasswordIncorrect = errors.New("share link password is incorrect")
+	// ErrShareLinkLocked is returned while a link is locked after too many
+	// wrong passwords.
+	ErrShareLinkLocked = errors.New("share link is locked after too many incorrect passwords")
+)
+
+// NewShareLinkToken returns a random token for a share link along with the
+// hash it is stored and looked up by.
+func NewShareLinkToken() (token, tokenHash string, err error) {
+	raw := make([]byte, shareLinkTokenBytes)
+	if _, err = rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(raw)
+	return token, HashShareLinkToken(token), nil
+}
+
+// HashShareLinkToken returns the stored form of a share link token.
+func HashShareLinkToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// HashShareLinkPassword hashes the password protecting a share link.
+// An empty password leaves the link unprotected.
+func HashShareLinkPassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// ShareLinkState reports the state of a link at now.
+func ShareLinkState(link *types.ShareLink, now time.Time) string {
+	switch {
+	case link.RevokedAt != nil:
+		return ShareLinkRevoked
+	case link.ExpiresAt != nil && !now.Before(*link.ExpiresAt):
+		return ShareLinkExpired
+	case link.MaxDownloads > 0 && link.DownloadCount >= link.MaxDownloads:
+		return ShareLinkExhausted
+	default:
+		return ShareLinkActive
+	}
+}
+
+// CheckShareLink verifies a link can be used at now with password. A locked
+// link refuses every password until its lock ends.
+func CheckShareLink(link *types.ShareLink, password string, now time.Time) error {
+	if ShareLinkState(link, now) != ShareLinkActive {
+		return ErrShareLinkUnavailable
+	}
+	if link.PasswordHash == "" {
+		return nil
+	}
+	if link.LockedUntil != nil && now.Before(*link.LockedUntil) {
+		return ErrShareLinkLocked
+	}
+	if password == "" {
+		return ErrShareLinkPasswordRequired
+	}
+	if bcrypt.CompareHashAndPassword([]byte(link.PasswordHash), []byte(password)) != nil {
+		return ErrShareLinkPasswordIncorrect
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package business
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareLinkToken(t *testing.T) {
+	token, hash, err := NewShareLinkToken()
+	require.NoError(t, err)
+	assert.Len(t, token, 43)
+	assert.Equal(t, hash, HashShareLinkToken(token))
+	assert.NotEqual(t, token, hash)
+
+	other, _, err := NewShareLinkToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestShareLinkState(t *testing.T) {
+	now := time.Now().UTC()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	for name, tc := range map[string]struct {
+		link *types.ShareLink
+		want string
+	}{
+		"active":           {&types.ShareLink{}, ShareLinkActive},
+		"before expiry":    {&types.ShareLink{ExpiresAt: &future}, ShareLinkActive},
+		"expired":          {&types.ShareLink{ExpiresAt: &past}, ShareLinkExpired},
+		"revoked":          {&types.ShareLink{RevokedAt: &past, ExpiresAt: &past}, ShareLinkRevoked},
+		"downloads left":   {&types.ShareLink{MaxDownloads: 2, DownloadCount: 1}, ShareLinkActive},
+		"out of downloads": {&types.ShareLink{MaxDownloads: 2, DownloadCount: 2}, ShareLinkExhausted},
+	} {
+		assert.Equal(t, tc.want, ShareLinkState(tc.link, now), name)
+	}
+}
+
+func TestCheckShareLink(t *testing.T) {
+	now := time.Now().UTC()
+	hash, err := HashShareLinkPassword("s3cret")
+	require.NoError(t, err)
+	link := &types.ShareLink{PasswordHash: hash}
+
+	require.ErrorIs(t, CheckShareLink(link, "", now), ErrShareLinkPasswordRequired)
+	require.ErrorIs(t, CheckShareLink(link, "wrong", now), ErrShareLinkPasswordIncorrect)
+	require.NoError(t, CheckShareLink(link, "s3cret", now))
+
+	lockedUntil := now.Add(time.Minute)
+	link.LockedUntil = &lockedUntil
+	require.ErrorIs(t, CheckShareLink(link, "s3cret", now), ErrShareLinkLocked)
+	require.NoError(t, CheckShareLink(link, "s3cret", lockedUntil), "the lock ends")
+	link.LockedUntil = nil
+
+	link.RevokedAt = &now
+	require.ErrorIs(t, CheckShareLink(link, "s3cret", now), ErrShareLinkUnavailable)
+
+	noPassword, err := HashShareLinkPassword("")
+	require.NoError(t, err)
+	assert.Empty(t, noPassword)
+	require.NoError(t, CheckShareLink(&types.ShareLink{}, "", now))
+}
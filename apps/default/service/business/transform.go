@@ -0,0 +1,142 @@
+package business
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/antinvestor/service-files/apps/default/service/types"
+)
+
+// transformQueryParams is the allowlist of thumbnail route query parameters.
+// allow_remote, timeout_ms and animated are accepted for client compatibility
+// and do not affect the output.
+var transformQueryParams = []string{
+	"width", "height", "method", "rotate", "format", "quality", "fx", "fy", "dpr",
+	"allow_remote", "timeout_ms", "animated",
+}
+
+var (
+	allowedRotations = []int{0, 90, 180, 270}
+	allowedFormats   = []string{types.FormatJPEG, types.FormatPNG, types.FormatWebP}
+	allowedDPRs      = []float64{1, 1.5, 2, 3}
+)
+
+// NewImageTransform returns a transform for the size with default options.
+func NewImageTransform(width, height int, resizeMethod string) *types.ImageTransform {
+	return &types.ImageTransform{
+		Width:        width,
+		Height:       height,
+		ResizeMethod: resizeMethod,
+		Format:       types.FormatJPEG,
+		Quality:      types.DefaultTransformQuality,
+		FocalX:       0.5,
+		FocalY:       0.5,
+		DPR:          1,
+	}
+}
+
+// ParseImageTransform builds a transform from thumbnail query parameters.
+// Parameters outside the allowlist and malformed values are rejected; range
+// checks happen when the download request is validated.
+func ParseImageTransform(query url.Values) (*types.ImageTransform, error) {
+	for key := range query {
+		if !slices.Contains(transformQueryParams, key) {
+			return nil, fmt.Errorf("invalid parameter: unsupported thumbnail parameter %q", key)
+		}
+	}
+
+	width, err := parseIntParam(query, "width", -1)
+	if err != nil {
+		return nil, err
+	}
+	height, err := parseIntParam(query, "height", -1)
+	if err != nil {
+		return nil, err
+	}
+	t := NewImageTransform(width, height, strings.ToLower(query.Get("method")))
+
+	if t.Rotate, err = parseIntParam(query, "rotate", 0); err != nil {
+		return nil, err
+	}
+	if t.Quality, err = parseIntParam(query, "quality", types.DefaultTransformQuality); err != nil {
+		return nil, err
+	}
+	if format := query.Get("format"); format != "" {
+		t.Format = strings.ToLower(format)
+		if t.Format == "jpg" {
+			t.Format = types.FormatJPEG
+		}
+	}
+	if t.FocalX, err = parseFloatParam(query, "fx", 0.5); err != nil {
+		return nil, err
+	}
+	if t.FocalY, err = parseFloatParam(query, "fy", 0.5); err != nil {
+		return nil, err
+	}
+	if t.DPR, err = parseFloatParam(query, "dpr", 1); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// validateImageTransform checks transform options against the allowlists and
+// the rendered size against maxDim.
+func validateImageTransform(t *types.ImageTransform, maxDim int) error {
+	if t.Width <= 0 || t.Height <= 0 {
+		return fmt.Errorf("invalid parameter: width and height must be > 0")
+	}
+	if t.ResizeMethod != types.Crop && t.ResizeMethod != types.Scale {
+		return fmt.Errorf("invalid parameter: unsupported resize method")
+	}
+	if !slices.Contains(allowedRotations, t.Rotate) {
+		return fmt.Errorf("invalid parameter: rotate must be one of 0, 90, 180 or 270")
+	}
+	if !slices.Contains(allowedFormats, t.Format) {
+		return fmt.Errorf("invalid parameter: format must be jpeg, png or webp")
+	}
+	if t.Quality < 1 || t.Quality > 100 {
+		return fmt.Errorf("invalid parameter: quality must be between 1 and 100")
+	}
+	if t.Format != types.FormatJPEG && t.Quality != types.DefaultTransformQuality {
+		return fmt.Errorf("invalid parameter: quality applies to jpeg output only")
+	}
+	if t.FocalX < 0 || t.FocalX > 1 || t.FocalY < 0 || t.FocalY > 1 {
+		return fmt.Errorf("invalid parameter: focal point must be between 0 and 1")
+	}
+	if !slices.Contains(allowedDPRs, t.DPR) {
+		return fmt.Errorf("invalid parameter: dpr must be one of 1, 1.5, 2 or 3")
+	}
+	width, height := t.PixelSize()
+	if width > maxDim || height > maxDim {
+		return fmt.Errorf("invalid parameter: width and height multiplied by dpr must be <= %d", maxDim)
+	}
+	return nil
+}
+
+func parseIntParam(query url.Values, key string, fallback int) (int, error) {
+	raw := query.Get(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid parameter: %s must be an integer", key)
+	}
+	return v, nil
+}
+
+func parseFloatParam(query url.Values, key string, fallback float64) (float64, error) {
+	raw := query.Get(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("invalid parameter: %s must be a number", key)
+	}
+	return v, nil
+}
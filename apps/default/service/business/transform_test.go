@@ -0,0 +1,103 @@
+package business
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImageTransform(t *testing.T) {
+	query := url.Values{
+		"width":   {"100"},
+		"height":  {"80"},
+		"method":  {"CROP"},
+		"rotate":  {"90"},
+		"format":  {"jpg"},
+		"quality": {"70"},
+		"fx":      {"0.25"},
+		"fy":      {"0.75"},
+		"dpr":     {"2"},
+	}
+	transform, err := ParseImageTransform(query)
+	require.NoError(t, err)
+	assert.Equal(t, &types.ImageTransform{
+		Width: 100, Height: 80, ResizeMethod: types.Crop, Rotate: 90,
+		Format: types.FormatJPEG, Quality: 70, FocalX: 0.25, FocalY: 0.75, DPR: 2,
+	}, transform)
+	require.NoError(t, validateImageTransform(transform, 2048))
+
+	defaults, err := ParseImageTransform(url.Values{"width": {"96"}, "height": {"96"}, "method": {"scale"}})
+	require.NoError(t, err)
+	assert.True(t, defaults.IsPlainThumbnail())
+
+	_, err = ParseImageTransform(url.Values{"width": {"96"}, "height": {"96"}, "blur": {"5"}})
+	require.ErrorContains(t, err, "invalid parameter")
+
+	_, err = ParseImageTransform(url.Values{"width": {"96"}, "height": {"96"}, "fx": {"NaN"}})
+	require.ErrorContains(t, err, "invalid parameter")
+}
+
+func TestValidateImageTransform(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mutate func(*types.ImageTransform)
+	}{
+		{name: "rotation", mutate: func(t *types.ImageTransform) { t.Rotate = 45 }},
+		{name: "format", mutate: func(t *types.ImageTransform) { t.Format = "gif" }},
+		{name: "quality", mutate: func(t *types.ImageTransform) { t.Quality = 101 }},
+		{name: "lossless quality", mutate: func(t *types.ImageTransform) { t.Format = types.FormatWebP; t.Quality = 50 }},
+		{name: "focal point", mutate: func(t *types.ImageTransform) { t.FocalX = 1.5 }},
+		{name: "dpr", mutate: func(t *types.ImageTransform) { t.DPR = 4 }},
+		{name: "dimension after dpr", mutate: func(t *types.ImageTransform) { t.Width = 1500; t.DPR = 2 }},
+		{name: "method", mutate: func(t *types.ImageTransform) { t.ResizeMethod = "stretch" }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transform := NewImageTransform(100, 100, types.Crop)
+			require.NoError(t, validateImageTransform(transform, 2048))
+			tc.mutate(transform)
+			require.ErrorContains(t, validateImageTransform(transform, 2048), "invalid parameter")
+		})
+	}
+}
+
+func TestImageTransformKey(t *testing.T) {
+	a := NewImageTransform(100, 100, types.Scale)
+	a.Format = types.FormatPNG
+	b := NewImageTransform(100, 100, types.Scale)
+	b.Format = types.FormatPNG
+	b.Quality = 10
+	b.FocalX = 0.1
+	assert.Equal(t, a.Key(), b.Key(), "quality and focal point do not affect scaled PNG output")
+
+	c := NewImageTransform(50, 50, types.Scale)
+	c.Format = types.FormatPNG
+	c.DPR = 2
+	assert.Equal(t, a.Key(), c.Key(), "keys use rendered pixel size")
+
+	d := NewImageTransform(100, 100, types.Scale)
+	d.Rotate = 180
+	assert.NotEqual(t, a.Key(), d.Key())
+	assert.False(t, d.IsPlainThumbnail())
+	e := NewImageTransform(100, 100, types.Crop)
+	e.FocalX = 0.501
+	f := NewImageTransform(101, 100, types.Crop)
+	f.FocalX = 0.504
+	assert.NotEqual(t, e.Key(), f.Key())
+	assert.Equal(t, e.Normalised().Key(), f.Normalised().Key(),
+		"sizes round to the size step and focal points to whole percents")
+
+	g := NewImageTransform(100, 100, types.Crop)
+	g.FocalX = 0.51
+	assert.NotEqual(t, e.Normalised().Key(), g.Normalised().Key(), "distinct focal points get distinct keys")
+
+	n := NewImageTransform(50, 33, types.Scale)
+	n.DPR = 2
+	w, h := n.Normalised().PixelSize()
+	assert.Equal(t, 112, w)
+	assert.Equal(t, 80, h)
+}
@@ -1,12 +1,14 @@
 package cache
 
 import (
+	"container/list"
 	"sync"
 	"time"
 )
 
 // CacheEntry represents a single cache entry
 type CacheEntry struct {
+	Key        string
 	Value      any
 	Expiration int64
 }
@@ -44,10 +46,12 @@ func DefaultCacheConfig() *CacheConfig {
 	}
 }
 
-// Cache is a thread-safe in-memory cache with TTL support
+// Cache is a thread-safe in-memory cache with TTL support. When full, the
+// least recently used entry is evicted.
 type Cache struct {
 	mu     sync.RWMutex
-	items  map[string]*CacheEntry
+	items  map[string]*list.Element
+	order  *list.List // front is the most recently used
 	config *CacheConfig
 }
 
@@ -58,7 +62,8 @@ func NewCache(config *CacheConfig) *Cache {
 	}
 
 	c := &Cache{
-		items:  make(map[string]*CacheEntry),
+		items:  make(map[string]*list.Element),
+		order:  list.New(),
 		config: config,
 	}
 
@@ -78,44 +83,50 @@ func (c *Cache) SetWithTTL(key string, value any, ttl time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// Check if we need to evict before adding
-	if c.config.MaxSize > 0 && len(c.items) >= c.config.MaxSize {
-		c.evictOne()
-	}
-
 	var expiration int64
 	if ttl > 0 {
 		expiration = time.Now().Add(ttl).UnixNano()
 	}
 
-	c.items[key] = &CacheEntry{
+	if elem, exists := c.items[key]; exists {
+		entry := elem.Value.(*CacheEntry)
+		entry.Value = value
+		entry.Expiration = expiration
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	// Check if we need to evict before adding
+	if c.config.MaxSize > 0 && len(c.items) >= c.config.MaxSize {
+		c.evictOne()
+	}
+
+	c.items[key] = c.order.PushFront(&CacheEntry{
+		Key:        key,
 		Value:      value,
 		Expiration: expiration,
-	}
+	})
 }
 
 // Get retrieves a value from the cache
 // Returns the value and true if found and not expired, nil and false otherwise
 func (c *Cache) Get(key string) (any, bool) {
 	c.mu.Lock()
-	item, found := c.items[key]
-	c.mu.Unlock()
+	defer c.mu.Unlock()
 
+	elem, found := c.items[key]
 	if !found {
 		return nil, false
 	}
 
+	item := elem.Value.(*CacheEntry)
 	if item.IsExpired() {
 		// Delete expired entry
-		c.mu.Lock()
-		delete(c.items, key)
-		if c.config.OnEviction != nil {
-			c.config.OnEviction(key, item.Value)
-		}
-		c.mu.Unlock()
+		c.remove(elem)
 		return nil, false
 	}
 
+	c.order.MoveToFront(elem)
 	return item.Value, true
 }
 
@@ -172,11 +183,8 @@ func (c *Cache) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if item, exists := c.items[key]; exists {
-		delete(c.items, key)
-		if c.config.OnEviction != nil {
-			c.config.OnEviction(key, item.Value)
-		}
+	if elem, exists := c.items[key]; exists {
+		c.remove(elem)
 	}
 }
 
@@ -186,12 +194,13 @@ func (c *Cache) Clear() {
 	defer c.mu.Unlock()
 
 	if c.config.OnEviction != nil {
-		for key, item := range c.items {
-			c.config.OnEviction(key, item.Value)
+		for key, elem := range c.items {
+			c.config.OnEviction(key, elem.Value.(*CacheEntry).Value)
 		}
 	}
 
-	c.items = make(map[string]*CacheEntry)
+	c.items = make(map[string]*list.Element)
+	c.order.Init()
 }
 
 // Size returns the number of entries in the cache
@@ -209,28 +218,30 @@ func (c *Cache) cleanup() {
 	for range ticker.C {
 		c.mu.Lock()
 		now := time.Now().UnixNano()
-		for key, item := range c.items {
+		for _, elem := range c.items {
+			item := elem.Value.(*CacheEntry)
 			if item.Expiration > 0 && now > item.Expiration {
-				delete(c.items, key)
-				if c.config.OnEviction != nil {
-					c.config.OnEviction(key, item.Value)
-				}
+				c.remove(elem)
 			}
 		}
 		c.mu.Unlock()
 	}
 }
 
-// evictOne evicts a single entry from the cache
-// Uses a simple FIFO strategy - in production, consider LRU or LFU
+// evictOne evicts the least recently used entry from the cache
 func (c *Cache) evictOne() {
-	// Simple FIFO eviction - remove first key
-	for key, item := range c.items {
-		delete(c.items, key)
-		if c.config.OnEviction != nil {
-			c.config.OnEviction(key, item.Value)
-		}
-		return
+	if elem := c.order.Back(); elem != nil {
+		c.remove(elem)
+	}
+}
+
+// remove deletes an entry; the caller holds the lock
+func (c *Cache) remove(elem *list.Element) {
+	item := elem.Value.(*CacheEntry)
+	c.order.Remove(elem)
+	delete(c.items, item.Key)
+	if c.config.OnEviction != nil {
+		c.config.OnEviction(item.Key, item.Value)
 	}
 }
 
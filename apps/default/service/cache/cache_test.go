@@ -298,3 +298,30 @@ func TestCacheWithNilConfig(t *testing.T) {
 	require.True(t, found)
 	require.Equal(t, "value", result)
 }
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	config := DefaultCacheConfig()
+	config.MaxSize = 2
+
+	cache := NewCache(config)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	// Reading a makes b the least recently used entry
+	_, found := cache.Get("a")
+	require.True(t, found)
+
+	cache.Set("c", 3)
+	_, foundA := cache.Get("a")
+	_, foundB := cache.Get("b")
+	_, foundC := cache.Get("c")
+	require.True(t, foundA)
+	require.False(t, foundB)
+	require.True(t, foundC)
+
+	// Replacing an entry does not evict another
+	cache.Set("a", 10)
+	require.Equal(t, 2, cache.Size())
+	value, _ := cache.Get("a")
+	require.Equal(t, 10, value)
+}
@@ -4,18 +4,81 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/pitabwire/util"
 )
 
-// Manager manages multiple cache instances for different data types
+// Cache types reported with hits and misses
+const (
+	TypeThumbnail    = "thumbnail"
+	TypeMetadata     = "metadata"
+	TypeURLPreview   = "url_preview"
+	TypeStorageStats = "storage_stats"
+)
+
+// sharedKeyPrefix namespaces the keys of the service in a shared store.
+const sharedKeyPrefix = "files:"
+
+// Store is the backend of the Manager caches. Frame's raw caches, such as
+// its Redis and Valkey caches, satisfy it.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+// Recorder receives cache hits and misses by cache type.
+type Recorder interface {
+	RecordCacheHit(ctx context.Context, cacheType string)
+	RecordCacheMiss(ctx context.Context, cacheType string)
+}
+
+// lruStore adapts an in-process Cache to a Store.
+type lruStore struct {
+	cache *Cache
+}
+
+// NewLRUStore returns an in-process store evicting the least recently used
+// entries once config.MaxSize is reached.
+func NewLRUStore(config *CacheConfig) Store {
+	return &lruStore{cache: NewCache(config)}
+}
+
+func (s *lruStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	value, found := s.cache.GetBytes(key)
+	return value, found, nil
+}
+
+func (s *lruStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.cache.SetWithTTL(key, value, ttl)
+	return nil
+}
+
+func (s *lruStore) Delete(_ context.Context, key string) error {
+	s.cache.Delete(key)
+	return nil
+}
+
+// Manager manages the caches of the different data types. Values are
+// serialized, so a store shared between replicas returns the same results
+// as an in-process one. Cache failures are logged and treated as misses.
+// A nil Manager caches nothing.
 type Manager struct {
-	thumbnailCache    *Cache
-	metadataCache     *Cache
-	urlPreviewCache   *Cache
-	storageStatsCache *Cache
+	thumbnailCache    Store
+	metadataCache     Store
+	urlPreviewCache   Store
+	storageStatsCache Store
+
+	config   *ManagerConfig
+	recorder Recorder
+	// prefix namespaces the keys in a store shared with other services
+	prefix string
 }
 
 // ManagerConfig defines the configuration for the cache manager
@@ -56,110 +119,208 @@ func DefaultManagerConfig() *ManagerConfig {
 	}
 }
 
-// NewManager creates a new cache manager
-func NewManager(config *ManagerConfig) *Manager {
+// NewManager creates a cache manager backed by in-process LRU caches
+func NewManager(config *ManagerConfig, recorder Recorder) *Manager {
 	if config == nil {
 		config = DefaultManagerConfig()
 	}
 
 	return &Manager{
-		thumbnailCache:    NewCache(config.ThumbnailCache),
-		metadataCache:     NewCache(config.MetadataCache),
-		urlPreviewCache:   NewCache(config.URLPreviewCache),
-		storageStatsCache: NewCache(config.StorageStatsCache),
+		thumbnailCache:    NewLRUStore(config.ThumbnailCache),
+		metadataCache:     NewLRUStore(config.MetadataCache),
+		urlPreviewCache:   NewLRUStore(config.URLPreviewCache),
+		storageStatsCache: NewLRUStore(config.StorageStatsCache),
+		config:            config,
+		recorder:          recorder,
 	}
 }
 
-// ThumbnailCache methods
+// NewSharedManager creates a cache manager whose caches all live in store,
+// such as a Redis cache shared by every replica. Only the TTLs of config
+// apply; the store bounds its own size.
+func NewSharedManager(store Store, config *ManagerConfig, recorder Recorder) *Manager {
+	if config == nil {
+		config = DefaultManagerConfig()
+	}
+
+	return &Manager{
+		thumbnailCache:    store,
+		metadataCache:     store,
+		urlPreviewCache:   store,
+		storageStatsCache: store,
+		config:            config,
+		recorder:          recorder,
+		prefix:            sharedKeyPrefix,
+	}
+}
+
+// get reads and decodes a value, recording the hit or miss.
+func (m *Manager) get(ctx context.Context, store Store, cacheType, key string, value any) bool {
+	data, found, err := store.Get(ctx, m.prefix+key)
+	if err == nil && found {
+		if err = json.Unmarshal(data, value); err == nil {
+			m.record(ctx, cacheType, true)
+			return true
+		}
+	}
+	if err != nil {
+		util.Log(ctx).WithError(err).With("cache", cacheType).Warn("cache read failed")
+	}
+	m.record(ctx, cacheType, false)
+	return false
+}
 
-// GetThumbnail retrieves cached thumbnail data
-func (m *Manager) GetThumbnail(mediaID string, width, height int, method string) ([]byte, bool) {
-	key := m.thumbnailKey(mediaID, width, height, method)
-	return m.thumbnailCache.GetBytes(key)
+// set encodes and stores a value.
+func (m *Manager) set(ctx context.Context, store Store, cacheType, key string, value any, ttl time.Duration) {
+	data, err := json.Marshal(value)
+	if err == nil {
+		err = store.Set(ctx, m.prefix+key, data, ttl)
+	}
+	if err != nil {
+		util.Log(ctx).WithError(err).With("cache", cacheType).Warn("cache write failed")
+	}
 }
 
-// SetThumbnail stores thumbnail data in cache
-func (m *Manager) SetThumbnail(mediaID string, width, height int, method string, data []byte) {
-	key := m.thumbnailKey(mediaID, width, height, method)
-	m.thumbnailCache.Set(key, data)
+func (m *Manager) remove(ctx context.Context, store Store, cacheType, key string) {
+	if err := store.Delete(ctx, m.prefix+key); err != nil {
+		util.Log(ctx).WithError(err).With("cache", cacheType).Warn("cache invalidation failed")
+	}
 }
 
-// DeleteThumbnail removes a thumbnail from cache
-func (m *Manager) DeleteThumbnail(mediaID string, width, height int, method string) {
-	key := m.thumbnailKey(mediaID, width, height, method)
-	m.thumbnailCache.Delete(key)
+func (m *Manager) record(ctx context.Context, cacheType string, hit bool) {
+	if m.recorder == nil {
+		return
+	}
+	if hit {
+		m.recorder.RecordCacheHit(ctx, cacheType)
+	} else {
+		m.recorder.RecordCacheMiss(ctx, cacheType)
+	}
 }
 
-// thumbnailKey generates a unique cache key for a thumbnail
-func (m *Manager) thumbnailKey(mediaID string, width, height int, method string) string {
-	return fmt.Sprintf("thumb:%s:%dx%d:%s", mediaID, width, height, method)
+// ThumbnailCache methods
+
+// ThumbnailData is a cached thumbnail with the metadata it is served with
+type ThumbnailData struct {
+	Content     []byte
+	ContentType string
+	Metadata    *types.MediaMetadata
+}
+
+// GetThumbnail retrieves a cached thumbnail. Variant identifies the size
+// and transform; the original's content hash is part of the key, so
+// thumbnails of replaced content are never returned.
+func (m *Manager) GetThumbnail(ctx context.Context, original *types.MediaMetadata, variant string) (*ThumbnailData, bool) {
+	if m == nil {
+		return nil, false
+	}
+	var data ThumbnailData
+	if !m.get(ctx, m.thumbnailCache, TypeThumbnail, m.thumbnailKey(ctx, original, variant), &data) {
+		return nil, false
+	}
+	return &data, true
 }
 
-// DeleteAllThumbnails removes all thumbnails for a media ID
-func (m *Manager) DeleteAllThumbnails(mediaID string) {
-	// In production, implement prefix-based deletion or use a cache with prefix support
-	// For now, we'll skip this as the simple cache doesn't support prefix deletion
+// SetThumbnail stores a thumbnail in cache
+func (m *Manager) SetThumbnail(ctx context.Context, original *types.MediaMetadata, variant string, data *ThumbnailData) {
+	if m == nil {
+		return
+	}
+	m.set(ctx, m.thumbnailCache, TypeThumbnail, m.thumbnailKey(ctx, original, variant), data, m.config.ThumbnailCache.DefaultTTL)
+}
+
+// thumbnailKey generates a unique cache key for a thumbnail
+func (m *Manager) thumbnailKey(ctx context.Context, original *types.MediaMetadata, variant string) string {
+	return fmt.Sprintf("thumb:%s:%s:%s:%s", tenancyScope(ctx), original.MediaID, original.Base64Hash, variant)
 }
 
 // MetadataCache methods
 
 // GetMetadata retrieves cached metadata
-func (m *Manager) GetMetadata(mediaID types.MediaID) (*types.MediaMetadata, bool) {
-	key := fmt.Sprintf("meta:%s", mediaID)
-	value, found := m.metadataCache.Get(key)
-	if !found {
+func (m *Manager) GetMetadata(ctx context.Context, mediaID types.MediaID) (*types.MediaMetadata, bool) {
+	if m == nil {
 		return nil, false
 	}
-	if metadata, ok := value.(*types.MediaMetadata); ok {
-		return metadata, true
+	var metadata types.MediaMetadata
+	if !m.get(ctx, m.metadataCache, TypeMetadata, m.metadataKey(ctx, mediaID), &metadata) {
+		return nil, false
 	}
-	return nil, false
+	return &metadata, true
 }
 
-// SetMetadata stores metadata in cache
-func (m *Manager) SetMetadata(mediaID types.MediaID, metadata *types.MediaMetadata) {
-	key := fmt.Sprintf("meta:%s", mediaID)
-	m.metadataCache.Set(key, metadata)
+// SetMetadata stores metadata in cache. Media still being processed is not
+// cached, as its scan status and placeholder are about to change.
+func (m *Manager) SetMetadata(ctx context.Context, metadata *types.MediaMetadata) {
+	if m == nil || metadata == nil || !isSettled(metadata) {
+		return
+	}
+	m.set(ctx, m.metadataCache, TypeMetadata, m.metadataKey(ctx, metadata.MediaID), metadata, m.config.MetadataCache.DefaultTTL)
 }
 
 // DeleteMetadata removes metadata from cache
-func (m *Manager) DeleteMetadata(mediaID types.MediaID) {
-	key := fmt.Sprintf("meta:%s", mediaID)
-	m.metadataCache.Delete(key)
+func (m *Manager) DeleteMetadata(ctx context.Context, mediaID types.MediaID) {
+	if m == nil {
+		return
+	}
+	m.remove(ctx, m.metadataCache, TypeMetadata, m.metadataKey(ctx, mediaID))
+}
+
+func (m *Manager) metadataKey(ctx context.Context, mediaID types.MediaID) string {
+	return fmt.Sprintf("meta:%s:%s", tenancyScope(ctx), mediaID)
+}
+
+// tenancyScope keeps the media entries of tenants apart, so a cached entry
+// is only served within the tenancy it was read in.
+func tenancyScope(ctx context.Context) string {
+	claims := security.ClaimsFromContext(ctx)
+	if claims == nil {
+		return "-"
+	}
+	return claims.GetTenantID() + "/" + claims.GetPartitionID()
+}
+
+// isSettled reports whether background processing of the media is done.
+func isSettled(metadata *types.MediaMetadata) bool {
+	if metadata.ScanStatus == types.ScanStatusPending {
+		return false
+	}
+	isOriginalImage := metadata.ParentID == "" && strings.HasPrefix(string(metadata.ContentType), "image/")
+	return !isOriginalImage || metadata.Placeholder != nil
 }
 
 // URLPreviewCache methods
 
 // URLPreviewData represents cached URL preview data
 type URLPreviewData struct {
-	Title       string
-	Description string
-	ImageURL    string
-	SiteName    string
+	OgData       map[string]string
+	ImageMediaID string
 }
 
-// GetURLPreview retrieves cached URL preview
-func (m *Manager) GetURLPreview(url string) (*URLPreviewData, bool) {
-	key := m.urlPreviewKey(url)
-	value, found := m.urlPreviewCache.Get(key)
-	if !found {
+// GetURLPreview retrieves the cached preview of a URL for a subject.
+// Previews are kept per subject because their image is stored as the
+// subject's media.
+func (m *Manager) GetURLPreview(ctx context.Context, subject, url string) (*URLPreviewData, bool) {
+	if m == nil {
 		return nil, false
 	}
-	if preview, ok := value.(*URLPreviewData); ok {
-		return preview, true
+	var preview URLPreviewData
+	if !m.get(ctx, m.urlPreviewCache, TypeURLPreview, m.urlPreviewKey(subject, url), &preview) {
+		return nil, false
 	}
-	return nil, false
+	return &preview, true
 }
 
 // SetURLPreview stores URL preview in cache
-func (m *Manager) SetURLPreview(url string, preview *URLPreviewData) {
-	key := m.urlPreviewKey(url)
-	m.urlPreviewCache.Set(key, preview)
+func (m *Manager) SetURLPreview(ctx context.Context, subject, url string, preview *URLPreviewData) {
+	if m == nil {
+		return
+	}
+	m.set(ctx, m.urlPreviewCache, TypeURLPreview, m.urlPreviewKey(subject, url), preview, m.config.URLPreviewCache.DefaultTTL)
 }
 
 // urlPreviewKey generates a cache key for URL preview
-func (m *Manager) urlPreviewKey(url string) string {
-	hash := sha256.Sum256([]byte(url))
+func (m *Manager) urlPreviewKey(subject, url string) string {
+	hash := sha256.Sum256([]byte(subject + "\x00" + url))
 	return fmt.Sprintf("url_preview:%s", hex.EncodeToString(hash[:]))
 }
 
@@ -177,49 +338,30 @@ type StorageStatsData struct {
 
 // GetStorageStats retrieves cached storage stats
 func (m *Manager) GetStorageStats(ctx context.Context, userID string) (*StorageStatsData, bool) {
-	key := fmt.Sprintf("stats:%s", userID)
-	value, found := m.storageStatsCache.Get(key)
-	if found {
-		if data, ok := value.(*StorageStatsData); ok {
-			// Check if the stats are still fresh (within 1 minute)
-			if time.Since(data.ComputedAt) < time.Minute {
-				return data, true
-			}
-			m.storageStatsCache.Delete(key)
-		}
+	if m == nil {
+		return nil, false
+	}
+	var data StorageStatsData
+	if !m.get(ctx, m.storageStatsCache, TypeStorageStats, m.storageStatsKey(userID), &data) {
+		return nil, false
+	}
+	// Check if the stats are still fresh (within 1 minute)
+	if time.Since(data.ComputedAt) >= time.Minute {
+		m.remove(ctx, m.storageStatsCache, TypeStorageStats, m.storageStatsKey(userID))
+		return nil, false
 	}
-	return nil, false
+	return &data, true
 }
 
 // SetStorageStats stores storage stats in cache
 func (m *Manager) SetStorageStats(ctx context.Context, userID string, data *StorageStatsData) {
+	if m == nil {
+		return
+	}
 	data.ComputedAt = time.Now()
-	key := fmt.Sprintf("stats:%s", userID)
-	m.storageStatsCache.Set(key, data)
+	m.set(ctx, m.storageStatsCache, TypeStorageStats, m.storageStatsKey(userID), data, m.config.StorageStatsCache.DefaultTTL)
 }
 
-// InvalidateAll clears all caches
-func (m *Manager) InvalidateAll() {
-	m.thumbnailCache.Clear()
-	m.metadataCache.Clear()
-	m.urlPreviewCache.Clear()
-	m.storageStatsCache.Clear()
-}
-
-// CacheStats provides statistics about the cache
-type CacheStats struct {
-	ThumbnailSize    int
-	MetadataSize     int
-	URLPreviewSize   int
-	StorageStatsSize int
-}
-
-// Stats returns cache statistics
-func (m *Manager) Stats() CacheStats {
-	return CacheStats{
-		ThumbnailSize:    m.thumbnailCache.Size(),
-		MetadataSize:     m.metadataCache.Size(),
-		URLPreviewSize:   m.urlPreviewCache.Size(),
-		StorageStatsSize: m.storageStatsCache.Size(),
-	}
+func (m *Manager) storageStatsKey(userID string) string {
+	return fmt.Sprintf("stats:%s", userID)
 }
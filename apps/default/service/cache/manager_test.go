@@ -0,0 +1,168 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	framecache "github.com/pitabwire/frame/v2/cache"
+	"github.com/pitabwire/frame/v2/cache/redis"
+	"github.com/pitabwire/frame/v2/data"
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/stretchr/testify/require"
+)
+
+type countingRecorder struct {
+	mu     sync.Mutex
+	hits   map[string]int
+	misses map[string]int
+}
+
+func newCountingRecorder() *countingRecorder {
+	return &countingRecorder{hits: map[string]int{}, misses: map[string]int{}}
+}
+
+func (r *countingRecorder) RecordCacheHit(_ context.Context, cacheType string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hits[cacheType]++
+}
+
+func (r *countingRecorder) RecordCacheMiss(_ context.Context, cacheType string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.misses[cacheType]++
+}
+
+func tenantCtx(tenantID string) context.Context {
+	claims := &security.AuthenticationClaims{TenantID: tenantID, PartitionID: "p1"}
+	return claims.ClaimsToContext(context.Background())
+}
+
+func newRedisManager(t *testing.T, recorder Recorder) (*Manager, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	raw, err := redis.New(framecache.WithDSN(data.DSN("redis://" + server.Addr())))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = raw.Close() })
+	return NewSharedManager(raw, nil, recorder), server
+}
+
+func TestManagerBackends(t *testing.T) {
+	backends := map[string]func(t *testing.T, recorder Recorder) *Manager{
+		"lru": func(_ *testing.T, recorder Recorder) *Manager {
+			return NewManager(nil, recorder)
+		},
+		"redis": func(t *testing.T, recorder Recorder) *Manager {
+			m, _ := newRedisManager(t, recorder)
+			return m
+		},
+	}
+
+	for name, newManager := range backends {
+		t.Run(name, func(t *testing.T) {
+			recorder := newCountingRecorder()
+			m := newManager(t, recorder)
+			ctx := tenantCtx("t1")
+
+			metadata := &types.MediaMetadata{
+				MediaID:     "media-1",
+				ContentType: "text/plain",
+				Base64Hash:  "hash-1",
+				OwnerID:     "owner",
+				ScanStatus:  types.ScanStatusClean,
+				Exif:        map[string]string{"Make": "Camera"},
+			}
+
+			_, found := m.GetMetadata(ctx, "media-1")
+			require.False(t, found)
+
+			m.SetMetadata(ctx, metadata)
+			cached, found := m.GetMetadata(ctx, "media-1")
+			require.True(t, found)
+			require.Equal(t, metadata, cached)
+
+			// Another tenant does not see the entry.
+			_, found = m.GetMetadata(tenantCtx("t2"), "media-1")
+			require.False(t, found)
+
+			m.DeleteMetadata(ctx, "media-1")
+			_, found = m.GetMetadata(ctx, "media-1")
+			require.False(t, found)
+
+			thumbnail := &ThumbnailData{Content: []byte{1, 2, 3}, ContentType: "image/jpeg", Metadata: &types.MediaMetadata{MediaID: "thumb-1"}}
+			m.SetThumbnail(ctx, metadata, "32x32:crop", thumbnail)
+			cachedThumbnail, found := m.GetThumbnail(ctx, metadata, "32x32:crop")
+			require.True(t, found)
+			require.Equal(t, thumbnail, cachedThumbnail)
+
+			// Replaced content has a new hash and so misses.
+			replaced := *metadata
+			replaced.Base64Hash = "hash-2"
+			_, found = m.GetThumbnail(ctx, &replaced, "32x32:crop")
+			require.False(t, found)
+
+			preview := &URLPreviewData{OgData: map[string]string{"og:title": "Title"}, ImageMediaID: "img-1"}
+			m.SetURLPreview(ctx, "user-1", "https://example.com", preview)
+			cachedPreview, found := m.GetURLPreview(ctx, "user-1", "https://example.com")
+			require.True(t, found)
+			require.Equal(t, preview, cachedPreview)
+			_, found = m.GetURLPreview(ctx, "user-2", "https://example.com")
+			require.False(t, found)
+
+			require.Equal(t, 1, recorder.hits[TypeMetadata])
+			require.Equal(t, 3, recorder.misses[TypeMetadata])
+			require.Equal(t, 1, recorder.hits[TypeThumbnail])
+			require.Equal(t, 1, recorder.misses[TypeThumbnail])
+			require.Equal(t, 1, recorder.hits[TypeURLPreview])
+			require.Equal(t, 1, recorder.misses[TypeURLPreview])
+		})
+	}
+}
+
+func TestManagerSkipsUnsettledMetadata(t *testing.T) {
+	m := NewManager(nil, nil)
+	ctx := tenantCtx("t1")
+
+	m.SetMetadata(ctx, &types.MediaMetadata{MediaID: "pending", ContentType: "text/plain", ScanStatus: types.ScanStatusPending})
+	_, found := m.GetMetadata(ctx, "pending")
+	require.False(t, found, "media awaiting a scan result is not cached")
+
+	m.SetMetadata(ctx, &types.MediaMetadata{MediaID: "image", ContentType: "image/png"})
+	_, found = m.GetMetadata(ctx, "image")
+	require.False(t, found, "images awaiting their placeholder are not cached")
+
+	m.SetMetadata(ctx, &types.MediaMetadata{MediaID: "image", ContentType: "image/png", Placeholder: &types.Placeholder{BlurHash: "LEHV6n"}})
+	_, found = m.GetMetadata(ctx, "image")
+	require.True(t, found)
+}
+
+func TestManagerSharedStoreIsNamespaced(t *testing.T) {
+	m, server := newRedisManager(t, nil)
+	ctx := tenantCtx("t1")
+
+	m.SetMetadata(ctx, &types.MediaMetadata{MediaID: "media-1", ContentType: "text/plain"})
+	keys := server.Keys()
+	require.Len(t, keys, 1)
+	require.Equal(t, "files:meta:t1/p1:media-1", keys[0])
+
+	// A failing store degrades to misses.
+	server.Close()
+	_, found := m.GetMetadata(ctx, "media-1")
+	require.False(t, found)
+}
+
+func TestNilManagerCachesNothing(t *testing.T) {
+	var m *Manager
+	ctx := context.Background()
+
+	m.SetMetadata(ctx, &types.MediaMetadata{MediaID: "media-1"})
+	_, found := m.GetMetadata(ctx, "media-1")
+	require.False(t, found)
+	m.DeleteMetadata(ctx, "media-1")
+	_, found = m.GetThumbnail(ctx, &types.MediaMetadata{MediaID: "media-1"}, "v")
+	require.False(t, found)
+	_, found = m.GetURLPreview(ctx, "user", "https://example.com")
+	require.False(t, found)
+}
@@ -6,17 +6,21 @@ import (
 
 	"github.com/antinvestor/service-files/apps/default/service/storage/models"
 	"github.com/antinvestor/service-files/apps/default/service/storage/repository"
+	"github.com/pitabwire/frame/v2"
 	"github.com/pitabwire/frame/v2/data"
 	"github.com/pitabwire/frame/v2/events"
 	"github.com/pitabwire/util"
 )
 
+// MediaAuditSaveEventName is the event persisting a media audit record.
+const MediaAuditSaveEventName = "media.audit.save.event"
+
 type MediaAuditSaveEvent struct {
 	AuditRepository repository.MediaAuditRepository
 }
 
 func (mas *MediaAuditSaveEvent) Name() string {
-	return "media.audit.save.event"
+	return MediaAuditSaveEventName
 }
 
 func (mas *MediaAuditSaveEvent) PayloadType() any {
@@ -51,6 +55,18 @@ func (mas *MediaAuditSaveEvent) Execute(ctx context.Context, payload any) error
 	return nil
 }
 
+// EmitMediaAudit queues an audit record to be saved. Like lifecycle events
+// it never fails the request being audited; failures are only logged.
+func EmitMediaAudit(ctx context.Context, service *frame.Service, audit *models.MediaAudit) {
+	if service == nil || service.EventsManager() == nil {
+		return
+	}
+	if err := service.EventsManager().Emit(ctx, MediaAuditSaveEventName, audit); err != nil {
+		util.Log(ctx).WithError(err).With("file_id", audit.FileID, "action", audit.Action).
+			Warn("failed to emit media audit")
+	}
+}
+
 func NewAuditSaveHandler(auditRepository repository.MediaAuditRepository) events.EventI {
 	return &MediaAuditSaveEvent{AuditRepository: auditRepository}
 }
@@ -0,0 +1,53 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	filesv1 "github.com/antinvestor/service-files/apps/default/gen/files/v1"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/frame/v2"
+	"github.com/pitabwire/util"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// LifecycleEventTypeHeader carries the event type name so consumers can
+// filter file events without decoding the payload.
+const LifecycleEventTypeHeader = "event_type"
+
+// NewLifecycleEvent builds a lifecycle event for the given media.
+func NewLifecycleEvent(eventType filesv1.LifecycleEventType, media *types.MediaMetadata, actorID string, attributes map[string]string) *filesv1.LifecycleEvent {
+	evt := &filesv1.LifecycleEvent{
+		EventId:    util.IDString(),
+		EventType:  eventType,
+		ActorId:    actorID,
+		OccurredAt: timestamppb.New(time.Now().UTC()),
+		Attributes: attributes,
+	}
+	if media != nil {
+		evt.MediaId = string(media.MediaID)
+		evt.OwnerId = string(media.OwnerID)
+	}
+	return evt
+}
+
+// PublishLifecycleEvent publishes a typed lifecycle event on the file events queue.
+// The payload is the protobuf encoding of filesv1.LifecycleEvent.
+func PublishLifecycleEvent(ctx context.Context, service *frame.Service, evt *filesv1.LifecycleEvent) error {
+	cfg := service.Config().(*config.FilesConfig)
+	return service.QueueManager().Publish(ctx, cfg.QueueFileEventsName, evt, map[string]string{
+		LifecycleEventTypeHeader: evt.GetEventType().String(),
+	})
+}
+
+// EmitLifecycleEvent builds and publishes a lifecycle event. Publishing is
+// best effort: failures are logged and never fail the triggering operation.
+func EmitLifecycleEvent(ctx context.Context, service *frame.Service, eventType filesv1.LifecycleEventType, media *types.MediaMetadata, actorID string, attributes map[string]string) {
+	evt := NewLifecycleEvent(eventType, media, actorID, attributes)
+	if err := PublishLifecycleEvent(ctx, service, evt); err != nil {
+		util.Log(ctx).WithError(err).
+			With("event_type", eventType.String(), "media_id", evt.GetMediaId()).
+			Warn("failed to publish file lifecycle event")
+	}
+}
@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -17,22 +19,31 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	commonv1 "buf.build/gen/go/antinvestor/common/protocolbuffers/go/common/v1"
-	"buf.build/gen/go/antinvestor/files/connectrpc/go/files/v1/filesv1connect"
-	filesv1 "buf.build/gen/go/antinvestor/files/protocolbuffers/go/files/v1"
 	"connectrpc.com/connect"
 	"github.com/antinvestor/service-files/apps/default/config"
+	filesv1 "github.com/antinvestor/service-files/apps/default/gen/files/v1"
+	"github.com/antinvestor/service-files/apps/default/gen/files/v1/filesv1connect"
 	"github.com/antinvestor/service-files/apps/default/service/authz"
 	"github.com/antinvestor/service-files/apps/default/service/business"
+	"github.com/antinvestor/service-files/apps/default/service/cache"
+	"github.com/antinvestor/service-files/apps/default/service/events"
+	"github.com/antinvestor/service-files/apps/default/service/handler/routing"
+	"github.com/antinvestor/service-files/apps/default/service/imagemeta"
+	"github.com/antinvestor/service-files/apps/default/service/queue"
 	"github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
 	"github.com/antinvestor/service-files/apps/default/service/types"
 	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/antinvestor/service-files/apps/default/service/webhooks"
 	"github.com/pitabwire/frame/v2"
+	"github.com/pitabwire/frame/v2/data"
 	"github.com/pitabwire/frame/v2/security"
 	"github.com/pitabwire/util"
 	"golang.org/x/net/html"
@@ -65,6 +76,9 @@ type FileServer struct {
 	authz        authz.Middleware
 	db           storage.Database
 	provider     storage.Provider
+	// cache serves repeated metadata, thumbnail and preview reads; nil
+	// disables caching
+	cache *cache.Manager
 
 	filesv1connect.UnimplementedFilesServiceHandler
 }
@@ -76,6 +90,7 @@ func NewFileServer(
 	authzMiddleware authz.Middleware,
 	db storage.Database,
 	provider storage.Provider,
+	cacheManager *cache.Manager,
 ) filesv1connect.FilesServiceHandler {
 	return &FileServer{
 		Service:      service,
@@ -83,9 +98,24 @@ func NewFileServer(
 		authz:        authzMiddleware,
 		db:           db,
 		provider:     provider,
+		cache:        cacheManager,
 	}
 }
 
+// mediaMetadata returns the metadata of a media, from the cache when
+// present. Nil metadata means the media does not exist.
+func (s *FileServer) mediaMetadata(ctx context.Context, mediaID types.MediaID) (*types.MediaMetadata, error) {
+	if metadata, ok := s.cache.GetMetadata(ctx, mediaID); ok {
+		return metadata, nil
+	}
+	metadata, err := s.db.GetMediaMetadata(ctx, mediaID)
+	if err != nil || metadata == nil {
+		return metadata, err
+	}
+	s.cache.SetMetadata(ctx, metadata)
+	return metadata, nil
+}
+
 // UploadContent handles file uploads via Connect RPC streaming
 func (s *FileServer) UploadContent(ctx context.Context, stream *connect.ClientStream[filesv1.UploadContentRequest]) (*connect.Response[filesv1.UploadContentResponse], error) {
 	sub, err := authenticatedSubject(ctx)
@@ -108,7 +138,7 @@ func (s *FileServer) UploadContent(ctx context.Context, stream *connect.ClientSt
 
 	req := stream.Msg()
 	metadata := req.GetMetadata()
-	if err = validateUploadMetadata(metadata, cfg); err != nil {
+	if err = validateUploadMetadata(ctx, metadata, cfg); err != nil {
 		return nil, err
 	}
 
@@ -138,6 +168,7 @@ func (s *FileServer) UploadContent(ctx context.Context, stream *connect.ClientSt
 	defer util.CloseAndLogOnError(ctx, tempFile)
 
 	isPublic := resolveUploadVisibility(metadata)
+	folder, _ := business.CleanFolder(metadata.Folder)
 
 	businessReq := &business.UploadRequest{
 		OwnerID:       types.OwnerID(sub),
@@ -148,6 +179,8 @@ func (s *FileServer) UploadContent(ctx context.Context, stream *connect.ClientSt
 		FileData:      tempFile,
 		Config:        cfg,
 		IsPublic:      isPublic,
+		Purpose:       metadata.Purpose,
+		Folder:        folder,
 	}
 
 	result, err := s.mediaService.UploadFile(ctx, businessReq)
@@ -158,18 +191,23 @@ func (s *FileServer) UploadContent(ctx context.Context, stream *connect.ClientSt
 	if err = queueThumbnailGeneration(ctx, s.Service, result.MediaID); err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	if err = queueMediaScan(ctx, s.Service, result.MediaID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
 
 	storedMeta, _ := s.db.GetMediaMetadata(ctx, result.MediaID)
+	s.fileUnderFolder(ctx, storedMeta)
+	events.EmitLifecycleEvent(ctx, s.Service, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_UPLOADED, storedMeta, sub, nil)
 
 	return connect.NewResponse(&filesv1.UploadContentResponse{
 		MediaId:    string(result.MediaID),
 		ServerName: result.ServerName,
 		ContentUri: result.ContentURI,
-		Metadata:   toMediaMetadata(storedMeta),
+		Metadata:   toMediaMetadata(storedMeta, callerSubject(ctx)),
 	}), nil
 }
 
-func validateUploadMetadata(metadata *filesv1.UploadMetadata, cfg *config.FilesConfig) error {
+func validateUploadMetadata(ctx context.Context, metadata *filesv1.UploadMetadata, cfg *config.FilesConfig) error {
 	if metadata == nil {
 		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("metadata is required"))
 	}
@@ -183,6 +221,12 @@ func validateUploadMetadata(metadata *filesv1.UploadMetadata, cfg *config.FilesC
 		return connect.NewError(connect.CodeInvalidArgument,
 			fmt.Errorf("invalid parameter: HTTP Content-Length is greater than the maximum allowed upload size (%v)", cfg.MaxFileSizeBytes))
 	}
+	if err := business.CheckDeclaredContentType(ctx, cfg, metadata.Purpose, metadata.ContentType, metadata.Filename); err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if _, err := business.CleanFolder(metadata.Folder); err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
 	return nil
 }
 
@@ -265,10 +309,16 @@ func (s *FileServer) GetContent(ctx context.Context, req *connect.Request[filesv
 		return nil, connect.NewError(connect.CodePermissionDenied, err)
 	}
 
+	metadata, err := s.mediaMetadata(ctx, types.MediaID(req.Msg.MediaId))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
 	businessReq := &business.DownloadRequest{
 		MediaID:            types.MediaID(req.Msg.MediaId),
 		IsThumbnailRequest: false,
 		Config:             cfg,
+		Metadata:           metadata,
 	}
 
 	result, err := s.mediaService.DownloadFile(ctx, businessReq)
@@ -298,7 +348,7 @@ func (s *FileServer) GetContent(ctx context.Context, req *connect.Request[filesv
 
 	return connect.NewResponse(&filesv1.GetContentResponse{
 		Content:  data,
-		Metadata: toMediaMetadata(result.MediaMetadata),
+		Metadata: toMediaMetadata(result.MediaMetadata, callerSubject(ctx)),
 	}), nil
 }
 
@@ -342,7 +392,7 @@ func (s *FileServer) HeadContent(ctx context.Context, req *connect.Request[files
 		return nil, connect.NewError(connect.CodePermissionDenied, err)
 	}
 
-	metadata, err := s.db.GetMediaMetadata(ctx, types.MediaID(mediaID))
+	metadata, err := s.mediaMetadata(ctx, types.MediaID(mediaID))
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
@@ -351,7 +401,7 @@ func (s *FileServer) HeadContent(ctx context.Context, req *connect.Request[files
 	}
 
 	return connect.NewResponse(&filesv1.HeadContentResponse{
-		Metadata: toMediaMetadata(metadata),
+		Metadata: toMediaMetadata(metadata, callerSubject(ctx)),
 	}), nil
 }
 
@@ -394,6 +444,27 @@ func (s *FileServer) GetContentThumbnail(ctx context.Context, req *connect.Reque
 			Height:       int(req.Msg.Height),
 			ResizeMethod: method,
 		}
+		businessReq.Transform = toImageTransform(req.Msg, method)
+	}
+
+	original, err := s.mediaMetadata(ctx, businessReq.MediaID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	businessReq.Metadata = original
+
+	// A cached thumbnail is still served under the original's scan policy.
+	if original != nil && businessReq.Transform != nil {
+		if err = business.CheckScanPolicy(cfg, original); err != nil {
+			return nil, connect.NewError(mapBusinessErrorToConnectCode(err), err)
+		}
+		if cached, ok := s.cache.GetThumbnail(ctx, original, businessReq.Transform.Key()); ok {
+			return connect.NewResponse(&filesv1.GetContentThumbnailResponse{
+				Content:     cached.Content,
+				Metadata:    toMediaMetadata(cached.Metadata, callerSubject(ctx)),
+				ContentType: cached.ContentType,
+			}), nil
+		}
 	}
 
 	result, err := s.mediaService.DownloadFile(ctx, businessReq)
@@ -422,12 +493,219 @@ func (s *FileServer) GetContentThumbnail(ctx context.Context, req *connect.Reque
 			fmt.Errorf("thumbnail size exceeds maximum allowed %d bytes", maxThumbnailBytes))
 	}
 
+	if original != nil && businessReq.Transform != nil {
+		s.cache.SetThumbnail(ctx, original, businessReq.Transform.Key(), &cache.ThumbnailData{
+			Content:     data,
+			ContentType: result.ContentType,
+			Metadata:    result.MediaMetadata,
+		})
+	}
+
 	return connect.NewResponse(&filesv1.GetContentThumbnailResponse{
-		Content:  data,
-		Metadata: toMediaMetadata(result.MediaMetadata),
+		Content:     data,
+		Metadata:    toMediaMetadata(result.MediaMetadata, callerSubject(ctx)),
+		ContentType: result.ContentType,
 	}), nil
 }
 
+// toImageTransform maps the transform options of a thumbnail request; unset
+// options keep their defaults.
+func toImageTransform(msg *filesv1.GetContentThumbnailRequest, method string) *types.ImageTransform {
+	transform := business.NewImageTransform(int(msg.GetWidth()), int(msg.GetHeight()), method)
+	transform.Rotate = int(msg.GetRotate())
+	switch msg.GetFormat() {
+	case filesv1.ImageFormat_IMAGE_FORMAT_PNG:
+		transform.Format = types.FormatPNG
+	case filesv1.ImageFormat_IMAGE_FORMAT_WEBP:
+		transform.Format = types.FormatWebP
+	default:
+		transform.Format = types.FormatJPEG
+	}
+	if msg.GetQuality() > 0 {
+		transform.Quality = int(msg.GetQuality())
+	}
+	if msg.HasFocalX() {
+		transform.FocalX = float64(msg.GetFocalX())
+	}
+	if msg.HasFocalY() {
+		transform.FocalY = float64(msg.GetFocalY())
+	}
+	if msg.GetDpr() > 0 {
+		transform.DPR = float64(msg.GetDpr())
+	}
+	return transform
+}
+
+// GetThumbnailStatus reports the thumbnail generation job of an image.
+func (s *FileServer) GetThumbnailStatus(ctx context.Context, req *connect.Request[filesv1.GetThumbnailStatusRequest]) (*connect.Response[filesv1.GetThumbnailStatusResponse], error) {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mediaID := req.Msg.GetMediaId()
+	if !isValidMediaID(mediaID) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid media id"))
+	}
+	if err = s.authz.CanViewFile(ctx, sub, mediaID); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	job, err := s.db.GetThumbnailJob(ctx, types.MediaID(mediaID))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if job == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no thumbnail job recorded for media"))
+	}
+
+	status, err := s.thumbnailStatus(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&filesv1.GetThumbnailStatusResponse{Status: status}), nil
+}
+
+// RequeueThumbnail resets the thumbnail job of an image and queues it again.
+func (s *FileServer) RequeueThumbnail(ctx context.Context, req *connect.Request[filesv1.RequeueThumbnailRequest]) (*connect.Response[filesv1.RequeueThumbnailResponse], error) {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mediaID := types.MediaID(req.Msg.GetMediaId())
+	if !isValidMediaID(string(mediaID)) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid media id"))
+	}
+	if err = s.authz.CanEditFile(ctx, sub, string(mediaID)); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	metadata, err := s.db.GetMediaMetadata(ctx, mediaID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if metadata == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("media not found"))
+	}
+	if !strings.HasPrefix(string(metadata.ContentType), "image") {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("thumbnails are only generated for images"))
+	}
+	if metadata.ScanStatus == types.ScanStatusInfected {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, business.ErrScanBlocked)
+	}
+
+	job, err := queue.ResetThumbnailJob(ctx, s.db, s.Service.Config().(*config.FilesConfig), mediaID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err = queueThumbnailGeneration(ctx, s.Service, mediaID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	status, err := s.thumbnailStatus(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&filesv1.RequeueThumbnailResponse{Status: status}), nil
+}
+
+// RegenerateThumbnails starts a bulk run queueing missing thumbnail sizes of
+// existing images, or resumes an interrupted run from its checkpoint.
+func (s *FileServer) RegenerateThumbnails(ctx context.Context, req *connect.Request[filesv1.RegenerateThumbnailsRequest]) (*connect.Response[filesv1.RegenerateThumbnailsResponse], error) {
+	if _, err := authenticatedSubject(ctx); err != nil {
+		return nil, err
+	}
+	cfg := s.Service.Config().(*config.FilesConfig)
+
+	var run *types.ThumbnailRegeneration
+	if runID := req.Msg.GetResumeRunId(); runID != "" {
+		existing, err := s.db.GetThumbnailRegeneration(ctx, runID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		if existing == nil {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("regeneration run not found"))
+		}
+		if existing.State == types.ThumbnailRegenerationCompleted {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("regeneration run already completed"))
+		}
+		if existing.State == types.ThumbnailRegenerationRunning && existing.LeaseUntil != nil && existing.LeaseUntil.After(time.Now()) {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, queue.ErrRegenerationInProgress)
+		}
+		existing.State = types.ThumbnailRegenerationRunning
+		existing.LastError = ""
+		if err = s.db.UpdateThumbnailRegeneration(ctx, existing); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		run = existing
+	} else {
+		contentType := strings.TrimSpace(req.Msg.GetContentType())
+		if contentType != "" && !strings.HasPrefix(contentType, "image/") {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("content type must be an image type"))
+		}
+		filter := types.ThumbnailRegenerationFilter{
+			OwnerID:     types.OwnerID(req.Msg.GetOwnerId()),
+			ContentType: contentType,
+		}
+		if req.Msg.GetCreatedAfter() != nil {
+			after := req.Msg.GetCreatedAfter().AsTime()
+			filter.CreatedAfter = &after
+		}
+		if req.Msg.GetCreatedBefore() != nil {
+			before := req.Msg.GetCreatedBefore().AsTime()
+			filter.CreatedBefore = &before
+		}
+		if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("created_after must be before created_before"))
+		}
+
+		run = &types.ThumbnailRegeneration{
+			Filter:        filter,
+			RemoveStale:   req.Msg.GetRemoveStaleSizes(),
+			RatePerSecond: int(req.Msg.GetRatePerSecond()),
+			State:         types.ThumbnailRegenerationRunning,
+		}
+		if run.RatePerSecond <= 0 {
+			run.RatePerSecond = cfg.ThumbnailRegenerationRatePerSecond
+		}
+		if err := s.db.StoreThumbnailRegeneration(ctx, run); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	err := s.Service.QueueManager().Publish(ctx, cfg.QueueThumbnailsRegenerateName, map[string]string{
+		"run_id": run.ID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&filesv1.RegenerateThumbnailsResponse{Run: toThumbnailRegeneration(run)}), nil
+}
+
+// GetThumbnailRegeneration reports the progress of a regeneration run.
+func (s *FileServer) GetThumbnailRegeneration(ctx context.Context, req *connect.Request[filesv1.GetThumbnailRegenerationRequest]) (*connect.Response[filesv1.GetThumbnailRegenerationResponse], error) {
+	if _, err := authenticatedSubject(ctx); err != nil {
+		return nil, err
+	}
+	run, err := s.db.GetThumbnailRegeneration(ctx, req.Msg.GetRunId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if run == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("regeneration run not found"))
+	}
+	return connect.NewResponse(&filesv1.GetThumbnailRegenerationResponse{Run: toThumbnailRegeneration(run)}), nil
+}
+
+func (s *FileServer) thumbnailStatus(ctx context.Context, job *types.ThumbnailJob) (*filesv1.ThumbnailStatus, error) {
+	thumbnails, err := s.db.GetThumbnails(ctx, job.MediaID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return toThumbnailStatus(job, len(thumbnails)), nil
+}
+
 // GetUrlPreview gets OpenGraph preview information for a URL
 func (s *FileServer) GetUrlPreview(ctx context.Context, req *connect.Request[filesv1.GetUrlPreviewRequest]) (*connect.Response[filesv1.GetUrlPreviewResponse], error) {
 	sub, err := authenticatedSubject(ctx)
@@ -449,6 +727,10 @@ func (s *FileServer) GetUrlPreview(ctx context.Context, req *connect.Request[fil
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("url not allowed"))
 	}
 
+	if cached, ok := s.cache.GetURLPreview(ctx, sub, urlStr); ok {
+		return urlPreviewResponse(cached)
+	}
+
 	ctx, cancel := applyRequestTimeout(ctx, 0)
 	defer cancel()
 
@@ -477,18 +759,7 @@ func (s *FileServer) GetUrlPreview(ctx context.Context, req *connect.Request[fil
 		}
 	}
 
-	structMap := make(map[string]any, len(ogData))
-	for k, v := range ogData {
-		structMap[k] = v
-	}
-	ogStruct, err := structpb.NewStruct(structMap)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
-	}
-
-	response := &filesv1.GetUrlPreviewResponse{
-		OgData: ogStruct,
-	}
+	preview := &cache.URLPreviewData{OgData: ogData}
 
 	ogImageURL := ogData["og:image"]
 	if ogImageURL != "" {
@@ -496,18 +767,35 @@ func (s *FileServer) GetUrlPreview(ctx context.Context, req *connect.Request[fil
 		if parseErr == nil && isAllowedPreviewURL(imgParsed) {
 			if strings.EqualFold(imgParsed.Scheme, "mxc") {
 				if mediaID := strings.TrimPrefix(imgParsed.Path, "/"); mediaID != "" {
-					response.OgImageMediaId = mediaID
+					preview.ImageMediaID = mediaID
 				}
 			} else if uploadErr := s.authz.CanUploadFile(ctx, sub); uploadErr == nil {
 				mediaID, fetchErr := s.fetchAndStorePreviewImage(ctx, client, ogImageURL, types.OwnerID(sub), cfg)
 				if fetchErr == nil && mediaID != "" {
-					response.OgImageMediaId = mediaID
+					preview.ImageMediaID = mediaID
 				}
 			}
 		}
 	}
 
-	return connect.NewResponse(response), nil
+	s.cache.SetURLPreview(ctx, sub, urlStr, preview)
+	return urlPreviewResponse(preview)
+}
+
+func urlPreviewResponse(preview *cache.URLPreviewData) (*connect.Response[filesv1.GetUrlPreviewResponse], error) {
+	structMap := make(map[string]any, len(preview.OgData))
+	for k, v := range preview.OgData {
+		structMap[k] = v
+	}
+	ogStruct, err := structpb.NewStruct(structMap)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&filesv1.GetUrlPreviewResponse{
+		OgData:         ogStruct,
+		OgImageMediaId: preview.ImageMediaID,
+	}), nil
 }
 
 // GetConfig retrieves the content repository configuration
@@ -842,6 +1130,9 @@ func (s *FileServer) GetSignedDownloadUrl(ctx context.Context, req *connect.Requ
 	if err = s.authz.CanViewFile(ctx, sub, mediaID); err != nil {
 		return nil, connect.NewError(connect.CodePermissionDenied, err)
 	}
+	if err = s.checkScanPolicy(ctx, mediaID); err != nil {
+		return nil, err
+	}
 	expiresAt, err := resolveURLExpiry(req.Msg.GetExpiresSeconds())
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
@@ -1070,12 +1361,16 @@ func (s *FileServer) CompleteMultipartUpload(ctx context.Context, req *connect.R
 	if err = store.UpdateUploadState(ctx, upload.ID(), "completed"); err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	if err = queueMediaScan(ctx, s.Service, result.MediaID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
 	metadata, err := s.db.GetMediaMetadata(ctx, result.MediaID)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	events.EmitLifecycleEvent(ctx, s.Service, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_UPLOADED, metadata, sub, nil)
 	return connect.NewResponse(&filesv1.CompleteMultipartUploadResponse{
-		Metadata: toMediaMetadata(metadata),
+		Metadata: toMediaMetadata(metadata, callerSubject(ctx)),
 	}), nil
 }
 
@@ -1139,9 +1434,15 @@ func (s *FileServer) DeleteContent(ctx context.Context, req *connect.Request[fil
 	if !ok {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("delete operation unavailable"))
 	}
+	metadata, _ := s.db.GetMediaMetadata(ctx, types.MediaID(mediaID))
 	if err = deleter.DeleteMedia(ctx, types.MediaID(mediaID)); err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	s.cache.DeleteMetadata(ctx, types.MediaID(mediaID))
+	if metadata == nil {
+		metadata = &types.MediaMetadata{MediaID: types.MediaID(mediaID)}
+	}
+	events.EmitLifecycleEvent(ctx, s.Service, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_DELETED, metadata, sub, nil)
 	return connect.NewResponse(&filesv1.DeleteContentResponse{Success: true}), nil
 }
 
@@ -1306,7 +1607,9 @@ func (s *FileServer) RestoreVersion(ctx context.Context, req *connect.Request[fi
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	return connect.NewResponse(&filesv1.RestoreVersionResponse{Metadata: toMediaMetadata(metadata)}), nil
+	// Cached thumbnails are keyed by content hash and so retire on their own.
+	s.cache.DeleteMetadata(ctx, types.MediaID(req.Msg.GetMediaId()))
+	return connect.NewResponse(&filesv1.RestoreVersionResponse{Metadata: toMediaMetadata(metadata, callerSubject(ctx))}), nil
 }
 
 func (s *FileServer) SetRetentionPolicy(ctx context.Context, req *connect.Request[filesv1.SetRetentionPolicyRequest]) (*connect.Response[filesv1.SetRetentionPolicyResponse], error) {
@@ -1361,7 +1664,7 @@ func (s *FileServer) GetRetentionPolicy(ctx context.Context, req *connect.Reques
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("retention store is unavailable"))
 	}
 	retention, err := retStore.GetRetention(ctx, req.Msg.GetMediaId())
-	if err != nil {
+	if err != nil || retention == nil {
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("retention policy not found"))
 	}
 	policy, err := retStore.GetPolicy(ctx, retention.PolicyID())
@@ -1492,7 +1795,7 @@ func (s *FileServer) SearchMedia(ctx context.Context, req *connect.Request[files
 
 	results := make([]*filesv1.MediaMetadata, len(merged))
 	for i, media := range merged {
-		results[i] = toMediaMetadata(media)
+		results[i] = toMediaMetadata(media, callerSubject(ctx))
 	}
 
 	hasMore := offset+int(limit) < totalAvailable || result.HasMore
@@ -1520,7 +1823,8 @@ func resolveURLExpiry(expiresSeconds int64) (time.Time, error) {
 	return time.Now().UTC().Add(time.Duration(expiresSeconds) * time.Second), nil
 }
 
-func (s *FileServer) signedFileURL(_ context.Context, purpose, mediaID, sub string, expiresAt time.Time) (string, error) {
+// publicBaseURL is where clients reach this service over HTTP.
+func (s *FileServer) publicBaseURL() (*url.URL, error) {
 	cfg := s.Service.Config().(*config.FilesConfig)
 	base := strings.TrimSpace(cfg.FileAccessServerUrl)
 	if base == "" {
@@ -1528,11 +1832,30 @@ func (s *FileServer) signedFileURL(_ context.Context, purpose, mediaID, sub stri
 	}
 	baseURL, err := url.Parse(base)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if baseURL.Scheme == "" {
 		baseURL.Scheme = "https"
 	}
+	return baseURL, nil
+}
+
+// publicURL returns the public URL of routePath.
+func (s *FileServer) publicURL(routePath string) (string, error) {
+	baseURL, err := s.publicBaseURL()
+	if err != nil {
+		return "", err
+	}
+	baseURL.Path = path.Join(baseURL.Path, routePath)
+	return baseURL.String(), nil
+}
+
+func (s *FileServer) signedFileURL(_ context.Context, purpose, mediaID, sub string, expiresAt time.Time) (string, error) {
+	cfg := s.Service.Config().(*config.FilesConfig)
+	baseURL, err := s.publicBaseURL()
+	if err != nil {
+		return "", err
+	}
 	baseURL.Path = path.Join(baseURL.Path, "/signed", purpose)
 
 	secret := strings.TrimSpace(cfg.CsrfSecret)
@@ -1634,19 +1957,90 @@ func (s *FileServer) PatchContent(ctx context.Context, req *connect.Request[file
 	if filename := req.Msg.GetFilename(); filename != "" {
 		updates["name"] = filename
 	}
+	var moved *types.MediaMetadata
+	if req.Msg.Folder != nil {
+		folder, folderErr := business.CleanFolder(req.Msg.GetFolder())
+		if folderErr != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, folderErr)
+		}
+		updates["folder"] = folder
+		if moved, err = s.moveToFolder(ctx, sub, mediaID, folder); err != nil {
+			return nil, err
+		}
+	}
+	wasPublic := false
 	if vis := req.Msg.GetVisibility(); vis != filesv1.MediaMetadata_VISIBILITY_UNSPECIFIED {
 		updates["public"] = vis == filesv1.MediaMetadata_VISIBILITY_PUBLIC
+		if vis == filesv1.MediaMetadata_VISIBILITY_PUBLIC {
+			if err = s.checkScanPolicy(ctx, mediaID); err != nil {
+				return nil, err
+			}
+		}
+		if current, _ := s.db.GetMediaMetadata(ctx, types.MediaID(mediaID)); current != nil {
+			wasPublic = current.IsPublic
+		}
 	}
 
 	updated, err := pStore.UpdateMediaMetadata(ctx, types.MediaID(mediaID), updates)
 	if err != nil {
+		if moved != nil {
+			s.restoreFolder(ctx, moved, updates["folder"].(string))
+		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	s.cache.DeleteMetadata(ctx, types.MediaID(mediaID))
+	if updated != nil && updated.IsPublic && !wasPublic {
+		events.EmitLifecycleEvent(ctx, s.Service, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_MADE_PUBLIC, updated, sub, nil)
+	}
 	return connect.NewResponse(&filesv1.PatchContentResponse{
-		Metadata: toMediaMetadata(updated),
+		Metadata: toMediaMetadata(updated, callerSubject(ctx)),
 	}), nil
 }
 
+// moveToFolder refiles a media under folder in Keto ahead of updating its
+// metadata, returning the metadata from before the move, or nil when the
+// folder does not change. Moving a file changes who inherits access to it,
+// so only its owner may move it.
+func (s *FileServer) moveToFolder(ctx context.Context, sub, mediaID, folder string) (*types.MediaMetadata, error) {
+	current, err := s.db.GetMediaMetadata(ctx, types.MediaID(mediaID))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if current == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("media not found"))
+	}
+	if current.Folder == folder {
+		return nil, nil
+	}
+	if string(current.OwnerID) != sub {
+		return nil, connect.NewError(connect.CodePermissionDenied, authz.ErrNotOwner)
+	}
+	if err = s.authz.SetFileFolder(ctx, sub, mediaID, current.Folder, folder); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return current, nil
+}
+
+// restoreFolder undoes moveToFolder after the metadata update failed.
+func (s *FileServer) restoreFolder(ctx context.Context, previous *types.MediaMetadata, folder string) {
+	err := s.authz.SetFileFolder(ctx, string(previous.OwnerID), string(previous.MediaID), folder, previous.Folder)
+	if err != nil {
+		util.Log(ctx).WithError(err).With("media_id", previous.MediaID).Warn("failed to restore media folder")
+	}
+}
+
+// fileUnderFolder links a newly stored media to its folder. The upload has
+// succeeded by now, so a failure only loses inherited access and is logged.
+func (s *FileServer) fileUnderFolder(ctx context.Context, metadata *types.MediaMetadata) {
+	if metadata == nil || metadata.Folder == "" {
+		return
+	}
+	err := s.authz.SetFileFolder(ctx, string(metadata.OwnerID), string(metadata.MediaID), "", metadata.Folder)
+	if err != nil {
+		util.Log(ctx).WithError(err).With("media_id", metadata.MediaID).Warn("failed to file media under its folder")
+	}
+}
+
 func (s *FileServer) FinalizeSignedUpload(ctx context.Context, req *connect.Request[filesv1.FinalizeSignedUploadRequest]) (*connect.Response[filesv1.FinalizeSignedUploadResponse], error) {
 	sub, err := authenticatedSubject(ctx)
 	if err != nil {
@@ -1676,12 +2070,24 @@ func (s *FileServer) FinalizeSignedUpload(ctx context.Context, req *connect.Requ
 	if sizeBytes := req.Msg.GetSizeBytes(); sizeBytes > 0 {
 		updates["size"] = sizeBytes
 	}
+	// The content was written straight to storage, so it is held back by the
+	// scan policy until it has been scanned again.
+	cfg := s.Service.Config().(*config.FilesConfig)
+	if cfg.ScanEnabled() {
+		if err = s.db.StoreScanResult(ctx, types.MediaID(mediaID), types.ScanStatusPending, ""); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
 	updated, err := pStore.UpdateMediaMetadata(ctx, types.MediaID(mediaID), updates)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	s.cache.DeleteMetadata(ctx, types.MediaID(mediaID))
+	if err = queueMediaScan(ctx, s.Service, types.MediaID(mediaID)); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
 	return connect.NewResponse(&filesv1.FinalizeSignedUploadResponse{
-		Metadata: toMediaMetadata(updated),
+		Metadata: toMediaMetadata(updated, callerSubject(ctx)),
 	}), nil
 }
 
@@ -1691,8 +2097,9 @@ func (s *FileServer) GrantAccess(ctx context.Context, req *connect.Request[files
 		return nil, err
 	}
 	mediaID := req.Msg.GetMediaId()
-	if !isValidMediaID(mediaID) {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid media id"))
+	folder, err := accessFolder(mediaID, req.Msg.GetFolder())
+	if err != nil {
+		return nil, err
 	}
 	grant := req.Msg.GetGrant()
 	if grant == nil {
@@ -1702,9 +2109,54 @@ func (s *FileServer) GrantAccess(ctx context.Context, req *connect.Request[files
 	if role == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid access role"))
 	}
-	if err = s.authz.GrantFileAccess(ctx, sub, mediaID, grant.GetPrincipalId(), role); err != nil {
+	principal, err := accessPrincipal(ctx, grant.GetPrincipalType(), grant.GetPrincipalId())
+	if err != nil {
+		return nil, err
+	}
+	var expiresAt *time.Time
+	if grant.GetExpiresAt() != nil {
+		t := grant.GetExpiresAt().AsTime().UTC()
+		if !t.After(time.Now()) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("expires_at must be in the future"))
+		}
+		expiresAt = &t
+	}
+	attributes := map[string]string{
+		"principal_id":   principal.ID,
+		"principal_type": principal.Kind,
+		"role":           role,
+	}
+	if expiresAt != nil {
+		attributes["expires_at"] = expiresAt.Format(time.RFC3339)
+	}
+
+	if folder != "" {
+		if err = s.authz.GrantFolderAccess(ctx, sub, folder, principal, role, expiresAt); err != nil {
+			if errors.Is(err, authz.ErrInvalidRelation) {
+				return nil, connect.NewError(connect.CodeInvalidArgument, err)
+			}
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		attributes["folder"] = folder
+		events.EmitLifecycleEvent(ctx, s.Service, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_SHARED,
+			&types.MediaMetadata{OwnerID: types.OwnerID(sub)}, sub, attributes)
+		return connect.NewResponse(&filesv1.GrantAccessResponse{Success: true}), nil
+	}
+
+	if err = s.checkScanPolicy(ctx, mediaID); err != nil {
+		return nil, err
+	}
+	if err = s.authz.GrantFileAccess(ctx, sub, mediaID, principal, role, expiresAt); err != nil {
+		if errors.Is(err, authz.ErrInvalidRelation) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
 		return nil, connect.NewError(connect.CodePermissionDenied, err)
 	}
+	metadata, _ := s.db.GetMediaMetadata(ctx, types.MediaID(mediaID))
+	if metadata == nil {
+		metadata = &types.MediaMetadata{MediaID: types.MediaID(mediaID)}
+	}
+	events.EmitLifecycleEvent(ctx, s.Service, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_SHARED, metadata, sub, attributes)
 	return connect.NewResponse(&filesv1.GrantAccessResponse{Success: true}), nil
 }
 
@@ -1714,25 +2166,44 @@ func (s *FileServer) RevokeAccess(ctx context.Context, req *connect.Request[file
 		return nil, err
 	}
 	mediaID := req.Msg.GetMediaId()
-	if !isValidMediaID(mediaID) {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid media id"))
+	folder, err := accessFolder(mediaID, req.Msg.GetFolder())
+	if err != nil {
+		return nil, err
+	}
+	principal, err := accessPrincipal(ctx, req.Msg.GetPrincipalType(), req.Msg.GetPrincipalId())
+	if err != nil {
+		return nil, err
+	}
+	if folder != "" {
+		if err = s.authz.RevokeFolderAccess(ctx, sub, folder, principal); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		return connect.NewResponse(&filesv1.RevokeAccessResponse{Success: true}), nil
 	}
-	if err = s.authz.RevokeFileAccess(ctx, sub, mediaID, req.Msg.GetPrincipalId()); err != nil {
+	if err = s.authz.RevokeFileAccess(ctx, sub, mediaID, principal); err != nil {
 		return nil, connect.NewError(connect.CodePermissionDenied, err)
 	}
 	return connect.NewResponse(&filesv1.RevokeAccessResponse{Success: true}), nil
 }
 
+// ListAccess lists the grants on a file or folder. Grants inherited from
+// enclosing folders are listed after the direct ones and name their folder.
 func (s *FileServer) ListAccess(ctx context.Context, req *connect.Request[filesv1.ListAccessRequest]) (*connect.Response[filesv1.ListAccessResponse], error) {
 	sub, err := authenticatedSubject(ctx)
 	if err != nil {
 		return nil, err
 	}
 	mediaID := req.Msg.GetMediaId()
-	if !isValidMediaID(mediaID) {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid media id"))
+	folder, err := accessFolder(mediaID, req.Msg.GetFolder())
+	if err != nil {
+		return nil, err
+	}
+	var grants []authz.AccessGrantInfo
+	if folder != "" {
+		grants, err = s.authz.ListFolderAccessGrants(ctx, sub, folder)
+	} else {
+		grants, err = s.authz.ListFileAccessGrants(ctx, sub, mediaID)
 	}
-	grants, err := s.authz.ListFileAccessGrants(ctx, sub, mediaID)
 	if err != nil {
 		if errors.Is(err, authz.ErrNotOwner) {
 			return nil, connect.NewError(connect.CodePermissionDenied, err)
@@ -1761,11 +2232,7 @@ func (s *FileServer) ListAccess(ctx context.Context, req *connect.Request[filesv
 	var pageGrants []*filesv1.AccessGrant
 	if offset < len(grants) {
 		for _, g := range grants[offset:end] {
-			ag := &filesv1.AccessGrant{}
-			ag.SetPrincipalId(g.PrincipalID)
-			ag.SetRole(stringToAccessRole(g.Role))
-			ag.SetPrincipalType(filesv1.PrincipalType_PRINCIPAL_TYPE_USER)
-			pageGrants = append(pageGrants, ag)
+			pageGrants = append(pageGrants, toAccessGrant(g))
 		}
 	}
 
@@ -1780,14 +2247,1081 @@ func (s *FileServer) ListAccess(ctx context.Context, req *connect.Request[filesv
 	}), nil
 }
 
-func (s *FileServer) DownloadContent(ctx context.Context, req *connect.Request[filesv1.DownloadContentRequest], stream *connect.ServerStream[filesv1.DownloadContentResponse]) error {
+// ListSharedWithMe lists the files others have shared with the caller,
+// most recently shared first, with the role the caller holds on each and who
+// shared it when. Metadata is looked up a page of shares at a time; the
+// cursor is the last share returned, so that shares made or revoked between
+// pages do not shift the ones still to come.
+func (s *FileServer) ListSharedWithMe(ctx context.Context, req *connect.Request[filesv1.ListSharedWithMeRequest]) (*connect.Response[filesv1.ListSharedWithMeResponse], error) {
 	sub, err := authenticatedSubject(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	mediaID := req.Msg.GetMediaId()
-	if !isValidMediaID(mediaID) {
-		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid media id"))
+	shared, err := s.authz.ListSharedFiles(ctx, sub)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	sort.SliceStable(shared, func(i, j int) bool {
+		return sharedBefore(shared[i], shared[j])
+	})
+
+	limit, _ := pageCursorBounds(req.Msg.GetCursor())
+	offset := 0
+	if page := req.Msg.GetCursor().GetPage(); page != "" {
+		last, cursorErr := decodeSharedCursor(page)
+		if cursorErr != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, cursorErr)
+		}
+		offset = sort.Search(len(shared), func(i int) bool {
+			return sharedBefore(last, shared[i])
+		})
+	}
+	sharedBy := req.Msg.GetSharedBy()
+	contentType := strings.ToLower(req.Msg.GetContentType())
+
+	var items []*filesv1.SharedItem
+	next := offset
+	for next < len(shared) && len(items) < limit {
+		end := min(next+limit-len(items), len(shared))
+		batch := shared[next:end]
+		next = end
+
+		ids := make([]types.MediaID, len(batch))
+		for i, file := range batch {
+			ids[i] = types.MediaID(file.FileID)
+		}
+		media, batchErr := s.db.GetMediaMetadataBatch(ctx, ids)
+		if batchErr != nil {
+			return nil, connect.NewError(connect.CodeInternal, batchErr)
+		}
+		byID := make(map[types.MediaID]*types.MediaMetadata, len(media))
+		for _, metadata := range media {
+			byID[metadata.MediaID] = metadata
+		}
+
+		for _, file := range batch {
+			metadata := byID[types.MediaID(file.FileID)]
+			if metadata == nil || string(metadata.OwnerID) == sub {
+				continue
+			}
+			grant := file.Grant
+			// Grants made before they were recorded can only have come from the owner.
+			if grant.GrantedBy == "" {
+				grant.GrantedBy = string(metadata.OwnerID)
+			}
+			if sharedBy != "" && grant.GrantedBy != sharedBy {
+				continue
+			}
+			if contentType != "" && !strings.HasPrefix(strings.ToLower(string(metadata.ContentType)), contentType) {
+				continue
+			}
+			items = append(items, &filesv1.SharedItem{
+				Media: toMediaMetadata(metadata, callerSubject(ctx)),
+				Grant: toAccessGrant(grant),
+			})
+		}
+	}
+
+	var nextCursor *commonv1.PageCursor
+	if next < len(shared) {
+		nextCursor = &commonv1.PageCursor{Limit: int32(limit), Page: encodeSharedCursor(shared[next-1])}
+	}
+
+	return connect.NewResponse(&filesv1.ListSharedWithMeResponse{
+		Items:      items,
+		NextCursor: nextCursor,
+	}), nil
+}
+
+// sharedBefore orders shares most recently granted first, then by file ID.
+func sharedBefore(a, b authz.SharedFile) bool {
+	if a.Grant.GrantedAt.Equal(b.Grant.GrantedAt) {
+		return a.FileID < b.FileID
+	}
+	return a.Grant.GrantedAt.After(b.Grant.GrantedAt)
+}
+
+// encodeSharedCursor encodes the position of a share in the shared list.
+// Shares granted before grants were recorded have no time and sort last.
+func encodeSharedCursor(file authz.SharedFile) string {
+	grantedAt := ""
+	if !file.Grant.GrantedAt.IsZero() {
+		grantedAt = strconv.FormatInt(file.Grant.GrantedAt.UnixNano(), 10)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(grantedAt + ":" + file.FileID))
+}
+
+// decodeSharedCursor decodes a cursor made by encodeSharedCursor into a
+// share that sorts where the last one returned did.
+func decodeSharedCursor(cursor string) (authz.SharedFile, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return authz.SharedFile{}, fmt.Errorf("invalid cursor")
+	}
+	nanos, fileID, found := strings.Cut(string(raw), ":")
+	if !found || fileID == "" {
+		return authz.SharedFile{}, fmt.Errorf("invalid cursor")
+	}
+	last := authz.SharedFile{FileID: fileID}
+	if nanos != "" {
+		grantedAt, parseErr := strconv.ParseInt(nanos, 10, 64)
+		if parseErr != nil {
+			return authz.SharedFile{}, fmt.Errorf("invalid cursor")
+		}
+		last.Grant.GrantedAt = time.Unix(0, grantedAt)
+	}
+	return last, nil
+}
+
+// ownershipTransferBatch is how many files TransferOwnership reads at a time.
+const ownershipTransferBatch = 100
+
+// ownershipTransferAudit is the audit action recorded for each transferred file.
+const ownershipTransferAudit = "ownership.transfer"
+
+// TransferOwnership hands a file, a folder tree or all of the caller's files
+// to another profile of the partition, up to OwnershipTransferMaxFiles files
+// at a time. Keto is updated file by file first and the OwnerID of
+// every file then changes in one transaction; if either step fails the Keto
+// changes already made are undone, so the caller sees every file move or none.
+func (s *FileServer) TransferOwnership(ctx context.Context, req *connect.Request[filesv1.TransferOwnershipRequest]) (*connect.Response[filesv1.TransferOwnershipResponse], error) {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	newOwner := strings.TrimSpace(req.Msg.GetNewOwnerId())
+	if newOwner == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("new_owner_id is required"))
+	}
+	if newOwner == sub {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("new_owner_id must differ from the current owner"))
+	}
+	if err = s.checkNewOwner(ctx, newOwner); err != nil {
+		return nil, err
+	}
+	previousRole := ""
+	if role := req.Msg.GetPreviousOwnerRole(); role != filesv1.AccessRole_ACCESS_ROLE_UNSPECIFIED {
+		previousRole = accessRoleToString(role)
+		if previousRole == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid access role"))
+		}
+	}
+
+	files, err := s.transferableFiles(ctx, sub, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.transferFiles(ctx, sub, newOwner, files, previousRole); err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		details := data.JSONMap{
+			"from_owner_id": sub,
+			"to_owner_id":   newOwner,
+		}
+		if previousRole != "" {
+			details["previous_owner_role"] = previousRole
+		}
+		audit := &models.MediaAudit{
+			FileID:  string(file.MediaID),
+			Action:  ownershipTransferAudit,
+			Source:  "profile:" + sub,
+			Details: details,
+		}
+		audit.GenID(ctx)
+		events.EmitMediaAudit(ctx, s.Service, audit)
+	}
+
+	return connect.NewResponse(&filesv1.TransferOwnershipResponse{
+		TransferredFiles:   int64(len(files)),
+		PreviousOwnerUsage: s.ownerUsage(ctx, sub),
+		NewOwnerUsage:      s.ownerUsage(ctx, newOwner),
+	}), nil
+}
+
+// transferFiles moves files from one owner to another in Keto and then
+// changes their OwnerID in one transaction, undoing the Keto changes if
+// either step fails.
+func (s *FileServer) transferFiles(ctx context.Context, fromOwner, toOwner string, files []*types.MediaMetadata, previousRole string) error {
+	for i, file := range files {
+		err := s.authz.TransferFileOwnership(ctx, fromOwner, toOwner, string(file.MediaID), file.Folder, previousRole)
+		if err != nil {
+			s.restoreOwnership(ctx, fromOwner, toOwner, files[:i], previousRole)
+			return connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	mediaIDs := make([]types.MediaID, len(files))
+	for i, file := range files {
+		mediaIDs[i] = file.MediaID
+	}
+	if err := s.db.TransferMediaOwnership(ctx, mediaIDs, types.OwnerID(fromOwner), types.OwnerID(toOwner)); err != nil {
+		s.restoreOwnership(ctx, fromOwner, toOwner, files, previousRole)
+		if errors.Is(err, storage.ErrOwnershipChanged) {
+			return connect.NewError(connect.CodeAborted, err)
+		}
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	for _, file := range files {
+		s.cache.DeleteMetadata(ctx, file.MediaID)
+	}
+	return nil
+}
+
+// checkNewOwner verifies that files may be transferred to newOwner, a
+// profile of the caller's tenant partition.
+func (s *FileServer) checkNewOwner(ctx context.Context, newOwner string) error {
+	err := s.authz.CheckPartitionMember(ctx, newOwner)
+	if errors.Is(err, authz.ErrNotPartitionMember) {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	return nil
+}
+
+// transferableFiles resolves the target of a TransferOwnership request into
+// the caller's original media it covers, refusing targets of more files than
+// one transfer may move.
+func (s *FileServer) transferableFiles(ctx context.Context, sub string, req *filesv1.TransferOwnershipRequest) ([]*types.MediaMetadata, error) {
+	targets := 0
+	for _, set := range []bool{req.GetMediaId() != "", req.GetFolder() != "", req.GetAllFiles()} {
+		if set {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("exactly one of media_id, folder and all_files must be set"))
+	}
+
+	if mediaID := req.GetMediaId(); mediaID != "" {
+		if !isValidMediaID(mediaID) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid media id"))
+		}
+		metadata, err := s.db.GetMediaMetadata(ctx, types.MediaID(mediaID))
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		if metadata == nil {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("media not found"))
+		}
+		if string(metadata.OwnerID) != sub {
+			return nil, connect.NewError(connect.CodePermissionDenied, authz.ErrNotOwner)
+		}
+		if metadata.ParentID != "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("thumbnails move with their original"))
+		}
+		return []*types.MediaMetadata{metadata}, nil
+	}
+
+	folder := ""
+	if req.GetFolder() != "" {
+		var err error
+		if folder, err = accessFolder("", req.GetFolder()); err != nil {
+			return nil, err
+		}
+	}
+	cfg := s.Service.Config().(*config.FilesConfig)
+	var files []*types.MediaMetadata
+	afterID := ""
+	for {
+		page, err := s.db.ListOwnerMedia(ctx, types.OwnerID(sub), folder, afterID, ownershipTransferBatch)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		files = append(files, page...)
+		if len(files) > cfg.OwnershipTransferMaxFiles {
+			return nil, connect.NewError(connect.CodeFailedPrecondition,
+				fmt.Errorf("a transfer may cover at most %d files; transfer the folders one at a time", cfg.OwnershipTransferMaxFiles))
+		}
+		if len(page) < ownershipTransferBatch {
+			return files, nil
+		}
+		afterID = string(page[len(page)-1].MediaID)
+	}
+}
+
+// restoreOwnership undoes the Keto changes of an ownership transfer that
+// could not be completed.
+func (s *FileServer) restoreOwnership(ctx context.Context, fromOwner, toOwner string, files []*types.MediaMetadata, previousRole string) {
+	for _, file := range files {
+		err := s.authz.RestoreFileOwnership(ctx, fromOwner, toOwner, string(file.MediaID), file.Folder, previousRole)
+		if err != nil {
+			util.Log(ctx).WithError(err).With("media_id", file.MediaID).Warn("failed to restore media ownership")
+		}
+	}
+}
+
+// ownerUsage reports the storage used by an owner, or nil when it cannot be
+// read. It is only informational, so failures are logged.
+func (s *FileServer) ownerUsage(ctx context.Context, ownerID string) *filesv1.UsageStats {
+	usageRepo, ok := s.db.(userUsageStore)
+	if !ok {
+		return nil
+	}
+	totalBytes, totalFiles, err := usageRepo.GetUserUsage(ctx, types.OwnerID(ownerID))
+	if err != nil {
+		util.Log(ctx).WithError(err).With("owner_id", ownerID).Warn("failed to read owner usage")
+		return nil
+	}
+	return &filesv1.UsageStats{TotalFiles: int64(totalFiles), TotalBytes: totalBytes}
+}
+
+// Batch sizes of ReconcileOwnership.
+const (
+	defaultReconcileBatch = 100
+	maxReconcileBatch     = 500
+)
+
+// ReconcileOwnership compares one batch of media records with their Keto
+// tuples. The cursor page is the last media ID of the previous batch.
+func (s *FileServer) ReconcileOwnership(ctx context.Context, req *connect.Request[filesv1.ReconcileOwnershipRequest]) (*connect.Response[filesv1.ReconcileOwnershipResponse], error) {
+	if _, err := authenticatedSubject(ctx); err != nil {
+		return nil, err
+	}
+	limit := defaultReconcileBatch
+	afterID := ""
+	if cursor := req.Msg.GetCursor(); cursor != nil {
+		if cursor.GetLimit() > 0 {
+			limit = min(int(cursor.GetLimit()), maxReconcileBatch)
+		}
+		afterID = cursor.GetPage()
+	}
+
+	report, err := s.authz.ReconcileOwnership(ctx, afterID, limit, !req.Msg.GetDryRun())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	issues := make([]*filesv1.OwnershipIssue, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		issues = append(issues, &filesv1.OwnershipIssue{
+			Kind:     ownershipIssueKindToProto(issue.Kind),
+			MediaId:  issue.FileID,
+			Relation: issue.Tuple.Relation,
+			Subject:  subjectString(issue.Tuple.Subject),
+			Repaired: issue.Repaired,
+		})
+	}
+	var nextCursor *commonv1.PageCursor
+	if report.Scanned == limit {
+		nextCursor = &commonv1.PageCursor{Limit: int32(limit), Page: report.LastID}
+	}
+
+	return connect.NewResponse(&filesv1.ReconcileOwnershipResponse{
+		Scanned:    int64(report.Scanned),
+		Issues:     issues,
+		NextCursor: nextCursor,
+	}), nil
+}
+
+// Audit actions recorded for each use of the tenant admin role.
+const (
+	tenantAdminListAudit     = "tenant_admin.list"
+	tenantAdminViewAudit     = "tenant_admin.view"
+	tenantAdminShareAudit    = "tenant_admin.share"
+	tenantAdminTransferAudit = "tenant_admin.transfer"
+	tenantAdminDeleteAudit   = "tenant_admin.delete"
+	tenantAdminExportAudit   = "tenant_admin.export"
+	tenantAdminEraseAudit    = "tenant_admin.erase"
+)
+
+// Page sizes of AdminListMedia.
+const (
+	defaultAdminListLimit = 20
+	maxAdminListLimit     = 100
+)
+
+// tenantAdminCall is an authorised use of the tenant admin role.
+type tenantAdminCall struct {
+	admin   authz.TenantAdmin
+	subject string
+	tenancy string
+	reason  string
+}
+
+// tenantAdmin checks that the caller administers the tenant partition of
+// their claims and gave a reason for the call.
+func (s *FileServer) tenantAdmin(ctx context.Context, reason string) (*tenantAdminCall, error) {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("reason is required"))
+	}
+	admin, ok := s.authz.(authz.TenantAdmin)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("tenant administration unavailable"))
+	}
+	tenancy, err := admin.CheckTenantAdmin(ctx, sub)
+	if err != nil {
+		if errors.Is(err, authz.ErrNotTenantAdmin) {
+			return nil, connect.NewError(connect.CodePermissionDenied, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return &tenantAdminCall{admin: admin, subject: sub, tenancy: tenancy, reason: reason}, nil
+}
+
+// auditTenantAdmin records a use of the tenant admin role on a file, or on
+// no file in particular for an empty fileID.
+func (s *FileServer) auditTenantAdmin(ctx context.Context, call *tenantAdminCall, fileID, action string, details data.JSONMap) {
+	if details == nil {
+		details = data.JSONMap{}
+	}
+	details["tenancy"] = call.tenancy
+	details["reason"] = call.reason
+	util.Log(ctx).WithFields(map[string]any{
+		"admin_profile_id": call.subject,
+		"tenancy":          call.tenancy,
+		"file_id":          fileID,
+		"action":           action,
+	}).Info("tenant admin action")
+
+	audit := &models.MediaAudit{
+		FileID:  fileID,
+		Action:  action,
+		Source:  "tenant_admin:" + call.subject,
+		Details: details,
+	}
+	audit.GenID(ctx)
+	events.EmitMediaAudit(ctx, s.Service, audit)
+}
+
+// tenantMedia returns a media of the caller's tenant partition.
+func (s *FileServer) tenantMedia(ctx context.Context, mediaID string) (*types.MediaMetadata, error) {
+	if !isValidMediaID(mediaID) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid media id"))
+	}
+	metadata, err := s.db.GetMediaMetadata(ctx, types.MediaID(mediaID))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if metadata == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("media not found"))
+	}
+	return metadata, nil
+}
+
+// AdminListMedia lists the original media of the caller's tenant partition
+// in ID order. The cursor page is the last media ID of the previous page.
+func (s *FileServer) AdminListMedia(ctx context.Context, req *connect.Request[filesv1.AdminListMediaRequest]) (*connect.Response[filesv1.AdminListMediaResponse], error) {
+	call, err := s.tenantAdmin(ctx, req.Msg.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	owner := strings.TrimSpace(req.Msg.GetOwnerId())
+	folder := ""
+	if req.Msg.GetFolder() != "" {
+		if owner == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("folder requires owner_id"))
+		}
+		if folder, err = accessFolder("", req.Msg.GetFolder()); err != nil {
+			return nil, err
+		}
+	}
+	limit := defaultAdminListLimit
+	afterID := ""
+	if cursor := req.Msg.GetCursor(); cursor != nil {
+		if cursor.GetLimit() > 0 {
+			limit = min(int(cursor.GetLimit()), maxAdminListLimit)
+		}
+		afterID = cursor.GetPage()
+	}
+
+	media, err := s.db.ListOwnerMedia(ctx, types.OwnerID(owner), folder, afterID, limit)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	items := make([]*filesv1.MediaMetadata, 0, len(media))
+	for _, metadata := range media {
+		items = append(items, toMediaMetadata(metadata, callerSubject(ctx)))
+	}
+	var nextCursor *commonv1.PageCursor
+	if len(media) == limit {
+		nextCursor = &commonv1.PageCursor{Limit: int32(limit), Page: string(media[len(media)-1].MediaID)}
+	}
+
+	s.auditTenantAdmin(ctx, call, "", tenantAdminListAudit, data.JSONMap{
+		"owner_id": owner,
+		"folder":   folder,
+		"count":    len(media),
+	})
+	return connect.NewResponse(&filesv1.AdminListMediaResponse{
+		Media:      items,
+		NextCursor: nextCursor,
+	}), nil
+}
+
+// AdminGetMedia returns the metadata, grants and a signed download URL of
+// any media of the caller's tenant partition.
+func (s *FileServer) AdminGetMedia(ctx context.Context, req *connect.Request[filesv1.AdminGetMediaRequest]) (*connect.Response[filesv1.AdminGetMediaResponse], error) {
+	call, err := s.tenantAdmin(ctx, req.Msg.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.tenantMedia(ctx, req.Msg.GetMediaId())
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, err := resolveURLExpiry(req.Msg.GetExpiresSeconds())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	mediaID := string(metadata.MediaID)
+
+	grants, err := s.authz.ListFileAccessGrants(ctx, string(metadata.OwnerID), mediaID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	downloadURL, err := s.signedFileURL(ctx, "download", mediaID, call.subject, expiresAt)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	accessGrants := make([]*filesv1.AccessGrant, 0, len(grants))
+	for _, g := range grants {
+		accessGrants = append(accessGrants, toAccessGrant(g))
+	}
+
+	s.auditTenantAdmin(ctx, call, mediaID, tenantAdminViewAudit, data.JSONMap{"owner_id": string(metadata.OwnerID)})
+	return connect.NewResponse(&filesv1.AdminGetMediaResponse{
+		Metadata:    toMediaMetadata(metadata, callerSubject(ctx)),
+		Grants:      accessGrants,
+		DownloadUrl: downloadURL,
+	}), nil
+}
+
+// AdminGrantAccess shares any file of the caller's tenant partition. The
+// grant is recorded as made by the admin.
+func (s *FileServer) AdminGrantAccess(ctx context.Context, req *connect.Request[filesv1.AdminGrantAccessRequest]) (*connect.Response[filesv1.AdminGrantAccessResponse], error) {
+	call, err := s.tenantAdmin(ctx, req.Msg.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	grant := req.Msg.GetGrant()
+	if grant == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("grant is required"))
+	}
+	role := accessRoleToString(grant.GetRole())
+	if role == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid access role"))
+	}
+	principal, err := accessPrincipal(ctx, grant.GetPrincipalType(), grant.GetPrincipalId())
+	if err != nil {
+		return nil, err
+	}
+	var expiresAt *time.Time
+	if grant.GetExpiresAt() != nil {
+		t := grant.GetExpiresAt().AsTime().UTC()
+		if !t.After(time.Now()) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("expires_at must be in the future"))
+		}
+		expiresAt = &t
+	}
+	metadata, err := s.tenantMedia(ctx, req.Msg.GetMediaId())
+	if err != nil {
+		return nil, err
+	}
+	mediaID := string(metadata.MediaID)
+	if err = s.checkScanPolicy(ctx, mediaID); err != nil {
+		return nil, err
+	}
+
+	if err = call.admin.GrantFileAccessAsAdmin(ctx, call.subject, mediaID, principal, role, expiresAt); err != nil {
+		if errors.Is(err, authz.ErrInvalidRelation) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	attributes := map[string]string{
+		"principal_id":   principal.ID,
+		"principal_type": principal.Kind,
+		"role":           role,
+	}
+	details := data.JSONMap{
+		"owner_id":       string(metadata.OwnerID),
+		"principal_id":   principal.ID,
+		"principal_type": principal.Kind,
+		"role":           role,
+	}
+	if expiresAt != nil {
+		attributes["expires_at"] = expiresAt.Format(time.RFC3339)
+		details["expires_at"] = attributes["expires_at"]
+	}
+	s.auditTenantAdmin(ctx, call, mediaID, tenantAdminShareAudit, details)
+	events.EmitLifecycleEvent(ctx, s.Service, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_SHARED, metadata, call.subject, attributes)
+	return connect.NewResponse(&filesv1.AdminGrantAccessResponse{Success: true}), nil
+}
+
+// AdminTransferOwnership hands a file, a folder tree or all files of any
+// owner in the caller's tenant partition to another profile, as
+// TransferOwnership does for the owner.
+func (s *FileServer) AdminTransferOwnership(ctx context.Context, req *connect.Request[filesv1.AdminTransferOwnershipRequest]) (*connect.Response[filesv1.AdminTransferOwnershipResponse], error) {
+	call, err := s.tenantAdmin(ctx, req.Msg.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	newOwner := strings.TrimSpace(req.Msg.GetNewOwnerId())
+	if newOwner == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("new_owner_id is required"))
+	}
+	previousRole := ""
+	if role := req.Msg.GetPreviousOwnerRole(); role != filesv1.AccessRole_ACCESS_ROLE_UNSPECIFIED {
+		previousRole = accessRoleToString(role)
+		if previousRole == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid access role"))
+		}
+	}
+
+	owner := strings.TrimSpace(req.Msg.GetOwnerId())
+	if mediaID := req.Msg.GetMediaId(); mediaID != "" {
+		metadata, mediaErr := s.tenantMedia(ctx, mediaID)
+		if mediaErr != nil {
+			return nil, mediaErr
+		}
+		if owner != "" && owner != string(metadata.OwnerID) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("owner_id does not own the media"))
+		}
+		owner = string(metadata.OwnerID)
+	}
+	if owner == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("owner_id is required"))
+	}
+	if newOwner == owner {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("new_owner_id must differ from the current owner"))
+	}
+	if err = s.checkNewOwner(ctx, newOwner); err != nil {
+		return nil, err
+	}
+
+	files, err := s.transferableFiles(ctx, owner, &filesv1.TransferOwnershipRequest{
+		MediaId:  req.Msg.GetMediaId(),
+		Folder:   req.Msg.GetFolder(),
+		AllFiles: req.Msg.GetAllFiles(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = s.transferFiles(ctx, owner, newOwner, files, previousRole); err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		details := data.JSONMap{
+			"from_owner_id": owner,
+			"to_owner_id":   newOwner,
+		}
+		if previousRole != "" {
+			details["previous_owner_role"] = previousRole
+		}
+		s.auditTenantAdmin(ctx, call, string(file.MediaID), tenantAdminTransferAudit, details)
+	}
+
+	return connect.NewResponse(&filesv1.AdminTransferOwnershipResponse{
+		TransferredFiles:   int64(len(files)),
+		PreviousOwnerUsage: s.ownerUsage(ctx, owner),
+		NewOwnerUsage:      s.ownerUsage(ctx, newOwner),
+	}), nil
+}
+
+// AdminDeleteContent deletes any media of the caller's tenant partition.
+func (s *FileServer) AdminDeleteContent(ctx context.Context, req *connect.Request[filesv1.AdminDeleteContentRequest]) (*connect.Response[filesv1.AdminDeleteContentResponse], error) {
+	call, err := s.tenantAdmin(ctx, req.Msg.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.tenantMedia(ctx, req.Msg.GetMediaId())
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkRetentionHold(ctx, metadata.MediaID); err != nil {
+		return nil, err
+	}
+	deleter, ok := s.db.(deleteStore)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("delete operation unavailable"))
+	}
+	if err = deleter.DeleteMedia(ctx, metadata.MediaID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	s.cache.DeleteMetadata(ctx, metadata.MediaID)
+
+	s.auditTenantAdmin(ctx, call, string(metadata.MediaID), tenantAdminDeleteAudit, data.JSONMap{"owner_id": string(metadata.OwnerID)})
+	events.EmitLifecycleEvent(ctx, s.Service, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_DELETED, metadata, call.subject, nil)
+	return connect.NewResponse(&filesv1.AdminDeleteContentResponse{Success: true}), nil
+}
+
+// checkRetentionHold refuses to remove media that is under legal hold or
+// whose retention has not yet expired.
+func (s *FileServer) checkRetentionHold(ctx context.Context, mediaID types.MediaID) error {
+	retStore, ok := s.db.(retentionStore)
+	if !ok {
+		return nil
+	}
+	retention, err := retStore.GetRetention(ctx, string(mediaID))
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if retention == nil {
+		return nil
+	}
+	if retention.IsLocked() {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("media %s is under legal hold", mediaID))
+	}
+	if expiresAt := retention.ExpiresAt(); expiresAt == nil || expiresAt.After(time.Now()) {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("media %s is under retention", mediaID))
+	}
+	return nil
+}
+
+// userDataExportAudit is the audit action recorded when a profile exports
+// its own data.
+const userDataExportAudit = "user_data.export"
+
+// ExportUserData starts packaging everything a profile owns into a zip
+// archive stored as a private media of the profile. A profile exports its
+// own data; a tenant admin may export any profile of their partition.
+func (s *FileServer) ExportUserData(ctx context.Context, req *connect.Request[filesv1.ExportUserDataRequest]) (*connect.Response[filesv1.ExportUserDataResponse], error) {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profileID := strings.TrimSpace(req.Msg.GetProfileId())
+	var call *tenantAdminCall
+	if profileID != "" && profileID != sub {
+		if call, err = s.tenantAdmin(ctx, req.Msg.GetReason()); err != nil {
+			return nil, err
+		}
+	} else {
+		profileID = sub
+	}
+
+	running, err := s.db.GetRunningUserDataExport(ctx, types.OwnerID(profileID))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if running != nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("user data export %s is still running", running.ID))
+	}
+
+	job := &types.UserDataExport{
+		ProfileID:   types.OwnerID(profileID),
+		RequestedBy: sub,
+		State:       types.UserDataExportRunning,
+	}
+	if err = s.db.StoreUserDataExport(ctx, job); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	cfg := s.Service.Config().(*config.FilesConfig)
+	err = s.Service.QueueManager().Publish(ctx, cfg.QueueUserExportName, map[string]string{
+		"job_id": job.ID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	details := data.JSONMap{"export_id": job.ID, "profile_id": profileID}
+	if call != nil {
+		s.auditTenantAdmin(ctx, call, "", tenantAdminExportAudit, details)
+	} else {
+		audit := &models.MediaAudit{
+			Action:  userDataExportAudit,
+			Source:  "profile:" + sub,
+			Details: details,
+		}
+		audit.GenID(ctx)
+		events.EmitMediaAudit(ctx, s.Service, audit)
+	}
+
+	return connect.NewResponse(&filesv1.ExportUserDataResponse{Export: toUserDataExport(job)}), nil
+}
+
+// GetUserDataExport reports the progress of an export of the caller's data
+// or one the caller requested.
+func (s *FileServer) GetUserDataExport(ctx context.Context, req *connect.Request[filesv1.GetUserDataExportRequest]) (*connect.Response[filesv1.GetUserDataExportResponse], error) {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	job, err := s.db.GetUserDataExport(ctx, req.Msg.GetExportId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if job == nil || (string(job.ProfileID) != sub && job.RequestedBy != sub) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user data export not found"))
+	}
+	return connect.NewResponse(&filesv1.GetUserDataExportResponse{Export: toUserDataExport(job)}), nil
+}
+
+// userDataEraseAudit is the audit action recorded when a profile erases its
+// own data.
+const userDataEraseAudit = "user_data.erase"
+
+// EraseUserData starts erasing everything a profile owns that no legal hold
+// or retention keeps. A profile erases its own data; a tenant admin may
+// erase any profile of their partition.
+func (s *FileServer) EraseUserData(ctx context.Context, req *connect.Request[filesv1.EraseUserDataRequest]) (*connect.Response[filesv1.EraseUserDataResponse], error) {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profileID := strings.TrimSpace(req.Msg.GetProfileId())
+	var call *tenantAdminCall
+	if profileID != "" && profileID != sub {
+		if call, err = s.tenantAdmin(ctx, req.Msg.GetReason()); err != nil {
+			return nil, err
+		}
+	} else {
+		profileID = sub
+	}
+
+	// Erasure reports are signed, so nothing is erased without the key.
+	cfg := s.Service.Config().(*config.FilesConfig)
+	if strings.TrimSpace(cfg.ErasureReportSigningKey) == "" {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("erasure report signing key is not configured"))
+	}
+
+	running, err := s.db.GetRunningUserDataErasure(ctx, types.OwnerID(profileID))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if running != nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("user data erasure %s is still running", running.ID))
+	}
+
+	job := &types.UserDataErasure{
+		ProfileID:   types.OwnerID(profileID),
+		RequestedBy: sub,
+		State:       types.UserDataErasureRunning,
+	}
+	if err = s.db.StoreUserDataErasure(ctx, job); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	err = s.Service.QueueManager().Publish(ctx, cfg.QueueUserEraseName, map[string]string{
+		"job_id": job.ID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	details := data.JSONMap{"erasure_id": job.ID, "profile_id": profileID}
+	if call != nil {
+		s.auditTenantAdmin(ctx, call, "", tenantAdminEraseAudit, details)
+	} else {
+		audit := &models.MediaAudit{
+			Action:  userDataEraseAudit,
+			Source:  "profile:" + sub,
+			Details: details,
+		}
+		audit.GenID(ctx)
+		events.EmitMediaAudit(ctx, s.Service, audit)
+	}
+
+	return connect.NewResponse(&filesv1.EraseUserDataResponse{Erasure: toUserDataErasure(job)}), nil
+}
+
+// GetUserDataErasure reports an erasure of the caller's data or one the
+// caller requested.
+func (s *FileServer) GetUserDataErasure(ctx context.Context, req *connect.Request[filesv1.GetUserDataErasureRequest]) (*connect.Response[filesv1.GetUserDataErasureResponse], error) {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	job, err := s.db.GetUserDataErasure(ctx, req.Msg.GetErasureId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if job == nil || (string(job.ProfileID) != sub && job.RequestedBy != sub) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("user data erasure not found"))
+	}
+	return connect.NewResponse(&filesv1.GetUserDataErasureResponse{Erasure: toUserDataErasure(job)}), nil
+}
+
+// CreateShareLink creates a link through which anyone holding its token can
+// reach a file without an account. The token is returned only here.
+func (s *FileServer) CreateShareLink(ctx context.Context, req *connect.Request[filesv1.CreateShareLinkRequest]) (*connect.Response[filesv1.CreateShareLinkResponse], error) {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mediaID := req.Msg.GetMediaId()
+	if !isValidMediaID(mediaID) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid media id"))
+	}
+	if err = s.requireFileOwner(ctx, sub, mediaID); err != nil {
+		return nil, err
+	}
+
+	permission := shareLinkPermissionToString(req.Msg.GetPermission())
+	if permission == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid share link permission"))
+	}
+	var expiresAt *time.Time
+	if req.Msg.GetExpiresAt() != nil {
+		t := req.Msg.GetExpiresAt().AsTime().UTC()
+		if !t.After(time.Now()) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("expires_at must be in the future"))
+		}
+		expiresAt = &t
+	}
+	if err = s.checkScanPolicy(ctx, mediaID); err != nil {
+		return nil, err
+	}
+
+	token, tokenHash, err := business.NewShareLinkToken()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	passwordHash, err := business.HashShareLinkPassword(req.Msg.GetPassword())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	link := &types.ShareLink{
+		MediaID:      types.MediaID(mediaID),
+		OwnerID:      types.OwnerID(sub),
+		TokenHash:    tokenHash,
+		PasswordHash: passwordHash,
+		Permission:   permission,
+		ExpiresAt:    expiresAt,
+		MaxDownloads: int(req.Msg.GetMaxDownloads()),
+	}
+	if err = s.db.StoreShareLink(ctx, link); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	linkURL, err := s.publicURL(routing.ShareLinkPathPrefix + token)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	metadata, _ := s.db.GetMediaMetadata(ctx, types.MediaID(mediaID))
+	if metadata == nil {
+		metadata = &types.MediaMetadata{MediaID: types.MediaID(mediaID)}
+	}
+	events.EmitLifecycleEvent(ctx, s.Service, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_SHARED, metadata, sub, map[string]string{
+		"share_link_id": link.ID,
+		"permission":    permission,
+	})
+
+	return connect.NewResponse(&filesv1.CreateShareLinkResponse{
+		Link:  toShareLink(link),
+		Token: token,
+		Url:   linkURL,
+	}), nil
+}
+
+// ListShareLinks lists the share links of a file owned by the caller.
+func (s *FileServer) ListShareLinks(ctx context.Context, req *connect.Request[filesv1.ListShareLinksRequest]) (*connect.Response[filesv1.ListShareLinksResponse], error) {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mediaID := req.Msg.GetMediaId()
+	if !isValidMediaID(mediaID) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid media id"))
+	}
+	if err = s.requireFileOwner(ctx, sub, mediaID); err != nil {
+		return nil, err
+	}
+
+	limit := 20
+	offset := 0
+	if cursor := req.Msg.GetCursor(); cursor != nil {
+		if cursor.GetLimit() > 0 {
+			limit = int(cursor.GetLimit())
+		}
+		if cursor.GetPage() != "" {
+			offset, _ = strconv.Atoi(cursor.GetPage())
+		}
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	links, total, err := s.db.ListShareLinks(ctx, types.MediaID(mediaID), req.Msg.GetIncludeRevoked(), limit, offset)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	result := make([]*filesv1.ShareLink, 0, len(links))
+	for _, link := range links {
+		result = append(result, toShareLink(link))
+	}
+
+	var nextCursor *commonv1.PageCursor
+	if end := offset + len(links); end < total {
+		nextCursor = &commonv1.PageCursor{Limit: int32(limit), Page: strconv.Itoa(end)}
+	}
+
+	return connect.NewResponse(&filesv1.ListShareLinksResponse{
+		Links:      result,
+		NextCursor: nextCursor,
+	}), nil
+}
+
+// RevokeShareLink stops a share link from resolving. The link's creator and
+// the owner of its file may revoke it.
+func (s *FileServer) RevokeShareLink(ctx context.Context, req *connect.Request[filesv1.RevokeShareLinkRequest]) (*connect.Response[filesv1.RevokeShareLinkResponse], error) {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	link, err := s.db.GetShareLink(ctx, req.Msg.GetLinkId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if link == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("share link not found"))
+	}
+	if string(link.OwnerID) != sub {
+		if err = s.requireFileOwner(ctx, sub, string(link.MediaID)); err != nil {
+			if connect.CodeOf(err) == connect.CodePermissionDenied {
+				return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("share link not found"))
+			}
+			return nil, err
+		}
+	}
+
+	if link.RevokedAt == nil {
+		now := time.Now().UTC()
+		if err = s.db.RevokeShareLink(ctx, link.ID, now); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		link.RevokedAt = &now
+	}
+	return connect.NewResponse(&filesv1.RevokeShareLinkResponse{Link: toShareLink(link)}), nil
+}
+
+// requireFileOwner allows only the owner of a file through.
+func (s *FileServer) requireFileOwner(ctx context.Context, sub, mediaID string) error {
+	owner, err := s.authz.GetFileOwner(ctx, mediaID)
+	if err != nil {
+		if errors.Is(err, authz.ErrNotFound) {
+			return connect.NewError(connect.CodeNotFound, fmt.Errorf("media not found"))
+		}
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if owner != sub {
+		return connect.NewError(connect.CodePermissionDenied, authz.ErrNotOwner)
+	}
+	return nil
+}
+
+func (s *FileServer) DownloadContent(ctx context.Context, req *connect.Request[filesv1.DownloadContentRequest], stream *connect.ServerStream[filesv1.DownloadContentResponse]) error {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return err
+	}
+	mediaID := req.Msg.GetMediaId()
+	if !isValidMediaID(mediaID) {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid media id"))
 	}
 	if err = s.authz.CanViewFile(ctx, sub, mediaID); err != nil {
 		return connect.NewError(connect.CodePermissionDenied, err)
@@ -1820,6 +3354,120 @@ func (s *FileServer) DownloadContent(ctx context.Context, req *connect.Request[f
 	}
 }
 
+// DownloadArchive streams a zip archive of the requested files or folder.
+func (s *FileServer) DownloadArchive(ctx context.Context, req *connect.Request[filesv1.DownloadArchiveRequest], stream *connect.ServerStream[filesv1.DownloadArchiveResponse]) error {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return err
+	}
+
+	archiver := business.NewArchiver(s.db, s.mediaService, s.authz)
+	entries, err := archiver.Resolve(ctx, sub, req.Msg.GetMediaIds(), req.Msg.GetFolder())
+	if err != nil {
+		return connect.NewError(mapBusinessErrorToConnectCode(err), err)
+	}
+
+	cfg := s.Service.Config().(*config.FilesConfig)
+	out := bufio.NewWriterSize(archiveStreamWriter{stream: stream}, contentReadBufferSize)
+	if err = archiver.Write(ctx, out, cfg, entries); err == nil {
+		err = out.Flush()
+	}
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	return nil
+}
+
+// archiveStreamWriter sends archive bytes as DownloadArchive responses.
+type archiveStreamWriter struct {
+	stream *connect.ServerStream[filesv1.DownloadArchiveResponse]
+}
+
+func (w archiveStreamWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&filesv1.DownloadArchiveResponse{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// ExtractArchive starts a job expanding an uploaded zip or tar.gz archive
+// into individual media owned by the caller.
+func (s *FileServer) ExtractArchive(ctx context.Context, req *connect.Request[filesv1.ExtractArchiveRequest]) (*connect.Response[filesv1.ExtractArchiveResponse], error) {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.authz.CanUploadFile(ctx, sub); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	mediaID := req.Msg.GetMediaId()
+	if !isValidMediaID(mediaID) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid media id"))
+	}
+	folder, err := business.CleanFolder(req.Msg.GetFolder())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err = s.authz.CanViewFile(ctx, sub, mediaID); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
+
+	source, err := s.db.GetMediaMetadata(ctx, types.MediaID(mediaID))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if source == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("media not found"))
+	}
+	if !business.IsArchive(source) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("media is not a zip or tar.gz archive"))
+	}
+	if err = s.checkScanPolicy(ctx, mediaID); err != nil {
+		return nil, err
+	}
+	if folder == "" {
+		folder = business.ExtractionFolder(source)
+	}
+
+	job := &types.ArchiveExtraction{
+		OwnerID:       types.OwnerID(sub),
+		SourceMediaID: source.MediaID,
+		Folder:        folder,
+		State:         types.ArchiveExtractionRunning,
+	}
+	if err = s.db.StoreArchiveExtraction(ctx, job); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	cfg := s.Service.Config().(*config.FilesConfig)
+	err = s.Service.QueueManager().Publish(ctx, cfg.QueueArchiveExtractName, map[string]string{
+		"job_id": job.ID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&filesv1.ExtractArchiveResponse{Job: toArchiveExtraction(job)}), nil
+}
+
+// GetArchiveExtraction reports the progress of an extraction job started by
+// the caller.
+func (s *FileServer) GetArchiveExtraction(ctx context.Context, req *connect.Request[filesv1.GetArchiveExtractionRequest]) (*connect.Response[filesv1.GetArchiveExtractionResponse], error) {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	job, err := s.db.GetArchiveExtraction(ctx, req.Msg.GetJobId())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if job == nil || string(job.OwnerID) != sub {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("extraction job not found"))
+	}
+	return connect.NewResponse(&filesv1.GetArchiveExtractionResponse{Job: toArchiveExtraction(job)}), nil
+}
+
 func (s *FileServer) DownloadContentRange(ctx context.Context, req *connect.Request[filesv1.DownloadContentRangeRequest], stream *connect.ServerStream[filesv1.DownloadContentRangeResponse]) error {
 	sub, err := authenticatedSubject(ctx)
 	if err != nil {
@@ -1892,6 +3540,125 @@ func (s *FileServer) DownloadContentRange(ctx context.Context, req *connect.Requ
 	return nil
 }
 
+// CreateWebhookSubscription registers an endpoint for file lifecycle events of
+// the caller's tenant. The signing secret is only returned here.
+func (s *FileServer) CreateWebhookSubscription(ctx context.Context, req *connect.Request[filesv1.CreateWebhookSubscriptionRequest]) (*connect.Response[filesv1.CreateWebhookSubscriptionResponse], error) {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := s.Service.Config().(*config.FilesConfig)
+	if err = webhooks.ValidateEndpoint(req.Msg.GetUrl(), cfg.WebhookAllowPrivateEndpoints); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	eventTypes := make([]string, 0, len(req.Msg.GetEventTypes()))
+	for _, eventType := range req.Msg.GetEventTypes() {
+		if eventType == filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_UNSPECIFIED {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("event type must be specified"))
+		}
+		if !slices.Contains(eventTypes, eventType.String()) {
+			eventTypes = append(eventTypes, eventType.String())
+		}
+	}
+
+	secret := webhooks.NewSecret()
+	sealed, err := webhooks.SealSecret(secret, cfg.EnvStorageEncryptionPhrase)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	subscription := &types.WebhookSubscription{
+		URL:          req.Msg.GetUrl(),
+		Description:  req.Msg.GetDescription(),
+		EventTypes:   eventTypes,
+		SealedSecret: sealed,
+		Active:       true,
+		CreatedBy:    sub,
+	}
+	if err = s.db.StoreWebhookSubscription(ctx, subscription); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&filesv1.CreateWebhookSubscriptionResponse{
+		Subscription:  toWebhookSubscription(subscription),
+		SigningSecret: secret,
+	}), nil
+}
+
+func (s *FileServer) ListWebhookSubscriptions(ctx context.Context, req *connect.Request[filesv1.ListWebhookSubscriptionsRequest]) (*connect.Response[filesv1.ListWebhookSubscriptionsResponse], error) {
+	if _, err := authenticatedSubject(ctx); err != nil {
+		return nil, err
+	}
+
+	limit, offset := pageCursorBounds(req.Msg.GetCursor())
+	subscriptions, total, err := s.db.ListWebhookSubscriptions(ctx, limit, offset)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	result := make([]*filesv1.WebhookSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		result = append(result, toWebhookSubscription(subscription))
+	}
+
+	return connect.NewResponse(&filesv1.ListWebhookSubscriptionsResponse{
+		Subscriptions: result,
+		NextCursor:    nextPageCursor(limit, offset, len(subscriptions), total),
+	}), nil
+}
+
+func (s *FileServer) DeleteWebhookSubscription(ctx context.Context, req *connect.Request[filesv1.DeleteWebhookSubscriptionRequest]) (*connect.Response[filesv1.DeleteWebhookSubscriptionResponse], error) {
+	if _, err := authenticatedSubject(ctx); err != nil {
+		return nil, err
+	}
+
+	subscriptionID := req.Msg.GetSubscriptionId()
+	subscription, err := s.db.GetWebhookSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if subscription == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("webhook subscription not found"))
+	}
+	if err = s.db.DeleteWebhookSubscription(ctx, subscriptionID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&filesv1.DeleteWebhookSubscriptionResponse{Success: true}), nil
+}
+
+func (s *FileServer) ListWebhookDeliveries(ctx context.Context, req *connect.Request[filesv1.ListWebhookDeliveriesRequest]) (*connect.Response[filesv1.ListWebhookDeliveriesResponse], error) {
+	if _, err := authenticatedSubject(ctx); err != nil {
+		return nil, err
+	}
+
+	subscriptionID := req.Msg.GetSubscriptionId()
+	subscription, err := s.db.GetWebhookSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if subscription == nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("webhook subscription not found"))
+	}
+
+	limit, offset := pageCursorBounds(req.Msg.GetCursor())
+	deliveries, total, err := s.db.ListWebhookDeliveries(ctx, subscriptionID, webhookStateFromProto(req.Msg.GetFilterState()), limit, offset)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	result := make([]*filesv1.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, toWebhookDelivery(delivery))
+	}
+
+	return connect.NewResponse(&filesv1.ListWebhookDeliveriesResponse{
+		Deliveries: result,
+		NextCursor: nextPageCursor(limit, offset, len(deliveries), total),
+	}), nil
+}
+
 func authenticatedSubject(ctx context.Context) (string, error) {
 	authClaims := security.ClaimsFromContext(ctx)
 	if authClaims == nil {
@@ -1906,6 +3673,16 @@ func authenticatedSubject(ctx context.Context) (string, error) {
 	return sub, nil
 }
 
+// callerSubject returns the authenticated subject, or an empty string for
+// anonymous and unauthenticated calls.
+func callerSubject(ctx context.Context) string {
+	sub, err := authenticatedSubject(ctx)
+	if err != nil {
+		return ""
+	}
+	return sub
+}
+
 func applyRequestTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
 	if timeout <= 0 {
 		timeout = defaultRequestTimeout
@@ -1929,6 +3706,8 @@ func mapBusinessErrorToConnectCode(err error) connect.Code {
 		return connect.CodeInvalidArgument
 	case strings.Contains(msg, "permission denied"):
 		return connect.CodePermissionDenied
+	case strings.Contains(msg, "failed precondition"):
+		return connect.CodeFailedPrecondition
 	default:
 		return connect.CodeInternal
 	}
@@ -2113,6 +3892,9 @@ func (s *FileServer) fetchAndStorePreviewImage(ctx context.Context, client *http
 	if err != nil {
 		return "", err
 	}
+	if err = queueMediaScan(ctx, s.Service, result.MediaID); err != nil {
+		return "", err
+	}
 
 	return string(result.MediaID), nil
 }
@@ -2221,19 +4003,27 @@ func mergeSearchResults(ctx context.Context, ownerResults []*types.MediaMetadata
 		merged = append(merged, media)
 	}
 
+	var lookup []types.MediaID
 	for _, sharedID := range sharedIDs {
-		if _, ok := seen[sharedID]; ok {
-			continue
+		if _, ok := seen[sharedID]; !ok {
+			lookup = append(lookup, types.MediaID(sharedID))
 		}
-		meta, err := db.GetMediaMetadata(ctx, types.MediaID(sharedID))
-		if err != nil || meta == nil {
-			continue
+	}
+	if len(lookup) > 0 {
+		shared, err := db.GetMediaMetadataBatch(ctx, lookup)
+		if err != nil {
+			util.Log(ctx).WithError(err).Warn("failed to look up shared media for search")
 		}
-		if !matchSearchFilters(meta, req, startDate, endDate) {
-			continue
+		for _, meta := range shared {
+			if _, ok := seen[string(meta.MediaID)]; ok {
+				continue
+			}
+			if !matchSearchFilters(meta, req, startDate, endDate) {
+				continue
+			}
+			seen[string(meta.MediaID)] = struct{}{}
+			merged = append(merged, meta)
 		}
-		seen[sharedID] = struct{}{}
-		merged = append(merged, meta)
 	}
 
 	sort.Slice(merged, func(i, j int) bool {
@@ -2287,7 +4077,34 @@ func queueThumbnailGeneration(ctx context.Context, service *frame.Service, media
 	})
 }
 
-func toMediaMetadata(metadata *types.MediaMetadata) *filesv1.MediaMetadata {
+// queueMediaScan requests a malware scan of newly stored media.
+func queueMediaScan(ctx context.Context, service *frame.Service, mediaID types.MediaID) error {
+	cfg := service.Config().(*config.FilesConfig)
+	if !cfg.ScanEnabled() {
+		return nil
+	}
+	return service.QueueManager().Publish(ctx, cfg.QueueMediaScanName, map[string]string{
+		"media_id": string(mediaID),
+	})
+}
+
+// checkScanPolicy refuses to serve or share media the malware scan policy
+// blocks.
+func (s *FileServer) checkScanPolicy(ctx context.Context, mediaID string) error {
+	metadata, err := s.db.GetMediaMetadata(ctx, types.MediaID(mediaID))
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	cfg := s.Service.Config().(*config.FilesConfig)
+	if err = business.CheckScanPolicy(cfg, metadata); err != nil {
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	return nil
+}
+
+// toMediaMetadata converts metadata for a response to viewer, the caller's
+// subject.
+func toMediaMetadata(metadata *types.MediaMetadata, viewer string) *filesv1.MediaMetadata {
 	if metadata == nil {
 		return nil
 	}
@@ -2297,6 +4114,11 @@ func toMediaMetadata(metadata *types.MediaMetadata) *filesv1.MediaMetadata {
 		visibility = filesv1.MediaMetadata_VISIBILITY_PUBLIC
 	}
 
+	placeholder := types.Placeholder{}
+	if metadata.Placeholder != nil {
+		placeholder = *metadata.Placeholder
+	}
+
 	return &filesv1.MediaMetadata{
 		MediaId:        string(metadata.MediaID),
 		ContentType:    string(metadata.ContentType),
@@ -2305,7 +4127,76 @@ func toMediaMetadata(metadata *types.MediaMetadata) *filesv1.MediaMetadata {
 		Filename:       string(metadata.UploadName),
 		ChecksumSha256: string(metadata.Base64Hash),
 		Visibility:     visibility,
+		Folder:         metadata.Folder,
+		Properties:     toMediaProperties(metadata, viewer),
+		Blurhash:       placeholder.BlurHash,
+		DominantColor:  placeholder.DominantColor,
+
+		DeclaredContentType: string(metadata.DeclaredContentType),
+		ScanStatus:          toScanStatus(metadata.ScanStatus),
+	}
+}
+
+func toScanStatus(status types.ScanStatus) filesv1.ScanStatus {
+	switch status {
+	case types.ScanStatusPending:
+		return filesv1.ScanStatus_SCAN_STATUS_PENDING
+	case types.ScanStatusClean:
+		return filesv1.ScanStatus_SCAN_STATUS_CLEAN
+	case types.ScanStatusInfected:
+		return filesv1.ScanStatus_SCAN_STATUS_INFECTED
+	case types.ScanStatusFailed:
+		return filesv1.ScanStatus_SCAN_STATUS_FAILED
+	default:
+		return filesv1.ScanStatus_SCAN_STATUS_UNSPECIFIED
+	}
+}
+
+// toMediaProperties converts the content properties, leaving out the EXIF
+// location unless viewer owns the media.
+func toMediaProperties(metadata *types.MediaMetadata, viewer string) *filesv1.MediaProperties {
+	p := metadata.Properties
+	exif := metadata.Exif
+	if viewer == "" || types.OwnerID(viewer) != metadata.OwnerID {
+		exif = withoutLocation(exif)
+	}
+	if p == nil && len(exif) == 0 {
+		return nil
+	}
+	out := &filesv1.MediaProperties{Exif: exif}
+	if p != nil {
+		out.Format = p.Format
+		out.Width = int32(p.Width)
+		out.Height = int32(p.Height)
+		out.ColorSpace = p.ColorSpace
+		out.DurationMs = p.DurationMillis
+		out.Codecs = p.Codecs
+		out.PageCount = int32(p.PageCount)
+		out.Title = p.Title
+	}
+	return out
+}
+
+// withoutLocation returns the EXIF fields without the GPS ones, sharing the
+// map when it carries none.
+func withoutLocation(exif map[string]string) map[string]string {
+	found := false
+	for key := range exif {
+		if imagemeta.IsLocationField(key) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return exif
 	}
+	out := make(map[string]string, len(exif))
+	for key, value := range exif {
+		if !imagemeta.IsLocationField(key) {
+			out[key] = value
+		}
+	}
+	return out
 }
 
 func accessRoleToString(role filesv1.AccessRole) string {
@@ -2321,6 +4212,474 @@ func accessRoleToString(role filesv1.AccessRole) string {
 	}
 }
 
+// pageCursorBounds resolves an offset page cursor into a limit and offset.
+func pageCursorBounds(cursor *commonv1.PageCursor) (int, int) {
+	limit := 20
+	offset := 0
+	if cursor != nil {
+		if cursor.GetLimit() > 0 {
+			limit = int(cursor.GetLimit())
+		}
+		if cursor.GetPage() != "" {
+			offset, _ = strconv.Atoi(cursor.GetPage())
+		}
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+func nextPageCursor(limit, offset, count, total int) *commonv1.PageCursor {
+	end := offset + count
+	if count == 0 || end >= total {
+		return nil
+	}
+	return &commonv1.PageCursor{Limit: int32(limit), Page: strconv.Itoa(end)}
+}
+
+func toWebhookSubscription(subscription *types.WebhookSubscription) *filesv1.WebhookSubscription {
+	eventTypes := make([]filesv1.LifecycleEventType, 0, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		if v, ok := filesv1.LifecycleEventType_value[eventType]; ok {
+			eventTypes = append(eventTypes, filesv1.LifecycleEventType(v))
+		}
+	}
+	return &filesv1.WebhookSubscription{
+		SubscriptionId: subscription.ID,
+		Url:            subscription.URL,
+		EventTypes:     eventTypes,
+		Description:    subscription.Description,
+		Active:         subscription.Active,
+		CreatedAt:      timestamppb.New(subscription.CreatedAt),
+		CreatedBy:      subscription.CreatedBy,
+	}
+}
+
+func toWebhookDelivery(delivery *types.WebhookDelivery) *filesv1.WebhookDelivery {
+	result := &filesv1.WebhookDelivery{
+		DeliveryId:     delivery.ID,
+		SubscriptionId: delivery.SubscriptionID,
+		EventId:        delivery.EventID,
+		EventType:      filesv1.LifecycleEventType(filesv1.LifecycleEventType_value[delivery.EventType]),
+		State:          webhookStateToProto(delivery.State),
+		Attempts:       int32(delivery.Attempts),
+		LastStatusCode: int32(delivery.LastStatusCode),
+		LastError:      delivery.LastError,
+		CreatedAt:      timestamppb.New(delivery.CreatedAt),
+	}
+	if delivery.NextAttemptAt != nil {
+		result.NextAttemptAt = timestamppb.New(*delivery.NextAttemptAt)
+	}
+	if delivery.DeliveredAt != nil {
+		result.DeliveredAt = timestamppb.New(*delivery.DeliveredAt)
+	}
+	return result
+}
+
+func toThumbnailStatus(job *types.ThumbnailJob, thumbnailCount int) *filesv1.ThumbnailStatus {
+	result := &filesv1.ThumbnailStatus{
+		MediaId:        string(job.MediaID),
+		State:          thumbnailJobStateToProto(job.State),
+		Attempts:       int32(job.Attempts),
+		LastError:      job.LastError,
+		CreatedAt:      timestamppb.New(job.CreatedAt),
+		UpdatedAt:      timestamppb.New(job.ModifiedAt),
+		ThumbnailCount: int32(thumbnailCount),
+	}
+	if job.NextAttemptAt != nil {
+		result.NextAttemptAt = timestamppb.New(*job.NextAttemptAt)
+	}
+	if job.CompletedAt != nil {
+		result.CompletedAt = timestamppb.New(*job.CompletedAt)
+	}
+	return result
+}
+
+func toThumbnailRegeneration(run *types.ThumbnailRegeneration) *filesv1.ThumbnailRegeneration {
+	result := &filesv1.ThumbnailRegeneration{
+		RunId:            run.ID,
+		State:            thumbnailRegenerationStateToProto(run.State),
+		OwnerId:          string(run.Filter.OwnerID),
+		ContentType:      run.Filter.ContentType,
+		RemoveStaleSizes: run.RemoveStale,
+		RatePerSecond:    int32(run.RatePerSecond),
+		Scanned:          run.Scanned,
+		Enqueued:         run.Enqueued,
+		Removed:          run.Removed,
+		Checkpoint:       run.Checkpoint,
+		LastError:        run.LastError,
+		CreatedAt:        timestamppb.New(run.CreatedAt),
+		UpdatedAt:        timestamppb.New(run.ModifiedAt),
+	}
+	if run.Filter.CreatedAfter != nil {
+		result.CreatedAfter = timestamppb.New(*run.Filter.CreatedAfter)
+	}
+	if run.Filter.CreatedBefore != nil {
+		result.CreatedBefore = timestamppb.New(*run.Filter.CreatedBefore)
+	}
+	if run.CompletedAt != nil {
+		result.CompletedAt = timestamppb.New(*run.CompletedAt)
+	}
+	return result
+}
+
+func toArchiveExtraction(job *types.ArchiveExtraction) *filesv1.ArchiveExtraction {
+	result := &filesv1.ArchiveExtraction{
+		JobId:          job.ID,
+		State:          archiveExtractionStateToProto(job.State),
+		SourceMediaId:  string(job.SourceMediaID),
+		Folder:         job.Folder,
+		Entries:        job.Entries,
+		Extracted:      job.Extracted,
+		Skipped:        job.Skipped,
+		Failed:         job.Failed,
+		ExtractedBytes: job.ExtractedBytes,
+		LastError:      job.LastError,
+		CreatedAt:      timestamppb.New(job.CreatedAt),
+		UpdatedAt:      timestamppb.New(job.ModifiedAt),
+	}
+	paths := make([]string, 0, len(job.Failures))
+	for entryPath := range job.Failures {
+		paths = append(paths, entryPath)
+	}
+	sort.Strings(paths)
+	for _, entryPath := range paths {
+		result.Failures = append(result.Failures, &filesv1.ArchiveExtractionFailure{
+			Path:  entryPath,
+			Error: job.Failures[entryPath],
+		})
+	}
+	if job.CompletedAt != nil {
+		result.CompletedAt = timestamppb.New(*job.CompletedAt)
+	}
+	return result
+}
+
+func toShareLink(link *types.ShareLink) *filesv1.ShareLink {
+	result := &filesv1.ShareLink{
+		LinkId:            link.ID,
+		MediaId:           string(link.MediaID),
+		Permission:        shareLinkPermissionToProto(link.Permission),
+		State:             shareLinkStateToProto(business.ShareLinkState(link, time.Now().UTC())),
+		PasswordProtected: link.PasswordHash != "",
+		MaxDownloads:      int32(link.MaxDownloads),
+		DownloadCount:     int32(link.DownloadCount),
+		CreatedBy:         string(link.OwnerID),
+		CreatedAt:         timestamppb.New(link.CreatedAt),
+	}
+	if link.ExpiresAt != nil {
+		result.ExpiresAt = timestamppb.New(*link.ExpiresAt)
+	}
+	if link.RevokedAt != nil {
+		result.RevokedAt = timestamppb.New(*link.RevokedAt)
+	}
+	if link.LastAccessedAt != nil {
+		result.LastAccessedAt = timestamppb.New(*link.LastAccessedAt)
+	}
+	return result
+}
+
+func shareLinkPermissionToString(permission filesv1.ShareLinkPermission) string {
+	switch permission {
+	case filesv1.ShareLinkPermission_SHARE_LINK_PERMISSION_UNSPECIFIED, filesv1.ShareLinkPermission_SHARE_LINK_PERMISSION_VIEW:
+		return types.ShareLinkView
+	case filesv1.ShareLinkPermission_SHARE_LINK_PERMISSION_DOWNLOAD:
+		return types.ShareLinkDownload
+	default:
+		return ""
+	}
+}
+
+func shareLinkPermissionToProto(permission string) filesv1.ShareLinkPermission {
+	switch permission {
+	case types.ShareLinkView:
+		return filesv1.ShareLinkPermission_SHARE_LINK_PERMISSION_VIEW
+	case types.ShareLinkDownload:
+		return filesv1.ShareLinkPermission_SHARE_LINK_PERMISSION_DOWNLOAD
+	default:
+		return filesv1.ShareLinkPermission_SHARE_LINK_PERMISSION_UNSPECIFIED
+	}
+}
+
+func shareLinkStateToProto(state string) filesv1.ShareLinkState {
+	switch state {
+	case business.ShareLinkActive:
+		return filesv1.ShareLinkState_SHARE_LINK_STATE_ACTIVE
+	case business.ShareLinkExpired:
+		return filesv1.ShareLinkState_SHARE_LINK_STATE_EXPIRED
+	case business.ShareLinkRevoked:
+		return filesv1.ShareLinkState_SHARE_LINK_STATE_REVOKED
+	case business.ShareLinkExhausted:
+		return filesv1.ShareLinkState_SHARE_LINK_STATE_EXHAUSTED
+	default:
+		return filesv1.ShareLinkState_SHARE_LINK_STATE_UNSPECIFIED
+	}
+}
+
+func toUserDataExport(job *types.UserDataExport) *filesv1.UserDataExport {
+	result := &filesv1.UserDataExport{
+		ExportId:      job.ID,
+		State:         userDataExportStateToProto(job.State),
+		ProfileId:     string(job.ProfileID),
+		RequestedBy:   job.RequestedBy,
+		TotalFiles:    job.TotalFiles,
+		ExportedFiles: job.ExportedFiles,
+		FailedFiles:   job.FailedFiles,
+		ExportedBytes: job.ExportedBytes,
+		ResultMediaId: string(job.ResultMediaID),
+		LastError:     job.LastError,
+		CreatedAt:     timestamppb.New(job.CreatedAt),
+		UpdatedAt:     timestamppb.New(job.ModifiedAt),
+	}
+	if job.CompletedAt != nil {
+		result.CompletedAt = timestamppb.New(*job.CompletedAt)
+	}
+	return result
+}
+
+func userDataExportStateToProto(state string) filesv1.UserDataExportState {
+	switch state {
+	case types.UserDataExportRunning:
+		return filesv1.UserDataExportState_USER_DATA_EXPORT_STATE_RUNNING
+	case types.UserDataExportCompleted:
+		return filesv1.UserDataExportState_USER_DATA_EXPORT_STATE_COMPLETED
+	case types.UserDataExportFailed:
+		return filesv1.UserDataExportState_USER_DATA_EXPORT_STATE_FAILED
+	default:
+		return filesv1.UserDataExportState_USER_DATA_EXPORT_STATE_UNSPECIFIED
+	}
+}
+
+func toUserDataErasure(job *types.UserDataErasure) *filesv1.UserDataErasure {
+	result := &filesv1.UserDataErasure{
+		ErasureId:   job.ID,
+		State:       userDataErasureStateToProto(job.State),
+		ProfileId:   string(job.ProfileID),
+		RequestedBy: job.RequestedBy,
+		Report:      job.Report,
+		Signature:   job.Signature,
+		LastError:   job.LastError,
+		CreatedAt:   timestamppb.New(job.CreatedAt),
+	}
+	if job.CompletedAt != nil {
+		result.CompletedAt = timestamppb.New(*job.CompletedAt)
+	}
+
+	var report types.ErasureReport
+	if job.Report == "" || json.Unmarshal([]byte(job.Report), &report) != nil {
+		return result
+	}
+	result.ErasedFiles = int64(len(report.ErasedFiles))
+	for _, retained := range report.RetainedFiles {
+		file := &filesv1.UserDataErasureRetainedFile{
+			MediaId: string(retained.MediaID),
+			Reason:  retained.Reason,
+		}
+		if retained.Until != nil {
+			file.RetainedUntil = timestamppb.New(*retained.Until)
+		}
+		result.RetainedFiles = append(result.RetainedFiles, file)
+	}
+	return result
+}
+
+func userDataErasureStateToProto(state string) filesv1.UserDataErasureState {
+	switch state {
+	case types.UserDataErasureRunning:
+		return filesv1.UserDataErasureState_USER_DATA_ERASURE_STATE_RUNNING
+	case types.UserDataErasureCompleted:
+		return filesv1.UserDataErasureState_USER_DATA_ERASURE_STATE_COMPLETED
+	case types.UserDataErasureFailed:
+		return filesv1.UserDataErasureState_USER_DATA_ERASURE_STATE_FAILED
+	default:
+		return filesv1.UserDataErasureState_USER_DATA_ERASURE_STATE_UNSPECIFIED
+	}
+}
+
+func archiveExtractionStateToProto(state string) filesv1.ArchiveExtractionState {
+	switch state {
+	case types.ArchiveExtractionRunning:
+		return filesv1.ArchiveExtractionState_ARCHIVE_EXTRACTION_STATE_RUNNING
+	case types.ArchiveExtractionCompleted:
+		return filesv1.ArchiveExtractionState_ARCHIVE_EXTRACTION_STATE_COMPLETED
+	case types.ArchiveExtractionFailed:
+		return filesv1.ArchiveExtractionState_ARCHIVE_EXTRACTION_STATE_FAILED
+	default:
+		return filesv1.ArchiveExtractionState_ARCHIVE_EXTRACTION_STATE_UNSPECIFIED
+	}
+}
+
+func thumbnailRegenerationStateToProto(state string) filesv1.ThumbnailRegenerationState {
+	switch state {
+	case types.ThumbnailRegenerationRunning:
+		return filesv1.ThumbnailRegenerationState_THUMBNAIL_REGENERATION_STATE_RUNNING
+	case types.ThumbnailRegenerationCompleted:
+		return filesv1.ThumbnailRegenerationState_THUMBNAIL_REGENERATION_STATE_COMPLETED
+	case types.ThumbnailRegenerationFailed:
+		return filesv1.ThumbnailRegenerationState_THUMBNAIL_REGENERATION_STATE_FAILED
+	default:
+		return filesv1.ThumbnailRegenerationState_THUMBNAIL_REGENERATION_STATE_UNSPECIFIED
+	}
+}
+
+func thumbnailJobStateToProto(state string) filesv1.ThumbnailJobState {
+	switch state {
+	case types.ThumbnailJobPending:
+		return filesv1.ThumbnailJobState_THUMBNAIL_JOB_STATE_PENDING
+	case types.ThumbnailJobCompleted:
+		return filesv1.ThumbnailJobState_THUMBNAIL_JOB_STATE_COMPLETED
+	case types.ThumbnailJobDeadLetter:
+		return filesv1.ThumbnailJobState_THUMBNAIL_JOB_STATE_DEAD_LETTER
+	default:
+		return filesv1.ThumbnailJobState_THUMBNAIL_JOB_STATE_UNSPECIFIED
+	}
+}
+
+func webhookStateToProto(state string) filesv1.WebhookDeliveryState {
+	switch state {
+	case types.WebhookDeliveryPending:
+		return filesv1.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_PENDING
+	case types.WebhookDeliveryDelivered:
+		return filesv1.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_DELIVERED
+	case types.WebhookDeliveryDeadLetter:
+		return filesv1.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_DEAD_LETTER
+	default:
+		return filesv1.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_UNSPECIFIED
+	}
+}
+
+func webhookStateFromProto(state filesv1.WebhookDeliveryState) string {
+	switch state {
+	case filesv1.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_PENDING:
+		return types.WebhookDeliveryPending
+	case filesv1.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_DELIVERED:
+		return types.WebhookDeliveryDelivered
+	case filesv1.WebhookDeliveryState_WEBHOOK_DELIVERY_STATE_DEAD_LETTER:
+		return types.WebhookDeliveryDeadLetter
+	default:
+		return ""
+	}
+}
+
+// accessFolder resolves the target of an access request: a folder of the
+// caller's when one is given, otherwise the media, which must then be valid.
+// It returns the cleaned folder, or "" for a media target.
+func accessFolder(mediaID, folder string) (string, error) {
+	if folder == "" {
+		if !isValidMediaID(mediaID) {
+			return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid media id"))
+		}
+		return "", nil
+	}
+	if mediaID != "" {
+		return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("only one of media_id and folder can be set"))
+	}
+	cleaned, err := business.CleanFolder(folder)
+	if err != nil {
+		return "", connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if cleaned == "" {
+		return "", connect.NewError(connect.CodeInvalidArgument, authz.ErrInvalidFolder)
+	}
+	return cleaned, nil
+}
+
+// accessPrincipal resolves the grantee of an access request. Tenant
+// partitions can only be granted within the caller's own tenant.
+func accessPrincipal(ctx context.Context, principalType filesv1.PrincipalType, principalID string) (authz.Principal, error) {
+	var kind string
+	switch principalType {
+	case filesv1.PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED:
+	case filesv1.PrincipalType_PRINCIPAL_TYPE_USER:
+		kind = authz.PrincipalUser
+	case filesv1.PrincipalType_PRINCIPAL_TYPE_ORGANIZATION:
+		kind = authz.PrincipalTenancy
+	case filesv1.PrincipalType_PRINCIPAL_TYPE_GROUP:
+		kind = authz.PrincipalGroup
+	default:
+		return authz.Principal{}, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("principal type %s is not supported", principalType))
+	}
+
+	principal, err := authz.ParsePrincipal(kind, principalID)
+	if err != nil {
+		return authz.Principal{}, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if principal.Kind == authz.PrincipalTenancy {
+		claims := security.ClaimsFromContext(ctx)
+		if claims == nil || claims.GetTenantID() != principal.TenantID() {
+			return authz.Principal{}, connect.NewError(connect.CodePermissionDenied,
+				errors.New("access can only be granted to partitions of your own tenant"))
+		}
+	}
+	return principal, nil
+}
+
+func toAccessGrant(g authz.AccessGrantInfo) *filesv1.AccessGrant {
+	ag := &filesv1.AccessGrant{}
+	ag.SetPrincipalId(g.Principal.ID)
+	ag.SetRole(stringToAccessRole(g.Role))
+	ag.SetPrincipalType(principalKindToProto(g.Principal.Kind))
+	ag.SetInheritedFrom(g.InheritedFrom)
+	ag.SetGrantedBy(g.GrantedBy)
+	if !g.GrantedAt.IsZero() {
+		ag.SetGrantedAt(timestamppb.New(g.GrantedAt))
+	}
+	if g.ExpiresAt != nil {
+		ag.SetExpiresAt(timestamppb.New(*g.ExpiresAt))
+	}
+	return ag
+}
+
+func ownershipIssueKindToProto(kind string) filesv1.OwnershipIssueKind {
+	switch kind {
+	case authz.IssueMissingOwnerTuple:
+		return filesv1.OwnershipIssueKind_OWNERSHIP_ISSUE_KIND_MISSING_OWNER_TUPLE
+	case authz.IssueStaleOwnerTuple:
+		return filesv1.OwnershipIssueKind_OWNERSHIP_ISSUE_KIND_STALE_OWNER_TUPLE
+	case authz.IssueDeletedMediaTuple:
+		return filesv1.OwnershipIssueKind_OWNERSHIP_ISSUE_KIND_DELETED_MEDIA_TUPLE
+	case authz.IssueUnknownSubject:
+		return filesv1.OwnershipIssueKind_OWNERSHIP_ISSUE_KIND_UNKNOWN_SUBJECT
+	case authz.IssueMissingParentTuple:
+		return filesv1.OwnershipIssueKind_OWNERSHIP_ISSUE_KIND_MISSING_PARENT_TUPLE
+	case authz.IssueStaleParentTuple:
+		return filesv1.OwnershipIssueKind_OWNERSHIP_ISSUE_KIND_STALE_PARENT_TUPLE
+	default:
+		return filesv1.OwnershipIssueKind_OWNERSHIP_ISSUE_KIND_UNSPECIFIED
+	}
+}
+
+// subjectString formats a Keto subject as "<namespace>:<id>", followed by
+// "#<relation>" for a subject set.
+func subjectString(subject security.SubjectRef) string {
+	namespace := subject.Namespace
+	if namespace == "" {
+		namespace = authz.NamespaceProfile
+	}
+	if subject.Relation == "" {
+		return namespace + ":" + subject.ID
+	}
+	return namespace + ":" + subject.ID + "#" + subject.Relation
+}
+
+func principalKindToProto(kind string) filesv1.PrincipalType {
+	switch kind {
+	case authz.PrincipalUser:
+		return filesv1.PrincipalType_PRINCIPAL_TYPE_USER
+	case authz.PrincipalTenancy:
+		return filesv1.PrincipalType_PRINCIPAL_TYPE_ORGANIZATION
+	case authz.PrincipalGroup:
+		return filesv1.PrincipalType_PRINCIPAL_TYPE_GROUP
+	default:
+		return filesv1.PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED
+	}
+}
+
 func stringToAccessRole(role string) filesv1.AccessRole {
 	switch role {
 	case "viewer":
@@ -3,6 +3,7 @@ package handler
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"image"
@@ -18,12 +19,13 @@ import (
 	"time"
 
 	commonv1 "buf.build/gen/go/antinvestor/common/protocolbuffers/go/common/v1"
-	"buf.build/gen/go/antinvestor/files/connectrpc/go/files/v1/filesv1connect"
-	filesv1 "buf.build/gen/go/antinvestor/files/protocolbuffers/go/files/v1"
 	"connectrpc.com/connect"
 	"github.com/antinvestor/service-files/apps/default/config"
+	filesv1 "github.com/antinvestor/service-files/apps/default/gen/files/v1"
+	"github.com/antinvestor/service-files/apps/default/gen/files/v1/filesv1connect"
 	"github.com/antinvestor/service-files/apps/default/service/authz"
 	"github.com/antinvestor/service-files/apps/default/service/business"
+	"github.com/antinvestor/service-files/apps/default/service/cache"
 	"github.com/antinvestor/service-files/apps/default/service/storage/connection"
 	"github.com/antinvestor/service-files/apps/default/service/storage/provider"
 	"github.com/antinvestor/service-files/apps/default/service/tests"
@@ -35,6 +37,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	_ "golang.org/x/image/webp" // registers the webp decoder for derivative checks
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -87,7 +90,7 @@ func (suite *FileServerTestSuite) Test_NewFileServer() {
 
 		for _, tc := range testCases {
 			t.Run(tc.name, func(t *testing.T) {
-				handler := NewFileServer(svc, mediaService, authzMiddleware, db, storageProvider)
+				handler := NewFileServer(svc, mediaService, authzMiddleware, db, storageProvider, nil)
 				require.NotNil(t, handler)
 				assert.Implements(t, (*filesv1connect.FilesServiceHandler)(nil), handler)
 			})
@@ -373,7 +376,7 @@ func (suite *FileServerTestSuite) Test_FileServer_GetUrlPreviewSuccess() {
 		require.NoError(t, err)
 		mediaService := business.NewMediaService(db, storageProvider)
 		authzMiddleware := authz.NewMiddleware(svc.SecurityManager().GetAuthorizer(ctx), db)
-		handler := NewFileServer(svc, mediaService, authzMiddleware, db, storageProvider).(*FileServer)
+		handler := NewFileServer(svc, mediaService, authzMiddleware, db, storageProvider, nil).(*FileServer)
 
 		imagePayload := createJPEGPayload(t, 32, 32)
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -517,7 +520,7 @@ func (suite *FileServerTestSuite) Test_FileServer_GetUrlPreviewAdditionalCases()
 		mediaService := business.NewMediaService(db, storageProvider)
 		authorizer := svc.SecurityManager().GetAuthorizer(ctx)
 		authzMiddleware := authz.NewMiddleware(authorizer, db)
-		handler := NewFileServer(svc, mediaService, authzMiddleware, db, storageProvider).(*FileServer)
+		handler := NewFileServer(svc, mediaService, authzMiddleware, db, storageProvider, nil).(*FileServer)
 
 		for _, tc := range testCases {
 			t.Run(tc.name, func(t *testing.T) {
@@ -707,6 +710,17 @@ func (suite *FileServerTestSuite) Test_FileServer_GetContentThumbnailBusinessVal
 			},
 			expectErr: connect.CodeInvalidArgument,
 		},
+		{
+			name:   "invalid_rotation",
+			userID: "@owner:example.com",
+			request: &filesv1.GetContentThumbnailRequest{
+				MediaId: "thumbnailMediaX",
+				Width:   32,
+				Height:  32,
+				Rotate:  45,
+			},
+			expectErr: connect.CodeInvalidArgument,
+		},
 	}
 
 	suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
@@ -1224,7 +1238,7 @@ func (suite *FileServerTestSuite) Test_FileServer_SearchMediaResultBounds() {
 					require.NoError(t, err)
 				}
 
-				require.NoError(t, handler.authz.GrantFileAccess(ctx, tc.otherOwner, "sharedMedia", tc.sharedUser, "viewer"))
+				require.NoError(t, handler.authz.GrantFileAccess(ctx, tc.otherOwner, "sharedMedia", authz.UserPrincipal(tc.sharedUser), "viewer", nil))
 
 				caseCtx := claimsCtx(ctx, tc.ownerID)
 				resp, err := handler.SearchMedia(caseCtx, connect.NewRequest(&filesv1.SearchMediaRequest{
@@ -1266,7 +1280,7 @@ func (suite *FileServerTestSuite) Test_FileServer_SearchMediaSharedEdgeCases() {
 		mediaService := business.NewMediaService(db, storageProvider)
 		authorizer := svc.SecurityManager().GetAuthorizer(ctx)
 		authzMiddleware := authz.NewMiddleware(authorizer, db)
-		handler := NewFileServer(svc, mediaService, authzMiddleware, db, storageProvider).(*FileServer)
+		handler := NewFileServer(svc, mediaService, authzMiddleware, db, storageProvider, nil).(*FileServer)
 
 		for _, tc := range testCases {
 			t.Run(tc.name, func(t *testing.T) {
@@ -1291,8 +1305,8 @@ func (suite *FileServerTestSuite) Test_FileServer_SearchMediaSharedEdgeCases() {
 					Config:        cfg,
 				})
 				require.NoError(t, err)
-				require.NoError(t, handler.authz.GrantFileAccess(ctx, "@other:example.com", "sharedHit001", "@owner:example.com", "viewer"))
-				require.NoError(t, handler.authz.GrantFileAccess(ctx, "@other:example.com", "sharedNoMatch001", "@owner:example.com", "viewer"))
+				require.NoError(t, handler.authz.GrantFileAccess(ctx, "@other:example.com", "sharedHit001", authz.UserPrincipal("@owner:example.com"), "viewer", nil))
+				require.NoError(t, handler.authz.GrantFileAccess(ctx, "@other:example.com", "sharedNoMatch001", authz.UserPrincipal("@owner:example.com"), "viewer", nil))
 
 				resp, err := handler.SearchMedia(claimsCtx(ctx, "@owner:example.com"), connect.NewRequest(&filesv1.SearchMediaRequest{
 					Query: tc.query,
@@ -1368,6 +1382,63 @@ func (suite *FileServerTestSuite) Test_FileServer_GetContentThumbnailSuccess() {
 	})
 }
 
+func (suite *FileServerTestSuite) Test_FileServer_GetContentThumbnailTransform() {
+	suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+		ctx, cfg, mediaService, handler := suite.setupFileServer(t, dep)
+		ctx = claimsCtx(ctx, "@owner:example.com")
+
+		payload := createJPEGPayload(t, 400, 200)
+		_, err := mediaService.UploadFile(ctx, &business.UploadRequest{
+			OwnerID:       "@owner:example.com",
+			MediaID:       "thumbTransform",
+			UploadName:    "image.jpg",
+			ContentType:   "image/jpeg",
+			FileSizeBytes: types.FileSizeBytes(len(payload)),
+			FileData:      bytes.NewReader(payload),
+			Config:        cfg,
+		})
+		require.NoError(t, err)
+
+		request := &filesv1.GetContentThumbnailRequest{
+			MediaId: "thumbTransform",
+			Width:   32,
+			Height:  64,
+			Method:  filesv1.ThumbnailMethod_CROP,
+			Rotate:  90,
+			Format:  filesv1.ImageFormat_IMAGE_FORMAT_WEBP,
+			Dpr:     2,
+		}
+		first, err := handler.GetContentThumbnail(ctx, connect.NewRequest(request))
+		require.NoError(t, err)
+		assert.Equal(t, "image/webp", first.Msg.ContentType)
+
+		img, format, err := image.Decode(bytes.NewReader(first.Msg.Content))
+		require.NoError(t, err)
+		assert.Equal(t, "webp", format)
+		assert.Equal(t, 64, img.Bounds().Dx())
+		assert.Equal(t, 128, img.Bounds().Dy())
+
+		// The derivative is stored, so a repeat request returns the same bytes.
+		second, err := handler.GetContentThumbnail(ctx, connect.NewRequest(protoCloneGetContentThumbnailRequest(request)))
+		require.NoError(t, err)
+		assert.Equal(t, first.Msg.Content, second.Msg.Content)
+
+		// Once the media holds the maximum, further transforms are refused.
+		cfg.MaxDerivativesPerMedia = 1
+		other := protoCloneGetContentThumbnailRequest(request)
+		other.Rotate = 180
+		_, err = handler.GetContentThumbnail(ctx, connect.NewRequest(other))
+		require.Error(t, err)
+		assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+		quality := protoCloneGetContentThumbnailRequest(request)
+		quality.Quality = 50
+		_, err = handler.GetContentThumbnail(ctx, connect.NewRequest(quality))
+		require.Error(t, err)
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "webp output is lossless")
+	})
+}
+
 func (suite *FileServerTestSuite) Test_FileServer_SearchQueryAndQueueHelpers() {
 	testCases := []struct {
 		name      string
@@ -1434,14 +1505,23 @@ func (suite *FileServerTestSuite) setupFileServer(
 	cfg := svc.Config().(*config.FilesConfig)
 
 	db := &connection.Database{
-		WorkManager:             svc.WorkManager(),
-		MediaRepository:         res.MediaRepository,
-		MultipartUploadRepo:     res.MultipartUploadRepo,
-		MultipartUploadPartRepo: res.MultipartUploadPartRepo,
-		FileVersionRepo:         res.FileVersionRepo,
-		RetentionPolicyRepo:     res.RetentionPolicyRepo,
-		FileRetentionRepo:       res.FileRetentionRepo,
-		StorageStatsRepo:        res.StorageStatsRepo,
+		WorkManager:               svc.WorkManager(),
+		MediaRepository:           res.MediaRepository,
+		MultipartUploadRepo:       res.MultipartUploadRepo,
+		MultipartUploadPartRepo:   res.MultipartUploadPartRepo,
+		FileVersionRepo:           res.FileVersionRepo,
+		RetentionPolicyRepo:       res.RetentionPolicyRepo,
+		FileRetentionRepo:         res.FileRetentionRepo,
+		StorageStatsRepo:          res.StorageStatsRepo,
+		WebhookSubscriptionRepo:   res.WebhookSubscriptionRepo,
+		WebhookDeliveryRepo:       res.WebhookDeliveryRepo,
+		ThumbnailJobRepo:          res.ThumbnailJobRepo,
+		ThumbnailRegenerationRepo: res.ThumbnailRegenerationRepo,
+		ArchiveExtractionRepo:     res.ArchiveExtractionRepo,
+		ShareLinkRepo:             res.ShareLinkRepo,
+		AccessGrantRepo:           res.AccessGrantRepo,
+		UserDataExportRepo:        res.UserDataExportRepo,
+		UserDataErasureRepo:       res.UserDataErasureRepo,
 	}
 
 	storageProvider, err := provider.GetStorageProvider(ctx, cfg)
@@ -1450,7 +1530,7 @@ func (suite *FileServerTestSuite) setupFileServer(
 	mediaService := business.NewMediaService(db, storageProvider)
 	authorizer := svc.SecurityManager().GetAuthorizer(ctx)
 	authzMiddleware := authz.NewMiddleware(authorizer, db)
-	handler := NewFileServer(svc, mediaService, authzMiddleware, db, storageProvider).(*FileServer)
+	handler := NewFileServer(svc, mediaService, authzMiddleware, db, storageProvider, nil).(*FileServer)
 
 	return ctx, cfg, mediaService, handler
 }
@@ -1989,6 +2069,101 @@ func (suite *FileServerTestSuite) Test_FileServer_GrantAccess() {
 				require.NoError(t, err)
 				assert.True(t, resp.Msg.GetSuccess())
 			})
+
+			t.Run("group", func(t *testing.T) {
+				authCtx := claimsCtx(ctx, ownerID)
+				grant := &filesv1.AccessGrant{}
+				grant.SetPrincipalId("group:reviewers")
+				grant.SetRole(filesv1.AccessRole_ACCESS_ROLE_WRITER)
+				resp, err := handler.GrantAccess(authCtx, connect.NewRequest(&filesv1.GrantAccessRequest{
+					MediaId: "grantfile01",
+					Grant:   grant,
+				}))
+				require.NoError(t, err)
+				assert.True(t, resp.Msg.GetSuccess())
+			})
+
+			t.Run("group_cannot_own", func(t *testing.T) {
+				authCtx := claimsCtx(ctx, ownerID)
+				grant := &filesv1.AccessGrant{}
+				grant.SetPrincipalId("reviewers")
+				grant.SetPrincipalType(filesv1.PrincipalType_PRINCIPAL_TYPE_GROUP)
+				grant.SetRole(filesv1.AccessRole_ACCESS_ROLE_OWNER)
+				_, err := handler.GrantAccess(authCtx, connect.NewRequest(&filesv1.GrantAccessRequest{
+					MediaId: "grantfile01",
+					Grant:   grant,
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+
+			t.Run("unsupported_principal_type", func(t *testing.T) {
+				authCtx := claimsCtx(ctx, ownerID)
+				grant := &filesv1.AccessGrant{}
+				grant.SetPrincipalId("service:billing")
+				grant.SetPrincipalType(filesv1.PrincipalType_PRINCIPAL_TYPE_SERVICE)
+				grant.SetRole(filesv1.AccessRole_ACCESS_ROLE_READER)
+				_, err := handler.GrantAccess(authCtx, connect.NewRequest(&filesv1.GrantAccessRequest{
+					MediaId: "grantfile01",
+					Grant:   grant,
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+
+			t.Run("partition_of_another_tenant", func(t *testing.T) {
+				authCtx := claimsCtx(ctx, ownerID)
+				grant := &filesv1.AccessGrant{}
+				grant.SetPrincipalId("tenancy:other-tenant/other-partition")
+				grant.SetRole(filesv1.AccessRole_ACCESS_ROLE_READER)
+				_, err := handler.GrantAccess(authCtx, connect.NewRequest(&filesv1.GrantAccessRequest{
+					MediaId: "grantfile01",
+					Grant:   grant,
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+			})
+
+			t.Run("expiry_in_past", func(t *testing.T) {
+				authCtx := claimsCtx(ctx, ownerID)
+				grant := &filesv1.AccessGrant{}
+				grant.SetPrincipalId("@contractor:example.com")
+				grant.SetRole(filesv1.AccessRole_ACCESS_ROLE_READER)
+				grant.SetExpiresAt(timestamppb.New(time.Now().Add(-time.Minute)))
+				_, err := handler.GrantAccess(authCtx, connect.NewRequest(&filesv1.GrantAccessRequest{
+					MediaId: "grantfile01",
+					Grant:   grant,
+				}))
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+
+			t.Run("expiry_listed", func(t *testing.T) {
+				authCtx := claimsCtx(ctx, ownerID)
+				expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+				grant := &filesv1.AccessGrant{}
+				grant.SetPrincipalId("@auditor:example.com")
+				grant.SetRole(filesv1.AccessRole_ACCESS_ROLE_READER)
+				grant.SetExpiresAt(timestamppb.New(expiresAt))
+				_, err := handler.GrantAccess(authCtx, connect.NewRequest(&filesv1.GrantAccessRequest{
+					MediaId: "grantfile01",
+					Grant:   grant,
+				}))
+				require.NoError(t, err)
+
+				resp, err := handler.ListAccess(authCtx, connect.NewRequest(&filesv1.ListAccessRequest{
+					MediaId: "grantfile01",
+				}))
+				require.NoError(t, err)
+				var listed *filesv1.AccessGrant
+				for _, g := range resp.Msg.GetGrants() {
+					if g.GetPrincipalId() == "@auditor:example.com" {
+						listed = g
+					}
+				}
+				require.NotNil(t, listed)
+				assert.True(t, expiresAt.Equal(listed.GetExpiresAt().AsTime()))
+				assert.Equal(t, ownerID, listed.GetGrantedBy())
+			})
 		})
 	})
 }
@@ -2097,141 +2272,1271 @@ func (suite *FileServerTestSuite) Test_FileServer_ListAccess() {
 	})
 }
 
-func (suite *FileServerTestSuite) Test_FileServer_PatchContent() {
+func (suite *FileServerTestSuite) Test_FileServer_FolderAccess() {
 	suite.Run("default", func() {
 		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
 			ctx, cfg, mediaService, handler := suite.setupFileServer(t, dep)
 
-			ownerID := "@patch-owner:example.com"
+			ownerID := "@folder-owner:example.com"
+			editorID := "@folder-editor:example.com"
 			_, err := mediaService.UploadFile(ctx, &business.UploadRequest{
 				OwnerID:       types.OwnerID(ownerID),
-				MediaID:       "patchfile01",
-				UploadName:    "original.txt",
+				MediaID:       "folderfile01",
+				UploadName:    "plan.txt",
 				ContentType:   "text/plain",
 				FileSizeBytes: 5,
 				FileData:      io.NopCloser(bytes.NewReader([]byte("hello"))),
 				Config:        cfg,
 			})
 			require.NoError(t, err)
+			ownerCtx := claimsCtx(ctx, ownerID)
 
-			t.Run("unauthenticated", func(t *testing.T) {
-				_, err := handler.PatchContent(t.Context(), connect.NewRequest(&filesv1.PatchContentRequest{
-					MediaId:  "patchfile01",
-					Filename: "renamed.txt",
+			folder := "shared/team"
+			_, err = handler.PatchContent(ownerCtx, connect.NewRequest(&filesv1.PatchContentRequest{
+				MediaId: "folderfile01",
+				Folder:  &folder,
+			}))
+			require.NoError(t, err)
+
+			_, err = handler.GrantAccess(ownerCtx, connect.NewRequest(&filesv1.GrantAccessRequest{
+				Folder: "/shared/",
+				Grant: &filesv1.AccessGrant{
+					PrincipalId: editorID,
+					Role:        filesv1.AccessRole_ACCESS_ROLE_WRITER,
+				},
+			}))
+			require.NoError(t, err)
+
+			t.Run("inherited_access", func(t *testing.T) {
+				require.NoError(t, handler.authz.CanEditFile(ctx, editorID, "folderfile01"))
+
+				resp, err := handler.ListAccess(ownerCtx, connect.NewRequest(&filesv1.ListAccessRequest{
+					MediaId: "folderfile01",
 				}))
-				require.Error(t, err)
-				assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+				require.NoError(t, err)
+				require.Len(t, resp.Msg.GetGrants(), 1)
+				assert.Equal(t, editorID, resp.Msg.GetGrants()[0].GetPrincipalId())
+				assert.Equal(t, "shared", resp.Msg.GetGrants()[0].GetInheritedFrom())
+
+				resp, err = handler.ListAccess(ownerCtx, connect.NewRequest(&filesv1.ListAccessRequest{
+					Folder: "shared",
+				}))
+				require.NoError(t, err)
+				require.Len(t, resp.Msg.GetGrants(), 1)
+				assert.Empty(t, resp.Msg.GetGrants()[0].GetInheritedFrom())
 			})
 
-			t.Run("invalid_media_id", func(t *testing.T) {
-				authCtx := claimsCtx(ctx, ownerID)
-				_, err := handler.PatchContent(authCtx, connect.NewRequest(&filesv1.PatchContentRequest{
-					MediaId:  "bad id",
-					Filename: "renamed.txt",
+			t.Run("only_owner_moves", func(t *testing.T) {
+				elsewhere := "private"
+				_, err := handler.PatchContent(claimsCtx(ctx, editorID), connect.NewRequest(&filesv1.PatchContentRequest{
+					MediaId: "folderfile01",
+					Folder:  &elsewhere,
 				}))
-				require.Error(t, err)
-				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+				assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
 			})
 
-			t.Run("rename_success", func(t *testing.T) {
-				authCtx := claimsCtx(ctx, ownerID)
-				resp, err := handler.PatchContent(authCtx, connect.NewRequest(&filesv1.PatchContentRequest{
-					MediaId:  "patchfile01",
-					Filename: "renamed.txt",
+			t.Run("media_or_folder", func(t *testing.T) {
+				_, err := handler.ListAccess(ownerCtx, connect.NewRequest(&filesv1.ListAccessRequest{
+					MediaId: "folderfile01",
+					Folder:  "shared",
 				}))
-				require.NoError(t, err)
-				require.NotNil(t, resp.Msg.GetMetadata())
-				assert.Equal(t, "renamed.txt", resp.Msg.GetMetadata().GetFilename())
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+				_, err = handler.RevokeAccess(ownerCtx, connect.NewRequest(&filesv1.RevokeAccessRequest{
+					Folder:      "/",
+					PrincipalId: editorID,
+				}))
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
 			})
 
-			t.Run("change_visibility", func(t *testing.T) {
-				authCtx := claimsCtx(ctx, ownerID)
-				resp, err := handler.PatchContent(authCtx, connect.NewRequest(&filesv1.PatchContentRequest{
-					MediaId:    "patchfile01",
-					Visibility: filesv1.MediaMetadata_VISIBILITY_PUBLIC,
+			t.Run("moving_out_drops_access", func(t *testing.T) {
+				elsewhere := "private"
+				_, err := handler.PatchContent(ownerCtx, connect.NewRequest(&filesv1.PatchContentRequest{
+					MediaId: "folderfile01",
+					Folder:  &elsewhere,
 				}))
 				require.NoError(t, err)
-				require.NotNil(t, resp.Msg.GetMetadata())
-				assert.Equal(t, filesv1.MediaMetadata_VISIBILITY_PUBLIC, resp.Msg.GetMetadata().GetVisibility())
+				require.Error(t, handler.authz.CanEditFile(ctx, editorID, "folderfile01"))
 			})
 		})
 	})
 }
 
-func (suite *FileServerTestSuite) Test_FileServer_FinalizeSignedUpload() {
+func (suite *FileServerTestSuite) Test_FileServer_ListSharedWithMe() {
 	suite.Run("default", func() {
 		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
 			ctx, cfg, mediaService, handler := suite.setupFileServer(t, dep)
 
-			ownerID := "@finalise-owner:example.com"
-			_, err := mediaService.UploadFile(ctx, &business.UploadRequest{
-				OwnerID:       types.OwnerID(ownerID),
-				MediaID:       "finalfile01",
-				UploadName:    "final.txt",
+			aliceID := "@sharer-alice:example.com"
+			bobID := "@sharer-bob:example.com"
+			recipientID := "@share-recipient:example.com"
+			uploads := []struct {
+				owner       string
+				mediaID     string
+				contentType string
+				role        filesv1.AccessRole
+			}{
+				{aliceID, "sharedwithme01", "text/plain", filesv1.AccessRole_ACCESS_ROLE_READER},
+				{aliceID, "sharedwithme02", "image/png", filesv1.AccessRole_ACCESS_ROLE_READER},
+				{bobID, "sharedwithme03", "image/jpeg", filesv1.AccessRole_ACCESS_ROLE_WRITER},
+			}
+			for _, u := range uploads {
+				_, err := mediaService.UploadFile(ctx, &business.UploadRequest{
+					OwnerID:       types.OwnerID(u.owner),
+					MediaID:       types.MediaID(u.mediaID),
+					UploadName:    types.Filename(u.mediaID),
+					ContentType:   types.ContentType(u.contentType),
+					FileSizeBytes: 5,
+					FileData:      io.NopCloser(bytes.NewReader([]byte("hello"))),
+					Config:        cfg,
+				})
+				require.NoError(t, err)
+				_, err = handler.GrantAccess(claimsCtx(ctx, u.owner), connect.NewRequest(&filesv1.GrantAccessRequest{
+					MediaId: u.mediaID,
+					Grant:   &filesv1.AccessGrant{PrincipalId: recipientID, Role: u.role},
+				}))
+				require.NoError(t, err)
+			}
+			// A second, stronger grant on the same file is listed in place of the first.
+			_, err := handler.GrantAccess(claimsCtx(ctx, aliceID), connect.NewRequest(&filesv1.GrantAccessRequest{
+				MediaId: "sharedwithme01",
+				Grant:   &filesv1.AccessGrant{PrincipalId: recipientID, Role: filesv1.AccessRole_ACCESS_ROLE_WRITER},
+			}))
+			require.NoError(t, err)
+			// A file of a shared folder's subfolder is shared through the folder.
+			_, err = mediaService.UploadFile(ctx, &business.UploadRequest{
+				OwnerID:       types.OwnerID(bobID),
+				MediaID:       "sharedwithme04",
+				UploadName:    "sharedwithme04",
 				ContentType:   "text/plain",
 				FileSizeBytes: 5,
 				FileData:      io.NopCloser(bytes.NewReader([]byte("hello"))),
 				Config:        cfg,
 			})
 			require.NoError(t, err)
+			folder := "projects/q1"
+			_, err = handler.PatchContent(claimsCtx(ctx, bobID), connect.NewRequest(&filesv1.PatchContentRequest{
+				MediaId: "sharedwithme04",
+				Folder:  &folder,
+			}))
+			require.NoError(t, err)
+			_, err = handler.GrantAccess(claimsCtx(ctx, bobID), connect.NewRequest(&filesv1.GrantAccessRequest{
+				Folder: "projects",
+				Grant:  &filesv1.AccessGrant{PrincipalId: recipientID, Role: filesv1.AccessRole_ACCESS_ROLE_READER},
+			}))
+			require.NoError(t, err)
+			recipientCtx := claimsCtx(ctx, recipientID)
 
-			t.Run("unauthenticated", func(t *testing.T) {
-				_, err := handler.FinalizeSignedUpload(t.Context(), connect.NewRequest(&filesv1.FinalizeSignedUploadRequest{
-					MediaId: "finalfile01",
+			t.Run("lists_shares", func(t *testing.T) {
+				resp, err := handler.ListSharedWithMe(recipientCtx, connect.NewRequest(&filesv1.ListSharedWithMeRequest{}))
+				require.NoError(t, err)
+				require.Len(t, resp.Msg.GetItems(), 4)
+				assert.Nil(t, resp.Msg.GetNextCursor())
+				for _, item := range resp.Msg.GetItems() {
+					assert.Equal(t, recipientID, item.GetGrant().GetPrincipalId())
+					assert.NotNil(t, item.GetGrant().GetGrantedAt())
+					if item.GetMedia().GetMediaId() == "sharedwithme01" {
+						assert.Equal(t, filesv1.AccessRole_ACCESS_ROLE_WRITER, item.GetGrant().GetRole())
+						assert.Equal(t, aliceID, item.GetGrant().GetGrantedBy())
+					}
+					if item.GetMedia().GetMediaId() == "sharedwithme04" {
+						assert.Equal(t, "projects", item.GetGrant().GetInheritedFrom())
+						assert.Equal(t, bobID, item.GetGrant().GetGrantedBy())
+					}
+				}
+			})
+
+			t.Run("filters", func(t *testing.T) {
+				resp, err := handler.ListSharedWithMe(recipientCtx, connect.NewRequest(&filesv1.ListSharedWithMeRequest{
+					SharedBy: aliceID,
 				}))
-				require.Error(t, err)
-				assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+				require.NoError(t, err)
+				require.Len(t, resp.Msg.GetItems(), 2)
+
+				resp, err = handler.ListSharedWithMe(recipientCtx, connect.NewRequest(&filesv1.ListSharedWithMeRequest{
+					ContentType: "image/",
+				}))
+				require.NoError(t, err)
+				require.Len(t, resp.Msg.GetItems(), 2)
+				for _, item := range resp.Msg.GetItems() {
+					assert.True(t, strings.HasPrefix(item.GetMedia().GetContentType(), "image/"))
+				}
 			})
 
-			t.Run("invalid_media_id", func(t *testing.T) {
-				authCtx := claimsCtx(ctx, ownerID)
-				_, err := handler.FinalizeSignedUpload(authCtx, connect.NewRequest(&filesv1.FinalizeSignedUploadRequest{
-					MediaId: "bad id",
+			t.Run("paginates", func(t *testing.T) {
+				seen := map[string]struct{}{}
+				cursor := &commonv1.PageCursor{Limit: 1}
+				for cursor != nil {
+					resp, err := handler.ListSharedWithMe(recipientCtx, connect.NewRequest(&filesv1.ListSharedWithMeRequest{
+						Cursor: cursor,
+					}))
+					require.NoError(t, err)
+					require.Len(t, resp.Msg.GetItems(), 1)
+					seen[resp.Msg.GetItems()[0].GetMedia().GetMediaId()] = struct{}{}
+					cursor = resp.Msg.GetNextCursor()
+				}
+				assert.Len(t, seen, 4)
+			})
+
+			t.Run("invalid_cursor", func(t *testing.T) {
+				_, err := handler.ListSharedWithMe(recipientCtx, connect.NewRequest(&filesv1.ListSharedWithMeRequest{
+					Cursor: &commonv1.PageCursor{Limit: 1, Page: "not-a-cursor"},
 				}))
-				require.Error(t, err)
 				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
 			})
 
-			t.Run("success", func(t *testing.T) {
-				authCtx := claimsCtx(ctx, ownerID)
-				resp, err := handler.FinalizeSignedUpload(authCtx, connect.NewRequest(&filesv1.FinalizeSignedUploadRequest{
-					MediaId:        "finalfile01",
-					ChecksumSha256: "abc123checksum",
-					SizeBytes:      5,
+			t.Run("own_files_not_listed", func(t *testing.T) {
+				resp, err := handler.ListSharedWithMe(claimsCtx(ctx, aliceID), connect.NewRequest(&filesv1.ListSharedWithMeRequest{}))
+				require.NoError(t, err)
+				assert.Empty(t, resp.Msg.GetItems())
+			})
+
+			t.Run("revoking_a_listed_share_keeps_the_cursor", func(t *testing.T) {
+				resp, err := handler.ListSharedWithMe(recipientCtx, connect.NewRequest(&filesv1.ListSharedWithMeRequest{
+					Cursor: &commonv1.PageCursor{Limit: 2},
 				}))
 				require.NoError(t, err)
-				require.NotNil(t, resp.Msg.GetMetadata())
+				require.Len(t, resp.Msg.GetItems(), 2)
+				require.Equal(t, "sharedwithme04", resp.Msg.GetItems()[0].GetMedia().GetMediaId())
+				seen := map[string]struct{}{}
+				for _, item := range resp.Msg.GetItems() {
+					seen[item.GetMedia().GetMediaId()] = struct{}{}
+				}
+
+				_, err = handler.RevokeAccess(claimsCtx(ctx, bobID), connect.NewRequest(&filesv1.RevokeAccessRequest{
+					Folder:      "projects",
+					PrincipalId: recipientID,
+				}))
+				require.NoError(t, err)
+
+				resp, err = handler.ListSharedWithMe(recipientCtx, connect.NewRequest(&filesv1.ListSharedWithMeRequest{
+					Cursor: resp.Msg.GetNextCursor(),
+				}))
+				require.NoError(t, err)
+				for _, item := range resp.Msg.GetItems() {
+					seen[item.GetMedia().GetMediaId()] = struct{}{}
+				}
+				assert.Len(t, seen, 4)
 			})
 		})
 	})
 }
 
-func (suite *FileServerTestSuite) Test_FileServer_DownloadContent_Validation() {
+func (suite *FileServerTestSuite) Test_FileServer_TransferOwnership() {
 	suite.Run("default", func() {
 		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
-			ctx, _, _, handler := suite.setupFileServer(t, dep)
+			ctx, cfg, mediaService, handler := suite.setupFileServer(t, dep)
 
-			t.Run("unauthenticated", func(t *testing.T) {
-				err := handler.DownloadContent(t.Context(), connect.NewRequest(&filesv1.DownloadContentRequest{
-					MediaId: "dlfile01",
-				}), nil)
-				require.Error(t, err)
-				assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+			leaverID := "@transfer-leaver:example.com"
+			colleagueID := "@transfer-colleague:example.com"
+			readerID := "@transfer-reader:example.com"
+			leaverCtx := claimsCtx(ctx, leaverID)
+			authorizer := handler.Service.SecurityManager().GetAuthorizer(ctx)
+			for _, profileID := range []string{colleagueID, readerID} {
+				require.NoError(t, authorizer.WriteTuple(ctx, authz.BuildAccessTuple("transfer-tenant/transfer-partition", profileID)))
+			}
+			for _, mediaID := range []string{"transferfile01", "transferfile02", "transferfile03"} {
+				_, err := mediaService.UploadFile(ctx, &business.UploadRequest{
+					OwnerID:       types.OwnerID(leaverID),
+					MediaID:       types.MediaID(mediaID),
+					UploadName:    "notes.txt",
+					ContentType:   "text/plain",
+					FileSizeBytes: 5,
+					FileData:      io.NopCloser(bytes.NewReader([]byte("hello"))),
+					Config:        cfg,
+				})
+				require.NoError(t, err)
+			}
+			folder := "handover/docs"
+			for _, mediaID := range []string{"transferfile01", "transferfile02"} {
+				_, err := handler.PatchContent(leaverCtx, connect.NewRequest(&filesv1.PatchContentRequest{
+					MediaId: mediaID,
+					Folder:  &folder,
+				}))
+				require.NoError(t, err)
+			}
+			_, err := handler.GrantAccess(leaverCtx, connect.NewRequest(&filesv1.GrantAccessRequest{
+				MediaId: "transferfile01",
+				Grant:   &filesv1.AccessGrant{PrincipalId: readerID, Role: filesv1.AccessRole_ACCESS_ROLE_READER},
+			}))
+			require.NoError(t, err)
+
+			t.Run("target_required", func(t *testing.T) {
+				_, err := handler.TransferOwnership(leaverCtx, connect.NewRequest(&filesv1.TransferOwnershipRequest{
+					MediaId:    "transferfile01",
+					AllFiles:   true,
+					NewOwnerId: colleagueID,
+				}))
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
 			})
 
-			t.Run("invalid_media_id", func(t *testing.T) {
-				authCtx := claimsCtx(ctx, "@dl-owner:example.com")
-				err := handler.DownloadContent(authCtx, connect.NewRequest(&filesv1.DownloadContentRequest{
-					MediaId: "bad id",
-				}), nil)
-				require.Error(t, err)
+			t.Run("new_owner_must_be_member", func(t *testing.T) {
+				_, err := handler.TransferOwnership(leaverCtx, connect.NewRequest(&filesv1.TransferOwnershipRequest{
+					MediaId:    "transferfile01",
+					NewOwnerId: "@transfer-stranger:example.com",
+				}))
 				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
 			})
+
+			t.Run("capped", func(t *testing.T) {
+				maxFiles := cfg.OwnershipTransferMaxFiles
+				cfg.OwnershipTransferMaxFiles = 1
+				defer func() { cfg.OwnershipTransferMaxFiles = maxFiles }()
+
+				_, err := handler.TransferOwnership(leaverCtx, connect.NewRequest(&filesv1.TransferOwnershipRequest{
+					Folder:     "handover",
+					NewOwnerId: colleagueID,
+				}))
+				assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+				metadata, err := handler.db.GetMediaMetadata(ctx, "transferfile01")
+				require.NoError(t, err)
+				assert.Equal(t, types.OwnerID(leaverID), metadata.OwnerID)
+			})
+
+			t.Run("only_owner_transfers", func(t *testing.T) {
+				_, err := handler.TransferOwnership(claimsCtx(ctx, readerID), connect.NewRequest(&filesv1.TransferOwnershipRequest{
+					MediaId:    "transferfile01",
+					NewOwnerId: readerID,
+				}))
+				assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+			})
+
+			t.Run("folder_tree", func(t *testing.T) {
+				resp, err := handler.TransferOwnership(leaverCtx, connect.NewRequest(&filesv1.TransferOwnershipRequest{
+					Folder:            "handover",
+					NewOwnerId:        colleagueID,
+					PreviousOwnerRole: filesv1.AccessRole_ACCESS_ROLE_READER,
+				}))
+				require.NoError(t, err)
+				assert.Equal(t, int64(2), resp.Msg.GetTransferredFiles())
+				assert.Equal(t, int64(1), resp.Msg.GetPreviousOwnerUsage().GetTotalFiles())
+				assert.Equal(t, int64(2), resp.Msg.GetNewOwnerUsage().GetTotalFiles())
+
+				for _, mediaID := range []string{"transferfile01", "transferfile02"} {
+					metadata, err := handler.db.GetMediaMetadata(ctx, types.MediaID(mediaID))
+					require.NoError(t, err)
+					assert.Equal(t, types.OwnerID(colleagueID), metadata.OwnerID)
+					require.NoError(t, handler.authz.CanDeleteFile(ctx, colleagueID, mediaID))
+					require.NoError(t, handler.authz.CanViewFile(ctx, leaverID, mediaID))
+					require.Error(t, handler.authz.CanEditFile(ctx, leaverID, mediaID))
+				}
+				// Grants on the file itself carry over.
+				require.NoError(t, handler.authz.CanViewFile(ctx, readerID, "transferfile01"))
+
+				// The files now inherit from the new owner's folders.
+				_, err = handler.GrantAccess(claimsCtx(ctx, colleagueID), connect.NewRequest(&filesv1.GrantAccessRequest{
+					Folder: "handover",
+					Grant:  &filesv1.AccessGrant{PrincipalId: readerID, Role: filesv1.AccessRole_ACCESS_ROLE_WRITER},
+				}))
+				require.NoError(t, err)
+				require.NoError(t, handler.authz.CanEditFile(ctx, readerID, "transferfile02"))
+			})
+
+			t.Run("all_files", func(t *testing.T) {
+				resp, err := handler.TransferOwnership(leaverCtx, connect.NewRequest(&filesv1.TransferOwnershipRequest{
+					AllFiles:   true,
+					NewOwnerId: colleagueID,
+				}))
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), resp.Msg.GetTransferredFiles())
+				assert.Equal(t, int64(0), resp.Msg.GetPreviousOwnerUsage().GetTotalFiles())
+				require.Error(t, handler.authz.CanViewFile(ctx, leaverID, "transferfile03"))
+			})
 		})
 	})
 }
 
-func (suite *FileServerTestSuite) Test_FileServer_DownloadContentRange_Validation() {
+func (suite *FileServerTestSuite) Test_FileServer_TenantAdmin() {
+	suite.Run("default", func() {
+		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+			ctx, cfg, mediaService, handler := suite.setupFileServer(t, dep)
+
+			ownerID := "@tenant-admin-owner:example.com"
+			_, err := mediaService.UploadFile(ctx, &business.UploadRequest{
+				OwnerID:       types.OwnerID(ownerID),
+				MediaID:       "tenantadminfile01",
+				UploadName:    "notes.txt",
+				ContentType:   "text/plain",
+				FileSizeBytes: 5,
+				FileData:      io.NopCloser(bytes.NewReader([]byte("hello"))),
+				Config:        cfg,
+			})
+			require.NoError(t, err)
+			ownerCtx := claimsCtx(ctx, ownerID)
+
+			t.Run("reason_required", func(t *testing.T) {
+				_, err := handler.AdminGetMedia(ownerCtx, connect.NewRequest(&filesv1.AdminGetMediaRequest{
+					MediaId: "tenantadminfile01",
+				}))
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+
+			t.Run("owners_are_not_admins", func(t *testing.T) {
+				_, err := handler.AdminDeleteContent(ownerCtx, connect.NewRequest(&filesv1.AdminDeleteContentRequest{
+					MediaId: "tenantadminfile01",
+					Reason:  "cleanup",
+				}))
+				assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+				_, err = handler.AdminListMedia(ownerCtx, connect.NewRequest(&filesv1.AdminListMediaRequest{
+					Reason: "audit",
+				}))
+				assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+				metadata, err := handler.db.GetMediaMetadata(ctx, "tenantadminfile01")
+				require.NoError(t, err)
+				assert.NotNil(t, metadata)
+			})
+
+			t.Run("retained_media_is_kept", func(t *testing.T) {
+				retStore := handler.db.(retentionStore)
+				past := time.Now().Add(-time.Hour)
+				future := time.Now().Add(time.Hour)
+				for _, tc := range []struct {
+					name      string
+					expiresAt *time.Time
+					isLocked  bool
+					want      connect.Code
+				}{
+					{name: "legal_hold", expiresAt: &past, isLocked: true, want: connect.CodeFailedPrecondition},
+					{name: "unexpired", expiresAt: &future, want: connect.CodeFailedPrecondition},
+					{name: "indefinite", want: connect.CodeFailedPrecondition},
+					{name: "expired", expiresAt: &past},
+				} {
+					t.Run(tc.name, func(t *testing.T) {
+						require.NoError(t, retStore.RemoveRetention(ctx, "tenantadminfile01"))
+						require.NoError(t, retStore.ApplyRetention(ctx, fileRetentionRequest{
+							mediaID:   "tenantadminfile01",
+							policyID:  "tenant-admin-" + tc.name,
+							expiresAt: tc.expiresAt,
+							isLocked:  tc.isLocked,
+						}))
+						err := handler.checkRetentionHold(ctx, "tenantadminfile01")
+						if tc.want == 0 {
+							require.NoError(t, err)
+							return
+						}
+						assert.Equal(t, tc.want, connect.CodeOf(err))
+					})
+				}
+			})
+		})
+	})
+}
+
+func (suite *FileServerTestSuite) Test_FileServer_PatchContent() {
+	suite.Run("default", func() {
+		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+			ctx, cfg, mediaService, handler := suite.setupFileServer(t, dep)
+
+			ownerID := "@patch-owner:example.com"
+			_, err := mediaService.UploadFile(ctx, &business.UploadRequest{
+				OwnerID:       types.OwnerID(ownerID),
+				MediaID:       "patchfile01",
+				UploadName:    "original.txt",
+				ContentType:   "text/plain",
+				FileSizeBytes: 5,
+				FileData:      io.NopCloser(bytes.NewReader([]byte("hello"))),
+				Config:        cfg,
+			})
+			require.NoError(t, err)
+
+			t.Run("unauthenticated", func(t *testing.T) {
+				_, err := handler.PatchContent(t.Context(), connect.NewRequest(&filesv1.PatchContentRequest{
+					MediaId:  "patchfile01",
+					Filename: "renamed.txt",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+			})
+
+			t.Run("invalid_media_id", func(t *testing.T) {
+				authCtx := claimsCtx(ctx, ownerID)
+				_, err := handler.PatchContent(authCtx, connect.NewRequest(&filesv1.PatchContentRequest{
+					MediaId:  "bad id",
+					Filename: "renamed.txt",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+
+			t.Run("rename_success", func(t *testing.T) {
+				authCtx := claimsCtx(ctx, ownerID)
+				resp, err := handler.PatchContent(authCtx, connect.NewRequest(&filesv1.PatchContentRequest{
+					MediaId:  "patchfile01",
+					Filename: "renamed.txt",
+				}))
+				require.NoError(t, err)
+				require.NotNil(t, resp.Msg.GetMetadata())
+				assert.Equal(t, "renamed.txt", resp.Msg.GetMetadata().GetFilename())
+			})
+
+			t.Run("change_visibility", func(t *testing.T) {
+				authCtx := claimsCtx(ctx, ownerID)
+				resp, err := handler.PatchContent(authCtx, connect.NewRequest(&filesv1.PatchContentRequest{
+					MediaId:    "patchfile01",
+					Visibility: filesv1.MediaMetadata_VISIBILITY_PUBLIC,
+				}))
+				require.NoError(t, err)
+				require.NotNil(t, resp.Msg.GetMetadata())
+				assert.Equal(t, filesv1.MediaMetadata_VISIBILITY_PUBLIC, resp.Msg.GetMetadata().GetVisibility())
+			})
+
+			t.Run("move_folder", func(t *testing.T) {
+				authCtx := claimsCtx(ctx, ownerID)
+				folder := "/invoices/2026/"
+				resp, err := handler.PatchContent(authCtx, connect.NewRequest(&filesv1.PatchContentRequest{
+					MediaId: "patchfile01",
+					Folder:  &folder,
+				}))
+				require.NoError(t, err)
+				assert.Equal(t, "invoices/2026", resp.Msg.GetMetadata().GetFolder())
+
+				escape := "invoices/../../etc"
+				_, err = handler.PatchContent(authCtx, connect.NewRequest(&filesv1.PatchContentRequest{
+					MediaId: "patchfile01",
+					Folder:  &escape,
+				}))
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+		})
+	})
+}
+
+func (suite *FileServerTestSuite) Test_FileServer_ThumbnailStatus() {
+	suite.Run("default", func() {
+		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+			ctx, _, _, handler := suite.setupFileServer(t, dep)
+			ownerID := "@thumb-owner:example.com"
+			authCtx := claimsCtx(ctx, ownerID)
+
+			require.NoError(t, handler.db.StoreMediaMetadata(ctx, &types.MediaMetadata{
+				MediaID:       "thumb-status-image",
+				OwnerID:       types.OwnerID(ownerID),
+				UploadName:    "img.jpg",
+				ContentType:   "image/jpeg",
+				Base64Hash:    "thumbhash1",
+				FileSizeBytes: 10,
+				ServerName:    "service_file",
+			}))
+			require.NoError(t, handler.db.StoreMediaMetadata(ctx, &types.MediaMetadata{
+				MediaID:       "thumb-status-text",
+				OwnerID:       types.OwnerID(ownerID),
+				UploadName:    "doc.txt",
+				ContentType:   "text/plain",
+				Base64Hash:    "thumbhash2",
+				FileSizeBytes: 10,
+				ServerName:    "service_file",
+			}))
+
+			t.Run("no_job_recorded", func(t *testing.T) {
+				_, err := handler.GetThumbnailStatus(authCtx, connect.NewRequest(&filesv1.GetThumbnailStatusRequest{
+					MediaId: "thumb-status-image",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+			})
+
+			t.Run("requeue_non_image_rejected", func(t *testing.T) {
+				_, err := handler.RequeueThumbnail(authCtx, connect.NewRequest(&filesv1.RequeueThumbnailRequest{
+					MediaId: "thumb-status-text",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+			})
+
+			t.Run("requeue_resets_job", func(t *testing.T) {
+				require.NoError(t, handler.db.StoreThumbnailJob(ctx, &types.ThumbnailJob{
+					MediaID:   "thumb-status-image",
+					State:     types.ThumbnailJobDeadLetter,
+					Attempts:  5,
+					LastError: "decode failed",
+				}))
+
+				status, err := handler.GetThumbnailStatus(authCtx, connect.NewRequest(&filesv1.GetThumbnailStatusRequest{
+					MediaId: "thumb-status-image",
+				}))
+				require.NoError(t, err)
+				assert.Equal(t, filesv1.ThumbnailJobState_THUMBNAIL_JOB_STATE_DEAD_LETTER, status.Msg.GetStatus().GetState())
+				assert.Equal(t, "decode failed", status.Msg.GetStatus().GetLastError())
+
+				requeued, err := handler.RequeueThumbnail(authCtx, connect.NewRequest(&filesv1.RequeueThumbnailRequest{
+					MediaId: "thumb-status-image",
+				}))
+				require.NoError(t, err)
+				assert.Equal(t, filesv1.ThumbnailJobState_THUMBNAIL_JOB_STATE_PENDING, requeued.Msg.GetStatus().GetState())
+				assert.Zero(t, requeued.Msg.GetStatus().GetAttempts())
+				assert.Empty(t, requeued.Msg.GetStatus().GetLastError())
+				assert.NotNil(t, requeued.Msg.GetStatus().GetNextAttemptAt())
+			})
+		})
+	})
+}
+
+func (suite *FileServerTestSuite) Test_FileServer_CachedMetadataInvalidation() {
+	suite.Run("default", func() {
+		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+			ctx, _, _, handler := suite.setupFileServer(t, dep)
+			handler.cache = cache.NewManager(nil, nil)
+			ownerID := "@cache-owner:example.com"
+			authCtx := claimsCtx(ctx, ownerID)
+
+			require.NoError(t, handler.db.StoreMediaMetadata(ctx, &types.MediaMetadata{
+				MediaID:       "cached-media",
+				OwnerID:       types.OwnerID(ownerID),
+				UploadName:    "before.txt",
+				ContentType:   "text/plain",
+				Base64Hash:    "cachehash1",
+				FileSizeBytes: 10,
+				ServerName:    "service_file",
+			}))
+
+			head := func() *filesv1.MediaMetadata {
+				resp, err := handler.HeadContent(authCtx, connect.NewRequest(&filesv1.HeadContentRequest{MediaId: "cached-media"}))
+				require.NoError(t, err)
+				return resp.Msg.GetMetadata()
+			}
+			assert.Equal(t, "before.txt", head().GetFilename())
+			_, found := handler.cache.GetMetadata(authCtx, "cached-media")
+			require.True(t, found)
+
+			_, err := handler.PatchContent(authCtx, connect.NewRequest(&filesv1.PatchContentRequest{
+				MediaId:  "cached-media",
+				Filename: "after.txt",
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, "after.txt", head().GetFilename())
+
+			_, err = handler.DeleteContent(authCtx, connect.NewRequest(&filesv1.DeleteContentRequest{MediaId: "cached-media"}))
+			require.NoError(t, err)
+			_, found = handler.cache.GetMetadata(authCtx, "cached-media")
+			assert.False(t, found)
+		})
+	})
+}
+
+func (suite *FileServerTestSuite) Test_FileServer_ThumbnailRegeneration() {
+	suite.Run("default", func() {
+		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+			ctx, _, _, handler := suite.setupFileServer(t, dep)
+			authCtx := claimsCtx(ctx, "@regen-admin:example.com")
+
+			t.Run("non_image_type_rejected", func(t *testing.T) {
+				_, err := handler.RegenerateThumbnails(authCtx, connect.NewRequest(&filesv1.RegenerateThumbnailsRequest{
+					ContentType: "video/mp4",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+
+			t.Run("unknown_run", func(t *testing.T) {
+				_, err := handler.GetThumbnailRegeneration(authCtx, connect.NewRequest(&filesv1.GetThumbnailRegenerationRequest{
+					RunId: "missing-run",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+				_, err = handler.RegenerateThumbnails(authCtx, connect.NewRequest(&filesv1.RegenerateThumbnailsRequest{
+					ResumeRunId: "missing-run",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+			})
+
+			t.Run("reports_progress", func(t *testing.T) {
+				run := &types.ThumbnailRegeneration{
+					Filter:        types.ThumbnailRegenerationFilter{OwnerID: "owner", ContentType: "image/"},
+					RemoveStale:   true,
+					RatePerSecond: 5,
+					State:         types.ThumbnailRegenerationCompleted,
+					Checkpoint:    "media-9",
+					Scanned:       9,
+					Enqueued:      4,
+				}
+				require.NoError(t, handler.db.StoreThumbnailRegeneration(ctx, run))
+
+				resp, err := handler.GetThumbnailRegeneration(authCtx, connect.NewRequest(&filesv1.GetThumbnailRegenerationRequest{
+					RunId: run.ID,
+				}))
+				require.NoError(t, err)
+				got := resp.Msg.GetRun()
+				assert.Equal(t, filesv1.ThumbnailRegenerationState_THUMBNAIL_REGENERATION_STATE_COMPLETED, got.GetState())
+				assert.Equal(t, "owner", got.GetOwnerId())
+				assert.Equal(t, "image/", got.GetContentType())
+				assert.True(t, got.GetRemoveStaleSizes())
+				assert.Equal(t, int64(9), got.GetScanned())
+				assert.Equal(t, int64(4), got.GetEnqueued())
+				assert.Equal(t, "media-9", got.GetCheckpoint())
+
+				// A completed run cannot be resumed.
+				_, err = handler.RegenerateThumbnails(authCtx, connect.NewRequest(&filesv1.RegenerateThumbnailsRequest{
+					ResumeRunId: run.ID,
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+			})
+
+			t.Run("live_run_not_resumed", func(t *testing.T) {
+				leaseUntil := time.Now().UTC().Add(time.Minute)
+				run := &types.ThumbnailRegeneration{
+					Filter:     types.ThumbnailRegenerationFilter{ContentType: "image/"},
+					State:      types.ThumbnailRegenerationRunning,
+					LeaseUntil: &leaseUntil,
+				}
+				require.NoError(t, handler.db.StoreThumbnailRegeneration(ctx, run))
+
+				_, err := handler.RegenerateThumbnails(authCtx, connect.NewRequest(&filesv1.RegenerateThumbnailsRequest{
+					ResumeRunId: run.ID,
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+			})
+		})
+	})
+}
+func (suite *FileServerTestSuite) Test_FileServer_WebhookSubscriptions() {
+	suite.Run("default", func() {
+		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+			ctx, _, _, handler := suite.setupFileServer(t, dep)
+			ownerID := "@webhook-owner:example.com"
+			authCtx := claimsCtx(ctx, ownerID)
+
+			t.Run("unauthenticated", func(t *testing.T) {
+				_, err := handler.CreateWebhookSubscription(t.Context(), connect.NewRequest(&filesv1.CreateWebhookSubscriptionRequest{
+					Url: "https://203.0.113.10/hooks",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+			})
+
+			t.Run("private_endpoint_rejected", func(t *testing.T) {
+				_, err := handler.CreateWebhookSubscription(authCtx, connect.NewRequest(&filesv1.CreateWebhookSubscriptionRequest{
+					Url: "http://127.0.0.1:8080/hooks",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+
+			var subscriptionID string
+			t.Run("create_list_and_deliveries", func(t *testing.T) {
+				created, err := handler.CreateWebhookSubscription(authCtx, connect.NewRequest(&filesv1.CreateWebhookSubscriptionRequest{
+					Url:         "https://203.0.113.10/hooks",
+					EventTypes:  []filesv1.LifecycleEventType{filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_UPLOADED},
+					Description: "uploads",
+				}))
+				require.NoError(t, err)
+				subscriptionID = created.Msg.GetSubscription().GetSubscriptionId()
+				require.NotEmpty(t, subscriptionID)
+				assert.True(t, strings.HasPrefix(created.Msg.GetSigningSecret(), "whsec_"))
+				assert.True(t, created.Msg.GetSubscription().GetActive())
+				assert.Equal(t, []filesv1.LifecycleEventType{filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_UPLOADED},
+					created.Msg.GetSubscription().GetEventTypes())
+
+				listed, err := handler.ListWebhookSubscriptions(authCtx, connect.NewRequest(&filesv1.ListWebhookSubscriptionsRequest{}))
+				require.NoError(t, err)
+				found := false
+				for _, sub := range listed.Msg.GetSubscriptions() {
+					if sub.GetSubscriptionId() == subscriptionID {
+						found = true
+					}
+				}
+				assert.True(t, found)
+
+				deliveries, err := handler.ListWebhookDeliveries(authCtx, connect.NewRequest(&filesv1.ListWebhookDeliveriesRequest{
+					SubscriptionId: subscriptionID,
+				}))
+				require.NoError(t, err)
+				assert.Empty(t, deliveries.Msg.GetDeliveries())
+			})
+
+			t.Run("delete", func(t *testing.T) {
+				resp, err := handler.DeleteWebhookSubscription(authCtx, connect.NewRequest(&filesv1.DeleteWebhookSubscriptionRequest{
+					SubscriptionId: subscriptionID,
+				}))
+				require.NoError(t, err)
+				assert.True(t, resp.Msg.GetSuccess())
+
+				_, err = handler.DeleteWebhookSubscription(authCtx, connect.NewRequest(&filesv1.DeleteWebhookSubscriptionRequest{
+					SubscriptionId: subscriptionID,
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+			})
+		})
+	})
+}
+
+func (suite *FileServerTestSuite) Test_FileServer_FinalizeSignedUpload() {
+	suite.Run("default", func() {
+		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+			ctx, cfg, mediaService, handler := suite.setupFileServer(t, dep)
+
+			ownerID := "@finalise-owner:example.com"
+			_, err := mediaService.UploadFile(ctx, &business.UploadRequest{
+				OwnerID:       types.OwnerID(ownerID),
+				MediaID:       "finalfile01",
+				UploadName:    "final.txt",
+				ContentType:   "text/plain",
+				FileSizeBytes: 5,
+				FileData:      io.NopCloser(bytes.NewReader([]byte("hello"))),
+				Config:        cfg,
+			})
+			require.NoError(t, err)
+
+			t.Run("unauthenticated", func(t *testing.T) {
+				_, err := handler.FinalizeSignedUpload(t.Context(), connect.NewRequest(&filesv1.FinalizeSignedUploadRequest{
+					MediaId: "finalfile01",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+			})
+
+			t.Run("invalid_media_id", func(t *testing.T) {
+				authCtx := claimsCtx(ctx, ownerID)
+				_, err := handler.FinalizeSignedUpload(authCtx, connect.NewRequest(&filesv1.FinalizeSignedUploadRequest{
+					MediaId: "bad id",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+
+			t.Run("success", func(t *testing.T) {
+				authCtx := claimsCtx(ctx, ownerID)
+				resp, err := handler.FinalizeSignedUpload(authCtx, connect.NewRequest(&filesv1.FinalizeSignedUploadRequest{
+					MediaId:        "finalfile01",
+					ChecksumSha256: "abc123checksum",
+					SizeBytes:      5,
+				}))
+				require.NoError(t, err)
+				require.NotNil(t, resp.Msg.GetMetadata())
+			})
+
+			t.Run("content_is_scanned_again", func(t *testing.T) {
+				cfg.ScannerProvider = config.ScannerClamAV
+				defer func() { cfg.ScannerProvider = config.ScannerNone }()
+				require.NoError(t, handler.Service.QueueManager().AddPublisher(ctx, cfg.QueueMediaScanName, cfg.QueueMediaScanURL))
+
+				authCtx := claimsCtx(ctx, ownerID)
+				resp, err := handler.FinalizeSignedUpload(authCtx, connect.NewRequest(&filesv1.FinalizeSignedUploadRequest{
+					MediaId:        "finalfile01",
+					ChecksumSha256: "def456checksum",
+					SizeBytes:      5,
+				}))
+				require.NoError(t, err)
+				assert.Equal(t, filesv1.ScanStatus_SCAN_STATUS_PENDING, resp.Msg.GetMetadata().GetScanStatus())
+			})
+		})
+	})
+}
+
+func (suite *FileServerTestSuite) Test_FileServer_DownloadContent_Validation() {
+	suite.Run("default", func() {
+		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+			ctx, _, _, handler := suite.setupFileServer(t, dep)
+
+			t.Run("unauthenticated", func(t *testing.T) {
+				err := handler.DownloadContent(t.Context(), connect.NewRequest(&filesv1.DownloadContentRequest{
+					MediaId: "dlfile01",
+				}), nil)
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+			})
+
+			t.Run("invalid_media_id", func(t *testing.T) {
+				authCtx := claimsCtx(ctx, "@dl-owner:example.com")
+				err := handler.DownloadContent(authCtx, connect.NewRequest(&filesv1.DownloadContentRequest{
+					MediaId: "bad id",
+				}), nil)
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+		})
+	})
+}
+
+func (suite *FileServerTestSuite) Test_FileServer_DownloadArchive_Validation() {
+	suite.Run("default", func() {
+		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+			ctx, _, _, handler := suite.setupFileServer(t, dep)
+
+			t.Run("unauthenticated", func(t *testing.T) {
+				err := handler.DownloadArchive(t.Context(), connect.NewRequest(&filesv1.DownloadArchiveRequest{
+					MediaIds: []string{"dlfile01"},
+				}), nil)
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+			})
+
+			t.Run("nothing_selected", func(t *testing.T) {
+				authCtx := claimsCtx(ctx, "@dl-owner:example.com")
+				err := handler.DownloadArchive(authCtx, connect.NewRequest(&filesv1.DownloadArchiveRequest{}), nil)
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+
+			t.Run("folder_escapes_root", func(t *testing.T) {
+				authCtx := claimsCtx(ctx, "@dl-owner:example.com")
+				err := handler.DownloadArchive(authCtx, connect.NewRequest(&filesv1.DownloadArchiveRequest{
+					Folder: "../secrets",
+				}), nil)
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+		})
+	})
+}
+
+func (suite *FileServerTestSuite) Test_FileServer_ArchiveExtraction() {
+	suite.Run("default", func() {
+		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+			ctx, _, _, handler := suite.setupFileServer(t, dep)
+			authCtx := claimsCtx(ctx, "@extract-owner:example.com")
+
+			t.Run("invalid_media_id", func(t *testing.T) {
+				_, err := handler.ExtractArchive(authCtx, connect.NewRequest(&filesv1.ExtractArchiveRequest{
+					MediaId: "bad id",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+
+			t.Run("folder_escapes_root", func(t *testing.T) {
+				_, err := handler.ExtractArchive(authCtx, connect.NewRequest(&filesv1.ExtractArchiveRequest{
+					MediaId: "archive01",
+					Folder:  "scans/../../etc",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+
+			t.Run("unknown_job", func(t *testing.T) {
+				_, err := handler.GetArchiveExtraction(authCtx, connect.NewRequest(&filesv1.GetArchiveExtractionRequest{
+					JobId: "missing-job",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+			})
+
+			t.Run("reports_progress_to_owner", func(t *testing.T) {
+				job := &types.ArchiveExtraction{
+					OwnerID:        "@extract-owner:example.com",
+					SourceMediaID:  "archive01",
+					Folder:         "scans",
+					State:          types.ArchiveExtractionCompleted,
+					Entries:        5,
+					Extracted:      2,
+					Skipped:        1,
+					Failed:         2,
+					ExtractedBytes: 2048,
+					Failures: map[string]string{
+						"z.exe":     "invalid parameter: content type is not allowed",
+						"../escape": "entry path leaves the target folder",
+					},
+				}
+				require.NoError(t, handler.db.StoreArchiveExtraction(ctx, job))
+
+				resp, err := handler.GetArchiveExtraction(authCtx, connect.NewRequest(&filesv1.GetArchiveExtractionRequest{
+					JobId: job.ID,
+				}))
+				require.NoError(t, err)
+				got := resp.Msg.GetJob()
+				assert.Equal(t, filesv1.ArchiveExtractionState_ARCHIVE_EXTRACTION_STATE_COMPLETED, got.GetState())
+				assert.Equal(t, "scans", got.GetFolder())
+				assert.Equal(t, int64(2), got.GetExtracted())
+				assert.Equal(t, int64(2048), got.GetExtractedBytes())
+				require.Len(t, got.GetFailures(), 2)
+				assert.Equal(t, "../escape", got.GetFailures()[0].GetPath(), "failures are listed in path order")
+
+				otherCtx := claimsCtx(ctx, "@someone-else:example.com")
+				_, err = handler.GetArchiveExtraction(otherCtx, connect.NewRequest(&filesv1.GetArchiveExtractionRequest{
+					JobId: job.ID,
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err), "jobs are only visible to their owner")
+			})
+		})
+	})
+}
+
+func (suite *FileServerTestSuite) Test_FileServer_UserDataExport() {
+	suite.Run("default", func() {
+		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+			ctx, _, _, handler := suite.setupFileServer(t, dep)
+			profileID := "@export-owner:example.com"
+			authCtx := claimsCtx(ctx, profileID)
+
+			t.Run("other_profile_needs_reason", func(t *testing.T) {
+				_, err := handler.ExportUserData(authCtx, connect.NewRequest(&filesv1.ExportUserDataRequest{
+					ProfileId: "@export-other:example.com",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+
+			t.Run("other_profile_needs_admin", func(t *testing.T) {
+				_, err := handler.ExportUserData(authCtx, connect.NewRequest(&filesv1.ExportUserDataRequest{
+					ProfileId: "@export-other:example.com",
+					Reason:    "subject access request",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+			})
+
+			t.Run("unknown_export", func(t *testing.T) {
+				_, err := handler.GetUserDataExport(authCtx, connect.NewRequest(&filesv1.GetUserDataExportRequest{
+					ExportId: "missing-export",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+			})
+
+			t.Run("reports_progress_to_profile", func(t *testing.T) {
+				job := &types.UserDataExport{
+					ProfileID:     types.OwnerID(profileID),
+					RequestedBy:   profileID,
+					State:         types.UserDataExportRunning,
+					TotalFiles:    4,
+					ExportedFiles: 2,
+					FailedFiles:   1,
+					ExportedBytes: 4096,
+				}
+				require.NoError(t, handler.db.StoreUserDataExport(ctx, job))
+
+				resp, err := handler.GetUserDataExport(authCtx, connect.NewRequest(&filesv1.GetUserDataExportRequest{
+					ExportId: job.ID,
+				}))
+				require.NoError(t, err)
+				got := resp.Msg.GetExport()
+				assert.Equal(t, filesv1.UserDataExportState_USER_DATA_EXPORT_STATE_RUNNING, got.GetState())
+				assert.Equal(t, int64(4), got.GetTotalFiles())
+				assert.Equal(t, int64(2), got.GetExportedFiles())
+				assert.Equal(t, int64(4096), got.GetExportedBytes())
+				assert.Empty(t, got.GetResultMediaId())
+
+				otherCtx := claimsCtx(ctx, "@export-other:example.com")
+				_, err = handler.GetUserDataExport(otherCtx, connect.NewRequest(&filesv1.GetUserDataExportRequest{
+					ExportId: job.ID,
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err), "exports are only visible to the profile and requester")
+			})
+
+			t.Run("one_running_export_per_profile", func(t *testing.T) {
+				busyID := "@export-busy:example.com"
+				running := &types.UserDataExport{
+					ProfileID:   types.OwnerID(busyID),
+					RequestedBy: busyID,
+					State:       types.UserDataExportRunning,
+				}
+				require.NoError(t, handler.db.StoreUserDataExport(ctx, running))
+
+				_, err := handler.ExportUserData(claimsCtx(ctx, busyID), connect.NewRequest(&filesv1.ExportUserDataRequest{}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+			})
+		})
+	})
+}
+
+func (suite *FileServerTestSuite) Test_FileServer_UserDataErasure() {
+	suite.Run("default", func() {
+		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+			ctx, cfg, _, handler := suite.setupFileServer(t, dep)
+			profileID := "@erase-owner:example.com"
+			authCtx := claimsCtx(ctx, profileID)
+
+			t.Run("other_profile_needs_reason", func(t *testing.T) {
+				_, err := handler.EraseUserData(authCtx, connect.NewRequest(&filesv1.EraseUserDataRequest{
+					ProfileId: "@erase-other:example.com",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+
+			t.Run("other_profile_needs_admin", func(t *testing.T) {
+				_, err := handler.EraseUserData(authCtx, connect.NewRequest(&filesv1.EraseUserDataRequest{
+					ProfileId: "@erase-other:example.com",
+					Reason:    "account closed",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+			})
+
+			t.Run("unknown_erasure", func(t *testing.T) {
+				_, err := handler.GetUserDataErasure(authCtx, connect.NewRequest(&filesv1.GetUserDataErasureRequest{
+					ErasureId: "missing-erasure",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+			})
+
+			t.Run("reports_signed_result_to_profile", func(t *testing.T) {
+				until := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+				report, err := json.Marshal(&types.ErasureReport{
+					ProfileID:   types.OwnerID(profileID),
+					ErasedFiles: []types.MediaID{"erased1", "erased2"},
+					RetainedFiles: []types.ErasureRetainedFile{
+						{MediaID: "held1", Reason: types.ErasureRetainedLegalHold},
+						{MediaID: "kept1", Reason: types.ErasureRetainedRetention, Until: &until},
+					},
+				})
+				require.NoError(t, err)
+				completedAt := time.Now()
+				job := &types.UserDataErasure{
+					ProfileID:   types.OwnerID(profileID),
+					RequestedBy: profileID,
+					State:       types.UserDataErasureCompleted,
+					Report:      string(report),
+					Signature:   "sha256=abc",
+					CompletedAt: &completedAt,
+				}
+				require.NoError(t, handler.db.StoreUserDataErasure(ctx, job))
+
+				resp, err := handler.GetUserDataErasure(authCtx, connect.NewRequest(&filesv1.GetUserDataErasureRequest{
+					ErasureId: job.ID,
+				}))
+				require.NoError(t, err)
+				got := resp.Msg.GetErasure()
+				assert.Equal(t, filesv1.UserDataErasureState_USER_DATA_ERASURE_STATE_COMPLETED, got.GetState())
+				assert.Equal(t, int64(2), got.GetErasedFiles())
+				require.Len(t, got.GetRetainedFiles(), 2)
+				assert.Equal(t, types.ErasureRetainedLegalHold, got.GetRetainedFiles()[0].GetReason())
+				assert.Nil(t, got.GetRetainedFiles()[0].GetRetainedUntil())
+				assert.Equal(t, until, got.GetRetainedFiles()[1].GetRetainedUntil().AsTime())
+				assert.Equal(t, string(report), got.GetReport())
+				assert.Equal(t, "sha256=abc", got.GetSignature())
+				assert.NotNil(t, got.GetCompletedAt())
+
+				otherCtx := claimsCtx(ctx, "@erase-other:example.com")
+				_, err = handler.GetUserDataErasure(otherCtx, connect.NewRequest(&filesv1.GetUserDataErasureRequest{
+					ErasureId: job.ID,
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err), "erasures are only visible to the profile and requester")
+			})
+
+			t.Run("requires_signing_key", func(t *testing.T) {
+				cfg.ErasureReportSigningKey = ""
+				_, err := handler.EraseUserData(authCtx, connect.NewRequest(&filesv1.EraseUserDataRequest{}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+			})
+
+			t.Run("one_running_erasure_per_profile", func(t *testing.T) {
+				cfg.ErasureReportSigningKey = "erasure-test-key"
+				busyID := "@erase-busy:example.com"
+				running := &types.UserDataErasure{
+					ProfileID:   types.OwnerID(busyID),
+					RequestedBy: busyID,
+					State:       types.UserDataErasureRunning,
+				}
+				require.NoError(t, handler.db.StoreUserDataErasure(ctx, running))
+
+				_, err := handler.EraseUserData(claimsCtx(ctx, busyID), connect.NewRequest(&filesv1.EraseUserDataRequest{}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+			})
+		})
+	})
+}
+
+func (suite *FileServerTestSuite) Test_FileServer_ShareLinks() {
+	suite.Run("default", func() {
+		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+			ctx, cfg, mediaService, handler := suite.setupFileServer(t, dep)
+			owner := "@share-owner:example.com"
+			authCtx := claimsCtx(ctx, owner)
+			otherCtx := claimsCtx(ctx, "@share-other:example.com")
+
+			content := "share link payload"
+			_, err := mediaService.UploadFile(ctx, &business.UploadRequest{
+				OwnerID:       types.OwnerID(owner),
+				MediaID:       "shareLinkMedia01",
+				UploadName:    "contract.pdf",
+				ContentType:   "application/pdf",
+				FileSizeBytes: types.FileSizeBytes(len(content)),
+				FileData:      bytes.NewReader([]byte(content)),
+				Config:        cfg,
+			})
+			require.NoError(t, err)
+
+			t.Run("unauthenticated", func(t *testing.T) {
+				_, err := handler.CreateShareLink(t.Context(), connect.NewRequest(&filesv1.CreateShareLinkRequest{
+					MediaId: "shareLinkMedia01",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+			})
+
+			t.Run("expiry_in_the_past", func(t *testing.T) {
+				_, err := handler.CreateShareLink(authCtx, connect.NewRequest(&filesv1.CreateShareLinkRequest{
+					MediaId:   "shareLinkMedia01",
+					ExpiresAt: timestamppb.New(time.Now().Add(-time.Hour)),
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			})
+
+			t.Run("only_the_owner_shares", func(t *testing.T) {
+				_, err := handler.CreateShareLink(otherCtx, connect.NewRequest(&filesv1.CreateShareLinkRequest{
+					MediaId: "shareLinkMedia01",
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+			})
+
+			t.Run("create_list_revoke", func(t *testing.T) {
+				created, err := handler.CreateShareLink(authCtx, connect.NewRequest(&filesv1.CreateShareLinkRequest{
+					MediaId:      "shareLinkMedia01",
+					Permission:   filesv1.ShareLinkPermission_SHARE_LINK_PERMISSION_DOWNLOAD,
+					ExpiresAt:    timestamppb.New(time.Now().Add(time.Hour)),
+					Password:     "s3cret",
+					MaxDownloads: 3,
+				}))
+				require.NoError(t, err)
+				link := created.Msg.GetLink()
+				assert.NotEmpty(t, created.Msg.GetToken())
+				assert.Contains(t, created.Msg.GetUrl(), "/v1/share/"+created.Msg.GetToken())
+				assert.True(t, link.GetPasswordProtected())
+				assert.Equal(t, filesv1.ShareLinkState_SHARE_LINK_STATE_ACTIVE, link.GetState())
+				assert.Equal(t, int32(3), link.GetMaxDownloads())
+
+				listed, err := handler.ListShareLinks(authCtx, connect.NewRequest(&filesv1.ListShareLinksRequest{
+					MediaId: "shareLinkMedia01",
+				}))
+				require.NoError(t, err)
+				require.Len(t, listed.Msg.GetLinks(), 1)
+				assert.Equal(t, link.GetLinkId(), listed.Msg.GetLinks()[0].GetLinkId())
+
+				_, err = handler.RevokeShareLink(otherCtx, connect.NewRequest(&filesv1.RevokeShareLinkRequest{
+					LinkId: link.GetLinkId(),
+				}))
+				require.Error(t, err)
+				assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+				revoked, err := handler.RevokeShareLink(authCtx, connect.NewRequest(&filesv1.RevokeShareLinkRequest{
+					LinkId: link.GetLinkId(),
+				}))
+				require.NoError(t, err)
+				assert.Equal(t, filesv1.ShareLinkState_SHARE_LINK_STATE_REVOKED, revoked.Msg.GetLink().GetState())
+
+				listed, err = handler.ListShareLinks(authCtx, connect.NewRequest(&filesv1.ListShareLinksRequest{
+					MediaId: "shareLinkMedia01",
+				}))
+				require.NoError(t, err)
+				assert.Empty(t, listed.Msg.GetLinks(), "revoked links are hidden by default")
+
+				listed, err = handler.ListShareLinks(authCtx, connect.NewRequest(&filesv1.ListShareLinksRequest{
+					MediaId:        "shareLinkMedia01",
+					IncludeRevoked: true,
+				}))
+				require.NoError(t, err)
+				assert.Len(t, listed.Msg.GetLinks(), 1)
+			})
+		})
+	})
+}
+
+func (suite *FileServerTestSuite) Test_FileServer_DownloadContentRange_Validation() {
 	suite.Run("default", func() {
 		suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
 			ctx, _, _, handler := suite.setupFileServer(t, dep)
@@ -2295,3 +3600,25 @@ func (suite *FileServerTestSuite) Test_FileServer_GetMultipartUpload() {
 		})
 	})
 }
+
+func TestToMediaPropertiesHidesLocationFromOthers(t *testing.T) {
+	metadata := &types.MediaMetadata{
+		OwnerID: "owner",
+		Exif: map[string]string{
+			"make":          "Acme",
+			"gps_latitude":  "-1.290000",
+			"gps_longitude": "36.816667",
+		},
+	}
+
+	owned := toMediaProperties(metadata, "owner")
+	require.NotNil(t, owned)
+	assert.Equal(t, "-1.290000", owned.GetExif()["gps_latitude"])
+
+	for _, viewer := range []string{"", "someone-else"} {
+		shared := toMediaProperties(metadata, viewer)
+		require.NotNil(t, shared)
+		assert.Equal(t, map[string]string{"make": "Acme"}, shared.GetExif())
+	}
+	assert.Len(t, metadata.Exif, 3, "the stored fields are not modified")
+}
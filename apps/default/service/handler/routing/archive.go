@@ -0,0 +1,72 @@
+package routing
+
+import (
+	"bufio"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/business"
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/pitabwire/util"
+)
+
+const (
+	defaultArchiveName = "archive.zip"
+	archiveBufferSize  = 64 * 1024
+)
+
+// Archive implements GET /archive, streaming a zip archive of the files
+// named by repeated media_id parameters, or of the folder parameter when
+// there are none. The optional name parameter sets the archive's download
+// name. Files that cannot be archived are reported in its manifest.json.
+func Archive(w http.ResponseWriter, req *http.Request, cfg *config.FilesConfig, archiver *business.Archiver) {
+	ctx := req.Context()
+	authClaims := security.ClaimsFromContext(ctx)
+	if authClaims == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	sub, err := authClaims.GetSubject()
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	query := req.URL.Query()
+	entries, err := archiver.Resolve(ctx, sub, query["media_id"], query.Get("folder"))
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.HasPrefix(err.Error(), "invalid parameter") {
+			code = http.StatusBadRequest
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	name := strings.TrimSpace(query.Get("name"))
+	if name == "" || strings.ContainsAny(name, "/\\") {
+		name = defaultArchiveName
+	} else if !strings.HasSuffix(strings.ToLower(name), ".zip") {
+		name += ".zip"
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	// Headers are sent, so failures can only cut the archive short.
+	out := bufio.NewWriterSize(w, archiveBufferSize)
+	if err = archiver.Write(ctx, out, cfg, entries); err == nil {
+		err = out.Flush()
+	}
+	if err != nil {
+		util.Log(ctx).WithError(err).Warn("failed to stream archive")
+	}
+}
@@ -16,14 +16,17 @@ package routing
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
+	"mime"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/antinvestor/service-files/apps/default/config"
 	"github.com/antinvestor/service-files/apps/default/service/authz"
 	"github.com/antinvestor/service-files/apps/default/service/business"
+	"github.com/antinvestor/service-files/apps/default/service/middleware"
 	"github.com/antinvestor/service-files/apps/default/service/storage"
 	"github.com/antinvestor/service-files/apps/default/service/types"
 	"github.com/pitabwire/frame/v2/security"
@@ -44,6 +47,7 @@ func Download(
 	provider storage.Provider,
 	mediaService business.MediaService,
 	authzMiddleware authz.Middleware,
+	headers *middleware.SecurityHeadersConfig,
 	isThumbnailRequest bool,
 	customFilename string,
 ) {
@@ -64,21 +68,21 @@ func Download(
 		return
 	}
 
-	// Parse thumbnail parameters if this is a thumbnail request
+	// Parse thumbnail and transform parameters if this is a thumbnail request
 	var thumbnailSize *types.ThumbnailSize
+	var transform *types.ImageTransform
 	if isThumbnailRequest {
-		width, widthErr := strconv.Atoi(req.FormValue("width"))
-		if widthErr != nil {
-			width = -1
-		}
-		height, heightErr := strconv.Atoi(req.FormValue("height"))
-		if heightErr != nil {
-			height = -1
+		transform, err = business.ParseImageTransform(req.URL.Query())
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
 		}
 		thumbnailSize = &types.ThumbnailSize{
-			Width:        width,
-			Height:       height,
-			ResizeMethod: strings.ToLower(req.FormValue("method")),
+			Width:        transform.Width,
+			Height:       transform.Height,
+			ResizeMethod: transform.ResizeMethod,
 		}
 	}
 
@@ -89,6 +93,7 @@ func Download(
 		ThumbnailSize:      thumbnailSize,
 		DownloadFilename:   customFilename,
 		Config:             cfg,
+		Transform:          transform,
 	}
 
 	// Execute business logic
@@ -100,7 +105,7 @@ func Download(
 	defer util.CloseAndLogOnError(req.Context(), result.FileData)
 
 	// Set response headers
-	addDownloadHeaders(w, result, customFilename, isThumbnailRequest)
+	addDownloadHeaders(w, result, customFilename, isThumbnailRequest, headers)
 
 	// Stream the file content
 	_, err = io.Copy(w, result.FileData)
@@ -109,8 +114,20 @@ func Download(
 	}
 }
 
-// addDownloadHeaders adds appropriate headers to the download response
-func addDownloadHeaders(w http.ResponseWriter, result *business.DownloadResult, customFilename string, isThumbnailRequest bool) {
+// addDownloadHeaders adds appropriate headers to the download response.
+// Content a browser could execute, such as HTML and SVG, is always served as
+// an attachment under a restrictive CSP so it never runs on our origin.
+func addDownloadHeaders(
+	w http.ResponseWriter,
+	result *business.DownloadResult,
+	customFilename string,
+	isThumbnailRequest bool,
+	headers *middleware.SecurityHeadersConfig,
+) {
+	if headers == nil {
+		headers = middleware.DefaultSecurityHeadersConfig()
+	}
+
 	// Set content type
 	if result.ContentType != "" {
 		w.Header().Set("Content-Type", result.ContentType)
@@ -122,7 +139,22 @@ func addDownloadHeaders(w http.ResponseWriter, result *business.DownloadResult,
 	}
 
 	// Set content disposition for downloads
-	if !isThumbnailRequest && customFilename != "" {
+	switch {
+	case headers.IsActiveContent(result.ContentType):
+		filename := customFilename
+		if filename == "" {
+			filename = result.Filename
+		}
+		disposition := "attachment"
+		if filename != "" {
+			disposition = mime.FormatMediaType("attachment", map[string]string{"filename": filename})
+		}
+		w.Header().Set("Content-Disposition", disposition)
+		if headers.ActiveContentSecurityPolicy != "" {
+			w.Header().Set("Content-Security-Policy", headers.ActiveContentSecurityPolicy)
+		}
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+	case !isThumbnailRequest && customFilename != "":
 		w.Header().Set("Content-Disposition", "inline; filename=\""+customFilename+"\"")
 	}
 
@@ -136,10 +168,15 @@ func addDownloadHeaders(w http.ResponseWriter, result *business.DownloadResult,
 
 // handleDownloadError handles errors during download and sets appropriate HTTP responses
 func handleDownloadError(ctx context.Context, w http.ResponseWriter, err error) {
-	w.WriteHeader(http.StatusInternalServerError)
-
 	// Write error response
 	w.Header().Set("Content-Type", "application/json")
+
+	code := http.StatusInternalServerError
+	if errors.Is(err, business.ErrScanBlocked) {
+		code = http.StatusForbidden
+	}
+	w.WriteHeader(code)
+
 	if _, writeErr := w.Write([]byte(`{"error":"` + err.Error() + `"}`)); writeErr != nil {
 		util.Log(ctx).WithError(writeErr).Warn("Failed to write error response")
 	}
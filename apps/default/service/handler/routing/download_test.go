@@ -13,6 +13,7 @@ import (
 	"github.com/antinvestor/service-files/apps/default/config"
 	"github.com/antinvestor/service-files/apps/default/service/authz"
 	"github.com/antinvestor/service-files/apps/default/service/business"
+	"github.com/antinvestor/service-files/apps/default/service/middleware"
 	"github.com/antinvestor/service-files/apps/default/service/storage/connection"
 	"github.com/antinvestor/service-files/apps/default/service/storage/provider"
 	"github.com/antinvestor/service-files/apps/default/service/tests"
@@ -68,6 +69,7 @@ func (suite *DownloadRoutingTestSuite) TestAddDownloadHeaders() {
 		isThumbnailRequest   bool
 		expectDispositionSet bool
 		expectCacheControl   string
+		expectAttachment     bool
 	}{
 		{
 			name: "cached_file_download",
@@ -93,12 +95,35 @@ func (suite *DownloadRoutingTestSuite) TestAddDownloadHeaders() {
 			expectDispositionSet: false,
 			expectCacheControl:   "public, max-age=3600",
 		},
+		{
+			name: "svg_forced_to_attachment",
+			result: &business.DownloadResult{
+				ContentType:   "image/svg+xml",
+				ContentLength: 10,
+				Filename:      "logo.svg",
+			},
+			expectDispositionSet: true,
+			expectCacheControl:   "public, max-age=3600",
+			expectAttachment:     true,
+		},
+		{
+			name: "html_thumbnail_still_attachment",
+			result: &business.DownloadResult{
+				ContentType:   "text/html; charset=utf-8",
+				ContentLength: 10,
+			},
+			customFilename:       "page.html",
+			isThumbnailRequest:   true,
+			expectDispositionSet: true,
+			expectCacheControl:   "public, max-age=3600",
+			expectAttachment:     true,
+		},
 	}
 
 	for _, tc := range testCases {
 		suite.T().Run(tc.name, func(t *testing.T) {
 			rec := httptest.NewRecorder()
-			addDownloadHeaders(rec, tc.result, tc.customFilename, tc.isThumbnailRequest)
+			addDownloadHeaders(rec, tc.result, tc.customFilename, tc.isThumbnailRequest, nil)
 			assert.Equal(t, tc.result.ContentType, rec.Header().Get("Content-Type"))
 			assert.Equal(t, tc.expectCacheControl, rec.Header().Get("Cache-Control"))
 			if tc.expectDispositionSet {
@@ -106,18 +131,37 @@ func (suite *DownloadRoutingTestSuite) TestAddDownloadHeaders() {
 			} else {
 				assert.Empty(t, rec.Header().Get("Content-Disposition"))
 			}
+			if tc.expectAttachment {
+				assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Disposition"), "attachment"))
+				assert.Contains(t, rec.Header().Get("Content-Disposition"), tc.result.Filename)
+				assert.Equal(t, middleware.DefaultSecurityHeadersConfig().ActiveContentSecurityPolicy,
+					rec.Header().Get("Content-Security-Policy"))
+				assert.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+			} else {
+				assert.Empty(t, rec.Header().Get("Content-Security-Policy"))
+			}
 		})
 	}
 }
 
 func (suite *DownloadRoutingTestSuite) TestHandleDownloadError() {
 	testCases := []struct {
-		name string
-		err  error
+		name     string
+		err      error
+		wantCode int
+		wantBody string
 	}{
 		{
-			name: "writes_error_json",
-			err:  errors.New("boom"),
+			name:     "writes_error_json",
+			err:      errors.New("boom"),
+			wantCode: http.StatusInternalServerError,
+			wantBody: "boom",
+		},
+		{
+			name:     "scan_blocked_is_forbidden",
+			err:      business.ErrScanBlocked,
+			wantCode: http.StatusForbidden,
+			wantBody: "malware scan policy",
 		},
 	}
 
@@ -125,9 +169,9 @@ func (suite *DownloadRoutingTestSuite) TestHandleDownloadError() {
 		suite.T().Run(tc.name, func(t *testing.T) {
 			rec := httptest.NewRecorder()
 			handleDownloadError(suite.T().Context(), rec, tc.err)
-			assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			assert.Equal(t, tc.wantCode, rec.Code)
 			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
-			assert.Contains(t, rec.Body.String(), "boom")
+			assert.Contains(t, rec.Body.String(), tc.wantBody)
 		})
 	}
 }
@@ -213,7 +257,7 @@ func (suite *DownloadRoutingTestSuite) TestDownload() {
 				}
 
 				rec := httptest.NewRecorder()
-				Download(rec, req, types.MediaID(tc.mediaID), cfg, db, storageProvider, mediaService, authzMiddleware, false, "")
+				Download(rec, req, types.MediaID(tc.mediaID), cfg, db, storageProvider, mediaService, authzMiddleware, nil, false, "")
 				assert.Equal(t, tc.wantCode, rec.Code)
 				if tc.wantCode == http.StatusOK {
 					assert.Equal(t, "text/plain", rec.Header().Get("Content-Type"))
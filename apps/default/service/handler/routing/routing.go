@@ -25,6 +25,7 @@ import (
 	"github.com/antinvestor/service-files/apps/default/config"
 	"github.com/antinvestor/service-files/apps/default/service/authz"
 	"github.com/antinvestor/service-files/apps/default/service/business"
+	"github.com/antinvestor/service-files/apps/default/service/middleware"
 	storage2 "github.com/antinvestor/service-files/apps/default/service/storage"
 	"github.com/antinvestor/service-files/apps/default/service/types"
 	"github.com/pitabwire/frame/v2"
@@ -198,6 +199,7 @@ func SetupMediaRoutes(
 	provider storage2.Provider,
 	mediaService business.MediaService,
 	authzMiddleware authz.Middleware,
+	headers *middleware.SecurityHeadersConfig,
 ) *Router {
 	cfg := service.Config().(*config.FilesConfig)
 	mediaRouter := NewRouter()
@@ -232,11 +234,18 @@ func SetupMediaRoutes(
 	v1mux.Handle("/config", configHandler).Methods(http.MethodGet, http.MethodOptions)
 
 	// Download endpoints
-	downloadHandlerAuthed := makeDownloadAPI("download_client", cfg, db, provider, mediaService, authzMiddleware)
+	downloadHandlerAuthed := makeDownloadAPI("download_client", cfg, db, provider, mediaService, authzMiddleware, headers)
 	v1mux.Handle("/download/{serverName}/{mediaId}", downloadHandlerAuthed).Methods(http.MethodGet, http.MethodOptions)
 	v1mux.Handle("/download/{serverName}/{mediaId}/{downloadName}", downloadHandlerAuthed).Methods(http.MethodGet, http.MethodOptions)
 
-	v1mux.Handle("/thumbnail/{serverName}/{mediaId}", makeDownloadAPI("thumbnail_authed_client", cfg, db, provider, mediaService, authzMiddleware)).Methods(http.MethodGet, http.MethodOptions)
+	v1mux.Handle("/thumbnail/{serverName}/{mediaId}", makeDownloadAPI("thumbnail_authed_client", cfg, db, provider, mediaService, authzMiddleware, headers)).Methods(http.MethodGet, http.MethodOptions)
+
+	archiver := business.NewArchiver(db, mediaService, authzMiddleware)
+	archiveHandler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		util.SetCORSHeaders(w)
+		Archive(w, req, cfg, archiver)
+	})
+	v1mux.Handle("/archive", archiveHandler).Methods(http.MethodGet, http.MethodOptions)
 
 	return mediaRouter
 }
@@ -248,6 +257,7 @@ func makeDownloadAPI(
 	provider storage2.Provider,
 	mediaService business.MediaService,
 	authzMiddleware authz.Middleware,
+	headers *middleware.SecurityHeadersConfig,
 ) http.HandlerFunc {
 	httpHandler := func(w http.ResponseWriter, req *http.Request) {
 		req = util.RequestWithLogging(req)
@@ -265,7 +275,7 @@ func makeDownloadAPI(
 		w.Header().Set("Cache-Control", "public,max-age=86400,s-maxage=86400")
 
 		Download(w, req, types.MediaID(vars["mediaId"]),
-			cfg, db, provider, mediaService, authzMiddleware,
+			cfg, db, provider, mediaService, authzMiddleware, headers,
 			strings.HasPrefix(name, "thumbnail"), vars["downloadName"],
 		)
 	}
@@ -331,7 +331,7 @@ func (suite *RoutingTestSuite) TestSetupMediaRoutes() {
 
 				mediaService := business.NewMediaService(db, storageProvider)
 				authzMiddleware := authz.NewMiddleware(svc.SecurityManager().GetAuthorizer(ctx), db)
-				router := SetupMediaRoutes(svc, db, storageProvider, mediaService, authzMiddleware)
+				router := SetupMediaRoutes(svc, db, storageProvider, mediaService, authzMiddleware, nil)
 
 				req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
 				if tc.body != "" {
@@ -407,7 +407,7 @@ func (suite *RoutingTestSuite) TestMakeDownloadAPI() {
 				})
 				require.NoError(t, err)
 
-				handler := makeDownloadAPI("download_client", cfg, db, storageProvider, mediaService, authzMiddleware)
+				handler := makeDownloadAPI("download_client", cfg, db, storageProvider, mediaService, authzMiddleware, nil)
 				req := httptest.NewRequest(http.MethodGet, tc.path, nil)
 				if tc.claimsSubject != "" {
 					claims := &security.AuthenticationClaims{
@@ -0,0 +1,283 @@
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/business"
+	"github.com/antinvestor/service-files/apps/default/service/events"
+	"github.com/antinvestor/service-files/apps/default/service/middleware"
+	"github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/pitabwire/frame/v2"
+	"github.com/pitabwire/frame/v2/data"
+	"github.com/pitabwire/util"
+)
+
+const (
+	// ShareLinkPathPrefix is where share links resolve. The routes carry no
+	// authentication; the token in the path is the credential.
+	ShareLinkPathPrefix = "/v1/share/"
+
+	// ShareLinkPasswordHeader carries the password of a protected link.
+	ShareLinkPasswordHeader = "X-Share-Password"
+
+	shareLinkContentPath = "content"
+)
+
+// Audit actions recorded for share link accesses.
+const (
+	ShareLinkAuditResolve  = "share_link.resolve"
+	ShareLinkAuditView     = "share_link.view"
+	ShareLinkAuditDownload = "share_link.download"
+	ShareLinkAuditDenied   = "share_link.denied"
+)
+
+// ShareLinkInfo describes the file behind a share link.
+type ShareLinkInfo struct {
+	Filename           string     `json:"filename"`
+	ContentType        string     `json:"content_type"`
+	SizeBytes          int64      `json:"size_bytes"`
+	Permission         string     `json:"permission"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"`
+	DownloadsRemaining *int       `json:"downloads_remaining,omitempty"`
+}
+
+// ShareLinkHandler serves share links:
+//
+//	GET      /v1/share/{token}          describes the shared file
+//	GET|POST /v1/share/{token}/content  serves the file
+//
+// The password of a protected link is sent in the X-Share-Password header,
+// or as the password field of a POSTed form. Every access is audited.
+type ShareLinkHandler struct {
+	service      *frame.Service
+	db           storage.Database
+	mediaService business.MediaService
+	headers      *middleware.SecurityHeadersConfig
+}
+
+// NewShareLinkHandler creates the handler for share link routes.
+func NewShareLinkHandler(service *frame.Service, db storage.Database, mediaService business.MediaService, headers *middleware.SecurityHeadersConfig) *ShareLinkHandler {
+	if headers == nil {
+		headers = middleware.DefaultSecurityHeadersConfig()
+	}
+	return &ShareLinkHandler{
+		service:      service,
+		db:           db,
+		mediaService: mediaService,
+		headers:      headers,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *ShareLinkHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	token, rest, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, ShareLinkPathPrefix), "/")
+	if token == "" || (rest != "" && rest != shareLinkContentPath) {
+		http.NotFound(w, req)
+		return
+	}
+	serveContent := rest == shareLinkContentPath
+	if req.Method != http.MethodGet && (!serveContent || req.Method != http.MethodPost) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Nothing under a share link may be cached or leak its token onwards.
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+
+	ctx := req.Context()
+	link, err := h.db.GetShareLinkByTokenHash(
+		utils.SystemContext(ctx, "resolve share link"), business.HashShareLinkToken(token))
+	if err != nil {
+		util.Log(ctx).WithError(err).Error("failed to resolve share link")
+		writeShareLinkError(w, http.StatusInternalServerError, "could not resolve share link")
+		return
+	}
+	if link == nil {
+		writeShareLinkError(w, http.StatusNotFound, "share link not found")
+		return
+	}
+	ctx = utils.TenantContext(ctx, link.TenantID, link.PartitionID)
+
+	now := time.Now().UTC()
+	if err = business.CheckShareLink(link, shareLinkPassword(req), now); err != nil {
+		h.audit(ctx, req, link, ShareLinkAuditDenied, err.Error())
+		switch {
+		case errors.Is(err, business.ErrShareLinkUnavailable):
+			writeShareLinkError(w, http.StatusGone, err.Error())
+		case errors.Is(err, business.ErrShareLinkLocked):
+			w.Header().Set("Retry-After", strconv.Itoa(max(int(link.LockedUntil.Sub(now).Seconds()), 1)))
+			writeShareLinkError(w, http.StatusTooManyRequests, err.Error())
+		default:
+			if errors.Is(err, business.ErrShareLinkPasswordIncorrect) {
+				h.recordPasswordFailure(ctx, link, now)
+			}
+			writeShareLinkError(w, http.StatusUnauthorized, err.Error())
+		}
+		return
+	}
+	if link.FailedAttempts > 0 {
+		if err = h.db.ResetShareLinkPasswordFailures(ctx, link.ID); err != nil {
+			util.Log(ctx).WithError(err).With("share_link_id", link.ID).Warn("failed to reset share link password failures")
+		}
+	}
+
+	metadata, err := h.db.GetMediaMetadata(ctx, link.MediaID)
+	if err != nil {
+		util.Log(ctx).WithError(err).With("share_link_id", link.ID).Error("failed to load shared media")
+		writeShareLinkError(w, http.StatusInternalServerError, "could not load shared file")
+		return
+	}
+	if metadata == nil {
+		writeShareLinkError(w, http.StatusNotFound, "shared file no longer exists")
+		return
+	}
+
+	if !serveContent {
+		h.describe(ctx, w, req, link, metadata, now)
+		return
+	}
+	h.serve(ctx, w, req, link, metadata, now)
+}
+
+func (h *ShareLinkHandler) describe(ctx context.Context, w http.ResponseWriter, req *http.Request, link *types.ShareLink, metadata *types.MediaMetadata, now time.Time) {
+	if err := h.db.TouchShareLink(ctx, link.ID, now); err != nil {
+		util.Log(ctx).WithError(err).With("share_link_id", link.ID).Warn("failed to record share link access")
+	}
+	h.audit(ctx, req, link, ShareLinkAuditResolve, "")
+
+	info := ShareLinkInfo{
+		Filename:    string(metadata.UploadName),
+		ContentType: string(metadata.ContentType),
+		SizeBytes:   int64(metadata.FileSizeBytes),
+		Permission:  link.Permission,
+		ExpiresAt:   link.ExpiresAt,
+	}
+	if link.MaxDownloads > 0 {
+		remaining := link.MaxDownloads - link.DownloadCount
+		info.DownloadsRemaining = &remaining
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(info)
+}
+
+func (h *ShareLinkHandler) serve(ctx context.Context, w http.ResponseWriter, req *http.Request, link *types.ShareLink, metadata *types.MediaMetadata, now time.Time) {
+	download := link.Permission == types.ShareLinkDownload
+	// Active content is only ever served as an attachment, which a view
+	// link must not hand out.
+	if !download && h.headers.IsActiveContent(string(metadata.ContentType)) {
+		h.audit(ctx, req, link, ShareLinkAuditDenied, "content cannot be viewed inline")
+		writeShareLinkError(w, http.StatusForbidden, "this file can only be shared for download")
+		return
+	}
+
+	cfg := h.service.Config().(*config.FilesConfig)
+	result, err := h.mediaService.DownloadFile(ctx, &business.DownloadRequest{
+		MediaID:  link.MediaID,
+		Metadata: metadata,
+		Config:   cfg,
+	})
+	if err != nil {
+		h.audit(ctx, req, link, ShareLinkAuditDenied, err.Error())
+		handleDownloadError(ctx, w, err)
+		return
+	}
+	defer util.CloseAndLogOnError(ctx, result.FileData)
+
+	// Counting after the file opens keeps failed reads from using up a
+	// download; counting before writing keeps the limit exact under races.
+	counted, err := h.db.RecordShareLinkDownload(ctx, link.ID, now)
+	if err != nil {
+		util.Log(ctx).WithError(err).With("share_link_id", link.ID).Error("failed to count share link download")
+		writeShareLinkError(w, http.StatusInternalServerError, "could not serve shared file")
+		return
+	}
+	if !counted {
+		h.audit(ctx, req, link, ShareLinkAuditDenied, business.ErrShareLinkUnavailable.Error())
+		writeShareLinkError(w, http.StatusGone, business.ErrShareLinkUnavailable.Error())
+		return
+	}
+
+	action := ShareLinkAuditView
+	if download {
+		action = ShareLinkAuditDownload
+	}
+	h.audit(ctx, req, link, action, "")
+
+	addDownloadHeaders(w, result, "", false, h.headers)
+	if !h.headers.IsActiveContent(result.ContentType) {
+		disposition := "inline"
+		if download {
+			disposition = "attachment"
+		}
+		if filename := string(metadata.UploadName); filename != "" {
+			disposition = mime.FormatMediaType(disposition, map[string]string{"filename": filename})
+		}
+		w.Header().Set("Content-Disposition", disposition)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+	}
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	if _, err = io.Copy(w, result.FileData); err != nil {
+		util.Log(ctx).WithError(err).With("share_link_id", link.ID).Error("failed to stream shared file")
+	}
+}
+
+// recordPasswordFailure counts a wrong password against link, locking it
+// once the configured number of attempts is reached.
+func (h *ShareLinkHandler) recordPasswordFailure(ctx context.Context, link *types.ShareLink, now time.Time) {
+	cfg := h.service.Config().(*config.FilesConfig)
+	lockUntil := now.Add(time.Duration(cfg.ShareLinkLockoutSeconds) * time.Second)
+	if err := h.db.RecordShareLinkPasswordFailure(ctx, link.ID, cfg.ShareLinkMaxPasswordAttempts, lockUntil); err != nil {
+		util.Log(ctx).WithError(err).With("share_link_id", link.ID).Warn("failed to record share link password failure")
+	}
+}
+
+func (h *ShareLinkHandler) audit(ctx context.Context, req *http.Request, link *types.ShareLink, action, reason string) {
+	details := data.JSONMap{
+		"ip":         middleware.GetIP(req),
+		"user_agent": req.UserAgent(),
+	}
+	if reason != "" {
+		details["reason"] = reason
+	}
+	audit := &models.MediaAudit{
+		FileID:  string(link.MediaID),
+		Action:  action,
+		Source:  "share_link:" + link.ID,
+		Details: details,
+	}
+	audit.GenID(ctx)
+	audit.TenantID = link.TenantID
+	audit.PartitionID = link.PartitionID
+	events.EmitMediaAudit(ctx, h.service, audit)
+}
+
+// shareLinkPassword reads the password sent for a protected link.
+func shareLinkPassword(req *http.Request) string {
+	if password := req.Header.Get(ShareLinkPasswordHeader); password != "" {
+		return password
+	}
+	if req.Method == http.MethodPost {
+		return req.PostFormValue("password")
+	}
+	return ""
+}
+
+func writeShareLinkError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,164 @@
+package routing
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/business"
+	"github.com/antinvestor/service-files/apps/default/service/storage/connection"
+	"github.com/antinvestor/service-files/apps/default/service/storage/provider"
+	"github.com/antinvestor/service-files/apps/default/service/tests"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/frame/v2/frametests/definition"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ShareLinkRoutingTestSuite struct {
+	tests.BaseTestSuite
+}
+
+func TestShareLinkRoutingTestSuite(t *testing.T) {
+	suite.Run(t, new(ShareLinkRoutingTestSuite))
+}
+
+func (suite *ShareLinkRoutingTestSuite) TestShareLink() {
+	suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+		ctx, svc, res := suite.CreateService(t, dep)
+		cfg := svc.Config().(*config.FilesConfig)
+		db := &connection.Database{
+			WorkManager:     svc.WorkManager(),
+			MediaRepository: res.MediaRepository,
+			ShareLinkRepo:   res.ShareLinkRepo,
+		}
+		storageProvider, err := provider.GetStorageProvider(ctx, cfg)
+		require.NoError(t, err)
+		mediaService := business.NewMediaService(db, storageProvider)
+		handler := NewShareLinkHandler(svc, db, mediaService, nil)
+
+		upload := func(mediaID, contentType, content string) {
+			_, uploadErr := mediaService.UploadFile(ctx, &business.UploadRequest{
+				OwnerID:       "@owner:example.com",
+				MediaID:       types.MediaID(mediaID),
+				UploadName:    "report.txt",
+				ContentType:   types.ContentType(contentType),
+				FileSizeBytes: types.FileSizeBytes(len(content)),
+				FileData:      bytes.NewReader([]byte(content)),
+				Config:        cfg,
+			})
+			require.NoError(t, uploadErr)
+		}
+		share := func(mediaID, permission, password string, maxDownloads int, expiresAt *time.Time) string {
+			token, tokenHash, tokenErr := business.NewShareLinkToken()
+			require.NoError(t, tokenErr)
+			passwordHash, hashErr := business.HashShareLinkPassword(password)
+			require.NoError(t, hashErr)
+			require.NoError(t, db.StoreShareLink(ctx, &types.ShareLink{
+				MediaID:      types.MediaID(mediaID),
+				OwnerID:      "@owner:example.com",
+				TokenHash:    tokenHash,
+				PasswordHash: passwordHash,
+				Permission:   permission,
+				MaxDownloads: maxDownloads,
+				ExpiresAt:    expiresAt,
+			}))
+			return token
+		}
+		serve := func(method, target, password string, form url.Values) *httptest.ResponseRecorder {
+			var body *strings.Reader
+			if form != nil {
+				body = strings.NewReader(form.Encode())
+			} else {
+				body = strings.NewReader("")
+			}
+			req := httptest.NewRequest(method, target, body)
+			if form != nil {
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+			if password != "" {
+				req.Header.Set(ShareLinkPasswordHeader, password)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			return rec
+		}
+
+		upload("shareLinkMediaA", "text/plain", "shared-content")
+		upload("shareLinkMediaB", "text/html", "<script>alert(1)</script>")
+
+		t.Run("unknown token", func(t *testing.T) {
+			rec := serve(http.MethodGet, ShareLinkPathPrefix+"unknown", "", nil)
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+		})
+
+		t.Run("download limit", func(t *testing.T) {
+			token := share("shareLinkMediaA", types.ShareLinkDownload, "", 1, nil)
+
+			rec := serve(http.MethodGet, ShareLinkPathPrefix+token, "", nil)
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"downloads_remaining":1`)
+
+			rec = serve(http.MethodGet, ShareLinkPathPrefix+token+"/content", "", nil)
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "shared-content", rec.Body.String())
+			assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Disposition"), "attachment"))
+			assert.Equal(t, "private, no-store", rec.Header().Get("Cache-Control"))
+
+			rec = serve(http.MethodGet, ShareLinkPathPrefix+token+"/content", "", nil)
+			assert.Equal(t, http.StatusGone, rec.Code)
+		})
+
+		t.Run("password", func(t *testing.T) {
+			token := share("shareLinkMediaA", types.ShareLinkView, "s3cret", 0, nil)
+
+			assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, ShareLinkPathPrefix+token, "", nil).Code)
+			assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, ShareLinkPathPrefix+token+"/content", "wrong", nil).Code)
+
+			rec := serve(http.MethodPost, ShareLinkPathPrefix+token+"/content", "", url.Values{"password": {"s3cret"}})
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Disposition"), "inline"))
+
+			// The right password cleared the earlier wrong one.
+			link, linkErr := db.GetShareLinkByTokenHash(ctx, business.HashShareLinkToken(token))
+			require.NoError(t, linkErr)
+			assert.Zero(t, link.FailedAttempts)
+		})
+
+		t.Run("password lockout", func(t *testing.T) {
+			token := share("shareLinkMediaA", types.ShareLinkView, "s3cret", 0, nil)
+
+			for range cfg.ShareLinkMaxPasswordAttempts {
+				assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, ShareLinkPathPrefix+token, "wrong", nil).Code)
+			}
+
+			// Locked, even the right password is refused.
+			rec := serve(http.MethodGet, ShareLinkPathPrefix+token, "s3cret", nil)
+			assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+			assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+		})
+
+		t.Run("expired", func(t *testing.T) {
+			past := time.Now().UTC().Add(-time.Minute)
+			token := share("shareLinkMediaA", types.ShareLinkView, "", 0, &past)
+			assert.Equal(t, http.StatusGone, serve(http.MethodGet, ShareLinkPathPrefix+token, "", nil).Code)
+		})
+
+		t.Run("view link refuses active content", func(t *testing.T) {
+			token := share("shareLinkMediaB", types.ShareLinkView, "", 0, nil)
+			assert.Equal(t, http.StatusForbidden, serve(http.MethodGet, ShareLinkPathPrefix+token+"/content", "", nil).Code)
+		})
+
+		t.Run("bad paths and methods", func(t *testing.T) {
+			token := share("shareLinkMediaA", types.ShareLinkView, "", 0, nil)
+			assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, ShareLinkPathPrefix+token+"/other", "", nil).Code)
+			assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodPost, ShareLinkPathPrefix+token, "", nil).Code)
+		})
+	})
+}
@@ -24,8 +24,10 @@ import (
 	"strings"
 
 	"github.com/antinvestor/service-files/apps/default/config"
+	filesv1 "github.com/antinvestor/service-files/apps/default/gen/files/v1"
 	"github.com/antinvestor/service-files/apps/default/service/authz"
 	"github.com/antinvestor/service-files/apps/default/service/business"
+	"github.com/antinvestor/service-files/apps/default/service/events"
 	"github.com/antinvestor/service-files/apps/default/service/storage"
 	"github.com/antinvestor/service-files/apps/default/service/types"
 	"github.com/pitabwire/frame/v2"
@@ -84,6 +86,31 @@ func Upload(req *http.Request, service *frame.Service, db storage.Database, prov
 	}
 	defer func() { _ = uploadReq.Close() }()
 
+	purpose := strings.TrimSpace(req.URL.Query().Get("purpose"))
+	err = business.CheckDeclaredContentType(
+		ctx, cfg, purpose, string(uploadReq.MediaMetadata.ContentType), string(uploadReq.MediaMetadata.UploadName),
+	)
+	if err != nil {
+		return util.JSONResponse{
+			Code: http.StatusUnsupportedMediaType,
+			JSON: map[string]interface{}{
+				"errcode": "M_UNKNOWN",
+				"error":   err.Error(),
+			},
+		}
+	}
+
+	folder, err := business.CleanFolder(req.URL.Query().Get("folder"))
+	if err != nil {
+		return util.JSONResponse{
+			Code: http.StatusBadRequest,
+			JSON: map[string]interface{}{
+				"errcode": "M_UNKNOWN",
+				"error":   err.Error(),
+			},
+		}
+	}
+
 	// Create business request
 	businessReq := &business.UploadRequest{
 		OwnerID:       ownerID,
@@ -93,6 +120,8 @@ func Upload(req *http.Request, service *frame.Service, db storage.Database, prov
 		FileData:      uploadReq.FileData,
 		Config:        cfg,
 		IsPublic:      false,
+		Purpose:       purpose,
+		Folder:        folder,
 	}
 
 	// Execute business logic
@@ -119,6 +148,29 @@ func Upload(req *http.Request, service *frame.Service, db storage.Database, prov
 		}
 	}
 
+	err = queueMediaScan(ctx, service, result.MediaID)
+	if err != nil {
+		return util.JSONResponse{
+			Code: http.StatusInternalServerError,
+			JSON: map[string]interface{}{
+				"errcode": "M_UNKNOWN",
+				"error":   "Failed to queue malware scan",
+			},
+		}
+	}
+
+	storedMeta, _ := db.GetMediaMetadata(ctx, result.MediaID)
+	if storedMeta == nil {
+		storedMeta = &types.MediaMetadata{MediaID: result.MediaID, OwnerID: ownerID}
+	}
+	if storedMeta.Folder != "" {
+		err = authzMiddleware.SetFileFolder(ctx, string(storedMeta.OwnerID), string(storedMeta.MediaID), "", storedMeta.Folder)
+		if err != nil {
+			util.Log(ctx).WithError(err).With("media_id", storedMeta.MediaID).Warn("failed to file media under its folder")
+		}
+	}
+	events.EmitLifecycleEvent(ctx, service, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_UPLOADED, storedMeta, sub, nil)
+
 	return util.JSONResponse{
 		Code: http.StatusOK,
 		JSON: uploadResponse{
@@ -320,3 +372,14 @@ func queueThumbnailGeneration(ctx context.Context, service *frame.Service, media
 		"media_id": string(mediaID),
 	})
 }
+
+// queueMediaScan requests a malware scan of newly stored media.
+func queueMediaScan(ctx context.Context, service *frame.Service, mediaID types.MediaID) error {
+	cfg := service.Config().(*config.FilesConfig)
+	if !cfg.ScanEnabled() {
+		return nil
+	}
+	return service.QueueManager().Publish(ctx, cfg.QueueMediaScanName, map[string]string{
+		"media_id": string(mediaID),
+	})
+}
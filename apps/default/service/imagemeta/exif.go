@@ -0,0 +1,275 @@
+// Package imagemeta reads and removes embedded image metadata.
+package imagemeta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Keys of the fields extracted from EXIF data.
+const (
+	FieldMake             = "make"
+	FieldModel            = "model"
+	FieldSoftware         = "software"
+	FieldDateTime         = "date_time"
+	FieldDateTimeOriginal = "date_time_original"
+	FieldLensMake         = "lens_make"
+	FieldLensModel        = "lens_model"
+	FieldSerialNumber     = "serial_number"
+	FieldOrientation      = "orientation"
+	FieldGPSLatitude      = "gps_latitude"
+	FieldGPSLongitude     = "gps_longitude"
+	FieldGPSAltitude      = "gps_altitude"
+)
+
+const (
+	tagOrientation  = 0x0112
+	tagExifIFD      = 0x8769
+	tagGPSIFD       = 0x8825
+	tagGPSLatRef    = 0x0001
+	tagGPSLat       = 0x0002
+	tagGPSLonRef    = 0x0003
+	tagGPSLon       = 0x0004
+	tagGPSAltRef    = 0x0005
+	tagGPSAlt       = 0x0006
+	maxIFDEntries   = 512
+	maxFieldLength  = 256
+	exifHeaderMagic = "Exif\x00\x00"
+)
+
+// asciiTags maps the textual IFD0 and EXIF IFD tags to field keys.
+var asciiTags = map[uint16]string{
+	0x010F: FieldMake,
+	0x0110: FieldModel,
+	0x0131: FieldSoftware,
+	0x0132: FieldDateTime,
+	0x9003: FieldDateTimeOriginal,
+	0xA431: FieldSerialNumber,
+	0xA433: FieldLensMake,
+	0xA434: FieldLensModel,
+}
+
+var errInvalidExif = errors.New("invalid exif data")
+
+// Exif is the subset of EXIF data the service understands.
+type Exif struct {
+	// Orientation is the EXIF orientation (1-8); 0 when absent.
+	Orientation int
+	// Fields holds the extracted values keyed by the Field constants.
+	Fields map[string]string
+}
+
+// IsLocationField reports whether key is one of the GPS fields, which reveal
+// where an image was taken and are only returned to the media owner.
+func IsLocationField(key string) bool {
+	switch key {
+	case FieldGPSLatitude, FieldGPSLongitude, FieldGPSAltitude:
+		return true
+	default:
+		return false
+	}
+}
+
+// HasLocation reports whether the data carried GPS coordinates.
+func (e *Exif) HasLocation() bool {
+	_, ok := e.Fields[FieldGPSLatitude]
+	return ok
+}
+
+// ParseExif decodes a TIFF structured EXIF block, with or without the
+// "Exif\0\0" prefix used by JPEG APP1 segments.
+func ParseExif(data []byte) (*Exif, error) {
+	data = bytes.TrimPrefix(data, []byte(exifHeaderMagic))
+	if len(data) < 8 {
+		return nil, errInvalidExif
+	}
+
+	var order binary.ByteOrder
+	switch string(data[:4]) {
+	case "II*\x00":
+		order = binary.LittleEndian
+	case "MM\x00*":
+		order = binary.BigEndian
+	default:
+		return nil, errInvalidExif
+	}
+
+	r := &tiffReader{data: data, order: order}
+	exif := &Exif{Fields: map[string]string{}}
+
+	ifd0, err := r.readIFD(order.Uint32(data[4:8]))
+	if err != nil {
+		return nil, err
+	}
+	r.collect(ifd0, exif)
+
+	if entry, ok := ifd0[tagExifIFD]; ok {
+		if sub, subErr := r.readIFD(r.uint(entry)); subErr == nil {
+			r.collect(sub, exif)
+		}
+	}
+	if entry, ok := ifd0[tagGPSIFD]; ok {
+		if gps, gpsErr := r.readIFD(r.uint(entry)); gpsErr == nil {
+			r.collectGPS(gps, exif)
+		}
+	}
+	return exif, nil
+}
+
+type ifdEntry struct {
+	typ   uint16
+	count uint32
+	value []byte
+}
+
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+func typeSize(typ uint16) int {
+	switch typ {
+	case 1, 2, 6, 7:
+		return 1
+	case 3, 8:
+		return 2
+	case 4, 9, 11:
+		return 4
+	case 5, 10, 12:
+		return 8
+	default:
+		return 0
+	}
+}
+
+func (r *tiffReader) readIFD(offset uint32) (map[uint16]ifdEntry, error) {
+	if uint64(offset)+2 > uint64(len(r.data)) {
+		return nil, errInvalidExif
+	}
+	count := int(r.order.Uint16(r.data[offset:]))
+	if count > maxIFDEntries {
+		return nil, errInvalidExif
+	}
+	start := int(offset) + 2
+	if start+count*12 > len(r.data) {
+		return nil, errInvalidExif
+	}
+
+	entries := make(map[uint16]ifdEntry, count)
+	for i := 0; i < count; i++ {
+		raw := r.data[start+i*12 : start+i*12+12]
+		tag := r.order.Uint16(raw[0:])
+		typ := r.order.Uint16(raw[2:])
+		n := r.order.Uint32(raw[4:])
+		size := typeSize(typ)
+		if size == 0 || uint64(n)*uint64(size) > uint64(len(r.data)) {
+			continue
+		}
+		length := int(n) * size
+		var value []byte
+		if length <= 4 {
+			value = raw[8 : 8+length]
+		} else {
+			valueOffset := int(r.order.Uint32(raw[8:]))
+			if valueOffset < 0 || valueOffset+length > len(r.data) {
+				continue
+			}
+			value = r.data[valueOffset : valueOffset+length]
+		}
+		entries[tag] = ifdEntry{typ: typ, count: n, value: value}
+	}
+	return entries, nil
+}
+
+// uint reads the first integer of a BYTE, SHORT or LONG entry.
+func (r *tiffReader) uint(e ifdEntry) uint32 {
+	switch {
+	case e.typ == 3 && len(e.value) >= 2:
+		return uint32(r.order.Uint16(e.value))
+	case e.typ == 4 && len(e.value) >= 4:
+		return r.order.Uint32(e.value)
+	case e.typ == 1 && len(e.value) >= 1:
+		return uint32(e.value[0])
+	default:
+		return 0
+	}
+}
+
+func (r *tiffReader) rationals(e ifdEntry) []float64 {
+	if e.typ != 5 {
+		return nil
+	}
+	out := make([]float64, 0, e.count)
+	for i := 0; i+8 <= len(e.value); i += 8 {
+		num := r.order.Uint32(e.value[i:])
+		den := r.order.Uint32(e.value[i+4:])
+		if den == 0 {
+			return nil
+		}
+		out = append(out, float64(num)/float64(den))
+	}
+	return out
+}
+
+func asciiValue(e ifdEntry) string {
+	if e.typ != 2 {
+		return ""
+	}
+	value := string(e.value)
+	if i := strings.IndexByte(value, 0); i >= 0 {
+		value = value[:i]
+	}
+	value = strings.TrimSpace(value)
+	if len(value) > maxFieldLength {
+		value = value[:maxFieldLength]
+	}
+	return strings.ToValidUTF8(value, "")
+}
+
+func (r *tiffReader) collect(entries map[uint16]ifdEntry, exif *Exif) {
+	for tag, key := range asciiTags {
+		if entry, ok := entries[tag]; ok {
+			if value := asciiValue(entry); value != "" {
+				exif.Fields[key] = value
+			}
+		}
+	}
+	if entry, ok := entries[tagOrientation]; ok {
+		if o := int(r.uint(entry)); o >= 1 && o <= 8 {
+			exif.Orientation = o
+			exif.Fields[FieldOrientation] = strconv.Itoa(o)
+		}
+	}
+}
+
+func (r *tiffReader) collectGPS(entries map[uint16]ifdEntry, exif *Exif) {
+	coordinate := func(valueTag, refTag uint16, negative string) (string, bool) {
+		parts := r.rationals(entries[valueTag])
+		if len(parts) != 3 {
+			return "", false
+		}
+		value := parts[0] + parts[1]/60 + parts[2]/3600
+		if strings.EqualFold(asciiValue(entries[refTag]), negative) {
+			value = -value
+		}
+		return fmt.Sprintf("%.6f", value), true
+	}
+
+	lat, latOK := coordinate(tagGPSLat, tagGPSLatRef, "S")
+	lon, lonOK := coordinate(tagGPSLon, tagGPSLonRef, "W")
+	if latOK && lonOK {
+		exif.Fields[FieldGPSLatitude] = lat
+		exif.Fields[FieldGPSLongitude] = lon
+	}
+	if alt := r.rationals(entries[tagGPSAlt]); len(alt) == 1 {
+		value := alt[0]
+		if ref, ok := entries[tagGPSAltRef]; ok && r.uint(ref) == 1 {
+			value = -value
+		}
+		exif.Fields[FieldGPSAltitude] = fmt.Sprintf("%.1f", value)
+	}
+}
@@ -0,0 +1,714 @@
+package imagemeta
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Format names returned by Sniff.
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatWebP = "webp"
+)
+
+const (
+	// reencodeQuality is used when a JPEG is re-encoded to apply its
+	// orientation.
+	reencodeQuality = 92
+	// maxOrientPixels bounds the decoded size of an image that needs its
+	// orientation applied.
+	maxOrientPixels = 50_000_000
+	// maxExifBytes bounds the EXIF payloads read into memory to be parsed.
+	// Larger payloads are still stripped, only without being parsed.
+	maxExifBytes = 1 << 20
+	sniffLength  = 12
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// ErrTooLarge is returned when an image must be decoded to apply its
+// orientation but exceeds the decode limit.
+var ErrTooLarge = errors.New("image dimensions are too large to normalise")
+
+// Result describes what sanitization did to an image.
+type Result struct {
+	// Format is the detected container format.
+	Format string
+	// Exif is the metadata found in the image, nil when there was none.
+	Exif *Exif
+	// Modified reports whether the content changed.
+	Modified bool
+	// Oriented reports whether pixels were transformed to apply the EXIF
+	// orientation.
+	Oriented bool
+
+	// iccSegments are the JPEG colour profile segments, restored after
+	// re-encoding.
+	iccSegments [][]byte
+}
+
+// Sniff detects the image container from the leading bytes, returning an
+// empty string for content the sanitizer does not handle.
+func Sniff(header []byte) string {
+	switch {
+	case len(header) >= 3 && header[0] == 0xFF && header[1] == 0xD8 && header[2] == 0xFF:
+		return FormatJPEG
+	case bytes.HasPrefix(header, pngSignature):
+		return FormatPNG
+	case len(header) >= 12 && string(header[:4]) == "RIFF" && string(header[8:12]) == "WEBP":
+		return FormatWebP
+	default:
+		return ""
+	}
+}
+
+// SanitizeFile sanitizes the image at path in place. The image is streamed
+// through a temporary file next to it, so only metadata segments and images
+// that need their orientation applied are held in memory. Files that are not
+// a supported image are left untouched and a nil result is returned.
+func SanitizeFile(path string) (*Result, error) {
+	header, err := readHeader(path)
+	if err != nil {
+		return nil, err
+	}
+	format := Sniff(header)
+	if format == "" {
+		return nil, nil
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = src.Close() }()
+
+	stripped, err := os.CreateTemp(filepath.Dir(path), ".sanitize-*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = stripped.Close()
+		_ = os.Remove(stripped.Name())
+	}()
+
+	out := bufio.NewWriter(stripped)
+	result, err := strip(format, src, out)
+	if err != nil {
+		return nil, err
+	}
+	if err = out.Flush(); err != nil {
+		return nil, err
+	}
+
+	final := stripped
+	if canOrient(result) {
+		if _, err = stripped.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		oriented, createErr := os.CreateTemp(filepath.Dir(path), ".sanitize-*")
+		if createErr != nil {
+			return nil, createErr
+		}
+		defer func() {
+			_ = oriented.Close()
+			_ = os.Remove(oriented.Name())
+		}()
+
+		out = bufio.NewWriter(oriented)
+		if err = orient(stripped, result, out); err != nil {
+			return nil, err
+		}
+		if err = out.Flush(); err != nil {
+			return nil, err
+		}
+		final = oriented
+	}
+
+	if !result.Modified {
+		return result, nil
+	}
+	if err = final.Close(); err != nil {
+		return nil, err
+	}
+	if err = os.Chmod(final.Name(), 0600); err != nil {
+		return nil, err
+	}
+	if err = os.Rename(final.Name(), path); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func readHeader(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	header := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return header[:n], nil
+}
+
+// Sanitize applies the EXIF orientation and removes location, device and
+// other descriptive metadata from a JPEG, PNG or WebP image. Colour profiles
+// are kept. WebP images are stripped but not re-oriented since that would
+// require re-encoding lossy or animated content. Unsupported content is
+// returned unchanged with a nil Exif.
+func Sanitize(content []byte) ([]byte, *Result, error) {
+	format := Sniff(content)
+	if format == "" {
+		return content, &Result{}, nil
+	}
+
+	var stripped bytes.Buffer
+	result, err := strip(format, bytes.NewReader(content), &stripped)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !canOrient(result) {
+		return stripped.Bytes(), result, nil
+	}
+
+	var oriented bytes.Buffer
+	if err = orient(bytes.NewReader(stripped.Bytes()), result, &oriented); err != nil {
+		return nil, nil, err
+	}
+	return oriented.Bytes(), result, nil
+}
+
+// strip copies the image from r to w without its metadata, recording what
+// was found and whether anything was left out.
+func strip(format string, r io.ReadSeeker, w io.Writer) (*Result, error) {
+	switch format {
+	case FormatJPEG:
+		return stripJPEG(bufio.NewReader(r), w)
+	case FormatPNG:
+		return stripPNG(bufio.NewReader(r), w)
+	default:
+		return stripWebP(r, w)
+	}
+}
+
+// canOrient reports whether the image has an orientation to apply. WebP is
+// never re-encoded.
+func canOrient(result *Result) bool {
+	return result.Format != FormatWebP && result.Exif != nil && result.Exif.Orientation > 1
+}
+
+// orient decodes the stripped image, applies its EXIF orientation and
+// writes the re-encoded image to w.
+func orient(stripped io.ReadSeeker, result *Result, w io.Writer) error {
+	if result.Format == FormatPNG {
+		img, err := decodeBounded(stripped, png.DecodeConfig, png.Decode)
+		if err != nil {
+			return err
+		}
+		if err = png.Encode(w, Orient(img, result.Exif.Orientation)); err != nil {
+			return err
+		}
+		result.Oriented, result.Modified = true, true
+		return nil
+	}
+
+	img, err := decodeBounded(stripped, jpeg.DecodeConfig, jpeg.Decode)
+	if err != nil {
+		return err
+	}
+	var encoded bytes.Buffer
+	if err = jpeg.Encode(&encoded, Orient(img, result.Exif.Orientation), &jpeg.Options{Quality: reencodeQuality}); err != nil {
+		return err
+	}
+	reencoded := encoded.Bytes()
+	if _, cmyk := img.(*image.CMYK); cmyk || len(result.iccSegments) == 0 {
+		_, err = w.Write(reencoded)
+	} else {
+		// The encoder converts to YCbCr, so only RGB profiles still apply.
+		_, err = w.Write(reencoded[:2])
+		for _, segment := range result.iccSegments {
+			if err == nil {
+				_, err = w.Write(segment)
+			}
+		}
+		if err == nil {
+			_, err = w.Write(reencoded[2:])
+		}
+	}
+	if err != nil {
+		return err
+	}
+	result.Oriented, result.Modified = true, true
+	return nil
+}
+
+var errTruncatedJPEG = errors.New("invalid jpeg: truncated segment")
+
+func stripJPEG(r *bufio.Reader, w io.Writer) (*Result, error) {
+	result := &Result{Format: FormatJPEG}
+	out := &errWriter{w: w}
+
+	soi := make([]byte, 2)
+	if _, err := io.ReadFull(r, soi); err != nil {
+		return nil, errTruncatedJPEG
+	}
+	out.write(soi)
+
+	// afterMarker is set when the entropy coded data ended at a marker
+	// whose 0xFF prefix was already consumed.
+	afterMarker := false
+	for {
+		if !afterMarker {
+			b, err := r.ReadByte()
+			if errors.Is(err, io.EOF) {
+				// The missing end of image marker is added.
+				result.Modified = true
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if b != 0xFF {
+				return nil, errors.New("invalid jpeg: expected marker")
+			}
+		}
+		afterMarker = false
+
+		marker, fill, err := readMarker(r)
+		if errors.Is(err, io.EOF) {
+			result.Modified = true
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if fill {
+			result.Modified = true
+		}
+
+		if marker == 0xD9 {
+			// Anything after EOI, such as appended multi-picture images, is
+			// dropped.
+			if _, peekErr := r.Peek(1); peekErr == nil {
+				result.Modified = true
+			}
+			break
+		}
+		if (marker >= 0xD0 && marker <= 0xD7) || marker == 0x01 {
+			out.write([]byte{0xFF, marker})
+			continue
+		}
+
+		lengthBytes := make([]byte, 2)
+		if _, err = io.ReadFull(r, lengthBytes); err != nil {
+			return nil, errTruncatedJPEG
+		}
+		segmentLength := int(binary.BigEndian.Uint16(lengthBytes))
+		if segmentLength < 2 {
+			return nil, errTruncatedJPEG
+		}
+		// Segment payloads are at most 64 KiB.
+		payload := make([]byte, segmentLength-2)
+		if _, err = io.ReadFull(r, payload); err != nil {
+			return nil, errTruncatedJPEG
+		}
+		segment := append([]byte{0xFF, marker}, lengthBytes...)
+		segment = append(segment, payload...)
+
+		keep := true
+		switch {
+		case marker == 0xE1:
+			// EXIF and XMP live in APP1; only the first EXIF block is read.
+			if result.Exif == nil && bytes.HasPrefix(payload, []byte(exifHeaderMagic)) {
+				if exif, exifErr := ParseExif(payload); exifErr == nil {
+					result.Exif = exif
+				}
+			}
+			keep = false
+		case marker == 0xE0:
+			keep = bytes.HasPrefix(payload, []byte("JFIF\x00"))
+		case marker == 0xE2:
+			keep = bytes.HasPrefix(payload, []byte("ICC_PROFILE\x00"))
+			if keep {
+				result.iccSegments = append(result.iccSegments, segment)
+			}
+		case marker == 0xEE:
+			// Adobe APP14 carries the colour transform and is kept.
+		case marker >= 0xE3 && marker <= 0xEF, marker == 0xFE:
+			// Maker notes, IPTC, other application data and comments.
+			keep = false
+		}
+		if !keep {
+			result.Modified = true
+			continue
+		}
+		out.write(segment)
+
+		if marker == 0xDA {
+			if afterMarker, err = copyEntropyData(r, out); err != nil {
+				return nil, err
+			}
+		}
+	}
+	out.write([]byte{0xFF, 0xD9})
+
+	if out.err != nil {
+		return nil, out.err
+	}
+	return result, nil
+}
+
+// readMarker reads the marker code following a 0xFF prefix, skipping fill
+// bytes, and reports whether there were any.
+func readMarker(r *bufio.Reader) (byte, bool, error) {
+	fill := false
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, fill, err
+		}
+		if b != 0xFF {
+			return b, fill, nil
+		}
+		fill = true
+	}
+}
+
+// copyEntropyData copies the entropy coded data following a start of scan
+// segment, keeping stuffed bytes and restart markers. It stops at the next
+// marker, consuming its 0xFF prefix, and reports whether it found one.
+func copyEntropyData(r *bufio.Reader, out *errWriter) (bool, error) {
+	for {
+		data, err := r.ReadSlice(0xFF)
+		switch {
+		case errors.Is(err, bufio.ErrBufferFull):
+			out.write(data)
+			continue
+		case errors.Is(err, io.EOF):
+			out.write(data)
+			return false, nil
+		case err != nil:
+			return false, err
+		}
+
+		next, err := r.Peek(1)
+		if errors.Is(err, io.EOF) {
+			out.write(data)
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		switch n := next[0]; {
+		case n == 0x00 || (n >= 0xD0 && n <= 0xD7):
+			out.write(data)
+			out.write(next)
+			_, _ = r.Discard(1)
+		case n == 0xFF:
+			out.write(data)
+		default:
+			out.write(data[:len(data)-1])
+			return true, out.err
+		}
+	}
+}
+
+// strippedPNGChunks are ancillary chunks that can carry descriptive or
+// location metadata.
+var strippedPNGChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+var errTruncatedPNG = errors.New("invalid png: truncated chunk")
+
+func stripPNG(r *bufio.Reader, w io.Writer) (*Result, error) {
+	result := &Result{Format: FormatPNG}
+	if _, err := r.Discard(len(pngSignature)); err != nil {
+		return nil, errTruncatedPNG
+	}
+	if _, err := w.Write(pngSignature); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return nil, errTruncatedPNG
+		}
+		length := int64(binary.BigEndian.Uint32(header))
+		chunkType := string(header[4:8])
+
+		if strippedPNGChunks[chunkType] {
+			result.Modified = true
+			if chunkType == "eXIf" && result.Exif == nil && length <= maxExifBytes {
+				payload := make([]byte, length)
+				if _, err := io.ReadFull(r, payload); err != nil {
+					return nil, errTruncatedPNG
+				}
+				if exif, err := ParseExif(payload); err == nil {
+					result.Exif = exif
+				}
+				length = 0
+			}
+			// The chunk data that is left and the CRC are skipped.
+			if n, err := r.Discard(int(length) + 4); err != nil || int64(n) != length+4 {
+				return nil, errTruncatedPNG
+			}
+			continue
+		}
+
+		if _, err := w.Write(header); err != nil {
+			return nil, err
+		}
+		if _, err := io.CopyN(w, r, length+4); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errTruncatedPNG
+			}
+			return nil, err
+		}
+		if chunkType == "IEND" {
+			if _, err := r.Peek(1); err == nil {
+				result.Modified = true
+			}
+			return result, nil
+		}
+	}
+}
+
+const (
+	webpFlagXMP  = 0x04
+	webpFlagEXIF = 0x08
+)
+
+// webpChunk locates a chunk of a WebP container; end includes the padding.
+type webpChunk struct {
+	fourCC string
+	offset int64
+	size   int64
+	end    int64
+}
+
+// stripWebP removes the EXIF and XMP chunks of a WebP image. The container
+// is walked twice: once to read the metadata and size the output, whose
+// length leads the RIFF header, and once to copy the kept chunks.
+func stripWebP(r io.ReadSeeker, w io.Writer) (*Result, error) {
+	result := &Result{Format: FormatWebP}
+
+	header := make([]byte, 12)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, errors.New("invalid webp: truncated container")
+	}
+	contentLength, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	riffEnd := 8 + int64(binary.LittleEndian.Uint32(header[4:8]))
+	if riffEnd > contentLength {
+		return nil, errors.New("invalid webp: truncated container")
+	}
+	if riffEnd < contentLength {
+		result.Modified = true
+	}
+
+	outLength := int64(12)
+	err = walkWebP(r, riffEnd, func(chunk webpChunk) error {
+		switch chunk.fourCC {
+		case "EXIF":
+			if result.Exif == nil && chunk.size <= maxExifBytes {
+				payload := make([]byte, chunk.size)
+				if _, readErr := io.ReadFull(r, payload); readErr != nil {
+					return readErr
+				}
+				if exif, exifErr := ParseExif(payload); exifErr == nil {
+					result.Exif = exif
+				}
+			}
+			result.Modified = true
+		case "XMP ":
+			result.Modified = true
+		default:
+			outLength += chunk.end - chunk.offset
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if binary.LittleEndian.Uint32(header[4:8]) != uint32(outLength-8) {
+		result.Modified = true
+	}
+	binary.LittleEndian.PutUint32(header[4:8], uint32(outLength-8))
+	out := &errWriter{w: w}
+	out.write(header)
+
+	err = walkWebP(r, riffEnd, func(chunk webpChunk) error {
+		switch chunk.fourCC {
+		case "EXIF", "XMP ":
+			return nil
+		case "VP8X":
+			if chunk.size >= 1 {
+				if _, seekErr := r.Seek(chunk.offset, io.SeekStart); seekErr != nil {
+					return seekErr
+				}
+				head := make([]byte, 9)
+				if _, readErr := io.ReadFull(r, head); readErr != nil {
+					return readErr
+				}
+				if head[8]&(webpFlagEXIF|webpFlagXMP) != 0 {
+					head[8] &^= webpFlagEXIF | webpFlagXMP
+					result.Modified = true
+				}
+				out.write(head)
+				_, copyErr := io.CopyN(out, r, chunk.end-chunk.offset-9)
+				return copyErr
+			}
+		}
+		if _, seekErr := r.Seek(chunk.offset, io.SeekStart); seekErr != nil {
+			return seekErr
+		}
+		_, copyErr := io.CopyN(out, r, chunk.end-chunk.offset)
+		return copyErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	if out.err != nil {
+		return nil, out.err
+	}
+	return result, nil
+}
+
+// walkWebP calls fn for each chunk of the container up to riffEnd, with r
+// positioned after the chunk header. Trailing bytes too short to hold a
+// chunk are ignored.
+func walkWebP(r io.ReadSeeker, riffEnd int64, fn func(webpChunk) error) error {
+	header := make([]byte, 8)
+	for pos := int64(12); pos+8 <= riffEnd; {
+		if _, err := r.Seek(pos, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err := io.ReadFull(r, header); err != nil {
+			return errors.New("invalid webp: truncated chunk")
+		}
+		size := int64(binary.LittleEndian.Uint32(header[4:]))
+		if pos+8+size > riffEnd {
+			return errors.New("invalid webp: truncated chunk")
+		}
+		chunk := webpChunk{
+			fourCC: string(header[:4]),
+			offset: pos,
+			size:   size,
+			end:    min(pos+8+size+size%2, riffEnd),
+		}
+		if err := fn(chunk); err != nil {
+			return err
+		}
+		pos = chunk.end
+	}
+	return nil
+}
+
+// errWriter keeps the first write error so a sequence of writes can be
+// checked once.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) write(p []byte) {
+	if e.err == nil {
+		_, e.err = e.w.Write(p)
+	}
+}
+
+func (e *errWriter) Write(p []byte) (int, error) {
+	e.write(p)
+	if e.err != nil {
+		return 0, e.err
+	}
+	return len(p), nil
+}
+
+func decodeBounded(
+	r io.ReadSeeker,
+	decodeConfig func(io.Reader) (image.Config, error),
+	decode func(io.Reader) (image.Image, error),
+) (image.Image, error) {
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := decodeConfig(bufio.NewReader(r))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || int64(cfg.Width)*int64(cfg.Height) > maxOrientPixels {
+		return nil, ErrTooLarge
+	}
+	if _, err = r.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return decode(bufio.NewReader(r))
+}
+
+// Orient transforms img so that it displays upright for the given EXIF
+// orientation. Orientation 1 or unknown values return img unchanged.
+func Orient(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	src := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			default:
+				dx, dy = y, w-1-x
+			}
+			si := src.PixOffset(x, y)
+			di := dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,342 @@
+package imagemeta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tiffEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	data  []byte
+}
+
+func asciiEntry(tag uint16, value string) tiffEntry {
+	return tiffEntry{tag: tag, typ: 2, count: uint32(len(value) + 1), data: append([]byte(value), 0)}
+}
+
+func shortEntry(tag uint16, value uint16) tiffEntry {
+	data := make([]byte, 2)
+	binary.LittleEndian.PutUint16(data, value)
+	return tiffEntry{tag: tag, typ: 3, count: 1, data: data}
+}
+
+func rationalEntry(tag uint16, values ...[2]uint32) tiffEntry {
+	data := make([]byte, 0, 8*len(values))
+	for _, v := range values {
+		data = binary.LittleEndian.AppendUint32(data, v[0])
+		data = binary.LittleEndian.AppendUint32(data, v[1])
+	}
+	return tiffEntry{tag: tag, typ: 5, count: uint32(len(values)), data: data}
+}
+
+// writeIFD appends an IFD at the end of buf with its out-of-line values
+// following it.
+func writeIFD(buf []byte, entries []tiffEntry) []byte {
+	offset := len(buf)
+	dataOffset := offset + 2 + 12*len(entries) + 4
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(len(entries)))
+	var values []byte
+	for _, e := range entries {
+		buf = binary.LittleEndian.AppendUint16(buf, e.tag)
+		buf = binary.LittleEndian.AppendUint16(buf, e.typ)
+		buf = binary.LittleEndian.AppendUint32(buf, e.count)
+		if len(e.data) <= 4 {
+			buf = append(buf, e.data...)
+			buf = append(buf, make([]byte, 4-len(e.data))...)
+			continue
+		}
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(dataOffset+len(values)))
+		values = append(values, e.data...)
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, 0)
+	return append(buf, values...)
+}
+
+// buildExif returns a little endian TIFF block with camera fields, the
+// orientation and, optionally, a GPS IFD.
+func buildExif(orientation uint16, withGPS bool) []byte {
+	ifd0 := []tiffEntry{
+		asciiEntry(0x010F, "Acme"),
+		asciiEntry(0x0110, "Phone 9"),
+		shortEntry(tagOrientation, orientation),
+	}
+	gps := []tiffEntry{
+		asciiEntry(tagGPSLatRef, "S"),
+		rationalEntry(tagGPSLat, [2]uint32{1, 1}, [2]uint32{17, 1}, [2]uint32{2400, 100}),
+		asciiEntry(tagGPSLonRef, "E"),
+		rationalEntry(tagGPSLon, [2]uint32{36, 1}, [2]uint32{49, 1}, [2]uint32{0, 1}),
+	}
+
+	buf := []byte("II*\x00\x08\x00\x00\x00")
+	if !withGPS {
+		return writeIFD(buf, ifd0)
+	}
+	// The GPS pointer is patched once the IFD0 size is known.
+	ifd0 = append(ifd0, tiffEntry{tag: tagGPSIFD, typ: 4, count: 1, data: make([]byte, 4)})
+	buf = writeIFD(buf, ifd0)
+	binary.LittleEndian.PutUint32(buf[8+2+12*3+8:], uint32(len(buf)))
+	return writeIFD(buf, gps)
+}
+
+// halves returns an image whose left half is red and right half is blue.
+func halves(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.NRGBA{R: 255, A: 255}
+			if x >= width/2 {
+				c = color.NRGBA{B: 255, A: 255}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func jpegWithSegments(t *testing.T, img image.Image, segments ...[]byte) []byte {
+	var encoded bytes.Buffer
+	require.NoError(t, jpeg.Encode(&encoded, img, &jpeg.Options{Quality: 95}))
+	out := append([]byte{}, encoded.Bytes()[:2]...)
+	for _, s := range segments {
+		out = append(out, s...)
+	}
+	return append(out, encoded.Bytes()[2:]...)
+}
+
+func jpegSegment(marker byte, payload []byte) []byte {
+	segment := []byte{0xFF, marker}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+func pngChunk(chunkType string, data []byte) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, chunkType...)
+	chunk = append(chunk, data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+func isRed(c color.Color) bool {
+	r, _, b, _ := c.RGBA()
+	return r > b
+}
+
+func TestParseExif(t *testing.T) {
+	exif, err := ParseExif(append([]byte(exifHeaderMagic), buildExif(6, true)...))
+	require.NoError(t, err)
+	assert.Equal(t, 6, exif.Orientation)
+	assert.Equal(t, "Acme", exif.Fields[FieldMake])
+	assert.Equal(t, "Phone 9", exif.Fields[FieldModel])
+	assert.Equal(t, "-1.290000", exif.Fields[FieldGPSLatitude])
+	assert.Equal(t, "36.816667", exif.Fields[FieldGPSLongitude])
+	assert.True(t, exif.HasLocation())
+
+	_, err = ParseExif([]byte("not exif"))
+	require.Error(t, err)
+}
+
+func TestSanitizeJPEGAppliesOrientation(t *testing.T) {
+	app1 := jpegSegment(0xE1, append([]byte(exifHeaderMagic), buildExif(6, true)...))
+	content := jpegWithSegments(t, halves(32, 16), app1)
+
+	sanitized, result, err := Sanitize(content)
+	require.NoError(t, err)
+	assert.True(t, result.Modified)
+	assert.True(t, result.Oriented)
+	require.NotNil(t, result.Exif)
+	assert.True(t, result.Exif.HasLocation())
+	assert.NotContains(t, string(sanitized), exifHeaderMagic)
+	assert.NotContains(t, string(sanitized), "Acme")
+
+	img, err := jpeg.Decode(bytes.NewReader(sanitized))
+	require.NoError(t, err)
+	assert.Equal(t, image.Pt(16, 32), img.Bounds().Size())
+	// Orientation 6 rotates clockwise, moving the red left half to the top.
+	assert.True(t, isRed(img.At(8, 4)))
+	assert.False(t, isRed(img.At(8, 28)))
+}
+
+func TestSanitizeJPEGStripsWithoutReencoding(t *testing.T) {
+	src := halves(32, 16)
+	plain := jpegWithSegments(t, src)
+	content := jpegWithSegments(t, src,
+		jpegSegment(0xE1, append([]byte(exifHeaderMagic), buildExif(1, true)...)),
+		jpegSegment(0xE1, []byte("http://ns.adobe.com/xap/1.0/\x00<x:xmpmeta/>")),
+		jpegSegment(0xED, []byte("Photoshop 3.0\x00IPTC")),
+		jpegSegment(0xFE, []byte("shot on Phone 9")),
+	)
+	content = append(content, []byte("trailing multi-picture data")...)
+
+	sanitized, result, err := Sanitize(content)
+	require.NoError(t, err)
+	assert.True(t, result.Modified)
+	assert.False(t, result.Oriented)
+	assert.Equal(t, plain, sanitized, "only metadata segments are removed")
+
+	again, result, err := Sanitize(sanitized)
+	require.NoError(t, err)
+	assert.False(t, result.Modified)
+	assert.Nil(t, result.Exif)
+	assert.Equal(t, sanitized, again)
+}
+
+func TestSanitizeFileStreamsInPlace(t *testing.T) {
+	src := halves(32, 16)
+	plain := jpegWithSegments(t, src)
+	stripped := jpegWithSegments(t, src,
+		jpegSegment(0xE1, append([]byte(exifHeaderMagic), buildExif(1, true)...)),
+		jpegSegment(0xFE, []byte("shot on Phone 9")),
+	)
+	oriented := jpegWithSegments(t, src,
+		jpegSegment(0xE1, append([]byte(exifHeaderMagic), buildExif(6, true)...)),
+	)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "content")
+	require.NoError(t, os.WriteFile(path, stripped, 0600))
+	result, err := SanitizeFile(path)
+	require.NoError(t, err)
+	assert.True(t, result.Modified)
+	assert.False(t, result.Oriented)
+	require.NotNil(t, result.Exif)
+	onDisk, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, plain, onDisk)
+
+	require.NoError(t, os.WriteFile(path, oriented, 0600))
+	result, err = SanitizeFile(path)
+	require.NoError(t, err)
+	assert.True(t, result.Oriented)
+	img, err := decodeFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, image.Pt(16, 32), img.Bounds().Size())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files are removed")
+}
+
+func decodeFile(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	return jpeg.Decode(file)
+}
+
+func TestSanitizePNG(t *testing.T) {
+	var encoded bytes.Buffer
+	require.NoError(t, png.Encode(&encoded, halves(8, 4)))
+	raw := encoded.Bytes()
+	ihdrEnd := len(pngSignature) + 12 + 13
+
+	content := append([]byte{}, raw[:ihdrEnd]...)
+	content = append(content, pngChunk("eXIf", buildExif(3, true))...)
+	content = append(content, pngChunk("tEXt", []byte("Comment\x00Acme Phone 9"))...)
+	content = append(content, raw[ihdrEnd:]...)
+
+	sanitized, result, err := Sanitize(content)
+	require.NoError(t, err)
+	assert.True(t, result.Oriented)
+	require.NotNil(t, result.Exif)
+	assert.Equal(t, 3, result.Exif.Orientation)
+	assert.NotContains(t, string(sanitized), "eXIf")
+	assert.NotContains(t, string(sanitized), "Acme")
+
+	img, err := png.Decode(bytes.NewReader(sanitized))
+	require.NoError(t, err)
+	assert.False(t, isRed(img.At(0, 0)), "orientation 3 rotates by 180 degrees")
+	assert.True(t, isRed(img.At(7, 0)))
+}
+
+func TestSanitizeWebP(t *testing.T) {
+	chunk := func(fourCC string, data []byte) []byte {
+		out := append([]byte(fourCC), binary.LittleEndian.AppendUint32(nil, uint32(len(data)))...)
+		out = append(out, data...)
+		if len(data)%2 == 1 {
+			out = append(out, 0)
+		}
+		return out
+	}
+	vp8x := make([]byte, 10)
+	vp8x[0] = webpFlagEXIF | webpFlagXMP
+
+	body := []byte("WEBP")
+	body = append(body, chunk("VP8X", vp8x)...)
+	body = append(body, chunk("VP8L", []byte{0x2f, 1, 2, 3, 4})...)
+	body = append(body, chunk("EXIF", buildExif(1, true))...)
+	body = append(body, chunk("XMP ", []byte("<x:xmpmeta/>"))...)
+	content := append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(len(body)))...)
+	content = append(content, body...)
+
+	sanitized, result, err := Sanitize(content)
+	require.NoError(t, err)
+	assert.True(t, result.Modified)
+	require.NotNil(t, result.Exif)
+	assert.True(t, result.Exif.HasLocation())
+	assert.NotContains(t, string(sanitized), "EXIF")
+	assert.NotContains(t, string(sanitized), "XMP ")
+	assert.Equal(t, byte(0), sanitized[20]&(webpFlagEXIF|webpFlagXMP))
+	assert.Equal(t, uint32(len(sanitized)-8), binary.LittleEndian.Uint32(sanitized[4:8]))
+	assert.Contains(t, string(sanitized), "VP8L")
+}
+
+func TestSanitizeIgnoresOtherContent(t *testing.T) {
+	content := []byte("plain text is not an image")
+	out, result, err := Sanitize(content)
+	require.NoError(t, err)
+	assert.False(t, result.Modified)
+	assert.Equal(t, content, out)
+
+	path := filepath.Join(t.TempDir(), "content")
+	require.NoError(t, os.WriteFile(path, content, 0600))
+	fileResult, err := SanitizeFile(path)
+	require.NoError(t, err)
+	assert.Nil(t, fileResult)
+}
+
+func TestSanitizeRejectsMalformedImages(t *testing.T) {
+	_, _, err := Sanitize([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF})
+	require.Error(t, err)
+
+	_, _, err = Sanitize(append(append([]byte{}, pngSignature...), 0, 0, 0, 9))
+	require.Error(t, err)
+}
+
+func TestOrient(t *testing.T) {
+	// A 2x1 image: red at x=0, blue at x=1.
+	src := halves(2, 1)
+	testCases := []struct {
+		orientation int
+		size        image.Point
+		redAt       image.Point
+	}{
+		{orientation: 1, size: image.Pt(2, 1), redAt: image.Pt(0, 0)},
+		{orientation: 2, size: image.Pt(2, 1), redAt: image.Pt(1, 0)},
+		{orientation: 3, size: image.Pt(2, 1), redAt: image.Pt(1, 0)},
+		{orientation: 4, size: image.Pt(2, 1), redAt: image.Pt(0, 0)},
+		{orientation: 5, size: image.Pt(1, 2), redAt: image.Pt(0, 0)},
+		{orientation: 6, size: image.Pt(1, 2), redAt: image.Pt(0, 0)},
+		{orientation: 7, size: image.Pt(1, 2), redAt: image.Pt(0, 1)},
+		{orientation: 8, size: image.Pt(1, 2), redAt: image.Pt(0, 1)},
+	}
+	for _, tc := range testCases {
+		out := Orient(src, tc.orientation)
+		assert.Equal(t, tc.size, out.Bounds().Size(), "orientation %d", tc.orientation)
+		assert.True(t, isRed(out.At(tc.redAt.X, tc.redAt.Y)), "orientation %d", tc.orientation)
+	}
+}
@@ -0,0 +1,276 @@
+package imagemeta
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// svgDroppedElements are removed together with their content. Names are
+// compared lower cased and without their namespace prefix.
+var svgDroppedElements = map[string]bool{
+	"script": true, "foreignobject": true, "iframe": true, "frame": true, "embed": true,
+	"object": true, "applet": true, "handler": true, "listener": true, "base": true,
+	"meta": true, "link": true,
+}
+
+// svgAnimationElements can assign attributes after load, so they are
+// removed when they target a link or an event handler.
+var svgAnimationElements = map[string]bool{
+	"set": true, "animate": true, "animatemotion": true, "animatetransform": true, "animatecolor": true,
+}
+
+var (
+	svgEntityPattern  = regexp.MustCompile(`<!ENTITY\s+([A-Za-z_][\w.-]*)\s+(?:"([^"%&]*)"|'([^'%&]*)')\s*>`)
+	cssImportPattern  = regexp.MustCompile(`(?i)@import[^;]*;?`)
+	cssURLPattern     = regexp.MustCompile(`(?i)url\(\s*(?:"([^"]*)"|'([^']*)'|([^)\s]*))\s*\)`)
+	cssCommentPattern = regexp.MustCompile(`/\*.*?\*/`)
+	dataImagePattern  = regexp.MustCompile(`(?i)^data:image/(png|jpe?g|gif|webp)[;,]`)
+)
+
+// ErrInvalidSVG is returned for SVG documents that are not well formed.
+var ErrInvalidSVG = errors.New("invalid svg document")
+
+// SanitizeSVGFile sanitizes the SVG document at path in place and reports
+// whether it changed.
+func SanitizeSVGFile(path string) (bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	sanitized, modified, err := SanitizeSVG(content)
+	if err != nil || !modified {
+		return false, err
+	}
+	return true, os.WriteFile(path, sanitized, 0600)
+}
+
+// SanitizeSVG removes active content from an SVG document: scripts,
+// foreignObject and other embedding elements, event handler attributes,
+// and references to anything outside the document, whether through links,
+// processing instructions, the doctype or CSS. Documents without such
+// content are returned unchanged.
+func SanitizeSVG(content []byte) ([]byte, bool, error) {
+	d := xml.NewDecoder(bytes.NewReader(content))
+	d.Strict = true
+	d.Entity = map[string]string{}
+
+	var (
+		out      bytes.Buffer
+		stack    []string
+		skip     int
+		modified bool
+	)
+	for {
+		tok, err := d.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: %v", ErrInvalidSVG, err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			stack = append(stack, qualifiedName(t.Name))
+			if skip > 0 {
+				skip++
+				continue
+			}
+			if dropSVGElement(t) {
+				skip, modified = 1, true
+				continue
+			}
+			attrs, changed := sanitizeSVGAttrs(t.Attr)
+			modified = modified || changed
+			out.WriteString("<" + qualifiedName(t.Name))
+			for _, a := range attrs {
+				out.WriteString(" " + qualifiedName(a.Name) + `="`)
+				_ = xml.EscapeText(&out, []byte(a.Value))
+				out.WriteString(`"`)
+			}
+			out.WriteString(">")
+		case xml.EndElement:
+			if len(stack) == 0 || stack[len(stack)-1] != qualifiedName(t.Name) {
+				return nil, false, fmt.Errorf("%w: unexpected end element %s", ErrInvalidSVG, qualifiedName(t.Name))
+			}
+			stack = stack[:len(stack)-1]
+			if skip > 0 {
+				skip--
+				continue
+			}
+			out.WriteString("</" + qualifiedName(t.Name) + ">")
+		case xml.CharData:
+			if skip > 0 {
+				continue
+			}
+			text := string(t)
+			if len(stack) > 0 && localName(stack[len(stack)-1]) == "style" {
+				var changed bool
+				text, changed = sanitizeCSS(text)
+				modified = modified || changed
+			}
+			_ = xml.EscapeText(&out, []byte(text))
+		case xml.Comment:
+			if skip > 0 {
+				continue
+			}
+			out.WriteString("<!--" + strings.ReplaceAll(string(t), "--", "- -") + "-->")
+		case xml.ProcInst:
+			if t.Target != "xml" || skip > 0 {
+				modified = true
+				continue
+			}
+			out.WriteString("<?xml " + string(t.Inst) + "?>")
+		case xml.Directive:
+			// The doctype may reference external definitions. Its simple
+			// internal entities, which editors use for namespace URIs, are
+			// kept for the references in the document.
+			for _, m := range svgEntityPattern.FindAllStringSubmatch(string(t), -1) {
+				d.Entity[m[1]] = m[2] + m[3]
+			}
+			modified = true
+		}
+	}
+	if len(stack) > 0 {
+		return nil, false, fmt.Errorf("%w: unclosed element %s", ErrInvalidSVG, stack[len(stack)-1])
+	}
+	if !modified {
+		return content, false, nil
+	}
+	return out.Bytes(), true, nil
+}
+
+func dropSVGElement(t xml.StartElement) bool {
+	name := strings.ToLower(t.Name.Local)
+	if svgDroppedElements[name] {
+		return true
+	}
+	if !svgAnimationElements[name] {
+		return false
+	}
+	for _, a := range t.Attr {
+		if strings.EqualFold(a.Name.Local, "attributeName") {
+			target := strings.ToLower(localName(strings.TrimSpace(a.Value)))
+			return target == "href" || target == "src" || strings.HasPrefix(target, "on")
+		}
+	}
+	return false
+}
+
+func sanitizeSVGAttrs(attrs []xml.Attr) ([]xml.Attr, bool) {
+	kept := attrs[:0:0]
+	modified := false
+	for _, a := range attrs {
+		name := strings.ToLower(a.Name.Local)
+		prefix := strings.ToLower(a.Name.Space)
+		switch {
+		case prefix == "xmlns" || (prefix == "" && name == "xmlns"):
+		case strings.HasPrefix(name, "on"),
+			prefix == "xml" && name == "base",
+			(name == "href" || name == "src") && !isLocalReference(a.Value):
+			modified = true
+			continue
+		case name == "style" || strings.Contains(strings.ToLower(a.Value), "url("):
+			value, changed := sanitizeCSS(a.Value)
+			modified = modified || changed
+			if changed && strings.TrimSpace(value) == "" {
+				continue
+			}
+			a.Value = value
+		}
+		kept = append(kept, a)
+	}
+	return kept, modified
+}
+
+// sanitizeCSS removes imports and replaces references to anything but
+// fragments of the document or embedded raster images. Styles that still
+// look active once escapes and comments are removed are dropped entirely.
+func sanitizeCSS(css string) (string, bool) {
+	out := cssImportPattern.ReplaceAllString(css, "")
+	out = cssURLPattern.ReplaceAllStringFunc(out, func(m string) string {
+		sub := cssURLPattern.FindStringSubmatch(m)
+		if isLocalReference(sub[1] + sub[2] + sub[3]) {
+			return m
+		}
+		return "none"
+	})
+
+	probe := strings.ToLower(cssCommentPattern.ReplaceAllString(decodeCSSEscapes(out), ""))
+	if strings.Contains(probe, "@import") || strings.Contains(probe, "javascript:") ||
+		strings.Contains(probe, "expression(") || hasExternalURL(probe) {
+		out = ""
+	}
+	return out, out != css
+}
+
+// decodeCSSEscapes resolves backslash escapes, which CSS allows anywhere
+// including in function names.
+func decodeCSSEscapes(css string) string {
+	if !strings.Contains(css, `\`) {
+		return css
+	}
+	var sb strings.Builder
+	for i := 0; i < len(css); i++ {
+		if css[i] != '\\' || i+1 >= len(css) {
+			sb.WriteByte(css[i])
+			continue
+		}
+		j := i + 1
+		for j < len(css) && j-i <= 6 && isHexDigit(css[j]) {
+			j++
+		}
+		if j == i+1 {
+			sb.WriteByte(css[j])
+			i = j
+			continue
+		}
+		code, _ := strconv.ParseUint(css[i+1:j], 16, 32)
+		sb.WriteRune(rune(code))
+		if j < len(css) && (css[j] == ' ' || css[j] == '\t' || css[j] == '\n') {
+			j++
+		}
+		i = j - 1
+	}
+	return sb.String()
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func hasExternalURL(css string) bool {
+	for _, sub := range cssURLPattern.FindAllStringSubmatch(css, -1) {
+		if !isLocalReference(sub[1] + sub[2] + sub[3]) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLocalReference reports whether a link stays within the document.
+func isLocalReference(ref string) bool {
+	ref = strings.TrimSpace(ref)
+	return strings.HasPrefix(ref, "#") || dataImagePattern.MatchString(ref)
+}
+
+func qualifiedName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return n.Space + ":" + n.Local
+}
+
+func localName(qualified string) string {
+	if i := strings.IndexByte(qualified, ':'); i >= 0 {
+		return qualified[i+1:]
+	}
+	return qualified
+}
@@ -0,0 +1,117 @@
+package imagemeta
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeSVG(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		contains []string
+		absent   []string
+	}{
+		{
+			name:     "script_element",
+			input:    `<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script><circle r="4"/></svg>`,
+			contains: []string{`<circle r="4"></circle>`},
+			absent:   []string{"script", "alert"},
+		},
+		{
+			name:     "event_handlers",
+			input:    `<svg xmlns="http://www.w3.org/2000/svg" onload="alert(1)"><rect ONCLICK="x()" width="2"/></svg>`,
+			contains: []string{`<rect width="2">`},
+			absent:   []string{"onload", "ONCLICK", "alert"},
+		},
+		{
+			name:   "foreign_object",
+			input:  `<svg xmlns="http://www.w3.org/2000/svg"><foreignObject><body xmlns="http://www.w3.org/1999/xhtml"><iframe src="https://evil.example"/></body></foreignObject></svg>`,
+			absent: []string{"foreignObject", "iframe", "evil"},
+		},
+		{
+			name: "external_references",
+			input: `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink">` +
+				`<use xlink:href="https://evil.example/sprite.svg#icon"/><use href="#local"/>` +
+				`<a href="javascript:alert(1)"><text>x</text></a><image href="data:image/png;base64,AAAA"/></svg>`,
+			contains: []string{`xmlns:xlink="http://www.w3.org/1999/xlink"`, `href="#local"`, `href="data:image/png;base64,AAAA"`},
+			absent:   []string{"evil", "javascript"},
+		},
+		{
+			name: "css_references",
+			input: `<svg xmlns="http://www.w3.org/2000/svg"><style>@import url(https://evil.example/a.css); .a { fill: url(#g) }` +
+				` .b { background: url('https://evil.example/t.png') }</style><rect style="fill:url(https://evil.example/p)" fill="url(#g)"/></svg>`,
+			contains: []string{"fill: url(#g)", `fill="url(#g)"`, "background: none"},
+			absent:   []string{"evil", "@import"},
+		},
+		{
+			name:   "escaped_css_is_dropped",
+			input:  `<svg xmlns="http://www.w3.org/2000/svg"><rect style="background:u\72l(https://evil.example)"/></svg>`,
+			absent: []string{"evil", "style"},
+		},
+		{
+			name:     "animation_targeting_links",
+			input:    `<svg xmlns="http://www.w3.org/2000/svg"><a><set attributeName="href" to="javascript:alert(1)"/><animate attributeName="r" to="5"/></a></svg>`,
+			contains: []string{`<animate attributeName="r" to="5">`},
+			absent:   []string{"javascript", "<set"},
+		},
+		{
+			name: "doctype_entities_and_stylesheets",
+			input: `<?xml version="1.0"?><?xml-stylesheet href="https://evil.example/s.css"?>` +
+				`<!DOCTYPE svg PUBLIC "-//W3C//DTD SVG 1.1//EN" "http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd" [<!ENTITY ns_svg "http://www.w3.org/2000/svg">]>` +
+				`<svg xmlns="&ns_svg;"><path d="M0 0"/></svg>`,
+			contains: []string{`<?xml version="1.0"?>`, `<svg xmlns="http://www.w3.org/2000/svg">`},
+			absent:   []string{"evil", "DOCTYPE", "ENTITY"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, modified, err := SanitizeSVG([]byte(tc.input))
+			require.NoError(t, err)
+			assert.True(t, modified)
+			for _, s := range tc.contains {
+				assert.Contains(t, string(out), s)
+			}
+			for _, s := range tc.absent {
+				assert.NotContains(t, string(out), s)
+			}
+		})
+	}
+}
+
+func TestSanitizeSVGUnchanged(t *testing.T) {
+	input := []byte("<svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 4 4\">\n  <!-- logo -->\n  <circle cx='2' cy='2' r='2' fill='url(#g)'/>\n</svg>\n")
+	out, modified, err := SanitizeSVG(input)
+	require.NoError(t, err)
+	assert.False(t, modified)
+	assert.Equal(t, input, out)
+}
+
+func TestSanitizeSVGInvalid(t *testing.T) {
+	for _, input := range []string{
+		`<svg><script></a>alert(1)</script></svg>`,
+		`<svg><g></svg>`,
+		`<svg>&undefined;</svg>`,
+		`<svg><g>`,
+	} {
+		_, _, err := SanitizeSVG([]byte(input))
+		assert.ErrorIs(t, err, ErrInvalidSVG, input)
+	}
+}
+
+func TestSanitizeSVGFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "content")
+	require.NoError(t, os.WriteFile(path, []byte(`<svg onload="x()"/>`), 0600))
+
+	modified, err := SanitizeSVGFile(path)
+	require.NoError(t, err)
+	assert.True(t, modified)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "<svg></svg>", string(content))
+}
@@ -0,0 +1,133 @@
+// Package mediainfo reads intrinsic properties such as dimensions, duration,
+// codecs and page counts from media headers without decoding the content.
+package mediainfo
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif" // registers the gif decoder for DecodeConfig
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+
+	"github.com/antinvestor/service-files/apps/default/service/imagemeta"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	_ "golang.org/x/image/webp" // registers the webp decoder for DecodeConfig
+)
+
+// Format names reported in MediaProperties.Format beyond the image formats
+// of the imagemeta package.
+const (
+	FormatGIF  = "gif"
+	FormatMP4  = "mp4"
+	FormatMOV  = "mov"
+	FormatWebM = "webm"
+	FormatMKV  = "mkv"
+	FormatMP3  = "mp3"
+	FormatPDF  = "pdf"
+)
+
+const headerLength = 64
+
+var errUnrecognised = errors.New("unrecognised media")
+
+// Extract reads the properties of the file at path. It returns nil without an
+// error when the content is not a supported format.
+func Extract(path string) (*types.MediaProperties, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, headerLength)
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	header = header[:n]
+
+	var props *types.MediaProperties
+	switch format := sniff(header); format {
+	case imagemeta.FormatJPEG, imagemeta.FormatPNG, imagemeta.FormatWebP, FormatGIF:
+		props, err = extractImage(io.NewSectionReader(file, 0, info.Size()), format)
+	case FormatMP4:
+		props, err = extractMP4(file, info.Size())
+	case FormatWebM:
+		props, err = extractEBML(file, info.Size())
+	case FormatMP3:
+		props, err = extractMP3(file, info.Size())
+	case FormatPDF:
+		props, err = extractPDF(file, info.Size())
+	default:
+		return nil, nil
+	}
+	if errors.Is(err, errUnrecognised) {
+		return nil, nil
+	}
+	return props, err
+}
+
+// sniff identifies the container from its leading bytes. Matroska and WebM
+// share a signature and are told apart by the document type later.
+func sniff(header []byte) string {
+	if format := imagemeta.Sniff(header); format != "" {
+		return format
+	}
+	switch {
+	case bytes.HasPrefix(header, []byte("GIF87a")), bytes.HasPrefix(header, []byte("GIF89a")):
+		return FormatGIF
+	case len(header) >= 8 && string(header[4:8]) == "ftyp":
+		return FormatMP4
+	case bytes.HasPrefix(header, []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		return FormatWebM
+	case bytes.HasPrefix(header, []byte("%PDF-")):
+		return FormatPDF
+	case bytes.HasPrefix(header, []byte("ID3")):
+		return FormatMP3
+	case len(header) >= 4:
+		if _, ok := parseMP3Header(header); ok {
+			return FormatMP3
+		}
+	}
+	return ""
+}
+
+func extractImage(r io.Reader, format string) (*types.MediaProperties, error) {
+	cfg, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return nil, err
+	}
+	return &types.MediaProperties{
+		Format:     format,
+		Width:      cfg.Width,
+		Height:     cfg.Height,
+		ColorSpace: colorSpace(cfg.ColorModel),
+	}, nil
+}
+
+func colorSpace(model color.Model) string {
+	if _, ok := model.(color.Palette); ok {
+		return "indexed"
+	}
+	switch model {
+	case color.GrayModel, color.Gray16Model:
+		return "gray"
+	case color.CMYKModel:
+		return "cmyk"
+	case color.NRGBAModel, color.NRGBA64Model, color.NYCbCrAModel:
+		return "rgba"
+	case color.RGBAModel, color.RGBA64Model, color.YCbCrModel:
+		return "rgb"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,274 @@
+package mediainfo
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func extractBytes(t *testing.T, content []byte) *types.MediaProperties {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "content")
+	require.NoError(t, os.WriteFile(path, content, 0600))
+	props, err := Extract(path)
+	require.NoError(t, err)
+	return props
+}
+
+func TestExtractImages(t *testing.T) {
+	encode := func(fn func(*bytes.Buffer) error) []byte {
+		var buf bytes.Buffer
+		require.NoError(t, fn(&buf))
+		return buf.Bytes()
+	}
+
+	testCases := []struct {
+		name       string
+		content    []byte
+		format     string
+		colorSpace string
+	}{
+		{
+			name:       "jpeg",
+			content:    encode(func(b *bytes.Buffer) error { return jpeg.Encode(b, image.NewRGBA(image.Rect(0, 0, 40, 30)), nil) }),
+			format:     "jpeg",
+			colorSpace: "rgb",
+		},
+		{
+			name:       "png_with_alpha",
+			content:    encode(func(b *bytes.Buffer) error { return png.Encode(b, image.NewNRGBA(image.Rect(0, 0, 40, 30))) }),
+			format:     "png",
+			colorSpace: "rgba",
+		},
+		{
+			name:       "gray_png",
+			content:    encode(func(b *bytes.Buffer) error { return png.Encode(b, image.NewGray(image.Rect(0, 0, 40, 30))) }),
+			format:     "png",
+			colorSpace: "gray",
+		},
+		{
+			name: "gif",
+			content: encode(func(b *bytes.Buffer) error {
+				return gif.Encode(b, image.NewPaletted(image.Rect(0, 0, 40, 30), palette.Plan9), nil)
+			}),
+			format:     "gif",
+			colorSpace: "indexed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			props := extractBytes(t, tc.content)
+			require.NotNil(t, props)
+			assert.Equal(t, tc.format, props.Format)
+			assert.Equal(t, 40, props.Width)
+			assert.Equal(t, 30, props.Height)
+			assert.Equal(t, tc.colorSpace, props.ColorSpace)
+		})
+	}
+}
+
+func buildBox(typ string, payloads ...[]byte) []byte {
+	body := bytes.Join(payloads, nil)
+	out := binary.BigEndian.AppendUint32(nil, uint32(8+len(body)))
+	return append(append(out, typ...), body...)
+}
+
+func mp4Track(handler, codec string, width, height uint32) []byte {
+	tkhd := make([]byte, 84)
+	binary.BigEndian.PutUint32(tkhd[76:], width<<16)
+	binary.BigEndian.PutUint32(tkhd[80:], height<<16)
+
+	hdlr := make([]byte, 24)
+	copy(hdlr[8:], handler)
+
+	stsd := make([]byte, 8)
+	binary.BigEndian.PutUint32(stsd[4:], 1)
+	entry := buildBox(codec, make([]byte, 8))
+
+	return buildBox("trak",
+		buildBox("tkhd", tkhd),
+		buildBox("mdia",
+			buildBox("hdlr", hdlr),
+			buildBox("minf", buildBox("stbl", buildBox("stsd", stsd, entry))),
+		),
+	)
+}
+
+func TestExtractMP4(t *testing.T) {
+	mvhd := make([]byte, 100)
+	binary.BigEndian.PutUint32(mvhd[12:], 600)
+	binary.BigEndian.PutUint32(mvhd[16:], 600*95/10)
+
+	// The movie box follows the media data, as in files that were not
+	// optimised for streaming.
+	content := bytes.Join([][]byte{
+		buildBox("ftyp", []byte("isom\x00\x00\x02\x00isomavc1")),
+		buildBox("mdat", make([]byte, 4096)),
+		buildBox("moov",
+			buildBox("mvhd", mvhd),
+			mp4Track("vide", "avc1", 1280, 720),
+			mp4Track("soun", "mp4a", 0, 0),
+		),
+	}, nil)
+
+	props := extractBytes(t, content)
+	require.NotNil(t, props)
+	assert.Equal(t, "mp4", props.Format)
+	assert.Equal(t, int64(9500), props.DurationMillis)
+	assert.Equal(t, 1280, props.Width)
+	assert.Equal(t, 720, props.Height)
+	assert.Equal(t, []string{"avc1", "mp4a"}, props.Codecs)
+}
+
+func TestExtractMP3(t *testing.T) {
+	// An ID3v2 tag of 20 bytes followed by MPEG-1 layer III frames at
+	// 128kbps and 44.1kHz.
+	id3 := append([]byte("ID3\x04\x00\x00\x00\x00\x00\x14"), make([]byte, 20)...)
+	frameHeader := []byte{0xFF, 0xFB, 0x90, 0x44}
+
+	xing := append([]byte{}, frameHeader...)
+	xing = append(xing, make([]byte, 32)...)
+	xing = append(xing, "Xing"...)
+	xing = binary.BigEndian.AppendUint32(xing, 0x01)
+	xing = binary.BigEndian.AppendUint32(xing, 441)
+	xing = append(xing, make([]byte, 400)...)
+
+	props := extractBytes(t, append(id3, xing...))
+	require.NotNil(t, props)
+	assert.Equal(t, "mp3", props.Format)
+	assert.Equal(t, []string{"mp3"}, props.Codecs)
+	assert.Equal(t, int64(441*1152*1000/44100), props.DurationMillis)
+
+	cbr := append([]byte{}, frameHeader...)
+	cbr = append(cbr, make([]byte, 16000-4)...)
+	props = extractBytes(t, append(id3, cbr...))
+	require.NotNil(t, props)
+	assert.Equal(t, int64(1000), props.DurationMillis)
+}
+
+func ebml(id uint32, payloads ...[]byte) []byte {
+	var out []byte
+	switch {
+	case id > 0xFFFFFF:
+		out = binary.BigEndian.AppendUint32(nil, id)
+	case id > 0xFFFF:
+		out = []byte{byte(id >> 16), byte(id >> 8), byte(id)}
+	case id > 0xFF:
+		out = binary.BigEndian.AppendUint16(nil, uint16(id))
+	default:
+		out = []byte{byte(id)}
+	}
+	body := bytes.Join(payloads, nil)
+	size := binary.BigEndian.AppendUint64(nil, uint64(len(body)))
+	size[0] = 0x01
+	return append(append(out, size...), body...)
+}
+
+func ebmlUintBytes(v uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, v)
+}
+
+func TestExtractWebM(t *testing.T) {
+	duration := binary.BigEndian.AppendUint64(nil, math.Float64bits(12345))
+
+	segmentBody := bytes.Join([][]byte{
+		ebml(ebmlInfoID,
+			ebml(ebmlTimecodeScale, ebmlUintBytes(1_000_000)),
+			ebml(ebmlDurationID, duration),
+		),
+		ebml(ebmlTracksID,
+			ebml(ebmlTrackEntryID,
+				ebml(ebmlTrackTypeID, []byte{1}),
+				ebml(ebmlCodecID, []byte("V_VP9")),
+				ebml(ebmlVideoID,
+					ebml(ebmlPixelWidthID, ebmlUintBytes(1920)),
+					ebml(ebmlPixelHeightID, ebmlUintBytes(1080)),
+				),
+			),
+			ebml(ebmlTrackEntryID,
+				ebml(ebmlTrackTypeID, []byte{2}),
+				ebml(ebmlCodecID, []byte("A_OPUS")),
+			),
+		),
+		ebml(ebmlClusterID, make([]byte, 64)),
+	}, nil)
+
+	// Live recordings leave the segment size unknown.
+	segment := append([]byte{0x18, 0x53, 0x80, 0x67, 0x01, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, segmentBody...)
+	content := append(ebml(ebmlHeaderID, ebml(ebmlDocTypeID, []byte("webm"))), segment...)
+
+	props := extractBytes(t, content)
+	require.NotNil(t, props)
+	assert.Equal(t, "webm", props.Format)
+	assert.Equal(t, int64(12345), props.DurationMillis)
+	assert.Equal(t, 1920, props.Width)
+	assert.Equal(t, 1080, props.Height)
+	assert.Equal(t, []string{"vp9", "opus"}, props.Codecs)
+}
+
+func TestExtractPDF(t *testing.T) {
+	plain := []byte(`%PDF-1.4
+1 0 obj << /Type /Catalog /Pages 2 0 R >> endobj
+2 0 obj << /Type /Pages /Kids [3 0 R 4 0 R 5 0 R] /Count 3 >> endobj
+3 0 obj << /Type /Page /Parent 2 0 R >> endobj
+4 0 obj << /Type /Page /Parent 2 0 R >> endobj
+5 0 obj << /Type /Page /Parent 2 0 R >> endobj
+6 0 obj << /Title (Quarterly \(draft\) Report) /Author (Finance) >> endobj
+trailer << /Size 7 /Root 1 0 R /Info 6 0 R >>
+%%EOF
+`)
+	props := extractBytes(t, plain)
+	require.NotNil(t, props)
+	assert.Equal(t, "pdf", props.Format)
+	assert.Equal(t, 3, props.PageCount)
+	assert.Equal(t, "Quarterly (draft) Report", props.Title)
+
+	// PDF 1.5 files pack the catalog and page tree into compressed object
+	// streams and use UTF-16 titles.
+	packed := "1 0 2 43 "
+	objects := "<< /Type /Catalog /Pages 2 0 R >>          " + "<< /Type /Pages /Kids [] /Count 12 >>"
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	_, err := zw.Write([]byte(packed + objects))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	var doc bytes.Buffer
+	doc.WriteString("%PDF-1.5\n")
+	fmt.Fprintf(&doc, "7 0 obj << /Type /ObjStm /N 2 /First %d /Filter /FlateDecode /Length %d >>\nstream\n", len(packed), compressed.Len())
+	doc.Write(compressed.Bytes())
+	doc.WriteString("\nendstream\nendobj\n")
+	doc.WriteString("8 0 obj << /Title <FEFF00C9007400E9> >> endobj\n")
+	doc.WriteString("9 0 obj << /Type /XRef /Root 1 0 R /Info 8 0 R >> endobj\n%%EOF\n")
+
+	props = extractBytes(t, doc.Bytes())
+	require.NotNil(t, props)
+	assert.Equal(t, 12, props.PageCount)
+	assert.Equal(t, "Été", props.Title)
+}
+
+func TestExtractUnsupported(t *testing.T) {
+	assert.Nil(t, extractBytes(t, []byte("just some text")))
+	assert.Nil(t, extractBytes(t, nil))
+}
+
+func TestColorSpace(t *testing.T) {
+	assert.Equal(t, "cmyk", colorSpace(color.CMYKModel))
+	assert.Equal(t, "", colorSpace(color.AlphaModel))
+}
@@ -0,0 +1,161 @@
+package mediainfo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/antinvestor/service-files/apps/default/service/types"
+)
+
+// mp3ScanBytes bounds the search for the first frame after the ID3 tag.
+const mp3ScanBytes = 64 << 10
+
+var (
+	mpeg1Layer3Bitrates = [16]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0}
+	mpeg2Layer3Bitrates = [16]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0}
+	mpegSampleRates     = map[int][3]int{
+		1: {44100, 48000, 32000},
+		2: {22050, 24000, 16000},
+		3: {11025, 12000, 8000},
+	}
+)
+
+// mp3Frame is a decoded MPEG audio layer III frame header.
+type mp3Frame struct {
+	// version is 1 for MPEG-1, 2 for MPEG-2 and 3 for MPEG-2.5.
+	version     int
+	bitrateKbps int
+	sampleRate  int
+	mono        bool
+}
+
+func (f mp3Frame) samplesPerFrame() int {
+	if f.version == 1 {
+		return 1152
+	}
+	return 576
+}
+
+// sideInfoLength is the size of the side information that precedes a Xing
+// or Info header in the first frame.
+func (f mp3Frame) sideInfoLength() int {
+	switch {
+	case f.version == 1 && f.mono:
+		return 17
+	case f.version == 1:
+		return 32
+	case f.mono:
+		return 9
+	default:
+		return 17
+	}
+}
+
+func parseMP3Header(b []byte) (mp3Frame, bool) {
+	if len(b) < 4 || b[0] != 0xFF || b[1]&0xE0 != 0xE0 {
+		return mp3Frame{}, false
+	}
+	var frame mp3Frame
+	switch (b[1] >> 3) & 0x03 {
+	case 3:
+		frame.version = 1
+	case 2:
+		frame.version = 2
+	case 0:
+		frame.version = 3
+	default:
+		return mp3Frame{}, false
+	}
+	if (b[1]>>1)&0x03 != 1 {
+		// Only layer III is reported as mp3.
+		return mp3Frame{}, false
+	}
+	bitrateIndex := b[2] >> 4
+	sampleRateIndex := (b[2] >> 2) & 0x03
+	if bitrateIndex == 0 || bitrateIndex == 15 || sampleRateIndex == 3 {
+		return mp3Frame{}, false
+	}
+	if frame.version == 1 {
+		frame.bitrateKbps = mpeg1Layer3Bitrates[bitrateIndex]
+	} else {
+		frame.bitrateKbps = mpeg2Layer3Bitrates[bitrateIndex]
+	}
+	frame.sampleRate = mpegSampleRates[frame.version][sampleRateIndex]
+	frame.mono = b[3]>>6 == 3
+	return frame, true
+}
+
+// id3v2Length returns the length of a leading ID3v2 tag, or 0 when absent.
+func id3v2Length(header []byte) int64 {
+	if len(header) < 10 || !bytes.HasPrefix(header, []byte("ID3")) {
+		return 0
+	}
+	size := int64(header[6]&0x7F)<<21 | int64(header[7]&0x7F)<<14 | int64(header[8]&0x7F)<<7 | int64(header[9]&0x7F)
+	length := 10 + size
+	if header[5]&0x10 != 0 {
+		length += 10
+	}
+	return length
+}
+
+func extractMP3(r io.ReaderAt, size int64) (*types.MediaProperties, error) {
+	header := make([]byte, 10)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil, errUnrecognised
+	}
+	start := id3v2Length(header)
+
+	scan := make([]byte, min(mp3ScanBytes, max(size-start, 0)))
+	n, err := r.ReadAt(scan, start)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	scan = scan[:n]
+
+	frameOffset := -1
+	var frame mp3Frame
+	for i := 0; i+4 <= len(scan); i++ {
+		if f, ok := parseMP3Header(scan[i:]); ok {
+			frame, frameOffset = f, i
+			break
+		}
+	}
+	if frameOffset < 0 {
+		return nil, errUnrecognised
+	}
+
+	props := &types.MediaProperties{Format: FormatMP3, Codecs: []string{"mp3"}}
+
+	// VBR files describe their frame count in a Xing, Info or VBRI header
+	// inside the first frame.
+	frames := uint32(0)
+	if tag := frameOffset + 4 + frame.sideInfoLength(); tag+12 <= len(scan) {
+		switch string(scan[tag : tag+4]) {
+		case "Xing", "Info":
+			if binary.BigEndian.Uint32(scan[tag+4:])&0x01 != 0 {
+				frames = binary.BigEndian.Uint32(scan[tag+8:])
+			}
+		}
+	}
+	if vbri := frameOffset + 4 + 32; frames == 0 && vbri+18 <= len(scan) && string(scan[vbri:vbri+4]) == "VBRI" {
+		frames = binary.BigEndian.Uint32(scan[vbri+14:])
+	}
+
+	if frames > 0 {
+		props.DurationMillis = int64(frames) * int64(frame.samplesPerFrame()) * 1000 / int64(frame.sampleRate)
+		return props, nil
+	}
+
+	audioBytes := size - start - int64(frameOffset)
+	trailer := make([]byte, 3)
+	if size >= 128 {
+		if _, err = r.ReadAt(trailer, size-128); err == nil && string(trailer) == "TAG" {
+			audioBytes -= 128
+		}
+	}
+	if audioBytes > 0 {
+		props.DurationMillis = audioBytes * 8 / int64(frame.bitrateKbps)
+	}
+	return props, nil
+}
@@ -0,0 +1,219 @@
+package mediainfo
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/antinvestor/service-files/apps/default/service/types"
+)
+
+// maxMoovBytes bounds the movie box read into memory.
+const maxMoovBytes = 32 << 20
+
+type mp4Box struct {
+	typ     string
+	payload []byte
+}
+
+// readMP4BoxHeader reads the box header at offset and returns the type, the
+// header length and the total box size.
+func readMP4BoxHeader(r io.ReaderAt, offset, end int64) (string, int64, int64, error) {
+	header := make([]byte, 16)
+	if _, err := r.ReadAt(header[:8], offset); err != nil {
+		return "", 0, 0, err
+	}
+	size := int64(binary.BigEndian.Uint32(header))
+	typ := string(header[4:8])
+	headerLen := int64(8)
+	switch size {
+	case 0:
+		size = end - offset
+	case 1:
+		if _, err := r.ReadAt(header[8:16], offset+8); err != nil {
+			return "", 0, 0, err
+		}
+		size = int64(binary.BigEndian.Uint64(header[8:16]))
+		headerLen = 16
+	}
+	if size < headerLen || offset+size > end {
+		return "", 0, 0, errors.New("invalid mp4 box size")
+	}
+	return typ, headerLen, size, nil
+}
+
+// childBoxes splits an in-memory box payload into its children.
+func childBoxes(payload []byte) []mp4Box {
+	var boxes []mp4Box
+	for len(payload) >= 8 {
+		size := int(binary.BigEndian.Uint32(payload))
+		headerLen := 8
+		if size == 1 && len(payload) >= 16 {
+			size = int(binary.BigEndian.Uint64(payload[8:16]))
+			headerLen = 16
+		} else if size == 0 {
+			size = len(payload)
+		}
+		if size < headerLen || size > len(payload) {
+			break
+		}
+		boxes = append(boxes, mp4Box{typ: string(payload[4:8]), payload: payload[headerLen:size]})
+		payload = payload[size:]
+	}
+	return boxes
+}
+
+func findBox(boxes []mp4Box, typ string) *mp4Box {
+	for i := range boxes {
+		if boxes[i].typ == typ {
+			return &boxes[i]
+		}
+	}
+	return nil
+}
+
+func extractMP4(r io.ReaderAt, size int64) (*types.MediaProperties, error) {
+	props := &types.MediaProperties{Format: FormatMP4}
+
+	var moov []byte
+	for offset := int64(0); offset+8 <= size; {
+		typ, headerLen, boxSize, err := readMP4BoxHeader(r, offset, size)
+		if err != nil {
+			return nil, err
+		}
+		switch typ {
+		case "ftyp":
+			brand := make([]byte, 4)
+			if _, err = r.ReadAt(brand, offset+headerLen); err == nil && string(brand) == "qt  " {
+				props.Format = FormatMOV
+			}
+		case "moov":
+			if boxSize-headerLen > maxMoovBytes {
+				return nil, errors.New("mp4 movie box is too large")
+			}
+			moov = make([]byte, boxSize-headerLen)
+			if _, err = r.ReadAt(moov, offset+headerLen); err != nil {
+				return nil, err
+			}
+		}
+		if moov != nil {
+			break
+		}
+		offset += boxSize
+	}
+	if moov == nil {
+		return nil, errUnrecognised
+	}
+
+	children := childBoxes(moov)
+	var timescale uint32
+	if mvhd := findBox(children, "mvhd"); mvhd != nil {
+		var duration uint64
+		timescale, duration = fullBoxDuration(mvhd.payload, 12, 20)
+		props.DurationMillis = durationMillis(duration, timescale)
+	}
+	if props.DurationMillis == 0 {
+		// Fragmented files carry the overall duration in the movie extends
+		// header instead.
+		if mvex := findBox(children, "mvex"); mvex != nil {
+			if mehd := findBox(childBoxes(mvex.payload), "mehd"); mehd != nil {
+				props.DurationMillis = durationMillis(mehdDuration(mehd.payload), timescale)
+			}
+		}
+	}
+
+	for _, trak := range children {
+		if trak.typ != "trak" {
+			continue
+		}
+		readTrack(childBoxes(trak.payload), props)
+	}
+	return props, nil
+}
+
+// fullBoxDuration reads the timescale and duration of an mvhd or mdhd box.
+// v0Offset and v1Offset locate the timescale for each box version.
+func fullBoxDuration(payload []byte, v0Offset, v1Offset int) (uint32, uint64) {
+	if len(payload) < 4 {
+		return 0, 0
+	}
+	if payload[0] == 1 {
+		if len(payload) < v1Offset+12 {
+			return 0, 0
+		}
+		return binary.BigEndian.Uint32(payload[v1Offset:]), binary.BigEndian.Uint64(payload[v1Offset+4:])
+	}
+	if len(payload) < v0Offset+8 {
+		return 0, 0
+	}
+	return binary.BigEndian.Uint32(payload[v0Offset:]), uint64(binary.BigEndian.Uint32(payload[v0Offset+4:]))
+}
+
+func mehdDuration(payload []byte) uint64 {
+	if len(payload) >= 12 && payload[0] == 1 {
+		return binary.BigEndian.Uint64(payload[4:])
+	}
+	if len(payload) >= 8 {
+		return uint64(binary.BigEndian.Uint32(payload[4:]))
+	}
+	return 0
+}
+
+func durationMillis(duration uint64, timescale uint32) int64 {
+	if timescale == 0 || duration == 0 || duration == ^uint64(0) || duration == uint64(^uint32(0)) {
+		return 0
+	}
+	return int64(float64(duration) * 1000 / float64(timescale))
+}
+
+func readTrack(trak []mp4Box, props *types.MediaProperties) {
+	mdia := findBox(trak, "mdia")
+	if mdia == nil {
+		return
+	}
+	mdiaChildren := childBoxes(mdia.payload)
+
+	handler := ""
+	if hdlr := findBox(mdiaChildren, "hdlr"); hdlr != nil && len(hdlr.payload) >= 12 {
+		handler = string(hdlr.payload[8:12])
+	}
+	if handler != "vide" && handler != "soun" {
+		return
+	}
+
+	if codec := sampleEntryType(mdiaChildren); codec != "" {
+		props.Codecs = append(props.Codecs, codec)
+	}
+
+	if handler == "vide" && props.Width == 0 {
+		if tkhd := findBox(trak, "tkhd"); tkhd != nil {
+			offset := 76
+			if len(tkhd.payload) > 0 && tkhd.payload[0] == 1 {
+				offset = 88
+			}
+			if len(tkhd.payload) >= offset+8 {
+				props.Width = int(binary.BigEndian.Uint32(tkhd.payload[offset:]) >> 16)
+				props.Height = int(binary.BigEndian.Uint32(tkhd.payload[offset+4:]) >> 16)
+			}
+		}
+	}
+}
+
+// sampleEntryType returns the fourcc of the first sample description, which
+// names the codec, e.g. avc1 or mp4a.
+func sampleEntryType(mdia []mp4Box) string {
+	minf := findBox(mdia, "minf")
+	if minf == nil {
+		return ""
+	}
+	stbl := findBox(childBoxes(minf.payload), "stbl")
+	if stbl == nil {
+		return ""
+	}
+	stsd := findBox(childBoxes(stbl.payload), "stsd")
+	if stsd == nil || len(stsd.payload) < 16 {
+		return ""
+	}
+	return strings.TrimSpace(string(stsd.payload[12:16]))
+}
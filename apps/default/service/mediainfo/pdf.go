@@ -0,0 +1,319 @@
+package mediainfo
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/antinvestor/service-files/apps/default/service/types"
+)
+
+const (
+	// maxPDFScanBytes bounds how much of a document is scanned.
+	maxPDFScanBytes = 32 << 20
+	// maxObjectStreamBytes bounds a single inflated object stream.
+	maxObjectStreamBytes = 8 << 20
+	maxTitleLength       = 256
+)
+
+var (
+	pdfObjectPattern  = regexp.MustCompile(`(\d+)\s+\d+\s+obj\b`)
+	pdfRootPattern    = regexp.MustCompile(`/Root\s+(\d+)\s+\d+\s+R`)
+	pdfInfoPattern    = regexp.MustCompile(`/Info\s+(\d+)\s+\d+\s+R`)
+	pdfPagesRef       = regexp.MustCompile(`/Pages\s+(\d+)\s+\d+\s+R`)
+	pdfCountPattern   = regexp.MustCompile(`/Count\s+(\d+)`)
+	pdfPagesType      = regexp.MustCompile(`/Type\s*/Pages\b`)
+	pdfPageType       = regexp.MustCompile(`/Type\s*/Page\b`)
+	pdfObjStmType     = regexp.MustCompile(`/Type\s*/ObjStm\b`)
+	pdfFirstPattern   = regexp.MustCompile(`/First\s+(\d+)`)
+	pdfEncryptPattern = regexp.MustCompile(`/Encrypt\s+\d+\s+\d+\s+R`)
+	pdfTitlePattern   = regexp.MustCompile(`/Title\s*`)
+)
+
+func extractPDF(r io.ReaderAt, size int64) (*types.MediaProperties, error) {
+	content := make([]byte, min(size, maxPDFScanBytes))
+	n, err := r.ReadAt(content, 0)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	content = content[:n]
+
+	objects := pdfObjects(content)
+	props := &types.MediaProperties{Format: FormatPDF, PageCount: pdfPageCount(content, objects)}
+	if !pdfEncryptPattern.Match(content) {
+		props.Title = pdfTitle(content, objects)
+	}
+	return props, nil
+}
+
+// pdfObjects indexes object bodies by number, including those packed in
+// flate compressed object streams. Later definitions replace earlier ones,
+// matching incremental updates.
+func pdfObjects(content []byte) map[int][]byte {
+	objects := map[int][]byte{}
+	matches := pdfObjectPattern.FindAllSubmatchIndex(content, -1)
+	for i, m := range matches {
+		end := len(content)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		body := content[m[1]:end]
+		if j := bytes.Index(body, []byte("endobj")); j >= 0 {
+			body = body[:j]
+		}
+		number, _ := strconv.Atoi(string(content[m[2]:m[3]]))
+		objects[number] = body
+
+		if pdfObjStmType.Match(body) {
+			for num, packed := range unpackObjectStream(body) {
+				objects[num] = packed
+			}
+		}
+	}
+	return objects
+}
+
+// unpackObjectStream inflates an object stream and splits it into objects.
+func unpackObjectStream(body []byte) map[int][]byte {
+	if !bytes.Contains(body, []byte("/FlateDecode")) {
+		return nil
+	}
+	first := pdfFirstPattern.FindSubmatch(body)
+	if first == nil {
+		return nil
+	}
+	firstOffset, _ := strconv.Atoi(string(first[1]))
+
+	start := bytes.Index(body, []byte("stream"))
+	if start < 0 {
+		return nil
+	}
+	start += len("stream")
+	for start < len(body) && (body[start] == '\r' || body[start] == '\n') {
+		start++
+	}
+	end := bytes.LastIndex(body, []byte("endstream"))
+	if end < start {
+		return nil
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(body[start:end]))
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = zr.Close() }()
+	data, err := io.ReadAll(io.LimitReader(zr, maxObjectStreamBytes))
+	if err != nil && len(data) == 0 {
+		return nil
+	}
+	if firstOffset > len(data) {
+		return nil
+	}
+
+	fields := strings.Fields(string(data[:firstOffset]))
+	type entry struct{ number, offset int }
+	entries := make([]entry, 0, len(fields)/2)
+	for i := 0; i+1 < len(fields); i += 2 {
+		number, numErr := strconv.Atoi(fields[i])
+		offset, offErr := strconv.Atoi(fields[i+1])
+		if numErr != nil || offErr != nil || firstOffset+offset > len(data) {
+			return nil
+		}
+		entries = append(entries, entry{number: number, offset: firstOffset + offset})
+	}
+
+	objects := make(map[int][]byte, len(entries))
+	for i, e := range entries {
+		end := len(data)
+		if i+1 < len(entries) {
+			end = entries[i+1].offset
+		}
+		if end < e.offset {
+			continue
+		}
+		objects[e.number] = data[e.offset:end]
+	}
+	return objects
+}
+
+func lastReference(pattern *regexp.Regexp, content []byte) (int, bool) {
+	matches := pattern.FindAllSubmatch(content, -1)
+	if len(matches) == 0 {
+		return 0, false
+	}
+	number, err := strconv.Atoi(string(matches[len(matches)-1][1]))
+	return number, err == nil
+}
+
+func pdfCount(body []byte) int {
+	m := pdfCountPattern.FindSubmatch(body)
+	if m == nil {
+		return 0
+	}
+	count, _ := strconv.Atoi(string(m[1]))
+	return count
+}
+
+// pdfPageCount reads /Count of the page tree root referenced by the
+// catalog, falling back to the largest page tree node and then to counting
+// page objects.
+func pdfPageCount(content []byte, objects map[int][]byte) int {
+	if root, ok := lastReference(pdfRootPattern, content); ok {
+		if pages := pdfPagesRef.FindSubmatch(objects[root]); pages != nil {
+			number, _ := strconv.Atoi(string(pages[1]))
+			if count := pdfCount(objects[number]); count > 0 {
+				return count
+			}
+		}
+	}
+
+	largest, pageObjects := 0, 0
+	for _, body := range objects {
+		switch {
+		case pdfPagesType.Match(body):
+			largest = max(largest, pdfCount(body))
+		case pdfPageType.Match(body):
+			pageObjects++
+		}
+	}
+	if largest > 0 {
+		return largest
+	}
+	return pageObjects
+}
+
+func pdfTitle(content []byte, objects map[int][]byte) string {
+	info, ok := lastReference(pdfInfoPattern, content)
+	if !ok {
+		return ""
+	}
+	body := objects[info]
+	loc := pdfTitlePattern.FindIndex(body)
+	if loc == nil {
+		return ""
+	}
+
+	raw, ok := readPDFString(body[loc[1]:])
+	if !ok {
+		return ""
+	}
+	title := strings.TrimSpace(decodePDFText(raw))
+	if len(title) > maxTitleLength {
+		title = title[:maxTitleLength]
+	}
+	return strings.ToValidUTF8(title, "")
+}
+
+// readPDFString parses a literal (...) or hex <...> string.
+func readPDFString(b []byte) ([]byte, bool) {
+	if len(b) == 0 {
+		return nil, false
+	}
+	switch b[0] {
+	case '(':
+		return readPDFLiteral(b[1:])
+	case '<':
+		end := bytes.IndexByte(b, '>')
+		if end < 0 {
+			return nil, false
+		}
+		hex := bytes.Map(func(r rune) rune {
+			if strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return r
+			}
+			return -1
+		}, b[1:end])
+		if len(hex)%2 == 1 {
+			hex = append(hex, '0')
+		}
+		out := make([]byte, len(hex)/2)
+		for i := range out {
+			v, err := strconv.ParseUint(string(hex[2*i:2*i+2]), 16, 8)
+			if err != nil {
+				return nil, false
+			}
+			out[i] = byte(v)
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
+
+func readPDFLiteral(b []byte) ([]byte, bool) {
+	var out []byte
+	depth := 1
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		switch c {
+		case '\\':
+			i++
+			if i >= len(b) {
+				return nil, false
+			}
+			switch e := b[i]; e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b':
+				out = append(out, '\b')
+			case 'f':
+				out = append(out, '\f')
+			case '\r', '\n':
+				// Line continuation.
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for j := 0; j < 2 && i+1 < len(b) && b[i+1] >= '0' && b[i+1] <= '7'; j++ {
+						i++
+						v = v*8 + int(b[i]-'0')
+					}
+					out = append(out, byte(v))
+				} else {
+					out = append(out, e)
+				}
+			}
+		case '(':
+			depth++
+			out = append(out, c)
+		case ')':
+			depth--
+			if depth == 0 {
+				return out, true
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return nil, false
+}
+
+// decodePDFText decodes a PDF text string, which is UTF-16BE when it starts
+// with a byte order mark and PDFDocEncoding, approximated by Latin-1,
+// otherwise.
+func decodePDFText(raw []byte) string {
+	if len(raw) >= 2 && raw[0] == 0xFE && raw[1] == 0xFF {
+		units := make([]uint16, 0, len(raw)/2)
+		for i := 2; i+1 < len(raw); i += 2 {
+			units = append(units, uint16(raw[i])<<8|uint16(raw[i+1]))
+		}
+		return string(utf16.Decode(units))
+	}
+	if bytes.HasPrefix(raw, []byte{0xEF, 0xBB, 0xBF}) && utf8.Valid(raw[3:]) {
+		return string(raw[3:])
+	}
+	runes := make([]rune, len(raw))
+	for i, c := range raw {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
@@ -0,0 +1,269 @@
+package mediainfo
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/antinvestor/service-files/apps/default/service/types"
+)
+
+// EBML element IDs read from WebM and Matroska files.
+const (
+	ebmlHeaderID      = 0x1A45DFA3
+	ebmlDocTypeID     = 0x4282
+	ebmlSegmentID     = 0x18538067
+	ebmlInfoID        = 0x1549A966
+	ebmlTimecodeScale = 0x2AD7B1
+	ebmlDurationID    = 0x4489
+	ebmlTracksID      = 0x1654AE6B
+	ebmlTrackEntryID  = 0xAE
+	ebmlTrackTypeID   = 0x83
+	ebmlCodecID       = 0x86
+	ebmlVideoID       = 0xE0
+	ebmlPixelWidthID  = 0xB0
+	ebmlPixelHeightID = 0xBA
+	ebmlClusterID     = 0x1F43B675
+
+	// maxEBMLElementBytes bounds the header elements read into memory.
+	maxEBMLElementBytes = 1 << 20
+	ebmlUnknownSize     = -1
+)
+
+var errInvalidEBML = errors.New("invalid ebml data")
+
+// webmCodecs maps Matroska codec IDs to short codec names.
+var webmCodecs = map[string]string{
+	"V_VP8":    "vp8",
+	"V_VP9":    "vp9",
+	"V_AV1":    "av1",
+	"A_OPUS":   "opus",
+	"A_VORBIS": "vorbis",
+}
+
+// readEBMLVint reads a variable length integer. IDs keep their length
+// marker bits; sizes have them removed and report all ones as unknown.
+func readEBMLVint(b []byte, keepMarker bool) (int64, int, error) {
+	if len(b) == 0 || b[0] == 0 {
+		return 0, 0, errInvalidEBML
+	}
+	length := 1
+	for mask := byte(0x80); b[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if length > 8 || len(b) < length {
+		return 0, 0, errInvalidEBML
+	}
+
+	value := int64(b[0])
+	if !keepMarker {
+		value &= int64(0xFF >> length)
+	}
+	allOnes := value == int64(0xFF>>length)
+	for _, c := range b[1:length] {
+		value = value<<8 | int64(c)
+		allOnes = allOnes && c == 0xFF
+	}
+	if !keepMarker && allOnes {
+		return ebmlUnknownSize, length, nil
+	}
+	return value, length, nil
+}
+
+// readEBMLElementHeader reads the ID and payload size of the element at
+// offset, returning the header length.
+func readEBMLElementHeader(r io.ReaderAt, offset int64) (int64, int64, int64, error) {
+	header := make([]byte, 12)
+	n, err := r.ReadAt(header, offset)
+	if n == 0 {
+		if err == nil {
+			err = io.EOF
+		}
+		return 0, 0, 0, err
+	}
+	header = header[:n]
+
+	id, idLen, err := readEBMLVint(header, true)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	size, sizeLen, err := readEBMLVint(header[idLen:], false)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return id, size, int64(idLen + sizeLen), nil
+}
+
+type ebmlElement struct {
+	id      int64
+	payload []byte
+}
+
+// ebmlChildren splits an in-memory master element into its children.
+func ebmlChildren(payload []byte) []ebmlElement {
+	var children []ebmlElement
+	for len(payload) > 0 {
+		id, idLen, err := readEBMLVint(payload, true)
+		if err != nil {
+			break
+		}
+		size, sizeLen, err := readEBMLVint(payload[idLen:], false)
+		if err != nil || size < 0 || int64(idLen+sizeLen)+size > int64(len(payload)) {
+			break
+		}
+		start := idLen + sizeLen
+		children = append(children, ebmlElement{id: id, payload: payload[start : start+int(size)]})
+		payload = payload[start+int(size):]
+	}
+	return children
+}
+
+func ebmlUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+func ebmlFloat(b []byte) float64 {
+	switch len(b) {
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b)))
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(b))
+	default:
+		return 0
+	}
+}
+
+func readEBMLPayload(r io.ReaderAt, offset, size int64) ([]byte, error) {
+	if size < 0 || size > maxEBMLElementBytes {
+		return nil, errInvalidEBML
+	}
+	payload := make([]byte, size)
+	if _, err := r.ReadAt(payload, offset); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func extractEBML(r io.ReaderAt, size int64) (*types.MediaProperties, error) {
+	id, headerSize, headerLen, err := readEBMLElementHeader(r, 0)
+	if err != nil || id != ebmlHeaderID {
+		return nil, errUnrecognised
+	}
+	header, err := readEBMLPayload(r, headerLen, headerSize)
+	if err != nil {
+		return nil, err
+	}
+
+	props := &types.MediaProperties{Format: FormatMKV}
+	for _, child := range ebmlChildren(header) {
+		if child.id == ebmlDocTypeID && string(child.payload) == "webm" {
+			props.Format = FormatWebM
+		}
+	}
+
+	offset := headerLen + headerSize
+	id, segmentSize, segmentHeaderLen, err := readEBMLElementHeader(r, offset)
+	if err != nil || id != ebmlSegmentID {
+		return nil, errUnrecognised
+	}
+	offset += segmentHeaderLen
+	end := size
+	if segmentSize != ebmlUnknownSize {
+		end = min(size, offset+segmentSize)
+	}
+
+	var (
+		timecodeScale = uint64(1_000_000)
+		duration      float64
+		haveInfo      bool
+		haveTracks    bool
+	)
+	for offset < end && !(haveInfo && haveTracks) {
+		id, elementSize, elementHeaderLen, headerErr := readEBMLElementHeader(r, offset)
+		if headerErr != nil || elementSize == ebmlUnknownSize || id == ebmlClusterID {
+			break
+		}
+		payloadOffset := offset + elementHeaderLen
+		offset = payloadOffset + elementSize
+
+		switch id {
+		case ebmlInfoID:
+			payload, readErr := readEBMLPayload(r, payloadOffset, elementSize)
+			if readErr != nil {
+				return nil, readErr
+			}
+			for _, child := range ebmlChildren(payload) {
+				switch child.id {
+				case ebmlTimecodeScale:
+					if scale := ebmlUint(child.payload); scale > 0 {
+						timecodeScale = scale
+					}
+				case ebmlDurationID:
+					duration = ebmlFloat(child.payload)
+				}
+			}
+			haveInfo = true
+		case ebmlTracksID:
+			payload, readErr := readEBMLPayload(r, payloadOffset, elementSize)
+			if readErr != nil {
+				return nil, readErr
+			}
+			readEBMLTracks(payload, props)
+			haveTracks = true
+		}
+	}
+
+	if duration > 0 && !math.IsInf(duration, 0) {
+		props.DurationMillis = int64(duration * float64(timecodeScale) / 1e6)
+	}
+	return props, nil
+}
+
+func readEBMLTracks(payload []byte, props *types.MediaProperties) {
+	for _, entry := range ebmlChildren(payload) {
+		if entry.id != ebmlTrackEntryID {
+			continue
+		}
+		var (
+			codec     string
+			trackType uint64
+			video     []byte
+		)
+		for _, child := range ebmlChildren(entry.payload) {
+			switch child.id {
+			case ebmlCodecID:
+				codec = strings.TrimRight(string(child.payload), "\x00")
+			case ebmlTrackTypeID:
+				trackType = ebmlUint(child.payload)
+			case ebmlVideoID:
+				video = child.payload
+			}
+		}
+		if trackType != 1 && trackType != 2 {
+			continue
+		}
+		if codec != "" {
+			name, ok := webmCodecs[codec]
+			if !ok {
+				name = strings.ToLower(codec[strings.IndexByte(codec, '_')+1:])
+			}
+			props.Codecs = append(props.Codecs, name)
+		}
+		if trackType == 1 && props.Width == 0 {
+			for _, child := range ebmlChildren(video) {
+				switch child.id {
+				case ebmlPixelWidthID:
+					props.Width = int(ebmlUint(child.payload))
+				case ebmlPixelHeightID:
+					props.Height = int(ebmlUint(child.payload))
+				}
+			}
+		}
+	}
+}
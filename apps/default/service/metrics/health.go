@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const defaultDependencyCheckTimeout = 5 * time.Second
+
+// DependencyCheck is a named readiness check probing one dependency of the
+// service, such as the database or the storage provider. It satisfies the
+// service's health checker interfaces, so failures show up on /readyz.
+type DependencyCheck struct {
+	name    string
+	timeout time.Duration
+	probe   func(ctx context.Context) error
+}
+
+// NewDependencyCheck creates a readiness check running probe with the given
+// timeout; a zero timeout uses five seconds.
+func NewDependencyCheck(name string, timeout time.Duration, probe func(ctx context.Context) error) *DependencyCheck {
+	if timeout <= 0 {
+		timeout = defaultDependencyCheckTimeout
+	}
+	return &DependencyCheck{name: name, timeout: timeout, probe: probe}
+}
+
+// Name names the dependency in health reports.
+func (c *DependencyCheck) Name() string {
+	return c.name
+}
+
+// CheckHealth runs the probe, failing when it errors or times out.
+func (c *DependencyCheck) CheckHealth() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if err := c.probe(ctx); err != nil {
+		return fmt.Errorf("%s unreachable: %w", c.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// interceptor records every Connect RPC served by the handler it wraps.
+type interceptor struct {
+	metrics *Metrics
+}
+
+// Interceptor returns a Connect interceptor recording the count, duration
+// and result code of each RPC by procedure. Streaming RPCs are recorded when
+// the stream ends.
+func (m *Metrics) Interceptor() connect.Interceptor {
+	return &interceptor{metrics: m}
+}
+
+func (i *interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+
+		start := time.Now()
+		i.metrics.RecordActiveRequest(ctx, 1)
+		defer i.metrics.RecordActiveRequest(ctx, -1)
+
+		resp, err := next(ctx, req)
+		i.metrics.RecordRPC(ctx, req.Spec().Procedure, rpcCode(err), time.Since(start))
+		return resp, err
+	}
+}
+
+func (i *interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		start := time.Now()
+		i.metrics.RecordActiveRequest(ctx, 1)
+		defer i.metrics.RecordActiveRequest(ctx, -1)
+
+		err := next(ctx, conn)
+		i.metrics.RecordRPC(ctx, conn.Spec().Procedure, rpcCode(err), time.Since(start))
+		return err
+	}
+}
+
+// rpcCode names the Connect code of an RPC result; errors that are not
+// Connect errors are reported to clients as unknown.
+func rpcCode(err error) string {
+	if err == nil {
+		return "ok"
+	}
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		return connectErr.Code().String()
+	}
+	return connect.CodeUnknown.String()
+}
@@ -4,7 +4,8 @@
 // partition_id derived from the context's security claims. The historic
 // file_service_* metric names are preserved so existing dashboards and
 // alerts keep working; metrics are exported through the service's OTel
-// pipeline instead of the previous bespoke Prometheus text handler.
+// pipeline, which serves them for Prometheus scraping unless another
+// exporter is configured.
 package metrics
 
 import (
@@ -36,8 +37,11 @@ func writeIgnoreErr(w io.Writer, format string, args ...any) {
 // diagnostics read from it.
 type Metrics struct {
 	// Request instruments.
-	requestsTotalCounter telemetry.Counter
-	activeRequestsGauge  telemetry.Gauge
+	requestsTotalCounter   telemetry.Counter
+	requestDurationHist    telemetry.Histogram
+	activeRequestsGauge    telemetry.Gauge
+	rpcRequestsCounter     telemetry.Counter
+	rpcRequestDurationHist telemetry.Histogram
 
 	// File transfer instruments.
 	uploadsCounter        telemetry.Counter
@@ -58,6 +62,14 @@ type Metrics struct {
 	cacheHitsCounter   telemetry.Counter
 	cacheMissesCounter telemetry.Counter
 
+	// Storage provider, encryption and queue instruments.
+	providerOperationsCounter telemetry.Counter
+	providerDurationHist      telemetry.Histogram
+	encryptionCounter         telemetry.Counter
+	encryptionBytesCounter    telemetry.Counter
+	encryptionDurationHist    telemetry.Histogram
+	queueDepthGauge           telemetry.Gauge
+
 	// Internal state backing gauges and the Get* accessors.
 	requestsTotal    map[string]int64
 	requestsDuration map[string][]time.Duration
@@ -89,7 +101,14 @@ func NewMetrics() *Metrics {
 
 	return &Metrics{
 		requestsTotalCounter: bm.Counter("file_service_requests_total", "Total requests"),
-		activeRequestsGauge:  bm.Gauge("file_service_active_requests", "Current number of active requests"),
+		requestDurationHist: bm.Histogram(
+			"file_service_request_duration_milliseconds", "HTTP request duration in milliseconds",
+		),
+		activeRequestsGauge: bm.Gauge("file_service_active_requests", "Current number of active requests"),
+		rpcRequestsCounter:  bm.Counter("file_service_rpc_requests_total", "Total RPC requests"),
+		rpcRequestDurationHist: bm.Histogram(
+			"file_service_rpc_request_duration_milliseconds", "RPC request duration in milliseconds",
+		),
 
 		uploadsCounter: bm.Counter("file_service_uploads_total", "Total number of file uploads"),
 		uploadBytesCounter: bm.Counter(
@@ -117,6 +136,23 @@ func NewMetrics() *Metrics {
 		cacheHitsCounter:   bm.Counter("file_service_cache_hits_total", "Cache hits total"),
 		cacheMissesCounter: bm.Counter("file_service_cache_misses_total", "Cache misses total"),
 
+		providerOperationsCounter: bm.Counter(
+			"file_service_provider_operations_total", "Total storage provider operations",
+		),
+		providerDurationHist: bm.Histogram(
+			"file_service_provider_operation_duration_milliseconds", "Storage provider operation duration in milliseconds",
+		),
+		encryptionCounter: bm.Counter(
+			"file_service_encryption_operations_total", "Total content encryption and decryption operations",
+		),
+		encryptionBytesCounter: bm.Counter(
+			"file_service_encryption_bytes_total", "Total plaintext bytes encrypted or decrypted", metric.WithUnit("B"),
+		),
+		encryptionDurationHist: bm.Histogram(
+			"file_service_encryption_duration_milliseconds", "Content encryption and decryption duration in milliseconds",
+		),
+		queueDepthGauge: bm.Gauge("file_service_queue_depth", "Pending items per background queue"),
+
 		requestsTotal:    make(map[string]int64),
 		requestsDuration: make(map[string][]time.Duration),
 		cacheHits:        make(map[string]int64),
@@ -125,7 +161,7 @@ func NewMetrics() *Metrics {
 }
 
 // RecordRequest records a completed request for the given endpoint.
-func (m *Metrics) RecordRequest(ctx context.Context, method, path string, duration time.Duration, _ int) {
+func (m *Metrics) RecordRequest(ctx context.Context, method, path string, duration time.Duration, status int) {
 	key := method + ":" + path
 
 	m.mu.Lock()
@@ -139,6 +175,43 @@ func (m *Metrics) RecordRequest(ctx context.Context, method, path string, durati
 	m.mu.Unlock()
 
 	m.requestsTotalCounter.Add(ctx, 1, attribute.String("endpoint", key))
+	m.requestDurationHist.Record(ctx, milliseconds(duration),
+		attribute.String("endpoint", key), attribute.Int("status_code", status))
+}
+
+// RecordRPC records a completed Connect RPC with its procedure and code.
+func (m *Metrics) RecordRPC(ctx context.Context, procedure, code string, duration time.Duration) {
+	attrs := []attribute.KeyValue{attribute.String("procedure", procedure), attribute.String("code", code)}
+	m.rpcRequestsCounter.Add(ctx, 1, attrs...)
+	m.rpcRequestDurationHist.Record(ctx, milliseconds(duration), attrs...)
+}
+
+// RecordProviderOperation records a call to the storage provider.
+func (m *Metrics) RecordProviderOperation(ctx context.Context, provider, operation string, duration time.Duration, err error) {
+	attrs := []attribute.KeyValue{
+		attribute.String("provider", provider),
+		attribute.String("operation", operation),
+		attribute.String("outcome", outcome(err)),
+	}
+	m.providerOperationsCounter.Add(ctx, 1, attrs...)
+	m.providerDurationHist.Record(ctx, milliseconds(duration), attrs...)
+}
+
+// RecordEncryption records the encryption or decryption of content at rest.
+// bytes counts the plaintext processed.
+func (m *Metrics) RecordEncryption(ctx context.Context, operation string, bytes int64, duration time.Duration, err error) {
+	attrs := []attribute.KeyValue{
+		attribute.String("operation", operation),
+		attribute.String("outcome", outcome(err)),
+	}
+	m.encryptionCounter.Add(ctx, 1, attrs...)
+	m.encryptionBytesCounter.Add(ctx, bytes, attrs...)
+	m.encryptionDurationHist.Record(ctx, milliseconds(duration), attrs...)
+}
+
+// RecordQueueDepth records the pending items of a background queue.
+func (m *Metrics) RecordQueueDepth(ctx context.Context, queue string, depth int64) {
+	m.queueDepthGauge.Record(ctx, depth, attribute.String("queue", queue))
 }
 
 // RecordActiveRequest adjusts the active request gauge by delta.
@@ -331,7 +404,7 @@ func (m *Metrics) Middleware() func(http.Handler) http.Handler {
 			next.ServeHTTP(wrapped, r)
 
 			duration := time.Since(start)
-			m.RecordRequest(ctx, r.Method, r.URL.Path, duration, wrapped.statusCode)
+			m.RecordRequest(ctx, r.Method, endpoint(r), duration, wrapped.statusCode)
 
 			if duration > 5*time.Second {
 				util.Log(ctx).WithFields(map[string]any{
@@ -343,6 +416,27 @@ func (m *Metrics) Middleware() func(http.Handler) http.Handler {
 	}
 }
 
+// endpoint labels a request with the pattern the mux routed it by, keeping
+// media IDs in paths out of metric attributes. Requests not routed by a
+// ServeMux keep their path.
+func endpoint(r *http.Request) string {
+	if r.Pattern != "" {
+		return r.Pattern
+	}
+	return r.URL.Path
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func outcome(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
+
 // responseWriterWrapper wraps http.ResponseWriter to capture status code
 type responseWriterWrapper struct {
 	http.ResponseWriter
@@ -354,6 +448,18 @@ func (w *responseWriterWrapper) WriteHeader(statusCode int) {
 	w.ResponseWriter.WriteHeader(statusCode)
 }
 
+// Flush keeps streaming responses working behind the middleware.
+func (w *responseWriterWrapper) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the wrapped writer to http.ResponseController.
+func (w *responseWriterWrapper) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 // HealthCheckHandler returns a health check handler
 func (m *Metrics) HealthCheckHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
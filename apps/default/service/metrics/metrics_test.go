@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -9,12 +10,14 @@ import (
 	"testing"
 	"time"
 
+	"connectrpc.com/connect"
 	"github.com/pitabwire/frame/v2/security"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func TestNewMetrics(t *testing.T) {
@@ -357,6 +360,137 @@ func TestMetricsTenantAttribution(t *testing.T) {
 	require.True(t, gaugeMatched, "expected an active requests datapoint attributed to tenant-files-attr")
 }
 
+func TestMiddlewareLabelsRoutePattern(t *testing.T) {
+	m := NewMetrics()
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/media/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("GET", "/v1/media/download/server/abc123", nil)
+	m.Middleware()(mux).ServeHTTP(httptest.NewRecorder(), req)
+
+	reqMetrics := m.GetRequestMetrics()
+	assert.Equal(t, int64(1), reqMetrics["GET:/v1/media/"])
+	assert.NotContains(t, reqMetrics, "GET:/v1/media/download/server/abc123")
+}
+
+func TestMiddlewareKeepsFlusher(t *testing.T) {
+	m := NewMetrics()
+
+	var flushable bool
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, flushable = w.(http.Flusher)
+		require.NoError(t, http.NewResponseController(w).Flush())
+	})
+
+	m.Middleware()(handler).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/stream", nil))
+	assert.True(t, flushable, "streaming handlers need a flushable writer")
+}
+
+func TestInterceptorRecordsRPC(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	m := NewMetrics()
+
+	const procedure = "/files.test.v1.TestService/Lookup"
+	mux := http.NewServeMux()
+	mux.Handle(procedure, connect.NewUnaryHandler(procedure,
+		func(_ context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("missing"))
+		},
+		connect.WithInterceptors(m.Interceptor()),
+	))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := connect.NewClient[emptypb.Empty, emptypb.Empty](server.Client(), server.URL+procedure)
+	_, err := client.CallUnary(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	rpcs := findMetric(rm, "file_service_rpc_requests_total")
+	require.NotNil(t, rpcs)
+	sum, ok := rpcs.Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+
+	dp := sum.DataPoints[0]
+	assert.Equal(t, int64(1), dp.Value)
+	recordedProcedure, _ := dp.Attributes.Value("procedure")
+	assert.Equal(t, procedure, recordedProcedure.AsString())
+	code, _ := dp.Attributes.Value("code")
+	assert.Equal(t, "not_found", code.AsString())
+
+	require.NotNil(t, findMetric(rm, "file_service_rpc_request_duration_milliseconds"))
+	assert.Equal(t, int64(0), m.GetActiveRequests())
+}
+
+func TestPrometheusExporter(t *testing.T) {
+	reader, handler, err := NewPrometheusExporter()
+	require.NoError(t, err)
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+
+	m := NewMetrics()
+	ctx := context.Background()
+	m.RecordProviderOperation(ctx, "LOCAL", "upload", 20*time.Millisecond, nil)
+	m.RecordEncryption(ctx, "encrypt", 1024, 5*time.Millisecond, nil)
+	m.RecordQueueDepth(ctx, "thumbnail_jobs", 7)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", PrometheusPath, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	body := w.Body.String()
+	assert.Contains(t, body, `file_service_provider_operations_total{`)
+	assert.Contains(t, body, `operation="upload"`)
+	assert.Contains(t, body, `file_service_encryption_operations_total{`)
+	assert.Contains(t, body, `file_service_queue_depth{`)
+	assert.Contains(t, body, `queue="thumbnail_jobs"`)
+	assert.Contains(t, body, `file_service_encryption_bytes_total{`)
+	assert.Contains(t, body, `file_service_provider_operation_duration_milliseconds_bucket{`)
+}
+
+func TestPrometheusServerServesOnlyScrapes(t *testing.T) {
+	_, handler, err := NewPrometheusExporter()
+	require.NoError(t, err)
+	server := NewPrometheusServer("127.0.0.1:0", handler)
+
+	w := httptest.NewRecorder()
+	server.server.Handler.ServeHTTP(w, httptest.NewRequest("GET", PrometheusPath, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	server.server.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/v1/media/abc", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	server.Start(t.Context())
+	server.Stop()
+}
+
+func TestDependencyCheck(t *testing.T) {
+	healthy := NewDependencyCheck("database", 0, func(context.Context) error { return nil })
+	assert.Equal(t, "database", healthy.Name())
+	assert.NoError(t, healthy.CheckHealth())
+
+	failing := NewDependencyCheck("storage_provider", 0, func(context.Context) error {
+		return errors.New("bucket missing")
+	})
+	err := failing.CheckHealth()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "storage_provider unreachable")
+
+	slow := NewDependencyCheck("slow", 10*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.ErrorIs(t, slow.CheckHealth(), context.DeadlineExceeded)
+}
+
 func TestResponseWriterWrapper(t *testing.T) {
 	w := httptest.NewRecorder()
 	wrapped := &responseWriterWrapper{
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/pitabwire/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// PrometheusPath is where the scrape endpoint is served.
+const PrometheusPath = "/metrics"
+
+// NewPrometheusExporter creates an OTel metric reader backed by its own
+// Prometheus registry, and the handler serving that registry for scraping.
+// The reader must be installed on the service's meter provider for the
+// service metrics to appear on the endpoint. Unit suffixes are not appended,
+// so metrics keep their file_service_* names.
+func NewPrometheusExporter() (sdkmetric.Reader, http.Handler, error) {
+	registry := prometheus.NewRegistry()
+	exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry), otelprometheus.WithoutUnits())
+	if err != nil {
+		return nil, nil, err
+	}
+	return exporter, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}), nil
+}
+
+// PrometheusServer serves the scrape endpoint on an internal address of its
+// own, so metrics are not exposed on the public listener.
+type PrometheusServer struct {
+	server *http.Server
+	done   chan struct{}
+}
+
+// NewPrometheusServer creates a server answering scrapes of handler on
+// PrometheusPath at address.
+func NewPrometheusServer(address string, handler http.Handler) *PrometheusServer {
+	mux := http.NewServeMux()
+	mux.Handle(PrometheusPath, handler)
+	return &PrometheusServer{
+		server: &http.Server{
+			Addr:              address,
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+}
+
+// Start listens in the background until Stop is called.
+func (s *PrometheusServer) Start(ctx context.Context) {
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		err := s.server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			util.Log(ctx).WithError(err).Error("prometheus scrape endpoint stopped")
+		}
+	}()
+}
+
+// Stop shuts the server down, waiting for in-flight scrapes to finish.
+func (s *PrometheusServer) Stop() {
+	if s.done == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = s.server.Shutdown(ctx)
+	<-s.done
+}
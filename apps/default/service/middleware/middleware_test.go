@@ -23,6 +23,21 @@ func TestDefaultSecurityHeadersConfig(t *testing.T) {
 	assert.Equal(t, "max-age=31536000; includeSubDomains; preload", cfg.StrictTransportSecurity)
 }
 
+func TestSecurityHeadersConfigIsActiveContent(t *testing.T) {
+	cfg := DefaultSecurityHeadersConfig()
+
+	assert.True(t, cfg.IsActiveContent("image/svg+xml"))
+	assert.True(t, cfg.IsActiveContent("Text/HTML; charset=utf-8"))
+	assert.True(t, cfg.IsActiveContent("multipart/related"))
+	assert.False(t, cfg.IsActiveContent("image/png"))
+	assert.False(t, cfg.IsActiveContent("text/plain"))
+	assert.False(t, cfg.IsActiveContent(""))
+
+	custom := &SecurityHeadersConfig{ActiveContentTypes: []string{"application/pdf"}}
+	assert.True(t, custom.IsActiveContent("application/pdf"))
+	assert.False(t, custom.IsActiveContent("image/svg+xml"))
+}
+
 func TestDefaultCORSConfig(t *testing.T) {
 	cfg := DefaultCORSConfig()
 
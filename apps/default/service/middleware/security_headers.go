@@ -49,6 +49,16 @@ type SecurityHeadersConfig struct {
 	// CrossOriginResourcePolicy defines the Cross-Origin-Resource-Policy header value
 	// Default: "same-origin"
 	CrossOriginResourcePolicy string
+
+	// ActiveContentTypes lists content types a browser may execute when served
+	// inline. Downloads of them are forced to be attachments and carry
+	// ActiveContentSecurityPolicy. Entries ending in /* match by prefix.
+	// Default: HTML, SVG, XML and script types
+	ActiveContentTypes []string
+
+	// ActiveContentSecurityPolicy defines the CSP header value for active content
+	// Default: "default-src 'none'; style-src 'unsafe-inline'; sandbox"
+	ActiveContentSecurityPolicy string
 }
 
 // DefaultSecurityHeadersConfig returns sensible defaults for security headers
@@ -64,7 +74,33 @@ func DefaultSecurityHeadersConfig() *SecurityHeadersConfig {
 		CrossOriginEmbedderPolicy: "require-corp",
 		CrossOriginOpenerPolicy:   "same-origin",
 		CrossOriginResourcePolicy: "same-origin",
+		ActiveContentTypes: []string{
+			"text/html", "application/xhtml+xml", "image/svg+xml", "text/xml", "application/xml",
+			"text/javascript", "application/javascript", "application/ecmascript", "text/ecmascript",
+			"application/x-shockwave-flash", "multipart/*",
+		},
+		ActiveContentSecurityPolicy: "default-src 'none'; style-src 'unsafe-inline'; sandbox",
+	}
+}
+
+// IsActiveContent reports whether content of the given type is active and
+// must not be rendered inline.
+func (c *SecurityHeadersConfig) IsActiveContent(contentType string) bool {
+	mediaType := strings.ToLower(strings.TrimSpace(contentType))
+	if i := strings.IndexByte(mediaType, ';'); i >= 0 {
+		mediaType = strings.TrimSpace(mediaType[:i])
+	}
+	for _, active := range c.ActiveContentTypes {
+		active = strings.ToLower(active)
+		if prefix, ok := strings.CutSuffix(active, "*"); ok {
+			if strings.HasPrefix(mediaType, prefix) {
+				return true
+			}
+		} else if mediaType == active {
+			return true
+		}
 	}
+	return false
 }
 
 // SecurityHeadersMiddleware returns a middleware that adds security headers to all responses
@@ -0,0 +1,460 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/pitabwire/util"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	rateLimitKeyPrefix = "files:ratelimit:"
+	// uploadSlotTTL bounds how long a slot leaked by a replica that died
+	// mid-upload keeps counting against the limit.
+	uploadSlotTTL = time.Hour
+)
+
+// CounterStore holds the counters behind TenantRateLimiter. Frame's raw
+// caches satisfy it: the in-memory cache keeps counters per replica and the
+// Redis cache shares them between replicas.
+type CounterStore interface {
+	Increment(ctx context.Context, key string, delta int64) (int64, error)
+	Decrement(ctx context.Context, key string, delta int64) (int64, error)
+	Expire(ctx context.Context, key string, ttl time.Duration) error
+}
+
+// TenantRateLimiter enforces the configured per-tenant and per-user limits
+// on requests per second, concurrent uploads and download bytes per second.
+// Requests and bandwidth are counted in one second windows. Failures of the
+// counter store let traffic through rather than failing it.
+type TenantRateLimiter struct {
+	cfg     *config.FilesConfig
+	store   CounterStore
+	uploads map[string]bool
+	now     func() time.Time
+}
+
+// NewTenantRateLimiter creates a limiter counting in store. Operations
+// named in uploadOperations hold an upload slot while they run.
+func NewTenantRateLimiter(cfg *config.FilesConfig, store CounterStore, uploadOperations ...string) *TenantRateLimiter {
+	uploads := make(map[string]bool, len(uploadOperations))
+	for _, operation := range uploadOperations {
+		uploads[operation] = true
+	}
+	return &TenantRateLimiter{cfg: cfg, store: store, uploads: uploads, now: time.Now}
+}
+
+// errRateLimited is returned when a limit refuses a request.
+var errRateLimited = errors.New("rate limit exceeded")
+
+// rateLimitScope is a tenant or a user of a tenant an operation is counted
+// against, with the limits applying to it.
+type rateLimitScope struct {
+	key   string
+	limit config.RateLimit
+}
+
+// scopes returns the scopes of the caller of ctx for an operation; callers
+// without claims are not limited.
+func (l *TenantRateLimiter) scopes(ctx context.Context, operation string) []rateLimitScope {
+	claims := security.ClaimsFromContext(ctx)
+	if claims == nil {
+		return nil
+	}
+	tenantID := claims.GetTenantID()
+	subject, _ := claims.GetSubject()
+
+	rule, operationScoped := l.cfg.RateLimitRuleFor(tenantID, operation)
+	counted := "*"
+	if operationScoped {
+		counted = operation
+	}
+
+	var scopes []rateLimitScope
+	if tenantID != "" {
+		scopes = append(scopes, rateLimitScope{key: "t:" + tenantID + ":" + counted, limit: rule.Tenant})
+	}
+	if subject != "" {
+		scopes = append(scopes, rateLimitScope{key: "u:" + tenantID + ":" + subject + ":" + counted, limit: rule.User})
+	}
+	return scopes
+}
+
+// allowRequest counts a request against every scope, or none when a limit
+// is exceeded, so a request one scope refuses does not use up the budget of
+// another. When refused it returns how long to wait before retrying.
+func (l *TenantRateLimiter) allowRequest(ctx context.Context, scopes []rateLimitScope) (time.Duration, bool) {
+	now := l.now()
+	window := now.Unix()
+	ok, err := l.charge(ctx, "req", scopes, window, 1, requestLimit)
+	if err != nil {
+		util.Log(ctx).WithError(err).Warn("rate limit store unavailable, request not limited")
+		return 0, true
+	}
+	if !ok {
+		return time.Unix(window+1, 0).Sub(now), false
+	}
+	return 0, true
+}
+
+// acquireUpload takes an upload slot in each scope, returning the function
+// releasing them, or false when a scope has none left.
+func (l *TenantRateLimiter) acquireUpload(ctx context.Context, scopes []rateLimitScope) (func(), bool) {
+	var held []string
+	release := func() {
+		releaseCtx := context.WithoutCancel(ctx)
+		for _, key := range held {
+			if _, err := l.store.Decrement(releaseCtx, key, 1); err != nil {
+				util.Log(ctx).WithError(err).Warn("failed to release upload slot")
+			}
+		}
+	}
+
+	for _, scope := range scopes {
+		if scope.limit.ConcurrentUploads == 0 {
+			continue
+		}
+		key := rateLimitKeyPrefix + "up:" + scope.key
+		count, err := l.store.Increment(ctx, key, 1)
+		if err != nil {
+			util.Log(ctx).WithError(err).Warn("rate limit store unavailable, upload not limited")
+			continue
+		}
+		held = append(held, key)
+		if err = l.store.Expire(ctx, key, uploadSlotTTL); err != nil {
+			util.Log(ctx).WithError(err).Warn("failed to refresh upload slot expiry")
+		}
+		if count > scope.limit.ConcurrentUploads {
+			release()
+			return nil, false
+		}
+	}
+	return release, true
+}
+
+// waitBandwidth blocks until n bytes fit the download budget of every
+// scope, or ctx ends. Each chunk is charged to all scopes at once.
+func (l *TenantRateLimiter) waitBandwidth(ctx context.Context, scopes []rateLimitScope, n int64) error {
+	var chunkLimit int64
+	for _, scope := range scopes {
+		if limit := scope.limit.DownloadBytesPerSecond; limit > 0 && (chunkLimit == 0 || limit < chunkLimit) {
+			chunkLimit = limit
+		}
+	}
+	if chunkLimit == 0 {
+		return nil
+	}
+
+	for remaining := n; remaining > 0; {
+		chunk := min(remaining, chunkLimit)
+		now := l.now()
+		window := now.Unix()
+		ok, err := l.charge(ctx, "bw", scopes, window, chunk, bandwidthLimit)
+		if err != nil {
+			util.Log(ctx).WithError(err).Warn("rate limit store unavailable, download not shaped")
+			return nil
+		}
+		if ok {
+			remaining -= chunk
+			continue
+		}
+		// Over budget: the chunk is sent in a later window.
+		timer := time.NewTimer(time.Unix(window+1, 0).Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return nil
+}
+
+// bandwidthExhausted reports whether a scope has used up the download
+// budget of the current window, and if so how long until the next one.
+func (l *TenantRateLimiter) bandwidthExhausted(ctx context.Context, scopes []rateLimitScope) (time.Duration, bool) {
+	now := l.now()
+	window := now.Unix()
+	for _, scope := range scopes {
+		limit := scope.limit.DownloadBytesPerSecond
+		if limit == 0 {
+			continue
+		}
+		used, err := l.countInWindow(ctx, fmt.Sprintf("bw:%s:%d", scope.key, window), 0)
+		if err != nil {
+			util.Log(ctx).WithError(err).Warn("rate limit store unavailable, download not limited")
+			return 0, false
+		}
+		if used >= limit {
+			return time.Unix(window+1, 0).Sub(now), true
+		}
+	}
+	return 0, false
+}
+
+// chargeBandwidth counts n bytes already sent against the download budget
+// of every scope, leaving later requests of the window to be refused when
+// they overran it.
+func (l *TenantRateLimiter) chargeBandwidth(ctx context.Context, scopes []rateLimitScope, n int64) {
+	window := l.now().Unix()
+	for _, scope := range scopes {
+		if scope.limit.DownloadBytesPerSecond == 0 {
+			continue
+		}
+		if _, err := l.countInWindow(ctx, fmt.Sprintf("bw:%s:%d", scope.key, window), n); err != nil {
+			util.Log(ctx).WithError(err).Warn("rate limit store unavailable, download not counted")
+			return
+		}
+	}
+}
+
+// charge adds delta to the kind counter of the window in every scope with a
+// limit. When a scope would exceed its limit the counts taken are returned
+// and nothing is charged.
+func (l *TenantRateLimiter) charge(
+	ctx context.Context,
+	kind string,
+	scopes []rateLimitScope,
+	window, delta int64,
+	limitOf func(config.RateLimit) int64,
+) (bool, error) {
+	var charged []string
+	refund := func() {
+		refundCtx := context.WithoutCancel(ctx)
+		for _, key := range charged {
+			if _, err := l.store.Decrement(refundCtx, rateLimitKeyPrefix+key, delta); err != nil {
+				util.Log(ctx).WithError(err).Warn("failed to refund rate limit budget")
+			}
+		}
+	}
+
+	for _, scope := range scopes {
+		limit := limitOf(scope.limit)
+		if limit == 0 {
+			continue
+		}
+		key := fmt.Sprintf("%s:%s:%d", kind, scope.key, window)
+		count, err := l.countInWindow(ctx, key, delta)
+		if err != nil {
+			refund()
+			return false, err
+		}
+		charged = append(charged, key)
+		if count > limit {
+			refund()
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func requestLimit(limit config.RateLimit) int64 { return limit.RequestsPerSecond }
+
+func bandwidthLimit(limit config.RateLimit) int64 { return limit.DownloadBytesPerSecond }
+
+func (l *TenantRateLimiter) countInWindow(ctx context.Context, key string, delta int64) (int64, error) {
+	key = rateLimitKeyPrefix + key
+	count, err := l.store.Increment(ctx, key, delta)
+	if err != nil {
+		return 0, err
+	}
+	if count == delta {
+		// First use of the window; keep it long enough for clock skew
+		// between replicas.
+		if err = l.store.Expire(ctx, key, 5*time.Second); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// Interceptor returns a Connect interceptor applying the limits to RPCs by
+// method name. It must run after authentication so the caller's claims are
+// known. Response messages count against the download bandwidth: streamed
+// messages are shaped to it, while unary RPCs are refused before they run
+// once the budget of the window is used up.
+func (l *TenantRateLimiter) Interceptor() connect.Interceptor {
+	return &rateLimitInterceptor{limiter: l}
+}
+
+type rateLimitInterceptor struct {
+	limiter *TenantRateLimiter
+}
+
+func (i *rateLimitInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+
+		scopes, release, err := i.admit(ctx, req.Spec().Procedure)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		if retryAfter, exhausted := i.limiter.bandwidthExhausted(ctx, scopes); exhausted {
+			return nil, rateLimitedError(retryAfter)
+		}
+
+		resp, err := next(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		if msg, ok := resp.Any().(proto.Message); ok {
+			i.limiter.chargeBandwidth(ctx, scopes, int64(proto.Size(msg)))
+		}
+		return resp, nil
+	}
+}
+
+func (i *rateLimitInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *rateLimitInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		scopes, release, err := i.admit(ctx, conn.Spec().Procedure)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		return next(ctx, &throttledStreamConn{StreamingHandlerConn: conn, ctx: ctx, limiter: i.limiter, scopes: scopes})
+	}
+}
+
+// admit applies the request and upload limits to a procedure.
+func (i *rateLimitInterceptor) admit(ctx context.Context, procedure string) ([]rateLimitScope, func(), error) {
+	operation := procedure[strings.LastIndex(procedure, "/")+1:]
+	scopes := i.limiter.scopes(ctx, operation)
+
+	if retryAfter, ok := i.limiter.allowRequest(ctx, scopes); !ok {
+		return nil, nil, rateLimitedError(retryAfter)
+	}
+
+	release := func() {}
+	if i.limiter.uploads[operation] {
+		var ok bool
+		if release, ok = i.limiter.acquireUpload(ctx, scopes); !ok {
+			return nil, nil, connect.NewError(connect.CodeResourceExhausted,
+				fmt.Errorf("%w: too many concurrent uploads", errRateLimited))
+		}
+	}
+	return scopes, release, nil
+}
+
+func rateLimitedError(retryAfter time.Duration) error {
+	err := connect.NewError(connect.CodeResourceExhausted, errRateLimited)
+	err.Meta().Set("Retry-After", retryAfterSeconds(retryAfter))
+	return err
+}
+
+// throttledStreamConn shapes the messages a streaming RPC sends to the
+// download bandwidth of its caller.
+type throttledStreamConn struct {
+	connect.StreamingHandlerConn
+	ctx     context.Context
+	limiter *TenantRateLimiter
+	scopes  []rateLimitScope
+}
+
+func (c *throttledStreamConn) Send(msg any) error {
+	if message, ok := msg.(proto.Message); ok {
+		if err := c.limiter.waitBandwidth(c.ctx, c.scopes, int64(proto.Size(message))); err != nil {
+			return err
+		}
+	}
+	return c.StreamingHandlerConn.Send(msg)
+}
+
+// Middleware returns HTTP middleware applying the limits to media routes,
+// named http:<route> after the first path segment below /v1/media/. It
+// must run after authentication. GET response bodies are shaped to the
+// download bandwidth.
+func (l *TenantRateLimiter) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			operation := httpOperation(r)
+			scopes := l.scopes(ctx, operation)
+
+			if retryAfter, ok := l.allowRequest(ctx, scopes); !ok {
+				util.Log(ctx).WithField("operation", operation).Warn("rate limit exceeded")
+				writeRateLimited(w, retryAfter)
+				return
+			}
+
+			if l.uploads[operation] {
+				release, ok := l.acquireUpload(ctx, scopes)
+				if !ok {
+					util.Log(ctx).WithField("operation", operation).Warn("concurrent upload limit exceeded")
+					writeRateLimited(w, time.Second)
+					return
+				}
+				defer release()
+			}
+
+			if r.Method == http.MethodGet {
+				w = &throttledResponseWriter{ResponseWriter: w, ctx: ctx, limiter: l, scopes: scopes}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func httpOperation(r *http.Request) string {
+	route := strings.TrimPrefix(r.URL.Path, "/v1/media/")
+	if i := strings.IndexByte(route, '/'); i >= 0 {
+		route = route[:i]
+	}
+	return "http:" + route
+}
+
+func writeRateLimited(w http.ResponseWriter, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", retryAfterSeconds(retryAfter))
+	w.Header().Set("X-RateLimit-Remaining", "0")
+	w.WriteHeader(http.StatusTooManyRequests)
+	writeIgnoreErr(w, `{"error": "rate limit exceeded", "code": "rate_limit_exceeded"}`)
+}
+
+func retryAfterSeconds(retryAfter time.Duration) string {
+	return strconv.Itoa(max(int((retryAfter+time.Second-1)/time.Second), 1))
+}
+
+// throttledResponseWriter shapes a response body to the download bandwidth
+// of the caller.
+type throttledResponseWriter struct {
+	http.ResponseWriter
+	ctx     context.Context
+	limiter *TenantRateLimiter
+	scopes  []rateLimitScope
+}
+
+func (w *throttledResponseWriter) Write(p []byte) (int, error) {
+	if err := w.limiter.waitBandwidth(w.ctx, w.scopes, int64(len(p))); err != nil {
+		return 0, err
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush keeps streamed downloads flowing through the writer.
+func (w *throttledResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the wrapped writer to http.ResponseController.
+func (w *throttledResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,258 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/golang-jwt/jwt/v5"
+	framecache "github.com/pitabwire/frame/v2/cache"
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func userContext(ctx context.Context, tenantID, subject string) context.Context {
+	claims := &security.AuthenticationClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: subject},
+		TenantID:         tenantID,
+		PartitionID:      "partition",
+	}
+	return claims.ClaimsToContext(ctx)
+}
+
+func newTestTenantRateLimiter(cfg *config.FilesConfig) *TenantRateLimiter {
+	limiter := NewTenantRateLimiter(cfg, framecache.NewInMemoryCache(), "UploadContent", "http:upload")
+	// Pin the clock mid-second so request windows do not roll over.
+	now := time.Unix(time.Now().Unix(), int64(100*time.Millisecond))
+	limiter.now = func() time.Time { return now }
+	return limiter
+}
+
+func TestTenantRateLimiterRequests(t *testing.T) {
+	limiter := newTestTenantRateLimiter(&config.FilesConfig{
+		RateLimitTenantRequestsPerSecond: 3,
+		RateLimitUserRequestsPerSecond:   2,
+	})
+
+	alice := userContext(t.Context(), "tenant-a", "alice")
+	bob := userContext(t.Context(), "tenant-a", "bob")
+
+	for range 2 {
+		_, ok := limiter.allowRequest(alice, limiter.scopes(alice, "GetContent"))
+		require.True(t, ok)
+	}
+	retryAfter, ok := limiter.allowRequest(alice, limiter.scopes(alice, "GetContent"))
+	require.False(t, ok, "user limit reached")
+	assert.Equal(t, 900*time.Millisecond, retryAfter)
+
+	// Alice's rejected request did not count against the tenant.
+	_, ok = limiter.allowRequest(bob, limiter.scopes(bob, "GetContent"))
+	require.True(t, ok)
+	_, ok = limiter.allowRequest(bob, limiter.scopes(bob, "GetContent"))
+	require.False(t, ok, "tenant limit reached")
+
+	other := userContext(t.Context(), "tenant-b", "carol")
+	_, ok = limiter.allowRequest(other, limiter.scopes(other, "GetContent"))
+	require.True(t, ok, "tenants are limited separately")
+
+	_, ok = limiter.allowRequest(t.Context(), limiter.scopes(t.Context(), "GetContent"))
+	require.True(t, ok, "callers without claims are not limited")
+}
+
+func TestTenantRateLimiterOperationRules(t *testing.T) {
+	limiter := newTestTenantRateLimiter(&config.FilesConfig{
+		RateLimitUserRequestsPerSecond: 1,
+		RateLimitRules: config.RateLimitRules{
+			"*/GetContent": {User: config.RateLimit{RequestsPerSecond: 2}},
+		},
+	})
+	ctx := userContext(t.Context(), "tenant-a", "alice")
+
+	// Operations with their own rule are counted apart from the rest.
+	for range 2 {
+		_, ok := limiter.allowRequest(ctx, limiter.scopes(ctx, "GetContent"))
+		require.True(t, ok)
+	}
+	_, ok := limiter.allowRequest(ctx, limiter.scopes(ctx, "GetContent"))
+	require.False(t, ok)
+
+	_, ok = limiter.allowRequest(ctx, limiter.scopes(ctx, "ListContent"))
+	require.True(t, ok)
+	_, ok = limiter.allowRequest(ctx, limiter.scopes(ctx, "SearchMedia"))
+	require.False(t, ok)
+}
+
+func TestTenantRateLimiterUploads(t *testing.T) {
+	limiter := newTestTenantRateLimiter(&config.FilesConfig{RateLimitUserConcurrentUploads: 2})
+	ctx := userContext(t.Context(), "tenant-a", "alice")
+	scopes := limiter.scopes(ctx, "UploadContent")
+
+	releaseFirst, ok := limiter.acquireUpload(ctx, scopes)
+	require.True(t, ok)
+	releaseSecond, ok := limiter.acquireUpload(ctx, scopes)
+	require.True(t, ok)
+	_, ok = limiter.acquireUpload(ctx, scopes)
+	require.False(t, ok)
+
+	releaseFirst()
+	releaseThird, ok := limiter.acquireUpload(ctx, scopes)
+	require.True(t, ok, "released slots are reusable")
+	releaseSecond()
+	releaseThird()
+}
+
+func TestTenantRateLimiterBandwidth(t *testing.T) {
+	limiter := NewTenantRateLimiter(&config.FilesConfig{RateLimitUserDownloadBytesPerSecond: 100},
+		framecache.NewInMemoryCache())
+	ctx := userContext(t.Context(), "tenant-a", "alice")
+	scopes := limiter.scopes(ctx, "DownloadContent")
+
+	start := time.Now()
+	require.NoError(t, limiter.waitBandwidth(ctx, scopes, 150))
+	assert.Greater(t, time.Now().Unix(), start.Unix(), "bytes over the budget wait for the next window")
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.NoError(t, limiter.waitBandwidth(cancelled, scopes, 50), "within the budget no wait is needed")
+	require.ErrorIs(t, limiter.waitBandwidth(cancelled, scopes, 100), context.Canceled)
+}
+
+func TestTenantRateLimiterMiddleware(t *testing.T) {
+	limiter := newTestTenantRateLimiter(&config.FilesConfig{
+		RateLimitUserConcurrentUploads: 1,
+		RateLimitRules: config.RateLimitRules{
+			"*/http:download": {User: config.RateLimit{RequestsPerSecond: 1}},
+		},
+	})
+	ctx := userContext(t.Context(), "tenant-a", "alice")
+
+	uploadStarted, finishUpload := make(chan struct{}), make(chan struct{})
+	handler := limiter.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v1/media/upload") {
+			close(uploadStarted)
+			<-finishUpload
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, path, nil).WithContext(ctx))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/v1/media/download/server/media").Code)
+	limited := serve(http.MethodGet, "/v1/media/download/server/media")
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "1", limited.Header().Get("Retry-After"))
+	assert.Contains(t, limited.Body.String(), "rate_limit_exceeded")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.Equal(t, http.StatusOK, serve(http.MethodPost, "/v1/media/upload").Code)
+	}()
+	<-uploadStarted
+	assert.Equal(t, http.StatusTooManyRequests, serve(http.MethodPost, "/v1/media/upload").Code)
+	close(finishUpload)
+	<-done
+}
+
+func TestTenantRateLimiterInterceptor(t *testing.T) {
+	limiter := newTestTenantRateLimiter(&config.FilesConfig{RateLimitUserRequestsPerSecond: 1})
+	interceptor := limiter.Interceptor()
+
+	mux := http.NewServeMux()
+	mux.Handle("/files.v1.FilesService/GetContent", connect.NewUnaryHandler(
+		"/files.v1.FilesService/GetContent",
+		func(_ context.Context, req *connect.Request[wrapperspb.StringValue]) (*connect.Response[wrapperspb.StringValue], error) {
+			return connect.NewResponse(req.Msg), nil
+		},
+		connect.WithInterceptors(claimsInterceptor("tenant-a", "alice"), interceptor),
+	))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := connect.NewClient[wrapperspb.StringValue, wrapperspb.StringValue](
+		server.Client(), server.URL+"/files.v1.FilesService/GetContent")
+
+	_, err := client.CallUnary(t.Context(), connect.NewRequest(wrapperspb.String("first")))
+	require.NoError(t, err)
+
+	_, err = client.CallUnary(t.Context(), connect.NewRequest(wrapperspb.String("second")))
+	var connectErr *connect.Error
+	require.True(t, errors.As(err, &connectErr))
+	assert.Equal(t, connect.CodeResourceExhausted, connectErr.Code())
+	assert.Equal(t, "1", connectErr.Meta().Get("Retry-After"))
+}
+
+func TestTenantRateLimiterBandwidthChargedToAllScopes(t *testing.T) {
+	limiter := newTestTenantRateLimiter(&config.FilesConfig{
+		RateLimitTenantDownloadBytesPerSecond: 150,
+		RateLimitUserDownloadBytesPerSecond:   100,
+	})
+	alice := userContext(t.Context(), "tenant-a", "alice")
+	bob := userContext(t.Context(), "tenant-a", "bob")
+
+	require.NoError(t, limiter.waitBandwidth(alice, limiter.scopes(alice, "DownloadContent"), 100))
+
+	// Alice is over her budget; the chunk she waits on is not taken from
+	// the tenant meanwhile.
+	waiting, cancel := context.WithCancel(alice)
+	cancel()
+	require.ErrorIs(t, limiter.waitBandwidth(waiting, limiter.scopes(alice, "DownloadContent"), 10), context.Canceled)
+
+	cancelled, cancelBob := context.WithCancel(bob)
+	cancelBob()
+	require.NoError(t, limiter.waitBandwidth(cancelled, limiter.scopes(bob, "DownloadContent"), 50))
+}
+
+func TestTenantRateLimiterInterceptorBandwidth(t *testing.T) {
+	limiter := newTestTenantRateLimiter(&config.FilesConfig{RateLimitUserDownloadBytesPerSecond: 4})
+	interceptor := limiter.Interceptor()
+
+	calls := 0
+	mux := http.NewServeMux()
+	mux.Handle("/files.v1.FilesService/GetContent", connect.NewUnaryHandler(
+		"/files.v1.FilesService/GetContent",
+		func(_ context.Context, req *connect.Request[wrapperspb.StringValue]) (*connect.Response[wrapperspb.StringValue], error) {
+			calls++
+			return connect.NewResponse(req.Msg), nil
+		},
+		connect.WithInterceptors(claimsInterceptor("tenant-a", "alice"), interceptor),
+	))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := connect.NewClient[wrapperspb.StringValue, wrapperspb.StringValue](
+		server.Client(), server.URL+"/files.v1.FilesService/GetContent")
+
+	// The first response overruns the budget without waiting, and the next
+	// call is refused before its handler runs.
+	_, err := client.CallUnary(t.Context(), connect.NewRequest(wrapperspb.String("larger than the budget")))
+	require.NoError(t, err)
+
+	_, err = client.CallUnary(t.Context(), connect.NewRequest(wrapperspb.String("second")))
+	var connectErr *connect.Error
+	require.True(t, errors.As(err, &connectErr))
+	assert.Equal(t, connect.CodeResourceExhausted, connectErr.Code())
+	assert.Equal(t, 1, calls)
+}
+
+// claimsInterceptor stands in for authentication, attaching the claims of a
+// user to handler calls.
+func claimsInterceptor(tenantID, subject string) connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			return next(userContext(ctx, tenantID, subject), req)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/pitabwire/util"
+)
+
+// accessGrantSweepBatch is how many expired grants are revoked at a time.
+const accessGrantSweepBatch = 100
+
+// ExpiredGrantRevoker revokes access grants that expired before a time.
+type ExpiredGrantRevoker interface {
+	RevokeExpiredGrants(ctx context.Context, before time.Time, limit int) (int, error)
+}
+
+// AccessGrantSweeper periodically revokes expired access grants from Keto.
+// Checks deny expired grants on their own; sweeping keeps Keto and the
+// listings of what is shared with whom free of them.
+type AccessGrantSweeper struct {
+	revoker  ExpiredGrantRevoker
+	interval time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewAccessGrantSweeper creates a sweeper revoking through revoker every interval.
+func NewAccessGrantSweeper(revoker ExpiredGrantRevoker, interval time.Duration) *AccessGrantSweeper {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &AccessGrantSweeper{revoker: revoker, interval: interval}
+}
+
+// Start sweeps immediately and then every interval until ctx is cancelled
+// or Stop is called.
+func (s *AccessGrantSweeper) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			if _, err := s.Sweep(ctx); err != nil {
+				util.Log(ctx).WithError(err).Warn("access grant expiry sweep failed")
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop ends the sweep loop and waits for an in-flight sweep to finish.
+func (s *AccessGrantSweeper) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// Sweep revokes every grant expired by now, returning how many were revoked.
+func (s *AccessGrantSweeper) Sweep(ctx context.Context) (int, error) {
+	sweepCtx := utils.SystemContext(ctx, "access grant expiry")
+	now := time.Now().UTC()
+
+	total := 0
+	for ctx.Err() == nil {
+		revoked, err := s.revoker.RevokeExpiredGrants(sweepCtx, now, accessGrantSweepBatch)
+		total += revoked
+		if err != nil {
+			return total, err
+		}
+		if revoked < accessGrantSweepBatch {
+			break
+		}
+	}
+	if total > 0 {
+		util.Log(ctx).With("revoked", total).Info("revoked expired access grants")
+	}
+	return total, nil
+}
@@ -0,0 +1,47 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGrantRevoker struct {
+	expired int
+	calls   int
+	err     error
+}
+
+func (f *fakeGrantRevoker) RevokeExpiredGrants(_ context.Context, _ time.Time, limit int) (int, error) {
+	f.calls++
+	if f.err != nil {
+		return 0, f.err
+	}
+	revoked := min(f.expired, limit)
+	f.expired -= revoked
+	return revoked, nil
+}
+
+func TestAccessGrantSweeper(t *testing.T) {
+	revoker := &fakeGrantRevoker{expired: accessGrantSweepBatch*2 + 5}
+	sweeper := NewAccessGrantSweeper(revoker, 0)
+
+	// Full batches are followed by another until the backlog is drained.
+	revoked, err := sweeper.Sweep(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, accessGrantSweepBatch*2+5, revoked)
+	require.Equal(t, 3, revoker.calls)
+
+	revoker.calls = 0
+	revoked, err = sweeper.Sweep(t.Context())
+	require.NoError(t, err)
+	require.Zero(t, revoked)
+	require.Equal(t, 1, revoker.calls)
+
+	revoker.err = errors.New("keto unavailable")
+	_, err = sweeper.Sweep(t.Context())
+	require.Error(t, err)
+}
@@ -0,0 +1,144 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	filesv1 "github.com/antinvestor/service-files/apps/default/gen/files/v1"
+	"github.com/antinvestor/service-files/apps/default/service/business"
+	"github.com/antinvestor/service-files/apps/default/service/events"
+	storage2 "github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/frame/v2"
+	"github.com/pitabwire/util"
+)
+
+// ArchiveExtractor runs archive extraction jobs. Each file of the archive
+// is stored as its own media and then queued for thumbnails and scanning
+// like a regular upload; progress is saved on the job as it goes.
+type ArchiveExtractor struct {
+	service       *frame.Service
+	mediaDatabase storage2.Database
+	mediaService  business.MediaService
+	enqueue       ThumbnailEnqueuer
+	folders       FolderLinker
+}
+
+// FolderLinker files a media under its folder so that it inherits the
+// access granted on the folder.
+type FolderLinker interface {
+	SetFileFolder(ctx context.Context, ownerProfileID, fileID, oldFolder, newFolder string) error
+}
+
+// NewArchiveExtractor creates an extractor storing files through mediaService.
+func NewArchiveExtractor(service *frame.Service, mediaDatabase storage2.Database, mediaService business.MediaService, enqueue ThumbnailEnqueuer, folders FolderLinker) *ArchiveExtractor {
+	return &ArchiveExtractor{
+		service:       service,
+		mediaDatabase: mediaDatabase,
+		mediaService:  mediaService,
+		enqueue:       enqueue,
+		folders:       folders,
+	}
+}
+
+// Handle runs the extraction named by the job_id of the payload.
+func (a *ArchiveExtractor) Handle(ctx context.Context, _ map[string]string, payload []byte) error {
+	jobPayload := map[string]string{}
+	if err := json.Unmarshal(payload, &jobPayload); err != nil {
+		return err
+	}
+
+	job, err := a.mediaDatabase.GetArchiveExtraction(ctx, jobPayload["job_id"])
+	if err != nil {
+		return err
+	}
+	if job == nil || job.State != types.ArchiveExtractionRunning {
+		return nil
+	}
+
+	// Failures are recorded on the job; redelivering would only repeat them.
+	_ = a.Run(ctx, job)
+	return nil
+}
+
+// Run extracts the archive of job. Should it be interrupted the job stays
+// running; running it again stores the files extracted before once more,
+// which deduplication resolves to the same media.
+func (a *ArchiveExtractor) Run(ctx context.Context, job *types.ArchiveExtraction) error {
+	cfg := a.service.Config().(*config.FilesConfig)
+
+	source, err := a.mediaDatabase.GetMediaMetadata(ctx, job.SourceMediaID)
+	if err != nil {
+		return a.fail(ctx, job, err)
+	}
+	if source == nil {
+		return a.fail(ctx, job, errors.New("archive media not found"))
+	}
+
+	extractor := business.NewExtractor(a.mediaService, a.processFile(cfg, job), a.save)
+	if err = extractor.Extract(ctx, cfg, job, source); err != nil {
+		if ctx.Err() != nil {
+			a.save(context.WithoutCancel(ctx), job)
+			return ctx.Err()
+		}
+		return a.fail(ctx, job, err)
+	}
+
+	now := time.Now().UTC()
+	job.State = types.ArchiveExtractionCompleted
+	job.CompletedAt = &now
+	a.save(ctx, job)
+	util.Log(ctx).With("job_id", job.ID, "extracted", job.Extracted, "skipped", job.Skipped, "failed", job.Failed).
+		Info("archive extraction completed")
+	return nil
+}
+
+// processFile queues the work that follows an upload for an extracted file.
+func (a *ArchiveExtractor) processFile(cfg *config.FilesConfig, job *types.ArchiveExtraction) business.ExtractedFileHandler {
+	return func(ctx context.Context, mediaID types.MediaID) error {
+		if err := a.enqueue(ctx, mediaID); err != nil {
+			return err
+		}
+		if cfg.ScanEnabled() {
+			err := a.service.QueueManager().Publish(ctx, cfg.QueueMediaScanName, map[string]string{
+				"media_id": string(mediaID),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		metadata, err := a.mediaDatabase.GetMediaMetadata(ctx, mediaID)
+		if err != nil {
+			return err
+		}
+		if metadata != nil && metadata.Folder != "" {
+			if err = a.folders.SetFileFolder(ctx, string(metadata.OwnerID), string(mediaID), "", metadata.Folder); err != nil {
+				util.Log(ctx).WithError(err).With("media_id", mediaID).Warn("failed to file extracted media under its folder")
+			}
+		}
+		events.EmitLifecycleEvent(ctx, a.service, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_UPLOADED,
+			metadata, string(job.OwnerID), map[string]string{"archive_media_id": string(job.SourceMediaID)})
+		return nil
+	}
+}
+
+func (a *ArchiveExtractor) fail(ctx context.Context, job *types.ArchiveExtraction, err error) error {
+	now := time.Now().UTC()
+	job.State = types.ArchiveExtractionFailed
+	job.LastError = truncateError(err.Error())
+	job.CompletedAt = &now
+	a.save(ctx, job)
+	util.Log(ctx).WithError(err).With("job_id", job.ID).Warn("archive extraction failed")
+	return fmt.Errorf("archive extraction %s: %w", job.ID, err)
+}
+
+func (a *ArchiveExtractor) save(ctx context.Context, job *types.ArchiveExtraction) {
+	if err := a.mediaDatabase.UpdateArchiveExtraction(ctx, job); err != nil {
+		util.Log(ctx).WithError(err).With("job_id", job.ID).Warn("failed to save archive extraction progress")
+	}
+}
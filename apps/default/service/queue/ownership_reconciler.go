@@ -0,0 +1,154 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/authz"
+	storage2 "github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/pitabwire/util"
+)
+
+// ownershipReconcileBatch is how many media, or Keto tuples of a namespace,
+// are compared at a time.
+const ownershipReconcileBatch = 100
+
+// ownershipReconcileLock is the advisory lock held for a walk, so that the
+// replicas of the service do not reconcile at the same time.
+const ownershipReconcileLock = "files.ownership_reconcile"
+
+// OwnershipChecker compares media records with their Keto tuples.
+type OwnershipChecker interface {
+	ReconcileOwnership(ctx context.Context, afterID string, limit int, repair bool) (*authz.ReconcileReport, error)
+	ReconcileTuples(ctx context.Context, tuples []security.RelationTuple, repair bool) ([]authz.ReconcileIssue, error)
+}
+
+// OwnershipReconciler periodically walks every media and compares its
+// OwnerID, folder and grants with Keto, then walks the file and file_folder
+// namespaces of Keto for tuples on media and folders that no longer exist.
+// In dry-run mode differences are only logged.
+type OwnershipReconciler struct {
+	checker  OwnershipChecker
+	lister   authz.NamespaceLister
+	locker   storage2.Locker
+	interval time.Duration
+	dryRun   bool
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewOwnershipReconciler creates a reconciler checking through checker every
+// interval, repairing what it finds unless dryRun is set. Without a lister
+// the Keto namespaces are not walked.
+func NewOwnershipReconciler(checker OwnershipChecker, lister authz.NamespaceLister, locker storage2.Locker, interval time.Duration, dryRun bool) *OwnershipReconciler {
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	return &OwnershipReconciler{checker: checker, lister: lister, locker: locker, interval: interval, dryRun: dryRun}
+}
+
+// Start reconciles immediately and then every interval until ctx is
+// cancelled or Stop is called.
+func (r *OwnershipReconciler) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			if _, err := r.Reconcile(ctx); err != nil {
+				util.Log(ctx).WithError(err).Warn("ownership reconciliation failed")
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop ends the reconcile loop and waits for an in-flight walk to finish.
+func (r *OwnershipReconciler) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+}
+
+// Reconcile walks every media and then the Keto namespaces once, returning
+// the differences found by kind. Nothing is walked while another replica
+// holds the reconcile lock.
+func (r *OwnershipReconciler) Reconcile(ctx context.Context) (map[string]int, error) {
+	release, acquired, err := r.locker.TryAdvisoryLock(ctx, ownershipReconcileLock)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		util.Log(ctx).Debug("ownership reconciliation is running elsewhere")
+		return nil, nil
+	}
+	defer release()
+
+	reconcileCtx := utils.SystemContext(ctx, "ownership reconciliation")
+
+	found := map[string]int{}
+	scanned, repaired := 0, 0
+	count := func(issues []authz.ReconcileIssue) {
+		for _, issue := range issues {
+			found[issue.Kind]++
+			if issue.Repaired {
+				repaired++
+			}
+		}
+	}
+
+	afterID := ""
+	for ctx.Err() == nil {
+		report, reconcileErr := r.checker.ReconcileOwnership(reconcileCtx, afterID, ownershipReconcileBatch, !r.dryRun)
+		if reconcileErr != nil {
+			return found, reconcileErr
+		}
+		scanned += report.Scanned
+		count(report.Issues)
+		if report.Scanned < ownershipReconcileBatch {
+			break
+		}
+		afterID = report.LastID
+	}
+
+	if r.lister != nil {
+		for _, namespace := range []string{authz.NamespaceFile, authz.NamespaceFolder} {
+			pageToken := ""
+			for ctx.Err() == nil {
+				tuples, next, listErr := r.lister.ListNamespaceTuples(reconcileCtx, namespace, pageToken, ownershipReconcileBatch)
+				if listErr != nil {
+					return found, listErr
+				}
+				issues, reconcileErr := r.checker.ReconcileTuples(reconcileCtx, tuples, !r.dryRun)
+				if reconcileErr != nil {
+					return found, reconcileErr
+				}
+				count(issues)
+				if next == "" {
+					break
+				}
+				pageToken = next
+			}
+		}
+	}
+
+	if len(found) > 0 {
+		log := util.Log(ctx).With("scanned", scanned, "repaired", repaired, "dry_run", r.dryRun)
+		for kind, n := range found {
+			log = log.With(kind, n)
+		}
+		log.Warn("media ownership differs from keto")
+	}
+	return found, nil
+}
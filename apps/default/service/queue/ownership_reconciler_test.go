@@ -0,0 +1,123 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/antinvestor/service-files/apps/default/service/authz"
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeOwnershipChecker struct {
+	media   int
+	afterID []string
+	repair  []bool
+	tuples  []security.RelationTuple
+	err     error
+}
+
+func (f *fakeOwnershipChecker) ReconcileOwnership(_ context.Context, afterID string, limit int, repair bool) (*authz.ReconcileReport, error) {
+	f.afterID = append(f.afterID, afterID)
+	f.repair = append(f.repair, repair)
+	if f.err != nil {
+		return nil, f.err
+	}
+	start := len(f.afterID) - 1
+	scanned := max(min(f.media-start*limit, limit), 0)
+	report := &authz.ReconcileReport{Scanned: scanned}
+	if scanned > 0 {
+		report.LastID = fmt.Sprintf("media-%d", start*limit+scanned)
+		report.Issues = []authz.ReconcileIssue{{Kind: authz.IssueMissingOwnerTuple, Repaired: repair}}
+	}
+	return report, nil
+}
+
+func (f *fakeOwnershipChecker) ReconcileTuples(_ context.Context, tuples []security.RelationTuple, repair bool) ([]authz.ReconcileIssue, error) {
+	f.tuples = append(f.tuples, tuples...)
+	var issues []authz.ReconcileIssue
+	for _, t := range tuples {
+		issues = append(issues, authz.ReconcileIssue{Kind: authz.IssueOrphanedTuple, FileID: t.Object.ID, Tuple: t, Repaired: repair})
+	}
+	return issues, nil
+}
+
+// fakeNamespaceLister serves two pages of one tuple for each namespace.
+type fakeNamespaceLister struct{}
+
+func (fakeNamespaceLister) ListNamespaceTuples(_ context.Context, namespace, pageToken string, _ int) ([]security.RelationTuple, string, error) {
+	next := "page-2"
+	if pageToken != "" {
+		next = ""
+	}
+	tuple := security.RelationTuple{Object: security.ObjectRef{Namespace: namespace, ID: namespace + "-" + next}}
+	return []security.RelationTuple{tuple}, next, nil
+}
+
+func (fakeNamespaceLister) Close() error { return nil }
+
+type fakeLocker struct {
+	held     bool
+	released int
+}
+
+func (l *fakeLocker) TryAdvisoryLock(_ context.Context, name string) (func(), bool, error) {
+	if name != ownershipReconcileLock {
+		return nil, false, fmt.Errorf("unexpected lock %q", name)
+	}
+	if l.held {
+		return nil, false, nil
+	}
+	return func() { l.released++ }, true, nil
+}
+
+func TestOwnershipReconciler(t *testing.T) {
+	checker := &fakeOwnershipChecker{media: ownershipReconcileBatch*2 + 5}
+	locker := &fakeLocker{}
+	reconciler := NewOwnershipReconciler(checker, nil, locker, 0, true)
+
+	// Full batches resume after the last media until a short batch ends the walk.
+	found, err := reconciler.Reconcile(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{authz.IssueMissingOwnerTuple: 3}, found)
+	require.Equal(t, []string{"", fmt.Sprintf("media-%d", ownershipReconcileBatch),
+		fmt.Sprintf("media-%d", ownershipReconcileBatch*2)}, checker.afterID)
+	require.Equal(t, []bool{false, false, false}, checker.repair)
+	require.Equal(t, 1, locker.released)
+
+	checker = &fakeOwnershipChecker{media: 3}
+	reconciler = NewOwnershipReconciler(checker, nil, locker, 0, false)
+	_, err = reconciler.Reconcile(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, []bool{true}, checker.repair)
+
+	checker.err = errors.New("keto unavailable")
+	_, err = reconciler.Reconcile(t.Context())
+	require.Error(t, err)
+	require.Equal(t, 3, locker.released)
+}
+
+func TestOwnershipReconcilerWalksNamespaces(t *testing.T) {
+	checker := &fakeOwnershipChecker{}
+	reconciler := NewOwnershipReconciler(checker, fakeNamespaceLister{}, &fakeLocker{}, 0, false)
+
+	found, err := reconciler.Reconcile(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{authz.IssueOrphanedTuple: 4}, found)
+	require.Len(t, checker.tuples, 4)
+	require.Equal(t, authz.NamespaceFile, checker.tuples[0].Object.Namespace)
+	require.Equal(t, authz.NamespaceFolder, checker.tuples[3].Object.Namespace)
+}
+
+func TestOwnershipReconcilerSkipsWhileLocked(t *testing.T) {
+	checker := &fakeOwnershipChecker{media: 3}
+	reconciler := NewOwnershipReconciler(checker, fakeNamespaceLister{}, &fakeLocker{held: true}, 0, false)
+
+	found, err := reconciler.Reconcile(t.Context())
+	require.NoError(t, err)
+	require.Empty(t, found)
+	require.Empty(t, checker.afterID)
+	require.Empty(t, checker.tuples)
+}
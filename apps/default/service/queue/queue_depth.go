@@ -0,0 +1,115 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	storage2 "github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/pitabwire/util"
+)
+
+// Queue names reported by QueueDepthSampler.
+const (
+	QueueThumbnailJobs      = "thumbnail_jobs"
+	QueueWebhookDeliveries  = "webhook_deliveries"
+	queueDepthSampleTimeout = 30 * time.Second
+)
+
+// DepthRecorder receives the pending items of a queue for the tenant of ctx.
+type DepthRecorder interface {
+	RecordQueueDepth(ctx context.Context, queue string, depth int64)
+}
+
+// QueueDepthSampler periodically records the pending thumbnail jobs and
+// webhook deliveries of every tenant. Tenants whose queue drained since the
+// last sample are recorded as zero so their gauges do not go stale.
+type QueueDepthSampler struct {
+	db       storage2.QueueDepthRepository
+	recorder DepthRecorder
+	interval time.Duration
+
+	// seen holds the tenancy scopes reported per queue by the last sample.
+	seen map[string]map[types.QueueDepth]bool
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewQueueDepthSampler creates a sampler reading db every interval.
+func NewQueueDepthSampler(db storage2.QueueDepthRepository, recorder DepthRecorder, interval time.Duration) *QueueDepthSampler {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	return &QueueDepthSampler{
+		db:       db,
+		recorder: recorder,
+		interval: interval,
+		seen:     map[string]map[types.QueueDepth]bool{},
+	}
+}
+
+// Start samples immediately and then every interval until ctx is cancelled
+// or Stop is called.
+func (s *QueueDepthSampler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			s.Sample(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop ends the sampling loop and waits for an in-flight sample to finish.
+func (s *QueueDepthSampler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// Sample records the current depth of every queue once.
+func (s *QueueDepthSampler) Sample(ctx context.Context) {
+	sampleCtx, cancel := context.WithTimeout(utils.SystemContext(ctx, "queue depth sampling"), queueDepthSampleTimeout)
+	defer cancel()
+
+	for _, q := range []struct {
+		name  string
+		count func(ctx context.Context) ([]types.QueueDepth, error)
+	}{
+		{QueueThumbnailJobs, s.db.CountPendingThumbnailJobs},
+		{QueueWebhookDeliveries, s.db.CountPendingWebhookDeliveries},
+	} {
+		depths, err := q.count(sampleCtx)
+		if err != nil {
+			util.Log(ctx).WithError(err).With("queue", q.name).Warn("failed to sample queue depth")
+			continue
+		}
+		s.record(ctx, q.name, depths)
+	}
+}
+
+func (s *QueueDepthSampler) record(ctx context.Context, queue string, depths []types.QueueDepth) {
+	current := make(map[types.QueueDepth]bool, len(depths))
+	for _, depth := range depths {
+		s.recorder.RecordQueueDepth(utils.TenantContext(ctx, depth.TenantID, depth.PartitionID), queue, depth.Depth)
+		current[types.QueueDepth{TenantID: depth.TenantID, PartitionID: depth.PartitionID}] = true
+	}
+	for scope := range s.seen[queue] {
+		if !current[scope] {
+			s.recorder.RecordQueueDepth(utils.TenantContext(ctx, scope.TenantID, scope.PartitionID), queue, 0)
+		}
+	}
+	s.seen[queue] = current
+}
@@ -0,0 +1,67 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeQueueDepths struct {
+	thumbnailJobs      []types.QueueDepth
+	webhookDeliveries  []types.QueueDepth
+	webhookDeliveryErr error
+}
+
+func (f *fakeQueueDepths) CountPendingThumbnailJobs(context.Context) ([]types.QueueDepth, error) {
+	return f.thumbnailJobs, nil
+}
+
+func (f *fakeQueueDepths) CountPendingWebhookDeliveries(context.Context) ([]types.QueueDepth, error) {
+	return f.webhookDeliveries, f.webhookDeliveryErr
+}
+
+type depthRecorder struct {
+	depths map[string]int64
+}
+
+func (r *depthRecorder) RecordQueueDepth(ctx context.Context, queue string, depth int64) {
+	claims := security.ClaimsFromContext(ctx)
+	r.depths[queue+"/"+claims.GetTenantID()+"/"+claims.GetPartitionID()] = depth
+}
+
+func TestQueueDepthSampler(t *testing.T) {
+	ctx := t.Context()
+	db := &fakeQueueDepths{
+		thumbnailJobs: []types.QueueDepth{
+			{TenantID: "tenant-a", PartitionID: "partition-a", Depth: 4},
+			{TenantID: "tenant-b", PartitionID: "partition-b", Depth: 2},
+		},
+		webhookDeliveries: []types.QueueDepth{
+			{TenantID: "tenant-a", PartitionID: "partition-a", Depth: 9},
+		},
+	}
+	recorder := &depthRecorder{depths: map[string]int64{}}
+	sampler := NewQueueDepthSampler(db, recorder, 0)
+
+	sampler.Sample(ctx)
+	require.Equal(t, map[string]int64{
+		"thumbnail_jobs/tenant-a/partition-a":     4,
+		"thumbnail_jobs/tenant-b/partition-b":     2,
+		"webhook_deliveries/tenant-a/partition-a": 9,
+	}, recorder.depths)
+
+	// A drained tenant drops out of the counts and is recorded as empty; a
+	// failed count leaves the previous sample in place.
+	db.thumbnailJobs = db.thumbnailJobs[:1]
+	db.webhookDeliveryErr = errors.New("database unavailable")
+	sampler.Sample(ctx)
+	require.Equal(t, map[string]int64{
+		"thumbnail_jobs/tenant-a/partition-a":     4,
+		"thumbnail_jobs/tenant-b/partition-b":     0,
+		"webhook_deliveries/tenant-a/partition-a": 9,
+	}, recorder.depths)
+}
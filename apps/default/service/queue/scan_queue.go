@@ -0,0 +1,137 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	filesv1 "github.com/antinvestor/service-files/apps/default/gen/files/v1"
+	"github.com/antinvestor/service-files/apps/default/service/events"
+	"github.com/antinvestor/service-files/apps/default/service/scanner"
+	storage2 "github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/pitabwire/frame/v2"
+)
+
+// ScanQueueHandler scans uploaded media for malware and records the outcome.
+// Infected media is quarantined: it is made private, its content is moved out
+// of the public bucket and it is no longer served.
+type ScanQueueHandler struct {
+	service       *frame.Service
+	mediaDatabase storage2.Database
+	provider      storage2.Provider
+	scanner       scanner.Scanner
+}
+
+func (sq *ScanQueueHandler) Handle(ctx context.Context, _ map[string]string, payload []byte) error {
+
+	logger := sq.service.Log(ctx)
+
+	mediaPayload := map[string]string{}
+	err := json.Unmarshal(payload, &mediaPayload)
+	if err != nil {
+		return err
+	}
+
+	mediaMetadata, err := sq.mediaDatabase.GetMediaMetadata(ctx, types.MediaID(mediaPayload["media_id"]))
+	if err != nil {
+		return err
+	}
+	if mediaMetadata == nil {
+		return nil
+	}
+	cfg := sq.service.Config().(*config.FilesConfig)
+
+	// A redelivered message for infected media finishes a quarantine that
+	// was interrupted before its content left the public bucket.
+	if mediaMetadata.ScanStatus == types.ScanStatusInfected {
+		return sq.quarantineContent(ctx, cfg, mediaMetadata)
+	}
+	// Media stored while scanning was disabled, and redelivered messages for
+	// media already scanned, are left alone.
+	if mediaMetadata.ScanStatus != types.ScanStatusPending || sq.scanner == nil {
+		return nil
+	}
+	logger = logger.With("media_id", mediaMetadata.MediaID)
+
+	scanCtx, cancel := context.WithTimeout(ctx, time.Duration(cfg.ScanTimeoutSeconds)*time.Second)
+	defer cancel()
+
+	result, err := sq.scan(scanCtx, cfg, mediaMetadata)
+	if err != nil {
+		logger.WithError(err).Warn("failed to scan media")
+		return sq.mediaDatabase.StoreScanResult(ctx, mediaMetadata.MediaID, types.ScanStatusFailed, "")
+	}
+
+	if !result.Infected {
+		return sq.mediaDatabase.StoreScanResult(ctx, mediaMetadata.MediaID, types.ScanStatusClean, "")
+	}
+
+	logger.With("signature", result.Signature).Warn("quarantined infected media")
+	err = sq.mediaDatabase.StoreScanResult(ctx, mediaMetadata.MediaID, types.ScanStatusInfected, result.Signature)
+	if err != nil {
+		return err
+	}
+	if err = sq.quarantineContent(ctx, cfg, mediaMetadata); err != nil {
+		return err
+	}
+
+	mediaMetadata.ScanStatus = types.ScanStatusInfected
+	mediaMetadata.ScanSignature = result.Signature
+	mediaMetadata.IsPublic = false
+	events.EmitLifecycleEvent(ctx, sq.service, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_QUARANTINED, mediaMetadata, "", map[string]string{
+		"signature": result.Signature,
+	})
+
+	return nil
+}
+
+// quarantineContent moves infected content out of the public bucket, where
+// it could otherwise still be fetched directly. Other media with the same
+// content share the stored file and are infected too.
+func (sq *ScanQueueHandler) quarantineContent(ctx context.Context, cfg *config.FilesConfig, mediaMetadata *types.MediaMetadata) error {
+	finalPath, err := utils.GetPathFromBase64Hash(mediaMetadata.Base64Hash, cfg.AbsBasePath)
+	if err != nil {
+		return fmt.Errorf("failed to get file path from metadata: %w", err)
+	}
+	return storage2.MoveFile(ctx, sq.provider, sq.provider.PublicBucket(), sq.provider.PrivateBucket(), types.Path(finalPath))
+}
+
+// scan streams the stored content, decrypted if it is encrypted at rest, to
+// the scanner.
+func (sq *ScanQueueHandler) scan(ctx context.Context, cfg *config.FilesConfig, mediaMetadata *types.MediaMetadata) (*scanner.Result, error) {
+	finalPath, err := utils.GetPathFromBase64Hash(mediaMetadata.Base64Hash, cfg.AbsBasePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file path from metadata: %w", err)
+	}
+
+	reader, finalizer, err := sq.provider.DownloadFile(ctx, sq.provider.GetBucket(mediaMetadata.IsPublic), types.Path(finalPath))
+	if err != nil {
+		return nil, err
+	}
+	defer finalizer()
+
+	var src io.Reader = reader
+	if mediaMetadata.Encryption != nil {
+		src, err = storage2.NewDecryptingReader(reader, []byte(cfg.EnvStorageEncryptionPhrase),
+			mediaMetadata.Encryption, storage2.EncryptionRecorderOf(sq.provider))
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialise decrypting reader: %w", err)
+		}
+	}
+
+	return sq.scanner.Scan(ctx, src)
+}
+
+func NewScanQueueHandler(service *frame.Service, mediaDatabase storage2.Database, mediaProvider storage2.Provider, mediaScanner scanner.Scanner) ScanQueueHandler {
+	return ScanQueueHandler{
+		service:       service,
+		mediaDatabase: mediaDatabase,
+		provider:      mediaProvider,
+		scanner:       mediaScanner,
+	}
+}
@@ -0,0 +1,143 @@
+package queue
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/scanner"
+	"github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/storage/connection"
+	"github.com/antinvestor/service-files/apps/default/service/storage/provider"
+	"github.com/antinvestor/service-files/apps/default/service/tests"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/pitabwire/frame/v2/frametests/definition"
+	"github.com/pitabwire/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScanQueueTestSuite struct {
+	tests.BaseTestSuite
+}
+
+func TestScanQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(ScanQueueTestSuite))
+}
+
+func (suite *ScanQueueTestSuite) TestHandle() {
+	testCases := []struct {
+		name          string
+		content       string
+		status        types.ScanStatus
+		scanErr       error
+		wantStatus    types.ScanStatus
+		wantSignature string
+	}{
+		{
+			name:       "clean_content",
+			content:    "quarterly report",
+			status:     types.ScanStatusPending,
+			wantStatus: types.ScanStatusClean,
+		},
+		{
+			name:          "infected_content_is_quarantined",
+			content:       "attachment " + scanner.EICAR,
+			status:        types.ScanStatusPending,
+			wantStatus:    types.ScanStatusInfected,
+			wantSignature: scanner.EICARSignature,
+		},
+		{
+			name:       "scanner_error_fails_scan",
+			content:    "quarterly report",
+			status:     types.ScanStatusPending,
+			scanErr:    errors.New("clamd unavailable"),
+			wantStatus: types.ScanStatusFailed,
+		},
+		{
+			name:       "scanned_media_is_not_rescanned",
+			content:    scanner.EICAR,
+			status:     types.ScanStatusClean,
+			wantStatus: types.ScanStatusClean,
+		},
+	}
+
+	suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+		ctx, svc, res := suite.CreateService(t, dep)
+		cfg := svc.Config().(*config.FilesConfig)
+		storageProvider, err := provider.GetStorageProvider(ctx, cfg)
+		require.NoError(t, err)
+
+		db, err := connection.NewMediaDatabase(
+			svc.WorkManager(),
+			res.MediaRepository,
+			res.MultipartUploadRepo,
+			res.MultipartUploadPartRepo,
+			res.FileVersionRepo,
+			res.RetentionPolicyRepo,
+			res.FileRetentionRepo,
+			res.StorageStatsRepo,
+			res.WebhookSubscriptionRepo,
+			res.WebhookDeliveryRepo,
+			res.ThumbnailJobRepo,
+			res.ThumbnailRegenerationRepo,
+			res.ArchiveExtractionRepo,
+			res.ShareLinkRepo,
+			res.AccessGrantRepo,
+			res.UserDataExportRepo,
+			res.UserDataErasureRepo,
+		)
+		require.NoError(t, err)
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				meta := &types.MediaMetadata{
+					MediaID:       types.MediaID("scan-" + tc.name),
+					OwnerID:       "owner",
+					UploadName:    "attachment.txt",
+					ContentType:   "text/plain",
+					Base64Hash:    types.Base64Hash("scan" + tc.name),
+					FileSizeBytes: types.FileSizeBytes(len(tc.content)),
+					ServerName:    "service_file",
+					IsPublic:      true,
+					ScanStatus:    tc.status,
+				}
+				source := filepath.Join(t.TempDir(), "content")
+				require.NoError(t, os.WriteFile(source, []byte(tc.content), 0600))
+				_, _, err = storage.UploadFileWithHashCheck(ctx, storageProvider, types.Path(source), meta, cfg.AbsBasePath, util.Log(ctx))
+				require.NoError(t, err)
+				require.NoError(t, db.StoreMediaMetadata(ctx, meta))
+
+				handler := NewScanQueueHandler(svc, db, storageProvider, &scanner.Fake{Err: tc.scanErr})
+				require.NoError(t, handler.Handle(ctx, map[string]string{}, mustJSON(map[string]string{
+					"media_id": string(meta.MediaID),
+				})))
+
+				stored, err := db.GetMediaMetadata(ctx, meta.MediaID)
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantStatus, stored.ScanStatus)
+				assert.Equal(t, tc.wantSignature, stored.ScanSignature)
+				assert.Equal(t, tc.wantStatus != types.ScanStatusInfected, stored.IsPublic)
+
+				// Quarantined content can no longer be fetched from the public
+				// bucket.
+				finalPath, err := utils.GetPathFromBase64Hash(meta.Base64Hash, cfg.AbsBasePath)
+				require.NoError(t, err)
+				for bucket, wantFound := range map[string]bool{
+					storageProvider.PublicBucket():  tc.wantStatus != types.ScanStatusInfected,
+					storageProvider.PrivateBucket(): tc.wantStatus == types.ScanStatusInfected,
+				} {
+					_, finalizer, downloadErr := storageProvider.DownloadFile(ctx, bucket, types.Path(finalPath))
+					assert.Equal(t, wantFound, downloadErr == nil, bucket)
+					if downloadErr == nil {
+						finalizer()
+					}
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,434 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	storage2 "github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/pitabwire/frame/v2"
+	"github.com/pitabwire/util"
+)
+
+const thumbnailRegenerationBatchSize = 100
+
+// ThumbnailEnqueuer queues thumbnail generation for a media.
+type ThumbnailEnqueuer func(ctx context.Context, mediaID types.MediaID) error
+
+// PublishThumbnailGeneration returns an enqueuer publishing to the thumbnail
+// generation queue of service.
+func PublishThumbnailGeneration(service *frame.Service) ThumbnailEnqueuer {
+	return func(ctx context.Context, mediaID types.MediaID) error {
+		cfg := service.Config().(*config.FilesConfig)
+		return service.QueueManager().Publish(ctx, cfg.QueueThumbnailsGenerateName, map[string]string{
+			"media_id": string(mediaID),
+		})
+	}
+}
+
+// ResetThumbnailJob creates the thumbnail job of a media, or resets an
+// existing one to pending with no attempts, ahead of queueing it again.
+func ResetThumbnailJob(ctx context.Context, db storage2.Database, cfg *config.FilesConfig, mediaID types.MediaID) (*types.ThumbnailJob, error) {
+	job, err := db.GetThumbnailJob(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	lease := ThumbnailJobLease(cfg)
+	if job == nil {
+		job = &types.ThumbnailJob{MediaID: mediaID, State: types.ThumbnailJobPending, NextAttemptAt: &lease}
+		return job, db.StoreThumbnailJob(ctx, job)
+	}
+	job.State = types.ThumbnailJobPending
+	job.Attempts = 0
+	job.LastError = ""
+	job.NextAttemptAt = &lease
+	job.CompletedAt = nil
+	return job, db.UpdateThumbnailJob(ctx, job)
+}
+
+// RegenerationPublisher queues a regeneration run to be worked on.
+type RegenerationPublisher func(ctx context.Context, runID string) error
+
+// PublishThumbnailRegeneration returns a publisher queueing runs on the
+// thumbnail regeneration queue of service.
+func PublishThumbnailRegeneration(service *frame.Service) RegenerationPublisher {
+	return func(ctx context.Context, runID string) error {
+		cfg := service.Config().(*config.FilesConfig)
+		return service.QueueManager().Publish(ctx, cfg.QueueThumbnailsRegenerateName, map[string]string{
+			"run_id": runID,
+		})
+	}
+}
+
+// ErrRegenerationInProgress is returned when another worker holds the lease
+// of a regeneration run.
+var ErrRegenerationInProgress = errors.New("regeneration run is in progress")
+
+// ThumbnailRegenerator runs bulk thumbnail regeneration. A run walks the
+// original images matching its filter in ID order, queues generation for
+// those missing a configured size and optionally removes thumbnails of sizes
+// no longer configured. Progress is saved after every batch, so an
+// interrupted run resumes after its checkpoint.
+//
+// A worker leases the run while it walks it and renews the lease as it makes
+// progress. Queued runs are walked in slices of half a lease, each slice
+// queueing the next, so no single message is held for the length of a run.
+// Running runs whose lease lapses, because their worker died, are queued
+// again by the sweep.
+type ThumbnailRegenerator struct {
+	service       *frame.Service
+	mediaDatabase storage2.Database
+	enqueue       ThumbnailEnqueuer
+	publish       RegenerationPublisher
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewThumbnailRegenerator creates a regenerator queueing generation through enqueue.
+func NewThumbnailRegenerator(service *frame.Service, mediaDatabase storage2.Database, enqueue ThumbnailEnqueuer) *ThumbnailRegenerator {
+	return &ThumbnailRegenerator{
+		service:       service,
+		mediaDatabase: mediaDatabase,
+		enqueue:       enqueue,
+		publish:       PublishThumbnailRegeneration(service),
+	}
+}
+
+// Handle works on the regeneration named by the run_id of the payload for
+// one slice, queueing the rest of the run. A run another worker holds is
+// left to it.
+func (r *ThumbnailRegenerator) Handle(ctx context.Context, _ map[string]string, payload []byte) error {
+	runPayload := map[string]string{}
+	if err := json.Unmarshal(payload, &runPayload); err != nil {
+		return err
+	}
+
+	run, err := r.mediaDatabase.GetThumbnailRegeneration(ctx, runPayload["run_id"])
+	if err != nil {
+		return err
+	}
+	if run == nil || run.State != types.ThumbnailRegenerationRunning {
+		return nil
+	}
+	cfg := r.service.Config().(*config.FilesConfig)
+	if err = r.claim(ctx, cfg, run); err != nil {
+		if errors.Is(err, ErrRegenerationInProgress) {
+			return nil
+		}
+		return err
+	}
+
+	done, err := r.step(ctx, cfg, run, time.Now().Add(regenerationLease(cfg)/2))
+	if err != nil {
+		// Failures are recorded on the run; redelivering would only repeat
+		// them. An interrupted slice is redelivered to carry on.
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return nil
+	}
+	if done {
+		return nil
+	}
+
+	r.release(ctx, run)
+	return r.publish(ctx, run.ID)
+}
+
+// Run walks the images of run from its checkpoint until none are left, ctx
+// is cancelled or an error occurs. A cancelled run stays running with its
+// progress saved so it can be resumed. It returns ErrRegenerationInProgress
+// when another worker holds the run.
+func (r *ThumbnailRegenerator) Run(ctx context.Context, run *types.ThumbnailRegeneration) error {
+	cfg := r.service.Config().(*config.FilesConfig)
+	if err := r.claim(ctx, cfg, run); err != nil {
+		return err
+	}
+	_, err := r.step(ctx, cfg, run, time.Time{})
+	return err
+}
+
+// step walks the images of a claimed run from its checkpoint until none are
+// left or, for a non-zero deadline, the first batch finishing after it. It
+// reports whether the run finished.
+func (r *ThumbnailRegenerator) step(ctx context.Context, cfg *config.FilesConfig, run *types.ThumbnailRegeneration, deadline time.Time) (bool, error) {
+	logger := util.Log(ctx).With("run_id", run.ID)
+	lease := regenerationLease(cfg)
+
+	rate := run.RatePerSecond
+	if rate <= 0 {
+		rate = cfg.ThumbnailRegenerationRatePerSecond
+	}
+	if rate <= 0 {
+		rate = 10
+	}
+	throttle := time.NewTicker(time.Second / time.Duration(rate))
+	defer throttle.Stop()
+
+	for {
+		batch, err := r.mediaDatabase.ListRegenerationCandidates(ctx, &run.Filter, run.Checkpoint, thumbnailRegenerationBatchSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				r.release(context.WithoutCancel(ctx), run)
+				return false, ctx.Err()
+			}
+			return false, r.fail(ctx, run, err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		for _, mediaMetadata := range batch {
+			enqueued, removed, mediaErr := r.regenerate(ctx, cfg, throttle, run.RemoveStale, mediaMetadata)
+			if mediaErr != nil {
+				if ctx.Err() != nil {
+					r.release(context.WithoutCancel(ctx), run)
+					return false, ctx.Err()
+				}
+				return false, r.fail(ctx, run, mediaErr)
+			}
+			run.Scanned++
+			run.Removed += int64(removed)
+			if enqueued {
+				run.Enqueued++
+			}
+			run.Checkpoint = string(mediaMetadata.MediaID)
+
+			// A slow batch renews the lease before it runs out.
+			if run.LeaseUntil != nil && time.Until(*run.LeaseUntil) < lease/2 {
+				r.renew(ctx, cfg, run)
+			}
+		}
+
+		r.renew(ctx, cfg, run)
+		logger.With("scanned", run.Scanned, "enqueued", run.Enqueued, "removed", run.Removed).
+			Info("thumbnail regeneration progress")
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return false, nil
+		}
+	}
+
+	now := time.Now().UTC()
+	run.State = types.ThumbnailRegenerationCompleted
+	run.CompletedAt = &now
+	run.LeaseUntil = nil
+	r.save(ctx, run)
+	logger.With("scanned", run.Scanned, "enqueued", run.Enqueued, "removed", run.Removed).
+		Info("thumbnail regeneration completed")
+	return true, nil
+}
+
+// ResumeStalled queues again the running runs whose lease lapsed a full lease
+// ago, because their worker died, or that were never picked up, returning
+// how many were queued. Waiting out a lease leaves slices that released the
+// run and queued the next one alone. Each run is queued under its own tenant.
+func (r *ThumbnailRegenerator) ResumeStalled(ctx context.Context) (int, error) {
+	cfg := r.service.Config().(*config.FilesConfig)
+	idleBefore := time.Now().UTC().Add(-regenerationLease(cfg))
+	runs, err := r.mediaDatabase.ListStalledThumbnailRegenerations(
+		utils.SystemContext(ctx, "thumbnail regeneration resume"), idleBefore, thumbnailRegenerationBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	resumed := 0
+	for _, run := range runs {
+		runCtx := utils.TenantContext(ctx, run.TenantID, run.PartitionID)
+		if err = r.publish(runCtx, run.ID); err != nil {
+			util.Log(ctx).WithError(err).With("run_id", run.ID).Warn("failed to resume stalled thumbnail regeneration")
+			continue
+		}
+		resumed++
+	}
+	if resumed > 0 {
+		util.Log(ctx).With("resumed", resumed).Info("resumed stalled thumbnail regenerations")
+	}
+	return resumed, nil
+}
+
+// Start sweeps for stalled runs every lease until ctx is cancelled or Stop
+// is called.
+func (r *ThumbnailRegenerator) Start(ctx context.Context) {
+	interval := regenerationLease(r.service.Config().(*config.FilesConfig))
+
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := r.ResumeStalled(ctx); err != nil {
+					util.Log(ctx).WithError(err).Warn("thumbnail regeneration resume sweep failed")
+				}
+			}
+		}
+	}()
+}
+
+// Stop ends the sweep loop and waits for an in-flight sweep to finish.
+func (r *ThumbnailRegenerator) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+}
+
+// claim takes the lease of run, returning ErrRegenerationInProgress when
+// another worker holds it.
+func (r *ThumbnailRegenerator) claim(ctx context.Context, cfg *config.FilesConfig, run *types.ThumbnailRegeneration) error {
+	leaseUntil := time.Now().UTC().Add(regenerationLease(cfg))
+	claimed, err := r.mediaDatabase.ClaimThumbnailRegeneration(ctx, run.ID, leaseUntil)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return ErrRegenerationInProgress
+	}
+	run.LeaseUntil = &leaseUntil
+	return nil
+}
+
+// renew saves the progress of run and extends its lease.
+func (r *ThumbnailRegenerator) renew(ctx context.Context, cfg *config.FilesConfig, run *types.ThumbnailRegeneration) {
+	leaseUntil := time.Now().UTC().Add(regenerationLease(cfg))
+	run.LeaseUntil = &leaseUntil
+	r.save(ctx, run)
+}
+
+// release saves the progress of run and gives up its lease, so the next
+// slice or a resume can claim it straight away.
+func (r *ThumbnailRegenerator) release(ctx context.Context, run *types.ThumbnailRegeneration) {
+	now := time.Now().UTC()
+	run.LeaseUntil = &now
+	r.save(ctx, run)
+}
+
+func regenerationLease(cfg *config.FilesConfig) time.Duration {
+	if cfg.ThumbnailRegenerationLeaseSeconds <= 0 {
+		return 2 * time.Minute
+	}
+	return time.Duration(cfg.ThumbnailRegenerationLeaseSeconds) * time.Second
+}
+
+// regenerate queues generation for an image missing configured sizes and
+// removes stale sizes when asked to. Both are throttled.
+func (r *ThumbnailRegenerator) regenerate(
+	ctx context.Context,
+	cfg *config.FilesConfig,
+	throttle *time.Ticker,
+	removeStale bool,
+	mediaMetadata *types.MediaMetadata,
+) (bool, int, error) {
+	if !hasThumbnails(mediaMetadata) {
+		return false, 0, nil
+	}
+
+	thumbnails, err := r.mediaDatabase.GetThumbnails(ctx, mediaMetadata.MediaID)
+	if err != nil {
+		return false, 0, err
+	}
+
+	present := map[config.ThumbnailSize]bool{}
+	var stale []types.MediaID
+	for _, thumbnail := range thumbnails {
+		if thumbnail.ThumbnailSize == nil {
+			continue
+		}
+		size := config.ThumbnailSize(*thumbnail.ThumbnailSize)
+		if isConfiguredSize(cfg.ThumbnailSizes, size) {
+			present[size] = true
+		} else {
+			stale = append(stale, thumbnail.MediaID)
+		}
+	}
+
+	removed := 0
+	if removeStale {
+		for _, thumbnailID := range stale {
+			if err = wait(ctx, throttle); err != nil {
+				return false, removed, err
+			}
+			if err = r.mediaDatabase.DeleteMedia(ctx, thumbnailID); err != nil {
+				return false, removed, err
+			}
+			removed++
+		}
+	}
+
+	if !missingSizes(cfg.ThumbnailSizes, present, mediaMetadata.Properties) {
+		return false, removed, nil
+	}
+	if err = wait(ctx, throttle); err != nil {
+		return false, removed, err
+	}
+	if _, err = ResetThumbnailJob(ctx, r.mediaDatabase, cfg, mediaMetadata.MediaID); err != nil {
+		return false, removed, err
+	}
+	if err = r.enqueue(ctx, mediaMetadata.MediaID); err != nil {
+		return false, removed, err
+	}
+	return true, removed, nil
+}
+
+func (r *ThumbnailRegenerator) fail(ctx context.Context, run *types.ThumbnailRegeneration, err error) error {
+	run.State = types.ThumbnailRegenerationFailed
+	run.LastError = truncateError(err.Error())
+	run.LeaseUntil = nil
+	r.save(ctx, run)
+	util.Log(ctx).WithError(err).With("run_id", run.ID).Warn("thumbnail regeneration failed")
+	return fmt.Errorf("thumbnail regeneration %s: %w", run.ID, err)
+}
+
+func (r *ThumbnailRegenerator) save(ctx context.Context, run *types.ThumbnailRegeneration) {
+	if err := r.mediaDatabase.UpdateThumbnailRegeneration(ctx, run); err != nil {
+		util.Log(ctx).WithError(err).With("run_id", run.ID).Warn("failed to save thumbnail regeneration progress")
+	}
+}
+
+// missingSizes reports whether a configured size is absent. Sizes the
+// original is too small for are never generated, so they are only counted
+// when the original's dimensions are unknown.
+func missingSizes(sizes []config.ThumbnailSize, present map[config.ThumbnailSize]bool, properties *types.MediaProperties) bool {
+	for _, size := range sizes {
+		if present[size] {
+			continue
+		}
+		if properties != nil && properties.Width > 0 && properties.Height > 0 &&
+			size.Width >= properties.Width && size.Height >= properties.Height {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func isConfiguredSize(sizes []config.ThumbnailSize, size config.ThumbnailSize) bool {
+	for _, configured := range sizes {
+		if configured == size {
+			return true
+		}
+	}
+	return false
+}
+
+func wait(ctx context.Context, throttle *time.Ticker) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-throttle.C:
+		return nil
+	}
+}
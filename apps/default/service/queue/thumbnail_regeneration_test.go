@@ -0,0 +1,154 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/storage/connection"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/frame/v2/frametests/definition"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *ThumbnailQueueTestSuite) TestRegeneratorRun() {
+	suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+		ctx, svc, res := suite.CreateService(t, dep)
+		cfg := svc.Config().(*config.FilesConfig)
+		cfg.ThumbnailSizes = []config.ThumbnailSize{{Width: 32, Height: 32, ResizeMethod: "crop"}}
+
+		db, err := connection.NewMediaDatabase(
+			svc.WorkManager(),
+			res.MediaRepository,
+			res.MultipartUploadRepo,
+			res.MultipartUploadPartRepo,
+			res.FileVersionRepo,
+			res.RetentionPolicyRepo,
+			res.FileRetentionRepo,
+			res.StorageStatsRepo,
+			res.WebhookSubscriptionRepo,
+			res.WebhookDeliveryRepo,
+			res.ThumbnailJobRepo,
+			res.ThumbnailRegenerationRepo,
+			res.ArchiveExtractionRepo,
+			res.ShareLinkRepo,
+			res.AccessGrantRepo,
+			res.UserDataExportRepo,
+			res.UserDataErasureRepo,
+		)
+		require.NoError(t, err)
+
+		media := func(id, parent, owner, contentType string, size *types.ThumbnailSize) *types.MediaMetadata {
+			return &types.MediaMetadata{
+				MediaID:       types.MediaID(id),
+				ParentID:      types.MediaID(parent),
+				OwnerID:       types.OwnerID(owner),
+				UploadName:    "img",
+				ContentType:   types.ContentType(contentType),
+				Base64Hash:    "abc12345",
+				FileSizeBytes: 10,
+				ServerName:    "service_file",
+				ThumbnailSize: size,
+			}
+		}
+		for _, mm := range []*types.MediaMetadata{
+			media("regen-a", "", "owner", "image/jpeg", nil),
+			media("regen-b", "", "owner", "image/png", nil),
+			media("regen-b-current", "regen-b", "owner", "image/png", &types.ThumbnailSize{Width: 32, Height: 32, ResizeMethod: "crop"}),
+			media("regen-b-stale", "regen-b", "owner", "image/png", &types.ThumbnailSize{Width: 64, Height: 64, ResizeMethod: "scale"}),
+			media("regen-c", "", "owner", "text/plain", nil),
+			media("regen-d", "", "someone-else", "image/jpeg", nil),
+		} {
+			require.NoError(t, db.StoreMediaMetadata(ctx, mm))
+		}
+
+		run := &types.ThumbnailRegeneration{
+			Filter:        types.ThumbnailRegenerationFilter{OwnerID: "owner", ContentType: "image/"},
+			RemoveStale:   true,
+			RatePerSecond: 1000,
+			State:         types.ThumbnailRegenerationRunning,
+		}
+		require.NoError(t, db.StoreThumbnailRegeneration(ctx, run))
+
+		var enqueued []types.MediaID
+		regenerator := NewThumbnailRegenerator(svc, db, func(_ context.Context, mediaID types.MediaID) error {
+			enqueued = append(enqueued, mediaID)
+			return nil
+		})
+		require.NoError(t, regenerator.Run(ctx, run))
+
+		assert.Equal(t, []types.MediaID{"regen-a"}, enqueued)
+
+		saved, err := db.GetThumbnailRegeneration(ctx, run.ID)
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.Equal(t, types.ThumbnailRegenerationCompleted, saved.State)
+		assert.Equal(t, int64(2), saved.Scanned)
+		assert.Equal(t, int64(1), saved.Enqueued)
+		assert.Equal(t, int64(1), saved.Removed)
+		assert.Equal(t, "regen-b", saved.Checkpoint)
+		assert.NotNil(t, saved.CompletedAt)
+
+		job, err := db.GetThumbnailJob(ctx, "regen-a")
+		require.NoError(t, err)
+		require.NotNil(t, job)
+		assert.Equal(t, types.ThumbnailJobPending, job.State)
+
+		thumbnails, err := db.GetThumbnails(ctx, "regen-b")
+		require.NoError(t, err)
+		require.Len(t, thumbnails, 1)
+		assert.Equal(t, types.MediaID("regen-b-current"), thumbnails[0].MediaID)
+
+		assert.Nil(t, saved.LeaseUntil)
+
+		// Resuming from the checkpoint finds nothing left to do.
+		enqueued = nil
+		saved.State = types.ThumbnailRegenerationRunning
+		require.NoError(t, db.UpdateThumbnailRegeneration(ctx, saved))
+		require.NoError(t, regenerator.Run(ctx, saved))
+		assert.Empty(t, enqueued)
+		assert.Equal(t, int64(2), saved.Scanned)
+
+		// A run another worker holds is not walked twice.
+		leaseUntil := time.Now().UTC().Add(time.Minute)
+		held := &types.ThumbnailRegeneration{
+			Filter:     types.ThumbnailRegenerationFilter{OwnerID: "owner", ContentType: "image/"},
+			State:      types.ThumbnailRegenerationRunning,
+			LeaseUntil: &leaseUntil,
+		}
+		require.NoError(t, db.StoreThumbnailRegeneration(ctx, held))
+		require.ErrorIs(t, regenerator.Run(ctx, held), ErrRegenerationInProgress)
+
+		payload, err := json.Marshal(map[string]string{"run_id": held.ID})
+		require.NoError(t, err)
+		require.NoError(t, regenerator.Handle(ctx, nil, payload))
+		assert.Empty(t, enqueued)
+
+		// Once the lease lapsed a lease ago, the sweep queues the run again
+		// and the queued slice finishes it.
+		expired := time.Now().UTC().Add(-time.Hour)
+		held.LeaseUntil = &expired
+		require.NoError(t, db.UpdateThumbnailRegeneration(ctx, held))
+
+		var published []string
+		regenerator.publish = func(_ context.Context, runID string) error {
+			published = append(published, runID)
+			return nil
+		}
+		resumed, err := regenerator.ResumeStalled(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, resumed)
+		assert.Equal(t, []string{held.ID}, published)
+
+		require.NoError(t, regenerator.Handle(ctx, nil, payload))
+		assert.Equal(t, []types.MediaID{"regen-a"}, enqueued)
+
+		saved, err = db.GetThumbnailRegeneration(ctx, held.ID)
+		require.NoError(t, err)
+		assert.Equal(t, types.ThumbnailRegenerationCompleted, saved.State)
+		assert.Nil(t, saved.LeaseUntil)
+	})
+}
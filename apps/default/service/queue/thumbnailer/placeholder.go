@@ -0,0 +1,211 @@
+package thumbnailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	nfnt "github.com/nfnt/resize"
+)
+
+const (
+	// placeholderSampleSize bounds the image the placeholder is computed
+	// from. Both values describe the image at a glance, so detail beyond
+	// this is wasted work.
+	placeholderSampleSize = 64
+
+	base83Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+)
+
+// GeneratePlaceholder computes the BlurHash and dominant color of the source
+// image and stores them on the media.
+func GeneratePlaceholder(
+	ctx context.Context,
+	mediaMetadata *types.MediaMetadata,
+	absBasePath config.Path,
+	db storage.Database,
+	provider storage.Provider,
+	encryptionKey string,
+) error {
+	img, err := readSourceImage(ctx, provider, absBasePath, mediaMetadata, encryptionKey)
+	if err != nil {
+		return err
+	}
+
+	placeholder, err := ComputePlaceholder(img)
+	if err != nil {
+		return err
+	}
+	return db.StorePlaceholder(ctx, mediaMetadata.MediaID, placeholder)
+}
+
+// ComputePlaceholder returns the BlurHash and dominant color of img. The hash
+// uses four components along the longer edge and three along the shorter.
+func ComputePlaceholder(img image.Image) (*types.Placeholder, error) {
+	b := img.Bounds()
+	if b.Dx() <= 0 || b.Dy() <= 0 {
+		return nil, errors.New("image has no pixels")
+	}
+	sample := nfnt.Thumbnail(placeholderSampleSize, placeholderSampleSize, img, nfnt.Bilinear)
+
+	xComponents, yComponents := 4, 3
+	if b.Dy() > b.Dx() {
+		xComponents, yComponents = 3, 4
+	}
+	hash, err := EncodeBlurHash(sample, xComponents, yComponents)
+	if err != nil {
+		return nil, err
+	}
+	return &types.Placeholder{BlurHash: hash, DominantColor: DominantColor(sample)}, nil
+}
+
+// EncodeBlurHash encodes img as a BlurHash with the given number of
+// components on each axis, following https://github.com/woltapp/blurhash.
+func EncodeBlurHash(img image.Image, xComponents, yComponents int) (string, error) {
+	if xComponents < 1 || xComponents > 9 || yComponents < 1 || yComponents > 9 {
+		return "", fmt.Errorf("blurhash components must be between 1 and 9, got %dx%d", xComponents, yComponents)
+	}
+	b := img.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width <= 0 || height <= 0 {
+		return "", errors.New("image has no pixels")
+	}
+
+	// Convert once to linear light; the basis functions are evaluated per
+	// component below.
+	linear := make([][3]float64, width*height)
+	for y := range height {
+		for x := range width {
+			c := color.NRGBAModel.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.NRGBA)
+			linear[y*width+x] = [3]float64{srgbToLinear(c.R), srgbToLinear(c.G), srgbToLinear(c.B)}
+		}
+	}
+
+	factors := make([][3]float64, 0, xComponents*yComponents)
+	for j := range yComponents {
+		for i := range xComponents {
+			normalisation := 2.0
+			if i == 0 && j == 0 {
+				normalisation = 1
+			}
+			var f [3]float64
+			for y := range height {
+				basisY := math.Cos(math.Pi * float64(j) * float64(y) / float64(height))
+				for x := range width {
+					basis := basisY * math.Cos(math.Pi*float64(i)*float64(x)/float64(width))
+					p := linear[y*width+x]
+					f[0] += basis * p[0]
+					f[1] += basis * p[1]
+					f[2] += basis * p[2]
+				}
+			}
+			scale := normalisation / float64(width*height)
+			factors = append(factors, [3]float64{f[0] * scale, f[1] * scale, f[2] * scale})
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(encodeBase83((xComponents-1)+(yComponents-1)*9, 1))
+
+	dc, ac := factors[0], factors[1:]
+	maxValue := 1.0
+	if len(ac) > 0 {
+		actualMax := 0.0
+		for _, f := range ac {
+			actualMax = max(actualMax, math.Abs(f[0]), math.Abs(f[1]), math.Abs(f[2]))
+		}
+		quantisedMax := int(max(0, min(82, math.Floor(actualMax*166-0.5))))
+		maxValue = float64(quantisedMax+1) / 166
+		sb.WriteString(encodeBase83(quantisedMax, 1))
+	} else {
+		sb.WriteString(encodeBase83(0, 1))
+	}
+
+	sb.WriteString(encodeBase83(linearToSRGB(dc[0])<<16|linearToSRGB(dc[1])<<8|linearToSRGB(dc[2]), 4))
+	quant := func(v float64) int {
+		return int(max(0, min(18, math.Floor(signPow(v/maxValue, 0.5)*9+9.5))))
+	}
+	for _, f := range ac {
+		sb.WriteString(encodeBase83(quant(f[0])*19*19+quant(f[1])*19+quant(f[2]), 2))
+	}
+	return sb.String(), nil
+}
+
+// DominantColor returns the most common color of img as #rrggbb. Pixels are
+// grouped into buckets of 16 levels per channel and the average of the
+// fullest bucket is returned, so noise and gradients do not split the vote.
+// Transparent pixels are ignored; a fully transparent image has no color.
+func DominantColor(img image.Image) string {
+	type bucket struct {
+		count   int
+		r, g, b int
+	}
+	buckets := map[int]*bucket{}
+	var best *bucket
+
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A < 128 {
+				continue
+			}
+			key := int(c.R>>4)<<8 | int(c.G>>4)<<4 | int(c.B>>4)
+			bk, ok := buckets[key]
+			if !ok {
+				bk = &bucket{}
+				buckets[key] = bk
+			}
+			bk.count++
+			bk.r += int(c.R)
+			bk.g += int(c.G)
+			bk.b += int(c.B)
+			if best == nil || bk.count > best.count {
+				best = bk
+			}
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return fmt.Sprintf("#%02x%02x%02x", best.r/best.count, best.g/best.count, best.b/best.count)
+}
+
+func encodeBase83(value, length int) string {
+	out := make([]byte, length)
+	for i := range length {
+		digit := value
+		for range length - i - 1 {
+			digit /= 83
+		}
+		out[i] = base83Alphabet[digit%83]
+	}
+	return string(out)
+}
+
+func srgbToLinear(c uint8) float64 {
+	v := float64(c) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(v float64) int {
+	v = max(0, min(1, v))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(v, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(v), exp), v)
+}
@@ -0,0 +1,63 @@
+package thumbnailer
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func solid(width, height int, c color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestEncodeBlurHash(t *testing.T) {
+	red := color.NRGBA{R: 255, A: 255}
+
+	// Size flag L for 4x3 components, then after the AC maximum the DC term
+	// carries the flat colour exactly.
+	hash, err := EncodeBlurHash(solid(32, 24, red), 4, 3)
+	require.NoError(t, err)
+	assert.Len(t, hash, 1+1+4+2*11)
+	assert.Equal(t, "L", hash[:1])
+	assert.Equal(t, "TI:j", hash[2:6])
+
+	split, err := EncodeBlurHash(quadrants(32, 24), 4, 3)
+	require.NoError(t, err)
+	assert.Len(t, split, 28)
+	assert.NotEqual(t, hash[6:8], split[6:8], "a left to right change must show in the first AC component")
+
+	_, err = EncodeBlurHash(solid(4, 4, red), 0, 3)
+	require.Error(t, err)
+}
+
+func TestComputePlaceholder(t *testing.T) {
+	portrait, err := ComputePlaceholder(solid(60, 200, color.NRGBA{G: 255, A: 255}))
+	require.NoError(t, err)
+	assert.Equal(t, byte('T'), portrait.BlurHash[0], "portrait images use 3x4 components")
+	assert.Equal(t, "#00ff00", portrait.DominantColor)
+
+	landscape, err := ComputePlaceholder(quadrants(400, 200))
+	require.NoError(t, err)
+	assert.Equal(t, byte('L'), landscape.BlurHash[0])
+}
+
+func TestDominantColor(t *testing.T) {
+	img := solid(40, 40, color.NRGBA{B: 250, A: 255})
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 20; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+	assert.Equal(t, "#0000fa", DominantColor(img))
+
+	assert.Empty(t, DominantColor(image.NewNRGBA(image.Rect(0, 0, 8, 8))), "transparent images have no color")
+}
@@ -192,7 +192,7 @@ func createThumbnail(
 		}
 		defer util.CloseAndLogOnError(ctx, dstFile)
 
-		info, err := storage.EncryptStream(ctx, srcFile, dstFile, []byte(encryptionKey))
+		info, err := storage.EncryptStream(ctx, srcFile, dstFile, []byte(encryptionKey), storage.EncryptionRecorderOf(provider))
 		if err != nil {
 			return err
 		}
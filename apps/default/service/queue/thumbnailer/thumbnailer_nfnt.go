@@ -232,7 +232,8 @@ func createThumbnail(
 		}
 		defer util.CloseAndLogOnError(ctx, dstFile)
 
-		info, encryptErr := storage2.EncryptStream(ctx, srcFile, dstFile, []byte(encryptionKey))
+		info, encryptErr := storage2.EncryptStream(ctx, srcFile, dstFile, []byte(encryptionKey),
+			storage2.EncryptionRecorderOf(provider))
 		if encryptErr != nil {
 			return encryptErr
 		}
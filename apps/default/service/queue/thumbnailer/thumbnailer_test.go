@@ -109,6 +109,15 @@ func (suite *ThumbnailerTestSuite) TestGenerateThumbnail() {
 					res.RetentionPolicyRepo,
 					res.FileRetentionRepo,
 					res.StorageStatsRepo,
+					res.WebhookSubscriptionRepo,
+					res.WebhookDeliveryRepo,
+					res.ThumbnailJobRepo,
+					res.ThumbnailRegenerationRepo,
+					res.ArchiveExtractionRepo,
+					res.ShareLinkRepo,
+					res.AccessGrantRepo,
+					res.UserDataExportRepo,
+					res.UserDataErasureRepo,
 				)
 				require.NoError(t, err)
 
@@ -204,6 +213,15 @@ func (suite *ThumbnailerTestSuite) TestGenerateThumbnails() {
 					res.RetentionPolicyRepo,
 					res.FileRetentionRepo,
 					res.StorageStatsRepo,
+					res.WebhookSubscriptionRepo,
+					res.WebhookDeliveryRepo,
+					res.ThumbnailJobRepo,
+					res.ThumbnailRegenerationRepo,
+					res.ArchiveExtractionRepo,
+					res.ShareLinkRepo,
+					res.AccessGrantRepo,
+					res.UserDataExportRepo,
+					res.UserDataErasureRepo,
 				)
 				require.NoError(t, err)
 				storageProvider, err := provider.GetStorageProvider(ctx, cfg)
@@ -0,0 +1,294 @@
+package thumbnailer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif" // registers the gif decoder for transform sources
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	nfnt "github.com/nfnt/resize"
+	"github.com/pitabwire/util"
+	_ "golang.org/x/image/webp" // registers the webp decoder for transform sources
+)
+
+// maxSourcePixels bounds the decoded size of a transform source so a small
+// file with huge declared dimensions cannot exhaust memory.
+const maxSourcePixels = 50_000_000
+
+// ApplyTransform resizes img to the transform's pixel size and then rotates
+// it. Width and height describe the output orientation. Images are never
+// upscaled.
+func ApplyTransform(img image.Image, t *types.ImageTransform) image.Image {
+	w, h := t.PixelSize()
+	if t.Rotate == 90 || t.Rotate == 270 {
+		w, h = h, w
+	}
+
+	var out image.Image
+	if t.ResizeMethod == types.Crop {
+		out = focalCrop(img, w, h, t.FocalX, t.FocalY)
+	} else {
+		out = nfnt.Thumbnail(uint(w), uint(h), img, nfnt.Lanczos3)
+	}
+	return rotate(out, t.Rotate)
+}
+
+// EncodeTransformed writes img in the transform's output format.
+func EncodeTransformed(w io.Writer, img image.Image, t *types.ImageTransform) error {
+	switch t.Format {
+	case types.FormatPNG:
+		return png.Encode(w, img)
+	case types.FormatWebP:
+		return encodeWebP(w, img)
+	default:
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: t.Quality})
+	}
+}
+
+// GenerateDerivative renders the transform of the source media and stores the
+// result as child media keyed by the transform, so identical requests are
+// served from storage.
+func GenerateDerivative(
+	ctx context.Context,
+	t *types.ImageTransform,
+	mediaMetadata *types.MediaMetadata,
+	absBasePath config.Path,
+	db storage.Database,
+	provider storage.Provider,
+	logger *util.LogEntry,
+	encryptionKey string,
+) (*types.ThumbnailMetadata, error) {
+	logger = logger.With("media_id", mediaMetadata.MediaID, "transform", t.Key())
+
+	img, err := readSourceImage(ctx, provider, absBasePath, mediaMetadata, encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	tempDir, err := utils.CreateTempDir(absBasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer utils.RemoveDir(tempDir, logger)
+
+	start := time.Now()
+	out := ApplyTransform(img, t)
+	outPath := types.Path(filepath.Join(string(tempDir), "derivative"))
+	if err = writeTransformed(outPath, out, t); err != nil {
+		return nil, err
+	}
+	logger.With(
+		"actual_width", out.Bounds().Dx(),
+		"actual_height", out.Bounds().Dy(),
+		"process_time", time.Since(start),
+	).Debug("generated image derivative")
+
+	hash, size, err := utils.ComputeHashAndSize(outPath)
+	if err != nil {
+		return nil, err
+	}
+
+	pixelWidth, pixelHeight := t.PixelSize()
+	derivative := &types.ThumbnailMetadata{
+		MediaMetadata: &types.MediaMetadata{
+			MediaID:           types.MediaID(utils.GenerateRandomString(32)),
+			ParentID:          mediaMetadata.MediaID,
+			ContentType:       t.ContentType(),
+			FileSizeBytes:     size,
+			Base64Hash:        hash,
+			OwnerID:           mediaMetadata.OwnerID,
+			ServerName:        mediaMetadata.ServerName,
+			IsPublic:          mediaMetadata.IsPublic,
+			CreationTimestamp: uint64(time.Now().UnixMilli()),
+			ThumbnailSize: &types.ThumbnailSize{
+				Width:        pixelWidth,
+				Height:       pixelHeight,
+				ResizeMethod: t.ResizeMethod,
+			},
+			Transform: t.Key(),
+		},
+	}
+
+	sourcePath := outPath
+	if !mediaMetadata.IsPublic {
+		encryptedPath := types.Path(string(outPath) + ".encrypted")
+		if err = encryptFile(ctx, provider, outPath, encryptedPath, derivative.MediaMetadata, encryptionKey); err != nil {
+			return nil, err
+		}
+		sourcePath = encryptedPath
+	}
+
+	finalPath, duplicate, err := storage.UploadFileWithHashCheck(ctx, provider, sourcePath, derivative.MediaMetadata, absBasePath, logger)
+	if err != nil {
+		return nil, err
+	}
+	if duplicate {
+		logger.With("dst", finalPath).Debug("file already stored, discarding duplicate")
+	}
+
+	if err = db.StoreThumbnail(ctx, derivative); err != nil {
+		logger.WithError(err).Error("failed to store image derivative metadata")
+		return nil, err
+	}
+	return derivative, nil
+}
+
+// readSourceImage downloads, decrypts and decodes the source media.
+func readSourceImage(ctx context.Context, provider storage.Provider, absBasePath config.Path, mediaMetadata *types.MediaMetadata, encryptionKey string) (image.Image, error) {
+	finalPath, err := utils.GetPathFromBase64Hash(mediaMetadata.Base64Hash, absBasePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file path from metadata: %w", err)
+	}
+
+	reader, finalizer, err := provider.DownloadFile(ctx, provider.GetBucket(mediaMetadata.IsPublic), types.Path(finalPath))
+	if err != nil {
+		return nil, err
+	}
+	defer finalizer()
+
+	var src io.Reader = reader
+	if mediaMetadata.Encryption != nil {
+		src, err = storage.NewDecryptingReader(reader, []byte(encryptionKey), mediaMetadata.Encryption,
+			storage.EncryptionRecorderOf(provider))
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialise decrypting reader: %w", err)
+		}
+	}
+
+	raw, err := io.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || int64(cfg.Width)*int64(cfg.Height) > maxSourcePixels {
+		return nil, errors.New("source image dimensions are too large to transform")
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	return img, err
+}
+
+func writeTransformed(dst types.Path, img image.Image, t *types.ImageTransform) (err error) {
+	out, err := os.Create(string(dst))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	return EncodeTransformed(out, img, t)
+}
+
+func encryptFile(ctx context.Context, provider storage.Provider, src, dst types.Path, mediaMetadata *types.MediaMetadata, encryptionKey string) error {
+	if len(encryptionKey) != 32 {
+		return fmt.Errorf("invalid encryption key length")
+	}
+	srcFile, err := os.Open(string(src))
+	if err != nil {
+		return err
+	}
+	defer util.CloseAndLogOnError(ctx, srcFile)
+
+	dstFile, err := os.Create(string(dst))
+	if err != nil {
+		return err
+	}
+	defer util.CloseAndLogOnError(ctx, dstFile)
+
+	info, err := storage.EncryptStream(ctx, srcFile, dstFile, []byte(encryptionKey), storage.EncryptionRecorderOf(provider))
+	if err != nil {
+		return err
+	}
+	mediaMetadata.Encryption = info
+	return nil
+}
+
+// focalCrop scales img to cover w x h and crops the excess around the focal
+// point, given as fractions of the source dimensions. When the source is
+// smaller than the window, the window shrinks to keep its aspect ratio.
+func focalCrop(img image.Image, w, h int, fx, fy float64) image.Image {
+	b := img.Bounds()
+	sw, sh := float64(b.Dx()), float64(b.Dy())
+
+	scale := math.Max(float64(w)/sw, float64(h)/sh)
+	if scale > 1 {
+		w = max(1, int(math.Round(float64(w)/scale)))
+		h = max(1, int(math.Round(float64(h)/scale)))
+		scale = 1
+	}
+
+	scaled := img
+	scaledW, scaledH := b.Dx(), b.Dy()
+	if scale < 1 {
+		scaledW = max(w, int(math.Round(sw*scale)))
+		scaledH = max(h, int(math.Round(sh*scale)))
+		scaled = nfnt.Resize(uint(scaledW), uint(scaledH), img, nfnt.Lanczos3)
+	}
+	w, h = min(w, scaledW), min(h, scaledH)
+
+	x0 := clamp(int(math.Round(fx*float64(scaledW)-float64(w)/2)), 0, scaledW-w)
+	y0 := clamp(int(math.Round(fy*float64(scaledH)-float64(h)/2)), 0, scaledH-h)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), scaled, scaled.Bounds().Min.Add(image.Pt(x0, y0)), draw.Src)
+	return dst
+}
+
+// rotate turns img clockwise by 90, 180 or 270 degrees.
+func rotate(img image.Image, degrees int) image.Image {
+	if degrees != 90 && degrees != 180 && degrees != 270 {
+		return img
+	}
+
+	b := img.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	src := image.NewNRGBA(image.Rect(0, 0, sw, sh))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+
+	dstRect := image.Rect(0, 0, sh, sw)
+	if degrees == 180 {
+		dstRect = image.Rect(0, 0, sw, sh)
+	}
+	dst := image.NewNRGBA(dstRect)
+	for y := 0; y < sh; y++ {
+		for x := 0; x < sw; x++ {
+			var dx, dy int
+			switch degrees {
+			case 90:
+				dx, dy = sh-1-y, x
+			case 180:
+				dx, dy = sw-1-x, sh-1-y
+			default:
+				dx, dy = y, sw-1-x
+			}
+			si := src.PixOffset(x, y)
+			di := dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+	return dst
+}
+
+func clamp(v, lo, hi int) int {
+	return max(lo, min(v, hi))
+}
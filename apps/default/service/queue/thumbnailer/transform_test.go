@@ -0,0 +1,116 @@
+package thumbnailer
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTransform(width, height int, method string) *types.ImageTransform {
+	return &types.ImageTransform{
+		Width: width, Height: height, ResizeMethod: method,
+		Format: types.FormatJPEG, Quality: types.DefaultTransformQuality,
+		FocalX: 0.5, FocalY: 0.5, DPR: 1,
+	}
+}
+
+// quadrants returns an image whose left half is red and right half is blue.
+func quadrants(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.NRGBA{R: 255, A: 255}
+			if x >= width/2 {
+				c = color.NRGBA{B: 255, A: 255}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestApplyTransformSizes(t *testing.T) {
+	src := quadrants(400, 200)
+
+	scaled := ApplyTransform(src, newTestTransform(100, 100, types.Scale))
+	assert.Equal(t, image.Pt(100, 50), scaled.Bounds().Size())
+
+	cropped := ApplyTransform(src, newTestTransform(100, 100, types.Crop))
+	assert.Equal(t, image.Pt(100, 100), cropped.Bounds().Size())
+
+	dpr := newTestTransform(50, 50, types.Crop)
+	dpr.DPR = 2
+	assert.Equal(t, image.Pt(100, 100), ApplyTransform(src, dpr).Bounds().Size())
+
+	rotated := newTestTransform(50, 100, types.Crop)
+	rotated.Rotate = 90
+	assert.Equal(t, image.Pt(50, 100), ApplyTransform(src, rotated).Bounds().Size())
+
+	// Requests larger than the source are not upscaled.
+	large := ApplyTransform(src, newTestTransform(800, 800, types.Crop))
+	assert.Equal(t, image.Pt(200, 200), large.Bounds().Size())
+}
+
+func TestApplyTransformFocalPoint(t *testing.T) {
+	src := quadrants(400, 200)
+
+	left := newTestTransform(50, 50, types.Crop)
+	left.FocalX = 0
+	out := ApplyTransform(src, left)
+	r, _, b, _ := out.At(25, 25).RGBA()
+	assert.Greater(t, r, b, "focal point on the left keeps the red half")
+
+	right := newTestTransform(50, 50, types.Crop)
+	right.FocalX = 1
+	out = ApplyTransform(src, right)
+	r, _, b, _ = out.At(25, 25).RGBA()
+	assert.Greater(t, b, r, "focal point on the right keeps the blue half")
+}
+
+func TestApplyTransformRotation(t *testing.T) {
+	src := quadrants(4, 2)
+	transform := newTestTransform(4, 4, types.Scale)
+
+	transform.Rotate = 90
+	out := ApplyTransform(src, transform)
+	require.Equal(t, image.Pt(2, 4), out.Bounds().Size())
+	// Clockwise: the left (red) half ends up on top.
+	assert.Equal(t, color.NRGBA{R: 255, A: 255}, color.NRGBAModel.Convert(out.At(0, 0)))
+	assert.Equal(t, color.NRGBA{B: 255, A: 255}, color.NRGBAModel.Convert(out.At(0, 3)))
+
+	transform.Rotate = 180
+	out = ApplyTransform(src, transform)
+	assert.Equal(t, color.NRGBA{B: 255, A: 255}, color.NRGBAModel.Convert(out.At(0, 0)))
+
+	transform.Rotate = 270
+	out = ApplyTransform(src, transform)
+	assert.Equal(t, color.NRGBA{B: 255, A: 255}, color.NRGBAModel.Convert(out.At(0, 0)))
+}
+
+func TestEncodeTransformedFormats(t *testing.T) {
+	src := quadrants(20, 10)
+
+	for format, name := range map[string]string{
+		types.FormatJPEG: "jpeg",
+		types.FormatPNG:  "png",
+		types.FormatWebP: "webp",
+	} {
+		t.Run(format, func(t *testing.T) {
+			transform := newTestTransform(20, 10, types.Scale)
+			transform.Format = format
+
+			var buf bytes.Buffer
+			require.NoError(t, EncodeTransformed(&buf, src, transform))
+
+			decoded, decodedFormat, err := image.Decode(&buf)
+			require.NoError(t, err)
+			assert.Equal(t, name, decodedFormat)
+			assert.Equal(t, src.Bounds().Size(), decoded.Bounds().Size())
+		})
+	}
+}
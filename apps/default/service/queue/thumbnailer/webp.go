@@ -0,0 +1,324 @@
+package thumbnailer
+
+import (
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	"io"
+	"sort"
+)
+
+// VP8L (lossless WebP) bitstream constants, see
+// https://developers.google.com/speed/webp/docs/webp_lossless_bitstream_specification
+const (
+	vp8lSignature      = 0x2f
+	vp8lMaxDimension   = 1 << 14
+	vp8lSubtractGreen  = 2
+	vp8lGreenAlphabet  = 256 + 24
+	vp8lColorAlphabet  = 256
+	vp8lDistAlphabet   = 40
+	vp8lMaxCodeLength  = 15
+	vp8lMaxCLCodeLen   = 7
+	vp8lCodeLengthSyms = 19
+)
+
+var vp8lCodeLengthOrder = [vp8lCodeLengthSyms]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// encodeWebP writes img as a lossless WebP. The encoder applies the subtract
+// green transform and entropy codes literals only; it trades compression
+// ratio for simplicity, which suits thumbnail-sized images.
+func encodeWebP(w io.Writer, img image.Image) error {
+	b := img.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width < 1 || height < 1 || width > vp8lMaxDimension || height > vp8lMaxDimension {
+		return errors.New("webp: image dimensions out of range")
+	}
+
+	nrgba, ok := img.(*image.NRGBA)
+	if !ok || nrgba.Rect.Min != (image.Point{}) || nrgba.Stride != 4*width {
+		nrgba = image.NewNRGBA(image.Rect(0, 0, width, height))
+		draw.Draw(nrgba, nrgba.Bounds(), img, b.Min, draw.Src)
+	}
+
+	// Subtract green and gather symbol histograms.
+	pix := make([]byte, len(nrgba.Pix))
+	copy(pix, nrgba.Pix)
+	var green, red, blue, alpha [256]uint32
+	hasAlpha := false
+	for i := 0; i < len(pix); i += 4 {
+		pix[i] -= pix[i+1]
+		pix[i+2] -= pix[i+1]
+		red[pix[i]]++
+		green[pix[i+1]]++
+		blue[pix[i+2]]++
+		alpha[pix[i+3]]++
+		if pix[i+3] != 0xff {
+			hasAlpha = true
+		}
+	}
+
+	bw := &bitWriter{}
+	bw.write(vp8lSignature, 8)
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	if hasAlpha {
+		bw.write(1, 1)
+	} else {
+		bw.write(0, 1)
+	}
+	bw.write(0, 3) // version
+
+	bw.write(1, 1) // transform present
+	bw.write(vp8lSubtractGreen, 2)
+	bw.write(0, 1) // no further transforms
+
+	bw.write(0, 1) // no color cache
+	bw.write(0, 1) // no meta prefix codes
+
+	greenFreq := make([]uint32, vp8lGreenAlphabet)
+	copy(greenFreq, green[:])
+	codes := [4]*prefixCode{
+		writePrefixCode(bw, greenFreq),
+		writePrefixCode(bw, red[:]),
+		writePrefixCode(bw, blue[:]),
+		writePrefixCode(bw, alpha[:]),
+	}
+	writePrefixCode(bw, make([]uint32, vp8lDistAlphabet))
+
+	for i := 0; i < len(pix); i += 4 {
+		codes[0].emit(bw, int(pix[i+1]))
+		codes[1].emit(bw, int(pix[i]))
+		codes[2].emit(bw, int(pix[i+2]))
+		codes[3].emit(bw, int(pix[i+3]))
+	}
+	payload := bw.bytes()
+
+	padded := len(payload) + len(payload)&1
+	header := make([]byte, 20)
+	copy(header[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(4+8+padded))
+	copy(header[8:12], "WEBP")
+	copy(header[12:16], "VP8L")
+	binary.LittleEndian.PutUint32(header[16:20], uint32(len(payload)))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.Write(payload); err != nil {
+		return err
+	}
+	if padded != len(payload) {
+		if _, err := w.Write([]byte{0}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bitWriter packs values least significant bit first, as VP8L requires.
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits uint
+}
+
+func (w *bitWriter) write(v uint32, n uint) {
+	w.acc |= uint64(v) << w.nbits
+	w.nbits += n
+	for w.nbits >= 8 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc >>= 8
+		w.nbits -= 8
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	if w.nbits > 0 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc, w.nbits = 0, 0
+	}
+	return w.buf
+}
+
+// prefixCode holds the bit-reversed codes ready to be written LSB first.
+type prefixCode struct {
+	codes   []uint32
+	lengths []uint32
+}
+
+func (c *prefixCode) emit(w *bitWriter, symbol int) {
+	if n := c.lengths[symbol]; n > 0 {
+		w.write(c.codes[symbol], uint(n))
+	}
+}
+
+// writePrefixCode writes the prefix code for the given histogram and returns
+// the code used to emit symbols.
+func writePrefixCode(w *bitWriter, freqs []uint32) *prefixCode {
+	var used []int
+	for symbol, f := range freqs {
+		if f > 0 {
+			used = append(used, symbol)
+		}
+	}
+
+	code := &prefixCode{codes: make([]uint32, len(freqs)), lengths: make([]uint32, len(freqs))}
+
+	// Simple code: one or two 8-bit symbols.
+	if len(used) <= 2 && (len(used) == 0 || used[len(used)-1] < 256) {
+		if len(used) == 0 {
+			used = []int{0}
+		}
+		w.write(1, 1)
+		w.write(uint32(len(used)-1), 1)
+		if used[0] < 2 {
+			w.write(0, 1)
+			w.write(uint32(used[0]), 1)
+		} else {
+			w.write(1, 1)
+			w.write(uint32(used[0]), 8)
+		}
+		if len(used) == 2 {
+			w.write(uint32(used[1]), 8)
+			code.codes[used[1]] = 1
+			code.lengths[used[0]] = 1
+			code.lengths[used[1]] = 1
+		}
+		return code
+	}
+
+	lengths := huffmanLengths(freqs, vp8lMaxCodeLength)
+	code.codes = canonicalCodes(lengths)
+	code.lengths = lengths
+
+	// Normal code: code lengths are themselves prefix coded.
+	var clFreqs [vp8lCodeLengthSyms]uint32
+	for _, l := range lengths {
+		clFreqs[l]++
+	}
+	clLengths := huffmanLengths(clFreqs[:], vp8lMaxCLCodeLen)
+	clCode := &prefixCode{codes: canonicalCodes(clLengths), lengths: clLengths}
+	clUsed := 0
+	for _, l := range clLengths {
+		if l > 0 {
+			clUsed++
+		}
+	}
+	if clUsed == 1 {
+		// A single-symbol code consumes no bits.
+		clCode.lengths = make([]uint32, vp8lCodeLengthSyms)
+	}
+
+	nCodes := vp8lCodeLengthSyms
+	for nCodes > 4 && clLengths[vp8lCodeLengthOrder[nCodes-1]] == 0 {
+		nCodes--
+	}
+	w.write(0, 1)
+	w.write(uint32(nCodes-4), 4)
+	for i := 0; i < nCodes; i++ {
+		w.write(clLengths[vp8lCodeLengthOrder[i]], 3)
+	}
+	w.write(0, 1) // code lengths for the whole alphabet follow
+	for _, l := range lengths {
+		clCode.emit(w, int(l))
+	}
+	return code
+}
+
+// huffmanLengths computes code lengths no longer than maxLen for the symbols
+// with a non-zero frequency. At least two symbols must be used.
+func huffmanLengths(freqs []uint32, maxLen uint32) []uint32 {
+	f := make([]uint64, len(freqs))
+	for i, v := range freqs {
+		f[i] = uint64(v)
+	}
+
+	for {
+		var symbols []int
+		for s, v := range f {
+			if v > 0 {
+				symbols = append(symbols, s)
+			}
+		}
+		lengths := make([]uint32, len(freqs))
+		if len(symbols) == 1 {
+			lengths[symbols[0]] = 1
+			return lengths
+		}
+		sort.SliceStable(symbols, func(i, j int) bool { return f[symbols[i]] < f[symbols[j]] })
+
+		// Two-queue Huffman construction: leaves sorted by weight followed
+		// by internal nodes, which are created in non-decreasing weight.
+		n := len(symbols)
+		weight := make([]uint64, 0, 2*n-1)
+		parent := make([]int, 2*n-1)
+		for _, s := range symbols {
+			weight = append(weight, f[s])
+		}
+		leaf, internal := 0, n
+		pick := func() int {
+			if leaf < n && (internal >= len(weight) || weight[leaf] <= weight[internal]) {
+				leaf++
+				return leaf - 1
+			}
+			internal++
+			return internal - 1
+		}
+		for len(weight) < 2*n-1 {
+			a, b := pick(), pick()
+			parent[a], parent[b] = len(weight), len(weight)
+			weight = append(weight, weight[a]+weight[b])
+		}
+
+		depth := make([]uint32, 2*n-1)
+		tooLong := false
+		for i := 2*n - 3; i >= 0; i-- {
+			depth[i] = depth[parent[i]] + 1
+			if i < n && depth[i] > maxLen {
+				tooLong = true
+			}
+		}
+		if !tooLong {
+			for i, s := range symbols {
+				lengths[s] = depth[i]
+			}
+			return lengths
+		}
+
+		// Flatten the distribution and retry until the tree fits.
+		for _, s := range symbols {
+			f[s] = f[s]>>1 + 1
+		}
+	}
+}
+
+// canonicalCodes assigns canonical prefix codes to the lengths and returns
+// them bit-reversed so they can be written least significant bit first.
+func canonicalCodes(lengths []uint32) []uint32 {
+	var count [vp8lMaxCodeLength + 1]uint32
+	for _, l := range lengths {
+		count[l]++
+	}
+	count[0] = 0
+	var next [vp8lMaxCodeLength + 1]uint32
+	code := uint32(0)
+	for l := 1; l <= vp8lMaxCodeLength; l++ {
+		code = (code + count[l-1]) << 1
+		next[l] = code
+	}
+
+	codes := make([]uint32, len(lengths))
+	for symbol, l := range lengths {
+		if l == 0 {
+			continue
+		}
+		c := next[l]
+		next[l]++
+		var reversed uint32
+		for i := uint32(0); i < l; i++ {
+			reversed = reversed<<1 | (c>>i)&1
+		}
+		codes[symbol] = reversed
+	}
+	return codes
+}
@@ -0,0 +1,85 @@
+package thumbnailer
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+)
+
+func TestEncodeWebPRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	gradient := image.NewNRGBA(image.Rect(0, 0, 64, 48))
+	noisy := image.NewNRGBA(image.Rect(0, 0, 33, 17))
+	for y := 0; y < 48; y++ {
+		for x := 0; x < 64; x++ {
+			gradient.SetNRGBA(x, y, color.NRGBA{R: uint8(x * 4), G: uint8(y * 5), B: uint8(x + y), A: 0xff})
+		}
+	}
+	for y := 0; y < 17; y++ {
+		for x := 0; x < 33; x++ {
+			noisy.SetNRGBA(x, y, color.NRGBA{R: uint8(rng.IntN(256)), G: uint8(rng.IntN(256)), B: uint8(rng.IntN(256)), A: uint8(rng.IntN(256))})
+		}
+	}
+	solid := image.NewNRGBA(image.Rect(0, 0, 5, 3))
+	for i := range solid.Pix {
+		solid.Pix[i] = 0x80
+	}
+	twoTone := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			c := color.NRGBA{R: 10, G: 20, B: 30, A: 0xff}
+			if (x+y)%2 == 0 {
+				c = color.NRGBA{R: 200, G: 100, B: 50, A: 0xff}
+			}
+			twoTone.SetNRGBA(x, y, c)
+		}
+	}
+	// Geometric distribution to push Huffman depths past the 15 bit limit.
+	skewed := image.NewNRGBA(image.Rect(0, 0, 256, 256))
+	for i := 0; i < len(skewed.Pix); i++ {
+		v := 0
+		for v < 40 && rng.IntN(2) == 0 {
+			v++
+		}
+		skewed.Pix[i] = uint8(v)
+	}
+	offset := image.NewRGBA(image.Rect(10, 10, 13, 12))
+	offset.Set(11, 11, color.RGBA{R: 255, A: 255})
+
+	testCases := map[string]image.Image{
+		"gradient": gradient,
+		"noisy":    noisy,
+		"solid":    solid,
+		"skewed":   skewed,
+		"two tone": twoTone,
+		"offset":   offset,
+		"1x1":      image.NewNRGBA(image.Rect(0, 0, 1, 1)),
+	}
+
+	for name, src := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, encodeWebP(&buf, src))
+
+			decoded, err := webp.Decode(&buf)
+			require.NoError(t, err)
+
+			b := src.Bounds()
+			require.Equal(t, b.Dx(), decoded.Bounds().Dx())
+			require.Equal(t, b.Dy(), decoded.Bounds().Dy())
+			for y := 0; y < b.Dy(); y++ {
+				for x := 0; x < b.Dx(); x++ {
+					want := color.NRGBAModel.Convert(src.At(b.Min.X+x, b.Min.Y+y)).(color.NRGBA)
+					got := color.NRGBAModel.Convert(decoded.At(x, y)).(color.NRGBA)
+					require.Equal(t, want, got, "pixel %d,%d", x, y)
+				}
+			}
+		})
+	}
+}
@@ -4,14 +4,30 @@ import (
 	"context"
 	"encoding/json"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/antinvestor/service-files/apps/default/config"
+	filesv1 "github.com/antinvestor/service-files/apps/default/gen/files/v1"
+	"github.com/antinvestor/service-files/apps/default/service/events"
 	"github.com/antinvestor/service-files/apps/default/service/queue/thumbnailer"
 	storage2 "github.com/antinvestor/service-files/apps/default/service/storage"
 	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/antinvestor/service-files/apps/default/service/webhooks"
 	"github.com/pitabwire/frame/v2"
+	"github.com/pitabwire/util"
 )
 
+const (
+	thumbnailRetryBatchSize = 100
+	maxJobErrorLength       = 512
+)
+
+// ThumbnailQueueHandler generates the thumbnails of newly stored images.
+// Every image gets a job record; failed attempts are rescheduled with
+// exponential backoff and retried by ThumbnailRetrier until they succeed or
+// are dead-lettered.
 type ThumbnailQueueHandler struct {
 	service       *frame.Service
 	mediaDatabase storage2.Database
@@ -20,39 +36,155 @@ type ThumbnailQueueHandler struct {
 
 func (fq *ThumbnailQueueHandler) Handle(ctx context.Context, _ map[string]string, payload []byte) error {
 
-	logger := fq.service.Log(ctx)
-
 	mediaPayload := map[string]string{}
 	err := json.Unmarshal(payload, &mediaPayload)
 	if err != nil {
 		return err
 	}
 
-	mediaMetadata, err := fq.mediaDatabase.GetMediaMetadata(ctx, types.MediaID(mediaPayload["media_id"]))
+	mediaID := types.MediaID(mediaPayload["media_id"])
+	mediaMetadata, err := fq.mediaDatabase.GetMediaMetadata(ctx, mediaID)
 	if err != nil {
 		return err
 	}
-	if mediaMetadata == nil {
+	if mediaMetadata == nil || !hasThumbnails(mediaMetadata) {
 		return nil
 	}
 
-	if !strings.HasPrefix(string(mediaMetadata.ContentType), "image") {
+	job, err := fq.mediaDatabase.GetThumbnailJob(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		// The first attempt runs now; the retry time only matters if this
+		// worker dies before recording the outcome.
+		lease := ThumbnailJobLease(fq.service.Config().(*config.FilesConfig))
+		job = &types.ThumbnailJob{
+			MediaID:       mediaID,
+			State:         types.ThumbnailJobPending,
+			NextAttemptAt: &lease,
+		}
+		if err = fq.mediaDatabase.StoreThumbnailJob(ctx, job); err != nil {
+			return err
+		}
+	} else if job.State != types.ThumbnailJobPending {
+		// A redelivered message for a finished job; requeueing resets the
+		// job to pending before publishing.
 		return nil
 	}
 
+	fq.attempt(ctx, mediaMetadata, job)
+	return nil
+
+}
+
+// RetryDue attempts every pending job whose next attempt is due and returns
+// the number of jobs attempted. Each job runs under its own tenant. Jobs are
+// leased as they are claimed, so replicas running the sweep at the same
+// time never attempt the same job.
+func (fq *ThumbnailQueueHandler) RetryDue(ctx context.Context) (int, error) {
 	cfg := fq.service.Config().(*config.FilesConfig)
+	jobs, err := fq.mediaDatabase.ClaimDueThumbnailJobs(
+		utils.SystemContext(ctx, "thumbnail job retry"), time.Now().UTC(), ThumbnailJobLease(cfg), thumbnailRetryBatchSize)
+	if err != nil {
+		return 0, err
+	}
 
-	thumbnailSizes := cfg.ThumbnailSizes
+	for _, job := range jobs {
+		jobCtx := utils.TenantContext(ctx, job.TenantID, job.PartitionID)
 
-	err = thumbnailer.GenerateThumbnails(
-		ctx, thumbnailSizes, mediaMetadata, cfg.AbsBasePath, fq.mediaDatabase, fq.provider, logger, cfg.EnvStorageEncryptionPhrase,
+		mediaMetadata, mediaErr := fq.mediaDatabase.GetMediaMetadata(jobCtx, job.MediaID)
+		if mediaErr != nil {
+			util.Log(ctx).WithError(mediaErr).With("media_id", job.MediaID).Warn("failed to load media for thumbnail retry")
+			continue
+		}
+		if mediaMetadata == nil || !hasThumbnails(mediaMetadata) {
+			job.State = types.ThumbnailJobDeadLetter
+			job.NextAttemptAt = nil
+			job.LastError = "media removed or no longer eligible for thumbnails"
+			fq.record(jobCtx, job)
+			continue
+		}
+		fq.attempt(jobCtx, mediaMetadata, job)
+	}
+
+	return len(jobs), nil
+}
+
+// attempt generates the thumbnails once and records the outcome, scheduling
+// the next attempt or dead-lettering the job when attempts are exhausted.
+func (fq *ThumbnailQueueHandler) attempt(ctx context.Context, mediaMetadata *types.MediaMetadata, job *types.ThumbnailJob) {
+	logger := fq.service.Log(ctx).With("media_id", mediaMetadata.MediaID)
+	cfg := fq.service.Config().(*config.FilesConfig)
+
+	// The placeholder is independent of the thumbnails, so a failure of one
+	// does not hold back the other.
+	err := thumbnailer.GeneratePlaceholder(
+		ctx, mediaMetadata, cfg.AbsBasePath, fq.mediaDatabase, fq.provider, cfg.EnvStorageEncryptionPhrase,
 	)
 	if err != nil {
-		logger.WithError(err).With("media_id", mediaMetadata.MediaID).Warn("failed to generate thumbnails")
+		logger.WithError(err).Warn("failed to generate placeholder")
 	}
 
-	return nil
+	job.Attempts++
+	err = thumbnailer.GenerateThumbnails(
+		ctx, cfg.ThumbnailSizes, mediaMetadata, cfg.AbsBasePath, fq.mediaDatabase, fq.provider, logger, cfg.EnvStorageEncryptionPhrase,
+	)
+
+	now := time.Now().UTC()
+	switch {
+	case err == nil:
+		job.State = types.ThumbnailJobCompleted
+		job.CompletedAt = &now
+		job.NextAttemptAt = nil
+		job.LastError = ""
+	case job.Attempts >= cfg.ThumbnailMaxAttempts:
+		logger.WithError(err).With("attempts", job.Attempts).Warn("thumbnail generation dead-lettered")
+		job.State = types.ThumbnailJobDeadLetter
+		job.NextAttemptAt = nil
+		job.LastError = truncateError(err.Error())
+	default:
+		logger.WithError(err).With("attempts", job.Attempts).Warn("failed to generate thumbnails, will retry")
+		next := now.Add(webhooks.Backoff(job.Attempts,
+			time.Duration(cfg.ThumbnailRetryBaseSeconds)*time.Second,
+			time.Duration(cfg.ThumbnailRetryMaxSeconds)*time.Second))
+		job.State = types.ThumbnailJobPending
+		job.NextAttemptAt = &next
+		job.LastError = truncateError(err.Error())
+	}
 
+	fq.record(ctx, job)
+
+	if job.State == types.ThumbnailJobCompleted {
+		events.EmitLifecycleEvent(ctx, fq.service, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_THUMBNAILED, mediaMetadata, "", nil)
+	}
+}
+
+func (fq *ThumbnailQueueHandler) record(ctx context.Context, job *types.ThumbnailJob) {
+	if err := fq.mediaDatabase.UpdateThumbnailJob(ctx, job); err != nil {
+		util.Log(ctx).WithError(err).With("media_id", job.MediaID).Warn("failed to record thumbnail job outcome")
+	}
+}
+
+// ThumbnailJobLease returns the retry time of a job that is attempted right
+// away, so the retrier only picks it up if the attempt is never recorded.
+func ThumbnailJobLease(cfg *config.FilesConfig) time.Time {
+	return time.Now().UTC().Add(webhooks.Backoff(1,
+		time.Duration(cfg.ThumbnailRetryBaseSeconds)*time.Second,
+		time.Duration(cfg.ThumbnailRetryMaxSeconds)*time.Second))
+}
+
+// hasThumbnails reports whether thumbnails are generated for the media.
+func hasThumbnails(mediaMetadata *types.MediaMetadata) bool {
+	return strings.HasPrefix(string(mediaMetadata.ContentType), "image") &&
+		mediaMetadata.ScanStatus != types.ScanStatusInfected
+}
+
+func truncateError(s string) string {
+	if len(s) <= maxJobErrorLength {
+		return s
+	}
+	return s[:maxJobErrorLength]
 }
 
 func NewThumbnailQueueHandler(service *frame.Service, mediaDatabase storage2.Database, mediaProvider storage2.Provider) ThumbnailQueueHandler {
@@ -62,3 +194,52 @@ func NewThumbnailQueueHandler(service *frame.Service, mediaDatabase storage2.Dat
 		provider:      mediaProvider,
 	}
 }
+
+// ThumbnailRetrier periodically retries thumbnail jobs whose backoff has
+// elapsed.
+type ThumbnailRetrier struct {
+	handler *ThumbnailQueueHandler
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewThumbnailRetrier creates a retrier for the jobs of handler.
+func NewThumbnailRetrier(handler *ThumbnailQueueHandler) *ThumbnailRetrier {
+	return &ThumbnailRetrier{handler: handler}
+}
+
+// Start runs the retry loop until ctx is cancelled or Stop is called.
+func (r *ThumbnailRetrier) Start(ctx context.Context) {
+	cfg := r.handler.service.Config().(*config.FilesConfig)
+	interval := time.Duration(cfg.ThumbnailRetryPollSeconds) * time.Second
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+
+	ctx, r.cancel = context.WithCancel(ctx)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := r.handler.RetryDue(ctx); err != nil {
+					util.Log(ctx).WithError(err).Warn("thumbnail retry sweep failed")
+				}
+			}
+		}
+	}()
+}
+
+// Stop ends the retry loop and waits for an in-flight sweep to finish.
+func (r *ThumbnailRetrier) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+}
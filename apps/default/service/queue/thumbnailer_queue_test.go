@@ -3,6 +3,7 @@ package queue
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/antinvestor/service-files/apps/default/config"
 	"github.com/antinvestor/service-files/apps/default/service/storage/connection"
@@ -10,6 +11,7 @@ import (
 	"github.com/antinvestor/service-files/apps/default/service/tests"
 	"github.com/antinvestor/service-files/apps/default/service/types"
 	"github.com/pitabwire/frame/v2/frametests/definition"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -90,6 +92,15 @@ func (suite *ThumbnailQueueTestSuite) TestHandle() {
 			res.RetentionPolicyRepo,
 			res.FileRetentionRepo,
 			res.StorageStatsRepo,
+			res.WebhookSubscriptionRepo,
+			res.WebhookDeliveryRepo,
+			res.ThumbnailJobRepo,
+			res.ThumbnailRegenerationRepo,
+			res.ArchiveExtractionRepo,
+			res.ShareLinkRepo,
+			res.AccessGrantRepo,
+			res.UserDataExportRepo,
+			res.UserDataErasureRepo,
 		)
 		require.NoError(t, err)
 
@@ -112,6 +123,87 @@ func (suite *ThumbnailQueueTestSuite) TestHandle() {
 	})
 }
 
+func (suite *ThumbnailQueueTestSuite) TestHandleRecordsJobOutcome() {
+	suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+		ctx, svc, res := suite.CreateService(t, dep)
+		cfg := svc.Config().(*config.FilesConfig)
+		cfg.ThumbnailMaxAttempts = 2
+		storageProvider, err := provider.GetStorageProvider(ctx, cfg)
+		require.NoError(t, err)
+
+		db, err := connection.NewMediaDatabase(
+			svc.WorkManager(),
+			res.MediaRepository,
+			res.MultipartUploadRepo,
+			res.MultipartUploadPartRepo,
+			res.FileVersionRepo,
+			res.RetentionPolicyRepo,
+			res.FileRetentionRepo,
+			res.StorageStatsRepo,
+			res.WebhookSubscriptionRepo,
+			res.WebhookDeliveryRepo,
+			res.ThumbnailJobRepo,
+			res.ThumbnailRegenerationRepo,
+			res.ArchiveExtractionRepo,
+			res.ShareLinkRepo,
+			res.AccessGrantRepo,
+			res.UserDataExportRepo,
+			res.UserDataErasureRepo,
+		)
+		require.NoError(t, err)
+
+		handler := NewThumbnailQueueHandler(svc, db, storageProvider)
+
+		// The content was never stored, so every attempt fails.
+		mediaID := types.MediaID("media-missing-content")
+		require.NoError(t, db.StoreMediaMetadata(ctx, &types.MediaMetadata{
+			MediaID:       mediaID,
+			OwnerID:       "owner",
+			UploadName:    "img.jpg",
+			ContentType:   "image/jpeg",
+			Base64Hash:    "abc12345",
+			FileSizeBytes: 10,
+			ServerName:    "service_file",
+		}))
+
+		require.NoError(t, handler.Handle(ctx, map[string]string{}, mustJSON(map[string]string{"media_id": string(mediaID)})))
+
+		job, err := db.GetThumbnailJob(ctx, mediaID)
+		require.NoError(t, err)
+		require.NotNil(t, job)
+		assert.Equal(t, types.ThumbnailJobPending, job.State)
+		assert.Equal(t, 1, job.Attempts)
+		assert.NotEmpty(t, job.LastError)
+		require.NotNil(t, job.NextAttemptAt)
+		assert.True(t, job.NextAttemptAt.After(time.Now()))
+
+		// Not yet due, so the sweep leaves it alone.
+		attempted, err := handler.RetryDue(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, attempted)
+
+		past := time.Now().UTC().Add(-time.Minute)
+		job.NextAttemptAt = &past
+		require.NoError(t, db.UpdateThumbnailJob(ctx, job))
+
+		attempted, err = handler.RetryDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, attempted)
+
+		job, err = db.GetThumbnailJob(ctx, mediaID)
+		require.NoError(t, err)
+		assert.Equal(t, types.ThumbnailJobDeadLetter, job.State)
+		assert.Equal(t, 2, job.Attempts)
+		assert.Nil(t, job.NextAttemptAt)
+
+		// Redelivery of the original message does not revive a dead letter.
+		require.NoError(t, handler.Handle(ctx, map[string]string{}, mustJSON(map[string]string{"media_id": string(mediaID)})))
+		job, err = db.GetThumbnailJob(ctx, mediaID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, job.Attempts)
+	})
+}
+
 func mustJSON(v map[string]string) []byte {
 	b, _ := json.Marshal(v)
 	return b
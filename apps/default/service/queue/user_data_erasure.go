@@ -0,0 +1,415 @@
+package queue
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	filesv1 "github.com/antinvestor/service-files/apps/default/gen/files/v1"
+	"github.com/antinvestor/service-files/apps/default/service/authz"
+	"github.com/antinvestor/service-files/apps/default/service/events"
+	storage2 "github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/pitabwire/frame/v2"
+	"github.com/pitabwire/util"
+)
+
+const (
+	userErasePageSize = 200
+
+	erasureSignaturePrefix = "sha256="
+)
+
+var errErasureSigningKey = errors.New("erasure report signing key is not configured")
+
+// AuditAnonymizer anonymizes the audit records of actions an erased profile
+// took.
+type AuditAnonymizer interface {
+	AnonymizeSource(ctx context.Context, source, replacement string) (int64, error)
+}
+
+// MetadataInvalidator drops the cached metadata of erased media.
+type MetadataInvalidator interface {
+	DeleteMetadata(ctx context.Context, mediaID types.MediaID)
+}
+
+type retentionReader interface {
+	GetRetention(ctx context.Context, mediaID string) (interface {
+		MediaID() string
+		PolicyID() string
+		AppliedAt() time.Time
+		ExpiresAt() *time.Time
+		IsLocked() bool
+	}, error)
+}
+
+// UserDataEraser runs user data erasure jobs. Every file the profile owns is
+// removed with its thumbnails, derivatives, versions and stored content,
+// unless a legal hold or an unexpired retention keeps it; kept files only
+// lose the access granted on them. What the profile left on others' files
+// is anonymized and a signed report of it all is saved on the job.
+type UserDataEraser struct {
+	service       *frame.Service
+	mediaDatabase storage2.Database
+	provider      storage2.Provider
+	relations     authz.ProfileEraser
+	audits        AuditAnonymizer
+	cache         MetadataInvalidator
+}
+
+// NewUserDataEraser creates an eraser deleting stored content through
+// provider.
+func NewUserDataEraser(service *frame.Service, mediaDatabase storage2.Database, provider storage2.Provider, relations authz.ProfileEraser, audits AuditAnonymizer, cache MetadataInvalidator) *UserDataEraser {
+	return &UserDataEraser{
+		service:       service,
+		mediaDatabase: mediaDatabase,
+		provider:      provider,
+		relations:     relations,
+		audits:        audits,
+		cache:         cache,
+	}
+}
+
+// Handle runs the erasure named by the job_id of the payload.
+func (e *UserDataEraser) Handle(ctx context.Context, _ map[string]string, payload []byte) error {
+	jobPayload := map[string]string{}
+	if err := json.Unmarshal(payload, &jobPayload); err != nil {
+		return err
+	}
+
+	job, err := e.mediaDatabase.GetUserDataErasure(ctx, jobPayload["job_id"])
+	if err != nil {
+		return err
+	}
+	if job == nil || job.State != types.UserDataErasureRunning {
+		return nil
+	}
+
+	if err = e.Run(ctx, job); err != nil && ctx.Err() != nil {
+		// An interrupted erasure is redelivered to carry on.
+		return ctx.Err()
+	}
+	// Failures are recorded on the job; redelivering would only repeat them.
+	return nil
+}
+
+// Run erases the data of the job's profile. Should it be interrupted the
+// job stays running with its progress saved and the context's error is
+// returned; running it again picks up where it stopped.
+func (e *UserDataEraser) Run(ctx context.Context, job *types.UserDataErasure) error {
+	cfg := e.service.Config().(*config.FilesConfig)
+	secret := erasureReportSecret(cfg)
+	if secret == "" {
+		return e.fail(ctx, job, errErasureSigningKey)
+	}
+
+	report, err := e.erase(ctx, cfg, job, func(erased []*types.MediaMetadata) {
+		for _, metadata := range erased {
+			events.EmitLifecycleEvent(ctx, e.service, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_DELETED,
+				metadata, job.RequestedBy, map[string]string{"erasure_id": job.ID})
+		}
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return e.fail(ctx, job, err)
+	}
+
+	report.CompletedAt = time.Now().UTC()
+	document, err := json.Marshal(report)
+	if err != nil {
+		return e.fail(ctx, job, err)
+	}
+	job.Report = string(document)
+	job.Signature = SignErasureReport([]byte(secret), document)
+	job.Progress = ""
+	job.State = types.UserDataErasureCompleted
+	job.CompletedAt = &report.CompletedAt
+	e.save(ctx, job)
+
+	util.Log(ctx).With("job_id", job.ID, "erased", len(report.ErasedFiles), "retained", len(report.RetainedFiles),
+		"errors", len(report.Errors)).Info("user data erasure completed")
+	return nil
+}
+
+// erase removes what the profile owns and anonymizes what it left on others'
+// files, returning the report. Each page of erased media is handed to
+// erased once gone, and the progress is saved on the job after it so that a
+// resumed erasure carries on from the saved report. Content that cannot be
+// deleted from storage is listed in the report rather than failing it.
+func (e *UserDataEraser) erase(ctx context.Context, cfg *config.FilesConfig, job *types.UserDataErasure, erased func([]*types.MediaMetadata)) (*types.ErasureReport, error) {
+	progress, err := erasureProgress(job)
+	if err != nil {
+		return nil, err
+	}
+	report := &progress.Report
+	pseudonym := "erased:" + job.ID
+	owner := string(job.ProfileID)
+
+	folders := map[string]bool{}
+	for _, folder := range progress.Folders {
+		folders[folder] = true
+	}
+	keptFolders := map[string]bool{}
+	for _, folder := range progress.KeptFolders {
+		keptFolders[folder] = true
+	}
+
+	// Content of media erased just before an interruption.
+	if err = e.deleteBlobs(ctx, cfg, job, progress); err != nil {
+		return nil, err
+	}
+
+	for !progress.MediaDone {
+		page, listErr := e.mediaDatabase.ListOwnerMediaWithDeleted(ctx, job.ProfileID, progress.AfterID, userErasePageSize)
+		if listErr != nil {
+			return nil, listErr
+		}
+
+		var pageErased []*types.MediaMetadata
+		for _, metadata := range page {
+			for folder := metadata.Folder; folder != ""; folder = authz.ParentFolder(folder) {
+				folders[folder] = true
+			}
+
+			retained, holdErr := e.hold(ctx, metadata.MediaID)
+			if holdErr != nil {
+				return nil, holdErr
+			}
+			keep := retained != nil
+			revoked, relErr := e.relations.RemoveFileRelations(ctx, string(metadata.MediaID), keep)
+			if relErr != nil {
+				return nil, relErr
+			}
+			report.RevokedGrants += int64(revoked)
+
+			if keep {
+				report.RetainedFiles = append(report.RetainedFiles, *retained)
+				for folder := metadata.Folder; folder != ""; folder = authz.ParentFolder(folder) {
+					keptFolders[folder] = true
+				}
+				continue
+			}
+			pageErased = append(pageErased, metadata)
+		}
+
+		if len(pageErased) > 0 {
+			mediaIDs := make([]types.MediaID, 0, len(pageErased))
+			for _, metadata := range pageErased {
+				mediaIDs = append(mediaIDs, metadata.MediaID)
+			}
+			removed, eraseErr := e.mediaDatabase.EraseMedia(ctx, mediaIDs)
+			if eraseErr != nil {
+				return nil, eraseErr
+			}
+			report.Derivatives += removed.Derivatives
+			report.Versions += removed.Versions
+			progress.PendingBlobs = removed.Blobs
+
+			for _, metadata := range pageErased {
+				e.cache.DeleteMetadata(ctx, metadata.MediaID)
+				report.ErasedFiles = append(report.ErasedFiles, metadata.MediaID)
+			}
+			erased(pageErased)
+		}
+
+		if len(page) > 0 {
+			progress.AfterID = string(page[len(page)-1].MediaID)
+		}
+		progress.MediaDone = len(page) < userErasePageSize
+		progress.Folders = sortedKeys(folders)
+		progress.KeptFolders = sortedKeys(keptFolders)
+		e.saveProgress(ctx, job, progress)
+
+		if err = e.deleteBlobs(ctx, cfg, job, progress); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, folder := range progress.Folders {
+		revoked, relErr := e.relations.RemoveFolderRelations(ctx, owner, folder, keptFolders[folder])
+		if relErr != nil {
+			return nil, relErr
+		}
+		report.RevokedGrants += int64(revoked)
+	}
+
+	revoked, err := e.relations.RevokeReceivedGrants(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	report.RevokedReceivedGrants = int64(revoked)
+
+	uploads, partPaths, err := e.mediaDatabase.EraseOwnerUploads(ctx, job.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+	report.MultipartUploads = uploads
+	for _, partPath := range partPaths {
+		if deleteErr := e.provider.DeleteFile(ctx, e.provider.PrivateBucket(), types.Path(partPath)); deleteErr != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("multipart part %s: %v", partPath, deleteErr))
+		}
+	}
+
+	deleted, anonymized, err := e.mediaDatabase.EraseOwnerRecords(ctx, job.ProfileID, pseudonym)
+	if err != nil {
+		return nil, err
+	}
+	audits, err := e.audits.AnonymizeSource(ctx, "profile:"+owner, pseudonym)
+	if err != nil {
+		return nil, err
+	}
+	report.DeletedRecords = deleted
+	report.AnonymizedRecords = anonymized + audits
+
+	return report, nil
+}
+
+// erasureProgress reads the progress saved on job, or starts it afresh.
+func erasureProgress(job *types.UserDataErasure) (*types.ErasureProgress, error) {
+	progress := &types.ErasureProgress{}
+	if job.Progress != "" {
+		if err := json.Unmarshal([]byte(job.Progress), progress); err != nil {
+			return nil, fmt.Errorf("reading saved erasure progress: %w", err)
+		}
+		return progress, nil
+	}
+	progress.Report = types.ErasureReport{
+		ErasureID:     job.ID,
+		ProfileID:     job.ProfileID,
+		RequestedBy:   job.RequestedBy,
+		StartedAt:     time.Now().UTC(),
+		ErasedFiles:   []types.MediaID{},
+		RetainedFiles: []types.ErasureRetainedFile{},
+	}
+	return progress, nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// hold reports why a media must be kept, or nil when it may be erased. A
+// locked retention is a legal hold; any other retention holds until it
+// expires, or for good when it has no expiry.
+func (e *UserDataEraser) hold(ctx context.Context, mediaID types.MediaID) (*types.ErasureRetainedFile, error) {
+	retentions, ok := e.mediaDatabase.(retentionReader)
+	if !ok {
+		return nil, nil
+	}
+	retention, err := retentions.GetRetention(ctx, string(mediaID))
+	if err != nil || retention == nil {
+		return nil, err
+	}
+
+	switch expiresAt := retention.ExpiresAt(); {
+	case retention.IsLocked():
+		return &types.ErasureRetainedFile{MediaID: mediaID, Reason: types.ErasureRetainedLegalHold}, nil
+	case expiresAt == nil || expiresAt.After(time.Now()):
+		return &types.ErasureRetainedFile{MediaID: mediaID, Reason: types.ErasureRetainedRetention, Until: expiresAt}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// deleteBlobs deletes the pending content no remaining media or version
+// refers to. Content is stored once per hash whoever uploaded it, so the
+// references are counted across tenants. Should it be interrupted the
+// content left is saved as still pending.
+func (e *UserDataEraser) deleteBlobs(ctx context.Context, cfg *config.FilesConfig, job *types.UserDataErasure, progress *types.ErasureProgress) error {
+	if len(progress.PendingBlobs) == 0 {
+		return nil
+	}
+	report := &progress.Report
+	referencesCtx := utils.SystemContext(ctx, "user data erasure")
+	for ; len(progress.PendingBlobs) > 0; progress.PendingBlobs = progress.PendingBlobs[1:] {
+		blob := progress.PendingBlobs[0]
+
+		references, err := e.mediaDatabase.CountBlobReferences(referencesCtx, blob.Hash)
+		if err == nil && references > 0 {
+			report.SharedBlobs++
+			continue
+		}
+		if err == nil {
+			var blobPath string
+			if blobPath, err = utils.GetPathFromBase64Hash(blob.Hash, cfg.AbsBasePath); err == nil {
+				err = e.provider.DeleteFile(ctx, e.provider.GetBucket(blob.IsPublic), types.Path(blobPath))
+			}
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				e.saveProgress(ctx, job, progress)
+				return ctx.Err()
+			}
+			report.Errors = append(report.Errors, fmt.Sprintf("content %s: %v", blob.Hash, err))
+			continue
+		}
+		report.DeletedBlobs++
+	}
+	progress.PendingBlobs = nil
+	e.saveProgress(ctx, job, progress)
+	return nil
+}
+
+func (e *UserDataEraser) fail(ctx context.Context, job *types.UserDataErasure, err error) error {
+	now := time.Now().UTC()
+	job.State = types.UserDataErasureFailed
+	job.LastError = truncateError(err.Error())
+	job.CompletedAt = &now
+	e.save(ctx, job)
+	util.Log(ctx).WithError(err).With("job_id", job.ID).Warn("user data erasure failed")
+	return fmt.Errorf("user data erasure %s: %w", job.ID, err)
+}
+
+// saveProgress records progress on job. It is saved even once ctx ends, as
+// the work it records is done.
+func (e *UserDataEraser) saveProgress(ctx context.Context, job *types.UserDataErasure, progress *types.ErasureProgress) {
+	document, err := json.Marshal(progress)
+	if err != nil {
+		util.Log(ctx).WithError(err).With("job_id", job.ID).Warn("failed to encode user data erasure progress")
+		return
+	}
+	job.Progress = string(document)
+	e.save(context.WithoutCancel(ctx), job)
+}
+
+func (e *UserDataEraser) save(ctx context.Context, job *types.UserDataErasure) {
+	if err := e.mediaDatabase.UpdateUserDataErasure(ctx, job); err != nil {
+		util.Log(ctx).WithError(err).With("job_id", job.ID).Warn("failed to save user data erasure")
+	}
+}
+
+// erasureReportSecret is the key erasure reports are signed with, empty
+// when none is configured.
+func erasureReportSecret(cfg *config.FilesConfig) string {
+	return strings.TrimSpace(cfg.ErasureReportSigningKey)
+}
+
+// SignErasureReport signs the JSON of an erasure report:
+// "sha256=" + hex(HMAC-SHA256(secret, report)).
+func SignErasureReport(secret []byte, report []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(report)
+	return erasureSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyErasureReport checks a signature produced by SignErasureReport in
+// constant time.
+func VerifyErasureReport(secret []byte, report []byte, signature string) bool {
+	return hmac.Equal([]byte(SignErasureReport(secret, report)), []byte(signature))
+}
@@ -0,0 +1,292 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	storage2 "github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/stretchr/testify/require"
+)
+
+type erasureRetention struct {
+	expiresAt *time.Time
+	locked    bool
+}
+
+func (r erasureRetention) MediaID() string       { return "" }
+func (r erasureRetention) PolicyID() string      { return "policy" }
+func (r erasureRetention) AppliedAt() time.Time  { return time.Unix(0, 0) }
+func (r erasureRetention) ExpiresAt() *time.Time { return r.expiresAt }
+func (r erasureRetention) IsLocked() bool        { return r.locked }
+
+type erasureDatabase struct {
+	storage2.Database
+	media      []*types.MediaMetadata
+	retentions map[types.MediaID]erasureRetention
+	blobs      map[types.MediaID][]types.ErasedBlob
+	references map[types.Base64Hash]int64
+	partPaths  []string
+	erased     []types.MediaID
+	pseudonym  string
+	saved      []string
+}
+
+func (d *erasureDatabase) UpdateUserDataErasure(_ context.Context, job *types.UserDataErasure) error {
+	d.saved = append(d.saved, job.Progress)
+	return nil
+}
+
+func (d *erasureDatabase) ListOwnerMediaWithDeleted(_ context.Context, ownerID types.OwnerID, afterID string, limit int) ([]*types.MediaMetadata, error) {
+	var page []*types.MediaMetadata
+	for _, metadata := range d.media {
+		if metadata.OwnerID == ownerID && string(metadata.MediaID) > afterID && len(page) < limit {
+			page = append(page, metadata)
+		}
+	}
+	return page, nil
+}
+
+func (d *erasureDatabase) GetRetention(_ context.Context, mediaID string) (interface {
+	MediaID() string
+	PolicyID() string
+	AppliedAt() time.Time
+	ExpiresAt() *time.Time
+	IsLocked() bool
+}, error) {
+	retention, ok := d.retentions[types.MediaID(mediaID)]
+	if !ok {
+		return nil, nil
+	}
+	return retention, nil
+}
+
+func (d *erasureDatabase) EraseMedia(_ context.Context, mediaIDs []types.MediaID) (*types.ErasedMedia, error) {
+	result := &types.ErasedMedia{}
+	for _, mediaID := range mediaIDs {
+		d.erased = append(d.erased, mediaID)
+		result.Derivatives++
+		result.Blobs = append(result.Blobs, d.blobs[mediaID]...)
+	}
+	return result, nil
+}
+
+func (d *erasureDatabase) CountBlobReferences(_ context.Context, hash types.Base64Hash) (int64, error) {
+	return d.references[hash], nil
+}
+
+func (d *erasureDatabase) EraseOwnerUploads(_ context.Context, _ types.OwnerID) (int64, []string, error) {
+	return 1, d.partPaths, nil
+}
+
+func (d *erasureDatabase) EraseOwnerRecords(_ context.Context, _ types.OwnerID, pseudonym string) (int64, int64, error) {
+	d.pseudonym = pseudonym
+	return 2, 3, nil
+}
+
+// erasureProvider records the files deleted from each bucket. Deleting
+// interruptAt cancels the erasure instead.
+type erasureProvider struct {
+	storage2.Provider
+	deleted     map[string][]types.Path
+	interruptAt types.Path
+	cancel      context.CancelFunc
+}
+
+func (p *erasureProvider) PrivateBucket() string { return "private" }
+func (p *erasureProvider) PublicBucket() string  { return "public" }
+func (p *erasureProvider) GetBucket(isPublic bool) string {
+	if isPublic {
+		return p.PublicBucket()
+	}
+	return p.PrivateBucket()
+}
+
+func (p *erasureProvider) DeleteFile(ctx context.Context, bucket string, path types.Path) error {
+	if p.cancel != nil && path == p.interruptAt {
+		p.cancel()
+		p.cancel = nil
+		return ctx.Err()
+	}
+	p.deleted[bucket] = append(p.deleted[bucket], path)
+	return nil
+}
+
+type erasureRelations struct {
+	files    map[string]bool
+	folders  map[string]bool
+	received string
+}
+
+func (r *erasureRelations) RemoveFileRelations(_ context.Context, fileID string, keepOwnership bool) (int, error) {
+	r.files[fileID] = keepOwnership
+	return 1, nil
+}
+
+func (r *erasureRelations) RemoveFolderRelations(_ context.Context, _ string, folder string, keepParent bool) (int, error) {
+	r.folders[folder] = keepParent
+	return 0, nil
+}
+
+func (r *erasureRelations) RevokeReceivedGrants(_ context.Context, profileID string) (int, error) {
+	r.received = profileID
+	return 4, nil
+}
+
+type erasureAudits struct {
+	source, replacement string
+}
+
+func (a *erasureAudits) AnonymizeSource(_ context.Context, source, replacement string) (int64, error) {
+	a.source, a.replacement = source, replacement
+	return 5, nil
+}
+
+type erasureCache struct {
+	deleted []types.MediaID
+}
+
+func (c *erasureCache) DeleteMetadata(_ context.Context, mediaID types.MediaID) {
+	c.deleted = append(c.deleted, mediaID)
+}
+
+func TestUserDataErasure(t *testing.T) {
+	ctx := t.Context()
+	profile := types.OwnerID("@erase:example.com")
+	future := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	db := &erasureDatabase{
+		media: []*types.MediaMetadata{
+			{MediaID: "m1", OwnerID: profile, Folder: "jobs/2024"},
+			{MediaID: "m2", OwnerID: profile, Folder: "legal"},
+			{MediaID: "m3", OwnerID: profile, Folder: "jobs"},
+			{MediaID: "m4", OwnerID: profile},
+			{MediaID: "m5", OwnerID: "@other:example.com"},
+		},
+		retentions: map[types.MediaID]erasureRetention{
+			"m2": {locked: true},
+			"m3": {expiresAt: &past},
+			"m4": {expiresAt: &future},
+		},
+		blobs: map[types.MediaID][]types.ErasedBlob{
+			"m1": {{Hash: "hash1"}, {Hash: "thumb1", IsPublic: true}},
+			"m3": {{Hash: "shared"}},
+		},
+		references: map[types.Base64Hash]int64{"shared": 1},
+		partPaths:  []string{"multipart/u1/part-000001"},
+	}
+	provider := &erasureProvider{deleted: map[string][]types.Path{}}
+	relations := &erasureRelations{files: map[string]bool{}, folders: map[string]bool{}}
+	audits := &erasureAudits{}
+	cache := &erasureCache{}
+	eraser := NewUserDataEraser(nil, db, provider, relations, audits, cache)
+	cfg := &config.FilesConfig{AbsBasePath: "/data"}
+	job := &types.UserDataErasure{ID: "erasure1", ProfileID: profile, RequestedBy: string(profile)}
+
+	var erased []*types.MediaMetadata
+	report, err := eraser.erase(ctx, cfg, job, func(page []*types.MediaMetadata) { erased = append(erased, page...) })
+	require.NoError(t, err)
+
+	require.Equal(t, []types.MediaID{"m1", "m3"}, report.ErasedFiles, "expired retention does not keep a file")
+	require.Len(t, erased, 2)
+	require.Equal(t, []types.MediaID{"m1", "m3"}, db.erased)
+	require.Equal(t, []types.MediaID{"m1", "m3"}, cache.deleted)
+	require.Equal(t, []types.ErasureRetainedFile{
+		{MediaID: "m2", Reason: types.ErasureRetainedLegalHold},
+		{MediaID: "m4", Reason: types.ErasureRetainedRetention, Until: &future},
+	}, report.RetainedFiles)
+
+	require.Equal(t, map[string]bool{"m1": false, "m2": true, "m3": false, "m4": true}, relations.files,
+		"kept files keep their ownership")
+	require.Equal(t, map[string]bool{"jobs/2024": false, "jobs": false, "legal": true}, relations.folders,
+		"folders of kept files stay linked")
+	require.Equal(t, string(profile), relations.received)
+	require.EqualValues(t, 4, report.RevokedGrants)
+	require.EqualValues(t, 4, report.RevokedReceivedGrants)
+
+	hashPath := func(hash types.Base64Hash) types.Path {
+		p, pathErr := utils.GetPathFromBase64Hash(hash, cfg.AbsBasePath)
+		require.NoError(t, pathErr)
+		return types.Path(p)
+	}
+	require.Equal(t, []types.Path{hashPath("hash1"), "multipart/u1/part-000001"}, provider.deleted["private"])
+	require.Equal(t, []types.Path{hashPath("thumb1")}, provider.deleted["public"])
+	require.EqualValues(t, 2, report.DeletedBlobs)
+	require.EqualValues(t, 1, report.SharedBlobs, "content still referenced elsewhere is kept")
+	require.EqualValues(t, 1, report.MultipartUploads)
+
+	require.Equal(t, "erased:erasure1", db.pseudonym)
+	require.Equal(t, "profile:"+string(profile), audits.source)
+	require.Equal(t, db.pseudonym, audits.replacement)
+	require.EqualValues(t, 2, report.DeletedRecords)
+	require.EqualValues(t, 8, report.AnonymizedRecords)
+	require.Empty(t, report.Errors)
+
+	document, err := json.Marshal(report)
+	require.NoError(t, err)
+	signature := SignErasureReport([]byte("secret"), document)
+	require.True(t, VerifyErasureReport([]byte("secret"), document, signature))
+	require.False(t, VerifyErasureReport([]byte("other"), document, signature))
+	require.False(t, VerifyErasureReport([]byte("secret"), append(document, ' '), signature))
+}
+
+func TestUserDataErasureResumes(t *testing.T) {
+	profile := types.OwnerID("@resume:example.com")
+	db := &erasureDatabase{
+		media: []*types.MediaMetadata{
+			{MediaID: "m1", OwnerID: profile, Folder: "docs"},
+			{MediaID: "m2", OwnerID: profile},
+		},
+		retentions: map[types.MediaID]erasureRetention{"m2": {locked: true}},
+		blobs:      map[types.MediaID][]types.ErasedBlob{"m1": {{Hash: "hash1"}, {Hash: "hash2"}}},
+	}
+	cfg := &config.FilesConfig{AbsBasePath: "/data"}
+	hashPath := func(hash types.Base64Hash) types.Path {
+		p, pathErr := utils.GetPathFromBase64Hash(hash, cfg.AbsBasePath)
+		require.NoError(t, pathErr)
+		return types.Path(p)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	provider := &erasureProvider{deleted: map[string][]types.Path{}, interruptAt: hashPath("hash2"), cancel: cancel}
+	relations := &erasureRelations{files: map[string]bool{}, folders: map[string]bool{}}
+	eraser := NewUserDataEraser(nil, db, provider, relations, &erasureAudits{}, &erasureCache{})
+	job := &types.UserDataErasure{ID: "erasure2", ProfileID: profile, RequestedBy: string(profile)}
+
+	var erased []*types.MediaMetadata
+	onErased := func(page []*types.MediaMetadata) { erased = append(erased, page...) }
+	_, err := eraser.erase(ctx, cfg, job, onErased)
+	require.ErrorIs(t, err, context.Canceled)
+
+	var saved types.ErasureProgress
+	require.NoError(t, json.Unmarshal([]byte(job.Progress), &saved))
+	require.True(t, saved.MediaDone)
+	require.Equal(t, []types.MediaID{"m1"}, saved.Report.ErasedFiles)
+	require.Equal(t, []types.ErasedBlob{{Hash: "hash2"}}, saved.PendingBlobs, "content not yet deleted stays pending")
+	require.EqualValues(t, 1, saved.Report.DeletedBlobs)
+
+	// The erased media are gone, so resuming only finishes what was left.
+	db.media = db.media[1:]
+	report, err := eraser.erase(t.Context(), cfg, job, onErased)
+	require.NoError(t, err)
+	require.Equal(t, []types.MediaID{"m1"}, report.ErasedFiles)
+	require.Equal(t, []types.MediaID{"m1"}, db.erased, "erased media are erased once")
+	require.Len(t, erased, 1)
+	require.Equal(t, []types.ErasureRetainedFile{{MediaID: "m2", Reason: types.ErasureRetainedLegalHold}}, report.RetainedFiles)
+	require.EqualValues(t, 2, report.RevokedGrants)
+	require.EqualValues(t, 2, report.DeletedBlobs)
+	require.Equal(t, []types.Path{hashPath("hash1"), hashPath("hash2")}, provider.deleted["private"])
+	require.Equal(t, map[string]bool{"docs": false}, relations.folders, "folders of media erased before the interruption are unlinked")
+	require.Empty(t, report.Errors)
+}
+
+func TestErasureReportSecret(t *testing.T) {
+	require.Equal(t, "key", erasureReportSecret(&config.FilesConfig{ErasureReportSigningKey: " key ", CsrfSecret: "csrf"}))
+	require.Empty(t, erasureReportSecret(&config.FilesConfig{CsrfSecret: "csrf", EnvStorageEncryptionPhrase: "phrase"}),
+		"reports are only signed with their own key")
+}
@@ -0,0 +1,525 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	filesv1 "github.com/antinvestor/service-files/apps/default/gen/files/v1"
+	"github.com/antinvestor/service-files/apps/default/service/authz"
+	"github.com/antinvestor/service-files/apps/default/service/business"
+	"github.com/antinvestor/service-files/apps/default/service/events"
+	storage2 "github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/pitabwire/frame/v2"
+	"github.com/pitabwire/util"
+)
+
+const (
+	userExportPageSize = 500
+
+	// Names of the documents written next to the files of an export.
+	userExportMetadataName = "metadata.json"
+	userExportGrantsName   = "grants.json"
+	userExportAuditName    = "audit.json"
+)
+
+var errUserExportTooLarge = errors.New("export is larger than the configured limit")
+
+// AuditLister reads the audit records an export includes.
+type AuditLister interface {
+	ListByFiles(ctx context.Context, fileIDs []string, afterID string, limit int) ([]*models.MediaAudit, error)
+	ListBySource(ctx context.Context, source string, afterID string, limit int) ([]*models.MediaAudit, error)
+}
+
+// SharedFileLister lists the files shared with a profile.
+type SharedFileLister interface {
+	ListSharedFiles(ctx context.Context, profileID string) ([]authz.SharedFile, error)
+}
+
+type versionLister interface {
+	GetVersions(ctx context.Context, mediaID string) ([]interface {
+		ID() string
+		MediaID() string
+		VersionNumber() int
+		ContentHash() string
+		FileSize() int64
+		UploadName() string
+		ContentType() string
+		CreatedAt() time.Time
+	}, error)
+}
+
+// UserDataExporter runs user data export jobs. Everything the profile owns
+// is streamed as one zip archive straight into a new private media of the
+// profile, so no more than a file at a time is held while packaging;
+// progress is saved on the job as it goes.
+type UserDataExporter struct {
+	service       *frame.Service
+	mediaDatabase storage2.Database
+	mediaService  business.MediaService
+	archiver      *business.Archiver
+	audits        AuditLister
+	shares        SharedFileLister
+}
+
+// NewUserDataExporter creates an exporter reading and storing files through
+// mediaService.
+func NewUserDataExporter(service *frame.Service, mediaDatabase storage2.Database, mediaService business.MediaService, audits AuditLister, shares SharedFileLister) *UserDataExporter {
+	return &UserDataExporter{
+		service:       service,
+		mediaDatabase: mediaDatabase,
+		mediaService:  mediaService,
+		archiver:      business.NewArchiver(mediaDatabase, mediaService, nil),
+		audits:        audits,
+		shares:        shares,
+	}
+}
+
+// Handle runs the export named by the job_id of the payload.
+func (e *UserDataExporter) Handle(ctx context.Context, _ map[string]string, payload []byte) error {
+	jobPayload := map[string]string{}
+	if err := json.Unmarshal(payload, &jobPayload); err != nil {
+		return err
+	}
+
+	job, err := e.mediaDatabase.GetUserDataExport(ctx, jobPayload["job_id"])
+	if err != nil {
+		return err
+	}
+	if job == nil || job.State != types.UserDataExportRunning {
+		return nil
+	}
+
+	if err = e.Run(ctx, job); err != nil && ctx.Err() != nil {
+		// An interrupted export is redelivered to start over.
+		return ctx.Err()
+	}
+	// Failures are recorded on the job; redelivering would only repeat them.
+	return nil
+}
+
+// Run packages the data of the job's profile. Should it be interrupted the
+// job stays running and the context's error is returned, so that running it
+// again starts the archive over.
+func (e *UserDataExporter) Run(ctx context.Context, job *types.UserDataExport) error {
+	cfg := e.service.Config().(*config.FilesConfig)
+
+	media, entries, err := e.collect(ctx, job.ProfileID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return e.fail(ctx, job, err)
+	}
+	var total int64
+	for _, entry := range entries {
+		total += int64(entry.Metadata.FileSizeBytes)
+	}
+	if total > cfg.UserExportMaxBytes {
+		return e.fail(ctx, job, fmt.Errorf("%w: %d bytes of files exceed %d bytes", errUserExportTooLarge, total, cfg.UserExportMaxBytes))
+	}
+
+	job.TotalFiles = int64(len(entries))
+	job.ExportedFiles, job.FailedFiles, job.ExportedBytes = 0, 0, 0
+	e.save(ctx, job)
+
+	opts := business.ArchiveOptions{
+		Documents: []business.ArchiveDocument{
+			{Name: userExportMetadataName, Write: e.writeMetadata(job, media)},
+			{Name: userExportGrantsName, Write: e.writeGrants(ctx, job, media)},
+			{Name: userExportAuditName, Write: e.writeAudit(ctx, job, media)},
+		},
+		Progress: func(record business.ArchiveManifestEntry) {
+			if record.Error != "" {
+				job.FailedFiles++
+			} else {
+				job.ExportedFiles++
+			}
+			job.ExportedBytes += record.Size
+			e.save(ctx, job)
+		},
+	}
+
+	mediaID, err := e.store(ctx, cfg, job, entries, opts)
+	if err != nil {
+		if ctx.Err() != nil {
+			e.save(context.WithoutCancel(ctx), job)
+			return ctx.Err()
+		}
+		return e.fail(ctx, job, err)
+	}
+
+	now := time.Now().UTC()
+	job.ResultMediaID = mediaID
+	job.State = types.UserDataExportCompleted
+	job.CompletedAt = &now
+	e.save(ctx, job)
+
+	result, err := e.mediaDatabase.GetMediaMetadata(ctx, mediaID)
+	if err != nil {
+		util.Log(ctx).WithError(err).With("job_id", job.ID).Warn("failed to read user data export archive")
+	}
+	events.EmitLifecycleEvent(ctx, e.service, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_USER_DATA_EXPORTED,
+		result, job.RequestedBy, map[string]string{"export_id": job.ID, "profile_id": string(job.ProfileID)})
+	util.Log(ctx).With("job_id", job.ID, "exported", job.ExportedFiles, "failed", job.FailedFiles, "bytes", job.ExportedBytes).
+		Info("user data export completed")
+	return nil
+}
+
+// exportedMedia is an original media of the profile with its versions.
+type exportedMedia struct {
+	metadata *types.MediaMetadata
+	versions []*types.MediaMetadata
+}
+
+// collect lists the original media of profileID and an archive entry for
+// each of them and each of their earlier versions. The archives of earlier
+// exports are left out, lest each export carry all those before it.
+func (e *UserDataExporter) collect(ctx context.Context, profileID types.OwnerID) ([]exportedMedia, []business.ArchiveEntry, error) {
+	versions, _ := e.mediaDatabase.(versionLister)
+
+	results, err := e.mediaDatabase.ListUserDataExportResults(ctx, profileID)
+	if err != nil {
+		return nil, nil, err
+	}
+	earlier := make(map[types.MediaID]bool, len(results))
+	for _, mediaID := range results {
+		earlier[mediaID] = true
+	}
+
+	var media []exportedMedia
+	var entries []business.ArchiveEntry
+	afterID := ""
+	for {
+		page, err := e.mediaDatabase.ListOwnerMedia(ctx, profileID, "", afterID, userExportPageSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, metadata := range page {
+			if earlier[metadata.MediaID] {
+				continue
+			}
+			item := exportedMedia{metadata: metadata}
+			entries = append(entries, business.ArchiveEntry{
+				MediaID:  metadata.MediaID,
+				Metadata: metadata,
+				Dir:      path.Join("media", metadata.Folder),
+			})
+
+			if versions != nil {
+				list, listErr := versions.GetVersions(ctx, string(metadata.MediaID))
+				if listErr != nil {
+					return nil, nil, listErr
+				}
+				for _, version := range list {
+					// Versions share the encryption of their media, as a
+					// restore does.
+					copied := *metadata
+					copied.Base64Hash = types.Base64Hash(version.ContentHash())
+					copied.FileSizeBytes = types.FileSizeBytes(version.FileSize())
+					copied.UploadName = types.Filename(version.UploadName())
+					copied.ContentType = types.ContentType(version.ContentType())
+					copied.CreationTimestamp = uint64(version.CreatedAt().UnixMilli())
+					item.versions = append(item.versions, &copied)
+					entries = append(entries, business.ArchiveEntry{
+						MediaID:  metadata.MediaID,
+						Metadata: &copied,
+						Dir:      path.Join("versions", string(metadata.MediaID), strconv.Itoa(version.VersionNumber())),
+					})
+				}
+			}
+			media = append(media, item)
+		}
+		if len(page) < userExportPageSize {
+			return media, entries, nil
+		}
+		afterID = string(page[len(page)-1].MediaID)
+	}
+}
+
+// store streams the archive into a new private media of the profile.
+func (e *UserDataExporter) store(ctx context.Context, cfg *config.FilesConfig, job *types.UserDataExport, entries []business.ArchiveEntry, opts business.ArchiveOptions) (types.MediaID, error) {
+	uploadCfg := *cfg
+	uploadCfg.MaxFileSizeBytes = config.FileSizeBytes(cfg.UserExportMaxBytes)
+
+	reader, writer := io.Pipe()
+	written := make(chan error, 1)
+	go func() {
+		err := e.archiver.WriteWithOptions(ctx, &exportLimitWriter{w: writer, remaining: cfg.UserExportMaxBytes}, cfg, entries, opts)
+		_ = writer.CloseWithError(err)
+		written <- err
+	}()
+
+	result, err := e.mediaService.UploadFile(ctx, &business.UploadRequest{
+		OwnerID:     job.ProfileID,
+		UploadName:  types.Filename("data-export-" + job.ID + ".zip"),
+		ContentType: "application/zip",
+		FileData:    reader,
+		Config:      &uploadCfg,
+	})
+	// An upload that stopped early leaves the archive writer blocked.
+	_ = reader.CloseWithError(errors.New("export upload stopped"))
+	if writeErr := <-written; writeErr != nil {
+		return "", writeErr
+	}
+	if err != nil {
+		return "", err
+	}
+	return result.MediaID, nil
+}
+
+func (e *UserDataExporter) writeMetadata(job *types.UserDataExport, media []exportedMedia) func(io.Writer) error {
+	return func(w io.Writer) error {
+		type versionDocument struct {
+			Name        string    `json:"name"`
+			ContentType string    `json:"content_type"`
+			Size        int64     `json:"size"`
+			Hash        string    `json:"hash"`
+			CreatedAt   time.Time `json:"created_at"`
+		}
+		type mediaDocument struct {
+			MediaID     string            `json:"media_id"`
+			Name        string            `json:"name"`
+			ContentType string            `json:"content_type"`
+			Size        int64             `json:"size"`
+			Hash        string            `json:"hash"`
+			Folder      string            `json:"folder,omitempty"`
+			IsPublic    bool              `json:"is_public"`
+			ScanStatus  string            `json:"scan_status,omitempty"`
+			Exif        map[string]string `json:"exif,omitempty"`
+			CreatedAt   time.Time         `json:"created_at"`
+			Versions    []versionDocument `json:"versions,omitempty"`
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		documents := make([]mediaDocument, 0, len(media))
+		for _, item := range media {
+			document := mediaDocument{
+				MediaID:     string(item.metadata.MediaID),
+				Name:        string(item.metadata.UploadName),
+				ContentType: string(item.metadata.ContentType),
+				Size:        int64(item.metadata.FileSizeBytes),
+				Hash:        string(item.metadata.Base64Hash),
+				Folder:      item.metadata.Folder,
+				IsPublic:    item.metadata.IsPublic,
+				ScanStatus:  string(item.metadata.ScanStatus),
+				Exif:        item.metadata.Exif,
+				CreatedAt:   time.UnixMilli(int64(item.metadata.CreationTimestamp)).UTC(),
+			}
+			for _, version := range item.versions {
+				document.Versions = append(document.Versions, versionDocument{
+					Name:        string(version.UploadName),
+					ContentType: string(version.ContentType),
+					Size:        int64(version.FileSizeBytes),
+					Hash:        string(version.Base64Hash),
+					CreatedAt:   time.UnixMilli(int64(version.CreationTimestamp)).UTC(),
+				})
+			}
+			documents = append(documents, document)
+		}
+		return encoder.Encode(map[string]any{
+			"profile_id":  string(job.ProfileID),
+			"exported_at": time.Now().UTC(),
+			"media":       documents,
+		})
+	}
+}
+
+func (e *UserDataExporter) writeGrants(ctx context.Context, job *types.UserDataExport, media []exportedMedia) func(io.Writer) error {
+	return func(w io.Writer) error {
+		type grantDocument struct {
+			FileID        string     `json:"file_id"`
+			Role          string     `json:"role"`
+			PrincipalKind string     `json:"principal_kind,omitempty"`
+			PrincipalID   string     `json:"principal_id,omitempty"`
+			InheritedFrom string     `json:"inherited_from,omitempty"`
+			GrantedBy     string     `json:"granted_by,omitempty"`
+			GrantedAt     *time.Time `json:"granted_at,omitempty"`
+			ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+		}
+
+		// Grant records are kept under the tenancy of whoever granted them.
+		recordsCtx := utils.SystemContext(ctx, "user data export")
+		granted := []grantDocument{}
+		for start := 0; start < len(media); start += userExportPageSize {
+			end := min(start+userExportPageSize, len(media))
+			fileIDs := make([]string, 0, end-start)
+			for _, item := range media[start:end] {
+				fileIDs = append(fileIDs, string(item.metadata.MediaID))
+			}
+			records, err := e.mediaDatabase.ListAccessGrants(recordsCtx, authz.NamespaceFile, fileIDs)
+			if err != nil {
+				return err
+			}
+			for _, record := range records {
+				grantedAt := record.CreatedAt
+				granted = append(granted, grantDocument{
+					FileID:        record.ObjectID,
+					Role:          authz.RelationToRole(record.Relation),
+					PrincipalKind: record.PrincipalKind,
+					PrincipalID:   record.PrincipalID,
+					GrantedBy:     string(record.GrantedBy),
+					GrantedAt:     &grantedAt,
+					ExpiresAt:     record.ExpiresAt,
+				})
+			}
+		}
+
+		shared, err := e.shares.ListSharedFiles(ctx, string(job.ProfileID))
+		if err != nil {
+			return err
+		}
+		sharedWith := make([]grantDocument, 0, len(shared))
+		for _, file := range shared {
+			document := grantDocument{
+				FileID:        file.FileID,
+				Role:          file.Grant.Role,
+				InheritedFrom: file.Grant.InheritedFrom,
+				GrantedBy:     file.Grant.GrantedBy,
+				ExpiresAt:     file.Grant.ExpiresAt,
+			}
+			if !file.Grant.GrantedAt.IsZero() {
+				document.GrantedAt = &file.Grant.GrantedAt
+			}
+			sharedWith = append(sharedWith, document)
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(map[string]any{
+			"granted_on_my_files": granted,
+			"shared_with_me":      sharedWith,
+		})
+	}
+}
+
+// writeAudit writes the audit records of the profile's files and of the
+// actions the profile took, one page at a time.
+func (e *UserDataExporter) writeAudit(ctx context.Context, job *types.UserDataExport, media []exportedMedia) func(io.Writer) error {
+	return func(w io.Writer) error {
+		type auditDocument struct {
+			ID        string         `json:"id"`
+			FileID    string         `json:"file_id,omitempty"`
+			Action    string         `json:"action"`
+			Source    string         `json:"source,omitempty"`
+			Details   map[string]any `json:"details,omitempty"`
+			CreatedAt time.Time      `json:"created_at"`
+		}
+
+		if _, err := io.WriteString(w, "[\n"); err != nil {
+			return err
+		}
+		first := true
+		write := func(record *models.MediaAudit) error {
+			if !first {
+				if _, err := io.WriteString(w, ",\n"); err != nil {
+					return err
+				}
+			}
+			first = false
+			line, err := json.Marshal(auditDocument{
+				ID:        record.GetID(),
+				FileID:    record.FileID,
+				Action:    record.Action,
+				Source:    record.Source,
+				Details:   record.Details,
+				CreatedAt: record.CreatedAt,
+			})
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(line)
+			return err
+		}
+
+		owned := make(map[string]bool, len(media))
+		for start := 0; start < len(media); start += userExportPageSize {
+			end := min(start+userExportPageSize, len(media))
+			fileIDs := make([]string, 0, end-start)
+			for _, item := range media[start:end] {
+				fileIDs = append(fileIDs, string(item.metadata.MediaID))
+				owned[string(item.metadata.MediaID)] = true
+			}
+			for afterID := ""; ; {
+				records, err := e.audits.ListByFiles(ctx, fileIDs, afterID, userExportPageSize)
+				if err != nil {
+					return err
+				}
+				for _, record := range records {
+					if err = write(record); err != nil {
+						return err
+					}
+				}
+				if len(records) < userExportPageSize {
+					break
+				}
+				afterID = records[len(records)-1].GetID()
+			}
+		}
+
+		for afterID := ""; ; {
+			records, err := e.audits.ListBySource(ctx, "profile:"+string(job.ProfileID), afterID, userExportPageSize)
+			if err != nil {
+				return err
+			}
+			for _, record := range records {
+				// Actions on the profile's own files are already written.
+				if owned[record.FileID] {
+					continue
+				}
+				if err = write(record); err != nil {
+					return err
+				}
+			}
+			if len(records) < userExportPageSize {
+				break
+			}
+			afterID = records[len(records)-1].GetID()
+		}
+
+		_, err := io.WriteString(w, "\n]\n")
+		return err
+	}
+}
+
+func (e *UserDataExporter) fail(ctx context.Context, job *types.UserDataExport, err error) error {
+	now := time.Now().UTC()
+	job.State = types.UserDataExportFailed
+	job.LastError = truncateError(err.Error())
+	job.CompletedAt = &now
+	e.save(ctx, job)
+	util.Log(ctx).WithError(err).With("job_id", job.ID).Warn("user data export failed")
+	return fmt.Errorf("user data export %s: %w", job.ID, err)
+}
+
+func (e *UserDataExporter) save(ctx context.Context, job *types.UserDataExport) {
+	if err := e.mediaDatabase.UpdateUserDataExport(ctx, job); err != nil {
+		util.Log(ctx).WithError(err).With("job_id", job.ID).Warn("failed to save user data export progress")
+	}
+}
+
+// exportLimitWriter fails once more than remaining bytes are written, so an
+// archive that compresses worse than expected still stops at the limit.
+type exportLimitWriter struct {
+	w         io.Writer
+	remaining int64
+}
+
+func (l *exportLimitWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining {
+		return 0, errUserExportTooLarge
+	}
+	n, err := l.w.Write(p)
+	l.remaining -= int64(n)
+	return n, err
+}
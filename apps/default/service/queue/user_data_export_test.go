@@ -0,0 +1,223 @@
+package queue
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/antinvestor/service-files/apps/default/service/authz"
+	"github.com/antinvestor/service-files/apps/default/service/business"
+	storage2 "github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/frame/v2/data"
+	"github.com/stretchr/testify/require"
+)
+
+type exportVersion struct {
+	number int
+	hash   string
+	name   string
+}
+
+func (v exportVersion) ID() string           { return "version-" + v.hash }
+func (v exportVersion) MediaID() string      { return "" }
+func (v exportVersion) VersionNumber() int   { return v.number }
+func (v exportVersion) ContentHash() string  { return v.hash }
+func (v exportVersion) FileSize() int64      { return 3 }
+func (v exportVersion) UploadName() string   { return v.name }
+func (v exportVersion) ContentType() string  { return "text/plain" }
+func (v exportVersion) CreatedAt() time.Time { return time.Unix(0, 0) }
+
+type exportDatabase struct {
+	storage2.Database
+	media    []*types.MediaMetadata
+	versions map[string][]exportVersion
+	grants   []*types.AccessGrant
+	results  []types.MediaID
+}
+
+func (d *exportDatabase) ListUserDataExportResults(_ context.Context, _ types.OwnerID) ([]types.MediaID, error) {
+	return d.results, nil
+}
+
+func (d *exportDatabase) ListOwnerMedia(_ context.Context, ownerID types.OwnerID, _ string, afterID string, limit int) ([]*types.MediaMetadata, error) {
+	var page []*types.MediaMetadata
+	for _, metadata := range d.media {
+		if metadata.OwnerID == ownerID && string(metadata.MediaID) > afterID && len(page) < limit {
+			page = append(page, metadata)
+		}
+	}
+	return page, nil
+}
+
+func (d *exportDatabase) GetVersions(_ context.Context, mediaID string) ([]interface {
+	ID() string
+	MediaID() string
+	VersionNumber() int
+	ContentHash() string
+	FileSize() int64
+	UploadName() string
+	ContentType() string
+	CreatedAt() time.Time
+}, error) {
+	var result []interface {
+		ID() string
+		MediaID() string
+		VersionNumber() int
+		ContentHash() string
+		FileSize() int64
+		UploadName() string
+		ContentType() string
+		CreatedAt() time.Time
+	}
+	for _, version := range d.versions[mediaID] {
+		result = append(result, version)
+	}
+	return result, nil
+}
+
+func (d *exportDatabase) ListAccessGrants(_ context.Context, _ string, _ []string) ([]*types.AccessGrant, error) {
+	return d.grants, nil
+}
+
+// exportMedia serves content by hash and keeps the uploaded archive.
+type exportMedia struct {
+	business.MediaService
+	content  map[types.Base64Hash]string
+	uploaded *business.UploadRequest
+	archive  []byte
+}
+
+func (m *exportMedia) DownloadFile(_ context.Context, req *business.DownloadRequest) (*business.DownloadResult, error) {
+	content, ok := m.content[req.Metadata.Base64Hash]
+	if !ok {
+		return nil, errors.New("file not found in storage")
+	}
+	return &business.DownloadResult{MediaMetadata: req.Metadata, FileData: io.NopCloser(strings.NewReader(content))}, nil
+}
+
+func (m *exportMedia) UploadFile(_ context.Context, req *business.UploadRequest) (*business.UploadResult, error) {
+	m.uploaded = req
+	archive, err := io.ReadAll(io.LimitReader(req.FileData, int64(req.Config.MaxFileSizeBytes)+1))
+	if err != nil {
+		return nil, err
+	}
+	m.archive = archive
+	return &business.UploadResult{MediaID: "export-archive"}, nil
+}
+
+type exportAudits struct {
+	records []*models.MediaAudit
+}
+
+func (a *exportAudits) ListByFiles(_ context.Context, fileIDs []string, afterID string, limit int) ([]*models.MediaAudit, error) {
+	var page []*models.MediaAudit
+	for _, record := range a.records {
+		for _, fileID := range fileIDs {
+			if record.FileID == fileID && record.GetID() > afterID && len(page) < limit {
+				page = append(page, record)
+			}
+		}
+	}
+	return page, nil
+}
+
+func (a *exportAudits) ListBySource(_ context.Context, source string, afterID string, limit int) ([]*models.MediaAudit, error) {
+	var page []*models.MediaAudit
+	for _, record := range a.records {
+		if record.Source == source && record.GetID() > afterID && len(page) < limit {
+			page = append(page, record)
+		}
+	}
+	return page, nil
+}
+
+type exportShares struct{}
+
+func (exportShares) ListSharedFiles(_ context.Context, _ string) ([]authz.SharedFile, error) {
+	return []authz.SharedFile{{FileID: "theirs", Grant: authz.AccessGrantInfo{Role: "reader", GrantedBy: "@friend:example.com"}}}, nil
+}
+
+func exportAudit(id, fileID, source string) *models.MediaAudit {
+	return &models.MediaAudit{BaseModel: data.BaseModel{ID: id}, FileID: fileID, Action: "test", Source: source}
+}
+
+func TestUserDataExportArchive(t *testing.T) {
+	ctx := t.Context()
+	profile := types.OwnerID("@export:example.com")
+	db := &exportDatabase{
+		media: []*types.MediaMetadata{
+			{MediaID: "m1", OwnerID: profile, UploadName: "cv.txt", Base64Hash: "h1", FileSizeBytes: 4, Folder: "jobs"},
+			{MediaID: "m2", OwnerID: profile, UploadName: "lost.txt", Base64Hash: "missing", FileSizeBytes: 4},
+			{MediaID: "m3", OwnerID: "@other:example.com", UploadName: "not-mine.txt", Base64Hash: "h3", FileSizeBytes: 4},
+			{MediaID: "m4", OwnerID: profile, UploadName: "data-export-job0.zip", Base64Hash: "h4", FileSizeBytes: 4},
+		},
+		results:  []types.MediaID{"m4"},
+		versions: map[string][]exportVersion{"m1": {{number: 1, hash: "h0", name: "cv-old.txt"}}},
+		grants:   []*types.AccessGrant{{ObjectID: "m1", Relation: authz.RelationViewer, PrincipalKind: "profile", PrincipalID: "@reader:example.com"}},
+	}
+	media := &exportMedia{content: map[types.Base64Hash]string{"h1": "new!", "h0": "old", "h3": "nope"}}
+	audits := &exportAudits{records: []*models.MediaAudit{
+		exportAudit("a1", "m1", "profile:"+string(profile)),
+		exportAudit("a2", "theirs", "profile:"+string(profile)),
+		exportAudit("a3", "m3", "profile:@other:example.com"),
+	}}
+	exporter := &UserDataExporter{
+		mediaDatabase: db,
+		mediaService:  media,
+		archiver:      business.NewArchiver(db, media, nil),
+		audits:        audits,
+		shares:        exportShares{},
+	}
+	job := &types.UserDataExport{ID: "job1", ProfileID: profile}
+	cfg := &config.FilesConfig{UserExportMaxBytes: 1 << 20}
+
+	exported, entries, err := exporter.collect(ctx, profile)
+	require.NoError(t, err)
+	require.Len(t, exported, 2, "only the profile's media are exported, without earlier exports")
+	require.Len(t, entries, 3, "versions are exported next to their media")
+
+	mediaID, err := exporter.store(ctx, cfg, job, entries, business.ArchiveOptions{Documents: []business.ArchiveDocument{
+		{Name: userExportMetadataName, Write: exporter.writeMetadata(job, exported)},
+		{Name: userExportGrantsName, Write: exporter.writeGrants(ctx, job, exported)},
+		{Name: userExportAuditName, Write: exporter.writeAudit(ctx, job, exported)},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, types.MediaID("export-archive"), mediaID)
+	require.Equal(t, profile, media.uploaded.OwnerID)
+	require.False(t, media.uploaded.IsPublic, "exports are private to the profile")
+
+	zr, err := zip.NewReader(bytes.NewReader(media.archive), int64(len(media.archive)))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, file := range zr.File {
+		rc, openErr := file.Open()
+		require.NoError(t, openErr)
+		content, readErr := io.ReadAll(rc)
+		require.NoError(t, readErr)
+		files[file.Name] = string(content)
+	}
+	require.Equal(t, "new!", files["media/jobs/cv.txt"])
+	require.Equal(t, "old", files["versions/m1/1/cv-old.txt"])
+	require.NotContains(t, files, "media/lost.txt", "unreadable files are only listed in the manifest")
+	require.Contains(t, files[business.ArchiveManifestName], "file not found in storage")
+	require.Contains(t, files[userExportMetadataName], `"cv-old.txt"`)
+	require.Contains(t, files[userExportGrantsName], "@reader:example.com")
+	require.Contains(t, files[userExportGrantsName], "@friend:example.com")
+
+	var trail []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(files[userExportAuditName]), &trail))
+	require.Len(t, trail, 2, "records of the profile's files and actions, each once")
+
+	// An archive growing past the limit stops with an error.
+	_, err = exporter.store(ctx, &config.FilesConfig{UserExportMaxBytes: 64}, job, entries, business.ArchiveOptions{})
+	require.ErrorIs(t, err, errUserExportTooLarge)
+}
@@ -0,0 +1,141 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// clamAVChunkSize stays well below the StreamMaxLength clamd accepts
+	// per chunk.
+	clamAVChunkSize = 64 << 10
+
+	defaultClamAVTimeout = 2 * time.Minute
+)
+
+// ClamAV scans content with a clamd daemon using the INSTREAM command.
+type ClamAV struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamAV returns a scanner for the clamd daemon at address, given as
+// tcp://host:port, unix:///path/to/clamd.sock or host:port.
+func NewClamAV(address string, timeout time.Duration) (*ClamAV, error) {
+	network, addr := "tcp", address
+	if strings.Contains(address, "://") {
+		u, err := url.Parse(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid clamav address: %w", err)
+		}
+		switch u.Scheme {
+		case "tcp":
+			addr = u.Host
+		case "unix":
+			network, addr = "unix", u.Path
+		default:
+			return nil, fmt.Errorf("unsupported clamav address scheme %q", u.Scheme)
+		}
+	}
+	if addr == "" {
+		return nil, errors.New("clamav address is required")
+	}
+	if timeout <= 0 {
+		timeout = defaultClamAVTimeout
+	}
+	return &ClamAV{network: network, address: addr, timeout: timeout}, nil
+}
+
+// Scan streams r to clamd and parses its verdict.
+func (c *ClamAV) Scan(ctx context.Context, r io.Reader) (*Result, error) {
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	deadline := time.Now().Add(c.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err = conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	if err = c.stream(conn, r); err != nil {
+		// clamd replies and closes the connection when the stream exceeds
+		// its size limit, so a failed write may still have a verdict.
+		if reply, readErr := readReply(conn); readErr == nil && reply != "" {
+			return parseReply(reply)
+		}
+		return nil, err
+	}
+
+	reply, err := readReply(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	return parseReply(reply)
+}
+
+// stream sends the content as length prefixed chunks followed by a zero
+// length chunk.
+func (c *ClamAV) stream(w io.Writer, r io.Reader) error {
+	if _, err := io.WriteString(w, "zINSTREAM\x00"); err != nil {
+		return err
+	}
+	buf := make([]byte, 4+clamAVChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf, uint32(n))
+			if _, writeErr := w.Write(buf[:4+n]); writeErr != nil {
+				return writeErr
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err := w.Write([]byte{0, 0, 0, 0})
+	return err
+}
+
+func readReply(r io.Reader) (string, error) {
+	reply, err := bufio.NewReader(r).ReadString(0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimRight(reply, "\x00")), nil
+}
+
+// parseReply interprets replies such as "stream: OK",
+// "stream: Eicar-Signature FOUND" and "INSTREAM size limit exceeded. ERROR".
+func parseReply(reply string) (*Result, error) {
+	verdict := strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case verdict == "OK":
+		return &Result{}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return &Result{Infected: true, Signature: strings.TrimSuffix(verdict, " FOUND")}, nil
+	case strings.HasSuffix(verdict, " ERROR"):
+		return nil, fmt.Errorf("clamd error: %s", strings.TrimSuffix(verdict, " ERROR"))
+	default:
+		return nil, fmt.Errorf("unexpected clamd reply %q", reply)
+	}
+}
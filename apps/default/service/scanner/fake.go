@@ -0,0 +1,35 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"io"
+)
+
+// EICAR is the industry standard anti-virus test file. Scanners detect it as
+// malware although it is harmless.
+const EICAR = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// EICARSignature is the signature Fake reports for the EICAR test file.
+const EICARSignature = "Eicar-Test-Signature"
+
+// Fake is a scanner for tests that reports content containing the EICAR
+// test file as infected. Scans fail with Err when it is set.
+type Fake struct {
+	Err error
+}
+
+// Scan implements Scanner.
+func (f *Fake) Scan(_ context.Context, r io.Reader) (*Result, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Contains(content, []byte(EICAR)) {
+		return &Result{Infected: true, Signature: EICARSignature}, nil
+	}
+	return &Result{}, nil
+}
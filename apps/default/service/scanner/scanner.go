@@ -0,0 +1,40 @@
+// Package scanner checks uploaded content for malware.
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+)
+
+// Result is the outcome of scanning content.
+type Result struct {
+	Infected bool
+	// Signature names the malware found in infected content.
+	Signature string
+}
+
+// Scanner checks content for malware. An error means the content could not
+// be scanned, not that it is infected.
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (*Result, error)
+}
+
+// New returns the configured scanner, or nil when scanning is disabled.
+func New(cfg *config.FilesConfig) (Scanner, error) {
+	switch cfg.ScannerProvider {
+	case "", config.ScannerNone:
+		return nil, nil
+	case config.ScannerClamAV:
+		clam, err := NewClamAV(cfg.ClamAVAddress, time.Duration(cfg.ScanTimeoutSeconds)*time.Second)
+		if err != nil {
+			return nil, err
+		}
+		return clam, nil
+	default:
+		return nil, fmt.Errorf("unsupported scanner provider %q", cfg.ScannerProvider)
+	}
+}
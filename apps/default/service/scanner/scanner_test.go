@@ -0,0 +1,200 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClamd accepts INSTREAM sessions and replies with the verdict reply
+// returns for the streamed content.
+func fakeClamd(t *testing.T, network, address string, reply func(content []byte) string) string {
+	t.Helper()
+	listener, err := net.Listen(network, address)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, acceptErr := listener.Accept()
+			if acceptErr != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				r := bufio.NewReader(conn)
+				command, readErr := r.ReadString(0)
+				if readErr != nil || command != "zINSTREAM\x00" {
+					_, _ = io.WriteString(conn, "UNKNOWN COMMAND\x00")
+					return
+				}
+				var content bytes.Buffer
+				for {
+					var size uint32
+					if binary.Read(r, binary.BigEndian, &size) != nil {
+						return
+					}
+					if size == 0 {
+						break
+					}
+					if _, copyErr := io.CopyN(&content, r, int64(size)); copyErr != nil {
+						return
+					}
+				}
+				_, _ = io.WriteString(conn, reply(content.Bytes())+"\x00")
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func eicarVerdict(content []byte) string {
+	if bytes.Contains(content, []byte(EICAR)) {
+		return "stream: Win.Test.EICAR_HDB-1 FOUND"
+	}
+	return "stream: OK"
+}
+
+func TestClamAVScan(t *testing.T) {
+	address := fakeClamd(t, "tcp", "127.0.0.1:0", eicarVerdict)
+	clam, err := NewClamAV("tcp://"+address, 5*time.Second)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		content   string
+		infected  bool
+		signature string
+	}{
+		{name: "clean", content: "hello world"},
+		{name: "empty", content: ""},
+		{name: "eicar", content: EICAR, infected: true, signature: "Win.Test.EICAR_HDB-1"},
+		{
+			name:      "eicar_after_several_chunks",
+			content:   strings.Repeat("a", 3*clamAVChunkSize+7) + EICAR,
+			infected:  true,
+			signature: "Win.Test.EICAR_HDB-1",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, scanErr := clam.Scan(context.Background(), strings.NewReader(tc.content))
+			require.NoError(t, scanErr)
+			assert.Equal(t, tc.infected, result.Infected)
+			assert.Equal(t, tc.signature, result.Signature)
+		})
+	}
+}
+
+func TestClamAVScanUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "clamd.sock")
+	fakeClamd(t, "unix", socket, eicarVerdict)
+
+	clam, err := NewClamAV("unix://"+socket, 5*time.Second)
+	require.NoError(t, err)
+	result, err := clam.Scan(context.Background(), strings.NewReader(EICAR))
+	require.NoError(t, err)
+	assert.True(t, result.Infected)
+}
+
+func TestClamAVScanErrors(t *testing.T) {
+	address := fakeClamd(t, "tcp", "127.0.0.1:0", func([]byte) string {
+		return "INSTREAM size limit exceeded. ERROR"
+	})
+	clam, err := NewClamAV(address, 5*time.Second)
+	require.NoError(t, err)
+	_, err = clam.Scan(context.Background(), strings.NewReader("content"))
+	assert.ErrorContains(t, err, "size limit exceeded")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachable := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	clam, err = NewClamAV(unreachable, time.Second)
+	require.NoError(t, err)
+	_, err = clam.Scan(context.Background(), strings.NewReader("content"))
+	assert.Error(t, err)
+}
+
+func TestNewClamAV(t *testing.T) {
+	testCases := []struct {
+		address string
+		network string
+		target  string
+		wantErr bool
+	}{
+		{address: "tcp://clamav:3310", network: "tcp", target: "clamav:3310"},
+		{address: "clamav:3310", network: "tcp", target: "clamav:3310"},
+		{address: "unix:///run/clamd.sock", network: "unix", target: "/run/clamd.sock"},
+		{address: "http://clamav:3310", wantErr: true},
+		{address: "", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.address, func(t *testing.T) {
+			clam, err := NewClamAV(tc.address, 0)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.network, clam.network)
+			assert.Equal(t, tc.target, clam.address)
+			assert.Equal(t, defaultClamAVTimeout, clam.timeout)
+		})
+	}
+}
+
+func TestParseReply(t *testing.T) {
+	result, err := parseReply("stream: OK")
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+
+	result, err = parseReply("stream: Eicar-Signature FOUND")
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Infected: true, Signature: "Eicar-Signature"}, result)
+
+	_, err = parseReply("stream: Can't allocate memory ERROR")
+	assert.Error(t, err)
+	_, err = parseReply("PONG")
+	assert.Error(t, err)
+}
+
+func TestFake(t *testing.T) {
+	fake := &Fake{}
+	result, err := fake.Scan(context.Background(), strings.NewReader("prefix "+EICAR+" suffix"))
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Infected: true, Signature: EICARSignature}, result)
+
+	result, err = fake.Scan(context.Background(), strings.NewReader("harmless"))
+	require.NoError(t, err)
+	assert.False(t, result.Infected)
+
+	fake.Err = errors.New("scanner unavailable")
+	_, err = fake.Scan(context.Background(), strings.NewReader("harmless"))
+	assert.ErrorIs(t, err, fake.Err)
+}
+
+func TestNew(t *testing.T) {
+	s, err := New(&config.FilesConfig{ScannerProvider: config.ScannerNone})
+	require.NoError(t, err)
+	assert.Nil(t, s)
+
+	s, err = New(&config.FilesConfig{ScannerProvider: config.ScannerClamAV, ClamAVAddress: "tcp://clamav:3310"})
+	require.NoError(t, err)
+	assert.IsType(t, &ClamAV{}, s)
+
+	_, err = New(&config.FilesConfig{ScannerProvider: "sophos"})
+	assert.Error(t, err)
+}
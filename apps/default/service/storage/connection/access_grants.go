@@ -0,0 +1,75 @@
+package connection
+
+import (
+	"context"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+)
+
+// StoreAccessGrant records a grant, replacing the record of the same role
+// granted before to the same principal on the same object.
+func (d *Database) StoreAccessGrant(ctx context.Context, grant *types.AccessGrant) error {
+	existing, err := d.AccessGrantRepo.ListByObjects(ctx, grant.ObjectNamespace, []string{grant.ObjectID})
+	if err != nil {
+		return err
+	}
+	for _, ag := range existing {
+		if ag.Relation == grant.Relation && ag.PrincipalKind == grant.PrincipalKind && ag.PrincipalID == grant.PrincipalID {
+			if err = d.AccessGrantRepo.Delete(ctx, ag.GetID()); err != nil {
+				return err
+			}
+		}
+	}
+
+	ag := models.AccessGrant{}
+	ag.Fill(grant)
+	if err = d.AccessGrantRepo.Create(ctx, &ag); err != nil {
+		return err
+	}
+	grant.ID = ag.GetID()
+	grant.TenantID = ag.TenantID
+	grant.PartitionID = ag.PartitionID
+	grant.CreatedAt = ag.CreatedAt
+	grant.ModifiedAt = ag.ModifiedAt
+	return nil
+}
+
+// ListAccessGrants returns the grants recorded on any of the given objects.
+func (d *Database) ListAccessGrants(ctx context.Context, namespace string, objectIDs []string) ([]*types.AccessGrant, error) {
+	grants, err := d.AccessGrantRepo.ListByObjects(ctx, namespace, objectIDs)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*types.AccessGrant, 0, len(grants))
+	for _, ag := range grants {
+		result = append(result, ag.ToApi())
+	}
+	return result, nil
+}
+
+// ListExpiredAccessGrants returns up to limit grants that expired before the
+// given time, oldest first.
+func (d *Database) ListExpiredAccessGrants(ctx context.Context, before time.Time, limit int) ([]*types.AccessGrant, error) {
+	grants, err := d.AccessGrantRepo.ListExpired(ctx, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*types.AccessGrant, 0, len(grants))
+	for _, ag := range grants {
+		result = append(result, ag.ToApi())
+	}
+	return result, nil
+}
+
+// DeleteAccessGrants removes the grants of the given relations recorded for
+// a principal on an object.
+func (d *Database) DeleteAccessGrants(ctx context.Context, namespace, objectID, principalKind, principalID string, relations []string) error {
+	return d.AccessGrantRepo.DeleteForPrincipal(ctx, namespace, objectID, principalKind, principalID, relations)
+}
+
+// DeleteAccessGrant removes one grant record.
+func (d *Database) DeleteAccessGrant(ctx context.Context, grantID string) error {
+	return d.AccessGrantRepo.Delete(ctx, grantID)
+}
@@ -0,0 +1,53 @@
+package connection
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"gorm.io/gorm"
+)
+
+// StoreArchiveExtraction inserts a new extraction job.
+func (d *Database) StoreArchiveExtraction(ctx context.Context, job *types.ArchiveExtraction) error {
+	ae := models.ArchiveExtraction{}
+	ae.Fill(job)
+	if err := d.ArchiveExtractionRepo.Create(ctx, &ae); err != nil {
+		return err
+	}
+	job.ID = ae.GetID()
+	job.CreatedAt = ae.CreatedAt
+	job.ModifiedAt = ae.ModifiedAt
+	return nil
+}
+
+// GetArchiveExtraction returns an extraction job or nil if it does not exist.
+func (d *Database) GetArchiveExtraction(ctx context.Context, jobID string) (*types.ArchiveExtraction, error) {
+	ae, err := d.ArchiveExtractionRepo.GetByID(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ae.ToApi(), nil
+}
+
+// UpdateArchiveExtraction records the progress of an extraction job.
+func (d *Database) UpdateArchiveExtraction(ctx context.Context, job *types.ArchiveExtraction) error {
+	ae := models.ArchiveExtraction{}
+	ae.Fill(job)
+	_, err := d.ArchiveExtractionRepo.BulkUpdate(ctx, []string{job.ID}, map[string]any{
+		"state":           ae.State,
+		"entries":         ae.Entries,
+		"extracted":       ae.Extracted,
+		"skipped":         ae.Skipped,
+		"failed":          ae.Failed,
+		"extracted_bytes": ae.ExtractedBytes,
+		"failures":        ae.Failures,
+		"last_error":      ae.LastError,
+		"completed_at":    ae.CompletedAt,
+	})
+	return err
+}
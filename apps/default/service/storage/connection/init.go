@@ -15,15 +15,33 @@ func NewMediaDatabase(
 	retentionPolicyRepo repository.RetentionPolicyRepository,
 	fileRetentionRepo repository.FileRetentionRepository,
 	storageStatsRepo repository.StorageStatsRepository,
+	webhookSubscriptionRepo repository.WebhookSubscriptionRepository,
+	webhookDeliveryRepo repository.WebhookDeliveryRepository,
+	thumbnailJobRepo repository.ThumbnailJobRepository,
+	thumbnailRegenerationRepo repository.ThumbnailRegenerationRepository,
+	archiveExtractionRepo repository.ArchiveExtractionRepository,
+	shareLinkRepo repository.ShareLinkRepository,
+	accessGrantRepo repository.AccessGrantRepository,
+	userDataExportRepo repository.UserDataExportRepository,
+	userDataErasureRepo repository.UserDataErasureRepository,
 ) (storage.Database, error) {
 	return &Database{
-		WorkManager:             workManager,
-		MediaRepository:         mediaRepo,
-		MultipartUploadRepo:     multipartUploadRepo,
-		MultipartUploadPartRepo: multipartUploadPartRepo,
-		FileVersionRepo:         fileVersionRepo,
-		RetentionPolicyRepo:     retentionPolicyRepo,
-		FileRetentionRepo:       fileRetentionRepo,
-		StorageStatsRepo:        storageStatsRepo,
+		WorkManager:               workManager,
+		MediaRepository:           mediaRepo,
+		MultipartUploadRepo:       multipartUploadRepo,
+		MultipartUploadPartRepo:   multipartUploadPartRepo,
+		FileVersionRepo:           fileVersionRepo,
+		RetentionPolicyRepo:       retentionPolicyRepo,
+		FileRetentionRepo:         fileRetentionRepo,
+		StorageStatsRepo:          storageStatsRepo,
+		WebhookSubscriptionRepo:   webhookSubscriptionRepo,
+		WebhookDeliveryRepo:       webhookDeliveryRepo,
+		ThumbnailJobRepo:          thumbnailJobRepo,
+		ThumbnailRegenerationRepo: thumbnailRegenerationRepo,
+		ArchiveExtractionRepo:     archiveExtractionRepo,
+		ShareLinkRepo:             shareLinkRepo,
+		AccessGrantRepo:           accessGrantRepo,
+		UserDataExportRepo:        userDataExportRepo,
+		UserDataErasureRepo:       userDataErasureRepo,
 	}, nil
 }
@@ -54,6 +54,15 @@ func (suite *DatastoreTestSuite) TestMediaRepository() {
 					res.RetentionPolicyRepo,
 					res.FileRetentionRepo,
 					res.StorageStatsRepo,
+					res.WebhookSubscriptionRepo,
+					res.WebhookDeliveryRepo,
+					res.ThumbnailJobRepo,
+					res.ThumbnailRegenerationRepo,
+					res.ArchiveExtractionRepo,
+					res.ShareLinkRepo,
+					res.AccessGrantRepo,
+					res.UserDataExportRepo,
+					res.UserDataErasureRepo,
 				)
 				assert.NoErrorf(t, err, "failed to open media database")
 
@@ -137,6 +146,15 @@ func (suite *DatastoreTestSuite) TestThumbnailsStorage() {
 					res.RetentionPolicyRepo,
 					res.FileRetentionRepo,
 					res.StorageStatsRepo,
+					res.WebhookSubscriptionRepo,
+					res.WebhookDeliveryRepo,
+					res.ThumbnailJobRepo,
+					res.ThumbnailRegenerationRepo,
+					res.ArchiveExtractionRepo,
+					res.ShareLinkRepo,
+					res.AccessGrantRepo,
+					res.UserDataExportRepo,
+					res.UserDataErasureRepo,
 				)
 				assert.NoErrorf(t, err, "failed to open media database")
 
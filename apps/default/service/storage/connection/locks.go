@@ -0,0 +1,49 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"hash/fnv"
+
+	"github.com/pitabwire/util"
+)
+
+// TryAdvisoryLock takes the Postgres session advisory lock named name on a
+// connection of its own, so that work run by every replica is done by one of
+// them at a time. It reports false when another session holds the lock.
+// Release unlocks it and hands the connection back to the pool.
+func (d *Database) TryAdvisoryLock(ctx context.Context, name string) (func(), bool, error) {
+	sqlDB, err := d.MediaRepository.Pool().DB(ctx, false).DB()
+	if err != nil {
+		return nil, false, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	key := advisoryLockKey(name)
+	var acquired bool
+	if err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil || !acquired {
+		_ = conn.Close()
+		return nil, false, err
+	}
+
+	release := func() {
+		// The walk holding the lock may have ended with its context.
+		if _, unlockErr := conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", key); unlockErr != nil {
+			util.Log(ctx).WithError(unlockErr).With("lock", name).Warn("failed to release advisory lock")
+			// Closing the session is the only other way to release the lock.
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+		_ = conn.Close()
+	}
+	return release, true, nil
+}
+
+// advisoryLockKey maps a lock name to the key Postgres locks on.
+func advisoryLockKey(name string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return int64(h.Sum64())
+}
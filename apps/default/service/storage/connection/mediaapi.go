@@ -19,6 +19,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/antinvestor/service-files/apps/default/service/storage"
 	"github.com/antinvestor/service-files/apps/default/service/storage/models"
 	"github.com/antinvestor/service-files/apps/default/service/storage/repository"
 	"github.com/antinvestor/service-files/apps/default/service/types"
@@ -29,14 +30,23 @@ import (
 )
 
 type Database struct {
-	WorkManager             workerpool.Manager
-	MediaRepository         repository.MediaRepository
-	MultipartUploadRepo     repository.MultipartUploadRepository
-	MultipartUploadPartRepo repository.MultipartUploadPartRepository
-	FileVersionRepo         repository.FileVersionRepository
-	RetentionPolicyRepo     repository.RetentionPolicyRepository
-	FileRetentionRepo       repository.FileRetentionRepository
-	StorageStatsRepo        repository.StorageStatsRepository
+	WorkManager               workerpool.Manager
+	MediaRepository           repository.MediaRepository
+	MultipartUploadRepo       repository.MultipartUploadRepository
+	MultipartUploadPartRepo   repository.MultipartUploadPartRepository
+	FileVersionRepo           repository.FileVersionRepository
+	RetentionPolicyRepo       repository.RetentionPolicyRepository
+	FileRetentionRepo         repository.FileRetentionRepository
+	StorageStatsRepo          repository.StorageStatsRepository
+	WebhookSubscriptionRepo   repository.WebhookSubscriptionRepository
+	WebhookDeliveryRepo       repository.WebhookDeliveryRepository
+	ThumbnailJobRepo          repository.ThumbnailJobRepository
+	ThumbnailRegenerationRepo repository.ThumbnailRegenerationRepository
+	ArchiveExtractionRepo     repository.ArchiveExtractionRepository
+	ShareLinkRepo             repository.ShareLinkRepository
+	AccessGrantRepo           repository.AccessGrantRepository
+	UserDataExportRepo        repository.UserDataExportRepository
+	UserDataErasureRepo       repository.UserDataErasureRepository
 }
 
 // StoreMediaMetadata inserts the metadata about the uploaded media into the database.
@@ -58,6 +68,125 @@ func (d *Database) GetMediaMetadata(ctx context.Context, mediaID types.MediaID)
 	return mediaMetadata.ToApi(), err
 }
 
+// GetMediaMetadataBatch looks up the metadata of many media in one query.
+// Media that do not exist are left out; the rest come back in no set order.
+func (d *Database) GetMediaMetadataBatch(ctx context.Context, mediaIDs []types.MediaID) ([]*types.MediaMetadata, error) {
+	ids := make([]string, 0, len(mediaIDs))
+	for _, id := range mediaIDs {
+		ids = append(ids, string(id))
+	}
+	media, err := d.MediaRepository.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*types.MediaMetadata, 0, len(media))
+	for _, mm := range media {
+		result = append(result, mm.ToApi())
+	}
+	return result, nil
+}
+
+// StorePlaceholder records the image placeholder on a media record, keeping
+// its other properties.
+func (d *Database) StorePlaceholder(ctx context.Context, mediaID types.MediaID, placeholder *types.Placeholder) error {
+	return d.MediaRepository.PatchProperties(ctx, string(mediaID), models.PlaceholderPatch(placeholder), nil)
+}
+
+// StoreScanResult records the malware scan outcome on a media record.
+// Infected media is quarantined by also withdrawing public access.
+func (d *Database) StoreScanResult(ctx context.Context, mediaID types.MediaID, status types.ScanStatus, signature string) error {
+	var columns map[string]any
+	if status == types.ScanStatusInfected {
+		columns = map[string]any{"public": false}
+	}
+	return d.MediaRepository.PatchProperties(ctx, string(mediaID), models.ScanResultPatch(status, signature), columns)
+}
+
+// ListOwnerMedia returns an owner's original media filed under folder or one
+// of its subfolders, or all of them for an empty folder, with IDs after afterID.
+// An empty owner lists the media of every owner.
+func (d *Database) ListOwnerMedia(ctx context.Context, ownerID types.OwnerID, folder string, afterID string, limit int) ([]*types.MediaMetadata, error) {
+	media, err := d.MediaRepository.ListByOwner(ctx, ownerID, folder, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*types.MediaMetadata, 0, len(media))
+	for _, mm := range media {
+		result = append(result, mm.ToApi())
+	}
+	return result, nil
+}
+
+// ListMediaOwnership returns the owner of every original media, deleted or
+// not, with IDs after afterID, in ID order.
+func (d *Database) ListMediaOwnership(ctx context.Context, afterID string, limit int) ([]*types.MediaOwnership, error) {
+	media, err := d.MediaRepository.ListOriginalsWithDeleted(ctx, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toMediaOwnership(media), nil
+}
+
+// ListMediaOwnershipByID returns the owner of each of the given media that
+// has a record, deleted or not.
+func (d *Database) ListMediaOwnershipByID(ctx context.Context, mediaIDs []types.MediaID) ([]*types.MediaOwnership, error) {
+	ids := make([]string, len(mediaIDs))
+	for i, id := range mediaIDs {
+		ids[i] = string(id)
+	}
+	media, err := d.MediaRepository.GetWithDeleted(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return toMediaOwnership(media), nil
+}
+
+func toMediaOwnership(media []*models.MediaMetadata) []*types.MediaOwnership {
+	result := make([]*types.MediaOwnership, 0, len(media))
+	for _, mm := range media {
+		result = append(result, &types.MediaOwnership{
+			MediaID: types.MediaID(mm.GetID()),
+			OwnerID: types.OwnerID(mm.OwnerID),
+			Folder:  mm.Folder,
+			Deleted: mm.DeletedAt.Valid,
+		})
+	}
+	return result
+}
+
+// TransferMediaOwnership hands the given media of one owner, with their
+// thumbnails and other derivatives, to another owner in a single transaction.
+// Nothing changes and storage.ErrOwnershipChanged is returned when any of the
+// media is missing or no longer belongs to fromOwner.
+func (d *Database) TransferMediaOwnership(ctx context.Context, mediaIDs []types.MediaID, fromOwner, toOwner types.OwnerID) error {
+	const batchSize = 500
+	return d.MediaRepository.Pool().DB(ctx, false).Transaction(func(tx *gorm.DB) error {
+		for start := 0; start < len(mediaIDs); start += batchSize {
+			end := min(start+batchSize, len(mediaIDs))
+			ids := make([]string, 0, end-start)
+			for _, id := range mediaIDs[start:end] {
+				ids = append(ids, string(id))
+			}
+			result := tx.Model(&models.MediaMetadata{}).
+				Where("owner_id = ? AND id IN ?", string(fromOwner), ids).
+				Update("owner_id", string(toOwner))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected != int64(len(ids)) {
+				return storage.ErrOwnershipChanged
+			}
+			err := tx.Model(&models.MediaMetadata{}).
+				Where("owner_id = ? AND parent_id IN ?", string(fromOwner), ids).
+				Update("owner_id", string(toOwner)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // UpdateMediaMetadata updates specific fields on a media record and returns the updated metadata.
 func (d *Database) UpdateMediaMetadata(ctx context.Context, mediaID types.MediaID, updates map[string]any) (*types.MediaMetadata, error) {
 	_, err := d.MediaRepository.BulkUpdate(ctx, []string{string(mediaID)}, updates)
@@ -182,16 +311,42 @@ func (d *Database) GetThumbnails(ctx context.Context, mediaID types.MediaID) ([]
 		return nil, err
 	}
 
-	apiTMM := make([]*types.ThumbnailMetadata, len(metadatas))
-	for i, mm := range metadatas {
-		apiTMM[i] = &types.ThumbnailMetadata{
-			MediaMetadata: mm.ToApi(),
+	apiTMM := make([]*types.ThumbnailMetadata, 0, len(metadatas))
+	for _, mm := range metadatas {
+		apiMM := mm.ToApi()
+		// Transform derivatives are looked up by key, never as size matches
+		if apiMM.Transform != "" {
+			continue
 		}
+		apiTMM = append(apiTMM, &types.ThumbnailMetadata{
+			MediaMetadata: apiMM,
+		})
 	}
 
 	return apiTMM, err
 }
 
+// GetDerivative returns the stored derivative of a media for a transform key.
+// Returns nil metadata if the derivative has not been generated yet.
+func (d *Database) GetDerivative(ctx context.Context, mediaID types.MediaID, transform string) (*types.ThumbnailMetadata, error) {
+	mediaMetadata, err := d.MediaRepository.GetByParentIDAndTransform(ctx, mediaID, transform)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &types.ThumbnailMetadata{
+		MediaMetadata: mediaMetadata.ToApi(),
+	}, nil
+}
+
+// CountDerivatives returns the number of transform derivatives stored for a media.
+func (d *Database) CountDerivatives(ctx context.Context, mediaID types.MediaID) (int64, error) {
+	return d.MediaRepository.CountDerivatives(ctx, mediaID)
+}
+
 func (d *Database) StoreUpload(ctx context.Context, upload interface {
 	GetID() string
 	GetOwnerID() string
@@ -649,7 +804,7 @@ func (d *Database) GetRetention(ctx context.Context, mediaID string) (interface
 	IsLocked() bool
 }, error) {
 	r, err := d.FileRetentionRepo.GetByMediaID(ctx, mediaID)
-	if err != nil {
+	if err != nil || r == nil {
 		return nil, err
 	}
 	return &dbFileRetentionResult{r: r}, nil
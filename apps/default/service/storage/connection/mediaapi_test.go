@@ -1,15 +1,18 @@
 package connection_test
 
 import (
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/antinvestor/service-files/apps/default/service/storage"
 	"github.com/antinvestor/service-files/apps/default/service/storage/connection"
 	"github.com/antinvestor/service-files/apps/default/service/tests"
 	"github.com/antinvestor/service-files/apps/default/service/types"
 	"github.com/pitabwire/frame/v2/data"
 	"github.com/pitabwire/frame/v2/frametests/definition"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -587,6 +590,238 @@ func (suite *ConnectionTestSuite) TestGetThumbnails() {
 	})
 }
 
+func (suite *ConnectionTestSuite) TestStorePlaceholder() {
+	suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+		ctx, svc, res := suite.CreateService(t, dep)
+		db := &connection.Database{
+			WorkManager:     svc.WorkManager(),
+			MediaRepository: res.MediaRepository,
+		}
+
+		media := &types.MediaMetadata{
+			MediaID:       "placeholder-media",
+			UploadName:    "photo.png",
+			ContentType:   "image/png",
+			FileSizeBytes: 2048,
+			Base64Hash:    "placeholder-hash",
+			OwnerID:       "test-owner",
+			Properties:    &types.MediaProperties{Format: "png", Width: 40, Height: 30},
+		}
+		require.NoError(t, db.StoreMediaMetadata(ctx, media))
+
+		placeholder := &types.Placeholder{BlurHash: "LDTI:j]9fQ]9|co1fQo1fQfQfQfQ", DominantColor: "#ff0000"}
+		require.NoError(t, db.StorePlaceholder(ctx, media.MediaID, placeholder))
+
+		stored, err := db.GetMediaMetadata(ctx, media.MediaID)
+		require.NoError(t, err)
+		assert.Equal(t, placeholder, stored.Placeholder)
+		assert.Equal(t, media.Properties, stored.Properties, "other properties are kept")
+
+		assert.Error(t, db.StorePlaceholder(ctx, "missing-media", placeholder))
+	})
+}
+
+func (suite *ConnectionTestSuite) TestStoreScanResult() {
+	suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+		ctx, svc, res := suite.CreateService(t, dep)
+		db := &connection.Database{
+			WorkManager:     svc.WorkManager(),
+			MediaRepository: res.MediaRepository,
+		}
+
+		media := &types.MediaMetadata{
+			MediaID:       "scanned-media",
+			UploadName:    "report.pdf",
+			ContentType:   "application/pdf",
+			FileSizeBytes: 2048,
+			Base64Hash:    "scanned-hash",
+			OwnerID:       "test-owner",
+			IsPublic:      true,
+			ScanStatus:    types.ScanStatusPending,
+		}
+		require.NoError(t, db.StoreMediaMetadata(ctx, media))
+
+		require.NoError(t, db.StoreScanResult(ctx, media.MediaID, types.ScanStatusInfected, "Eicar-Test-Signature"))
+
+		stored, err := db.GetMediaMetadata(ctx, media.MediaID)
+		require.NoError(t, err)
+		assert.Equal(t, types.ScanStatusInfected, stored.ScanStatus)
+		assert.Equal(t, "Eicar-Test-Signature", stored.ScanSignature)
+		assert.False(t, stored.IsPublic, "infected media is quarantined")
+
+		// Writers of different properties do not overwrite each other.
+		placeholder := &types.Placeholder{BlurHash: "LDTI:j]9fQ]9|co1fQo1fQfQfQfQ", DominantColor: "#00ff00"}
+		var wg sync.WaitGroup
+		errs := make(chan error, 2)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			errs <- db.StoreScanResult(ctx, media.MediaID, types.ScanStatusClean, "")
+		}()
+		go func() {
+			defer wg.Done()
+			errs <- db.StorePlaceholder(ctx, media.MediaID, placeholder)
+		}()
+		wg.Wait()
+		close(errs)
+		for err = range errs {
+			require.NoError(t, err)
+		}
+
+		stored, err = db.GetMediaMetadata(ctx, media.MediaID)
+		require.NoError(t, err)
+		assert.Equal(t, types.ScanStatusClean, stored.ScanStatus)
+		assert.Empty(t, stored.ScanSignature, "the earlier signature is cleared")
+		assert.Equal(t, placeholder, stored.Placeholder)
+
+		assert.Error(t, db.StoreScanResult(ctx, "missing-media", types.ScanStatusClean, ""))
+	})
+}
+
+func (suite *ConnectionTestSuite) TestTransferMediaOwnership() {
+	suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+		ctx, svc, res := suite.CreateService(t, dep)
+		db := &connection.Database{
+			WorkManager:     svc.WorkManager(),
+			MediaRepository: res.MediaRepository,
+		}
+
+		for _, media := range []*types.MediaMetadata{
+			{MediaID: "transfer-original", OwnerID: "transfer-from", Base64Hash: "transfer-hash-1", FileSizeBytes: 10},
+			{MediaID: "transfer-thumb", OwnerID: "transfer-from", Base64Hash: "transfer-hash-2", FileSizeBytes: 5, ParentID: "transfer-original"},
+			{MediaID: "transfer-other", OwnerID: "someone-else", Base64Hash: "transfer-hash-3", FileSizeBytes: 10},
+		} {
+			require.NoError(t, db.StoreMediaMetadata(ctx, media))
+		}
+
+		t.Run("changed_owner_moves_nothing", func(t *testing.T) {
+			err := db.TransferMediaOwnership(ctx, []types.MediaID{"transfer-original", "transfer-other"}, "transfer-from", "transfer-to")
+			require.ErrorIs(t, err, storage.ErrOwnershipChanged)
+
+			stored, err := db.GetMediaMetadata(ctx, "transfer-original")
+			require.NoError(t, err)
+			assert.Equal(t, types.OwnerID("transfer-from"), stored.OwnerID)
+		})
+
+		t.Run("moves_derivatives", func(t *testing.T) {
+			require.NoError(t, db.TransferMediaOwnership(ctx, []types.MediaID{"transfer-original"}, "transfer-from", "transfer-to"))
+			for _, mediaID := range []types.MediaID{"transfer-original", "transfer-thumb"} {
+				stored, err := db.GetMediaMetadata(ctx, mediaID)
+				require.NoError(t, err)
+				assert.Equal(t, types.OwnerID("transfer-to"), stored.OwnerID)
+			}
+		})
+	})
+}
+
+func (suite *ConnectionTestSuite) TestEraseMedia() {
+	suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+		ctx, svc, res := suite.CreateService(t, dep)
+		db := &connection.Database{
+			WorkManager:     svc.WorkManager(),
+			MediaRepository: res.MediaRepository,
+		}
+
+		for _, media := range []*types.MediaMetadata{
+			{MediaID: "erase-original", OwnerID: "erase-owner", Base64Hash: "erase-hash-1", FileSizeBytes: 10},
+			{MediaID: "erase-thumb", OwnerID: "erase-owner", Base64Hash: "erase-hash-2", FileSizeBytes: 5, ParentID: "erase-original"},
+			{MediaID: "erase-kept", OwnerID: "erase-owner", Base64Hash: "erase-hash-3", FileSizeBytes: 10},
+		} {
+			require.NoError(t, db.StoreMediaMetadata(ctx, media))
+		}
+
+		erased, err := db.EraseMedia(ctx, []types.MediaID{"erase-original"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), erased.Derivatives)
+		assert.ElementsMatch(t, []types.ErasedBlob{{Hash: "erase-hash-1"}, {Hash: "erase-hash-2"}}, erased.Blobs)
+
+		for _, mediaID := range []types.MediaID{"erase-original", "erase-thumb"} {
+			stored, getErr := db.GetMediaMetadata(ctx, mediaID)
+			require.NoError(t, getErr)
+			assert.Nil(t, stored, "erased media and their derivatives are gone")
+		}
+		stored, err := db.GetMediaMetadata(ctx, "erase-kept")
+		require.NoError(t, err)
+		assert.NotNil(t, stored)
+	})
+}
+
+func (suite *ConnectionTestSuite) TestClaimDueWebhookDeliveries() {
+	suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+		ctx, svc, res := suite.CreateService(t, dep)
+		db := &connection.Database{
+			WorkManager:         svc.WorkManager(),
+			WebhookDeliveryRepo: res.WebhookDeliveryRepo,
+		}
+
+		due := time.Now().UTC().Add(-time.Minute)
+		delivery := &types.WebhookDelivery{
+			SubscriptionID: "claim-subscription",
+			EventID:        "claim-event",
+			State:          types.WebhookDeliveryPending,
+			NextAttemptAt:  &due,
+		}
+		stored, err := db.StoreWebhookDelivery(ctx, delivery)
+		require.NoError(t, err)
+		require.True(t, stored)
+
+		stored, err = db.StoreWebhookDelivery(ctx, &types.WebhookDelivery{
+			SubscriptionID: delivery.SubscriptionID,
+			EventID:        delivery.EventID,
+			State:          types.WebhookDeliveryPending,
+			NextAttemptAt:  &due,
+		})
+		require.NoError(t, err)
+		assert.False(t, stored, "a redelivered event is recorded once")
+
+		now := time.Now().UTC()
+		claimed, err := db.ClaimDueWebhookDeliveries(ctx, now, now.Add(time.Minute), 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, delivery.ID, claimed[0].ID)
+
+		claimed, err = db.ClaimDueWebhookDeliveries(ctx, now, now.Add(time.Minute), 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed, "a leased delivery is not claimed again")
+
+		claimed, err = db.ClaimDueWebhookDeliveries(ctx, now.Add(2*time.Minute), now.Add(3*time.Minute), 10)
+		require.NoError(t, err)
+		assert.Len(t, claimed, 1, "an expired lease is claimed again")
+	})
+}
+
+func (suite *ConnectionTestSuite) TestClaimDueThumbnailJobs() {
+	suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
+		ctx, svc, res := suite.CreateService(t, dep)
+		db := &connection.Database{
+			WorkManager:      svc.WorkManager(),
+			ThumbnailJobRepo: res.ThumbnailJobRepo,
+		}
+
+		due := time.Now().UTC().Add(-time.Minute)
+		job := &types.ThumbnailJob{
+			MediaID:       "claim-thumbnail-media",
+			State:         types.ThumbnailJobPending,
+			NextAttemptAt: &due,
+		}
+		require.NoError(t, db.StoreThumbnailJob(ctx, job))
+
+		now := time.Now().UTC()
+		claimed, err := db.ClaimDueThumbnailJobs(ctx, now, now.Add(time.Minute), 10)
+		require.NoError(t, err)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, job.ID, claimed[0].ID)
+
+		claimed, err = db.ClaimDueThumbnailJobs(ctx, now, now.Add(time.Minute), 10)
+		require.NoError(t, err)
+		assert.Empty(t, claimed, "a leased job is not claimed again")
+
+		claimed, err = db.ClaimDueThumbnailJobs(ctx, now.Add(2*time.Minute), now.Add(3*time.Minute), 10)
+		require.NoError(t, err)
+		assert.Len(t, claimed, 1, "an expired lease is claimed again")
+	})
+}
+
 func (suite *ConnectionTestSuite) TestNewMediaDatabase() {
 	suite.WithTestDependancies(suite.T(), func(t *testing.T, dep *definition.DependencyOption) {
 		_, svc, res := suite.CreateService(t, dep)
@@ -612,6 +847,15 @@ func (suite *ConnectionTestSuite) TestNewMediaDatabase() {
 					res.RetentionPolicyRepo,
 					res.FileRetentionRepo,
 					res.StorageStatsRepo,
+					res.WebhookSubscriptionRepo,
+					res.WebhookDeliveryRepo,
+					res.ThumbnailJobRepo,
+					res.ThumbnailRegenerationRepo,
+					res.ArchiveExtractionRepo,
+					res.ShareLinkRepo,
+					res.AccessGrantRepo,
+					res.UserDataExportRepo,
+					res.UserDataErasureRepo,
 				)
 				if tc.wantErr {
 					assert.Error(t, err)
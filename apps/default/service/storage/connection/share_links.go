@@ -0,0 +1,94 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"gorm.io/gorm"
+)
+
+// StoreShareLink inserts a new share link.
+func (d *Database) StoreShareLink(ctx context.Context, link *types.ShareLink) error {
+	sl := models.ShareLink{}
+	sl.Fill(link)
+	if err := d.ShareLinkRepo.Create(ctx, &sl); err != nil {
+		return err
+	}
+	link.ID = sl.GetID()
+	link.TenantID = sl.TenantID
+	link.PartitionID = sl.PartitionID
+	link.CreatedAt = sl.CreatedAt
+	link.ModifiedAt = sl.ModifiedAt
+	return nil
+}
+
+// GetShareLink returns a share link or nil if it does not exist.
+func (d *Database) GetShareLink(ctx context.Context, linkID string) (*types.ShareLink, error) {
+	sl, err := d.ShareLinkRepo.GetByID(ctx, linkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return sl.ToApi(), nil
+}
+
+// GetShareLinkByTokenHash returns the link holding a token or nil if there is none.
+func (d *Database) GetShareLinkByTokenHash(ctx context.Context, tokenHash string) (*types.ShareLink, error) {
+	sl, err := d.ShareLinkRepo.GetByTokenHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return sl.ToApi(), nil
+}
+
+// ListShareLinks returns the share links of a media item, newest first.
+func (d *Database) ListShareLinks(ctx context.Context, mediaID types.MediaID, includeRevoked bool, limit, offset int) ([]*types.ShareLink, int, error) {
+	links, total, err := d.ShareLinkRepo.ListByMedia(ctx, string(mediaID), includeRevoked, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	result := make([]*types.ShareLink, 0, len(links))
+	for _, sl := range links {
+		result = append(result, sl.ToApi())
+	}
+	return result, total, nil
+}
+
+// RevokeShareLink stops a share link from resolving.
+func (d *Database) RevokeShareLink(ctx context.Context, linkID string, at time.Time) error {
+	_, err := d.ShareLinkRepo.BulkUpdate(ctx, []string{linkID}, map[string]any{
+		"revoked_at": at,
+	})
+	return err
+}
+
+// RecordShareLinkDownload counts a download, reporting false once the link
+// is revoked or has no downloads left.
+func (d *Database) RecordShareLinkDownload(ctx context.Context, linkID string, at time.Time) (bool, error) {
+	return d.ShareLinkRepo.RecordDownload(ctx, linkID, at)
+}
+
+// RecordShareLinkPasswordFailure counts a wrong password against a share
+// link, locking it until lockUntil once maxAttempts are reached.
+func (d *Database) RecordShareLinkPasswordFailure(ctx context.Context, linkID string, maxAttempts int, lockUntil time.Time) error {
+	return d.ShareLinkRepo.RecordPasswordFailure(ctx, linkID, maxAttempts, lockUntil)
+}
+
+// ResetShareLinkPasswordFailures clears the wrong passwords counted against
+// a share link.
+func (d *Database) ResetShareLinkPasswordFailures(ctx context.Context, linkID string) error {
+	return d.ShareLinkRepo.ResetPasswordFailures(ctx, linkID)
+}
+
+// TouchShareLink records when a share link was last used.
+func (d *Database) TouchShareLink(ctx context.Context, linkID string, at time.Time) error {
+	return d.ShareLinkRepo.Touch(ctx, linkID, at)
+}
@@ -0,0 +1,143 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"gorm.io/gorm"
+)
+
+// StoreThumbnailJob inserts a new thumbnail job.
+func (d *Database) StoreThumbnailJob(ctx context.Context, job *types.ThumbnailJob) error {
+	tj := models.ThumbnailJob{}
+	tj.Fill(job)
+	if err := d.ThumbnailJobRepo.Create(ctx, &tj); err != nil {
+		return err
+	}
+	job.ID = tj.GetID()
+	job.TenantID = tj.TenantID
+	job.PartitionID = tj.PartitionID
+	job.CreatedAt = tj.CreatedAt
+	job.ModifiedAt = tj.ModifiedAt
+	return nil
+}
+
+// GetThumbnailJob returns the job of a media item or nil if none was recorded.
+func (d *Database) GetThumbnailJob(ctx context.Context, mediaID types.MediaID) (*types.ThumbnailJob, error) {
+	tj, err := d.ThumbnailJobRepo.GetByMediaID(ctx, string(mediaID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return tj.ToApi(), nil
+}
+
+// UpdateThumbnailJob records the outcome of a job attempt.
+func (d *Database) UpdateThumbnailJob(ctx context.Context, job *types.ThumbnailJob) error {
+	_, err := d.ThumbnailJobRepo.BulkUpdate(ctx, []string{job.ID}, map[string]any{
+		"state":           job.State,
+		"attempts":        job.Attempts,
+		"last_error":      job.LastError,
+		"next_attempt_at": job.NextAttemptAt,
+		"completed_at":    job.CompletedAt,
+	})
+	return err
+}
+
+// ClaimDueThumbnailJobs returns pending jobs whose next attempt is at or
+// before the given time, leasing them until leaseUntil so that no other
+// replica attempts them meanwhile.
+func (d *Database) ClaimDueThumbnailJobs(ctx context.Context, before, leaseUntil time.Time, limit int) ([]*types.ThumbnailJob, error) {
+	jobs, err := d.ThumbnailJobRepo.ClaimDue(ctx, before, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*types.ThumbnailJob, 0, len(jobs))
+	for _, tj := range jobs {
+		result = append(result, tj.ToApi())
+	}
+	return result, nil
+}
+
+// StoreThumbnailRegeneration inserts a new regeneration run.
+func (d *Database) StoreThumbnailRegeneration(ctx context.Context, run *types.ThumbnailRegeneration) error {
+	tr := models.ThumbnailRegeneration{}
+	tr.Fill(run)
+	if err := d.ThumbnailRegenerationRepo.Create(ctx, &tr); err != nil {
+		return err
+	}
+	run.ID = tr.GetID()
+	run.CreatedAt = tr.CreatedAt
+	run.ModifiedAt = tr.ModifiedAt
+	return nil
+}
+
+// GetThumbnailRegeneration returns a regeneration run or nil if it does not exist.
+func (d *Database) GetThumbnailRegeneration(ctx context.Context, runID string) (*types.ThumbnailRegeneration, error) {
+	tr, err := d.ThumbnailRegenerationRepo.GetByID(ctx, runID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return tr.ToApi(), nil
+}
+
+// UpdateThumbnailRegeneration records the progress of a regeneration run.
+func (d *Database) UpdateThumbnailRegeneration(ctx context.Context, run *types.ThumbnailRegeneration) error {
+	_, err := d.ThumbnailRegenerationRepo.BulkUpdate(ctx, []string{run.ID}, map[string]any{
+		"state":        run.State,
+		"checkpoint":   run.Checkpoint,
+		"scanned":      run.Scanned,
+		"enqueued":     run.Enqueued,
+		"removed":      run.Removed,
+		"last_error":   run.LastError,
+		"completed_at": run.CompletedAt,
+		"lease_until":  run.LeaseUntil,
+	})
+	return err
+}
+
+// ClaimThumbnailRegeneration leases a running regeneration run until
+// leaseUntil, reporting false when another worker holds it.
+func (d *Database) ClaimThumbnailRegeneration(ctx context.Context, runID string, leaseUntil time.Time) (bool, error) {
+	return d.ThumbnailRegenerationRepo.Claim(ctx, runID, time.Now().UTC(), leaseUntil)
+}
+
+// ListStalledThumbnailRegenerations returns running regeneration runs whose
+// lease lapsed, or that were never leased and left idle, before idleBefore.
+func (d *Database) ListStalledThumbnailRegenerations(ctx context.Context, idleBefore time.Time, limit int) ([]*types.ThumbnailRegeneration, error) {
+	runs, err := d.ThumbnailRegenerationRepo.ListStalled(ctx, idleBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*types.ThumbnailRegeneration, 0, len(runs))
+	for _, tr := range runs {
+		result = append(result, tr.ToApi())
+	}
+	return result, nil
+}
+
+// ListRegenerationCandidates returns original images matching filter with IDs after afterID.
+func (d *Database) ListRegenerationCandidates(ctx context.Context, filter *types.ThumbnailRegenerationFilter, afterID string, limit int) ([]*types.MediaMetadata, error) {
+	media, err := d.MediaRepository.ListOriginalImages(ctx, filter, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*types.MediaMetadata, 0, len(media))
+	for _, mm := range media {
+		result = append(result, mm.ToApi())
+	}
+	return result, nil
+}
+
+// CountPendingThumbnailJobs returns the pending thumbnail jobs of each tenant.
+func (d *Database) CountPendingThumbnailJobs(ctx context.Context) ([]types.QueueDepth, error) {
+	return d.ThumbnailJobRepo.CountPendingByTenant(ctx)
+}
@@ -0,0 +1,223 @@
+package connection
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"gorm.io/gorm"
+)
+
+// StoreUserDataErasure inserts a new user data erasure job.
+func (d *Database) StoreUserDataErasure(ctx context.Context, job *types.UserDataErasure) error {
+	ue := models.UserDataErasure{}
+	ue.Fill(job)
+	if err := d.UserDataErasureRepo.Create(ctx, &ue); err != nil {
+		return err
+	}
+	job.ID = ue.GetID()
+	job.CreatedAt = ue.CreatedAt
+	job.ModifiedAt = ue.ModifiedAt
+	return nil
+}
+
+// GetUserDataErasure returns a user data erasure job or nil if it does not exist.
+func (d *Database) GetUserDataErasure(ctx context.Context, jobID string) (*types.UserDataErasure, error) {
+	ue, err := d.UserDataErasureRepo.GetByID(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ue.ToApi(), nil
+}
+
+// GetRunningUserDataErasure returns the running erasure job of profileID or
+// nil when none is running.
+func (d *Database) GetRunningUserDataErasure(ctx context.Context, profileID types.OwnerID) (*types.UserDataErasure, error) {
+	ue, err := d.UserDataErasureRepo.GetRunningByProfile(ctx, string(profileID))
+	if err != nil || ue == nil {
+		return nil, err
+	}
+	return ue.ToApi(), nil
+}
+
+// UpdateUserDataErasure records the progress or outcome of a user data
+// erasure job.
+func (d *Database) UpdateUserDataErasure(ctx context.Context, job *types.UserDataErasure) error {
+	ue := models.UserDataErasure{}
+	ue.Fill(job)
+	_, err := d.UserDataErasureRepo.BulkUpdate(ctx, []string{job.ID}, map[string]any{
+		"state":        ue.State,
+		"report":       ue.Report,
+		"signature":    ue.Signature,
+		"progress":     ue.Progress,
+		"last_error":   ue.LastError,
+		"completed_at": ue.CompletedAt,
+	})
+	return err
+}
+
+// ListOwnerMediaWithDeleted lists the original media of an owner, deleted
+// or not, with IDs after afterID, in ID order.
+func (d *Database) ListOwnerMediaWithDeleted(ctx context.Context, ownerID types.OwnerID, afterID string, limit int) ([]*types.MediaMetadata, error) {
+	media, err := d.MediaRepository.ListByOwnerWithDeleted(ctx, ownerID, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*types.MediaMetadata, 0, len(media))
+	for _, mm := range media {
+		result = append(result, mm.ToApi())
+	}
+	return result, nil
+}
+
+// EraseMedia permanently deletes media with their thumbnails, derivatives,
+// versions, retention, share links and thumbnail jobs in a single
+// transaction, so an interrupted erasure leaves each media whole or gone.
+func (d *Database) EraseMedia(ctx context.Context, mediaIDs []types.MediaID) (*types.ErasedMedia, error) {
+	ids := make([]string, 0, len(mediaIDs))
+	for _, id := range mediaIDs {
+		ids = append(ids, string(id))
+	}
+
+	result := &types.ErasedMedia{}
+	if len(ids) == 0 {
+		return result, nil
+	}
+	seen := map[types.ErasedBlob]bool{}
+	addBlob := func(hash string, isPublic bool) {
+		blob := types.ErasedBlob{Hash: types.Base64Hash(hash), IsPublic: isPublic}
+		if hash != "" && !seen[blob] {
+			seen[blob] = true
+			result.Blobs = append(result.Blobs, blob)
+		}
+	}
+
+	err := d.MediaRepository.Pool().DB(ctx, false).Transaction(func(tx *gorm.DB) error {
+		var media []*models.MediaMetadata
+		err := tx.Unscoped().Where("id IN ? OR parent_id IN ?", ids, ids).Find(&media).Error
+		if err != nil {
+			return err
+		}
+		public := make(map[string]bool, len(media))
+		erasedIDs := make([]string, 0, len(media))
+		for _, m := range media {
+			public[m.GetID()] = m.Public
+			erasedIDs = append(erasedIDs, m.GetID())
+			if m.ParentID != "" {
+				result.Derivatives++
+			}
+			addBlob(m.Hash, m.Public)
+		}
+
+		var versions []*models.FileVersion
+		if err = tx.Unscoped().Where("media_id IN ?", ids).Find(&versions).Error; err != nil {
+			return err
+		}
+		for _, version := range versions {
+			// Versions are stored in the bucket of their media.
+			result.Versions++
+			addBlob(version.ContentHash, public[version.MediaID])
+		}
+
+		for _, erase := range []struct {
+			model any
+			ids   []string
+		}{
+			{&models.FileVersion{}, ids},
+			{&models.FileRetention{}, ids},
+			{&models.ShareLink{}, ids},
+			{&models.ThumbnailJob{}, erasedIDs},
+		} {
+			if len(erase.ids) == 0 {
+				continue
+			}
+			if err = tx.Unscoped().Where("media_id IN ?", erase.ids).Delete(erase.model).Error; err != nil {
+				return err
+			}
+		}
+		if len(erasedIDs) == 0 {
+			return nil
+		}
+		return tx.Unscoped().Where("id IN ?", erasedIDs).Delete(&models.MediaMetadata{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CountBlobReferences counts the media and versions, soft deleted or not,
+// still stored under a content hash.
+func (d *Database) CountBlobReferences(ctx context.Context, hash types.Base64Hash) (int64, error) {
+	media, err := d.MediaRepository.CountByHash(ctx, hash)
+	if err != nil {
+		return 0, err
+	}
+	versions, err := d.FileVersionRepo.CountByContent(ctx, string(hash))
+	if err != nil {
+		return 0, err
+	}
+	return media + versions, nil
+}
+
+// EraseOwnerUploads permanently deletes the multipart uploads of a profile
+// and returns the storage paths of their parts.
+func (d *Database) EraseOwnerUploads(ctx context.Context, ownerID types.OwnerID) (int64, []string, error) {
+	uploads, err := d.MultipartUploadRepo.EraseByOwner(ctx, string(ownerID))
+	if err != nil {
+		return 0, nil, err
+	}
+	uploadIDs := make([]string, 0, len(uploads))
+	for _, upload := range uploads {
+		uploadIDs = append(uploadIDs, upload.GetID())
+	}
+	parts, err := d.MultipartUploadPartRepo.EraseByUploadIDs(ctx, uploadIDs)
+	if err != nil {
+		return 0, nil, err
+	}
+	paths := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part.StoragePath != "" {
+			paths = append(paths, part.StoragePath)
+		}
+	}
+	return int64(len(uploads)), paths, nil
+}
+
+// EraseOwnerRecords deletes the jobs, share links and unused retention
+// policies of a profile and puts pseudonym in its place on the versions,
+// grants and webhook subscriptions it created on others' files.
+func (d *Database) EraseOwnerRecords(ctx context.Context, ownerID types.OwnerID, pseudonym string) (int64, int64, error) {
+	owner := string(ownerID)
+	var deleted, anonymized int64
+
+	for _, erase := range []func(context.Context, string) (int64, error){
+		d.ShareLinkRepo.EraseByOwner,
+		d.ArchiveExtractionRepo.EraseByOwner,
+		d.UserDataExportRepo.EraseByProfile,
+		d.RetentionPolicyRepo.EraseUnusedByOwner,
+	} {
+		n, err := erase(ctx, owner)
+		if err != nil {
+			return deleted, anonymized, err
+		}
+		deleted += n
+	}
+
+	for _, reassign := range []func(context.Context, string, string) (int64, error){
+		d.FileVersionRepo.ReassignCreator,
+		d.AccessGrantRepo.ReassignGrantor,
+		d.WebhookSubscriptionRepo.ReassignCreator,
+	} {
+		n, err := reassign(ctx, owner, pseudonym)
+		if err != nil {
+			return deleted, anonymized, err
+		}
+		anonymized += n
+	}
+	return deleted, anonymized, nil
+}
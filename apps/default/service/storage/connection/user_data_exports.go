@@ -0,0 +1,76 @@
+package connection
+
+import (
+	"context"
+	"errors"
+
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"gorm.io/gorm"
+)
+
+// StoreUserDataExport inserts a new user data export job.
+func (d *Database) StoreUserDataExport(ctx context.Context, job *types.UserDataExport) error {
+	ue := models.UserDataExport{}
+	ue.Fill(job)
+	if err := d.UserDataExportRepo.Create(ctx, &ue); err != nil {
+		return err
+	}
+	job.ID = ue.GetID()
+	job.CreatedAt = ue.CreatedAt
+	job.ModifiedAt = ue.ModifiedAt
+	return nil
+}
+
+// GetUserDataExport returns a user data export job or nil if it does not exist.
+func (d *Database) GetUserDataExport(ctx context.Context, jobID string) (*types.UserDataExport, error) {
+	ue, err := d.UserDataExportRepo.GetByID(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return ue.ToApi(), nil
+}
+
+// GetRunningUserDataExport returns the running export job of profileID or
+// nil when none is running.
+func (d *Database) GetRunningUserDataExport(ctx context.Context, profileID types.OwnerID) (*types.UserDataExport, error) {
+	ue, err := d.UserDataExportRepo.GetRunningByProfile(ctx, string(profileID))
+	if err != nil || ue == nil {
+		return nil, err
+	}
+	return ue.ToApi(), nil
+}
+
+// ListUserDataExportResults returns the archives earlier exports of
+// profileID produced.
+func (d *Database) ListUserDataExportResults(ctx context.Context, profileID types.OwnerID) ([]types.MediaID, error) {
+	ids, err := d.UserDataExportRepo.ListResultMediaIDs(ctx, string(profileID))
+	if err != nil {
+		return nil, err
+	}
+	results := make([]types.MediaID, 0, len(ids))
+	for _, id := range ids {
+		results = append(results, types.MediaID(id))
+	}
+	return results, nil
+}
+
+// UpdateUserDataExport records the progress of a user data export job.
+func (d *Database) UpdateUserDataExport(ctx context.Context, job *types.UserDataExport) error {
+	ue := models.UserDataExport{}
+	ue.Fill(job)
+	_, err := d.UserDataExportRepo.BulkUpdate(ctx, []string{job.ID}, map[string]any{
+		"state":           ue.State,
+		"total_files":     ue.TotalFiles,
+		"exported_files":  ue.ExportedFiles,
+		"failed_files":    ue.FailedFiles,
+		"exported_bytes":  ue.ExportedBytes,
+		"result_media_id": ue.ResultMediaID,
+		"last_error":      ue.LastError,
+		"completed_at":    ue.CompletedAt,
+	})
+	return err
+}
@@ -0,0 +1,126 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"gorm.io/gorm"
+)
+
+// StoreWebhookSubscription inserts a new webhook subscription.
+func (d *Database) StoreWebhookSubscription(ctx context.Context, subscription *types.WebhookSubscription) error {
+	sub := models.WebhookSubscription{}
+	sub.Fill(subscription)
+	if err := d.WebhookSubscriptionRepo.Create(ctx, &sub); err != nil {
+		return err
+	}
+	subscription.ID = sub.GetID()
+	subscription.CreatedAt = sub.CreatedAt
+	return nil
+}
+
+// GetWebhookSubscription returns a subscription or nil if it does not exist.
+func (d *Database) GetWebhookSubscription(ctx context.Context, subscriptionID string) (*types.WebhookSubscription, error) {
+	sub, err := d.WebhookSubscriptionRepo.GetByID(ctx, subscriptionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return sub.ToApi(), nil
+}
+
+// ListWebhookSubscriptions returns a page of subscriptions and the total count.
+func (d *Database) ListWebhookSubscriptions(ctx context.Context, limit, offset int) ([]*types.WebhookSubscription, int, error) {
+	subs, total, err := d.WebhookSubscriptionRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	result := make([]*types.WebhookSubscription, 0, len(subs))
+	for _, sub := range subs {
+		result = append(result, sub.ToApi())
+	}
+	return result, total, nil
+}
+
+// ListActiveWebhookSubscriptions returns all active subscriptions of the caller's tenant.
+func (d *Database) ListActiveWebhookSubscriptions(ctx context.Context) ([]*types.WebhookSubscription, error) {
+	subs, err := d.WebhookSubscriptionRepo.ListActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*types.WebhookSubscription, 0, len(subs))
+	for _, sub := range subs {
+		result = append(result, sub.ToApi())
+	}
+	return result, nil
+}
+
+// DeleteWebhookSubscription removes a subscription.
+func (d *Database) DeleteWebhookSubscription(ctx context.Context, subscriptionID string) error {
+	return d.WebhookSubscriptionRepo.Delete(ctx, subscriptionID)
+}
+
+// StoreWebhookDelivery inserts a new delivery record. It reports false and
+// stores nothing when the subscription already has a delivery of the event,
+// as when the event is delivered to the service again.
+func (d *Database) StoreWebhookDelivery(ctx context.Context, delivery *types.WebhookDelivery) (bool, error) {
+	del := models.WebhookDelivery{}
+	del.Fill(delivery)
+	stored, err := d.WebhookDeliveryRepo.CreateOnce(ctx, &del)
+	if err != nil || !stored {
+		return false, err
+	}
+	delivery.ID = del.GetID()
+	delivery.CreatedAt = del.CreatedAt
+	return true, nil
+}
+
+// UpdateWebhookDelivery records the outcome of a delivery attempt.
+func (d *Database) UpdateWebhookDelivery(ctx context.Context, delivery *types.WebhookDelivery) error {
+	_, err := d.WebhookDeliveryRepo.BulkUpdate(ctx, []string{delivery.ID}, map[string]any{
+		"state":            delivery.State,
+		"attempts":         delivery.Attempts,
+		"last_status_code": delivery.LastStatusCode,
+		"last_error":       delivery.LastError,
+		"next_attempt_at":  delivery.NextAttemptAt,
+		"delivered_at":     delivery.DeliveredAt,
+	})
+	return err
+}
+
+// ListWebhookDeliveries returns a page of the delivery history of a subscription.
+func (d *Database) ListWebhookDeliveries(ctx context.Context, subscriptionID, state string, limit, offset int) ([]*types.WebhookDelivery, int, error) {
+	deliveries, total, err := d.WebhookDeliveryRepo.ListBySubscription(ctx, subscriptionID, state, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	result := make([]*types.WebhookDelivery, 0, len(deliveries))
+	for _, del := range deliveries {
+		result = append(result, del.ToApi())
+	}
+	return result, total, nil
+}
+
+// ClaimDueWebhookDeliveries leases the pending deliveries whose next attempt
+// is at or before the given time until leaseUntil.
+func (d *Database) ClaimDueWebhookDeliveries(ctx context.Context, before, leaseUntil time.Time, limit int) ([]*types.WebhookDelivery, error) {
+	deliveries, err := d.WebhookDeliveryRepo.ClaimDue(ctx, before, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*types.WebhookDelivery, 0, len(deliveries))
+	for _, del := range deliveries {
+		result = append(result, del.ToApi())
+	}
+	return result, nil
+}
+
+// CountPendingWebhookDeliveries returns the pending webhook deliveries of each tenant.
+func (d *Database) CountPendingWebhookDeliveries(ctx context.Context) ([]types.QueueDepth, error) {
+	return d.WebhookDeliveryRepo.CountPendingByTenant(ctx)
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/antinvestor/service-files/apps/default/service/types"
 )
@@ -19,9 +20,18 @@ const (
 	defaultChunkSize  = 64 * 1024
 )
 
-// EncryptStream encrypts data from src to dst using chunked AES-GCM.
-// It returns encryption metadata required for decryption.
-func EncryptStream(ctx context.Context, src io.Reader, dst io.Writer, masterKey []byte) (*types.EncryptionInfo, error) {
+// EncryptStream encrypts data from src to dst using chunked AES-GCM,
+// reporting to recorder when it is not nil. It returns encryption metadata
+// required for decryption.
+func EncryptStream(ctx context.Context, src io.Reader, dst io.Writer, masterKey []byte, recorder EncryptionRecorder) (*types.EncryptionInfo, error) {
+	start := time.Now()
+	var plaintextBytes int64
+	info, err := encryptStream(src, dst, masterKey, &plaintextBytes)
+	recordEncryption(ctx, recorder, "encrypt", plaintextBytes, time.Since(start), err)
+	return info, err
+}
+
+func encryptStream(src io.Reader, dst io.Writer, masterKey []byte, plaintextBytes *int64) (*types.EncryptionInfo, error) {
 	if len(masterKey) != 32 {
 		return nil, fmt.Errorf("invalid master key length: %d", len(masterKey))
 	}
@@ -59,6 +69,7 @@ func EncryptStream(ctx context.Context, src io.Reader, dst io.Writer, masterKey
 	for {
 		n, readErr := src.Read(buf)
 		if n > 0 {
+			*plaintextBytes += int64(n)
 			nonce := makeNonce(noncePrefix, counter)
 			counter++
 			ciphertext := dataGCM.Seal(nil, nonce, buf[:n], nil)
@@ -84,8 +95,9 @@ func EncryptStream(ctx context.Context, src io.Reader, dst io.Writer, masterKey
 	}, nil
 }
 
-// NewDecryptingReader returns a reader that decrypts content encrypted by EncryptStream.
-func NewDecryptingReader(src io.Reader, masterKey []byte, info *types.EncryptionInfo) (io.Reader, error) {
+// NewDecryptingReader returns a reader that decrypts content encrypted by
+// EncryptStream, reporting the stream to recorder when it is not nil.
+func NewDecryptingReader(src io.Reader, masterKey []byte, info *types.EncryptionInfo, recorder EncryptionRecorder) (io.Reader, error) {
 	if info == nil {
 		return src, nil
 	}
@@ -127,6 +139,8 @@ func NewDecryptingReader(src io.Reader, masterKey []byte, info *types.Encryption
 		src:         src,
 		gcm:         dataGCM,
 		noncePrefix: noncePrefix,
+		recorder:    recorder,
+		start:       time.Now(),
 	}, nil
 }
 
@@ -137,14 +151,23 @@ type decryptingReader struct {
 	counter     uint64
 	buf         []byte
 	closed      bool
+
+	// The stream is reported once, when it ends or fails. Readers carry no
+	// context, so decryption is reported without tenant attribution.
+	recorder  EncryptionRecorder
+	start     time.Time
+	plaintext int64
+	reported  bool
 }
 
 func (dr *decryptingReader) Read(p []byte) (int, error) {
 	for len(dr.buf) == 0 {
 		chunk, err := dr.readNextChunk()
 		if err != nil {
+			dr.report(err)
 			return 0, err
 		}
+		dr.plaintext += int64(len(chunk))
 		dr.buf = chunk
 	}
 
@@ -153,6 +176,17 @@ func (dr *decryptingReader) Read(p []byte) (int, error) {
 	return n, nil
 }
 
+func (dr *decryptingReader) report(err error) {
+	if dr.reported {
+		return
+	}
+	dr.reported = true
+	if err == io.EOF {
+		err = nil
+	}
+	recordEncryption(context.Background(), dr.recorder, "decrypt", dr.plaintext, time.Since(dr.start), err)
+}
+
 func (dr *decryptingReader) readNextChunk() ([]byte, error) {
 	if dr.closed {
 		return nil, io.EOF
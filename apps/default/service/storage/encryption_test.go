@@ -36,13 +36,13 @@ func (s *EncryptionTestSuite) TestEncryptDecryptStream() {
 	for _, tc := range cases {
 		s.T().Run(tc.name, func(t *testing.T) {
 			var encrypted bytes.Buffer
-			info, err := storage.EncryptStream(t.Context(), bytes.NewReader(tc.payload), &encrypted, tc.masterKey)
+			info, err := storage.EncryptStream(t.Context(), bytes.NewReader(tc.payload), &encrypted, tc.masterKey, nil)
 			require.NoError(t, err)
 			require.NotNil(t, info)
 			require.Equal(t, 1, info.Version)
 			require.NotEmpty(t, info.WrappedKey)
 
-			reader, err := storage.NewDecryptingReader(bytes.NewReader(encrypted.Bytes()), tc.masterKey, info)
+			reader, err := storage.NewDecryptingReader(bytes.NewReader(encrypted.Bytes()), tc.masterKey, info, nil)
 			require.NoError(t, err)
 
 			decrypted, err := io.ReadAll(reader)
@@ -74,7 +74,7 @@ func (s *EncryptionTestSuite) TestDecryptReader_InvalidKey() {
 
 	for _, tc := range cases {
 		s.T().Run(tc.name, func(t *testing.T) {
-			_, err := storage.NewDecryptingReader(bytes.NewReader(nil), tc.masterKey, tc.info)
+			_, err := storage.NewDecryptingReader(bytes.NewReader(nil), tc.masterKey, tc.info, nil)
 			require.Error(t, err)
 		})
 	}
@@ -90,7 +90,7 @@ func (s *EncryptionTestSuite) TestDecryptReaderAndHelpersEdgeCases() {
 			name: "nil_info_returns_original_reader",
 			run: func(t *testing.T) error {
 				src := bytes.NewReader([]byte("plain"))
-				reader, err := storage.NewDecryptingReader(src, []byte("0123456789abcdef0123456789abcdef"), nil)
+				reader, err := storage.NewDecryptingReader(src, []byte("0123456789abcdef0123456789abcdef"), nil, nil)
 				require.NoError(t, err)
 				data, err := io.ReadAll(reader)
 				require.NoError(t, err)
@@ -112,6 +112,7 @@ func (s *EncryptionTestSuite) TestDecryptReaderAndHelpersEdgeCases() {
 						WrappedKeyNonce: "AA",
 						NoncePrefix:     "AA",
 					},
+					nil,
 				)
 				return err
 			},
@@ -120,7 +121,7 @@ func (s *EncryptionTestSuite) TestDecryptReaderAndHelpersEdgeCases() {
 		{
 			name: "encrypt_stream_rejects_bad_key_length",
 			run: func(t *testing.T) error {
-				_, err := storage.EncryptStream(t.Context(), bytes.NewReader([]byte("abc")), &bytes.Buffer{}, []byte("short"))
+				_, err := storage.EncryptStream(t.Context(), bytes.NewReader([]byte("abc")), &bytes.Buffer{}, []byte("short"), nil)
 				return err
 			},
 			expectErr: true,
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/util"
+	"gocloud.dev/blob"
+)
+
+// OperationRecorder receives the outcome of storage provider calls.
+type OperationRecorder interface {
+	RecordProviderOperation(ctx context.Context, provider, operation string, duration time.Duration, err error)
+}
+
+// EncryptionRecorder receives the outcome of encrypting and decrypting content.
+type EncryptionRecorder interface {
+	RecordEncryption(ctx context.Context, operation string, bytes int64, duration time.Duration, err error)
+}
+
+type instrumentedProvider struct {
+	Provider
+	recorder OperationRecorder
+}
+
+// NewInstrumentedProvider wraps provider so each upload, download, delete
+// and bucket open is reported to recorder.
+func NewInstrumentedProvider(provider Provider, recorder OperationRecorder) Provider {
+	if recorder == nil {
+		return provider
+	}
+	return &instrumentedProvider{Provider: provider, recorder: recorder}
+}
+
+func (p *instrumentedProvider) Init(ctx context.Context, bucketName string) (*blob.Bucket, error) {
+	start := time.Now()
+	bucket, err := p.Provider.Init(ctx, bucketName)
+	p.recorder.RecordProviderOperation(ctx, p.Name(), "open_bucket", time.Since(start), err)
+	return bucket, err
+}
+
+func (p *instrumentedProvider) UploadFile(ctx context.Context, bucket string, sourcePath types.Path, destinationPath types.Path) (bool, error) {
+	start := time.Now()
+	duplicate, err := p.Provider.UploadFile(ctx, bucket, sourcePath, destinationPath)
+	p.recorder.RecordProviderOperation(ctx, p.Name(), "upload", time.Since(start), err)
+	return duplicate, err
+}
+
+func (p *instrumentedProvider) DownloadFile(ctx context.Context, bucket string, sourcePath types.Path) (io.Reader, func(), error) {
+	start := time.Now()
+	reader, cleanup, err := p.Provider.DownloadFile(ctx, bucket, sourcePath)
+	p.recorder.RecordProviderOperation(ctx, p.Name(), "download", time.Since(start), err)
+	return reader, cleanup, err
+}
+
+func (p *instrumentedProvider) DeleteFile(ctx context.Context, bucket string, path types.Path) error {
+	start := time.Now()
+	err := p.Provider.DeleteFile(ctx, bucket, path)
+	p.recorder.RecordProviderOperation(ctx, p.Name(), "delete", time.Since(start), err)
+	return err
+}
+
+// CheckReachable opens both buckets of provider and reports an error when
+// either cannot be reached.
+func CheckReachable(ctx context.Context, provider Provider) error {
+	for _, bucketName := range []string{provider.PrivateBucket(), provider.PublicBucket()} {
+		bucket, err := provider.Init(ctx, bucketName)
+		if err != nil {
+			return err
+		}
+		accessible, err := bucket.IsAccessible(ctx)
+		util.CloseAndLogOnError(ctx, bucket)
+		if err != nil {
+			return err
+		}
+		if !accessible {
+			return errors.New("bucket " + bucketName + " is not accessible")
+		}
+	}
+	return nil
+}
+
+// EncryptionRecorderOf returns the encryption recorder provider reports
+// to, or nil when provider is not instrumented with one.
+func EncryptionRecorderOf(provider Provider) EncryptionRecorder {
+	instrumented, ok := provider.(*instrumentedProvider)
+	if !ok {
+		return nil
+	}
+	recorder, _ := instrumented.recorder.(EncryptionRecorder)
+	return recorder
+}
+
+func recordEncryption(ctx context.Context, recorder EncryptionRecorder, operation string, bytes int64, duration time.Duration, err error) {
+	if recorder != nil {
+		recorder.RecordEncryption(ctx, operation, bytes, duration, err)
+	}
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/storage/provider/local"
+	"github.com/antinvestor/service-files/apps/default/service/tests"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type recordedOperation struct {
+	provider  string
+	operation string
+	bytes     int64
+	failed    bool
+}
+
+type operationRecorder struct {
+	mu         sync.Mutex
+	operations []recordedOperation
+}
+
+func (r *operationRecorder) RecordProviderOperation(_ context.Context, provider, operation string, _ time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.operations = append(r.operations, recordedOperation{provider: provider, operation: operation, failed: err != nil})
+}
+
+func (r *operationRecorder) RecordEncryption(_ context.Context, operation string, bytes int64, _ time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.operations = append(r.operations, recordedOperation{operation: operation, bytes: bytes, failed: err != nil})
+}
+
+type InstrumentedProviderTestSuite struct {
+	tests.BaseTestSuite
+}
+
+func TestInstrumentedProviderTestSuite(t *testing.T) {
+	suite.Run(t, new(InstrumentedProviderTestSuite))
+}
+
+func (suite *InstrumentedProviderTestSuite) TestRecordsProviderOperations() {
+	t := suite.T()
+	ctx := t.Context()
+	baseDir := t.TempDir()
+
+	provider := local.NewProvider("LOCAL", filepath.Join(baseDir, "private"), filepath.Join(baseDir, "public"))
+	require.NoError(t, provider.Setup(ctx))
+
+	recorder := &operationRecorder{}
+	instrumented := NewInstrumentedProvider(provider, recorder)
+
+	source := filepath.Join(baseDir, "source.txt")
+	require.NoError(t, os.WriteFile(source, []byte("content"), 0o600))
+
+	_, err := instrumented.UploadFile(ctx, provider.PrivateBucket(), types.Path(source), "stored/source.txt")
+	require.NoError(t, err)
+	_, cleanup, err := instrumented.DownloadFile(ctx, provider.PrivateBucket(), "stored/source.txt")
+	require.NoError(t, err)
+	cleanup()
+	_, _, err = instrumented.DownloadFile(ctx, provider.PrivateBucket(), "stored/missing.txt")
+	require.Error(t, err)
+
+	require.Equal(t, []recordedOperation{
+		{provider: "LOCAL", operation: "upload"},
+		{provider: "LOCAL", operation: "download"},
+		{provider: "LOCAL", operation: "download", failed: true},
+	}, recorder.operations)
+
+	require.NoError(t, CheckReachable(ctx, instrumented))
+	require.NoError(t, os.RemoveAll(provider.PublicBucket()))
+	require.Error(t, CheckReachable(ctx, instrumented))
+}
+
+func (suite *InstrumentedProviderTestSuite) TestRecordsEncryption() {
+	t := suite.T()
+	baseDir := t.TempDir()
+	provider := local.NewProvider("LOCAL", filepath.Join(baseDir, "private"), filepath.Join(baseDir, "public"))
+	require.Nil(t, EncryptionRecorderOf(provider))
+
+	recorder := &operationRecorder{}
+	instrumented := NewInstrumentedProvider(provider, recorder)
+	require.Equal(t, recorder, EncryptionRecorderOf(instrumented))
+
+	masterKey := []byte("0123456789abcdef0123456789abcdef")
+	payload := bytes.Repeat([]byte("metrics-"), 10000)
+
+	var encrypted bytes.Buffer
+	info, err := EncryptStream(t.Context(), bytes.NewReader(payload), &encrypted, masterKey, EncryptionRecorderOf(instrumented))
+	require.NoError(t, err)
+
+	reader, err := NewDecryptingReader(bytes.NewReader(encrypted.Bytes()), masterKey, info, EncryptionRecorderOf(instrumented))
+	require.NoError(t, err)
+	_, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	// Reading past the end reports the stream only once.
+	_, _ = reader.Read(make([]byte, 1))
+
+	require.Equal(t, []recordedOperation{
+		{operation: "encrypt", bytes: int64(len(payload))},
+		{operation: "decrypt", bytes: int64(len(payload))},
+	}, recorder.operations)
+}
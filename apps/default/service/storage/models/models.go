@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/antinvestor/service-files/apps/default/service/types"
@@ -10,6 +11,16 @@ import (
 )
 
 const (
+	transformKey = "t"
+	exifKey      = "exif"
+	mediaKey     = "media"
+
+	blurHashKey      = "blurhash"
+	dominantColorKey = "dominant_color"
+	declaredTypeKey  = "declared_type"
+	scanStatusKey    = "scan_status"
+	scanSignatureKey = "scan_signature"
+
 	encVersionKey      = "enc_v"
 	encAlgKey          = "enc_alg"
 	encChunkSizeKey    = "enc_chunk"
@@ -24,6 +35,7 @@ type MediaMetadata struct {
 
 	OwnerID  string `gorm:"type:TEXT"`
 	ParentID string `gorm:"type:TEXT"`
+	Folder   string `gorm:"type:TEXT;index"`
 
 	Name string `gorm:"type:TEXT"`
 	Ext  string `gorm:"type:TEXT"`
@@ -53,6 +65,7 @@ func (mm *MediaMetadata) ToApi() *types.MediaMetadata {
 		OwnerID:           types.OwnerID(mm.OwnerID),
 		ServerName:        mm.ServerName,
 		IsPublic:          mm.Public,
+		Folder:            mm.Folder,
 	}
 
 	if mm.ParentID != "" {
@@ -77,10 +90,22 @@ func (mm *MediaMetadata) ToApi() *types.MediaMetadata {
 			Height:       h,
 			ResizeMethod: mm.Properties.GetString("m"),
 		}
+		tmm.Transform = mm.Properties.GetString(transformKey)
 	}
 
 	if mm.Properties != nil {
 		tmm.Encryption = readEncryptionInfo(mm.Properties)
+		tmm.Exif = readStringMap(mm.Properties, exifKey)
+		tmm.Properties = readMediaProperties(mm.Properties)
+		tmm.DeclaredContentType = types.ContentType(mm.Properties.GetString(declaredTypeKey))
+		tmm.ScanStatus = types.ScanStatus(mm.Properties.GetString(scanStatusKey))
+		tmm.ScanSignature = mm.Properties.GetString(scanSignatureKey)
+		if blurHash := mm.Properties.GetString(blurHashKey); blurHash != "" {
+			tmm.Placeholder = &types.Placeholder{
+				BlurHash:      blurHash,
+				DominantColor: mm.Properties.GetString(dominantColorKey),
+			}
+		}
 	}
 
 	return &tmm
@@ -90,6 +115,7 @@ func (mm *MediaMetadata) ToApi() *types.MediaMetadata {
 func (mm *MediaMetadata) Fill(tmm *types.MediaMetadata) {
 	mm.ID = string(tmm.MediaID)
 	mm.ParentID = string(tmm.ParentID)
+	mm.Folder = tmm.Folder
 	mm.Size = int64(tmm.FileSizeBytes)
 	mm.Name = string(tmm.UploadName)
 	mm.Mimetype = string(tmm.ContentType)
@@ -108,6 +134,13 @@ func (mm *MediaMetadata) Fill(tmm *types.MediaMetadata) {
 		mm.Properties["m"] = tmm.ThumbnailSize.ResizeMethod
 	}
 
+	if tmm.Transform != "" {
+		if mm.Properties == nil {
+			mm.Properties = make(data.JSONMap)
+		}
+		mm.Properties[transformKey] = tmm.Transform
+	}
+
 	if tmm.Encryption != nil {
 		if mm.Properties == nil {
 			mm.Properties = make(data.JSONMap)
@@ -115,6 +148,174 @@ func (mm *MediaMetadata) Fill(tmm *types.MediaMetadata) {
 		writeEncryptionInfo(mm.Properties, tmm.Encryption)
 	}
 
+	if len(tmm.Exif) > 0 {
+		if mm.Properties == nil {
+			mm.Properties = make(data.JSONMap)
+		}
+		exif := make(map[string]any, len(tmm.Exif))
+		for k, v := range tmm.Exif {
+			exif[k] = v
+		}
+		mm.Properties[exifKey] = exif
+	}
+
+	if tmm.Properties != nil {
+		if mm.Properties == nil {
+			mm.Properties = make(data.JSONMap)
+		}
+		mm.Properties[mediaKey] = writeMediaProperties(tmm.Properties)
+	}
+
+	if tmm.Placeholder != nil {
+		mm.SetPlaceholder(tmm.Placeholder)
+	}
+
+	if tmm.DeclaredContentType != "" {
+		if mm.Properties == nil {
+			mm.Properties = make(data.JSONMap)
+		}
+		mm.Properties[declaredTypeKey] = string(tmm.DeclaredContentType)
+	}
+
+	if tmm.ScanStatus != "" {
+		mm.SetScanResult(tmm.ScanStatus, tmm.ScanSignature)
+	}
+
+}
+
+// PropertiesPatch is a change to some keys of a media record's properties,
+// applied in place so concurrent writers of other keys are not overwritten.
+type PropertiesPatch struct {
+	// Set holds the keys to add or replace.
+	Set data.JSONMap
+	// Remove lists the keys to delete.
+	Remove []string
+}
+
+// apply makes the patch on props.
+func (p PropertiesPatch) apply(props data.JSONMap) {
+	for _, key := range p.Remove {
+		delete(props, key)
+	}
+	for key, value := range p.Set {
+		props[key] = value
+	}
+}
+
+// PlaceholderPatch records the image placeholder.
+func PlaceholderPatch(placeholder *types.Placeholder) PropertiesPatch {
+	return PropertiesPatch{Set: data.JSONMap{
+		blurHashKey:      placeholder.BlurHash,
+		dominantColorKey: placeholder.DominantColor,
+	}}
+}
+
+// ScanResultPatch records the malware scan outcome, clearing the signature
+// of an earlier result when there is none.
+func ScanResultPatch(status types.ScanStatus, signature string) PropertiesPatch {
+	patch := PropertiesPatch{Set: data.JSONMap{scanStatusKey: string(status)}}
+	if signature != "" {
+		patch.Set[scanSignatureKey] = signature
+	} else {
+		patch.Remove = []string{scanSignatureKey}
+	}
+	return patch
+}
+
+// SetPlaceholder records the image placeholder in the properties.
+func (mm *MediaMetadata) SetPlaceholder(placeholder *types.Placeholder) {
+	if mm.Properties == nil {
+		mm.Properties = make(data.JSONMap)
+	}
+	PlaceholderPatch(placeholder).apply(mm.Properties)
+}
+
+// SetScanResult records the malware scan outcome in the properties.
+func (mm *MediaMetadata) SetScanResult(status types.ScanStatus, signature string) {
+	if mm.Properties == nil {
+		mm.Properties = make(data.JSONMap)
+	}
+	ScanResultPatch(status, signature).apply(mm.Properties)
+}
+
+// readStringMap returns the nested object stored under key with its string
+// values.
+func readStringMap(props data.JSONMap, key string) map[string]string {
+	raw, ok := props[key].(map[string]any)
+	if !ok || len(raw) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(raw))
+	for k, v := range raw {
+		if str, isStr := v.(string); isStr {
+			out[k] = str
+		}
+	}
+	return out
+}
+
+func writeMediaProperties(p *types.MediaProperties) map[string]any {
+	out := map[string]any{}
+	setIf := func(key string, value any, ok bool) {
+		if ok {
+			out[key] = value
+		}
+	}
+	setIf("format", p.Format, p.Format != "")
+	setIf("width", p.Width, p.Width > 0)
+	setIf("height", p.Height, p.Height > 0)
+	setIf("color_space", p.ColorSpace, p.ColorSpace != "")
+	setIf("duration_ms", p.DurationMillis, p.DurationMillis > 0)
+	setIf("codecs", p.Codecs, len(p.Codecs) > 0)
+	setIf("page_count", p.PageCount, p.PageCount > 0)
+	setIf("title", p.Title, p.Title != "")
+	return out
+}
+
+// readMediaProperties decodes the nested media object. Numbers arrive as
+// float64 once the properties have been through JSON.
+func readMediaProperties(props data.JSONMap) *types.MediaProperties {
+	raw, ok := props[mediaKey].(map[string]any)
+	if !ok || len(raw) == 0 {
+		return nil
+	}
+	number := func(key string) int64 {
+		switch v := raw[key].(type) {
+		case float64:
+			return int64(v)
+		case int:
+			return int64(v)
+		case int64:
+			return v
+		default:
+			return 0
+		}
+	}
+	text := func(key string) string {
+		v, _ := raw[key].(string)
+		return v
+	}
+
+	p := &types.MediaProperties{
+		Format:         text("format"),
+		Width:          int(number("width")),
+		Height:         int(number("height")),
+		ColorSpace:     text("color_space"),
+		DurationMillis: number("duration_ms"),
+		PageCount:      int(number("page_count")),
+		Title:          text("title"),
+	}
+	switch codecs := raw["codecs"].(type) {
+	case []string:
+		p.Codecs = codecs
+	case []any:
+		for _, c := range codecs {
+			if str, isStr := c.(string); isStr {
+				p.Codecs = append(p.Codecs, str)
+			}
+		}
+	}
+	return p
 }
 
 func readEncryptionInfo(props data.JSONMap) *types.EncryptionInfo {
@@ -162,9 +363,10 @@ func writeEncryptionInfo(props data.JSONMap, info *types.EncryptionInfo) {
 // MediaAudit model responsible for holding events on a file
 type MediaAudit struct {
 	data.BaseModel
-	FileID string `gorm:"type:TEXT"`
-	Action string `gorm:"type:TEXT"`
-	Source string `gorm:"type:TEXT"`
+	FileID  string `gorm:"type:TEXT"`
+	Action  string `gorm:"type:TEXT"`
+	Source  string `gorm:"type:TEXT"`
+	Details data.JSONMap
 }
 
 // MultipartUpload model for tracking multipart file uploads
@@ -232,6 +434,397 @@ type FileRetention struct {
 	Metadata  data.JSONMap
 }
 
+// WebhookSubscription model for tenant endpoints receiving lifecycle events
+type WebhookSubscription struct {
+	data.BaseModel
+	URL          string `gorm:"type:TEXT;not null"`
+	Description  string `gorm:"type:TEXT"`
+	EventTypes   string `gorm:"type:TEXT"`
+	SealedSecret string `gorm:"type:TEXT;not null"`
+	Active       bool   `gorm:"default:true;index:idx_webhook_subscriptions_active"`
+	CreatedBy    string `gorm:"type:TEXT"`
+}
+
+func (ws *WebhookSubscription) ToApi() *types.WebhookSubscription {
+	var eventTypes []string
+	if ws.EventTypes != "" {
+		eventTypes = strings.Split(ws.EventTypes, ",")
+	}
+	return &types.WebhookSubscription{
+		ID:           ws.GetID(),
+		URL:          ws.URL,
+		Description:  ws.Description,
+		EventTypes:   eventTypes,
+		SealedSecret: ws.SealedSecret,
+		Active:       ws.Active,
+		CreatedBy:    ws.CreatedBy,
+		CreatedAt:    ws.CreatedAt,
+	}
+}
+
+func (ws *WebhookSubscription) Fill(tws *types.WebhookSubscription) {
+	ws.ID = tws.ID
+	ws.URL = tws.URL
+	ws.Description = tws.Description
+	ws.EventTypes = strings.Join(tws.EventTypes, ",")
+	ws.SealedSecret = tws.SealedSecret
+	ws.Active = tws.Active
+	ws.CreatedBy = tws.CreatedBy
+}
+
+// WebhookDelivery model for the delivery history of lifecycle events
+type WebhookDelivery struct {
+	data.BaseModel
+	SubscriptionID string `gorm:"type:VARCHAR(50);not null;index:idx_webhook_deliveries_subscription_id;uniqueIndex:idx_webhook_deliveries_event,priority:1"`
+	EventID        string `gorm:"type:VARCHAR(50);not null;uniqueIndex:idx_webhook_deliveries_event,priority:2"`
+	EventType      string `gorm:"type:TEXT"`
+	Payload        string `gorm:"type:TEXT"`
+	State          string `gorm:"type:VARCHAR(20);default:'pending';index:idx_webhook_deliveries_due,priority:1"`
+	Attempts       int    `gorm:"default:0"`
+	LastStatusCode int
+	LastError      string     `gorm:"type:TEXT"`
+	NextAttemptAt  *time.Time `gorm:"index:idx_webhook_deliveries_due,priority:2"`
+	DeliveredAt    *time.Time
+}
+
+func (wd *WebhookDelivery) ToApi() *types.WebhookDelivery {
+	return &types.WebhookDelivery{
+		ID:             wd.GetID(),
+		SubscriptionID: wd.SubscriptionID,
+		EventID:        wd.EventID,
+		EventType:      wd.EventType,
+		Payload:        []byte(wd.Payload),
+		State:          wd.State,
+		Attempts:       wd.Attempts,
+		LastStatusCode: wd.LastStatusCode,
+		LastError:      wd.LastError,
+		NextAttemptAt:  wd.NextAttemptAt,
+		DeliveredAt:    wd.DeliveredAt,
+		CreatedAt:      wd.CreatedAt,
+	}
+}
+
+func (wd *WebhookDelivery) Fill(twd *types.WebhookDelivery) {
+	wd.ID = twd.ID
+	wd.SubscriptionID = twd.SubscriptionID
+	wd.EventID = twd.EventID
+	wd.EventType = twd.EventType
+	wd.Payload = string(twd.Payload)
+	wd.State = twd.State
+	wd.Attempts = twd.Attempts
+	wd.LastStatusCode = twd.LastStatusCode
+	wd.LastError = twd.LastError
+	wd.NextAttemptAt = twd.NextAttemptAt
+	wd.DeliveredAt = twd.DeliveredAt
+}
+
+// ThumbnailJob model tracking derivative generation for a media item
+type ThumbnailJob struct {
+	data.BaseModel
+	MediaID       string     `gorm:"type:VARCHAR(50);not null;uniqueIndex:idx_thumbnail_jobs_media_id"`
+	State         string     `gorm:"type:VARCHAR(20);default:'pending';index:idx_thumbnail_jobs_due,priority:1"`
+	Attempts      int        `gorm:"default:0"`
+	LastError     string     `gorm:"type:TEXT"`
+	NextAttemptAt *time.Time `gorm:"index:idx_thumbnail_jobs_due,priority:2"`
+	CompletedAt   *time.Time
+}
+
+func (tj *ThumbnailJob) ToApi() *types.ThumbnailJob {
+	return &types.ThumbnailJob{
+		ID:            tj.GetID(),
+		MediaID:       types.MediaID(tj.MediaID),
+		State:         tj.State,
+		Attempts:      tj.Attempts,
+		LastError:     tj.LastError,
+		NextAttemptAt: tj.NextAttemptAt,
+		CompletedAt:   tj.CompletedAt,
+		TenantID:      tj.TenantID,
+		PartitionID:   tj.PartitionID,
+		CreatedAt:     tj.CreatedAt,
+		ModifiedAt:    tj.ModifiedAt,
+	}
+}
+
+func (tj *ThumbnailJob) Fill(ttj *types.ThumbnailJob) {
+	tj.ID = ttj.ID
+	tj.MediaID = string(ttj.MediaID)
+	tj.State = ttj.State
+	tj.Attempts = ttj.Attempts
+	tj.LastError = ttj.LastError
+	tj.NextAttemptAt = ttj.NextAttemptAt
+	tj.CompletedAt = ttj.CompletedAt
+}
+
+// ThumbnailRegeneration model for bulk thumbnail regeneration runs
+type ThumbnailRegeneration struct {
+	data.BaseModel
+	OwnerID       string `gorm:"type:TEXT"`
+	ContentType   string `gorm:"type:TEXT"`
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	RemoveStale   bool   `gorm:"default:false"`
+	RatePerSecond int    `gorm:"default:0"`
+	State         string `gorm:"type:VARCHAR(20);default:'running'"`
+	Checkpoint    string `gorm:"type:VARCHAR(50)"`
+	Scanned       int64  `gorm:"default:0"`
+	Enqueued      int64  `gorm:"default:0"`
+	Removed       int64  `gorm:"default:0"`
+	LastError     string `gorm:"type:TEXT"`
+	CompletedAt   *time.Time
+	LeaseUntil    *time.Time
+}
+
+func (tr *ThumbnailRegeneration) ToApi() *types.ThumbnailRegeneration {
+	return &types.ThumbnailRegeneration{
+		ID: tr.GetID(),
+		Filter: types.ThumbnailRegenerationFilter{
+			OwnerID:       types.OwnerID(tr.OwnerID),
+			ContentType:   tr.ContentType,
+			CreatedAfter:  tr.CreatedAfter,
+			CreatedBefore: tr.CreatedBefore,
+		},
+		RemoveStale:   tr.RemoveStale,
+		RatePerSecond: tr.RatePerSecond,
+		State:         tr.State,
+		Checkpoint:    tr.Checkpoint,
+		Scanned:       tr.Scanned,
+		Enqueued:      tr.Enqueued,
+		Removed:       tr.Removed,
+		LastError:     tr.LastError,
+		CompletedAt:   tr.CompletedAt,
+		LeaseUntil:    tr.LeaseUntil,
+		TenantID:      tr.TenantID,
+		PartitionID:   tr.PartitionID,
+		CreatedAt:     tr.CreatedAt,
+		ModifiedAt:    tr.ModifiedAt,
+	}
+}
+
+func (tr *ThumbnailRegeneration) Fill(ttr *types.ThumbnailRegeneration) {
+	tr.ID = ttr.ID
+	tr.OwnerID = string(ttr.Filter.OwnerID)
+	tr.ContentType = ttr.Filter.ContentType
+	tr.CreatedAfter = ttr.Filter.CreatedAfter
+	tr.CreatedBefore = ttr.Filter.CreatedBefore
+	tr.RemoveStale = ttr.RemoveStale
+	tr.RatePerSecond = ttr.RatePerSecond
+	tr.State = ttr.State
+	tr.Checkpoint = ttr.Checkpoint
+	tr.Scanned = ttr.Scanned
+	tr.Enqueued = ttr.Enqueued
+	tr.Removed = ttr.Removed
+	tr.LastError = ttr.LastError
+	tr.CompletedAt = ttr.CompletedAt
+	tr.LeaseUntil = ttr.LeaseUntil
+}
+
+// ArchiveExtraction model for archive extraction jobs
+type ArchiveExtraction struct {
+	data.BaseModel
+	OwnerID        string `gorm:"type:TEXT;index"`
+	SourceMediaID  string `gorm:"type:VARCHAR(50)"`
+	Folder         string `gorm:"type:TEXT"`
+	State          string `gorm:"type:VARCHAR(20);default:'running'"`
+	Entries        int64  `gorm:"default:0"`
+	Extracted      int64  `gorm:"default:0"`
+	Skipped        int64  `gorm:"default:0"`
+	Failed         int64  `gorm:"default:0"`
+	ExtractedBytes int64  `gorm:"default:0"`
+	Failures       data.JSONMap
+	LastError      string `gorm:"type:TEXT"`
+	CompletedAt    *time.Time
+}
+
+func (ae *ArchiveExtraction) ToApi() *types.ArchiveExtraction {
+	failures := make(map[string]string, len(ae.Failures))
+	for entryPath, reason := range ae.Failures {
+		failures[entryPath], _ = reason.(string)
+	}
+	return &types.ArchiveExtraction{
+		ID:             ae.GetID(),
+		OwnerID:        types.OwnerID(ae.OwnerID),
+		SourceMediaID:  types.MediaID(ae.SourceMediaID),
+		Folder:         ae.Folder,
+		State:          ae.State,
+		Entries:        ae.Entries,
+		Extracted:      ae.Extracted,
+		Skipped:        ae.Skipped,
+		Failed:         ae.Failed,
+		ExtractedBytes: ae.ExtractedBytes,
+		Failures:       failures,
+		LastError:      ae.LastError,
+		CompletedAt:    ae.CompletedAt,
+		CreatedAt:      ae.CreatedAt,
+		ModifiedAt:     ae.ModifiedAt,
+	}
+}
+
+func (ae *ArchiveExtraction) Fill(tae *types.ArchiveExtraction) {
+	ae.ID = tae.ID
+	ae.OwnerID = string(tae.OwnerID)
+	ae.SourceMediaID = string(tae.SourceMediaID)
+	ae.Folder = tae.Folder
+	ae.State = tae.State
+	ae.Entries = tae.Entries
+	ae.Extracted = tae.Extracted
+	ae.Skipped = tae.Skipped
+	ae.Failed = tae.Failed
+	ae.ExtractedBytes = tae.ExtractedBytes
+	ae.Failures = failuresToJSON(tae.Failures)
+	ae.LastError = tae.LastError
+	ae.CompletedAt = tae.CompletedAt
+}
+
+func failuresToJSON(failures map[string]string) data.JSONMap {
+	result := make(data.JSONMap, len(failures))
+	for entryPath, reason := range failures {
+		result[entryPath] = reason
+	}
+	return result
+}
+
+// UserDataExport model for user data export jobs
+type UserDataExport struct {
+	data.BaseModel
+	ProfileID     string `gorm:"type:TEXT;index"`
+	RequestedBy   string `gorm:"type:TEXT"`
+	State         string `gorm:"type:VARCHAR(20);default:'running'"`
+	TotalFiles    int64  `gorm:"default:0"`
+	ExportedFiles int64  `gorm:"default:0"`
+	FailedFiles   int64  `gorm:"default:0"`
+	ExportedBytes int64  `gorm:"default:0"`
+	ResultMediaID string `gorm:"type:VARCHAR(50)"`
+	LastError     string `gorm:"type:TEXT"`
+	CompletedAt   *time.Time
+}
+
+func (ue *UserDataExport) ToApi() *types.UserDataExport {
+	return &types.UserDataExport{
+		ID:            ue.GetID(),
+		ProfileID:     types.OwnerID(ue.ProfileID),
+		RequestedBy:   ue.RequestedBy,
+		State:         ue.State,
+		TotalFiles:    ue.TotalFiles,
+		ExportedFiles: ue.ExportedFiles,
+		FailedFiles:   ue.FailedFiles,
+		ExportedBytes: ue.ExportedBytes,
+		ResultMediaID: types.MediaID(ue.ResultMediaID),
+		LastError:     ue.LastError,
+		CompletedAt:   ue.CompletedAt,
+		CreatedAt:     ue.CreatedAt,
+		ModifiedAt:    ue.ModifiedAt,
+	}
+}
+
+func (ue *UserDataExport) Fill(tue *types.UserDataExport) {
+	ue.ID = tue.ID
+	ue.ProfileID = string(tue.ProfileID)
+	ue.RequestedBy = tue.RequestedBy
+	ue.State = tue.State
+	ue.TotalFiles = tue.TotalFiles
+	ue.ExportedFiles = tue.ExportedFiles
+	ue.FailedFiles = tue.FailedFiles
+	ue.ExportedBytes = tue.ExportedBytes
+	ue.ResultMediaID = string(tue.ResultMediaID)
+	ue.LastError = tue.LastError
+	ue.CompletedAt = tue.CompletedAt
+}
+
+// ShareLink model for share links to files
+type ShareLink struct {
+	data.BaseModel
+	MediaID        string `gorm:"type:VARCHAR(50);index"`
+	OwnerID        string `gorm:"type:TEXT"`
+	TokenHash      string `gorm:"type:VARCHAR(64);uniqueIndex"`
+	PasswordHash   string `gorm:"type:TEXT"`
+	Permission     string `gorm:"type:VARCHAR(20);default:'view'"`
+	ExpiresAt      *time.Time
+	MaxDownloads   int `gorm:"default:0"`
+	DownloadCount  int `gorm:"default:0"`
+	RevokedAt      *time.Time
+	LastAccessedAt *time.Time
+	FailedAttempts int `gorm:"default:0"`
+	LockedUntil    *time.Time
+}
+
+func (sl *ShareLink) ToApi() *types.ShareLink {
+	return &types.ShareLink{
+		ID:             sl.GetID(),
+		MediaID:        types.MediaID(sl.MediaID),
+		OwnerID:        types.OwnerID(sl.OwnerID),
+		TokenHash:      sl.TokenHash,
+		PasswordHash:   sl.PasswordHash,
+		Permission:     sl.Permission,
+		ExpiresAt:      sl.ExpiresAt,
+		MaxDownloads:   sl.MaxDownloads,
+		DownloadCount:  sl.DownloadCount,
+		RevokedAt:      sl.RevokedAt,
+		LastAccessedAt: sl.LastAccessedAt,
+		FailedAttempts: sl.FailedAttempts,
+		LockedUntil:    sl.LockedUntil,
+		TenantID:       sl.TenantID,
+		PartitionID:    sl.PartitionID,
+		CreatedAt:      sl.CreatedAt,
+		ModifiedAt:     sl.ModifiedAt,
+	}
+}
+
+func (sl *ShareLink) Fill(tsl *types.ShareLink) {
+	sl.ID = tsl.ID
+	sl.MediaID = string(tsl.MediaID)
+	sl.OwnerID = string(tsl.OwnerID)
+	sl.TokenHash = tsl.TokenHash
+	sl.PasswordHash = tsl.PasswordHash
+	sl.Permission = tsl.Permission
+	sl.ExpiresAt = tsl.ExpiresAt
+	sl.MaxDownloads = tsl.MaxDownloads
+	sl.DownloadCount = tsl.DownloadCount
+	sl.RevokedAt = tsl.RevokedAt
+	sl.LastAccessedAt = tsl.LastAccessedAt
+	sl.FailedAttempts = tsl.FailedAttempts
+	sl.LockedUntil = tsl.LockedUntil
+}
+
+// AccessGrant model for the records kept of access granted in Keto
+type AccessGrant struct {
+	data.BaseModel
+	ObjectNamespace string     `gorm:"type:VARCHAR(50);index:idx_access_grant_object"`
+	ObjectID        string     `gorm:"type:TEXT;index:idx_access_grant_object"`
+	Relation        string     `gorm:"type:VARCHAR(50)"`
+	PrincipalKind   string     `gorm:"type:VARCHAR(20)"`
+	PrincipalID     string     `gorm:"type:TEXT"`
+	GrantedBy       string     `gorm:"type:TEXT"`
+	ExpiresAt       *time.Time `gorm:"index"`
+}
+
+func (ag *AccessGrant) ToApi() *types.AccessGrant {
+	return &types.AccessGrant{
+		ID:              ag.GetID(),
+		ObjectNamespace: ag.ObjectNamespace,
+		ObjectID:        ag.ObjectID,
+		Relation:        ag.Relation,
+		PrincipalKind:   ag.PrincipalKind,
+		PrincipalID:     ag.PrincipalID,
+		GrantedBy:       types.OwnerID(ag.GrantedBy),
+		ExpiresAt:       ag.ExpiresAt,
+		TenantID:        ag.TenantID,
+		PartitionID:     ag.PartitionID,
+		CreatedAt:       ag.CreatedAt,
+		ModifiedAt:      ag.ModifiedAt,
+	}
+}
+
+func (ag *AccessGrant) Fill(tag *types.AccessGrant) {
+	ag.ID = tag.ID
+	ag.ObjectNamespace = tag.ObjectNamespace
+	ag.ObjectID = tag.ObjectID
+	ag.Relation = tag.Relation
+	ag.PrincipalKind = tag.PrincipalKind
+	ag.PrincipalID = tag.PrincipalID
+	ag.GrantedBy = string(tag.GrantedBy)
+	ag.ExpiresAt = tag.ExpiresAt
+}
+
 // StorageStats model for tracking storage statistics
 type StorageStats struct {
 	data.BaseModel
@@ -242,3 +835,44 @@ type StorageStats struct {
 	PrivateBytes int64 `gorm:"default:0"`
 	Metadata     data.JSONMap
 }
+
+// UserDataErasure model for user data erasure jobs
+type UserDataErasure struct {
+	data.BaseModel
+	ProfileID   string `gorm:"type:TEXT;index"`
+	RequestedBy string `gorm:"type:TEXT"`
+	State       string `gorm:"type:VARCHAR(20);default:'running'"`
+	Report      string `gorm:"type:TEXT"`
+	Signature   string `gorm:"type:TEXT"`
+	Progress    string `gorm:"type:TEXT"`
+	LastError   string `gorm:"type:TEXT"`
+	CompletedAt *time.Time
+}
+
+func (ue *UserDataErasure) ToApi() *types.UserDataErasure {
+	return &types.UserDataErasure{
+		ID:          ue.GetID(),
+		ProfileID:   types.OwnerID(ue.ProfileID),
+		RequestedBy: ue.RequestedBy,
+		State:       ue.State,
+		Report:      ue.Report,
+		Signature:   ue.Signature,
+		Progress:    ue.Progress,
+		LastError:   ue.LastError,
+		CompletedAt: ue.CompletedAt,
+		CreatedAt:   ue.CreatedAt,
+		ModifiedAt:  ue.ModifiedAt,
+	}
+}
+
+func (ue *UserDataErasure) Fill(tue *types.UserDataErasure) {
+	ue.ID = tue.ID
+	ue.ProfileID = string(tue.ProfileID)
+	ue.RequestedBy = tue.RequestedBy
+	ue.State = tue.State
+	ue.Report = tue.Report
+	ue.Signature = tue.Signature
+	ue.Progress = tue.Progress
+	ue.LastError = tue.LastError
+	ue.CompletedAt = tue.CompletedAt
+}
@@ -432,3 +432,112 @@ func (s *ModelsTestSuite) TestMediaMetadata_EncryptionRoundTrip() {
 		})
 	}
 }
+
+func (s *ModelsTestSuite) TestMediaMetadata_ExifRoundTrip() {
+	t := s.T()
+
+	model := &models.MediaMetadata{}
+	model.Fill(&types.MediaMetadata{
+		MediaID: "exif-media",
+		Exif:    map[string]string{"make": "Acme", "gps_latitude": "-1.290000"},
+	})
+	api := model.ToApi()
+	assert.Equal(t, map[string]string{"make": "Acme", "gps_latitude": "-1.290000"}, api.Exif)
+
+	decoded := &models.MediaMetadata{
+		Properties: map[string]interface{}{
+			"exif": map[string]interface{}{"model": "Phone 9", "ignored": 3},
+		},
+	}
+	assert.Equal(t, map[string]string{"model": "Phone 9"}, decoded.ToApi().Exif)
+	assert.Nil(t, (&models.MediaMetadata{}).ToApi().Exif)
+}
+
+func (s *ModelsTestSuite) TestMediaMetadata_PropertiesRoundTrip() {
+	t := s.T()
+
+	model := &models.MediaMetadata{}
+	model.Fill(&types.MediaMetadata{
+		MediaID: "video-media",
+		Properties: &types.MediaProperties{
+			Format:         "mp4",
+			Width:          1280,
+			Height:         720,
+			DurationMillis: 9500,
+			Codecs:         []string{"avc1", "mp4a"},
+		},
+	})
+	assert.Equal(t, &types.MediaProperties{
+		Format:         "mp4",
+		Width:          1280,
+		Height:         720,
+		DurationMillis: 9500,
+		Codecs:         []string{"avc1", "mp4a"},
+	}, model.ToApi().Properties)
+
+	// Properties read back from the database carry JSON numbers and arrays.
+	decoded := &models.MediaMetadata{
+		Properties: map[string]interface{}{
+			"media": map[string]interface{}{
+				"format":     "pdf",
+				"page_count": float64(12),
+				"title":      "Report",
+				"codecs":     []interface{}{"ignored", 3},
+			},
+		},
+	}
+	props := decoded.ToApi().Properties
+	assert.Equal(t, "pdf", props.Format)
+	assert.Equal(t, 12, props.PageCount)
+	assert.Equal(t, "Report", props.Title)
+	assert.Equal(t, []string{"ignored"}, props.Codecs)
+	assert.Nil(t, (&models.MediaMetadata{}).ToApi().Properties)
+}
+
+func (s *ModelsTestSuite) TestMediaMetadata_PlaceholderRoundTrip() {
+	t := s.T()
+
+	placeholder := &types.Placeholder{BlurHash: "LEHV6nWB2yk8pyo0adR*.7kCMdnj", DominantColor: "#336699"}
+	model := &models.MediaMetadata{}
+	model.Fill(&types.MediaMetadata{MediaID: "placeholder-media", Placeholder: placeholder})
+	assert.Equal(t, placeholder, model.ToApi().Placeholder)
+
+	model.SetPlaceholder(&types.Placeholder{BlurHash: "L00000fQfQfQfQfQfQfQfQfQfQfQ"})
+	assert.Equal(t, "L00000fQfQfQfQfQfQfQfQfQfQfQ", model.ToApi().Placeholder.BlurHash)
+	assert.Nil(t, (&models.MediaMetadata{}).ToApi().Placeholder)
+}
+
+func (s *ModelsTestSuite) TestMediaMetadata_DeclaredContentTypeRoundTrip() {
+	t := s.T()
+
+	model := &models.MediaMetadata{}
+	model.Fill(&types.MediaMetadata{
+		MediaID:             "corrected-media",
+		ContentType:         "text/html; charset=utf-8",
+		DeclaredContentType: "image/png",
+	})
+	api := model.ToApi()
+	assert.Equal(t, types.ContentType("text/html; charset=utf-8"), api.ContentType)
+	assert.Equal(t, types.ContentType("image/png"), api.DeclaredContentType)
+
+	assert.Empty(t, (&models.MediaMetadata{Properties: map[string]interface{}{}}).ToApi().DeclaredContentType)
+}
+
+func (s *ModelsTestSuite) TestMediaMetadata_ScanResultRoundTrip() {
+	t := s.T()
+
+	model := &models.MediaMetadata{}
+	model.Fill(&types.MediaMetadata{
+		MediaID:       "scanned-media",
+		ScanStatus:    types.ScanStatusInfected,
+		ScanSignature: "Eicar-Test-Signature",
+	})
+	api := model.ToApi()
+	assert.Equal(t, types.ScanStatusInfected, api.ScanStatus)
+	assert.Equal(t, "Eicar-Test-Signature", api.ScanSignature)
+
+	model.SetScanResult(types.ScanStatusClean, "")
+	api = model.ToApi()
+	assert.Equal(t, types.ScanStatusClean, api.ScanStatus)
+	assert.Empty(t, api.ScanSignature)
+}
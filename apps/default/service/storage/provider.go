@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 
 	"github.com/antinvestor/service-files/apps/default/config"
 	"github.com/antinvestor/service-files/apps/default/service/types"
 	"github.com/antinvestor/service-files/apps/default/service/utils"
 	"github.com/pitabwire/util"
 	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
 )
 
 type Provider interface {
@@ -21,6 +23,9 @@ type Provider interface {
 	Init(ctx context.Context, bucketName string) (*blob.Bucket, error)
 	UploadFile(ctx context.Context, bucket string, sourcePath types.Path, destinationPath types.Path) (bool, error)
 	DownloadFile(ctx context.Context, bucket string, sourcePath types.Path) (io.Reader, func(), error)
+	// DeleteFile removes a stored file; a file that does not exist is not an
+	// error.
+	DeleteFile(ctx context.Context, bucket string, path types.Path) error
 }
 
 // UploadFileWithHashCheck checks for hash collisions when moving a temporary file to its final path based on metadata
@@ -46,3 +51,36 @@ func UploadFileWithHashCheck(ctx context.Context, provider Provider, sourcePath
 	}
 	return types.Path(finalPath), duplicate, nil
 }
+
+// MoveFile moves a stored file from one bucket to another through a local
+// temporary copy. A file already missing from the source bucket is not an
+// error, so an interrupted move can be repeated.
+func MoveFile(ctx context.Context, provider Provider, fromBucket, toBucket string, path types.Path) error {
+	reader, finalizer, err := provider.DownloadFile(ctx, fromBucket, path)
+	if err != nil {
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return nil
+		}
+		return err
+	}
+	defer finalizer()
+
+	tempFile, err := os.CreateTemp("", "files-move-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tempFile.Name()) }()
+
+	_, err = io.Copy(tempFile, reader)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err = provider.UploadFile(ctx, toBucket, types.Path(tempFile.Name()), path); err != nil {
+		return err
+	}
+	return provider.DeleteFile(ctx, fromBucket, path)
+}
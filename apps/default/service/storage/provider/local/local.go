@@ -10,6 +10,7 @@ import (
 	"github.com/pitabwire/util"
 	"gocloud.dev/blob"
 	_ "gocloud.dev/blob/fileblob"
+	"gocloud.dev/gcerrors"
 )
 
 type ProviderLocal struct {
@@ -118,6 +119,21 @@ func (provider *ProviderLocal) DownloadFile(ctx context.Context, bucketName stri
 	}, nil
 }
 
+func (provider *ProviderLocal) DeleteFile(ctx context.Context, bucketName string, inBucketPath types.Path) error {
+
+	bucket, err := provider.Init(ctx, bucketName)
+	if err != nil {
+		return err
+	}
+	defer util.CloseAndLogOnError(ctx, bucket)
+
+	err = bucket.Delete(ctx, string(inBucketPath))
+	if err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+		return err
+	}
+	return nil
+}
+
 func NewProvider(name, provateBucket, publicBucket string) *ProviderLocal {
 	return &ProviderLocal{
 		name:          name,
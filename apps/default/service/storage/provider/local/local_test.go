@@ -128,3 +128,29 @@ func (suite *LocalProviderTestSuite) TestProviderLocal_Metadata() {
 		})
 	}
 }
+
+func TestProviderLocal_DeleteFile(t *testing.T) {
+	ctx := t.Context()
+	bucket := t.TempDir()
+	prov := local.NewProvider("local-provider", bucket, bucket)
+
+	source := filepath.Join(t.TempDir(), "source.txt")
+	if err := os.WriteFile(source, []byte("erase me"), 0o600); err != nil {
+		t.Fatalf("Error writing source file: %v", err)
+	}
+	if _, err := prov.UploadFile(ctx, bucket, types.Path(source), "multipart/u1/part-000001"); err != nil {
+		t.Fatalf("Upload file experienced issues: %v", err)
+	}
+
+	if err := prov.DeleteFile(ctx, bucket, "multipart/u1/part-000001"); err != nil {
+		t.Fatalf("Delete file experienced issues: %v", err)
+	}
+	if _, _, err := prov.DownloadFile(ctx, bucket, "multipart/u1/part-000001"); err == nil {
+		t.Error("A deleted file can still be downloaded")
+	}
+
+	// Deleting again is not an error.
+	if err := prov.DeleteFile(ctx, bucket, "multipart/u1/part-000001"); err != nil {
+		t.Errorf("Deleting a missing file failed: %v", err)
+	}
+}
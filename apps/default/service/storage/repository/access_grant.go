@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/pitabwire/frame/v2/datastore"
+	"github.com/pitabwire/frame/v2/datastore/pool"
+	"github.com/pitabwire/frame/v2/workerpool"
+)
+
+// AccessGrantRepository defines the interface for access grant record operations
+type AccessGrantRepository interface {
+	datastore.BaseRepository[*models.AccessGrant]
+	ListByObjects(ctx context.Context, namespace string, objectIDs []string) ([]*models.AccessGrant, error)
+	ListExpired(ctx context.Context, before time.Time, limit int) ([]*models.AccessGrant, error)
+	DeleteForPrincipal(ctx context.Context, namespace, objectID, principalKind, principalID string, relations []string) error
+	ReassignGrantor(ctx context.Context, from, to string) (int64, error)
+}
+
+// NewAccessGrantRepository creates a new access grant repository instance
+func NewAccessGrantRepository(ctx context.Context, dbPool pool.Pool, workMan workerpool.Manager) AccessGrantRepository {
+	repo := accessGrantRepository{
+		BaseRepository: datastore.NewBaseRepository[*models.AccessGrant](
+			ctx, dbPool, workMan, func() *models.AccessGrant { return &models.AccessGrant{} },
+		),
+	}
+	return &repo
+}
+
+type accessGrantRepository struct {
+	datastore.BaseRepository[*models.AccessGrant]
+}
+
+// ListByObjects retrieves the grants recorded on any of the given objects
+func (r *accessGrantRepository) ListByObjects(ctx context.Context, namespace string, objectIDs []string) ([]*models.AccessGrant, error) {
+	var grants []*models.AccessGrant
+	if len(objectIDs) == 0 {
+		return grants, nil
+	}
+	err := r.Pool().DB(ctx, true).
+		Where("object_namespace = ? AND object_id IN ?", namespace, objectIDs).
+		Order("created_at ASC").
+		Find(&grants).Error
+	return grants, err
+}
+
+// ListExpired retrieves grants that expired before the given time, oldest first
+func (r *accessGrantRepository) ListExpired(ctx context.Context, before time.Time, limit int) ([]*models.AccessGrant, error) {
+	var grants []*models.AccessGrant
+	err := r.Pool().DB(ctx, true).
+		Where("expires_at IS NOT NULL AND expires_at <= ?", before).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&grants).Error
+	return grants, err
+}
+
+// DeleteForPrincipal removes the grants of the given relations recorded for
+// a principal on an object
+func (r *accessGrantRepository) DeleteForPrincipal(ctx context.Context, namespace, objectID, principalKind, principalID string, relations []string) error {
+	return r.Pool().DB(ctx, false).
+		Where("object_namespace = ? AND object_id = ? AND principal_kind = ? AND principal_id = ? AND relation IN ?",
+			namespace, objectID, principalKind, principalID, relations).
+		Delete(&models.AccessGrant{}).Error
+}
+
+// ReassignGrantor replaces the grantor of the grants made by from
+func (r *accessGrantRepository) ReassignGrantor(ctx context.Context, from, to string) (int64, error) {
+	result := r.Pool().DB(ctx, false).Model(&models.AccessGrant{}).
+		Where("granted_by = ?", from).Update("granted_by", to)
+	return result.RowsAffected, result.Error
+}
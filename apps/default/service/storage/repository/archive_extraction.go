@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/pitabwire/frame/v2/datastore"
+	"github.com/pitabwire/frame/v2/datastore/pool"
+	"github.com/pitabwire/frame/v2/workerpool"
+)
+
+// ArchiveExtractionRepository defines the interface for archive extraction job operations
+type ArchiveExtractionRepository interface {
+	datastore.BaseRepository[*models.ArchiveExtraction]
+	EraseByOwner(ctx context.Context, ownerID string) (int64, error)
+}
+
+// NewArchiveExtractionRepository creates a new archive extraction repository instance
+func NewArchiveExtractionRepository(ctx context.Context, dbPool pool.Pool, workMan workerpool.Manager) ArchiveExtractionRepository {
+	repo := archiveExtractionRepository{
+		BaseRepository: datastore.NewBaseRepository[*models.ArchiveExtraction](
+			ctx, dbPool, workMan, func() *models.ArchiveExtraction { return &models.ArchiveExtraction{} },
+		),
+	}
+	return &repo
+}
+
+type archiveExtractionRepository struct {
+	datastore.BaseRepository[*models.ArchiveExtraction]
+}
+
+// EraseByOwner permanently deletes the extraction jobs of ownerID.
+func (r *archiveExtractionRepository) EraseByOwner(ctx context.Context, ownerID string) (int64, error) {
+	result := r.Pool().DB(ctx, false).Unscoped().Where("owner_id = ?", ownerID).Delete(&models.ArchiveExtraction{})
+	return result.RowsAffected, result.Error
+}
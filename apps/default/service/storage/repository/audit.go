@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/pitabwire/frame/v2/data"
 	"github.com/pitabwire/frame/v2/datastore"
 	"github.com/pitabwire/frame/v2/datastore/pool"
 	"github.com/pitabwire/frame/v2/workerpool"
@@ -11,6 +12,9 @@ import (
 
 type MediaAuditRepository interface {
 	datastore.BaseRepository[*models.MediaAudit]
+	ListByFiles(ctx context.Context, fileIDs []string, afterID string, limit int) ([]*models.MediaAudit, error)
+	ListBySource(ctx context.Context, source string, afterID string, limit int) ([]*models.MediaAudit, error)
+	AnonymizeSource(ctx context.Context, source, replacement string) (int64, error)
 }
 
 func NewMediaAuditRepository(ctx context.Context, dbPool pool.Pool, workMan workerpool.Manager) MediaAuditRepository {
@@ -25,3 +29,43 @@ func NewMediaAuditRepository(ctx context.Context, dbPool pool.Pool, workMan work
 type fileAuditRepository struct {
 	datastore.BaseRepository[*models.MediaAudit]
 }
+
+// ListByFiles retrieves the audit records of the given files with IDs after
+// afterID, in ID order.
+func (ar *fileAuditRepository) ListByFiles(ctx context.Context, fileIDs []string, afterID string, limit int) ([]*models.MediaAudit, error) {
+	var records []*models.MediaAudit
+	if len(fileIDs) == 0 {
+		return records, nil
+	}
+	err := ar.Pool().DB(ctx, true).
+		Where("file_id IN ?", fileIDs).
+		Where("id > ?", afterID).
+		Order("id ASC").Limit(limit).Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// ListBySource retrieves the audit records of actions taken by source, such
+// as "profile:<id>", with IDs after afterID, in ID order.
+func (ar *fileAuditRepository) ListBySource(ctx context.Context, source string, afterID string, limit int) ([]*models.MediaAudit, error) {
+	var records []*models.MediaAudit
+	err := ar.Pool().DB(ctx, true).
+		Where("source = ?", source).
+		Where("id > ?", afterID).
+		Order("id ASC").Limit(limit).Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// AnonymizeSource replaces source on the records of actions it took and
+// clears their details, which may name the actor.
+func (ar *fileAuditRepository) AnonymizeSource(ctx context.Context, source, replacement string) (int64, error) {
+	result := ar.Pool().DB(ctx, false).Model(&models.MediaAudit{}).
+		Where("source = ?", source).
+		Updates(map[string]any{"source": replacement, "details": data.JSONMap{}})
+	return result.RowsAffected, result.Error
+}
@@ -2,12 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/antinvestor/service-files/apps/default/service/storage/models"
 	"github.com/pitabwire/frame/v2/datastore"
 	"github.com/pitabwire/frame/v2/datastore/pool"
 	"github.com/pitabwire/frame/v2/workerpool"
+	"gorm.io/gorm"
 )
 
 // FileRetentionRepository defines the interface for file retention operations
@@ -49,7 +51,10 @@ func (r *fileRetentionRepository) GetByMediaID(ctx context.Context, mediaID stri
 	retention := &models.FileRetention{}
 	err := r.Pool().DB(ctx, true).First(retention, "media_id = ?", mediaID).Error
 	if err != nil {
-		return nil, nil
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
 	}
 	return retention, nil
 }
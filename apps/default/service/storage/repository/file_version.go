@@ -15,6 +15,8 @@ type FileVersionRepository interface {
 	GetByMediaID(ctx context.Context, mediaID string) ([]*models.FileVersion, error)
 	GetVersion(ctx context.Context, mediaID string, versionNumber int) (*models.FileVersion, error)
 	GetVersionsPaginated(ctx context.Context, mediaID string, limit, offset int) ([]*models.FileVersion, int, error)
+	CountByContent(ctx context.Context, hash string) (int64, error)
+	ReassignCreator(ctx context.Context, from, to string) (int64, error)
 }
 
 // NewFileVersionRepository creates a new file version repository instance
@@ -76,3 +78,19 @@ func (r *fileVersionRepository) GetVersionsPaginated(ctx context.Context, mediaI
 
 	return versions, int(count), nil
 }
+
+// CountByContent counts the versions, soft deleted or not, stored under
+// the given content hash.
+func (r *fileVersionRepository) CountByContent(ctx context.Context, hash string) (int64, error) {
+	var count int64
+	err := r.Pool().DB(ctx, true).Unscoped().Model(&models.FileVersion{}).
+		Where("content_hash = ? OR storage_path = ?", hash, hash).Count(&count).Error
+	return count, err
+}
+
+// ReassignCreator replaces the creator of the versions created by from.
+func (r *fileVersionRepository) ReassignCreator(ctx context.Context, from, to string) (int64, error) {
+	result := r.Pool().DB(ctx, false).Unscoped().Model(&models.FileVersion{}).
+		Where("created_by = ?", from).Update("created_by", to)
+	return result.RowsAffected, result.Error
+}
@@ -2,13 +2,16 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"strconv"
+	"strings"
 
 	"github.com/antinvestor/service-files/apps/default/service/storage/models"
 	"github.com/antinvestor/service-files/apps/default/service/types"
 	"github.com/pitabwire/frame/v2/datastore"
 	"github.com/pitabwire/frame/v2/datastore/pool"
 	"github.com/pitabwire/frame/v2/workerpool"
+	"gorm.io/gorm"
 )
 
 type MediaRepository interface {
@@ -16,7 +19,17 @@ type MediaRepository interface {
 	GetByHash(ctx context.Context, ownerId types.OwnerID, hash types.Base64Hash) (*models.MediaMetadata, error)
 	GetByParentID(ctx context.Context, parentId types.MediaID) ([]*models.MediaMetadata, error)
 	GetByParentIDAndThumbnailSize(ctx context.Context, parentId types.MediaID, thumbnailSize *types.ThumbnailSize) (*models.MediaMetadata, error)
+	GetByParentIDAndTransform(ctx context.Context, parentId types.MediaID, transform string) (*models.MediaMetadata, error)
+	CountDerivatives(ctx context.Context, parentId types.MediaID) (int64, error)
 	GetByOwnerID(ctx context.Context, ownerId types.OwnerID, query string, page int32, limit int32) ([]*models.MediaMetadata, error)
+	ListOriginalImages(ctx context.Context, filter *types.ThumbnailRegenerationFilter, afterID string, limit int) ([]*models.MediaMetadata, error)
+	GetByIDs(ctx context.Context, ids []string) ([]*models.MediaMetadata, error)
+	ListByOwner(ctx context.Context, ownerID types.OwnerID, folder string, afterID string, limit int) ([]*models.MediaMetadata, error)
+	ListOriginalsWithDeleted(ctx context.Context, afterID string, limit int) ([]*models.MediaMetadata, error)
+	GetWithDeleted(ctx context.Context, ids []string) ([]*models.MediaMetadata, error)
+	ListByOwnerWithDeleted(ctx context.Context, ownerID types.OwnerID, afterID string, limit int) ([]*models.MediaMetadata, error)
+	CountByHash(ctx context.Context, hash types.Base64Hash) (int64, error)
+	PatchProperties(ctx context.Context, id string, patch models.PropertiesPatch, columns map[string]any) error
 }
 
 func NewMediaRepository(ctx context.Context, dbPool pool.Pool, workMan workerpool.Manager) MediaRepository {
@@ -55,7 +68,7 @@ func (mr *mediaRepository) GetByParentID(ctx context.Context, parentId types.Med
 
 func (mr *mediaRepository) GetByParentIDAndThumbnailSize(ctx context.Context, parentId types.MediaID, thumbnailSize *types.ThumbnailSize) (*models.MediaMetadata, error) {
 	media := &models.MediaMetadata{}
-	tx := mr.Pool().DB(ctx, true).Where(" parent_id = ? ", string(parentId))
+	tx := mr.Pool().DB(ctx, true).Where(" parent_id = ? AND COALESCE(properties ->> 't', '') = '' ", string(parentId))
 	if thumbnailSize != nil {
 		tx = tx.Where("properties ->> 'h' = ?  "+
 			"AND properties ->> 'w' = ?  "+
@@ -71,6 +84,26 @@ func (mr *mediaRepository) GetByParentIDAndThumbnailSize(ctx context.Context, pa
 	return media, nil
 }
 
+func (mr *mediaRepository) GetByParentIDAndTransform(ctx context.Context, parentId types.MediaID, transform string) (*models.MediaMetadata, error) {
+	media := &models.MediaMetadata{}
+	err := mr.Pool().DB(ctx, true).
+		Where("parent_id = ? AND properties ->> 't' = ?", string(parentId), transform).
+		First(media).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+func (mr *mediaRepository) CountDerivatives(ctx context.Context, parentId types.MediaID) (int64, error) {
+	var count int64
+	err := mr.Pool().DB(ctx, true).Model(&models.MediaMetadata{}).
+		Where("parent_id = ? AND COALESCE(properties ->> 't', '') <> ''", string(parentId)).
+		Count(&count).Error
+	return count, err
+}
+
 func (mr *mediaRepository) GetByOwnerID(ctx context.Context, ownerId types.OwnerID, query string, page int32, limit int32) ([]*models.MediaMetadata, error) {
 	fileList := make([]*models.MediaMetadata, 0)
 	tx := mr.Pool().DB(ctx, true).Where(" owner_id = ? ", string(ownerId))
@@ -91,3 +124,167 @@ func (mr *mediaRepository) GetByOwnerID(ctx context.Context, ownerId types.Owner
 
 	return fileList, nil
 }
+
+// ListOriginalImages retrieves original images matching filter with IDs
+// after afterID, in ID order, so a walk can be resumed from the last ID seen.
+func (mr *mediaRepository) ListOriginalImages(ctx context.Context, filter *types.ThumbnailRegenerationFilter, afterID string, limit int) ([]*models.MediaMetadata, error) {
+	var media []*models.MediaMetadata
+	tx := mr.Pool().DB(ctx, true).
+		Where("COALESCE(parent_id, '') = '' AND mimetype LIKE ?", "image/%").
+		Where("id > ?", afterID)
+
+	if filter.OwnerID != "" {
+		tx = tx.Where("owner_id = ?", string(filter.OwnerID))
+	}
+	switch {
+	case filter.ContentType == "":
+	case strings.HasSuffix(filter.ContentType, "/"):
+		tx = tx.Where("mimetype LIKE ?", filter.ContentType+"%")
+	default:
+		tx = tx.Where("mimetype = ?", filter.ContentType)
+	}
+	if filter.CreatedAfter != nil {
+		tx = tx.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		tx = tx.Where("created_at < ?", *filter.CreatedBefore)
+	}
+
+	err := tx.Order("id ASC").Limit(limit).Find(&media).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// ListByOwner retrieves an owner's original media filed under folder or one
+// of its subfolders with IDs after afterID, in ID order. An empty folder
+// matches all of the owner's media and an empty owner every owner's.
+func (mr *mediaRepository) ListByOwner(ctx context.Context, ownerID types.OwnerID, folder string, afterID string, limit int) ([]*models.MediaMetadata, error) {
+	var media []*models.MediaMetadata
+	tx := mr.Pool().DB(ctx, true).
+		Where("COALESCE(parent_id, '') = ''").
+		Where("id > ?", afterID)
+	if ownerID != "" {
+		tx = tx.Where("owner_id = ?", string(ownerID))
+	}
+	if folder != "" {
+		tx = whereInFolder(tx, folder)
+	}
+
+	err := tx.Order("id ASC").Limit(limit).Find(&media).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// ListOriginalsWithDeleted retrieves original media, deleted or not, with
+// IDs after afterID, in ID order.
+func (mr *mediaRepository) ListOriginalsWithDeleted(ctx context.Context, afterID string, limit int) ([]*models.MediaMetadata, error) {
+	var media []*models.MediaMetadata
+	err := mr.Pool().DB(ctx, true).Unscoped().
+		Where("COALESCE(parent_id, '') = ''").
+		Where("id > ?", afterID).
+		Order("id ASC").Limit(limit).Find(&media).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// GetWithDeleted retrieves the media with the given IDs, deleted or not.
+func (mr *mediaRepository) GetWithDeleted(ctx context.Context, ids []string) ([]*models.MediaMetadata, error) {
+	var media []*models.MediaMetadata
+	err := mr.Pool().DB(ctx, true).Unscoped().Where("id IN ?", ids).Find(&media).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// ListByOwnerWithDeleted retrieves an owner's original media, deleted or
+// not, with IDs after afterID, in ID order.
+func (mr *mediaRepository) ListByOwnerWithDeleted(ctx context.Context, ownerID types.OwnerID, afterID string, limit int) ([]*models.MediaMetadata, error) {
+	var media []*models.MediaMetadata
+	err := mr.Pool().DB(ctx, true).Unscoped().
+		Where("COALESCE(parent_id, '') = ''").
+		Where("owner_id = ?", string(ownerID)).
+		Where("id > ?", afterID).
+		Order("id ASC").Limit(limit).Find(&media).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// GetByIDs retrieves the media with the given IDs in one query. IDs with no
+// record are left out.
+func (mr *mediaRepository) GetByIDs(ctx context.Context, ids []string) ([]*models.MediaMetadata, error) {
+	media := make([]*models.MediaMetadata, 0, len(ids))
+	if len(ids) == 0 {
+		return media, nil
+	}
+
+	err := mr.Pool().DB(ctx, true).Where("id IN ?", ids).Find(&media).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// whereInFolder limits tx to media filed under folder or one of its subfolders.
+func whereInFolder(tx *gorm.DB, folder string) *gorm.DB {
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(folder)
+	return tx.Where("(folder = ? OR folder LIKE ?)", folder, escaped+"/%")
+}
+
+// CountByHash counts the media, soft deleted or not, whose content has the
+// given hash.
+func (mr *mediaRepository) CountByHash(ctx context.Context, hash types.Base64Hash) (int64, error) {
+	var count int64
+	err := mr.Pool().DB(ctx, true).Unscoped().Model(&models.MediaMetadata{}).
+		Where("hash = ?", string(hash)).Count(&count).Error
+	return count, err
+}
+
+// PatchProperties applies patch to the properties of a media record in a
+// single statement, together with any other column updates, so that
+// concurrent patches of different keys all survive. It returns
+// gorm.ErrRecordNotFound when the record does not exist.
+func (mr *mediaRepository) PatchProperties(ctx context.Context, id string, patch models.PropertiesPatch, columns map[string]any) error {
+	set, err := json.Marshal(patch.Set)
+	if err != nil {
+		return err
+	}
+
+	expr := "(COALESCE(properties, '{}'::jsonb)"
+	args := make([]any, 0, len(patch.Remove)+1)
+	for _, key := range patch.Remove {
+		expr += " - ?::text"
+		args = append(args, key)
+	}
+	expr += ") || ?::jsonb"
+	args = append(args, string(set))
+
+	updates := map[string]any{"properties": gorm.Expr(expr, args...)}
+	for column, value := range columns {
+		updates[column] = value
+	}
+	result := mr.Pool().DB(ctx, false).Model(&models.MediaMetadata{}).
+		Where("id = ?", id).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
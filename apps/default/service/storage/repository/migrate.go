@@ -19,5 +19,14 @@ func Migrate(ctx context.Context, dbManager datastore.Manager, migrationPath str
 		&models.RetentionPolicy{},
 		&models.FileRetention{},
 		&models.StorageStats{},
+		&models.WebhookSubscription{},
+		&models.WebhookDelivery{},
+		&models.ThumbnailJob{},
+		&models.ThumbnailRegeneration{},
+		&models.ArchiveExtraction{},
+		&models.ShareLink{},
+		&models.AccessGrant{},
+		&models.UserDataExport{},
+		&models.UserDataErasure{},
 	)
 }
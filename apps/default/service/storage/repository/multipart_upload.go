@@ -17,6 +17,7 @@ type MultipartUploadRepository interface {
 	GetByMediaID(ctx context.Context, mediaID string) (*models.MultipartUpload, error)
 	UpdateState(ctx context.Context, uploadID string, state string) error
 	GetExpiredUploads(ctx context.Context, before time.Time) ([]*models.MultipartUpload, error)
+	EraseByOwner(ctx context.Context, ownerID string) ([]*models.MultipartUpload, error)
 }
 
 // NewMultipartUploadRepository creates a new multipart upload repository instance
@@ -69,3 +70,18 @@ func (r *multipartUploadRepository) GetExpiredUploads(ctx context.Context, befor
 	}
 	return uploads, nil
 }
+
+// EraseByOwner permanently deletes the uploads of ownerID, soft deleted or
+// not, and returns the deleted rows.
+func (r *multipartUploadRepository) EraseByOwner(ctx context.Context, ownerID string) ([]*models.MultipartUpload, error) {
+	var uploads []*models.MultipartUpload
+	err := r.Pool().DB(ctx, true).Unscoped().Where("owner_id = ?", ownerID).Find(&uploads).Error
+	if err != nil || len(uploads) == 0 {
+		return uploads, err
+	}
+	err = r.Pool().DB(ctx, false).Unscoped().Where("owner_id = ?", ownerID).Delete(&models.MultipartUpload{}).Error
+	if err != nil {
+		return nil, err
+	}
+	return uploads, nil
+}
@@ -15,6 +15,7 @@ type MultipartUploadPartRepository interface {
 	GetByUploadID(ctx context.Context, uploadID string) ([]*models.MultipartUploadPart, error)
 	GetPart(ctx context.Context, uploadID string, partNumber int) (*models.MultipartUploadPart, error)
 	DeleteByUploadID(ctx context.Context, uploadID string) error
+	EraseByUploadIDs(ctx context.Context, uploadIDs []string) ([]*models.MultipartUploadPart, error)
 }
 
 // NewMultipartUploadPartRepository creates a new multipart upload part repository instance
@@ -57,3 +58,21 @@ func (r *multipartUploadPartRepository) GetPart(ctx context.Context, uploadID st
 func (r *multipartUploadPartRepository) DeleteByUploadID(ctx context.Context, uploadID string) error {
 	return r.Pool().DB(ctx, true).Delete(&models.MultipartUploadPart{}, "upload_id = ?", uploadID).Error
 }
+
+// EraseByUploadIDs permanently deletes the parts of the given uploads, soft
+// deleted or not, and returns the deleted rows.
+func (r *multipartUploadPartRepository) EraseByUploadIDs(ctx context.Context, uploadIDs []string) ([]*models.MultipartUploadPart, error) {
+	var parts []*models.MultipartUploadPart
+	if len(uploadIDs) == 0 {
+		return parts, nil
+	}
+	err := r.Pool().DB(ctx, true).Unscoped().Where("upload_id IN ?", uploadIDs).Find(&parts).Error
+	if err != nil || len(parts) == 0 {
+		return parts, err
+	}
+	err = r.Pool().DB(ctx, false).Unscoped().Where("upload_id IN ?", uploadIDs).Delete(&models.MultipartUploadPart{}).Error
+	if err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
@@ -15,6 +15,7 @@ type RetentionPolicyRepository interface {
 	GetByID(ctx context.Context, policyID string) (*models.RetentionPolicy, error)
 	GetDefault(ctx context.Context) (*models.RetentionPolicy, error)
 	ListByOwner(ctx context.Context, ownerID string, limit, offset int) ([]*models.RetentionPolicy, int, error)
+	EraseUnusedByOwner(ctx context.Context, ownerID string) (int64, error)
 }
 
 // NewRetentionPolicyRepository creates a new retention policy repository instance
@@ -74,3 +75,13 @@ func (r *retentionPolicyRepository) ListByOwner(ctx context.Context, ownerID str
 
 	return policies, int(count), nil
 }
+
+// EraseUnusedByOwner permanently deletes the policies of ownerID that are
+// neither system policies nor applied to any file
+func (r *retentionPolicyRepository) EraseUnusedByOwner(ctx context.Context, ownerID string) (int64, error) {
+	result := r.Pool().DB(ctx, false).Unscoped().
+		Where("owner_id = ? AND is_system = ?", ownerID, false).
+		Where("id NOT IN (?)", r.Pool().DB(ctx, true).Unscoped().Model(&models.FileRetention{}).Select("policy_id")).
+		Delete(&models.RetentionPolicy{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/pitabwire/frame/v2/datastore"
+	"github.com/pitabwire/frame/v2/datastore/pool"
+	"github.com/pitabwire/frame/v2/workerpool"
+	"gorm.io/gorm"
+)
+
+// ShareLinkRepository defines the interface for share link operations
+type ShareLinkRepository interface {
+	datastore.BaseRepository[*models.ShareLink]
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.ShareLink, error)
+	ListByMedia(ctx context.Context, mediaID string, includeRevoked bool, limit, offset int) ([]*models.ShareLink, int, error)
+	RecordDownload(ctx context.Context, id string, at time.Time) (bool, error)
+	Touch(ctx context.Context, id string, at time.Time) error
+	RecordPasswordFailure(ctx context.Context, id string, maxAttempts int, lockUntil time.Time) error
+	ResetPasswordFailures(ctx context.Context, id string) error
+	EraseByOwner(ctx context.Context, ownerID string) (int64, error)
+}
+
+// NewShareLinkRepository creates a new share link repository instance
+func NewShareLinkRepository(ctx context.Context, dbPool pool.Pool, workMan workerpool.Manager) ShareLinkRepository {
+	repo := shareLinkRepository{
+		BaseRepository: datastore.NewBaseRepository[*models.ShareLink](
+			ctx, dbPool, workMan, func() *models.ShareLink { return &models.ShareLink{} },
+		),
+	}
+	return &repo
+}
+
+type shareLinkRepository struct {
+	datastore.BaseRepository[*models.ShareLink]
+}
+
+// GetByTokenHash retrieves the link holding a token. Tokens are resolved
+// before the tenant is known, so callers pass a system context.
+func (r *shareLinkRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.ShareLink, error) {
+	link := &models.ShareLink{}
+	err := r.Pool().DB(ctx, true).Where("token_hash = ?", tokenHash).First(link).Error
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// ListByMedia retrieves the links of a media item, newest first
+func (r *shareLinkRepository) ListByMedia(ctx context.Context, mediaID string, includeRevoked bool, limit, offset int) ([]*models.ShareLink, int, error) {
+	var links []*models.ShareLink
+	var count int64
+
+	query := r.Pool().DB(ctx, true).Model(&models.ShareLink{}).Where("media_id = ?", mediaID)
+	if !includeRevoked {
+		query = query.Where("revoked_at IS NULL")
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&links).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return links, int(count), nil
+}
+
+// RecordDownload counts one download against a link. It reports false when
+// the link was revoked or used up in the meantime, so concurrent requests
+// can never go over the download limit.
+func (r *shareLinkRepository) RecordDownload(ctx context.Context, id string, at time.Time) (bool, error) {
+	result := r.Pool().DB(ctx, false).Model(&models.ShareLink{}).
+		Where("id = ? AND revoked_at IS NULL AND (max_downloads = 0 OR download_count < max_downloads)", id).
+		Updates(map[string]any{
+			"download_count":   gorm.Expr("download_count + 1"),
+			"last_accessed_at": at,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Touch records when a link was last used
+func (r *shareLinkRepository) Touch(ctx context.Context, id string, at time.Time) error {
+	return r.Pool().DB(ctx, false).Model(&models.ShareLink{}).
+		Where("id = ?", id).
+		Update("last_accessed_at", at).Error
+}
+
+// RecordPasswordFailure counts a wrong password against a link in one
+// update, locking it until lockUntil once maxAttempts are reached.
+func (r *shareLinkRepository) RecordPasswordFailure(ctx context.Context, id string, maxAttempts int, lockUntil time.Time) error {
+	return r.Pool().DB(ctx, false).Model(&models.ShareLink{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"failed_attempts": gorm.Expr("failed_attempts + 1"),
+			"locked_until":    gorm.Expr("CASE WHEN failed_attempts + 1 >= ? THEN ?::timestamptz ELSE locked_until END", maxAttempts, lockUntil),
+		}).Error
+}
+
+// ResetPasswordFailures clears the wrong passwords counted against a link
+// once the right one is sent.
+func (r *shareLinkRepository) ResetPasswordFailures(ctx context.Context, id string) error {
+	return r.Pool().DB(ctx, false).Model(&models.ShareLink{}).
+		Where("id = ? AND failed_attempts > 0", id).
+		Updates(map[string]any{"failed_attempts": 0, "locked_until": nil}).Error
+}
+
+// EraseByOwner permanently deletes the share links created by ownerID.
+func (r *shareLinkRepository) EraseByOwner(ctx context.Context, ownerID string) (int64, error) {
+	result := r.Pool().DB(ctx, false).Unscoped().Where("owner_id = ?", ownerID).Delete(&models.ShareLink{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/frame/v2/datastore"
+	"github.com/pitabwire/frame/v2/datastore/pool"
+	"github.com/pitabwire/frame/v2/workerpool"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ThumbnailJobRepository defines the interface for thumbnail job operations
+type ThumbnailJobRepository interface {
+	datastore.BaseRepository[*models.ThumbnailJob]
+	GetByMediaID(ctx context.Context, mediaID string) (*models.ThumbnailJob, error)
+	ClaimDue(ctx context.Context, before, leaseUntil time.Time, limit int) ([]*models.ThumbnailJob, error)
+	CountPendingByTenant(ctx context.Context) ([]types.QueueDepth, error)
+}
+
+// NewThumbnailJobRepository creates a new thumbnail job repository instance
+func NewThumbnailJobRepository(ctx context.Context, dbPool pool.Pool, workMan workerpool.Manager) ThumbnailJobRepository {
+	repo := thumbnailJobRepository{
+		BaseRepository: datastore.NewBaseRepository[*models.ThumbnailJob](
+			ctx, dbPool, workMan, func() *models.ThumbnailJob { return &models.ThumbnailJob{} },
+		),
+	}
+	return &repo
+}
+
+type thumbnailJobRepository struct {
+	datastore.BaseRepository[*models.ThumbnailJob]
+}
+
+// GetByMediaID retrieves the job of a media item
+func (r *thumbnailJobRepository) GetByMediaID(ctx context.Context, mediaID string) (*models.ThumbnailJob, error) {
+	job := &models.ThumbnailJob{}
+	err := r.Pool().DB(ctx, true).Where("media_id = ?", mediaID).First(job).Error
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// ClaimDue retrieves pending jobs whose next attempt is due and moves their
+// next attempt to leaseUntil, so other replicas skip them while they are
+// attempted. Rows locked by a concurrent claim are skipped.
+func (r *thumbnailJobRepository) ClaimDue(ctx context.Context, before, leaseUntil time.Time, limit int) ([]*models.ThumbnailJob, error) {
+	var jobs []*models.ThumbnailJob
+	err := r.Pool().DB(ctx, false).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("state = ? AND next_attempt_at <= ?", types.ThumbnailJobPending, before).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&jobs).Error
+		if err != nil || len(jobs) == 0 {
+			return err
+		}
+
+		ids := make([]string, 0, len(jobs))
+		for _, job := range jobs {
+			ids = append(ids, job.ID)
+			job.NextAttemptAt = &leaseUntil
+		}
+		return tx.Model(&models.ThumbnailJob{}).Where("id IN ?", ids).
+			Update("next_attempt_at", leaseUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// CountPendingByTenant counts pending jobs per tenant and partition
+func (r *thumbnailJobRepository) CountPendingByTenant(ctx context.Context) ([]types.QueueDepth, error) {
+	var depths []types.QueueDepth
+	err := r.Pool().DB(ctx, true).Model(&models.ThumbnailJob{}).
+		Select("tenant_id, partition_id, count(*) AS depth").
+		Where("state = ?", types.ThumbnailJobPending).
+		Group("tenant_id, partition_id").
+		Scan(&depths).Error
+	if err != nil {
+		return nil, err
+	}
+	return depths, nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/frame/v2/datastore"
+	"github.com/pitabwire/frame/v2/datastore/pool"
+	"github.com/pitabwire/frame/v2/workerpool"
+)
+
+// ThumbnailRegenerationRepository defines the interface for thumbnail regeneration run operations
+type ThumbnailRegenerationRepository interface {
+	datastore.BaseRepository[*models.ThumbnailRegeneration]
+	Claim(ctx context.Context, id string, now, leaseUntil time.Time) (bool, error)
+	ListStalled(ctx context.Context, idleBefore time.Time, limit int) ([]*models.ThumbnailRegeneration, error)
+}
+
+// NewThumbnailRegenerationRepository creates a new thumbnail regeneration repository instance
+func NewThumbnailRegenerationRepository(ctx context.Context, dbPool pool.Pool, workMan workerpool.Manager) ThumbnailRegenerationRepository {
+	repo := thumbnailRegenerationRepository{
+		BaseRepository: datastore.NewBaseRepository[*models.ThumbnailRegeneration](
+			ctx, dbPool, workMan, func() *models.ThumbnailRegeneration { return &models.ThumbnailRegeneration{} },
+		),
+	}
+	return &repo
+}
+
+type thumbnailRegenerationRepository struct {
+	datastore.BaseRepository[*models.ThumbnailRegeneration]
+}
+
+// Claim leases a running run until leaseUntil unless another worker holds an
+// unexpired lease on it, reporting whether the lease was taken.
+func (r *thumbnailRegenerationRepository) Claim(ctx context.Context, id string, now, leaseUntil time.Time) (bool, error) {
+	result := r.Pool().DB(ctx, false).Model(&models.ThumbnailRegeneration{}).
+		Where("id = ? AND state = ? AND (lease_until IS NULL OR lease_until <= ?)", id, types.ThumbnailRegenerationRunning, now).
+		Update("lease_until", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ListStalled retrieves running runs nobody is working on: those whose lease
+// lapsed, or that were never leased and left untouched, before idleBefore.
+func (r *thumbnailRegenerationRepository) ListStalled(ctx context.Context, idleBefore time.Time, limit int) ([]*models.ThumbnailRegeneration, error) {
+	var runs []*models.ThumbnailRegeneration
+	err := r.Pool().DB(ctx, true).
+		Where("state = ? AND COALESCE(lease_until, modified_at) <= ?", types.ThumbnailRegenerationRunning, idleBefore).
+		Order("modified_at ASC").
+		Limit(limit).
+		Find(&runs).Error
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/frame/v2/datastore"
+	"github.com/pitabwire/frame/v2/datastore/pool"
+	"github.com/pitabwire/frame/v2/workerpool"
+)
+
+// UserDataErasureRepository defines the interface for user data erasure job operations
+type UserDataErasureRepository interface {
+	datastore.BaseRepository[*models.UserDataErasure]
+	GetRunningByProfile(ctx context.Context, profileID string) (*models.UserDataErasure, error)
+}
+
+// NewUserDataErasureRepository creates a new user data erasure repository instance
+func NewUserDataErasureRepository(ctx context.Context, dbPool pool.Pool, workMan workerpool.Manager) UserDataErasureRepository {
+	repo := userDataErasureRepository{
+		BaseRepository: datastore.NewBaseRepository[*models.UserDataErasure](
+			ctx, dbPool, workMan, func() *models.UserDataErasure { return &models.UserDataErasure{} },
+		),
+	}
+	return &repo
+}
+
+type userDataErasureRepository struct {
+	datastore.BaseRepository[*models.UserDataErasure]
+}
+
+// GetRunningByProfile retrieves the running erasure job of profileID, if any.
+func (r *userDataErasureRepository) GetRunningByProfile(ctx context.Context, profileID string) (*models.UserDataErasure, error) {
+	var jobs []*models.UserDataErasure
+	err := r.Pool().DB(ctx, true).
+		Where("profile_id = ? AND state = ?", profileID, types.UserDataErasureRunning).
+		Order("created_at ASC").
+		Limit(1).
+		Find(&jobs).Error
+	if err != nil || len(jobs) == 0 {
+		return nil, err
+	}
+	return jobs[0], nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/frame/v2/datastore"
+	"github.com/pitabwire/frame/v2/datastore/pool"
+	"github.com/pitabwire/frame/v2/workerpool"
+)
+
+// UserDataExportRepository defines the interface for user data export job operations
+type UserDataExportRepository interface {
+	datastore.BaseRepository[*models.UserDataExport]
+	GetRunningByProfile(ctx context.Context, profileID string) (*models.UserDataExport, error)
+	ListResultMediaIDs(ctx context.Context, profileID string) ([]string, error)
+	EraseByProfile(ctx context.Context, profileID string) (int64, error)
+}
+
+// NewUserDataExportRepository creates a new user data export repository instance
+func NewUserDataExportRepository(ctx context.Context, dbPool pool.Pool, workMan workerpool.Manager) UserDataExportRepository {
+	repo := userDataExportRepository{
+		BaseRepository: datastore.NewBaseRepository[*models.UserDataExport](
+			ctx, dbPool, workMan, func() *models.UserDataExport { return &models.UserDataExport{} },
+		),
+	}
+	return &repo
+}
+
+type userDataExportRepository struct {
+	datastore.BaseRepository[*models.UserDataExport]
+}
+
+// GetRunningByProfile retrieves the running export job of profileID, if any.
+func (r *userDataExportRepository) GetRunningByProfile(ctx context.Context, profileID string) (*models.UserDataExport, error) {
+	var jobs []*models.UserDataExport
+	err := r.Pool().DB(ctx, true).
+		Where("profile_id = ? AND state = ?", profileID, types.UserDataExportRunning).
+		Order("created_at ASC").
+		Limit(1).
+		Find(&jobs).Error
+	if err != nil || len(jobs) == 0 {
+		return nil, err
+	}
+	return jobs[0], nil
+}
+
+// ListResultMediaIDs retrieves the archives earlier exports of profileID
+// produced.
+func (r *userDataExportRepository) ListResultMediaIDs(ctx context.Context, profileID string) ([]string, error) {
+	var ids []string
+	err := r.Pool().DB(ctx, true).Model(&models.UserDataExport{}).
+		Where("profile_id = ? AND result_media_id <> ''", profileID).
+		Pluck("result_media_id", &ids).Error
+	return ids, err
+}
+
+// EraseByProfile permanently deletes the export jobs of profileID.
+func (r *userDataExportRepository) EraseByProfile(ctx context.Context, profileID string) (int64, error) {
+	result := r.Pool().DB(ctx, false).Unscoped().Where("profile_id = ?", profileID).Delete(&models.UserDataExport{})
+	return result.RowsAffected, result.Error
+}
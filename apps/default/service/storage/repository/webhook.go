@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/storage/models"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/frame/v2/datastore"
+	"github.com/pitabwire/frame/v2/datastore/pool"
+	"github.com/pitabwire/frame/v2/workerpool"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WebhookSubscriptionRepository defines the interface for webhook subscription operations
+type WebhookSubscriptionRepository interface {
+	datastore.BaseRepository[*models.WebhookSubscription]
+	ListActive(ctx context.Context) ([]*models.WebhookSubscription, error)
+	List(ctx context.Context, limit, offset int) ([]*models.WebhookSubscription, int, error)
+	ReassignCreator(ctx context.Context, from, to string) (int64, error)
+}
+
+// NewWebhookSubscriptionRepository creates a new webhook subscription repository instance
+func NewWebhookSubscriptionRepository(ctx context.Context, dbPool pool.Pool, workMan workerpool.Manager) WebhookSubscriptionRepository {
+	repo := webhookSubscriptionRepository{
+		BaseRepository: datastore.NewBaseRepository[*models.WebhookSubscription](
+			ctx, dbPool, workMan, func() *models.WebhookSubscription { return &models.WebhookSubscription{} },
+		),
+	}
+	return &repo
+}
+
+type webhookSubscriptionRepository struct {
+	datastore.BaseRepository[*models.WebhookSubscription]
+}
+
+// ListActive retrieves the active subscriptions visible to the caller's tenant
+func (r *webhookSubscriptionRepository) ListActive(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+	err := r.Pool().DB(ctx, true).Where("active = ?", true).
+		Order("created_at ASC").
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// List retrieves subscriptions with pagination
+func (r *webhookSubscriptionRepository) List(ctx context.Context, limit, offset int) ([]*models.WebhookSubscription, int, error) {
+	var subscriptions []*models.WebhookSubscription
+	var count int64
+
+	query := r.Pool().DB(ctx, true).Model(&models.WebhookSubscription{})
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return subscriptions, int(count), nil
+}
+
+// WebhookDeliveryRepository defines the interface for webhook delivery operations
+type WebhookDeliveryRepository interface {
+	datastore.BaseRepository[*models.WebhookDelivery]
+	CreateOnce(ctx context.Context, delivery *models.WebhookDelivery) (bool, error)
+	ListBySubscription(ctx context.Context, subscriptionID, state string, limit, offset int) ([]*models.WebhookDelivery, int, error)
+	ClaimDue(ctx context.Context, before, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error)
+	CountPendingByTenant(ctx context.Context) ([]types.QueueDepth, error)
+}
+
+// NewWebhookDeliveryRepository creates a new webhook delivery repository instance
+func NewWebhookDeliveryRepository(ctx context.Context, dbPool pool.Pool, workMan workerpool.Manager) WebhookDeliveryRepository {
+	repo := webhookDeliveryRepository{
+		BaseRepository: datastore.NewBaseRepository[*models.WebhookDelivery](
+			ctx, dbPool, workMan, func() *models.WebhookDelivery { return &models.WebhookDelivery{} },
+		),
+	}
+	return &repo
+}
+
+type webhookDeliveryRepository struct {
+	datastore.BaseRepository[*models.WebhookDelivery]
+}
+
+// CreateOnce inserts a delivery unless the subscription already has one for
+// the event, reporting whether it was inserted.
+func (r *webhookDeliveryRepository) CreateOnce(ctx context.Context, delivery *models.WebhookDelivery) (bool, error) {
+	result := r.Pool().DB(ctx, false).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "event_id"}},
+		DoNothing: true,
+	}).Create(delivery)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListBySubscription retrieves the delivery history of a subscription, most recent first.
+// An empty state returns deliveries in any state.
+func (r *webhookDeliveryRepository) ListBySubscription(ctx context.Context, subscriptionID, state string, limit, offset int) ([]*models.WebhookDelivery, int, error) {
+	var deliveries []*models.WebhookDelivery
+	var count int64
+
+	query := r.Pool().DB(ctx, true).Model(&models.WebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+	if state != "" {
+		query = query.Where("state = ?", state)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, int(count), nil
+}
+
+// ClaimDue retrieves pending deliveries whose next attempt is due and moves
+// their next attempt to leaseUntil, so other replicas skip them while they
+// are attempted. Rows locked by a concurrent claim are skipped.
+func (r *webhookDeliveryRepository) ClaimDue(ctx context.Context, before, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := r.Pool().DB(ctx, false).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("state = ? AND next_attempt_at <= ?", types.WebhookDeliveryPending, before).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]string, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+			delivery.NextAttemptAt = &leaseUntil
+		}
+		return tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).
+			Update("next_attempt_at", leaseUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// CountPendingByTenant counts pending deliveries per tenant and partition
+func (r *webhookDeliveryRepository) CountPendingByTenant(ctx context.Context) ([]types.QueueDepth, error) {
+	var depths []types.QueueDepth
+	err := r.Pool().DB(ctx, true).Model(&models.WebhookDelivery{}).
+		Select("tenant_id, partition_id, count(*) AS depth").
+		Where("state = ?", types.WebhookDeliveryPending).
+		Group("tenant_id, partition_id").
+		Scan(&depths).Error
+	if err != nil {
+		return nil, err
+	}
+	return depths, nil
+}
+
+// ReassignCreator replaces the creator of the subscriptions created by from
+func (r *webhookSubscriptionRepository) ReassignCreator(ctx context.Context, from, to string) (int64, error) {
+	result := r.Pool().DB(ctx, false).Model(&models.WebhookSubscription{}).
+		Where("created_by = ?", from).Update("created_by", to)
+	return result.RowsAffected, result.Error
+}
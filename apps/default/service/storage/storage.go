@@ -16,26 +16,164 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/antinvestor/service-files/apps/default/service/types"
 	"github.com/pitabwire/frame/v2/data"
 	"github.com/pitabwire/frame/v2/workerpool"
 )
 
+// ErrOwnershipChanged is returned by TransferMediaOwnership when some of the
+// media no longer belong to the owner they are transferred from.
+var ErrOwnershipChanged = errors.New("media changed owner during the transfer")
+
 type Database interface {
 	MediaRepository
 	ThumbnailsRepository
+	ThumbnailJobRepository
+	ThumbnailRegenerationRepository
+	ArchiveExtractionRepository
+	ShareLinkRepository
+	AccessGrantRepository
+	UserDataExportRepository
+	UserDataErasureRepository
+	WebhookRepository
+	QueueDepthRepository
+	Locker
 }
 
 type MediaRepository interface {
 	StoreMediaMetadata(ctx context.Context, mediaMetadata *types.MediaMetadata) error
 	GetMediaMetadata(ctx context.Context, mediaID types.MediaID) (*types.MediaMetadata, error)
+	GetMediaMetadataBatch(ctx context.Context, mediaIDs []types.MediaID) ([]*types.MediaMetadata, error)
 	GetMediaMetadataByHash(ctx context.Context, ownerID types.OwnerID, mediaHash types.Base64Hash) (*types.MediaMetadata, error)
+	StorePlaceholder(ctx context.Context, mediaID types.MediaID, placeholder *types.Placeholder) error
+	StoreScanResult(ctx context.Context, mediaID types.MediaID, status types.ScanStatus, signature string) error
+	DeleteMedia(ctx context.Context, mediaID types.MediaID) error
 	Search(ctx context.Context, query *data.SearchQuery) (workerpool.JobResultPipe[*types.MediaMetadata], error)
+	ListOwnerMedia(ctx context.Context, ownerID types.OwnerID, folder string, afterID string, limit int) ([]*types.MediaMetadata, error)
+	TransferMediaOwnership(ctx context.Context, mediaIDs []types.MediaID, fromOwner, toOwner types.OwnerID) error
+	ListMediaOwnership(ctx context.Context, afterID string, limit int) ([]*types.MediaOwnership, error)
+	ListMediaOwnershipByID(ctx context.Context, mediaIDs []types.MediaID) ([]*types.MediaOwnership, error)
 }
 
 type ThumbnailsRepository interface {
 	StoreThumbnail(ctx context.Context, thumbnailMetadata *types.ThumbnailMetadata) error
 	GetThumbnail(ctx context.Context, mediaID types.MediaID, width, height int, resizeMethod string) (*types.ThumbnailMetadata, error)
 	GetThumbnails(ctx context.Context, mediaID types.MediaID) ([]*types.ThumbnailMetadata, error)
+	GetDerivative(ctx context.Context, mediaID types.MediaID, transform string) (*types.ThumbnailMetadata, error)
+	CountDerivatives(ctx context.Context, mediaID types.MediaID) (int64, error)
+}
+
+// ThumbnailJobRepository persists the state of thumbnail generation jobs.
+type ThumbnailJobRepository interface {
+	StoreThumbnailJob(ctx context.Context, job *types.ThumbnailJob) error
+	GetThumbnailJob(ctx context.Context, mediaID types.MediaID) (*types.ThumbnailJob, error)
+	UpdateThumbnailJob(ctx context.Context, job *types.ThumbnailJob) error
+	ClaimDueThumbnailJobs(ctx context.Context, before, leaseUntil time.Time, limit int) ([]*types.ThumbnailJob, error)
+}
+
+// ThumbnailRegenerationRepository persists bulk regeneration runs and lists
+// the images they walk.
+type ThumbnailRegenerationRepository interface {
+	StoreThumbnailRegeneration(ctx context.Context, run *types.ThumbnailRegeneration) error
+	GetThumbnailRegeneration(ctx context.Context, runID string) (*types.ThumbnailRegeneration, error)
+	UpdateThumbnailRegeneration(ctx context.Context, run *types.ThumbnailRegeneration) error
+	ClaimThumbnailRegeneration(ctx context.Context, runID string, leaseUntil time.Time) (bool, error)
+	ListStalledThumbnailRegenerations(ctx context.Context, idleBefore time.Time, limit int) ([]*types.ThumbnailRegeneration, error)
+	ListRegenerationCandidates(ctx context.Context, filter *types.ThumbnailRegenerationFilter, afterID string, limit int) ([]*types.MediaMetadata, error)
+}
+
+// QueueDepthRepository counts the pending work of the background queues per
+// tenant. It reads across tenants, so callers use a system context.
+type QueueDepthRepository interface {
+	CountPendingThumbnailJobs(ctx context.Context) ([]types.QueueDepth, error)
+	CountPendingWebhookDeliveries(ctx context.Context) ([]types.QueueDepth, error)
+}
+
+// Locker takes locks shared by every replica of the service. Release must
+// be called once a lock that was acquired is no longer needed.
+type Locker interface {
+	TryAdvisoryLock(ctx context.Context, name string) (release func(), acquired bool, err error)
+}
+
+// ArchiveExtractionRepository persists archive extraction jobs.
+type ArchiveExtractionRepository interface {
+	StoreArchiveExtraction(ctx context.Context, job *types.ArchiveExtraction) error
+	GetArchiveExtraction(ctx context.Context, jobID string) (*types.ArchiveExtraction, error)
+	UpdateArchiveExtraction(ctx context.Context, job *types.ArchiveExtraction) error
+}
+
+// UserDataExportRepository persists user data export jobs.
+type UserDataExportRepository interface {
+	StoreUserDataExport(ctx context.Context, job *types.UserDataExport) error
+	GetUserDataExport(ctx context.Context, jobID string) (*types.UserDataExport, error)
+	GetRunningUserDataExport(ctx context.Context, profileID types.OwnerID) (*types.UserDataExport, error)
+	ListUserDataExportResults(ctx context.Context, profileID types.OwnerID) ([]types.MediaID, error)
+	UpdateUserDataExport(ctx context.Context, job *types.UserDataExport) error
+}
+
+// UserDataErasureRepository persists user data erasure jobs and removes the
+// records of an erased profile for good.
+type UserDataErasureRepository interface {
+	StoreUserDataErasure(ctx context.Context, job *types.UserDataErasure) error
+	GetUserDataErasure(ctx context.Context, jobID string) (*types.UserDataErasure, error)
+	GetRunningUserDataErasure(ctx context.Context, profileID types.OwnerID) (*types.UserDataErasure, error)
+	UpdateUserDataErasure(ctx context.Context, job *types.UserDataErasure) error
+	// ListOwnerMediaWithDeleted lists the original media of an owner,
+	// deleted or not, with IDs after afterID, in ID order.
+	ListOwnerMediaWithDeleted(ctx context.Context, ownerID types.OwnerID, afterID string, limit int) ([]*types.MediaMetadata, error)
+	// EraseMedia permanently deletes media with their thumbnails,
+	// derivatives, versions, retention, share links and thumbnail jobs.
+	EraseMedia(ctx context.Context, mediaIDs []types.MediaID) (*types.ErasedMedia, error)
+	// CountBlobReferences counts the media and versions still stored under
+	// a content hash.
+	CountBlobReferences(ctx context.Context, hash types.Base64Hash) (int64, error)
+	// EraseOwnerUploads permanently deletes the multipart uploads of a
+	// profile and returns the storage paths of their parts.
+	EraseOwnerUploads(ctx context.Context, ownerID types.OwnerID) (int64, []string, error)
+	// EraseOwnerRecords deletes the jobs, share links and unused retention
+	// policies of a profile and puts pseudonym in its place on the records
+	// it left on others' files, returning how many were deleted and
+	// anonymized.
+	EraseOwnerRecords(ctx context.Context, ownerID types.OwnerID, pseudonym string) (int64, int64, error)
+}
+
+// ShareLinkRepository persists public share links to files.
+type ShareLinkRepository interface {
+	StoreShareLink(ctx context.Context, link *types.ShareLink) error
+	GetShareLink(ctx context.Context, linkID string) (*types.ShareLink, error)
+	GetShareLinkByTokenHash(ctx context.Context, tokenHash string) (*types.ShareLink, error)
+	ListShareLinks(ctx context.Context, mediaID types.MediaID, includeRevoked bool, limit, offset int) ([]*types.ShareLink, int, error)
+	RevokeShareLink(ctx context.Context, linkID string, at time.Time) error
+	RecordShareLinkDownload(ctx context.Context, linkID string, at time.Time) (bool, error)
+	TouchShareLink(ctx context.Context, linkID string, at time.Time) error
+	RecordShareLinkPasswordFailure(ctx context.Context, linkID string, maxAttempts int, lockUntil time.Time) error
+	ResetShareLinkPasswordFailures(ctx context.Context, linkID string) error
+}
+
+// AccessGrantRepository persists the records of access granted in Keto.
+// Grants are looked up by their object whoever asks, so callers reading
+// across tenants use a system context.
+type AccessGrantRepository interface {
+	StoreAccessGrant(ctx context.Context, grant *types.AccessGrant) error
+	ListAccessGrants(ctx context.Context, namespace string, objectIDs []string) ([]*types.AccessGrant, error)
+	ListExpiredAccessGrants(ctx context.Context, before time.Time, limit int) ([]*types.AccessGrant, error)
+	DeleteAccessGrants(ctx context.Context, namespace, objectID, principalKind, principalID string, relations []string) error
+	DeleteAccessGrant(ctx context.Context, grantID string) error
+}
+
+// WebhookRepository persists webhook subscriptions and their delivery history.
+type WebhookRepository interface {
+	StoreWebhookSubscription(ctx context.Context, subscription *types.WebhookSubscription) error
+	GetWebhookSubscription(ctx context.Context, subscriptionID string) (*types.WebhookSubscription, error)
+	ListWebhookSubscriptions(ctx context.Context, limit, offset int) ([]*types.WebhookSubscription, int, error)
+	ListActiveWebhookSubscriptions(ctx context.Context) ([]*types.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, subscriptionID string) error
+
+	StoreWebhookDelivery(ctx context.Context, delivery *types.WebhookDelivery) (bool, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *types.WebhookDelivery) error
+	ListWebhookDeliveries(ctx context.Context, subscriptionID, state string, limit, offset int) ([]*types.WebhookDelivery, int, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, before, leaseUntil time.Time, limit int) ([]*types.WebhookDelivery, error)
 }
@@ -19,14 +19,23 @@ import (
 )
 
 type ServiceResources struct {
-	MediaRepository         repository.MediaRepository
-	AuditRepository         repository.MediaAuditRepository
-	MultipartUploadRepo     repository.MultipartUploadRepository
-	MultipartUploadPartRepo repository.MultipartUploadPartRepository
-	FileVersionRepo         repository.FileVersionRepository
-	RetentionPolicyRepo     repository.RetentionPolicyRepository
-	FileRetentionRepo       repository.FileRetentionRepository
-	StorageStatsRepo        repository.StorageStatsRepository
+	MediaRepository           repository.MediaRepository
+	AuditRepository           repository.MediaAuditRepository
+	MultipartUploadRepo       repository.MultipartUploadRepository
+	MultipartUploadPartRepo   repository.MultipartUploadPartRepository
+	FileVersionRepo           repository.FileVersionRepository
+	RetentionPolicyRepo       repository.RetentionPolicyRepository
+	FileRetentionRepo         repository.FileRetentionRepository
+	StorageStatsRepo          repository.StorageStatsRepository
+	WebhookSubscriptionRepo   repository.WebhookSubscriptionRepository
+	WebhookDeliveryRepo       repository.WebhookDeliveryRepository
+	ThumbnailJobRepo          repository.ThumbnailJobRepository
+	ThumbnailRegenerationRepo repository.ThumbnailRegenerationRepository
+	ArchiveExtractionRepo     repository.ArchiveExtractionRepository
+	ShareLinkRepo             repository.ShareLinkRepository
+	AccessGrantRepo           repository.AccessGrantRepository
+	UserDataExportRepo        repository.UserDataExportRepository
+	UserDataErasureRepo       repository.UserDataErasureRepository
 }
 
 type BaseTestSuite struct {
@@ -108,14 +117,23 @@ func (bs *BaseTestSuite) CreateService(t *testing.T, depOpts *definition.Depende
 	dbPool := dbManager.GetPool(ctx, datastore.DefaultPoolName)
 
 	deps := ServiceResources{
-		MediaRepository:         repository.NewMediaRepository(ctx, dbPool, workMan),
-		AuditRepository:         repository.NewMediaAuditRepository(ctx, dbPool, workMan),
-		MultipartUploadRepo:     repository.NewMultipartUploadRepository(ctx, dbPool, workMan),
-		MultipartUploadPartRepo: repository.NewMultipartUploadPartRepository(ctx, dbPool, workMan),
-		FileVersionRepo:         repository.NewFileVersionRepository(ctx, dbPool, workMan),
-		RetentionPolicyRepo:     repository.NewRetentionPolicyRepository(ctx, dbPool, workMan),
-		FileRetentionRepo:       repository.NewFileRetentionRepository(ctx, dbPool, workMan),
-		StorageStatsRepo:        repository.NewStorageStatsRepository(ctx, dbPool, workMan),
+		MediaRepository:           repository.NewMediaRepository(ctx, dbPool, workMan),
+		AuditRepository:           repository.NewMediaAuditRepository(ctx, dbPool, workMan),
+		MultipartUploadRepo:       repository.NewMultipartUploadRepository(ctx, dbPool, workMan),
+		MultipartUploadPartRepo:   repository.NewMultipartUploadPartRepository(ctx, dbPool, workMan),
+		FileVersionRepo:           repository.NewFileVersionRepository(ctx, dbPool, workMan),
+		RetentionPolicyRepo:       repository.NewRetentionPolicyRepository(ctx, dbPool, workMan),
+		FileRetentionRepo:         repository.NewFileRetentionRepository(ctx, dbPool, workMan),
+		StorageStatsRepo:          repository.NewStorageStatsRepository(ctx, dbPool, workMan),
+		WebhookSubscriptionRepo:   repository.NewWebhookSubscriptionRepository(ctx, dbPool, workMan),
+		WebhookDeliveryRepo:       repository.NewWebhookDeliveryRepository(ctx, dbPool, workMan),
+		ThumbnailJobRepo:          repository.NewThumbnailJobRepository(ctx, dbPool, workMan),
+		ThumbnailRegenerationRepo: repository.NewThumbnailRegenerationRepository(ctx, dbPool, workMan),
+		ArchiveExtractionRepo:     repository.NewArchiveExtractionRepository(ctx, dbPool, workMan),
+		ShareLinkRepo:             repository.NewShareLinkRepository(ctx, dbPool, workMan),
+		AccessGrantRepo:           repository.NewAccessGrantRepository(ctx, dbPool, workMan),
+		UserDataExportRepo:        repository.NewUserDataExportRepository(ctx, dbPool, workMan),
+		UserDataErasureRepo:       repository.NewUserDataErasureRepository(ctx, dbPool, workMan),
 	}
 
 	svc.Init(ctx, frame.WithRegisterEvents(
@@ -15,7 +15,10 @@
 package types
 
 import (
+	"fmt"
+	"math"
 	"sync"
+	"time"
 
 	"github.com/antinvestor/service-files/apps/default/config"
 )
@@ -57,7 +60,59 @@ type MediaMetadata struct {
 	ThumbnailSize     *ThumbnailSize
 	ServerName        string
 	IsPublic          bool
-	Encryption        *EncryptionInfo
+	// Folder is the slash separated path the media is filed under, such as
+	// invoices/2026; empty for media at the root.
+	Folder     string
+	Encryption *EncryptionInfo
+	// Transform is the canonical key of the image transform that produced
+	// this derivative; empty for originals and plain thumbnails.
+	Transform string
+	// Exif holds the image metadata extracted at upload when the tenant
+	// keeps a copy of it; the stored content no longer carries it.
+	Exif map[string]string
+	// Properties describes the content as read from its headers at upload.
+	Properties *MediaProperties
+	// Placeholder is computed for images by the thumbnail queue.
+	Placeholder *Placeholder
+	// DeclaredContentType is the type the uploader declared when it was
+	// replaced by the type detected from the content.
+	DeclaredContentType ContentType
+	// ScanStatus is empty for media stored while scanning was disabled.
+	ScanStatus ScanStatus
+	// ScanSignature names the malware found in infected media.
+	ScanSignature string
+}
+
+// ScanStatus is the outcome of scanning media for malware.
+type ScanStatus string
+
+// Malware scan statuses
+const (
+	ScanStatusPending  ScanStatus = "pending"
+	ScanStatusClean    ScanStatus = "clean"
+	ScanStatusInfected ScanStatus = "infected"
+	ScanStatusFailed   ScanStatus = "failed"
+)
+
+// Placeholder lets clients render an image preview before any thumbnail has
+// loaded.
+type Placeholder struct {
+	BlurHash string
+	// DominantColor is formatted as #rrggbb.
+	DominantColor string
+}
+
+// MediaProperties are intrinsic properties of media content. Fields that do
+// not apply to the kind of media are zero.
+type MediaProperties struct {
+	Format         string
+	Width          int
+	Height         int
+	ColorSpace     string
+	DurationMillis int64
+	Codecs         []string
+	PageCount      int
+	Title          string
 }
 
 // RemoteRequestResult is used for broadcasting the result of a request for a remote file to routines waiting on the condition
@@ -108,6 +163,95 @@ const Crop = "crop"
 // Scale indicates we should scale the thumbnail on resize
 const Scale = "scale"
 
+// Image formats a transform can produce
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatWebP = "webp"
+)
+
+// DefaultTransformQuality is the JPEG quality used when none is requested
+const DefaultTransformQuality = 85
+
+// TransformSizeStep is the granularity of stored derivative sizes in pixels
+const TransformSizeStep = 16
+
+// ImageTransform describes an image derivative generated on request.
+// Width and Height are CSS pixels; the rendered size is multiplied by DPR.
+type ImageTransform struct {
+	Width        int
+	Height       int
+	ResizeMethod string
+	// Rotate is a clockwise rotation in degrees: 0, 90, 180 or 270
+	Rotate int
+	Format string
+	// Quality applies to JPEG output only; PNG and WebP are lossless
+	Quality int
+	// FocalX and FocalY position the crop window as fractions of the source
+	FocalX float64
+	FocalY float64
+	DPR    float64
+}
+
+// PixelSize returns the rendered width and height after applying DPR.
+func (t *ImageTransform) PixelSize() (int, int) {
+	return int(math.Round(float64(t.Width) * t.DPR)), int(math.Round(float64(t.Height) * t.DPR))
+}
+
+// Normalised returns the transform a stored derivative is generated for: the
+// rendered size rounded up to a multiple of TransformSizeStep at a DPR of 1,
+// and the focal point rounded to whole percents. Requests that normalise to
+// the same transform share one derivative.
+func (t *ImageTransform) Normalised() *ImageTransform {
+	n := *t
+	w, h := t.PixelSize()
+	n.Width, n.Height, n.DPR = roundUpToStep(w), roundUpToStep(h), 1
+	n.FocalX, n.FocalY = 0.5, 0.5
+	if t.ResizeMethod == Crop {
+		n.FocalX, n.FocalY = math.Round(t.FocalX*100)/100, math.Round(t.FocalY*100)/100
+	}
+	return &n
+}
+
+func roundUpToStep(size int) int {
+	return (size + TransformSizeStep - 1) / TransformSizeStep * TransformSizeStep
+}
+
+// IsPlainThumbnail reports whether the transform only resizes to a JPEG at
+// the default quality, which the regular thumbnail pipeline already serves.
+func (t *ImageTransform) IsPlainThumbnail() bool {
+	centred := t.ResizeMethod != Crop || (t.FocalX == 0.5 && t.FocalY == 0.5)
+	return t.Rotate == 0 && t.Format == FormatJPEG && t.Quality == DefaultTransformQuality && centred && t.DPR == 1
+}
+
+// Key returns a canonical string identifying the derivative, used to find a
+// previously generated copy. Options that do not affect the output, such as
+// quality for lossless formats, are left out.
+func (t *ImageTransform) Key() string {
+	w, h := t.PixelSize()
+	quality := 0
+	if t.Format == FormatJPEG {
+		quality = t.Quality
+	}
+	focalX, focalY := 50, 50
+	if t.ResizeMethod == Crop {
+		focalX, focalY = int(math.Round(t.FocalX*100)), int(math.Round(t.FocalY*100))
+	}
+	return fmt.Sprintf("%dx%d:%s:r%d:%s:q%d:f%d,%d", w, h, t.ResizeMethod, t.Rotate, t.Format, quality, focalX, focalY)
+}
+
+// ContentType returns the MIME type of the transform output.
+func (t *ImageTransform) ContentType() ContentType {
+	switch t.Format {
+	case FormatPNG:
+		return "image/png"
+	case FormatWebP:
+		return "image/webp"
+	default:
+		return "image/jpeg"
+	}
+}
+
 // EncryptionInfo captures metadata required to decrypt content at rest.
 type EncryptionInfo struct {
 	Version         int
@@ -117,3 +261,326 @@ type EncryptionInfo struct {
 	WrappedKeyNonce string
 	NoncePrefix     string
 }
+
+// Webhook delivery states
+const (
+	WebhookDeliveryPending    = "pending"
+	WebhookDeliveryDelivered  = "delivered"
+	WebhookDeliveryDeadLetter = "dead_letter"
+)
+
+// WebhookSubscription is a tenant endpoint receiving file lifecycle events
+type WebhookSubscription struct {
+	ID          string
+	URL         string
+	Description string
+	// EventTypes holds LifecycleEventType names; empty means all events
+	EventTypes []string
+	// SealedSecret is the signing secret encrypted with the storage phrase
+	SealedSecret string
+	Active       bool
+	CreatedBy    string
+	CreatedAt    time.Time
+}
+
+// WebhookDelivery tracks the delivery of one lifecycle event to one subscription
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	Payload        []byte
+	State          string
+	Attempts       int
+	LastStatusCode int
+	LastError      string
+	NextAttemptAt  *time.Time
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+}
+
+// Thumbnail job states
+const (
+	ThumbnailJobPending    = "pending"
+	ThumbnailJobCompleted  = "completed"
+	ThumbnailJobDeadLetter = "dead_letter"
+)
+
+// ThumbnailJob tracks the generation of the derivatives of one media item
+type ThumbnailJob struct {
+	ID            string
+	MediaID       MediaID
+	State         string
+	Attempts      int
+	LastError     string
+	NextAttemptAt *time.Time
+	CompletedAt   *time.Time
+	// TenantID and PartitionID scope retries, which run without caller claims
+	TenantID    string
+	PartitionID string
+	CreatedAt   time.Time
+	ModifiedAt  time.Time
+}
+
+// Thumbnail regeneration run states
+const (
+	ThumbnailRegenerationRunning   = "running"
+	ThumbnailRegenerationCompleted = "completed"
+	ThumbnailRegenerationFailed    = "failed"
+)
+
+// ThumbnailRegenerationFilter selects the original images a regeneration run
+// walks. Zero fields do not filter.
+type ThumbnailRegenerationFilter struct {
+	OwnerID OwnerID
+	// ContentType matches exactly, or as a prefix when it ends in "/"
+	ContentType   string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// ThumbnailRegeneration is a bulk run queueing missing thumbnail sizes of
+// existing images
+type ThumbnailRegeneration struct {
+	ID            string
+	Filter        ThumbnailRegenerationFilter
+	RemoveStale   bool
+	RatePerSecond int
+	State         string
+	// Checkpoint is the ID of the last media processed; media IDs sort by
+	// creation, so a resumed run continues after it
+	Checkpoint  string
+	Scanned     int64
+	Enqueued    int64
+	Removed     int64
+	LastError   string
+	CompletedAt *time.Time
+	// LeaseUntil is when the worker holding a running run must next renew
+	// it; a running run past its lease was interrupted
+	LeaseUntil  *time.Time
+	TenantID    string
+	PartitionID string
+	CreatedAt   time.Time
+	ModifiedAt  time.Time
+}
+
+// Archive extraction job states
+const (
+	ArchiveExtractionRunning   = "running"
+	ArchiveExtractionCompleted = "completed"
+	ArchiveExtractionFailed    = "failed"
+)
+
+// ArchiveExtraction is a job expanding an uploaded zip or tar.gz archive
+// into individual media under a folder
+type ArchiveExtraction struct {
+	ID            string
+	OwnerID       OwnerID
+	SourceMediaID MediaID
+	Folder        string
+	State         string
+	Entries       int64
+	Extracted     int64
+	Skipped       int64
+	Failed        int64
+	// ExtractedBytes counts the uncompressed bytes read from the archive
+	ExtractedBytes int64
+	// Failures maps the archive path of entries that were not extracted to
+	// the reason
+	Failures    map[string]string
+	LastError   string
+	CompletedAt *time.Time
+	CreatedAt   time.Time
+	ModifiedAt  time.Time
+}
+
+// User data export job states
+const (
+	UserDataExportRunning   = "running"
+	UserDataExportCompleted = "completed"
+	UserDataExportFailed    = "failed"
+)
+
+// UserDataExport is a job packaging everything a profile owns into a zip
+// archive stored as a new private media of the profile
+type UserDataExport struct {
+	ID          string
+	ProfileID   OwnerID
+	RequestedBy string
+	State       string
+	// TotalFiles counts the files and versions to export once they are known
+	TotalFiles    int64
+	ExportedFiles int64
+	FailedFiles   int64
+	ExportedBytes int64
+	// ResultMediaID is the archive, set once the export completes
+	ResultMediaID MediaID
+	LastError     string
+	CompletedAt   *time.Time
+	CreatedAt     time.Time
+	ModifiedAt    time.Time
+}
+
+// User data erasure job states
+const (
+	UserDataErasureRunning   = "running"
+	UserDataErasureCompleted = "completed"
+	UserDataErasureFailed    = "failed"
+)
+
+// Reasons an erasure keeps a file of the profile
+const (
+	ErasureRetainedLegalHold = "legal_hold"
+	ErasureRetainedRetention = "retention"
+)
+
+// UserDataErasure is a job removing or anonymizing everything a profile
+// owns. Files under a legal hold or an unexpired retention are kept.
+type UserDataErasure struct {
+	ID          string
+	ProfileID   OwnerID
+	RequestedBy string
+	State       string
+	// Report is the JSON encoded ErasureReport, set once the erasure
+	// completes, and Signature its HMAC
+	Report    string
+	Signature string
+	// Progress is the JSON encoded ErasureProgress, saved after each page
+	// of media so that an interrupted erasure resumes where it stopped
+	Progress    string
+	LastError   string
+	CompletedAt *time.Time
+	CreatedAt   time.Time
+	ModifiedAt  time.Time
+}
+
+// ErasureProgress is what a running erasure has done so far
+type ErasureProgress struct {
+	Report ErasureReport `json:"report"`
+	// AfterID is the last media handled; MediaDone is set once every
+	// media of the profile has been
+	AfterID   string `json:"after_id,omitempty"`
+	MediaDone bool   `json:"media_done,omitempty"`
+	// Folders are those the profile's media were filed under and
+	// KeptFolders those still holding a retained file
+	Folders     []string `json:"folders,omitempty"`
+	KeptFolders []string `json:"kept_folders,omitempty"`
+	// PendingBlobs is content of erased media not yet deleted from storage
+	PendingBlobs []ErasedBlob `json:"pending_blobs,omitempty"`
+}
+
+// ErasureReport records what an erasure removed, anonymized and kept
+type ErasureReport struct {
+	ErasureID     string                `json:"erasure_id"`
+	ProfileID     OwnerID               `json:"profile_id"`
+	RequestedBy   string                `json:"requested_by"`
+	StartedAt     time.Time             `json:"started_at"`
+	CompletedAt   time.Time             `json:"completed_at"`
+	ErasedFiles   []MediaID             `json:"erased_files"`
+	RetainedFiles []ErasureRetainedFile `json:"retained_files"`
+	Versions      int64                 `json:"versions"`
+	Derivatives   int64                 `json:"derivatives"`
+	DeletedBlobs  int64                 `json:"deleted_blobs"`
+	// SharedBlobs are blobs kept because other media still reference them
+	SharedBlobs      int64 `json:"shared_blobs"`
+	MultipartUploads int64 `json:"multipart_uploads"`
+	// RevokedGrants are grants on the profile's files and folders,
+	// RevokedReceivedGrants those the profile held on others' files
+	RevokedGrants         int64    `json:"revoked_grants"`
+	RevokedReceivedGrants int64    `json:"revoked_received_grants"`
+	AnonymizedRecords     int64    `json:"anonymized_records"`
+	DeletedRecords        int64    `json:"deleted_records"`
+	Errors                []string `json:"errors,omitempty"`
+}
+
+// ErasureRetainedFile is a file an erasure kept and why
+type ErasureRetainedFile struct {
+	MediaID MediaID    `json:"media_id"`
+	Reason  string     `json:"reason"`
+	Until   *time.Time `json:"until,omitempty"`
+}
+
+// ErasedMedia describes the records removed with a set of erased media
+type ErasedMedia struct {
+	Derivatives int64
+	Versions    int64
+	// Blobs are the distinct contents the removed records referenced
+	Blobs []ErasedBlob
+}
+
+// ErasedBlob is stored content referenced by erased records
+type ErasedBlob struct {
+	Hash     Base64Hash `json:"hash"`
+	IsPublic bool       `json:"is_public,omitempty"`
+}
+
+// Share link permissions
+const (
+	ShareLinkView     = "view"
+	ShareLinkDownload = "download"
+)
+
+// ShareLink gives anyone holding its token access to one file without an
+// account. Only hashes of the token and password are kept
+type ShareLink struct {
+	ID           string
+	MediaID      MediaID
+	OwnerID      OwnerID
+	TokenHash    string
+	PasswordHash string
+	Permission   string
+	ExpiresAt    *time.Time
+	// MaxDownloads of zero allows any number of downloads
+	MaxDownloads   int
+	DownloadCount  int
+	RevokedAt      *time.Time
+	LastAccessedAt *time.Time
+	// FailedAttempts counts wrong passwords sent in a row; the link refuses
+	// passwords until LockedUntil once there are too many
+	FailedAttempts int
+	LockedUntil    *time.Time
+	TenantID       string
+	PartitionID    string
+	CreatedAt      time.Time
+	ModifiedAt     time.Time
+}
+
+// AccessGrant records a role granted on a file or folder in Keto, so that
+// what Keto cannot hold, such as when the grant expires, is kept with it
+type AccessGrant struct {
+	ID string
+	// ObjectNamespace and ObjectID name the Keto object the role is granted on
+	ObjectNamespace string
+	ObjectID        string
+	Relation        string
+	PrincipalKind   string
+	PrincipalID     string
+	GrantedBy       OwnerID
+	// ExpiresAt of nil keeps the grant until it is revoked
+	ExpiresAt   *time.Time
+	TenantID    string
+	PartitionID string
+	CreatedAt   time.Time
+	ModifiedAt  time.Time
+}
+
+// Expired reports whether the grant has expired by now
+func (g *AccessGrant) Expired(now time.Time) bool {
+	return g.ExpiresAt != nil && !g.ExpiresAt.After(now)
+}
+
+// MediaOwnership is the part of a media record its Keto tuples are
+// reconciled against, including media that has been deleted
+type MediaOwnership struct {
+	MediaID MediaID
+	OwnerID OwnerID
+	Folder  string
+	Deleted bool
+}
+
+// QueueDepth counts the pending items of a background queue for one tenant
+type QueueDepth struct {
+	TenantID    string
+	PartitionID string
+	Depth       int64
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/pitabwire/frame/v2/security"
+	"github.com/pitabwire/frame/v2/tenancy"
+)
+
+// SystemServiceName identifies this service when it elevates to a system
+// principal. It must be allowlisted with frame.WithSystemPrincipalAllowGlobal.
+const SystemServiceName = "service_file"
+
+// SystemContext elevates ctx for background work that spans tenants, such as
+// retry sweeps, where no caller claims are available.
+func SystemContext(ctx context.Context, reason string) context.Context {
+	ctx = tenancy.WithSystemPrincipal(ctx, tenancy.SystemPrincipal{
+		ServiceName: SystemServiceName,
+		Reason:      reason,
+		AllowGlobal: true,
+	})
+	return security.SkipTenancyChecksOnClaims(ctx)
+}
+
+// TenantContext scopes ctx to a tenant for background work resumed on its
+// behalf, such as a retry of a job first queued under the tenant's claims.
+func TenantContext(ctx context.Context, tenantID, partitionID string) context.Context {
+	claims := &security.AuthenticationClaims{
+		TenantID:    tenantID,
+		PartitionID: partitionID,
+		ServiceName: SystemServiceName,
+	}
+	return claims.ClaimsToContext(ctx)
+}
@@ -0,0 +1,281 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/config"
+	filesv1 "github.com/antinvestor/service-files/apps/default/gen/files/v1"
+	"github.com/antinvestor/service-files/apps/default/service/storage"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+	"github.com/pitabwire/frame/v2"
+	"github.com/pitabwire/util"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	retryBatchSize       = 100
+	maxResponseBodyBytes = 4 << 10
+	maxLastErrorLength   = 512
+)
+
+// Dispatcher delivers file lifecycle events to the webhook subscriptions of
+// the event's tenant. New events arrive through Handle, which is registered as
+// the file events queue subscriber; failed deliveries are retried with
+// exponential backoff by the loop started with Start until they succeed or are
+// dead-lettered.
+type Dispatcher struct {
+	service *frame.Service
+	db      storage.WebhookRepository
+	client  *http.Client
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDispatcher creates a webhook dispatcher delivering through a client that
+// only reaches private addresses when the configuration allows them.
+func NewDispatcher(service *frame.Service, db storage.WebhookRepository) *Dispatcher {
+	cfg := service.Config().(*config.FilesConfig)
+	return &Dispatcher{
+		service: service,
+		db:      db,
+		client:  NewDeliveryClient(cfg.WebhookAllowPrivateEndpoints),
+	}
+}
+
+// Handle fans a lifecycle event out to the matching subscriptions of the tenant.
+func (d *Dispatcher) Handle(ctx context.Context, _ map[string]string, payload []byte) error {
+	evt := &filesv1.LifecycleEvent{}
+	if err := proto.Unmarshal(payload, evt); err != nil {
+		return err
+	}
+
+	subscriptions, err := d.db.ListActiveWebhookSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	body, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(evt)
+	if err != nil {
+		return err
+	}
+
+	cfg := d.service.Config().(*config.FilesConfig)
+	logger := util.Log(ctx).With("event_id", evt.GetEventId(), "event_type", evt.GetEventType().String())
+	for _, subscription := range subscriptions {
+		if !Subscribes(subscription, evt.GetEventType()) {
+			continue
+		}
+
+		// The first attempt runs now; the retry time only matters if this
+		// worker dies before recording the outcome.
+		lease := DeliveryLease(cfg)
+		delivery := &types.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        evt.GetEventId(),
+			EventType:      evt.GetEventType().String(),
+			Payload:        body,
+			State:          types.WebhookDeliveryPending,
+			NextAttemptAt:  &lease,
+		}
+		stored, storeErr := d.db.StoreWebhookDelivery(ctx, delivery)
+		if storeErr != nil {
+			logger.WithError(storeErr).With("subscription_id", subscription.ID).Warn("failed to record webhook delivery")
+			continue
+		}
+		if !stored {
+			// The event was handled before; its delivery is retried by the
+			// sweep if that attempt did not finish.
+			continue
+		}
+
+		d.attempt(ctx, subscription, delivery)
+	}
+
+	return nil
+}
+
+// Subscribes reports whether a subscription receives the given event type.
+func Subscribes(subscription *types.WebhookSubscription, eventType filesv1.LifecycleEventType) bool {
+	if len(subscription.EventTypes) == 0 {
+		return true
+	}
+	return slices.Contains(subscription.EventTypes, eventType.String())
+}
+
+// RetryDue attempts every pending delivery whose next attempt is due and
+// returns the number of deliveries attempted. Deliveries are leased before
+// they are attempted, so replicas sweeping at the same time never attempt
+// the same delivery.
+func (d *Dispatcher) RetryDue(ctx context.Context) (int, error) {
+	ctx = utils.SystemContext(ctx, "webhook delivery retry")
+	cfg := d.service.Config().(*config.FilesConfig)
+
+	deliveries, err := d.db.ClaimDueWebhookDeliveries(ctx, time.Now().UTC(), DeliveryLease(cfg), retryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		subscription, subErr := d.db.GetWebhookSubscription(ctx, delivery.SubscriptionID)
+		if subErr != nil {
+			util.Log(ctx).WithError(subErr).With("delivery_id", delivery.ID).Warn("failed to load webhook subscription")
+			continue
+		}
+		if subscription == nil || !subscription.Active {
+			delivery.State = types.WebhookDeliveryDeadLetter
+			delivery.NextAttemptAt = nil
+			delivery.LastError = "subscription removed"
+			d.record(ctx, delivery)
+			continue
+		}
+		d.attempt(ctx, subscription, delivery)
+	}
+
+	return len(deliveries), nil
+}
+
+// Start runs the retry loop until ctx is cancelled or Stop is called.
+func (d *Dispatcher) Start(ctx context.Context) {
+	cfg := d.service.Config().(*config.FilesConfig)
+	interval := time.Duration(cfg.WebhookRetryPollSeconds) * time.Second
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+
+	ctx, d.cancel = context.WithCancel(ctx)
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := d.RetryDue(ctx); err != nil {
+					util.Log(ctx).WithError(err).Warn("webhook retry sweep failed")
+				}
+			}
+		}
+	}()
+}
+
+// Stop ends the retry loop and waits for an in-flight sweep to finish.
+func (d *Dispatcher) Stop() {
+	if d.cancel != nil {
+		d.cancel()
+	}
+	d.wg.Wait()
+}
+
+// attempt posts a delivery once and records the outcome, scheduling the next
+// attempt or dead-lettering it when attempts are exhausted.
+func (d *Dispatcher) attempt(ctx context.Context, subscription *types.WebhookSubscription, delivery *types.WebhookDelivery) {
+	cfg := d.service.Config().(*config.FilesConfig)
+
+	delivery.Attempts++
+	statusCode, err := d.post(ctx, cfg, subscription, delivery)
+	delivery.LastStatusCode = statusCode
+
+	now := time.Now().UTC()
+	switch {
+	case err == nil:
+		delivery.State = types.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+		delivery.LastError = ""
+	case delivery.Attempts >= cfg.WebhookMaxAttempts:
+		delivery.State = types.WebhookDeliveryDeadLetter
+		delivery.NextAttemptAt = nil
+		delivery.LastError = truncate(err.Error(), maxLastErrorLength)
+	default:
+		next := now.Add(Backoff(delivery.Attempts,
+			time.Duration(cfg.WebhookRetryBaseSeconds)*time.Second,
+			time.Duration(cfg.WebhookRetryMaxSeconds)*time.Second))
+		delivery.State = types.WebhookDeliveryPending
+		delivery.NextAttemptAt = &next
+		delivery.LastError = truncate(err.Error(), maxLastErrorLength)
+	}
+
+	d.record(ctx, delivery)
+}
+
+func (d *Dispatcher) record(ctx context.Context, delivery *types.WebhookDelivery) {
+	if err := d.db.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		util.Log(ctx).WithError(err).With("delivery_id", delivery.ID).Warn("failed to record webhook delivery outcome")
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, cfg *config.FilesConfig, subscription *types.WebhookSubscription, delivery *types.WebhookDelivery) (int, error) {
+	secret, err := OpenSecret(subscription.SealedSecret, cfg.EnvStorageEncryptionPhrase)
+	if err != nil {
+		return 0, err
+	}
+
+	timeout := time.Duration(cfg.WebhookTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, delivery.EventID)
+	req.Header.Set(HeaderEventType, delivery.EventType)
+	req.Header.Set(HeaderDeliveryID, delivery.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer util.CloseAndLogOnError(ctx, resp.Body)
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodyBytes))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// DeliveryLease returns the time until which a delivery being attempted is
+// hidden from the retry sweep. It outlasts the request timeout, so the sweep
+// only picks the delivery up if the attempt is never recorded.
+func DeliveryLease(cfg *config.FilesConfig) time.Time {
+	timeout := time.Duration(cfg.WebhookTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return time.Now().UTC().Add(timeout + Backoff(1,
+		time.Duration(cfg.WebhookRetryBaseSeconds)*time.Second,
+		time.Duration(cfg.WebhookRetryMaxSeconds)*time.Second))
+}
+
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	return s[:limit]
+}
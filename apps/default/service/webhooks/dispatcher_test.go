@@ -0,0 +1,377 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	aconfig "github.com/antinvestor/service-files/apps/default/config"
+	filesv1 "github.com/antinvestor/service-files/apps/default/gen/files/v1"
+	"github.com/antinvestor/service-files/apps/default/service/types"
+	"github.com/pitabwire/frame/v2"
+	"github.com/pitabwire/frame/v2/config"
+	"github.com/pitabwire/frame/v2/frametests"
+	"github.com/pitabwire/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// memoryWebhookStore is an in-memory storage.WebhookRepository.
+type memoryWebhookStore struct {
+	mu            sync.Mutex
+	subscriptions map[string]*types.WebhookSubscription
+	deliveries    map[string]*types.WebhookDelivery
+}
+
+func newMemoryWebhookStore() *memoryWebhookStore {
+	return &memoryWebhookStore{
+		subscriptions: map[string]*types.WebhookSubscription{},
+		deliveries:    map[string]*types.WebhookDelivery{},
+	}
+}
+
+func (m *memoryWebhookStore) StoreWebhookSubscription(_ context.Context, subscription *types.WebhookSubscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if subscription.ID == "" {
+		subscription.ID = util.IDString()
+	}
+	m.subscriptions[subscription.ID] = subscription
+	return nil
+}
+
+func (m *memoryWebhookStore) GetWebhookSubscription(_ context.Context, subscriptionID string) (*types.WebhookSubscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.subscriptions[subscriptionID], nil
+}
+
+func (m *memoryWebhookStore) ListWebhookSubscriptions(_ context.Context, _, _ int) ([]*types.WebhookSubscription, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*types.WebhookSubscription
+	for _, subscription := range m.subscriptions {
+		result = append(result, subscription)
+	}
+	return result, len(result), nil
+}
+
+func (m *memoryWebhookStore) ListActiveWebhookSubscriptions(ctx context.Context) ([]*types.WebhookSubscription, error) {
+	all, _, _ := m.ListWebhookSubscriptions(ctx, 0, 0)
+	var result []*types.WebhookSubscription
+	for _, subscription := range all {
+		if subscription.Active {
+			result = append(result, subscription)
+		}
+	}
+	return result, nil
+}
+
+func (m *memoryWebhookStore) DeleteWebhookSubscription(_ context.Context, subscriptionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.subscriptions, subscriptionID)
+	return nil
+}
+
+func (m *memoryWebhookStore) StoreWebhookDelivery(_ context.Context, delivery *types.WebhookDelivery) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.deliveries {
+		if existing.SubscriptionID == delivery.SubscriptionID && existing.EventID == delivery.EventID {
+			return false, nil
+		}
+	}
+	delivery.ID = util.IDString()
+	stored := *delivery
+	m.deliveries[delivery.ID] = &stored
+	return true, nil
+}
+
+func (m *memoryWebhookStore) UpdateWebhookDelivery(_ context.Context, delivery *types.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := *delivery
+	m.deliveries[delivery.ID] = &stored
+	return nil
+}
+
+func (m *memoryWebhookStore) ListWebhookDeliveries(_ context.Context, subscriptionID, state string, _, _ int) ([]*types.WebhookDelivery, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*types.WebhookDelivery
+	for _, delivery := range m.deliveries {
+		if delivery.SubscriptionID == subscriptionID && (state == "" || delivery.State == state) {
+			stored := *delivery
+			result = append(result, &stored)
+		}
+	}
+	return result, len(result), nil
+}
+
+func (m *memoryWebhookStore) ClaimDueWebhookDeliveries(_ context.Context, before, leaseUntil time.Time, _ int) ([]*types.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*types.WebhookDelivery
+	for _, delivery := range m.deliveries {
+		if delivery.State == types.WebhookDeliveryPending && delivery.NextAttemptAt != nil && !delivery.NextAttemptAt.After(before) {
+			delivery.NextAttemptAt = &leaseUntil
+			stored := *delivery
+			result = append(result, &stored)
+		}
+	}
+	return result, nil
+}
+
+func newTestDispatcher(t *testing.T, store *memoryWebhookStore, maxAttempts int) *Dispatcher {
+	t.Helper()
+
+	cfg, err := config.FromEnv[aconfig.FilesConfig]()
+	require.NoError(t, err)
+	cfg.EnvStorageEncryptionPhrase = testEncryptionKey
+	cfg.WebhookAllowPrivateEndpoints = true
+	cfg.WebhookMaxAttempts = maxAttempts
+
+	_, svc := frame.NewServiceWithContext(t.Context(),
+		frame.WithName("webhook-tests"),
+		frame.WithConfig(&cfg),
+		frametests.WithNoopDriver())
+
+	return NewDispatcher(svc, store)
+}
+
+func addSubscription(t *testing.T, store *memoryWebhookStore, url string, eventTypes ...string) (*types.WebhookSubscription, string) {
+	t.Helper()
+
+	secret := NewSecret()
+	sealed, err := SealSecret(secret, testEncryptionKey)
+	require.NoError(t, err)
+
+	subscription := &types.WebhookSubscription{
+		URL:          url,
+		EventTypes:   eventTypes,
+		SealedSecret: sealed,
+		Active:       true,
+	}
+	require.NoError(t, store.StoreWebhookSubscription(t.Context(), subscription))
+	return subscription, secret
+}
+
+func lifecyclePayload(t *testing.T, eventType filesv1.LifecycleEventType) []byte {
+	t.Helper()
+
+	payload, err := proto.Marshal(&filesv1.LifecycleEvent{
+		EventId:   util.IDString(),
+		EventType: eventType,
+		MediaId:   "media01",
+		OwnerId:   "owner01",
+	})
+	require.NoError(t, err)
+	return payload
+}
+
+func TestDispatcherDeliversSignedEvent(t *testing.T) {
+	type received struct {
+		headers http.Header
+		body    []byte
+	}
+	requests := make(chan received, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- received{headers: r.Header.Clone(), body: body}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	store := newMemoryWebhookStore()
+	dispatcher := newTestDispatcher(t, store, 3)
+	subscription, secret := addSubscription(t, store, server.URL,
+		filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_UPLOADED.String())
+	_, _ = addSubscription(t, store, server.URL,
+		filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_DELETED.String())
+
+	err := dispatcher.Handle(t.Context(), nil, lifecyclePayload(t, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_UPLOADED))
+	require.NoError(t, err)
+
+	got := <-requests
+	assert.Equal(t, "LIFECYCLE_EVENT_TYPE_UPLOADED", got.headers.Get(HeaderEventType))
+	timestamp, err := strconv.ParseInt(got.headers.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.True(t, Verify([]byte(secret), timestamp, got.body, got.headers.Get(HeaderSignature)))
+	assert.Contains(t, string(got.body), `"media_id":"media01"`)
+
+	deliveries, _, err := store.ListWebhookDeliveries(t.Context(), subscription.ID, "", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, types.WebhookDeliveryDelivered, deliveries[0].State)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusNoContent, deliveries[0].LastStatusCode)
+	assert.NotNil(t, deliveries[0].DeliveredAt)
+	assert.Len(t, requests, 0, "non-matching subscription must not be called")
+}
+
+func TestDispatcherRetriesThenDeadLetters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	store := newMemoryWebhookStore()
+	dispatcher := newTestDispatcher(t, store, 2)
+	subscription, _ := addSubscription(t, store, server.URL)
+
+	err := dispatcher.Handle(t.Context(), nil, lifecyclePayload(t, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_SHARED))
+	require.NoError(t, err)
+
+	deliveries, _, err := store.ListWebhookDeliveries(t.Context(), subscription.ID, "", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	delivery := deliveries[0]
+	assert.Equal(t, types.WebhookDeliveryPending, delivery.State)
+	assert.Equal(t, http.StatusInternalServerError, delivery.LastStatusCode)
+	require.NotNil(t, delivery.NextAttemptAt)
+	assert.True(t, delivery.NextAttemptAt.After(time.Now()))
+
+	// Make the retry due now.
+	due := time.Now().Add(-time.Second)
+	delivery.NextAttemptAt = &due
+	require.NoError(t, store.UpdateWebhookDelivery(t.Context(), delivery))
+
+	attempted, err := dispatcher.RetryDue(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+
+	deliveries, _, err = store.ListWebhookDeliveries(t.Context(), subscription.ID, types.WebhookDeliveryDeadLetter, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, 2, deliveries[0].Attempts)
+	assert.Nil(t, deliveries[0].NextAttemptAt)
+	assert.Contains(t, deliveries[0].LastError, "500")
+}
+
+func TestDispatcherRetryLeasesDeliveries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	store := newMemoryWebhookStore()
+	dispatcher := newTestDispatcher(t, store, 5)
+	subscription, _ := addSubscription(t, store, server.URL)
+
+	due := time.Now().Add(-time.Second)
+	delivery := &types.WebhookDelivery{
+		SubscriptionID: subscription.ID,
+		EventID:        "evt1",
+		State:          types.WebhookDeliveryPending,
+		NextAttemptAt:  &due,
+	}
+	_, err := store.StoreWebhookDelivery(t.Context(), delivery)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := dispatcher.RetryDue(t.Context())
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load(), "concurrent sweeps attempt a delivery once")
+}
+
+func TestDispatcherDeadLettersRemovedSubscription(t *testing.T) {
+	store := newMemoryWebhookStore()
+	dispatcher := newTestDispatcher(t, store, 5)
+
+	due := time.Now().Add(-time.Second)
+	delivery := &types.WebhookDelivery{
+		SubscriptionID: "missing",
+		EventID:        "evt1",
+		State:          types.WebhookDeliveryPending,
+		NextAttemptAt:  &due,
+	}
+	_, err := store.StoreWebhookDelivery(t.Context(), delivery)
+	require.NoError(t, err)
+
+	_, err = dispatcher.RetryDue(t.Context())
+	require.NoError(t, err)
+
+	deliveries, _, err := store.ListWebhookDeliveries(t.Context(), "missing", types.WebhookDeliveryDeadLetter, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "subscription removed", deliveries[0].LastError)
+}
+
+func TestDeliveryClientRefusesPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// The loopback server stands in for a host whose DNS now answers with
+	// an internal address.
+	resp, err := NewDeliveryClient(false).Get(server.URL)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	require.ErrorIs(t, err, errPrivateEndpoint)
+
+	resp, err = NewDeliveryClient(true).Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestDeliveryClientDoesNotFollowRedirects(t *testing.T) {
+	var reached atomic.Int32
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		reached.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer internal.Close()
+	redirecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL, http.StatusFound)
+	}))
+	defer redirecting.Close()
+
+	resp, err := NewDeliveryClient(true).Get(redirecting.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+	assert.Zero(t, reached.Load(), "redirects are not followed")
+}
+
+func TestDispatcherDeliversRedeliveredEventOnce(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	store := newMemoryWebhookStore()
+	dispatcher := newTestDispatcher(t, store, 3)
+	subscription, _ := addSubscription(t, store, server.URL)
+
+	payload := lifecyclePayload(t, filesv1.LifecycleEventType_LIFECYCLE_EVENT_TYPE_UPLOADED)
+	require.NoError(t, dispatcher.Handle(t.Context(), nil, payload))
+	require.NoError(t, dispatcher.Handle(t.Context(), nil, payload))
+
+	assert.Equal(t, int32(1), calls.Load(), "a redelivered event is sent once")
+	deliveries, _, err := store.ListWebhookDeliveries(t.Context(), subscription.ID, "", 0, 0)
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+}
@@ -0,0 +1,106 @@
+package webhooks
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var errPrivateEndpoint = errors.New("endpoint host resolves to a private address")
+
+// blockedPrefixes are ranges that are not reachable from the internet but
+// are not covered by the private address checks of netip: carrier-grade NAT
+// shared space and IPv6 unique local addresses.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("fc00::/7"),
+}
+
+// ValidateEndpoint checks that a subscription URL is an absolute http(s) URL
+// and, unless allowPrivate is set, that it does not resolve to a loopback,
+// private or link-local address. Deliveries check the address they dial
+// again, as DNS may answer differently by then.
+func ValidateEndpoint(rawURL string, allowPrivate bool) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.New("endpoint url is invalid")
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return errors.New("endpoint url must use http or https")
+	}
+	host := u.Hostname()
+	if host == "" {
+		return errors.New("endpoint url must include a host")
+	}
+	if allowPrivate {
+		return nil
+	}
+
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil {
+		ips, err = net.LookupIP(host)
+		if err != nil || len(ips) == 0 {
+			return errors.New("endpoint host cannot be resolved")
+		}
+	}
+	for _, ip := range ips {
+		addr, ok := netip.AddrFromSlice(ip)
+		if !ok || isPrivateAddr(addr) {
+			return errPrivateEndpoint
+		}
+	}
+	return nil
+}
+
+// isPrivateAddr reports whether addr, or the IPv4 address an IPv4-mapped
+// IPv6 address carries, is not a public unicast address.
+func isPrivateAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsUnspecified() {
+		return true
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewDeliveryClient creates the client webhooks are delivered with. Unless
+// allowPrivate is set it refuses to connect to private addresses, checked on
+// the address actually dialled so that DNS answering differently after the
+// endpoint was validated cannot reach internal hosts. Redirects are never
+// followed and no proxy is used.
+func NewDeliveryClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil || isPrivateAddr(addr) {
+				return errPrivateEndpoint
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,83 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/antinvestor/service-files/apps/default/service/utils"
+)
+
+// Headers set on every webhook delivery.
+const (
+	HeaderEventID    = "X-Files-Event-Id"
+	HeaderEventType  = "X-Files-Event-Type"
+	HeaderDeliveryID = "X-Files-Delivery-Id"
+	HeaderTimestamp  = "X-Files-Timestamp"
+	HeaderSignature  = "X-Files-Signature"
+
+	signaturePrefix = "sha256="
+	secretLength    = 48
+)
+
+// Sign computes the signature header value for a delivery body:
+// "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body)).
+func Sign(secret []byte, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature produced by Sign in constant time.
+func Verify(secret []byte, timestamp int64, body []byte, signature string) bool {
+	expected := Sign(secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// NewSecret generates a signing secret for a new subscription.
+func NewSecret() string {
+	return "whsec_" + utils.GenerateRandomString(secretLength)
+}
+
+// SealSecret encrypts a signing secret for storage.
+func SealSecret(secret string, encryptionKey string) (string, error) {
+	sealed, err := utils.Encrypt([]byte(secret), encryptionKey)
+	if err != nil {
+		return "", fmt.Errorf("seal webhook secret: %w", err)
+	}
+	return hex.EncodeToString(sealed), nil
+}
+
+// OpenSecret decrypts a signing secret sealed with SealSecret.
+func OpenSecret(sealed string, encryptionKey string) ([]byte, error) {
+	raw, err := hex.DecodeString(sealed)
+	if err != nil {
+		return nil, fmt.Errorf("decode webhook secret: %w", err)
+	}
+	secret, err := utils.Decrypt(raw, encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("open webhook secret: %w", err)
+	}
+	return secret, nil
+}
+
+// Backoff returns the delay before the next attempt after the given number of
+// failed attempts, doubling from base and capped at maxDelay.
+func Backoff(attempts int, base, maxDelay time.Duration) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	if attempts > 30 {
+		return maxDelay
+	}
+	delay := base << (attempts - 1)
+	if delay <= 0 || delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
@@ -0,0 +1,84 @@
+package webhooks
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEncryptionKey = "0123456789abcdef0123456789abcdef"
+
+func TestSignAndVerify(t *testing.T) {
+	secret := []byte("whsec_test")
+	body := []byte(`{"event_id":"evt1"}`)
+
+	signature := Sign(secret, 1700000000, body)
+	require.True(t, strings.HasPrefix(signature, "sha256="))
+	assert.True(t, Verify(secret, 1700000000, body, signature))
+
+	assert.False(t, Verify(secret, 1700000001, body, signature), "timestamp is part of the signed content")
+	assert.False(t, Verify([]byte("other"), 1700000000, body, signature))
+	assert.False(t, Verify(secret, 1700000000, []byte(`{}`), signature))
+}
+
+func TestSealAndOpenSecret(t *testing.T) {
+	secret := NewSecret()
+	require.True(t, strings.HasPrefix(secret, "whsec_"))
+
+	sealed, err := SealSecret(secret, testEncryptionKey)
+	require.NoError(t, err)
+	assert.NotContains(t, sealed, secret)
+
+	opened, err := OpenSecret(sealed, testEncryptionKey)
+	require.NoError(t, err)
+	assert.Equal(t, secret, string(opened))
+
+	_, err = OpenSecret("not-hex", testEncryptionKey)
+	require.Error(t, err)
+}
+
+func TestBackoff(t *testing.T) {
+	base := 30 * time.Second
+	maxDelay := 10 * time.Minute
+
+	assert.Equal(t, base, Backoff(0, base, maxDelay))
+	assert.Equal(t, base, Backoff(1, base, maxDelay))
+	assert.Equal(t, 60*time.Second, Backoff(2, base, maxDelay))
+	assert.Equal(t, 240*time.Second, Backoff(4, base, maxDelay))
+	assert.Equal(t, maxDelay, Backoff(6, base, maxDelay))
+	assert.Equal(t, maxDelay, Backoff(100, base, maxDelay))
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	testCases := []struct {
+		name         string
+		url          string
+		allowPrivate bool
+		wantErr      bool
+	}{
+		{name: "public ip", url: "https://203.0.113.10/hooks"},
+		{name: "unsupported scheme", url: "ftp://203.0.113.10/hooks", wantErr: true},
+		{name: "missing host", url: "https:///hooks", wantErr: true},
+		{name: "loopback", url: "http://127.0.0.1:8080/hooks", wantErr: true},
+		{name: "private", url: "http://10.1.2.3/hooks", wantErr: true},
+		{name: "link local", url: "http://169.254.169.254/latest", wantErr: true},
+		{name: "shared address space", url: "http://100.64.1.2/hooks", wantErr: true},
+		{name: "unique local", url: "http://[fd00::1]/hooks", wantErr: true},
+		{name: "ipv4 mapped loopback", url: "http://[::ffff:127.0.0.1]/hooks", wantErr: true},
+		{name: "private allowed", url: "http://10.1.2.3/hooks", allowPrivate: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateEndpoint(tc.url, tc.allowPrivate)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
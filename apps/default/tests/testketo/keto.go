@@ -39,25 +39,54 @@ namespaces:
 
 	oplNamespaces = `import { Namespace, Context } from "@ory/keto-namespace-types"
 
+class file_folder implements Namespace {
+  related: {
+    parent: file_folder[]
+    granted_owner: profile_user[]
+    granted_viewer: (profile_user | SubjectSet<group, "member"> | SubjectSet<tenancy_access, "member">)[]
+    granted_editor: (profile_user | SubjectSet<group, "member"> | SubjectSet<tenancy_access, "member">)[]
+  }
+
+  permits = {
+    view: (ctx: Context): boolean =>
+      this.related.granted_owner.includes(ctx.subject) ||
+      this.related.granted_viewer.includes(ctx.subject) ||
+      this.related.parent.traverse((f) => f.permits.view(ctx)),
+
+    edit: (ctx: Context): boolean =>
+      this.related.granted_owner.includes(ctx.subject) ||
+      this.related.granted_editor.includes(ctx.subject) ||
+      this.related.parent.traverse((f) => f.permits.edit(ctx)),
+
+    delete: (ctx: Context): boolean =>
+      this.related.granted_owner.includes(ctx.subject) ||
+      this.related.parent.traverse((f) => f.permits.delete(ctx)),
+  }
+}
+
 class file implements Namespace {
   related: {
+    parent: file_folder[]
     granted_owner: profile_user[]
-    granted_viewer: profile_user[]
-    granted_editor: profile_user[]
-    granted_uploader: profile_user[]
+    granted_viewer: (profile_user | SubjectSet<group, "member"> | SubjectSet<tenancy_access, "member">)[]
+    granted_editor: (profile_user | SubjectSet<group, "member"> | SubjectSet<tenancy_access, "member">)[]
+    granted_uploader: (profile_user | SubjectSet<group, "member"> | SubjectSet<tenancy_access, "member">)[]
   }
 
   permits = {
     view: (ctx: Context): boolean =>
       this.related.granted_owner.includes(ctx.subject) ||
-      this.related.granted_viewer.includes(ctx.subject),
+      this.related.granted_viewer.includes(ctx.subject) ||
+      this.related.parent.traverse((f) => f.permits.view(ctx)),
 
     edit: (ctx: Context): boolean =>
       this.related.granted_owner.includes(ctx.subject) ||
-      this.related.granted_editor.includes(ctx.subject),
+      this.related.granted_editor.includes(ctx.subject) ||
+      this.related.parent.traverse((f) => f.permits.edit(ctx)),
 
     delete: (ctx: Context): boolean =>
-      this.related.granted_owner.includes(ctx.subject),
+      this.related.granted_owner.includes(ctx.subject) ||
+      this.related.parent.traverse((f) => f.permits.delete(ctx)),
 
     upload: (ctx: Context): boolean =>
       this.related.granted_owner.includes(ctx.subject) ||
@@ -66,6 +95,20 @@ class file implements Namespace {
 }
 
 class profile_user implements Namespace {}
+
+class tenancy_access implements Namespace {
+  related: {
+    member: (profile_user | tenancy_access)[]
+    service: (profile_user | tenancy_access)[]
+    admin: (profile_user | tenancy_access)[]
+  }
+}
+
+class group implements Namespace {
+  related: {
+    member: (profile_user | SubjectSet<group, "member">)[]
+  }
+}
 `
 )
 
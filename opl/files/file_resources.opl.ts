@@ -36,32 +36,87 @@ class profile_user implements Namespace {
   }
 }
 
+// tenancy_access holds the members of each tenant partition, keyed by the
+// tenancy path "<tenant_id>/<partition_id>". It is owned by the platform and
+// declared here so files can be shared with a whole partition. Admins of a
+// partition may manage every file in it through the admin RPCs.
+class tenancy_access implements Namespace {
+  related: {
+    member: (profile_user | tenancy_access)[]
+    service: (profile_user | tenancy_access)[]
+    admin: (profile_user | tenancy_access)[]
+  }
+}
+
+// group is a named set of profiles; groups may contain other groups.
+// Files shared with group#member follow its membership as it changes.
+class group implements Namespace {
+  related: {
+    member: (profile_user | SubjectSet<group, "member">)[]
+  }
+}
+
 // ---------------------------------------------------------------------------
 // Plane 3 -- Per-resource namespaces (file, file_version, etc.)
 // These enforce fine-grained, object-level access control.
 // ---------------------------------------------------------------------------
 
+// file_folder namespace represents a folder of one owner's files, keyed by
+// "<escaped owner id>/<folder path>". Roles granted on a folder apply to
+// every file filed in it and in the folders below it.
+class file_folder implements Namespace {
+  related: {
+    parent: file_folder[]
+    granted_owner: profile_user[]
+    granted_viewer: (profile_user | SubjectSet<group, "member"> | SubjectSet<tenancy_access, "member">)[]
+    granted_editor: (profile_user | SubjectSet<group, "member"> | SubjectSet<tenancy_access, "member">)[]
+  }
+
+  permits = {
+    view: (ctx: Context): boolean =>
+      this.related.granted_owner.includes(ctx.subject) ||
+      this.related.granted_viewer.includes(ctx.subject) ||
+      this.related.parent.traverse((f) => f.permits.view(ctx)),
+
+    edit: (ctx: Context): boolean =>
+      this.related.granted_owner.includes(ctx.subject) ||
+      this.related.granted_editor.includes(ctx.subject) ||
+      this.related.parent.traverse((f) => f.permits.edit(ctx)),
+
+    delete: (ctx: Context): boolean =>
+      this.related.granted_owner.includes(ctx.subject) ||
+      this.related.parent.traverse((f) => f.permits.delete(ctx)),
+  }
+}
+
 // file namespace represents individual files/media with ownership and sharing.
-// Supports viewer, editor, uploader roles in addition to owner.
+// Supports viewer, editor, uploader roles in addition to owner. Shared roles
+// can be granted to a profile, a group or a tenant partition; ownership is
+// always a single profile. View, edit and delete are also inherited from the
+// folder the file is filed in.
 class file implements Namespace {
   related: {
+    parent: file_folder[]
     granted_owner: profile_user[]
-    granted_viewer: profile_user[]
-    granted_editor: profile_user[]
-    granted_uploader: profile_user[]
+    granted_viewer: (profile_user | SubjectSet<group, "member"> | SubjectSet<tenancy_access, "member">)[]
+    granted_editor: (profile_user | SubjectSet<group, "member"> | SubjectSet<tenancy_access, "member">)[]
+    granted_uploader: (profile_user | SubjectSet<group, "member"> | SubjectSet<tenancy_access, "member">)[]
   }
 
   permits = {
     view: (ctx: Context): boolean =>
       this.related.granted_owner.includes(ctx.subject) ||
-      this.related.granted_viewer.includes(ctx.subject),
+      this.related.granted_viewer.includes(ctx.subject) ||
+      this.related.parent.traverse((f) => f.permits.view(ctx)),
 
     edit: (ctx: Context): boolean =>
       this.related.granted_owner.includes(ctx.subject) ||
-      this.related.granted_editor.includes(ctx.subject),
+      this.related.granted_editor.includes(ctx.subject) ||
+      this.related.parent.traverse((f) => f.permits.edit(ctx)),
 
     delete: (ctx: Context): boolean =>
-      this.related.granted_owner.includes(ctx.subject),
+      this.related.granted_owner.includes(ctx.subject) ||
+      this.related.parent.traverse((f) => f.permits.delete(ctx)),
 
     upload: (ctx: Context): boolean =>
       this.related.granted_owner.includes(ctx.subject) ||
@@ -2,12 +2,7 @@ import { Namespace, Context } from "@ory/keto-namespace-types"
 
 class profile_user implements Namespace {}
 
-class tenancy_access implements Namespace {
-  related: {
-    member: (profile_user | tenancy_access)[]
-    service: (profile_user | tenancy_access)[]
-  }
-}
+// tenancy_access is declared once, in file_resources.opl.ts.
 
 class service_file implements Namespace {
   related: {
@@ -24,6 +19,10 @@ class service_file implements Namespace {
     granted_content_delete: (profile_user | service_file)[]
     granted_file_access_view: (profile_user | service_file)[]
     granted_file_access_manage: (profile_user | service_file)[]
+    granted_webhook_manage: (profile_user | service_file)[]
+    granted_thumbnail_manage: (profile_user | service_file)[]
+    granted_access_reconcile: (profile_user | service_file)[]
+    granted_tenant_admin: (profile_user | service_file)[]
   }
 
   permits = {
@@ -70,5 +69,29 @@ class service_file implements Namespace {
       this.related.owner.includes(ctx.subject) ||
       this.related.service.includes(ctx.subject) ||
       this.related.granted_file_access_manage.includes(ctx.subject),
+
+    webhook_manage: (ctx: Context): boolean =>
+      this.related.admin.includes(ctx.subject) ||
+      this.related.owner.includes(ctx.subject) ||
+      this.related.service.includes(ctx.subject) ||
+      this.related.granted_webhook_manage.includes(ctx.subject),
+
+    thumbnail_manage: (ctx: Context): boolean =>
+      this.related.admin.includes(ctx.subject) ||
+      this.related.owner.includes(ctx.subject) ||
+      this.related.service.includes(ctx.subject) ||
+      this.related.granted_thumbnail_manage.includes(ctx.subject),
+
+    access_reconcile: (ctx: Context): boolean =>
+      this.related.admin.includes(ctx.subject) ||
+      this.related.owner.includes(ctx.subject) ||
+      this.related.service.includes(ctx.subject) ||
+      this.related.granted_access_reconcile.includes(ctx.subject),
+
+    tenant_admin: (ctx: Context): boolean =>
+      this.related.admin.includes(ctx.subject) ||
+      this.related.owner.includes(ctx.subject) ||
+      this.related.service.includes(ctx.subject) ||
+      this.related.granted_tenant_admin.includes(ctx.subject),
   }
 }